@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/beuphecan/remote-time-tracker/internal/backup"
+	"github.com/beuphecan/remote-time-tracker/internal/config"
+)
+
+// Restores screenshot storage from the encrypted snapshots written by the
+// backup.uploads job. Uses the same BACKUP_* settings as the server.
+
+func main() {
+	list := flag.Bool("list", false, "List available snapshots and exit")
+	snapshot := flag.String("snapshot", "latest", "Snapshot id to restore")
+	dest := flag.String("dest", "", "Destination directory (default: the screenshots upload directory)")
+	overwrite := flag.Bool("overwrite", false, "Overwrite files that already exist in the destination")
+	flag.Parse()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	screenshotsDir := filepath.Join(cfg.Upload.PrivatePath, "screenshots")
+	snapshotter, err := backup.Open(cfg.Backup, screenshotsDir)
+	if err != nil {
+		log.Fatalf("Failed to open backup target: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if *list {
+		ids, err := snapshotter.Snapshots(ctx)
+		if err != nil {
+			log.Fatalf("Failed to list snapshots: %v", err)
+		}
+		for _, id := range ids {
+			manifest, err := snapshotter.LoadManifest(ctx, id)
+			if err != nil {
+				fmt.Printf("%s  (unreadable: %v)\n", id, err)
+				continue
+			}
+			var size int64
+			for _, f := range manifest.Files {
+				size += f.Size
+			}
+			fmt.Printf("%s  %d files  %d bytes\n", id, len(manifest.Files), size)
+		}
+		return
+	}
+
+	target := *dest
+	if target == "" {
+		target = screenshotsDir
+	}
+
+	log.Printf("♻️  Restoring snapshot %s into %s...", *snapshot, target)
+	restored, err := snapshotter.Restore(ctx, *snapshot, target, *overwrite)
+	if err != nil {
+		log.Fatalf("Restore failed after %d files: %v", restored, err)
+	}
+	log.Printf("✅ Restored %d files", restored)
+}
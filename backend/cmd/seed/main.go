@@ -0,0 +1,411 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"log"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/config"
+	"github.com/beuphecan/remote-time-tracker/internal/database"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Seed data for local development and QA. Every user shares one password so
+// any account can be used to log in.
+
+const demoOrgSlug = "acme-demo"
+
+type seedUser struct {
+	email      string
+	firstName  string
+	lastName   string
+	systemRole string
+	orgRole    string
+	roleName   string // Workspace role
+}
+
+var seedUsers = []seedUser{
+	{"admin@demo.local", "Ada", "Admin", models.SystemRoleAdmin, models.OrgRoleOwner, "pm"},
+	{"manager@demo.local", "Max", "Manager", models.SystemRoleMember, models.OrgRoleAdmin, "pm"},
+	{"dev1@demo.local", "Dana", "Developer", models.SystemRoleMember, models.OrgRoleMember, "dev"},
+	{"dev2@demo.local", "Diego", "Developer", models.SystemRoleMember, models.OrgRoleMember, "dev"},
+	{"designer@demo.local", "Dora", "Designer", models.SystemRoleMember, models.OrgRoleMember, "designer"},
+	{"qa@demo.local", "Quinn", "Tester", models.SystemRoleMember, models.OrgRoleMember, "tester"},
+}
+
+type seedWorkspace struct {
+	name        string
+	slug        string
+	color       string
+	projectCode string
+	members     []string // Emails; the first is the workspace admin
+	tasks       []string
+}
+
+var seedWorkspaces = []seedWorkspace{
+	{"Engineering", "engineering", "#10B981", "ENG",
+		[]string{"manager@demo.local", "dev1@demo.local", "dev2@demo.local", "qa@demo.local"},
+		[]string{"Implement login flow", "Fix sync retry bug", "Code review", "Write API tests", "Refactor reports module"}},
+	{"Design", "design", "#EC4899", "DSN",
+		[]string{"designer@demo.local", "manager@demo.local", "dev1@demo.local"},
+		[]string{"Dashboard mockups", "Icon set refresh", "Usability interviews"}},
+	{"Support", "support", "#F59E0B", "SUP",
+		[]string{"manager@demo.local", "qa@demo.local", "dev2@demo.local"},
+		[]string{"Triage tickets", "Customer onboarding call", "Update help center"}},
+}
+
+func main() {
+	password := flag.String("password", "Password123!", "Password for every seeded user")
+	days := flag.Int("days", 14, "Days of time log history to generate")
+	screenshots := flag.Int("screenshots", 3, "Fake screenshots per time log")
+	flag.Parse()
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	if cfg.Server.Env == "production" {
+		log.Fatal("Refusing to seed a production environment")
+	}
+
+	// Connect to database
+	db, err := database.Connect(&cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer database.Close()
+
+	if err := database.AutoMigrate(db); err != nil {
+		log.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	var existing int64
+	if err := db.Model(&models.Organization{}).Where("slug = ?", demoOrgSlug).Count(&existing).Error; err != nil {
+		log.Fatalf("Failed to check for seed data: %v", err)
+	}
+	if existing > 0 {
+		log.Printf("Demo organization %q already exists, nothing to do (reset the database to reseed)", demoOrgSlug)
+		return
+	}
+
+	passwordHash, err := utils.HashPassword(*password)
+	if err != nil {
+		log.Fatalf("Failed to hash password: %v", err)
+	}
+
+	s := &seeder{
+		rng:            rand.New(rand.NewSource(42)),
+		passwordHash:   passwordHash,
+		days:           *days,
+		screenshots:    *screenshots,
+		screenshotsDir: filepath.Join(cfg.Upload.PrivatePath, "screenshots"),
+		users:          make(map[string]*models.User),
+	}
+	if err := os.MkdirAll(s.screenshotsDir, 0700); err != nil {
+		log.Fatalf("Failed to create screenshots directory: %v", err)
+	}
+
+	log.Println("🌱 Seeding demo data...")
+	if err := db.Transaction(s.run); err != nil {
+		// Files written before the failure have no rows pointing at them
+		for _, path := range s.files {
+			_ = utils.DeleteFile(path)
+		}
+		log.Fatalf("Failed to seed: %v", err)
+	}
+
+	log.Printf("✅ Seeded %d users, %d workspaces, %d tasks, %d time logs and %d screenshots",
+		len(s.users), len(seedWorkspaces), s.taskCount, s.timeLogCount, len(s.files))
+	log.Printf("🔑 Log in as any of these users with password %q:", *password)
+	for _, u := range seedUsers {
+		log.Printf("   %-22s %s, %s", u.email, u.orgRole, u.systemRole)
+	}
+}
+
+type seeder struct {
+	rng            *rand.Rand
+	passwordHash   string
+	days           int
+	screenshots    int
+	screenshotsDir string
+
+	users        map[string]*models.User
+	files        []string
+	taskCount    int
+	timeLogCount int
+}
+
+func (s *seeder) run(tx *gorm.DB) error {
+	now := time.Now().UTC()
+
+	for _, u := range seedUsers {
+		user := &models.User{
+			Email:          u.email,
+			PasswordHash:   s.passwordHash,
+			FirstName:      u.firstName,
+			LastName:       u.lastName,
+			Role:           "user",
+			SystemRole:     u.systemRole,
+			IsActive:       true,
+			PresenceStatus: models.UserPresenceOffline,
+		}
+		if u.systemRole == models.SystemRoleAdmin {
+			user.Role = "admin"
+		}
+		if err := tx.Create(user).Error; err != nil {
+			return fmt.Errorf("create user %s: %w", u.email, err)
+		}
+		s.users[u.email] = user
+	}
+
+	owner := s.users[seedUsers[0].email]
+	org := &models.Organization{
+		Name:            "Acme Demo",
+		Slug:            demoOrgSlug,
+		Description:     "Demo organization created by cmd/seed",
+		OwnerID:         owner.ID,
+		InviteCode:      "DEMO1234",
+		AllowInviteLink: true,
+		MaxMembers:      100,
+		IsActive:        true,
+	}
+	if err := tx.Create(org).Error; err != nil {
+		return fmt.Errorf("create organization: %w", err)
+	}
+
+	for _, u := range seedUsers {
+		member := &models.OrganizationMember{
+			OrganizationID: org.ID,
+			UserID:         s.users[u.email].ID,
+			Role:           u.orgRole,
+			JoinedAt:       now.AddDate(0, 0, -s.days-1),
+			IsActive:       true,
+		}
+		if err := tx.Create(member).Error; err != nil {
+			return fmt.Errorf("add organization member %s: %w", u.email, err)
+		}
+	}
+
+	workspaceRepo := repository.NewWorkspaceRepository(tx)
+	if err := workspaceRepo.CreateDefaultRoles(org.ID); err != nil {
+		return fmt.Errorf("create workspace roles: %w", err)
+	}
+	var roles []models.WorkspaceRole
+	if err := tx.Where("organization_id = ?", org.ID).Find(&roles).Error; err != nil {
+		return err
+	}
+	roleIDs := make(map[string]uint, len(roles))
+	for _, r := range roles {
+		roleIDs[r.Name] = r.ID
+	}
+	userRoles := make(map[string]string, len(seedUsers))
+	for _, u := range seedUsers {
+		userRoles[u.email] = u.roleName
+	}
+
+	for _, ws := range seedWorkspaces {
+		workspace := &models.Workspace{
+			OrganizationID:  org.ID,
+			Name:            ws.name,
+			Slug:            ws.slug,
+			Color:           ws.color,
+			AdminID:         s.users[ws.members[0]].ID,
+			IsActive:        true,
+			IsBillable:      true,
+			Currency:        "USD",
+			HourlyRateMinor: 5000,
+			ProjectCode:     ws.projectCode,
+		}
+		if err := tx.Create(workspace).Error; err != nil {
+			return fmt.Errorf("create workspace %s: %w", ws.name, err)
+		}
+
+		for i, email := range ws.members {
+			roleID := roleIDs[userRoles[email]]
+			member := &models.WorkspaceMember{
+				WorkspaceID:     workspace.ID,
+				UserID:          s.users[email].ID,
+				WorkspaceRoleID: &roleID,
+				RoleName:        userRoles[email],
+				IsAdmin:         i == 0,
+				CanViewReports:  true,
+				CanManageTasks:  i == 0,
+				AddedBy:         &owner.ID,
+				JoinedAt:        now.AddDate(0, 0, -s.days-1),
+				IsActive:        true,
+			}
+			if err := tx.Create(member).Error; err != nil {
+				return fmt.Errorf("add workspace member %s: %w", email, err)
+			}
+
+			if err := s.seedWork(tx, org, workspace, &ws, s.users[email], now); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// seedWork creates a member's tasks in a workspace and a few tracked sessions
+// on each working day, with screenshots
+func (s *seeder) seedWork(tx *gorm.DB, org *models.Organization, ws *models.Workspace, seed *seedWorkspace, user *models.User, now time.Time) error {
+	var tasks []*models.Task
+	for _, title := range seed.tasks {
+		if s.rng.Intn(3) == 0 {
+			continue // Not everyone works on every task
+		}
+		task := &models.Task{
+			UserID:         user.ID,
+			OrganizationID: &org.ID,
+			WorkspaceID:    &ws.ID,
+			LocalID:        uuid.New().String(),
+			Title:          title,
+			Status:         "active",
+			Priority:       s.rng.Intn(3),
+			Color:          seed.color,
+			IsManual:       true,
+			ProjectCode:    seed.projectCode,
+		}
+		if err := tx.Create(task).Error; err != nil {
+			return fmt.Errorf("create task: %w", err)
+		}
+		tasks = append(tasks, task)
+		s.taskCount++
+	}
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	for d := s.days; d >= 1; d-- {
+		day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -d)
+		if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+			continue
+		}
+
+		start := day.Add(time.Duration(8+s.rng.Intn(3)) * time.Hour)
+		for session := 0; session < 1+s.rng.Intn(2); session++ {
+			task := tasks[s.rng.Intn(len(tasks))]
+			duration := time.Duration(45+s.rng.Intn(120)) * time.Minute
+			end := start.Add(duration)
+
+			timeLog := &models.TimeLog{
+				UserID:         user.ID,
+				OrganizationID: &org.ID,
+				WorkspaceID:    &ws.ID,
+				TaskID:         &task.ID,
+				TaskLocalID:    task.LocalID,
+				StartTime:      start,
+				EndTime:        &end,
+				Duration:       int64(duration.Seconds()),
+				Status:         "stopped",
+				TaskTitle:      task.Title,
+				IsSynced:       true,
+				LocalID:        uuid.New().String(),
+				Version:        1,
+			}
+			// Older weeks have been approved already
+			if d > 7 {
+				approvedAt := end.Add(24 * time.Hour)
+				timeLog.IsApproved = true
+				timeLog.ApprovedBy = &ws.AdminID
+				timeLog.ApprovedAt = &approvedAt
+			}
+			if err := tx.Create(timeLog).Error; err != nil {
+				return fmt.Errorf("create time log: %w", err)
+			}
+			s.timeLogCount++
+
+			if err := s.seedScreenshots(tx, timeLog, task, seed.color); err != nil {
+				return err
+			}
+			start = end.Add(time.Duration(15+s.rng.Intn(60)) * time.Minute)
+		}
+	}
+	return nil
+}
+
+func (s *seeder) seedScreenshots(tx *gorm.DB, timeLog *models.TimeLog, task *models.Task, hexColor string) error {
+	for i := 0; i < s.screenshots; i++ {
+		capturedAt := timeLog.StartTime.Add(time.Duration(timeLog.Duration) * time.Second * time.Duration(i+1) / time.Duration(s.screenshots+1))
+
+		data, err := fakeScreenshot(s.rng, hexColor)
+		if err != nil {
+			return err
+		}
+		fileName := fmt.Sprintf("seed_%d_%d_%s.png", timeLog.UserID, capturedAt.Unix(), uuid.New().String()[:8])
+		filePath := filepath.Join(s.screenshotsDir, fileName)
+		if err := os.WriteFile(filePath, data, 0600); err != nil {
+			return fmt.Errorf("write screenshot: %w", err)
+		}
+		s.files = append(s.files, filePath)
+
+		screenshot := &models.Screenshot{
+			UserID:         timeLog.UserID,
+			OrganizationID: timeLog.OrganizationID,
+			WorkspaceID:    timeLog.WorkspaceID,
+			TimeLogID:      &timeLog.ID,
+			TaskID:         &task.ID,
+			TaskLocalID:    task.LocalID,
+			FilePath:       filePath,
+			FileName:       fileName,
+			FileSize:       int64(len(data)),
+			MimeType:       "image/png",
+			CapturedAt:     capturedAt,
+			Checksum:       utils.CalculateChecksum(data),
+			IsSynced:       true,
+			LocalID:        uuid.New().String(),
+		}
+		if err := tx.Create(screenshot).Error; err != nil {
+			return fmt.Errorf("create screenshot: %w", err)
+		}
+	}
+	return nil
+}
+
+// fakeScreenshot draws a small desktop-like image: a title bar in the
+// workspace color over random window blocks
+func fakeScreenshot(rng *rand.Rand, hexColor string) ([]byte, error) {
+	const width, height = 640, 360
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	fill := func(x0, y0, x1, y1 int, c color.Color) {
+		for y := y0; y < y1 && y < height; y++ {
+			for x := x0; x < x1 && x < width; x++ {
+				img.Set(x, y, c)
+			}
+		}
+	}
+
+	fill(0, 0, width, height, color.RGBA{240, 242, 245, 255})
+	var accent color.RGBA
+	if _, err := fmt.Sscanf(hexColor, "#%02x%02x%02x", &accent.R, &accent.G, &accent.B); err != nil {
+		accent = color.RGBA{59, 130, 246, 255}
+	}
+	accent.A = 255
+	fill(0, 0, width, 24, accent)
+
+	for i := 0; i < 4+rng.Intn(4); i++ {
+		x, y := rng.Intn(width-160), 32+rng.Intn(height-120)
+		shade := uint8(160 + rng.Intn(80))
+		fill(x, y, x+120+rng.Intn(200), y+60+rng.Intn(100), color.RGBA{shade, shade, shade, 255})
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -1,16 +1,33 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
 
+	"github.com/beuphecan/remote-time-tracker/internal/backup"
+	"github.com/beuphecan/remote-time-tracker/internal/cache"
 	"github.com/beuphecan/remote-time-tracker/internal/config"
 	"github.com/beuphecan/remote-time-tracker/internal/controller"
 	"github.com/beuphecan/remote-time-tracker/internal/database"
+	"github.com/beuphecan/remote-time-tracker/internal/mail"
+	"github.com/beuphecan/remote-time-tracker/internal/middleware"
+	"github.com/beuphecan/remote-time-tracker/internal/money"
+	"github.com/beuphecan/remote-time-tracker/internal/objectstore"
+	"github.com/beuphecan/remote-time-tracker/internal/ocr"
+	"github.com/beuphecan/remote-time-tracker/internal/ratelimit"
 	"github.com/beuphecan/remote-time-tracker/internal/repository"
 	"github.com/beuphecan/remote-time-tracker/internal/router"
+	"github.com/beuphecan/remote-time-tracker/internal/scanner"
+	"github.com/beuphecan/remote-time-tracker/internal/scheduler"
 	"github.com/beuphecan/remote-time-tracker/internal/service"
 
 	_ "github.com/beuphecan/remote-time-tracker/docs" // Swagger generated docs
@@ -49,6 +66,9 @@ import (
 // @tag.name sync
 // @tag.description Data synchronization from Electron desktop app
 
+// @tag.name devices
+// @tag.description Desktop app diagnostics - Log bundle uploads
+
 // @tag.name organizations
 // @tag.description Organization management - Create, manage organizations and members
 
@@ -58,6 +78,12 @@ import (
 // @tag.name invitations
 // @tag.description User invitations to organizations and workspaces
 
+// @tag.name users
+// @tag.description Current user privacy - Personal data export and account deletion
+
+// @tag.name notifications
+// @tag.description In-app notifications - Notification bell, unread count, mark read
+
 // @tag.name admin
 // @tag.description System administration - User, Organization, Task, TimeLog management (Admin only)
 
@@ -70,19 +96,23 @@ import (
 // ensureUploadDirectories creates necessary upload directories if they don't exist
 func ensureUploadDirectories(cfg *config.Config) error {
 	uploadPath := cfg.Upload.Path
-	screenshotsPath := filepath.Join(uploadPath, "screenshots")
+	screenshotsPath := filepath.Join(cfg.Upload.PrivatePath, "screenshots")
 
-	// Create base upload directory
-	if err := os.MkdirAll(uploadPath, 0755); err != nil {
-		return fmt.Errorf("failed to create upload directory: %w", err)
+	// Files still in the legacy public directory are moved out at startup,
+	// which tells the two apart by path prefix
+	if rel, err := filepath.Rel(uploadPath, cfg.Upload.PrivatePath); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("PRIVATE_UPLOAD_PATH %s must not be inside UPLOAD_PATH %s", cfg.Upload.PrivatePath, uploadPath)
+	}
+	if err := os.MkdirAll(cfg.Upload.PrivatePath, 0700); err != nil {
+		return fmt.Errorf("failed to create private upload directory: %w", err)
 	}
 
 	// Create screenshots subdirectory
-	if err := os.MkdirAll(screenshotsPath, 0755); err != nil {
+	if err := os.MkdirAll(screenshotsPath, 0700); err != nil {
 		return fmt.Errorf("failed to create screenshots directory: %w", err)
 	}
 
-	log.Printf("📁 Upload path: %s", uploadPath)
+	log.Printf("🔒 Private upload path: %s", cfg.Upload.PrivatePath)
 	log.Printf("📸 Screenshots path: %s", screenshotsPath)
 
 	return nil
@@ -122,26 +152,151 @@ func main() {
 	screenshotRepo := repository.NewScreenshotRepository(db)
 	deviceRepo := repository.NewDeviceRepository(db)
 	syncLogRepo := repository.NewSyncLogRepository(db)
+	syncConflictRepo := repository.NewSyncConflictRepository(db)
+	syncStore := repository.NewSyncStore(db)
 	orgRepo := repository.NewOrganizationRepository(db)
 	workspaceRepo := repository.NewWorkspaceRepository(db)
 	invitationRepo := repository.NewInvitationRepository(db)
 	adminRepo := repository.NewAdminRepository(db)
+	adminStatsRepo := repository.NewAdminStatsRepository(db)
+	auditLogRepo := repository.NewAuditLogRepository(db)
+	privacyRepo := repository.NewPrivacyRepository(db)
+	analyticsRepo := repository.NewAnalyticsRepository(db)
+	retentionRepo := repository.NewRetentionRepository(db)
+	storageCounterRepo := repository.NewStorageCounterRepository(db)
+	deviceLogRepo := repository.NewDeviceLogRepository(db)
+	telemetryRepo := repository.NewTelemetryRepository(db)
+	webhookRepo := repository.NewWebhookRepository(db)
+	screenshotDeletionRepo := repository.NewScreenshotDeletionRepository(db)
+	deviceApprovalRepo := repository.NewDeviceApprovalRepository(db)
+	appReleaseRepo := repository.NewAppReleaseRepository(db)
+	featureFlagRepo := repository.NewFeatureFlagRepository(db)
+	captureExclusionRepo := repository.NewCaptureExclusionRepository(db)
+	privateIntervalRepo := repository.NewPrivateIntervalRepository(db)
+	encryptionKeyRepo := repository.NewEncryptionKeyRepository(db)
+	payrollRepo := repository.NewPayrollRepository(db)
+	scheduleRepo := repository.NewScheduleRepository(db)
+	leaveRepo := repository.NewLeaveRepository(db)
+	holidayRepo := repository.NewHolidayRepository(db)
+	clientRepo := repository.NewClientRepository(db)
+	orgSettingRepo := repository.NewOrganizationSettingRepository(db)
+	invoiceRepo := repository.NewInvoiceRepository(db)
+	overtimeRepo := repository.NewOvertimeRepository(db)
+	reportRepo := repository.NewReportRepository(db)
+	statsRollupRepo := repository.NewStatsRollupRepository(db)
+	shareLinkRepo := repository.NewShareLinkRepository(db)
+	savedReportRepo := repository.NewSavedReportRepository(db)
+	taskAssignmentRepo := repository.NewTaskAssignmentRepository(db)
+	taskBoardRepo := repository.NewTaskBoardRepository(db)
+	taskCommentRepo := repository.NewTaskCommentRepository(db)
+	searchRepo := repository.NewSearchRepository(db)
+	publicIDRepo := repository.NewPublicIDRepository(db)
+	idempotencyRepo := repository.NewIdempotencyRepository(db)
+	jiraRepo := repository.NewJiraRepository(db)
+	slackRepo := repository.NewSlackRepository(db)
+	commitLinkRepo := repository.NewCommitLinkRepository(db)
+	calendarRepo := repository.NewCalendarRepository(db)
+	emailRepo := repository.NewEmailRepository(db)
+	passwordResetRepo := repository.NewPasswordResetRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	roleChangeRepo := repository.NewRoleChangeRepository(db)
+	handoffRepo := repository.NewHandoffRepository(db)
+	screenshotTierRepo := repository.NewScreenshotTierRepository(db)
+	screenshotScanRepo := repository.NewScreenshotScanRepository(db)
+	screenshotOCRRepo := repository.NewScreenshotOCRRepository(db)
+	presenceRepo := repository.NewPresenceRepository(db)
+	orgExportRepo := repository.NewOrganizationExportRepository(db)
+	operationRepo := repository.NewOperationRepository(db)
+	orgImportRepo := repository.NewOrganizationImportRepository(db)
+	timeLogEditRepo := repository.NewTimeLogEditRepository(db)
+
+	// Cache hot stats queries when Redis is configured; the admin dashboard
+	// metrics and organization settings fall back to an in-memory cache
+	// without it
+	var analyticsCache cache.Cache = cache.NewMemory()
+	if redisCache := newRedisCache(cfg); redisCache != nil {
+		statsCache := repository.NewStatsCache(redisCache, cfg.Cache.StatsTTL)
+		adminRepo = repository.NewCachedAdminRepository(adminRepo, statsCache)
+		orgRepo.SetStatsCache(statsCache)
+		workspaceRepo.SetStatsCache(statsCache)
+		analyticsCache = redisCache
+	}
 
 	log.Println("✅ Repositories initialized")
 
 	// Initialize services
-	authService := service.NewAuthService(userRepo, orgRepo, invitationRepo, workspaceRepo)
-	taskService := service.NewTaskService(taskRepo)
-	timeLogService := service.NewTimeLogService(timeLogRepo, deviceRepo, userRepo)
-	presenceService := service.NewPresenceService(userRepo, deviceRepo)
-	syncService := service.NewSyncService(timeLogRepo, screenshotRepo, deviceRepo, syncLogRepo, taskRepo)
-	screenshotService := service.NewScreenshotService(screenshotRepo, timeLogRepo, taskRepo)
-	organizationService := service.NewOrganizationService(orgRepo, workspaceRepo, userRepo)
-	workspaceService := service.NewWorkspaceService(workspaceRepo, orgRepo, userRepo)
-	invitationService := service.NewInvitationService(invitationRepo, orgRepo, workspaceRepo, userRepo)
+	slackService := service.NewSlackService(slackRepo, orgRepo, userRepo)
+	calendarService := service.NewCalendarService(calendarRepo)
+	emailService := service.NewEmailService(emailRepo, newMailSender(cfg))
+	notificationService := service.NewNotificationService(notificationRepo)
+	webhookService := service.NewWebhookService(webhookRepo, orgRepo, workspaceRepo, userRepo, slackService)
+	permissionService := service.NewPermissionService(roleChangeRepo, orgRepo, workspaceRepo, userRepo)
+	auditService := service.NewAuditService(auditLogRepo)
+	periodLockService := service.NewPeriodLockService(orgRepo, auditService)
+	storageQuotaService := service.NewStorageQuotaService(storageCounterRepo, orgRepo)
+	authService := service.NewAuthService(userRepo, orgRepo, invitationRepo, workspaceRepo, webhookService, passwordResetRepo, emailService)
+	presenceService := service.NewPresenceService(userRepo, deviceRepo, presenceRepo, orgRepo)
+	workspaceService := service.NewWorkspaceService(workspaceRepo, orgRepo, userRepo, permissionService)
+	timeLogHistoryService := service.NewTimeLogHistoryService(timeLogEditRepo, timeLogRepo, permissionService)
+	commitLinkService := service.NewCommitLinkService(commitLinkRepo, timeLogRepo, workspaceRepo, userRepo, workspaceService)
+	timeLogService := service.NewTimeLogService(timeLogRepo, deviceRepo, userRepo, taskRepo, workspaceRepo, commitLinkService, periodLockService)
+	taskAssignmentService := service.NewTaskAssignmentService(taskAssignmentRepo, workspaceRepo, workspaceService)
+	taskBoardService := service.NewTaskBoardService(taskBoardRepo, taskRepo, workspaceService)
+	searchService := service.NewSearchService(searchRepo, orgRepo)
+	taskCommentService := service.NewTaskCommentService(taskCommentRepo, taskRepo, userRepo, workspaceService, notificationService)
+	if err := taskCommentService.MovePublicAttachments(); err != nil {
+		log.Printf("⚠️  Failed to move task attachments out of the public uploads directory: %v", err)
+	}
+	taskService := service.NewTaskService(taskRepo, commitLinkRepo, userRepo, workspaceService, taskAssignmentService, notificationService)
+	jiraService := service.NewJiraService(jiraRepo, workspaceRepo, workspaceService, taskAssignmentService)
+	complianceService := service.NewComplianceService(timeLogRepo, workspaceRepo, orgRepo, workspaceService, webhookService)
+	rateProvider := newRateProvider(cfg)
+	orgSettingsService := service.NewOrganizationSettingsService(orgSettingRepo, orgRepo, analyticsCache)
+	reportService := service.NewReportService(reportRepo, analyticsRepo, workspaceRepo, orgRepo, userRepo, workspaceService, rateProvider)
+	shareLinkService := service.NewShareLinkService(shareLinkRepo, workspaceRepo, workspaceService, reportService, orgSettingsService)
+	savedReportService := service.NewSavedReportService(savedReportRepo, orgRepo, userRepo, reportService, emailService)
+	statsRollupService := service.NewStatsRollupService(statsRollupRepo)
+	budgetService := service.NewBudgetService(timeLogRepo, workspaceRepo, orgRepo, workspaceService, notificationService, webhookService)
+	capturePolicyService := service.NewCapturePolicyService(captureExclusionRepo, orgRepo)
+	encryptionKeyService := service.NewEncryptionKeyService(encryptionKeyRepo, orgRepo)
+	payrollService := service.NewPayrollService(payrollRepo, orgRepo)
+	leaveService := service.NewLeaveService(leaveRepo, holidayRepo, orgRepo, workspaceRepo, notificationService)
+	holidayService := service.NewHolidayService(holidayRepo, orgRepo)
+	clientService := service.NewClientService(clientRepo, orgRepo)
+	invoiceService := service.NewInvoiceService(invoiceRepo, clientRepo, orgRepo, workspaceRepo, rateProvider, orgSettingsService)
+	scheduleService := service.NewScheduleService(scheduleRepo, leaveRepo, holidayRepo, userRepo)
+	overtimeService := service.NewOvertimeService(overtimeRepo, scheduleRepo, leaveRepo, holidayRepo, notificationService)
+	adminAnalyticsService := service.NewAdminAnalyticsService(adminStatsRepo, orgRepo, scheduleService, analyticsCache)
+	deviceApprovalService := service.NewDeviceApprovalService(deviceApprovalRepo, orgRepo)
+	featureFlagService := service.NewFeatureFlagService(featureFlagRepo, orgRepo)
+	deviceConfigService := service.NewDeviceConfigService(deviceRepo, workspaceRepo, orgRepo, capturePolicyService, deviceApprovalService, featureFlagService)
+	updateService := service.NewUpdateService(appReleaseRepo, orgRepo)
+	privateIntervalService := service.NewPrivateIntervalService(privateIntervalRepo, timeLogRepo)
+	screenshotScanService := service.NewScreenshotScanService(screenshotScanRepo, screenshotRepo, newScanner(cfg), cfg.Scanner.Timeout)
+	screenshotOCRService := service.NewScreenshotOCRService(screenshotOCRRepo, newOCR(cfg), cfg.OCR.Timeout)
+	syncService := service.NewSyncService(syncStore, deviceRepo, syncLogRepo, syncConflictRepo, workspaceRepo, complianceService, capturePolicyService, encryptionKeyService, privateIntervalService, orgSettingsService, adminAnalyticsService, commitLinkService, deviceApprovalService, updateService, periodLockService, storageQuotaService, screenshotScanService, screenshotOCRService)
+	screenshotService := service.NewScreenshotService(screenshotRepo, timeLogRepo, taskRepo, workspaceRepo, workspaceService)
+	if err := screenshotService.MovePublicScreenshots(); err != nil {
+		log.Printf("⚠️  Failed to move screenshots out of the public uploads directory: %v", err)
+	}
+	screenshotTierService := service.NewScreenshotTierService(screenshotTierRepo, newColdStore(cfg), cfg.Upload.PrivatePath, cfg.ColdStore.AfterDays, cfg.ColdStore.RestoreDays)
+	organizationService := service.NewOrganizationService(orgRepo, workspaceRepo, userRepo, timeLogRepo, handoffRepo, webhookService, permissionService)
+	invitationService := service.NewInvitationService(invitationRepo, orgRepo, workspaceRepo, userRepo, webhookService, notificationService, emailService)
 	roleService := service.NewRoleService(workspaceRepo, orgRepo)
-	updateService := service.NewUpdateService()
 	systemService := service.NewSystemService(userRepo)
+	operationService := service.NewOperationService(operationRepo)
+	privacyService := service.NewPrivacyService(privacyRepo, userRepo, screenshotTierService, operationService)
+	analyticsService := service.NewAnalyticsService(analyticsRepo, orgRepo)
+	orgExportService := service.NewOrganizationExportService(orgExportRepo, orgRepo, screenshotTierService, operationService)
+	orgDeletionService := service.NewOrganizationDeletionService(orgRepo, screenshotTierService)
+	orgImportService := service.NewOrganizationImportService(orgImportRepo, orgRepo, workspaceRepo)
+	retentionService := service.NewRetentionService(retentionRepo, orgRepo, screenshotTierService)
+	deviceLogService := service.NewDeviceLogService(deviceLogRepo, deviceRepo)
+	if err := deviceLogService.MovePublicBundles(); err != nil {
+		log.Printf("⚠️  Failed to move device log bundles out of the public uploads directory: %v", err)
+	}
+	telemetryService := service.NewTelemetryService(telemetryRepo, orgRepo)
+	screenshotDeletionService := service.NewScreenshotDeletionService(screenshotDeletionRepo, screenshotRepo, orgRepo, workspaceRepo, auditService)
 	adminService := service.NewAdminService(
 		adminRepo,
 		userRepo,
@@ -150,52 +305,497 @@ func main() {
 		taskRepo,
 		timeLogRepo,
 		screenshotRepo,
+		permissionService,
+		slackService,
+		commitLinkService,
+		notificationService,
+		orgDeletionService,
+		auditService,
+		timeLogHistoryService,
 	)
 
 	log.Println("✅ Services initialized")
 
+	// Precompute the admin dashboard in the background
+	go adminAnalyticsService.Warm(context.Background())
+
 	// Initialize controllers
 	authController := controller.NewAuthController(authService)
 	timeLogController := controller.NewTimeLogController(timeLogService)
 	presenceController := controller.NewPresenceController(presenceService)
 	syncController := controller.NewSyncController(syncService)
-	screenshotController := controller.NewScreenshotController(screenshotService)
+	screenshotController := controller.NewScreenshotController(screenshotService, screenshotTierService)
 	taskController := controller.NewTaskController(taskService)
 	systemController := controller.NewSystemController(systemService)
 	organizationController := controller.NewOrganizationController(organizationService, workspaceService, invitationService, roleService)
-	workspaceController := controller.NewWorkspaceController(workspaceService)
+	workspaceController := controller.NewWorkspaceController(workspaceService, complianceService, budgetService)
+	reportController := controller.NewReportController(reportService)
+	savedReportController := controller.NewSavedReportController(savedReportService)
 	invitationController := controller.NewInvitationController(invitationService)
-	adminController := controller.NewAdminController(adminService)
-	adminPresenceController := controller.NewAdminPresenceController()
+	adminController := controller.NewAdminController(adminService, adminAnalyticsService, screenshotTierService, auditService)
+	adminPresenceController := controller.NewAdminPresenceController(presenceService)
+	adminActivityFeedController := controller.NewAdminActivityFeedController()
 	updateController := controller.NewUpdateController(updateService)
+	adminReleaseController := controller.NewAdminReleaseController(updateService)
+	auditLogController := controller.NewAuditLogController(auditService)
+	privacyController := controller.NewPrivacyController(privacyService)
+	analyticsController := controller.NewAnalyticsController(analyticsService)
+	adminRetentionController := controller.NewAdminRetentionController(retentionService)
+	adminStorageQuotaController := controller.NewAdminStorageQuotaController(storageQuotaService)
+	adminScreenshotScanController := controller.NewAdminScreenshotScanController(screenshotScanService)
+	adminScreenshotOCRController := controller.NewAdminScreenshotOCRController(screenshotOCRService)
+	deviceLogController := controller.NewDeviceLogController(deviceLogService)
+	deviceConfigController := controller.NewDeviceConfigController(deviceConfigService)
+	featureFlagController := controller.NewFeatureFlagController(featureFlagService)
+	adminFeatureFlagController := controller.NewAdminFeatureFlagController(featureFlagService)
+	adminDeviceLogController := controller.NewAdminDeviceLogController(deviceLogService)
+	telemetryController := controller.NewTelemetryController(telemetryService)
+	adminTelemetryController := controller.NewAdminTelemetryController(telemetryService)
+	webhookController := controller.NewWebhookController(webhookService)
+	screenshotDeletionController := controller.NewScreenshotDeletionController(screenshotDeletionService)
+	deviceApprovalController := controller.NewDeviceApprovalController(deviceApprovalService)
+	capturePolicyController := controller.NewCapturePolicyController(capturePolicyService)
+	encryptionKeyController := controller.NewEncryptionKeyController(encryptionKeyService)
+	payrollController := controller.NewPayrollController(payrollService)
+	periodLockController := controller.NewPeriodLockController(periodLockService)
+	adminScheduleController := controller.NewAdminScheduleController(scheduleService)
+	adminOvertimeController := controller.NewAdminOvertimeController(overtimeService)
+	leaveController := controller.NewLeaveController(leaveService)
+	holidayController := controller.NewHolidayController(holidayService)
+	clientController := controller.NewClientController(clientService)
+	orgSettingsController := controller.NewOrganizationSettingsController(orgSettingsService)
+	orgDeletionController := controller.NewOrganizationDeletionController(orgDeletionService)
+	invoiceController := controller.NewInvoiceController(invoiceService)
+	taskAssignmentController := controller.NewTaskAssignmentController(taskAssignmentService)
+	taskBoardController := controller.NewTaskBoardController(taskBoardService)
+	taskCommentController := controller.NewTaskCommentController(taskCommentService)
+	searchController := controller.NewSearchController(searchService)
+	jiraController := controller.NewJiraController(jiraService)
+	commitLinkController := controller.NewCommitLinkController(commitLinkService)
+	timeLogHistoryController := controller.NewTimeLogHistoryController(timeLogHistoryService)
+	calendarController := controller.NewCalendarController(calendarService)
+	shareLinkController := controller.NewShareLinkController(shareLinkService)
+	privateIntervalController := controller.NewPrivateIntervalController(privateIntervalService)
+	slackController := controller.NewSlackController(slackService)
+	notificationPreferenceController := controller.NewNotificationPreferenceController(emailService)
+	notificationController := controller.NewNotificationController(notificationService)
+	captchaService := service.NewCaptchaService(cfg.Captcha.VerifyURL, cfg.Captcha.Secret)
+	invitePreviewService := service.NewInvitePreviewService(orgRepo, captchaService, cfg.Captcha.InvitePreview)
+	invitePreviewController := controller.NewInvitePreviewController(invitePreviewService)
+	permissionController := controller.NewPermissionController(permissionService)
+	orgExportController := controller.NewOrganizationExportController(orgExportService)
+	operationController := controller.NewOperationController(operationService)
+	orgImportController := controller.NewOrganizationImportController(orgImportService)
+
+	purgeService := service.NewPurgeService(repository.NewPurgeRepository(db), screenshotTierService, operationService, cfg.Purge.OlderThanDays)
+	adminMaintenanceController := controller.NewAdminMaintenanceController(purgeService)
+
+	// Background jobs; the advisory locker keeps each run on a single instance
+	jobScheduler := scheduler.New(scheduler.NewPostgresLocker(db))
+	registerJobs(jobScheduler, cfg, operationService, privacyService, orgExportService, orgDeletionService, retentionService, invitationService, deviceLogService, telemetryService, webhookService, purgeService, screenshotTierService, jiraService, slackService, calendarService, emailService, notificationService, budgetService, overtimeService, savedReportService, statsRollupService, screenshotScanService, screenshotOCRService, presenceService, idempotencyRepo, newUploadsBackup(cfg))
+	adminJobsController := controller.NewAdminJobsController(jobScheduler)
+
+	healthService := service.NewHealthService(repository.NewHealthRepository(db), cfg.Upload.PrivatePath)
+	healthController := controller.NewHealthController(healthService)
 
 	log.Println("✅ Controllers initialized")
 
+	// Rate limits for auth, sync and invite preview endpoints
+	rateLimiter := newRateLimiter(cfg)
+	rateLimitExempt := middleware.NewRateLimitExemptions(cfg.RateLimit.ExemptUserIDs, cfg.RateLimit.ExemptIPs)
+
 	// Setup router with full config
 	r := router.SetupRouterWithConfig(&router.RouterConfig{
-		AuthController:          authController,
-		TimeLogController:       timeLogController,
-		SyncController:          syncController,
-		ScreenshotController:    screenshotController,
-		TaskController:          taskController,
-		SystemController:        systemController,
-		PresenceController:      presenceController,
-		OrganizationController:  organizationController,
-		WorkspaceController:     workspaceController,
-		InvitationController:    invitationController,
-		AdminController:         adminController,
-		AdminPresenceController: adminPresenceController,
-		UpdateController:        updateController,
-		OrganizationService:     organizationService,
-		WorkspaceService:        workspaceService,
+		AuthController:                   authController,
+		TimeLogController:                timeLogController,
+		SyncController:                   syncController,
+		ScreenshotController:             screenshotController,
+		TaskController:                   taskController,
+		SystemController:                 systemController,
+		PresenceController:               presenceController,
+		OrganizationController:           organizationController,
+		WorkspaceController:              workspaceController,
+		ReportController:                 reportController,
+		SavedReportController:            savedReportController,
+		InvitationController:             invitationController,
+		AdminController:                  adminController,
+		AdminPresenceController:          adminPresenceController,
+		AdminActivityFeedController:      adminActivityFeedController,
+		UpdateController:                 updateController,
+		AuditLogController:               auditLogController,
+		PrivacyController:                privacyController,
+		AnalyticsController:              analyticsController,
+		AdminRetentionController:         adminRetentionController,
+		AdminStorageQuotaController:      adminStorageQuotaController,
+		AdminScreenshotScanController:    adminScreenshotScanController,
+		AdminScreenshotOCRController:     adminScreenshotOCRController,
+		AdminJobsController:              adminJobsController,
+		AdminMaintenanceController:       adminMaintenanceController,
+		OrganizationExportController:     orgExportController,
+		OperationController:              operationController,
+		OrganizationImportController:     orgImportController,
+		DeviceLogController:              deviceLogController,
+		AdminDeviceLogController:         adminDeviceLogController,
+		DeviceConfigController:           deviceConfigController,
+		FeatureFlagController:            featureFlagController,
+		AdminFeatureFlagController:       adminFeatureFlagController,
+		TelemetryController:              telemetryController,
+		AdminTelemetryController:         adminTelemetryController,
+		AdminReleaseController:           adminReleaseController,
+		TelemetryRateLimit:               cfg.Telemetry.RateLimit,
+		WebhookController:                webhookController,
+		ScreenshotDeletionController:     screenshotDeletionController,
+		DeviceApprovalController:         deviceApprovalController,
+		CapturePolicyController:          capturePolicyController,
+		EncryptionKeyController:          encryptionKeyController,
+		PayrollController:                payrollController,
+		PeriodLockController:             periodLockController,
+		AdminScheduleController:          adminScheduleController,
+		AdminOvertimeController:          adminOvertimeController,
+		LeaveController:                  leaveController,
+		HolidayController:                holidayController,
+		ClientController:                 clientController,
+		OrganizationSettingsController:   orgSettingsController,
+		OrganizationDeletionController:   orgDeletionController,
+		InvoiceController:                invoiceController,
+		PermissionController:             permissionController,
+		HealthController:                 healthController,
+		TaskAssignmentController:         taskAssignmentController,
+		TaskBoardController:              taskBoardController,
+		TaskCommentController:            taskCommentController,
+		SearchController:                 searchController,
+		JiraController:                   jiraController,
+		CommitLinkController:             commitLinkController,
+		TimeLogHistoryController:         timeLogHistoryController,
+		CalendarController:               calendarController,
+		ShareLinkController:              shareLinkController,
+		PrivateIntervalController:        privateIntervalController,
+		NotificationPreferenceController: notificationPreferenceController,
+		NotificationController:           notificationController,
+		SlackController:                  slackController,
+		InvitePreviewController:          invitePreviewController,
+		RateLimiter:                      rateLimiter,
+		AuthRateLimit: middleware.RateLimitPolicy{
+			Name:   "auth",
+			PerIP:  ratelimit.Rate{PerMinute: cfg.RateLimit.AuthPerIP, Burst: cfg.RateLimit.AuthBurst},
+			Exempt: rateLimitExempt,
+		},
+		SyncRateLimit: middleware.RateLimitPolicy{
+			Name:    "sync",
+			PerIP:   ratelimit.Rate{PerMinute: cfg.RateLimit.SyncPerIP, Burst: cfg.RateLimit.SyncBurst},
+			PerUser: ratelimit.Rate{PerMinute: cfg.RateLimit.SyncPerUser, Burst: cfg.RateLimit.SyncBurst},
+			Exempt:  rateLimitExempt,
+		},
+		InvitePreviewRateLimit: middleware.RateLimitPolicy{
+			Name:   "invite_preview",
+			PerIP:  ratelimit.Rate{PerMinute: cfg.RateLimit.InvitePerIP, Burst: cfg.RateLimit.InviteBurst},
+			Exempt: rateLimitExempt,
+		},
+		OrganizationService: organizationService,
+		WorkspaceService:    workspaceService,
+		AuditService:        auditService,
+		PublicIDRepository:  publicIDRepo,
+
+		IdempotencyRepository: idempotencyRepo,
 	})
 
+	jobScheduler.Start()
+	defer jobScheduler.Stop()
+
 	// Start server
 	addr := fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port)
-	log.Printf("🚀 Server starting on %s in %s mode", addr, cfg.Server.Env)
-	log.Printf("📚 API documentation: http://%s/api/v1", addr)
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           r,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	serverErr := make(chan error, 1)
+	go func() {
+		log.Printf("🚀 Server starting on %s in %s mode", addr, cfg.Server.Env)
+		log.Printf("📚 API documentation: http://%s/api/v1", addr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	if err := r.Run(addr); err != nil {
+	select {
+	case err := <-serverErr:
 		log.Fatalf("Failed to start server: %v", err)
+	case <-ctx.Done():
+	}
+	stop()
+
+	gracefulShutdown(srv, cfg, healthService)
+}
+
+// gracefulShutdown fails readiness so load balancers stop routing new
+// requests, then waits for in-flight requests such as sync uploads to finish.
+// Deferred cleanup in main stops the scheduler and closes the database.
+func gracefulShutdown(srv *http.Server, cfg *config.Config, healthService service.HealthService) {
+	log.Printf("🛑 Shutdown signal received, draining for %s", cfg.Server.DrainDelay)
+	healthService.MarkShuttingDown()
+	time.Sleep(cfg.Server.DrainDelay)
+
+	// Streams never finish on their own, so end them before waiting
+	service.PresenceBroadcaster.Close()
+	service.ActivityFeedBroadcaster.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Printf("⚠️  Server did not shut down cleanly: %v", err)
+		return
 	}
+	log.Println("✅ Server stopped")
+}
+
+// registerJobs registers the background jobs with the scheduler
+func registerJobs(s *scheduler.Scheduler, cfg *config.Config, operationService service.OperationService, privacyService service.PrivacyService, orgExportService service.OrganizationExportService, orgDeletionService service.OrganizationDeletionService, retentionService service.RetentionService, invitationService service.InvitationService, deviceLogService service.DeviceLogService, telemetryService service.TelemetryService, webhookService service.WebhookService, purgeService service.PurgeService, screenshotTierService service.ScreenshotTierService, jiraService service.JiraService, slackService service.SlackService, calendarService service.CalendarService, emailService service.EmailService, notificationService service.NotificationService, budgetService service.BudgetService, overtimeService service.OvertimeService, savedReportService service.SavedReportService, statsRollupService service.StatsRollupService, screenshotScanService service.ScreenshotScanService, screenshotOCRService service.ScreenshotOCRService, presenceService service.PresenceService, idempotencyRepo repository.IdempotencyRepository, uploadsBackup *backup.Snapshotter) {
+	// Backups only run when a target is configured
+	backupSchedule := cfg.Jobs.UploadsBackupSchedule
+	var backupJob scheduler.JobFunc
+	if uploadsBackup != nil {
+		backupJob = uploadsBackup.Run
+	} else {
+		backupSchedule = ""
+	}
+
+	jobs := []struct {
+		name    string
+		spec    string
+		timeout time.Duration
+		fn      scheduler.JobFunc
+	}{
+		// Execute account erasures whose grace period has ended
+		{"privacy.erasure", cfg.Jobs.PrivacyErasureSchedule, 30 * time.Minute, privacyService.ProcessDueErasures},
+		// Delete expired data export archives
+		{"privacy.export_cleanup", cfg.Jobs.ExportCleanupSchedule, 10 * time.Minute, privacyService.PurgeExpiredExports},
+		// Delete expired organization export archives
+		{"organizations.export_cleanup", cfg.Jobs.ExportCleanupSchedule, 10 * time.Minute, orgExportService.PurgeExpiredExports},
+		// Purge organizations whose deletion grace period has ended
+		{"organizations.deletion", cfg.Jobs.OrgDeletionSchedule, 30 * time.Minute, orgDeletionService.ProcessDueDeletions},
+		// Delete finished operations past their retention
+		{"operations.cleanup", cfg.Jobs.OperationCleanupSchedule, 10 * time.Minute, operationService.PurgeFinished},
+		// Delete stored Idempotency-Key responses past their TTL
+		{"idempotency.cleanup", cfg.Jobs.IdempotencyCleanupSchedule, 10 * time.Minute, func(ctx context.Context) error {
+			_, err := idempotencyRepo.DeleteExpired(time.Now())
+			return err
+		}},
+		// Delete desktop app log bundles past their retention
+		{"device_logs.cleanup", cfg.Jobs.DeviceLogCleanupSchedule, 10 * time.Minute, deviceLogService.PurgeExpired},
+		// Delete crash reports and usage events past the telemetry retention
+		{"telemetry.cleanup", cfg.Jobs.TelemetryCleanupSchedule, 10 * time.Minute, telemetryService.PurgeExpired},
+		// Purge screenshots past each organization's retention period
+		{"retention.screenshots", cfg.Jobs.ScreenshotRetentionSchedule, time.Hour, func(ctx context.Context) error {
+			purged, freed, err := retentionService.PurgeExpiredScreenshots(ctx)
+			if purged > 0 {
+				log.Printf("✅ Retention purged %d screenshots (%d bytes)", purged, freed)
+			}
+			return err
+		}},
+		// Retry webhook deliveries whose backoff has elapsed
+		{"webhooks.retry", cfg.Jobs.WebhookRetrySchedule, 5 * time.Minute, webhookService.RetryDue},
+		// Delete webhook delivery history past its retention
+		{"webhooks.cleanup", cfg.Jobs.WebhookCleanupSchedule, 10 * time.Minute, webhookService.PurgeDeliveries},
+		// Import Jira issues as tasks and push worklogs for connected workspaces
+		{"integrations.jira_sync", cfg.Jobs.JiraSyncSchedule, 30 * time.Minute, jiraService.SyncAll},
+		// Post yesterday's hours per workspace to subscribed Slack channels
+		{"integrations.slack_daily_summary", cfg.Jobs.SlackDailySummarySchedule, 30 * time.Minute, slackService.SendDailySummaries},
+		// Push completed time logs to connected Google calendars
+		{"integrations.google_calendar_push", cfg.Jobs.GoogleCalendarPushSchedule, 30 * time.Minute, calendarService.PushAll},
+		// Send queued emails and retry failed ones whose backoff has elapsed
+		{"email.delivery", cfg.Jobs.EmailDeliverySchedule, 5 * time.Minute, emailService.DeliverDue},
+		// Delete sent and failed emails past the outbox retention
+		{"email.cleanup", cfg.Jobs.EmailCleanupSchedule, 10 * time.Minute, emailService.PurgeOld},
+		// Queue last week's tracked time summary for each user
+		{"email.weekly_summary", cfg.Jobs.WeeklySummarySchedule, 30 * time.Minute, emailService.SendWeeklySummaries},
+		// Delete in-app notifications past their retention
+		{"notifications.cleanup", cfg.Jobs.NotificationCleanupSchedule, 10 * time.Minute, notificationService.PurgeOld},
+		// Recompute workspace budget consumption and send threshold alerts
+		{"budgets.rollup", cfg.Jobs.BudgetRollupSchedule, 30 * time.Minute, budgetService.RollupAll},
+		// Store overtime of scheduled users, flag their time logs and alert managers
+		{"overtime.detect", cfg.Jobs.OvertimeDetectionSchedule, 30 * time.Minute, overtimeService.DetectAll},
+		// Email scheduled saved reports that are due
+		{"reports.deliver", cfg.Jobs.SavedReportDeliverySchedule, 15 * time.Minute, savedReportService.DeliverDue},
+		// Roll up past days into the daily stats read by dashboards
+		{"stats.rollup", cfg.Jobs.StatsRollupSchedule, time.Hour, statsRollupService.RollupDays},
+		// Permanently remove rows soft-deleted long ago
+		{"maintenance.purge", cfg.Jobs.SoftDeletePurgeSchedule, time.Hour, purgeService.PurgeExpired},
+		// Scan screenshots whose background malware scan did not run
+		{"screenshots.scan", cfg.Jobs.ScreenshotScanSchedule, 30 * time.Minute, screenshotScanService.ScanPending},
+		// Read the text of new screenshots for the admin screenshot search
+		{"screenshots.ocr", cfg.Jobs.ScreenshotOCRSchedule, 30 * time.Minute, screenshotOCRService.ExtractPending},
+		// Mark users whose heartbeats stopped offline
+		{"presence.offline", cfg.Jobs.PresenceOfflineSchedule, time.Minute, presenceService.MarkStaleOffline},
+		// Delete presence history past its retention
+		{"presence.cleanup", cfg.Jobs.PresenceCleanupSchedule, 10 * time.Minute, presenceService.PurgeHistory},
+		// Move old screenshot files to cold storage
+		{"screenshots.cold_storage", cfg.Jobs.ColdStorageSchedule, 6 * time.Hour, screenshotTierService.TierOldScreenshots},
+		// Snapshot screenshot storage to the backup target
+		{"backup.uploads", backupSchedule, 6 * time.Hour, backupJob},
+		// Mark pending invitations past their expiry
+		{"invitations.expire", cfg.Jobs.InvitationExpirySchedule, 5 * time.Minute, func(ctx context.Context) error {
+			return invitationService.ExpireOldInvitations()
+		}},
+	}
+
+	for _, job := range jobs {
+		if job.spec == "" {
+			log.Printf("⏰ Job %s disabled", job.name)
+			continue
+		}
+		if err := s.Register(job.name, job.spec, job.timeout, job.fn); err != nil {
+			log.Fatalf("Failed to register job: %v", err)
+		}
+	}
+}
+
+// newUploadsBackup returns the screenshot storage snapshotter, or nil when
+// backups are not configured or misconfigured
+func newUploadsBackup(cfg *config.Config) *backup.Snapshotter {
+	if cfg.Backup.Target == "" {
+		return nil
+	}
+
+	snapshotter, err := backup.Open(cfg.Backup, filepath.Join(cfg.Upload.PrivatePath, "screenshots"))
+	if err != nil {
+		log.Printf("⚠️  Uploads backup disabled: %v", err)
+		return nil
+	}
+	return snapshotter
+}
+
+// newColdStore returns the screenshot cold storage, or nil when tiering is
+// not configured or misconfigured
+func newColdStore(cfg *config.Config) objectstore.Store {
+	if cfg.ColdStore.Target == "" {
+		return nil
+	}
+
+	store, err := objectstore.Open(cfg.ColdStore.Target, objectstore.S3Config{
+		Endpoint:     cfg.ColdStore.S3Endpoint,
+		Region:       cfg.ColdStore.S3Region,
+		AccessKey:    cfg.ColdStore.S3AccessKey,
+		SecretKey:    cfg.ColdStore.S3SecretKey,
+		StorageClass: cfg.ColdStore.StorageClass,
+	})
+	if err != nil {
+		log.Printf("⚠️  Screenshot cold storage disabled: %v", err)
+		return nil
+	}
+	return store
+}
+
+// newScanner returns the configured malware scanner, or nil when scanning is
+// disabled or misconfigured
+func newScanner(cfg *config.Config) scanner.Scanner {
+	fileScanner, err := scanner.New(scanner.Config{
+		Provider:      cfg.Scanner.Provider,
+		Timeout:       cfg.Scanner.Timeout,
+		ClamAVAddress: cfg.Scanner.ClamAVAddress,
+		APIURL:        cfg.Scanner.APIURL,
+		APIKey:        cfg.Scanner.APIKey,
+	})
+	if err != nil {
+		log.Printf("⚠️  Screenshot malware scanning disabled: %v", err)
+		return nil
+	}
+	return fileScanner
+}
+
+// newOCR returns the configured OCR extractor, or nil when OCR is disabled or
+// misconfigured
+func newOCR(cfg *config.Config) ocr.Extractor {
+	extractor, err := ocr.New(ocr.Config{
+		Provider:      cfg.OCR.Provider,
+		Timeout:       cfg.OCR.Timeout,
+		TesseractPath: cfg.OCR.TesseractPath,
+		Languages:     cfg.OCR.Languages,
+		APIURL:        cfg.OCR.APIURL,
+		APIKey:        cfg.OCR.APIKey,
+	})
+	if err != nil {
+		log.Printf("⚠️  Screenshot OCR disabled: %v", err)
+		return nil
+	}
+	return extractor
+}
+
+// newMailSender returns the configured email provider, or one that only logs
+// messages when the provider is misconfigured
+func newMailSender(cfg *config.Config) mail.Sender {
+	sender, err := mail.New(mail.Config{
+		Provider:     cfg.Email.Provider,
+		From:         cfg.Email.From,
+		FromName:     cfg.Email.FromName,
+		Timeout:      cfg.Email.Timeout,
+		SMTPHost:     cfg.Email.SMTPHost,
+		SMTPPort:     cfg.Email.SMTPPort,
+		SMTPUsername: cfg.Email.SMTPUsername,
+		SMTPPassword: cfg.Email.SMTPPassword,
+		SMTPTLS:      cfg.Email.SMTPTLS,
+		APIKey:       cfg.Email.SendGridAPIKey,
+	})
+	if err != nil {
+		log.Printf("⚠️  Email delivery disabled, logging messages instead: %v", err)
+		return mail.LogSender{}
+	}
+	return sender
+}
+
+// newRateProvider returns the configured exchange rate provider, or one that
+// only converts between equal currencies when it is misconfigured
+func newRateProvider(cfg *config.Config) money.RateProvider {
+	rates, err := money.NewRateProvider(money.FXConfig{
+		Provider:     cfg.FX.Provider,
+		BaseCurrency: cfg.FX.BaseCurrency,
+		Rates:        cfg.FX.Rates,
+		CacheTTL:     cfg.FX.CacheTTL,
+	})
+	if err != nil {
+		log.Printf("⚠️  Exchange rates disabled, reports only convert equal currencies: %v", err)
+		rates, _ = money.NewStaticRates(cfg.FX.BaseCurrency, "")
+	}
+	return rates
+}
+
+// newRedisCache connects to Redis, or returns nil when caching is disabled or
+// Redis is unreachable (the server then uses local caches or PostgreSQL)
+func newRedisCache(cfg *config.Config) cache.Cache {
+	if cfg.Cache.RedisURL == "" {
+		return nil
+	}
+
+	redisCache, err := cache.NewRedis(cfg.Cache.RedisURL, cfg.Cache.KeyPrefix)
+	if err != nil {
+		log.Printf("⚠️  Stats cache disabled: %v", err)
+		return nil
+	}
+
+	log.Printf("✅ Stats cache enabled (TTL %s)", cfg.Cache.StatsTTL)
+	return redisCache
+}
+
+// newRateLimiter returns a Redis-backed limiter shared by all instances when
+// Redis is configured, an in-memory one otherwise, or nil when disabled
+func newRateLimiter(cfg *config.Config) ratelimit.Limiter {
+	if !cfg.RateLimit.Enabled {
+		return nil
+	}
+
+	if cfg.Cache.RedisURL != "" {
+		limiter, err := ratelimit.NewRedis(cfg.Cache.RedisURL, cfg.Cache.KeyPrefix)
+		if err == nil {
+			log.Println("✅ Rate limiting enabled (Redis)")
+			return limiter
+		}
+		log.Printf("⚠️  Redis rate limiter unavailable, limiting per instance: %v", err)
+	}
+
+	log.Println("✅ Rate limiting enabled (in-memory)")
+	return ratelimit.NewMemory()
 }
@@ -0,0 +1,202 @@
+// Package apperror defines the typed errors handlers report to clients. Each
+// carries the HTTP status and a machine-readable code clients can branch on;
+// the message is meant for people and never exposes internal errors.
+package apperror
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/beuphecan/remote-time-tracker/internal/validation"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+// Error codes
+const (
+	CodeBadRequest      = "bad_request"
+	CodeValidation      = "validation_failed"
+	CodeUnauthorized    = "unauthorized"
+	CodeForbidden       = "forbidden"
+	CodeNotFound        = "not_found"
+	CodeConflict        = "conflict"
+	CodeGone            = "gone"
+	CodePayloadTooLarge = "payload_too_large"
+	CodeRateLimited     = "rate_limited"
+	CodeInternal        = "internal_error"
+	CodeUnavailable     = "service_unavailable"
+
+	CodeIdempotencyKeyReused = "idempotency_key_reused"
+	CodeVersionConflict      = "version_conflict"
+	CodeDeviceNotApproved    = "device_not_approved"
+	CodeUpgradeRequired      = "upgrade_required"
+	CodePeriodLocked         = "period_locked"
+)
+
+// InternalMessage replaces the message of internal errors sent to clients
+const InternalMessage = "An internal error occurred"
+
+// Error is an error with the status and code it is reported with
+type Error struct {
+	Status  int
+	Code    string
+	Message string
+	Details interface{} // Optional structured context, e.g. the invalid fields
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// FieldError describes one invalid request field
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// New creates an error with the code for its status
+func New(status int, message string) *Error {
+	return &Error{Status: status, Code: CodeForStatus(status), Message: message}
+}
+
+// NotFound creates a not_found error
+func NotFound(message string) *Error {
+	return New(http.StatusNotFound, message)
+}
+
+// Forbidden creates a forbidden error
+func Forbidden(message string) *Error {
+	return New(http.StatusForbidden, message)
+}
+
+// Unauthorized creates an unauthorized error
+func Unauthorized(message string) *Error {
+	return New(http.StatusUnauthorized, message)
+}
+
+// Conflict creates a conflict error
+func Conflict(message string) *Error {
+	return New(http.StatusConflict, message)
+}
+
+// Gone creates a gone error for something that existed but is no longer
+// available, such as an expired invitation
+func Gone(message string) *Error {
+	return New(http.StatusGone, message)
+}
+
+// Unavailable creates a service_unavailable error for a feature the server is
+// not configured for
+func Unavailable(message string) *Error {
+	return New(http.StatusServiceUnavailable, message)
+}
+
+// VersionConflict creates a version_conflict error for an update made against
+// an outdated version of a record; current is the record's current state
+func VersionConflict(current interface{}) *Error {
+	return &Error{
+		Status:  http.StatusConflict,
+		Code:    CodeVersionConflict,
+		Message: "This record was changed by someone else; review the current version and try again",
+		Details: current,
+	}
+}
+
+// Validation creates a validation_failed error; details may be nil
+func Validation(message string, details interface{}) *Error {
+	return &Error{Status: http.StatusBadRequest, Code: CodeValidation, Message: message, Details: details}
+}
+
+// Binding turns a request binding error into a validation error listing the
+// invalid fields
+func Binding(err error) *Error {
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return Validation("Invalid request: "+err.Error(), nil)
+	}
+
+	details := make([]FieldError, 0, len(fieldErrs))
+	for _, fe := range fieldErrs {
+		details = append(details, FieldError{
+			Field:   fe.Field(),
+			Rule:    fe.Tag(),
+			Message: fieldMessage(fe),
+		})
+	}
+	return Validation("Invalid request: check the highlighted fields", details)
+}
+
+func fieldMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return fe.Field() + " is required"
+	case "email":
+		return fe.Field() + " must be a valid email address"
+	case "oneof":
+		return fe.Field() + " must be one of: " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	case "min", "gte":
+		return fe.Field() + " must be at least " + fe.Param()
+	case "max", "lte":
+		return fe.Field() + " must be at most " + fe.Param()
+	case "hex_color", "hexcolor":
+		return fe.Field() + " must be a hex color such as #4F46E5"
+	case "slug":
+		return fe.Field() + " may only contain lowercase letters, digits and single hyphens"
+	case "flag_key":
+		return fe.Field() + " may only contain lowercase letters, digits and single underscores"
+	case "iso4217":
+		return fe.Field() + " must be an ISO 4217 currency code such as EUR"
+	case "iana_timezone":
+		return fe.Field() + " must be an IANA time zone such as Europe/Berlin"
+	case "role":
+		return fe.Field() + " must be one of: " + strings.Join(validation.Roles(fe.Param()), ", ")
+	default:
+		return fe.Field() + " is invalid"
+	}
+}
+
+// From maps any error to the error reported to clients. Typed errors keep
+// their status, code and details, with the full wrapped message; a missing
+// record is not_found; anything else is an internal error.
+func From(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return &Error{Status: appErr.Status, Code: appErr.Code, Message: err.Error(), Details: appErr.Details}
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return NotFound("Not found")
+	}
+	return New(http.StatusInternalServerError, InternalMessage)
+}
+
+// CodeForStatus returns the error code for an HTTP status
+func CodeForStatus(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return CodeBadRequest
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound, http.StatusMethodNotAllowed:
+		return CodeNotFound
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusGone:
+		return CodeGone
+	case http.StatusRequestEntityTooLarge:
+		return CodePayloadTooLarge
+	case http.StatusUnprocessableEntity:
+		return CodeValidation
+	case http.StatusTooManyRequests:
+		return CodeRateLimited
+	case http.StatusServiceUnavailable:
+		return CodeUnavailable
+	}
+	if status >= 500 {
+		return CodeInternal
+	}
+	return CodeBadRequest
+}
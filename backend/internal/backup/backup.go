@@ -0,0 +1,454 @@
+// Package backup takes incremental, encrypted snapshots of a directory (the
+// screenshot storage) into an object store and restores them.
+//
+// Layout in the store:
+//
+//	objects/<id>     file contents, encrypted; id is an HMAC of the plaintext
+//	                 so unchanged files are uploaded once across snapshots
+//	manifests/<id>   encrypted JSON listing every file of one snapshot
+package backup
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/config"
+	"github.com/beuphecan/remote-time-tracker/internal/objectstore"
+)
+
+const (
+	objectsPrefix   = "objects/"
+	manifestsPrefix = "manifests/"
+
+	// snapshotIDFormat sorts lexically in time order
+	snapshotIDFormat = "20060102T150405Z"
+)
+
+// magic prefixes every encrypted blob so the format can evolve
+var magic = []byte("RTB1")
+
+// ErrNoSnapshots is returned when the store holds no snapshot to restore
+var ErrNoSnapshots = errors.New("no snapshots found")
+
+// Manifest lists the files of one snapshot keyed by slash-separated path
+// relative to the backed up directory
+type Manifest struct {
+	ID        string               `json:"id"`
+	CreatedAt time.Time            `json:"created_at"`
+	Files     map[string]FileEntry `json:"files"`
+}
+
+// FileEntry describes one file in a snapshot
+type FileEntry struct {
+	Object  string    `json:"object"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+}
+
+// Stats summarizes a snapshot run
+type Stats struct {
+	SnapshotID      string
+	Files           int
+	Uploaded        int
+	UploadedBytes   int64
+	PrunedSnapshots int
+	PrunedObjects   int
+}
+
+// Snapshotter backs up a directory into a store
+type Snapshotter struct {
+	store objectstore.Store
+	root  string
+	aead  cipher.AEAD
+	idKey []byte
+	keep  int
+	nowFn func() time.Time
+}
+
+// ParseKey decodes a base64 encoded 32 byte encryption key
+func ParseKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("backup key is not valid base64: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("backup key must be 32 bytes, got %d", len(key))
+	}
+	return key, nil
+}
+
+// Open creates a snapshotter for root from the backup configuration
+func Open(cfg config.BackupConfig, root string) (*Snapshotter, error) {
+	if cfg.Target == "" {
+		return nil, errors.New("BACKUP_TARGET is not set")
+	}
+	key, err := ParseKey(cfg.EncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	store, err := objectstore.Open(cfg.Target, objectstore.S3Config{
+		Endpoint:  cfg.S3Endpoint,
+		Region:    cfg.S3Region,
+		AccessKey: cfg.S3AccessKey,
+		SecretKey: cfg.S3SecretKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return NewSnapshotter(store, root, key, cfg.Keep)
+}
+
+// NewSnapshotter creates a snapshotter for root. key encrypts everything
+// written to store; keep is the number of snapshots retained (0 keeps all).
+func NewSnapshotter(store objectstore.Store, root string, key []byte, keep int) (*Snapshotter, error) {
+	if len(key) != 32 {
+		return nil, errors.New("backup key must be 32 bytes")
+	}
+
+	// Separate subkeys for encryption and object ids
+	block, err := aes.NewCipher(deriveKey(key, "encrypt"))
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Snapshotter{
+		store: store,
+		root:  root,
+		aead:  aead,
+		idKey: deriveKey(key, "object-id"),
+		keep:  keep,
+		nowFn: time.Now,
+	}, nil
+}
+
+func deriveKey(key []byte, purpose string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(purpose))
+	return h.Sum(nil)
+}
+
+// Run takes a snapshot; it matches scheduler.JobFunc
+func (s *Snapshotter) Run(ctx context.Context) error {
+	stats, err := s.Snapshot(ctx)
+	if err != nil {
+		return err
+	}
+	log.Printf("✅ Uploads backup %s: %d files, %d uploaded (%d bytes), pruned %d snapshots and %d objects",
+		stats.SnapshotID, stats.Files, stats.Uploaded, stats.UploadedBytes, stats.PrunedSnapshots, stats.PrunedObjects)
+	return nil
+}
+
+// Snapshot uploads files that changed since the latest snapshot, writes a
+// new manifest and prunes snapshots beyond the retention count
+func (s *Snapshotter) Snapshot(ctx context.Context) (*Stats, error) {
+	previous, err := s.latestManifest(ctx)
+	if err != nil && !errors.Is(err, ErrNoSnapshots) {
+		return nil, err
+	}
+
+	existing, err := s.objectSet(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{
+		ID:        s.nowFn().UTC().Format(snapshotIDFormat),
+		CreatedAt: s.nowFn().UTC(),
+		Files:     make(map[string]FileEntry),
+	}
+	stats := &Stats{SnapshotID: manifest.ID}
+
+	err = filepath.WalkDir(s.root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		// Skip dotfiles and in-progress writes
+		if strings.HasPrefix(d.Name(), ".") && path != s.root {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(s.root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		entry := FileEntry{Size: info.Size(), ModTime: info.ModTime().UTC()}
+
+		// Unchanged size and mtime: reuse the object without reading the file
+		if previous != nil {
+			if prev, ok := previous.Files[rel]; ok && prev.Size == entry.Size && prev.ModTime.Equal(entry.ModTime) && existing[prev.Object] {
+				entry.Object = prev.Object
+				manifest.Files[rel] = entry
+				return nil
+			}
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			// Files can disappear mid-walk (retention, deletions)
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		entry.Object = s.objectID(data)
+		if !existing[entry.Object] {
+			if err := s.putEncrypted(ctx, objectsPrefix+entry.Object, data); err != nil {
+				return fmt.Errorf("failed to upload %s: %w", rel, err)
+			}
+			existing[entry.Object] = true
+			stats.Uploaded++
+			stats.UploadedBytes += int64(len(data))
+		}
+		manifest.Files[rel] = entry
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	stats.Files = len(manifest.Files)
+
+	body, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.putEncrypted(ctx, manifestsPrefix+manifest.ID, body); err != nil {
+		return nil, fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	if err := s.prune(ctx, stats); err != nil {
+		// The snapshot itself succeeded; leftovers are pruned next run
+		log.Printf("⚠️  Failed to prune uploads backups: %v", err)
+	}
+	return stats, nil
+}
+
+// Snapshots lists snapshot ids, oldest first
+func (s *Snapshotter) Snapshots(ctx context.Context) ([]string, error) {
+	objects, err := s.store.List(ctx, manifestsPrefix)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(objects))
+	for _, obj := range objects {
+		ids = append(ids, strings.TrimPrefix(obj.Key, manifestsPrefix))
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// LoadManifest reads a snapshot's manifest; id "latest" picks the newest
+func (s *Snapshotter) LoadManifest(ctx context.Context, id string) (*Manifest, error) {
+	if id == "" || id == "latest" {
+		return s.latestManifest(ctx)
+	}
+
+	data, err := s.getDecrypted(ctx, manifestsPrefix+id)
+	if err != nil {
+		return nil, err
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %w", id, err)
+	}
+	return &manifest, nil
+}
+
+// Restore writes the files of snapshot id into dest. Existing files are
+// kept unless overwrite is set. Returns the number of files written.
+func (s *Snapshotter) Restore(ctx context.Context, id, dest string, overwrite bool) (int, error) {
+	manifest, err := s.LoadManifest(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	paths := make([]string, 0, len(manifest.Files))
+	for rel := range manifest.Files {
+		paths = append(paths, rel)
+	}
+	sort.Strings(paths)
+
+	restored := 0
+	for _, rel := range paths {
+		if err := ctx.Err(); err != nil {
+			return restored, err
+		}
+
+		if !filepath.IsLocal(filepath.FromSlash(rel)) {
+			return restored, fmt.Errorf("manifest path %q escapes destination", rel)
+		}
+		target := filepath.Join(dest, filepath.FromSlash(rel))
+		if !overwrite {
+			if _, err := os.Stat(target); err == nil {
+				continue
+			}
+		}
+
+		entry := manifest.Files[rel]
+		data, err := s.getDecrypted(ctx, objectsPrefix+entry.Object)
+		if err != nil {
+			return restored, fmt.Errorf("failed to restore %s: %w", rel, err)
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return restored, err
+		}
+		if err := os.WriteFile(target, data, 0644); err != nil {
+			return restored, err
+		}
+		_ = os.Chtimes(target, entry.ModTime, entry.ModTime)
+		restored++
+	}
+	return restored, nil
+}
+
+func (s *Snapshotter) latestManifest(ctx context.Context) (*Manifest, error) {
+	ids, err := s.Snapshots(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, ErrNoSnapshots
+	}
+	return s.LoadManifest(ctx, ids[len(ids)-1])
+}
+
+// objectSet lists the ids of every stored object
+func (s *Snapshotter) objectSet(ctx context.Context) (map[string]bool, error) {
+	objects, err := s.store.List(ctx, objectsPrefix)
+	if err != nil {
+		return nil, err
+	}
+	set := make(map[string]bool, len(objects))
+	for _, obj := range objects {
+		set[strings.TrimPrefix(obj.Key, objectsPrefix)] = true
+	}
+	return set, nil
+}
+
+// prune deletes snapshots beyond the retention count, then every object no
+// remaining snapshot references
+func (s *Snapshotter) prune(ctx context.Context, stats *Stats) error {
+	if s.keep <= 0 {
+		return nil
+	}
+
+	ids, err := s.Snapshots(ctx)
+	if err != nil {
+		return err
+	}
+	if len(ids) <= s.keep {
+		return nil
+	}
+
+	for _, id := range ids[:len(ids)-s.keep] {
+		if err := s.store.Delete(ctx, manifestsPrefix+id); err != nil {
+			return err
+		}
+		stats.PrunedSnapshots++
+	}
+
+	referenced := make(map[string]bool)
+	for _, id := range ids[len(ids)-s.keep:] {
+		manifest, err := s.LoadManifest(ctx, id)
+		if err != nil {
+			return err
+		}
+		for _, entry := range manifest.Files {
+			referenced[entry.Object] = true
+		}
+	}
+
+	existing, err := s.objectSet(ctx)
+	if err != nil {
+		return err
+	}
+	for object := range existing {
+		if referenced[object] {
+			continue
+		}
+		if err := s.store.Delete(ctx, objectsPrefix+object); err != nil {
+			return err
+		}
+		stats.PrunedObjects++
+	}
+	return nil
+}
+
+// objectID identifies content without revealing its hash to the store
+func (s *Snapshotter) objectID(data []byte) string {
+	h := hmac.New(sha256.New, s.idKey)
+	h.Write(data)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// putEncrypted stores magic || nonce || AES-GCM(data), binding the
+// ciphertext to its key so objects cannot be swapped
+func (s *Snapshotter) putEncrypted(ctx context.Context, key string, data []byte) error {
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	out := make([]byte, 0, len(magic)+len(nonce)+len(data)+s.aead.Overhead())
+	out = append(out, magic...)
+	out = append(out, nonce...)
+	out = s.aead.Seal(out, nonce, data, []byte(key))
+	return s.store.Put(ctx, key, out)
+}
+
+func (s *Snapshotter) getDecrypted(ctx context.Context, key string) ([]byte, error) {
+	r, err := s.store.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	blob, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	header := len(magic) + s.aead.NonceSize()
+	if len(blob) < header || string(blob[:len(magic)]) != string(magic) {
+		return nil, fmt.Errorf("%s is not a backup object", key)
+	}
+	data, err := s.aead.Open(nil, blob[len(magic):header], blob[header:], []byte(key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt %s (wrong key?)", key)
+	}
+	return data, nil
+}
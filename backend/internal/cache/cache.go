@@ -0,0 +1,19 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrMiss is returned by Get when the key is not cached
+var ErrMiss = errors.New("cache miss")
+
+// Cache stores JSON-encodable values with a TTL
+type Cache interface {
+	// Get decodes the cached value into dest, or returns ErrMiss
+	Get(ctx context.Context, key string, dest interface{}) error
+	Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+	Close() error
+}
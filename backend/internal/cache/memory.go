@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// memoryCleanupInterval is how often expired entries are dropped
+const memoryCleanupInterval = 5 * time.Minute
+
+type memoryEntry struct {
+	data    []byte
+	expires time.Time
+}
+
+// Memory is a Cache for a single server instance. Values are stored as JSON,
+// like in Redis, so callers get a fresh copy they can modify.
+type Memory struct {
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+	done    chan struct{}
+}
+
+// NewMemory creates an in-memory cache
+func NewMemory() *Memory {
+	m := &Memory{entries: make(map[string]memoryEntry), done: make(chan struct{})}
+
+	// Drop expired entries periodically
+	go m.cleanup()
+
+	return m
+}
+
+func (m *Memory) cleanup() {
+	ticker := time.NewTicker(memoryCleanupInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.done:
+			return
+		case now := <-ticker.C:
+			m.mu.Lock()
+			for key, entry := range m.entries {
+				if now.After(entry.expires) {
+					delete(m.entries, key)
+				}
+			}
+			m.mu.Unlock()
+		}
+	}
+}
+
+func (m *Memory) Get(ctx context.Context, key string, dest interface{}) error {
+	m.mu.Lock()
+	entry, ok := m.entries[key]
+	m.mu.Unlock()
+	if !ok || time.Now().After(entry.expires) {
+		return ErrMiss
+	}
+	return json.Unmarshal(entry.data, dest)
+}
+
+func (m *Memory) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	m.entries[key] = memoryEntry{data: data, expires: time.Now().Add(ttl)}
+	m.mu.Unlock()
+	return nil
+}
+
+func (m *Memory) Delete(ctx context.Context, keys ...string) error {
+	m.mu.Lock()
+	for _, key := range keys {
+		delete(m.entries, key)
+	}
+	m.mu.Unlock()
+	return nil
+}
+
+func (m *Memory) Close() error {
+	close(m.done)
+	return nil
+}
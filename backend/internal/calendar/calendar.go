@@ -0,0 +1,258 @@
+package calendar
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Reporting periods
+const (
+	PeriodDay     = "day"
+	PeriodWeek    = "week"
+	PeriodMonth   = "month"
+	PeriodQuarter = "quarter" // fiscal quarter
+	PeriodYear    = "year"    // fiscal year
+)
+
+// DefaultWorkingDays is Monday through Friday
+var DefaultWorkingDays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+
+// Calendar describes an organization's working week and fiscal year.
+// It is the single place reports, trend grouping, timesheet periods and
+// payroll exports should ask for period boundaries.
+type Calendar struct {
+	WorkingDays          []time.Weekday
+	WeekStart            time.Weekday
+	FiscalYearStartMonth time.Month
+	Location             *time.Location
+	Holidays             map[string]bool // YYYY-MM-DD dates that are not working days
+}
+
+// Default returns the calendar used when an organization has no settings:
+// Monday-Friday working week starting on Monday, fiscal year = calendar year, UTC
+func Default() Calendar {
+	return Calendar{
+		WorkingDays:          DefaultWorkingDays,
+		WeekStart:            time.Monday,
+		FiscalYearStartMonth: time.January,
+		Location:             time.UTC,
+	}
+}
+
+// New builds a calendar from stored organization settings, falling back to
+// defaults for anything invalid
+func New(workingDays string, weekStart, fiscalYearStartMonth int) Calendar {
+	cal := Default()
+
+	if days, err := ParseWorkingDays(workingDays); err == nil && len(days) > 0 {
+		cal.WorkingDays = days
+	}
+	if weekStart >= 0 && weekStart <= 6 {
+		cal.WeekStart = time.Weekday(weekStart)
+	}
+	if fiscalYearStartMonth >= 1 && fiscalYearStartMonth <= 12 {
+		cal.FiscalYearStartMonth = time.Month(fiscalYearStartMonth)
+	}
+
+	return cal
+}
+
+// ParseWorkingDays parses a comma-separated weekday list (0=Sunday ... 6=Saturday)
+func ParseWorkingDays(s string) ([]time.Weekday, error) {
+	var nums []int
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid weekday %q: must be 0 (Sunday) to 6 (Saturday)", part)
+		}
+		nums = append(nums, n)
+	}
+	return WeekdaysFromInts(nums)
+}
+
+// WeekdaysFromInts validates, de-duplicates and sorts weekday numbers
+func WeekdaysFromInts(nums []int) ([]time.Weekday, error) {
+	seen := make(map[time.Weekday]bool)
+	var days []time.Weekday
+
+	for _, n := range nums {
+		if n < 0 || n > 6 {
+			return nil, fmt.Errorf("invalid weekday %d: must be 0 (Sunday) to 6 (Saturday)", n)
+		}
+		day := time.Weekday(n)
+		if !seen[day] {
+			seen[day] = true
+			days = append(days, day)
+		}
+	}
+
+	sort.Slice(days, func(i, j int) bool { return days[i] < days[j] })
+	return days, nil
+}
+
+// FormatWorkingDays serializes weekdays into the stored comma-separated form
+func FormatWorkingDays(days []time.Weekday) string {
+	parts := make([]string, 0, len(days))
+	for _, d := range days {
+		parts = append(parts, strconv.Itoa(int(d)))
+	}
+	return strings.Join(parts, ",")
+}
+
+// ValidatePeriod checks that a period name is supported
+func ValidatePeriod(period string) error {
+	switch period {
+	case PeriodDay, PeriodWeek, PeriodMonth, PeriodQuarter, PeriodYear:
+		return nil
+	default:
+		return errors.New("invalid period: must be day, week, month, quarter or year")
+	}
+}
+
+func (c Calendar) loc() *time.Location {
+	if c.Location == nil {
+		return time.UTC
+	}
+	return c.Location
+}
+
+func (c Calendar) startOfDay(t time.Time) time.Time {
+	t = t.In(c.loc())
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, c.loc())
+}
+
+// WithHolidays returns a copy of the calendar with the dates added as holidays
+func (c Calendar) WithHolidays(dates []time.Time) Calendar {
+	holidays := make(map[string]bool, len(c.Holidays)+len(dates))
+	for date := range c.Holidays {
+		holidays[date] = true
+	}
+	for _, d := range dates {
+		holidays[d.Format("2006-01-02")] = true
+	}
+	c.Holidays = holidays
+	return c
+}
+
+// IsHoliday reports whether t falls on one of the calendar's holidays
+func (c Calendar) IsHoliday(t time.Time) bool {
+	return c.Holidays[t.In(c.loc()).Format("2006-01-02")]
+}
+
+// IsWorkingDay reports whether t falls on one of the configured working days
+// and is not a holiday
+func (c Calendar) IsWorkingDay(t time.Time) bool {
+	if c.IsHoliday(t) {
+		return false
+	}
+	wd := t.In(c.loc()).Weekday()
+	for _, d := range c.WorkingDays {
+		if d == wd {
+			return true
+		}
+	}
+	return false
+}
+
+// WorkingDaysBetween counts working days in the half-open range [start, end)
+func (c Calendar) WorkingDaysBetween(start, end time.Time) int {
+	count := 0
+	for d := c.startOfDay(start); d.Before(end); d = d.AddDate(0, 0, 1) {
+		if c.IsWorkingDay(d) {
+			count++
+		}
+	}
+	return count
+}
+
+// StartOfWeek returns the first day of the week containing t
+func (c Calendar) StartOfWeek(t time.Time) time.Time {
+	day := c.startOfDay(t)
+	offset := (int(day.Weekday()) - int(c.WeekStart) + 7) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// FiscalYear returns the fiscal year t belongs to, named after the calendar
+// year in which it ends (e.g. Apr 2025 - Mar 2026 is FY2026)
+func (c Calendar) FiscalYear(t time.Time) int {
+	t = t.In(c.loc())
+	if c.FiscalYearStartMonth == time.January || t.Month() < c.FiscalYearStartMonth {
+		return t.Year()
+	}
+	return t.Year() + 1
+}
+
+// StartOfFiscalYear returns the first day of the fiscal year containing t
+func (c Calendar) StartOfFiscalYear(t time.Time) time.Time {
+	t = t.In(c.loc())
+	year := t.Year()
+	if t.Month() < c.FiscalYearStartMonth {
+		year--
+	}
+	return time.Date(year, c.FiscalYearStartMonth, 1, 0, 0, 0, 0, c.loc())
+}
+
+// FiscalQuarter returns the fiscal quarter (1-4) containing t
+func (c Calendar) FiscalQuarter(t time.Time) int {
+	t = t.In(c.loc())
+	months := (int(t.Month()) - int(c.FiscalYearStartMonth) + 12) % 12
+	return months/3 + 1
+}
+
+// PeriodStart returns the start of the period containing t
+func (c Calendar) PeriodStart(period string, t time.Time) time.Time {
+	switch period {
+	case PeriodWeek:
+		return c.StartOfWeek(t)
+	case PeriodMonth:
+		t = t.In(c.loc())
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, c.loc())
+	case PeriodQuarter:
+		fyStart := c.StartOfFiscalYear(t)
+		return fyStart.AddDate(0, (c.FiscalQuarter(t)-1)*3, 0)
+	case PeriodYear:
+		return c.StartOfFiscalYear(t)
+	default:
+		return c.startOfDay(t)
+	}
+}
+
+// PeriodRange returns the half-open range [start, end) of the period containing t
+func (c Calendar) PeriodRange(period string, t time.Time) (time.Time, time.Time) {
+	start := c.PeriodStart(period, t)
+	switch period {
+	case PeriodWeek:
+		return start, start.AddDate(0, 0, 7)
+	case PeriodMonth:
+		return start, start.AddDate(0, 1, 0)
+	case PeriodQuarter:
+		return start, start.AddDate(0, 3, 0)
+	case PeriodYear:
+		return start, start.AddDate(1, 0, 0)
+	default:
+		return start, start.AddDate(0, 0, 1)
+	}
+}
+
+// PeriodLabel returns a stable label for the period containing t, e.g.
+// "2025-03-10" (day/week start), "2025-03" (month), "FY2026-Q1", "FY2026"
+func (c Calendar) PeriodLabel(period string, t time.Time) string {
+	switch period {
+	case PeriodMonth:
+		return c.PeriodStart(period, t).Format("2006-01")
+	case PeriodQuarter:
+		return fmt.Sprintf("FY%d-Q%d", c.FiscalYear(t), c.FiscalQuarter(t))
+	case PeriodYear:
+		return fmt.Sprintf("FY%d", c.FiscalYear(t))
+	default:
+		return c.PeriodStart(period, t).Format("2006-01-02")
+	}
+}
@@ -0,0 +1,146 @@
+package calendar
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrUnknownHolidayPreset is returned for countries without a holiday preset
+var ErrUnknownHolidayPreset = errors.New("unknown holiday preset: use one of DE, GB, US, VN")
+
+// Holiday is a public holiday on a date
+type Holiday struct {
+	Date time.Time // Midnight UTC
+	Name string
+}
+
+// holidayPreset builds a country's public holidays for a year. Presets cover
+// nationwide holidays on their official date; substitute days for holidays
+// falling on a weekend and regional holidays are added as custom dates.
+type holidayPreset struct {
+	name     string
+	holidays func(year int) []Holiday
+}
+
+var holidayPresets = map[string]holidayPreset{
+	"DE": {"Germany", germanHolidays},
+	"GB": {"United Kingdom (England and Wales)", britishHolidays},
+	"US": {"United States (federal)", usHolidays},
+	"VN": {"Vietnam (solar calendar holidays)", vietnameseHolidays},
+}
+
+// HolidayPresetCountries returns the country codes with a holiday preset and their names
+func HolidayPresetCountries() map[string]string {
+	countries := make(map[string]string, len(holidayPresets))
+	for code, preset := range holidayPresets {
+		countries[code] = preset.name
+	}
+	return countries
+}
+
+// PresetHolidays returns a country's public holidays for a year, by date
+func PresetHolidays(country string, year int) ([]Holiday, error) {
+	preset, ok := holidayPresets[strings.ToUpper(country)]
+	if !ok {
+		return nil, ErrUnknownHolidayPreset
+	}
+
+	holidays := preset.holidays(year)
+	sort.Slice(holidays, func(i, j int) bool { return holidays[i].Date.Before(holidays[j].Date) })
+	return holidays, nil
+}
+
+func germanHolidays(year int) []Holiday {
+	easter := easterSunday(year)
+	return []Holiday{
+		{date(year, time.January, 1), "Neujahr"},
+		{easter.AddDate(0, 0, -2), "Karfreitag"},
+		{easter.AddDate(0, 0, 1), "Ostermontag"},
+		{date(year, time.May, 1), "Tag der Arbeit"},
+		{easter.AddDate(0, 0, 39), "Christi Himmelfahrt"},
+		{easter.AddDate(0, 0, 50), "Pfingstmontag"},
+		{date(year, time.October, 3), "Tag der Deutschen Einheit"},
+		{date(year, time.December, 25), "1. Weihnachtstag"},
+		{date(year, time.December, 26), "2. Weihnachtstag"},
+	}
+}
+
+func britishHolidays(year int) []Holiday {
+	easter := easterSunday(year)
+	return []Holiday{
+		{date(year, time.January, 1), "New Year's Day"},
+		{easter.AddDate(0, 0, -2), "Good Friday"},
+		{easter.AddDate(0, 0, 1), "Easter Monday"},
+		{nthWeekday(year, time.May, time.Monday, 1), "Early May bank holiday"},
+		{lastWeekday(year, time.May, time.Monday), "Spring bank holiday"},
+		{lastWeekday(year, time.August, time.Monday), "Summer bank holiday"},
+		{date(year, time.December, 25), "Christmas Day"},
+		{date(year, time.December, 26), "Boxing Day"},
+	}
+}
+
+func usHolidays(year int) []Holiday {
+	return []Holiday{
+		{date(year, time.January, 1), "New Year's Day"},
+		{nthWeekday(year, time.January, time.Monday, 3), "Martin Luther King Jr. Day"},
+		{nthWeekday(year, time.February, time.Monday, 3), "Washington's Birthday"},
+		{lastWeekday(year, time.May, time.Monday), "Memorial Day"},
+		{date(year, time.June, 19), "Juneteenth"},
+		{date(year, time.July, 4), "Independence Day"},
+		{nthWeekday(year, time.September, time.Monday, 1), "Labor Day"},
+		{nthWeekday(year, time.October, time.Monday, 2), "Columbus Day"},
+		{date(year, time.November, 11), "Veterans Day"},
+		{nthWeekday(year, time.November, time.Thursday, 4), "Thanksgiving Day"},
+		{date(year, time.December, 25), "Christmas Day"},
+	}
+}
+
+// vietnameseHolidays are the solar calendar holidays; Tết and the Hùng Kings'
+// festival follow the lunar calendar and are added as custom dates
+func vietnameseHolidays(year int) []Holiday {
+	return []Holiday{
+		{date(year, time.January, 1), "Tết Dương lịch"},
+		{date(year, time.April, 30), "Ngày Giải phóng miền Nam"},
+		{date(year, time.May, 1), "Ngày Quốc tế Lao động"},
+		{date(year, time.September, 2), "Quốc khánh"},
+	}
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// nthWeekday returns the n-th (1-based) weekday of a month
+func nthWeekday(year int, month time.Month, weekday time.Weekday, n int) time.Time {
+	first := date(year, month, 1)
+	offset := (int(weekday) - int(first.Weekday()) + 7) % 7
+	return first.AddDate(0, 0, offset+(n-1)*7)
+}
+
+// lastWeekday returns the last weekday of a month
+func lastWeekday(year int, month time.Month, weekday time.Weekday) time.Time {
+	last := date(year, month+1, 0)
+	offset := (int(last.Weekday()) - int(weekday) + 7) % 7
+	return last.AddDate(0, 0, -offset)
+}
+
+// easterSunday returns Western Easter Sunday (anonymous Gregorian algorithm)
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return date(year, time.Month(month), day)
+}
@@ -13,14 +13,38 @@ import (
 
 // Config holds all configuration for the application
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
-	Upload   UploadConfig
-	CORS     CORSConfig
-	Log      LogConfig
-	GitHub   GitHubConfig
-	Presence PresenceConfig
+	Server       ServerConfig
+	Database     DatabaseConfig
+	JWT          JWTConfig
+	Upload       UploadConfig
+	CORS         CORSConfig
+	Log          LogConfig
+	GitHub       GitHubConfig
+	Presence     PresenceConfig
+	Privacy      PrivacyConfig
+	Jobs         JobsConfig
+	DeviceLog    DeviceLogConfig
+	Cache        CacheConfig
+	Telemetry    TelemetryConfig
+	Webhook      WebhookConfig
+	Sync         SyncConfig
+	Timer        TimerConfig
+	RateLimit    RateLimitConfig
+	Captcha      CaptchaConfig
+	Backup       BackupConfig
+	Purge        PurgeConfig
+	ColdStore    ColdStorageConfig
+	Scanner      ScannerConfig
+	OCR          OCRConfig
+	Jira         JiraConfig
+	Slack        SlackConfig
+	Google       GoogleConfig
+	Email        EmailConfig
+	Notification NotificationConfig
+	Overtime     OvertimeConfig
+	API          APIConfig
+	Idempotency  IdempotencyConfig
+	FX           FXConfig
 }
 
 // GitHubConfig holds GitHub API configuration for auto-updates
@@ -32,9 +56,14 @@ type GitHubConfig struct {
 
 // ServerConfig holds server-related configuration
 type ServerConfig struct {
-	Port string
-	Host string
-	Env  string
+	Port            string
+	Host            string
+	Env             string
+	ShutdownTimeout time.Duration // Maximum time to wait for in-flight requests on shutdown
+	DrainDelay      time.Duration // Time readiness fails before the server stops accepting requests
+	// Proxies (IPs or CIDRs) whose X-Forwarded-For is believed for the client
+	// IP that rate limits and audit logs use; none by default
+	TrustedProxies []string
 }
 
 // DatabaseConfig holds database-related configuration
@@ -50,16 +79,25 @@ type DatabaseConfig struct {
 
 // JWTConfig holds JWT-related configuration
 type JWTConfig struct {
-	Secret        string
-	Expiry        time.Duration
-	RefreshExpiry time.Duration
+	Secret              string
+	Expiry              time.Duration
+	RefreshExpiry       time.Duration
+	ImpersonationExpiry time.Duration // Lifetime of admin impersonation tokens
 }
 
 // UploadConfig holds file upload configuration
 type UploadConfig struct {
-	Path             string
+	Path string // Legacy public upload directory, emptied into PrivatePath at startup
+	// Screenshots, exports, logs and attachments; only served by authorized
+	// endpoints. It must not be inside Path.
+	PrivatePath      string
 	MaxSize          int64
 	AllowedFileTypes []string
+	// Synced screenshots larger than this are rejected
+	MaxScreenshotWidth  int
+	MaxScreenshotHeight int
+	// File extensions task attachments may have
+	AttachmentTypes []string
 }
 
 // CORSConfig holds CORS configuration
@@ -76,7 +114,244 @@ type LogConfig struct {
 // PresenceConfig holds presence/heartbeat configuration
 type PresenceConfig struct {
 	HeartbeatInterval time.Duration
-	StaleAfter        time.Duration
+	StaleAfter        time.Duration // Users without a heartbeat for this long are marked offline
+	HistoryRetention  time.Duration // How long presence history intervals are kept
+}
+
+// PrivacyConfig holds personal data export and erasure configuration
+type PrivacyConfig struct {
+	ErasureGracePeriod time.Duration // Delay before a requested account erasure is executed
+	ExportRetention    time.Duration // How long generated export archives can be downloaded
+	ExportLinkTTL      time.Duration // Lifetime of signed organization export download links
+
+	OrgDeletionGracePeriod time.Duration // Delay before a deleted organization and its data are purged
+}
+
+// DeviceLogConfig holds limits for desktop app log bundle uploads
+type DeviceLogConfig struct {
+	MaxSize      int64         // Maximum bundle size in bytes
+	Retention    time.Duration // How long bundles are kept
+	MaxPerDevice int           // Older bundles beyond this are deleted on upload
+}
+
+// CacheConfig holds the optional Redis cache configuration
+type CacheConfig struct {
+	RedisURL  string        // Empty disables caching
+	KeyPrefix string        // Namespace for keys on a shared server
+	StatsTTL  time.Duration // Lifetime of cached organization and workspace statistics
+
+	// Lifetime of each cached admin dashboard metric. Without Redis these
+	// are cached in memory.
+	OverviewStatsTTL        time.Duration
+	TrendStatsTTL           time.Duration
+	UserPerformanceStatsTTL time.Duration
+	OrgDistributionStatsTTL time.Duration
+	ActivityStatsTTL        time.Duration
+
+	// Lifetime of cached organization settings; updates drop them at once
+	OrgSettingsTTL time.Duration
+}
+
+// TelemetryConfig holds crash telemetry and feature usage configuration
+type TelemetryConfig struct {
+	Retention time.Duration // How long crash reports and usage events are kept
+	RateLimit int           // Uploads per minute per client IP
+}
+
+// WebhookConfig holds outgoing organization webhook configuration
+type WebhookConfig struct {
+	Timeout           time.Duration // Per-request timeout
+	MaxAttempts       int           // Deliveries are marked failed after this many attempts
+	DeliveryRetention time.Duration // How long delivery history is kept (and can be replayed)
+	AllowHTTP         bool          // Allow plain http:// endpoints (development only)
+	AllowPrivateHosts bool          // Allow receivers on loopback and private networks (development only)
+}
+
+// JiraConfig holds the workspace Jira integration settings
+type JiraConfig struct {
+	Timeout   time.Duration // Per-request timeout for Jira API calls
+	AllowHTTP bool          // Allow plain http:// Jira sites (development only)
+}
+
+// SlackConfig holds the Slack app used to install organization notifications
+// with OAuth. Incoming webhook URLs can be configured without it.
+type SlackConfig struct {
+	ClientID     string // Empty disables the OAuth install
+	ClientSecret string
+	RedirectURL  string // Frontend page Slack redirects back to with the code and state
+	Timeout      time.Duration
+}
+
+// GoogleConfig holds the Google OAuth client users connect Google Calendar with
+type GoogleConfig struct {
+	ClientID     string // Empty disables Google Calendar push
+	ClientSecret string
+	RedirectURL  string // Frontend page Google redirects back to with the code and state
+	Timeout      time.Duration
+}
+
+// EmailConfig holds outgoing email settings
+type EmailConfig struct {
+	Provider         string // log (development), smtp or sendgrid
+	From             string
+	FromName         string
+	SMTPHost         string
+	SMTPPort         string
+	SMTPUsername     string
+	SMTPPassword     string
+	SMTPTLS          string // starttls, tls or none
+	SendGridAPIKey   string
+	Timeout          time.Duration
+	AppURL           string        // Frontend base URL used in links
+	MaxAttempts      int           // Emails are marked failed after this many attempts
+	Retention        time.Duration // How long sent and failed emails are kept in the outbox
+	PasswordResetTTL time.Duration
+}
+
+// NotificationConfig holds in-app notification settings
+type NotificationConfig struct {
+	Retention time.Duration // How long notifications are kept, read or not
+}
+
+// OvertimeConfig holds overtime detection settings
+type OvertimeConfig struct {
+	MinMinutes     int  // Overtime below this in a day or week is not recorded
+	NotifyManagers bool // Notify workspace admins the first time a day or week has overtime
+}
+
+// APIConfig holds the API version lifecycle. Once deprecated, /api/v1
+// responses carry Deprecation and Sunset headers pointing clients at v2.
+type APIConfig struct {
+	V1DeprecatedAt *time.Time // Nil while v1 is supported
+	V1SunsetAt     *time.Time // Announced date v1 stops being served
+}
+
+// IdempotencyConfig holds Idempotency-Key handling for POST requests
+type IdempotencyConfig struct {
+	KeyTTL time.Duration // How long a key's response is replayed to retries
+}
+
+// FXConfig holds the exchange rate provider reports convert amounts with
+type FXConfig struct {
+	Provider     string        // static or ecb
+	BaseCurrency string        // Currency static rates are quoted against
+	Rates        string        // Static rates, e.g. "EUR=0.92,GBP=0.79"
+	CacheTTL     time.Duration // How long fetched rates are reused
+}
+
+// SyncConfig holds desktop sync configuration
+type SyncConfig struct {
+	ConflictPolicy     string        // last_write_wins, server_wins or manual
+	TransactionMode    string        // item or batch
+	ConfigPollInterval time.Duration // How often the desktop app checks its configuration document for changes
+}
+
+// TimerConfig holds time tracking session rules
+type TimerConfig struct {
+	ConcurrencyPolicy string // stop or reject another running timer when one starts
+}
+
+// RateLimitConfig holds request rate limits for auth and sync endpoints.
+// Limits are requests per minute with a token bucket burst; 0 disables one.
+type RateLimitConfig struct {
+	Enabled       bool
+	AuthPerIP     int // Login, register and refresh requests per client IP
+	AuthBurst     int
+	SyncPerUser   int // Sync requests per user
+	SyncPerIP     int // Sync requests per client IP (several users may share one)
+	SyncBurst     int
+	InvitePerIP   int // Invite code previews per client IP
+	InviteBurst   int
+	ExemptUserIDs []string // Users never rate limited
+	ExemptIPs     []string // IPs or CIDR ranges never rate limited
+}
+
+// CaptchaConfig holds CAPTCHA verification for public endpoints. Any
+// siteverify-compatible provider works (hCaptcha, reCAPTCHA, Turnstile).
+type CaptchaConfig struct {
+	VerifyURL     string // Empty disables CAPTCHA
+	Secret        string
+	InvitePreview bool // Require a CAPTCHA token to preview invite codes
+}
+
+// BackupConfig holds the encrypted screenshot storage backup settings
+type BackupConfig struct {
+	Target        string // s3://bucket/prefix or a directory; empty disables backups
+	S3Endpoint    string // Defaults to AWS for the region; set for MinIO etc.
+	S3Region      string
+	S3AccessKey   string
+	S3SecretKey   string
+	EncryptionKey string // Base64 encoded 32 byte key
+	Keep          int    // Number of snapshots retained (0 keeps all)
+}
+
+// ColdStorageConfig holds the screenshot storage tiering settings
+type ColdStorageConfig struct {
+	Target       string // s3://bucket/prefix or a directory; empty disables tiering
+	S3Endpoint   string
+	S3Region     string
+	S3AccessKey  string
+	S3SecretKey  string
+	StorageClass string // e.g. STANDARD_IA, GLACIER or DEEP_ARCHIVE
+	AfterDays    int    // Screenshots captured longer ago move to cold storage
+	RestoreDays  int    // How long an archive restore stays readable
+}
+
+// ScannerConfig holds the malware scanning of uploaded screenshots
+type ScannerConfig struct {
+	Provider      string        // none, clamav or http
+	Timeout       time.Duration // Per-file scan timeout
+	ClamAVAddress string        // clamd TCP address, host:port
+	APIURL        string        // External scanning API the file is POSTed to
+	APIKey        string
+}
+
+// OCRConfig holds the text extraction that makes screenshots searchable
+type OCRConfig struct {
+	Provider      string        // none, tesseract or http
+	Timeout       time.Duration // Per-screenshot extraction timeout
+	TesseractPath string
+	Languages     string // tesseract languages, e.g. eng+vie
+	APIURL        string // External OCR API the image is POSTed to
+	APIKey        string
+}
+
+// PurgeConfig holds the soft-deleted data purge settings
+type PurgeConfig struct {
+	OlderThanDays int // Rows soft-deleted longer ago are purged (0 disables the job)
+}
+
+// JobsConfig holds cron schedules for background jobs (empty disables a job)
+type JobsConfig struct {
+	PrivacyErasureSchedule      string
+	ExportCleanupSchedule       string
+	DeviceLogCleanupSchedule    string
+	TelemetryCleanupSchedule    string
+	ScreenshotRetentionSchedule string
+	InvitationExpirySchedule    string
+	WebhookRetrySchedule        string
+	WebhookCleanupSchedule      string
+	UploadsBackupSchedule       string
+	SoftDeletePurgeSchedule     string
+	ColdStorageSchedule         string
+	OperationCleanupSchedule    string
+	JiraSyncSchedule            string
+	SlackDailySummarySchedule   string
+	GoogleCalendarPushSchedule  string
+	EmailDeliverySchedule       string
+	EmailCleanupSchedule        string
+	WeeklySummarySchedule       string
+	NotificationCleanupSchedule string
+	BudgetRollupSchedule        string
+	OvertimeDetectionSchedule   string
+	SavedReportDeliverySchedule string
+	IdempotencyCleanupSchedule  string
+	OrgDeletionSchedule         string
+	StatsRollupSchedule         string
+	ScreenshotScanSchedule      string
+	ScreenshotOCRSchedule       string
+	PresenceOfflineSchedule     string
+	PresenceCleanupSchedule     string
 }
 
 var AppConfig *Config
@@ -90,9 +365,12 @@ func Load() (*Config, error) {
 
 	config := &Config{
 		Server: ServerConfig{
-			Port: getEnv("PORT", "8080"),
-			Host: getEnv("HOST", "0.0.0.0"),
-			Env:  getEnv("ENV", "development"),
+			Port:            getEnv("PORT", "8080"),
+			Host:            getEnv("HOST", "0.0.0.0"),
+			Env:             getEnv("ENV", "development"),
+			ShutdownTimeout: parseDuration(getEnv("SHUTDOWN_TIMEOUT", "30s")),
+			DrainDelay:      parseDuration(getEnv("SHUTDOWN_DRAIN_DELAY", "5s")),
+			TrustedProxies:  parseList(getEnv("TRUSTED_PROXIES", "")),
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -104,14 +382,19 @@ func Load() (*Config, error) {
 			TimeZone: getEnv("DB_TIMEZONE", "UTC"),
 		},
 		JWT: JWTConfig{
-			Secret:        getEnv("JWT_SECRET", "change-this-secret"),
-			Expiry:        parseDuration(getEnv("JWT_EXPIRY", "24h")),
-			RefreshExpiry: parseDuration(getEnv("JWT_REFRESH_EXPIRY", "168h")),
+			Secret:              getEnv("JWT_SECRET", "change-this-secret"),
+			Expiry:              parseDuration(getEnv("JWT_EXPIRY", "24h")),
+			RefreshExpiry:       parseDuration(getEnv("JWT_REFRESH_EXPIRY", "168h")),
+			ImpersonationExpiry: parseDuration(getEnv("JWT_IMPERSONATION_EXPIRY", "15m")),
 		},
 		Upload: UploadConfig{
-			Path:             getEnv("UPLOAD_PATH", "/app/uploads"),
-			MaxSize:          parseInt64(getEnv("MAX_UPLOAD_SIZE", "10485760")),
-			AllowedFileTypes: []string{"image/png", "image/jpeg", "image/jpg"},
+			Path:                getEnv("UPLOAD_PATH", "/app/uploads"),
+			PrivatePath:         getEnv("PRIVATE_UPLOAD_PATH", "/app/private"),
+			MaxSize:             parseInt64(getEnv("MAX_UPLOAD_SIZE", "10485760")),
+			AllowedFileTypes:    parseList(getEnv("ALLOWED_FILE_TYPES", "image/png,image/jpeg,image/jpg")),
+			MaxScreenshotWidth:  parseInt(getEnv("MAX_SCREENSHOT_WIDTH", "16384"), 16384),
+			MaxScreenshotHeight: parseInt(getEnv("MAX_SCREENSHOT_HEIGHT", "16384"), 16384),
+			AttachmentTypes:     parseList(getEnv("TASK_ATTACHMENT_TYPES", ".pdf,.png,.jpg,.jpeg,.gif,.webp,.txt,.csv,.log,.md,.json,.zip,.doc,.docx,.xls,.xlsx,.ppt,.pptx,.odt,.ods")),
 		},
 		CORS: CORSConfig{
 			AllowedOrigins: parseOrigins(getEnv("ALLOWED_ORIGINS", "http://localhost:3000,http://localhost:5173")),
@@ -128,6 +411,188 @@ func Load() (*Config, error) {
 		Presence: PresenceConfig{
 			HeartbeatInterval: parseDuration(getEnv("PRESENCE_HEARTBEAT_INTERVAL", "15s")),
 			StaleAfter:        parseDuration(getEnv("PRESENCE_STALE_AFTER", "45s")),
+			HistoryRetention:  parseDuration(getEnv("PRESENCE_HISTORY_RETENTION", "2160h")),
+		},
+		Privacy: PrivacyConfig{
+			ErasureGracePeriod: parseDuration(getEnv("ERASURE_GRACE_PERIOD", "720h")),
+			ExportRetention:    parseDuration(getEnv("DATA_EXPORT_RETENTION", "168h")),
+			ExportLinkTTL:      parseDuration(getEnv("DATA_EXPORT_LINK_TTL", "1h")),
+
+			OrgDeletionGracePeriod: parseDuration(getEnv("ORG_DELETION_GRACE_PERIOD", "336h")),
+		},
+		DeviceLog: DeviceLogConfig{
+			MaxSize:      parseInt64(getEnv("DEVICE_LOG_MAX_SIZE", "20971520")),
+			Retention:    parseDuration(getEnv("DEVICE_LOG_RETENTION", "336h")),
+			MaxPerDevice: parseInt(getEnv("DEVICE_LOG_MAX_PER_DEVICE", "10"), 10),
+		},
+		Cache: CacheConfig{
+			RedisURL:  getEnv("REDIS_URL", ""),
+			KeyPrefix: getEnv("CACHE_KEY_PREFIX", "rtt:"),
+			StatsTTL:  parseDuration(getEnv("CACHE_STATS_TTL", "60s")),
+
+			OverviewStatsTTL:        parseDuration(getEnv("CACHE_OVERVIEW_STATS_TTL", "60s")),
+			TrendStatsTTL:           parseDuration(getEnv("CACHE_TREND_STATS_TTL", "10m")),
+			UserPerformanceStatsTTL: parseDuration(getEnv("CACHE_USER_PERFORMANCE_STATS_TTL", "5m")),
+			OrgDistributionStatsTTL: parseDuration(getEnv("CACHE_ORG_DISTRIBUTION_STATS_TTL", "15m")),
+			ActivityStatsTTL:        parseDuration(getEnv("CACHE_ACTIVITY_STATS_TTL", "30s")),
+
+			OrgSettingsTTL: parseDuration(getEnv("CACHE_ORG_SETTINGS_TTL", "5m")),
+		},
+		Telemetry: TelemetryConfig{
+			Retention: parseDuration(getEnv("TELEMETRY_RETENTION", "2160h")),
+			RateLimit: parseInt(getEnv("TELEMETRY_RATE_LIMIT", "30"), 30),
+		},
+		Webhook: WebhookConfig{
+			Timeout:           parseDuration(getEnv("WEBHOOK_TIMEOUT", "10s")),
+			MaxAttempts:       parseInt(getEnv("WEBHOOK_MAX_ATTEMPTS", "6"), 6),
+			DeliveryRetention: parseDuration(getEnv("WEBHOOK_DELIVERY_RETENTION", "720h")),
+			AllowHTTP:         getEnv("WEBHOOK_ALLOW_HTTP", "false") == "true",
+			AllowPrivateHosts: getEnv("WEBHOOK_ALLOW_PRIVATE_HOSTS", "false") == "true",
+		},
+		Jira: JiraConfig{
+			Timeout:   parseDuration(getEnv("JIRA_TIMEOUT", "30s")),
+			AllowHTTP: getEnv("JIRA_ALLOW_HTTP", "false") == "true",
+		},
+		Slack: SlackConfig{
+			ClientID:     getEnv("SLACK_CLIENT_ID", ""),
+			ClientSecret: getEnv("SLACK_CLIENT_SECRET", ""),
+			RedirectURL:  getEnv("SLACK_REDIRECT_URL", ""),
+			Timeout:      parseDuration(getEnv("SLACK_TIMEOUT", "10s")),
+		},
+		Google: GoogleConfig{
+			ClientID:     getEnv("GOOGLE_CLIENT_ID", ""),
+			ClientSecret: getEnv("GOOGLE_CLIENT_SECRET", ""),
+			RedirectURL:  getEnv("GOOGLE_REDIRECT_URL", ""),
+			Timeout:      parseDuration(getEnv("GOOGLE_TIMEOUT", "10s")),
+		},
+		Email: EmailConfig{
+			Provider:         getEnv("EMAIL_PROVIDER", "log"),
+			From:             getEnv("EMAIL_FROM", ""),
+			FromName:         getEnv("EMAIL_FROM_NAME", "Remote Time Tracker"),
+			SMTPHost:         getEnv("SMTP_HOST", ""),
+			SMTPPort:         getEnv("SMTP_PORT", "587"),
+			SMTPUsername:     getEnv("SMTP_USERNAME", ""),
+			SMTPPassword:     getEnv("SMTP_PASSWORD", ""),
+			SMTPTLS:          getEnv("SMTP_TLS", "starttls"),
+			SendGridAPIKey:   getEnv("SENDGRID_API_KEY", ""),
+			Timeout:          parseDuration(getEnv("EMAIL_TIMEOUT", "15s")),
+			AppURL:           strings.TrimRight(getEnv("EMAIL_APP_URL", "http://localhost:3000"), "/"),
+			MaxAttempts:      parseInt(getEnv("EMAIL_MAX_ATTEMPTS", "6"), 6),
+			Retention:        parseDuration(getEnv("EMAIL_OUTBOX_RETENTION", "720h")),
+			PasswordResetTTL: parseDuration(getEnv("PASSWORD_RESET_TTL", "1h")),
+		},
+		Notification: NotificationConfig{
+			Retention: parseDuration(getEnv("NOTIFICATION_RETENTION", "2160h")),
+		},
+		Overtime: OvertimeConfig{
+			MinMinutes:     parseInt(getEnv("OVERTIME_MIN_MINUTES", "15"), 15),
+			NotifyManagers: getEnv("OVERTIME_NOTIFY_MANAGERS", "false") == "true",
+		},
+		API: APIConfig{
+			V1DeprecatedAt: parseDate(getEnv("API_V1_DEPRECATED_AT", "")),
+			V1SunsetAt:     parseDate(getEnv("API_V1_SUNSET_AT", "")),
+		},
+		Idempotency: IdempotencyConfig{
+			KeyTTL: parseDuration(getEnv("IDEMPOTENCY_KEY_TTL", "24h")),
+		},
+		FX: FXConfig{
+			Provider:     getEnv("FX_PROVIDER", "static"),
+			BaseCurrency: getEnv("FX_BASE_CURRENCY", "USD"),
+			Rates:        getEnv("FX_RATES", ""),
+			CacheTTL:     parseDuration(getEnv("FX_CACHE_TTL", "6h")),
+		},
+		Sync: SyncConfig{
+			ConflictPolicy:     getEnv("SYNC_CONFLICT_POLICY", "last_write_wins"),
+			TransactionMode:    getEnv("SYNC_TRANSACTION_MODE", "item"),
+			ConfigPollInterval: parseDuration(getEnv("SYNC_CONFIG_POLL_INTERVAL", "5m")),
+		},
+		Timer: TimerConfig{
+			ConcurrencyPolicy: getEnv("TIMER_CONCURRENCY_POLICY", "stop"),
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:       getEnv("RATE_LIMIT_ENABLED", "true") == "true",
+			AuthPerIP:     parseInt(getEnv("RATE_LIMIT_AUTH_PER_IP", "20"), 20),
+			AuthBurst:     parseInt(getEnv("RATE_LIMIT_AUTH_BURST", "10"), 10),
+			SyncPerUser:   parseInt(getEnv("RATE_LIMIT_SYNC_PER_USER", "60"), 60),
+			SyncPerIP:     parseInt(getEnv("RATE_LIMIT_SYNC_PER_IP", "600"), 600),
+			SyncBurst:     parseInt(getEnv("RATE_LIMIT_SYNC_BURST", "30"), 30),
+			InvitePerIP:   parseInt(getEnv("RATE_LIMIT_INVITE_PER_IP", "10"), 10),
+			InviteBurst:   parseInt(getEnv("RATE_LIMIT_INVITE_BURST", "5"), 5),
+			ExemptUserIDs: parseList(getEnv("RATE_LIMIT_EXEMPT_USER_IDS", "")),
+			ExemptIPs:     parseList(getEnv("RATE_LIMIT_EXEMPT_IPS", "")),
+		},
+		Captcha: CaptchaConfig{
+			VerifyURL:     getEnv("CAPTCHA_VERIFY_URL", ""),
+			Secret:        getEnv("CAPTCHA_SECRET", ""),
+			InvitePreview: getEnv("CAPTCHA_INVITE_PREVIEW", "false") == "true",
+		},
+		Backup: BackupConfig{
+			Target:        getEnv("BACKUP_TARGET", ""),
+			S3Endpoint:    getEnv("BACKUP_S3_ENDPOINT", ""),
+			S3Region:      getEnv("BACKUP_S3_REGION", "us-east-1"),
+			S3AccessKey:   getEnv("BACKUP_S3_ACCESS_KEY", ""),
+			S3SecretKey:   getEnv("BACKUP_S3_SECRET_KEY", ""),
+			EncryptionKey: getEnv("BACKUP_ENCRYPTION_KEY", ""),
+			Keep:          parseInt(getEnv("BACKUP_KEEP", "14"), 14),
+		},
+		ColdStore: ColdStorageConfig{
+			Target:       getEnv("COLD_STORAGE_TARGET", ""),
+			S3Endpoint:   getEnv("COLD_STORAGE_S3_ENDPOINT", ""),
+			S3Region:     getEnv("COLD_STORAGE_S3_REGION", "us-east-1"),
+			S3AccessKey:  getEnv("COLD_STORAGE_S3_ACCESS_KEY", ""),
+			S3SecretKey:  getEnv("COLD_STORAGE_S3_SECRET_KEY", ""),
+			StorageClass: getEnv("COLD_STORAGE_CLASS", "STANDARD_IA"),
+			AfterDays:    parseInt(getEnv("COLD_STORAGE_AFTER_DAYS", "90"), 90),
+			RestoreDays:  parseInt(getEnv("COLD_STORAGE_RESTORE_DAYS", "7"), 7),
+		},
+		Scanner: ScannerConfig{
+			Provider:      getEnv("SCANNER_PROVIDER", "none"),
+			Timeout:       parseDuration(getEnv("SCANNER_TIMEOUT", "30s")),
+			ClamAVAddress: getEnv("SCANNER_CLAMAV_ADDRESS", "localhost:3310"),
+			APIURL:        getEnv("SCANNER_API_URL", ""),
+			APIKey:        getEnv("SCANNER_API_KEY", ""),
+		},
+		OCR: OCRConfig{
+			Provider:      getEnv("OCR_PROVIDER", "none"),
+			Timeout:       parseDuration(getEnv("OCR_TIMEOUT", "60s")),
+			TesseractPath: getEnv("OCR_TESSERACT_PATH", "tesseract"),
+			Languages:     getEnv("OCR_LANGUAGES", "eng"),
+			APIURL:        getEnv("OCR_API_URL", ""),
+			APIKey:        getEnv("OCR_API_KEY", ""),
+		},
+		Purge: PurgeConfig{
+			OlderThanDays: parseInt(getEnv("SOFT_DELETE_PURGE_AFTER_DAYS", "90"), 90),
+		},
+		Jobs: JobsConfig{
+			PrivacyErasureSchedule:      getEnv("JOB_PRIVACY_ERASURE_SCHEDULE", "@hourly"),
+			ExportCleanupSchedule:       getEnv("JOB_EXPORT_CLEANUP_SCHEDULE", "@hourly"),
+			DeviceLogCleanupSchedule:    getEnv("JOB_DEVICE_LOG_CLEANUP_SCHEDULE", "@hourly"),
+			TelemetryCleanupSchedule:    getEnv("JOB_TELEMETRY_CLEANUP_SCHEDULE", "@daily"),
+			ScreenshotRetentionSchedule: getEnv("JOB_SCREENSHOT_RETENTION_SCHEDULE", "0 */6 * * *"),
+			InvitationExpirySchedule:    getEnv("JOB_INVITATION_EXPIRY_SCHEDULE", "@hourly"),
+			WebhookRetrySchedule:        getEnv("JOB_WEBHOOK_RETRY_SCHEDULE", "@every 1m"),
+			WebhookCleanupSchedule:      getEnv("JOB_WEBHOOK_CLEANUP_SCHEDULE", "@daily"),
+			UploadsBackupSchedule:       getEnv("JOB_UPLOADS_BACKUP_SCHEDULE", "@daily"),
+			SoftDeletePurgeSchedule:     getEnv("JOB_SOFT_DELETE_PURGE_SCHEDULE", "@daily"),
+			ColdStorageSchedule:         getEnv("JOB_COLD_STORAGE_SCHEDULE", "0 3 * * *"),
+			OperationCleanupSchedule:    getEnv("JOB_OPERATION_CLEANUP_SCHEDULE", "@daily"),
+			JiraSyncSchedule:            getEnv("JOB_JIRA_SYNC_SCHEDULE", "@every 15m"),
+			SlackDailySummarySchedule:   getEnv("JOB_SLACK_DAILY_SUMMARY_SCHEDULE", "0 8 * * *"),
+			GoogleCalendarPushSchedule:  getEnv("JOB_GOOGLE_CALENDAR_PUSH_SCHEDULE", "@every 15m"),
+			EmailDeliverySchedule:       getEnv("JOB_EMAIL_DELIVERY_SCHEDULE", "@every 30s"),
+			EmailCleanupSchedule:        getEnv("JOB_EMAIL_CLEANUP_SCHEDULE", "@daily"),
+			WeeklySummarySchedule:       getEnv("JOB_WEEKLY_SUMMARY_SCHEDULE", "0 7 * * 1"),
+			NotificationCleanupSchedule: getEnv("JOB_NOTIFICATION_CLEANUP_SCHEDULE", "@daily"),
+			BudgetRollupSchedule:        getEnv("JOB_BUDGET_ROLLUP_SCHEDULE", "@hourly"),
+			OvertimeDetectionSchedule:   getEnv("JOB_OVERTIME_DETECTION_SCHEDULE", "@hourly"),
+			SavedReportDeliverySchedule: getEnv("JOB_SAVED_REPORT_DELIVERY_SCHEDULE", "@every 5m"),
+			IdempotencyCleanupSchedule:  getEnv("JOB_IDEMPOTENCY_CLEANUP_SCHEDULE", "@hourly"),
+			OrgDeletionSchedule:         getEnv("JOB_ORG_DELETION_SCHEDULE", "@hourly"),
+			StatsRollupSchedule:         getEnv("JOB_STATS_ROLLUP_SCHEDULE", "5 0 * * *"),
+			ScreenshotScanSchedule:      getEnv("JOB_SCREENSHOT_SCAN_SCHEDULE", "@every 10m"),
+			ScreenshotOCRSchedule:       getEnv("JOB_SCREENSHOT_OCR_SCHEDULE", "@every 5m"),
+			PresenceOfflineSchedule:     getEnv("JOB_PRESENCE_OFFLINE_SCHEDULE", "@every 30s"),
+			PresenceCleanupSchedule:     getEnv("JOB_PRESENCE_CLEANUP_SCHEDULE", "@daily"),
 		},
 	}
 
@@ -173,6 +638,17 @@ func parseOrigins(s string) []string {
 	return origins
 }
 
+// parseList splits a comma separated list, dropping empty entries
+func parseList(s string) []string {
+	list := []string{}
+	for _, part := range strings.Split(s, ",") {
+		if trimmed := strings.TrimSpace(part); trimmed != "" {
+			list = append(list, trimmed)
+		}
+	}
+	return list
+}
+
 func parseDuration(s string) time.Duration {
 	d, err := time.ParseDuration(s)
 	if err != nil {
@@ -182,6 +658,29 @@ func parseDuration(s string) time.Duration {
 	return d
 }
 
+// parseDate parses a YYYY-MM-DD or RFC 3339 date; empty or invalid is nil
+func parseDate(s string) *time.Time {
+	if s == "" {
+		return nil
+	}
+	for _, layout := range []string{"2006-01-02", time.RFC3339} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return &t
+		}
+	}
+	log.Printf("Failed to parse date %s, ignoring it", s)
+	return nil
+}
+
+func parseInt(s string, defaultValue int) int {
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		log.Printf("Failed to parse int %s, using default %d", s, defaultValue)
+		return defaultValue
+	}
+	return i
+}
+
 func parseInt64(s string) int64 {
 	i, err := strconv.ParseInt(s, 10, 64)
 	if err != nil {
@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminActivityFeedController handles the admin activity feed stream
+type AdminActivityFeedController struct{}
+
+// NewAdminActivityFeedController creates a new admin activity feed controller
+func NewAdminActivityFeedController() *AdminActivityFeedController {
+	return &AdminActivityFeedController{}
+}
+
+// Stream provides an SSE stream of activity feed events
+// @Summary Activity feed stream (admin only)
+// @Description Stream audit-log-style activity events (user login, time log created, organization created) via Server-Sent Events
+// @Tags admin
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Success 200 {string} string "SSE stream"
+// @Router /admin/activfeed/stream [get]
+func (c *AdminActivityFeedController) Stream(ctx *gin.Context) {
+	ctx.Writer.Header().Set("Content-Type", "text/event-stream")
+	ctx.Writer.Header().Set("Cache-Control", "no-cache")
+	ctx.Writer.Header().Set("Connection", "keep-alive")
+	ctx.Writer.Header().Set("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+
+	flusher, ok := ctx.Writer.(http.Flusher)
+	if !ok {
+		ctx.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	sub := service.ActivityFeedBroadcaster.Subscribe()
+	defer service.ActivityFeedBroadcaster.Unsubscribe(sub)
+
+	pingTicker := time.NewTicker(25 * time.Second)
+	defer pingTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Request.Context().Done():
+			return
+		case payload, ok := <-sub:
+			if !ok {
+				return // Server shutting down
+			}
+			_, _ = fmt.Fprintf(ctx.Writer, "data: %s\n\n", payload)
+			flusher.Flush()
+		case <-pingTicker.C:
+			_, _ = fmt.Fprintf(ctx.Writer, ": ping\n\n")
+			flusher.Flush()
+		}
+	}
+}
@@ -1,12 +1,16 @@
 package controller
 
 import (
+	"encoding/csv"
+	"errors"
+	"fmt"
 	"net/http"
 	"path/filepath"
 	"strconv"
 	"time"
 
 	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/format"
 	"github.com/beuphecan/remote-time-tracker/internal/service"
 	"github.com/beuphecan/remote-time-tracker/internal/utils"
 	"github.com/gin-gonic/gin"
@@ -14,13 +18,19 @@ import (
 
 // AdminController handles admin-only HTTP requests
 type AdminController struct {
-	adminService service.AdminService
+	adminService     service.AdminService
+	analyticsService service.AdminAnalyticsService
+	tierService      service.ScreenshotTierService
+	auditService     service.AuditService
 }
 
 // NewAdminController creates a new admin controller
-func NewAdminController(adminService service.AdminService) *AdminController {
+func NewAdminController(adminService service.AdminService, analyticsService service.AdminAnalyticsService, tierService service.ScreenshotTierService, auditService service.AuditService) *AdminController {
 	return &AdminController{
-		adminService: adminService,
+		adminService:     adminService,
+		analyticsService: analyticsService,
+		tierService:      tierService,
+		auditService:     auditService,
 	}
 }
 
@@ -72,7 +82,7 @@ func (c *AdminController) ListUsers(ctx *gin.Context) {
 
 	result, err := c.adminService.ListUsers(params)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -95,13 +105,13 @@ func (c *AdminController) ListUsers(ctx *gin.Context) {
 func (c *AdminController) GetUser(ctx *gin.Context) {
 	userID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	user, err := c.adminService.GetUser(uint(userID))
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		utils.ErrorResponse(ctx, http.StatusNotFound, "user not found")
 		return
 	}
 
@@ -124,13 +134,13 @@ func (c *AdminController) GetUser(ctx *gin.Context) {
 func (c *AdminController) CreateUser(ctx *gin.Context) {
 	var req dto.AdminCreateUserRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.ValidationErrorResponse(ctx, err)
 		return
 	}
 
 	user, err := c.adminService.CreateUser(&req)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -154,19 +164,20 @@ func (c *AdminController) CreateUser(ctx *gin.Context) {
 func (c *AdminController) UpdateUser(ctx *gin.Context) {
 	userID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	var req dto.AdminUpdateUserRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.ValidationErrorResponse(ctx, err)
 		return
 	}
 
-	user, err := c.adminService.UpdateUser(uint(userID), &req)
+	actorID := ctx.GetUint("userID")
+	user, err := c.adminService.UpdateUser(uint(userID), actorID, &req)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -188,19 +199,19 @@ func (c *AdminController) UpdateUser(ctx *gin.Context) {
 func (c *AdminController) DeleteUser(ctx *gin.Context) {
 	userID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	// Prevent self-deletion
 	actorID := ctx.GetUint("userID")
 	if uint(userID) == actorID {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "cannot delete your own account"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "cannot delete your own account")
 		return
 	}
 
 	if err := c.adminService.DeleteUser(uint(userID)); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete user"})
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "failed to delete user")
 		return
 	}
 
@@ -224,18 +235,18 @@ func (c *AdminController) DeleteUser(ctx *gin.Context) {
 func (c *AdminController) ActivateUser(ctx *gin.Context) {
 	userID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	var req dto.AdminActivateUserRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.ValidationErrorResponse(ctx, err)
 		return
 	}
 
 	if err := c.adminService.ActivateUser(uint(userID), req.Active); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -263,18 +274,18 @@ func (c *AdminController) ActivateUser(ctx *gin.Context) {
 func (c *AdminController) ChangeUserRole(ctx *gin.Context) {
 	userID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	var req dto.AdminChangeRoleRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.ValidationErrorResponse(ctx, err)
 		return
 	}
 
 	if err := c.adminService.ChangeUserRole(uint(userID), req.Role); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -298,31 +309,103 @@ func (c *AdminController) ChangeUserRole(ctx *gin.Context) {
 func (c *AdminController) ChangeUserSystemRole(ctx *gin.Context) {
 	userID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	var req dto.AdminChangeSystemRoleRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.ValidationErrorResponse(ctx, err)
 		return
 	}
 
 	// Prevent changing own system role
 	actorID := ctx.GetUint("userID")
 	if uint(userID) == actorID {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "cannot change your own system role"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "cannot change your own system role")
 		return
 	}
 
-	if err := c.adminService.ChangeUserSystemRole(uint(userID), req.SystemRole); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := c.adminService.ChangeUserSystemRole(uint(userID), actorID, req.SystemRole); err != nil {
+		utils.RespondError(ctx, err)
 		return
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{"message": "user system role changed successfully"})
 }
 
+// ImpersonateUser issues a short-lived token to act as another user
+// @Summary Impersonate user (admin only)
+// @Description Issue a short-lived, clearly-marked access token for the target user so support can debug as them. The impersonation is recorded in the audit log. System admins cannot be impersonated and the token cannot be refreshed.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Param request body dto.AdminImpersonateRequest false "Impersonation reason"
+// @Success 200 {object} dto.AdminImpersonationResponse "Impersonation session"
+// @Failure 400 {object} dto.ErrorResponse "Invalid user ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - target cannot be impersonated"
+// @Router /admin/users/{id}/impersonate [post]
+func (c *AdminController) ImpersonateUser(ctx *gin.Context) {
+	userID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	// Reason is optional; it is captured by the audit middleware
+	var req dto.AdminImpersonateRequest
+	_ = ctx.ShouldBindJSON(&req)
+
+	adminID := ctx.GetUint("userID")
+	result, err := c.adminService.ImpersonateUser(uint(userID), adminID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// MergeUser merges a duplicate user into another
+// @Summary Merge users (admin only)
+// @Description Merge a duplicate account (the path user) into the account the person keeps. Tasks, task assignments, time logs, screenshots, devices and organization and workspace memberships move to the target in one transaction; where both were members, the target's membership is kept, and organizations the duplicate owns pass to the target. The duplicate is deactivated and the merge is recorded in the audit log.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Duplicate user ID"
+// @Param request body dto.AdminMergeUserRequest true "Target user"
+// @Success 200 {object} dto.AdminUserMergeResponse "Users merged"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /admin/users/{id}/merge [post]
+func (c *AdminController) MergeUser(ctx *gin.Context) {
+	userID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	var req dto.AdminMergeUserRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	adminID := ctx.GetUint("userID")
+	result, err := c.adminService.MergeUser(uint(userID), req.TargetUserID, adminID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
 // ============================================================================
 // ORGANIZATION MANAGEMENT
 // ============================================================================
@@ -369,7 +452,7 @@ func (c *AdminController) ListOrganizations(ctx *gin.Context) {
 
 	result, err := c.adminService.ListOrganizations(params)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -392,13 +475,13 @@ func (c *AdminController) ListOrganizations(ctx *gin.Context) {
 func (c *AdminController) GetOrganization(ctx *gin.Context) {
 	orgID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
 		return
 	}
 
 	org, err := c.adminService.GetOrganization(uint(orgID))
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{"error": "organization not found"})
+		utils.ErrorResponse(ctx, http.StatusNotFound, "organization not found")
 		return
 	}
 
@@ -414,40 +497,48 @@ func (c *AdminController) GetOrganization(ctx *gin.Context) {
 // @Security BearerAuth
 // @Param id path int true "Organization ID"
 // @Param request body dto.AdminUpdateOrgRequest true "Organization data"
+// @Param If-Match header string false "Version the update is based on, instead of version in the body"
 // @Success 200 {object} dto.AdminOrgResponse "Updated organization"
 // @Failure 400 {object} dto.ErrorResponse "Invalid request"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 409 {object} dto.ErrorResponse "Version conflict; details hold the current state"
 // @Router /admin/organizations/{id} [put]
 func (c *AdminController) UpdateOrganization(ctx *gin.Context) {
 	orgID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
 		return
 	}
 
 	var req dto.AdminUpdateOrgRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+	if !bindVersion(ctx, &req.Version) {
 		return
 	}
 
 	org, err := c.adminService.UpdateOrganization(uint(orgID), &req)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		if respondVersionConflict(ctx, err) {
+			return
+		}
+		utils.RespondError(ctx, err)
 		return
 	}
 
 	ctx.JSON(http.StatusOK, org)
 }
 
-// DeleteOrganization deletes organization
+// DeleteOrganization schedules organization deletion
 // @Summary Delete organization (admin only)
-// @Description Delete an organization and all associated data
+// @Description Schedule an organization for deletion. After the grace period the organization and all associated data are permanently purged; the owner can cancel the deletion until then.
 // @Tags admin
 // @Security BearerAuth
 // @Param id path int true "Organization ID"
-// @Success 204 "Organization deleted"
+// @Success 204 "Organization deletion scheduled"
 // @Failure 400 {object} dto.ErrorResponse "Invalid organization ID"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 403 {object} dto.ErrorResponse "Forbidden"
@@ -456,12 +547,12 @@ func (c *AdminController) UpdateOrganization(ctx *gin.Context) {
 func (c *AdminController) DeleteOrganization(ctx *gin.Context) {
 	orgID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
 		return
 	}
 
 	if err := c.adminService.DeleteOrganization(uint(orgID)); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete organization"})
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "failed to delete organization")
 		return
 	}
 
@@ -485,19 +576,19 @@ func (c *AdminController) DeleteOrganization(ctx *gin.Context) {
 func (c *AdminController) VerifyOrganization(ctx *gin.Context) {
 	orgID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
 		return
 	}
 
 	var req dto.AdminVerifyOrgRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.ValidationErrorResponse(ctx, err)
 		return
 	}
 
 	adminID := ctx.GetUint("userID")
 	if err := c.adminService.VerifyOrganization(uint(orgID), req.Verified, adminID); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -560,7 +651,7 @@ func (c *AdminController) ListWorkspaces(ctx *gin.Context) {
 
 	result, err := c.adminService.ListWorkspaces(params)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -583,13 +674,13 @@ func (c *AdminController) ListWorkspaces(ctx *gin.Context) {
 func (c *AdminController) GetWorkspace(ctx *gin.Context) {
 	wsID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid workspace ID")
 		return
 	}
 
 	workspace, err := c.adminService.GetWorkspace(uint(wsID))
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{"error": "workspace not found"})
+		utils.ErrorResponse(ctx, http.StatusNotFound, "workspace not found")
 		return
 	}
 
@@ -605,27 +696,35 @@ func (c *AdminController) GetWorkspace(ctx *gin.Context) {
 // @Security BearerAuth
 // @Param id path int true "Workspace ID"
 // @Param request body dto.AdminUpdateWorkspaceRequest true "Workspace data"
+// @Param If-Match header string false "Version the update is based on, instead of version in the body"
 // @Success 200 {object} dto.AdminWorkspaceResponse "Updated workspace"
 // @Failure 400 {object} dto.ErrorResponse "Invalid request"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 409 {object} dto.ErrorResponse "Version conflict; details hold the current state"
 // @Router /admin/workspaces/{id} [put]
 func (c *AdminController) UpdateWorkspace(ctx *gin.Context) {
 	wsID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid workspace ID")
 		return
 	}
 
 	var req dto.AdminUpdateWorkspaceRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+	if !bindVersion(ctx, &req.Version) {
 		return
 	}
 
 	workspace, err := c.adminService.UpdateWorkspace(uint(wsID), &req)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		if respondVersionConflict(ctx, err) {
+			return
+		}
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -647,12 +746,12 @@ func (c *AdminController) UpdateWorkspace(ctx *gin.Context) {
 func (c *AdminController) DeleteWorkspace(ctx *gin.Context) {
 	wsID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid workspace ID")
 		return
 	}
 
 	if err := c.adminService.DeleteWorkspace(uint(wsID)); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete workspace"})
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "failed to delete workspace")
 		return
 	}
 
@@ -676,19 +775,19 @@ func (c *AdminController) DeleteWorkspace(ctx *gin.Context) {
 func (c *AdminController) ArchiveWorkspace(ctx *gin.Context) {
 	wsID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid workspace ID")
 		return
 	}
 
 	var req dto.AdminArchiveWorkspaceRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.ValidationErrorResponse(ctx, err)
 		return
 	}
 
 	adminID := ctx.GetUint("userID")
 	if err := c.adminService.ArchiveWorkspace(uint(wsID), req.Archived, adminID); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -716,7 +815,12 @@ func (c *AdminController) ArchiveWorkspace(ctx *gin.Context) {
 // @Param workspace_id query int false "Filter by workspace"
 // @Param status query string false "Filter by status"
 // @Param is_manual query bool false "Filter by manual creation"
+// @Param tag query string false "Filter by label"
+// @Param due_from query string false "Due on or after (YYYY-MM-DD)"
+// @Param due_to query string false "Due on or before (YYYY-MM-DD)"
+// @Param overdue query bool false "Only active tasks past their due date"
 // @Success 200 {object} dto.AdminTaskListResponse "Task list"
+// @Failure 400 {object} dto.ErrorResponse "Invalid filter"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 403 {object} dto.ErrorResponse "Forbidden"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error"
@@ -743,7 +847,7 @@ func (c *AdminController) ListTasks(ctx *gin.Context) {
 
 	if ctx.Query("workspace_id") != "" {
 		if params.OrgID == nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": "workspace_id requires org_id"})
+			utils.ErrorResponse(ctx, http.StatusBadRequest, "workspace_id requires org_id")
 			return
 		}
 		wsID := uint(parseIntParam(ctx, "workspace_id", 0))
@@ -755,9 +859,16 @@ func (c *AdminController) ListTasks(ctx *gin.Context) {
 		params.IsManual = &isManual
 	}
 
+	filter, err := parseTaskFilter(ctx)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+	params.TaskFilter = *filter
+
 	result, err := c.adminService.ListTasks(params)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -780,13 +891,13 @@ func (c *AdminController) ListTasks(ctx *gin.Context) {
 func (c *AdminController) GetTask(ctx *gin.Context) {
 	taskID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid task ID")
 		return
 	}
 
 	task, err := c.adminService.GetTask(uint(taskID))
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		utils.ErrorResponse(ctx, http.StatusNotFound, "task not found")
 		return
 	}
 
@@ -802,27 +913,35 @@ func (c *AdminController) GetTask(ctx *gin.Context) {
 // @Security BearerAuth
 // @Param id path int true "Task ID"
 // @Param request body dto.AdminUpdateTaskRequest true "Task data"
+// @Param If-Match header string false "Version the update is based on, instead of version in the body"
 // @Success 200 {object} dto.AdminTaskResponse "Updated task"
 // @Failure 400 {object} dto.ErrorResponse "Invalid request"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 409 {object} dto.ErrorResponse "Version conflict; details hold the current state"
 // @Router /admin/tasks/{id} [put]
 func (c *AdminController) UpdateTask(ctx *gin.Context) {
 	taskID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid task ID")
 		return
 	}
 
 	var req dto.AdminUpdateTaskRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+	if !bindVersion(ctx, &req.Version) {
 		return
 	}
 
 	task, err := c.adminService.UpdateTask(uint(taskID), &req)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		if respondVersionConflict(ctx, err) {
+			return
+		}
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -844,12 +963,12 @@ func (c *AdminController) UpdateTask(ctx *gin.Context) {
 func (c *AdminController) DeleteTask(ctx *gin.Context) {
 	taskID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid task ID")
 		return
 	}
 
 	if err := c.adminService.DeleteTask(uint(taskID)); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete task"})
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "failed to delete task")
 		return
 	}
 
@@ -872,6 +991,9 @@ func (c *AdminController) DeleteTask(ctx *gin.Context) {
 // @Param task_id query int false "Filter by task"
 // @Param status query string false "Filter by status"
 // @Param is_approved query bool false "Filter by approval status"
+// @Param pending_approval query bool false "Only manual entries awaiting review (true) or none of them (false)"
+// @Param cost_center query string false "Filter by cost center"
+// @Param project_code query string false "Filter by project code"
 // @Param start_date query string false "Filter by start date (YYYY-MM-DD)"
 // @Param end_date query string false "Filter by end date (YYYY-MM-DD)"
 // @Success 200 {object} dto.AdminTimeLogListResponse "Time log list"
@@ -880,12 +1002,95 @@ func (c *AdminController) DeleteTask(ctx *gin.Context) {
 // @Failure 500 {object} dto.ErrorResponse "Internal server error"
 // @Router /admin/timelogs [get]
 func (c *AdminController) ListTimeLogs(ctx *gin.Context) {
+	params := parseTimeLogListParams(ctx)
+
+	result, err := c.adminService.ListTimeLogs(params)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// ExportTimeLogs exports time logs as CSV
+// @Summary Export time logs as CSV (admin only)
+// @Description Export time logs matching the filters as CSV, including the effective cost center and project code for accounting reconciliation
+// @Tags admin
+// @Produce text/csv
+// @Security BearerAuth
+// @Param user_id query int false "Filter by user"
+// @Param org_id query int false "Filter by organization"
+// @Param workspace_id query int false "Filter by workspace"
+// @Param task_id query int false "Filter by task"
+// @Param status query string false "Filter by status"
+// @Param is_approved query bool false "Filter by approval status"
+// @Param pending_approval query bool false "Only manual entries awaiting review (true) or none of them (false)"
+// @Param cost_center query string false "Filter by cost center"
+// @Param project_code query string false "Filter by project code"
+// @Param start_date query string false "Filter by start date (YYYY-MM-DD)"
+// @Param end_date query string false "Filter by end date (YYYY-MM-DD)"
+// @Success 200 {file} file "CSV file"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /admin/timelogs/export [get]
+func (c *AdminController) ExportTimeLogs(ctx *gin.Context) {
+	params := parseTimeLogListParams(ctx)
+
+	rows, err := c.adminService.ExportTimeLogs(params)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	filename := fmt.Sprintf("timelogs-%s.csv", time.Now().Format("20060102-150405"))
+	ctx.Header("Content-Type", "text/csv; charset=utf-8")
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	ctx.Status(http.StatusOK)
+
+	w := csv.NewWriter(ctx.Writer)
+	_ = w.Write([]string{
+		"id", "user_email", "user_name", "organization", "workspace", "task",
+		"cost_center", "project_code", "start_time", "end_time", "duration_seconds",
+		"duration_hours", "status", "is_manual", "is_approved",
+	})
+	for _, r := range rows {
+		endTime := ""
+		if r.EndTime != nil {
+			endTime = r.EndTime.UTC().Format(time.RFC3339)
+		}
+		_ = w.Write([]string{
+			strconv.FormatUint(uint64(r.ID), 10),
+			r.UserEmail,
+			r.UserName,
+			r.OrgName,
+			r.WorkspaceName,
+			r.TaskTitle,
+			r.CostCenter,
+			r.ProjectCode,
+			r.StartTime.UTC().Format(time.RFC3339),
+			endTime,
+			strconv.FormatInt(r.Duration, 10),
+			strconv.FormatFloat(float64(r.Duration)/3600, 'f', 2, 64),
+			r.Status,
+			strconv.FormatBool(r.IsManual),
+			strconv.FormatBool(r.IsApproved),
+		})
+	}
+	w.Flush()
+}
+
+// parseTimeLogListParams reads the admin time log filters from the query string
+func parseTimeLogListParams(ctx *gin.Context) *dto.AdminTimeLogListParams {
 	params := &dto.AdminTimeLogListParams{
-		Page:      parseIntParam(ctx, "page", 1),
-		PageSize:  parseIntParam(ctx, "page_size", 20),
-		Status:    ctx.Query("status"),
-		SortBy:    ctx.Query("sort_by"),
-		SortOrder: ctx.Query("sort_order"),
+		Page:        parseIntParam(ctx, "page", 1),
+		PageSize:    parseIntParam(ctx, "page_size", 20),
+		Status:      ctx.Query("status"),
+		CostCenter:  ctx.Query("cost_center"),
+		ProjectCode: ctx.Query("project_code"),
+		SortBy:      ctx.Query("sort_by"),
+		SortOrder:   ctx.Query("sort_order"),
 	}
 
 	if ctx.Query("user_id") != "" {
@@ -913,6 +1118,11 @@ func (c *AdminController) ListTimeLogs(ctx *gin.Context) {
 		params.IsApproved = &isApproved
 	}
 
+	if ctx.Query("pending_approval") != "" {
+		pending := ctx.Query("pending_approval") == "true"
+		params.Pending = &pending
+	}
+
 	if ctx.Query("start_date") != "" {
 		if t, err := time.Parse("2006-01-02", ctx.Query("start_date")); err == nil {
 			params.StartDate = &t
@@ -926,13 +1136,7 @@ func (c *AdminController) ListTimeLogs(ctx *gin.Context) {
 		}
 	}
 
-	result, err := c.adminService.ListTimeLogs(params)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
-
-	ctx.JSON(http.StatusOK, result)
+	return params
 }
 
 // GetTimeLog gets time log by ID
@@ -951,13 +1155,13 @@ func (c *AdminController) ListTimeLogs(ctx *gin.Context) {
 func (c *AdminController) GetTimeLog(ctx *gin.Context) {
 	tlID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid time log ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid time log ID")
 		return
 	}
 
 	timeLog, err := c.adminService.GetTimeLog(uint(tlID))
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{"error": "time log not found"})
+		utils.ErrorResponse(ctx, http.StatusNotFound, "time log not found")
 		return
 	}
 
@@ -981,19 +1185,20 @@ func (c *AdminController) GetTimeLog(ctx *gin.Context) {
 func (c *AdminController) UpdateTimeLog(ctx *gin.Context) {
 	tlID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid time log ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid time log ID")
 		return
 	}
 
 	var req dto.AdminUpdateTimeLogRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.ValidationErrorResponse(ctx, err)
 		return
 	}
 
-	timeLog, err := c.adminService.UpdateTimeLog(uint(tlID), &req)
+	adminID := ctx.GetUint("userID")
+	timeLog, err := c.adminService.UpdateTimeLog(uint(tlID), &req, adminID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -1015,12 +1220,12 @@ func (c *AdminController) UpdateTimeLog(ctx *gin.Context) {
 func (c *AdminController) DeleteTimeLog(ctx *gin.Context) {
 	tlID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid time log ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid time log ID")
 		return
 	}
 
 	if err := c.adminService.DeleteTimeLog(uint(tlID)); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete time log"})
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "failed to delete time log")
 		return
 	}
 
@@ -1044,13 +1249,13 @@ func (c *AdminController) DeleteTimeLog(ctx *gin.Context) {
 func (c *AdminController) ApproveTimeLogs(ctx *gin.Context) {
 	var req dto.AdminApproveTimeLogsRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.ValidationErrorResponse(ctx, err)
 		return
 	}
 
 	adminID := ctx.GetUint("userID")
 	if err := c.adminService.ApproveTimeLogs(&req, adminID); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -1074,6 +1279,7 @@ func (c *AdminController) ApproveTimeLogs(ctx *gin.Context) {
 // @Param timelog_id query int false "Filter by time log"
 // @Param start_date query string false "Filter by start date (YYYY-MM-DD)"
 // @Param end_date query string false "Filter by end date (YYYY-MM-DD)"
+// @Param scan_status query string false "Filter by malware scan status (pending, clean, quarantined, released)"
 // @Success 200 {object} dto.AdminScreenshotListResponse "Screenshot list"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 403 {object} dto.ErrorResponse "Forbidden"
@@ -1081,10 +1287,11 @@ func (c *AdminController) ApproveTimeLogs(ctx *gin.Context) {
 // @Router /admin/screenshots [get]
 func (c *AdminController) ListScreenshots(ctx *gin.Context) {
 	params := &dto.AdminScreenshotListParams{
-		Page:      parseIntParam(ctx, "page", 1),
-		PageSize:  parseIntParam(ctx, "page_size", 20),
-		SortBy:    ctx.Query("sort_by"),
-		SortOrder: ctx.Query("sort_order"),
+		Page:       parseIntParam(ctx, "page", 1),
+		PageSize:   parseIntParam(ctx, "page_size", 20),
+		SortBy:     ctx.Query("sort_by"),
+		SortOrder:  ctx.Query("sort_order"),
+		ScanStatus: ctx.Query("scan_status"),
 	}
 
 	if ctx.Query("user_id") != "" {
@@ -1127,7 +1334,7 @@ func (c *AdminController) ListScreenshots(ctx *gin.Context) {
 
 	result, err := c.adminService.ListScreenshots(params)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -1150,13 +1357,13 @@ func (c *AdminController) ListScreenshots(ctx *gin.Context) {
 func (c *AdminController) GetScreenshot(ctx *gin.Context) {
 	ssID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid screenshot ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid screenshot ID")
 		return
 	}
 
 	screenshot, err := c.adminService.GetScreenshot(uint(ssID))
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{"error": "screenshot not found"})
+		utils.ErrorResponse(ctx, http.StatusNotFound, "screenshot not found")
 		return
 	}
 
@@ -1165,12 +1372,13 @@ func (c *AdminController) GetScreenshot(ctx *gin.Context) {
 
 // ViewScreenshot serves the screenshot file for viewing (admin only)
 // @Summary View screenshot file (admin only)
-// @Description View a screenshot file inline in browser
+// @Description View the original screenshot file inline in browser. Originals of blurred screenshots are only available here, and every view is recorded in the audit log. Screenshots in cold storage are retrieved first; while an archive restore is in progress the response is 202 with status "retrieval_pending" and a Retry-After header.
 // @Tags admin
 // @Produce image/png,image/jpeg
 // @Security BearerAuth
 // @Param id path int true "Screenshot ID"
 // @Success 200 {file} file "Screenshot image"
+// @Success 202 {object} map[string]interface{} "Retrieval from cold storage pending"
 // @Failure 400 {object} dto.ErrorResponse "Invalid screenshot ID"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 403 {object} dto.ErrorResponse "Forbidden"
@@ -1189,11 +1397,21 @@ func (c *AdminController) ViewScreenshot(ctx *gin.Context) {
 		return
 	}
 
+	if !respondScreenshotRetrieval(ctx, c.tierService.EnsureLocalByID(ctx.Request.Context(), screenshot.ID)) {
+		return
+	}
+
 	if !utils.FileExists(screenshot.FilePath) {
 		utils.ErrorResponse(ctx, http.StatusNotFound, "Screenshot file not found on server")
 		return
 	}
 
+	// Originals are restricted to system admins, so every view is audited
+	if c.auditService != nil {
+		c.auditService.RecordView(ctx.GetUint("user_id"), "screenshot", screenshot.ID, ctx.ClientIP(), ctx.Request.UserAgent(),
+			map[string]interface{}{"variant": "original", "owner_id": screenshot.UserID})
+	}
+
 	ctx.Header("Content-Type", screenshot.MimeType)
 	ctx.Header("Content-Disposition", "inline; filename="+filepath.Base(screenshot.FileName))
 	ctx.File(screenshot.FilePath)
@@ -1214,12 +1432,12 @@ func (c *AdminController) ViewScreenshot(ctx *gin.Context) {
 func (c *AdminController) DeleteScreenshot(ctx *gin.Context) {
 	ssID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid screenshot ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid screenshot ID")
 		return
 	}
 
 	if err := c.adminService.DeleteScreenshot(uint(ssID)); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete screenshot"})
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "failed to delete screenshot")
 		return
 	}
 
@@ -1243,12 +1461,12 @@ func (c *AdminController) DeleteScreenshot(ctx *gin.Context) {
 func (c *AdminController) BulkDeleteScreenshots(ctx *gin.Context) {
 	var req dto.AdminBulkDeleteRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.ValidationErrorResponse(ctx, err)
 		return
 	}
 
 	if err := c.adminService.BulkDeleteScreenshots(req.IDs); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -1265,15 +1483,16 @@ func (c *AdminController) BulkDeleteScreenshots(ctx *gin.Context) {
 // @Tags admin
 // @Produce json
 // @Security BearerAuth
+// @Param locale query string false "Locale for human-readable values (en/vi), defaults to Accept-Language"
 // @Success 200 {object} dto.AdminOverviewStats "Overview statistics"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 403 {object} dto.ErrorResponse "Forbidden"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error"
 // @Router /admin/stats/overview [get]
 func (c *AdminController) GetOverviewStats(ctx *gin.Context) {
-	stats, err := c.adminService.GetOverviewStats()
+	stats, err := c.analyticsService.GetOverviewStats(requestLocale(ctx))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -1286,9 +1505,12 @@ func (c *AdminController) GetOverviewStats(ctx *gin.Context) {
 // @Tags admin
 // @Produce json
 // @Security BearerAuth
-// @Param period query string false "Period (day/week/month)" default(day)
+// @Param locale query string false "Locale for human-readable values (en/vi), defaults to Accept-Language"
+// @Param period query string false "Period (day/week/month/quarter/year)" default(day)
+// @Param org_id query int false "Organization whose working-week and fiscal calendar is used for grouping"
 // @Param start_date query string false "Start date (YYYY-MM-DD)"
 // @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Param tz query string false "IANA time zone days are grouped in, defaults to the organization's timezone or UTC"
 // @Success 200 {object} dto.AdminTrendStats "Trend statistics"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 403 {object} dto.ErrorResponse "Forbidden"
@@ -1315,9 +1537,21 @@ func (c *AdminController) GetTrendStats(ctx *gin.Context) {
 		}
 	}
 
-	stats, err := c.adminService.GetTrendStats(req)
+	if ctx.Query("org_id") != "" {
+		orgID := uint(parseIntParam(ctx, "org_id", 0))
+		req.OrgID = &orgID
+	}
+
+	loc, err := parseTimezoneParam(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
+		return
+	}
+	req.Location = loc
+
+	stats, err := c.analyticsService.GetTrendStats(req, requestLocale(ctx))
+	if err != nil {
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -1326,22 +1560,63 @@ func (c *AdminController) GetTrendStats(ctx *gin.Context) {
 
 // GetUserPerformanceStats gets user performance statistics
 // @Summary Get user performance stats (admin only)
-// @Description Get top performing users statistics
+// @Description Get top performing users by time tracked in a period: all time, this week or month of the organization calendar, or the custom start_date to end_date, optionally in one organization or workspace. Each user's tracked hours in the schedule window are compared with the expected hours of their work schedule: schedule_status is on_track within 10%, otherwise under or over, and unscheduled for users without a schedule. Holidays of the user's organizations (holiday_days) and working days on approved leave (leave_days) are not expected.
 // @Tags admin
 // @Produce json
 // @Security BearerAuth
+// @Param locale query string false "Locale for human-readable values (en/vi), defaults to Accept-Language"
 // @Param limit query int false "Number of top users" default(10)
+// @Param period query string false "Ranked period: all, week, month or custom" default(all)
+// @Param org_id query int false "Only time tracked in this organization"
+// @Param workspace_id query int false "Only time tracked in this workspace"
+// @Param start_date query string false "Schedule window and custom period start date (YYYY-MM-DD), defaults to 6 days before end_date"
+// @Param end_date query string false "Schedule window and custom period end date (YYYY-MM-DD, inclusive), defaults to today"
 // @Success 200 {array} dto.AdminUserPerformance "User performance list"
+// @Failure 400 {object} dto.ErrorResponse "Invalid date range"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 403 {object} dto.ErrorResponse "Forbidden"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error"
 // @Router /admin/stats/user-performance [get]
 func (c *AdminController) GetUserPerformanceStats(ctx *gin.Context) {
-	limit := parseIntParam(ctx, "limit", 10)
+	params := &dto.AdminUserPerformanceParams{
+		Limit:  parseIntParam(ctx, "limit", 10),
+		Period: ctx.Query("period"),
+	}
+	if ctx.Query("org_id") != "" {
+		orgID := uint(parseIntParam(ctx, "org_id", 0))
+		params.OrgID = &orgID
+	}
+	if ctx.Query("workspace_id") != "" {
+		workspaceID := uint(parseIntParam(ctx, "workspace_id", 0))
+		params.WorkspaceID = &workspaceID
+	}
 
-	stats, err := c.adminService.GetUserPerformanceStats(limit)
+	// Default to the last 7 days
+	params.EndDate = time.Now()
+	if ctx.Query("end_date") != "" {
+		if t, err := time.Parse("2006-01-02", ctx.Query("end_date")); err == nil {
+			params.EndDate = t
+		}
+	}
+	params.StartDate = params.EndDate.AddDate(0, 0, -6)
+	if ctx.Query("start_date") != "" {
+		if t, err := time.Parse("2006-01-02", ctx.Query("start_date")); err == nil {
+			params.StartDate = t
+		}
+	}
+
+	if params.EndDate.Before(params.StartDate) || params.EndDate.Sub(params.StartDate) > 366*24*time.Hour {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid date range: end_date must not be before start_date and the range cannot exceed 366 days")
+		return
+	}
+
+	stats, err := c.analyticsService.GetUserPerformanceStats(params, requestLocale(ctx))
+	if errors.Is(err, service.ErrInvalidLeaderboardPeriod) {
+		utils.RespondError(ctx, err)
+		return
+	}
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -1354,15 +1629,16 @@ func (c *AdminController) GetUserPerformanceStats(ctx *gin.Context) {
 // @Tags admin
 // @Produce json
 // @Security BearerAuth
+// @Param locale query string false "Locale for human-readable values (en/vi), defaults to Accept-Language"
 // @Success 200 {object} dto.AdminOrgStats "Organization statistics"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 403 {object} dto.ErrorResponse "Forbidden"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error"
 // @Router /admin/stats/org-distribution [get]
 func (c *AdminController) GetOrgDistributionStats(ctx *gin.Context) {
-	stats, err := c.adminService.GetOrgDistributionStats()
+	stats, err := c.analyticsService.GetOrgDistributionStats(requestLocale(ctx))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -1375,15 +1651,16 @@ func (c *AdminController) GetOrgDistributionStats(ctx *gin.Context) {
 // @Tags admin
 // @Produce json
 // @Security BearerAuth
+// @Param locale query string false "Locale for human-readable values (en/vi), defaults to Accept-Language"
 // @Success 200 {object} dto.AdminActivityStats "Activity statistics"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 403 {object} dto.ErrorResponse "Forbidden"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error"
 // @Router /admin/stats/activity [get]
 func (c *AdminController) GetActivityStats(ctx *gin.Context) {
-	stats, err := c.adminService.GetActivityStats()
+	stats, err := c.analyticsService.GetActivityStats(requestLocale(ctx))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -1396,6 +1673,7 @@ func (c *AdminController) GetActivityStats(ctx *gin.Context) {
 // @Tags admin
 // @Produce json
 // @Security BearerAuth
+// @Param locale query string false "Locale for human-readable values (en/vi), defaults to Accept-Language"
 // @Success 200 {object} dto.AdminOverviewStats "System statistics"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 403 {object} dto.ErrorResponse "Forbidden"
@@ -1417,3 +1695,12 @@ func parseIntParam(ctx *gin.Context, name string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+// requestLocale resolves the locale for human-readable values from the
+// "locale" query parameter, falling back to the Accept-Language header
+func requestLocale(ctx *gin.Context) format.Locale {
+	if locale := ctx.Query("locale"); locale != "" {
+		return format.ParseLocale(locale)
+	}
+	return format.ParseLocale(ctx.GetHeader("Accept-Language"))
+}
@@ -0,0 +1,152 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminDeviceLogController lets system admins inspect desktop app log bundles
+type AdminDeviceLogController struct {
+	deviceLogService service.DeviceLogService
+}
+
+// NewAdminDeviceLogController creates a new admin device log controller
+func NewAdminDeviceLogController(deviceLogService service.DeviceLogService) *AdminDeviceLogController {
+	return &AdminDeviceLogController{
+		deviceLogService: deviceLogService,
+	}
+}
+
+// ListDeviceLogs lists uploaded log bundles
+// @Summary List device log bundles (admin only)
+// @Description Get uploaded desktop app log bundles with device and user details, newest first
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Param user_id query int false "Filter by user ID"
+// @Param device_id query int false "Filter by device ID"
+// @Success 200 {object} dto.AdminDeviceLogListResponse "Log bundle list"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /admin/device-logs [get]
+func (c *AdminDeviceLogController) ListDeviceLogs(ctx *gin.Context) {
+	params := &dto.AdminDeviceLogListParams{
+		Page:     parseIntParam(ctx, "page", 1),
+		PageSize: parseIntParam(ctx, "page_size", 20),
+	}
+
+	if ctx.Query("user_id") != "" {
+		userID := uint(parseIntParam(ctx, "user_id", 0))
+		params.UserID = &userID
+	}
+
+	if ctx.Query("device_id") != "" {
+		deviceID := uint(parseIntParam(ctx, "device_id", 0))
+		params.DeviceID = &deviceID
+	}
+
+	result, err := c.deviceLogService.List(params)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// GetDeviceLog gets a log bundle by ID
+// @Summary Get device log bundle (admin only)
+// @Description Get log bundle details including the uploading device
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Log bundle ID"
+// @Success 200 {object} dto.AdminDeviceLogResponse "Log bundle details"
+// @Failure 400 {object} dto.ErrorResponse "Invalid log bundle ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Log bundle not found"
+// @Router /admin/device-logs/{id} [get]
+func (c *AdminDeviceLogController) GetDeviceLog(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid log bundle ID")
+		return
+	}
+
+	bundle, err := c.deviceLogService.Get(uint(id))
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, bundle)
+}
+
+// DownloadDeviceLog serves the log bundle file
+// @Summary Download device log bundle (admin only)
+// @Description Download the uploaded log bundle file
+// @Tags admin
+// @Produce application/octet-stream
+// @Security BearerAuth
+// @Param id path int true "Log bundle ID"
+// @Success 200 {file} file "Log bundle"
+// @Failure 400 {object} dto.ErrorResponse "Invalid log bundle ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Log bundle not found"
+// @Router /admin/device-logs/{id}/download [get]
+func (c *AdminDeviceLogController) DownloadDeviceLog(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid log bundle ID")
+		return
+	}
+
+	bundle, err := c.deviceLogService.GetFile(uint(id))
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	fileName := bundle.OriginalName
+	if fileName == "" {
+		fileName = bundle.FileName
+	}
+	ctx.FileAttachment(bundle.FilePath, fileName)
+}
+
+// DeleteDeviceLog deletes a log bundle
+// @Summary Delete device log bundle (admin only)
+// @Description Delete a log bundle and its file
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Log bundle ID"
+// @Success 200 {object} map[string]interface{} "Log bundle deleted"
+// @Failure 400 {object} dto.ErrorResponse "Invalid log bundle ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Log bundle not found"
+// @Router /admin/device-logs/{id} [delete]
+func (c *AdminDeviceLogController) DeleteDeviceLog(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid log bundle ID")
+		return
+	}
+
+	if err := c.deviceLogService.Delete(uint(id)); err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "log bundle deleted"})
+}
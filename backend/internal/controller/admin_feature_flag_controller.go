@@ -0,0 +1,216 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminFeatureFlagController lets system admins manage feature flags and
+// their per-organization and per-user overrides
+type AdminFeatureFlagController struct {
+	flagService service.FeatureFlagService
+}
+
+// NewAdminFeatureFlagController creates a new admin feature flag controller
+func NewAdminFeatureFlagController(flagService service.FeatureFlagService) *AdminFeatureFlagController {
+	return &AdminFeatureFlagController{
+		flagService: flagService,
+	}
+}
+
+// ListFlags lists feature flags
+// @Summary List feature flags (admin only)
+// @Description Get all feature flags with their overrides, by key
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Feature flags"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /admin/feature-flags [get]
+func (c *AdminFeatureFlagController) ListFlags(ctx *gin.Context) {
+	flags, err := c.flagService.ListFlags()
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"flags": flags})
+}
+
+// CreateFlag defines a feature flag
+// @Summary Create feature flag (admin only)
+// @Description Define a feature flag. It is on for everyone when enabled, else for rollout_percent of organizations (users outside an organization are bucketed on their own). Capabilities without a flag are on.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.CreateFeatureFlagRequest true "Feature flag"
+// @Success 201 {object} dto.FeatureFlagResponse "Feature flag created"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 409 {object} dto.ErrorResponse "Key already exists"
+// @Router /admin/feature-flags [post]
+func (c *AdminFeatureFlagController) CreateFlag(ctx *gin.Context) {
+	var req dto.CreateFeatureFlagRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	flag, err := c.flagService.CreateFlag(&req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, flag)
+}
+
+// UpdateFlag updates a feature flag
+// @Summary Update feature flag (admin only)
+// @Description Change a flag's description, global state or rollout percentage. Raising the percentage keeps the organizations that already had the flag.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Feature flag ID"
+// @Param request body dto.UpdateFeatureFlagRequest true "Fields to change"
+// @Success 200 {object} dto.FeatureFlagResponse "Feature flag updated"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Feature flag not found"
+// @Router /admin/feature-flags/{id} [put]
+func (c *AdminFeatureFlagController) UpdateFlag(ctx *gin.Context) {
+	flagID, ok := parseFlagID(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateFeatureFlagRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	flag, err := c.flagService.UpdateFlag(flagID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, flag)
+}
+
+// DeleteFlag deletes a feature flag
+// @Summary Delete feature flag (admin only)
+// @Description Delete a flag and its overrides; the capability it gated is on for everyone again
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Feature flag ID"
+// @Success 200 {object} dto.SuccessResponse "Feature flag deleted"
+// @Failure 400 {object} dto.ErrorResponse "Invalid feature flag ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Feature flag not found"
+// @Router /admin/feature-flags/{id} [delete]
+func (c *AdminFeatureFlagController) DeleteFlag(ctx *gin.Context) {
+	flagID, ok := parseFlagID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.flagService.DeleteFlag(flagID); err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Feature flag deleted", nil)
+}
+
+// SetOverride turns a flag on or off for an organization or a user
+// @Summary Set feature flag override (admin only)
+// @Description Turn a flag on or off for one organization or one user, replacing any previous override of it. A user's override wins over their organization's.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Feature flag ID"
+// @Param request body dto.SetFeatureFlagOverrideRequest true "Override target and state"
+// @Success 200 {object} dto.FeatureFlagResponse "Override set"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Feature flag or organization not found"
+// @Router /admin/feature-flags/{id}/overrides [put]
+func (c *AdminFeatureFlagController) SetOverride(ctx *gin.Context) {
+	flagID, ok := parseFlagID(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.SetFeatureFlagOverrideRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	flag, err := c.flagService.SetOverride(flagID, userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, flag)
+}
+
+// DeleteOverride removes a feature flag override
+// @Summary Delete feature flag override (admin only)
+// @Description Remove an override; its organization or user follows the flag's rollout again
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Feature flag ID"
+// @Param override_id path int true "Override ID"
+// @Success 200 {object} dto.SuccessResponse "Override deleted"
+// @Failure 400 {object} dto.ErrorResponse "Invalid ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Override not found"
+// @Router /admin/feature-flags/{id}/overrides/{override_id} [delete]
+func (c *AdminFeatureFlagController) DeleteOverride(ctx *gin.Context) {
+	flagID, ok := parseFlagID(ctx)
+	if !ok {
+		return
+	}
+	overrideID, err := strconv.ParseUint(ctx.Param("override_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid override ID")
+		return
+	}
+
+	if err := c.flagService.DeleteOverride(flagID, uint(overrideID)); err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Override deleted", nil)
+}
+
+func parseFlagID(ctx *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid feature flag ID")
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/beuphecan/remote-time-tracker/internal/scheduler"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminJobsController exposes background job status to system admins
+type AdminJobsController struct {
+	scheduler *scheduler.Scheduler
+}
+
+// NewAdminJobsController creates a new admin jobs controller
+func NewAdminJobsController(s *scheduler.Scheduler) *AdminJobsController {
+	return &AdminJobsController{
+		scheduler: s,
+	}
+}
+
+// ListJobs returns the status of all scheduled jobs
+// @Summary List scheduled jobs (admin only)
+// @Description Get every registered background job with its schedule, next run and last run result on this instance
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Job statuses"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /admin/jobs [get]
+func (c *AdminJobsController) ListJobs(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, gin.H{"jobs": c.scheduler.Status()})
+}
+
+// RunJob triggers a scheduled job immediately
+// @Summary Run a job now (admin only)
+// @Description Trigger a registered job in the background. The run is skipped if another instance holds the job lock.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "Job name"
+// @Success 202 {object} map[string]interface{} "Job triggered"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Job not found"
+// @Failure 409 {object} dto.ErrorResponse "Job already running"
+// @Router /admin/jobs/{name}/run [post]
+func (c *AdminJobsController) RunJob(ctx *gin.Context) {
+	name := ctx.Param("name")
+
+	if err := c.scheduler.RunNow(name); err != nil {
+		switch {
+		case errors.Is(err, scheduler.ErrJobNotFound):
+			utils.ErrorResponse(ctx, http.StatusNotFound, "Job not found")
+		case errors.Is(err, scheduler.ErrJobRunning):
+			utils.ErrorResponse(ctx, http.StatusConflict, "Job is already running")
+		default:
+			utils.RespondError(ctx, err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, gin.H{"message": "job triggered", "name": name})
+}
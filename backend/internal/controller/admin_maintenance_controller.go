@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminMaintenanceController handles admin database maintenance requests
+type AdminMaintenanceController struct {
+	purgeService service.PurgeService
+}
+
+// NewAdminMaintenanceController creates a new admin maintenance controller
+func NewAdminMaintenanceController(purgeService service.PurgeService) *AdminMaintenanceController {
+	return &AdminMaintenanceController{
+		purgeService: purgeService,
+	}
+}
+
+// Purge permanently removes soft-deleted data
+// @Summary Purge soft-deleted data (admin only)
+// @Description Permanently delete rows (and screenshot files) soft-deleted more than older_than_days ago. Rows still referenced by live data are skipped. With dry_run the purgeable rows are only counted. With async the purge runs in the background and an operation is returned; poll /operations/{id} for progress and the result.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.AdminPurgeRequest false "Purge options"
+// @Success 200 {object} dto.AdminPurgeResponse "Purge result"
+// @Success 202 {object} dto.OperationResponse "Purge started (async)"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 500 {object} dto.ErrorResponse "Purge failed"
+// @Router /admin/maintenance/purge [post]
+func (c *AdminMaintenanceController) Purge(ctx *gin.Context) {
+	var req dto.AdminPurgeRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			utils.ValidationErrorResponse(ctx, err)
+			return
+		}
+	}
+
+	days := 0
+	if req.OlderThanDays != nil {
+		days = *req.OlderThanDays
+	}
+
+	if req.Async {
+		op, err := c.purgeService.StartPurge(days, req.DryRun, ctx.GetUint("userID"))
+		if err != nil {
+			utils.RespondError(ctx, err)
+			return
+		}
+		ctx.JSON(http.StatusAccepted, op)
+		return
+	}
+
+	result, err := c.purgeService.Purge(ctx.Request.Context(), days, req.DryRun)
+	if err != nil {
+		if result == nil {
+			utils.RespondError(ctx, err)
+			return
+		}
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminOvertimeController serves the overtime found by the overtime rules
+type AdminOvertimeController struct {
+	overtimeService service.OvertimeService
+}
+
+// NewAdminOvertimeController creates a new admin overtime controller
+func NewAdminOvertimeController(overtimeService service.OvertimeService) *AdminOvertimeController {
+	return &AdminOvertimeController{
+		overtimeService: overtimeService,
+	}
+}
+
+// GetReport gets the overtime report
+// @Summary Get overtime report (admin only)
+// @Description Get the days and weeks in which users with a work schedule tracked more than expected. The daily rule compares a day's tracked time with the expected hours per day, the weekly rule a week's (Monday to Sunday in the schedule's timezone) with the expected hours of its working days. Days off, holidays and leave days expect no time. Overtime is detected by a background job over the current and previous week.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param start_date query string false "First day or week start (YYYY-MM-DD), defaults to 30 days before end_date"
+// @Param end_date query string false "Last day or week start (YYYY-MM-DD), defaults to today"
+// @Param user_id query int false "Only this user"
+// @Param rule query string false "daily or weekly, defaults to both"
+// @Success 200 {object} dto.AdminOvertimeReport "Overtime report"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /admin/reports/overtime [get]
+func (c *AdminOvertimeController) GetReport(ctx *gin.Context) {
+	params := &dto.AdminOvertimeReportParams{
+		UserID: uint(parseIntParam(ctx, "user_id", 0)),
+		Rule:   ctx.Query("rule"),
+	}
+
+	// Default to the last 30 days
+	now := time.Now()
+	params.EndDate = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if ctx.Query("end_date") != "" {
+		t, err := time.Parse("2006-01-02", ctx.Query("end_date"))
+		if err != nil {
+			utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid end_date: use YYYY-MM-DD")
+			return
+		}
+		params.EndDate = t
+	}
+	params.StartDate = params.EndDate.AddDate(0, 0, -29)
+	if ctx.Query("start_date") != "" {
+		t, err := time.Parse("2006-01-02", ctx.Query("start_date"))
+		if err != nil {
+			utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid start_date: use YYYY-MM-DD")
+			return
+		}
+		params.StartDate = t
+	}
+
+	if params.EndDate.Before(params.StartDate) || params.EndDate.Sub(params.StartDate) > 366*24*time.Hour {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid date range: end_date must not be before start_date and the range cannot exceed 366 days")
+		return
+	}
+
+	report, err := c.overtimeService.GetReport(params)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
@@ -3,18 +3,123 @@ package controller
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
 	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
 	"github.com/gin-gonic/gin"
 )
 
-// AdminPresenceController handles admin presence stream
-type AdminPresenceController struct{}
+// presenceHistoryDefaultRange is the period returned when no range is given
+const presenceHistoryDefaultRange = 24 * time.Hour
+
+// AdminPresenceController handles the admin presence dashboard: current
+// presence per organization, the presence history and the live stream
+type AdminPresenceController struct {
+	presenceService service.PresenceService
+}
 
 // NewAdminPresenceController creates a new admin presence controller
-func NewAdminPresenceController() *AdminPresenceController {
-	return &AdminPresenceController{}
+func NewAdminPresenceController(presenceService service.PresenceService) *AdminPresenceController {
+	return &AdminPresenceController{
+		presenceService: presenceService,
+	}
+}
+
+// ListPresence lists the presence of an organization's members
+// @Summary Organization presence (admin only)
+// @Description Get the current presence status (working, online, idle, stale, offline) of every active member of an organization, with counts per status
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param org_id query int true "Organization ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.AdminPresenceListResponse} "Member presence"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Organization not found"
+// @Router /admin/presence [get]
+func (c *AdminPresenceController) ListPresence(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Query("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "org_id is required")
+		return
+	}
+
+	resp, err := c.presenceService.ListOrganization(uint(orgID))
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Presence retrieved", resp)
+}
+
+// GetPresenceHistory returns who was online when
+// @Summary Presence history (admin only)
+// @Description Get the presence intervals of an organization's members overlapping a period of at most 31 days (default: the last 24 hours). Pass at instead of from/to to see who was online at one moment. Time without an interval was spent offline.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param org_id query int true "Organization ID"
+// @Param user_id query int false "Filter by user"
+// @Param from query string false "Period start (RFC 3339)"
+// @Param to query string false "Period end (RFC 3339)"
+// @Param at query string false "Single moment (RFC 3339), instead of from and to"
+// @Success 200 {object} dto.SuccessResponse{data=dto.AdminPresenceHistoryResponse} "Presence intervals"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Organization not found"
+// @Router /admin/presence/history [get]
+func (c *AdminPresenceController) GetPresenceHistory(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Query("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "org_id is required")
+		return
+	}
+	params := &dto.AdminPresenceHistoryParams{OrgID: uint(orgID)}
+
+	if ctx.Query("user_id") != "" {
+		userID := uint(parseIntParam(ctx, "user_id", 0))
+		params.UserID = &userID
+	}
+
+	times := map[string]*time.Time{}
+	for _, name := range []string{"from", "to", "at"} {
+		if value := ctx.Query(name); value != "" {
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				utils.ErrorResponse(ctx, http.StatusBadRequest, fmt.Sprintf("invalid %s, expected RFC 3339 time", name))
+				return
+			}
+			times[name] = &t
+		}
+	}
+
+	switch {
+	case times["at"] != nil:
+		params.From, params.To = *times["at"], *times["at"]
+	default:
+		params.To = time.Now()
+		if times["to"] != nil {
+			params.To = *times["to"]
+		}
+		params.From = params.To.Add(-presenceHistoryDefaultRange)
+		if times["from"] != nil {
+			params.From = *times["from"]
+		}
+	}
+
+	resp, err := c.presenceService.History(params)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Presence history retrieved", resp)
 }
 
 // Stream provides an SSE stream of presence updates
@@ -48,7 +153,10 @@ func (c *AdminPresenceController) Stream(ctx *gin.Context) {
 		select {
 		case <-ctx.Request.Context().Done():
 			return
-		case payload := <-sub:
+		case payload, ok := <-sub:
+			if !ok {
+				return // Server shutting down
+			}
 			_, _ = fmt.Fprintf(ctx.Writer, "data: %s\n\n", payload)
 			flusher.Flush()
 		case <-pingTicker.C:
@@ -0,0 +1,133 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminReleaseController lets system admins publish desktop app releases to
+// release channels
+type AdminReleaseController struct {
+	updateService *service.UpdateService
+}
+
+// NewAdminReleaseController creates a new admin release controller
+func NewAdminReleaseController(updateService *service.UpdateService) *AdminReleaseController {
+	return &AdminReleaseController{
+		updateService: updateService,
+	}
+}
+
+// ListReleases lists published releases
+// @Summary List published releases (admin only)
+// @Description Get the desktop app releases published to release channels, newest first
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param channel query string false "Filter by channel (stable, beta)"
+// @Success 200 {object} map[string]interface{} "Published releases"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /admin/updates/releases [get]
+func (c *AdminReleaseController) ListReleases(ctx *gin.Context) {
+	releases, err := c.updateService.ListReleases(ctx.Query("channel"))
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"releases": releases})
+}
+
+// PublishRelease publishes a release
+// @Summary Publish release (admin only)
+// @Description Publish a desktop app release with the URLs and SHA-512 checksums of its installers, block maps and deltas to the stable or beta channel. Update checks on the channel offer it from then on, ahead of GitHub releases; a delta is only offered to clients on its from_version. A mandatory release makes it the minimum version of its channel: older clients are told to upgrade and their syncs fail with 426 upgrade_required.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.PublishReleaseRequest true "Release metadata and assets"
+// @Success 201 {object} dto.AppReleaseResponse "Release published"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 409 {object} dto.ErrorResponse "Version already published"
+// @Router /admin/updates/releases [post]
+func (c *AdminReleaseController) PublishRelease(ctx *gin.Context) {
+	var req dto.PublishReleaseRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	release, err := c.updateService.PublishRelease(userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, release)
+}
+
+// ListDownloadStats aggregates release downloads by version
+// @Summary Download statistics by release (admin only)
+// @Description Count downloads of each app version with installer, block map and delta downloads split out, bytes served and unique users, most recently downloaded first
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param days query int false "Look-back window in days" default(30)
+// @Param platform query string false "Filter by platform (darwin, win32, linux)"
+// @Param limit query int false "Maximum releases" default(20)
+// @Success 200 {object} map[string]interface{} "Release download summaries"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /admin/updates/downloads [get]
+func (c *AdminReleaseController) ListDownloadStats(ctx *gin.Context) {
+	params := &dto.AdminReleaseDownloadParams{
+		Days:     parseIntParam(ctx, "days", 30),
+		Platform: ctx.Query("platform"),
+		Limit:    parseIntParam(ctx, "limit", 20),
+	}
+
+	releases, err := c.updateService.ListDownloadStats(params)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"releases": releases, "days": params.Days})
+}
+
+// WithdrawRelease withdraws a published release
+// @Summary Withdraw release (admin only)
+// @Description Unpublish a desktop app release. If it was mandatory, the minimum version of its channel falls back to the previous mandatory release.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Release ID"
+// @Success 200 {object} dto.SuccessResponse "Release withdrawn"
+// @Failure 400 {object} dto.ErrorResponse "Invalid release ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Release not found"
+// @Router /admin/updates/releases/{id} [delete]
+func (c *AdminReleaseController) WithdrawRelease(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid release ID")
+		return
+	}
+
+	if err := c.updateService.WithdrawRelease(uint(id)); err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Release withdrawn", nil)
+}
@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminRetentionController handles admin data retention requests
+type AdminRetentionController struct {
+	retentionService service.RetentionService
+}
+
+// NewAdminRetentionController creates a new admin retention controller
+func NewAdminRetentionController(retentionService service.RetentionService) *AdminRetentionController {
+	return &AdminRetentionController{
+		retentionService: retentionService,
+	}
+}
+
+// PreviewScreenshotRetention previews a screenshot retention policy
+// @Summary Preview screenshot retention (admin only)
+// @Description Show how many screenshots and how much storage a retention policy would reclaim for an organization. Uses the organization's configured retention unless days is given.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Organization ID"
+// @Param days query int false "Retention in days to preview (overrides the organization setting)"
+// @Param locale query string false "Locale for human-readable values (en, vi)"
+// @Success 200 {object} dto.AdminRetentionPreviewResponse "Retention preview"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /admin/organizations/{id}/retention/preview [get]
+func (c *AdminRetentionController) PreviewScreenshotRetention(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	var days *int
+	if ctx.Query("days") != "" {
+		d, err := strconv.Atoi(ctx.Query("days"))
+		if err != nil || d < 1 {
+			utils.ErrorResponse(ctx, http.StatusBadRequest, "days must be a positive integer")
+			return
+		}
+		days = &d
+	}
+
+	preview, err := c.retentionService.PreviewScreenshotRetention(uint(orgID), days, requestLocale(ctx))
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, preview)
+}
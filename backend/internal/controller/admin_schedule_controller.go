@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminScheduleController lets system admins manage users' work schedules
+type AdminScheduleController struct {
+	scheduleService service.ScheduleService
+}
+
+// NewAdminScheduleController creates a new admin work schedule controller
+func NewAdminScheduleController(scheduleService service.ScheduleService) *AdminScheduleController {
+	return &AdminScheduleController{
+		scheduleService: scheduleService,
+	}
+}
+
+// ListSchedules lists work schedules
+// @Summary List work schedules (admin only)
+// @Description Get users' work schedules: working days, expected hours per day and timezone
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} dto.WorkScheduleListResponse "Work schedule list"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /admin/schedules [get]
+func (c *AdminScheduleController) ListSchedules(ctx *gin.Context) {
+	result, err := c.scheduleService.ListSchedules(parseIntParam(ctx, "page", 1), parseIntParam(ctx, "page_size", 20))
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// GetSchedule gets a user's work schedule
+// @Summary Get user work schedule (admin only)
+// @Description Get the work schedule a user's tracked hours are compared against
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 200 {object} dto.WorkScheduleResponse "Work schedule"
+// @Failure 400 {object} dto.ErrorResponse "Invalid user ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Work schedule not found"
+// @Router /admin/users/{id}/schedule [get]
+func (c *AdminScheduleController) GetSchedule(ctx *gin.Context) {
+	userID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	schedule, err := c.scheduleService.GetSchedule(uint(userID))
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, schedule)
+}
+
+// UpdateSchedule sets a user's work schedule
+// @Summary Set user work schedule (admin only)
+// @Description Create or replace a user's work schedule. Working days are 0 (Sunday) to 6 (Saturday); the timezone is an IANA name and defaults to UTC.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Param request body dto.UpdateWorkScheduleRequest true "Work schedule"
+// @Success 200 {object} dto.WorkScheduleResponse "Work schedule saved"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "User not found"
+// @Router /admin/users/{id}/schedule [put]
+func (c *AdminScheduleController) UpdateSchedule(ctx *gin.Context) {
+	userID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	var req dto.UpdateWorkScheduleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	schedule, err := c.scheduleService.UpdateSchedule(uint(userID), ctx.GetUint("userID"), &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, schedule)
+}
+
+// DeleteSchedule removes a user's work schedule
+// @Summary Delete user work schedule (admin only)
+// @Description Remove a user's work schedule; the user then shows as unscheduled in the performance stats
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 200 {object} dto.SuccessResponse "Work schedule deleted"
+// @Failure 400 {object} dto.ErrorResponse "Invalid user ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Work schedule not found"
+// @Router /admin/users/{id}/schedule [delete]
+func (c *AdminScheduleController) DeleteSchedule(ctx *gin.Context) {
+	userID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	if err := c.scheduleService.DeleteSchedule(uint(userID)); err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "work schedule deleted"})
+}
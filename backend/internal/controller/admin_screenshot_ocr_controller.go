@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminScreenshotOCRController handles the admin search through the text
+// read from screenshots by the OCR job
+type AdminScreenshotOCRController struct {
+	ocrService service.ScreenshotOCRService
+}
+
+// NewAdminScreenshotOCRController creates a new admin screenshot OCR controller
+func NewAdminScreenshotOCRController(ocrService service.ScreenshotOCRService) *AdminScreenshotOCRController {
+	return &AdminScreenshotOCRController{
+		ocrService: ocrService,
+	}
+}
+
+// SearchScreenshots searches the text shown in screenshots
+// @Summary Search screenshot text (admin only)
+// @Description Find the screenshots showing every word of the query, e.g. a document title or URL, most recent first. Only screenshots read by the OCR job are searched; encrypted ones never are.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param q query string true "Words to search for"
+// @Param user_id query int false "Filter by user"
+// @Param org_id query int false "Filter by organization"
+// @Param start_date query string false "Filter by start date (YYYY-MM-DD)"
+// @Param end_date query string false "Filter by end date (YYYY-MM-DD)"
+// @Param limit query int false "Maximum results (max 200)" default(50)
+// @Success 200 {object} dto.AdminScreenshotSearchResponse "Matching screenshots"
+// @Failure 400 {object} dto.ErrorResponse "Invalid search"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /admin/screenshots/search [get]
+func (c *AdminScreenshotOCRController) SearchScreenshots(ctx *gin.Context) {
+	params := &dto.AdminScreenshotSearchParams{
+		Query: ctx.Query("q"),
+		Limit: parseIntParam(ctx, "limit", 0),
+	}
+
+	if ctx.Query("user_id") != "" {
+		userID := uint(parseIntParam(ctx, "user_id", 0))
+		params.UserID = &userID
+	}
+
+	if ctx.Query("org_id") != "" {
+		orgID := uint(parseIntParam(ctx, "org_id", 0))
+		params.OrgID = &orgID
+	}
+
+	if ctx.Query("start_date") != "" {
+		t, err := time.Parse("2006-01-02", ctx.Query("start_date"))
+		if err != nil {
+			utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid start_date, expected YYYY-MM-DD")
+			return
+		}
+		params.StartDate = &t
+	}
+
+	if ctx.Query("end_date") != "" {
+		t, err := time.Parse("2006-01-02", ctx.Query("end_date"))
+		if err != nil {
+			utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid end_date, expected YYYY-MM-DD")
+			return
+		}
+		t = t.Add(24*time.Hour - time.Second)
+		params.EndDate = &t
+	}
+
+	result, err := c.ocrService.Search(params)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
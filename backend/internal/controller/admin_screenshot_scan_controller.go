@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminScreenshotScanController handles the admin review of screenshots
+// quarantined by the malware scanner. Quarantined screenshots are listed with
+// GET /admin/screenshots?scan_status=quarantined and viewed with
+// GET /admin/screenshots/{id}/view.
+type AdminScreenshotScanController struct {
+	scanService service.ScreenshotScanService
+}
+
+// NewAdminScreenshotScanController creates a new admin screenshot scan controller
+func NewAdminScreenshotScanController(scanService service.ScreenshotScanService) *AdminScreenshotScanController {
+	return &AdminScreenshotScanController{
+		scanService: scanService,
+	}
+}
+
+// ReleaseScreenshot releases a quarantined screenshot
+// @Summary Release quarantined screenshot (admin only)
+// @Description Mark a screenshot flagged by the malware scanner as a false positive, so its members can see it again
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Screenshot ID"
+// @Success 200 {object} dto.SuccessResponse "Screenshot released"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Screenshot not found"
+// @Failure 409 {object} dto.ErrorResponse "Screenshot is not quarantined"
+// @Router /admin/screenshots/{id}/quarantine/release [post]
+func (c *AdminScreenshotScanController) ReleaseScreenshot(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid screenshot ID")
+		return
+	}
+
+	if err := c.scanService.Release(uint(id), ctx.GetUint("userID")); err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Screenshot released", nil)
+}
+
+// DeleteQuarantinedScreenshot deletes a quarantined screenshot
+// @Summary Delete quarantined screenshot (admin only)
+// @Description Delete a screenshot flagged by the malware scanner together with its file, without waiting for the purge of deleted screenshots
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Screenshot ID"
+// @Success 200 {object} dto.SuccessResponse "Screenshot deleted"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Screenshot not found"
+// @Failure 409 {object} dto.ErrorResponse "Screenshot is not quarantined"
+// @Router /admin/screenshots/{id}/quarantine [delete]
+func (c *AdminScreenshotScanController) DeleteQuarantinedScreenshot(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid screenshot ID")
+		return
+	}
+
+	if err := c.scanService.DeleteQuarantined(uint(id)); err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Screenshot deleted", nil)
+}
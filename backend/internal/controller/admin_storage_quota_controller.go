@@ -0,0 +1,119 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminStorageQuotaController handles admin screenshot storage quota requests
+type AdminStorageQuotaController struct {
+	storageQuotaService service.StorageQuotaService
+}
+
+// NewAdminStorageQuotaController creates a new admin storage quota controller
+func NewAdminStorageQuotaController(storageQuotaService service.StorageQuotaService) *AdminStorageQuotaController {
+	return &AdminStorageQuotaController{
+		storageQuotaService: storageQuotaService,
+	}
+}
+
+// GetQuota returns an organization's storage quota and usage
+// @Summary Get organization storage quota (admin only)
+// @Description Get an organization's screenshot storage quota and current usage
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Organization ID"
+// @Param locale query string false "Locale for human-readable values (en, vi)"
+// @Success 200 {object} dto.AdminStorageQuotaResponse "Storage quota"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Organization not found"
+// @Router /admin/organizations/{id}/storage-quota [get]
+func (c *AdminStorageQuotaController) GetQuota(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	quota, err := c.storageQuotaService.Get(uint(orgID), requestLocale(ctx))
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, quota)
+}
+
+// UpdateQuota sets or clears an organization's storage quota
+// @Summary Update organization storage quota (admin only)
+// @Description Set an organization's screenshot storage quota in bytes, or clear it with a null quota_bytes. Screenshot uploads over the quota are rejected during sync.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Organization ID"
+// @Param locale query string false "Locale for human-readable values (en, vi)"
+// @Param request body dto.AdminUpdateStorageQuotaRequest true "Storage quota"
+// @Success 200 {object} dto.AdminStorageQuotaResponse "Updated storage quota"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /admin/organizations/{id}/storage-quota [put]
+func (c *AdminStorageQuotaController) UpdateQuota(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	var req dto.AdminUpdateStorageQuotaRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	quota, err := c.storageQuotaService.Update(uint(orgID), &req, requestLocale(ctx))
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, quota)
+}
+
+// Recount recomputes an organization's storage usage
+// @Summary Recount organization storage usage (admin only)
+// @Description Recompute an organization's screenshot storage usage from its screenshots, e.g. after files were removed outside the application
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Organization ID"
+// @Param locale query string false "Locale for human-readable values (en, vi)"
+// @Success 200 {object} dto.AdminStorageQuotaResponse "Recounted storage quota"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /admin/organizations/{id}/storage-quota/recount [post]
+func (c *AdminStorageQuotaController) Recount(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	quota, err := c.storageQuotaService.Recount(uint(orgID), requestLocale(ctx))
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, quota)
+}
@@ -0,0 +1,138 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminTelemetryController exposes crash aggregates per release and feature usage to system admins
+type AdminTelemetryController struct {
+	telemetryService service.TelemetryService
+}
+
+// NewAdminTelemetryController creates a new admin telemetry controller
+func NewAdminTelemetryController(telemetryService service.TelemetryService) *AdminTelemetryController {
+	return &AdminTelemetryController{
+		telemetryService: telemetryService,
+	}
+}
+
+// ListReleaseCrashes aggregates crash reports by release
+// @Summary Crash statistics by release (admin only)
+// @Description Aggregate desktop crash reports per app version with affected devices and crash-free rate, to inform update rollout decisions
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param days query int false "Look-back window in days" default(30)
+// @Param platform query string false "Filter by platform (darwin, win32, linux)"
+// @Param limit query int false "Maximum releases" default(20)
+// @Success 200 {object} map[string]interface{} "Release crash summaries"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /admin/updates/crashes [get]
+func (c *AdminTelemetryController) ListReleaseCrashes(ctx *gin.Context) {
+	params := crashListParams(ctx)
+
+	releases, err := c.telemetryService.ListReleaseCrashes(params)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"releases": releases, "days": params.Days})
+}
+
+// GetReleaseCrashes shows the most frequent crashes of a release
+// @Summary Crash details of a release (admin only)
+// @Description Get a release's crash summary and its crash groups by stack hash, most frequent first
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param version path string true "App version"
+// @Param days query int false "Look-back window in days" default(30)
+// @Param platform query string false "Filter by platform (darwin, win32, linux)"
+// @Param limit query int false "Maximum crash groups" default(20)
+// @Success 200 {object} dto.AdminReleaseCrashResponse "Release crash details"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "No crash reports for this release"
+// @Router /admin/updates/crashes/{version} [get]
+func (c *AdminTelemetryController) GetReleaseCrashes(ctx *gin.Context) {
+	result, err := c.telemetryService.GetReleaseCrashes(ctx.Param("version"), crashListParams(ctx))
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func crashListParams(ctx *gin.Context) *dto.AdminCrashListParams {
+	return &dto.AdminCrashListParams{
+		Days:     parseIntParam(ctx, "days", 30),
+		Platform: ctx.Query("platform"),
+		Limit:    parseIntParam(ctx, "limit", 20),
+	}
+}
+
+// ListFeatureUsage aggregates usage events by feature
+// @Summary Feature usage (admin only)
+// @Description Aggregate feature usage events with the number of organizations and users using each feature and its adoption rate among active organizations. Opted-out organizations are excluded.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param days query int false "Look-back window in days" default(30)
+// @Param org_id query int false "Filter by organization ID"
+// @Param limit query int false "Maximum features" default(50)
+// @Success 200 {object} dto.AdminFeatureUsageResponse "Feature usage"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /admin/usage/features [get]
+func (c *AdminTelemetryController) ListFeatureUsage(ctx *gin.Context) {
+	result, err := c.telemetryService.ListFeatureUsage(featureUsageParams(ctx))
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// GetFeatureUsage shows which organizations use a feature
+// @Summary Feature usage by organization (admin only)
+// @Description Get the organizations using a feature, most active first
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param feature path string true "Feature name"
+// @Param days query int false "Look-back window in days" default(30)
+// @Param limit query int false "Maximum organizations" default(50)
+// @Success 200 {object} dto.AdminFeatureDetailResponse "Feature usage by organization"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /admin/usage/features/{feature} [get]
+func (c *AdminTelemetryController) GetFeatureUsage(ctx *gin.Context) {
+	result, err := c.telemetryService.GetFeatureUsage(ctx.Param("feature"), featureUsageParams(ctx))
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func featureUsageParams(ctx *gin.Context) *dto.AdminFeatureUsageParams {
+	params := &dto.AdminFeatureUsageParams{
+		Days:  parseIntParam(ctx, "days", 30),
+		Limit: parseIntParam(ctx, "limit", 50),
+	}
+	if ctx.Query("org_id") != "" {
+		orgID := uint(parseIntParam(ctx, "org_id", 0))
+		params.OrgID = &orgID
+	}
+	return params
+}
@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// AnalyticsController handles organization analytics query requests
+type AnalyticsController struct {
+	analyticsService service.AnalyticsService
+}
+
+// NewAnalyticsController creates a new analytics controller
+func NewAnalyticsController(analyticsService service.AnalyticsService) *AnalyticsController {
+	return &AnalyticsController{
+		analyticsService: analyticsService,
+	}
+}
+
+// ListQueries lists the predefined analytics queries
+// @Summary List analytics queries
+// @Description List the predefined analytics queries and the parameters they accept
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Success 200 {array} dto.AnalyticsQueryInfo "Available queries"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Router /organizations/{org_id}/analytics/queries [get]
+func (c *AnalyticsController) ListQueries(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, c.analyticsService.ListQueries())
+}
+
+// RunQuery runs a predefined analytics query
+// @Summary Run analytics query
+// @Description Run a predefined, parameterized read-only query scoped to the organization. Owners and admins see all members; other members only see their own records.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param request body dto.AnalyticsQueryRequest true "Query name and parameters"
+// @Success 200 {object} dto.AnalyticsQueryResponse "Query result"
+// @Failure 400 {object} dto.ErrorResponse "Invalid query or parameters"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/analytics/query [post]
+func (c *AnalyticsController) RunQuery(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	var req dto.AnalyticsQueryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	result, err := c.analyticsService.Run(uint(orgID), userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
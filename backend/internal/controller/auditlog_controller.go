@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// AuditLogController handles audit log HTTP requests
+type AuditLogController struct {
+	auditService service.AuditService
+}
+
+// NewAuditLogController creates a new audit log controller
+func NewAuditLogController(auditService service.AuditService) *AuditLogController {
+	return &AuditLogController{
+		auditService: auditService,
+	}
+}
+
+// ListAuditLogs lists audit log entries
+// @Summary List audit logs (admin only)
+// @Description Get paginated list of audit log entries with filtering
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Items per page" default(20)
+// @Param user_id query int false "Filter by acting user"
+// @Param entity_type query string false "Filter by entity type (user, organization, workspace, task, time_log, ...)"
+// @Param entity_id query int false "Filter by entity ID"
+// @Param action query string false "Filter by action (login, create, update, delete, ...)"
+// @Param status query string false "Filter by status (success/failed)"
+// @Param start_date query string false "Filter from date (YYYY-MM-DD)"
+// @Param end_date query string false "Filter to date (YYYY-MM-DD)"
+// @Param sort_order query string false "Sort order (asc/desc)" default(desc)
+// @Success 200 {object} dto.AdminAuditLogListResponse "Audit log list"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /admin/audit-logs [get]
+func (c *AuditLogController) ListAuditLogs(ctx *gin.Context) {
+	params := &dto.AdminAuditLogListParams{
+		Page:       parseIntParam(ctx, "page", 1),
+		PageSize:   parseIntParam(ctx, "page_size", 20),
+		EntityType: ctx.Query("entity_type"),
+		Action:     ctx.Query("action"),
+		Status:     ctx.Query("status"),
+		SortOrder:  ctx.Query("sort_order"),
+	}
+
+	if ctx.Query("user_id") != "" {
+		userID := uint(parseIntParam(ctx, "user_id", 0))
+		params.UserID = &userID
+	}
+
+	if ctx.Query("entity_id") != "" {
+		entityID := uint(parseIntParam(ctx, "entity_id", 0))
+		params.EntityID = &entityID
+	}
+
+	if ctx.Query("start_date") != "" {
+		if t, err := time.Parse("2006-01-02", ctx.Query("start_date")); err == nil {
+			params.StartDate = &t
+		}
+	}
+
+	if ctx.Query("end_date") != "" {
+		if t, err := time.Parse("2006-01-02", ctx.Query("end_date")); err == nil {
+			t = t.Add(24*time.Hour - time.Second) // End of day
+			params.EndDate = &t
+		}
+	}
+
+	result, err := c.auditService.List(params)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
@@ -34,13 +34,13 @@ func NewAuthController(authService service.AuthService) *AuthController {
 func (ctrl *AuthController) Register(c *gin.Context) {
 	var req dto.RegisterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		utils.ValidationErrorResponse(c, err)
 		return
 	}
 
 	response, err := ctrl.authService.Register(&req)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -60,13 +60,13 @@ func (ctrl *AuthController) Register(c *gin.Context) {
 func (ctrl *AuthController) Login(c *gin.Context) {
 	var req dto.LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		utils.ValidationErrorResponse(c, err)
 		return
 	}
 
 	response, err := ctrl.authService.Login(&req)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusUnauthorized, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -89,19 +89,69 @@ func (ctrl *AuthController) RefreshToken(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		utils.ValidationErrorResponse(c, err)
 		return
 	}
 
 	response, err := ctrl.authService.RefreshToken(req.RefreshToken)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusUnauthorized, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
 	utils.SuccessResponse(c, http.StatusOK, "Token refreshed successfully", response)
 }
 
+// ForgotPassword emails a password reset link
+// @Summary Request a password reset
+// @Description Email a single-use password reset link. Always succeeds so the endpoint does not reveal which emails have accounts.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.ForgotPasswordRequest true "Account email"
+// @Success 200 {object} dto.SuccessResponse "Reset link sent if the account exists"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Router /auth/password/forgot [post]
+func (ctrl *AuthController) ForgotPassword(c *gin.Context) {
+	var req dto.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	if err := ctrl.authService.RequestPasswordReset(&req); err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "If the account exists, a reset link has been sent", nil)
+}
+
+// ResetPassword sets a new password with a reset token
+// @Summary Reset password
+// @Description Set a new password using the token from a password reset email
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.ResetPasswordRequest true "Reset token and new password"
+// @Success 200 {object} dto.SuccessResponse "Password reset"
+// @Failure 400 {object} dto.ErrorResponse "Invalid, used or expired token"
+// @Router /auth/password/reset [post]
+func (ctrl *AuthController) ResetPassword(c *gin.Context) {
+	var req dto.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	if err := ctrl.authService.ResetPassword(&req); err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Password reset successfully", nil)
+}
+
 // Me returns current user info
 // @Summary Get current user info
 // @Description Get authenticated user's profile information
@@ -128,6 +178,53 @@ func (ctrl *AuthController) Me(c *gin.Context) {
 
 	utils.SuccessResponse(c, http.StatusOK, "User info retrieved", dto.UserResponse{
 		ID:          user.ID,
+		UUID:        user.UUID,
+		Email:       user.Email,
+		FirstName:   user.FirstName,
+		LastName:    user.LastName,
+		Role:        user.Role,
+		SystemRole:  user.SystemRole,
+		IsActive:    user.IsActive,
+		LastLoginAt: user.LastLoginAt,
+		Timezone:    user.Timezone,
+		CreatedAt:   user.CreatedAt,
+	})
+}
+
+// UpdateTimezone sets the current user's timezone
+// @Summary Update my timezone
+// @Description Set the IANA time zone your own reports group days and hours in when no tz parameter is given
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.UpdateTimezoneRequest true "Time zone"
+// @Success 200 {object} dto.SuccessResponse{data=dto.UserResponse} "Timezone updated"
+// @Failure 400 {object} dto.ErrorResponse "Invalid timezone"
+// @Failure 401 {object} dto.ErrorResponse "Not authenticated"
+// @Router /users/me/timezone [put]
+func (ctrl *AuthController) UpdateTimezone(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req dto.UpdateTimezoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	user, err := ctrl.authService.UpdateTimezone(userID.(uint), &req)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "User not found")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Timezone updated", dto.UserResponse{
+		ID:          user.ID,
+		UUID:        user.UUID,
 		Email:       user.Email,
 		FirstName:   user.FirstName,
 		LastName:    user.LastName,
@@ -135,6 +232,7 @@ func (ctrl *AuthController) Me(c *gin.Context) {
 		SystemRole:  user.SystemRole,
 		IsActive:    user.IsActive,
 		LastLoginAt: user.LastLoginAt,
+		Timezone:    user.Timezone,
 		CreatedAt:   user.CreatedAt,
 	})
 }
@@ -0,0 +1,200 @@
+package controller
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// CalendarController handles the user's calendar feed and Google Calendar push
+type CalendarController struct {
+	calendarService service.CalendarService
+}
+
+// NewCalendarController creates a new calendar controller
+func NewCalendarController(calendarService service.CalendarService) *CalendarController {
+	return &CalendarController{
+		calendarService: calendarService,
+	}
+}
+
+// GetFeed returns the user's calendar feed
+// @Summary Get calendar feed
+// @Description Get the private ICS feed URL of your completed time logs from the last 90 days, for subscribing from Google Calendar, Outlook or Apple Calendar. enabled is false until the feed is created.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.CalendarFeedResponse "Calendar feed"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Router /users/me/calendar/feed [get]
+func (c *CalendarController) GetFeed(ctx *gin.Context) {
+	userID := ctx.GetUint("userID")
+	feed, err := c.calendarService.GetFeed(userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, feed)
+}
+
+// RotateFeed creates the calendar feed or replaces its URL
+// @Summary Create or rotate calendar feed
+// @Description Create your private ICS feed, or replace its URL when it has been shared by mistake. The previous URL stops working immediately.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.CalendarFeedResponse "Calendar feed"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Router /users/me/calendar/feed [post]
+func (c *CalendarController) RotateFeed(ctx *gin.Context) {
+	userID := ctx.GetUint("userID")
+	feed, err := c.calendarService.RotateFeed(userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, feed)
+}
+
+// DisableFeed removes the calendar feed
+// @Summary Disable calendar feed
+// @Description Remove your ICS feed; its URL stops working
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Success 204 "Calendar feed removed"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Calendar feed not found"
+// @Router /users/me/calendar/feed [delete]
+func (c *CalendarController) DisableFeed(ctx *gin.Context) {
+	userID := ctx.GetUint("userID")
+	if err := c.calendarService.DisableFeed(userID); err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ServeFeed renders a calendar feed
+// @Summary Calendar feed
+// @Description iCalendar feed of a user's completed time logs, authorized by the private token in its URL
+// @Tags users
+// @Produce text/calendar
+// @Param token path string true "Feed token followed by .ics"
+// @Success 200 {string} string "iCalendar document"
+// @Failure 404 {object} dto.ErrorResponse "Calendar feed not found"
+// @Router /calendar/{token} [get]
+func (c *CalendarController) ServeFeed(ctx *gin.Context) {
+	token, ok := strings.CutSuffix(ctx.Param("token"), ".ics")
+	if !ok {
+		utils.ErrorResponse(ctx, http.StatusNotFound, service.ErrCalendarFeedNotFound.Error())
+		return
+	}
+
+	body, err := c.calendarService.RenderFeed(token)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.Header("Cache-Control", "private, max-age=300")
+	ctx.Data(http.StatusOK, "text/calendar; charset=utf-8", body)
+}
+
+// GetGoogle returns the user's Google Calendar connection
+// @Summary Get Google Calendar connection
+// @Description Get your Google Calendar connection and the outcome of its last push. OAuth tokens are never returned.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.GoogleCalendarResponse "Google Calendar connection"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Google Calendar is not connected"
+// @Router /users/me/calendar/google [get]
+func (c *CalendarController) GetGoogle(ctx *gin.Context) {
+	userID := ctx.GetUint("userID")
+	conn, err := c.calendarService.GetGoogle(userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, conn)
+}
+
+// DisconnectGoogle stops pushing to Google Calendar
+// @Summary Disconnect Google Calendar
+// @Description Stop pushing time logs to Google Calendar. Events already pushed stay in the calendar.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Success 204 "Google Calendar disconnected"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Google Calendar is not connected"
+// @Router /users/me/calendar/google [delete]
+func (c *CalendarController) DisconnectGoogle(ctx *gin.Context) {
+	userID := ctx.GetUint("userID")
+	if err := c.calendarService.DisconnectGoogle(userID); err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// GoogleOAuthURL starts the Google Calendar connection
+// @Summary Get Google Calendar consent URL
+// @Description Get the Google page where you allow pushing events to your calendar. Google redirects to GOOGLE_REDIRECT_URL with code and state, which the frontend posts to the oauth endpoint within 10 minutes. Only available when a Google OAuth client is configured.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.GoogleCalendarOAuthURLResponse "Google consent URL"
+// @Failure 400 {object} dto.ErrorResponse "Google Calendar is not configured"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Router /users/me/calendar/google/oauth [get]
+func (c *CalendarController) GoogleOAuthURL(ctx *gin.Context) {
+	userID := ctx.GetUint("userID")
+	result, err := c.calendarService.GoogleOAuthURL(userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// CompleteGoogleOAuth finishes the Google Calendar connection
+// @Summary Connect Google Calendar
+// @Description Exchange the code Google redirected back with and start pushing completed time logs as events. Only time logs started after connecting are pushed, every 15 minutes by default. Edits to a time log after it was pushed are not synced.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.CompleteGoogleCalendarOAuthRequest true "Code and state from Google"
+// @Success 200 {object} dto.GoogleCalendarResponse "Google Calendar connected"
+// @Failure 400 {object} dto.ErrorResponse "Invalid state or Google rejected the code"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Router /users/me/calendar/google/oauth [post]
+func (c *CalendarController) CompleteGoogleOAuth(ctx *gin.Context) {
+	var req dto.CompleteGoogleCalendarOAuthRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	conn, err := c.calendarService.CompleteGoogleOAuth(ctx.Request.Context(), userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, conn)
+}
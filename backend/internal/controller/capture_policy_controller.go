@@ -0,0 +1,199 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// CapturePolicyController handles the agent capture policy and sensitive-window exclusion rules
+type CapturePolicyController struct {
+	capturePolicyService service.CapturePolicyService
+}
+
+// NewCapturePolicyController creates a new capture policy controller
+func NewCapturePolicyController(capturePolicyService service.CapturePolicyService) *CapturePolicyController {
+	return &CapturePolicyController{
+		capturePolicyService: capturePolicyService,
+	}
+}
+
+// captureRuleParams parses the organization and rule IDs from the path
+func captureRuleParams(ctx *gin.Context) (uint, uint, bool) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return 0, 0, false
+	}
+
+	ruleID, err := strconv.ParseUint(ctx.Param("rule_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid rule ID")
+		return 0, 0, false
+	}
+
+	return uint(orgID), uint(ruleID), true
+}
+
+// GetPolicy gets the capture policy agents apply
+// @Summary Get capture policy
+// @Description Get the organization's capture policy for the desktop agent. Skip capturing whenever the foreground app or window title matches an exclusion (case-insensitive substring, or regex when is_regex). Screenshots uploaded anyway are discarded at sync.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Success 200 {object} dto.CapturePolicyResponse "Capture policy"
+// @Failure 400 {object} dto.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/capture-policy [get]
+func (c *CapturePolicyController) GetPolicy(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	policy, err := c.capturePolicyService.GetPolicy(uint(orgID), userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, policy)
+}
+
+// ListRules lists the organization's capture exclusion rules
+// @Summary List capture exclusion rules
+// @Description List sensitive-window rules, including paused ones. Only owner or admin can view.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Success 200 {array} dto.CaptureExclusionRuleResponse "Capture exclusion rules"
+// @Failure 400 {object} dto.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/capture-exclusions [get]
+func (c *CapturePolicyController) ListRules(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	rules, err := c.capturePolicyService.ListRules(uint(orgID), userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rules)
+}
+
+// CreateRule creates a capture exclusion rule
+// @Summary Create capture exclusion rule
+// @Description Never capture windows whose app name or title matches the pattern, e.g. app "1Password" or window_title "bank". Only owner or admin can create.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param request body dto.CreateCaptureExclusionRuleRequest true "Rule details"
+// @Success 201 {object} dto.CaptureExclusionRuleResponse "Rule created"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/capture-exclusions [post]
+func (c *CapturePolicyController) CreateRule(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	var req dto.CreateCaptureExclusionRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	rule, err := c.capturePolicyService.CreateRule(uint(orgID), userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, rule)
+}
+
+// UpdateRule updates a capture exclusion rule
+// @Summary Update capture exclusion rule
+// @Description Change a rule's pattern or match type, or pause it with is_active=false
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param rule_id path int true "Rule ID"
+// @Param request body dto.UpdateCaptureExclusionRuleRequest true "Rule changes"
+// @Success 200 {object} dto.CaptureExclusionRuleResponse "Rule updated"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/capture-exclusions/{rule_id} [put]
+func (c *CapturePolicyController) UpdateRule(ctx *gin.Context) {
+	orgID, ruleID, ok := captureRuleParams(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateCaptureExclusionRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	rule, err := c.capturePolicyService.UpdateRule(orgID, ruleID, userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rule)
+}
+
+// DeleteRule deletes a capture exclusion rule
+// @Summary Delete capture exclusion rule
+// @Description Remove a sensitive-window rule; agents resume capturing matching windows on their next policy refresh
+// @Tags organizations
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param rule_id path int true "Rule ID"
+// @Success 204 "Rule deleted"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/capture-exclusions/{rule_id} [delete]
+func (c *CapturePolicyController) DeleteRule(ctx *gin.Context) {
+	orgID, ruleID, ok := captureRuleParams(ctx)
+	if !ok {
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	if err := c.capturePolicyService.DeleteRule(orgID, ruleID, userID); err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,202 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// ClientController handles the clients an organization's workspaces are done for
+type ClientController struct {
+	clientService service.ClientService
+}
+
+// NewClientController creates a new client controller
+func NewClientController(clientService service.ClientService) *ClientController {
+	return &ClientController{
+		clientService: clientService,
+	}
+}
+
+// ListClients lists the organization's clients
+// @Summary List clients
+// @Description List the organization's clients by name with their workspace counts. Tracked time per client is reported by the hours_by_client analytics query and the client custom report dimension.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Success 200 {array} dto.ClientResponse "Clients"
+// @Failure 400 {object} dto.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/clients [get]
+func (c *ClientController) ListClients(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	clients, err := c.clientService.List(uint(orgID), userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, clients)
+}
+
+// GetClient gets a client with its workspaces
+// @Summary Get client
+// @Description Get a client of the organization with its workspaces
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param client_id path int true "Client ID"
+// @Success 200 {object} dto.ClientResponse "Client"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Client not found"
+// @Router /organizations/{org_id}/clients/{client_id} [get]
+func (c *ClientController) GetClient(ctx *gin.Context) {
+	orgID, clientID, ok := parseClientParams(ctx)
+	if !ok {
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	client, err := c.clientService.GetByID(orgID, clientID, userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, client)
+}
+
+// CreateClient adds a client
+// @Summary Create client
+// @Description Add a client to the organization. Workspaces are assigned to it with their client_id. Only owner or admin can manage clients.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param request body dto.CreateClientRequest true "Client"
+// @Success 201 {object} dto.ClientResponse "Client created"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 409 {object} dto.ErrorResponse "Client name already used"
+// @Router /organizations/{org_id}/clients [post]
+func (c *ClientController) CreateClient(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	var req dto.CreateClientRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	client, err := c.clientService.Create(uint(orgID), userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, client)
+}
+
+// UpdateClient updates a client
+// @Summary Update client
+// @Description Update a client's name, contact or notes. Only owner or admin can manage clients.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param client_id path int true "Client ID"
+// @Param request body dto.UpdateClientRequest true "Client changes"
+// @Success 200 {object} dto.ClientResponse "Client updated"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Client not found"
+// @Failure 409 {object} dto.ErrorResponse "Client name already used"
+// @Router /organizations/{org_id}/clients/{client_id} [put]
+func (c *ClientController) UpdateClient(ctx *gin.Context) {
+	orgID, clientID, ok := parseClientParams(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateClientRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	client, err := c.clientService.Update(orgID, clientID, userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, client)
+}
+
+// DeleteClient deletes a client
+// @Summary Delete client
+// @Description Delete a client. Its workspaces are kept without a client. Only owner or admin can manage clients.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param client_id path int true "Client ID"
+// @Success 200 {object} dto.SuccessResponse "Client deleted"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Client not found"
+// @Router /organizations/{org_id}/clients/{client_id} [delete]
+func (c *ClientController) DeleteClient(ctx *gin.Context) {
+	orgID, clientID, ok := parseClientParams(ctx)
+	if !ok {
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	if err := c.clientService.Delete(orgID, clientID, userID); err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Client deleted", nil)
+}
+
+func parseClientParams(ctx *gin.Context) (uint, uint, bool) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return 0, 0, false
+	}
+	clientID, err := strconv.ParseUint(ctx.Param("client_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid client ID")
+		return 0, 0, false
+	}
+	return uint(orgID), uint(clientID), true
+}
@@ -0,0 +1,265 @@
+package controller
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// Push payloads of large pushes can be a few MB
+const maxVCSWebhookBody = 5 << 20
+
+// CommitLinkController handles workspace repositories and the commits linked to time logs
+type CommitLinkController struct {
+	commitService service.CommitLinkService
+}
+
+// NewCommitLinkController creates a new commit link controller
+func NewCommitLinkController(commitService service.CommitLinkService) *CommitLinkController {
+	return &CommitLinkController{
+		commitService: commitService,
+	}
+}
+
+// ListRepositories lists the workspace's repositories
+// @Summary List workspace repositories
+// @Description List the GitHub and GitLab repositories linked to the workspace. Only workspace managers can view.
+// @Tags workspaces
+// @Produce json
+// @Security BearerAuth
+// @Param workspace_id path int true "Workspace ID"
+// @Success 200 {array} dto.VCSRepositoryResponse "Repositories"
+// @Failure 400 {object} dto.ErrorResponse "Invalid workspace ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /workspaces/{workspace_id}/repositories [get]
+func (c *CommitLinkController) ListRepositories(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseUint(ctx.Param("workspace_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid workspace ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	repos, err := c.commitService.ListRepositories(uint(workspaceID), userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, repos)
+}
+
+// AddRepository links a repository to the workspace
+// @Summary Add workspace repository
+// @Description Link a GitHub or GitLab repository to the workspace. Configure its push webhook (webhook_url, content type application/json) with the returned webhook_secret, which is only shown once: as the GitHub secret, or as the GitLab secret token. Pushed commits are linked to the time log their author (matched by email) was tracking in the workspace. The default repository, which the first one added becomes, resolves bare "#123" and "!45" refs in time log notes. Only workspace managers can add.
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param workspace_id path int true "Workspace ID"
+// @Param request body dto.CreateVCSRepositoryRequest true "Repository"
+// @Success 201 {object} dto.VCSRepositoryResponse "Repository added"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /workspaces/{workspace_id}/repositories [post]
+func (c *CommitLinkController) AddRepository(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseUint(ctx.Param("workspace_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid workspace ID")
+		return
+	}
+
+	var req dto.CreateVCSRepositoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	repo, err := c.commitService.AddRepository(uint(workspaceID), userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, repo)
+}
+
+// DeleteRepository unlinks a repository from the workspace
+// @Summary Remove workspace repository
+// @Description Stop accepting the repository's push webhook. Commits already linked to time logs are kept. Only workspace managers can remove.
+// @Tags workspaces
+// @Produce json
+// @Security BearerAuth
+// @Param workspace_id path int true "Workspace ID"
+// @Param repo_id path int true "Repository ID"
+// @Success 204 "Repository removed"
+// @Failure 400 {object} dto.ErrorResponse "Invalid ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Repository not found"
+// @Router /workspaces/{workspace_id}/repositories/{repo_id} [delete]
+func (c *CommitLinkController) DeleteRepository(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseUint(ctx.Param("workspace_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid workspace ID")
+		return
+	}
+	repoID, err := strconv.ParseUint(ctx.Param("repo_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid repository ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	if err := c.commitService.DeleteRepository(uint(workspaceID), uint(repoID), userID); err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListTimeLogCommits lists the commits linked to a time log
+// @Summary List time log commits
+// @Description List the commits, pull/merge requests and issues linked to one of your time logs
+// @Tags timelogs
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Time log ID"
+// @Success 200 {array} dto.TimeLogCommitResponse "Linked commits"
+// @Failure 400 {object} dto.ErrorResponse "Invalid ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Time log not found"
+// @Router /timelogs/{id}/commits [get]
+func (c *CommitLinkController) ListTimeLogCommits(ctx *gin.Context) {
+	timeLogID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid time log ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	commits, err := c.commitService.ListTimeLogCommits(uint(timeLogID), userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, commits)
+}
+
+// AddTimeLogCommit links a commit URL to a time log
+// @Summary Link commit to time log
+// @Description Link a GitHub or GitLab commit, pull/merge request or issue URL to one of your time logs. Refs in time log notes ("#123", "owner/repo#123", "!45", "owner/repo@sha" or full URLs) are linked automatically when the time log is stopped or synced.
+// @Tags timelogs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Time log ID"
+// @Param request body dto.LinkTimeLogCommitRequest true "Commit URL"
+// @Success 201 {object} dto.TimeLogCommitResponse "Commit linked"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Time log not found"
+// @Router /timelogs/{id}/commits [post]
+func (c *CommitLinkController) AddTimeLogCommit(ctx *gin.Context) {
+	timeLogID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid time log ID")
+		return
+	}
+
+	var req dto.LinkTimeLogCommitRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	commit, err := c.commitService.AddTimeLogCommit(uint(timeLogID), userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, commit)
+}
+
+// RemoveTimeLogCommit unlinks a commit from a time log
+// @Summary Unlink commit from time log
+// @Description Remove a commit, pull/merge request or issue link from one of your time logs
+// @Tags timelogs
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Time log ID"
+// @Param commit_id path int true "Commit link ID"
+// @Success 204 "Commit unlinked"
+// @Failure 400 {object} dto.ErrorResponse "Invalid ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Commit link not found"
+// @Router /timelogs/{id}/commits/{commit_id} [delete]
+func (c *CommitLinkController) RemoveTimeLogCommit(ctx *gin.Context) {
+	timeLogID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid time log ID")
+		return
+	}
+	linkID, err := strconv.ParseUint(ctx.Param("commit_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid commit link ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	if err := c.commitService.RemoveTimeLogCommit(uint(timeLogID), uint(linkID), userID); err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// HandleWebhook receives a repository push webhook
+// @Summary Repository push webhook
+// @Description Receives GitHub (X-Hub-Signature-256) and GitLab (X-Gitlab-Token) push events for a workspace repository and links each commit to the time log its author was tracking when committing. Other events are acknowledged and ignored.
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Param repo_id path int true "Repository ID"
+// @Success 200 {object} dto.VCSWebhookResponse "Push processed"
+// @Failure 400 {object} dto.ErrorResponse "Invalid payload"
+// @Failure 401 {object} dto.ErrorResponse "Invalid signature"
+// @Failure 404 {object} dto.ErrorResponse "Repository not found"
+// @Router /public/vcs/repositories/{repo_id}/webhook [post]
+func (c *CommitLinkController) HandleWebhook(ctx *gin.Context) {
+	repoID, err := strconv.ParseUint(ctx.Param("repo_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid repository ID")
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(ctx.Request.Body, maxVCSWebhookBody))
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "failed to read payload")
+		return
+	}
+
+	result, err := c.commitService.HandleWebhook(uint(repoID), ctx.Request.Header, body)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
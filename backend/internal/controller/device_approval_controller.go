@@ -0,0 +1,154 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// DeviceApprovalController handles the device approval queue of organizations
+// that require device approval
+type DeviceApprovalController struct {
+	approvalService service.DeviceApprovalService
+}
+
+// NewDeviceApprovalController creates a new device approval controller
+func NewDeviceApprovalController(approvalService service.DeviceApprovalService) *DeviceApprovalController {
+	return &DeviceApprovalController{
+		approvalService: approvalService,
+	}
+}
+
+// ListQueue lists the organization's device approvals
+// @Summary List device approvals
+// @Description Get the devices that synced or tried to sync into the organization and their approval status. Only used when the organization requires device approval; org owners and admins only.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(20)
+// @Param status query string false "Filter by status (pending, approved, rejected)"
+// @Param user_id query int false "Filter by device owner"
+// @Success 200 {object} map[string]interface{} "Device approvals with pagination"
+// @Failure 400 {object} dto.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/device-approvals [get]
+func (c *DeviceApprovalController) ListQueue(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	params := &dto.DeviceApprovalListParams{
+		Page:    parseIntParam(ctx, "page", 1),
+		PerPage: parseIntParam(ctx, "per_page", 20),
+		Status:  ctx.Query("status"),
+	}
+	if userIDStr := ctx.Query("user_id"); userIDStr != "" {
+		ownerID, err := strconv.ParseUint(userIDStr, 10, 32)
+		if err != nil {
+			utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid user ID")
+			return
+		}
+		id := uint(ownerID)
+		params.UserID = &id
+	}
+
+	userID := ctx.GetUint("userID")
+	approvals, total, err := c.approvalService.ListForOrg(uint(orgID), userID, params)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	totalPages := int((total + int64(params.PerPage) - 1) / int64(params.PerPage))
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"approvals": approvals,
+		"pagination": dto.PaginationMeta{
+			Page:       params.Page,
+			PerPage:    params.PerPage,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	})
+}
+
+// Approve approves a device
+// @Summary Approve device
+// @Description Let a device sync into the organization. Previously rejected devices can be approved again.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param approval_id path int true "Device approval ID"
+// @Param request body dto.ReviewDeviceApprovalRequest false "Review note"
+// @Success 200 {object} dto.DeviceApprovalResponse "Device approved"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Device approval not found"
+// @Failure 409 {object} dto.ErrorResponse "Device already approved"
+// @Router /organizations/{org_id}/device-approvals/{approval_id}/approve [post]
+func (c *DeviceApprovalController) Approve(ctx *gin.Context) {
+	c.review(ctx, c.approvalService.Approve)
+}
+
+// Reject rejects a device
+// @Summary Reject device
+// @Description Stop a device from syncing into the organization. Rejecting an approved device revokes its approval; its syncs fail with device_not_approved.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param approval_id path int true "Device approval ID"
+// @Param request body dto.ReviewDeviceApprovalRequest false "Review note"
+// @Success 200 {object} dto.DeviceApprovalResponse "Device rejected"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Device approval not found"
+// @Failure 409 {object} dto.ErrorResponse "Device already rejected"
+// @Router /organizations/{org_id}/device-approvals/{approval_id}/reject [post]
+func (c *DeviceApprovalController) Reject(ctx *gin.Context) {
+	c.review(ctx, c.approvalService.Reject)
+}
+
+func (c *DeviceApprovalController) review(ctx *gin.Context, decide func(orgID, approvalID, userID uint, req *dto.ReviewDeviceApprovalRequest) (*dto.DeviceApprovalResponse, error)) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+	approvalID, err := strconv.ParseUint(ctx.Param("approval_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid device approval ID")
+		return
+	}
+
+	var req dto.ReviewDeviceApprovalRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			utils.ValidationErrorResponse(ctx, err)
+			return
+		}
+	}
+
+	userID := ctx.GetUint("userID")
+	approval, err := decide(uint(orgID), uint(approvalID), userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, approval)
+}
@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/beuphecan/remote-time-tracker/internal/middleware"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// DeviceConfigController serves the configuration document the desktop app
+// polls for policy changes
+type DeviceConfigController struct {
+	configService service.DeviceConfigService
+}
+
+// NewDeviceConfigController creates a new device configuration controller
+func NewDeviceConfigController(configService service.DeviceConfigService) *DeviceConfigController {
+	return &DeviceConfigController{
+		configService: configService,
+	}
+}
+
+// GetConfig returns the configuration document of a device
+// @Summary Get device configuration
+// @Description Get the screenshot, idle and feature policy the desktop app applies on this device, for the workspace it tracks time in; features whose feature flag is off are disabled. Poll with If-None-Match set to the last ETag: 304 means nothing changed. Without workspace_id the server defaults apply.
+// @Tags devices
+// @Produce json
+// @Security BearerAuth
+// @Param uuid path string true "Device UUID"
+// @Param workspace_id query int false "Workspace the device tracks time in"
+// @Param If-None-Match header string false "ETag of the document the app already has"
+// @Success 200 {object} dto.DeviceConfigResponse "Configuration document"
+// @Success 304 "Not modified"
+// @Failure 400 {object} dto.ErrorResponse "Invalid workspace ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Not a member of the workspace"
+// @Failure 404 {object} dto.ErrorResponse "Device not found"
+// @Router /devices/{uuid}/config [get]
+func (ctrl *DeviceConfigController) GetConfig(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var workspaceID *uint
+	if raw := c.Query("workspace_id"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid workspace ID")
+			return
+		}
+		wsID := uint(id)
+		workspaceID = &wsID
+	}
+
+	document, err := ctrl.configService.GetConfig(userID, c.Param("uuid"), workspaceID)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.ETagResponse(c, document)
+}
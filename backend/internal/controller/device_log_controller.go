@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/beuphecan/remote-time-tracker/internal/config"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/middleware"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// deviceLogFormOverhead leaves room for multipart headers and form fields
+const deviceLogFormOverhead = 1 << 20
+
+// DeviceLogController handles log bundle uploads from the desktop app
+type DeviceLogController struct {
+	deviceLogService service.DeviceLogService
+}
+
+// NewDeviceLogController creates a new device log controller
+func NewDeviceLogController(deviceLogService service.DeviceLogService) *DeviceLogController {
+	return &DeviceLogController{
+		deviceLogService: deviceLogService,
+	}
+}
+
+// Upload handles a log bundle upload from the desktop app
+// @Summary Upload desktop app logs
+// @Description Upload the Electron app's local log bundle for one of the user's registered devices. Bundles are size-capped, kept for a limited time and visible to system admins for debugging sync issues.
+// @Tags devices
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "Log bundle (.zip, .gz, .tgz, .log, .txt)"
+// @Param device_uuid formData string true "Device UUID registered via sync"
+// @Param app_version formData string false "Desktop app version"
+// @Param note formData string false "What the user was doing when the issue occurred"
+// @Success 201 {object} dto.SuccessResponse{data=dto.DeviceLogUploadResponse} "Log bundle uploaded"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request or file"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 413 {object} dto.ErrorResponse "Log bundle too large"
+// @Router /devices/logs [post]
+func (ctrl *DeviceLogController) Upload(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	maxSize := config.AppConfig.DeviceLog.MaxSize
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize+deviceLogFormOverhead)
+
+	var req dto.DeviceLogUploadRequest
+	if err := c.ShouldBind(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Log bundle file is required")
+		return
+	}
+	if file.Size > maxSize {
+		utils.ErrorResponse(c, http.StatusRequestEntityTooLarge, "Log bundle is too large")
+		return
+	}
+
+	result, err := ctrl.deviceLogService.Upload(userID, &req, file)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Log bundle uploaded", result)
+}
@@ -0,0 +1,168 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// EncryptionKeyController handles organization keys for client-side screenshot encryption
+type EncryptionKeyController struct {
+	encryptionKeyService service.EncryptionKeyService
+}
+
+// NewEncryptionKeyController creates a new encryption key controller
+func NewEncryptionKeyController(encryptionKeyService service.EncryptionKeyService) *EncryptionKeyController {
+	return &EncryptionKeyController{
+		encryptionKeyService: encryptionKeyService,
+	}
+}
+
+// ListKeys lists the organization's encryption keys
+// @Summary List encryption keys
+// @Description List the organization's screenshot encryption keys, newest first. Agents encrypt each screenshot with a random AES-256-GCM key, wrap it with the active key (RSA-OAEP-256) and upload key_fingerprint, wrapped_key and nonce with it.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Success 200 {array} dto.EncryptionKeyResponse "Encryption keys"
+// @Failure 400 {object} dto.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/encryption-keys [get]
+func (c *EncryptionKeyController) ListKeys(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	keys, err := c.encryptionKeyService.ListKeys(uint(orgID), userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, keys)
+}
+
+// RegisterKey registers a new encryption key
+// @Summary Register encryption key
+// @Description Register an RSA public key (PEM, at least 2048 bits) and make it the active key. The previous key is retired: uploads for it are still accepted, and its screenshots can be moved to the new key with re-key. Only owner or admin can register.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param request body dto.RegisterEncryptionKeyRequest true "Public key"
+// @Success 201 {object} dto.EncryptionKeyResponse "Key registered"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 409 {object} dto.ErrorResponse "Key already registered"
+// @Router /organizations/{org_id}/encryption-keys [post]
+func (c *EncryptionKeyController) RegisterKey(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	var req dto.RegisterEncryptionKeyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	key, err := c.encryptionKeyService.RegisterKey(uint(orgID), userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, key)
+}
+
+// ListKeyScreenshots lists the screenshots encrypted for a key
+// @Summary List screenshots encrypted for a key
+// @Description List the encryption envelopes of screenshots whose content key is wrapped with the given key, oldest first. After a rotation, page through a retired key's screenshots and re-key them until the list is empty. Only owner or admin can list.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param key_id path int true "Key ID"
+// @Param limit query int false "Maximum screenshots (max 500)" default(100)
+// @Success 200 {array} dto.EncryptedScreenshotKey "Encryption envelopes"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Key not found"
+// @Router /organizations/{org_id}/encryption-keys/{key_id}/screenshots [get]
+func (c *EncryptionKeyController) ListKeyScreenshots(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	keyID, err := strconv.ParseUint(ctx.Param("key_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid key ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	limit := parseIntParam(ctx, "limit", 100)
+	envelopes, err := c.encryptionKeyService.ListKeyScreenshots(uint(orgID), uint(keyID), userID, limit)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, envelopes)
+}
+
+// RekeyScreenshots re-wraps screenshot content keys with the active key
+// @Summary Re-key screenshots
+// @Description Replace the wrapped content keys of screenshots with keys wrapped for the active key. Unwrap each key with the old private key and wrap it with the active public key locally; the encrypted files are unchanged and the server never sees a private or content key. Screenshots not found in the organization or not encrypted are skipped. Only owner or admin can re-key.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param request body dto.RekeyScreenshotsRequest true "Re-wrapped content keys"
+// @Success 200 {object} dto.RekeyScreenshotsResponse "Screenshots re-keyed"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Key not found"
+// @Failure 409 {object} dto.ErrorResponse "Key is not the active key"
+// @Router /organizations/{org_id}/encryption-keys/rekey [post]
+func (c *EncryptionKeyController) RekeyScreenshots(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	var req dto.RekeyScreenshotsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	result, err := c.encryptionKeyService.RekeyScreenshots(uint(orgID), userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
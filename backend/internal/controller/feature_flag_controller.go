@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/beuphecan/remote-time-tracker/internal/middleware"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// FeatureFlagController evaluates feature flags for clients
+type FeatureFlagController struct {
+	flagService service.FeatureFlagService
+}
+
+// NewFeatureFlagController creates a new feature flag controller
+func NewFeatureFlagController(flagService service.FeatureFlagService) *FeatureFlagController {
+	return &FeatureFlagController{
+		flagService: flagService,
+	}
+}
+
+// Evaluate returns the feature flags of the current user
+// @Summary Get feature flags
+// @Description Get whether each feature flag is on for the current user, in the organization when organization_id is set. Clients hide capabilities whose flag is off; flags that are not listed are on.
+// @Tags features
+// @Produce json
+// @Security BearerAuth
+// @Param organization_id query int false "Organization to evaluate the flags in"
+// @Success 200 {object} dto.SuccessResponse{data=dto.FeaturesResponse} "Feature flags"
+// @Failure 400 {object} dto.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Not a member of the organization"
+// @Router /features [get]
+func (ctrl *FeatureFlagController) Evaluate(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var orgID *uint
+	if raw := c.Query("organization_id"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid organization ID")
+			return
+		}
+		org := uint(id)
+		orgID = &org
+	}
+
+	features, err := ctrl.flagService.Evaluate(userID, orgID)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Feature flags retrieved", features)
+}
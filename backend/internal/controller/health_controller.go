@@ -0,0 +1,48 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// HealthController handles Kubernetes liveness and readiness probes
+type HealthController struct {
+	healthService service.HealthService
+}
+
+// NewHealthController creates a new health controller
+func NewHealthController(healthService service.HealthService) *HealthController {
+	return &HealthController{
+		healthService: healthService,
+	}
+}
+
+// Liveness reports whether the server process is alive
+// @Summary Liveness probe
+// @Description Returns 200 while the server is serving requests. Dependencies are not checked, so a database outage does not restart the server.
+// @Tags system
+// @Produce json
+// @Success 200 {object} dto.HealthResponse "Alive"
+// @Router /healthz [get]
+func (c *HealthController) Liveness(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, c.healthService.Liveness())
+}
+
+// Readiness reports whether the server can take traffic
+// @Summary Readiness probe
+// @Description Checks database connectivity and that the upload storage is writable. Returns 503 when a check fails or the server is shutting down.
+// @Tags system
+// @Produce json
+// @Success 200 {object} dto.HealthResponse "Ready"
+// @Failure 503 {object} dto.HealthResponse "Not ready"
+// @Router /readyz [get]
+func (c *HealthController) Readiness(ctx *gin.Context) {
+	resp, ready := c.healthService.Readiness(ctx.Request.Context())
+	if !ready {
+		ctx.JSON(http.StatusServiceUnavailable, resp)
+		return
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
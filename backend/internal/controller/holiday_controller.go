@@ -0,0 +1,178 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// HolidayController handles organization holiday calendars
+type HolidayController struct {
+	holidayService service.HolidayService
+}
+
+// NewHolidayController creates a new holiday controller
+func NewHolidayController(holidayService service.HolidayService) *HolidayController {
+	return &HolidayController{
+		holidayService: holidayService,
+	}
+}
+
+// ListPresets lists the countries with a holiday preset
+// @Summary List holiday presets
+// @Description List the countries whose public holidays can be imported. Presets cover nationwide holidays on their official date; substitute days and regional or lunar calendar holidays are added as custom dates.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Success 200 {array} dto.HolidayPresetResponse "Holiday presets"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Router /organizations/{org_id}/calendar/holidays/presets [get]
+func (c *HolidayController) ListPresets(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, c.holidayService.ListPresets())
+}
+
+// ListHolidays lists the organization's holidays
+// @Summary List organization holidays
+// @Description List the organization's holidays in a year. Holidays are not working days: they are excluded from expected hours and leave day counts.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param year query int false "Year, defaults to the current year"
+// @Success 200 {array} dto.HolidayResponse "Holidays"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/calendar/holidays [get]
+func (c *HolidayController) ListHolidays(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	holidays, err := c.holidayService.List(uint(orgID), userID, parseIntParam(ctx, "year", 0))
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, holidays)
+}
+
+// CreateHoliday adds a custom holiday
+// @Summary Add organization holiday
+// @Description Add a custom holiday or company day off. Only owner or admin can add holidays.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param request body dto.CreateHolidayRequest true "Holiday"
+// @Success 201 {object} dto.HolidayResponse "Holiday added"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 409 {object} dto.ErrorResponse "Date already has a holiday"
+// @Router /organizations/{org_id}/calendar/holidays [post]
+func (c *HolidayController) CreateHoliday(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	var req dto.CreateHolidayRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	holiday, err := c.holidayService.Create(uint(orgID), userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, holiday)
+}
+
+// ImportPreset adds a country's public holidays
+// @Summary Import holiday preset
+// @Description Add a country's public holidays for a year. Dates that already have a holiday keep it. Only owner or admin can import holidays.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param request body dto.ImportHolidayPresetRequest true "Country and year"
+// @Success 200 {object} dto.ImportHolidayPresetResponse "Holidays imported"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/calendar/holidays/presets [post]
+func (c *HolidayController) ImportPreset(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	var req dto.ImportHolidayPresetRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	result, err := c.holidayService.ImportPreset(uint(orgID), userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// DeleteHoliday removes a holiday
+// @Summary Delete organization holiday
+// @Description Remove a holiday from the organization calendar. Only owner or admin can delete holidays.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param holiday_id path int true "Holiday ID"
+// @Success 200 {object} dto.SuccessResponse "Holiday deleted"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Holiday not found"
+// @Router /organizations/{org_id}/calendar/holidays/{holiday_id} [delete]
+func (c *HolidayController) DeleteHoliday(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	holidayID, err := strconv.ParseUint(ctx.Param("holiday_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid holiday ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	if err := c.holidayService.Delete(uint(orgID), uint(holidayID), userID); err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "holiday deleted"})
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/beuphecan/remote-time-tracker/internal/dto"
 	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
 	"github.com/gin-gonic/gin"
 )
 
@@ -27,7 +28,7 @@ func NewInvitationController(invitationService service.InvitationService) *Invit
 
 // GetByToken gets invitation info by token
 // @Summary Get invitation by token
-// @Description Get invitation details by token (before accepting)
+// @Description Preview an invitation from its landing page before accepting: organization, workspace, role and inviter. account_exists tells whether the invitee should log in or sign up.
 // @Tags invitations
 // @Produce json
 // @Param token path string true "Invitation token"
@@ -38,13 +39,13 @@ func NewInvitationController(invitationService service.InvitationService) *Invit
 func (c *InvitationController) GetByToken(ctx *gin.Context) {
 	token := ctx.Param("token")
 	if token == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invitation token is required"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invitation token is required")
 		return
 	}
 
 	invitation, err := c.invitationService.GetByToken(token)
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -65,14 +66,14 @@ func (c *InvitationController) GetByToken(ctx *gin.Context) {
 func (c *InvitationController) Accept(ctx *gin.Context) {
 	token := ctx.Param("token")
 	if token == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invitation token is required"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invitation token is required")
 		return
 	}
 
 	userID := ctx.GetUint("userID")
 	member, err := c.invitationService.Accept(token, userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -93,13 +94,13 @@ func (c *InvitationController) Accept(ctx *gin.Context) {
 func (c *InvitationController) Revoke(ctx *gin.Context) {
 	invitationID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid invitation ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid invitation ID")
 		return
 	}
 
 	userID := ctx.GetUint("userID")
 	if err := c.invitationService.Revoke(uint(invitationID), userID); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -120,40 +121,77 @@ func (c *InvitationController) Revoke(ctx *gin.Context) {
 func (c *InvitationController) GetMyInvitations(ctx *gin.Context) {
 	email := ctx.GetString("userEmail")
 	if email == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "user email not found"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "user email not found")
 		return
 	}
 
 	invitations, err := c.invitationService.GetByEmail(email)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
 	ctx.JSON(http.StatusOK, invitations)
 }
 
+// AcceptToken accepts an invitation from its landing page
+// @Summary Accept invitation from landing page
+// @Description Accept an invitation and join its organization and workspace with the stored roles. With a bearer token the logged-in invitee accepts. Without one, invitees without an account sign up (password and first_name required) and existing invitees log in with their password; both receive tokens in auth.
+// @Tags invitations
+// @Accept json
+// @Produce json
+// @Param token path string true "Invitation token"
+// @Param request body dto.AcceptInvitationTokenRequest false "Sign-up or login details when not logged in"
+// @Success 200 {object} dto.AcceptInvitationResponse "Invitation accepted"
+// @Failure 400 {object} dto.ErrorResponse "Invalid token, expired invitation or missing sign-up details"
+// @Failure 401 {object} dto.ErrorResponse "Wrong password for the existing account"
+// @Router /invitations/{token}/accept [post]
+func (c *InvitationController) AcceptToken(ctx *gin.Context) {
+	var req dto.AcceptInvitationTokenRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			utils.ValidationErrorResponse(ctx, err)
+			return
+		}
+	}
+
+	userID := ctx.GetUint("userID")
+	result, err := c.invitationService.AcceptWithAccount(ctx.Param("token"), userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
 // AcceptByBody accepts an invitation (token in body)
 // @Summary Accept invitation by token in body
-// @Description Accept an invitation with token provided in request body (for public registration flow)
+// @Description Accept an invitation with token provided in request body (requires authentication)
 // @Tags invitations
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param request body dto.AcceptInvitationRequest true "Invitation token"
 // @Success 200 {object} dto.OrganizationMemberResponse "Invitation accepted"
 // @Failure 400 {object} dto.ErrorResponse "Invalid request or token"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Router /invitations/accept [post]
 func (c *InvitationController) AcceptByBody(ctx *gin.Context) {
 	var req dto.AcceptInvitationRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.ValidationErrorResponse(ctx, err)
 		return
 	}
 
 	userID := ctx.GetUint("userID")
+	if userID == 0 {
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "log in to accept this invitation")
+		return
+	}
 	member, err := c.invitationService.Accept(req.Token, userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// InvitePreviewController handles the public invite code preview
+type InvitePreviewController struct {
+	invitePreviewService service.InvitePreviewService
+}
+
+// NewInvitePreviewController creates a new invite preview controller
+func NewInvitePreviewController(invitePreviewService service.InvitePreviewService) *InvitePreviewController {
+	return &InvitePreviewController{
+		invitePreviewService: invitePreviewService,
+	}
+}
+
+// Preview gets organization info by invite code
+// @Summary Get organization by invite code
+// @Description Get public organization info by invite code (used before joining). Requests are rate limited per IP. When CAPTCHA is required, pass the widget token in the X-Captcha-Token header or captcha_token query parameter.
+// @Tags organizations
+// @Produce json
+// @Param invite_code path string true "Invite code"
+// @Param X-Captcha-Token header string false "CAPTCHA token, when required"
+// @Success 200 {object} dto.SuccessResponse{data=dto.OrganizationPublicInfo} "Organization found"
+// @Failure 400 {object} dto.ErrorResponse "Invite code required or CAPTCHA failed"
+// @Failure 404 {object} dto.ErrorResponse "Organization not found"
+// @Failure 429 {object} dto.ErrorResponse "Rate limit exceeded"
+// @Router /public/organizations/invite/{invite_code} [get]
+// @Router /organizations/join/{invite_code} [get]
+func (c *InvitePreviewController) Preview(ctx *gin.Context) {
+	code := ctx.Param("invite_code")
+	if code == "" {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invite code is required")
+		return
+	}
+
+	captchaToken := ctx.GetHeader("X-Captcha-Token")
+	if captchaToken == "" {
+		captchaToken = ctx.Query("captcha_token")
+	}
+
+	org, err := c.invitePreviewService.Preview(ctx.Request.Context(), code, ctx.ClientIP(), captchaToken)
+	if err != nil {
+		if errors.Is(err, service.ErrCaptchaFailed) {
+			utils.RespondError(ctx, err)
+			return
+		}
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Organization found",
+		"data":    org,
+	})
+}
+
+// GetLookupStats gets invite code lookup metrics
+// @Summary Get invite code lookup metrics
+// @Description Get counts of invite code preview lookups and failures on this server instance, with the IPs failing most in the current hour, to detect enumeration. System admin only.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.InviteLookupStats "Lookup metrics"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /admin/security/invite-lookups [get]
+func (c *InvitePreviewController) GetLookupStats(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, c.invitePreviewService.Stats())
+}
@@ -0,0 +1,276 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// InvoiceController handles an organization's invoices
+type InvoiceController struct {
+	invoiceService service.InvoiceService
+}
+
+// NewInvoiceController creates a new invoice controller
+func NewInvoiceController(invoiceService service.InvoiceService) *InvoiceController {
+	return &InvoiceController{
+		invoiceService: invoiceService,
+	}
+}
+
+// ListInvoices lists the organization's invoices
+// @Summary List invoices
+// @Description List the organization's invoices, newest first, without their line items. Only owner or admin can manage invoices.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param status query string false "Only invoices with this status (draft, sent, paid)"
+// @Success 200 {array} dto.InvoiceResponse "Invoices"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/invoices [get]
+func (c *InvoiceController) ListInvoices(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	var params dto.InvoiceListParams
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	invoices, err := c.invoiceService.List(uint(orgID), userID, &params)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, invoices)
+}
+
+// GetInvoice gets an invoice with its line items
+// @Summary Get invoice
+// @Description Get an invoice of the organization with its line items
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param invoice_id path int true "Invoice ID"
+// @Success 200 {object} dto.InvoiceResponse "Invoice"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Invoice not found"
+// @Router /organizations/{org_id}/invoices/{invoice_id} [get]
+func (c *InvoiceController) GetInvoice(ctx *gin.Context) {
+	orgID, invoiceID, ok := parseInvoiceParams(ctx)
+	if !ok {
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	invoice, err := c.invoiceService.GetByID(orgID, invoiceID, userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, invoice)
+}
+
+// CreateInvoice creates a draft invoice from a date range
+// @Summary Create invoice
+// @Description Create a draft invoice for the approved, not yet invoiced time of billable workspaces started in a date range, optionally only of a client or a workspace. Each workspace becomes one line item per cost center and project code (the task's, else the workspace's), billed at its hourly rate converted to the organization currency. The invoiced time logs are locked against edits until the draft is deleted.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param request body dto.CreateInvoiceRequest true "Invoice period"
+// @Success 201 {object} dto.InvoiceResponse "Invoice created"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request or nothing to invoice"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Client or workspace not found"
+// @Failure 409 {object} dto.ErrorResponse "Time logs invoiced meanwhile"
+// @Router /organizations/{org_id}/invoices [post]
+func (c *InvoiceController) CreateInvoice(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	var req dto.CreateInvoiceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	invoice, err := c.invoiceService.Create(uint(orgID), userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, invoice)
+}
+
+// SendInvoice marks a draft invoice sent
+// @Summary Mark invoice sent
+// @Description Mark a draft invoice as sent to the client
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param invoice_id path int true "Invoice ID"
+// @Success 200 {object} dto.InvoiceResponse "Invoice sent"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Invoice not found"
+// @Failure 409 {object} dto.ErrorResponse "Invoice is not a draft"
+// @Router /organizations/{org_id}/invoices/{invoice_id}/send [post]
+func (c *InvoiceController) SendInvoice(ctx *gin.Context) {
+	orgID, invoiceID, ok := parseInvoiceParams(ctx)
+	if !ok {
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	invoice, err := c.invoiceService.Send(orgID, invoiceID, userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, invoice)
+}
+
+// PayInvoice marks an invoice paid
+// @Summary Mark invoice paid
+// @Description Mark a draft or sent invoice as paid, now or at the given time
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param invoice_id path int true "Invoice ID"
+// @Param request body dto.PayInvoiceRequest false "Payment"
+// @Success 200 {object} dto.InvoiceResponse "Invoice paid"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Invoice not found"
+// @Failure 409 {object} dto.ErrorResponse "Invoice already paid"
+// @Router /organizations/{org_id}/invoices/{invoice_id}/pay [post]
+func (c *InvoiceController) PayInvoice(ctx *gin.Context) {
+	orgID, invoiceID, ok := parseInvoiceParams(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.PayInvoiceRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			utils.ValidationErrorResponse(ctx, err)
+			return
+		}
+	}
+
+	userID := ctx.GetUint("userID")
+	invoice, err := c.invoiceService.Pay(orgID, invoiceID, userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, invoice)
+}
+
+// DeleteInvoice deletes a draft invoice
+// @Summary Delete invoice
+// @Description Delete a draft invoice. Its time logs are unlocked and can be invoiced again. Sent and paid invoices are kept.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param invoice_id path int true "Invoice ID"
+// @Success 200 {object} dto.SuccessResponse "Invoice deleted"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Invoice not found"
+// @Failure 409 {object} dto.ErrorResponse "Invoice is not a draft"
+// @Router /organizations/{org_id}/invoices/{invoice_id} [delete]
+func (c *InvoiceController) DeleteInvoice(ctx *gin.Context) {
+	orgID, invoiceID, ok := parseInvoiceParams(ctx)
+	if !ok {
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	if err := c.invoiceService.Delete(orgID, invoiceID, userID); err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Invoice deleted", nil)
+}
+
+// DownloadInvoicePDF downloads an invoice as PDF
+// @Summary Download invoice PDF
+// @Description Download an invoice with its line items and total as PDF
+// @Tags organizations
+// @Produce application/pdf
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param invoice_id path int true "Invoice ID"
+// @Success 200 {file} file "Invoice PDF"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Invoice not found"
+// @Router /organizations/{org_id}/invoices/{invoice_id}/pdf [get]
+func (c *InvoiceController) DownloadInvoicePDF(ctx *gin.Context) {
+	orgID, invoiceID, ok := parseInvoiceParams(ctx)
+	if !ok {
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	file, err := c.invoiceService.PDF(orgID, invoiceID, userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", file.Filename))
+	ctx.Data(http.StatusOK, file.ContentType, file.Data)
+}
+
+func parseInvoiceParams(ctx *gin.Context) (uint, uint, bool) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return 0, 0, false
+	}
+	invoiceID, err := strconv.ParseUint(ctx.Param("invoice_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid invoice ID")
+		return 0, 0, false
+	}
+	return uint(orgID), uint(invoiceID), true
+}
@@ -0,0 +1,150 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// JiraController handles workspace Jira integrations
+type JiraController struct {
+	jiraService service.JiraService
+}
+
+// NewJiraController creates a new Jira integration controller
+func NewJiraController(jiraService service.JiraService) *JiraController {
+	return &JiraController{
+		jiraService: jiraService,
+	}
+}
+
+// GetIntegration returns the workspace's Jira integration
+// @Summary Get Jira integration
+// @Description Get the workspace's Jira connection and the outcome of its last sync. The API token is never returned. Only workspace managers can view.
+// @Tags workspaces
+// @Produce json
+// @Security BearerAuth
+// @Param workspace_id path int true "Workspace ID"
+// @Success 200 {object} dto.JiraIntegrationResponse "Jira integration"
+// @Failure 400 {object} dto.ErrorResponse "Invalid workspace ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Workspace is not connected to Jira"
+// @Router /workspaces/{workspace_id}/integrations/jira [get]
+func (c *JiraController) GetIntegration(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseUint(ctx.Param("workspace_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid workspace ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	integration, err := c.jiraService.Get(uint(workspaceID), userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, integration)
+}
+
+// SaveIntegration connects the workspace to a Jira project
+// @Summary Connect or update Jira integration
+// @Description Connect the workspace to a Jira Cloud project using an account email and API token, or change the existing connection. The credentials and project are checked with Jira before saving. api_token may be omitted when updating unless base_url or email changes. Issues matching the project (and jql, when set) are imported as tasks with their key in external_ref; stopped time logs of at least a minute on those tasks are pushed back as worklogs when push_worklogs is on. Only workspace managers can connect.
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param workspace_id path int true "Workspace ID"
+// @Param request body dto.SaveJiraIntegrationRequest true "Jira connection"
+// @Success 200 {object} dto.JiraIntegrationResponse "Jira integration saved"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request or Jira rejected the connection"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /workspaces/{workspace_id}/integrations/jira [put]
+func (c *JiraController) SaveIntegration(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseUint(ctx.Param("workspace_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid workspace ID")
+		return
+	}
+
+	var req dto.SaveJiraIntegrationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	integration, err := c.jiraService.Save(ctx.Request.Context(), uint(workspaceID), userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, integration)
+}
+
+// DeleteIntegration disconnects the workspace from Jira
+// @Summary Disconnect Jira integration
+// @Description Remove the workspace's Jira connection. Imported tasks are kept along with their external_ref. Only workspace managers can disconnect.
+// @Tags workspaces
+// @Produce json
+// @Security BearerAuth
+// @Param workspace_id path int true "Workspace ID"
+// @Success 204 "Jira integration removed"
+// @Failure 400 {object} dto.ErrorResponse "Invalid workspace ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Workspace is not connected to Jira"
+// @Router /workspaces/{workspace_id}/integrations/jira [delete]
+func (c *JiraController) DeleteIntegration(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseUint(ctx.Param("workspace_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid workspace ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	if err := c.jiraService.Disconnect(uint(workspaceID), userID); err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// SyncIntegration runs a Jira sync immediately
+// @Summary Sync Jira now
+// @Description Import issues and push pending worklogs without waiting for the scheduled sync. Jira failures are reported in the error field of the summary. Only workspace managers can sync.
+// @Tags workspaces
+// @Produce json
+// @Security BearerAuth
+// @Param workspace_id path int true "Workspace ID"
+// @Success 200 {object} dto.JiraSyncResponse "Sync summary"
+// @Failure 400 {object} dto.ErrorResponse "Invalid workspace ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Workspace is not connected to Jira"
+// @Failure 409 {object} dto.ErrorResponse "A sync is already running"
+// @Router /workspaces/{workspace_id}/integrations/jira/sync [post]
+func (c *JiraController) SyncIntegration(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseUint(ctx.Param("workspace_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid workspace ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	result, err := c.jiraService.SyncNow(ctx.Request.Context(), uint(workspaceID), userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,356 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// LeaveController handles time-off requests, their review queue and the team leave calendar
+type LeaveController struct {
+	leaveService service.LeaveService
+}
+
+// NewLeaveController creates a new leave controller
+func NewLeaveController(leaveService service.LeaveService) *LeaveController {
+	return &LeaveController{
+		leaveService: leaveService,
+	}
+}
+
+// ============================================================================
+// MEMBER REQUESTS
+// ============================================================================
+
+// RequestLeave requests time off
+// @Summary Request leave
+// @Description Request time off (vacation, sick or other) for an inclusive date range. The request must not overlap your pending or approved leave in the organization. Org admins and the admins of your workspaces review it.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param request body dto.CreateLeaveRequest true "Leave request"
+// @Success 201 {object} dto.LeaveRequestResponse "Leave requested"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 409 {object} dto.ErrorResponse "Overlaps existing leave"
+// @Router /organizations/{org_id}/leave-requests [post]
+func (c *LeaveController) RequestLeave(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	var req dto.CreateLeaveRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	request, err := c.leaveService.Request(uint(orgID), userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, request)
+}
+
+// ListMyRequests lists the user's leave requests
+// @Summary List my leave requests
+// @Description Get your leave requests in the organization with their review outcome, newest first
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(20)
+// @Param status query string false "Filter by status (pending, approved, rejected, cancelled)"
+// @Success 200 {object} map[string]interface{} "Leave requests with pagination"
+// @Failure 400 {object} dto.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Router /organizations/{org_id}/leave-requests/mine [get]
+func (c *LeaveController) ListMyRequests(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	params := &dto.LeaveRequestListParams{
+		Page:    parseIntParam(ctx, "page", 1),
+		PerPage: parseIntParam(ctx, "per_page", 20),
+		Status:  ctx.Query("status"),
+	}
+
+	userID := ctx.GetUint("userID")
+	requests, total, err := c.leaveService.ListMine(uint(orgID), userID, params)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"requests":   requests,
+		"pagination": leavePagination(params, total),
+	})
+}
+
+// CancelRequest withdraws a leave request
+// @Summary Cancel leave request
+// @Description Withdraw one of your pending leave requests, or approved leave that has not started yet
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param request_id path int true "Leave request ID"
+// @Success 200 {object} dto.SuccessResponse "Leave request cancelled"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Leave request not found"
+// @Router /organizations/{org_id}/leave-requests/{request_id} [delete]
+func (c *LeaveController) CancelRequest(ctx *gin.Context) {
+	orgID, requestID, ok := leaveRequestParams(ctx)
+	if !ok {
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	if err := c.leaveService.Cancel(orgID, userID, requestID); err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "leave request cancelled"})
+}
+
+// ============================================================================
+// ADMIN REVIEW QUEUE
+// ============================================================================
+
+// leaveRequestParams parses the organization and request IDs from the path
+func leaveRequestParams(ctx *gin.Context) (uint, uint, bool) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return 0, 0, false
+	}
+
+	requestID, err := strconv.ParseUint(ctx.Param("request_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid leave request ID")
+		return 0, 0, false
+	}
+
+	return uint(orgID), uint(requestID), true
+}
+
+// ListQueue lists the organization's leave requests
+// @Summary List leave requests
+// @Description Get the leave approval queue. Org owners and admins see every request; workspace admins see requests from members of the workspaces they manage.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(20)
+// @Param status query string false "Filter by status (pending, approved, rejected, cancelled)"
+// @Param user_id query int false "Filter by requester"
+// @Success 200 {object} map[string]interface{} "Leave requests with pagination"
+// @Failure 400 {object} dto.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/leave-requests [get]
+func (c *LeaveController) ListQueue(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	params := &dto.LeaveRequestListParams{
+		Page:    parseIntParam(ctx, "page", 1),
+		PerPage: parseIntParam(ctx, "per_page", 20),
+		Status:  ctx.Query("status"),
+	}
+	if userIDStr := ctx.Query("user_id"); userIDStr != "" {
+		requesterID, err := strconv.ParseUint(userIDStr, 10, 32)
+		if err != nil {
+			utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid user ID")
+			return
+		}
+		id := uint(requesterID)
+		params.UserID = &id
+	}
+
+	userID := ctx.GetUint("userID")
+	requests, total, err := c.leaveService.ListForOrg(uint(orgID), userID, params)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"requests":   requests,
+		"pagination": leavePagination(params, total),
+	})
+}
+
+// Approve approves a leave request
+// @Summary Approve leave request
+// @Description Approve a pending leave request. Working days on approved leave are excluded from expected hours in reports. Admins cannot approve their own leave unless they own the organization.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param request_id path int true "Leave request ID"
+// @Param request body dto.ReviewLeaveRequest false "Review note"
+// @Success 200 {object} dto.LeaveRequestResponse "Request approved"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Leave request not found"
+// @Router /organizations/{org_id}/leave-requests/{request_id}/approve [post]
+func (c *LeaveController) Approve(ctx *gin.Context) {
+	orgID, requestID, ok := leaveRequestParams(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.ReviewLeaveRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			utils.ValidationErrorResponse(ctx, err)
+			return
+		}
+	}
+
+	userID := ctx.GetUint("userID")
+	request, err := c.leaveService.Approve(orgID, requestID, userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request)
+}
+
+// Reject rejects a leave request
+// @Summary Reject leave request
+// @Description Reject a pending leave request. A note explaining the decision is required.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param request_id path int true "Leave request ID"
+// @Param request body dto.ReviewLeaveRequest true "Review note"
+// @Success 200 {object} dto.LeaveRequestResponse "Request rejected"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Leave request not found"
+// @Router /organizations/{org_id}/leave-requests/{request_id}/reject [post]
+func (c *LeaveController) Reject(ctx *gin.Context) {
+	orgID, requestID, ok := leaveRequestParams(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.ReviewLeaveRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	request, err := c.leaveService.Reject(orgID, requestID, userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request)
+}
+
+// ============================================================================
+// TEAM CALENDAR
+// ============================================================================
+
+// GetTeamCalendar gets who is on leave each day
+// @Summary Get team leave calendar
+// @Description Get the members on approved leave for each day of a range, with the organization's working days flagged. Any member can view it.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param start_date query string false "Start date (YYYY-MM-DD), defaults to today"
+// @Param end_date query string false "End date (YYYY-MM-DD, inclusive), defaults to 4 weeks from start_date; at most 93 days"
+// @Param workspace_id query int false "Restrict to the workspace's members"
+// @Success 200 {object} dto.TeamLeaveCalendar "Team leave calendar"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/leave-calendar [get]
+func (c *LeaveController) GetTeamCalendar(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	params := &dto.TeamLeaveCalendarParams{}
+	if startDate := ctx.Query("start_date"); startDate != "" {
+		t, err := time.Parse("2006-01-02", startDate)
+		if err != nil {
+			utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid start_date: use YYYY-MM-DD")
+			return
+		}
+		params.StartDate = &t
+	}
+	if endDate := ctx.Query("end_date"); endDate != "" {
+		t, err := time.Parse("2006-01-02", endDate)
+		if err != nil {
+			utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid end_date: use YYYY-MM-DD")
+			return
+		}
+		params.EndDate = &t
+	}
+	if workspaceIDStr := ctx.Query("workspace_id"); workspaceIDStr != "" {
+		workspaceID, err := strconv.ParseUint(workspaceIDStr, 10, 32)
+		if err != nil {
+			utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid workspace ID")
+			return
+		}
+		id := uint(workspaceID)
+		params.WorkspaceID = &id
+	}
+
+	userID := ctx.GetUint("userID")
+	result, err := c.leaveService.GetTeamCalendar(uint(orgID), userID, params)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func leavePagination(params *dto.LeaveRequestListParams, total int64) dto.PaginationMeta {
+	return dto.PaginationMeta{
+		Page:       params.Page,
+		PerPage:    params.PerPage,
+		Total:      total,
+		TotalPages: int((total + int64(params.PerPage) - 1) / int64(params.PerPage)),
+	}
+}
@@ -0,0 +1,131 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// NotificationController handles the user's in-app notifications
+type NotificationController struct {
+	notificationService service.NotificationService
+}
+
+// NewNotificationController creates a new notification controller
+func NewNotificationController(notificationService service.NotificationService) *NotificationController {
+	return &NotificationController{
+		notificationService: notificationService,
+	}
+}
+
+// List lists the user's notifications
+// @Summary List notifications
+// @Description Get your in-app notifications, newest first. Types: invitation_received, timelog_rejected, workspace_archived; entity_type and entity_id point to what the notification is about.
+// @Tags notifications
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(20)
+// @Param unread query bool false "Only unread notifications"
+// @Success 200 {object} dto.SuccessResponse{data=dto.PaginationResponse} "Notifications retrieved successfully"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /notifications [get]
+func (c *NotificationController) List(ctx *gin.Context) {
+	userID := ctx.GetUint("user_id")
+
+	params := &dto.NotificationListParams{
+		Page:       parseIntParam(ctx, "page", 1),
+		PerPage:    parseIntParam(ctx, "per_page", 20),
+		UnreadOnly: ctx.Query("unread") == "true",
+	}
+
+	notifications, total, err := c.notificationService.List(userID, params)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Notifications retrieved successfully", dto.PaginationResponse{
+		Data:       notifications,
+		Page:       params.Page,
+		PerPage:    params.PerPage,
+		Total:      total,
+		TotalPages: int((total + int64(params.PerPage) - 1) / int64(params.PerPage)),
+	})
+}
+
+// UnreadCount returns the number of unread notifications
+// @Summary Get unread notification count
+// @Description Get the number of unread notifications for the notification bell. Cheap enough to poll.
+// @Tags notifications
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.SuccessResponse{data=dto.UnreadNotificationCountResponse} "Unread count retrieved successfully"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /notifications/unread-count [get]
+func (c *NotificationController) UnreadCount(ctx *gin.Context) {
+	userID := ctx.GetUint("user_id")
+
+	count, err := c.notificationService.UnreadCount(userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Unread count retrieved successfully", count)
+}
+
+// MarkRead marks a notification read
+// @Summary Mark notification read
+// @Description Mark one of your notifications as read. Marking a read notification again keeps its original read time.
+// @Tags notifications
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Notification ID"
+// @Success 200 {object} dto.SuccessResponse "Notification marked read"
+// @Failure 400 {object} dto.ErrorResponse "Invalid notification ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Notification not found"
+// @Router /notifications/{id}/read [put]
+func (c *NotificationController) MarkRead(ctx *gin.Context) {
+	userID := ctx.GetUint("user_id")
+	notificationID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid notification ID")
+		return
+	}
+
+	if err := c.notificationService.MarkRead(userID, uint(notificationID)); err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Notification marked read", nil)
+}
+
+// MarkAllRead marks all notifications read
+// @Summary Mark all notifications read
+// @Description Mark all of your unread notifications as read
+// @Tags notifications
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.SuccessResponse "All notifications marked read"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /notifications/read-all [put]
+func (c *NotificationController) MarkAllRead(ctx *gin.Context) {
+	userID := ctx.GetUint("user_id")
+
+	if err := c.notificationService.MarkAllRead(userID); err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "All notifications marked read", nil)
+}
@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// NotificationPreferenceController handles the user's email notification settings
+type NotificationPreferenceController struct {
+	emailService service.EmailService
+}
+
+// NewNotificationPreferenceController creates a new notification preference controller
+func NewNotificationPreferenceController(emailService service.EmailService) *NotificationPreferenceController {
+	return &NotificationPreferenceController{
+		emailService: emailService,
+	}
+}
+
+// GetPreferences returns the user's notification preferences
+// @Summary Get notification preferences
+// @Description Get which optional emails you receive. Invitations, welcome and password reset emails are always sent.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.NotificationPreferencesResponse "Notification preferences"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Router /users/me/notification-preferences [get]
+func (c *NotificationPreferenceController) GetPreferences(ctx *gin.Context) {
+	userID := ctx.GetUint("userID")
+	prefs, err := c.emailService.GetPreferences(userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, prefs)
+}
+
+// UpdatePreferences changes the user's notification preferences
+// @Summary Update notification preferences
+// @Description Turn optional emails on or off. Omitted fields are left unchanged; email_enabled off stops all optional email.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.UpdateNotificationPreferencesRequest true "Preferences to change"
+// @Success 200 {object} dto.NotificationPreferencesResponse "Updated preferences"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Router /users/me/notification-preferences [put]
+func (c *NotificationPreferenceController) UpdatePreferences(ctx *gin.Context) {
+	var req dto.UpdateNotificationPreferencesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	prefs, err := c.emailService.UpdatePreferences(userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, prefs)
+}
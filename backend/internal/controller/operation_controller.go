@@ -0,0 +1,50 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// OperationController exposes the progress of long-running operations
+type OperationController struct {
+	operationService service.OperationService
+}
+
+// NewOperationController creates a new operation controller
+func NewOperationController(operationService service.OperationService) *OperationController {
+	return &OperationController{
+		operationService: operationService,
+	}
+}
+
+// GetOperation returns an operation's status and progress
+// @Summary Get operation
+// @Description Poll a long-running operation (data export, organization export, purge) started by the current user. Once completed, result_url points to the result and result holds a summary, if the operation produces one.
+// @Tags operations
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Operation ID"
+// @Success 200 {object} dto.OperationResponse "Operation"
+// @Failure 400 {object} dto.ErrorResponse "Invalid operation ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Operation not found"
+// @Router /operations/{id} [get]
+func (c *OperationController) GetOperation(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid operation ID")
+		return
+	}
+
+	op, err := c.operationService.GetOperation(uint(id), ctx.GetUint("userID"))
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, op)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/beuphecan/remote-time-tracker/internal/dto"
 	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
 	"github.com/gin-gonic/gin"
 )
 
@@ -51,14 +52,14 @@ func NewOrganizationController(
 func (c *OrganizationController) Create(ctx *gin.Context) {
 	var req dto.CreateOrganizationRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.ValidationErrorResponse(ctx, err)
 		return
 	}
 
 	userID := ctx.GetUint("userID")
 	org, err := c.orgService.Create(userID, &req)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -81,7 +82,7 @@ func (c *OrganizationController) Create(ctx *gin.Context) {
 func (c *OrganizationController) GetByID(ctx *gin.Context) {
 	orgID, err := strconv.Atoi(ctx.Param("org_id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
 		return
 	}
 
@@ -98,7 +99,7 @@ func (c *OrganizationController) GetByID(ctx *gin.Context) {
 	}
 
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -114,59 +115,238 @@ func (c *OrganizationController) GetByID(ctx *gin.Context) {
 // @Security BearerAuth
 // @Param org_id path int true "Organization ID"
 // @Param request body dto.UpdateOrganizationRequest true "Organization data"
+// @Param If-Match header string false "Version the update is based on, instead of version in the body"
 // @Success 200 {object} dto.OrganizationResponse "Organization updated"
 // @Failure 400 {object} dto.ErrorResponse "Invalid request"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 409 {object} dto.ErrorResponse "Version conflict; details hold the current state"
 // @Router /organizations/{org_id} [put]
 func (c *OrganizationController) Update(ctx *gin.Context) {
 	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
 		return
 	}
 
 	var req dto.UpdateOrganizationRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+	if !bindVersion(ctx, &req.Version) {
 		return
 	}
 
 	userID := ctx.GetUint("userID")
 	org, err := c.orgService.Update(uint(orgID), userID, &req)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		if respondVersionConflict(ctx, err) {
+			return
+		}
+		utils.RespondError(ctx, err)
 		return
 	}
 
 	ctx.JSON(http.StatusOK, org)
 }
 
-// Delete deletes an organization
-// @Summary Delete organization
-// @Description Delete an organization. Only owner can delete. All workspaces and data will be removed.
+// GetCalendar gets organization calendar settings
+// @Summary Get organization calendar
+// @Description Get working days, week start and fiscal year start used for reports and period grouping
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Success 200 {object} dto.OrganizationCalendarResponse "Calendar settings"
+// @Failure 400 {object} dto.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/calendar [get]
+func (c *OrganizationController) GetCalendar(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	cal, err := c.orgService.GetCalendar(uint(orgID), userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, cal)
+}
+
+// UpdateCalendar updates organization calendar settings
+// @Summary Update organization calendar
+// @Description Update working days, week start and fiscal year start. Only owner or admin can update.
 // @Tags organizations
+// @Accept json
+// @Produce json
 // @Security BearerAuth
 // @Param org_id path int true "Organization ID"
-// @Success 204 "Organization deleted"
+// @Param request body dto.UpdateOrganizationCalendarRequest true "Calendar settings"
+// @Success 200 {object} dto.OrganizationCalendarResponse "Calendar updated"
 // @Failure 400 {object} dto.ErrorResponse "Invalid request"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
-// @Failure 403 {object} dto.ErrorResponse "Forbidden - only owner can delete"
-// @Router /organizations/{org_id} [delete]
-func (c *OrganizationController) Delete(ctx *gin.Context) {
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/calendar [put]
+func (c *OrganizationController) UpdateCalendar(ctx *gin.Context) {
 	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	var req dto.UpdateOrganizationCalendarRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
 		return
 	}
 
 	userID := ctx.GetUint("userID")
-	if err := c.orgService.Delete(uint(orgID), userID); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	cal, err := c.orgService.UpdateCalendar(uint(orgID), userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusNoContent, nil)
+	ctx.JSON(http.StatusOK, cal)
+}
+
+// GetRetention gets organization data retention settings
+// @Summary Get organization retention
+// @Description Get how long screenshots are kept before they are purged
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Success 200 {object} dto.OrganizationRetentionResponse "Retention settings"
+// @Failure 400 {object} dto.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/retention [get]
+func (c *OrganizationController) GetRetention(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	retention, err := c.orgService.GetRetention(uint(orgID), userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, retention)
+}
+
+// UpdateRetention updates organization data retention settings
+// @Summary Update organization retention
+// @Description Set how many days screenshots are kept (0 keeps them forever). Expired screenshots are purged by a background job. Only owner or admin can update.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param request body dto.UpdateOrganizationRetentionRequest true "Retention settings"
+// @Success 200 {object} dto.OrganizationRetentionResponse "Retention updated"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/retention [put]
+func (c *OrganizationController) UpdateRetention(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	var req dto.UpdateOrganizationRetentionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	retention, err := c.orgService.UpdateRetention(uint(orgID), userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, retention)
+}
+
+// GetUsageAnalytics gets the organization's feature usage analytics setting
+// @Summary Get organization usage analytics setting
+// @Description Get whether members' feature usage events are collected for product analytics
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Success 200 {object} dto.OrganizationUsageAnalyticsResponse "Usage analytics setting"
+// @Failure 400 {object} dto.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/usage-analytics [get]
+func (c *OrganizationController) GetUsageAnalytics(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	setting, err := c.orgService.GetUsageAnalytics(uint(orgID), userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, setting)
+}
+
+// UpdateUsageAnalytics opts the organization in or out of feature usage analytics
+// @Summary Update organization usage analytics setting
+// @Description Opt the organization out of (or back into) feature usage analytics. While opted out, usage events from members are dropped and previously collected events are hidden and purged by a background job. Only owner or admin can update.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param request body dto.UpdateOrganizationUsageAnalyticsRequest true "Usage analytics setting"
+// @Success 200 {object} dto.OrganizationUsageAnalyticsResponse "Usage analytics setting updated"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/usage-analytics [put]
+func (c *OrganizationController) UpdateUsageAnalytics(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	var req dto.UpdateOrganizationUsageAnalyticsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	setting, err := c.orgService.UpdateUsageAnalytics(uint(orgID), userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, setting)
 }
 
 // List lists user's organizations
@@ -183,7 +363,7 @@ func (c *OrganizationController) List(ctx *gin.Context) {
 	userID := ctx.GetUint("userID")
 	orgs, err := c.orgService.GetUserOrganizations(userID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -209,14 +389,14 @@ func (c *OrganizationController) List(ctx *gin.Context) {
 func (c *OrganizationController) GetMembers(ctx *gin.Context) {
 	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
 		return
 	}
 
 	userID := ctx.GetUint("userID")
 	members, err := c.orgService.GetMembers(uint(orgID), userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -240,20 +420,20 @@ func (c *OrganizationController) GetMembers(ctx *gin.Context) {
 func (c *OrganizationController) AddMember(ctx *gin.Context) {
 	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
 		return
 	}
 
 	var req dto.AddOrganizationMemberRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.ValidationErrorResponse(ctx, err)
 		return
 	}
 
 	userID := ctx.GetUint("userID")
 	member, err := c.orgService.AddMember(uint(orgID), userID, &req)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -278,26 +458,26 @@ func (c *OrganizationController) AddMember(ctx *gin.Context) {
 func (c *OrganizationController) UpdateMember(ctx *gin.Context) {
 	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
 		return
 	}
 
 	memberUserID, err := strconv.ParseUint(ctx.Param("user_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	var req dto.UpdateOrganizationMemberRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.ValidationErrorResponse(ctx, err)
 		return
 	}
 
 	userID := ctx.GetUint("userID")
 	member, err := c.orgService.UpdateMember(uint(orgID), uint(memberUserID), userID, &req)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -319,59 +499,99 @@ func (c *OrganizationController) UpdateMember(ctx *gin.Context) {
 func (c *OrganizationController) RemoveMember(ctx *gin.Context) {
 	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
 		return
 	}
 
 	memberUserID, err := strconv.ParseUint(ctx.Param("user_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	userID := ctx.GetUint("userID")
 	if err := c.orgService.RemoveMember(uint(orgID), uint(memberUserID), userID); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
 	ctx.JSON(http.StatusNoContent, nil)
 }
 
-// ============================================================================
-// JOIN ORGANIZATION
-// ============================================================================
-
-// GetOrgByInviteCode gets organization info by invite code
-// @Summary Get organization by invite code
-// @Description Get public organization info by invite code (used before joining)
+// HandoffWork reassigns a member's pending work to another member
+// @Summary Hand off member work
+// @Description Reassign a member's active tasks and unapproved time logs in the organization to another active member in one operation. Intended for members being removed; a removed member's running timers are stopped and included. Only owner or admin can hand off work.
 // @Tags organizations
+// @Accept json
 // @Produce json
-// @Param invite_code path string true "Invite code"
-// @Success 200 {object} dto.SuccessResponse "Organization found"
-// @Failure 400 {object} dto.ErrorResponse "Invite code required"
-// @Failure 404 {object} dto.ErrorResponse "Organization not found"
-// @Router /organizations/join/{invite_code} [get]
-func (c *OrganizationController) GetOrgByInviteCode(ctx *gin.Context) {
-	code := ctx.Param("invite_code")
-	if code == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invite code is required"})
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param user_id path int true "User ID whose work is handed off"
+// @Param request body dto.MemberHandoffRequest true "Target member"
+// @Success 200 {object} dto.MemberHandoffResponse "Transfer summary"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Only admins can hand off work"
+// @Router /organizations/{org_id}/members/{user_id}/handoff [post]
+func (c *OrganizationController) HandoffWork(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
 		return
 	}
 
-	org, err := c.orgService.GetOrgByInviteCode(code)
+	memberUserID, err := strconv.ParseUint(ctx.Param("user_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	var req dto.MemberHandoffRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	summary, err := c.orgService.HandoffWork(uint(orgID), uint(memberUserID), userID, &req)
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"message": "Organization found",
-		"data":    org,
-	})
+	ctx.JSON(http.StatusOK, summary)
 }
 
+// Leave removes the current user from the organization
+// @Summary Leave organization
+// @Description Leave the organization. Running timers in the organization are stopped, workspace memberships removed, and workspaces you administer are handed to the owner. The owner must transfer ownership before leaving.
+// @Tags organizations
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Success 204 "Left organization"
+// @Failure 400 {object} dto.ErrorResponse "Not a member or owner"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Router /organizations/{org_id}/leave [post]
+func (c *OrganizationController) Leave(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	if err := c.orgService.Leave(uint(orgID), userID); err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ============================================================================
+// JOIN ORGANIZATION
+// ============================================================================
+
 // JoinByInviteCode joins organization by invite code
 // @Summary Join organization by invite code
 // @Description Join an organization using its invite code
@@ -386,14 +606,14 @@ func (c *OrganizationController) GetOrgByInviteCode(ctx *gin.Context) {
 func (c *OrganizationController) JoinByInviteCode(ctx *gin.Context) {
 	code := ctx.Param("code")
 	if code == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invite code is required"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invite code is required")
 		return
 	}
 
 	userID := ctx.GetUint("userID")
 	member, err := c.orgService.JoinByInviteCode(userID, code)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -415,14 +635,14 @@ func (c *OrganizationController) JoinByInviteCode(ctx *gin.Context) {
 func (c *OrganizationController) RegenerateInviteCode(ctx *gin.Context) {
 	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
 		return
 	}
 
 	userID := ctx.GetUint("userID")
 	code, err := c.orgService.RegenerateInviteCode(uint(orgID), userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -446,19 +666,19 @@ func (c *OrganizationController) RegenerateInviteCode(ctx *gin.Context) {
 func (c *OrganizationController) TransferOwnership(ctx *gin.Context) {
 	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
 		return
 	}
 
 	var req dto.TransferOwnershipRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.ValidationErrorResponse(ctx, err)
 		return
 	}
 
 	userID := ctx.GetUint("userID")
 	if err := c.orgService.TransferOwnership(uint(orgID), userID, &req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -483,14 +703,14 @@ func (c *OrganizationController) TransferOwnership(ctx *gin.Context) {
 func (c *OrganizationController) GetRoles(ctx *gin.Context) {
 	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
 		return
 	}
 
 	userID := ctx.GetUint("userID")
 	roles, err := c.roleService.GetByOrganization(uint(orgID), userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -514,20 +734,20 @@ func (c *OrganizationController) GetRoles(ctx *gin.Context) {
 func (c *OrganizationController) CreateRole(ctx *gin.Context) {
 	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
 		return
 	}
 
 	var req dto.CreateWorkspaceRoleRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.ValidationErrorResponse(ctx, err)
 		return
 	}
 
 	userID := ctx.GetUint("userID")
 	role, err := c.roleService.Create(uint(orgID), userID, &req)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -552,20 +772,20 @@ func (c *OrganizationController) CreateRole(ctx *gin.Context) {
 func (c *OrganizationController) UpdateRole(ctx *gin.Context) {
 	roleID, err := strconv.ParseUint(ctx.Param("role_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid role ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid role ID")
 		return
 	}
 
 	var req dto.UpdateWorkspaceRoleRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.ValidationErrorResponse(ctx, err)
 		return
 	}
 
 	userID := ctx.GetUint("userID")
 	role, err := c.roleService.Update(uint(roleID), userID, &req)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -587,13 +807,13 @@ func (c *OrganizationController) UpdateRole(ctx *gin.Context) {
 func (c *OrganizationController) DeleteRole(ctx *gin.Context) {
 	roleID, err := strconv.ParseUint(ctx.Param("role_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid role ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid role ID")
 		return
 	}
 
 	userID := ctx.GetUint("userID")
 	if err := c.roleService.Delete(uint(roleID), userID); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -618,14 +838,14 @@ func (c *OrganizationController) DeleteRole(ctx *gin.Context) {
 func (c *OrganizationController) GetWorkspaces(ctx *gin.Context) {
 	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
 		return
 	}
 
 	userID := ctx.GetUint("userID")
 	workspaces, err := c.workspaceService.GetWorkspacesByOrg(uint(orgID), userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -649,20 +869,20 @@ func (c *OrganizationController) GetWorkspaces(ctx *gin.Context) {
 func (c *OrganizationController) CreateWorkspace(ctx *gin.Context) {
 	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
 		return
 	}
 
 	var req dto.CreateWorkspaceRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.ValidationErrorResponse(ctx, err)
 		return
 	}
 
 	userID := ctx.GetUint("userID")
 	workspace, err := c.workspaceService.Create(uint(orgID), userID, &req)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -687,14 +907,14 @@ func (c *OrganizationController) CreateWorkspace(ctx *gin.Context) {
 func (c *OrganizationController) GetInvitations(ctx *gin.Context) {
 	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
 		return
 	}
 
 	userID := ctx.GetUint("userID")
 	invitations, err := c.invitationService.GetPendingByOrg(uint(orgID), userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -718,20 +938,20 @@ func (c *OrganizationController) GetInvitations(ctx *gin.Context) {
 func (c *OrganizationController) CreateInvitation(ctx *gin.Context) {
 	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
 		return
 	}
 
 	var req dto.CreateInvitationRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.ValidationErrorResponse(ctx, err)
 		return
 	}
 
 	userID := ctx.GetUint("userID")
 	invitation, err := c.invitationService.Create(uint(orgID), userID, &req)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -753,13 +973,13 @@ func (c *OrganizationController) CreateInvitation(ctx *gin.Context) {
 func (c *OrganizationController) RevokeInvitation(ctx *gin.Context) {
 	invitationID, err := strconv.ParseUint(ctx.Param("invitation_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid invitation ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid invitation ID")
 		return
 	}
 
 	userID := ctx.GetUint("userID")
 	if err := c.invitationService.Revoke(uint(invitationID), userID); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
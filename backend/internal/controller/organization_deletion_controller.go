@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// OrganizationDeletionController handles the scheduled deletion of organizations
+type OrganizationDeletionController struct {
+	deletionService service.OrganizationDeletionService
+}
+
+// NewOrganizationDeletionController creates a new organization deletion controller
+func NewOrganizationDeletionController(deletionService service.OrganizationDeletionService) *OrganizationDeletionController {
+	return &OrganizationDeletionController{
+		deletionService: deletionService,
+	}
+}
+
+// RequestDeletion schedules an organization for deletion
+// @Summary Delete organization
+// @Description Schedule the organization for deletion. Only owner can delete. After a grace period (14 days by default) its workspaces, tasks, time logs, screenshots, invitations, memberships and all other data are permanently purged in the background; until then the owner can cancel the deletion. Requesting it again keeps the original schedule.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Success 202 {object} dto.OrganizationDeletionResponse "Organization deletion scheduled"
+// @Failure 400 {object} dto.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - only owner can delete"
+// @Router /organizations/{org_id} [delete]
+func (c *OrganizationDeletionController) RequestDeletion(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	deletion, err := c.deletionService.RequestDeletion(uint(orgID), userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, deletion)
+}
+
+// CancelDeletion cancels a pending organization deletion
+// @Summary Cancel organization deletion
+// @Description Cancel the organization's pending deletion during its grace period. Only owner can cancel.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Success 200 {object} dto.SuccessResponse "Organization deletion cancelled"
+// @Failure 400 {object} dto.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - only owner can cancel"
+// @Failure 409 {object} dto.ErrorResponse "No deletion is scheduled"
+// @Router /organizations/{org_id}/deletion/cancel [post]
+func (c *OrganizationDeletionController) CancelDeletion(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	if err := c.deletionService.CancelDeletion(uint(orgID), userID); err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Organization deletion cancelled", nil)
+}
@@ -0,0 +1,149 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// OrganizationExportController handles full organization data exports
+type OrganizationExportController struct {
+	exportService service.OrganizationExportService
+}
+
+// NewOrganizationExportController creates a new organization export controller
+func NewOrganizationExportController(exportService service.OrganizationExportService) *OrganizationExportController {
+	return &OrganizationExportController{
+		exportService: exportService,
+	}
+}
+
+// RequestExport queues an organization export
+// @Summary Request organization data export
+// @Description Queue a ZIP archive of the organization's members, workspaces, tasks, time logs (JSON) and screenshot files. The archive is built asynchronously; poll the export for its progress and a signed download link. Only the owner can export.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Success 202 {object} dto.OrganizationExportResponse "Export queued"
+// @Failure 400 {object} dto.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 409 {object} dto.ErrorResponse "Export already in progress"
+// @Router /organizations/{org_id}/export [post]
+func (c *OrganizationExportController) RequestExport(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	export, err := c.exportService.RequestExport(uint(orgID), userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, export)
+}
+
+// ListExports lists the organization's exports
+// @Summary List organization data exports
+// @Description List the organization's exports, newest first. Only the owner can view.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Success 200 {array} dto.OrganizationExportResponse "Exports"
+// @Failure 400 {object} dto.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/exports [get]
+func (c *OrganizationExportController) ListExports(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	exports, err := c.exportService.ListExports(uint(orgID), userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, exports)
+}
+
+// GetExport returns an export's progress
+// @Summary Get organization data export
+// @Description Poll an export's status and progress. Completed exports include a signed download_url that works without a token until download_url_expires_at; poll again for a fresh link.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param export_id path int true "Export ID"
+// @Success 200 {object} dto.OrganizationExportResponse "Export"
+// @Failure 400 {object} dto.ErrorResponse "Invalid ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Export not found"
+// @Router /organizations/{org_id}/exports/{export_id} [get]
+func (c *OrganizationExportController) GetExport(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+	exportID, err := strconv.ParseUint(ctx.Param("export_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid export ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	export, err := c.exportService.GetExport(uint(orgID), uint(exportID), userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, export)
+}
+
+// DownloadExport serves an export archive through a signed link
+// @Summary Download organization data export
+// @Description Download a completed export archive. The link is signed and time-limited, so no bearer token is needed; get one from the export's download_url.
+// @Tags organizations
+// @Produce application/zip
+// @Param export_id path int true "Export ID"
+// @Param expires query int true "Link expiry (unix seconds)"
+// @Param signature query string true "Link signature"
+// @Success 200 {file} file "Export archive"
+// @Failure 400 {object} dto.ErrorResponse "Export not ready"
+// @Failure 403 {object} dto.ErrorResponse "Invalid or expired link"
+// @Failure 404 {object} dto.ErrorResponse "Export not found"
+// @Router /public/organization-exports/{export_id}/download [get]
+func (c *OrganizationExportController) DownloadExport(ctx *gin.Context) {
+	exportID, err := strconv.ParseUint(ctx.Param("export_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid export ID")
+		return
+	}
+
+	export, err := c.exportService.GetSignedExportFile(uint(exportID), ctx.Query("expires"), ctx.Query("signature"))
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.Header("Content-Description", "File Transfer")
+	ctx.Header("Content-Disposition", "attachment; filename="+export.FileName)
+	ctx.Header("Content-Type", "application/zip")
+	ctx.File(export.FilePath)
+}
@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportFileSize bounds uploaded CSV files
+const maxImportFileSize = 20 << 20
+
+// OrganizationImportController handles importing time data from other trackers
+type OrganizationImportController struct {
+	importService service.OrganizationImportService
+}
+
+// NewOrganizationImportController creates a new organization import controller
+func NewOrganizationImportController(importService service.OrganizationImportService) *OrganizationImportController {
+	return &OrganizationImportController{
+		importService: importService,
+	}
+}
+
+// Import imports a CSV export from another time tracker
+// @Summary Import time data from CSV
+// @Description Import a Hubstaff, Toggl or Clockify CSV export as tasks and time logs. Users are matched to organization members by email (or full name when the export has none). Valid rows are imported and invalid ones are listed with their line number; rows imported before are skipped, so a corrected file can be imported again. With dry_run rows are only validated. Only organization admins can import.
+// @Tags organizations
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param file formData file true "CSV export"
+// @Param source formData string false "CSV format: auto (default), hubstaff, toggl or clockify"
+// @Param workspace_id formData int false "Workspace for imported tasks and time logs"
+// @Param timezone formData string false "IANA timezone of the CSV's dates and times (default UTC)"
+// @Param dry_run formData bool false "Validate without importing"
+// @Success 200 {object} dto.OrganizationImportResponse "Import report"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request or file"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 413 {object} dto.ErrorResponse "File too large"
+// @Router /organizations/{org_id}/import [post]
+func (c *OrganizationImportController) Import(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxImportFileSize+(1<<20))
+
+	var req dto.OrganizationImportRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			utils.ErrorResponse(ctx, http.StatusRequestEntityTooLarge, "CSV file is too large")
+			return
+		}
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "CSV file is required")
+		return
+	}
+	if fileHeader.Size > maxImportFileSize {
+		utils.ErrorResponse(ctx, http.StatusRequestEntityTooLarge, "CSV file is too large")
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "failed to read CSV file")
+		return
+	}
+	defer file.Close()
+
+	result, err := c.importService.Import(uint(orgID), ctx.GetUint("userID"), &req, file)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// OrganizationSettingsController handles an organization's typed settings
+type OrganizationSettingsController struct {
+	settingsService service.OrganizationSettingsService
+}
+
+// NewOrganizationSettingsController creates a new organization settings controller
+func NewOrganizationSettingsController(settingsService service.OrganizationSettingsService) *OrganizationSettingsController {
+	return &OrganizationSettingsController{
+		settingsService: settingsService,
+	}
+}
+
+// GetSettings returns the organization's settings
+// @Summary Get organization settings
+// @Description Get every organization setting with its value, its default and the JSON Schema its value must match. Settings the organization has not changed have is_default set.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Success 200 {object} dto.OrganizationSettingsResponse "Organization settings"
+// @Failure 400 {object} dto.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/settings [get]
+func (c *OrganizationSettingsController) GetSettings(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	settings, err := c.settingsService.Get(uint(orgID), userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, settings)
+}
+
+// UpdateSettings changes organization settings
+// @Summary Update organization settings
+// @Description Set the given settings, each validated against its schema; null resets a setting to its default. Settings left out are unchanged. Only owner or admin can change settings.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param request body dto.UpdateOrganizationSettingsRequest true "Settings by key"
+// @Success 200 {object} dto.OrganizationSettingsResponse "Organization settings"
+// @Failure 400 {object} dto.ErrorResponse "Unknown setting or invalid value"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/settings [put]
+func (c *OrganizationSettingsController) UpdateSettings(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	var req dto.UpdateOrganizationSettingsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	settings, err := c.settingsService.Update(uint(orgID), userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, settings)
+}
@@ -0,0 +1,221 @@
+package controller
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/money"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// PayrollController handles member cost rates and the payroll report
+type PayrollController struct {
+	payrollService service.PayrollService
+}
+
+// NewPayrollController creates a new payroll controller
+func NewPayrollController(payrollService service.PayrollService) *PayrollController {
+	return &PayrollController{
+		payrollService: payrollService,
+	}
+}
+
+// ListCostRates lists the members' cost rates
+// @Summary List member cost rates
+// @Description List the hourly cost rate of each active member, used for payroll. Cost rates are separate from the client-facing workspace hourly rate. Only owner or admin can list.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Success 200 {array} dto.MemberCostRateResponse "Member cost rates"
+// @Failure 400 {object} dto.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/cost-rates [get]
+func (c *PayrollController) ListCostRates(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	rates, err := c.payrollService.ListCostRates(uint(orgID), userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rates)
+}
+
+// UpdateCostRate sets a member's cost rate
+// @Summary Set member cost rate
+// @Description Set the hourly cost rate of a member, or clear it with null. Only owner or admin can set cost rates.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param user_id path int true "Member user ID"
+// @Param request body dto.UpdateCostRateRequest true "Cost rate"
+// @Success 200 {object} dto.MemberCostRateResponse "Cost rate updated"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Member not found"
+// @Router /organizations/{org_id}/cost-rates/{user_id} [put]
+func (c *PayrollController) UpdateCostRate(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	memberUserID, err := strconv.ParseUint(ctx.Param("user_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	var req dto.UpdateCostRateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	rate, err := c.payrollService.UpdateCostRate(uint(orgID), uint(memberUserID), userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rate)
+}
+
+// GetReport gets the payroll report
+// @Summary Get payroll report
+// @Description Get approved hours times cost rate per member, pay period, cost center and project code; a time log takes its task's cost center and project code, else its workspace's. The date range is widened to whole pay periods of the organization calendar; time logs count in the period they started in, in the tz parameter's time zone. Members without a cost rate are listed with a cost of 0 and counted in missing_cost_rates. Only owner or admin can view.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param period query string false "Pay period: week or month" default(month)
+// @Param start_date query string false "Start date (YYYY-MM-DD), defaults to the current pay period"
+// @Param end_date query string false "End date (YYYY-MM-DD, inclusive), defaults to start_date"
+// @Param tz query string false "IANA time zone of the dates, defaults to the organization's timezone"
+// @Success 200 {object} dto.PayrollReport "Payroll report"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/reports/payroll [get]
+func (c *PayrollController) GetReport(ctx *gin.Context) {
+	report, ok := c.report(ctx)
+	if !ok {
+		return
+	}
+	ctx.JSON(http.StatusOK, report)
+}
+
+// ExportReport exports the payroll report as CSV
+// @Summary Export payroll report as CSV
+// @Description Export the payroll report as CSV with one row per member, pay period, cost center and project code, for handing to payroll. Takes the same parameters as the payroll report. Only owner or admin can export.
+// @Tags organizations
+// @Produce text/csv
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param period query string false "Pay period: week or month" default(month)
+// @Param start_date query string false "Start date (YYYY-MM-DD), defaults to the current pay period"
+// @Param end_date query string false "End date (YYYY-MM-DD, inclusive), defaults to start_date"
+// @Param tz query string false "IANA time zone of the dates, defaults to the organization's timezone"
+// @Success 200 {file} file "CSV file"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/reports/payroll/export [get]
+func (c *PayrollController) ExportReport(ctx *gin.Context) {
+	report, ok := c.report(ctx)
+	if !ok {
+		return
+	}
+
+	filename := fmt.Sprintf("payroll-%s-%s.csv", report.StartDate.Format("20060102"), report.EndDate.AddDate(0, 0, -1).Format("20060102"))
+	ctx.Header("Content-Type", "text/csv; charset=utf-8")
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	ctx.Status(http.StatusOK)
+
+	w := csv.NewWriter(ctx.Writer)
+	_ = w.Write([]string{
+		"user_id", "email", "name", "period", "period_start", "period_end",
+		"cost_center", "project_code", "approved_hours", "cost_rate", "cost", "currency",
+	})
+	for _, r := range report.Rows {
+		costRate := ""
+		if r.CostRate != nil {
+			costRate = strconv.FormatFloat(*r.CostRate, 'f', money.Exponent(report.Currency), 64)
+		}
+		_ = w.Write([]string{
+			strconv.FormatUint(uint64(r.UserID), 10),
+			r.Email,
+			r.Name,
+			r.Period,
+			r.PeriodStart.Format("2006-01-02"),
+			r.PeriodEnd.AddDate(0, 0, -1).Format("2006-01-02"),
+			r.CostCenter,
+			r.ProjectCode,
+			strconv.FormatFloat(r.ApprovedHours, 'f', 2, 64),
+			costRate,
+			strconv.FormatFloat(r.Cost, 'f', money.Exponent(report.Currency), 64),
+			report.Currency,
+		})
+	}
+	w.Flush()
+}
+
+// report reads the report parameters and builds the report, writing the error response on failure
+func (c *PayrollController) report(ctx *gin.Context) (*dto.PayrollReport, bool) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return nil, false
+	}
+
+	params := &dto.PayrollReportParams{Period: ctx.Query("period")}
+	if startDate := ctx.Query("start_date"); startDate != "" {
+		t, err := time.Parse("2006-01-02", startDate)
+		if err != nil {
+			utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid start_date: use YYYY-MM-DD")
+			return nil, false
+		}
+		params.StartDate = &t
+	}
+	if endDate := ctx.Query("end_date"); endDate != "" {
+		t, err := time.Parse("2006-01-02", endDate)
+		if err != nil {
+			utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid end_date: use YYYY-MM-DD")
+			return nil, false
+		}
+		params.EndDate = &t
+	}
+	loc, err := parseTimezoneParam(ctx)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return nil, false
+	}
+	params.Location = loc
+
+	userID := ctx.GetUint("userID")
+	report, err := c.payrollService.GetReport(uint(orgID), userID, params)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return nil, false
+	}
+	return report, true
+}
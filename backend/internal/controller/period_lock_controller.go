@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// PeriodLockController handles closed pay periods
+type PeriodLockController struct {
+	periodLockService service.PeriodLockService
+}
+
+// NewPeriodLockController creates a new period lock controller
+func NewPeriodLockController(periodLockService service.PeriodLockService) *PeriodLockController {
+	return &PeriodLockController{
+		periodLockService: periodLockService,
+	}
+}
+
+// GetLock gets the organization's lock date
+// @Summary Get pay period lock
+// @Description Get the date before which time entries are locked. Members cannot add manual entries or sync edits of time logs starting before it; such changes are rejected with code period_locked.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Success 200 {object} dto.PeriodLockResponse "Pay period lock"
+// @Failure 400 {object} dto.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/period-lock [get]
+func (c *PeriodLockController) GetLock(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	lock, err := c.periodLockService.Get(uint(orgID), userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, lock)
+}
+
+// Lock closes the pay periods before a date
+// @Summary Lock pay periods
+// @Description Lock time entries starting before a date, e.g. the first day of the month after a closed payroll month. Members can no longer add or edit them; admins still can. The lock date can only move forward here; use reopen to move it back. Only owner or admin can lock.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param request body dto.LockPeriodRequest true "Lock date"
+// @Success 200 {object} dto.PeriodLockResponse "Pay periods locked"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/period-lock [put]
+func (c *PeriodLockController) Lock(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	var req dto.LockPeriodRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	lock, err := c.periodLockService.Lock(uint(orgID), userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, lock)
+}
+
+// Reopen reopens closed pay periods
+// @Summary Reopen pay periods
+// @Description Move the lock date back, or remove the lock when locked_before is empty, so members can edit the reopened periods again. The reason is recorded in the audit log. Only owner or admin can reopen.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param request body dto.ReopenPeriodRequest true "New lock date and reason"
+// @Success 200 {object} dto.PeriodLockResponse "Pay periods reopened"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 409 {object} dto.ErrorResponse "No pay period is locked"
+// @Router /organizations/{org_id}/period-lock/reopen [post]
+func (c *PeriodLockController) Reopen(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	var req dto.ReopenPeriodRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	lock, err := c.periodLockService.Reopen(uint(orgID), userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, lock)
+}
@@ -0,0 +1,213 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// PermissionController handles member effective permissions and role history
+type PermissionController struct {
+	permissionService service.PermissionService
+}
+
+// NewPermissionController creates a new permission controller
+func NewPermissionController(permissionService service.PermissionService) *PermissionController {
+	return &PermissionController{
+		permissionService: permissionService,
+	}
+}
+
+// memberParams parses the organization and member user IDs from the path
+func memberParams(ctx *gin.Context) (uint, uint, bool) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return 0, 0, false
+	}
+
+	memberUserID, err := strconv.ParseUint(ctx.Param("user_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid user ID")
+		return 0, 0, false
+	}
+
+	return uint(orgID), uint(memberUserID), true
+}
+
+// GetMemberPermissions returns a member's effective permissions
+// @Summary Get member effective permissions
+// @Description Resolve what a member can do in the organization from their organization role, workspace memberships and roles, and system role. Organization owners and admins manage every workspace. Members can view their own permissions; only owner, admin or system admin can view others.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param user_id path int true "User ID"
+// @Success 200 {object} dto.MemberPermissionsResponse "Effective permissions"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/members/{user_id}/permissions [get]
+func (c *PermissionController) GetMemberPermissions(ctx *gin.Context) {
+	orgID, memberUserID, ok := memberParams(ctx)
+	if !ok {
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	permissions, err := c.permissionService.GetMemberPermissions(orgID, memberUserID, userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, permissions)
+}
+
+// GetRoleHistory lists a member's role changes
+// @Summary Get member role change history
+// @Description Audit trail of the member's organization role, workspace role and permission flag changes in this organization, and of their system role, newest first
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param user_id path int true "User ID"
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(20)
+// @Success 200 {object} map[string]interface{} "Role changes with pagination"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/members/{user_id}/role-history [get]
+func (c *PermissionController) GetRoleHistory(ctx *gin.Context) {
+	orgID, memberUserID, ok := memberParams(ctx)
+	if !ok {
+		return
+	}
+
+	page := parseIntParam(ctx, "page", 1)
+	perPage := parseIntParam(ctx, "per_page", 20)
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+
+	userID := ctx.GetUint("userID")
+	changes, total, err := c.permissionService.GetRoleHistory(orgID, memberUserID, userID, page, perPage)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"changes": changes,
+		"pagination": dto.PaginationMeta{
+			Page:       page,
+			PerPage:    perPage,
+			Total:      total,
+			TotalPages: int((total + int64(perPage) - 1) / int64(perPage)),
+		},
+	})
+}
+
+// GetPermissionSchema lists the workspace permissions roles can grant
+// @Summary Get workspace permission schema
+// @Description List every permission a workspace role's matrix can grant or revoke, e.g. tasks.create, reports.view, screenshots.view, members.manage. Default permissions are held by members unless their role revokes them; workspace managers hold all of them.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Success 200 {array} dto.WorkspacePermissionDefinitionResponse "Permission schema"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Router /organizations/{org_id}/roles/permission-schema [get]
+func (c *PermissionController) GetPermissionSchema(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, c.permissionService.GetPermissionSchema())
+}
+
+// roleParams parses the organization and role IDs from the path
+func roleParams(ctx *gin.Context) (uint, uint, bool) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return 0, 0, false
+	}
+
+	roleID, err := strconv.ParseUint(ctx.Param("role_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid role ID")
+		return 0, 0, false
+	}
+
+	return uint(orgID), uint(roleID), true
+}
+
+// GetRolePermissions returns a workspace role's permission matrix
+// @Summary Get role permission matrix
+// @Description Get the permissions members with this workspace role hold, over the whole schema. Legacy member flags and admin rights can grant more.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param role_id path int true "Role ID"
+// @Success 200 {object} dto.RolePermissionsResponse "Role permission matrix"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Router /organizations/{org_id}/roles/{role_id}/permissions [get]
+func (c *PermissionController) GetRolePermissions(ctx *gin.Context) {
+	orgID, roleID, ok := roleParams(ctx)
+	if !ok {
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	permissions, err := c.permissionService.GetRolePermissions(orgID, roleID, userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, permissions)
+}
+
+// UpdateRolePermissions replaces a workspace role's permission matrix
+// @Summary Update role permission matrix
+// @Description Replace the role's permission matrix: true grants a permission, false revokes a default one, and permissions left out keep their default. Only owner or admin can edit.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param role_id path int true "Role ID"
+// @Param request body dto.UpdateRolePermissionsRequest true "Permission matrix"
+// @Success 200 {object} dto.RolePermissionsResponse "Updated permission matrix"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request or unknown permission"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/roles/{role_id}/permissions [put]
+func (c *PermissionController) UpdateRolePermissions(ctx *gin.Context) {
+	orgID, roleID, ok := roleParams(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateRolePermissionsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	permissions, err := c.permissionService.UpdateRolePermissions(orgID, roleID, userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, permissions)
+}
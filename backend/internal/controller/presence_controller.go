@@ -41,13 +41,13 @@ func (c *PresenceController) Heartbeat(ctx *gin.Context) {
 
 	var req dto.PresenceHeartbeatRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		utils.ValidationErrorResponse(ctx, err)
 		return
 	}
 
 	resp, err := c.presenceService.UpdatePresence(userID, &req)
 	if err != nil {
-		utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		utils.RespondError(ctx, err)
 		return
 	}
 
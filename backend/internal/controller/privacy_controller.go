@@ -0,0 +1,206 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/middleware"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// PrivacyController handles personal data export and account erasure requests
+type PrivacyController struct {
+	privacyService service.PrivacyService
+}
+
+// NewPrivacyController creates a new privacy controller
+func NewPrivacyController(privacyService service.PrivacyService) *PrivacyController {
+	return &PrivacyController{
+		privacyService: privacyService,
+	}
+}
+
+// RequestExport handles requesting a personal data export
+// @Summary Request personal data export
+// @Description Queue an archive of all of the current user's tasks, time logs, screenshots and devices. The archive is built asynchronously; poll the export for its status.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Success 202 {object} dto.SuccessResponse{data=dto.DataExportResponse} "Data export queued"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 409 {object} dto.ErrorResponse "Export already in progress"
+// @Router /users/me/export [post]
+func (ctrl *PrivacyController) RequestExport(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	export, err := ctrl.privacyService.RequestExport(userID)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusAccepted, "Data export queued", export)
+}
+
+// ListExports handles listing the current user's data exports
+// @Summary List personal data exports
+// @Description Get all data exports of the current user
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.DataExportResponse} "Data exports retrieved successfully"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /users/me/exports [get]
+func (ctrl *PrivacyController) ListExports(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	exports, err := ctrl.privacyService.ListExports(userID)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Data exports retrieved successfully", exports)
+}
+
+// GetExport handles retrieving a data export status
+// @Summary Get personal data export
+// @Description Get the status of a data export of the current user
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Export ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.DataExportResponse} "Data export retrieved successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid export ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Data export not found"
+// @Router /users/me/exports/{id} [get]
+func (ctrl *PrivacyController) GetExport(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid export ID")
+		return
+	}
+
+	export, err := ctrl.privacyService.GetExport(uint(id), userID)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Data export retrieved successfully", export)
+}
+
+// DownloadExport serves a completed data export archive
+// @Summary Download personal data export
+// @Description Download a completed data export archive (zip)
+// @Tags users
+// @Produce application/zip
+// @Security BearerAuth
+// @Param id path int true "Export ID"
+// @Success 200 {file} file "Export archive"
+// @Failure 400 {object} dto.ErrorResponse "Invalid export ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Data export not found or not ready"
+// @Router /users/me/exports/{id}/download [get]
+func (ctrl *PrivacyController) DownloadExport(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid export ID")
+		return
+	}
+
+	export, err := ctrl.privacyService.GetExportFile(uint(id), userID)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	c.Header("Content-Description", "File Transfer")
+	c.Header("Content-Disposition", "attachment; filename="+export.FileName)
+	c.Header("Content-Type", "application/zip")
+	c.File(export.FilePath)
+}
+
+// RequestDeletion handles scheduling the current account for erasure
+// @Summary Delete my account
+// @Description Schedule the current account for erasure. After the grace period the account is anonymized and its screenshots, devices and exports are purged. The request can be cancelled during the grace period.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.AccountDeletionRequest true "Password confirmation"
+// @Success 202 {object} dto.SuccessResponse{data=dto.AccountDeletionResponse} "Account deletion scheduled"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request or password"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Router /users/me [delete]
+func (ctrl *PrivacyController) RequestDeletion(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req dto.AccountDeletionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	result, err := ctrl.privacyService.RequestErasure(userID, &req)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusAccepted, "Account deletion scheduled", result)
+}
+
+// CancelDeletion handles cancelling a scheduled account erasure
+// @Summary Cancel account deletion
+// @Description Cancel a scheduled account erasure during the grace period
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.SuccessResponse "Account deletion cancelled"
+// @Failure 400 {object} dto.ErrorResponse "No deletion scheduled"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Router /users/me/deletion/cancel [post]
+func (ctrl *PrivacyController) CancelDeletion(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	if err := ctrl.privacyService.CancelErasure(userID); err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Account deletion cancelled", nil)
+}
@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// PrivateIntervalController handles privacy mode, the user's pauses of
+// screenshot capture
+type PrivateIntervalController struct {
+	privateIntervalService service.PrivateIntervalService
+}
+
+// NewPrivateIntervalController creates a new private interval controller
+func NewPrivateIntervalController(privateIntervalService service.PrivateIntervalService) *PrivateIntervalController {
+	return &PrivateIntervalController{
+		privateIntervalService: privateIntervalService,
+	}
+}
+
+// ListPrivateIntervals lists the user's private intervals
+// @Summary List private intervals
+// @Description List your private intervals started in a date range, oldest first, with their total. Intervals still in progress count up to now.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param start_date query string false "Start date (YYYY-MM-DD), defaults to 30 days before end_date"
+// @Param end_date query string false "End date, inclusive (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} dto.PrivateIntervalListResponse "Private intervals"
+// @Failure 400 {object} dto.ErrorResponse "Invalid date range"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Router /users/me/private-intervals [get]
+func (c *PrivateIntervalController) ListPrivateIntervals(ctx *gin.Context) {
+	params, err := parseReportSummaryParams(ctx)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	intervals, err := c.privateIntervalService.List(userID, params)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, intervals)
+}
+
+// StartPrivateInterval turns private mode on
+// @Summary Start private interval
+// @Description Pause screenshot capture for a reason (personal, confidential, meeting, other). Screenshots captured until the interval ends are discarded on upload. The time keeps being tracked, labelled on the given or running time log, and managers see its total as private time in reports. With end_time, records an interval that already ended, such as one kept offline.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.StartPrivateIntervalRequest true "Private interval"
+// @Success 201 {object} dto.PrivateIntervalResponse "Private interval started"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Time log not found"
+// @Failure 409 {object} dto.ErrorResponse "Private mode already on or interval overlaps another"
+// @Router /users/me/private-intervals [post]
+func (c *PrivateIntervalController) StartPrivateInterval(ctx *gin.Context) {
+	var req dto.StartPrivateIntervalRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	interval, err := c.privateIntervalService.Start(userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, interval)
+}
+
+// EndPrivateInterval turns private mode off
+// @Summary End private interval
+// @Description End a private interval now; screenshot capture resumes. Ending an interval that already ended changes nothing.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Private interval ID"
+// @Success 200 {object} dto.PrivateIntervalResponse "Private interval ended"
+// @Failure 400 {object} dto.ErrorResponse "Invalid private interval ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Private interval not found"
+// @Router /users/me/private-intervals/{id}/end [post]
+func (c *PrivateIntervalController) EndPrivateInterval(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid private interval ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	interval, err := c.privateIntervalService.End(userID, uint(id))
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, interval)
+}
@@ -0,0 +1,234 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// ReportController serves tracked time dashboards to workspace members and users
+type ReportController struct {
+	reportService service.ReportService
+}
+
+// NewReportController creates a new report controller
+func NewReportController(reportService service.ReportService) *ReportController {
+	return &ReportController{
+		reportService: reportService,
+	}
+}
+
+// GetWorkspaceSummary gets a workspace's report summary
+// @Summary Get workspace report summary
+// @Description Get the workspace's tracked time over a date range: daily, weekly and monthly durations, top tasks, top members, time spent in private mode and activity by hour and weekday. Days and hours follow the time logs' start time in the tz parameter, defaulting to the organization's timezone; weeks follow the organization calendar. Members who can view reports and workspace managers can view.
+// @Tags workspaces
+// @Produce json
+// @Security BearerAuth
+// @Param workspace_id path int true "Workspace ID"
+// @Param start_date query string false "Start date (YYYY-MM-DD), defaults to 30 days before end_date"
+// @Param end_date query string false "End date, inclusive (YYYY-MM-DD), defaults to today"
+// @Param tz query string false "IANA time zone of the dates, days and hours, e.g. Europe/Berlin"
+// @Param locale query string false "Locale for human-readable values (en/vi), defaults to Accept-Language"
+// @Success 200 {object} dto.ReportSummary "Report summary"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /workspaces/{workspace_id}/reports/summary [get]
+func (c *ReportController) GetWorkspaceSummary(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseUint(ctx.Param("workspace_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid workspace ID")
+		return
+	}
+
+	params, err := parseReportSummaryParams(ctx)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	summary, err := c.reportService.GetWorkspaceSummary(uint(workspaceID), userID, params, requestLocale(ctx))
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, summary)
+}
+
+// GetMySummary gets the current user's report summary
+// @Summary Get my report summary
+// @Description Get your own tracked time over a date range: daily, weekly and monthly durations, top tasks, time spent in private mode and activity by hour and weekday. Days and hours follow the time logs' start time in the tz parameter, defaulting to your timezone; with a workspace, weeks follow its organization calendar.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param start_date query string false "Start date (YYYY-MM-DD), defaults to 30 days before end_date"
+// @Param end_date query string false "End date, inclusive (YYYY-MM-DD), defaults to today"
+// @Param tz query string false "IANA time zone of the dates, days and hours, e.g. Europe/Berlin"
+// @Param workspace_id query int false "Only time tracked in this workspace"
+// @Param locale query string false "Locale for human-readable values (en/vi), defaults to Accept-Language"
+// @Success 200 {object} dto.ReportSummary "Report summary"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Router /users/me/reports/summary [get]
+func (c *ReportController) GetMySummary(ctx *gin.Context) {
+	params, err := parseReportSummaryParams(ctx)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+	if ctx.Query("workspace_id") != "" {
+		workspaceID, err := strconv.ParseUint(ctx.Query("workspace_id"), 10, 32)
+		if err != nil {
+			utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid workspace ID")
+			return
+		}
+		id := uint(workspaceID)
+		params.WorkspaceID = &id
+	}
+
+	userID := ctx.GetUint("userID")
+	summary, err := c.reportService.GetMySummary(userID, params, requestLocale(ctx))
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, summary)
+}
+
+// GetOrganizationStats gets the organization dashboard stats
+// @Summary Get organization stats
+// @Description Get the organization's dashboard: members, members who tracked time today and are tracking now, active workspaces, tracked time this week with the top workspaces, and screenshot storage. Today and this week follow the organization calendar in UTC. Only owner or admin can view.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param locale query string false "Locale for human-readable values (en/vi), defaults to Accept-Language"
+// @Success 200 {object} dto.OrganizationStats "Organization stats"
+// @Failure 400 {object} dto.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/stats [get]
+func (c *ReportController) GetOrganizationStats(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	stats, err := c.reportService.GetOrganizationStats(uint(orgID), userID, requestLocale(ctx))
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, stats)
+}
+
+// GetCustomReportOptions lists the custom report dimensions and measures
+// @Summary List custom report fields
+// @Description List the dimensions custom reports can group by and the measures they can aggregate, with the result columns each adds.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Success 200 {object} dto.CustomReportOptions "Dimensions and measures"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Router /organizations/{org_id}/reports/custom/options [get]
+func (c *ReportController) GetCustomReportOptions(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, c.reportService.CustomReportOptions())
+}
+
+// RunCustomReport runs a custom report
+// @Summary Run custom report
+// @Description Group the organization's time logs by up to 3 dimensions (user, task, root_task, workspace, client and one of day, week or month) and aggregate the chosen measures (duration, billable_amount, screenshots). Organization admins report on every member, members who can view a workspace's reports on that workspace, and everyone else on their own time. Dates follow the time logs' start time in the request's timezone, defaulting to the organization's; weeks follow the organization calendar.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param request body dto.CustomReportRequest true "Dimensions, measures and filters"
+// @Success 200 {object} dto.CustomReportResponse "Grouped results"
+// @Failure 400 {object} dto.ErrorResponse "Invalid dimensions, measures or filters"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/reports/custom [post]
+func (c *ReportController) RunCustomReport(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	var req dto.CustomReportRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	result, err := c.reportService.RunCustomReport(uint(orgID), userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// parseReportSummaryParams reads the date range, defaulting to the last 30 days
+func parseReportSummaryParams(ctx *gin.Context) (*dto.ReportSummaryParams, error) {
+	now := time.Now().UTC()
+	params := &dto.ReportSummaryParams{
+		EndDate: time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC),
+	}
+	if ctx.Query("end_date") != "" {
+		t, err := time.Parse("2006-01-02", ctx.Query("end_date"))
+		if err != nil {
+			return nil, apperror.Validation("invalid end_date: use YYYY-MM-DD", nil)
+		}
+		params.EndDate = t
+	}
+	params.StartDate = params.EndDate.AddDate(0, 0, -29)
+	if ctx.Query("start_date") != "" {
+		t, err := time.Parse("2006-01-02", ctx.Query("start_date"))
+		if err != nil {
+			return nil, apperror.Validation("invalid start_date: use YYYY-MM-DD", nil)
+		}
+		params.StartDate = t
+	}
+
+	if params.EndDate.Before(params.StartDate) || params.EndDate.Sub(params.StartDate) > 366*24*time.Hour {
+		return nil, apperror.Validation("invalid date range: end_date must not be before start_date and the range cannot exceed 366 days", nil)
+	}
+
+	loc, err := parseTimezoneParam(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params.Location = loc
+	return params, nil
+}
+
+// parseTimezoneParam reads the tz query parameter, an IANA time zone name;
+// nil when it is not set
+func parseTimezoneParam(ctx *gin.Context) (*time.Location, error) {
+	tz := ctx.Query("tz")
+	if tz == "" {
+		return nil, nil
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil || tz == "Local" {
+		return nil, apperror.Validation("invalid tz: use an IANA time zone such as Europe/Berlin", nil)
+	}
+	return loc, nil
+}
@@ -0,0 +1,383 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// SavedReportController handles members' saved custom reports and their email schedules
+type SavedReportController struct {
+	savedReportService service.SavedReportService
+}
+
+// NewSavedReportController creates a new saved report controller
+func NewSavedReportController(savedReportService service.SavedReportService) *SavedReportController {
+	return &SavedReportController{
+		savedReportService: savedReportService,
+	}
+}
+
+// savedReportParams parses the organization and saved report IDs from the path
+func savedReportParams(ctx *gin.Context) (uint, uint, bool) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return 0, 0, false
+	}
+
+	reportID, err := strconv.ParseUint(ctx.Param("report_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid saved report ID")
+		return 0, 0, false
+	}
+
+	return uint(orgID), uint(reportID), true
+}
+
+// List lists your saved reports
+// @Summary List saved reports
+// @Description List the custom reports you saved in the organization, with their email schedules
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Success 200 {array} dto.SavedReportResponse "Saved reports"
+// @Failure 400 {object} dto.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Router /organizations/{org_id}/saved-reports [get]
+func (c *SavedReportController) List(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	reports, err := c.savedReportService.List(uint(orgID), userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, reports)
+}
+
+// Create saves a custom report
+// @Summary Save custom report
+// @Description Save a custom report's dimensions, measures and filters with a range of days and a file format, to rerun it or email it on a schedule. The report always runs with your access.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param request body dto.CreateSavedReportRequest true "Report configuration"
+// @Success 201 {object} dto.SavedReportResponse "Saved report created"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/saved-reports [post]
+func (c *SavedReportController) Create(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	var req dto.CreateSavedReportRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	report, err := c.savedReportService.Create(uint(orgID), userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, report)
+}
+
+// Get gets a saved report
+// @Summary Get saved report
+// @Description Get one of your saved reports with its email schedules
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param report_id path int true "Saved report ID"
+// @Success 200 {object} dto.SavedReportResponse "Saved report"
+// @Failure 400 {object} dto.ErrorResponse "Invalid ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Saved report not found"
+// @Router /organizations/{org_id}/saved-reports/{report_id} [get]
+func (c *SavedReportController) Get(ctx *gin.Context) {
+	orgID, reportID, ok := savedReportParams(ctx)
+	if !ok {
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	report, err := c.savedReportService.Get(orgID, reportID, userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// Update updates a saved report
+// @Summary Update saved report
+// @Description Change a saved report's name, dimensions, measures, filters, range or format. A workspace_id or user_id of 0 removes that filter.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param report_id path int true "Saved report ID"
+// @Param request body dto.UpdateSavedReportRequest true "Report changes"
+// @Success 200 {object} dto.SavedReportResponse "Saved report updated"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Saved report not found"
+// @Router /organizations/{org_id}/saved-reports/{report_id} [put]
+func (c *SavedReportController) Update(ctx *gin.Context) {
+	orgID, reportID, ok := savedReportParams(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateSavedReportRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	report, err := c.savedReportService.Update(orgID, reportID, userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// Delete deletes a saved report
+// @Summary Delete saved report
+// @Description Delete a saved report and stop its email schedules
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param report_id path int true "Saved report ID"
+// @Success 200 {object} dto.MessageResponse "Saved report deleted"
+// @Failure 400 {object} dto.ErrorResponse "Invalid ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Saved report not found"
+// @Router /organizations/{org_id}/saved-reports/{report_id} [delete]
+func (c *SavedReportController) Delete(ctx *gin.Context) {
+	orgID, reportID, ok := savedReportParams(ctx)
+	if !ok {
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	if err := c.savedReportService.Delete(orgID, reportID, userID); err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "saved report deleted"})
+}
+
+// Run runs a saved report
+// @Summary Run saved report
+// @Description Run a saved report over its range of days up to yesterday (UTC)
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param report_id path int true "Saved report ID"
+// @Success 200 {object} dto.CustomReportResponse "Grouped results"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Saved report not found"
+// @Router /organizations/{org_id}/saved-reports/{report_id}/run [post]
+func (c *SavedReportController) Run(ctx *gin.Context) {
+	orgID, reportID, ok := savedReportParams(ctx)
+	if !ok {
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	result, err := c.savedReportService.Run(orgID, reportID, userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// Export downloads a saved report
+// @Summary Download saved report
+// @Description Run a saved report over its range of days up to yesterday and download it as CSV or PDF, as saved. Days follow the tz parameter, defaulting to UTC.
+// @Tags organizations
+// @Produce text/csv
+// @Produce application/pdf
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param report_id path int true "Saved report ID"
+// @Param tz query string false "IANA time zone of the report's days, e.g. Europe/Berlin"
+// @Success 200 {file} file "Report file"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Saved report not found"
+// @Router /organizations/{org_id}/saved-reports/{report_id}/export [get]
+func (c *SavedReportController) Export(ctx *gin.Context) {
+	orgID, reportID, ok := savedReportParams(ctx)
+	if !ok {
+		return
+	}
+
+	loc, err := parseTimezoneParam(ctx)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	file, err := c.savedReportService.Export(orgID, reportID, userID, loc)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", file.Filename))
+	ctx.Data(http.StatusOK, file.ContentType, file.Data)
+}
+
+// CreateSchedule schedules a saved report
+// @Summary Schedule saved report email
+// @Description Email a saved report daily or weekly at an hour of a timezone to organization members, with the report attached in its format. Each run covers the report's range of days up to the day before, in the schedule's timezone.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param report_id path int true "Saved report ID"
+// @Param request body dto.CreateReportScheduleRequest true "Schedule details"
+// @Success 201 {object} dto.ReportScheduleResponse "Schedule created"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Saved report not found"
+// @Router /organizations/{org_id}/saved-reports/{report_id}/schedules [post]
+func (c *SavedReportController) CreateSchedule(ctx *gin.Context) {
+	orgID, reportID, ok := savedReportParams(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateReportScheduleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	schedule, err := c.savedReportService.CreateSchedule(orgID, reportID, userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, schedule)
+}
+
+// UpdateSchedule updates a saved report schedule
+// @Summary Update saved report schedule
+// @Description Change when and to whom a saved report is emailed, or pause it with is_active=false
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param report_id path int true "Saved report ID"
+// @Param schedule_id path int true "Schedule ID"
+// @Param request body dto.UpdateReportScheduleRequest true "Schedule changes"
+// @Success 200 {object} dto.ReportScheduleResponse "Schedule updated"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Saved report or schedule not found"
+// @Router /organizations/{org_id}/saved-reports/{report_id}/schedules/{schedule_id} [put]
+func (c *SavedReportController) UpdateSchedule(ctx *gin.Context) {
+	orgID, reportID, ok := savedReportParams(ctx)
+	if !ok {
+		return
+	}
+	scheduleID, err := strconv.ParseUint(ctx.Param("schedule_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid schedule ID")
+		return
+	}
+
+	var req dto.UpdateReportScheduleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	schedule, err := c.savedReportService.UpdateSchedule(orgID, reportID, uint(scheduleID), userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, schedule)
+}
+
+// DeleteSchedule deletes a saved report schedule
+// @Summary Delete saved report schedule
+// @Description Stop emailing a saved report on this schedule
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param report_id path int true "Saved report ID"
+// @Param schedule_id path int true "Schedule ID"
+// @Success 200 {object} dto.MessageResponse "Schedule deleted"
+// @Failure 400 {object} dto.ErrorResponse "Invalid ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Saved report or schedule not found"
+// @Router /organizations/{org_id}/saved-reports/{report_id}/schedules/{schedule_id} [delete]
+func (c *SavedReportController) DeleteSchedule(ctx *gin.Context) {
+	orgID, reportID, ok := savedReportParams(ctx)
+	if !ok {
+		return
+	}
+	scheduleID, err := strconv.ParseUint(ctx.Param("schedule_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid schedule ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	if err := c.savedReportService.DeleteSchedule(orgID, reportID, uint(scheduleID), userID); err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "report schedule deleted"})
+}
@@ -1,27 +1,51 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
+	"os"
 	"path/filepath"
 	"strconv"
 	"time"
 
 	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
 	"github.com/beuphecan/remote-time-tracker/internal/service"
 	"github.com/beuphecan/remote-time-tracker/internal/utils"
 	"github.com/gin-gonic/gin"
 )
 
+// coldRetrievalRetryAfter is the Retry-After hint while a screenshot is
+// restored from archive storage
+const coldRetrievalRetryAfter = 15 * time.Minute
+
 // ScreenshotController handles screenshot-related HTTP requests
 type ScreenshotController struct {
 	screenshotService service.ScreenshotService
+	tierService       service.ScreenshotTierService
 }
 
 // NewScreenshotController creates a new screenshot controller
-func NewScreenshotController(screenshotService service.ScreenshotService) *ScreenshotController {
+func NewScreenshotController(screenshotService service.ScreenshotService, tierService service.ScreenshotTierService) *ScreenshotController {
 	return &ScreenshotController{
 		screenshotService: screenshotService,
+		tierService:       tierService,
+	}
+}
+
+// respondScreenshotRetrieval reports whether a cold screenshot's file was
+// retrieved (err is nil); otherwise it writes the response
+func respondScreenshotRetrieval(ctx *gin.Context, err error) bool {
+	if errors.Is(err, service.ErrRetrievalPending) {
+		ctx.Header("Retry-After", strconv.Itoa(int(coldRetrievalRetryAfter.Seconds())))
+		ctx.JSON(http.StatusAccepted, gin.H{"status": "retrieval_pending", "message": err.Error()})
+		return false
+	}
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return false
 	}
+	return true
 }
 
 // GetScreenshot retrieves a single screenshot
@@ -47,7 +71,7 @@ func (c *ScreenshotController) GetScreenshot(ctx *gin.Context) {
 
 	screenshot, err := c.screenshotService.GetScreenshot(uint(id), userID)
 	if err != nil {
-		utils.ErrorResponse(ctx, http.StatusNotFound, err.Error())
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -74,7 +98,7 @@ func (c *ScreenshotController) ListScreenshots(ctx *gin.Context) {
 
 	screenshots, total, err := c.screenshotService.GetScreenshotsByUser(userID, page, perPage)
 	if err != nil {
-		utils.ErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -112,7 +136,7 @@ func (c *ScreenshotController) GetScreenshotsByTimeLog(ctx *gin.Context) {
 
 	screenshots, err := c.screenshotService.GetScreenshotsByTimeLog(uint(timeLogID), userID)
 	if err != nil {
-		utils.ErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -143,7 +167,7 @@ func (c *ScreenshotController) GetScreenshotsByTaskID(ctx *gin.Context) {
 
 	screenshots, err := c.screenshotService.GetScreenshotsByTaskID(uint(taskID), userID)
 	if err != nil {
-		utils.ErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -191,7 +215,7 @@ func (c *ScreenshotController) GetScreenshotsByDateRange(ctx *gin.Context) {
 
 	screenshots, err := c.screenshotService.GetScreenshotsByDateRange(userID, startDate, endDate)
 	if err != nil {
-		utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -200,7 +224,7 @@ func (c *ScreenshotController) GetScreenshotsByDateRange(ctx *gin.Context) {
 
 // DeleteScreenshot deletes a screenshot
 // @Summary Delete screenshot
-// @Description Delete a screenshot by ID
+// @Description Delete a personal screenshot by ID. Screenshots captured for an organization must be removed through a deletion request (POST /screenshots/{id}/deletion-request).
 // @Tags screenshots
 // @Produce json
 // @Security BearerAuth
@@ -221,7 +245,7 @@ func (c *ScreenshotController) DeleteScreenshot(ctx *gin.Context) {
 
 	err = c.screenshotService.DeleteScreenshot(uint(id), userID)
 	if err != nil {
-		utils.ErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -261,7 +285,7 @@ func (c *ScreenshotController) GetScreenshotStats(ctx *gin.Context) {
 
 	stats, err := c.screenshotService.GetScreenshotStats(userID, startDate, endDate)
 	if err != nil {
-		utils.ErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -270,14 +294,16 @@ func (c *ScreenshotController) GetScreenshotStats(ctx *gin.Context) {
 
 // DownloadScreenshot serves the screenshot file
 // @Summary Download screenshot file
-// @Description Download a screenshot file as attachment
+// @Description Download a screenshot file as attachment. Screenshots in cold storage are retrieved first; while an archive restore is in progress the response is 202 with status "retrieval_pending" and a Retry-After header. Members with both the screenshots.view and screenshots.download permissions can download others' screenshots; in workspaces that blur screenshots they get the blurred version, and 403 when none exists.
 // @Tags screenshots
 // @Produce application/octet-stream
 // @Security BearerAuth
 // @Param id path int true "Screenshot ID"
 // @Success 200 {file} file "Screenshot file"
+// @Success 202 {object} map[string]interface{} "Retrieval from cold storage pending"
 // @Failure 400 {object} dto.ErrorResponse "Invalid screenshot ID"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Original restricted to system admins or download not permitted"
 // @Failure 404 {object} dto.ErrorResponse "Screenshot not found"
 // @Router /screenshots/{id}/download [get]
 func (c *ScreenshotController) DownloadScreenshot(ctx *gin.Context) {
@@ -289,32 +315,99 @@ func (c *ScreenshotController) DownloadScreenshot(ctx *gin.Context) {
 		return
 	}
 
-	screenshot, err := c.screenshotService.GetScreenshot(uint(id), userID)
-	if err != nil {
-		utils.ErrorResponse(ctx, http.StatusNotFound, err.Error())
+	view, ok := c.resolveView(ctx, uint(id), userID, true)
+	if !ok {
 		return
 	}
+	screenshot := view.Screenshot
 
 	// Set appropriate headers
 	ctx.Header("Content-Description", "File Transfer")
 	ctx.Header("Content-Transfer-Encoding", "binary")
-	ctx.Header("Content-Disposition", "attachment; filename="+screenshot.FileName)
+	ctx.Header("Content-Disposition", "attachment; filename="+screenshotFileName(screenshot, userID))
 	ctx.Header("Content-Type", screenshot.MimeType)
 
 	// Serve the file
-	ctx.File(screenshot.FilePath)
+	ctx.File(view.Path)
+}
+
+// DownloadEncryptedScreenshot returns an encrypted screenshot for client-side decryption
+// @Summary Download encrypted screenshot
+// @Description Get an encrypted screenshot as base64 ciphertext with its envelope: the content key wrapped for the organization key with key_fingerprint, the AES-GCM nonce and the algorithms. Unwrap the content key with the organization's private key and decrypt locally. Access rules are the same as for downloads.
+// @Tags screenshots
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Screenshot ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.EncryptedScreenshotResponse} "Encrypted screenshot retrieved successfully"
+// @Success 202 {object} map[string]interface{} "Retrieval from cold storage pending"
+// @Failure 400 {object} dto.ErrorResponse "Screenshot is not encrypted"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Original restricted to system admins or download not permitted"
+// @Failure 404 {object} dto.ErrorResponse "Screenshot not found"
+// @Router /screenshots/{id}/encrypted [get]
+func (c *ScreenshotController) DownloadEncryptedScreenshot(ctx *gin.Context) {
+	userID := ctx.GetUint("user_id")
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid screenshot ID")
+		return
+	}
+
+	view, ok := c.resolveView(ctx, uint(id), userID, true)
+	if !ok {
+		return
+	}
+	if !view.Screenshot.IsEncrypted || view.Blurred {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Screenshot is not encrypted")
+		return
+	}
+
+	data, err := os.ReadFile(view.Path)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusNotFound, "Screenshot file not found on server")
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Encrypted screenshot retrieved successfully",
+		service.ToEncryptedScreenshotResponse(view.Screenshot, data))
+}
+
+// resolveView finds the file the user may see, retrieving originals from cold
+// storage; otherwise it writes the response
+func (c *ScreenshotController) resolveView(ctx *gin.Context, id, userID uint, download bool) (*service.ScreenshotView, bool) {
+	view, err := c.screenshotService.GetScreenshotView(id, userID, download)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return nil, false
+	}
+
+	if !view.Blurred && !respondScreenshotRetrieval(ctx, c.tierService.EnsureLocal(ctx.Request.Context(), view.Screenshot)) {
+		return nil, false
+	}
+	return view, true
+}
+
+// screenshotFileName names the served file; other viewers get the UUID rather
+// than the stored file name
+func screenshotFileName(screenshot *models.Screenshot, userID uint) string {
+	if screenshot.UserID == userID {
+		return filepath.Base(screenshot.FileName)
+	}
+	return screenshot.UUID + filepath.Ext(screenshot.FileName)
 }
 
 // ViewScreenshot serves the screenshot file for viewing
 // @Summary View screenshot file
-// @Description View a screenshot file inline in browser
+// @Description View a screenshot file inline in browser. Members whose role lacks screenshots.view_own cannot see their own screenshots of that workspace. Screenshots in cold storage are retrieved first; while an archive restore is in progress the response is 202 with status "retrieval_pending" and a Retry-After header. Members with the screenshots.view permission can view others' screenshots; in workspaces that blur screenshots they get the blurred version, and 403 when none exists.
 // @Tags screenshots
 // @Produce image/png,image/jpeg
 // @Security BearerAuth
 // @Param id path int true "Screenshot ID"
 // @Success 200 {file} file "Screenshot image"
+// @Success 202 {object} map[string]interface{} "Retrieval from cold storage pending"
 // @Failure 400 {object} dto.ErrorResponse "Invalid screenshot ID"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Original restricted to system admins"
 // @Failure 404 {object} dto.ErrorResponse "Screenshot not found"
 // @Router /screenshots/{id}/view [get]
 func (c *ScreenshotController) ViewScreenshot(ctx *gin.Context) {
@@ -326,24 +419,24 @@ func (c *ScreenshotController) ViewScreenshot(ctx *gin.Context) {
 		return
 	}
 
-	screenshot, err := c.screenshotService.GetScreenshot(uint(id), userID)
-	if err != nil {
-		utils.ErrorResponse(ctx, http.StatusNotFound, err.Error())
+	view, ok := c.resolveView(ctx, uint(id), userID, false)
+	if !ok {
 		return
 	}
+	screenshot := view.Screenshot
 
 	// Check if file exists
-	if !utils.FileExists(screenshot.FilePath) {
+	if !utils.FileExists(view.Path) {
 		utils.ErrorResponse(ctx, http.StatusNotFound, "Screenshot file not found on server")
 		return
 	}
 
 	// Set appropriate headers for viewing
 	ctx.Header("Content-Type", screenshot.MimeType)
-	ctx.Header("Content-Disposition", "inline; filename="+filepath.Base(screenshot.FileName))
+	ctx.Header("Content-Disposition", "inline; filename="+screenshotFileName(screenshot, userID))
 
 	// Serve the file
-	ctx.File(screenshot.FilePath)
+	ctx.File(view.Path)
 }
 
 // GetTodayScreenshotCount returns the count of screenshots captured today
@@ -361,7 +454,7 @@ func (c *ScreenshotController) GetTodayScreenshotCount(ctx *gin.Context) {
 
 	count, err := c.screenshotService.GetTodayScreenshotCount(userID)
 	if err != nil {
-		utils.ErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		utils.RespondError(ctx, err)
 		return
 	}
 
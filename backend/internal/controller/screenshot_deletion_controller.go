@@ -0,0 +1,283 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// ScreenshotDeletionController handles screenshot deletion requests and their approval queue
+type ScreenshotDeletionController struct {
+	deletionService service.ScreenshotDeletionService
+}
+
+// NewScreenshotDeletionController creates a new screenshot deletion controller
+func NewScreenshotDeletionController(deletionService service.ScreenshotDeletionService) *ScreenshotDeletionController {
+	return &ScreenshotDeletionController{
+		deletionService: deletionService,
+	}
+}
+
+// ============================================================================
+// USER REQUESTS
+// ============================================================================
+
+// RequestDeletion asks a manager to delete a screenshot
+// @Summary Request screenshot deletion
+// @Description Ask a manager to delete one of your organization screenshots (e.g. an accidental capture of sensitive data). The reason is kept on record after the screenshot is deleted.
+// @Tags screenshots
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Screenshot ID"
+// @Param request body dto.CreateScreenshotDeletionRequest true "Justification"
+// @Success 201 {object} dto.SuccessResponse{data=dto.ScreenshotDeletionRequestResponse} "Deletion requested"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Router /screenshots/{id}/deletion-request [post]
+// @Router /screenshots/{id}/delete-request [post]
+func (c *ScreenshotDeletionController) RequestDeletion(ctx *gin.Context) {
+	userID := ctx.GetUint("user_id")
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid screenshot ID")
+		return
+	}
+
+	var req dto.CreateScreenshotDeletionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	request, err := c.deletionService.Request(userID, uint(id), &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusCreated, "Deletion requested", request)
+}
+
+// ListMyRequests lists the user's deletion requests
+// @Summary List my screenshot deletion requests
+// @Description Get your screenshot deletion requests with their review outcome, newest first
+// @Tags screenshots
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(20)
+// @Param status query string false "Filter by status (pending, approved, rejected, cancelled)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.PaginationResponse} "Deletion requests retrieved successfully"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /screenshots/deletion-requests [get]
+func (c *ScreenshotDeletionController) ListMyRequests(ctx *gin.Context) {
+	userID := ctx.GetUint("user_id")
+
+	params := &dto.ScreenshotDeletionRequestListParams{
+		Page:    parseIntParam(ctx, "page", 1),
+		PerPage: parseIntParam(ctx, "per_page", 20),
+		Status:  ctx.Query("status"),
+	}
+
+	requests, total, err := c.deletionService.ListMine(userID, params)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Deletion requests retrieved successfully", dto.PaginationResponse{
+		Data:       requests,
+		Page:       params.Page,
+		PerPage:    params.PerPage,
+		Total:      total,
+		TotalPages: int((total + int64(params.PerPage) - 1) / int64(params.PerPage)),
+	})
+}
+
+// CancelRequest withdraws a pending deletion request
+// @Summary Cancel screenshot deletion request
+// @Description Withdraw one of your pending screenshot deletion requests
+// @Tags screenshots
+// @Produce json
+// @Security BearerAuth
+// @Param request_id path int true "Deletion request ID"
+// @Success 200 {object} dto.SuccessResponse "Deletion request cancelled"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Router /screenshots/deletion-requests/{request_id} [delete]
+func (c *ScreenshotDeletionController) CancelRequest(ctx *gin.Context) {
+	userID := ctx.GetUint("user_id")
+	requestID, err := strconv.ParseUint(ctx.Param("request_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid deletion request ID")
+		return
+	}
+
+	if err := c.deletionService.Cancel(userID, uint(requestID)); err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Deletion request cancelled", nil)
+}
+
+// ============================================================================
+// MANAGER REVIEW QUEUE
+// ============================================================================
+
+// deletionRequestParams parses the organization and request IDs from the path
+func deletionRequestParams(ctx *gin.Context) (uint, uint, bool) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return 0, 0, false
+	}
+
+	requestID, err := strconv.ParseUint(ctx.Param("request_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid deletion request ID")
+		return 0, 0, false
+	}
+
+	return uint(orgID), uint(requestID), true
+}
+
+// ListQueue lists the organization's deletion requests
+// @Summary List screenshot deletion requests
+// @Description Get the screenshot deletion approval queue. Org owners and admins see every request; workspace admins see requests for the workspaces they manage.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(20)
+// @Param status query string false "Filter by status (pending, approved, rejected, cancelled)"
+// @Param user_id query int false "Filter by requester"
+// @Success 200 {object} map[string]interface{} "Deletion requests with pagination"
+// @Failure 400 {object} dto.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/screenshot-deletion-requests [get]
+func (c *ScreenshotDeletionController) ListQueue(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	params := &dto.ScreenshotDeletionRequestListParams{
+		Page:    parseIntParam(ctx, "page", 1),
+		PerPage: parseIntParam(ctx, "per_page", 20),
+		Status:  ctx.Query("status"),
+	}
+	if userIDStr := ctx.Query("user_id"); userIDStr != "" {
+		requesterID, err := strconv.ParseUint(userIDStr, 10, 32)
+		if err != nil {
+			utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid user ID")
+			return
+		}
+		id := uint(requesterID)
+		params.UserID = &id
+	}
+
+	userID := ctx.GetUint("userID")
+	requests, total, err := c.deletionService.ListForOrg(uint(orgID), userID, params)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	totalPages := int((total + int64(params.PerPage) - 1) / int64(params.PerPage))
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"requests": requests,
+		"pagination": dto.PaginationMeta{
+			Page:       params.Page,
+			PerPage:    params.PerPage,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	})
+}
+
+// Approve approves a deletion request and deletes the screenshot
+// @Summary Approve screenshot deletion request
+// @Description Approve a pending request: the screenshot and its file are deleted, and the request keeps the user's justification and your note. The deletion is recorded in the audit log against the screenshot
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param request_id path int true "Deletion request ID"
+// @Param request body dto.ReviewScreenshotDeletionRequest false "Review note"
+// @Success 200 {object} dto.ScreenshotDeletionRequestResponse "Request approved"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/screenshot-deletion-requests/{request_id}/approve [post]
+func (c *ScreenshotDeletionController) Approve(ctx *gin.Context) {
+	orgID, requestID, ok := deletionRequestParams(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.ReviewScreenshotDeletionRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			utils.ValidationErrorResponse(ctx, err)
+			return
+		}
+	}
+
+	userID := ctx.GetUint("userID")
+	request, err := c.deletionService.Approve(orgID, requestID, userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request)
+}
+
+// Reject rejects a deletion request
+// @Summary Reject screenshot deletion request
+// @Description Reject a pending request; the screenshot is kept. A note explaining the decision is required.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param request_id path int true "Deletion request ID"
+// @Param request body dto.ReviewScreenshotDeletionRequest true "Review note"
+// @Success 200 {object} dto.ScreenshotDeletionRequestResponse "Request rejected"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/screenshot-deletion-requests/{request_id}/reject [post]
+func (c *ScreenshotDeletionController) Reject(ctx *gin.Context) {
+	orgID, requestID, ok := deletionRequestParams(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.ReviewScreenshotDeletionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	request, err := c.deletionService.Reject(orgID, requestID, userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request)
+}
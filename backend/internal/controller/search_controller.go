@@ -0,0 +1,73 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// SearchController handles full-text search
+type SearchController struct {
+	searchService service.SearchService
+}
+
+// NewSearchController creates a new search controller
+func NewSearchController(searchService service.SearchService) *SearchController {
+	return &SearchController{
+		searchService: searchService,
+	}
+}
+
+// Search searches tasks, time log notes and members
+// @Summary Search
+// @Description Full-text search across tasks (title, description, tags), time logs (notes, task title) and members (name, email), best match first. Every word must match, as a prefix. Results cover your own tasks and time, tasks of your workspaces, time logs of organizations you administer and members of your organizations.
+// @Tags search
+// @Produce json
+// @Security BearerAuth
+// @Param q query string true "Search text"
+// @Param types query string false "Comma-separated result types: task, time_log, member (default all)"
+// @Param organization_id query int false "Only search this organization"
+// @Param limit query int false "Maximum results (1-50)" default(20)
+// @Success 200 {object} dto.SuccessResponse{data=dto.SearchResponse} "Search results"
+// @Failure 400 {object} dto.ErrorResponse "Invalid search"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Not a member of the organization"
+// @Router /search [get]
+func (c *SearchController) Search(ctx *gin.Context) {
+	userID := ctx.GetUint("user_id")
+
+	params := &dto.SearchParams{
+		Query: strings.TrimSpace(ctx.Query("q")),
+		Limit: parseIntParam(ctx, "limit", 20),
+	}
+	if types := ctx.Query("types"); types != "" {
+		for _, t := range strings.Split(types, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				params.Types = append(params.Types, t)
+			}
+		}
+	}
+	if orgID := ctx.Query("organization_id"); orgID != "" {
+		id, err := strconv.ParseUint(orgID, 10, 32)
+		if err != nil {
+			utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid organization ID")
+			return
+		}
+		value := uint(id)
+		params.OrganizationID = &value
+	}
+
+	results, err := c.searchService.Search(userID, params)
+	if err != nil {
+		// Typed errors; the error middleware responds
+		ctx.Error(err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Search results", results)
+}
@@ -0,0 +1,156 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// ShareLinkController handles read-only workspace report links for people
+// without an account
+type ShareLinkController struct {
+	shareLinkService service.ShareLinkService
+}
+
+// NewShareLinkController creates a new share link controller
+func NewShareLinkController(shareLinkService service.ShareLinkService) *ShareLinkController {
+	return &ShareLinkController{
+		shareLinkService: shareLinkService,
+	}
+}
+
+// ListShareLinks lists a workspace's share links
+// @Summary List share links
+// @Description List the workspace's report share links, newest first, with their view counts. Members who can view reports can manage share links.
+// @Tags workspaces
+// @Produce json
+// @Security BearerAuth
+// @Param workspace_id path int true "Workspace ID"
+// @Success 200 {array} dto.ShareLinkResponse "Share links"
+// @Failure 400 {object} dto.ErrorResponse "Invalid workspace ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /workspaces/{workspace_id}/share-links [get]
+func (c *ShareLinkController) ListShareLinks(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseUint(ctx.Param("workspace_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid workspace ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	links, err := c.shareLinkService.List(uint(workspaceID), userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, links)
+}
+
+// CreateShareLink creates a share link
+// @Summary Create share link
+// @Description Create an expiring link that shows the workspace's tracked hours and tasks to people without an account, such as a client. Screenshots and member details are never shared. With start_date and end_date the report range is fixed; otherwise viewers choose it.
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param workspace_id path int true "Workspace ID"
+// @Param request body dto.CreateShareLinkRequest true "Share link"
+// @Success 201 {object} dto.ShareLinkResponse "Share link created"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /workspaces/{workspace_id}/share-links [post]
+func (c *ShareLinkController) CreateShareLink(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseUint(ctx.Param("workspace_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid workspace ID")
+		return
+	}
+
+	var req dto.CreateShareLinkRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	link, err := c.shareLinkService.Create(uint(workspaceID), userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, link)
+}
+
+// RevokeShareLink revokes a share link
+// @Summary Revoke share link
+// @Description Revoke a share link so it no longer shows the report. Revoked links stay listed with their view counts.
+// @Tags workspaces
+// @Produce json
+// @Security BearerAuth
+// @Param workspace_id path int true "Workspace ID"
+// @Param link_id path int true "Share link ID"
+// @Success 200 {object} dto.SuccessResponse "Share link revoked"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Share link not found"
+// @Router /workspaces/{workspace_id}/share-links/{link_id} [delete]
+func (c *ShareLinkController) RevokeShareLink(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseUint(ctx.Param("workspace_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid workspace ID")
+		return
+	}
+	linkID, err := strconv.ParseUint(ctx.Param("link_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid share link ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	if err := c.shareLinkService.Revoke(uint(workspaceID), uint(linkID), userID); err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Share link revoked", nil)
+}
+
+// ViewSharedReport shows the report of a share link
+// @Summary View shared report
+// @Description Show a workspace's tracked hours and tasks through a share link, authorized by the token in its URL. Links with a fixed range ignore start_date and end_date.
+// @Tags workspaces
+// @Produce json
+// @Param token path string true "Share link token"
+// @Param start_date query string false "Start date (YYYY-MM-DD), defaults to 30 days before end_date"
+// @Param end_date query string false "End date, inclusive (YYYY-MM-DD), defaults to today"
+// @Param locale query string false "Locale for human-readable values (en/vi), defaults to Accept-Language"
+// @Success 200 {object} dto.SharedReportResponse "Shared report"
+// @Failure 400 {object} dto.ErrorResponse "Invalid date range"
+// @Failure 404 {object} dto.ErrorResponse "Share link not found"
+// @Failure 410 {object} dto.ErrorResponse "Share link expired or revoked"
+// @Router /public/share-links/{token} [get]
+func (c *ShareLinkController) ViewSharedReport(ctx *gin.Context) {
+	params, err := parseReportSummaryParams(ctx)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	report, err := c.shareLinkService.View(ctx.Param("token"), params, requestLocale(ctx))
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.Header("Cache-Control", "private, no-store")
+	ctx.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,214 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// SlackController handles organization Slack notifications
+type SlackController struct {
+	slackService service.SlackService
+}
+
+// NewSlackController creates a new Slack integration controller
+func NewSlackController(slackService service.SlackService) *SlackController {
+	return &SlackController{
+		slackService: slackService,
+	}
+}
+
+// GetIntegration returns the organization's Slack integration
+// @Summary Get Slack integration
+// @Description Get the organization's Slack notification settings and the outcome of the last post. The webhook URL is never returned. Only owner or admin can view.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Success 200 {object} dto.SlackIntegrationResponse "Slack integration"
+// @Failure 400 {object} dto.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Organization is not connected to Slack"
+// @Router /organizations/{org_id}/integrations/slack [get]
+func (c *SlackController) GetIntegration(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	integration, err := c.slackService.Get(uint(orgID), userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, integration)
+}
+
+// SaveIntegration configures Slack notifications with an incoming webhook
+// @Summary Configure Slack integration
+// @Description Post notifications to a Slack channel through an incoming webhook URL, or change the events of an existing integration (omit webhook_url to keep the current channel). Events: member.joined, timelog.approved, timelog.rejected and daily_summary (the previous UTC day's hours per workspace). Only owner or admin can configure.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param request body dto.SaveSlackIntegrationRequest true "Slack settings"
+// @Success 200 {object} dto.SlackIntegrationResponse "Slack integration saved"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/integrations/slack [put]
+func (c *SlackController) SaveIntegration(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	var req dto.SaveSlackIntegrationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	integration, err := c.slackService.Save(uint(orgID), userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, integration)
+}
+
+// DeleteIntegration disconnects Slack
+// @Summary Disconnect Slack integration
+// @Description Stop posting notifications to Slack. Only owner or admin can disconnect.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Success 204 "Slack integration removed"
+// @Failure 400 {object} dto.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Organization is not connected to Slack"
+// @Router /organizations/{org_id}/integrations/slack [delete]
+func (c *SlackController) DeleteIntegration(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	if err := c.slackService.Delete(uint(orgID), userID); err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// SendTest posts a test message
+// @Summary Send Slack test message
+// @Description Post a test message to the connected channel. Only owner or admin can send.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Success 200 {object} dto.SuccessResponse "Test message posted"
+// @Failure 400 {object} dto.ErrorResponse "Slack rejected the message"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Organization is not connected to Slack"
+// @Router /organizations/{org_id}/integrations/slack/test [post]
+func (c *SlackController) SendTest(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	if err := c.slackService.SendTest(uint(orgID), userID); err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Test message posted"})
+}
+
+// OAuthURL starts the Slack app install
+// @Summary Get Slack install URL
+// @Description Get the Slack page where an admin picks the channel for notifications. Slack redirects to SLACK_REDIRECT_URL with code and state, which the frontend posts to the oauth endpoint within 10 minutes. Only available when a Slack app is configured. Only owner or admin can install.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Success 200 {object} dto.SlackOAuthURLResponse "Slack install URL"
+// @Failure 400 {object} dto.ErrorResponse "Slack OAuth is not configured"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/integrations/slack/oauth [get]
+func (c *SlackController) OAuthURL(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	result, err := c.slackService.OAuthURL(uint(orgID), userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// CompleteOAuth finishes the Slack app install
+// @Summary Complete Slack install
+// @Description Exchange the code Slack redirected back with for the channel's incoming webhook and save the integration. Must be called by the admin who started the install. Events default to all events.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param request body dto.CompleteSlackOAuthRequest true "Code and state from Slack"
+// @Success 200 {object} dto.SlackIntegrationResponse "Slack integration saved"
+// @Failure 400 {object} dto.ErrorResponse "Invalid state or Slack rejected the install"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/integrations/slack/oauth [post]
+func (c *SlackController) CompleteOAuth(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	var req dto.CompleteSlackOAuthRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	integration, err := c.slackService.CompleteOAuth(ctx.Request.Context(), uint(orgID), userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, integration)
+}
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/beuphecan/remote-time-tracker/internal/dto"
 	"github.com/beuphecan/remote-time-tracker/internal/middleware"
@@ -33,6 +34,8 @@ func NewSyncController(syncService service.SyncService) *SyncController {
 // @Success 200 {object} dto.SuccessResponse{data=dto.BatchSyncResponse} "Batch sync completed"
 // @Failure 400 {object} dto.ErrorResponse "Invalid request"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Device not approved (code device_not_approved) by an organization that requires device approval"
+// @Failure 426 {object} dto.ErrorResponse "App version below the minimum of its release channel (code upgrade_required)"
 // @Failure 500 {object} dto.ErrorResponse "Sync failed"
 // @Router /sync/batch [post]
 func (ctrl *SyncController) BatchSync(c *gin.Context) {
@@ -44,15 +47,101 @@ func (ctrl *SyncController) BatchSync(c *gin.Context) {
 
 	var req dto.BatchSyncRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		utils.ValidationErrorResponse(c, err)
 		return
 	}
 
 	response, err := ctrl.syncService.BatchSync(userID, &req)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
 	utils.SuccessResponse(c, http.StatusOK, "Batch sync completed", response)
 }
+
+// ListConflicts lists the user's time log sync conflicts
+// @Summary List sync conflicts
+// @Description Get time logs edited on two devices, newest first. Under the manual policy (SYNC_CONFLICT_POLICY=manual) conflicts stay pending until resolved.
+// @Tags sync
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Filter by status (pending, resolved)"
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(20)
+// @Success 200 {object} dto.SuccessResponse{data=dto.PaginationResponse} "Sync conflicts retrieved successfully"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /sync/conflicts [get]
+func (ctrl *SyncController) ListConflicts(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	page := parseIntParam(c, "page", 1)
+	perPage := parseIntParam(c, "per_page", 20)
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+
+	conflicts, total, err := ctrl.syncService.ListConflicts(userID, c.Query("status"), page, perPage)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Sync conflicts retrieved successfully", dto.PaginationResponse{
+		Data:       conflicts,
+		Page:       page,
+		PerPage:    perPage,
+		Total:      total,
+		TotalPages: int((total + int64(perPage) - 1) / int64(perPage)),
+	})
+}
+
+// ResolveConflict resolves a pending sync conflict
+// @Summary Resolve sync conflict
+// @Description Keep the server version of the time log, or apply the conflicting change from the device
+// @Tags sync
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Sync conflict ID"
+// @Param request body dto.ResolveSyncConflictRequest true "Side to keep"
+// @Success 200 {object} dto.SuccessResponse{data=dto.SyncConflictResponse} "Sync conflict resolved"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 409 {object} dto.ErrorResponse "Time log is in a locked pay period (code period_locked)"
+// @Router /sync/conflicts/{id}/resolve [post]
+func (ctrl *SyncController) ResolveConflict(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid sync conflict ID")
+		return
+	}
+
+	var req dto.ResolveSyncConflictRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	conflict, err := ctrl.syncService.ResolveConflict(userID, uint(id), &req)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Sync conflict resolved", conflict)
+}
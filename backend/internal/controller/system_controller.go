@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"log"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -39,14 +40,14 @@ func NewSystemController(systemService service.SystemService) *SystemController
 func (c *SystemController) InitializeAdmin(ctx *gin.Context) {
 	var req dto.InitAdminRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid request: "+err.Error())
+		utils.ValidationErrorResponse(ctx, err)
 		return
 	}
 
 	// Check if admin already exists
 	hasAdmin, err := c.systemService.HasSystemAdmin()
 	if err != nil {
-		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to check admin status: "+err.Error())
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -58,7 +59,7 @@ func (c *SystemController) InitializeAdmin(ctx *gin.Context) {
 	// Create admin
 	admin, err := c.systemService.InitializeAdmin(&req)
 	if err != nil {
-		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to create admin: "+err.Error())
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -67,6 +68,7 @@ func (c *SystemController) InitializeAdmin(ctx *gin.Context) {
 		Message: "System admin created successfully",
 		User: dto.UserResponse{
 			ID:         admin.ID,
+			UUID:       admin.UUID,
 			Email:      admin.Email,
 			FirstName:  admin.FirstName,
 			LastName:   admin.LastName,
@@ -89,7 +91,7 @@ func (c *SystemController) InitializeAdmin(ctx *gin.Context) {
 func (c *SystemController) CheckAdminExists(ctx *gin.Context) {
 	hasAdmin, err := c.systemService.HasSystemAdmin()
 	if err != nil {
-		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to check admin status: "+err.Error())
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -108,7 +110,7 @@ func (c *SystemController) CheckAdminExists(ctx *gin.Context) {
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Router /system/uploads/check [get]
 func (c *SystemController) CheckUploadsFolder(ctx *gin.Context) {
-	uploadPath := config.AppConfig.Upload.Path
+	uploadPath := config.AppConfig.Upload.PrivatePath
 	screenshotsPath := filepath.Join(uploadPath, "screenshots")
 
 	checks := map[string]interface{}{
@@ -128,12 +130,13 @@ func (c *SystemController) CheckUploadsFolder(ctx *gin.Context) {
 
 		// Check if writable
 		testFile := filepath.Join(uploadPath, ".write_test")
-		if err := os.WriteFile(testFile, []byte("test"), 0644); err == nil {
+		if err := os.WriteFile(testFile, []byte("test"), 0600); err == nil {
 			checks["upload_path_writable"] = true
 			os.Remove(testFile)
 		}
 	} else {
-		checks["upload_path_error"] = err.Error()
+		log.Printf("⚠️ Upload path check failed: %v", err)
+		checks["upload_path_error"] = "path does not exist or is not accessible"
 	}
 
 	// Check if screenshots path exists
@@ -143,7 +146,7 @@ func (c *SystemController) CheckUploadsFolder(ctx *gin.Context) {
 
 		// Check if writable
 		testFile := filepath.Join(screenshotsPath, ".write_test")
-		if err := os.WriteFile(testFile, []byte("test"), 0644); err == nil {
+		if err := os.WriteFile(testFile, []byte("test"), 0600); err == nil {
 			checks["screenshots_path_writable"] = true
 			os.Remove(testFile)
 		}
@@ -166,7 +169,8 @@ func (c *SystemController) CheckUploadsFolder(ctx *gin.Context) {
 			checks["sample_files"] = samples
 		}
 	} else {
-		checks["screenshots_path_error"] = err.Error()
+		log.Printf("⚠️ Screenshots path check failed: %v", err)
+		checks["screenshots_path_error"] = "path does not exist or is not accessible"
 	}
 
 	utils.SuccessResponse(ctx, http.StatusOK, "Upload folder check completed", checks)
@@ -183,7 +187,7 @@ func (c *SystemController) CheckUploadsFolder(ctx *gin.Context) {
 // @Failure 500 {object} dto.ErrorResponse "Failed to create folders"
 // @Router /system/uploads/ensure [post]
 func (c *SystemController) EnsureUploadsFolders(ctx *gin.Context) {
-	uploadPath := config.AppConfig.Upload.Path
+	uploadPath := config.AppConfig.Upload.PrivatePath
 	screenshotsPath := filepath.Join(uploadPath, "screenshots")
 
 	results := map[string]interface{}{
@@ -192,15 +196,15 @@ func (c *SystemController) EnsureUploadsFolders(ctx *gin.Context) {
 	}
 
 	// Create upload path
-	if err := os.MkdirAll(uploadPath, 0755); err != nil {
-		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to create upload path: "+err.Error())
+	if err := os.MkdirAll(uploadPath, 0700); err != nil {
+		utils.RespondError(ctx, err)
 		return
 	}
 	results["upload_path_created"] = true
 
 	// Create screenshots path
-	if err := os.MkdirAll(screenshotsPath, 0755); err != nil {
-		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to create screenshots path: "+err.Error())
+	if err := os.MkdirAll(screenshotsPath, 0700); err != nil {
+		utils.RespondError(ctx, err)
 		return
 	}
 	results["screenshots_path_created"] = true
@@ -0,0 +1,170 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// TaskAssignmentController handles workspace task assignment rules
+type TaskAssignmentController struct {
+	assignmentService service.TaskAssignmentService
+}
+
+// NewTaskAssignmentController creates a new task assignment controller
+func NewTaskAssignmentController(assignmentService service.TaskAssignmentService) *TaskAssignmentController {
+	return &TaskAssignmentController{
+		assignmentService: assignmentService,
+	}
+}
+
+// assignmentRuleParams parses the workspace and rule IDs from the path
+func assignmentRuleParams(ctx *gin.Context) (uint, uint, bool) {
+	workspaceID, err := strconv.ParseUint(ctx.Param("workspace_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid workspace ID")
+		return 0, 0, false
+	}
+
+	ruleID, err := strconv.ParseUint(ctx.Param("rule_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid rule ID")
+		return 0, 0, false
+	}
+
+	return uint(workspaceID), uint(ruleID), true
+}
+
+// ListRules lists the workspace's task assignment rules
+// @Summary List task assignment rules
+// @Description List assignment rules in the order they are tried, including paused ones. Only workspace managers can view.
+// @Tags workspaces
+// @Produce json
+// @Security BearerAuth
+// @Param workspace_id path int true "Workspace ID"
+// @Success 200 {array} dto.TaskAssignmentRuleResponse "Assignment rules"
+// @Failure 400 {object} dto.ErrorResponse "Invalid workspace ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /workspaces/{workspace_id}/assignment-rules [get]
+func (c *TaskAssignmentController) ListRules(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseUint(ctx.Param("workspace_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid workspace ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	rules, err := c.assignmentService.ListRules(uint(workspaceID), userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rules)
+}
+
+// CreateRule creates a task assignment rule
+// @Summary Create task assignment rule
+// @Description Add a rule for tasks created with auto_assign. round_robin rotates through all members, by_role through members with workspace_role_id, and by_tag matches tasks whose project_code or cost_center equals tag_value, assigning them to assignee_id (or rotating when empty). Rules run by ascending priority. Only workspace managers can create.
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param workspace_id path int true "Workspace ID"
+// @Param request body dto.CreateTaskAssignmentRuleRequest true "Rule details"
+// @Success 201 {object} dto.TaskAssignmentRuleResponse "Rule created"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /workspaces/{workspace_id}/assignment-rules [post]
+func (c *TaskAssignmentController) CreateRule(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseUint(ctx.Param("workspace_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid workspace ID")
+		return
+	}
+
+	var req dto.CreateTaskAssignmentRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	rule, err := c.assignmentService.CreateRule(uint(workspaceID), userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, rule)
+}
+
+// UpdateRule updates a task assignment rule
+// @Summary Update task assignment rule
+// @Description Change a rule's strategy, priority or match, or pause it with is_active=false. Set assignee_id to 0 to rotate instead of using a fixed assignee.
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param workspace_id path int true "Workspace ID"
+// @Param rule_id path int true "Rule ID"
+// @Param request body dto.UpdateTaskAssignmentRuleRequest true "Rule changes"
+// @Success 200 {object} dto.TaskAssignmentRuleResponse "Rule updated"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /workspaces/{workspace_id}/assignment-rules/{rule_id} [put]
+func (c *TaskAssignmentController) UpdateRule(ctx *gin.Context) {
+	workspaceID, ruleID, ok := assignmentRuleParams(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateTaskAssignmentRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	rule, err := c.assignmentService.UpdateRule(workspaceID, ruleID, userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rule)
+}
+
+// DeleteRule deletes a task assignment rule
+// @Summary Delete task assignment rule
+// @Description Remove an assignment rule. Tasks already assigned keep their assignee.
+// @Tags workspaces
+// @Security BearerAuth
+// @Param workspace_id path int true "Workspace ID"
+// @Param rule_id path int true "Rule ID"
+// @Success 204 "Rule deleted"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /workspaces/{workspace_id}/assignment-rules/{rule_id} [delete]
+func (c *TaskAssignmentController) DeleteRule(ctx *gin.Context) {
+	workspaceID, ruleID, ok := assignmentRuleParams(ctx)
+	if !ok {
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	if err := c.assignmentService.DeleteRule(workspaceID, ruleID, userID); err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
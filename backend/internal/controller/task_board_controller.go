@@ -0,0 +1,158 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// TaskBoardController handles workspace Kanban boards
+type TaskBoardController struct {
+	boardService service.TaskBoardService
+}
+
+// NewTaskBoardController creates a new task board controller
+func NewTaskBoardController(boardService service.TaskBoardService) *TaskBoardController {
+	return &TaskBoardController{
+		boardService: boardService,
+	}
+}
+
+// boardWorkspaceID parses the workspace ID from the path
+func boardWorkspaceID(ctx *gin.Context) (uint, bool) {
+	workspaceID, err := strconv.ParseUint(ctx.Param("workspace_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid workspace ID")
+		return 0, false
+	}
+	return uint(workspaceID), true
+}
+
+// ListStatuses lists the workspace's board columns
+// @Summary List task statuses
+// @Description List the workspace's Kanban board columns in board order. Each column maps onto a base task status (active, completed, archived). Workspaces that never configured columns get one per base status, with is_default set.
+// @Tags workspaces
+// @Produce json
+// @Security BearerAuth
+// @Param workspace_id path int true "Workspace ID"
+// @Success 200 {object} dto.TaskStatusListResponse "Board columns"
+// @Failure 400 {object} dto.ErrorResponse "Invalid workspace ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Not a workspace member"
+// @Router /workspaces/{workspace_id}/task-statuses [get]
+func (c *TaskBoardController) ListStatuses(ctx *gin.Context) {
+	workspaceID, ok := boardWorkspaceID(ctx)
+	if !ok {
+		return
+	}
+
+	statuses, err := c.boardService.ListStatuses(workspaceID, ctx.GetUint("userID"))
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, statuses)
+}
+
+// UpdateStatuses replaces the workspace's board columns
+// @Summary Update task statuses
+// @Description Replace the workspace's Kanban board columns; their order is the board order. The board needs at least one column per base status. Tasks in removed columns move to the first column of their status, and tasks in a column whose category changed take the new status. Requires settings.manage.
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param workspace_id path int true "Workspace ID"
+// @Param request body dto.UpdateTaskStatusesRequest true "Board columns"
+// @Success 200 {object} dto.TaskStatusListResponse "Board columns"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /workspaces/{workspace_id}/task-statuses [put]
+func (c *TaskBoardController) UpdateStatuses(ctx *gin.Context) {
+	workspaceID, ok := boardWorkspaceID(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateTaskStatusesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	statuses, err := c.boardService.UpdateStatuses(workspaceID, ctx.GetUint("userID"), &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, statuses)
+}
+
+// GetBoard returns the workspace's Kanban board
+// @Summary Get task board
+// @Description Get the workspace's board columns with their tasks in order. Archived tasks are left out unless include_archived is true.
+// @Tags workspaces
+// @Produce json
+// @Security BearerAuth
+// @Param workspace_id path int true "Workspace ID"
+// @Param include_archived query bool false "Include archived tasks"
+// @Success 200 {object} dto.TaskBoardResponse "Task board"
+// @Failure 400 {object} dto.ErrorResponse "Invalid workspace ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Not a workspace member"
+// @Router /workspaces/{workspace_id}/tasks/board [get]
+func (c *TaskBoardController) GetBoard(ctx *gin.Context) {
+	workspaceID, ok := boardWorkspaceID(ctx)
+	if !ok {
+		return
+	}
+
+	board, err := c.boardService.GetBoard(workspaceID, ctx.GetUint("userID"), ctx.Query("include_archived") == "true")
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, board)
+}
+
+// ReorderTasks moves tasks within or into a board column
+// @Summary Reorder tasks
+// @Description Place tasks in a board column in the given order; task_ids is the column's full order after the move, including tasks dragged from other columns. Moved tasks take the column's base status. Members can move tasks they own or are assigned to; tasks.manage moves any task. Returns the updated board.
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param workspace_id path int true "Workspace ID"
+// @Param request body dto.ReorderTasksRequest true "Column and task order"
+// @Success 200 {object} dto.TaskBoardResponse "Task board"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /workspaces/{workspace_id}/tasks/reorder [put]
+func (c *TaskBoardController) ReorderTasks(ctx *gin.Context) {
+	workspaceID, ok := boardWorkspaceID(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.ReorderTasksRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	board, err := c.boardService.Reorder(workspaceID, ctx.GetUint("userID"), &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, board)
+}
@@ -0,0 +1,323 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/beuphecan/remote-time-tracker/internal/config"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/middleware"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// taskAttachmentFormOverhead leaves room for multipart headers and form fields
+const taskAttachmentFormOverhead = 1 << 20
+
+// TaskCommentController handles task comments and attachments
+type TaskCommentController struct {
+	commentService service.TaskCommentService
+}
+
+// NewTaskCommentController creates a new task comment controller
+func NewTaskCommentController(commentService service.TaskCommentService) *TaskCommentController {
+	return &TaskCommentController{
+		commentService: commentService,
+	}
+}
+
+// taskPathIDs parses the authenticated user and the task ID, plus the named
+// child ID when given
+func taskPathIDs(c *gin.Context, child, label string) (userID, taskID, childID uint, ok bool) {
+	userID, ok = middleware.GetUserID(c)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		return 0, 0, 0, false
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid task ID")
+		return 0, 0, 0, false
+	}
+	if child == "" {
+		return userID, uint(id), 0, true
+	}
+
+	cid, err := strconv.ParseUint(c.Param(child), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid "+label+" ID")
+		return 0, 0, 0, false
+	}
+	return userID, uint(id), uint(cid), true
+}
+
+// ListComments handles listing a task's comments
+// @Summary List task comments
+// @Description List the task's comments, oldest first, with the files attached to each. Available to the task owner, its assignees and members of its workspace.
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Task ID"
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.TaskCommentResponse} "Comments retrieved successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid task ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Task not found"
+// @Router /tasks/{id}/comments [get]
+func (ctrl *TaskCommentController) ListComments(c *gin.Context) {
+	userID, taskID, _, ok := taskPathIDs(c, "", "")
+	if !ok {
+		return
+	}
+
+	comments, err := ctrl.commentService.ListComments(taskID, userID)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Comments retrieved successfully", comments)
+}
+
+// CreateComment handles posting a comment on a task
+// @Summary Create task comment
+// @Description Comment on a task. Mentioned users must be able to see the task and are notified.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Task ID"
+// @Param request body dto.CreateTaskCommentRequest true "Comment"
+// @Success 201 {object} dto.SuccessResponse{data=dto.TaskCommentResponse} "Comment created successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Task not found"
+// @Router /tasks/{id}/comments [post]
+func (ctrl *TaskCommentController) CreateComment(c *gin.Context) {
+	userID, taskID, _, ok := taskPathIDs(c, "", "")
+	if !ok {
+		return
+	}
+
+	var req dto.CreateTaskCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	comment, err := ctrl.commentService.CreateComment(taskID, userID, &req)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Comment created successfully", comment)
+}
+
+// UpdateComment handles editing a task comment
+// @Summary Update task comment
+// @Description Edit one of your comments. Users mentioned for the first time are notified.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Task ID"
+// @Param comment_id path int true "Comment ID"
+// @Param request body dto.UpdateTaskCommentRequest true "Comment"
+// @Success 200 {object} dto.SuccessResponse{data=dto.TaskCommentResponse} "Comment updated successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Not your comment"
+// @Failure 404 {object} dto.ErrorResponse "Task or comment not found"
+// @Router /tasks/{id}/comments/{comment_id} [put]
+func (ctrl *TaskCommentController) UpdateComment(c *gin.Context) {
+	userID, taskID, commentID, ok := taskPathIDs(c, "comment_id", "comment")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateTaskCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	comment, err := ctrl.commentService.UpdateComment(taskID, commentID, userID, &req)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Comment updated successfully", comment)
+}
+
+// DeleteComment handles deleting a task comment
+// @Summary Delete task comment
+// @Description Delete a comment and the files attached to it. Authors can delete their own comments; the task owner and members with tasks.manage can delete any.
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Task ID"
+// @Param comment_id path int true "Comment ID"
+// @Success 200 {object} dto.SuccessResponse "Comment deleted successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Task or comment not found"
+// @Router /tasks/{id}/comments/{comment_id} [delete]
+func (ctrl *TaskCommentController) DeleteComment(c *gin.Context) {
+	userID, taskID, commentID, ok := taskPathIDs(c, "comment_id", "comment")
+	if !ok {
+		return
+	}
+
+	if err := ctrl.commentService.DeleteComment(taskID, commentID, userID); err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Comment deleted successfully", nil)
+}
+
+// ListAttachments handles listing a task's attachments
+// @Summary List task attachments
+// @Description List the files attached to the task and its comments.
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Task ID"
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.TaskAttachmentResponse} "Attachments retrieved successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid task ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Task not found"
+// @Router /tasks/{id}/attachments [get]
+func (ctrl *TaskCommentController) ListAttachments(c *gin.Context) {
+	userID, taskID, _, ok := taskPathIDs(c, "", "")
+	if !ok {
+		return
+	}
+
+	attachments, err := ctrl.commentService.ListAttachments(taskID, userID)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Attachments retrieved successfully", attachments)
+}
+
+// UploadAttachment handles attaching a file to a task
+// @Summary Upload task attachment
+// @Description Attach a file to the task, or to one of your comments on it. Files are limited to the server's maximum upload size and to the allowed types (TASK_ATTACHMENT_TYPES, e.g. .pdf, .png, .docx).
+// @Tags tasks
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Task ID"
+// @Param file formData file true "File to attach"
+// @Param comment_id formData int false "Comment to attach the file to"
+// @Success 201 {object} dto.SuccessResponse{data=dto.TaskAttachmentResponse} "Attachment uploaded successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request or file"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Not your comment"
+// @Failure 404 {object} dto.ErrorResponse "Task or comment not found"
+// @Failure 413 {object} dto.ErrorResponse "File too large"
+// @Router /tasks/{id}/attachments [post]
+func (ctrl *TaskCommentController) UploadAttachment(c *gin.Context) {
+	userID, taskID, _, ok := taskPathIDs(c, "", "")
+	if !ok {
+		return
+	}
+
+	maxSize := config.AppConfig.Upload.MaxSize
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize+taskAttachmentFormOverhead)
+
+	var req dto.TaskAttachmentUploadRequest
+	if err := c.ShouldBind(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "File is required")
+		return
+	}
+	if file.Size > maxSize {
+		utils.ErrorResponse(c, http.StatusRequestEntityTooLarge, "File is too large")
+		return
+	}
+
+	attachment, err := ctrl.commentService.UploadAttachment(taskID, userID, &req, file)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Attachment uploaded successfully", attachment)
+}
+
+// DownloadAttachment serves an attachment file
+// @Summary Download task attachment
+// @Description Download a file attached to the task.
+// @Tags tasks
+// @Produce application/octet-stream
+// @Security BearerAuth
+// @Param id path int true "Task ID"
+// @Param attachment_id path int true "Attachment ID"
+// @Success 200 {file} file "Attachment"
+// @Failure 400 {object} dto.ErrorResponse "Invalid ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Task or attachment not found"
+// @Router /tasks/{id}/attachments/{attachment_id}/download [get]
+func (ctrl *TaskCommentController) DownloadAttachment(c *gin.Context) {
+	userID, taskID, attachmentID, ok := taskPathIDs(c, "attachment_id", "attachment")
+	if !ok {
+		return
+	}
+
+	attachment, err := ctrl.commentService.GetAttachmentFile(taskID, attachmentID, userID)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	fileName := attachment.OriginalName
+	if fileName == "" {
+		fileName = attachment.FileName
+	}
+	// Uploaded files never render as active content on the API origin
+	c.Header("Content-Type", attachment.ContentType)
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Content-Security-Policy", "sandbox")
+	c.FileAttachment(attachment.FilePath, fileName)
+}
+
+// DeleteAttachment handles removing a task attachment
+// @Summary Delete task attachment
+// @Description Delete an attachment and its file. Uploaders can delete their own files; the task owner and members with tasks.manage can delete any.
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Task ID"
+// @Param attachment_id path int true "Attachment ID"
+// @Success 200 {object} dto.SuccessResponse "Attachment deleted successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Task or attachment not found"
+// @Router /tasks/{id}/attachments/{attachment_id} [delete]
+func (ctrl *TaskCommentController) DeleteAttachment(c *gin.Context) {
+	userID, taskID, attachmentID, ok := taskPathIDs(c, "attachment_id", "attachment")
+	if !ok {
+		return
+	}
+
+	if err := ctrl.commentService.DeleteAttachment(taskID, attachmentID, userID); err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Attachment deleted successfully", nil)
+}
@@ -1,9 +1,13 @@
 package controller
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
 	"github.com/beuphecan/remote-time-tracker/internal/dto"
 	"github.com/beuphecan/remote-time-tracker/internal/middleware"
 	"github.com/beuphecan/remote-time-tracker/internal/service"
@@ -25,7 +29,7 @@ func NewTaskController(taskService service.TaskService) *TaskController {
 
 // Create handles creating a new task
 // @Summary Create a new task
-// @Description Create a new task. Tasks can be manually created (is_manual=true) or auto-created from time tracker.
+// @Description Create a new task. Tasks can be manually created (is_manual=true) or auto-created from time tracker. With auto_assign, workspace managers and members who can manage tasks assign the task with the workspace's assignment rules; it stays with the creator when no rule matches.
 // @Tags tasks
 // @Accept json
 // @Produce json
@@ -34,6 +38,7 @@ func NewTaskController(taskService service.TaskService) *TaskController {
 // @Success 201 {object} dto.SuccessResponse{data=dto.TaskWithStats} "Task created successfully"
 // @Failure 400 {object} dto.ErrorResponse "Invalid request"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Cannot create or assign tasks in this workspace"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error"
 // @Router /tasks [post]
 func (ctrl *TaskController) Create(c *gin.Context) {
@@ -45,13 +50,13 @@ func (ctrl *TaskController) Create(c *gin.Context) {
 
 	var req dto.CreateTaskRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		utils.ValidationErrorResponse(c, err)
 		return
 	}
 
 	task, err := ctrl.taskService.Create(userID, &req)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -60,7 +65,7 @@ func (ctrl *TaskController) Create(c *gin.Context) {
 
 // GetByID handles retrieving a task by ID
 // @Summary Get task by ID
-// @Description Get detailed information about a specific task including statistics
+// @Description Get detailed information about a specific task, including linked commits and tracked time against the estimate
 // @Tags tasks
 // @Produce json
 // @Security BearerAuth
@@ -86,7 +91,7 @@ func (ctrl *TaskController) GetByID(c *gin.Context) {
 
 	task, err := ctrl.taskService.GetByID(uint(id), userID)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -95,13 +100,18 @@ func (ctrl *TaskController) GetByID(c *gin.Context) {
 
 // List handles listing tasks with pagination
 // @Summary List tasks
-// @Description Get paginated list of tasks for the authenticated user with statistics
+// @Description Get paginated list of tasks the authenticated user owns or is assigned to, with statistics
 // @Tags tasks
 // @Produce json
 // @Security BearerAuth
 // @Param page query int false "Page number" default(1) minimum(1)
 // @Param per_page query int false "Items per page" default(50) minimum(1) maximum(100)
+// @Param tag query string false "Filter by label"
+// @Param due_from query string false "Due on or after (YYYY-MM-DD)"
+// @Param due_to query string false "Due on or before (YYYY-MM-DD)"
+// @Param overdue query bool false "Only active tasks past their due date"
 // @Success 200 {object} dto.SuccessResponse "Tasks retrieved successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid filter"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error"
 // @Router /tasks [get]
@@ -123,9 +133,15 @@ func (ctrl *TaskController) List(c *gin.Context) {
 		perPage = 50
 	}
 
-	tasks, total, err := ctrl.taskService.GetByUserID(userID, page, perPage)
+	filter, err := parseTaskFilter(c)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		utils.RespondError(c, err)
+		return
+	}
+
+	tasks, total, err := ctrl.taskService.GetByUserID(userID, filter, page, perPage)
+	if err != nil {
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -148,17 +164,20 @@ func (ctrl *TaskController) List(c *gin.Context) {
 
 // Update handles updating a task
 // @Summary Update task
-// @Description Update an existing task's details
+// @Description Update an existing task's details. Handing the task to another member with assignee_id requires permission to assign tasks in its workspace.
 // @Tags tasks
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Task ID"
 // @Param request body dto.UpdateTaskRequest true "Task update details"
+// @Param If-Match header string false "Version the update is based on, instead of version in the body"
 // @Success 200 {object} dto.SuccessResponse{data=dto.TaskWithStats} "Task updated successfully"
 // @Failure 400 {object} dto.ErrorResponse "Invalid request"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Cannot assign tasks in this workspace"
 // @Failure 404 {object} dto.ErrorResponse "Task not found"
+// @Failure 409 {object} dto.ErrorResponse "Version conflict; details hold the current state"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error"
 // @Router /tasks/{id} [put]
 func (ctrl *TaskController) Update(c *gin.Context) {
@@ -177,13 +196,19 @@ func (ctrl *TaskController) Update(c *gin.Context) {
 
 	var req dto.UpdateTaskRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+	if !bindVersion(c, &req.Version) {
 		return
 	}
 
 	task, err := ctrl.taskService.Update(uint(id), userID, &req)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		if respondVersionConflict(c, err) {
+			return
+		}
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -218,7 +243,7 @@ func (ctrl *TaskController) Delete(c *gin.Context) {
 	}
 
 	if err := ctrl.taskService.Delete(uint(id), userID); err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -227,7 +252,7 @@ func (ctrl *TaskController) Delete(c *gin.Context) {
 
 // GetActiveTasks handles retrieving active tasks for a user
 // @Summary Get active tasks
-// @Description Get all active (non-completed, non-archived) tasks for the authenticated user
+// @Description Get all active (non-completed, non-archived) tasks the authenticated user owns or is assigned to
 // @Tags tasks
 // @Produce json
 // @Security BearerAuth
@@ -244,7 +269,7 @@ func (ctrl *TaskController) GetActiveTasks(c *gin.Context) {
 
 	tasks, err := ctrl.taskService.GetActiveTasks(userID)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -252,3 +277,176 @@ func (ctrl *TaskController) GetActiveTasks(c *gin.Context) {
 		"tasks": tasks,
 	})
 }
+
+// GetTree handles getting a task with its subtasks
+// @Summary Get task tree
+// @Description Get the task with its nested subtasks. Each node carries its own tracked time and total_seconds, its time including all subtasks.
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Task ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.TaskTreeNode} "Task tree retrieved successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid task ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Task not found"
+// @Router /tasks/{id}/tree [get]
+func (ctrl *TaskController) GetTree(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid task ID")
+		return
+	}
+
+	tree, err := ctrl.taskService.GetTree(uint(id), userID)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Task tree retrieved successfully", tree)
+}
+
+// ListAssignees handles listing the users a task is assigned to
+// @Summary List task assignees
+// @Description List the task's owner followed by its additional assignees. Available to the owner and assignees.
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Task ID"
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.TaskAssigneeResponse} "Assignees retrieved successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid task ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Task not found"
+// @Router /tasks/{id}/assignees [get]
+func (ctrl *TaskController) ListAssignees(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid task ID")
+		return
+	}
+
+	assignees, err := ctrl.taskService.ListAssignees(uint(id), userID)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Assignees retrieved successfully", assignees)
+}
+
+// AddAssignee handles assigning a workspace member to a task
+// @Summary Add task assignee
+// @Description Assign another workspace member to a workspace task. The task shows in their task lists and desktop app, and they are notified. Requires permission to assign tasks in the workspace.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Task ID"
+// @Param request body dto.AddTaskAssigneeRequest true "Member to assign"
+// @Success 201 {object} dto.SuccessResponse{data=[]dto.TaskAssigneeResponse} "Assignee added successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request or user already assigned"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Cannot assign tasks in this workspace"
+// @Failure 404 {object} dto.ErrorResponse "Task not found"
+// @Router /tasks/{id}/assignees [post]
+func (ctrl *TaskController) AddAssignee(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid task ID")
+		return
+	}
+
+	var req dto.AddTaskAssigneeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	assignees, err := ctrl.taskService.AddAssignee(uint(id), userID, &req)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Assignee added successfully", assignees)
+}
+
+// RemoveAssignee handles unassigning a member from a task
+// @Summary Remove task assignee
+// @Description Remove an additional assignee from a task. Assignees can remove themselves; removing others requires permission to assign tasks in the workspace.
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Task ID"
+// @Param user_id path int true "Assignee user ID"
+// @Success 200 {object} dto.SuccessResponse "Assignee removed successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Cannot assign tasks in this workspace"
+// @Failure 404 {object} dto.ErrorResponse "Task or assignee not found"
+// @Router /tasks/{id}/assignees/{user_id} [delete]
+func (ctrl *TaskController) RemoveAssignee(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid task ID")
+		return
+	}
+	assigneeID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	if err := ctrl.taskService.RemoveAssignee(uint(id), uint(assigneeID), userID); err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Assignee removed successfully", nil)
+}
+
+// parseTaskFilter reads the label and due date filters shared by the user and
+// admin task lists
+func parseTaskFilter(c *gin.Context) (*dto.TaskFilter, error) {
+	filter := &dto.TaskFilter{
+		Tag:     strings.ToLower(strings.TrimSpace(c.Query("tag"))),
+		Overdue: c.Query("overdue") == "true",
+	}
+	for _, param := range []struct {
+		name string
+		dst  **time.Time
+	}{{"due_from", &filter.DueFrom}, {"due_to", &filter.DueTo}} {
+		if value := c.Query(param.name); value != "" {
+			t, err := time.Parse("2006-01-02", value)
+			if err != nil {
+				return nil, apperror.Validation(fmt.Sprintf("invalid %s: use YYYY-MM-DD", param.name), nil)
+			}
+			*param.dst = &t
+		}
+	}
+	return filter, nil
+}
@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/middleware"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// TelemetryController handles crash telemetry and feature usage events from clients
+type TelemetryController struct {
+	telemetryService service.TelemetryService
+}
+
+// NewTelemetryController creates a new telemetry controller
+func NewTelemetryController(telemetryService service.TelemetryService) *TelemetryController {
+	return &TelemetryController{
+		telemetryService: telemetryService,
+	}
+}
+
+// ReportErrors ingests crash and error reports
+// @Summary Report desktop app crashes
+// @Description Submit up to 50 structured crash or error reports (version, OS, stack hash). Authentication is optional so crashes before login are still collected; reports are linked to the user when a token is sent.
+// @Tags updates
+// @Accept json
+// @Produce json
+// @Param request body dto.CrashReportBatchRequest true "Crash reports"
+// @Success 202 {object} dto.SuccessResponse{data=dto.CrashReportBatchResponse} "Crash reports accepted"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 429 {object} dto.ErrorResponse "Rate limit exceeded"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /telemetry/errors [post]
+func (ctrl *TelemetryController) ReportErrors(c *gin.Context) {
+	var req dto.CrashReportBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	var userID *uint
+	if id, ok := middleware.GetUserID(c); ok {
+		userID = &id
+	}
+
+	result, err := ctrl.telemetryService.IngestCrashReports(userID, &req)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusAccepted, "Crash reports accepted", result)
+}
+
+// ReportEvents ingests feature usage events
+// @Summary Report feature usage
+// @Description Submit up to 100 feature usage events (e.g. manual_task, invoicing, kanban) for product analytics. Events are attributed to an organization only when the user is a member, and dropped when the organization opted out of usage analytics.
+// @Tags updates
+// @Accept json
+// @Produce json
+// @Param request body dto.UsageEventBatchRequest true "Usage events"
+// @Success 202 {object} dto.SuccessResponse{data=dto.UsageEventBatchResponse} "Usage events accepted"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 429 {object} dto.ErrorResponse "Rate limit exceeded"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /telemetry/events [post]
+func (ctrl *TelemetryController) ReportEvents(c *gin.Context) {
+	var req dto.UsageEventBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	var userID *uint
+	if id, ok := middleware.GetUserID(c); ok {
+		userID = &id
+	}
+
+	result, err := ctrl.telemetryService.IngestUsageEvents(userID, &req)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusAccepted, "Usage events accepted", result)
+}
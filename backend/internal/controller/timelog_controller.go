@@ -26,7 +26,7 @@ func NewTimeLogController(timeLogService service.TimeLogService) *TimeLogControl
 
 // Start handles starting time tracking
 // @Summary Start time tracking
-// @Description Start a new time tracking session. Only one active session is allowed per user.
+// @Description Start a new time tracking session. Only one active session is allowed per user: depending on the server's timer concurrency policy, a session running on another device is stopped or the start is refused.
 // @Tags timelogs
 // @Accept json
 // @Produce json
@@ -45,19 +45,54 @@ func (ctrl *TimeLogController) Start(c *gin.Context) {
 
 	var req dto.StartTimeLogRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		utils.ValidationErrorResponse(c, err)
 		return
 	}
 
 	timeLog, err := ctrl.timeLogService.Start(userID, &req)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
 	utils.SuccessResponse(c, http.StatusCreated, "Time tracking started", timeLog)
 }
 
+// CreateManual handles adding a past time entry
+// @Summary Add manual time entry
+// @Description Add a past time entry with optional task, workspace and notes. Entries must end in the past, last at most 24 hours and not overlap any of your other time logs, including a running session, and cannot start in a pay period locked by an organization admin. In workspaces that require approval for manual entries the entry is created with pending_approval until a manager reviews it.
+// @Tags timelogs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.CreateManualTimeLogRequest true "Manual time entry"
+// @Success 201 {object} dto.SuccessResponse{data=dto.TimeLogResponse} "Time entry created"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 409 {object} dto.ErrorResponse "Overlaps an existing time log, or its pay period is locked (code period_locked)"
+// @Router /timelogs/manual [post]
+func (ctrl *TimeLogController) CreateManual(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req dto.CreateManualTimeLogRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err)
+		return
+	}
+
+	timeLog, err := ctrl.timeLogService.CreateManual(userID, &req)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Time entry created", timeLog)
+}
+
 // Stop handles stopping time tracking
 // @Summary Stop time tracking
 // @Description Stop the active time tracking session and save the duration
@@ -79,13 +114,13 @@ func (ctrl *TimeLogController) Stop(c *gin.Context) {
 
 	var req dto.StopTimeLogRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		utils.ValidationErrorResponse(c, err)
 		return
 	}
 
 	timeLog, err := ctrl.timeLogService.Stop(userID, &req)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -113,13 +148,13 @@ func (ctrl *TimeLogController) Pause(c *gin.Context) {
 
 	var req dto.PauseTimeLogRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		utils.ValidationErrorResponse(c, err)
 		return
 	}
 
 	timeLog, err := ctrl.timeLogService.Pause(userID, &req)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -147,13 +182,13 @@ func (ctrl *TimeLogController) Resume(c *gin.Context) {
 
 	var req dto.ResumeTimeLogRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		utils.ValidationErrorResponse(c, err)
 		return
 	}
 
 	timeLog, err := ctrl.timeLogService.Resume(userID, &req)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -179,7 +214,7 @@ func (ctrl *TimeLogController) GetActive(c *gin.Context) {
 
 	timeLog, err := ctrl.timeLogService.GetActiveSession(userID)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -191,6 +226,37 @@ func (ctrl *TimeLogController) GetActive(c *gin.Context) {
 	utils.SuccessResponse(c, http.StatusOK, "Active session retrieved", timeLog)
 }
 
+// GetCurrent retrieves the authoritative running session
+// @Summary Get current timer
+// @Description Get the user's single authoritative running or paused session across all devices, with the tracked time computed by the server. Devices showing a different running session should stop theirs. Data is null when no timer is active.
+// @Tags timelogs
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.SuccessResponse{data=dto.CurrentTimerResponse} "Current timer retrieved (null if no timer is active)"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /timelogs/current [get]
+func (ctrl *TimeLogController) GetCurrent(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	current, err := ctrl.timeLogService.GetCurrent(userID)
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	if current == nil {
+		utils.SuccessResponse(c, http.StatusOK, "No active timer", nil)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Current timer retrieved", current)
+}
+
 // List retrieves user's time logs
 // @Summary List time logs
 // @Description Get paginated list of time logs for the authenticated user
@@ -215,7 +281,7 @@ func (ctrl *TimeLogController) List(c *gin.Context) {
 
 	timeLogs, total, err := ctrl.timeLogService.GetByUserID(userID, page, perPage)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -249,7 +315,7 @@ func (ctrl *TimeLogController) GetByID(c *gin.Context) {
 
 	timeLog, err := ctrl.timeLogService.GetByID(uint(id), userID)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -281,21 +347,30 @@ func (ctrl *TimeLogController) GetStats(c *gin.Context) {
 
 	totalTime, err := ctrl.timeLogService.GetTotalTime(userID, startDate, endDate)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
 	timeLogs, err := ctrl.timeLogService.GetByDateRange(userID, startDate, endDate)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
+	// Breaks are reported apart from pauses; neither counts as tracked time
+	var breakTime, pausedTime int64
+	for _, timeLog := range timeLogs {
+		breakTime += timeLog.BreakTotal
+		pausedTime += timeLog.PausedTotal
+	}
+
 	utils.SuccessResponse(c, http.StatusOK, "Statistics retrieved", gin.H{
-		"total_time_seconds": totalTime,
-		"total_time_hours":   float64(totalTime) / 3600,
-		"session_count":      len(timeLogs),
-		"start_date":         startDate,
-		"end_date":           endDate,
+		"total_time_seconds":  totalTime,
+		"total_time_hours":    float64(totalTime) / 3600,
+		"break_time_seconds":  breakTime,
+		"paused_time_seconds": pausedTime,
+		"session_count":       len(timeLogs),
+		"start_date":          startDate,
+		"end_date":            endDate,
 	})
 }
@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// TimeLogHistoryController handles the edit history of time logs
+type TimeLogHistoryController struct {
+	historyService service.TimeLogHistoryService
+}
+
+// NewTimeLogHistoryController creates a new time log history controller
+func NewTimeLogHistoryController(historyService service.TimeLogHistoryService) *TimeLogHistoryController {
+	return &TimeLogHistoryController{
+		historyService: historyService,
+	}
+}
+
+// ListHistory lists a time log's edits
+// @Summary Get time log edit history
+// @Description Audit trail of the time log's edits by admins, synced devices and sync conflict resolutions, newest first, with the values before and after each edit and the names of the values it changed. Available to the time log's owner and to members who can view time logs in its workspace.
+// @Tags timelogs
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Time log ID"
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(20)
+// @Success 200 {object} map[string]interface{} "Edits with pagination"
+// @Failure 400 {object} dto.ErrorResponse "Invalid ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Time log not found"
+// @Router /timelogs/{id}/history [get]
+func (c *TimeLogHistoryController) ListHistory(ctx *gin.Context) {
+	timeLogID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid time log ID")
+		return
+	}
+
+	page := parseIntParam(ctx, "page", 1)
+	perPage := parseIntParam(ctx, "per_page", 20)
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+
+	userID := ctx.GetUint("userID")
+	edits, total, err := c.historyService.List(uint(timeLogID), userID, page, perPage)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"edits": edits,
+		"pagination": dto.PaginationMeta{
+			Page:       page,
+			PerPage:    perPage,
+			Total:      total,
+			TotalPages: int((total + int64(perPage) - 1) / int64(perPage)),
+		},
+	})
+}
@@ -26,10 +26,11 @@ func NewUpdateController(updateService *service.UpdateService) *UpdateController
 
 // CheckForUpdates checks if a new version is available
 // @Summary Check for app updates
-// @Description Check if a new version of the desktop app is available
+// @Description Check if a new version of the desktop app is available on the release channel: the one organization_id is pinned to, else the requested channel, else stable. is_mandatory is set when the current version is below the channel's minimum version.
 // @Tags updates
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param request body dto.UpdateCheckRequest true "Current version and platform info"
 // @Success 200 {object} dto.UpdateCheckResponse "Update check result"
 // @Failure 400 {object} dto.ErrorResponse "Invalid request"
@@ -38,17 +39,17 @@ func NewUpdateController(updateService *service.UpdateService) *UpdateController
 func (c *UpdateController) CheckForUpdates(ctx *gin.Context) {
 	var req dto.UpdateCheckRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		utils.ValidationErrorResponse(ctx, err)
 		return
 	}
 
 	log.Printf("📱 Update check from client: version=%s, platform=%s, arch=%s",
 		req.CurrentVersion, req.Platform, req.Arch)
 
-	result, err := c.updateService.CheckForUpdates(req)
+	result, err := c.updateService.CheckForUpdates(ctx.GetUint("userID"), req)
 	if err != nil {
 		log.Printf("❌ Update check failed: %v", err)
-		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to check for updates: "+err.Error())
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -62,7 +63,10 @@ func (c *UpdateController) CheckForUpdates(ctx *gin.Context) {
 // @Produce json
 // @Param platform query string false "Platform (darwin, win32, linux)" default(darwin)
 // @Param arch query string false "Architecture (x64, arm64)" default(x64)
+// @Param channel query string false "Release channel (stable, beta)"
+// @Param organization_id query int false "Organization whose pinned channel applies"
 // @Success 200 {object} dto.UpdateCheckResponse "Latest version info"
+// @Failure 400 {object} dto.ErrorResponse "Invalid organization ID"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error"
 // @Router /updates/latest [get]
 func (c *UpdateController) GetLatestVersion(ctx *gin.Context) {
@@ -73,26 +77,38 @@ func (c *UpdateController) GetLatestVersion(ctx *gin.Context) {
 		CurrentVersion: "0.0.0", // Always return latest
 		Platform:       platform,
 		Arch:           arch,
+		Channel:        ctx.Query("channel"),
+	}
+	if orgIDStr := ctx.Query("organization_id"); orgIDStr != "" {
+		orgID, err := strconv.ParseUint(orgIDStr, 10, 32)
+		if err != nil {
+			utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid organization ID")
+			return
+		}
+		id := uint(orgID)
+		req.OrganizationID = &id
 	}
 
-	result, err := c.updateService.CheckForUpdates(req)
+	result, err := c.updateService.CheckForUpdates(ctx.GetUint("userID"), req)
 	if err != nil {
 		log.Printf("❌ Failed to get latest version: %v", err)
-		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to get latest version: "+err.Error())
+		utils.RespondError(ctx, err)
 		return
 	}
 
 	utils.SuccessResponse(ctx, http.StatusOK, "Latest version retrieved", result)
 }
 
-// DownloadAsset proxies the download of a release asset from GitHub
+// DownloadAsset serves the download of a release asset: published assets are
+// redirected to where they are hosted, GitHub assets are proxied
 // @Summary Download release asset
-// @Description Download a specific release asset (installer/update file)
+// @Description Download a specific release asset (installer, block map or delta). Assets of published releases redirect to their host; GitHub assets are streamed. Every download is counted in the release download statistics.
 // @Tags updates
 // @Produce application/octet-stream
 // @Param version path string true "Version tag (e.g., v1.0.0)"
 // @Param filename path string true "Asset filename"
 // @Success 200 {file} binary "File download"
+// @Success 302 "Redirect to the published asset"
 // @Failure 400 {object} dto.ErrorResponse "Invalid request"
 // @Failure 404 {object} dto.ErrorResponse "Asset not found"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error"
@@ -108,11 +124,17 @@ func (c *UpdateController) DownloadAsset(ctx *gin.Context) {
 
 	log.Printf("📥 Download request: version=%s, file=%s", version, filename)
 
+	if published, err := c.updateService.GetPublishedAsset(version, filename); err == nil {
+		c.updateService.RecordDownload(ctx.GetUint("userID"), version, *published)
+		ctx.Redirect(http.StatusFound, published.URL)
+		return
+	}
+
 	// Get asset info for content length
 	assetInfo, err := c.updateService.GetAssetInfo(version, filename)
 	if err != nil {
 		log.Printf("❌ Asset not found: %v", err)
-		utils.ErrorResponse(ctx, http.StatusNotFound, "Asset not found: "+err.Error())
+		utils.ErrorResponse(ctx, http.StatusNotFound, "Asset not found")
 		return
 	}
 
@@ -130,11 +152,15 @@ func (c *UpdateController) DownloadAsset(ctx *gin.Context) {
 		log.Printf("❌ Download streaming failed: %v", err)
 		// Can't send error response if we already started streaming
 		if written == 0 {
-			utils.ErrorResponse(ctx, http.StatusInternalServerError, "Download failed: "+err.Error())
+			utils.RespondError(ctx, err)
 		}
 		return
 	}
 
+	c.updateService.RecordDownload(ctx.GetUint("userID"), version, dto.ReleaseAsset{
+		Name: filename,
+		Size: written,
+	})
 	log.Printf("✅ Download complete: %s (%d bytes)", filename, written)
 }
 
@@ -158,7 +184,7 @@ func (c *UpdateController) GetYMLFile(ctx *gin.Context) {
 	ymlInfo, err := c.updateService.GetYMLFile(platform)
 	if err != nil {
 		log.Printf("❌ Failed to get YML file: %v", err)
-		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to get update info: "+err.Error())
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -193,9 +219,9 @@ func (c *UpdateController) GetReleaseNotes(ctx *gin.Context) {
 			Platform:       "darwin",
 			Arch:           "x64",
 		}
-		result, err := c.updateService.CheckForUpdates(req)
+		result, err := c.updateService.CheckForUpdates(ctx.GetUint("userID"), req)
 		if err != nil {
-			utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to get release notes: "+err.Error())
+			utils.RespondError(ctx, err)
 			return
 		}
 
@@ -207,11 +233,20 @@ func (c *UpdateController) GetReleaseNotes(ctx *gin.Context) {
 		return
 	}
 
+	if published, err := c.updateService.GetPublishedRelease(version); err == nil {
+		utils.SuccessResponse(ctx, http.StatusOK, "Release notes retrieved", gin.H{
+			"version":       published.Version,
+			"release_notes": published.ReleaseNotes,
+			"release_date":  published.PublishedAt,
+		})
+		return
+	}
+
 	release, err = c.updateService.GetReleaseByTag(version)
 	if err != nil {
 		release, err = c.updateService.GetReleaseByTag("v" + version)
 		if err != nil {
-			utils.ErrorResponse(ctx, http.StatusNotFound, "Release not found: "+err.Error())
+			utils.ErrorResponse(ctx, http.StatusNotFound, "Release not found")
 			return
 		}
 	}
@@ -237,7 +272,7 @@ func (c *UpdateController) GetPublicDownloadLinks(ctx *gin.Context) {
 	downloads, err := c.updateService.GetAllPlatformDownloads()
 	if err != nil {
 		log.Printf("❌ Failed to get download links: %v", err)
-		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to get download links: "+err.Error())
+		utils.RespondError(ctx, err)
 		return
 	}
 
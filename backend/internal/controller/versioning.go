@@ -0,0 +1,42 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// bindVersion fills an update request's version from the If-Match header
+// ("3", 3 or W/"3") when the body has none. It responds 400 and returns
+// false for a header that is not a version.
+func bindVersion(ctx *gin.Context, version **uint) bool {
+	header := strings.TrimSpace(ctx.GetHeader("If-Match"))
+	if header == "" || *version != nil {
+		return true
+	}
+
+	value, err := strconv.ParseUint(strings.Trim(strings.TrimPrefix(header, "W/"), `"`), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "If-Match must be the version of the record being updated")
+		return false
+	}
+	v := uint(value)
+	*version = &v
+	return true
+}
+
+// respondVersionConflict sends the 409 with the current state for an update
+// made against an outdated version; false for any other error
+func respondVersionConflict(ctx *gin.Context, err error) bool {
+	var appErr *apperror.Error
+	if !errors.As(err, &appErr) || appErr.Code != apperror.CodeVersionConflict {
+		return false
+	}
+	utils.RespondError(ctx, err)
+	return true
+}
@@ -0,0 +1,286 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookController handles organization webhooks for HR system integrations
+type WebhookController struct {
+	webhookService service.WebhookService
+}
+
+// NewWebhookController creates a new webhook controller
+func NewWebhookController(webhookService service.WebhookService) *WebhookController {
+	return &WebhookController{
+		webhookService: webhookService,
+	}
+}
+
+// webhookParams parses the organization and webhook IDs from the path
+func webhookParams(ctx *gin.Context) (uint, uint, bool) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return 0, 0, false
+	}
+
+	webhookID, err := strconv.ParseUint(ctx.Param("webhook_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid webhook ID")
+		return 0, 0, false
+	}
+
+	return uint(orgID), uint(webhookID), true
+}
+
+// List lists the organization's webhooks
+// @Summary List organization webhooks
+// @Description List webhooks receiving member lifecycle events. Only owner or admin can view.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Success 200 {array} dto.WebhookResponse "Webhooks"
+// @Failure 400 {object} dto.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/webhooks [get]
+func (c *WebhookController) List(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	webhooks, err := c.webhookService.List(uint(orgID), userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, webhooks)
+}
+
+// Create creates a webhook
+// @Summary Create organization webhook
+// @Description Subscribe an HTTPS endpoint to member lifecycle events (member.joined, member.removed, member.role_changed) and weekly hour cap alerts (member.hour_cap_approaching, member.hour_cap_exceeded) and workspace budget alerts (workspace.budget_threshold). Requests are signed with HMAC-SHA256 over "<X-Webhook-Timestamp>.<body>" in the X-Webhook-Signature header. The signing secret is only returned in this response.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param request body dto.CreateWebhookRequest true "Webhook details"
+// @Success 201 {object} dto.WebhookResponse "Webhook created"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/webhooks [post]
+func (c *WebhookController) Create(ctx *gin.Context) {
+	orgID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	var req dto.CreateWebhookRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	webhook, err := c.webhookService.Create(uint(orgID), userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, webhook)
+}
+
+// Update updates a webhook
+// @Summary Update organization webhook
+// @Description Change a webhook's URL, events or description, or pause it with is_active=false
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param webhook_id path int true "Webhook ID"
+// @Param request body dto.UpdateWebhookRequest true "Webhook changes"
+// @Success 200 {object} dto.WebhookResponse "Webhook updated"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/webhooks/{webhook_id} [put]
+func (c *WebhookController) Update(ctx *gin.Context) {
+	orgID, webhookID, ok := webhookParams(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateWebhookRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	webhook, err := c.webhookService.Update(orgID, webhookID, userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, webhook)
+}
+
+// Delete deletes a webhook
+// @Summary Delete organization webhook
+// @Description Stop delivering events to a webhook
+// @Tags organizations
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param webhook_id path int true "Webhook ID"
+// @Success 204 "Webhook deleted"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/webhooks/{webhook_id} [delete]
+func (c *WebhookController) Delete(ctx *gin.Context) {
+	orgID, webhookID, ok := webhookParams(ctx)
+	if !ok {
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	if err := c.webhookService.Delete(orgID, webhookID, userID); err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// RotateSecret issues a new signing secret
+// @Summary Rotate webhook secret
+// @Description Generate a new signing secret. The previous secret stops working immediately; replay failed deliveries once the receiver is updated.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param webhook_id path int true "Webhook ID"
+// @Success 200 {object} dto.WebhookResponse "Webhook with new secret"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/webhooks/{webhook_id}/rotate-secret [post]
+func (c *WebhookController) RotateSecret(ctx *gin.Context) {
+	orgID, webhookID, ok := webhookParams(ctx)
+	if !ok {
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	webhook, err := c.webhookService.RotateSecret(orgID, webhookID, userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, webhook)
+}
+
+// ListDeliveries lists a webhook's delivery history
+// @Summary List webhook deliveries
+// @Description Get delivery attempts with response status and payload, newest first
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param webhook_id path int true "Webhook ID"
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(20)
+// @Param status query string false "Filter by status (pending, succeeded, failed)"
+// @Param event query string false "Filter by event"
+// @Success 200 {object} map[string]interface{} "Deliveries with pagination"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/webhooks/{webhook_id}/deliveries [get]
+func (c *WebhookController) ListDeliveries(ctx *gin.Context) {
+	orgID, webhookID, ok := webhookParams(ctx)
+	if !ok {
+		return
+	}
+
+	params := &dto.WebhookDeliveryListParams{
+		Page:    parseIntParam(ctx, "page", 1),
+		PerPage: parseIntParam(ctx, "per_page", 20),
+		Status:  ctx.Query("status"),
+		Event:   ctx.Query("event"),
+	}
+
+	userID := ctx.GetUint("userID")
+	deliveries, total, err := c.webhookService.ListDeliveries(orgID, webhookID, userID, params)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	totalPages := int((total + int64(params.PerPage) - 1) / int64(params.PerPage))
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"deliveries": deliveries,
+		"pagination": dto.PaginationMeta{
+			Page:       params.Page,
+			PerPage:    params.PerPage,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	})
+}
+
+// Replay re-sends missed deliveries
+// @Summary Replay webhook deliveries
+// @Description Re-send past events to the webhook, either by delivery ID or for a time window (failed deliveries only unless include_succeeded is set). Replays keep the original event ID so receivers can deduplicate. At most 500 deliveries are replayed per request.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path int true "Organization ID"
+// @Param webhook_id path int true "Webhook ID"
+// @Param request body dto.ReplayWebhookRequest true "Deliveries to replay"
+// @Success 202 {object} dto.ReplayWebhookResponse "Replay queued"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /organizations/{org_id}/webhooks/{webhook_id}/replay [post]
+func (c *WebhookController) Replay(ctx *gin.Context) {
+	orgID, webhookID, ok := webhookParams(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.ReplayWebhookRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	result, err := c.webhookService.Replay(orgID, webhookID, userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, result)
+}
@@ -3,21 +3,27 @@ package controller
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/beuphecan/remote-time-tracker/internal/dto"
 	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
 	"github.com/gin-gonic/gin"
 )
 
 // WorkspaceController handles workspace-related HTTP requests
 type WorkspaceController struct {
-	workspaceService service.WorkspaceService
+	workspaceService  service.WorkspaceService
+	complianceService service.ComplianceService
+	budgetService     service.BudgetService
 }
 
 // NewWorkspaceController creates a new workspace controller
-func NewWorkspaceController(workspaceService service.WorkspaceService) *WorkspaceController {
+func NewWorkspaceController(workspaceService service.WorkspaceService, complianceService service.ComplianceService, budgetService service.BudgetService) *WorkspaceController {
 	return &WorkspaceController{
-		workspaceService: workspaceService,
+		workspaceService:  workspaceService,
+		complianceService: complianceService,
+		budgetService:     budgetService,
 	}
 }
 
@@ -41,7 +47,7 @@ func NewWorkspaceController(workspaceService service.WorkspaceService) *Workspac
 func (c *WorkspaceController) GetByID(ctx *gin.Context) {
 	workspaceID, err := strconv.ParseUint(ctx.Param("workspace_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid workspace ID")
 		return
 	}
 
@@ -58,7 +64,7 @@ func (c *WorkspaceController) GetByID(ctx *gin.Context) {
 	}
 
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -74,28 +80,36 @@ func (c *WorkspaceController) GetByID(ctx *gin.Context) {
 // @Security BearerAuth
 // @Param workspace_id path int true "Workspace ID"
 // @Param request body dto.UpdateWorkspaceRequest true "Workspace data"
+// @Param If-Match header string false "Version the update is based on, instead of version in the body"
 // @Success 200 {object} dto.WorkspaceResponse "Workspace updated"
 // @Failure 400 {object} dto.ErrorResponse "Invalid request"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 409 {object} dto.ErrorResponse "Version conflict; details hold the current state"
 // @Router /workspaces/{workspace_id} [put]
 func (c *WorkspaceController) Update(ctx *gin.Context) {
 	workspaceID, err := strconv.ParseUint(ctx.Param("workspace_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid workspace ID")
 		return
 	}
 
 	var req dto.UpdateWorkspaceRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+	if !bindVersion(ctx, &req.Version) {
 		return
 	}
 
 	userID := ctx.GetUint("userID")
 	workspace, err := c.workspaceService.Update(uint(workspaceID), userID, &req)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		if respondVersionConflict(ctx, err) {
+			return
+		}
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -116,13 +130,13 @@ func (c *WorkspaceController) Update(ctx *gin.Context) {
 func (c *WorkspaceController) Delete(ctx *gin.Context) {
 	workspaceID, err := strconv.ParseUint(ctx.Param("workspace_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid workspace ID")
 		return
 	}
 
 	userID := ctx.GetUint("userID")
 	if err := c.workspaceService.Delete(uint(workspaceID), userID); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -149,13 +163,13 @@ func (c *WorkspaceController) List(ctx *gin.Context) {
 	if orgIDStr != "" {
 		orgID, err := strconv.ParseUint(orgIDStr, 10, 32)
 		if err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization ID"})
+			utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid organization ID")
 			return
 		}
 
 		workspaces, err := c.workspaceService.GetUserWorkspacesByOrg(userID, uint(orgID))
 		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			utils.RespondError(ctx, err)
 			return
 		}
 		ctx.JSON(http.StatusOK, workspaces)
@@ -164,7 +178,7 @@ func (c *WorkspaceController) List(ctx *gin.Context) {
 
 	workspaces, err := c.workspaceService.GetUserWorkspaces(userID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -185,19 +199,19 @@ func (c *WorkspaceController) List(ctx *gin.Context) {
 // @Success 200 {array} dto.WorkspaceMemberResponse "Workspace members"
 // @Failure 400 {object} dto.ErrorResponse "Invalid workspace ID"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
-// @Failure 403 {object} dto.ErrorResponse "Not a member"
+// @Failure 403 {object} dto.ErrorResponse "Missing the members.view permission"
 // @Router /workspaces/{workspace_id}/members [get]
 func (c *WorkspaceController) GetMembers(ctx *gin.Context) {
 	workspaceID, err := strconv.ParseUint(ctx.Param("workspace_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid workspace ID")
 		return
 	}
 
 	userID := ctx.GetUint("userID")
 	members, err := c.workspaceService.GetMembers(uint(workspaceID), userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -221,20 +235,20 @@ func (c *WorkspaceController) GetMembers(ctx *gin.Context) {
 func (c *WorkspaceController) AddMember(ctx *gin.Context) {
 	workspaceID, err := strconv.ParseUint(ctx.Param("workspace_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid workspace ID")
 		return
 	}
 
 	var req dto.AddWorkspaceMemberRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.ValidationErrorResponse(ctx, err)
 		return
 	}
 
 	userID := ctx.GetUint("userID")
 	member, err := c.workspaceService.AddMember(uint(workspaceID), userID, &req)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -259,26 +273,26 @@ func (c *WorkspaceController) AddMember(ctx *gin.Context) {
 func (c *WorkspaceController) UpdateMember(ctx *gin.Context) {
 	workspaceID, err := strconv.ParseUint(ctx.Param("workspace_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid workspace ID")
 		return
 	}
 
 	memberUserID, err := strconv.ParseUint(ctx.Param("user_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	var req dto.UpdateWorkspaceMemberRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.ValidationErrorResponse(ctx, err)
 		return
 	}
 
 	userID := ctx.GetUint("userID")
 	member, err := c.workspaceService.UpdateMember(uint(workspaceID), uint(memberUserID), userID, &req)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
@@ -300,21 +314,205 @@ func (c *WorkspaceController) UpdateMember(ctx *gin.Context) {
 func (c *WorkspaceController) RemoveMember(ctx *gin.Context) {
 	workspaceID, err := strconv.ParseUint(ctx.Param("workspace_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid workspace ID")
 		return
 	}
 
 	memberUserID, err := strconv.ParseUint(ctx.Param("user_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid user ID")
 		return
 	}
 
 	userID := ctx.GetUint("userID")
 	if err := c.workspaceService.RemoveMember(uint(workspaceID), uint(memberUserID), userID); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, err)
 		return
 	}
 
 	ctx.JSON(http.StatusNoContent, nil)
 }
+
+// ============================================================================
+// TRACKING SETTINGS
+// ============================================================================
+
+// GetTrackingSettings gets the workspace's screenshot capture policy
+// @Summary Get workspace tracking settings
+// @Description Get the screenshot capture policy the desktop app applies while tracking in this workspace: interval, blur mode, monitors to capture and whether screenshots are disabled. Fetch it instead of hardcoding the policy; screenshot uploads are rejected with screenshots_disabled when disabled.
+// @Tags workspaces
+// @Produce json
+// @Security BearerAuth
+// @Param workspace_id path int true "Workspace ID"
+// @Success 200 {object} dto.TrackingSettingsResponse "Tracking settings"
+// @Failure 400 {object} dto.ErrorResponse "Invalid workspace ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /workspaces/{workspace_id}/tracking-settings [get]
+func (c *WorkspaceController) GetTrackingSettings(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseUint(ctx.Param("workspace_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid workspace ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	settings, err := c.workspaceService.GetTrackingSettings(uint(workspaceID), userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, settings)
+}
+
+// UpdateTrackingSettings changes the workspace's screenshot capture policy
+// @Summary Update workspace tracking settings
+// @Description Change the screenshot capture policy, idle threshold, disabled desktop features and whether manual time entries need approval. Devices pick the changes up from their configuration document. Omitted fields are left unchanged. Requires the settings.manage permission.
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param workspace_id path int true "Workspace ID"
+// @Param request body dto.UpdateTrackingSettingsRequest true "Settings to change"
+// @Success 200 {object} dto.TrackingSettingsResponse "Updated tracking settings"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /workspaces/{workspace_id}/tracking-settings [put]
+func (c *WorkspaceController) UpdateTrackingSettings(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseUint(ctx.Param("workspace_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid workspace ID")
+		return
+	}
+
+	var req dto.UpdateTrackingSettingsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	settings, err := c.workspaceService.UpdateTrackingSettings(uint(workspaceID), userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, settings)
+}
+
+// ============================================================================
+// HOUR CAP COMPLIANCE
+// ============================================================================
+
+// GetCompliance gets the weekly hour cap compliance report
+// @Summary Get workspace hour cap compliance
+// @Description Get each member's tracked hours for a week against their weekly hour cap, with the number of time logs flagged for the cap. Weeks follow the organization calendar. Only workspace managers can view.
+// @Tags workspaces
+// @Produce json
+// @Security BearerAuth
+// @Param workspace_id path int true "Workspace ID"
+// @Param week query string false "Any date within the week (YYYY-MM-DD), defaults to the current week"
+// @Success 200 {object} dto.WorkspaceComplianceReport "Compliance report"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /workspaces/{workspace_id}/compliance [get]
+func (c *WorkspaceController) GetCompliance(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseUint(ctx.Param("workspace_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid workspace ID")
+		return
+	}
+
+	params := &dto.WorkspaceComplianceParams{}
+	if week := ctx.Query("week"); week != "" {
+		t, err := time.Parse("2006-01-02", week)
+		if err != nil {
+			utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid week: use YYYY-MM-DD")
+			return
+		}
+		params.Week = &t
+	}
+
+	userID := ctx.GetUint("userID")
+	report, err := c.complianceService.GetWorkspaceReport(uint(workspaceID), userID, params)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// ============================================================================
+// BUDGET
+// ============================================================================
+
+// GetBudget gets the workspace budget and its burn-down
+// @Summary Get workspace budget
+// @Description Get the workspace budget (hours, or money at the workspace hourly rate) with its consumption by approved time logs and a daily burn-down. Days follow the time logs' start time in UTC. Alerts fire at the thresholds from the periodic rollup. Only workspace managers can view.
+// @Tags workspaces
+// @Produce json
+// @Security BearerAuth
+// @Param workspace_id path int true "Workspace ID"
+// @Success 200 {object} dto.WorkspaceBudgetResponse "Workspace budget"
+// @Failure 400 {object} dto.ErrorResponse "Invalid workspace ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /workspaces/{workspace_id}/budget [get]
+func (c *WorkspaceController) GetBudget(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseUint(ctx.Param("workspace_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid workspace ID")
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	budget, err := c.budgetService.GetWorkspaceBudget(uint(workspaceID), userID)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, budget)
+}
+
+// UpdateBudget changes the workspace budget
+// @Summary Update workspace budget
+// @Description Set the budget type (hours, money or none), amount and alert thresholds in percent (default 80 and 100). Changing the type or amount starts a new budget whose thresholds alert again. Money budgets need a workspace hourly rate. Requires the settings.manage permission.
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param workspace_id path int true "Workspace ID"
+// @Param request body dto.UpdateWorkspaceBudgetRequest true "Budget to set"
+// @Success 200 {object} dto.WorkspaceBudgetResponse "Updated workspace budget"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Router /workspaces/{workspace_id}/budget [put]
+func (c *WorkspaceController) UpdateBudget(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseUint(ctx.Param("workspace_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "invalid workspace ID")
+		return
+	}
+
+	var req dto.UpdateWorkspaceBudgetRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	userID := ctx.GetUint("userID")
+	budget, err := c.budgetService.UpdateWorkspaceBudget(uint(workspaceID), userID, &req)
+	if err != nil {
+		utils.RespondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, budget)
+}
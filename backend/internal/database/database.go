@@ -58,28 +58,121 @@ func AutoMigrate(db *gorm.DB) error {
 		// Core models
 		&models.User{},
 		&models.Task{},
+		&models.TaskAssignee{},
+		&models.TaskComment{},
+		&models.TaskAttachment{},
+		&models.WorkspaceTaskStatus{},
 		&models.TimeLog{},
 		&models.Screenshot{},
 		&models.DeviceInfo{},
+		&models.DeviceLogBundle{},
+		&models.UserPresenceInterval{},
+		&models.CrashReport{},
+		&models.UsageEvent{},
+		&models.AppRelease{},
+		&models.AppReleaseAsset{},
+		&models.AppReleaseDownload{},
 		&models.SyncLog{},
 		&models.AuditLog{},
+		&models.DataExport{},
+		&models.OrganizationExport{},
+		&models.Operation{},
+		&models.IdempotencyKey{},
+		&models.ScreenshotDailyRollup{},
+		&models.DailyUserStat{},
+		&models.DailyWorkspaceStat{},
+		&models.DailyStatsRollup{},
+		&models.OrganizationStorageCounter{},
+		&models.ScreenshotDeletionRequest{},
+		&models.DeviceApproval{},
+		&models.FeatureFlag{},
+		&models.FeatureFlagOverride{},
+		&models.SyncConflict{},
 		// Organization & Workspace models
 		&models.Organization{},
 		&models.OrganizationMember{},
+		&models.OrganizationSetting{},
 		&models.WorkspaceRole{},
+		&models.Client{},
 		&models.Workspace{},
 		&models.WorkspaceMember{},
 		&models.Invitation{},
+		&models.OrganizationWebhook{},
+		&models.WebhookDelivery{},
+		&models.RoleChange{},
+		&models.CaptureExclusionRule{},
+		&models.ScreenshotEncryptionKey{},
+		&models.TaskAssignmentRule{},
+		&models.JiraIntegration{},
+		&models.JiraWorklog{},
+		&models.SlackIntegration{},
+		&models.VCSRepository{},
+		&models.TimeLogCommit{},
+		&models.TimeLogBreak{},
+		&models.TimeLogEdit{},
+		&models.PrivateInterval{},
+		&models.WorkSchedule{},
+		&models.OvertimeRecord{},
+		&models.LeaveRequest{},
+		&models.OrganizationHoliday{},
+		&models.CalendarFeed{},
+		&models.GoogleCalendarConnection{},
+		&models.GoogleCalendarEvent{},
+		&models.ShareLink{},
+		&models.EmailOutbox{},
+		&models.EmailAttachment{},
+		&models.NotificationPreference{},
+		&models.Invoice{},
+		&models.InvoiceLineItem{},
+		&models.SavedReport{},
+		&models.ReportSchedule{},
+		&models.PasswordResetToken{},
+		&models.Notification{},
 	)
 
 	if err != nil {
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
 
+	if err := migrateMoneyToMinorUnits(db); err != nil {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+
 	log.Println("✅ Database migrations completed")
 	return nil
 }
 
+// migrateMoneyToMinorUnits moves the decimal hourly and cost rates of
+// databases created before currencies into the integer minor unit columns.
+// Those rates were all in the default currency, USD, with two decimals.
+func migrateMoneyToMinorUnits(db *gorm.DB) error {
+	columns := []struct {
+		model    interface{}
+		table    string
+		from, to string
+	}{
+		{&models.Workspace{}, "workspaces", "hourly_rate", "hourly_rate_minor"},
+		{&models.OrganizationMember{}, "organization_members", "cost_rate", "cost_rate_minor"},
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, c := range columns {
+			if !tx.Migrator().HasColumn(c.model, c.from) {
+				continue
+			}
+			update := fmt.Sprintf("UPDATE %s SET %s = ROUND(%s * 100) WHERE %s IS NOT NULL", c.table, c.to, c.from, c.from)
+			if err := tx.Exec(update).Error; err != nil {
+				return err
+			}
+			if err := tx.Migrator().DropColumn(c.model, c.from); err != nil {
+				return err
+			}
+			log.Printf("✅ Migrated %s.%s to %s", c.table, c.from, c.to)
+		}
+		return nil
+	})
+}
+
 // Close closes the database connection
 func Close() error {
 	if DB != nil {
@@ -22,6 +22,7 @@ type AdminUserListParams struct {
 // AdminUserResponse represents a user in admin responses
 type AdminUserResponse struct {
 	ID              uint       `json:"id"`
+	UUID            string     `json:"uuid"`
 	Email           string     `json:"email"`
 	FirstName       string     `json:"first_name"`
 	LastName        string     `json:"last_name"`
@@ -63,8 +64,8 @@ type AdminCreateUserRequest struct {
 	Password   string `json:"password" binding:"required,min=8"`
 	FirstName  string `json:"first_name" binding:"required"`
 	LastName   string `json:"last_name" binding:"required"`
-	Role       string `json:"role"`
-	SystemRole string `json:"system_role"`
+	Role       string `json:"role" binding:"omitempty,role=user"`
+	SystemRole string `json:"system_role" binding:"omitempty,role=system"`
 	IsActive   *bool  `json:"is_active"`
 }
 
@@ -73,20 +74,52 @@ type AdminUpdateUserRequest struct {
 	Email      string `json:"email" binding:"omitempty,email"`
 	FirstName  string `json:"first_name"`
 	LastName   string `json:"last_name"`
-	Role       string `json:"role"`
-	SystemRole string `json:"system_role"`
+	Role       string `json:"role" binding:"omitempty,role=user"`
+	SystemRole string `json:"system_role" binding:"omitempty,role=system"`
 	IsActive   *bool  `json:"is_active"`
 	Password   string `json:"password" binding:"omitempty,min=8"`
 }
 
 // AdminChangeRoleRequest represents request to change user role
 type AdminChangeRoleRequest struct {
-	Role string `json:"role" binding:"required"`
+	Role string `json:"role" binding:"required,role=user"`
 }
 
 // AdminChangeSystemRoleRequest represents request to change user system role
 type AdminChangeSystemRoleRequest struct {
-	SystemRole string `json:"system_role" binding:"required"`
+	SystemRole string `json:"system_role" binding:"required,role=system"`
+}
+
+// AdminImpersonateRequest represents request to impersonate a user
+type AdminImpersonateRequest struct {
+	Reason string `json:"reason"` // Why support needs to act as this user (recorded in the audit log)
+}
+
+// AdminImpersonationResponse represents an issued impersonation session
+type AdminImpersonationResponse struct {
+	AccessToken    string            `json:"access_token"`
+	ExpiresAt      time.Time         `json:"expires_at"`
+	Impersonation  bool              `json:"impersonation"`
+	ImpersonatorID uint              `json:"impersonator_id"`
+	User           AdminUserResponse `json:"user"`
+}
+
+// AdminMergeUserRequest represents request to merge a duplicate user into another
+type AdminMergeUserRequest struct {
+	TargetUserID uint `json:"target_user_id" binding:"required"` // User who keeps the merged records
+}
+
+// AdminUserMergeResponse represents the records moved by a user merge
+type AdminUserMergeResponse struct {
+	SourceUserID            uint              `json:"source_user_id"`
+	Target                  AdminUserResponse `json:"target"`
+	Tasks                   int64             `json:"tasks"`
+	TaskAssignments         int64             `json:"task_assignments"`
+	TimeLogs                int64             `json:"time_logs"`
+	Screenshots             int64             `json:"screenshots"`
+	Devices                 int64             `json:"devices"`
+	OrganizationMemberships int64             `json:"organization_memberships"`
+	WorkspaceMemberships    int64             `json:"workspace_memberships"`
 }
 
 // AdminActivateUserRequest represents request to activate/deactivate user
@@ -168,6 +201,8 @@ type AdminOrgListParams struct {
 // AdminOrgResponse represents an organization in admin responses
 type AdminOrgResponse struct {
 	ID              uint       `json:"id"`
+	UUID            string     `json:"uuid"`
+	Version         uint       `json:"version"`
 	Name            string     `json:"name"`
 	Slug            string     `json:"slug"`
 	Description     string     `json:"description"`
@@ -226,6 +261,8 @@ type AdminUpdateOrgRequest struct {
 	AdminNotes      string `json:"admin_notes"`
 	AllowInviteLink *bool  `json:"allow_invite_link"`
 	MaxMembers      *int   `json:"max_members"`
+
+	Version *uint `json:"version"` // Version the edit is based on, or the If-Match header; nil skips the check
 }
 
 // AdminVerifyOrgRequest represents request to verify organization
@@ -253,6 +290,8 @@ type AdminWorkspaceListParams struct {
 // AdminWorkspaceResponse represents a workspace in admin responses
 type AdminWorkspaceResponse struct {
 	ID          uint       `json:"id"`
+	UUID        string     `json:"uuid"`
+	Version     uint       `json:"version"`
 	Name        string     `json:"name"`
 	Slug        string     `json:"slug"`
 	Description string     `json:"description"`
@@ -268,6 +307,9 @@ type AdminWorkspaceResponse struct {
 	ArchivedAt  *time.Time `json:"archived_at"`
 	IsBillable  bool       `json:"is_billable"`
 	HourlyRate  float64    `json:"hourly_rate"`
+	Currency    string     `json:"currency"`
+	CostCenter  string     `json:"cost_center"`
+	ProjectCode string     `json:"project_code"`
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
 }
@@ -303,6 +345,11 @@ type AdminUpdateWorkspaceRequest struct {
 	IsActive    *bool    `json:"is_active"`
 	IsBillable  *bool    `json:"is_billable"`
 	HourlyRate  *float64 `json:"hourly_rate"`
+	Currency    *string  `json:"currency" binding:"omitempty,iso4217"`
+	CostCenter  *string  `json:"cost_center"`
+	ProjectCode *string  `json:"project_code"`
+
+	Version *uint `json:"version"` // Version the edit is based on, or the If-Match header; nil skips the check
 }
 
 // AdminArchiveWorkspaceRequest represents request to archive workspace
@@ -324,13 +371,16 @@ type AdminTaskListParams struct {
 	WorkspaceID *uint  `form:"workspace_id"`
 	Status      string `form:"status"`
 	IsManual    *bool  `form:"is_manual"`
-	SortBy      string `form:"sort_by"`
-	SortOrder   string `form:"sort_order"`
+	TaskFilter
+	SortBy    string `form:"sort_by"`
+	SortOrder string `form:"sort_order"`
 }
 
 // AdminTaskResponse represents a task in admin responses
 type AdminTaskResponse struct {
 	ID              uint       `json:"id"`
+	UUID            string     `json:"uuid"`
+	Version         uint       `json:"version"`
 	Title           string     `json:"title"`
 	Description     string     `json:"description"`
 	UserID          uint       `json:"user_id"`
@@ -345,6 +395,13 @@ type AdminTaskResponse struct {
 	Color           string     `json:"color"`
 	IsManual        bool       `json:"is_manual"`
 	AdminNotes      string     `json:"admin_notes"`
+	CostCenter      string     `json:"cost_center"`
+	ProjectCode     string     `json:"project_code"`
+	CreatedBy       *uint      `json:"created_by"`
+	ParentTaskID    *uint      `json:"parent_task_id"`
+	DueDate         *time.Time `json:"due_date"`
+	EstimateSeconds int64      `json:"estimate_seconds"`
+	Tags            []string   `json:"tags"`
 	StartTime       *time.Time `json:"start_time"`
 	EndTime         *time.Time `json:"end_time"`
 	TotalTime       int64      `json:"total_time"`
@@ -369,15 +426,25 @@ type AdminTaskDetailResponse struct {
 	ScreenshotCount int64                     `json:"screenshot_count"`
 	TimeLogs        []AdminTimeLogResponse    `json:"timelogs"`
 	Screenshots     []AdminScreenshotResponse `json:"screenshots"`
+	Commits         []TimeLogCommitResponse   `json:"commits"`
+	Estimate        TaskEstimateResponse      `json:"estimate"`
 }
 
 // AdminUpdateTaskRequest represents admin request to update task
 type AdminUpdateTaskRequest struct {
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Status      string `json:"status"`
-	Priority    *int   `json:"priority"`
-	AdminNotes  string `json:"admin_notes"`
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	Status      string  `json:"status"`
+	Priority    *int    `json:"priority"`
+	AdminNotes  string  `json:"admin_notes"`
+	CostCenter  *string `json:"cost_center"`
+	ProjectCode *string `json:"project_code"`
+
+	DueDate         *string   `json:"due_date"`         // YYYY-MM-DD; an empty string clears it
+	EstimateSeconds *int64    `json:"estimate_seconds"` // 0 clears the estimate
+	Tags            *[]string `json:"tags"`             // Replaces the labels; an empty list clears them
+
+	Version *uint `json:"version"` // Version the edit is based on, or the If-Match header; nil skips the check
 }
 
 // ============================================================================
@@ -394,6 +461,9 @@ type AdminTimeLogListParams struct {
 	TaskID      *uint      `form:"task_id"`
 	Status      string     `form:"status"`
 	IsApproved  *bool      `form:"is_approved"`
+	Pending     *bool      `form:"pending_approval"` // Manual entries awaiting review
+	CostCenter  string     `form:"cost_center"`
+	ProjectCode string     `form:"project_code"`
 	StartDate   *time.Time `form:"start_date"`
 	EndDate     *time.Time `form:"end_date"`
 	SortBy      string     `form:"sort_by"`
@@ -403,6 +473,7 @@ type AdminTimeLogListParams struct {
 // AdminTimeLogResponse represents a time log in admin responses
 type AdminTimeLogResponse struct {
 	ID              uint       `json:"id"`
+	UUID            string     `json:"uuid"`
 	UserID          uint       `json:"user_id"`
 	UserEmail       string     `json:"user_email"`
 	UserName        string     `json:"user_name"`
@@ -416,11 +487,17 @@ type AdminTimeLogResponse struct {
 	EndTime         *time.Time `json:"end_time"`
 	Duration        int64      `json:"duration"`
 	Status          string     `json:"status"`
+	CapStatus       string     `json:"cap_status"`       // Weekly hour cap flag: "", approaching, exceeded
+	OvertimeSeconds int64      `json:"overtime_seconds"` // Past the work schedule's daily or weekly hours
 	IsManual        bool       `json:"is_manual"`
 	IsApproved      bool       `json:"is_approved"`
+	PendingApproval bool       `json:"pending_approval"`
 	ApprovedBy      *uint      `json:"approved_by"`
 	ApprovedAt      *time.Time `json:"approved_at"`
 	AdminNotes      string     `json:"admin_notes"`
+	InvoiceID       *uint      `json:"invoice_id"`   // Invoice billing the time log; invoiced time logs are locked
+	CostCenter      string     `json:"cost_center"`  // Task cost center, falling back to the workspace
+	ProjectCode     string     `json:"project_code"` // Task project code, falling back to the workspace
 	ScreenshotCount int64      `json:"screenshot_count"`
 	CreatedAt       time.Time  `json:"created_at"`
 }
@@ -436,6 +513,7 @@ type AdminTimeLogDetailResponse struct {
 	AdminTimeLogResponse
 	ScreenshotCount int64                     `json:"screenshot_count"`
 	Screenshots     []AdminScreenshotResponse `json:"screenshots"`
+	Commits         []TimeLogCommitResponse   `json:"commits"`
 }
 
 // AdminUpdateTimeLogRequest represents admin request to update time log
@@ -470,31 +548,37 @@ type AdminScreenshotListParams struct {
 	EndDate     *time.Time `form:"end_date"`
 	SortBy      string     `form:"sort_by"`
 	SortOrder   string     `form:"sort_order"`
+	ScanStatus  string     `form:"scan_status"` // pending, clean, quarantined or released
 }
 
 // AdminScreenshotResponse represents a screenshot in admin responses
 type AdminScreenshotResponse struct {
-	ID            uint      `json:"id"`
-	UserID        uint      `json:"user_id"`
-	UserEmail     string    `json:"user_email"`
-	UserName      string    `json:"user_name"`
-	TimeLogID     *uint     `json:"timelog_id"`
-	TaskID        *uint     `json:"task_id"`
-	TaskTitle     string    `json:"task_title"`
-	OrgID         *uint     `json:"organization_id"`
-	OrgName       string    `json:"org_name"`
-	WorkspaceID   *uint     `json:"workspace_id"`
-	WorkspaceName string    `json:"workspace_name"`
-	FilePath      string    `json:"file_path"`
-	FileName      string    `json:"file_name"`
-	FileSize      int64     `json:"file_size"`
-	MimeType      string    `json:"mime_type"`
-	ThumbnailPath string    `json:"thumbnail_path"`
-	MonitorIndex  int       `json:"monitor_index"`
-	CapturedAt    time.Time `json:"captured_at"`
-	ScreenNumber  int       `json:"screen_number"`
-	IsEncrypted   bool      `json:"is_encrypted"`
-	CreatedAt     time.Time `json:"created_at"`
+	ID            uint       `json:"id"`
+	UUID          string     `json:"uuid"`
+	UserID        uint       `json:"user_id"`
+	UserEmail     string     `json:"user_email"`
+	UserName      string     `json:"user_name"`
+	TimeLogID     *uint      `json:"timelog_id"`
+	TaskID        *uint      `json:"task_id"`
+	TaskTitle     string     `json:"task_title"`
+	OrgID         *uint      `json:"organization_id"`
+	OrgName       string     `json:"org_name"`
+	WorkspaceID   *uint      `json:"workspace_id"`
+	WorkspaceName string     `json:"workspace_name"`
+	FilePath      string     `json:"file_path"`
+	FileName      string     `json:"file_name"`
+	FileSize      int64      `json:"file_size"`
+	MimeType      string     `json:"mime_type"`
+	ThumbnailPath string     `json:"thumbnail_path"`
+	MonitorIndex  int        `json:"monitor_index"`
+	CapturedAt    time.Time  `json:"captured_at"`
+	ScreenNumber  int        `json:"screen_number"`
+	IsEncrypted   bool       `json:"is_encrypted"`
+	StorageTier   string     `json:"storage_tier"`          // hot or cold (retrieved on first view)
+	ScanStatus    string     `json:"scan_status,omitempty"` // pending, clean, quarantined or released
+	ScanSignature string     `json:"scan_signature,omitempty"`
+	ScannedAt     *time.Time `json:"scanned_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
 }
 
 // AdminScreenshotListResponse represents screenshot list response
@@ -503,6 +587,225 @@ type AdminScreenshotListResponse struct {
 	Pagination  AdminPaginationResponse   `json:"pagination"`
 }
 
+// AdminScreenshotSearchParams represents query parameters for searching the
+// text read from screenshots
+type AdminScreenshotSearchParams struct {
+	Query     string     `form:"q"`
+	UserID    *uint      `form:"user_id"`
+	OrgID     *uint      `form:"org_id"`
+	StartDate *time.Time `form:"start_date"`
+	EndDate   *time.Time `form:"end_date"`
+	Limit     int        `form:"limit"`
+}
+
+// AdminScreenshotSearchResult represents a screenshot whose text matches a search
+type AdminScreenshotSearchResult struct {
+	ID          uint      `json:"id"`
+	UserID      uint      `json:"user_id"`
+	UserEmail   string    `json:"user_email"`
+	UserName    string    `json:"user_name"`
+	OrgID       *uint     `json:"organization_id"`
+	WorkspaceID *uint     `json:"workspace_id"`
+	TimeLogID   *uint     `json:"timelog_id"`
+	CapturedAt  time.Time `json:"captured_at"`
+	Snippet     string    `json:"snippet"` // Matched words wrapped in <b> tags
+}
+
+// AdminScreenshotSearchResponse represents screenshot search results, most
+// recent first
+type AdminScreenshotSearchResponse struct {
+	Query   string                        `json:"query"`
+	Results []AdminScreenshotSearchResult `json:"results"`
+}
+
+// ============================================================================
+// ADMIN PRESENCE DTOs
+// ============================================================================
+
+// AdminPresenceMemberResponse represents an organization member's current presence
+type AdminPresenceMemberResponse struct {
+	UserID         uint       `json:"user_id"`
+	UserEmail      string     `json:"user_email"`
+	UserName       string     `json:"user_name"`
+	Role           string     `json:"role"`
+	Status         string     `json:"status"` // working, online, idle, stale or offline
+	LastPresenceAt *time.Time `json:"last_presence_at"`
+	LastWorkingAt  *time.Time `json:"last_working_at"`
+}
+
+// AdminPresenceListResponse represents the presence of an organization's members
+type AdminPresenceListResponse struct {
+	OrganizationID uint                          `json:"organization_id"`
+	Counts         map[string]int                `json:"counts"` // Members per status
+	Members        []AdminPresenceMemberResponse `json:"members"`
+}
+
+// AdminPresenceHistoryParams represents query parameters for the presence history
+type AdminPresenceHistoryParams struct {
+	OrgID  uint
+	UserID *uint
+	From   time.Time
+	To     time.Time
+}
+
+// AdminPresenceIntervalResponse represents a span a user spent in one status
+type AdminPresenceIntervalResponse struct {
+	UserID          uint       `json:"user_id"`
+	UserEmail       string     `json:"user_email"`
+	UserName        string     `json:"user_name"`
+	Status          string     `json:"status"` // working, online or idle
+	StartedAt       time.Time  `json:"started_at"`
+	EndedAt         *time.Time `json:"ended_at"` // Nil while the status lasts
+	DurationSeconds int64      `json:"duration_seconds"`
+}
+
+// AdminPresenceHistoryResponse represents the presence intervals overlapping
+// a period, oldest first
+type AdminPresenceHistoryResponse struct {
+	OrganizationID uint                            `json:"organization_id"`
+	From           time.Time                       `json:"from"`
+	To             time.Time                       `json:"to"`
+	Intervals      []AdminPresenceIntervalResponse `json:"intervals"`
+	Truncated      bool                            `json:"truncated"`
+}
+
+// AdminDeviceLogListParams represents device log bundle list query parameters
+type AdminDeviceLogListParams struct {
+	Page     int   `form:"page"`
+	PageSize int   `form:"page_size"`
+	UserID   *uint `form:"user_id"`
+	DeviceID *uint `form:"device_id"`
+}
+
+// AdminDeviceLogResponse represents an uploaded desktop app log bundle
+type AdminDeviceLogResponse struct {
+	ID             uint       `json:"id"`
+	UserID         uint       `json:"user_id"`
+	UserEmail      string     `json:"user_email"`
+	UserName       string     `json:"user_name"`
+	DeviceID       uint       `json:"device_id"`
+	DeviceUUID     string     `json:"device_uuid"`
+	DeviceName     string     `json:"device_name"`
+	OS             string     `json:"os"`
+	OSVersion      string     `json:"os_version"`
+	AppVersion     string     `json:"app_version"`
+	DeviceLastSeen *time.Time `json:"device_last_seen_at"`
+	FileName       string     `json:"file_name"`
+	OriginalName   string     `json:"original_name"`
+	FileSize       int64      `json:"file_size"`
+	Note           string     `json:"note"`
+	CreatedAt      time.Time  `json:"created_at"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+}
+
+// AdminDeviceLogListResponse represents device log bundle list response
+type AdminDeviceLogListResponse struct {
+	Logs       []AdminDeviceLogResponse `json:"logs"`
+	Pagination AdminPaginationResponse  `json:"pagination"`
+}
+
+// AdminCrashListParams represents crash aggregation query parameters
+type AdminCrashListParams struct {
+	Days     int    `form:"days"`     // Look-back window, default 30
+	Platform string `form:"platform"` // Optional platform filter
+	Limit    int    `form:"limit"`
+}
+
+// AdminReleaseCrashSummary aggregates crash reports of one release
+type AdminReleaseCrashSummary struct {
+	AppVersion      string    `json:"app_version"`
+	Reports         int64     `json:"reports"`
+	FatalReports    int64     `json:"fatal_reports"`
+	UniqueStacks    int64     `json:"unique_stacks"`
+	AffectedDevices int64     `json:"affected_devices"`
+	AffectedUsers   int64     `json:"affected_users"`
+	ActiveDevices   int64     `json:"active_devices"`  // Devices seen on this release in the window
+	CrashFreeRate   *float64  `json:"crash_free_rate"` // Percent of active devices without a report
+	FirstSeenAt     time.Time `json:"first_seen_at"`
+	LastSeenAt      time.Time `json:"last_seen_at"`
+}
+
+// AdminCrashStackGroup aggregates crash reports sharing a stack hash
+type AdminCrashStackGroup struct {
+	StackHash       string    `json:"stack_hash"`
+	ErrorType       string    `json:"error_type"`
+	Message         string    `json:"message"`     // Most recent message
+	StackTrace      string    `json:"stack_trace"` // Most recent stack trace
+	ProcessType     string    `json:"process_type"`
+	Platforms       string    `json:"platforms"` // Comma-separated
+	Reports         int64     `json:"reports"`
+	FatalReports    int64     `json:"fatal_reports"`
+	AffectedDevices int64     `json:"affected_devices"`
+	FirstSeenAt     time.Time `json:"first_seen_at"`
+	LastSeenAt      time.Time `json:"last_seen_at"`
+}
+
+// AdminReleaseCrashResponse represents crash details of one release
+type AdminReleaseCrashResponse struct {
+	Summary AdminReleaseCrashSummary `json:"summary"`
+	Stacks  []AdminCrashStackGroup   `json:"stacks"`
+}
+
+// AdminReleaseDownloadParams represents release download aggregation query parameters
+type AdminReleaseDownloadParams struct {
+	Days     int    `form:"days"`     // Look-back window, default 30
+	Platform string `form:"platform"` // Optional platform filter
+	Limit    int    `form:"limit"`
+}
+
+// AdminReleaseDownloadSummary aggregates the downloads of one release
+type AdminReleaseDownloadSummary struct {
+	Version            string    `json:"version"`
+	Downloads          int64     `json:"downloads"`
+	InstallerDownloads int64     `json:"installer_downloads"`
+	BlockmapDownloads  int64     `json:"blockmap_downloads"`
+	DeltaDownloads     int64     `json:"delta_downloads"`
+	Bytes              int64     `json:"bytes"`
+	UniqueUsers        int64     `json:"unique_users"`
+	LastDownloadedAt   time.Time `json:"last_downloaded_at"`
+}
+
+// AdminFeatureUsageParams represents feature usage aggregation query parameters
+type AdminFeatureUsageParams struct {
+	Days  int   `form:"days"`   // Look-back window, default 30
+	OrgID *uint `form:"org_id"` // Optional organization filter
+	Limit int   `form:"limit"`
+}
+
+// AdminFeatureUsage aggregates usage events of one feature
+type AdminFeatureUsage struct {
+	Feature       string    `json:"feature"`
+	Events        int64     `json:"events"`
+	Organizations int64     `json:"organizations"`
+	Users         int64     `json:"users"`
+	AdoptionRate  *float64  `json:"adoption_rate"` // Percent of organizations active in the window that used the feature
+	FirstUsedAt   time.Time `json:"first_used_at"`
+	LastUsedAt    time.Time `json:"last_used_at"`
+}
+
+// AdminFeatureOrgUsage aggregates one organization's usage of a feature
+type AdminFeatureOrgUsage struct {
+	OrganizationID   uint      `json:"organization_id"`
+	OrganizationName string    `json:"organization_name"`
+	Events           int64     `json:"events"`
+	Users            int64     `json:"users"`
+	LastUsedAt       time.Time `json:"last_used_at"`
+}
+
+// AdminFeatureUsageResponse represents feature usage across organizations
+type AdminFeatureUsageResponse struct {
+	Days       int                 `json:"days"`
+	ActiveOrgs int64               `json:"active_orgs"` // Organizations with any usage event in the window
+	Features   []AdminFeatureUsage `json:"features"`
+}
+
+// AdminFeatureDetailResponse represents one feature's usage per organization
+type AdminFeatureDetailResponse struct {
+	Feature       string                 `json:"feature"`
+	Days          int                    `json:"days"`
+	Organizations []AdminFeatureOrgUsage `json:"organizations"`
+}
+
 // AdminBulkDeleteRequest represents bulk delete request
 type AdminBulkDeleteRequest struct {
 	IDs []uint `json:"ids" binding:"required"`
@@ -510,9 +813,145 @@ type AdminBulkDeleteRequest struct {
 
 // AdminTrendRequest represents request for trend statistics
 type AdminTrendRequest struct {
-	Period    string    `json:"period"` // day, week, month
-	StartDate time.Time `json:"start_date"`
-	EndDate   time.Time `json:"end_date"`
+	Period    string         `json:"period"` // day, week, month, quarter, year (quarter/year follow the fiscal calendar)
+	StartDate time.Time      `json:"start_date"`
+	EndDate   time.Time      `json:"end_date"`
+	OrgID     *uint          `json:"org_id"` // Organization whose calendar is used for grouping (default calendar if nil)
+	Location  *time.Location `json:"-"`      // Zone of the dates and days; nil uses the organization's timezone, or UTC
+}
+
+// WorkScheduleResponse represents a user's work schedule
+type WorkScheduleResponse struct {
+	ID                  uint      `json:"id"`
+	UserID              uint      `json:"user_id"`
+	UserName            string    `json:"user_name"`
+	Email               string    `json:"email"`
+	WorkingDays         []int     `json:"working_days"` // 0=Sunday ... 6=Saturday
+	ExpectedHoursPerDay float64   `json:"expected_hours_per_day"`
+	ExpectedHoursWeek   float64   `json:"expected_hours_week"`
+	Timezone            string    `json:"timezone"`
+	UpdatedBy           *uint     `json:"updated_by"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+// UpdateWorkScheduleRequest creates or replaces a user's work schedule
+type UpdateWorkScheduleRequest struct {
+	WorkingDays         []int   `json:"working_days" binding:"required,min=1,max=7,dive,min=0,max=6"`
+	ExpectedHoursPerDay float64 `json:"expected_hours_per_day" binding:"required,gt=0,max=24"`
+	Timezone            string  `json:"timezone" binding:"omitempty,max=64,iana_timezone"` // Defaults to UTC
+}
+
+// WorkScheduleListResponse represents a paginated list of work schedules
+type WorkScheduleListResponse struct {
+	Schedules  []WorkScheduleResponse  `json:"schedules"`
+	Pagination AdminPaginationResponse `json:"pagination"`
+}
+
+// AdminOvertimeReportParams filters the overtime report
+type AdminOvertimeReportParams struct {
+	StartDate time.Time
+	EndDate   time.Time
+	UserID    uint   // Zero for all users
+	Rule      string // daily, weekly or empty for both
+}
+
+// AdminOvertimeReport lists the overtime found in users' days and weeks
+type AdminOvertimeReport struct {
+	StartDate           string              `json:"start_date"`
+	EndDate             string              `json:"end_date"`
+	DailyOvertimeHours  float64             `json:"daily_overtime_hours"`
+	WeeklyOvertimeHours float64             `json:"weekly_overtime_hours"`
+	Users               []AdminOvertimeUser `json:"users"`
+}
+
+// AdminOvertimeUser is a user's overtime in the report
+type AdminOvertimeUser struct {
+	UserID              uint                  `json:"user_id"`
+	UserName            string                `json:"user_name"`
+	Email               string                `json:"email"`
+	DailyOvertimeHours  float64               `json:"daily_overtime_hours"`
+	WeeklyOvertimeHours float64               `json:"weekly_overtime_hours"`
+	Periods             []AdminOvertimePeriod `json:"periods"`
+}
+
+// AdminOvertimePeriod is a day or week with overtime
+type AdminOvertimePeriod struct {
+	ID            uint       `json:"id"`
+	Rule          string     `json:"rule" example:"daily"`
+	PeriodStart   string     `json:"period_start" example:"2025-03-10"` // Day, or first day of the week
+	ExpectedHours float64    `json:"expected_hours"`
+	TrackedHours  float64    `json:"tracked_hours"`
+	OvertimeHours float64    `json:"overtime_hours"`
+	TimeLogCount  int        `json:"time_log_count"` // Time logs with overtime
+	NotifiedAt    *time.Time `json:"notified_at"`    // When workspace admins were notified
+}
+
+// AdminRetentionPreviewResponse shows what a screenshot retention policy would reclaim
+type AdminRetentionPreviewResponse struct {
+	OrganizationID     uint       `json:"organization_id"`
+	RetentionDays      int        `json:"retention_days"`
+	Cutoff             time.Time  `json:"cutoff"` // Screenshots captured before this are purged
+	ScreenshotCount    int64      `json:"screenshot_count"`
+	ReclaimableBytes   int64      `json:"reclaimable_bytes"`
+	ReclaimableHuman   string     `json:"reclaimable_human"`
+	OldestScreenshotAt *time.Time `json:"oldest_screenshot_at"`
+}
+
+// AdminStorageQuotaResponse shows an organization's screenshot storage against its quota
+type AdminStorageQuotaResponse struct {
+	OrganizationID  uint      `json:"organization_id"`
+	QuotaBytes      *int64    `json:"quota_bytes"` // Nil = unlimited
+	QuotaHuman      string    `json:"quota_human,omitempty"`
+	UsedBytes       int64     `json:"used_bytes"`
+	UsedHuman       string    `json:"used_human"`
+	ScreenshotCount int64     `json:"screenshot_count"`
+	Exceeded        bool      `json:"exceeded"`   // Sync rejects screenshots with quota_exceeded
+	UpdatedAt       time.Time `json:"updated_at"` // When the usage was last counted or changed
+}
+
+// AdminUpdateStorageQuotaRequest sets an organization's screenshot storage quota
+type AdminUpdateStorageQuotaRequest struct {
+	QuotaBytes *int64 `json:"quota_bytes" binding:"omitempty,min=0"` // Null removes the quota
+}
+
+// ============================================================================
+// ADMIN AUDIT LOG DTOs
+// ============================================================================
+
+// AdminAuditLogListParams represents query parameters for listing audit logs
+type AdminAuditLogListParams struct {
+	Page       int        `form:"page"`
+	PageSize   int        `form:"page_size"`
+	UserID     *uint      `form:"user_id"`
+	EntityType string     `form:"entity_type"`
+	EntityID   *uint      `form:"entity_id"`
+	Action     string     `form:"action"`
+	Status     string     `form:"status"`
+	StartDate  *time.Time `form:"start_date"`
+	EndDate    *time.Time `form:"end_date"`
+	SortOrder  string     `form:"sort_order"`
+}
+
+// AdminAuditLogResponse represents an audit log entry in admin responses
+type AdminAuditLogResponse struct {
+	ID         uint                   `json:"id"`
+	UserID     *uint                  `json:"user_id"`
+	UserEmail  string                 `json:"user_email"`
+	UserName   string                 `json:"user_name"`
+	Action     string                 `json:"action"`
+	EntityType string                 `json:"entity_type"`
+	EntityID   *uint                  `json:"entity_id"`
+	IPAddress  string                 `json:"ip_address"`
+	UserAgent  string                 `json:"user_agent"`
+	Details    map[string]interface{} `json:"details"`
+	Status     string                 `json:"status"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+// AdminAuditLogListResponse represents audit log list response
+type AdminAuditLogListResponse struct {
+	AuditLogs  []AdminAuditLogResponse `json:"audit_logs"`
+	Pagination AdminPaginationResponse `json:"pagination"`
 }
 
 // ============================================================================
@@ -532,10 +971,16 @@ type AdminOverviewStats struct {
 	ActiveTasks           int64  `json:"active_tasks"`
 	TotalTimeLogs         int64  `json:"total_timelogs"`
 	TotalDuration         int64  `json:"total_duration"` // seconds
-	WeekDuration          int64  `json:"week_duration"`  // seconds
+	TotalDurationHuman    string `json:"total_duration_human"`
+	WeekDuration          int64  `json:"week_duration"` // seconds
+	WeekDurationHuman     string `json:"week_duration_human"`
 	TotalScreenshots      int64  `json:"total_screenshots"`
-	TotalStorage          int64  `json:"total_storage"` // bytes
+	TotalStorage          int64  `json:"total_storage"` // bytes, counting shared files once per screenshot
 	TotalStorageHuman     string `json:"total_storage_human"`
+	// Identical screenshots sharing one file, and the disk that saves
+	DedupedScreenshots int64  `json:"deduped_screenshots"`
+	DedupeSavedBytes   int64  `json:"dedupe_saved_bytes"`
+	DedupeSavedHuman   string `json:"dedupe_saved_human"`
 }
 
 // AdminTrendStats represents trend statistics
@@ -546,12 +991,13 @@ type AdminTrendStats struct {
 
 // AdminDailyStat represents daily statistics
 type AdminDailyStat struct {
-	Date        string `json:"date"`
-	TotalUsers  int64  `json:"total_users,omitempty"`
-	NewUsers    int64  `json:"new_users,omitempty"`
-	Duration    int64  `json:"duration,omitempty"`
-	TimeLogs    int64  `json:"timelogs,omitempty"`
-	Screenshots int64  `json:"screenshots,omitempty"`
+	Date          string `json:"date"`
+	TotalUsers    int64  `json:"total_users,omitempty"`
+	NewUsers      int64  `json:"new_users,omitempty"`
+	Duration      int64  `json:"duration,omitempty"`
+	DurationHuman string `json:"duration_human,omitempty"`
+	TimeLogs      int64  `json:"timelogs,omitempty"`
+	Screenshots   int64  `json:"screenshots,omitempty"`
 }
 
 // AdminUserStats represents user statistics
@@ -561,12 +1007,31 @@ type AdminUserStats struct {
 
 // AdminUserPerformance represents user performance data
 type AdminUserPerformance struct {
-	UserID        uint   `json:"user_id"`
-	UserName      string `json:"user_name"`
-	Email         string `json:"email"`
-	TotalDuration int64  `json:"total_duration"`
-	TaskCount     int64  `json:"task_count"`
-	Rank          int    `json:"rank"`
+	UserID             uint   `json:"user_id"`
+	UserName           string `json:"user_name"`
+	Email              string `json:"email"`
+	TotalDuration      int64  `json:"total_duration"`
+	TotalDurationHuman string `json:"total_duration_human" gorm:"-"`
+	TaskCount          int64  `json:"task_count"`
+	Rank               int    `json:"rank"`
+
+	// Tracked vs expected hours over the schedule window
+	ScheduleStatus string   `json:"schedule_status" gorm:"-"` // under, on_track, over, unscheduled
+	TrackedHours   float64  `json:"tracked_hours" gorm:"-"`
+	ExpectedHours  *float64 `json:"expected_hours" gorm:"-"` // Nil without a work schedule
+	VarianceHours  *float64 `json:"variance_hours" gorm:"-"` // Tracked minus expected
+	LeaveDays      int      `json:"leave_days" gorm:"-"`     // Working days on approved leave, excluded from expected
+	HolidayDays    int      `json:"holiday_days" gorm:"-"`   // Scheduled weekdays that are organization holidays, excluded from expected
+}
+
+// AdminUserPerformanceParams represents query parameters for the user performance stats
+type AdminUserPerformanceParams struct {
+	Limit       int
+	Period      string    // Ranked period: all (default), week, month or custom (StartDate to EndDate)
+	StartDate   time.Time // Schedule window, inclusive dates
+	EndDate     time.Time
+	OrgID       *uint // Rank time in this organization only
+	WorkspaceID *uint // Rank time in this workspace only
 }
 
 // AdminOrgStats represents organization statistics
@@ -583,21 +1048,23 @@ type AdminOrgSizeCategory struct {
 
 // AdminTopWorkspace represents top workspace data
 type AdminTopWorkspace struct {
-	WorkspaceID      uint   `json:"workspace_id"`
-	Name             string `json:"name"`
-	OrganizationName string `json:"organization_name"`
-	TotalDuration    int64  `json:"total_duration"`
-	MemberCount      int64  `json:"member_count"`
+	WorkspaceID        uint   `json:"workspace_id"`
+	Name               string `json:"name"`
+	OrganizationName   string `json:"organization_name"`
+	TotalDuration      int64  `json:"total_duration"`
+	TotalDurationHuman string `json:"total_duration_human" gorm:"-"`
+	MemberCount        int64  `json:"member_count"`
 }
 
 // AdminActivityStats represents activity statistics
 type AdminActivityStats struct {
-	TodayDuration    int64             `json:"today_duration"`
-	TodayActiveUsers int64             `json:"today_active_users"`
-	TodayScreenshots int64             `json:"today_screenshots"`
-	ActivityByHour   []AdminHourlyStat `json:"activity_by_hour"`
-	PeakHour         int               `json:"peak_hour"`
-	PeakHourCount    int64             `json:"peak_hour_count"`
+	TodayDuration      int64             `json:"today_duration"`
+	TodayDurationHuman string            `json:"today_duration_human"`
+	TodayActiveUsers   int64             `json:"today_active_users"`
+	TodayScreenshots   int64             `json:"today_screenshots"`
+	ActivityByHour     []AdminHourlyStat `json:"activity_by_hour"`
+	PeakHour           int               `json:"peak_hour"`
+	PeakHourCount      int64             `json:"peak_hour_count"`
 }
 
 // AdminHourlyStat represents hourly statistics
@@ -626,3 +1093,81 @@ type AdminListResponse struct {
 	Data       interface{}             `json:"data"`
 	Pagination AdminPaginationResponse `json:"pagination"`
 }
+
+// ============================================================================
+// ADMIN MAINTENANCE DTOs
+// ============================================================================
+
+// AdminPurgeRequest represents a soft-deleted data purge request
+type AdminPurgeRequest struct {
+	OlderThanDays *int `json:"older_than_days" binding:"omitempty,min=1"` // Defaults to SOFT_DELETE_PURGE_AFTER_DAYS
+	DryRun        bool `json:"dry_run"`
+	Async         bool `json:"async"` // Run in the background and return an operation to poll
+}
+
+// AdminPurgeResponse reports what a purge removed (or would remove)
+type AdminPurgeResponse struct {
+	DryRun        bool                    `json:"dry_run"`
+	OlderThanDays int                     `json:"older_than_days"`
+	Cutoff        time.Time               `json:"cutoff"` // Rows soft-deleted before this are purged
+	Tables        []AdminPurgeTableResult `json:"tables"`
+	TotalRows     int64                   `json:"total_rows"`
+	FilesDeleted  int64                   `json:"files_deleted"`
+	BytesFreed    int64                   `json:"bytes_freed"`
+}
+
+// AdminPurgeTableResult reports the purge of one table
+type AdminPurgeTableResult struct {
+	Table   string `json:"table"`
+	Rows    int64  `json:"rows"`    // Rows purged, or purgeable in a dry run
+	Skipped int64  `json:"skipped"` // Rows still referenced by live data
+}
+
+// ============================================================================
+// ADMIN FEATURE FLAG DTOs
+// ============================================================================
+
+// CreateFeatureFlagRequest represents a request to define a feature flag
+type CreateFeatureFlagRequest struct {
+	Key            string `json:"key" binding:"required,max=100,flag_key"`
+	Description    string `json:"description" binding:"max=2000"`
+	Enabled        bool   `json:"enabled"`
+	RolloutPercent int    `json:"rollout_percent" binding:"min=0,max=100"`
+}
+
+// UpdateFeatureFlagRequest represents a feature flag update request
+type UpdateFeatureFlagRequest struct {
+	Description    *string `json:"description" binding:"omitempty,max=2000"`
+	Enabled        *bool   `json:"enabled"`
+	RolloutPercent *int    `json:"rollout_percent" binding:"omitempty,min=0,max=100"`
+}
+
+// SetFeatureFlagOverrideRequest turns a flag on or off for an organization
+// or a user; exactly one of them must be set
+type SetFeatureFlagOverrideRequest struct {
+	OrganizationID *uint `json:"organization_id"`
+	UserID         *uint `json:"user_id"`
+	Enabled        bool  `json:"enabled"`
+}
+
+// FeatureFlagOverrideResponse represents a feature flag override
+type FeatureFlagOverrideResponse struct {
+	ID             uint      `json:"id"`
+	OrganizationID *uint     `json:"organization_id,omitempty"`
+	UserID         *uint     `json:"user_id,omitempty"`
+	Enabled        bool      `json:"enabled"`
+	CreatedBy      uint      `json:"created_by"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// FeatureFlagResponse represents a feature flag with its overrides
+type FeatureFlagResponse struct {
+	ID             uint                          `json:"id"`
+	Key            string                        `json:"key"`
+	Description    string                        `json:"description"`
+	Enabled        bool                          `json:"enabled"`
+	RolloutPercent int                           `json:"rollout_percent"`
+	Overrides      []FeatureFlagOverrideResponse `json:"overrides"`
+	CreatedAt      time.Time                     `json:"created_at"`
+	UpdatedAt      time.Time                     `json:"updated_at"`
+}
@@ -1,6 +1,9 @@
 package dto
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // RegisterRequest represents user registration request
 type RegisterRequest struct {
@@ -12,8 +15,8 @@ type RegisterRequest struct {
 	// Organization options - one of these must be provided
 	// Option 1: Create new organization (user becomes owner)
 	CreateOrganization bool   `json:"create_organization"`
-	OrganizationName   string `json:"organization_name"` // Required if CreateOrganization is true
-	OrganizationSlug   string `json:"organization_slug"` // Optional, auto-generated from name if empty
+	OrganizationName   string `json:"organization_name"`                                  // Required if CreateOrganization is true
+	OrganizationSlug   string `json:"organization_slug" binding:"omitempty,max=255,slug"` // Optional, auto-generated from name if empty
 
 	// Option 2: Join existing organization via invite code
 	InviteCode string `json:"invite_code"` // Organization invite code
@@ -39,6 +42,7 @@ type LoginResponse struct {
 // UserResponse represents user data in responses
 type UserResponse struct {
 	ID          uint       `json:"id"`
+	UUID        string     `json:"uuid"`
 	Email       string     `json:"email"`
 	FirstName   string     `json:"first_name"`
 	LastName    string     `json:"last_name"`
@@ -46,12 +50,18 @@ type UserResponse struct {
 	SystemRole  string     `json:"system_role"`
 	IsActive    bool       `json:"is_active"`
 	LastLoginAt *time.Time `json:"last_login_at"`
+	Timezone    string     `json:"timezone,omitempty"` // IANA name; personal reports group days here
 	CreatedAt   time.Time  `json:"created_at"`
 }
 
+// UpdateTimezoneRequest sets the timezone personal reports default to
+type UpdateTimezoneRequest struct {
+	Timezone string `json:"timezone" binding:"required,max=64,iana_timezone" example:"Europe/Berlin"`
+}
+
 // PresenceHeartbeatRequest represents a presence heartbeat from client
 type PresenceHeartbeatRequest struct {
-	Status   string `json:"status" binding:"required"` // working, idle
+	Status   string `json:"status" binding:"required"` // working, online, idle, or offline when the app closes
 	DeviceID *uint  `json:"device_id"`
 }
 
@@ -67,37 +77,158 @@ type CreateTaskRequest struct {
 	Title          string `json:"title" binding:"required"`
 	Description    string `json:"description"`
 	Priority       int    `json:"priority"`
-	Color          string `json:"color"`
+	Color          string `json:"color" binding:"omitempty,hex_color"`
 	IsManual       bool   `json:"is_manual"`       // true: manually created, false: auto from time tracker
 	OrganizationID *uint  `json:"organization_id"` // Organization ID (required for workspace context)
 	WorkspaceID    *uint  `json:"workspace_id"`    // Workspace ID the task belongs to
+	CostCenter     string `json:"cost_center"`     // Optional accounting cost center
+	ProjectCode    string `json:"project_code"`    // Optional accounting project code
+	AutoAssign     bool   `json:"auto_assign"`     // Assign with the workspace's assignment rules instead of to the creator
+	AssigneeID     *uint  `json:"assignee_id"`     // Assign to a workspace member instead of the creator
+	ParentTaskID   *uint  `json:"parent_task_id"`  // Create as a subtask
+
+	DueDate         string   `json:"due_date"`         // Optional, YYYY-MM-DD
+	EstimateSeconds int64    `json:"estimate_seconds"` // Optional time estimate
+	Tags            []string `json:"tags"`             // Optional labels
 }
 
 // UpdateTaskRequest represents task update request
 type UpdateTaskRequest struct {
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Status      string `json:"status"`
-	Priority    int    `json:"priority"`
-	Color       string `json:"color"`
-	IsManual    *bool  `json:"is_manual"` // Pointer to allow optional update
+	Title        string  `json:"title"`
+	Description  string  `json:"description"`
+	Status       string  `json:"status"`
+	Priority     int     `json:"priority"`
+	Color        string  `json:"color" binding:"omitempty,hex_color"`
+	IsManual     *bool   `json:"is_manual"`      // Pointer to allow optional update
+	CostCenter   *string `json:"cost_center"`    // Pointer so an empty string clears the tag
+	ProjectCode  *string `json:"project_code"`   // Pointer so an empty string clears the tag
+	AssigneeID   *uint   `json:"assignee_id"`    // Hand the task to another workspace member
+	ParentTaskID *uint   `json:"parent_task_id"` // Move under another task; 0 makes it top-level
+
+	DueDate         *string   `json:"due_date"`         // YYYY-MM-DD; an empty string clears it
+	EstimateSeconds *int64    `json:"estimate_seconds"` // 0 clears the estimate
+	Tags            *[]string `json:"tags"`             // Replaces the labels; an empty list clears them
+
+	Version *uint `json:"version"` // Version the edit is based on, or the If-Match header; nil skips the check
+}
+
+// AddTaskAssigneeRequest assigns a workspace member to a task
+type AddTaskAssigneeRequest struct {
+	UserID uint `json:"user_id" binding:"required"`
+}
+
+// TaskAssigneeResponse is a user a task is assigned to. The owner comes
+// first; the others were added as additional assignees.
+type TaskAssigneeResponse struct {
+	UserID     uint       `json:"user_id"`
+	Email      string     `json:"email"`
+	Name       string     `json:"name"`
+	Owner      bool       `json:"owner"`
+	AssignedBy *uint      `json:"assigned_by"` // Nil for the owner
+	AssignedAt *time.Time `json:"assigned_at"` // Nil for the owner
+}
+
+// CreateTaskCommentRequest posts a comment on a task
+type CreateTaskCommentRequest struct {
+	Body       string `json:"body" binding:"required,max=10000"`
+	MentionIDs []uint `json:"mention_ids"` // Users to notify; they must be able to see the task
+}
+
+// UpdateTaskCommentRequest edits a comment; users mentioned for the first
+// time are notified
+type UpdateTaskCommentRequest struct {
+	Body       string `json:"body" binding:"required,max=10000"`
+	MentionIDs []uint `json:"mention_ids"`
+}
+
+// TaskCommentResponse is a comment in a task's discussion
+type TaskCommentResponse struct {
+	ID          uint                     `json:"id"`
+	TaskID      uint                     `json:"task_id"`
+	AuthorID    uint                     `json:"author_id"`
+	AuthorName  string                   `json:"author_name"`
+	AuthorEmail string                   `json:"author_email"`
+	Body        string                   `json:"body"`
+	MentionIDs  []uint                   `json:"mention_ids"`
+	Attachments []TaskAttachmentResponse `json:"attachments"`
+	Edited      bool                     `json:"edited"`
+	CreatedAt   time.Time                `json:"created_at"`
+	UpdatedAt   time.Time                `json:"updated_at"`
+}
+
+// TaskAttachmentUploadRequest represents the form fields of an attachment upload
+type TaskAttachmentUploadRequest struct {
+	CommentID *uint `form:"comment_id"` // Attach the file to one of your comments on the task
+}
+
+// TaskAttachmentResponse is a file attached to a task
+type TaskAttachmentResponse struct {
+	ID          uint      `json:"id"`
+	TaskID      uint      `json:"task_id"`
+	CommentID   *uint     `json:"comment_id"`
+	UploadedBy  uint      `json:"uploaded_by"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	FileSize    int64     `json:"file_size"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// TaskFilter narrows task lists by label and due date
+type TaskFilter struct {
+	Tag     string
+	DueFrom *time.Time
+	DueTo   *time.Time
+	Overdue bool // Active tasks whose due date has passed
+}
+
+// TaskTreeNode is a task with its subtasks. TotalSeconds rolls up the time
+// tracked on the task and everything below it.
+type TaskTreeNode struct {
+	ID              uint           `json:"id"`
+	Title           string         `json:"title"`
+	Status          string         `json:"status"`
+	UserID          uint           `json:"user_id"`
+	ParentTaskID    *uint          `json:"parent_task_id"`
+	EstimateSeconds int64          `json:"estimate_seconds"`
+	TrackedSeconds  int64          `json:"tracked_seconds"` // On the task itself
+	TotalSeconds    int64          `json:"total_seconds"`   // Including subtasks
+	Subtasks        []TaskTreeNode `json:"subtasks"`
+}
+
+// TaskEstimateResponse compares the time tracked on a task with its estimate
+type TaskEstimateResponse struct {
+	EstimateSeconds  int64   `json:"estimate_seconds"`
+	TrackedSeconds   int64   `json:"tracked_seconds"`   // Including subtasks
+	SubtaskSeconds   int64   `json:"subtask_seconds"`   // Tracked on subtasks
+	RemainingSeconds int64   `json:"remaining_seconds"` // Negative once over the estimate
+	PercentUsed      float64 `json:"percent_used"`      // 0 without an estimate
+	OverEstimate     bool    `json:"over_estimate"`
 }
 
 // TaskWithStats represents a task with aggregated statistics
 type TaskWithStats struct {
-	ID              uint      `json:"id"`
-	Title           string    `json:"title"`
-	Description     string    `json:"description"`
-	Status          string    `json:"status"`
-	Priority        int       `json:"priority"`
-	Color           string    `json:"color"`
-	IsManual        bool      `json:"is_manual"`        // true: manually created, false: auto from time tracker
-	OrganizationID  *uint     `json:"organization_id"`  // Organization ID
-	WorkspaceID     *uint     `json:"workspace_id"`     // Workspace ID the task belongs to
-	Duration        int64     `json:"duration"`         // Total duration in seconds
-	ScreenshotCount int64     `json:"screenshot_count"` // Total screenshots
-	CreatedAt       time.Time `json:"created_at"`
-	UpdatedAt       time.Time `json:"updated_at"`
+	ID              uint       `json:"id"`
+	UUID            string     `json:"uuid"`
+	Version         uint       `json:"version"`
+	Title           string     `json:"title"`
+	Description     string     `json:"description"`
+	Status          string     `json:"status"`
+	Priority        int        `json:"priority"`
+	Color           string     `json:"color"`
+	IsManual        bool       `json:"is_manual"`       // true: manually created, false: auto from time tracker
+	OrganizationID  *uint      `json:"organization_id"` // Organization ID
+	WorkspaceID     *uint      `json:"workspace_id"`    // Workspace ID the task belongs to
+	CostCenter      string     `json:"cost_center"`     // Accounting cost center
+	ProjectCode     string     `json:"project_code"`    // Accounting project code
+	CreatedBy       *uint      `json:"created_by"`      // Who created the task
+	ParentTaskID    *uint      `json:"parent_task_id"`  // Set on subtasks
+	DueDate         *time.Time `json:"due_date"`
+	EstimateSeconds int64      `json:"estimate_seconds"` // 0: no estimate
+	Tags            []string   `json:"tags"`
+	Duration        int64      `json:"duration"`         // Total duration in seconds
+	ScreenshotCount int64      `json:"screenshot_count"` // Total screenshots
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
 }
 
 // StartTimeLogRequest represents starting a time log
@@ -108,6 +239,32 @@ type StartTimeLogRequest struct {
 	Notes    string `json:"notes"`
 }
 
+// CreateManualTimeLogRequest represents adding a past time entry by hand
+type CreateManualTimeLogRequest struct {
+	TaskID      *uint     `json:"task_id"`      // The entry takes the task's workspace
+	WorkspaceID *uint     `json:"workspace_id"` // For entries without a task
+	StartTime   time.Time `json:"start_time" binding:"required"`
+	EndTime     time.Time `json:"end_time" binding:"required"`
+	Notes       string    `json:"notes" binding:"max=5000"`
+}
+
+// CurrentTimerResponse is the user's authoritative running or paused session
+type CurrentTimerResponse struct {
+	TimeLogID   uint       `json:"time_log_id"`
+	LocalID     string     `json:"local_id"`
+	TaskID      *uint      `json:"task_id"`
+	TaskTitle   string     `json:"task_title"`
+	WorkspaceID *uint      `json:"workspace_id"`
+	DeviceID    *uint      `json:"device_id"` // Device that started or last synced the session
+	Status      string     `json:"status"`    // running, paused
+	StartTime   time.Time  `json:"start_time"`
+	PausedAt    *time.Time `json:"paused_at"`
+	PausedTotal int64      `json:"paused_total"`
+	Elapsed     int64      `json:"elapsed"` // Tracked seconds as of server_time, excluding pauses
+	Version     int        `json:"version"`
+	ServerTime  time.Time  `json:"server_time"`
+}
+
 // StopTimeLogRequest represents stopping a time log
 type StopTimeLogRequest struct {
 	LocalID   string `json:"local_id"`
@@ -151,6 +308,26 @@ type SyncTimeLogItem struct {
 	Status         string     `json:"status"`
 	Notes          string     `json:"notes"`
 	TaskTitle      string     `json:"task_title"` // Task title when stopped
+
+	// Work/break cycles. Breaks replace the time log's stored breaks when
+	// sent; omit them to keep the server's. BreakTotal defaults to the sum of
+	// the breaks' durations.
+	BreakTotal int64              `json:"break_total"`
+	Breaks     []SyncTimeLogBreak `json:"breaks" binding:"omitempty,max=500,dive"`
+
+	// Conflict detection: the server version this edit is based on (from
+	// time_log_versions of an earlier sync) and when the device made the edit
+	BaseVersion *int       `json:"base_version"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+}
+
+// SyncTimeLogBreak represents a break within a synced time log
+type SyncTimeLogBreak struct {
+	LocalID   string     `json:"local_id" binding:"required,max=100"`
+	Kind      string     `json:"kind" binding:"omitempty,oneof=short long other"` // Defaults to short
+	StartTime time.Time  `json:"start_time" binding:"required"`
+	EndTime   *time.Time `json:"end_time"` // Nil while the break is running
+	Duration  int64      `json:"duration" binding:"min=0"`
 }
 
 // SyncScreenshotItem represents a screenshot item to sync
@@ -170,6 +347,17 @@ type SyncScreenshotItem struct {
 	IsEncrypted    bool      `json:"is_encrypted"`
 	Checksum       string    `json:"checksum"`
 	Base64Data     string    `json:"base64_data"` // For file upload
+
+	// Encryption envelope, required when IsEncrypted; the fingerprint must be
+	// a key registered for the organization
+	KeyFingerprint string `json:"key_fingerprint"`
+	WrappedKey     string `json:"wrapped_key"`
+	Nonce          string `json:"nonce"`
+
+	// Foreground window at capture time, checked against the organization's
+	// capture exclusion rules; never stored
+	AppName     string `json:"app_name"`
+	WindowTitle string `json:"window_title"`
 }
 
 // SyncDeviceInfoItem represents device info to sync
@@ -190,14 +378,79 @@ type BatchSyncResponse struct {
 	ScreenshotsSync SyncResult          `json:"screenshots_sync"`
 	DeviceInfo      *DeviceInfoResponse `json:"device_info"`
 	SyncedAt        time.Time           `json:"synced_at"`
+
+	// Current server version per time log LocalID; send it back as base_version
+	TimeLogVersions map[string]int         `json:"time_log_versions,omitempty"`
+	Conflicts       []SyncConflictResponse `json:"conflicts,omitempty"`
+}
+
+// SyncConflictResponse represents a time log edited on two devices
+type SyncConflictResponse struct {
+	ID            uint             `json:"id"`
+	TimeLogID     uint             `json:"time_log_id"`
+	LocalID       string           `json:"local_id"`
+	Policy        string           `json:"policy"`     // last_write_wins, server_wins, manual
+	Status        string           `json:"status"`     // pending, resolved
+	Resolution    string           `json:"resolution"` // client_applied, server_kept; empty while pending
+	ServerVersion int              `json:"server_version"`
+	ClientVersion *int             `json:"client_version"`
+	Server        SyncTimeLogState `json:"server"`
+	Client        SyncTimeLogState `json:"client"`
+	CreatedAt     time.Time        `json:"created_at"`
+	ResolvedAt    *time.Time       `json:"resolved_at"`
+}
+
+// SyncTimeLogState represents the conflicting fields of one side of a sync conflict
+type SyncTimeLogState struct {
+	EndTime     *time.Time `json:"end_time"`
+	Duration    int64      `json:"duration"`
+	PausedTotal int64      `json:"paused_total"`
+	Status      string     `json:"status"`
+	Notes       string     `json:"notes"`
+	TaskTitle   string     `json:"task_title"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+}
+
+// ResolveSyncConflictRequest represents a manual sync conflict resolution
+type ResolveSyncConflictRequest struct {
+	Keep string `json:"keep" binding:"required,oneof=client server"`
 }
 
 // SyncResult represents sync result for a data type
 type SyncResult struct {
-	Total   int      `json:"total"`
-	Success int      `json:"success"`
-	Failed  int      `json:"failed"`
-	Errors  []string `json:"errors,omitempty"`
+	Total    int           `json:"total"`
+	Success  int           `json:"success"`
+	Failed   int           `json:"failed"`
+	Errors   []string      `json:"errors,omitempty"` // Messages of ItemErrors, kept for older clients
+	CapFlags []SyncCapFlag `json:"cap_flags,omitempty"`
+
+	ItemErrors []SyncItemError `json:"item_errors,omitempty"`
+
+	// Screenshots matching a capture exclusion rule or captured in a private
+	// interval; counted as success so the agent drops its local copy, but not
+	// stored
+	Discarded []string `json:"discarded,omitempty"`
+
+	// Running time logs the server stopped because a newer timer was started
+	// on another device; stop them locally too
+	StoppedTimers []string `json:"stopped_timers,omitempty"`
+}
+
+// SyncItemError reports why one item failed to sync
+type SyncItemError struct {
+	LocalID string `json:"local_id"`
+	Code    string `json:"code"` // invalid_data, hour_cap_blocked, screenshots_disabled, version_conflict, invoiced, task_required, period_locked, quota_exceeded, storage_error, database_error, batch_aborted
+	Message string `json:"message"`
+}
+
+// SyncCapFlag reports a time log flagged or blocked by the member's weekly hour cap
+type SyncCapFlag struct {
+	LocalID       string  `json:"local_id"`
+	WorkspaceID   uint    `json:"workspace_id"`
+	Status        string  `json:"status"` // approaching, exceeded
+	Blocked       bool    `json:"blocked"`
+	WeeklyHourCap float64 `json:"weekly_hour_cap"`
+	LoggedHours   float64 `json:"logged_hours"`
 }
 
 // DeviceInfoResponse represents device info in responses
@@ -212,11 +465,67 @@ type DeviceInfoResponse struct {
 	IsActive   bool       `json:"is_active"`
 }
 
-// ErrorResponse represents an error response
+// DeviceConfigResponse is the configuration document the desktop app polls
+// for a device, scoped to the workspace it tracks time in. Without a
+// workspace the server defaults apply.
+type DeviceConfigResponse struct {
+	DeviceUUID     string `json:"device_uuid"`
+	OrganizationID *uint  `json:"organization_id"`
+	WorkspaceID    *uint  `json:"workspace_id"`
+
+	ScreenshotsDisabled       bool   `json:"screenshots_disabled"`
+	ScreenshotIntervalSeconds int    `json:"screenshot_interval_seconds"`
+	ScreenshotBlurMode        string `json:"screenshot_blur_mode"`
+	ScreenshotMonitors        int    `json:"screenshot_monitors"` // 0 for all
+	IdleThresholdSeconds      int    `json:"idle_threshold_seconds"`
+
+	Features          map[string]bool                `json:"features"`                  // Desktop features and whether the app may use them
+	CaptureExclusions []CaptureExclusionRuleResponse `json:"capture_exclusions"`        // Skip capture when any matches
+	DeviceApproval    string                         `json:"device_approval,omitempty"` // pending, approved or rejected when the organization requires device approval
+
+	PollIntervalSeconds int `json:"poll_interval_seconds"` // How often to check this document for changes
+}
+
+// FeaturesResponse represents the feature flags evaluated for the user
+type FeaturesResponse struct {
+	OrganizationID *uint           `json:"organization_id,omitempty"`
+	Features       map[string]bool `json:"features"` // Flag key to whether it is on
+}
+
+// ReviewDeviceApprovalRequest represents an admin's decision on a device
+type ReviewDeviceApprovalRequest struct {
+	Note string `json:"note" binding:"max=2000"`
+}
+
+// DeviceApprovalListParams represents query parameters for device approval lists
+type DeviceApprovalListParams struct {
+	Page    int
+	PerPage int
+	Status  string
+	UserID  *uint
+}
+
+// DeviceApprovalResponse represents a device's approval in an organization
+type DeviceApprovalResponse struct {
+	ID             uint                `json:"id"`
+	OrganizationID uint                `json:"organization_id"`
+	DeviceID       uint                `json:"device_id"`
+	Device         *DeviceInfoResponse `json:"device,omitempty"`
+	UserID         uint                `json:"user_id"`
+	User           *UserResponse       `json:"user,omitempty"`
+	Status         string              `json:"status" example:"pending"`
+	ReviewedBy     *uint               `json:"reviewed_by"`
+	Reviewer       *UserResponse       `json:"reviewer,omitempty"`
+	ReviewedAt     *time.Time          `json:"reviewed_at"`
+	ReviewNote     string              `json:"review_note"`
+	CreatedAt      time.Time           `json:"created_at"`
+}
+
+// ErrorResponse is the error envelope every endpoint responds with
 type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message"`
-	Code    int    `json:"code"`
+	Code    string      `json:"code"` // Machine-readable, e.g. not_found or validation_failed
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
 }
 
 // SuccessResponse represents a success response
@@ -250,6 +559,17 @@ type PaginationResponse struct {
 	TotalPages int         `json:"total_pages"`
 }
 
+// ForgotPasswordRequest starts a password reset
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ResetPasswordRequest sets a new password with a reset token from email
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,min=8"`
+}
+
 // RefreshTokenRequest represents refresh token request
 type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" binding:"required" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
@@ -258,6 +578,7 @@ type RefreshTokenRequest struct {
 // TimeLogResponse represents time log in responses
 type TimeLogResponse struct {
 	ID          uint       `json:"id" example:"1"`
+	UUID        string     `json:"uuid" example:"0b8e9c3a-3f6d-4c52-9d0e-6f1f8e2a7b41"`
 	UserID      uint       `json:"user_id" example:"1"`
 	TaskID      *uint      `json:"task_id" example:"1"`
 	LocalID     string     `json:"local_id" example:"550e8400-e29b-41d4-a716-446655440000"`
@@ -267,7 +588,9 @@ type TimeLogResponse struct {
 	ResumedAt   *time.Time `json:"resumed_at"`
 	Duration    int64      `json:"duration" example:"28800"`
 	PausedTotal int64      `json:"paused_total" example:"3600"`
+	BreakTotal  int64      `json:"break_total" example:"1500"`
 	Status      string     `json:"status" example:"stopped"`
+	CapStatus   string     `json:"cap_status" example:""`
 	TaskTitle   string     `json:"task_title" example:"Working on feature X"`
 	Notes       string     `json:"notes" example:"Completed the main functionality"`
 	CreatedAt   time.Time  `json:"created_at"`
@@ -277,6 +600,7 @@ type TimeLogResponse struct {
 // ScreenshotResponse represents screenshot in responses
 type ScreenshotResponse struct {
 	ID           uint      `json:"id" example:"1"`
+	UUID         string    `json:"uuid" example:"0b8e9c3a-3f6d-4c52-9d0e-6f1f8e2a7b41"`
 	UserID       uint      `json:"user_id" example:"1"`
 	TimeLogID    *uint     `json:"time_log_id" example:"1"`
 	TaskID       *uint     `json:"task_id" example:"1"`
@@ -299,11 +623,297 @@ type ScreenshotStats struct {
 	ThisMonthCount int64 `json:"this_month_count" example:"350"`
 }
 
+// CreateScreenshotDeletionRequest represents a user's request to delete a screenshot
+type CreateScreenshotDeletionRequest struct {
+	Reason string `json:"reason" binding:"required,min=10,max=2000" example:"Captured my banking details by accident"`
+}
+
+// ReviewScreenshotDeletionRequest represents a manager's decision on a deletion request
+type ReviewScreenshotDeletionRequest struct {
+	Note string `json:"note" binding:"max=2000"` // Required when rejecting
+}
+
+// ScreenshotDeletionRequestListParams represents query parameters for deletion request lists
+type ScreenshotDeletionRequestListParams struct {
+	Page    int
+	PerPage int
+	Status  string
+	UserID  *uint
+}
+
+// ScreenshotDeletionRequestResponse represents a screenshot deletion request in responses
+type ScreenshotDeletionRequestResponse struct {
+	ID             uint          `json:"id"`
+	ScreenshotID   uint          `json:"screenshot_id"`
+	UserID         uint          `json:"user_id"`
+	User           *UserResponse `json:"user,omitempty"`
+	OrganizationID uint          `json:"organization_id"`
+	WorkspaceID    *uint         `json:"workspace_id"`
+	Reason         string        `json:"reason"`
+	Status         string        `json:"status" example:"pending"`
+	FileName       string        `json:"file_name"`
+	CapturedAt     time.Time     `json:"captured_at"`
+	ReviewedBy     *uint         `json:"reviewed_by"`
+	Reviewer       *UserResponse `json:"reviewer,omitempty"`
+	ReviewedAt     *time.Time    `json:"reviewed_at"`
+	ReviewNote     string        `json:"review_note"`
+	CreatedAt      time.Time     `json:"created_at"`
+}
+
 // TimeLogStats represents time tracking statistics
 type TimeLogStats struct {
-	TotalTimeSeconds int64   `json:"total_time_seconds" example:"144000"`
-	TotalTimeHours   float64 `json:"total_time_hours" example:"40.0"`
-	SessionCount     int     `json:"session_count" example:"20"`
-	StartDate        string  `json:"start_date" example:"2024-01-01"`
-	EndDate          string  `json:"end_date" example:"2024-01-07"`
+	TotalTimeSeconds  int64   `json:"total_time_seconds" example:"144000"`
+	TotalTimeHours    float64 `json:"total_time_hours" example:"40.0"`
+	BreakTimeSeconds  int64   `json:"break_time_seconds" example:"9000"`
+	PausedTimeSeconds int64   `json:"paused_time_seconds" example:"1800"`
+	SessionCount      int     `json:"session_count" example:"20"`
+	StartDate         string  `json:"start_date" example:"2024-01-01"`
+	EndDate           string  `json:"end_date" example:"2024-01-07"`
+}
+
+// TimeLogCommitResponse represents a commit, pull/merge request or issue
+// linked to a time log
+type TimeLogCommitResponse struct {
+	ID         uint      `json:"id"`
+	TimeLogID  uint      `json:"time_log_id"`
+	TaskID     *uint     `json:"task_id"`
+	Provider   string    `json:"provider"`   // github, gitlab
+	Repository string    `json:"repository"` // owner/repo
+	Kind       string    `json:"kind"`       // commit, pull_request, merge_request, issue
+	Ref        string    `json:"ref"`        // Commit SHA or number
+	URL        string    `json:"url"`
+	Title      string    `json:"title,omitempty"`
+	Source     string    `json:"source"` // notes, webhook, manual
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TimeLogEditResponse represents an edit in a time log's history, with its
+// values before and after
+type TimeLogEditResponse struct {
+	ID        uint            `json:"id"`
+	TimeLogID uint            `json:"time_log_id"`
+	Source    string          `json:"source"`    // admin, sync, conflict_resolution
+	EditedBy  *uint           `json:"edited_by"` // Nil when edited by the system
+	Editor    *UserResponse   `json:"editor,omitempty"`
+	Changed   []string        `json:"changed"` // Names of the values that changed
+	Before    json.RawMessage `json:"before"`
+	After     json.RawMessage `json:"after"`
+	EditedAt  time.Time       `json:"edited_at"`
+}
+
+// LinkTimeLogCommitRequest links a GitHub or GitLab URL to a time log
+type LinkTimeLogCommitRequest struct {
+	URL string `json:"url" binding:"required,url,max=500" example:"https://github.com/acme/app/pull/123"`
+}
+
+// CreateVCSRepositoryRequest links a repository to a workspace
+type CreateVCSRepositoryRequest struct {
+	Provider  string `json:"provider" binding:"required,oneof=github gitlab"`
+	FullName  string `json:"full_name" binding:"required,max=255" example:"acme/app"`
+	BaseURL   string `json:"base_url" binding:"omitempty,url,max=255"` // Self-hosted GitLab or GitHub Enterprise; defaults to the public site
+	IsDefault bool   `json:"is_default"`                               // Resolve bare "#123" refs in notes against this repository
+}
+
+// VCSRepositoryResponse represents a workspace repository
+type VCSRepositoryResponse struct {
+	ID            uint      `json:"id"`
+	WorkspaceID   uint      `json:"workspace_id"`
+	Provider      string    `json:"provider"`
+	BaseURL       string    `json:"base_url"`
+	FullName      string    `json:"full_name"`
+	IsDefault     bool      `json:"is_default"`
+	WebhookURL    string    `json:"webhook_url"`              // Path of the push webhook to configure in GitHub/GitLab
+	WebhookSecret string    `json:"webhook_secret,omitempty"` // Only returned on creation
+	CreatedBy     uint      `json:"created_by"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// VCSWebhookResponse summarizes a processed push webhook
+type VCSWebhookResponse struct {
+	Linked  int `json:"linked"`  // Commits linked to a time log
+	Skipped int `json:"skipped"` // Commits whose author was not tracking time in the workspace
+}
+
+// CalendarFeedResponse represents the user's ICS calendar feed
+type CalendarFeedResponse struct {
+	Enabled       bool       `json:"enabled"`
+	URL           string     `json:"url,omitempty"` // Private; anyone with it can read the feed
+	LastFetchedAt *time.Time `json:"last_fetched_at,omitempty"`
+	CreatedAt     *time.Time `json:"created_at,omitempty"`
+}
+
+// GoogleCalendarOAuthURLResponse is the Google consent page for calendar access
+type GoogleCalendarOAuthURLResponse struct {
+	URL string `json:"url"`
+}
+
+// CompleteGoogleCalendarOAuthRequest carries the code and state Google redirected back with
+type CompleteGoogleCalendarOAuthRequest struct {
+	Code       string `json:"code" binding:"required"`
+	State      string `json:"state" binding:"required"`
+	CalendarID string `json:"calendar_id" binding:"omitempty,max=255"` // Defaults to the primary calendar
+}
+
+// GoogleCalendarResponse represents the user's Google Calendar connection.
+// OAuth tokens are never returned.
+type GoogleCalendarResponse struct {
+	ID           uint       `json:"id"`
+	CalendarID   string     `json:"calendar_id"`
+	PushSince    time.Time  `json:"push_since"`
+	IsActive     bool       `json:"is_active"` // False once Google revokes access
+	LastPushedAt *time.Time `json:"last_pushed_at"`
+	LastError    string     `json:"last_error,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// StartPrivateIntervalRequest turns private mode on, or records an interval
+// that already ended when end_time is given
+type StartPrivateIntervalRequest struct {
+	Reason    string     `json:"reason" binding:"required,oneof=personal confidential meeting other"`
+	Note      string     `json:"note" binding:"max=500"`
+	TimeLogID *uint      `json:"time_log_id"` // Defaults to the running time log
+	StartTime *time.Time `json:"start_time"`  // Defaults to now
+	EndTime   *time.Time `json:"end_time"`
+}
+
+// PrivateIntervalResponse represents an interval without screenshot capture
+type PrivateIntervalResponse struct {
+	ID          uint       `json:"id"`
+	TimeLogID   *uint      `json:"time_log_id"`
+	WorkspaceID *uint      `json:"workspace_id"`
+	Reason      string     `json:"reason"`
+	Note        string     `json:"note"`
+	StartTime   time.Time  `json:"start_time"`
+	EndTime     *time.Time `json:"end_time"` // Nil while private mode is on
+	Duration    int64      `json:"duration"` // Seconds, up to now while in progress
+	Active      bool       `json:"active"`
+}
+
+// PrivateIntervalListResponse lists the user's private intervals in a date range
+type PrivateIntervalListResponse struct {
+	StartDate     string                    `json:"start_date"`
+	EndDate       string                    `json:"end_date"`
+	TotalDuration int64                     `json:"total_duration"` // Seconds
+	Intervals     []PrivateIntervalResponse `json:"intervals"`
+}
+
+// NotificationPreferencesResponse is the user's email notification settings
+type NotificationPreferencesResponse struct {
+	EmailEnabled       bool `json:"email_enabled"`        // Master switch for non-transactional email
+	EmailWeeklySummary bool `json:"email_weekly_summary"` // Weekly tracked time summary, sent on Mondays
+}
+
+// UpdateNotificationPreferencesRequest changes the given settings
+type UpdateNotificationPreferencesRequest struct {
+	EmailEnabled       *bool `json:"email_enabled"`
+	EmailWeeklySummary *bool `json:"email_weekly_summary"`
+}
+
+// NotificationListParams represents query parameters for the notification list
+type NotificationListParams struct {
+	Page       int
+	PerPage    int
+	UnreadOnly bool
+}
+
+// NotificationResponse is an in-app notification
+type NotificationResponse struct {
+	ID         uint       `json:"id"`
+	Type       string     `json:"type"` // invitation_received, timelog_rejected, workspace_archived
+	Title      string     `json:"title"`
+	Body       string     `json:"body"`
+	EntityType string     `json:"entity_type,omitempty"`
+	EntityID   *uint      `json:"entity_id,omitempty"`
+	ReadAt     *time.Time `json:"read_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// UnreadNotificationCountResponse is the number shown on the notification bell
+type UnreadNotificationCountResponse struct {
+	Unread int64 `json:"unread"`
+}
+
+// DeviceLogUploadRequest represents the form fields of a log bundle upload
+type DeviceLogUploadRequest struct {
+	DeviceUUID string `form:"device_uuid" binding:"required"`
+	AppVersion string `form:"app_version"`
+	Note       string `form:"note"`
+}
+
+// DeviceLogUploadResponse represents an accepted log bundle upload
+type DeviceLogUploadResponse struct {
+	ID        uint      `json:"id"`
+	FileName  string    `json:"file_name"`
+	FileSize  int64     `json:"file_size"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// DataExportResponse represents a personal data export request
+type DataExportResponse struct {
+	ID          uint       `json:"id"`
+	OperationID *uint      `json:"operation_id,omitempty"`
+	Status      string     `json:"status"` // pending, processing, completed, failed
+	FileName    string     `json:"file_name,omitempty"`
+	FileSize    int64      `json:"file_size"`
+	Error       string     `json:"error,omitempty"`
+	DownloadURL string     `json:"download_url,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+}
+
+// OperationResponse represents a long-running background operation
+type OperationResponse struct {
+	ID             uint            `json:"id"`
+	Type           string          `json:"type"` // data_export, organization_export, purge
+	OrganizationID *uint           `json:"organization_id,omitempty"`
+	Status         string          `json:"status"`               // pending, running, completed, failed
+	Progress       int             `json:"progress"`             // 0-100
+	ResultURL      string          `json:"result_url,omitempty"` // Where the finished result can be fetched
+	Result         json.RawMessage `json:"result,omitempty"`
+	Error          string          `json:"error,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	StartedAt      *time.Time      `json:"started_at"`
+	CompletedAt    *time.Time      `json:"completed_at"`
+}
+
+// AccountDeletionRequest represents a request to erase the current account
+type AccountDeletionRequest struct {
+	Password string `json:"password" binding:"required"` // Current password confirms the request
+}
+
+// AccountDeletionResponse represents the erasure schedule of an account
+type AccountDeletionResponse struct {
+	DeletionRequestedAt *time.Time `json:"deletion_requested_at"`
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at"`
+}
+
+// SearchParams represents the query of a search across tasks, time log
+// notes and members
+type SearchParams struct {
+	Query          string
+	Types          []string // task, time_log, member; empty searches all
+	OrganizationID *uint
+	Limit          int
+}
+
+// SearchResult is a search match. Title and Snippet describe it in the
+// result list; ID, OrganizationID and WorkspaceID locate it.
+type SearchResult struct {
+	Type           string    `json:"type"` // task, time_log, member
+	ID             uint      `json:"id"`
+	Title          string    `json:"title"`
+	Snippet        string    `json:"snippet"`
+	OrganizationID *uint     `json:"organization_id"`
+	WorkspaceID    *uint     `json:"workspace_id"`
+	UserID         uint      `json:"user_id"` // Owner of the task or time log, or the member
+	Date           time.Time `json:"date"`    // Created, started or joined
+	Rank           float64   `json:"rank"`
+}
+
+// SearchResponse lists search results, best match first
+type SearchResponse struct {
+	Query   string         `json:"query"`
+	Results []SearchResult `json:"results"`
 }
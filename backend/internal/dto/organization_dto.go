@@ -1,6 +1,9 @@
 package dto
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // ============================================================================
 // ORGANIZATION DTOs
@@ -9,9 +12,10 @@ import "time"
 // CreateOrganizationRequest represents organization creation request
 type CreateOrganizationRequest struct {
 	Name        string `json:"name" binding:"required,min=2,max=255"`
-	Slug        string `json:"slug" binding:"required,min=2,max=255,alphanum"`
+	Slug        string `json:"slug" binding:"required,min=2,max=255,slug"`
 	Description string `json:"description"`
 	LogoURL     string `json:"logo_url"`
+	Currency    string `json:"currency" binding:"omitempty,iso4217"` // Defaults to USD
 }
 
 // UpdateOrganizationRequest represents organization update request
@@ -23,28 +27,328 @@ type UpdateOrganizationRequest struct {
 	ShareInviteCode *bool   `json:"share_invite_code"` // If true, all members can see invite code
 	MaxMembers      *int    `json:"max_members"`
 	IsActive        *bool   `json:"is_active"`
+
+	RequireDeviceApproval *bool   `json:"require_device_approval"`                              // Devices must be approved by an admin before they sync
+	UpdateChannel         *string `json:"update_channel" binding:"omitempty,oneof=stable beta"` // Release channel members' desktop apps update from
+	Currency              *string `json:"currency" binding:"omitempty,iso4217"`                 // Member cost rates keep their amounts
+	Timezone              *string `json:"timezone" binding:"omitempty,max=64,iana_timezone"`    // Organization reports group days here
+
+	Version *uint `json:"version"` // Version the edit is based on, or the If-Match header; nil skips the check
 }
 
 // OrganizationResponse represents organization data in responses
 type OrganizationResponse struct {
-	ID              uint                         `json:"id"`
-	Name            string                       `json:"name"`
-	Slug            string                       `json:"slug"`
-	Description     string                       `json:"description"`
-	LogoURL         string                       `json:"logo_url"`
-	OwnerID         uint                         `json:"owner_id"`
-	Owner           *UserResponse                `json:"owner,omitempty"`
-	InviteCode      string                       `json:"invite_code,omitempty"`
-	AllowInviteLink bool                         `json:"allow_invite_link"`
-	ShareInviteCode bool                         `json:"share_invite_code"` // If true, all members can see invite code
-	MaxMembers      int                          `json:"max_members"`
-	IsActive        bool                         `json:"is_active"`
-	MemberCount     int64                        `json:"member_count"`
-	WorkspaceCount  int64                        `json:"workspace_count"`
-	Members         []OrganizationMemberResponse `json:"members,omitempty"`
-	Workspaces      []WorkspaceResponse          `json:"workspaces,omitempty"`
-	CreatedAt       time.Time                    `json:"created_at"`
-	UpdatedAt       time.Time                    `json:"updated_at"`
+	ID                    uint                         `json:"id"`
+	UUID                  string                       `json:"uuid"`
+	Version               uint                         `json:"version"`
+	Name                  string                       `json:"name"`
+	Slug                  string                       `json:"slug"`
+	Description           string                       `json:"description"`
+	LogoURL               string                       `json:"logo_url"`
+	OwnerID               uint                         `json:"owner_id"`
+	Owner                 *UserResponse                `json:"owner,omitempty"`
+	InviteCode            string                       `json:"invite_code,omitempty"`
+	AllowInviteLink       bool                         `json:"allow_invite_link"`
+	ShareInviteCode       bool                         `json:"share_invite_code"` // If true, all members can see invite code
+	MaxMembers            int                          `json:"max_members"`
+	IsActive              bool                         `json:"is_active"`
+	RequireDeviceApproval bool                         `json:"require_device_approval"`
+	UpdateChannel         string                       `json:"update_channel"`
+	Currency              string                       `json:"currency"`
+	Timezone              string                       `json:"timezone"`
+	MemberCount           int64                        `json:"member_count"`
+	WorkspaceCount        int64                        `json:"workspace_count"`
+	Members               []OrganizationMemberResponse `json:"members,omitempty"`
+	Workspaces            []WorkspaceResponse          `json:"workspaces,omitempty"`
+	DeletionScheduledAt   *time.Time                   `json:"deletion_scheduled_at,omitempty"` // Set while a deletion is pending
+	CreatedAt             time.Time                    `json:"created_at"`
+	UpdatedAt             time.Time                    `json:"updated_at"`
+}
+
+// OrganizationDeletionResponse represents the deletion schedule of an organization
+type OrganizationDeletionResponse struct {
+	OrganizationID      uint       `json:"organization_id"`
+	DeletionRequestedAt *time.Time `json:"deletion_requested_at"`
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at"` // All data is purged after this time unless cancelled
+}
+
+// OrganizationCalendarResponse represents an organization's working-week and fiscal calendar
+type OrganizationCalendarResponse struct {
+	OrganizationID       uint   `json:"organization_id"`
+	WorkingDays          []int  `json:"working_days"`            // 0=Sunday ... 6=Saturday
+	WeekStartDay         int    `json:"week_start_day"`          // 0=Sunday ... 6=Saturday
+	FiscalYearStartMonth int    `json:"fiscal_year_start_month"` // 1=January ... 12=December
+	CurrentFiscalYear    int    `json:"current_fiscal_year"`
+	CurrentFiscalQuarter int    `json:"current_fiscal_quarter"`
+	CurrentWeekStart     string `json:"current_week_start"` // YYYY-MM-DD
+}
+
+// UpdateOrganizationCalendarRequest represents an organization calendar update request
+type UpdateOrganizationCalendarRequest struct {
+	WorkingDays          []int `json:"working_days" binding:"omitempty,min=1,max=7,dive,min=0,max=6"`
+	WeekStartDay         *int  `json:"week_start_day" binding:"omitempty,min=0,max=6"`
+	FiscalYearStartMonth *int  `json:"fiscal_year_start_month" binding:"omitempty,min=1,max=12"`
+}
+
+// OrganizationRetentionResponse represents an organization's data retention settings
+type OrganizationRetentionResponse struct {
+	OrganizationID          uint    `json:"organization_id"`
+	ScreenshotRetentionDays int     `json:"screenshot_retention_days"` // 0 = keep forever
+	ScreenshotsPurgedBefore *string `json:"screenshots_purged_before"` // YYYY-MM-DD cutoff, nil when disabled
+}
+
+// UpdateOrganizationRetentionRequest represents an organization retention update request
+type UpdateOrganizationRetentionRequest struct {
+	ScreenshotRetentionDays *int `json:"screenshot_retention_days" binding:"required,min=0,max=3650"`
+}
+
+// OrganizationUsageAnalyticsResponse represents an organization's feature usage analytics setting
+type OrganizationUsageAnalyticsResponse struct {
+	OrganizationID uint `json:"organization_id"`
+	OptOut         bool `json:"opt_out"`
+}
+
+// UpdateOrganizationUsageAnalyticsRequest represents a usage analytics opt-out update request
+type UpdateOrganizationUsageAnalyticsRequest struct {
+	OptOut *bool `json:"opt_out" binding:"required"`
+}
+
+// CapturePolicyResponse is the capture policy agents apply for an organization
+type CapturePolicyResponse struct {
+	OrganizationID uint                           `json:"organization_id"`
+	Exclusions     []CaptureExclusionRuleResponse `json:"exclusions"` // Active rules; skip capture when any matches
+}
+
+// CaptureExclusionRuleResponse represents a sensitive-window capture exclusion rule
+type CaptureExclusionRuleResponse struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	MatchType string    `json:"match_type"` // app, window_title
+	Pattern   string    `json:"pattern"`
+	IsRegex   bool      `json:"is_regex"`
+	IsActive  bool      `json:"is_active"`
+	CreatedBy uint      `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CreateCaptureExclusionRuleRequest represents a capture exclusion rule creation request
+type CreateCaptureExclusionRuleRequest struct {
+	Name      string `json:"name" binding:"max=100"`
+	MatchType string `json:"match_type" binding:"required,oneof=app window_title"`
+	Pattern   string `json:"pattern" binding:"required,max=255"`
+	IsRegex   bool   `json:"is_regex"`
+}
+
+// UpdateCaptureExclusionRuleRequest represents a capture exclusion rule update request
+type UpdateCaptureExclusionRuleRequest struct {
+	Name      *string `json:"name" binding:"omitempty,max=100"`
+	MatchType string  `json:"match_type" binding:"omitempty,oneof=app window_title"`
+	Pattern   string  `json:"pattern" binding:"omitempty,max=255"`
+	IsRegex   *bool   `json:"is_regex"`
+	IsActive  *bool   `json:"is_active"`
+}
+
+// EncryptionKeyResponse represents an organization's screenshot encryption key
+type EncryptionKeyResponse struct {
+	ID          uint       `json:"id"`
+	Fingerprint string     `json:"fingerprint"` // Send with encrypted uploads as key_fingerprint
+	PublicKey   string     `json:"public_key"`  // PEM
+	Algorithm   string     `json:"algorithm"`
+	Status      string     `json:"status"` // active, retired
+	CreatedBy   uint       `json:"created_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+	RetiredAt   *time.Time `json:"retired_at,omitempty"`
+}
+
+// RegisterEncryptionKeyRequest registers a new organization public key
+type RegisterEncryptionKeyRequest struct {
+	PublicKey string `json:"public_key" binding:"required,max=4096"` // PEM encoded RSA public key, at least 2048 bits
+}
+
+// EncryptedScreenshotKey is the encryption envelope of one screenshot
+type EncryptedScreenshotKey struct {
+	ScreenshotID   uint   `json:"screenshot_id"`
+	KeyFingerprint string `json:"key_fingerprint"`
+	WrappedKey     string `json:"wrapped_key"` // Base64 content key wrapped with the organization key
+	Nonce          string `json:"nonce"`       // Base64 AES-GCM nonce
+}
+
+// RekeyScreenshotsRequest moves screenshots to the active key. Clients unwrap
+// each content key with the old private key and wrap it with the active public
+// key; the encrypted files do not change.
+type RekeyScreenshotsRequest struct {
+	KeyFingerprint string                `json:"key_fingerprint" binding:"required,len=64"` // Must be the active key
+	Screenshots    []RekeyScreenshotItem `json:"screenshots" binding:"required,min=1,max=500,dive"`
+}
+
+// RekeyScreenshotItem is one re-wrapped content key
+type RekeyScreenshotItem struct {
+	ScreenshotID uint   `json:"screenshot_id" binding:"required"`
+	WrappedKey   string `json:"wrapped_key" binding:"required,base64"`
+}
+
+// RekeyScreenshotsResponse reports the outcome of a re-key batch
+type RekeyScreenshotsResponse struct {
+	Updated int    `json:"updated"`
+	Skipped []uint `json:"skipped"` // Unknown, unencrypted or other organizations' screenshots
+}
+
+// EncryptedScreenshotResponse is an encrypted screenshot with what clients
+// need to decrypt it locally
+type EncryptedScreenshotResponse struct {
+	EncryptedScreenshotKey
+	KeyAlgorithm     string    `json:"key_algorithm"`
+	ContentAlgorithm string    `json:"content_algorithm"`
+	FileName         string    `json:"file_name"`
+	MimeType         string    `json:"mime_type"` // Of the decrypted image
+	Checksum         string    `json:"checksum"`
+	CapturedAt       time.Time `json:"captured_at"`
+	Data             string    `json:"data"` // Base64 ciphertext
+}
+
+// TaskAssignmentRuleResponse represents a workspace task assignment rule
+type TaskAssignmentRuleResponse struct {
+	ID              uint      `json:"id"`
+	WorkspaceID     uint      `json:"workspace_id"`
+	Name            string    `json:"name"`
+	Strategy        string    `json:"strategy"` // round_robin, by_role, by_tag
+	Priority        int       `json:"priority"`
+	WorkspaceRoleID *uint     `json:"workspace_role_id,omitempty"`
+	TagField        string    `json:"tag_field,omitempty"`
+	TagValue        string    `json:"tag_value,omitempty"`
+	AssigneeID      *uint     `json:"assignee_id,omitempty"`
+	IsActive        bool      `json:"is_active"`
+	CreatedBy       uint      `json:"created_by"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// CreateTaskAssignmentRuleRequest represents a task assignment rule creation request
+type CreateTaskAssignmentRuleRequest struct {
+	Name            string `json:"name" binding:"max=100"`
+	Strategy        string `json:"strategy" binding:"required,oneof=round_robin by_role by_tag"`
+	Priority        int    `json:"priority"`
+	WorkspaceRoleID *uint  `json:"workspace_role_id"`                                            // Required for by_role
+	TagField        string `json:"tag_field" binding:"omitempty,oneof=project_code cost_center"` // Required for by_tag
+	TagValue        string `json:"tag_value" binding:"max=100"`                                  // Required for by_tag
+	AssigneeID      *uint  `json:"assignee_id"`                                                  // by_tag only
+}
+
+// UpdateTaskAssignmentRuleRequest represents a task assignment rule update request
+type UpdateTaskAssignmentRuleRequest struct {
+	Name            *string `json:"name" binding:"omitempty,max=100"`
+	Strategy        string  `json:"strategy" binding:"omitempty,oneof=round_robin by_role by_tag"`
+	Priority        *int    `json:"priority"`
+	WorkspaceRoleID *uint   `json:"workspace_role_id"`
+	TagField        string  `json:"tag_field" binding:"omitempty,oneof=project_code cost_center"`
+	TagValue        *string `json:"tag_value" binding:"omitempty,max=100"`
+	AssigneeID      *uint   `json:"assignee_id"` // 0 clears the fixed assignee
+	IsActive        *bool   `json:"is_active"`
+}
+
+// TaskStatusColumn is a Kanban board column in a request
+type TaskStatusColumn struct {
+	Key      string `json:"key" binding:"required,max=50"` // Lowercase letters, digits, "-" and "_"
+	Name     string `json:"name" binding:"required,max=100"`
+	Color    string `json:"color" binding:"omitempty,hex_color"`
+	Category string `json:"category" binding:"required,oneof=active completed archived"` // Base status of tasks in the column
+}
+
+// UpdateTaskStatusesRequest replaces a workspace's board columns; their
+// order is the board order
+type UpdateTaskStatusesRequest struct {
+	Statuses []TaskStatusColumn `json:"statuses" binding:"required,min=1,max=20,dive"`
+}
+
+// TaskStatusResponse represents a workspace board column
+type TaskStatusResponse struct {
+	Key      string `json:"key"`
+	Name     string `json:"name"`
+	Color    string `json:"color"`
+	Category string `json:"category"`
+	Position int    `json:"position"`
+}
+
+// TaskStatusListResponse lists a workspace's board columns
+type TaskStatusListResponse struct {
+	Statuses  []TaskStatusResponse `json:"statuses"`
+	IsDefault bool                 `json:"is_default"` // The workspace never configured its columns
+}
+
+// TaskBoardCard is a task on a workspace board
+type TaskBoardCard struct {
+	ID        uint       `json:"id"`
+	Title     string     `json:"title"`
+	Status    string     `json:"status"`
+	Position  int        `json:"position"`
+	Priority  int        `json:"priority"`
+	Color     string     `json:"color"`
+	UserID    uint       `json:"user_id"`
+	UserName  string     `json:"user_name"`
+	DueDate   *time.Time `json:"due_date"`
+	Tags      []string   `json:"tags"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TaskBoardColumn is a board column with its tasks in order
+type TaskBoardColumn struct {
+	TaskStatusResponse
+	Tasks []TaskBoardCard `json:"tasks"`
+}
+
+// TaskBoardResponse represents a workspace's Kanban board
+type TaskBoardResponse struct {
+	WorkspaceID uint              `json:"workspace_id"`
+	Columns     []TaskBoardColumn `json:"columns"`
+}
+
+// ReorderTasksRequest places tasks in a board column. TaskIDs is the
+// column's full order after the move, so tasks dragged from another column
+// are included.
+type ReorderTasksRequest struct {
+	Status  string `json:"status" binding:"required"` // Column key
+	TaskIDs []uint `json:"task_ids" binding:"required,min=1,max=500"`
+}
+
+// SaveJiraIntegrationRequest connects a workspace to a Jira project, or
+// changes an existing connection
+type SaveJiraIntegrationRequest struct {
+	BaseURL      string `json:"base_url" binding:"required,url,max=255"` // e.g. https://acme.atlassian.net
+	Email        string `json:"email" binding:"required,email,max=255"`
+	APIToken     string `json:"api_token" binding:"max=255"` // Required when connecting; empty keeps the stored token
+	ProjectKey   string `json:"project_key" binding:"required,max=50"`
+	JQL          string `json:"jql" binding:"max=2000"` // Optional extra filter, e.g. "sprint in openSprints()"
+	PushWorklogs *bool  `json:"push_worklogs"`          // Default true
+	IsActive     *bool  `json:"is_active"`              // Default true
+}
+
+// JiraIntegrationResponse represents a workspace's Jira connection. The API
+// token is never returned.
+type JiraIntegrationResponse struct {
+	ID            uint       `json:"id"`
+	WorkspaceID   uint       `json:"workspace_id"`
+	BaseURL       string     `json:"base_url"`
+	Email         string     `json:"email"`
+	ProjectKey    string     `json:"project_key"`
+	JQL           string     `json:"jql"`
+	PushWorklogs  bool       `json:"push_worklogs"`
+	IsActive      bool       `json:"is_active"`
+	CreatedBy     uint       `json:"created_by"`
+	LastSyncAt    *time.Time `json:"last_sync_at"`
+	LastSyncError string     `json:"last_sync_error,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// JiraSyncResponse summarizes a Jira sync run
+type JiraSyncResponse struct {
+	IssuesFound     int    `json:"issues_found"`
+	TasksCreated    int    `json:"tasks_created"`
+	TasksUpdated    int    `json:"tasks_updated"`
+	WorklogsPushed  int    `json:"worklogs_pushed"`
+	WorklogsPending int    `json:"worklogs_pending"` // Left for the next run after a push failure
+	Error           string `json:"error,omitempty"`
 }
 
 // OrganizationListResponse represents organization in list responses
@@ -72,7 +376,7 @@ type AddOrganizationMemberRequest struct {
 
 // UpdateOrganizationMemberRequest represents updating member role
 type UpdateOrganizationMemberRequest struct {
-	Role     string `json:"role" binding:"required,oneof=owner admin member"`
+	Role     string `json:"role" binding:"required,role=organization"`
 	IsActive *bool  `json:"is_active"`
 }
 
@@ -87,6 +391,45 @@ type OrganizationMemberResponse struct {
 	InvitedBy *uint         `json:"invited_by"`
 }
 
+// ============================================================================
+// ORGANIZATION SETTINGS DTOs
+// ============================================================================
+
+// OrganizationSettingsResponse lists every organization setting with its
+// effective value
+type OrganizationSettingsResponse struct {
+	OrganizationID uint                          `json:"organization_id"`
+	Settings       []OrganizationSettingResponse `json:"settings"`
+}
+
+// OrganizationSettingResponse represents a setting, its value and the JSON
+// Schema values must match
+type OrganizationSettingResponse struct {
+	Key         string                `json:"key" example:"share_links.default_expiry_days"`
+	Description string                `json:"description"`
+	Value       interface{}           `json:"value"`
+	Default     interface{}           `json:"default"`
+	IsDefault   bool                  `json:"is_default"` // Not set by the organization
+	Schema      SettingSchemaResponse `json:"schema"`
+	UpdatedAt   *time.Time            `json:"updated_at,omitempty"`
+	UpdatedBy   *uint                 `json:"updated_by,omitempty"`
+}
+
+// SettingSchemaResponse is the JSON Schema of a setting's value
+type SettingSchemaResponse struct {
+	Type      string   `json:"type" example:"integer"` // boolean, integer, string
+	Minimum   *int64   `json:"minimum,omitempty"`
+	Maximum   *int64   `json:"maximum,omitempty"`
+	MaxLength int      `json:"maxLength,omitempty"`
+	Enum      []string `json:"enum,omitempty"`
+}
+
+// UpdateOrganizationSettingsRequest sets the given settings; a null value
+// resets a setting to its default. Settings left out are unchanged.
+type UpdateOrganizationSettingsRequest struct {
+	Settings map[string]json.RawMessage `json:"settings" binding:"required,min=1"`
+}
+
 // ============================================================================
 // WORKSPACE ROLE DTOs
 // ============================================================================
@@ -96,8 +439,8 @@ type CreateWorkspaceRoleRequest struct {
 	Name        string `json:"name" binding:"required,min=2,max=100,alphanum"`
 	DisplayName string `json:"display_name" binding:"required,min=2,max=255"`
 	Description string `json:"description"`
-	Color       string `json:"color"`
-	Permissions string `json:"permissions"` // JSON string of permissions
+	Color       string `json:"color" binding:"omitempty,hex_color"`
+	Permissions string `json:"permissions"` // JSON object of workspace permissions, e.g. {"tasks.manage": true}
 	IsDefault   bool   `json:"is_default"`
 	SortOrder   int    `json:"sort_order"`
 }
@@ -106,7 +449,7 @@ type CreateWorkspaceRoleRequest struct {
 type UpdateWorkspaceRoleRequest struct {
 	DisplayName *string `json:"display_name"`
 	Description *string `json:"description"`
-	Color       *string `json:"color"`
+	Color       *string `json:"color" binding:"omitempty,hex_color"`
 	Permissions *string `json:"permissions"`
 	IsDefault   *bool   `json:"is_default"`
 	SortOrder   *int    `json:"sort_order"`
@@ -126,6 +469,29 @@ type WorkspaceRoleResponse struct {
 	CreatedAt      time.Time `json:"created_at"`
 }
 
+// WorkspacePermissionDefinitionResponse describes a permission in the role matrix
+type WorkspacePermissionDefinitionResponse struct {
+	Key         string `json:"key"`   // e.g. tasks.create
+	Group       string `json:"group"` // tasks, time, reports, screenshots, members, settings
+	Description string `json:"description"`
+	Default     bool   `json:"default"` // Granted to members unless their role revokes it
+}
+
+// RolePermissionsResponse is a workspace role's permission matrix
+type RolePermissionsResponse struct {
+	RoleID         uint            `json:"role_id"`
+	OrganizationID uint            `json:"organization_id"`
+	Name           string          `json:"name"`
+	DisplayName    string          `json:"display_name"`
+	Permissions    map[string]bool `json:"permissions"` // Every permission in the schema, true when members with the role have it
+}
+
+// UpdateRolePermissionsRequest replaces a workspace role's permission matrix.
+// Permissions left out keep their default.
+type UpdateRolePermissionsRequest struct {
+	Permissions map[string]bool `json:"permissions" binding:"required"`
+}
+
 // ============================================================================
 // WORKSPACE DTOs
 // ============================================================================
@@ -133,34 +499,77 @@ type WorkspaceRoleResponse struct {
 // CreateWorkspaceRequest represents workspace creation request
 type CreateWorkspaceRequest struct {
 	Name        string     `json:"name" binding:"required,min=2,max=255"`
-	Slug        string     `json:"slug" binding:"required,min=2,max=255,alphanum"`
+	Slug        string     `json:"slug" binding:"required,min=2,max=255,slug"`
 	Description string     `json:"description"`
-	Color       string     `json:"color"`
+	Color       string     `json:"color" binding:"omitempty,hex_color"`
 	Icon        string     `json:"icon"`
 	AdminID     uint       `json:"admin_id"` // If not provided, creator becomes admin
 	IsBillable  bool       `json:"is_billable"`
 	HourlyRate  float64    `json:"hourly_rate"`
+	Currency    string     `json:"currency" binding:"omitempty,iso4217"` // Defaults to the organization currency
+	ClientID    *uint      `json:"client_id"`
 	StartDate   *time.Time `json:"start_date"`
 	EndDate     *time.Time `json:"end_date"`
+	CostCenter  string     `json:"cost_center" binding:"max=100"`
+	ProjectCode string     `json:"project_code" binding:"max=100"`
 }
 
 // UpdateWorkspaceRequest represents workspace update request
 type UpdateWorkspaceRequest struct {
 	Name        *string    `json:"name"`
 	Description *string    `json:"description"`
-	Color       *string    `json:"color"`
+	Color       *string    `json:"color" binding:"omitempty,hex_color"`
 	Icon        *string    `json:"icon"`
 	AdminID     *uint      `json:"admin_id"`
 	IsActive    *bool      `json:"is_active"`
 	IsBillable  *bool      `json:"is_billable"`
 	HourlyRate  *float64   `json:"hourly_rate"`
+	Currency    *string    `json:"currency" binding:"omitempty,iso4217"` // Keeps the hourly rate's amount
+	ClientID    *uint      `json:"client_id"`                            // 0 removes the client
 	StartDate   *time.Time `json:"start_date"`
 	EndDate     *time.Time `json:"end_date"`
+	CostCenter  *string    `json:"cost_center" binding:"omitempty,max=100"`
+	ProjectCode *string    `json:"project_code" binding:"omitempty,max=100"`
+
+	CapWarnPercent *int    `json:"cap_warn_percent" binding:"omitempty,min=1,max=100"`
+	CapEnforcement *string `json:"cap_enforcement" binding:"omitempty,oneof=flag block"`
+
+	Version *uint `json:"version"` // Version the edit is based on, or the If-Match header; nil skips the check
+}
+
+// TrackingSettingsResponse is the screenshot capture policy the desktop app
+// applies while tracking time in a workspace
+type TrackingSettingsResponse struct {
+	WorkspaceID               uint   `json:"workspace_id"`
+	ScreenshotsDisabled       bool   `json:"screenshots_disabled"` // Do not capture; uploads are rejected
+	ScreenshotIntervalSeconds int    `json:"screenshot_interval_seconds"`
+	ScreenshotBlurMode        string `json:"screenshot_blur_mode"` // none, blur
+	ScreenshotMonitors        int    `json:"screenshot_monitors"`  // Monitors to capture, 0 for all
+
+	IdleThresholdSeconds int      `json:"idle_threshold_seconds"`
+	DisabledFeatures     []string `json:"disabled_features"` // manual_time, idle_detection, offline_tracking
+
+	ManualEntriesRequireApproval bool `json:"manual_entries_require_approval"`
+}
+
+// UpdateTrackingSettingsRequest changes a workspace's screenshot capture policy
+type UpdateTrackingSettingsRequest struct {
+	ScreenshotsDisabled       *bool   `json:"screenshots_disabled"`
+	ScreenshotIntervalSeconds *int    `json:"screenshot_interval_seconds" binding:"omitempty,min=60,max=3600"`
+	ScreenshotBlurMode        *string `json:"screenshot_blur_mode" binding:"omitempty,oneof=none blur"`
+	ScreenshotMonitors        *int    `json:"screenshot_monitors" binding:"omitempty,min=0,max=16"`
+
+	IdleThresholdSeconds *int      `json:"idle_threshold_seconds" binding:"omitempty,min=60,max=3600"`
+	DisabledFeatures     *[]string `json:"disabled_features" binding:"omitempty,dive,oneof=manual_time idle_detection offline_tracking"` // Replaces the list; an empty list enables every feature
+
+	ManualEntriesRequireApproval *bool `json:"manual_entries_require_approval"`
 }
 
 // WorkspaceResponse represents workspace data in responses
 type WorkspaceResponse struct {
 	ID             uint                      `json:"id"`
+	UUID           string                    `json:"uuid"`
+	Version        uint                      `json:"version"`
 	OrganizationID uint                      `json:"organization_id"`
 	Name           string                    `json:"name"`
 	Slug           string                    `json:"slug"`
@@ -172,8 +581,14 @@ type WorkspaceResponse struct {
 	IsActive       bool                      `json:"is_active"`
 	IsBillable     bool                      `json:"is_billable"`
 	HourlyRate     float64                   `json:"hourly_rate"`
+	Currency       string                    `json:"currency"`
+	ClientID       *uint                     `json:"client_id"`
 	StartDate      *time.Time                `json:"start_date"`
 	EndDate        *time.Time                `json:"end_date"`
+	CostCenter     string                    `json:"cost_center"`
+	ProjectCode    string                    `json:"project_code"`
+	CapWarnPercent int                       `json:"cap_warn_percent"`
+	CapEnforcement string                    `json:"cap_enforcement"`
 	MemberCount    int64                     `json:"member_count"`
 	TaskCount      int64                     `json:"task_count"`
 	Members        []WorkspaceMemberResponse `json:"members,omitempty"`
@@ -191,6 +606,7 @@ type WorkspaceListResponse struct {
 	Description      string    `json:"description,omitempty"`
 	Color            string    `json:"color"`
 	Icon             string    `json:"icon"`
+	ClientID         *uint     `json:"client_id"`
 	IsAdmin          bool      `json:"is_admin"` // Whether current user is admin of this workspace
 	WorkspaceRoleID  *uint     `json:"workspace_role_id,omitempty"`
 	RoleName         string    `json:"role_name"`
@@ -206,22 +622,24 @@ type WorkspaceListResponse struct {
 
 // AddWorkspaceMemberRequest represents adding a member to workspace
 type AddWorkspaceMemberRequest struct {
-	UserID          uint   `json:"user_id" binding:"required"`
-	WorkspaceRoleID *uint  `json:"workspace_role_id"`
-	RoleName        string `json:"role_name"`
-	IsAdmin         bool   `json:"is_admin"`
-	CanViewReports  bool   `json:"can_view_reports"`
-	CanManageTasks  bool   `json:"can_manage_tasks"`
+	UserID          uint     `json:"user_id" binding:"required"`
+	WorkspaceRoleID *uint    `json:"workspace_role_id"`
+	RoleName        string   `json:"role_name"`
+	IsAdmin         bool     `json:"is_admin"`
+	CanViewReports  bool     `json:"can_view_reports"`
+	CanManageTasks  bool     `json:"can_manage_tasks"`
+	WeeklyHourCap   *float64 `json:"weekly_hour_cap" binding:"omitempty,gt=0,max=168"`
 }
 
 // UpdateWorkspaceMemberRequest represents updating workspace member
 type UpdateWorkspaceMemberRequest struct {
-	WorkspaceRoleID *uint   `json:"workspace_role_id"`
-	RoleName        *string `json:"role_name"`
-	IsAdmin         *bool   `json:"is_admin"`
-	CanViewReports  *bool   `json:"can_view_reports"`
-	CanManageTasks  *bool   `json:"can_manage_tasks"`
-	IsActive        *bool   `json:"is_active"`
+	WorkspaceRoleID *uint    `json:"workspace_role_id"`
+	RoleName        *string  `json:"role_name"`
+	IsAdmin         *bool    `json:"is_admin"`
+	CanViewReports  *bool    `json:"can_view_reports"`
+	CanManageTasks  *bool    `json:"can_manage_tasks"`
+	IsActive        *bool    `json:"is_active"`
+	WeeklyHourCap   *float64 `json:"weekly_hour_cap" binding:"omitempty,min=0,max=168"` // 0 removes the cap
 }
 
 // WorkspaceMemberResponse represents workspace member data
@@ -238,6 +656,70 @@ type WorkspaceMemberResponse struct {
 	JoinedAt        time.Time              `json:"joined_at"`
 	IsActive        bool                   `json:"is_active"`
 	AddedBy         *uint                  `json:"added_by"`
+	WeeklyHourCap   *float64               `json:"weekly_hour_cap"`
+}
+
+// WorkspaceComplianceParams represents query parameters for the hour cap compliance report
+type WorkspaceComplianceParams struct {
+	Week *time.Time // Any time within the reported week; defaults to now
+}
+
+// WorkspaceComplianceReport represents a workspace's weekly hour cap compliance
+type WorkspaceComplianceReport struct {
+	WorkspaceID    uint                     `json:"workspace_id"`
+	WeekStart      time.Time                `json:"week_start"`
+	WeekEnd        time.Time                `json:"week_end"`
+	CapWarnPercent int                      `json:"cap_warn_percent"`
+	CapEnforcement string                   `json:"cap_enforcement"`
+	CappedMembers  int                      `json:"capped_members"`
+	Approaching    int                      `json:"approaching"`
+	Exceeded       int                      `json:"exceeded"`
+	Members        []MemberComplianceReport `json:"members"`
+}
+
+// MemberComplianceReport represents one member's hours against their weekly cap
+type MemberComplianceReport struct {
+	UserID         uint          `json:"user_id"`
+	User           *UserResponse `json:"user,omitempty"`
+	WeeklyHourCap  *float64      `json:"weekly_hour_cap"`
+	LoggedHours    float64       `json:"logged_hours"`
+	RemainingHours *float64      `json:"remaining_hours"` // Nil when the member has no cap
+	PercentUsed    *float64      `json:"percent_used"`
+	Status         string        `json:"status"` // ok, approaching, exceeded, uncapped
+	FlaggedLogs    int64         `json:"flagged_logs"`
+}
+
+// WorkspaceBudgetResponse represents a workspace's budget and its burn-down
+type WorkspaceBudgetResponse struct {
+	WorkspaceID    uint                  `json:"workspace_id"`
+	BudgetType     string                `json:"budget_type"` // "" (none), hours, money
+	BudgetAmount   float64               `json:"budget_amount"`
+	HourlyRate     float64               `json:"hourly_rate"` // Prices approved hours for money budgets
+	Currency       string                `json:"currency"`    // Of money budgets and the hourly rate
+	Thresholds     []int                 `json:"thresholds"`
+	ConsumedHours  float64               `json:"consumed_hours"`
+	Consumed       float64               `json:"consumed"` // In the budget's unit
+	Remaining      float64               `json:"remaining"`
+	PercentUsed    float64               `json:"percent_used"`
+	AlertedPercent int                   `json:"alerted_percent"` // Highest threshold alerted, 0 for none
+	RolledUpAt     *time.Time            `json:"rolled_up_at"`    // Last rollup that checked the thresholds
+	BurnDown       []BudgetBurnDownPoint `json:"burn_down"`
+}
+
+// BudgetBurnDownPoint is the budget consumed by approved time logs started on a day
+type BudgetBurnDownPoint struct {
+	Date      string  `json:"date"` // YYYY-MM-DD (UTC)
+	Hours     float64 `json:"hours"`
+	Consumed  float64 `json:"consumed"`  // On the day, in the budget's unit
+	Remaining float64 `json:"remaining"` // At the end of the day
+}
+
+// UpdateWorkspaceBudgetRequest changes a workspace's budget. Changing the type
+// or amount starts a new budget whose thresholds alert again.
+type UpdateWorkspaceBudgetRequest struct {
+	BudgetType   *string  `json:"budget_type" binding:"omitempty,oneof=none hours money"` // none removes the budget
+	BudgetAmount *float64 `json:"budget_amount" binding:"omitempty,min=0"`
+	Thresholds   []int    `json:"thresholds" binding:"omitempty,min=1,max=10,dive,min=1,max=1000"`
 }
 
 // ============================================================================
@@ -273,6 +755,7 @@ type InvitationResponse struct {
 	AcceptedAt      *time.Time             `json:"accepted_at"`
 	Message         string                 `json:"message"`
 	InviteLink      string                 `json:"invite_link,omitempty"`
+	AccountExists   *bool                  `json:"account_exists,omitempty"` // Invitation preview only: whether the invitee must log in or sign up
 	CreatedAt       time.Time              `json:"created_at"`
 }
 
@@ -281,6 +764,22 @@ type AcceptInvitationRequest struct {
 	Token string `json:"token" binding:"required"`
 }
 
+// AcceptInvitationTokenRequest accepts an invitation from its landing page.
+// Logged-in invitees send an empty body; otherwise the invitee signs up with
+// a password and name, or proves an existing account with its password.
+type AcceptInvitationTokenRequest struct {
+	Password  string `json:"password"`
+	FirstName string `json:"first_name"` // Required when creating an account
+	LastName  string `json:"last_name"`
+}
+
+// AcceptInvitationResponse is the membership created by accepting an invitation
+type AcceptInvitationResponse struct {
+	Member         OrganizationMemberResponse `json:"member"`
+	AccountCreated bool                       `json:"account_created"`
+	Auth           *LoginResponse             `json:"auth,omitempty"` // Tokens when the invitee was not logged in
+}
+
 // JoinByCodeRequest represents joining org by invite code
 type JoinByCodeRequest struct {
 	InviteCode string `json:"invite_code" binding:"required"`
@@ -299,11 +798,11 @@ type RegisterWithOrgRequest struct {
 	LastName  string `json:"last_name" binding:"required"`
 
 	// Organization choice: create new or join existing
-	CreateOrganization bool   `json:"create_organization"` // true: create new, false: join existing
-	OrganizationName   string `json:"organization_name"`   // Required if create_organization is true
-	OrganizationSlug   string `json:"organization_slug"`   // Required if create_organization is true
-	InviteCode         string `json:"invite_code"`         // Required if create_organization is false
-	InvitationToken    string `json:"invitation_token"`    // Alternative to invite_code (from email link)
+	CreateOrganization bool   `json:"create_organization"`                                // true: create new, false: join existing
+	OrganizationName   string `json:"organization_name"`                                  // Required if create_organization is true
+	OrganizationSlug   string `json:"organization_slug" binding:"omitempty,max=255,slug"` // Required if create_organization is true
+	InviteCode         string `json:"invite_code"`                                        // Required if create_organization is false
+	InvitationToken    string `json:"invitation_token"`                                   // Alternative to invite_code (from email link)
 }
 
 // RegisterWithOrgResponse represents registration with organization response
@@ -337,12 +836,45 @@ type OrgPermission struct {
 
 // WorkspacePermission represents user's permission in a workspace
 type WorkspacePermission struct {
-	WorkspaceID    uint   `json:"workspace_id"`
-	OrganizationID uint   `json:"organization_id"`
-	RoleName       string `json:"role_name"`
-	IsAdmin        bool   `json:"is_admin"`
-	CanViewReports bool   `json:"can_view_reports"`
-	CanManageTasks bool   `json:"can_manage_tasks"`
+	WorkspaceID     uint     `json:"workspace_id"`
+	OrganizationID  uint     `json:"organization_id"`
+	WorkspaceName   string   `json:"workspace_name,omitempty"`
+	RoleName        string   `json:"role_name"`
+	IsAdmin         bool     `json:"is_admin"`
+	CanViewReports  bool     `json:"can_view_reports"`
+	CanManageTasks  bool     `json:"can_manage_tasks"`
+	RolePermissions []string `json:"role_permissions,omitempty"` // Permissions granted by the custom workspace role
+	Permissions     []string `json:"permissions"`                // Effective workspace permissions, e.g. tasks.create
+	GrantedBy       []string `json:"granted_by,omitempty"`       // membership, workspace_admin, organization_role
+}
+
+// MemberPermissionsResponse represents a member's effective permissions in an
+// organization, resolved from their organization role, workspace memberships
+// and system role
+type MemberPermissionsResponse struct {
+	OrganizationID uint                  `json:"organization_id"`
+	UserID         uint                  `json:"user_id"`
+	SystemRole     string                `json:"system_role"`
+	IsSystemAdmin  bool                  `json:"is_system_admin"` // Full access through the admin API
+	Organization   OrgPermission         `json:"organization"`
+	Workspaces     []WorkspacePermission `json:"workspaces"`
+	Permissions    []string              `json:"permissions"` // Flattened, e.g. org:admin, workspace:12:manage_tasks
+}
+
+// RoleChangeResponse represents an entry of a member's role change history
+type RoleChangeResponse struct {
+	ID             uint          `json:"id"`
+	UserID         uint          `json:"user_id"`
+	OrganizationID *uint         `json:"organization_id"`
+	WorkspaceID    *uint         `json:"workspace_id"`
+	Scope          string        `json:"scope"` // system, organization, workspace
+	Attribute      string        `json:"attribute"`
+	From           string        `json:"from"`
+	To             string        `json:"to"`
+	Source         string        `json:"source"`
+	ChangedBy      *uint         `json:"changed_by"` // Nil when changed by the system
+	Actor          *UserResponse `json:"actor,omitempty"`
+	ChangedAt      time.Time     `json:"changed_at"`
 }
 
 // ContextInfo represents current user's context (selected org/workspace)
@@ -362,7 +894,877 @@ type OrganizationPublicInfo struct {
 	MemberCount int64  `json:"member_count"`
 }
 
+// InviteLookupStats summarizes invite code preview lookups on this server
+// instance, for spotting enumeration attempts
+type InviteLookupStats struct {
+	Since           time.Time            `json:"since"` // When counting started (server start)
+	TotalLookups    int64                `json:"total_lookups"`
+	FailedLookups   int64                `json:"failed_lookups"`
+	CaptchaFailures int64                `json:"captcha_failures"`
+	WindowStart     time.Time            `json:"window_start"`    // Start of the current per-IP window
+	TopFailingIPs   []InviteLookupIPStat `json:"top_failing_ips"` // Most failures in the current window
+}
+
+// InviteLookupIPStat represents failed invite code lookups from one IP
+type InviteLookupIPStat struct {
+	IP            string    `json:"ip"`
+	Failures      int64     `json:"failures"`
+	LastFailureAt time.Time `json:"last_failure_at"`
+}
+
 // TransferOwnershipRequest represents ownership transfer request
 type TransferOwnershipRequest struct {
 	NewOwnerID uint `json:"new_owner_id" binding:"required"`
 }
+
+// MemberHandoffRequest represents a request to reassign a member's pending work
+type MemberHandoffRequest struct {
+	ToUserID uint `json:"to_user_id" binding:"required"`
+}
+
+// MemberHandoffResponse summarizes the work transferred between members
+type MemberHandoffResponse struct {
+	OrganizationID         uint   `json:"organization_id"`
+	FromUserID             uint   `json:"from_user_id"`
+	ToUserID               uint   `json:"to_user_id"`
+	TasksTransferred       int    `json:"tasks_transferred"`
+	TaskIDs                []uint `json:"task_ids"`
+	TimeLogsTransferred    int    `json:"time_logs_transferred"`
+	TimeLogIDs             []uint `json:"time_log_ids"`
+	TrackedSeconds         int64  `json:"tracked_seconds"` // Total duration of the transferred time logs
+	ScreenshotsTransferred int64  `json:"screenshots_transferred"`
+}
+
+// ============================================================================
+// ANALYTICS DTOs
+// ============================================================================
+
+// AnalyticsQueryParamInfo describes a parameter accepted by an analytics query
+type AnalyticsQueryParamInfo struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"` // date, int
+	Required    bool   `json:"required"`
+	Description string `json:"description"`
+}
+
+// AnalyticsQueryInfo describes a predefined analytics query
+type AnalyticsQueryInfo struct {
+	Name        string                    `json:"name"`
+	Description string                    `json:"description"`
+	Columns     []string                  `json:"columns"`
+	Params      []AnalyticsQueryParamInfo `json:"params"`
+}
+
+// AnalyticsQueryRequest represents a request to run a predefined analytics query
+type AnalyticsQueryRequest struct {
+	Query  string            `json:"query" binding:"required"`
+	Params map[string]string `json:"params"`
+}
+
+// AnalyticsQueryResponse represents the tabular result of an analytics query
+type AnalyticsQueryResponse struct {
+	Query     string          `json:"query"`
+	Scope     string          `json:"scope"` // organization (admins) or self (members)
+	Columns   []string        `json:"columns"`
+	Rows      [][]interface{} `json:"rows"`
+	RowCount  int             `json:"row_count"`
+	Truncated bool            `json:"truncated"`
+}
+
+// ============================================================================
+// REPORT SUMMARY DTOs
+// ============================================================================
+
+// ReportSummaryParams represents query parameters for a report summary
+type ReportSummaryParams struct {
+	StartDate   time.Time
+	EndDate     time.Time      // Inclusive
+	WorkspaceID *uint          // Personal summary only: limit to one workspace
+	Location    *time.Location // Zone of the dates, days and hours; nil uses the user's or organization's timezone
+}
+
+// ReportSummary represents a dashboard of tracked time over a date range
+type ReportSummary struct {
+	WorkspaceID        *uint                `json:"workspace_id,omitempty"`
+	UserID             *uint                `json:"user_id,omitempty"` // Personal summary
+	StartDate          string               `json:"start_date"`
+	EndDate            string               `json:"end_date"`
+	TotalDuration      int64                `json:"total_duration"` // Seconds
+	TotalDurationHuman string               `json:"total_duration_human"`
+	TimeLogs           int64                `json:"timelogs"`
+	ActiveDays         int                  `json:"active_days"`
+	ActiveUsers        int64                `json:"active_users"`
+	Daily              []ReportDurationStat `json:"daily"`
+	Weekly             []ReportDurationStat `json:"weekly"`  // Labelled by week start
+	Monthly            []ReportDurationStat `json:"monthly"` // Labelled YYYY-MM
+	TopTasks           []ReportTaskStat     `json:"top_tasks"`
+	TopMembers         []ReportMemberStat   `json:"top_members,omitempty"` // Workspace summary
+	Activity           ReportActivity       `json:"activity"`
+
+	// Tracked time spent in private mode, without screenshots; not part of
+	// shared reports
+	PrivateDuration      int64  `json:"private_duration,omitempty"` // Seconds
+	PrivateDurationHuman string `json:"private_duration_human,omitempty"`
+}
+
+// ReportDurationStat represents tracked time in a day, week or month
+type ReportDurationStat struct {
+	Period        string `json:"period" example:"2025-03-10"`
+	Duration      int64  `json:"duration"`
+	DurationHuman string `json:"duration_human"`
+	TimeLogs      int64  `json:"timelogs"`
+}
+
+// ReportTaskStat represents tracked time on a task; time logs without a
+// task are grouped by their title
+type ReportTaskStat struct {
+	TaskID        *uint  `json:"task_id"`
+	Title         string `json:"title"`
+	Duration      int64  `json:"duration"`
+	DurationHuman string `json:"duration_human"`
+	TimeLogs      int64  `json:"timelogs"`
+}
+
+// ReportMemberStat represents a member's tracked time
+type ReportMemberStat struct {
+	UserID        uint   `json:"user_id"`
+	UserName      string `json:"user_name"`
+	Email         string `json:"email"`
+	Duration      int64  `json:"duration"`
+	DurationHuman string `json:"duration_human"`
+	TimeLogs      int64  `json:"timelogs"`
+
+	PrivateDuration int64 `json:"private_duration"` // Seconds in private mode
+}
+
+// ReportActivity represents when time is tracked, by start time in UTC
+type ReportActivity struct {
+	ByHour    []ReportHourStat    `json:"by_hour"`    // 24 hours
+	ByWeekday []ReportWeekdayStat `json:"by_weekday"` // 0=Sunday ... 6=Saturday
+	PeakHour  int                 `json:"peak_hour"`
+}
+
+// ReportHourStat represents tracked time started in an hour of the day
+type ReportHourStat struct {
+	Hour     int   `json:"hour"`
+	Duration int64 `json:"duration"`
+	TimeLogs int64 `json:"timelogs"`
+}
+
+// ReportWeekdayStat represents tracked time started on a day of the week
+type ReportWeekdayStat struct {
+	Weekday  int   `json:"weekday"`
+	Duration int64 `json:"duration"`
+	TimeLogs int64 `json:"timelogs"`
+}
+
+// OrganizationStats is an organization's dashboard overview. Today and this
+// week follow the organization calendar in UTC.
+type OrganizationStats struct {
+	OrganizationID    uint                        `json:"organization_id"`
+	Members           int64                       `json:"members"`
+	ActiveToday       int64                       `json:"active_today"` // Members who tracked time today
+	TrackingNow       int64                       `json:"tracking_now"` // Members with a running timer
+	Workspaces        int64                       `json:"workspaces"`   // Active, not archived
+	WeekStart         string                      `json:"week_start"`
+	WeekEnd           string                      `json:"week_end"` // Inclusive
+	WeekDuration      int64                       `json:"week_duration"`
+	WeekDurationHuman string                      `json:"week_duration_human"`
+	TopWorkspaces     []OrganizationWorkspaceStat `json:"top_workspaces"` // This week
+	Screenshots       int64                       `json:"screenshots"`
+	StorageUsed       int64                       `json:"storage_used"` // Bytes of stored screenshots
+	StorageUsedHuman  string                      `json:"storage_used_human"`
+}
+
+// OrganizationWorkspaceStat is a workspace's tracked time in the organization dashboard
+type OrganizationWorkspaceStat struct {
+	WorkspaceID   uint   `json:"workspace_id"`
+	Name          string `json:"name"`
+	Duration      int64  `json:"duration"` // Seconds
+	DurationHuman string `json:"duration_human"`
+	Members       int64  `json:"members"` // Members who tracked time in it
+}
+
+// CustomReportField describes a dimension or measure of the report builder
+type CustomReportField struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Columns     []string `json:"columns"` // Result columns it adds
+}
+
+// CustomReportOptions lists what the report builder can group by and measure
+type CustomReportOptions struct {
+	Dimensions []CustomReportField `json:"dimensions"`
+	Measures   []CustomReportField `json:"measures"`
+}
+
+// CustomReportRequest represents a report builder query
+type CustomReportRequest struct {
+	Dimensions  []string `json:"dimensions" binding:"required,min=1,max=3" example:"user,week"`
+	Measures    []string `json:"measures" binding:"required,min=1" example:"duration"`
+	StartDate   string   `json:"start_date" example:"2025-03-01"` // Defaults to 30 days before end_date
+	EndDate     string   `json:"end_date" example:"2025-03-31"`   // Inclusive; defaults to today
+	WorkspaceID *uint    `json:"workspace_id"`
+	UserID      *uint    `json:"user_id"`                                           // Only for callers who can see other members
+	Limit       int      `json:"limit"`                                             // Default 100, max 1000
+	Timezone    string   `json:"timezone" binding:"omitempty,max=64,iana_timezone"` // Zone of the dates and of day, week and month; defaults to the organization's
+}
+
+// CustomReportResponse represents the grouped result of a report builder query
+type CustomReportResponse struct {
+	Dimensions []string        `json:"dimensions"`
+	Measures   []string        `json:"measures"`
+	Scope      string          `json:"scope"`    // organization, workspace or self
+	Currency   string          `json:"currency"` // billable_amount is converted to the organization currency
+	Timezone   string          `json:"timezone"` // Of the dates and of day, week and month
+	StartDate  string          `json:"start_date"`
+	EndDate    string          `json:"end_date"`
+	Columns    []string        `json:"columns"`
+	Rows       [][]interface{} `json:"rows"`
+	RowCount   int             `json:"row_count"`
+	Truncated  bool            `json:"truncated"`
+}
+
+// ============================================================================
+// SHARE LINK DTOs
+// ============================================================================
+
+// CreateShareLinkRequest creates a read-only report link for people without an account
+type CreateShareLinkRequest struct {
+	Name          string `json:"name" binding:"max=255" example:"Acme stakeholders"`
+	ExpiresInDays int    `json:"expires_in_days" binding:"omitempty,min=1,max=365"` // Defaults to the organization's share_links.default_expiry_days
+	StartDate     string `json:"start_date" example:"2025-07-01"`                   // YYYY-MM-DD; with end_date, fixes the report range
+	EndDate       string `json:"end_date" example:"2025-07-31"`                     // YYYY-MM-DD, inclusive
+}
+
+// ShareLinkResponse represents a workspace report share link
+type ShareLinkResponse struct {
+	ID           uint       `json:"id"`
+	WorkspaceID  uint       `json:"workspace_id"`
+	Name         string     `json:"name"`
+	URL          string     `json:"url"`        // Anyone with it can view the report until it expires
+	StartDate    *string    `json:"start_date"` // Fixed report range, if any
+	EndDate      *string    `json:"end_date"`
+	ExpiresAt    time.Time  `json:"expires_at"`
+	RevokedAt    *time.Time `json:"revoked_at"`
+	Active       bool       `json:"active"` // Neither expired nor revoked
+	ViewCount    int64      `json:"view_count"`
+	LastViewedAt *time.Time `json:"last_viewed_at"`
+	CreatedBy    uint       `json:"created_by"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// SharedReportResponse is the report a share link shows: tracked hours and
+// tasks, without members or screenshots
+type SharedReportResponse struct {
+	WorkspaceName string        `json:"workspace_name"`
+	Name          string        `json:"name"`
+	ExpiresAt     time.Time     `json:"expires_at"`
+	Report        ReportSummary `json:"report"`
+}
+
+// ============================================================================
+// SAVED REPORT DTOs
+// ============================================================================
+
+// CreateSavedReportRequest represents a saved custom report
+type CreateSavedReportRequest struct {
+	Name        string   `json:"name" binding:"required,max=255"`
+	Dimensions  []string `json:"dimensions" binding:"required,min=1,max=3" example:"user,week"`
+	Measures    []string `json:"measures" binding:"required,min=1" example:"duration"`
+	WorkspaceID *uint    `json:"workspace_id"`
+	UserID      *uint    `json:"user_id"`                                      // Only this member's time
+	RangeDays   int      `json:"range_days" binding:"omitempty,min=1,max=366"` // Days up to yesterday; defaults to 7
+	Format      string   `json:"format" binding:"omitempty,oneof=csv pdf"`     // Defaults to csv
+}
+
+// UpdateSavedReportRequest represents a saved report update; a workspace_id
+// or user_id of 0 removes that filter
+type UpdateSavedReportRequest struct {
+	Name        *string  `json:"name" binding:"omitempty,max=255"`
+	Dimensions  []string `json:"dimensions" binding:"omitempty,min=1,max=3"`
+	Measures    []string `json:"measures" binding:"omitempty,min=1"`
+	WorkspaceID *uint    `json:"workspace_id"`
+	UserID      *uint    `json:"user_id"`
+	RangeDays   *int     `json:"range_days" binding:"omitempty,min=1,max=366"`
+	Format      *string  `json:"format" binding:"omitempty,oneof=csv pdf"`
+}
+
+// SavedReportResponse represents a saved report and its schedules
+type SavedReportResponse struct {
+	ID             uint                     `json:"id"`
+	OrganizationID uint                     `json:"organization_id"`
+	Name           string                   `json:"name"`
+	Dimensions     []string                 `json:"dimensions"`
+	Measures       []string                 `json:"measures"`
+	WorkspaceID    *uint                    `json:"workspace_id"`
+	UserID         *uint                    `json:"user_id"`
+	RangeDays      int                      `json:"range_days"`
+	Format         string                   `json:"format"`
+	Schedules      []ReportScheduleResponse `json:"schedules"`
+	CreatedAt      time.Time                `json:"created_at"`
+	UpdatedAt      time.Time                `json:"updated_at"`
+}
+
+// CreateReportScheduleRequest represents a saved report email schedule
+type CreateReportScheduleRequest struct {
+	Frequency  string   `json:"frequency" binding:"required,oneof=daily weekly"`
+	Weekday    *int     `json:"weekday" binding:"omitempty,min=0,max=6"`           // Weekly only, 0=Sunday; defaults to Monday
+	Hour       *int     `json:"hour" binding:"omitempty,min=0,max=23"`             // Defaults to 8
+	Timezone   string   `json:"timezone" binding:"omitempty,max=50,iana_timezone"` // Defaults to UTC
+	Recipients []string `json:"recipients" binding:"omitempty,max=20,dive,email"`  // Organization members; defaults to you
+	IsActive   *bool    `json:"is_active"`
+}
+
+// UpdateReportScheduleRequest represents a report schedule update
+type UpdateReportScheduleRequest struct {
+	Frequency  *string  `json:"frequency" binding:"omitempty,oneof=daily weekly"`
+	Weekday    *int     `json:"weekday" binding:"omitempty,min=0,max=6"`
+	Hour       *int     `json:"hour" binding:"omitempty,min=0,max=23"`
+	Timezone   *string  `json:"timezone" binding:"omitempty,max=50,iana_timezone"`
+	Recipients []string `json:"recipients" binding:"omitempty,min=1,max=20,dive,email"`
+	IsActive   *bool    `json:"is_active"`
+}
+
+// ReportScheduleResponse represents a saved report email schedule
+type ReportScheduleResponse struct {
+	ID            uint       `json:"id"`
+	SavedReportID uint       `json:"saved_report_id"`
+	Frequency     string     `json:"frequency"`
+	Weekday       int        `json:"weekday"`
+	Hour          int        `json:"hour"`
+	Timezone      string     `json:"timezone"`
+	Recipients    []string   `json:"recipients"`
+	IsActive      bool       `json:"is_active"`
+	NextRunAt     time.Time  `json:"next_run_at"`
+	LastRunAt     *time.Time `json:"last_run_at"`
+	LastError     string     `json:"last_error,omitempty"`
+}
+
+// ============================================================================
+// PAYROLL DTOs
+// ============================================================================
+
+// MemberCostRateResponse represents a member's payroll cost rate
+type MemberCostRateResponse struct {
+	UserID   uint          `json:"user_id"`
+	User     *UserResponse `json:"user,omitempty"`
+	Role     string        `json:"role"`
+	IsActive bool          `json:"is_active"`
+	CostRate *float64      `json:"cost_rate"` // Hourly cost, nil when not set
+	Currency string        `json:"currency"`  // Organization currency
+}
+
+// UpdateCostRateRequest sets a member's hourly cost rate in the organization
+// currency; null clears it
+type UpdateCostRateRequest struct {
+	CostRate *float64 `json:"cost_rate" binding:"omitempty,min=0"`
+}
+
+// PayrollReportParams represents query parameters for the payroll report
+type PayrollReportParams struct {
+	Period    string         // week, month
+	StartDate *time.Time     // Defaults to the current pay period
+	EndDate   *time.Time     // Inclusive; defaults to StartDate
+	Location  *time.Location // Zone of the dates and days; nil uses the organization's timezone
+}
+
+// PayrollReport represents approved hours and their cost per member and pay period
+type PayrollReport struct {
+	OrganizationID   uint               `json:"organization_id"`
+	Period           string             `json:"period"` // week, month
+	StartDate        time.Time          `json:"start_date"`
+	EndDate          time.Time          `json:"end_date"` // Exclusive
+	TotalHours       float64            `json:"total_hours"`
+	TotalCost        float64            `json:"total_cost"`
+	Currency         string             `json:"currency"`           // Of cost rates and costs: the organization currency
+	MissingCostRates int                `json:"missing_cost_rates"` // Members with approved hours but no cost rate
+	Rows             []PayrollReportRow `json:"rows"`
+}
+
+// PayrollReportRow represents one member's approved hours in one pay period
+// for one cost center and project code
+type PayrollReportRow struct {
+	UserID        uint      `json:"user_id"`
+	Email         string    `json:"email"`
+	Name          string    `json:"name"`
+	Period        string    `json:"period"` // Period label, e.g. 2025-03 or 2025-03-10 (week start)
+	PeriodStart   time.Time `json:"period_start"`
+	PeriodEnd     time.Time `json:"period_end"`   // Exclusive
+	CostCenter    string    `json:"cost_center"`  // The task's, else the workspace's; empty when untagged
+	ProjectCode   string    `json:"project_code"` // The task's, else the workspace's; empty when untagged
+	ApprovedHours float64   `json:"approved_hours"`
+	CostRate      *float64  `json:"cost_rate"`
+	Cost          float64   `json:"cost"` // 0 without a cost rate
+}
+
+// LockPeriodRequest closes the pay periods before a date
+type LockPeriodRequest struct {
+	LockedBefore string `json:"locked_before" binding:"required" example:"2025-04-01"` // YYYY-MM-DD; cannot move an existing lock back
+}
+
+// ReopenPeriodRequest moves the lock date back, or removes the lock when
+// locked_before is empty
+type ReopenPeriodRequest struct {
+	LockedBefore string `json:"locked_before" example:"2025-03-01"` // YYYY-MM-DD, optional
+	Reason       string `json:"reason" binding:"required,max=500"`
+}
+
+// PeriodLockResponse represents an organization's closed pay periods
+type PeriodLockResponse struct {
+	OrganizationID uint       `json:"organization_id"`
+	LockedBefore   *time.Time `json:"locked_before"` // Time logs starting before it are locked; nil when no period is closed
+}
+
+// ============================================================================
+// CLIENT DTOs
+// ============================================================================
+
+// CreateClientRequest represents client creation request
+type CreateClientRequest struct {
+	Name         string `json:"name" binding:"required,min=1,max=255"`
+	ContactName  string `json:"contact_name" binding:"max=255"`
+	ContactEmail string `json:"contact_email" binding:"omitempty,email,max=255"`
+	Notes        string `json:"notes"`
+}
+
+// UpdateClientRequest represents client update request
+type UpdateClientRequest struct {
+	Name         *string `json:"name" binding:"omitempty,min=1,max=255"`
+	ContactName  *string `json:"contact_name" binding:"omitempty,max=255"`
+	ContactEmail *string `json:"contact_email" binding:"omitempty,email,max=255"`
+	Notes        *string `json:"notes"`
+}
+
+// ClientResponse represents a client in responses
+type ClientResponse struct {
+	ID             uint                      `json:"id"`
+	OrganizationID uint                      `json:"organization_id"`
+	Name           string                    `json:"name"`
+	ContactName    string                    `json:"contact_name"`
+	ContactEmail   string                    `json:"contact_email"`
+	Notes          string                    `json:"notes"`
+	WorkspaceCount int64                     `json:"workspace_count"`
+	Workspaces     []ClientWorkspaceResponse `json:"workspaces,omitempty"` // Only on single client requests
+	CreatedAt      time.Time                 `json:"created_at"`
+	UpdatedAt      time.Time                 `json:"updated_at"`
+}
+
+// ClientWorkspaceResponse represents a workspace of a client
+type ClientWorkspaceResponse struct {
+	ID         uint    `json:"id"`
+	Name       string  `json:"name"`
+	Slug       string  `json:"slug"`
+	IsActive   bool    `json:"is_active"`
+	IsBillable bool    `json:"is_billable"`
+	HourlyRate float64 `json:"hourly_rate"`
+	Currency   string  `json:"currency"`
+}
+
+// ============================================================================
+// INVOICE DTOs
+// ============================================================================
+
+// CreateInvoiceRequest bills the approved, uninvoiced time of billable
+// workspaces started in a date range
+type CreateInvoiceRequest struct {
+	StartDate   string `json:"start_date" binding:"required" example:"2025-07-01"` // YYYY-MM-DD
+	EndDate     string `json:"end_date" binding:"required" example:"2025-07-31"`   // YYYY-MM-DD, inclusive
+	ClientID    *uint  `json:"client_id"`                                          // Only the client's workspaces
+	WorkspaceID *uint  `json:"workspace_id"`                                       // Only this workspace
+	DueDate     string `json:"due_date" example:"2025-08-31"`                      // YYYY-MM-DD; defaults to the organization's payment terms
+	Notes       string `json:"notes" binding:"max=5000"`
+}
+
+// PayInvoiceRequest marks an invoice paid
+type PayInvoiceRequest struct {
+	PaidAt *time.Time `json:"paid_at"` // Defaults to now
+}
+
+// InvoiceListParams represents invoice list query parameters
+type InvoiceListParams struct {
+	Status string `form:"status" binding:"omitempty,oneof=draft sent paid"`
+}
+
+// InvoiceResponse represents an invoice
+type InvoiceResponse struct {
+	ID             uint                      `json:"id"`
+	OrganizationID uint                      `json:"organization_id"`
+	Number         string                    `json:"number"`
+	ClientID       *uint                     `json:"client_id"`
+	BillTo         string                    `json:"bill_to"`
+	WorkspaceID    *uint                     `json:"workspace_id"`
+	Status         string                    `json:"status"` // draft, sent, paid
+	Currency       string                    `json:"currency"`
+	PeriodStart    string                    `json:"period_start"` // YYYY-MM-DD
+	PeriodEnd      string                    `json:"period_end"`   // YYYY-MM-DD, inclusive
+	DueDate        *string                   `json:"due_date"`     // YYYY-MM-DD
+	Total          float64                   `json:"total"`
+	Notes          string                    `json:"notes"`
+	CreatedBy      uint                      `json:"created_by"`
+	SentAt         *time.Time                `json:"sent_at"`
+	PaidAt         *time.Time                `json:"paid_at"`
+	LineItems      []InvoiceLineItemResponse `json:"line_items,omitempty"` // Only on single invoice requests
+	CreatedAt      time.Time                 `json:"created_at"`
+	UpdatedAt      time.Time                 `json:"updated_at"`
+}
+
+// InvoiceLineItemResponse represents the billed time of one workspace with
+// one cost center and project code
+type InvoiceLineItemResponse struct {
+	WorkspaceID  uint    `json:"workspace_id"`
+	Description  string  `json:"description"`
+	CostCenter   string  `json:"cost_center"`
+	ProjectCode  string  `json:"project_code"`
+	Hours        float64 `json:"hours"`
+	TimeLogCount int     `json:"time_log_count"`
+	UnitPrice    float64 `json:"unit_price"` // Hourly rate in the invoice currency
+	Amount       float64 `json:"amount"`
+}
+
+// ============================================================================
+// HOLIDAY DTOs
+// ============================================================================
+
+// HolidayResponse represents a holiday in an organization's calendar
+type HolidayResponse struct {
+	ID        uint      `json:"id"`
+	Date      string    `json:"date" example:"2025-12-25"`
+	Name      string    `json:"name" example:"Christmas Day"`
+	Country   string    `json:"country" example:"GB"` // Preset country, empty for custom dates
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CreateHolidayRequest adds a custom holiday
+type CreateHolidayRequest struct {
+	Date string `json:"date" binding:"required" example:"2025-12-24"` // YYYY-MM-DD
+	Name string `json:"name" binding:"required,max=255" example:"Christmas Eve"`
+}
+
+// ImportHolidayPresetRequest adds a country's public holidays for a year
+type ImportHolidayPresetRequest struct {
+	Country string `json:"country" binding:"required,len=2" example:"US"`
+	Year    int    `json:"year" binding:"required,min=2000,max=2100" example:"2025"`
+}
+
+// ImportHolidayPresetResponse represents the result of a preset import
+type ImportHolidayPresetResponse struct {
+	Country  string            `json:"country"`
+	Year     int               `json:"year"`
+	Added    int               `json:"added"`
+	Skipped  int               `json:"skipped"` // Dates that already had a holiday
+	Holidays []HolidayResponse `json:"holidays"`
+}
+
+// HolidayPresetResponse represents a country with a holiday preset
+type HolidayPresetResponse struct {
+	Country string `json:"country" example:"US"`
+	Name    string `json:"name" example:"United States (federal)"`
+}
+
+// ============================================================================
+// LEAVE DTOs
+// ============================================================================
+
+// CreateLeaveRequest represents a member's time-off request
+type CreateLeaveRequest struct {
+	Type      string `json:"type" binding:"required,oneof=vacation sick other" example:"vacation"`
+	StartDate string `json:"start_date" binding:"required" example:"2025-07-14"` // YYYY-MM-DD
+	EndDate   string `json:"end_date" binding:"required" example:"2025-07-18"`   // YYYY-MM-DD, inclusive
+	Reason    string `json:"reason" binding:"max=2000"`
+}
+
+// ReviewLeaveRequest represents an admin's decision on a leave request
+type ReviewLeaveRequest struct {
+	Note string `json:"note" binding:"max=2000"` // Required when rejecting
+}
+
+// LeaveRequestListParams represents query parameters for leave request lists
+type LeaveRequestListParams struct {
+	Page    int
+	PerPage int
+	Status  string
+	UserID  *uint
+}
+
+// LeaveRequestResponse represents a leave request in responses
+type LeaveRequestResponse struct {
+	ID             uint          `json:"id"`
+	OrganizationID uint          `json:"organization_id"`
+	UserID         uint          `json:"user_id"`
+	User           *UserResponse `json:"user,omitempty"`
+	Type           string        `json:"type" example:"vacation"`
+	StartDate      string        `json:"start_date" example:"2025-07-14"`
+	EndDate        string        `json:"end_date" example:"2025-07-18"` // Inclusive
+	WorkingDays    int           `json:"working_days" example:"5"`      // Working days of the organization calendar in the range
+	Reason         string        `json:"reason"`
+	Status         string        `json:"status" example:"pending"`
+	ReviewedBy     *uint         `json:"reviewed_by"`
+	Reviewer       *UserResponse `json:"reviewer,omitempty"`
+	ReviewedAt     *time.Time    `json:"reviewed_at"`
+	ReviewNote     string        `json:"review_note"`
+	CreatedAt      time.Time     `json:"created_at"`
+}
+
+// TeamLeaveCalendarParams represents query parameters for the team leave calendar
+type TeamLeaveCalendarParams struct {
+	StartDate   *time.Time // Defaults to today
+	EndDate     *time.Time // Inclusive; defaults to 4 weeks after StartDate
+	WorkspaceID *uint      // Restrict to the workspace's members
+}
+
+// TeamLeaveCalendar represents who is on approved leave on each day of a range
+type TeamLeaveCalendar struct {
+	OrganizationID uint                   `json:"organization_id"`
+	StartDate      string                 `json:"start_date"`
+	EndDate        string                 `json:"end_date"` // Inclusive
+	Days           []TeamLeaveCalendarDay `json:"days"`
+}
+
+// TeamLeaveCalendarDay represents the members on leave on one day
+type TeamLeaveCalendarDay struct {
+	Date         string                   `json:"date" example:"2025-07-14"`
+	IsWorkingDay bool                     `json:"is_working_day"` // False on weekends and holidays
+	Holiday      string                   `json:"holiday,omitempty"`
+	OnLeave      []TeamLeaveCalendarEntry `json:"on_leave"`
+}
+
+// TeamLeaveCalendarEntry represents one member's leave on a calendar day
+type TeamLeaveCalendarEntry struct {
+	RequestID uint   `json:"request_id"`
+	UserID    uint   `json:"user_id"`
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+}
+
+// ============================================================================
+// ORGANIZATION WEBHOOKS
+// ============================================================================
+
+// CreateWebhookRequest represents a webhook creation request
+type CreateWebhookRequest struct {
+	URL         string   `json:"url" binding:"required,url,max=500"`
+	Events      []string `json:"events" binding:"required,min=1,dive,oneof=member.joined member.removed member.role_changed member.hour_cap_approaching member.hour_cap_exceeded workspace.budget_threshold"`
+	Description string   `json:"description" binding:"max=255"`
+}
+
+// UpdateWebhookRequest represents a webhook update request
+type UpdateWebhookRequest struct {
+	URL         string   `json:"url" binding:"omitempty,url,max=500"`
+	Events      []string `json:"events" binding:"omitempty,min=1,dive,oneof=member.joined member.removed member.role_changed member.hour_cap_approaching member.hour_cap_exceeded workspace.budget_threshold"`
+	Description *string  `json:"description" binding:"omitempty,max=255"`
+	IsActive    *bool    `json:"is_active"`
+}
+
+// WebhookResponse represents an organization webhook
+type WebhookResponse struct {
+	ID             uint       `json:"id"`
+	OrganizationID uint       `json:"organization_id"`
+	URL            string     `json:"url"`
+	Events         []string   `json:"events"`
+	Description    string     `json:"description"`
+	IsActive       bool       `json:"is_active"`
+	Secret         string     `json:"secret,omitempty"` // Only returned on creation and rotation
+	LastDeliveryAt *time.Time `json:"last_delivery_at"`
+	CreatedBy      uint       `json:"created_by"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// WebhookDeliveryResponse represents one delivery of an event to a webhook
+type WebhookDeliveryResponse struct {
+	ID             uint            `json:"id"`
+	EventID        string          `json:"event_id"`
+	Event          string          `json:"event"`
+	Status         string          `json:"status"` // pending, succeeded, failed
+	Attempts       int             `json:"attempts"`
+	ResponseStatus int             `json:"response_status"`
+	Error          string          `json:"error,omitempty"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at"`
+	ReplayOf       *uint           `json:"replay_of"`
+	Payload        json.RawMessage `json:"payload"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// WebhookDeliveryListParams represents delivery history query parameters
+type WebhookDeliveryListParams struct {
+	Page    int    `form:"page"`
+	PerPage int    `form:"per_page"`
+	Status  string `form:"status"`
+	Event   string `form:"event"`
+}
+
+// ReplayWebhookRequest selects deliveries to send again. Either list delivery
+// IDs or give a time window; by default only failed deliveries are replayed.
+type ReplayWebhookRequest struct {
+	DeliveryIDs      []uint     `json:"delivery_ids" binding:"omitempty,max=500"`
+	Since            *time.Time `json:"since"`
+	Until            *time.Time `json:"until"`
+	Event            string     `json:"event" binding:"omitempty,oneof=member.joined member.removed member.role_changed member.hour_cap_approaching member.hour_cap_exceeded workspace.budget_threshold"`
+	IncludeSucceeded bool       `json:"include_succeeded"`
+}
+
+// ReplayWebhookResponse represents the result of a replay request
+type ReplayWebhookResponse struct {
+	Replayed int `json:"replayed"`
+}
+
+// WebhookEventPayload is the JSON body POSTed to webhook endpoints
+type WebhookEventPayload struct {
+	ID           string                   `json:"id"` // Stable across retries and replays
+	Event        string                   `json:"event"`
+	OccurredAt   time.Time                `json:"occurred_at"`
+	Organization WebhookOrganizationInfo  `json:"organization"`
+	Member       WebhookMemberInfo        `json:"member"`
+	Changes      map[string]WebhookChange `json:"changes,omitempty"`
+	HourCap      *WebhookHourCapInfo      `json:"hour_cap,omitempty"` // Only set for hour cap events
+	Budget       *WebhookBudgetInfo       `json:"budget,omitempty"`   // Only set for budget events, whose member is the workspace admin
+	Actor        *WebhookActorInfo        `json:"actor"`              // Nil when the member acted themselves or the system did
+	Source       string                   `json:"source"`             // admin, invitation, invite_code, registration, ownership_transfer, hour_cap, budget, leave
+}
+
+// WebhookOrganizationInfo identifies the organization in webhook payloads
+type WebhookOrganizationInfo struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// WebhookMemberInfo describes the member in webhook payloads
+type WebhookMemberInfo struct {
+	UserID     uint                     `json:"user_id"`
+	Email      string                   `json:"email"`
+	FirstName  string                   `json:"first_name"`
+	LastName   string                   `json:"last_name"`
+	Role       string                   `json:"role"`
+	IsActive   bool                     `json:"is_active"`
+	JoinedAt   time.Time                `json:"joined_at"`
+	InvitedBy  *uint                    `json:"invited_by"`
+	Workspaces []WebhookWorkspaceMember `json:"workspaces"`
+}
+
+// WebhookWorkspaceMember describes a member's workspace assignment in webhook payloads
+type WebhookWorkspaceMember struct {
+	WorkspaceID uint   `json:"workspace_id"`
+	Name        string `json:"name"`
+	Role        string `json:"role"`
+	IsAdmin     bool   `json:"is_admin"`
+}
+
+// WebhookHourCapInfo describes a member's weekly hours in hour cap webhook payloads
+type WebhookHourCapInfo struct {
+	WorkspaceID   uint      `json:"workspace_id"`
+	WorkspaceName string    `json:"workspace_name"`
+	WeekStart     time.Time `json:"week_start"`
+	WeeklyHourCap float64   `json:"weekly_hour_cap"`
+	LoggedHours   float64   `json:"logged_hours"`
+	Enforcement   string    `json:"enforcement"`
+}
+
+// WebhookBudgetInfo describes a workspace's budget consumption in budget webhook payloads
+type WebhookBudgetInfo struct {
+	WorkspaceID   uint    `json:"workspace_id"`
+	WorkspaceName string  `json:"workspace_name"`
+	BudgetType    string  `json:"budget_type"`
+	BudgetAmount  float64 `json:"budget_amount"`
+	Currency      string  `json:"currency"` // Of money budgets
+	Consumed      float64 `json:"consumed"`
+	PercentUsed   float64 `json:"percent_used"`
+	Threshold     int     `json:"threshold"` // Highest threshold crossed
+}
+
+// WebhookChange describes a changed field in webhook payloads
+type WebhookChange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// WebhookActorInfo identifies who triggered the event in webhook payloads
+type WebhookActorInfo struct {
+	UserID uint   `json:"user_id"`
+	Email  string `json:"email"`
+	Name   string `json:"name"`
+}
+
+// SaveSlackIntegrationRequest configures an organization's Slack
+// notifications. webhook_url connects through a pasted incoming webhook;
+// omit it to only change the events of an existing integration.
+type SaveSlackIntegrationRequest struct {
+	WebhookURL string   `json:"webhook_url" binding:"omitempty,url,max=500"` // https://hooks.slack.com/services/...
+	Events     []string `json:"events" binding:"required,min=1,dive,oneof=member.joined timelog.approved timelog.rejected daily_summary"`
+	IsActive   *bool    `json:"is_active"`
+}
+
+// SlackOAuthURLResponse is the Slack page that installs the app into a channel
+type SlackOAuthURLResponse struct {
+	URL string `json:"url"`
+}
+
+// CompleteSlackOAuthRequest carries the code and state Slack redirected back with
+type CompleteSlackOAuthRequest struct {
+	Code   string   `json:"code" binding:"required"`
+	State  string   `json:"state" binding:"required"`
+	Events []string `json:"events" binding:"omitempty,dive,oneof=member.joined timelog.approved timelog.rejected daily_summary"` // Defaults to all events
+}
+
+// SlackIntegrationResponse represents an organization's Slack integration.
+// The webhook URL is a secret and is never returned.
+type SlackIntegrationResponse struct {
+	ID             uint       `json:"id"`
+	OrganizationID uint       `json:"organization_id"`
+	Channel        string     `json:"channel,omitempty"`
+	TeamName       string     `json:"team_name,omitempty"`
+	InstalledVia   string     `json:"installed_via"` // webhook or oauth
+	Events         []string   `json:"events"`
+	IsActive       bool       `json:"is_active"`
+	CreatedBy      uint       `json:"created_by"`
+	LastPostedAt   *time.Time `json:"last_posted_at"`
+	LastError      string     `json:"last_error,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// OrganizationExportResponse represents an organization data export
+type OrganizationExportResponse struct {
+	ID                   uint       `json:"id"`
+	OrganizationID       uint       `json:"organization_id"`
+	RequestedBy          uint       `json:"requested_by"`
+	OperationID          *uint      `json:"operation_id,omitempty"`
+	Status               string     `json:"status"`   // pending, processing, completed, failed
+	Progress             int        `json:"progress"` // 0-100
+	FileName             string     `json:"file_name,omitempty"`
+	FileSize             int64      `json:"file_size"`
+	Error                string     `json:"error,omitempty"`
+	DownloadURL          string     `json:"download_url,omitempty"` // Signed link, usable without a token until download_url_expires_at
+	DownloadURLExpiresAt *time.Time `json:"download_url_expires_at,omitempty"`
+	CreatedAt            time.Time  `json:"created_at"`
+	CompletedAt          *time.Time `json:"completed_at"`
+	ExpiresAt            *time.Time `json:"expires_at"`
+}
+
+// OrganizationImportRequest holds the form fields of a CSV time data import
+type OrganizationImportRequest struct {
+	Source      string `form:"source" binding:"omitempty,oneof=auto hubstaff toggl clockify"` // Defaults to auto-detection from the CSV header
+	WorkspaceID *uint  `form:"workspace_id"`                                                  // Workspace for imported tasks and time logs
+	Timezone    string `form:"timezone"`                                                      // IANA zone of the CSV's dates and times, default UTC
+	DryRun      bool   `form:"dry_run"`                                                       // Validate rows without importing them
+}
+
+// OrganizationImportResponse reports the outcome of a CSV import
+type OrganizationImportResponse struct {
+	Source          string                       `json:"source"`
+	DryRun          bool                         `json:"dry_run"`
+	TotalRows       int                          `json:"total_rows"`
+	Imported        int                          `json:"imported"`   // Time logs created (or that would be, in a dry run)
+	Duplicates      int                          `json:"duplicates"` // Rows already imported before
+	Failed          int                          `json:"failed"`
+	TasksCreated    int                          `json:"tasks_created"`
+	Errors          []OrganizationImportRowError `json:"errors"`
+	ErrorsTruncated bool                         `json:"errors_truncated"` // More rows failed than are listed
+}
+
+// OrganizationImportRowError describes why one CSV row was not imported
+type OrganizationImportRowError struct {
+	Line  int    `json:"line"` // Line in the CSV file, header is line 1
+	Error string `json:"error"`
+}
@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 // ============================================================================
 // SYSTEM ADMIN DTOs
 // ============================================================================
@@ -23,3 +25,21 @@ type InitAdminResponse struct {
 type CheckAdminExistsResponse struct {
 	Exists bool `json:"exists"`
 }
+
+// ============================================================================
+// HEALTH PROBE DTOs
+// ============================================================================
+
+// HealthResponse represents a liveness or readiness probe result
+type HealthResponse struct {
+	Status string                       `json:"status"` // ok, unavailable, shutting_down
+	Checks map[string]HealthCheckResult `json:"checks,omitempty"`
+	Time   time.Time                    `json:"time"`
+}
+
+// HealthCheckResult represents the result of a single dependency check
+type HealthCheckResult struct {
+	Status    string `json:"status"` // ok, failed
+	LatencyMs int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
@@ -11,6 +11,8 @@ type UpdateCheckRequest struct {
 	CurrentVersion string `json:"current_version" binding:"required"` // Current app version (e.g., "1.0.0")
 	Platform       string `json:"platform" binding:"required"`        // darwin, win32, linux
 	Arch           string `json:"arch" binding:"required"`            // x64, arm64, ia32
+	Channel        string `json:"channel" binding:"omitempty,oneof=stable beta"`
+	OrganizationID *uint  `json:"organization_id"` // The organization's pinned channel overrides Channel
 }
 
 // UpdateCheckResponse represents the response for update check
@@ -19,17 +21,23 @@ type UpdateCheckResponse struct {
 	LatestVersion   string         `json:"latest_version,omitempty"`
 	ReleaseDate     *time.Time     `json:"release_date,omitempty"`
 	ReleaseNotes    string         `json:"release_notes,omitempty"`
-	IsMandatory     bool           `json:"is_mandatory,omitempty"`
+	IsMandatory     bool           `json:"is_mandatory,omitempty"`    // The current version is below MinimumVersion
+	MinimumVersion  string         `json:"minimum_version,omitempty"` // Oldest version the channel still supports
+	Channel         string         `json:"channel"`
 	Files           []ReleaseAsset `json:"files,omitempty"`
 }
 
 // ReleaseAsset represents a downloadable file from the release
 type ReleaseAsset struct {
 	Name        string `json:"name"`         // Filename (e.g., "Remote Time Tracker-1.0.1.dmg")
-	URL         string `json:"url"`          // Direct download URL (proxied through backend)
+	URL         string `json:"url"`          // Download URL (proxied through backend for GitHub releases)
 	Size        int64  `json:"size"`         // File size in bytes
 	ContentType string `json:"content_type"` // MIME type
 	SHA512      string `json:"sha512,omitempty"`
+	Kind        string `json:"kind,omitempty"`         // installer, blockmap, delta
+	FromVersion string `json:"from_version,omitempty"` // Version a delta applies to
+	Platform    string `json:"platform,omitempty"`     // Set for published releases
+	Arch        string `json:"arch,omitempty"`
 }
 
 // GHRelease represents GitHub release response (internal use)
@@ -91,6 +99,50 @@ type ReleaseNotesResponse struct {
 // PublicDownloadsResponse is alias for PublicDownloadResponse for swagger
 type PublicDownloadsResponse = PublicDownloadResponse
 
+// ============================================================
+// Release Publishing DTOs (admin)
+// ============================================================
+
+// PublishReleaseAssetRequest describes a hosted file of a published release
+type PublishReleaseAssetRequest struct {
+	Name        string `json:"name" binding:"required,max=255"`
+	Kind        string `json:"kind" binding:"omitempty,oneof=installer blockmap delta"` // Defaults to installer
+	FromVersion string `json:"from_version" binding:"required_if=Kind delta,max=50"`    // Version a delta applies to
+	Platform    string `json:"platform" binding:"required,oneof=darwin win32 linux"`
+	Arch        string `json:"arch" binding:"omitempty,oneof=x64 arm64 ia32"` // Empty for all architectures
+	URL         string `json:"url" binding:"required,url"`
+	Size        int64  `json:"size" binding:"min=0"`
+	ContentType string `json:"content_type" binding:"max=100"`
+	SHA512      string `json:"sha512" binding:"required,max=128"` // Base64 SHA-512, verified by the app after download
+}
+
+// PublishReleaseRequest represents a request to publish a desktop app release
+type PublishReleaseRequest struct {
+	Version      string                       `json:"version" binding:"required,max=50"`
+	Channel      string                       `json:"channel" binding:"omitempty,oneof=stable beta"` // Defaults to stable
+	ReleaseNotes string                       `json:"release_notes"`
+	IsMandatory  bool                         `json:"is_mandatory"` // Clients below this version must upgrade
+	Assets       []PublishReleaseAssetRequest `json:"assets" binding:"required,min=1,dive"`
+}
+
+// AppReleaseResponse represents a published desktop app release
+type AppReleaseResponse struct {
+	ID           uint           `json:"id"`
+	Version      string         `json:"version"`
+	Channel      string         `json:"channel"`
+	ReleaseNotes string         `json:"release_notes"`
+	IsMandatory  bool           `json:"is_mandatory"`
+	PublishedBy  uint           `json:"published_by"`
+	PublishedAt  time.Time      `json:"published_at"`
+	Assets       []ReleaseAsset `json:"assets"`
+}
+
+// UpgradeRequiredDetails is the detail of an upgrade_required error
+type UpgradeRequiredDetails struct {
+	CurrentVersion string `json:"current_version"`
+	MinimumVersion string `json:"minimum_version"`
+}
+
 // ============================================================
 // Public Download DTOs (for website)
 // ============================================================
@@ -112,3 +164,54 @@ type PlatformDownload struct {
 	Size        int64  `json:"size"`         // File size in bytes
 	ContentType string `json:"content_type"` // MIME type
 }
+
+// ============================================================
+// Crash Telemetry DTOs
+// ============================================================
+
+// CrashReportItem represents a single crash or error report from the desktop app
+type CrashReportItem struct {
+	DeviceUUID  string     `json:"device_uuid"`
+	AppVersion  string     `json:"app_version" binding:"required,max=50"`
+	Platform    string     `json:"platform" binding:"max=20"` // darwin, win32, linux
+	OSVersion   string     `json:"os_version" binding:"max=50"`
+	Arch        string     `json:"arch" binding:"max=20"`
+	ProcessType string     `json:"process_type" binding:"max=20"` // main, renderer
+	ErrorType   string     `json:"error_type" binding:"max=255"`
+	Message     string     `json:"message"`
+	StackHash   string     `json:"stack_hash" binding:"max=64"` // Computed from stack_trace when empty
+	StackTrace  string     `json:"stack_trace"`
+	IsFatal     bool       `json:"is_fatal"`
+	OccurredAt  *time.Time `json:"occurred_at"` // Defaults to the time of receipt
+}
+
+// CrashReportBatchRequest represents crash reports queued by the desktop app
+type CrashReportBatchRequest struct {
+	Reports []CrashReportItem `json:"reports" binding:"required,min=1,max=50,dive"`
+}
+
+// CrashReportBatchResponse represents the result of a crash report upload
+type CrashReportBatchResponse struct {
+	Accepted int `json:"accepted"`
+}
+
+// UsageEventItem represents a single feature usage event from a client
+type UsageEventItem struct {
+	Feature        string     `json:"feature" binding:"required,max=50"` // manual_task, invoicing, kanban, ...
+	Action         string     `json:"action" binding:"max=50"`
+	OrganizationID *uint      `json:"organization_id"`           // Organization the feature was used in
+	Platform       string     `json:"platform" binding:"max=20"` // web, darwin, win32, linux
+	AppVersion     string     `json:"app_version" binding:"max=50"`
+	OccurredAt     *time.Time `json:"occurred_at"` // Defaults to the time of receipt
+}
+
+// UsageEventBatchRequest represents feature usage events queued by a client
+type UsageEventBatchRequest struct {
+	Events []UsageEventItem `json:"events" binding:"required,min=1,max=100,dive"`
+}
+
+// UsageEventBatchResponse represents the result of a usage event upload
+type UsageEventBatchResponse struct {
+	Accepted int `json:"accepted"`
+	Dropped  int `json:"dropped"` // Events of opted-out or foreign organizations
+}
@@ -0,0 +1,126 @@
+package format
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Locale identifies the language used for human-readable output
+type Locale string
+
+// Supported locales
+const (
+	LocaleEN Locale = "en"
+	LocaleVI Locale = "vi"
+)
+
+// DefaultLocale is used when no supported locale is requested
+const DefaultLocale = LocaleEN
+
+// localeSpec holds the locale-specific tokens used when formatting
+type localeSpec struct {
+	decimalSep string
+	hour       string
+	minute     string
+	second     string
+	separator  string // between duration parts, e.g. "2h 5m"
+	unitSpace  bool   // whether a space goes between number and duration unit
+}
+
+var locales = map[Locale]localeSpec{
+	LocaleEN: {decimalSep: ".", hour: "h", minute: "m", second: "s", separator: " "},
+	LocaleVI: {decimalSep: ",", hour: "giờ", minute: "phút", second: "giây", separator: " ", unitSpace: true},
+}
+
+var byteUnits = []string{"B", "KB", "MB", "GB", "TB", "PB", "EB"}
+
+// ParseLocale resolves a locale from a query value or an Accept-Language header
+// (e.g. "vi-VN,vi;q=0.9,en;q=0.8"). Unsupported values fall back to DefaultLocale.
+func ParseLocale(s string) Locale {
+	for _, part := range strings.Split(s, ",") {
+		tag := strings.TrimSpace(strings.SplitN(part, ";", 2)[0])
+		lang := strings.ToLower(strings.SplitN(strings.ReplaceAll(tag, "_", "-"), "-", 2)[0])
+		if _, ok := locales[Locale(lang)]; ok {
+			return Locale(lang)
+		}
+	}
+	return DefaultLocale
+}
+
+func specFor(loc Locale) localeSpec {
+	if spec, ok := locales[loc]; ok {
+		return spec
+	}
+	return locales[DefaultLocale]
+}
+
+// Bytes formats a byte count using binary (1024) units, e.g. "1.5 MB"
+func Bytes(b int64, loc Locale) string {
+	spec := specFor(loc)
+
+	sign := ""
+	if b < 0 {
+		sign = "-"
+		b = -b
+	}
+
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%s%d B", sign, b)
+	}
+
+	value := float64(b)
+	exp := 0
+	for value >= unit && exp < len(byteUnits)-1 {
+		value /= unit
+		exp++
+	}
+
+	number := strconv.FormatFloat(value, 'f', 1, 64)
+	number = strings.TrimSuffix(number, ".0")
+	number = strings.Replace(number, ".", spec.decimalSep, 1)
+
+	return sign + number + " " + byteUnits[exp]
+}
+
+// Duration formats a number of seconds as a compact human-readable duration,
+// e.g. "2h 15m" or "45s". At most two significant parts are shown.
+func Duration(seconds int64, loc Locale) string {
+	spec := specFor(loc)
+
+	sign := ""
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+
+	hours := seconds / 3600
+	minutes := (seconds % 3600) / 60
+	secs := seconds % 60
+
+	part := func(n int64, unit string) string {
+		if spec.unitSpace {
+			return fmt.Sprintf("%d %s", n, unit)
+		}
+		return fmt.Sprintf("%d%s", n, unit)
+	}
+
+	var parts []string
+	switch {
+	case hours > 0:
+		parts = append(parts, part(hours, spec.hour))
+		if minutes > 0 {
+			parts = append(parts, part(minutes, spec.minute))
+		}
+	case minutes > 0:
+		parts = append(parts, part(minutes, spec.minute))
+		if secs > 0 {
+			parts = append(parts, part(secs, spec.second))
+		}
+	default:
+		parts = append(parts, part(secs, spec.second))
+	}
+
+	return sign + strings.Join(parts, spec.separator)
+}
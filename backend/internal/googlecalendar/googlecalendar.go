@@ -0,0 +1,185 @@
+// Package googlecalendar is a minimal Google Calendar API (v3) client covering
+// what pushing time logs needs: the OAuth code and refresh token grants and
+// event creation.
+package googlecalendar
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Google endpoints; variables so they can point elsewhere in development
+var (
+	AuthorizeURL = "https://accounts.google.com/o/oauth2/v2/auth"
+	TokenURL     = "https://oauth2.googleapis.com/token"
+	APIURL       = "https://www.googleapis.com/calendar/v3"
+)
+
+// Scope allows creating events without reading the rest of the calendar
+const Scope = "https://www.googleapis.com/auth/calendar.events"
+
+// maxErrorBody caps how much of an error response is kept
+const maxErrorBody = 1024
+
+// APIError is a non-success response from Google
+type APIError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("google %s %s: %d %s: %s", e.Method, e.Path, e.StatusCode, http.StatusText(e.StatusCode), e.Body)
+}
+
+// Revoked reports whether Google no longer accepts the grant, e.g. because
+// the user removed the app's access
+func (e *APIError) Revoked() bool {
+	return e.StatusCode == http.StatusUnauthorized ||
+		(e.StatusCode == http.StatusBadRequest && strings.Contains(e.Body, "invalid_grant"))
+}
+
+// Client calls Google on behalf of the app's OAuth client
+type Client struct {
+	clientID     string
+	clientSecret string
+	redirectURL  string
+	httpClient   *http.Client
+}
+
+// NewClient creates a client for the OAuth client
+func NewClient(clientID, clientSecret, redirectURL string, timeout time.Duration) *Client {
+	return &Client{
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		redirectURL:  redirectURL,
+		httpClient:   &http.Client{Timeout: timeout},
+	}
+}
+
+// Token is an OAuth token. RefreshToken is only set by the code exchange.
+type Token struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"` // Seconds
+}
+
+// Expiry returns when the access token expires, a minute early to allow for
+// clock skew and slow requests
+func (t *Token) Expiry(now time.Time) time.Time {
+	return now.Add(time.Duration(t.ExpiresIn)*time.Second - time.Minute)
+}
+
+// Event is a calendar event
+type Event struct {
+	ID          string    `json:"id,omitempty"`
+	Summary     string    `json:"summary"`
+	Description string    `json:"description,omitempty"`
+	Start       EventTime `json:"start"`
+	End         EventTime `json:"end"`
+}
+
+// EventTime is the start or end of a timed event
+type EventTime struct {
+	DateTime string `json:"dateTime"` // RFC 3339
+}
+
+// NewEventTime formats t for an event
+func NewEventTime(t time.Time) EventTime {
+	return EventTime{DateTime: t.UTC().Format(time.RFC3339)}
+}
+
+// AuthCodeURL returns the consent page that redirects back with a code and
+// state. Offline access with forced consent makes Google return a refresh token.
+func (c *Client) AuthCodeURL(state string) string {
+	query := url.Values{
+		"client_id":     {c.clientID},
+		"redirect_uri":  {c.redirectURL},
+		"response_type": {"code"},
+		"scope":         {Scope},
+		"access_type":   {"offline"},
+		"prompt":        {"consent"},
+		"state":         {state},
+	}
+	return AuthorizeURL + "?" + query.Encode()
+}
+
+// Exchange trades an authorization code for tokens
+func (c *Client) Exchange(ctx context.Context, code string) (*Token, error) {
+	return c.token(ctx, url.Values{
+		"grant_type":   {"authorization_code"},
+		"code":         {code},
+		"redirect_uri": {c.redirectURL},
+	})
+}
+
+// Refresh gets a new access token
+func (c *Client) Refresh(ctx context.Context, refreshToken string) (*Token, error) {
+	return c.token(ctx, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+	})
+}
+
+func (c *Client) token(ctx context.Context, form url.Values) (*Token, error) {
+	form.Set("client_id", c.clientID)
+	form.Set("client_secret", c.clientSecret)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var token Token
+	if err := c.send(req, "/token", &token); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// InsertEvent creates an event and returns its ID
+func (c *Client) InsertEvent(ctx context.Context, accessToken, calendarID string, event Event) (string, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return "", err
+	}
+
+	path := "/calendars/" + url.PathEscape(calendarID) + "/events"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, APIURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	var created Event
+	if err := c.send(req, path, &created); err != nil {
+		return "", err
+	}
+	return created.ID, nil
+}
+
+func (c *Client) send(req *http.Request, path string, out interface{}) error {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return &APIError{Method: req.Method, Path: path, StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(snippet))}
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
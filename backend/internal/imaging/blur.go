@@ -0,0 +1,117 @@
+// Package imaging validates uploaded screenshots and produces
+// privacy-preserving variants of them
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+)
+
+const (
+	// blurPasses of a box blur approximate a gaussian blur
+	blurPasses = 3
+	// blurDivisor sets the radius relative to the image width, so text stays
+	// unreadable on high resolution monitors
+	blurDivisor   = 150
+	minBlurRadius = 6
+	jpegQuality   = 80
+)
+
+// ErrUnsupportedFormat is returned for images other than PNG and JPEG
+var ErrUnsupportedFormat = errors.New("unsupported image format")
+
+// Blur decodes a PNG or JPEG screenshot, blurs it until text is unreadable
+// and encodes it in the original format
+func Blur(data []byte) ([]byte, error) {
+	src, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode screenshot: %w", err)
+	}
+	if format != "png" && format != "jpeg" {
+		return nil, ErrUnsupportedFormat
+	}
+
+	bounds := src.Bounds()
+	img := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(img, img.Bounds(), src, bounds.Min, draw.Src)
+
+	radius := bounds.Dx() / blurDivisor
+	if radius < minBlurRadius {
+		radius = minBlurRadius
+	}
+	for i := 0; i < blurPasses; i++ {
+		boxBlur(img, radius, true)
+		boxBlur(img, radius, false)
+	}
+
+	var out bytes.Buffer
+	if format == "png" {
+		err = png.Encode(&out, img)
+	} else {
+		err = jpeg.Encode(&out, img, &jpeg.Options{Quality: jpegQuality})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode blurred screenshot: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+// boxBlur averages every pixel with its neighbours within radius along rows
+// (horizontal) or columns, using a sliding window sum per line
+func boxBlur(img *image.RGBA, radius int, horizontal bool) {
+	w, h := img.Rect.Dx(), img.Rect.Dy()
+	lines, length := h, w
+	if !horizontal {
+		lines, length = w, h
+	}
+
+	offset := func(line, i int) int {
+		if horizontal {
+			return line*img.Stride + i*4
+		}
+		return i*img.Stride + line*4
+	}
+
+	buf := make([]uint8, length*4)
+	for line := 0; line < lines; line++ {
+		var sum [4]int
+		// Edge pixels are repeated beyond the image bounds
+		for i := -radius; i <= radius; i++ {
+			o := offset(line, clamp(i, 0, length-1))
+			for c := 0; c < 4; c++ {
+				sum[c] += int(img.Pix[o+c])
+			}
+		}
+
+		window := 2*radius + 1
+		for i := 0; i < length; i++ {
+			for c := 0; c < 4; c++ {
+				buf[i*4+c] = uint8(sum[c] / window)
+			}
+			out := offset(line, clamp(i-radius, 0, length-1))
+			in := offset(line, clamp(i+radius+1, 0, length-1))
+			for c := 0; c < 4; c++ {
+				sum[c] += int(img.Pix[in+c]) - int(img.Pix[out+c])
+			}
+		}
+
+		for i := 0; i < length; i++ {
+			copy(img.Pix[offset(line, i):offset(line, i)+4], buf[i*4:i*4+4])
+		}
+	}
+}
+
+func clamp(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
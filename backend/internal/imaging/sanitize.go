@@ -0,0 +1,168 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+)
+
+var (
+	pngSignature = []byte("\x89PNG\r\n\x1a\n")
+	jpegMagic    = []byte{0xFF, 0xD8, 0xFF}
+)
+
+// ErrNotImage is returned for uploads whose bytes are not a PNG or JPEG image
+var ErrNotImage = errors.New("file is not a PNG or JPEG image")
+
+// Limits bound the screenshots accepted from clients; zero disables a limit
+type Limits struct {
+	MaxBytes  int64
+	MaxWidth  int
+	MaxHeight int
+}
+
+// Sanitize checks that an uploaded screenshot is a PNG or JPEG image within
+// the limits, going by its magic bytes rather than the client's MIME type,
+// and strips metadata (EXIF, XMP, text chunks, comments) along with any data
+// appended after the image. Returns the cleaned file and its MIME type.
+func Sanitize(data []byte, limits Limits) ([]byte, string, error) {
+	if limits.MaxBytes > 0 && int64(len(data)) > limits.MaxBytes {
+		return nil, "", fmt.Errorf("file is %d bytes, over the %d byte limit", len(data), limits.MaxBytes)
+	}
+
+	var mimeType string
+	switch {
+	case bytes.HasPrefix(data, pngSignature):
+		mimeType = "image/png"
+	case bytes.HasPrefix(data, jpegMagic):
+		mimeType = "image/jpeg"
+	default:
+		return nil, "", ErrNotImage
+	}
+
+	// Only the header is decoded, so oversized images are refused before
+	// their pixels are allocated
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid image: %w", err)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return nil, "", errors.New("invalid image: empty dimensions")
+	}
+	if (limits.MaxWidth > 0 && cfg.Width > limits.MaxWidth) || (limits.MaxHeight > 0 && cfg.Height > limits.MaxHeight) {
+		return nil, "", fmt.Errorf("image is %dx%d, over the %dx%d limit", cfg.Width, cfg.Height, limits.MaxWidth, limits.MaxHeight)
+	}
+
+	var clean []byte
+	if mimeType == "image/png" {
+		clean, err = stripPNG(data)
+	} else {
+		clean, err = stripJPEG(data)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid image: %w", err)
+	}
+	return clean, mimeType, nil
+}
+
+// pngMetadataChunks carry text, timestamps and EXIF; rendering never needs them
+var pngMetadataChunks = map[string]bool{
+	"eXIf": true,
+	"tEXt": true,
+	"zTXt": true,
+	"iTXt": true,
+	"tIME": true,
+}
+
+// stripPNG copies the chunks of a PNG up to IEND, dropping metadata chunks
+func stripPNG(data []byte) ([]byte, error) {
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(pngSignature)
+
+	for pos := len(pngSignature); ; {
+		// Chunk: length, type, data, CRC
+		if len(data)-pos < 12 {
+			return nil, errors.New("truncated PNG chunk")
+		}
+		length := int(binary.BigEndian.Uint32(data[pos:]))
+		end := pos + 12 + length
+		if end > len(data) || end < pos {
+			return nil, errors.New("truncated PNG chunk")
+		}
+
+		chunkType := string(data[pos+4 : pos+8])
+		if !pngMetadataChunks[chunkType] {
+			out.Write(data[pos:end])
+		}
+		if chunkType == "IEND" {
+			return out.Bytes(), nil
+		}
+		pos = end
+	}
+}
+
+// JPEG markers handled by stripJPEG
+const (
+	jpegSOI  = 0xD8
+	jpegEOI  = 0xD9
+	jpegSOS  = 0xDA
+	jpegAPP1 = 0xE1 // EXIF and XMP
+	jpegAPPD = 0xED // IPTC (Photoshop)
+	jpegCOM  = 0xFE
+)
+
+// stripJPEG copies the segments of a JPEG up to EOI, dropping EXIF, XMP,
+// IPTC and comment segments
+func stripJPEG(data []byte) ([]byte, error) {
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write([]byte{0xFF, jpegSOI})
+
+	for pos := 2; ; {
+		if pos+1 >= len(data) || data[pos] != 0xFF {
+			return nil, errors.New("malformed JPEG segment")
+		}
+		// Markers may be preceded by fill bytes
+		marker := data[pos+1]
+		if marker == 0xFF {
+			pos++
+			continue
+		}
+		if marker == jpegEOI {
+			out.Write(data[pos : pos+2])
+			return out.Bytes(), nil
+		}
+		if marker >= 0xD0 && marker <= 0xD7 || marker == 0x01 {
+			// Standalone markers without a length
+			out.Write(data[pos : pos+2])
+			pos += 2
+			continue
+		}
+
+		if pos+4 > len(data) {
+			return nil, errors.New("truncated JPEG segment")
+		}
+		end := pos + 2 + int(binary.BigEndian.Uint16(data[pos+2:]))
+		if end > len(data) || end < pos+4 {
+			return nil, errors.New("truncated JPEG segment")
+		}
+
+		if marker == jpegSOS {
+			// Entropy-coded data, and the scans and tables of progressive
+			// images, run to EOI; a 0xFF in the data is always followed by 0x00
+			// or a marker, so the first FF D9 ends the image
+			eoi := bytes.Index(data[end:], []byte{0xFF, jpegEOI})
+			if eoi < 0 {
+				return nil, errors.New("missing JPEG end of image")
+			}
+			out.Write(data[pos : end+eoi+2])
+			return out.Bytes(), nil
+		}
+
+		if marker != jpegAPP1 && marker != jpegAPPD && marker != jpegCOM {
+			out.Write(data[pos:end])
+		}
+		pos = end
+	}
+}
@@ -0,0 +1,212 @@
+// Package jira is a minimal Jira Cloud REST API (v3) client covering what the
+// task sync needs: project lookup, issue search and worklogs.
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// maxErrorBody caps how much of an error response is kept
+const maxErrorBody = 1024
+
+// worklogTimeLayout is the timestamp format Jira accepts for worklog start times
+const worklogTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+// Issue status categories
+const (
+	StatusCategoryNew        = "new"
+	StatusCategoryInProgress = "indeterminate"
+	StatusCategoryDone       = "done"
+)
+
+// APIError is a non-success response from Jira
+type APIError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("jira %s %s: %d %s: %s", e.Method, e.Path, e.StatusCode, http.StatusText(e.StatusCode), e.Body)
+}
+
+// Unauthorized reports whether Jira rejected the credentials
+func (e *APIError) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+}
+
+// Client calls a Jira Cloud site with an account email and API token
+type Client struct {
+	baseURL    string
+	email      string
+	token      string
+	httpClient *http.Client
+}
+
+// NewClient creates a client for the site at baseURL (e.g. https://acme.atlassian.net)
+func NewClient(baseURL, email, token string, timeout time.Duration) *Client {
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		email:      email,
+		token:      token,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// Project is a Jira project
+type Project struct {
+	ID   string `json:"id"`
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+// User is a Jira account. EmailAddress is empty when the account hides it.
+type User struct {
+	AccountID    string `json:"accountId"`
+	DisplayName  string `json:"displayName"`
+	EmailAddress string `json:"emailAddress"`
+}
+
+// Issue is a Jira issue with the fields the sync reads
+type Issue struct {
+	ID     string      `json:"id"`
+	Key    string      `json:"key"`
+	Fields IssueFields `json:"fields"`
+}
+
+// IssueFields holds the requested issue fields
+type IssueFields struct {
+	Summary  string `json:"summary"`
+	Assignee *User  `json:"assignee"`
+	Status   struct {
+		Name           string `json:"name"`
+		StatusCategory struct {
+			Key string `json:"key"` // new, indeterminate or done
+		} `json:"statusCategory"`
+	} `json:"status"`
+	Updated string `json:"updated"`
+}
+
+// Worklog is time logged on an issue
+type Worklog struct {
+	ID               string `json:"id"`
+	TimeSpentSeconds int64  `json:"timeSpentSeconds"`
+}
+
+// GetProject returns the project with key
+func (c *Client) GetProject(ctx context.Context, key string) (*Project, error) {
+	var project Project
+	if err := c.do(ctx, http.MethodGet, "/rest/api/3/project/"+url.PathEscape(key), nil, nil, &project); err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// SearchIssues returns every issue matching jql, following pagination
+func (c *Client) SearchIssues(ctx context.Context, jql string) ([]Issue, error) {
+	var issues []Issue
+	pageToken := ""
+	for {
+		query := url.Values{
+			"jql":        {jql},
+			"fields":     {"summary,status,assignee,updated"},
+			"maxResults": {"100"},
+		}
+		if pageToken != "" {
+			query.Set("nextPageToken", pageToken)
+		}
+
+		var page struct {
+			Issues        []Issue `json:"issues"`
+			NextPageToken string  `json:"nextPageToken"`
+			IsLast        bool    `json:"isLast"`
+		}
+		if err := c.do(ctx, http.MethodGet, "/rest/api/3/search/jql", query, nil, &page); err != nil {
+			return nil, err
+		}
+		issues = append(issues, page.Issues...)
+
+		if page.IsLast || page.NextPageToken == "" {
+			return issues, nil
+		}
+		pageToken = page.NextPageToken
+	}
+}
+
+// AddWorklog logs seconds of work on the issue, starting at started
+func (c *Client) AddWorklog(ctx context.Context, issueKey string, started time.Time, seconds int64, comment string) (*Worklog, error) {
+	body := map[string]interface{}{
+		"started":          started.Format(worklogTimeLayout),
+		"timeSpentSeconds": seconds,
+	}
+	if comment != "" {
+		// Comments are Atlassian Document Format
+		body["comment"] = map[string]interface{}{
+			"type":    "doc",
+			"version": 1,
+			"content": []interface{}{
+				map[string]interface{}{
+					"type":    "paragraph",
+					"content": []interface{}{map[string]interface{}{"type": "text", "text": comment}},
+				},
+			},
+		}
+	}
+
+	var worklog Worklog
+	path := "/rest/api/3/issue/" + url.PathEscape(issueKey) + "/worklog"
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &worklog); err != nil {
+		return nil, err
+	}
+	return &worklog, nil
+}
+
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(c.email, c.token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return &APIError{Method: method, Path: path, StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(snippet))}
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,84 @@
+// Package mail sends email through SMTP or a provider HTTP API and renders
+// the templated messages the server sends.
+package mail
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// Email providers
+const (
+	ProviderLog      = "log" // Logs messages instead of sending them (development)
+	ProviderSMTP     = "smtp"
+	ProviderSendGrid = "sendgrid"
+)
+
+// Message is a rendered email. HTML and attachments are optional.
+type Message struct {
+	To          string
+	ToName      string
+	Subject     string
+	Text        string
+	HTML        string
+	Attachments []Attachment
+}
+
+// Attachment is a file sent with a message
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// Sender delivers messages
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// Config selects and configures the provider
+type Config struct {
+	Provider string // log, smtp or sendgrid
+	From     string // Sender address
+	FromName string
+	Timeout  time.Duration
+
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	SMTPTLS      string // starttls, tls (implicit, usually port 465) or none
+
+	APIKey string // SendGrid API key
+}
+
+// New creates the sender for cfg.Provider
+func New(cfg Config) (Sender, error) {
+	switch cfg.Provider {
+	case "", ProviderLog:
+		return LogSender{}, nil
+	case ProviderSMTP:
+		if cfg.SMTPHost == "" || cfg.From == "" {
+			return nil, fmt.Errorf("smtp provider needs a host and a from address")
+		}
+		return NewSMTP(cfg), nil
+	case ProviderSendGrid:
+		if cfg.APIKey == "" || cfg.From == "" {
+			return nil, fmt.Errorf("sendgrid provider needs an API key and a from address")
+		}
+		return NewSendGrid(cfg), nil
+	default:
+		return nil, fmt.Errorf("unknown email provider %q", cfg.Provider)
+	}
+}
+
+// LogSender logs messages instead of sending them
+type LogSender struct{}
+
+// Send logs the message recipient and subject
+func (LogSender) Send(_ context.Context, msg Message) error {
+	log.Printf("📧 Email to %s: %s (not sent, EMAIL_PROVIDER=log)", msg.To, msg.Subject)
+	return nil
+}
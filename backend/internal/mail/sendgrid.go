@@ -0,0 +1,107 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// SendGridURL is the SendGrid v3 send endpoint; a variable so it can point
+// elsewhere in development
+var SendGridURL = "https://api.sendgrid.com/v3/mail/send"
+
+// maxErrorBody caps how much of an error response is kept
+const maxErrorBody = 1024
+
+// SendGrid sends messages through the SendGrid API
+type SendGrid struct {
+	apiKey     string
+	from       string
+	fromName   string
+	httpClient *http.Client
+}
+
+// NewSendGrid creates a SendGrid sender
+func NewSendGrid(cfg Config) *SendGrid {
+	return &SendGrid{
+		apiKey:     cfg.APIKey,
+		from:       cfg.From,
+		fromName:   cfg.FromName,
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+type sendGridAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type sendGridAttachment struct {
+	Content     string `json:"content"` // Base64
+	Type        string `json:"type"`
+	Filename    string `json:"filename"`
+	Disposition string `json:"disposition"`
+}
+
+// Send delivers msg with one API call
+func (s *SendGrid) Send(ctx context.Context, msg Message) error {
+	content := []sendGridContent{{Type: "text/plain", Value: msg.Text}}
+	if msg.HTML != "" {
+		content = append(content, sendGridContent{Type: "text/html", Value: msg.HTML})
+	}
+
+	body := map[string]interface{}{
+		"personalizations": []interface{}{
+			map[string]interface{}{"to": []sendGridAddress{{Email: msg.To, Name: msg.ToName}}},
+		},
+		"from":    sendGridAddress{Email: s.from, Name: s.fromName},
+		"subject": msg.Subject,
+		"content": content,
+	}
+	if len(msg.Attachments) > 0 {
+		attachments := make([]sendGridAttachment, 0, len(msg.Attachments))
+		for _, attachment := range msg.Attachments {
+			attachments = append(attachments, sendGridAttachment{
+				Content:     base64.StdEncoding.EncodeToString(attachment.Data),
+				Type:        attachment.ContentType,
+				Filename:    attachment.Filename,
+				Disposition: "attachment",
+			})
+		}
+		body["attachments"] = attachments
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, SendGridURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return fmt.Errorf("sendgrid: %d %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), strings.TrimSpace(string(snippet)))
+	}
+	return nil
+}
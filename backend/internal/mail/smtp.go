@@ -0,0 +1,200 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// SMTP TLS modes
+const (
+	TLSStartTLS = "starttls"
+	TLSImplicit = "tls"
+	TLSNone     = "none"
+)
+
+// SMTP sends messages through an SMTP relay
+type SMTP struct {
+	host     string
+	port     string
+	username string
+	password string
+	tlsMode  string
+	from     mail.Address
+	timeout  time.Duration
+}
+
+// NewSMTP creates an SMTP sender
+func NewSMTP(cfg Config) *SMTP {
+	port := cfg.SMTPPort
+	if port == "" {
+		port = "587"
+	}
+	tlsMode := cfg.SMTPTLS
+	if tlsMode == "" {
+		tlsMode = TLSStartTLS
+	}
+	return &SMTP{
+		host:     cfg.SMTPHost,
+		port:     port,
+		username: cfg.SMTPUsername,
+		password: cfg.SMTPPassword,
+		tlsMode:  tlsMode,
+		from:     mail.Address{Name: cfg.FromName, Address: cfg.From},
+		timeout:  cfg.Timeout,
+	}
+}
+
+// Send delivers msg in a single SMTP session
+func (s *SMTP) Send(ctx context.Context, msg Message) error {
+	body, err := buildMIME(s.from, msg)
+	if err != nil {
+		return err
+	}
+
+	addr := net.JoinHostPort(s.host, s.port)
+	dialer := &net.Dialer{Timeout: s.timeout}
+	tlsConfig := &tls.Config{ServerName: s.host}
+
+	var conn net.Conn
+	if s.tlsMode == TLSImplicit {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return err
+	}
+	if s.timeout > 0 {
+		_ = conn.SetDeadline(time.Now().Add(s.timeout))
+	}
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if s.tlsMode == TLSStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("smtp server %s does not support STARTTLS", s.host)
+		}
+		if err := client.StartTLS(tlsConfig); err != nil {
+			return err
+		}
+	}
+	if s.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(s.from.Address); err != nil {
+		return err
+	}
+	if err := client.Rcpt(msg.To); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(body); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// buildMIME renders msg as a multipart/alternative message with
+// quoted-printable text and HTML parts, wrapped in multipart/mixed with
+// base64 parts when it has attachments
+func buildMIME(from mail.Address, msg Message) ([]byte, error) {
+	boundary, err := randomHex(16)
+	if err != nil {
+		return nil, err
+	}
+	mixedBoundary, err := randomHex(16)
+	if err != nil {
+		return nil, err
+	}
+	messageID, err := randomHex(16)
+	if err != nil {
+		return nil, err
+	}
+	domain := from.Address[strings.LastIndex(from.Address, "@")+1:]
+
+	var buf bytes.Buffer
+	to := mail.Address{Name: msg.ToName, Address: msg.To}
+	fmt.Fprintf(&buf, "From: %s\r\n", from.String())
+	fmt.Fprintf(&buf, "To: %s\r\n", to.String())
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&buf, "Message-ID: <%s@%s>\r\n", messageID, domain)
+	buf.WriteString("MIME-Version: 1.0\r\n")
+
+	parts := []struct{ contentType, body string }{{"text/plain", msg.Text}}
+	if msg.HTML != "" {
+		parts = append(parts, struct{ contentType, body string }{"text/html", msg.HTML})
+	}
+
+	if len(msg.Attachments) > 0 {
+		fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%q\r\n\r\n", mixedBoundary)
+		fmt.Fprintf(&buf, "--%s\r\n", mixedBoundary)
+	}
+	fmt.Fprintf(&buf, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", boundary)
+	for _, part := range parts {
+		fmt.Fprintf(&buf, "--%s\r\n", boundary)
+		fmt.Fprintf(&buf, "Content-Type: %s; charset=utf-8\r\n", part.contentType)
+		buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+		qp := quotedprintable.NewWriter(&buf)
+		if _, err := qp.Write([]byte(part.body)); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+		buf.WriteString("\r\n")
+	}
+	fmt.Fprintf(&buf, "--%s--\r\n", boundary)
+
+	if len(msg.Attachments) > 0 {
+		for _, attachment := range msg.Attachments {
+			filename := mime.QEncoding.Encode("utf-8", attachment.Filename)
+			fmt.Fprintf(&buf, "\r\n--%s\r\n", mixedBoundary)
+			fmt.Fprintf(&buf, "Content-Type: %s; name=%q\r\n", attachment.ContentType, filename)
+			fmt.Fprintf(&buf, "Content-Disposition: attachment; filename=%q\r\n", filename)
+			buf.WriteString("Content-Transfer-Encoding: base64\r\n\r\n")
+			encoded := base64.StdEncoding.EncodeToString(attachment.Data)
+			for len(encoded) > 76 {
+				buf.WriteString(encoded[:76] + "\r\n")
+				encoded = encoded[76:]
+			}
+			buf.WriteString(encoded + "\r\n")
+		}
+		fmt.Fprintf(&buf, "--%s--\r\n", mixedBoundary)
+	}
+	return buf.Bytes(), nil
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
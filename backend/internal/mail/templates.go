@@ -0,0 +1,133 @@
+package mail
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+// Message templates
+const (
+	TemplateInvitation    = "invitation"
+	TemplateWelcome       = "welcome"
+	TemplatePasswordReset = "password_reset"
+	TemplateWeeklySummary = "weekly_summary"
+	TemplateSavedReport   = "saved_report"
+)
+
+// Templates lists every message template
+var Templates = []string{
+	TemplateInvitation,
+	TemplateWelcome,
+	TemplatePasswordReset,
+	TemplateWeeklySummary,
+	TemplateSavedReport,
+}
+
+//go:embed templates/*.txt templates/*.html
+var templateFS embed.FS
+
+var templateFuncs = map[string]interface{}{
+	"date": func(t time.Time) string { return t.Format("Mon, Jan 2, 2006") },
+}
+
+// Each template has a text file defining "subject" and "text", and an HTML
+// file defining "content", which is wrapped in the shared layout
+var (
+	textTemplates = make(map[string]*texttemplate.Template)
+	htmlTemplates = make(map[string]*htmltemplate.Template)
+)
+
+func init() {
+	for _, name := range Templates {
+		textTemplates[name] = texttemplate.Must(texttemplate.New(name).Funcs(templateFuncs).
+			ParseFS(templateFS, "templates/"+name+".txt"))
+		htmlTemplates[name] = htmltemplate.Must(htmltemplate.New(name).Funcs(templateFuncs).
+			ParseFS(templateFS, "templates/layout.html", "templates/"+name+".html"))
+	}
+}
+
+// InvitationData fills the invitation template
+type InvitationData struct {
+	OrganizationName string
+	WorkspaceName    string // Empty for organization-only invitations
+	InviterName      string
+	Message          string
+	AcceptURL        string
+	ExpiresAt        time.Time
+}
+
+// WelcomeData fills the welcome template
+type WelcomeData struct {
+	Name   string
+	AppURL string
+}
+
+// PasswordResetData fills the password reset template
+type PasswordResetData struct {
+	Name      string
+	ResetURL  string
+	ExpiresIn string // e.g. "1 hour"
+}
+
+// WeeklySummaryData fills the weekly summary template
+type WeeklySummaryData struct {
+	Name           string
+	PeriodStart    time.Time
+	PeriodEnd      time.Time // Last day of the period
+	Total          string
+	Workspaces     []SummaryLine
+	Tasks          []SummaryLine
+	PreferencesURL string
+}
+
+// SavedReportData fills the scheduled saved report template; the report
+// itself is attached
+type SavedReportData struct {
+	Name       string
+	ReportName string
+	OwnerName  string
+	StartDate  time.Time
+	EndDate    time.Time // Last day of the report
+	Format     string    // CSV or PDF
+	RowCount   int
+	Truncated  bool
+	ReportsURL string
+}
+
+// SummaryLine is a named duration in a summary
+type SummaryLine struct {
+	Name     string
+	Duration string
+}
+
+// Render renders the named template for the recipient
+func Render(name, to, toName string, data interface{}) (Message, error) {
+	textTmpl, ok := textTemplates[name]
+	if !ok {
+		return Message{}, fmt.Errorf("unknown email template %q", name)
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := textTmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return Message{}, err
+	}
+	if err := textTmpl.ExecuteTemplate(&text, "text", data); err != nil {
+		return Message{}, err
+	}
+	if err := htmlTemplates[name].ExecuteTemplate(&html, "layout", data); err != nil {
+		return Message{}, err
+	}
+
+	return Message{
+		To:      to,
+		ToName:  toName,
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    strings.TrimSpace(text.String()) + "\n",
+		HTML:    html.String(),
+	}, nil
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 	"sync"
 	"time"
 
@@ -78,14 +80,20 @@ func SystemAdminRequiredWithUser(userRepo repository.UserRepository) gin.Handler
 // AUDIT LOG MIDDLEWARE
 // ============================================================================
 
-// auditResponseWriter wraps gin.ResponseWriter to capture response body
+// auditResponseWriter wraps gin.ResponseWriter to count the response bytes
+// and keep the first limit of them
 type auditResponseWriter struct {
 	gin.ResponseWriter
-	body *bytes.Buffer
+	body  bytes.Buffer
+	limit int
+	size  int
 }
 
-func (w auditResponseWriter) Write(b []byte) (int, error) {
-	w.body.Write(b)
+func (w *auditResponseWriter) Write(b []byte) (int, error) {
+	w.size += len(b)
+	if room := w.limit - w.body.Len(); room > 0 {
+		w.body.Write(b[:min(len(b), room)])
+	}
 	return w.ResponseWriter.Write(b)
 }
 
@@ -102,11 +110,8 @@ func AdminAuditLog(auditRepo repository.AuditLogRepository) gin.HandlerFunc {
 			c.Request.Body = io.NopCloser(bytes.NewBuffer(requestBody))
 		}
 
-		// Capture response
-		writer := &auditResponseWriter{
-			ResponseWriter: c.Writer,
-			body:           bytes.NewBufferString(""),
-		}
+		// Count the response; its body is not logged
+		writer := &auditResponseWriter{ResponseWriter: c.Writer}
 		c.Writer = writer
 
 		// Process request
@@ -122,24 +127,16 @@ func AdminAuditLog(auditRepo repository.AuditLogRepository) gin.HandlerFunc {
 		// Prepare audit details
 		details := map[string]interface{}{
 			"method":        c.Request.Method,
-			"path":          c.Request.URL.Path,
-			"query":         c.Request.URL.RawQuery,
+			"path":          redactAuditPath(c),
+			"query":         redactAuditQuery(c.Request.URL.RawQuery),
 			"status":        c.Writer.Status(),
 			"duration_ms":   time.Since(startTime).Milliseconds(),
-			"response_size": writer.body.Len(),
+			"response_size": writer.size,
 		}
 
 		// Add sanitized request body (remove sensitive fields)
-		if len(requestBody) > 0 && len(requestBody) < 10000 { // Limit size
-			var bodyMap map[string]interface{}
-			if err := json.Unmarshal(requestBody, &bodyMap); err == nil {
-				// Remove sensitive fields
-				delete(bodyMap, "password")
-				delete(bodyMap, "token")
-				delete(bodyMap, "secret")
-				delete(bodyMap, "api_key")
-				details["request_body"] = bodyMap
-			}
+		if bodyMap := sanitizeAuditBody(requestBody); bodyMap != nil {
+			details["request_body"] = bodyMap
 		}
 
 		detailsJSON, _ := json.Marshal(details)
@@ -176,7 +173,104 @@ func AdminAuditLog(auditRepo repository.AuditLogRepository) gin.HandlerFunc {
 }
 
 // Helper functions for audit logging
+
+// maxAuditBodySize is the size from which request bodies are left out of
+// audit details
+const maxAuditBodySize = 10000
+
+// auditSensitiveKeyParts mark request fields holding credentials (passwords,
+// tokens, API keys, secrets, incoming webhook URLs such as Slack's, which
+// anyone can post to); matching fields are stripped from request bodies at
+// any depth before they are stored
+var auditSensitiveKeyParts = []string{"password", "token", "secret", "api_key", "apikey", "webhook_url"}
+
+// isSensitiveAuditKey reports whether a field name looks like a credential
+func isSensitiveAuditKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, part := range auditSensitiveKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// sanitizeAuditBody decodes a JSON request body and removes sensitive fields.
+// Returns nil for empty, oversized or non-JSON bodies.
+func sanitizeAuditBody(body []byte) map[string]interface{} {
+	if len(body) == 0 || len(body) >= maxAuditBodySize {
+		return nil
+	}
+
+	var bodyMap map[string]interface{}
+	if err := json.Unmarshal(body, &bodyMap); err != nil {
+		return nil
+	}
+
+	redactAuditValue(bodyMap)
+	return bodyMap
+}
+
+// redactAuditPath replaces credentials passed as path parameters, e.g. the
+// invitation token of /invitations/:token/accept, with the parameter name
+func redactAuditPath(c *gin.Context) string {
+	path := c.Request.URL.Path
+	for _, param := range c.Params {
+		if param.Value != "" && isSensitiveAuditKey(param.Key) {
+			path = strings.Replace(path, "/"+param.Value, "/:"+param.Key, 1)
+		}
+	}
+	return path
+}
+
+// redactAuditQuery removes sensitive parameters from a raw query string
+func redactAuditQuery(rawQuery string) string {
+	if rawQuery == "" {
+		return ""
+	}
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return ""
+	}
+	for key := range values {
+		if isSensitiveAuditKey(key) {
+			values.Del(key)
+		}
+	}
+	return values.Encode()
+}
+
+// redactAuditValue removes sensitive fields from decoded JSON objects,
+// including those nested in objects and arrays
+func redactAuditValue(value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if isSensitiveAuditKey(key) {
+				delete(v, key)
+				continue
+			}
+			redactAuditValue(field)
+		}
+	case []interface{}:
+		for _, item := range v {
+			redactAuditValue(item)
+		}
+	}
+}
+
 func determineAction(method, path string) string {
+	switch {
+	case strings.HasSuffix(path, "/auth/login"):
+		return "login"
+	case strings.HasSuffix(path, "/auth/logout"):
+		return "logout"
+	case strings.HasSuffix(path, "/auth/register"):
+		return "register"
+	case strings.HasSuffix(path, "/impersonate"):
+		return "impersonate"
+	}
+
 	switch method {
 	case "GET":
 		return "view"
@@ -194,6 +288,21 @@ func determineAction(method, path string) string {
 func determineEntityType(path string) string {
 	// Extract entity type from path like /api/v1/admin/users
 	// This is a simplified version
+	if contains(path, "/auth") {
+		return "user"
+	}
+	if contains(path, "/members") {
+		return "member"
+	}
+	if contains(path, "/invitations") {
+		return "invitation"
+	}
+	if contains(path, "/roles") {
+		return "role"
+	}
+	if contains(path, "/sync") {
+		return "sync"
+	}
 	if contains(path, "/users") {
 		return "user"
 	}
@@ -0,0 +1,185 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIVersion describes one mounted version of the API
+type APIVersion struct {
+	Name         string     // Path segment, e.g. v1
+	DeprecatedAt *time.Time // When the version was deprecated; nil while supported
+	SunsetAt     *time.Time // When the version stops being served; nil if not scheduled
+	Successor    string     // Version clients should move to, e.g. v2
+
+	// FieldRenames maps legacy JSON field and query parameter names to the
+	// names this version uses. Handlers keep speaking the legacy names.
+	FieldRenames map[string]string
+}
+
+// Status returns "deprecated" or "current"
+func (v APIVersion) Status() string {
+	if v.DeprecatedAt != nil {
+		return "deprecated"
+	}
+	return "current"
+}
+
+// APIVersionHeaders tags responses with the API version serving them and,
+// for a deprecated version, the Deprecation (RFC 9745) and Sunset (RFC 8594)
+// headers and a link to its successor
+func APIVersionHeaders(version APIVersion) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("api_version", version.Name)
+		c.Header("API-Version", version.Name)
+
+		if version.DeprecatedAt != nil {
+			c.Header("Deprecation", "@"+strconv.FormatInt(version.DeprecatedAt.Unix(), 10))
+			if version.SunsetAt != nil {
+				c.Header("Sunset", version.SunsetAt.UTC().Format(http.TimeFormat))
+			}
+			if version.Successor != "" {
+				c.Header("Link", `</api/`+version.Successor+`>; rel="successor-version"`)
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// RenameJSONFields is the compatibility shim between an API version and the
+// handlers, which keep the legacy field names old desktop clients sync with.
+// Query parameters and JSON request bodies gain the legacy names on the way
+// in, and JSON responses are renamed to the version's names on the way out. Other responses (files, event streams) pass through untouched.
+func RenameJSONFields(renames map[string]string) gin.HandlerFunc {
+	legacy := make(map[string]string, len(renames))
+	for from, to := range renames {
+		legacy[to] = from
+	}
+
+	return func(c *gin.Context) {
+		// Requests keep the new names alongside the legacy copies: some
+		// handlers already use a new name (per_page, organization_id) for
+		// their own fields
+		if query := c.Request.URL.Query(); len(query) > 0 {
+			copied := false
+			for key, values := range query {
+				if from, ok := legacy[key]; ok && !query.Has(from) {
+					query[from] = values
+					copied = true
+				}
+			}
+			if copied {
+				c.Request.URL.RawQuery = query.Encode()
+			}
+		}
+
+		if c.Request.Body != nil && c.ContentType() == "application/json" {
+			body, err := io.ReadAll(c.Request.Body)
+			if err == nil {
+				body = rewriteJSONKeys(body, legacy, true)
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+			c.Request.ContentLength = int64(len(body))
+		}
+
+		writer := &renameResponseWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		c.Next()
+
+		c.Writer = writer.ResponseWriter
+		if writer.buffered {
+			writer.ResponseWriter.Write(rewriteJSONKeys(writer.body.Bytes(), renames, false))
+		}
+	}
+}
+
+// renameResponseWriter holds back JSON responses so their fields can be
+// renamed once the handler is done
+type renameResponseWriter struct {
+	gin.ResponseWriter
+	body     bytes.Buffer
+	buffered bool
+}
+
+func (w *renameResponseWriter) Write(b []byte) (int, error) {
+	if !w.buffered && (w.ResponseWriter.Written() || !strings.HasPrefix(w.Header().Get("Content-Type"), "application/json")) {
+		return w.ResponseWriter.Write(b)
+	}
+	w.buffered = true
+	return w.body.Write(b)
+}
+
+func (w *renameResponseWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *renameResponseWriter) Written() bool {
+	return w.buffered || w.ResponseWriter.Written()
+}
+
+func (w *renameResponseWriter) Size() int {
+	if w.buffered {
+		return w.body.Len()
+	}
+	return w.ResponseWriter.Size()
+}
+
+// rewriteJSONKeys renames object keys at any depth, or copies them when keep
+// is set. Keys that would collide with a field already present are left
+// alone, and bodies that are not valid JSON are returned unchanged.
+func rewriteJSONKeys(body []byte, renames map[string]string, keep bool) []byte {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return body
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return body
+	}
+	renamed, err := json.Marshal(rewriteKeys(value, renames, keep))
+	if err != nil {
+		return body
+	}
+	return renamed
+}
+
+func rewriteKeys(value interface{}, renames map[string]string, keep bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			out[key] = rewriteKeys(item, renames, keep)
+		}
+		for from, to := range renames {
+			item, ok := out[from]
+			if !ok {
+				continue
+			}
+			if _, exists := v[to]; exists {
+				continue
+			}
+			out[to] = item
+			if !keep {
+				delete(out, from)
+			}
+		}
+		return out
+	case []interface{}:
+		for i, item := range v {
+			v[i] = rewriteKeys(item, renames, keep)
+		}
+		return v
+	default:
+		return value
+	}
+}
@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// maxAuditResponseCapture bounds how much of a response is kept to read the
+// ID of a created entity from
+const maxAuditResponseCapture = 64 * 1024
+
+// AuditMutations records every mutating request (POST, PUT, PATCH, DELETE)
+// in the audit log with the actor, affected entity, request body and IP.
+// details.request is the redacted JSON body as submitted, not a diff of the
+// entity; bodies of maxAuditBodySize or more are noted by size only.
+// Read-only requests pass through untouched.
+func AuditMutations(auditService service.AuditService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !isMutatingMethod(c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		startTime := time.Now()
+
+		// Only JSON bodies are captured; uploads are never buffered for auditing
+		var requestBody []byte
+		if c.Request.Body != nil && c.ContentType() == "application/json" {
+			requestBody, _ = io.ReadAll(c.Request.Body)
+			// Restore the body for downstream handlers
+			c.Request.Body = io.NopCloser(bytes.NewBuffer(requestBody))
+		}
+
+		writer := &auditResponseWriter{ResponseWriter: c.Writer, limit: maxAuditResponseCapture}
+		c.Writer = writer
+
+		c.Next()
+
+		var userIDPtr *uint
+		if id, ok := GetUserID(c); ok && id > 0 {
+			userIDPtr = &id
+		}
+
+		details := map[string]interface{}{
+			"method":      c.Request.Method,
+			"path":        redactAuditPath(c),
+			"route":       c.FullPath(),
+			"query":       redactAuditQuery(c.Request.URL.RawQuery),
+			"status":      c.Writer.Status(),
+			"duration_ms": time.Since(startTime).Milliseconds(),
+		}
+
+		if request := sanitizeAuditBody(requestBody); request != nil {
+			details["request"] = request
+		} else if len(requestBody) >= maxAuditBodySize {
+			details["request_omitted"] = true
+			details["request_size"] = len(requestBody)
+		}
+
+		if impersonatorID, ok := GetImpersonatorID(c); ok {
+			details["impersonator_id"] = impersonatorID
+		}
+
+		entityID := extractEntityID(c)
+		if entityID == nil && c.Request.Method == http.MethodPost && writer.size <= maxAuditResponseCapture {
+			entityID = extractCreatedEntityID(writer.body.Bytes())
+		}
+
+		status := service.AuditStatusSuccess
+		if c.Writer.Status() >= 400 {
+			status = service.AuditStatusFailed
+		}
+
+		detailsJSON, _ := json.Marshal(details)
+
+		auditService.Record(&models.AuditLog{
+			UserID:     userIDPtr,
+			Action:     determineAction(c.Request.Method, c.Request.URL.Path),
+			EntityType: determineEntityType(c.Request.URL.Path),
+			EntityID:   entityID,
+			IPAddress:  c.ClientIP(),
+			UserAgent:  c.Request.UserAgent(),
+			Details:    string(detailsJSON),
+			Status:     status,
+		})
+	}
+}
+
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// extractCreatedEntityID reads the ID of a newly created entity from a
+// response body shaped like {"data": {"id": 1}} or {"id": 1}
+func extractCreatedEntityID(body []byte) *uint {
+	if len(body) == 0 {
+		return nil
+	}
+
+	var payload struct {
+		ID   *uint `json:"id"`
+		Data struct {
+			ID *uint `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil
+	}
+
+	if payload.Data.ID != nil && *payload.Data.ID > 0 {
+		return payload.Data.ID
+	}
+	if payload.ID != nil && *payload.ID > 0 {
+		return payload.ID
+	}
+	return nil
+}
@@ -51,6 +51,12 @@ func AuthMiddleware() gin.HandlerFunc {
 		c.Set("user_role", claims.Role)
 		c.Set("userRole", claims.Role)
 
+		// Mark impersonated sessions so handlers and the audit log can tell them apart
+		if claims.IsImpersonation() {
+			c.Set("impersonator_id", *claims.ImpersonatorID)
+			c.Header("X-Impersonated-By", utils.UintToString(*claims.ImpersonatorID))
+		}
+
 		c.Next()
 	}
 }
@@ -98,6 +104,16 @@ func AdminMiddleware() gin.HandlerFunc {
 	}
 }
 
+// GetImpersonatorID retrieves the impersonating admin's ID from context, if any
+func GetImpersonatorID(c *gin.Context) (uint, bool) {
+	impersonatorID, exists := c.Get("impersonator_id")
+	if !exists {
+		return 0, false
+	}
+	id, ok := impersonatorID.(uint)
+	return id, ok
+}
+
 // GetUserID retrieves user ID from context
 func GetUserID(c *gin.Context) (uint, bool) {
 	userID, exists := c.Get("user_id")
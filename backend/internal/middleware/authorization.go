@@ -270,6 +270,38 @@ func (m *AuthorizationMiddleware) RequireWorkspaceManager() gin.HandlerFunc {
 	}
 }
 
+// RequireWorkspacePermission requires the user to hold a workspace permission
+// from their role's permission matrix (see models.WorkspacePermissionSchema).
+// Managers hold every permission.
+func (m *AuthorizationMiddleware) RequireWorkspacePermission(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetUint("userID")
+		if userID == 0 {
+			utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+			c.Abort()
+			return
+		}
+
+		workspaceID, err := m.getWorkspaceIDFromContext(c)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Workspace ID required")
+			c.Abort()
+			return
+		}
+
+		allowed, err := m.workspaceService.HasPermission(workspaceID, userID, permission)
+		if err != nil || !allowed {
+			utils.ErrorResponse(c, http.StatusForbidden, "Missing workspace permission: "+permission)
+			c.Abort()
+			return
+		}
+
+		// Set workspace context
+		c.Set("workspaceID", workspaceID)
+		c.Next()
+	}
+}
+
 // ============================================================================
 // HELPER FUNCTIONS
 // ============================================================================
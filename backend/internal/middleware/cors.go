@@ -18,8 +18,8 @@ func CORSMiddleware() gin.HandlerFunc {
 	corsConfig := cors.Config{
 		AllowOrigins:     cfg.AllowedOrigins,
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "Accept"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "Accept", "Idempotency-Key", "If-Match", "If-None-Match"},
+		ExposeHeaders:    []string{"Content-Length", "Idempotent-Replayed", "ETag"},
 		AllowCredentials: true,
 		MaxAge:           12 * 3600, // 12 hours
 	}
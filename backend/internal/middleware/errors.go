@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorHandler sends the error envelope for errors handlers attach with
+// c.Error instead of responding themselves. Typed apperror errors keep their
+// status and code; anything else is reported as an internal error.
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		utils.RespondError(c, c.Errors.Last().Err)
+	}
+}
+
+// NotFoundHandler answers unknown routes with the error envelope
+func NotFoundHandler(c *gin.Context) {
+	utils.ErrorResponse(c, http.StatusNotFound, "Route not found")
+}
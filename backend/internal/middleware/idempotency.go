@@ -0,0 +1,161 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// IdempotencyKeyHeader is the request header carrying a client-chosen key
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+const maxIdempotencyKeyLength = 255
+
+// Idempotency makes POST requests with an Idempotency-Key header safe to
+// retry. The first request with a key runs and its response is stored for
+// ttl; a retry with the same key and request replays that response (marked
+// Idempotent-Replayed: true) instead of running again. Reusing a key for a
+// different request is rejected, as is a retry while the first request is
+// still running. Server errors are not stored so the client can retry them.
+//
+// Keys are per user, so this must run after authentication. JSON bodies are
+// part of the request fingerprint; other bodies (uploads) are not read.
+func Idempotency(repo repository.IdempotencyRepository, ttl time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		userID, ok := GetUserID(c)
+		if c.Request.Method != http.MethodPost || key == "" || !ok {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Idempotency-Key must be at most 255 characters")
+			c.Abort()
+			return
+		}
+
+		hash := sha256.New()
+		io.WriteString(hash, c.Request.Method+" "+c.Request.URL.Path+"\n")
+		if c.Request.Body != nil && c.ContentType() == "application/json" {
+			body, err := io.ReadAll(c.Request.Body)
+			if err != nil {
+				utils.ErrorResponse(c, http.StatusBadRequest, "Failed to read request body")
+				c.Abort()
+				return
+			}
+			hash.Write(body)
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		fingerprint := hex.EncodeToString(hash.Sum(nil))
+
+		record := &models.IdempotencyKey{
+			UserID:      userID,
+			Key:         key,
+			Method:      c.Request.Method,
+			Path:        c.Request.URL.Path,
+			Fingerprint: fingerprint,
+			ExpiresAt:   time.Now().Add(ttl),
+		}
+		reserved, err := reserveIdempotencyKey(repo, record)
+		if err != nil {
+			utils.RespondError(c, err)
+			c.Abort()
+			return
+		}
+		if !reserved {
+			existing, err := repo.Find(userID, key)
+			if err != nil {
+				utils.RespondError(c, err)
+				c.Abort()
+				return
+			}
+			if existing == nil {
+				utils.RespondError(c, apperror.Conflict("A request with this Idempotency-Key is still in progress"))
+				c.Abort()
+				return
+			}
+			switch {
+			case existing.Fingerprint != fingerprint:
+				utils.RespondError(c, &apperror.Error{
+					Status:  http.StatusUnprocessableEntity,
+					Code:    apperror.CodeIdempotencyKeyReused,
+					Message: "This Idempotency-Key was already used for a different request",
+				})
+			case existing.StatusCode == 0:
+				utils.RespondError(c, apperror.Conflict("A request with this Idempotency-Key is still in progress"))
+			default:
+				c.Header("Idempotent-Replayed", "true")
+				c.Data(existing.StatusCode, existing.ContentType, existing.ResponseBody)
+			}
+			c.Abort()
+			return
+		}
+
+		writer := &idempotencyResponseWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		// A panicking handler must not leave the key stuck in progress
+		defer func() {
+			if r := recover(); r != nil {
+				repo.Delete(record.ID)
+				panic(r)
+			}
+		}()
+
+		c.Next()
+
+		if writer.Status() >= http.StatusInternalServerError {
+			if err := repo.Delete(record.ID); err != nil {
+				log.Printf("Failed to release idempotency key %d: %v", record.ID, err)
+			}
+			return
+		}
+		if err := repo.Complete(record.ID, writer.Status(), writer.Header().Get("Content-Type"), writer.body.Bytes()); err != nil {
+			log.Printf("Failed to store idempotent response %d: %v", record.ID, err)
+		}
+	}
+}
+
+// reserveIdempotencyKey reserves the key, first clearing an expired record
+// the cleanup job has not removed yet
+func reserveIdempotencyKey(repo repository.IdempotencyRepository, record *models.IdempotencyKey) (bool, error) {
+	existing, err := repo.Find(record.UserID, record.Key)
+	if err != nil {
+		return false, err
+	}
+	if existing != nil {
+		if existing.ExpiresAt.After(time.Now()) {
+			return false, nil
+		}
+		if err := repo.Delete(existing.ID); err != nil {
+			return false, err
+		}
+	}
+	return repo.Reserve(record)
+}
+
+// idempotencyResponseWriter keeps a copy of the response body to store
+type idempotencyResponseWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *idempotencyResponseWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *idempotencyResponseWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// publicIDParams maps path parameters to the table of the entity they name
+var publicIDParams = map[string]string{
+	"org_id":       "organizations",
+	"workspace_id": "workspaces",
+	"task_id":      "tasks",
+	"timelog_id":   "time_logs",
+	"user_id":      "users",
+}
+
+// publicIDSegments maps the path segment before an ":id" parameter to the
+// table of the entity it names, e.g. /tasks/:id
+var publicIDSegments = map[string]string{
+	"organizations": "organizations",
+	"workspaces":    "workspaces",
+	"tasks":         "tasks",
+	"timelogs":      "time_logs",
+	"users":         "users",
+	"screenshots":   "screenshots",
+}
+
+// ResolvePublicIDs lets path parameters name primary entities by public UUID
+// as well as by numeric ID. UUIDs are swapped for the internal ID before the
+// handlers parse the parameters; unknown UUIDs are a 404.
+func ResolvePublicIDs(publicIDRepo repository.PublicIDRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for i, param := range c.Params {
+			if len(param.Value) != 36 {
+				continue
+			}
+			if _, err := uuid.Parse(param.Value); err != nil {
+				continue
+			}
+			table := publicIDTable(c.FullPath(), param.Key)
+			if table == "" {
+				continue
+			}
+
+			id, err := publicIDRepo.FindID(table, strings.ToLower(param.Value))
+			if err != nil {
+				utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to resolve ID")
+				c.Abort()
+				return
+			}
+			if id == 0 {
+				utils.ErrorResponse(c, http.StatusNotFound, "Not found")
+				c.Abort()
+				return
+			}
+			c.Params[i].Value = strconv.FormatUint(uint64(id), 10)
+		}
+		c.Next()
+	}
+}
+
+// publicIDTable returns the table a path parameter refers to, or "" when it
+// does not name a primary entity
+func publicIDTable(fullPath, key string) string {
+	if key != "id" {
+		return publicIDParams[key]
+	}
+
+	segments := strings.Split(fullPath, "/")
+	for i := 1; i < len(segments); i++ {
+		if segments[i] == ":id" {
+			return publicIDSegments[segments[i-1]]
+		}
+	}
+	return ""
+}
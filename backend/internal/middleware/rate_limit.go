@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/ratelimit"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimitPolicy limits requests to a group of endpoints. Clients are limited
+// per IP address and, once authenticated, per user; a request must pass both.
+type RateLimitPolicy struct {
+	Name    string         // Bucket namespace, e.g. "auth" or "sync"
+	PerIP   ratelimit.Rate // Zero PerMinute disables the IP limit
+	PerUser ratelimit.Rate // Zero PerMinute disables the user limit
+	Exempt  *RateLimitExemptions
+}
+
+// RateLimitExemptions lists users and networks that are never rate limited,
+// e.g. load testing clients or an office NAT
+type RateLimitExemptions struct {
+	userIDs  map[uint]bool
+	networks []*net.IPNet
+}
+
+// NewRateLimitExemptions parses user IDs and IP addresses or CIDR ranges.
+// Invalid entries are logged and skipped.
+func NewRateLimitExemptions(userIDs, ips []string) *RateLimitExemptions {
+	e := &RateLimitExemptions{userIDs: make(map[uint]bool)}
+	for _, s := range userIDs {
+		id, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			log.Printf("⚠️  Ignoring invalid rate limit exempt user ID %q", s)
+			continue
+		}
+		e.userIDs[uint(id)] = true
+	}
+	for _, s := range ips {
+		if !strings.Contains(s, "/") {
+			if strings.Contains(s, ":") {
+				s += "/128"
+			} else {
+				s += "/32"
+			}
+		}
+		_, network, err := net.ParseCIDR(s)
+		if err != nil {
+			log.Printf("⚠️  Ignoring invalid rate limit exempt IP %q", s)
+			continue
+		}
+		e.networks = append(e.networks, network)
+	}
+	return e
+}
+
+func (e *RateLimitExemptions) allows(ip string, userID uint) bool {
+	if e == nil {
+		return false
+	}
+	if userID != 0 && e.userIDs[userID] {
+		return true
+	}
+	parsed := net.ParseIP(ip)
+	for _, network := range e.networks {
+		if parsed != nil && network.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// RateLimit limits requests with token buckets and reports the most
+// restrictive bucket in RateLimit-Limit, RateLimit-Remaining and
+// RateLimit-Reset headers. Rejected requests get 429 with Retry-After. If the
+// limiter fails (e.g. Redis is down) requests are let through.
+func RateLimit(limiter ratelimit.Limiter, policy RateLimitPolicy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ip := c.ClientIP()
+		userID := c.GetUint("userID")
+		if policy.Exempt.allows(ip, userID) {
+			c.Next()
+			return
+		}
+
+		var tightest *ratelimit.Result
+		take := func(key string, rate ratelimit.Rate) {
+			if !rate.Enabled() {
+				return
+			}
+			res, err := limiter.Take(c.Request.Context(), key, rate)
+			if err != nil {
+				log.Printf("⚠️  Rate limiter unavailable: %v", err)
+				return
+			}
+			if tightest == nil || !res.Allowed || (tightest.Allowed && res.Remaining < tightest.Remaining) {
+				tightest = &res
+			}
+		}
+
+		take("ratelimit:"+policy.Name+":ip:"+ip, policy.PerIP)
+		if userID != 0 {
+			take("ratelimit:"+policy.Name+":user:"+strconv.FormatUint(uint64(userID), 10), policy.PerUser)
+		}
+		if tightest == nil {
+			c.Next()
+			return
+		}
+
+		c.Header("RateLimit-Limit", strconv.Itoa(tightest.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(tightest.Remaining))
+		c.Header("RateLimit-Reset", ceilSeconds(tightest.Reset))
+
+		if !tightest.Allowed {
+			c.Header("Retry-After", ceilSeconds(tightest.RetryAfter))
+			utils.ErrorResponse(c, http.StatusTooManyRequests, "Rate limit exceeded. Please try again later.")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -1,8 +1,10 @@
 package models
 
 import (
+	"strings"
 	"time"
 
+	"github.com/beuphecan/remote-time-tracker/internal/calendar"
 	"gorm.io/gorm"
 )
 
@@ -12,6 +14,7 @@ type User struct {
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	UUID      string         `gorm:"<-:create;type:uuid;not null;uniqueIndex;default:gen_random_uuid()" json:"uuid"` // Public ID for APIs, generated on insert; foreign keys use ID
 
 	Email          string     `gorm:"uniqueIndex;not null" json:"email"`
 	PasswordHash   string     `gorm:"not null" json:"-"`
@@ -21,9 +24,18 @@ type User struct {
 	SystemRole     string     `gorm:"size:20;default:'member';index" json:"system_role"` // admin, member (system-level) - indexed for admin queries
 	IsActive       bool       `gorm:"default:true" json:"is_active"`
 	LastLoginAt    *time.Time `json:"last_login_at"`
-	PresenceStatus string     `gorm:"size:20;default:'idle';index" json:"presence_status"` // working, idle
+	PresenceStatus string     `gorm:"size:20;default:'idle';index" json:"presence_status"` // working, online, idle, offline
 	LastPresenceAt *time.Time `gorm:"index" json:"last_presence_at"`
 	LastWorkingAt  *time.Time `gorm:"index" json:"last_working_at"`
+	Timezone       string     `gorm:"size:64;not null;default:'UTC'" json:"timezone"` // IANA name; personal reports group days here
+
+	// Account erasure (GDPR): requested by the user, executed after a grace period
+	DeletionRequestedAt *time.Time `json:"deletion_requested_at"`
+	DeletionScheduledAt *time.Time `gorm:"index" json:"deletion_scheduled_at"`
+	AnonymizedAt        *time.Time `json:"anonymized_at"`
+
+	// Full-text search document, maintained by PostgreSQL
+	SearchVector string `gorm:"->:false;<-:false;type:tsvector GENERATED ALWAYS AS (setweight(to_tsvector('simple', coalesce(first_name, '') || ' ' || coalesce(last_name, '')), 'A') || setweight(to_tsvector('simple', coalesce(email, '')), 'B')) STORED;index:idx_users_search,type:gin" json:"-"`
 
 	// Relations
 	Tasks               []Task               `gorm:"foreignKey:UserID" json:"tasks,omitempty"`
@@ -35,13 +47,29 @@ type User struct {
 	WorkspaceMembers    []WorkspaceMember    `gorm:"foreignKey:UserID" json:"workspace_members,omitempty"`
 }
 
-// User presence status constants
+// User presence status constants. Heartbeats move a user between working
+// (tracking time), online (app open) and idle (away from the keyboard); users
+// whose heartbeats stop are marked offline. Stale is only computed, for users
+// not yet marked offline.
 const (
 	UserPresenceWorking = "working"
+	UserPresenceOnline  = "online"
 	UserPresenceIdle    = "idle"
+	UserPresenceOffline = "offline"
 	UserPresenceStale   = "stale"
 )
 
+// UserPresenceInterval is a span a user spent in one presence status, kept to
+// answer who was online when. Offline time has no interval.
+type UserPresenceInterval struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time  `json:"created_at"`
+	UserID    uint       `gorm:"not null;index:idx_presence_user_started,priority:1" json:"user_id"`
+	Status    string     `gorm:"size:20;not null" json:"status"` // working, online, idle
+	StartedAt time.Time  `gorm:"not null;index:idx_presence_user_started,priority:2" json:"started_at"`
+	EndedAt   *time.Time `gorm:"index" json:"ended_at"` // Nil while the status lasts
+}
+
 // IsSystemAdmin checks if user has system admin role
 func (u *User) IsSystemAdmin() bool {
 	return u.SystemRole == SystemRoleAdmin || u.Role == "admin"
@@ -58,8 +86,12 @@ type Task struct {
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	UUID      string         `gorm:"<-:create;type:uuid;not null;uniqueIndex;default:gen_random_uuid()" json:"uuid"`
+	Version   uint           `gorm:"not null;default:1" json:"version"` // Bumped on every update for optimistic locking
 
-	UserID         uint   `gorm:"not null;index" json:"user_id"`
+	UserID         uint   `gorm:"not null;index" json:"user_id"` // Assignee, who tracks time on the task
+	CreatedBy      *uint  `gorm:"index" json:"created_by"`       // Nil when not recorded (older and imported tasks)
+	ParentTaskID   *uint  `gorm:"index" json:"parent_task_id"`   // Set on subtasks
 	OrganizationID *uint  `gorm:"index" json:"organization_id"`
 	WorkspaceID    *uint  `gorm:"index" json:"workspace_id"`
 	LocalID        string `gorm:"size:100;uniqueIndex" json:"local_id"` // UUID from Electron app
@@ -70,14 +102,41 @@ type Task struct {
 	Color          string `gorm:"size:7" json:"color"`                  // Hex color code
 	IsManual       bool   `gorm:"default:false;index" json:"is_manual"` // true: manually created, false: auto from time tracker
 
+	// Accounting tags; empty values inherit from the workspace in exports
+	CostCenter  string `gorm:"size:100;index" json:"cost_center"`
+	ProjectCode string `gorm:"size:100;index" json:"project_code"`
+
+	// Key of the linked issue in an external tracker (e.g. Jira "PROJ-123")
+	ExternalRef string `gorm:"size:100;index" json:"external_ref"`
+
+	// Planning
+	DueDate         *time.Time `gorm:"type:date;index" json:"due_date"`
+	EstimateSeconds int64      `gorm:"default:0" json:"estimate_seconds"` // 0: no estimate
+	Tags            string     `gorm:"size:500" json:"tags"`              // Comma-separated, lowercase labels
+
+	// Workspace Kanban board placement. An empty or removed column puts the
+	// task in the first column of its status.
+	BoardStatus   string `gorm:"size:50" json:"board_status"` // Key of a WorkspaceTaskStatus
+	BoardPosition int    `gorm:"default:0" json:"board_position"`
+
 	// Admin fields
 	AdminNotes string `gorm:"type:text" json:"admin_notes"` // Admin notes for internal use
 
+	// Full-text search document, maintained by PostgreSQL
+	SearchVector string `gorm:"->:false;<-:false;type:tsvector GENERATED ALWAYS AS (setweight(to_tsvector('simple', coalesce(title, '')), 'A') || setweight(to_tsvector('simple', coalesce(description, '')), 'B') || setweight(to_tsvector('simple', coalesce(external_ref, '') || ' ' || replace(coalesce(tags, ''), ',', ' ')), 'C')) STORED;index:idx_tasks_search,type:gin" json:"-"`
+
 	// Relations
 	User         User          `gorm:"foreignKey:UserID" json:"user,omitempty"`
 	Organization *Organization `gorm:"foreignKey:OrganizationID" json:"organization,omitempty"`
 	Workspace    *Workspace    `gorm:"foreignKey:WorkspaceID" json:"workspace,omitempty"`
 	TimeLogs     []TimeLog     `gorm:"foreignKey:TaskID" json:"time_logs,omitempty"`
+
+	// Commits, pull/merge requests and issues linked to the task's time logs;
+	// only loaded for task details
+	Commits []TimeLogCommit `gorm:"-" json:"commits,omitempty"`
+
+	// Time tracked against the estimate; only loaded for task details
+	Estimate *TaskEstimate `gorm:"-" json:"estimate,omitempty"`
 }
 
 // TableName overrides the table name
@@ -85,12 +144,119 @@ func (Task) TableName() string {
 	return "tasks"
 }
 
+// Base task statuses. Workspace board columns each map onto one, so filters
+// and reports keep working with custom columns.
+const (
+	TaskStatusActive    = "active"
+	TaskStatusCompleted = "completed"
+	TaskStatusArchived  = "archived"
+)
+
+// TaskStatuses lists the base task statuses
+var TaskStatuses = []string{TaskStatusActive, TaskStatusCompleted, TaskStatusArchived}
+
+// WorkspaceTaskStatus is a column of a workspace's Kanban board. Workspaces
+// that never configured columns get DefaultTaskStatuses.
+type WorkspaceTaskStatus struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	WorkspaceID uint   `gorm:"not null;uniqueIndex:idx_workspace_task_status_key,priority:1" json:"workspace_id"`
+	Key         string `gorm:"size:50;not null;uniqueIndex:idx_workspace_task_status_key,priority:2" json:"key"`
+	Name        string `gorm:"size:100;not null" json:"name"`
+	Color       string `gorm:"size:7" json:"color"`
+	Category    string `gorm:"size:20;not null" json:"category"` // Base status of the column's tasks: active, completed, archived
+	Position    int    `gorm:"not null" json:"position"`
+}
+
+// TableName overrides the table name
+func (WorkspaceTaskStatus) TableName() string {
+	return "workspace_task_statuses"
+}
+
+// DefaultTaskStatuses returns one board column per base status
+func DefaultTaskStatuses(workspaceID uint) []WorkspaceTaskStatus {
+	return []WorkspaceTaskStatus{
+		{WorkspaceID: workspaceID, Key: TaskStatusActive, Name: "Active", Category: TaskStatusActive, Position: 0},
+		{WorkspaceID: workspaceID, Key: TaskStatusCompleted, Name: "Completed", Category: TaskStatusCompleted, Position: 1},
+		{WorkspaceID: workspaceID, Key: TaskStatusArchived, Name: "Archived", Category: TaskStatusArchived, Position: 2},
+	}
+}
+
+// TaskAssignee is a workspace member assigned to a task besides its owner
+// (Task.UserID). Assignees see the task in their task lists and track time
+// against it.
+type TaskAssignee struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	TaskID     uint `gorm:"not null;uniqueIndex:idx_task_assignee,priority:1" json:"task_id"`
+	UserID     uint `gorm:"not null;uniqueIndex:idx_task_assignee,priority:2;index" json:"user_id"`
+	AssignedBy uint `gorm:"not null" json:"assigned_by"`
+}
+
+// TableName overrides the table name
+func (TaskAssignee) TableName() string {
+	return "task_assignees"
+}
+
+// TaskComment is a message in a task's discussion
+type TaskComment struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	TaskID   uint   `gorm:"not null;index" json:"task_id"`
+	AuthorID uint   `gorm:"not null;index" json:"author_id"`
+	Body     string `gorm:"type:text;not null" json:"body"`
+	Mentions string `gorm:"type:text" json:"mentions"` // Comma-separated IDs of the mentioned users
+}
+
+// TableName overrides the table name
+func (TaskComment) TableName() string {
+	return "task_comments"
+}
+
+// TaskAttachment is a file attached to a task, optionally through one of its
+// comments
+type TaskAttachment struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	TaskID       uint   `gorm:"not null;index" json:"task_id"`
+	CommentID    *uint  `gorm:"index" json:"comment_id"`
+	UploadedBy   uint   `gorm:"not null" json:"uploaded_by"`
+	FilePath     string `gorm:"size:500;not null" json:"-"`
+	FileName     string `gorm:"size:255;not null" json:"file_name"` // Name on disk
+	OriginalName string `gorm:"size:255" json:"original_name"`      // Name sent by the uploader
+	ContentType  string `gorm:"size:100" json:"content_type"`
+	FileSize     int64  `gorm:"not null" json:"file_size"`
+}
+
+// TableName overrides the table name
+func (TaskAttachment) TableName() string {
+	return "task_attachments"
+}
+
+// TaskEstimate compares the time tracked on a task with its estimate
+type TaskEstimate struct {
+	EstimateSeconds  int64   `json:"estimate_seconds"`
+	TrackedSeconds   int64   `json:"tracked_seconds"`   // Including subtasks
+	SubtaskSeconds   int64   `json:"subtask_seconds"`   // Tracked on subtasks
+	RemainingSeconds int64   `json:"remaining_seconds"` // Negative once over the estimate
+	PercentUsed      float64 `json:"percent_used"`      // 0 without an estimate
+	OverEstimate     bool    `json:"over_estimate"`
+}
+
 // TimeLog represents a time tracking session
 type TimeLog struct {
 	ID        uint           `gorm:"primaryKey" json:"id"`
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	UUID      string         `gorm:"<-:create;type:uuid;not null;uniqueIndex;default:gen_random_uuid()" json:"uuid"`
 
 	UserID         uint   `gorm:"not null;index" json:"user_id"`
 	OrganizationID *uint  `gorm:"index" json:"organization_id"`
@@ -109,8 +275,18 @@ type TimeLog struct {
 	IsManual    bool       `gorm:"default:false" json:"is_manual"`
 	Notes       string     `gorm:"type:text" json:"notes"`
 	IsSynced    bool       `gorm:"default:false" json:"is_synced"`
-	LocalID     string     `gorm:"size:100;index" json:"local_id"` // ID from Electron app
-	PausedTotal int64      `gorm:"default:0" json:"paused_total"`  // Total paused time in seconds
+	LocalID     string     `gorm:"size:100;index" json:"local_id"`  // ID from Electron app
+	PausedTotal int64      `gorm:"default:0" json:"paused_total"`   // Total paused time in seconds
+	BreakTotal  int64      `gorm:"default:0" json:"break_total"`    // Total break time in seconds (pomodoro cycles), separate from pauses
+	CapStatus   string     `gorm:"size:20;index" json:"cap_status"` // Weekly hour cap flag: "", approaching, exceeded
+
+	// Seconds past the expected hours of the user's work schedule for the
+	// day or week, whichever rule found more; set by overtime detection
+	OvertimeSeconds int64 `gorm:"default:0" json:"overtime_seconds"`
+
+	// Sync conflict detection
+	Version      int   `gorm:"not null;default:1" json:"version"` // Incremented on every server-side change
+	SyncDeviceID *uint `json:"sync_device_id"`                    // Device that last synced a change
 
 	// Admin fields
 	IsApproved bool       `gorm:"default:false" json:"is_approved"` // Admin approved time log
@@ -118,14 +294,29 @@ type TimeLog struct {
 	ApprovedAt *time.Time `json:"approved_at"`
 	AdminNotes string     `gorm:"type:text" json:"admin_notes"` // Admin notes for internal use
 
+	// Manual entry awaiting review in a workspace that requires approval;
+	// cleared once an admin approves or rejects it
+	PendingApproval bool `gorm:"default:false;index" json:"pending_approval"`
+
+	// Invoice the time log is billed on. Invoiced time logs are locked
+	// against edits; only invoice creation and draft deletion write it.
+	InvoiceID *uint `gorm:"<-:false;index" json:"invoice_id"`
+
+	// Full-text search document, maintained by PostgreSQL
+	SearchVector string `gorm:"->:false;<-:false;type:tsvector GENERATED ALWAYS AS (setweight(to_tsvector('simple', coalesce(notes, '')), 'A') || setweight(to_tsvector('simple', coalesce(task_title, '')), 'B')) STORED;index:idx_time_logs_search,type:gin" json:"-"`
+
 	// Relations
-	User         User          `gorm:"foreignKey:UserID" json:"user,omitempty"`
-	Organization *Organization `gorm:"foreignKey:OrganizationID" json:"organization,omitempty"`
-	Workspace    *Workspace    `gorm:"foreignKey:WorkspaceID" json:"workspace,omitempty"`
-	Task         *Task         `gorm:"foreignKey:TaskID" json:"task,omitempty"`
-	Device       *DeviceInfo   `gorm:"foreignKey:DeviceID" json:"device,omitempty"`
-	Screenshots  []Screenshot  `gorm:"foreignKey:TimeLogID" json:"screenshots,omitempty"`
-	Approver     *User         `gorm:"foreignKey:ApprovedBy" json:"approver,omitempty"`
+	User         User           `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Organization *Organization  `gorm:"foreignKey:OrganizationID" json:"organization,omitempty"`
+	Workspace    *Workspace     `gorm:"foreignKey:WorkspaceID" json:"workspace,omitempty"`
+	Task         *Task          `gorm:"foreignKey:TaskID" json:"task,omitempty"`
+	Device       *DeviceInfo    `gorm:"foreignKey:DeviceID" json:"device,omitempty"`
+	Screenshots  []Screenshot   `gorm:"foreignKey:TimeLogID" json:"screenshots,omitempty"`
+	Breaks       []TimeLogBreak `gorm:"foreignKey:TimeLogID" json:"breaks,omitempty"`
+	Approver     *User          `gorm:"foreignKey:ApprovedBy" json:"approver,omitempty"`
+
+	// Segments the user kept private; no screenshots exist for them
+	PrivateIntervals []PrivateInterval `gorm:"foreignKey:TimeLogID" json:"private_intervals,omitempty"`
 }
 
 // TableName overrides the table name
@@ -133,12 +324,239 @@ func (TimeLog) TableName() string {
 	return "time_logs"
 }
 
+// Break kinds of a work/break cycle
+const (
+	BreakKindShort = "short" // Between pomodoro work intervals
+	BreakKindLong  = "long"  // After a set of work intervals
+	BreakKindOther = "other" // Taken outside a pomodoro cycle
+)
+
+// TimeLogBreak is a break within a time log, reported by the desktop app.
+// Breaks are not work time and are totalled apart from pauses.
+type TimeLogBreak struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	TimeLogID uint       `gorm:"not null;uniqueIndex:idx_time_log_break" json:"time_log_id"`
+	UserID    uint       `gorm:"not null;index" json:"user_id"`
+	LocalID   string     `gorm:"size:100;not null;uniqueIndex:idx_time_log_break" json:"local_id"` // ID from Electron app
+	Kind      string     `gorm:"size:20;not null;default:'short'" json:"kind"`                     // short, long, other
+	StartTime time.Time  `gorm:"not null" json:"start_time"`
+	EndTime   *time.Time `json:"end_time"`                  // Nil while the break is running
+	Duration  int64      `gorm:"default:0" json:"duration"` // Seconds
+}
+
+// TableName overrides the table name
+func (TimeLogBreak) TableName() string {
+	return "time_log_breaks"
+}
+
+// Sources of time log edits
+const (
+	TimeLogEditSourceAdmin    = "admin"               // System admin edit
+	TimeLogEditSourceSync     = "sync"                // Edit of a finished time log synced from the desktop app
+	TimeLogEditSourceConflict = "conflict_resolution" // The user kept their device's version of a sync conflict
+)
+
+// TimeLogSnapshot holds the values of a time log an edit can change
+type TimeLogSnapshot struct {
+	StartTime   time.Time  `json:"start_time"`
+	EndTime     *time.Time `json:"end_time"`
+	Duration    int64      `json:"duration"`
+	PausedTotal int64      `json:"paused_total"`
+	BreakTotal  int64      `json:"break_total"`
+	Status      string     `json:"status"`
+	Notes       string     `json:"notes"`
+	TaskID      *uint      `json:"task_id"`
+	WorkspaceID *uint      `json:"workspace_id"`
+	IsApproved  bool       `json:"is_approved"`
+	AdminNotes  string     `json:"admin_notes"`
+}
+
+// Snapshot returns the time log's editable values
+func (t *TimeLog) Snapshot() TimeLogSnapshot {
+	snapshot := TimeLogSnapshot{
+		StartTime:   t.StartTime.UTC(),
+		Duration:    t.Duration,
+		PausedTotal: t.PausedTotal,
+		BreakTotal:  t.BreakTotal,
+		Status:      t.Status,
+		Notes:       t.Notes,
+		TaskID:      t.TaskID,
+		WorkspaceID: t.WorkspaceID,
+		IsApproved:  t.IsApproved,
+		AdminNotes:  t.AdminNotes,
+	}
+	if t.EndTime != nil {
+		endTime := t.EndTime.UTC()
+		snapshot.EndTime = &endTime
+	}
+	return snapshot
+}
+
+// TimeLogEdit records a change to a time log for audits, with its values
+// before and after the change
+type TimeLogEdit struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+
+	TimeLogID uint   `gorm:"not null;index" json:"time_log_id"`
+	EditedBy  *uint  `json:"edited_by"`                      // Nil when edited by the system
+	Source    string `gorm:"size:30;not null" json:"source"` // admin, sync, conflict_resolution
+	Before    string `gorm:"type:jsonb" json:"before"`       // TimeLogSnapshot
+	After     string `gorm:"type:jsonb" json:"after"`
+
+	// Relations
+	Editor *User `gorm:"foreignKey:EditedBy" json:"editor,omitempty"`
+}
+
+// TableName overrides the table name
+func (TimeLogEdit) TableName() string {
+	return "time_log_edits"
+}
+
+// Reasons a user keeps an interval private
+const (
+	PrivateReasonPersonal     = "personal"     // Personal matters such as banking or health
+	PrivateReasonConfidential = "confidential" // Work under an NDA or with restricted data
+	PrivateReasonMeeting      = "meeting"      // Sensitive call or one-on-one
+	PrivateReasonOther        = "other"
+)
+
+// PrivateInterval is a span in which the user paused screenshot capture.
+// Screenshots captured within it are discarded on upload. The time is still
+// tracked and is reported as private time, so the gap stays visible.
+type PrivateInterval struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	UserID         uint       `gorm:"not null;index:idx_private_interval_user_start" json:"user_id"`
+	OrganizationID *uint      `gorm:"index" json:"organization_id"`
+	WorkspaceID    *uint      `gorm:"index" json:"workspace_id"`
+	TimeLogID      *uint      `gorm:"index" json:"time_log_id"`       // Time log the interval labels
+	Reason         string     `gorm:"size:20;not null" json:"reason"` // personal, confidential, meeting, other
+	Note           string     `gorm:"size:500" json:"note"`           // Optional, visible to managers
+	StartTime      time.Time  `gorm:"not null;index:idx_private_interval_user_start" json:"start_time"`
+	EndTime        *time.Time `json:"end_time"`                  // Nil while private mode is on
+	Duration       int64      `gorm:"default:0" json:"duration"` // Seconds, set when it ends
+}
+
+// TableName overrides the table name
+func (PrivateInterval) TableName() string {
+	return "private_intervals"
+}
+
+// WorkSchedule is a user's expected working time. Tracked hours are compared
+// against it to flag under- and overtime.
+type WorkSchedule struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	UserID              uint    `gorm:"not null;uniqueIndex" json:"user_id"`
+	WorkingDays         string  `gorm:"size:20;not null;default:'1,2,3,4,5'" json:"working_days"` // Comma-separated weekdays, 0=Sunday
+	ExpectedHoursPerDay float64 `gorm:"type:decimal(4,2);not null;default:8" json:"expected_hours_per_day"`
+	Timezone            string  `gorm:"size:64;not null;default:'UTC'" json:"timezone"` // IANA name; days start at midnight here
+	UpdatedBy           *uint   `json:"updated_by"`
+
+	// Relations
+	User User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// TableName overrides the table name
+func (WorkSchedule) TableName() string {
+	return "work_schedules"
+}
+
+// Overtime rules
+const (
+	OvertimeRuleDaily  = "daily"  // Past the expected hours of a day
+	OvertimeRuleWeekly = "weekly" // Past the expected hours of a week
+)
+
+// OvertimeRecord is a user's overtime in one day or week of their work
+// schedule, stored by the overtime detection job
+type OvertimeRecord struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	UserID          uint       `gorm:"not null;uniqueIndex:idx_overtime_user_rule_period" json:"user_id"`
+	Rule            string     `gorm:"size:20;not null;uniqueIndex:idx_overtime_user_rule_period" json:"rule"`
+	PeriodStart     time.Time  `gorm:"type:date;not null;uniqueIndex:idx_overtime_user_rule_period;index" json:"period_start"` // Day, or first day of the week, in the schedule's timezone
+	ExpectedSeconds int64      `gorm:"not null" json:"expected_seconds"`
+	TrackedSeconds  int64      `gorm:"not null" json:"tracked_seconds"`
+	OvertimeSeconds int64      `gorm:"not null" json:"overtime_seconds"`
+	TimeLogCount    int        `gorm:"not null" json:"time_log_count"` // Time logs with overtime in the period
+	NotifiedAt      *time.Time `json:"notified_at"`                    // When workspace admins were notified
+
+	// Relations
+	User User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// TableName overrides the table name
+func (OvertimeRecord) TableName() string {
+	return "overtime_records"
+}
+
+// LeaveRequest is a member's time off (vacation, sick leave) in an
+// organization, reviewed by an org or workspace admin. Working days on
+// approved leave are not expected to have tracked time.
+type LeaveRequest struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	OrganizationID uint      `gorm:"not null;index" json:"organization_id"`
+	UserID         uint      `gorm:"not null;index" json:"user_id"`
+	Type           string    `gorm:"size:20;not null" json:"type"` // vacation, sick, other
+	StartDate      time.Time `gorm:"type:date;not null;index" json:"start_date"`
+	EndDate        time.Time `gorm:"type:date;not null;index" json:"end_date"` // Inclusive
+	Reason         string    `gorm:"type:text" json:"reason"`
+	Status         string    `gorm:"size:20;not null;default:'pending';index" json:"status"` // pending, approved, rejected, cancelled
+
+	ReviewedBy *uint      `json:"reviewed_by"`
+	ReviewedAt *time.Time `json:"reviewed_at"`
+	ReviewNote string     `gorm:"type:text" json:"review_note"`
+
+	// Relations
+	User     User  `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Reviewer *User `gorm:"foreignKey:ReviewedBy" json:"reviewer,omitempty"`
+}
+
+// TableName overrides the table name
+func (LeaveRequest) TableName() string {
+	return "leave_requests"
+}
+
+// OrganizationHoliday is a public holiday or company day off in an
+// organization's calendar, added from a country preset or as a custom date.
+// Holidays are not working days for expected hours.
+type OrganizationHoliday struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	OrganizationID uint      `gorm:"not null;uniqueIndex:idx_org_holiday_date,priority:1" json:"organization_id"`
+	Date           time.Time `gorm:"type:date;not null;uniqueIndex:idx_org_holiday_date,priority:2" json:"date"`
+	Name           string    `gorm:"size:255;not null" json:"name"`
+	Country        string    `gorm:"size:2" json:"country"` // Preset country code, empty for custom dates
+	CreatedBy      *uint     `json:"created_by"`
+}
+
+// TableName overrides the table name
+func (OrganizationHoliday) TableName() string {
+	return "organization_holidays"
+}
+
 // Screenshot represents a captured screenshot
 type Screenshot struct {
 	ID        uint           `gorm:"primaryKey" json:"id"`
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	UUID      string         `gorm:"<-:create;type:uuid;not null;uniqueIndex;default:gen_random_uuid()" json:"uuid"`
 
 	UserID         uint   `gorm:"not null;index" json:"user_id"`
 	OrganizationID *uint  `gorm:"index" json:"organization_id"`
@@ -148,17 +566,48 @@ type Screenshot struct {
 	DeviceID       *uint  `gorm:"index" json:"device_id"`
 	TaskID         *uint  `gorm:"index" json:"task_id"`
 
-	FilePath     string    `gorm:"size:500;not null" json:"file_path"`
+	FilePath     string    `gorm:"size:500;not null;index" json:"file_path"` // Shared by identical screenshots of a user
 	FileName     string    `gorm:"size:255;not null" json:"file_name"`
 	FileSize     int64     `gorm:"not null" json:"file_size"`
 	MimeType     string    `gorm:"size:50" json:"mime_type"`
 	CapturedAt   time.Time `gorm:"not null;index" json:"captured_at"`
 	ScreenNumber int       `gorm:"default:0" json:"screen_number"`
 	IsEncrypted  bool      `gorm:"default:false" json:"is_encrypted"`
-	Checksum     string    `gorm:"size:64" json:"checksum"` // SHA256 checksum
+	Checksum     string    `gorm:"size:64;index" json:"checksum"` // SHA256 checksum
 	IsSynced     bool      `gorm:"default:false" json:"is_synced"`
 	LocalID      string    `gorm:"size:100;index" json:"local_id"`
 
+	// Client-side encryption envelope: the file is encrypted with a random
+	// content key, which is wrapped with the organization's public key
+	KeyFingerprint  string `gorm:"size:64;index" json:"key_fingerprint,omitempty"`
+	WrappedKey      string `gorm:"type:text" json:"-"`
+	EncryptionNonce string `gorm:"size:64" json:"-"`
+
+	// Blurred variant stored when the workspace blur mode is blur; managers
+	// see it instead of the original, which only system admins can view
+	BlurredPath string `gorm:"size:500" json:"-"`
+
+	// Storage tiering; cold screenshots have no local file until retrieved
+	StorageTier string     `gorm:"size:20;default:'hot';index" json:"storage_tier"`
+	ColdKey     string     `gorm:"size:500" json:"-"` // Object key in cold storage, kept after retrieval
+	TieredAt    *time.Time `json:"tiered_at,omitempty"`
+	RetrievedAt *time.Time `json:"-"` // Last retrieval from cold storage
+
+	// Malware scanning; empty status when scanning is disabled or the file is
+	// encrypted. Quarantined files are only shown to system admins.
+	ScanStatus    string     `gorm:"size:20;index" json:"scan_status,omitempty"`
+	ScanSignature string     `gorm:"size:255" json:"scan_signature,omitempty"` // Threat detected in a quarantined file
+	ScannedAt     *time.Time `json:"scanned_at,omitempty"`
+	ReviewedByID  *uint      `json:"reviewed_by_id,omitempty"` // System admin who released a quarantined file
+	ReviewedAt    *time.Time `json:"reviewed_at,omitempty"`
+
+	// Text read from the file by the optional OCR job, searchable by system
+	// admins; empty status when OCR is disabled or the file is encrypted
+	OCRStatus       string     `gorm:"size:20;index" json:"-"`
+	OCRText         string     `gorm:"type:text" json:"-"`
+	OCRAt           *time.Time `json:"-"`
+	OCRSearchVector string     `gorm:"->:false;<-:false;type:tsvector GENERATED ALWAYS AS (to_tsvector('simple', coalesce(ocr_text, ''))) STORED;index:idx_screenshots_ocr_search,type:gin" json:"-"`
+
 	// Relations
 	User         User          `gorm:"foreignKey:UserID" json:"user,omitempty"`
 	Organization *Organization `gorm:"foreignKey:OrganizationID" json:"organization,omitempty"`
@@ -201,6 +650,76 @@ func (DeviceInfo) TableName() string {
 	return "device_info"
 }
 
+// DeviceApproval is an organization's decision on whether a device may sync
+// into it, for organizations that require device approval. A device syncing
+// into such an organization for the first time gets a pending approval.
+type DeviceApproval struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	OrganizationID uint   `gorm:"not null;uniqueIndex:idx_device_approval_org_device" json:"organization_id"`
+	DeviceID       uint   `gorm:"not null;uniqueIndex:idx_device_approval_org_device" json:"device_id"`
+	UserID         uint   `gorm:"not null;index" json:"user_id"`                          // Device owner
+	Status         string `gorm:"size:20;not null;default:'pending';index" json:"status"` // pending, approved, rejected
+
+	ReviewedBy *uint      `json:"reviewed_by"`
+	ReviewedAt *time.Time `json:"reviewed_at"`
+	ReviewNote string     `gorm:"type:text" json:"review_note"`
+
+	// Relations
+	Device   DeviceInfo `gorm:"foreignKey:DeviceID" json:"device,omitempty"`
+	User     User       `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Reviewer *User      `gorm:"foreignKey:ReviewedBy" json:"reviewer,omitempty"`
+}
+
+// TableName overrides the table name
+func (DeviceApproval) TableName() string {
+	return "device_approvals"
+}
+
+// FeatureFlag gates a capability so it can be rolled out gradually. A flag is
+// on for everyone when enabled, else for the rollout percentage of
+// organizations; overrides for an organization or a user take precedence.
+type FeatureFlag struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Key            string `gorm:"size:100;not null;uniqueIndex" json:"key"` // e.g. screenshots, activity_tracking
+	Description    string `gorm:"type:text" json:"description"`
+	Enabled        bool   `gorm:"default:false" json:"enabled"`
+	RolloutPercent int    `gorm:"default:0" json:"rollout_percent"` // 0-100, when not enabled for everyone
+
+	// Relations
+	Overrides []FeatureFlagOverride `gorm:"foreignKey:FlagID" json:"overrides,omitempty"`
+}
+
+// TableName overrides the table name
+func (FeatureFlag) TableName() string {
+	return "feature_flags"
+}
+
+// FeatureFlagOverride turns a flag on or off for one organization or one
+// user. Zero OrganizationID/UserID means none (NULLs would defeat the unique
+// key).
+type FeatureFlagOverride struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	FlagID         uint `gorm:"not null;uniqueIndex:idx_feature_flag_override_target,priority:1" json:"flag_id"`
+	OrganizationID uint `gorm:"not null;default:0;uniqueIndex:idx_feature_flag_override_target,priority:2" json:"organization_id"`
+	UserID         uint `gorm:"not null;default:0;uniqueIndex:idx_feature_flag_override_target,priority:3" json:"user_id"`
+	Enabled        bool `gorm:"not null" json:"enabled"`
+	CreatedBy      uint `gorm:"not null" json:"created_by"`
+}
+
+// TableName overrides the table name
+func (FeatureFlagOverride) TableName() string {
+	return "feature_flag_overrides"
+}
+
 // SyncLog represents a synchronization log entry
 type SyncLog struct {
 	ID        uint           `gorm:"primaryKey" json:"id"`
@@ -253,93 +772,1034 @@ func (AuditLog) TableName() string {
 	return "audit_logs"
 }
 
-// ============================================================================
-// ORGANIZATION & WORKSPACE MODELS
-// ============================================================================
+// DataExport represents a user's personal data export archive (GDPR)
+type DataExport struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	UserID      uint       `gorm:"not null;index" json:"user_id"`
+	OperationID *uint      `gorm:"index" json:"operation_id"`
+	Status      string     `gorm:"size:20;default:'pending';index" json:"status"` // pending, processing, completed, failed
+	FilePath    string     `gorm:"size:500" json:"-"`
+	FileName    string     `gorm:"size:255" json:"file_name"`
+	FileSize    int64      `gorm:"default:0" json:"file_size"`
+	Error       string     `gorm:"type:text" json:"error,omitempty"`
+	CompletedAt *time.Time `json:"completed_at"`
+	ExpiresAt   *time.Time `gorm:"index" json:"expires_at"`
+}
 
-// Organization represents a company or team
-type Organization struct {
-	ID        uint           `gorm:"primaryKey" json:"id"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+// TableName overrides the table name
+func (DataExport) TableName() string {
+	return "data_exports"
+}
 
-	Name            string `gorm:"size:255;not null" json:"name"`
-	Slug            string `gorm:"size:255;uniqueIndex;not null" json:"slug"`
-	Description     string `gorm:"type:text" json:"description"`
-	LogoURL         string `gorm:"size:500" json:"logo_url"`
-	OwnerID         uint   `gorm:"not null;index" json:"owner_id"`
-	InviteCode      string `gorm:"size:50;uniqueIndex" json:"invite_code"`
-	AllowInviteLink bool   `gorm:"default:true" json:"allow_invite_link"`
-	ShareInviteCode bool   `gorm:"default:false" json:"share_invite_code"` // If true, all members can see invite code
-	MaxMembers      int    `gorm:"default:100" json:"max_members"`
-	IsActive        bool   `gorm:"default:true" json:"is_active"`
+// OrganizationExport is a full export archive of an organization's data,
+// requested by its owner
+type OrganizationExport struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 
-	// Admin fields
-	IsVerified bool       `gorm:"default:false" json:"is_verified"` // Admin verified organization
-	VerifiedAt *time.Time `json:"verified_at"`
-	VerifiedBy *uint      `json:"verified_by"`
-	AdminNotes string     `gorm:"type:text" json:"admin_notes"` // Admin notes for internal use
+	OrganizationID uint       `gorm:"not null;index" json:"organization_id"`
+	RequestedBy    uint       `gorm:"not null" json:"requested_by"`
+	OperationID    *uint      `gorm:"index" json:"operation_id"`
+	Status         string     `gorm:"size:20;default:'pending';index" json:"status"` // Same values as DataExport
+	Progress       int        `gorm:"default:0" json:"progress"`                     // Percent of records written
+	FilePath       string     `gorm:"size:500" json:"-"`
+	FileName       string     `gorm:"size:255" json:"file_name"`
+	FileSize       int64      `gorm:"default:0" json:"file_size"`
+	Error          string     `gorm:"type:text" json:"error,omitempty"`
+	CompletedAt    *time.Time `json:"completed_at"`
+	ExpiresAt      *time.Time `gorm:"index" json:"expires_at"`
 
 	// Relations
-	Owner      User                 `gorm:"foreignKey:OwnerID" json:"owner,omitempty"`
-	Members    []OrganizationMember `gorm:"foreignKey:OrganizationID" json:"members,omitempty"`
-	Workspaces []Workspace          `gorm:"foreignKey:OrganizationID" json:"workspaces,omitempty"`
-	Roles      []WorkspaceRole      `gorm:"foreignKey:OrganizationID" json:"roles,omitempty"`
+	Organization Organization `gorm:"foreignKey:OrganizationID" json:"-"`
 }
 
 // TableName overrides the table name
-func (Organization) TableName() string {
-	return "organizations"
+func (OrganizationExport) TableName() string {
+	return "organization_exports"
 }
 
-// OrganizationMember represents a user's membership in an organization
-type OrganizationMember struct {
-	ID        uint           `gorm:"primaryKey" json:"id"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
-
-	OrganizationID uint      `gorm:"not null;index" json:"organization_id"`
-	UserID         uint      `gorm:"not null;index" json:"user_id"`
-	Role           string    `gorm:"size:50;not null;default:'member'" json:"role"` // owner, admin, member
-	InvitedBy      *uint     `json:"invited_by"`
-	JoinedAt       time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"joined_at"`
-	IsActive       bool      `gorm:"default:true" json:"is_active"`
-
-	// Relations
-	Organization Organization `gorm:"foreignKey:OrganizationID" json:"organization,omitempty"`
-	User         User         `gorm:"foreignKey:UserID" json:"user,omitempty"`
-	Inviter      *User        `gorm:"foreignKey:InvitedBy" json:"inviter,omitempty"`
+// Operation tracks a long-running background job (export, purge, ...) so
+// its requester can poll progress and find the result
+type Operation struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Type           string     `gorm:"size:50;not null;index" json:"type"`
+	UserID         uint       `gorm:"not null;index" json:"user_id"` // Requester; only they can poll it
+	OrganizationID *uint      `gorm:"index" json:"organization_id"`
+	Status         string     `gorm:"size:20;default:'pending';index" json:"status"` // pending, running, completed, failed
+	Progress       int        `gorm:"default:0" json:"progress"`                     // 0-100
+	ResultURL      string     `gorm:"size:500" json:"result_url"`
+	Result         string     `gorm:"type:text" json:"result"` // JSON summary, if the operation has one
+	Error          string     `gorm:"type:text" json:"error,omitempty"`
+	StartedAt      *time.Time `json:"started_at"`
+	CompletedAt    *time.Time `gorm:"index" json:"completed_at"`
 }
 
 // TableName overrides the table name
-func (OrganizationMember) TableName() string {
-	return "organization_members"
+func (Operation) TableName() string {
+	return "operations"
 }
 
-// WorkspaceRole represents custom roles for workspace members
-type WorkspaceRole struct {
-	ID        uint           `gorm:"primaryKey" json:"id"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
-
-	OrganizationID uint   `gorm:"not null;index" json:"organization_id"`
-	Name           string `gorm:"size:100;not null" json:"name"`
-	DisplayName    string `gorm:"size:255;not null" json:"display_name"`
-	Description    string `gorm:"type:text" json:"description"`
-	Color          string `gorm:"size:7" json:"color"`
-	Permissions    string `gorm:"type:jsonb;default:'{}'" json:"permissions"`
-	IsDefault      bool   `gorm:"default:false" json:"is_default"`
-	SortOrder      int    `gorm:"default:0" json:"sort_order"`
+// IdempotencyKey records a POST request made with an Idempotency-Key header
+// so a retry with the same key replays the stored response instead of
+// repeating the request. StatusCode is 0 while the first request is running.
+type IdempotencyKey struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
 
-	// Relations
-	Organization Organization `gorm:"foreignKey:OrganizationID" json:"organization,omitempty"`
+	UserID       uint      `gorm:"not null;uniqueIndex:idx_idempotency_keys_user_key" json:"user_id"`
+	Key          string    `gorm:"size:255;not null;uniqueIndex:idx_idempotency_keys_user_key" json:"key"`
+	Method       string    `gorm:"size:10;not null" json:"method"`
+	Path         string    `gorm:"size:500;not null" json:"path"`
+	Fingerprint  string    `gorm:"size:64;not null" json:"fingerprint"` // SHA-256 of method, path and body
+	StatusCode   int       `gorm:"default:0" json:"status_code"`
+	ContentType  string    `gorm:"size:100" json:"content_type"`
+	ResponseBody []byte    `json:"-"`
+	ExpiresAt    time.Time `gorm:"not null;index" json:"expires_at"`
 }
 
 // TableName overrides the table name
-func (WorkspaceRole) TableName() string {
+func (IdempotencyKey) TableName() string {
+	return "idempotency_keys"
+}
+
+// DeviceLogBundle is a log archive uploaded by the desktop app for debugging
+type DeviceLogBundle struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	UserID       uint      `gorm:"not null;index" json:"user_id"`
+	DeviceID     uint      `gorm:"not null;index" json:"device_id"`
+	FilePath     string    `gorm:"size:500;not null" json:"-"`
+	FileName     string    `gorm:"size:255;not null" json:"file_name"` // Name on disk
+	OriginalName string    `gorm:"size:255" json:"original_name"`      // Name sent by the app
+	FileSize     int64     `gorm:"not null" json:"file_size"`
+	AppVersion   string    `gorm:"size:50" json:"app_version"`
+	Note         string    `gorm:"type:text" json:"note"` // What the user was doing when the issue occurred
+	ExpiresAt    time.Time `gorm:"not null;index" json:"expires_at"`
+
+	// Relations
+	User   User       `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Device DeviceInfo `gorm:"foreignKey:DeviceID" json:"device,omitempty"`
+}
+
+// TableName overrides the table name
+func (DeviceLogBundle) TableName() string {
+	return "device_log_bundles"
+}
+
+// CrashReport is a structured crash or error report sent by the desktop app
+type CrashReport struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	UserID      *uint     `gorm:"index" json:"user_id"` // Nil when the crash happened before login
+	DeviceUUID  string    `gorm:"size:100;index" json:"device_uuid"`
+	AppVersion  string    `gorm:"size:50;not null;index" json:"app_version"`
+	Platform    string    `gorm:"size:20;index" json:"platform"` // darwin, win32, linux
+	OSVersion   string    `gorm:"size:50" json:"os_version"`
+	Arch        string    `gorm:"size:20" json:"arch"`
+	ProcessType string    `gorm:"size:20" json:"process_type"` // main, renderer
+	ErrorType   string    `gorm:"size:255" json:"error_type"`
+	Message     string    `gorm:"type:text" json:"message"`
+	StackHash   string    `gorm:"size:64;not null;index" json:"stack_hash"` // Groups reports of the same crash
+	StackTrace  string    `gorm:"type:text" json:"stack_trace"`
+	IsFatal     bool      `gorm:"default:false" json:"is_fatal"`
+	OccurredAt  time.Time `gorm:"not null;index" json:"occurred_at"`
+}
+
+// TableName overrides the table name
+func (CrashReport) TableName() string {
+	return "crash_reports"
+}
+
+// UsageEvent records a user touching a product feature, for adoption analytics
+type UsageEvent struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	OrganizationID *uint     `gorm:"index" json:"organization_id"`          // Nil when not tied to an organization
+	UserID         *uint     `gorm:"index" json:"user_id"`                  // Nil for anonymous clients
+	Feature        string    `gorm:"size:50;not null;index" json:"feature"` // manual_task, invoicing, kanban, ...
+	Action         string    `gorm:"size:50" json:"action"`                 // open, create, export, ...
+	Platform       string    `gorm:"size:20" json:"platform"`               // web, darwin, win32, linux
+	AppVersion     string    `gorm:"size:50" json:"app_version"`
+	OccurredAt     time.Time `gorm:"not null;index" json:"occurred_at"`
+}
+
+// TableName overrides the table name
+func (UsageEvent) TableName() string {
+	return "usage_events"
+}
+
+// AppRelease is a desktop app release published by a system admin. Published
+// releases take precedence over GitHub releases in update checks.
+type AppRelease struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Version      string    `gorm:"size:50;not null;uniqueIndex" json:"version"`
+	Channel      string    `gorm:"size:20;not null;default:'stable';index" json:"channel"` // stable, beta
+	ReleaseNotes string    `gorm:"type:text" json:"release_notes"`
+	IsMandatory  bool      `gorm:"default:false" json:"is_mandatory"` // Older clients on the channel must upgrade and are refused sync
+	PublishedBy  uint      `gorm:"not null" json:"published_by"`
+	PublishedAt  time.Time `gorm:"not null;index" json:"published_at"`
+
+	// Relations
+	Assets []AppReleaseAsset `gorm:"foreignKey:ReleaseID" json:"assets,omitempty"`
+}
+
+// TableName overrides the table name
+func (AppRelease) TableName() string {
+	return "app_releases"
+}
+
+// AppReleaseAsset is a downloadable file of a published release, hosted at URL.
+// Besides full installers a release can carry block maps, which let the app
+// download only the changed blocks, and deltas from a given older version.
+type AppReleaseAsset struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	ReleaseID   uint   `gorm:"not null;index" json:"release_id"`
+	Name        string `gorm:"size:255;not null" json:"name"`
+	Kind        string `gorm:"size:20;not null;default:'installer'" json:"kind"` // installer, blockmap, delta
+	FromVersion string `gorm:"size:50" json:"from_version"`                      // Version a delta applies to
+	Platform    string `gorm:"size:20;not null" json:"platform"`                 // darwin, win32, linux
+	Arch        string `gorm:"size:20" json:"arch"`                              // x64, arm64, ia32; empty for all
+	URL         string `gorm:"type:text;not null" json:"url"`
+	Size        int64  `json:"size"`
+	ContentType string `gorm:"size:100" json:"content_type"`
+	SHA512      string `gorm:"size:128" json:"sha512"` // Base64, as electron-updater expects
+}
+
+// TableName overrides the table name
+func (AppReleaseAsset) TableName() string {
+	return "app_release_assets"
+}
+
+// AppReleaseDownload records a download of a release asset, published or
+// proxied from GitHub
+type AppReleaseDownload struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+
+	UserID    *uint  `gorm:"index" json:"user_id"` // Nil for public downloads
+	Version   string `gorm:"size:50;not null;index" json:"version"`
+	AssetName string `gorm:"size:255;not null" json:"asset_name"`
+	Kind      string `gorm:"size:20;not null" json:"kind"` // installer, blockmap, delta
+	Platform  string `gorm:"size:20" json:"platform"`
+	Arch      string `gorm:"size:20" json:"arch"`
+	Bytes     int64  `json:"bytes"` // Asset size; redirected downloads count as complete
+}
+
+// TableName overrides the table name
+func (AppReleaseDownload) TableName() string {
+	return "app_release_downloads"
+}
+
+// ScreenshotDailyRollup keeps per-day screenshot aggregates for screenshots
+// purged by retention, so reports stay accurate after the images are gone.
+// Zero WorkspaceID/TaskID means none (NULLs would defeat the unique key).
+type ScreenshotDailyRollup struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Date           time.Time `gorm:"type:date;not null;uniqueIndex:idx_screenshot_rollup_key,priority:1" json:"date"`
+	OrganizationID uint      `gorm:"not null;uniqueIndex:idx_screenshot_rollup_key,priority:2" json:"organization_id"`
+	WorkspaceID    uint      `gorm:"not null;default:0;uniqueIndex:idx_screenshot_rollup_key,priority:3" json:"workspace_id"`
+	UserID         uint      `gorm:"not null;uniqueIndex:idx_screenshot_rollup_key,priority:4;index" json:"user_id"`
+	TaskID         uint      `gorm:"not null;default:0;uniqueIndex:idx_screenshot_rollup_key,priority:5;index" json:"task_id"`
+
+	ScreenshotCount int64     `gorm:"not null;default:0" json:"screenshot_count"`
+	TotalBytes      int64     `gorm:"not null;default:0" json:"total_bytes"`
+	FirstCapturedAt time.Time `json:"first_captured_at"` // Activity window of the day
+	LastCapturedAt  time.Time `json:"last_captured_at"`
+}
+
+// TableName overrides the table name
+func (ScreenshotDailyRollup) TableName() string {
+	return "screenshot_daily_rollups"
+}
+
+// DailyUserStat is a user's tracked time and screenshots on a UTC day, rolled
+// up from time logs and screenshots so dashboards don't scan them on every
+// request. Zero OrganizationID/WorkspaceID means none.
+type DailyUserStat struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	Date           time.Time `gorm:"type:date;not null;uniqueIndex:idx_daily_user_stat_key,priority:1" json:"date"`
+	OrganizationID uint      `gorm:"not null;default:0;uniqueIndex:idx_daily_user_stat_key,priority:2" json:"organization_id"`
+	WorkspaceID    uint      `gorm:"not null;default:0;uniqueIndex:idx_daily_user_stat_key,priority:3" json:"workspace_id"`
+	UserID         uint      `gorm:"not null;uniqueIndex:idx_daily_user_stat_key,priority:4;index" json:"user_id"`
+
+	Duration    int64 `gorm:"not null;default:0" json:"duration"` // Seconds of time logs started that day
+	TimeLogs    int64 `gorm:"not null;default:0" json:"time_logs"`
+	Screenshots int64 `gorm:"not null;default:0" json:"screenshots"` // Including those purged by retention
+}
+
+// TableName overrides the table name
+func (DailyUserStat) TableName() string {
+	return "daily_user_stats"
+}
+
+// DailyWorkspaceStat is a workspace's tracked time and screenshots on a UTC
+// day; zero WorkspaceID holds the organization's time outside workspaces
+type DailyWorkspaceStat struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	Date           time.Time `gorm:"type:date;not null;uniqueIndex:idx_daily_workspace_stat_key,priority:1" json:"date"`
+	OrganizationID uint      `gorm:"not null;default:0;uniqueIndex:idx_daily_workspace_stat_key,priority:2" json:"organization_id"`
+	WorkspaceID    uint      `gorm:"not null;default:0;uniqueIndex:idx_daily_workspace_stat_key,priority:3" json:"workspace_id"`
+
+	Duration       int64  `gorm:"not null;default:0" json:"duration"`
+	TimeLogs       int64  `gorm:"not null;default:0" json:"time_logs"`
+	ActiveUsers    int64  `gorm:"not null;default:0" json:"active_users"`
+	Screenshots    int64  `gorm:"not null;default:0" json:"screenshots"`
+	TimeLogsByHour string `gorm:"type:jsonb;not null;default:'[]'" json:"time_logs_by_hour"` // 24 counts of time logs by UTC start hour
+}
+
+// TableName overrides the table name
+func (DailyWorkspaceStat) TableName() string {
+	return "daily_workspace_stats"
+}
+
+// DailyStatsRollup marks a UTC day as rolled up into the daily stats tables.
+// Rolled-up days form one contiguous range, read from the rollups; other
+// days, such as today, are read from the raw tables.
+type DailyStatsRollup struct {
+	Date       time.Time `gorm:"type:date;primaryKey" json:"date"`
+	RolledUpAt time.Time `gorm:"not null" json:"rolled_up_at"`
+}
+
+// TableName overrides the table name
+func (DailyStatsRollup) TableName() string {
+	return "daily_stats_rollups"
+}
+
+// OrganizationStorageCounter counts an organization's screenshots and their
+// bytes, updated on upload and delete so quota checks don't sum the
+// screenshots table. The row is created from the screenshots on first use.
+type OrganizationStorageCounter struct {
+	OrganizationID  uint      `gorm:"primaryKey;autoIncrement:false" json:"organization_id"`
+	ScreenshotCount int64     `gorm:"not null;default:0" json:"screenshot_count"`
+	UsedBytes       int64     `gorm:"not null;default:0" json:"used_bytes"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// TableName overrides the table name
+func (OrganizationStorageCounter) TableName() string {
+	return "organization_storage_counters"
+}
+
+// SyncConflict records a time log edited on two devices. Conflicts resolved by
+// policy are kept for visibility; under the manual policy they stay pending
+// until the user picks a side.
+type SyncConflict struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	UserID    uint   `gorm:"not null;index" json:"user_id"`
+	TimeLogID uint   `gorm:"not null;index" json:"time_log_id"`
+	LocalID   string `gorm:"size:100;index" json:"local_id"`
+	DeviceID  *uint  `json:"device_id"` // Device whose change conflicted
+
+	Policy          string     `gorm:"size:20;not null" json:"policy"`
+	ServerVersion   int        `json:"server_version"`
+	ClientVersion   *int       `json:"client_version"` // Base version the device edited
+	ClientUpdatedAt *time.Time `json:"client_updated_at"`
+	ClientData      string     `gorm:"type:text" json:"-"` // JSON of the rejected/pending sync item
+	ServerData      string     `gorm:"type:text" json:"-"` // JSON of the server state at conflict time
+
+	Status     string     `gorm:"size:20;not null;default:'pending';index" json:"status"` // pending, resolved
+	Resolution string     `gorm:"size:20" json:"resolution"`                              // client_applied, server_kept
+	ResolvedAt *time.Time `json:"resolved_at"`
+}
+
+// TableName overrides the table name
+func (SyncConflict) TableName() string {
+	return "sync_conflicts"
+}
+
+// ScreenshotDeletionRequest is a user's request to delete one of their
+// organization screenshots (e.g. an accidental sensitive capture), reviewed by
+// a manager. It outlives the screenshot to keep the justification on record.
+type ScreenshotDeletionRequest struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	ScreenshotID   uint  `gorm:"not null;index" json:"screenshot_id"`
+	UserID         uint  `gorm:"not null;index" json:"user_id"` // Requester, the screenshot owner
+	OrganizationID uint  `gorm:"not null;index" json:"organization_id"`
+	WorkspaceID    *uint `gorm:"index" json:"workspace_id"`
+
+	Reason string `gorm:"type:text;not null" json:"reason"`                       // Justification given by the user
+	Status string `gorm:"size:20;not null;default:'pending';index" json:"status"` // pending, approved, rejected, cancelled
+
+	// Snapshot of the screenshot, kept after the file is deleted
+	FileName   string    `gorm:"size:255" json:"file_name"`
+	CapturedAt time.Time `json:"captured_at"`
+
+	ReviewedBy *uint      `json:"reviewed_by"`
+	ReviewedAt *time.Time `json:"reviewed_at"`
+	ReviewNote string     `gorm:"type:text" json:"review_note"`
+
+	// Relations
+	User     User  `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Reviewer *User `gorm:"foreignKey:ReviewedBy" json:"reviewer,omitempty"`
+}
+
+// TableName overrides the table name
+func (ScreenshotDeletionRequest) TableName() string {
+	return "screenshot_deletion_requests"
+}
+
+// CaptureExclusionRule is an organization rule for windows that must never be
+// captured (password managers, banking sites). Agents skip capture for matching
+// windows, and matching screenshots that are uploaded anyway are discarded.
+type CaptureExclusionRule struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	OrganizationID uint   `gorm:"not null;index" json:"organization_id"`
+	Name           string `gorm:"size:100" json:"name"`
+	MatchType      string `gorm:"size:20;not null" json:"match_type"` // app, window_title
+	Pattern        string `gorm:"size:255;not null" json:"pattern"`   // Case-insensitive substring, or regex when IsRegex
+	IsRegex        bool   `gorm:"default:false" json:"is_regex"`
+	IsActive       bool   `gorm:"default:true" json:"is_active"`
+	CreatedBy      uint   `gorm:"not null" json:"created_by"`
+}
+
+// TableName overrides the table name
+func (CaptureExclusionRule) TableName() string {
+	return "capture_exclusion_rules"
+}
+
+// Screenshot encryption key statuses
+const (
+	EncryptionKeyActive  = "active"  // New uploads are encrypted for this key
+	EncryptionKeyRetired = "retired" // Still decrypts existing screenshots until they are re-keyed
+)
+
+// Screenshot encryption algorithms
+const (
+	EncryptionKeyAlgorithm     = "RSA-OAEP-256" // Wraps the per-screenshot content key
+	EncryptionContentAlgorithm = "AES-256-GCM"  // Encrypts the screenshot file
+)
+
+// ScreenshotEncryptionKey is an organization's public key for client-side
+// screenshot encryption. The private key never reaches the server; admins
+// decrypt downloaded screenshots locally.
+type ScreenshotEncryptionKey struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	OrganizationID uint       `gorm:"not null;uniqueIndex:idx_encryption_key_org_fingerprint" json:"organization_id"`
+	Fingerprint    string     `gorm:"size:64;not null;uniqueIndex:idx_encryption_key_org_fingerprint" json:"fingerprint"` // SHA256 of the DER public key
+	PublicKey      string     `gorm:"type:text;not null" json:"public_key"`                                               // PEM
+	Algorithm      string     `gorm:"size:30;not null" json:"algorithm"`
+	Status         string     `gorm:"size:20;not null;default:'active';index" json:"status"`
+	CreatedBy      uint       `gorm:"not null" json:"created_by"`
+	RetiredAt      *time.Time `json:"retired_at,omitempty"`
+}
+
+// TableName overrides the table name
+func (ScreenshotEncryptionKey) TableName() string {
+	return "screenshot_encryption_keys"
+}
+
+// Task assignment strategies
+const (
+	AssignmentRoundRobin = "round_robin" // Rotate through all active workspace members
+	AssignmentByRole     = "by_role"     // Rotate through members with a workspace role
+	AssignmentByTag      = "by_tag"      // Tasks with a matching accounting tag
+)
+
+// Task tag fields a by_tag assignment rule can match
+const (
+	AssignmentTagProjectCode = "project_code"
+	AssignmentTagCostCenter  = "cost_center"
+)
+
+// TaskAssignmentRule picks the member a new workspace task is assigned to.
+// Active rules are tried by ascending priority; the first that matches the
+// task and finds an active member wins.
+type TaskAssignmentRule struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	WorkspaceID     uint   `gorm:"not null;index" json:"workspace_id"`
+	Name            string `gorm:"size:100" json:"name"`
+	Strategy        string `gorm:"size:20;not null" json:"strategy"` // round_robin, by_role, by_tag
+	Priority        int    `gorm:"default:0" json:"priority"`        // Lower runs first
+	WorkspaceRoleID *uint  `json:"workspace_role_id"`                // by_role: role whose members rotate
+	TagField        string `gorm:"size:20" json:"tag_field"`         // by_tag: project_code, cost_center
+	TagValue        string `gorm:"size:100" json:"tag_value"`        // by_tag: case-insensitive exact match
+	AssigneeID      *uint  `json:"assignee_id"`                      // by_tag: fixed assignee; nil rotates through all members
+	IsActive        bool   `gorm:"default:true" json:"is_active"`
+	CreatedBy       uint   `gorm:"not null" json:"created_by"`
+
+	// Round-robin cursor: the member assigned last
+	LastAssignedUserID *uint `json:"-"`
+}
+
+// TableName overrides the table name
+func (TaskAssignmentRule) TableName() string {
+	return "task_assignment_rules"
+}
+
+// JiraIntegration connects a workspace to a Jira project. Issues are imported
+// as tasks and stopped time logs on them are pushed back as worklogs.
+type JiraIntegration struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	WorkspaceID   uint       `gorm:"not null;uniqueIndex" json:"workspace_id"`
+	BaseURL       string     `gorm:"size:255;not null" json:"base_url"` // e.g. https://acme.atlassian.net
+	Email         string     `gorm:"size:255;not null" json:"email"`    // Account the API token belongs to
+	APIToken      string     `gorm:"size:255;not null" json:"-"`
+	ProjectKey    string     `gorm:"size:50;not null" json:"project_key"`
+	JQL           string     `gorm:"type:text" json:"jql"` // Extra filter ANDed with the project
+	PushWorklogs  bool       `gorm:"default:true" json:"push_worklogs"`
+	IsActive      bool       `gorm:"default:true" json:"is_active"`
+	CreatedBy     uint       `gorm:"not null" json:"created_by"` // Owns imported issues without a matching assignee
+	LastSyncAt    *time.Time `json:"last_sync_at"`
+	LastSyncError string     `gorm:"type:text" json:"last_sync_error"`
+}
+
+// TableName overrides the table name
+func (JiraIntegration) TableName() string {
+	return "jira_integrations"
+}
+
+// JiraWorklog records a time log pushed to Jira so it is only pushed once
+type JiraWorklog struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	IntegrationID uint   `gorm:"not null;index" json:"integration_id"`
+	TimeLogID     uint   `gorm:"not null;uniqueIndex" json:"time_log_id"`
+	IssueKey      string `gorm:"size:100;not null" json:"issue_key"`
+	WorklogID     string `gorm:"size:50" json:"worklog_id"`
+}
+
+// TableName overrides the table name
+func (JiraWorklog) TableName() string {
+	return "jira_worklogs"
+}
+
+// VCSRepository is a GitHub or GitLab repository linked to a workspace. Its
+// push webhook links commits to the time log their author was tracking, and
+// "#123" style refs in time log notes resolve against it.
+type VCSRepository struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	WorkspaceID   uint   `gorm:"not null;index;uniqueIndex:idx_vcs_repository" json:"workspace_id"`
+	Provider      string `gorm:"size:20;not null;uniqueIndex:idx_vcs_repository" json:"provider"`   // github, gitlab
+	BaseURL       string `gorm:"size:255;not null" json:"base_url"`                                 // https://github.com, https://gitlab.com or a self-hosted GitLab
+	FullName      string `gorm:"size:255;not null;uniqueIndex:idx_vcs_repository" json:"full_name"` // owner/repo or group/subgroup/project
+	IsDefault     bool   `gorm:"default:false" json:"is_default"`                                   // Bare "#123" refs in notes resolve here
+	WebhookSecret string `gorm:"size:100;not null" json:"-"`
+	CreatedBy     uint   `gorm:"not null" json:"created_by"`
+}
+
+// TableName overrides the table name
+func (VCSRepository) TableName() string {
+	return "vcs_repositories"
+}
+
+// TimeLogCommit links a commit, pull/merge request or issue to a time log
+type TimeLogCommit struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	TimeLogID  uint   `gorm:"not null;uniqueIndex:idx_time_log_commit" json:"time_log_id"`
+	TaskID     *uint  `gorm:"index" json:"task_id"`
+	Provider   string `gorm:"size:20;not null;uniqueIndex:idx_time_log_commit" json:"provider"`    // github, gitlab
+	Repository string `gorm:"size:255;not null;uniqueIndex:idx_time_log_commit" json:"repository"` // owner/repo
+	Kind       string `gorm:"size:20;not null;uniqueIndex:idx_time_log_commit" json:"kind"`        // commit, pull_request, merge_request, issue
+	Ref        string `gorm:"size:64;not null;uniqueIndex:idx_time_log_commit" json:"ref"`         // Commit SHA or number
+	URL        string `gorm:"size:500" json:"url"`
+	Title      string `gorm:"size:255" json:"title"`          // Commit message subject, when known
+	Source     string `gorm:"size:20;not null" json:"source"` // notes, webhook, manual
+	CreatedBy  *uint  `json:"created_by"`                     // Nil for webhook links
+}
+
+// TableName overrides the table name
+func (TimeLogCommit) TableName() string {
+	return "time_log_commits"
+}
+
+// CalendarFeed is a user's private ICS subscription of their completed time
+// logs. The token in the feed URL is its only credential.
+type CalendarFeed struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	UserID        uint       `gorm:"not null;uniqueIndex" json:"user_id"`
+	Token         string     `gorm:"size:100;not null;uniqueIndex" json:"-"`
+	LastFetchedAt *time.Time `json:"last_fetched_at"` // Last time a calendar app fetched the feed
+}
+
+// TableName overrides the table name
+func (CalendarFeed) TableName() string {
+	return "calendar_feeds"
+}
+
+// ShareLink gives people outside the organization, such as a client, a
+// read-only view of a workspace's tracked hours and tasks without an
+// account. Screenshots are never shared. The token in the link URL is its
+// only credential; links expire and can be revoked.
+type ShareLink struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	WorkspaceID  uint       `gorm:"not null;index" json:"workspace_id"`
+	Name         string     `gorm:"size:255" json:"name"` // Who the link is for
+	Token        string     `gorm:"size:100;not null;uniqueIndex" json:"-"`
+	StartDate    *time.Time `gorm:"type:date" json:"start_date"` // Fixed report range; viewers choose it when unset
+	EndDate      *time.Time `gorm:"type:date" json:"end_date"`   // Inclusive
+	ExpiresAt    time.Time  `gorm:"not null" json:"expires_at"`
+	RevokedAt    *time.Time `json:"revoked_at"`
+	ViewCount    int64      `gorm:"not null;default:0" json:"view_count"`
+	LastViewedAt *time.Time `json:"last_viewed_at"`
+	CreatedBy    uint       `json:"created_by"`
+}
+
+// TableName overrides the table name
+func (ShareLink) TableName() string {
+	return "share_links"
+}
+
+// GoogleCalendarConnection pushes a user's completed time logs to one of
+// their Google calendars as events
+type GoogleCalendarConnection struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	UserID       uint       `gorm:"not null;uniqueIndex" json:"user_id"`
+	CalendarID   string     `gorm:"size:255;not null;default:'primary'" json:"calendar_id"`
+	RefreshToken string     `gorm:"type:text;not null" json:"-"`
+	AccessToken  string     `gorm:"type:text" json:"-"`
+	TokenExpiry  time.Time  `json:"-"`
+	PushSince    time.Time  `gorm:"not null" json:"push_since"` // Only time logs started after connecting are pushed
+	IsActive     bool       `gorm:"default:true" json:"is_active"`
+	LastPushedAt *time.Time `json:"last_pushed_at"`
+	LastError    string     `gorm:"type:text" json:"last_error"` // Cleared by the next successful push
+}
+
+// TableName overrides the table name
+func (GoogleCalendarConnection) TableName() string {
+	return "google_calendar_connections"
+}
+
+// GoogleCalendarEvent records a time log pushed to Google Calendar, so it is
+// never pushed twice
+type GoogleCalendarEvent struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	ConnectionID uint   `gorm:"not null;uniqueIndex:idx_google_calendar_event" json:"connection_id"`
+	TimeLogID    uint   `gorm:"not null;uniqueIndex:idx_google_calendar_event" json:"time_log_id"`
+	EventID      string `gorm:"size:255;not null" json:"event_id"`
+}
+
+// TableName overrides the table name
+func (GoogleCalendarEvent) TableName() string {
+	return "google_calendar_events"
+}
+
+// EmailOutbox is a queued email. Messages are rendered when queued and sent
+// by the delivery job, which retries failures with backoff.
+type EmailOutbox struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	UserID        *uint      `gorm:"index" json:"user_id"` // Nil for recipients without an account (invitations)
+	ToEmail       string     `gorm:"size:255;not null" json:"to_email"`
+	ToName        string     `gorm:"size:255" json:"to_name"`
+	Template      string     `gorm:"size:50;not null;index" json:"template"`
+	Subject       string     `gorm:"size:500;not null" json:"subject"`
+	TextBody      string     `gorm:"type:text;not null" json:"-"`
+	HTMLBody      string     `gorm:"type:text" json:"-"`
+	Status        string     `gorm:"size:20;not null;default:'pending';index" json:"status"` // pending, sent, failed
+	Attempts      int        `gorm:"default:0" json:"attempts"`
+	LastError     string     `gorm:"type:text" json:"last_error"`
+	NextAttemptAt *time.Time `gorm:"index" json:"next_attempt_at"` // Nil once sent or failed
+	SentAt        *time.Time `json:"sent_at"`
+
+	// Relations
+	Attachments []EmailAttachment `gorm:"foreignKey:EmailID" json:"-"`
+}
+
+// TableName overrides the table name
+func (EmailOutbox) TableName() string {
+	return "email_outbox"
+}
+
+// EmailAttachment is a file sent with a queued email, deleted with it
+type EmailAttachment struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	EmailID     uint   `gorm:"not null;index" json:"email_id"`
+	Filename    string `gorm:"size:255;not null" json:"filename"`
+	ContentType string `gorm:"size:100;not null" json:"content_type"`
+	Data        []byte `gorm:"type:bytea;not null" json:"-"`
+}
+
+// TableName overrides the table name
+func (EmailAttachment) TableName() string {
+	return "email_attachments"
+}
+
+// Invoice bills a client for the approved time of billable workspaces in a
+// date range. Its line items are fixed when it is created and the time logs
+// they cover are locked; deleting a draft releases them.
+type Invoice struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	OrganizationID uint       `gorm:"not null;uniqueIndex:idx_org_invoice_number,priority:1" json:"organization_id"`
+	Number         string     `gorm:"size:50;not null;uniqueIndex:idx_org_invoice_number,priority:2" json:"number"` // INV-<year>-<sequence>
+	ClientID       *uint      `gorm:"index" json:"client_id"`
+	BillTo         string     `gorm:"size:255" json:"bill_to"` // Client name when the invoice was created
+	WorkspaceID    *uint      `gorm:"index" json:"workspace_id"`
+	Status         string     `gorm:"size:20;not null;default:'draft';index" json:"status"` // draft, sent, paid
+	Currency       string     `gorm:"size:3;not null" json:"currency"`
+	PeriodStart    time.Time  `gorm:"type:date;not null" json:"period_start"`
+	PeriodEnd      time.Time  `gorm:"type:date;not null" json:"period_end"` // Inclusive
+	DueDate        *time.Time `gorm:"type:date" json:"due_date"`
+	TotalMinor     int64      `gorm:"not null;default:0" json:"total_minor"` // In minor units of Currency
+	Notes          string     `gorm:"type:text" json:"notes"`
+	CreatedBy      uint       `json:"created_by"`
+	SentAt         *time.Time `json:"sent_at"`
+	PaidAt         *time.Time `json:"paid_at"`
+
+	// Relations
+	LineItems []InvoiceLineItem `gorm:"foreignKey:InvoiceID" json:"line_items,omitempty"`
+}
+
+// TableName overrides the table name
+func (Invoice) TableName() string {
+	return "invoices"
+}
+
+// InvoiceLineItem bills a workspace's approved time at its hourly rate,
+// converted to the invoice currency
+type InvoiceLineItem struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	InvoiceID      uint   `gorm:"not null;index" json:"invoice_id"`
+	WorkspaceID    uint   `gorm:"not null" json:"workspace_id"`
+	Description    string `gorm:"size:500;not null" json:"description"`
+	CostCenter     string `gorm:"size:100" json:"cost_center"`
+	ProjectCode    string `gorm:"size:100" json:"project_code"`
+	Seconds        int64  `gorm:"not null" json:"seconds"`
+	TimeLogCount   int    `gorm:"not null" json:"time_log_count"`
+	UnitPriceMinor int64  `gorm:"not null" json:"unit_price_minor"` // Hourly rate in minor units of the invoice currency
+	AmountMinor    int64  `gorm:"not null" json:"amount_minor"`
+}
+
+// TableName overrides the table name
+func (InvoiceLineItem) TableName() string {
+	return "invoice_line_items"
+}
+
+// SavedReport is a custom report configuration a member saved to rerun it or
+// have it emailed on a schedule. It always runs with its owner's access.
+type SavedReport struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	OrganizationID uint   `gorm:"not null;index" json:"organization_id"`
+	UserID         uint   `gorm:"not null;index" json:"user_id"` // Owner
+	Name           string `gorm:"size:255;not null" json:"name"`
+	Dimensions     string `gorm:"size:100;not null" json:"dimensions"` // Comma-separated custom report dimensions
+	Measures       string `gorm:"size:100;not null" json:"measures"`   // Comma-separated custom report measures
+	WorkspaceID    *uint  `gorm:"index" json:"workspace_id"`
+	FilterUserID   *uint  `json:"filter_user_id"`                 // Only this member's time
+	RangeDays      int    `gorm:"not null" json:"range_days"`     // Days covered, ending the day before each run
+	Format         string `gorm:"size:10;not null" json:"format"` // csv or pdf
+
+	// Relations
+	Organization Organization     `gorm:"foreignKey:OrganizationID" json:"organization,omitempty"`
+	User         User             `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Schedules    []ReportSchedule `gorm:"foreignKey:SavedReportID" json:"schedules,omitempty"`
+}
+
+// TableName overrides the table name
+func (SavedReport) TableName() string {
+	return "saved_reports"
+}
+
+// ReportSchedule emails a saved report to its recipients daily or weekly at
+// an hour of the schedule's timezone
+type ReportSchedule struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	SavedReportID uint       `gorm:"not null;index" json:"saved_report_id"`
+	Frequency     string     `gorm:"size:10;not null" json:"frequency"` // daily or weekly
+	Weekday       int        `gorm:"not null" json:"weekday"`           // Weekly only, 0=Sunday
+	Hour          int        `gorm:"not null" json:"hour"`              // 0-23
+	Timezone      string     `gorm:"size:50;not null" json:"timezone"`
+	Recipients    string     `gorm:"type:text;not null" json:"recipients"` // Comma-separated member emails
+	IsActive      bool       `gorm:"not null" json:"is_active"`
+	NextRunAt     time.Time  `gorm:"not null;index" json:"next_run_at"`
+	LastRunAt     *time.Time `json:"last_run_at"`
+	LastError     string     `gorm:"type:text" json:"last_error"`
+
+	// Relations
+	SavedReport SavedReport `gorm:"foreignKey:SavedReportID" json:"-"`
+}
+
+// TableName overrides the table name
+func (ReportSchedule) TableName() string {
+	return "report_schedules"
+}
+
+// NotificationPreference holds a user's notification opt-outs. Users without
+// a row get the defaults. Transactional email (invitations, welcome, password
+// resets) is always sent.
+type NotificationPreference struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	UserID uint `gorm:"not null;uniqueIndex" json:"user_id"`
+
+	// No column defaults: gorm would insert true in place of an explicit false
+	EmailEnabled       bool `gorm:"not null" json:"email_enabled"`        // Master switch for non-transactional email
+	EmailWeeklySummary bool `gorm:"not null" json:"email_weekly_summary"` // Last week's tracked time, sent on Mondays
+}
+
+// TableName overrides the table name
+func (NotificationPreference) TableName() string {
+	return "notification_preferences"
+}
+
+// DefaultNotificationPreference returns the preferences of a user who never changed them
+func DefaultNotificationPreference(userID uint) NotificationPreference {
+	return NotificationPreference{
+		UserID:             userID,
+		EmailEnabled:       true,
+		EmailWeeklySummary: true,
+	}
+}
+
+// PasswordResetToken is a single-use password reset link. Only a hash of
+// the token is stored.
+type PasswordResetToken struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	UserID    uint       `gorm:"not null;index" json:"user_id"`
+	TokenHash string     `gorm:"size:64;not null;uniqueIndex" json:"-"` // SHA-256 hex
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+}
+
+// TableName overrides the table name
+func (PasswordResetToken) TableName() string {
+	return "password_reset_tokens"
+}
+
+// Notification is an in-app notification shown under the bell in the web and
+// desktop apps
+type Notification struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+
+	UserID     uint       `gorm:"not null;index:idx_notifications_user_read" json:"user_id"`
+	Type       string     `gorm:"size:50;not null" json:"type"` // invitation_received, timelog_rejected, workspace_archived, task_assigned
+	Title      string     `gorm:"size:255;not null" json:"title"`
+	Body       string     `gorm:"type:text" json:"body"`
+	EntityType string     `gorm:"size:50" json:"entity_type"` // What the notification links to: invitation, timelog, workspace
+	EntityID   *uint      `json:"entity_id"`
+	ReadAt     *time.Time `gorm:"index:idx_notifications_user_read" json:"read_at"`
+}
+
+// ============================================================================
+// ORGANIZATION & WORKSPACE MODELS
+// ============================================================================
+
+// Organization represents a company or team
+type Organization struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	UUID      string         `gorm:"<-:create;type:uuid;not null;uniqueIndex;default:gen_random_uuid()" json:"uuid"`
+	Version   uint           `gorm:"not null;default:1" json:"version"`
+
+	Name            string `gorm:"size:255;not null" json:"name"`
+	Slug            string `gorm:"size:255;uniqueIndex;not null" json:"slug"`
+	Description     string `gorm:"type:text" json:"description"`
+	LogoURL         string `gorm:"size:500" json:"logo_url"`
+	OwnerID         uint   `gorm:"not null;index" json:"owner_id"`
+	InviteCode      string `gorm:"size:50;uniqueIndex" json:"invite_code"`
+	AllowInviteLink bool   `gorm:"default:true" json:"allow_invite_link"`
+	ShareInviteCode bool   `gorm:"default:false" json:"share_invite_code"` // If true, all members can see invite code
+	MaxMembers      int    `gorm:"default:100" json:"max_members"`
+	IsActive        bool   `gorm:"default:true" json:"is_active"`
+
+	// Calendar settings (used for reports, trend grouping, timesheets and payroll periods)
+	WorkingDays          string `gorm:"size:20;default:'1,2,3,4,5'" json:"working_days"` // Comma-separated weekdays, 0=Sunday ... 6=Saturday
+	WeekStartDay         int    `gorm:"default:1" json:"week_start_day"`                 // 0=Sunday ... 6=Saturday
+	FiscalYearStartMonth int    `gorm:"default:1" json:"fiscal_year_start_month"`        // 1=January ... 12=December
+	Timezone             string `gorm:"size:64;not null;default:'UTC'" json:"timezone"`  // IANA name; organization reports group days here
+
+	// Retention settings
+	ScreenshotRetentionDays int `gorm:"default:0" json:"screenshot_retention_days"` // 0 = keep screenshots forever
+
+	// Storage settings
+	StorageQuotaBytes *int64 `json:"storage_quota_bytes"` // Screenshot storage; nil = unlimited
+
+	// Privacy settings
+	UsageAnalyticsOptOut bool `gorm:"default:false" json:"usage_analytics_opt_out"` // Drop feature usage events from members
+
+	// Billing settings
+	Currency string `gorm:"size:3;not null;default:'USD'" json:"currency"` // ISO 4217; member cost rates and reports are in it
+
+	// Security settings
+	RequireDeviceApproval bool `gorm:"default:false" json:"require_device_approval"` // Devices must be approved by an admin before they sync
+
+	// Desktop app updates
+	UpdateChannel string `gorm:"size:20;not null;default:'stable'" json:"update_channel"` // stable, beta; pins members' desktop apps to the channel
+
+	// Payroll settings; time logs starting before EntriesLockedBefore are in
+	// closed pay periods and only admins can add or edit them
+	EntriesLockedBefore *time.Time `json:"entries_locked_before"`
+
+	// Admin fields
+	IsVerified bool       `gorm:"default:false" json:"is_verified"` // Admin verified organization
+	VerifiedAt *time.Time `json:"verified_at"`
+	VerifiedBy *uint      `json:"verified_by"`
+	AdminNotes string     `gorm:"type:text" json:"admin_notes"` // Admin notes for internal use
+
+	// Pending deletion; the organization and all its data are purged at
+	// DeletionScheduledAt unless the deletion is cancelled before
+	DeletionRequestedAt *time.Time `json:"deletion_requested_at"`
+	DeletionScheduledAt *time.Time `gorm:"index" json:"deletion_scheduled_at"`
+	DeletionRequestedBy *uint      `json:"deletion_requested_by"`
+
+	// Relations
+	Owner      User                 `gorm:"foreignKey:OwnerID" json:"owner,omitempty"`
+	Members    []OrganizationMember `gorm:"foreignKey:OrganizationID" json:"members,omitempty"`
+	Workspaces []Workspace          `gorm:"foreignKey:OrganizationID" json:"workspaces,omitempty"`
+	Roles      []WorkspaceRole      `gorm:"foreignKey:OrganizationID" json:"roles,omitempty"`
+}
+
+// TableName overrides the table name
+func (Organization) TableName() string {
+	return "organizations"
+}
+
+// Calendar returns the organization's working-week and fiscal calendar
+func (o *Organization) Calendar() calendar.Calendar {
+	return calendar.New(o.WorkingDays, o.WeekStartDay, o.FiscalYearStartMonth)
+}
+
+// OrganizationMember represents a user's membership in an organization
+type OrganizationMember struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	OrganizationID uint      `gorm:"not null;index" json:"organization_id"`
+	UserID         uint      `gorm:"not null;index" json:"user_id"`
+	Role           string    `gorm:"size:50;not null;default:'member'" json:"role"` // owner, admin, member
+	InvitedBy      *uint     `json:"invited_by"`
+	JoinedAt       time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"joined_at"`
+	IsActive       bool      `gorm:"default:true" json:"is_active"`
+
+	// Hourly cost of the member for payroll in minor units of the
+	// organization currency, separate from the client-facing workspace
+	// hourly rate; nil when not set
+	CostRateMinor *int64 `json:"cost_rate_minor"`
+
+	// Relations
+	Organization Organization `gorm:"foreignKey:OrganizationID" json:"organization,omitempty"`
+	User         User         `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Inviter      *User        `gorm:"foreignKey:InvitedBy" json:"inviter,omitempty"`
+}
+
+// TableName overrides the table name
+func (OrganizationMember) TableName() string {
+	return "organization_members"
+}
+
+// OrganizationSetting is an organization's value for a setting of
+// OrganizationSettingSchema. Settings without a row use the schema default, so
+// adding a setting needs no migration.
+type OrganizationSetting struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	OrganizationID uint   `gorm:"not null;uniqueIndex:idx_org_setting_key" json:"organization_id"`
+	Key            string `gorm:"size:100;not null;uniqueIndex:idx_org_setting_key" json:"key"`
+	Value          string `gorm:"type:jsonb;not null" json:"value"` // JSON, valid against the setting's schema
+	UpdatedBy      uint   `gorm:"not null" json:"updated_by"`
+}
+
+// TableName overrides the table name
+func (OrganizationSetting) TableName() string {
+	return "organization_settings"
+}
+
+// WorkspaceRole represents custom roles for workspace members
+type WorkspaceRole struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	OrganizationID uint   `gorm:"not null;index" json:"organization_id"`
+	Name           string `gorm:"size:100;not null" json:"name"`
+	DisplayName    string `gorm:"size:255;not null" json:"display_name"`
+	Description    string `gorm:"type:text" json:"description"`
+	Color          string `gorm:"size:7" json:"color"`
+	Permissions    string `gorm:"type:jsonb;default:'{}'" json:"permissions"`
+	IsDefault      bool   `gorm:"default:false" json:"is_default"`
+	SortOrder      int    `gorm:"default:0" json:"sort_order"`
+
+	// Relations
+	Organization Organization `gorm:"foreignKey:OrganizationID" json:"organization,omitempty"`
+}
+
+// TableName overrides the table name
+func (WorkspaceRole) TableName() string {
 	return "workspace_roles"
 }
 
@@ -349,19 +1809,54 @@ type Workspace struct {
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	UUID      string         `gorm:"<-:create;type:uuid;not null;uniqueIndex;default:gen_random_uuid()" json:"uuid"`
+	Version   uint           `gorm:"not null;default:1" json:"version"`
 
-	OrganizationID uint       `gorm:"not null;index" json:"organization_id"`
-	Name           string     `gorm:"size:255;not null" json:"name"`
-	Slug           string     `gorm:"size:255;not null" json:"slug"`
-	Description    string     `gorm:"type:text" json:"description"`
-	Color          string     `gorm:"size:7" json:"color"`
-	Icon           string     `gorm:"size:50" json:"icon"`
-	AdminID        uint       `gorm:"not null;index" json:"admin_id"`
-	IsActive       bool       `gorm:"default:true" json:"is_active"`
-	IsBillable     bool       `gorm:"default:false" json:"is_billable"`
-	HourlyRate     float64    `gorm:"type:decimal(10,2)" json:"hourly_rate"`
-	StartDate      *time.Time `json:"start_date"`
-	EndDate        *time.Time `json:"end_date"`
+	OrganizationID  uint       `gorm:"not null;index" json:"organization_id"`
+	Name            string     `gorm:"size:255;not null" json:"name"`
+	Slug            string     `gorm:"size:255;not null" json:"slug"`
+	Description     string     `gorm:"type:text" json:"description"`
+	Color           string     `gorm:"size:7" json:"color"`
+	Icon            string     `gorm:"size:50" json:"icon"`
+	AdminID         uint       `gorm:"not null;index" json:"admin_id"`
+	IsActive        bool       `gorm:"default:true" json:"is_active"`
+	IsBillable      bool       `gorm:"default:false" json:"is_billable"`
+	Currency        string     `gorm:"size:3;not null;default:'USD'" json:"currency"` // ISO 4217, the organization currency unless chosen
+	HourlyRateMinor int64      `gorm:"default:0" json:"hourly_rate_minor"`            // In minor units of Currency
+	StartDate       *time.Time `json:"start_date"`
+	EndDate         *time.Time `json:"end_date"`
+
+	// Client the work is done for; nil for internal workspaces
+	ClientID *uint `gorm:"index" json:"client_id"`
+
+	// Accounting tags used for enterprise export reconciliation
+	CostCenter  string `gorm:"size:100;index" json:"cost_center"`
+	ProjectCode string `gorm:"size:100;index" json:"project_code"`
+
+	// Weekly hour cap compliance
+	CapWarnPercent int    `gorm:"default:80" json:"cap_warn_percent"`            // Share of the cap at which members are warned
+	CapEnforcement string `gorm:"size:20;default:'flag'" json:"cap_enforcement"` // flag, block
+
+	// Screenshot capture policy the desktop app fetches
+	ScreenshotsDisabled       bool   `gorm:"default:false" json:"screenshots_disabled"` // Uploads are rejected when set
+	ScreenshotIntervalSeconds int    `gorm:"default:300" json:"screenshot_interval_seconds"`
+	ScreenshotBlurMode        string `gorm:"size:20;default:'none'" json:"screenshot_blur_mode"` // none, blur (store a blurred variant for managers)
+	ScreenshotMonitors        int    `gorm:"default:0" json:"screenshot_monitors"`               // Monitors to capture, 0 for all
+
+	// Desktop app behaviour, served with the capture policy in the device configuration document
+	IdleThresholdSeconds int    `gorm:"default:300" json:"idle_threshold_seconds"` // Inactivity after which the app treats the user as idle
+	DisabledFeatures     string `gorm:"size:255" json:"disabled_features"`         // Comma-separated desktop features turned off, see DesktopFeatures
+
+	// Manual time entries wait for a manager's approval when set
+	ManualEntriesRequireApproval bool `gorm:"default:false" json:"manual_entries_require_approval"`
+
+	// Project budget, consumed by approved time logs
+	BudgetType           string     `gorm:"size:20" json:"budget_type"`                          // "" (none), hours, money
+	BudgetAmount         float64    `gorm:"type:decimal(12,2);default:0" json:"budget_amount"`   // Hours, or money in the workspace currency
+	BudgetThresholds     string     `gorm:"size:100;default:'80,100'" json:"budget_thresholds"`  // Percentages that trigger alerts
+	BudgetConsumed       float64    `gorm:"type:decimal(12,2);default:0" json:"budget_consumed"` // As of the last rollup, in the budget's unit
+	BudgetRolledUpAt     *time.Time `json:"budget_rolled_up_at"`                                 // Last rollup
+	BudgetAlertedPercent int        `gorm:"default:0" json:"budget_alerted_percent"`             // Highest threshold alerted for the current budget
 
 	// Admin fields
 	IsArchived bool       `gorm:"default:false" json:"is_archived"` // Admin archived workspace
@@ -371,6 +1866,7 @@ type Workspace struct {
 	// Relations
 	Organization Organization      `gorm:"foreignKey:OrganizationID" json:"organization,omitempty"`
 	Admin        User              `gorm:"foreignKey:AdminID" json:"admin,omitempty"`
+	Client       *Client           `gorm:"foreignKey:ClientID" json:"client,omitempty"`
 	Members      []WorkspaceMember `gorm:"foreignKey:WorkspaceID" json:"members,omitempty"`
 	Tasks        []Task            `gorm:"foreignKey:WorkspaceID" json:"tasks,omitempty"`
 }
@@ -380,6 +1876,31 @@ func (Workspace) TableName() string {
 	return "workspaces"
 }
 
+// Client is a customer an organization, typically an agency, works for.
+// Workspaces are grouped by client so reports can aggregate across them.
+type Client struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	OrganizationID uint   `gorm:"not null;index" json:"organization_id"`
+	Name           string `gorm:"size:255;not null" json:"name"`
+	ContactName    string `gorm:"size:255" json:"contact_name"`
+	ContactEmail   string `gorm:"size:255" json:"contact_email"`
+	Notes          string `gorm:"type:text" json:"notes"`
+	CreatedBy      uint   `json:"created_by"`
+
+	// Relations
+	Organization Organization `gorm:"foreignKey:OrganizationID" json:"organization,omitempty"`
+	Workspaces   []Workspace  `gorm:"foreignKey:ClientID" json:"workspaces,omitempty"`
+}
+
+// TableName overrides the table name
+func (Client) TableName() string {
+	return "clients"
+}
+
 // WorkspaceMember represents a user's membership in a workspace
 type WorkspaceMember struct {
 	ID        uint           `gorm:"primaryKey" json:"id"`
@@ -398,6 +1919,11 @@ type WorkspaceMember struct {
 	JoinedAt        time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"joined_at"`
 	IsActive        bool      `gorm:"default:true" json:"is_active"`
 
+	// Weekly hour cap (contractor compliance); nil means no cap
+	WeeklyHourCap    *float64   `gorm:"type:decimal(6,2)" json:"weekly_hour_cap"`
+	CapNotifiedWeek  *time.Time `json:"-"` // Start of the week the last cap notification was sent for
+	CapNotifiedLevel string     `gorm:"size:20" json:"-"`
+
 	// Relations
 	Workspace     Workspace      `gorm:"foreignKey:WorkspaceID" json:"workspace,omitempty"`
 	User          User           `gorm:"foreignKey:UserID" json:"user,omitempty"`
@@ -443,6 +1969,129 @@ func (Invitation) TableName() string {
 	return "invitations"
 }
 
+// OrganizationWebhook is an HTTP endpoint subscribed to organization events,
+// typically an HR system syncing the member roster
+type OrganizationWebhook struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	OrganizationID uint       `gorm:"not null;index" json:"organization_id"`
+	URL            string     `gorm:"size:500;not null" json:"url"`
+	Secret         string     `gorm:"size:100;not null" json:"-"`      // HMAC-SHA256 signing key
+	Events         string     `gorm:"size:255;not null" json:"events"` // Comma-separated event names
+	Description    string     `gorm:"size:255" json:"description"`
+	IsActive       bool       `gorm:"default:true" json:"is_active"`
+	CreatedBy      uint       `gorm:"not null" json:"created_by"`
+	LastDeliveryAt *time.Time `json:"last_delivery_at"`
+
+	// Relations
+	Organization Organization `gorm:"foreignKey:OrganizationID" json:"organization,omitempty"`
+}
+
+// TableName overrides the table name
+func (OrganizationWebhook) TableName() string {
+	return "organization_webhooks"
+}
+
+// Subscribes reports whether the webhook receives the event
+func (w *OrganizationWebhook) Subscribes(event string) bool {
+	for _, e := range strings.Split(w.Events, ",") {
+		if strings.TrimSpace(e) == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery is one attempt series to deliver an event to a webhook.
+// Replays reuse the EventID so receivers can deduplicate.
+type WebhookDelivery struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	WebhookID      uint       `gorm:"not null;index" json:"webhook_id"`
+	OrganizationID uint       `gorm:"not null;index" json:"organization_id"`
+	UserID         uint       `gorm:"not null;index" json:"user_id"` // Member the event is about
+	EventID        string     `gorm:"size:36;not null;index" json:"event_id"`
+	Event          string     `gorm:"size:50;not null;index" json:"event"`
+	Payload        string     `gorm:"type:text;not null" json:"payload"`
+	Status         string     `gorm:"size:20;not null;default:'pending';index" json:"status"` // pending, succeeded, failed
+	Attempts       int        `gorm:"default:0" json:"attempts"`
+	ResponseStatus int        `json:"response_status"`
+	Error          string     `gorm:"type:text" json:"error"`
+	NextAttemptAt  *time.Time `gorm:"index" json:"next_attempt_at"` // Nil when no retry is scheduled
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	ReplayOf       *uint      `json:"replay_of"` // Original delivery when replayed
+}
+
+// TableName overrides the table name
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// SlackIntegration posts an organization's notifications to a Slack channel
+// through an incoming webhook, either pasted in or installed with OAuth
+type SlackIntegration struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	OrganizationID uint       `gorm:"not null;uniqueIndex" json:"organization_id"`
+	WebhookURL     string     `gorm:"size:500;not null" json:"-"`
+	Channel        string     `gorm:"size:100" json:"channel"`               // Reported by Slack on OAuth install
+	TeamName       string     `gorm:"size:255" json:"team_name"`             // Reported by Slack on OAuth install
+	InstalledVia   string     `gorm:"size:20;not null" json:"installed_via"` // webhook or oauth
+	Events         string     `gorm:"size:255;not null" json:"events"`       // Comma-separated event names
+	IsActive       bool       `gorm:"default:true" json:"is_active"`
+	CreatedBy      uint       `gorm:"not null" json:"created_by"`
+	LastPostedAt   *time.Time `json:"last_posted_at"`
+	LastError      string     `gorm:"type:text" json:"last_error"`
+}
+
+// TableName overrides the table name
+func (SlackIntegration) TableName() string {
+	return "slack_integrations"
+}
+
+// Subscribes reports whether the integration posts the event
+func (i *SlackIntegration) Subscribes(event string) bool {
+	for _, e := range strings.Split(i.Events, ",") {
+		if strings.TrimSpace(e) == event {
+			return true
+		}
+	}
+	return false
+}
+
+// RoleChange records a change to a member's access for audits: their
+// organization role, a workspace role or permission flag, or their system role.
+// System role changes have no organization and appear in every org's history.
+type RoleChange struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+
+	UserID         uint   `gorm:"not null;index" json:"user_id"` // Member whose access changed
+	OrganizationID *uint  `gorm:"index" json:"organization_id"`
+	WorkspaceID    *uint  `gorm:"index" json:"workspace_id"`
+	Scope          string `gorm:"size:20;not null" json:"scope"`     // system, organization, workspace
+	Attribute      string `gorm:"size:50;not null" json:"attribute"` // role, workspace_role, is_admin, can_view_reports, can_manage_tasks, system_role
+	FromValue      string `gorm:"size:100" json:"from_value"`
+	ToValue        string `gorm:"size:100" json:"to_value"`
+	ChangedBy      *uint  `json:"changed_by"`            // Nil when changed by the system
+	Source         string `gorm:"size:30" json:"source"` // admin, ownership_transfer, system_admin
+
+	// Relations
+	Actor *User `gorm:"foreignKey:ChangedBy" json:"actor,omitempty"`
+}
+
+// TableName overrides the table name
+func (RoleChange) TableName() string {
+	return "role_changes"
+}
+
 // ============================================================================
 // ROLE CONSTANTS
 // ============================================================================
@@ -460,6 +2109,20 @@ const (
 	OrgRoleMember = "member"
 )
 
+// Role change scopes
+const (
+	RoleScopeSystem       = "system"
+	RoleScopeOrganization = "organization"
+	RoleScopeWorkspace    = "workspace"
+)
+
+// Role change sources
+const (
+	RoleChangeSourceAdmin             = "admin"              // Organization or workspace admin
+	RoleChangeSourceOwnershipTransfer = "ownership_transfer" // Organization ownership transfer
+	RoleChangeSourceSystemAdmin       = "system_admin"       // System admin panel
+)
+
 // Invitation status
 const (
 	InvitationStatusPending  = "pending"
@@ -468,12 +2131,396 @@ const (
 	InvitationStatusRevoked  = "revoked"
 )
 
+// Screenshot deletion request status
+const (
+	DeletionRequestPending   = "pending"
+	DeletionRequestApproved  = "approved"
+	DeletionRequestRejected  = "rejected"
+	DeletionRequestCancelled = "cancelled"
+)
+
+// Desktop app features a workspace can turn off
+const (
+	FeatureScreenshots     = "screenshots"      // Turned off with Workspace.ScreenshotsDisabled
+	FeatureManualTime      = "manual_time"      // Manual time entries
+	FeatureIdleDetection   = "idle_detection"   // Pausing or prompting when the user goes idle
+	FeatureOfflineTracking = "offline_tracking" // Tracking while the server is unreachable, synced later
+)
+
+// DesktopFeatures lists the features a workspace turns off through its
+// disabled features
+var DesktopFeatures = []string{FeatureManualTime, FeatureIdleDetection, FeatureOfflineTracking}
+
+// Device approval status
+const (
+	DeviceApprovalPending  = "pending"
+	DeviceApprovalApproved = "approved"
+	DeviceApprovalRejected = "rejected"
+)
+
+// Release channels of the desktop app. Beta clients also receive stable
+// releases.
+const (
+	ReleaseChannelStable = "stable"
+	ReleaseChannelBeta   = "beta"
+)
+
+// Kinds of release assets
+const (
+	ReleaseAssetInstaller = "installer"
+	ReleaseAssetBlockmap  = "blockmap"
+	ReleaseAssetDelta     = "delta"
+)
+
+// Leave types
+const (
+	LeaveTypeVacation = "vacation"
+	LeaveTypeSick     = "sick"
+	LeaveTypeOther    = "other"
+)
+
+// Leave request status
+const (
+	LeaveRequestPending   = "pending"
+	LeaveRequestApproved  = "approved"
+	LeaveRequestRejected  = "rejected"
+	LeaveRequestCancelled = "cancelled"
+)
+
+// Screenshot storage tiers
+const (
+	StorageTierHot  = "hot"  // File on the server's upload volume
+	StorageTierCold = "cold" // File only in cold storage
+)
+
+// Screenshot malware scan statuses
+const (
+	ScreenshotScanPending     = "pending"
+	ScreenshotScanClean       = "clean"
+	ScreenshotScanQuarantined = "quarantined" // Flagged by the scanner, awaiting admin review
+	ScreenshotScanReleased    = "released"    // Flagged, then released by an admin as a false positive
+)
+
+// Screenshot OCR statuses
+const (
+	ScreenshotOCRPending = "pending"
+	ScreenshotOCRDone    = "done"
+)
+
+// Capture exclusion rule match targets
+const (
+	CaptureMatchApp         = "app"          // Application / process name
+	CaptureMatchWindowTitle = "window_title" // Window title, e.g. a browser tab
+)
+
+// Sync conflict resolution policies
+const (
+	SyncConflictLastWriteWins = "last_write_wins"
+	SyncConflictServerWins    = "server_wins"
+	SyncConflictManual        = "manual"
+)
+
+// Running timer concurrency policies: a user has at most one running or
+// paused time log; starting another one either stops it or is rejected
+const (
+	TimerConcurrencyStop   = "stop"
+	TimerConcurrencyReject = "reject"
+)
+
+// Sync transaction scope
+const (
+	SyncTransactionItem  = "item"  // Each item commits on its own
+	SyncTransactionBatch = "batch" // The whole batch commits or rolls back together
+)
+
+// Sync item error codes
+const (
+	SyncErrorInvalidData         = "invalid_data"         // Item cannot be processed as sent
+	SyncErrorHourCapBlocked      = "hour_cap_blocked"     // Weekly hour cap reached in a blocking workspace
+	SyncErrorScreenshotsDisabled = "screenshots_disabled" // Workspace does not accept screenshots; drop the local copy
+	SyncErrorTimerRunning        = "timer_running"        // Another timer is running; stop it first
+	SyncErrorVersionConflict     = "version_conflict"     // Changed by another device during sync; retry
+	SyncErrorInvoiced            = "invoiced"             // Billed on an invoice and locked; keep the server copy
+	SyncErrorTaskRequired        = "task_required"        // Organization only accepts time on existing tasks; pick one
+	SyncErrorPeriodLocked        = "period_locked"        // Pay period closed by an admin; keep the server copy
+	SyncErrorQuotaExceeded       = "quota_exceeded"       // Organization screenshot storage quota reached; keep the local copy
+	SyncErrorStorage             = "storage_error"        // Screenshot file could not be written
+	SyncErrorDatabase            = "database_error"
+	SyncErrorBatchAborted        = "batch_aborted" // Rolled back because another item of the batch failed
+)
+
+// Workspace screenshot blur modes
+const (
+	ScreenshotBlurNone = "none"
+	ScreenshotBlurBlur = "blur"
+)
+
+// Sync conflict status and resolutions
+const (
+	SyncConflictPending  = "pending"
+	SyncConflictResolved = "resolved"
+
+	SyncResolutionClientApplied = "client_applied"
+	SyncResolutionServerKept    = "server_kept"
+)
+
+// Weekly hour cap status of a member or time log
+const (
+	CapStatusApproaching = "approaching"
+	CapStatusExceeded    = "exceeded"
+)
+
+// Workspace budget units
+const (
+	BudgetTypeHours = "hours"
+	BudgetTypeMoney = "money"
+)
+
+// Weekly hour cap enforcement at sync
+const (
+	CapEnforcementFlag  = "flag"  // Accept time logs and flag them
+	CapEnforcementBlock = "block" // Reject new time logs once the cap is reached
+)
+
+// Organization webhook events
+const (
+	WebhookEventMemberJoined       = "member.joined"
+	WebhookEventMemberRemoved      = "member.removed"
+	WebhookEventMemberRoleChanged  = "member.role_changed"
+	WebhookEventHourCapApproaching = "member.hour_cap_approaching"
+	WebhookEventHourCapExceeded    = "member.hour_cap_exceeded"
+	WebhookEventBudgetThreshold    = "workspace.budget_threshold"
+)
+
+// WebhookEvents lists the events webhooks can subscribe to
+var WebhookEvents = []string{
+	WebhookEventMemberJoined,
+	WebhookEventMemberRemoved,
+	WebhookEventMemberRoleChanged,
+	WebhookEventHourCapApproaching,
+	WebhookEventHourCapExceeded,
+	WebhookEventBudgetThreshold,
+}
+
+// Invoice status
+const (
+	InvoiceStatusDraft = "draft"
+	InvoiceStatusSent  = "sent"
+	InvoiceStatusPaid  = "paid"
+)
+
+// Saved report formats
+const (
+	SavedReportFormatCSV = "csv"
+	SavedReportFormatPDF = "pdf"
+)
+
+// Report schedule frequencies
+const (
+	ReportFrequencyDaily  = "daily"
+	ReportFrequencyWeekly = "weekly"
+)
+
+// Email outbox statuses
+const (
+	EmailStatusPending = "pending"
+	EmailStatusSent    = "sent"
+	EmailStatusFailed  = "failed"
+)
+
+// In-app notification types
+const (
+	NotificationTypeInvitationReceived = "invitation_received"
+	NotificationTypeTimeLogRejected    = "timelog_rejected"
+	NotificationTypeWorkspaceArchived  = "workspace_archived"
+	NotificationTypeBudgetThreshold    = "budget_threshold"
+	NotificationTypeLeaveReviewed      = "leave_reviewed"
+	NotificationTypeOvertimeDetected   = "overtime_detected"
+	NotificationTypeTaskAssigned       = "task_assigned"
+	NotificationTypeTaskMentioned      = "task_mentioned"
+)
+
+// Version control providers
+const (
+	VCSProviderGitHub = "github"
+	VCSProviderGitLab = "gitlab"
+)
+
+// Kinds of linked VCS references
+const (
+	CommitKindCommit       = "commit"
+	CommitKindPullRequest  = "pull_request"
+	CommitKindMergeRequest = "merge_request"
+	CommitKindIssue        = "issue"
+)
+
+// How a VCS reference was linked to a time log
+const (
+	CommitSourceNotes   = "notes"
+	CommitSourceWebhook = "webhook"
+	CommitSourceManual  = "manual"
+)
+
+// Slack notification events
+const (
+	SlackEventMemberJoined    = "member.joined"
+	SlackEventTimeLogApproved = "timelog.approved"
+	SlackEventTimeLogRejected = "timelog.rejected"
+	SlackEventDailySummary    = "daily_summary"
+)
+
+// SlackEvents lists the events a Slack integration can post
+var SlackEvents = []string{
+	SlackEventMemberJoined,
+	SlackEventTimeLogApproved,
+	SlackEventTimeLogRejected,
+	SlackEventDailySummary,
+}
+
+// Webhook delivery status
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// Data export status
+const (
+	DataExportStatusPending    = "pending"
+	DataExportStatusProcessing = "processing"
+	DataExportStatusCompleted  = "completed"
+	DataExportStatusFailed     = "failed"
+)
+
+// Operation types
+const (
+	OperationTypeDataExport         = "data_export"
+	OperationTypeOrganizationExport = "organization_export"
+	OperationTypePurge              = "purge"
+)
+
+// Operation status
+const (
+	OperationStatusPending   = "pending"
+	OperationStatusRunning   = "running"
+	OperationStatusCompleted = "completed"
+	OperationStatusFailed    = "failed"
+)
+
+// Workspace permissions, granted through a workspace role's permission matrix
+const (
+	PermTasksCreate         = "tasks.create"
+	PermTasksManage         = "tasks.manage"
+	PermTimeLogsView        = "timelogs.view"
+	PermReportsView         = "reports.view"
+	PermScreenshotsViewOwn  = "screenshots.view_own"
+	PermScreenshotsView     = "screenshots.view" // Other members' screenshots
+	PermScreenshotsDownload = "screenshots.download"
+	PermMembersView         = "members.view"
+	PermMembersManage       = "members.manage"
+	PermSettingsManage      = "settings.manage"
+)
+
+// WorkspacePermissionDefinition describes a permission in the role matrix
+type WorkspacePermissionDefinition struct {
+	Key         string
+	Group       string
+	Description string
+	Default     bool // Granted to members unless their role revokes it
+}
+
+// WorkspacePermissionSchema lists every workspace permission in display order
+var WorkspacePermissionSchema = []WorkspacePermissionDefinition{
+	{Key: PermTasksCreate, Group: "tasks", Description: "Create tasks in the workspace", Default: true},
+	{Key: PermTasksManage, Group: "tasks", Description: "Edit, assign and delete any task in the workspace"},
+	{Key: PermTimeLogsView, Group: "time", Description: "View other members' time logs"},
+	{Key: PermReportsView, Group: "reports", Description: "View workspace reports and hour cap compliance"},
+	{Key: PermScreenshotsViewOwn, Group: "screenshots", Description: "View your own screenshots", Default: true},
+	{Key: PermScreenshotsView, Group: "screenshots", Description: "View other members' screenshots, blurred where the workspace blurs them"},
+	{Key: PermScreenshotsDownload, Group: "screenshots", Description: "Download other members' original screenshot files"},
+	{Key: PermMembersView, Group: "members", Description: "View the workspace member list", Default: true},
+	{Key: PermMembersManage, Group: "members", Description: "Add, update and remove workspace members"},
+	{Key: PermSettingsManage, Group: "settings", Description: "Manage integrations, repositories and assignment rules"},
+}
+
+// IsWorkspacePermission reports whether key is in the permission schema
+func IsWorkspacePermission(key string) bool {
+	for _, def := range WorkspacePermissionSchema {
+		if def.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
 // Default workspace roles
 var DefaultWorkspaceRoles = []WorkspaceRole{
-	{Name: "pm", DisplayName: "Project Manager", Color: "#3B82F6", SortOrder: 1},
+	{Name: "pm", DisplayName: "Project Manager", Color: "#3B82F6", SortOrder: 1,
+		Permissions: `{"tasks.manage": true, "timelogs.view": true, "reports.view": true}`},
 	{Name: "ba", DisplayName: "Business Analyst", Color: "#8B5CF6", SortOrder: 2},
 	{Name: "dev", DisplayName: "Developer", Color: "#10B981", SortOrder: 3},
 	{Name: "tester", DisplayName: "Tester/QA", Color: "#F59E0B", SortOrder: 4},
 	{Name: "designer", DisplayName: "Designer", Color: "#EC4899", SortOrder: 5},
 	{Name: "devops", DisplayName: "DevOps Engineer", Color: "#6366F1", SortOrder: 6},
 }
+
+// Organization setting keys
+const (
+	SettingShareLinksEnabled       = "share_links.enabled"
+	SettingShareLinksExpiryDays    = "share_links.default_expiry_days"
+	SettingInvoicePaymentTermsDays = "invoices.payment_terms_days"
+	SettingSyncTaskCreation        = "sync.task_creation"
+)
+
+// Task auto-creation policies for time logs synced from the desktop app
+const (
+	TaskCreationAlways          = "always_create"          // Create a task for every new task title
+	TaskCreationReuseByTitle    = "reuse_by_title_per_day" // Reuse the user's task of the same title created that day
+	TaskCreationRequireExisting = "require_existing_task"  // Reject time logs that are not on an existing task
+)
+
+// SettingSchema is the JSON Schema subset an organization setting's value is
+// validated against
+type SettingSchema struct {
+	Type      string   `json:"type"` // boolean, integer, string
+	Minimum   *int64   `json:"minimum,omitempty"`
+	Maximum   *int64   `json:"maximum,omitempty"`
+	MaxLength int      `json:"maxLength,omitempty"`
+	Enum      []string `json:"enum,omitempty"`
+}
+
+// OrganizationSettingDefinition describes an organization setting
+type OrganizationSettingDefinition struct {
+	Key         string
+	Description string
+	Schema      SettingSchema
+	Default     interface{}
+}
+
+// integerSetting is the schema of an integer setting within [min, max]
+func integerSetting(min, max int64) SettingSchema {
+	return SettingSchema{Type: "integer", Minimum: &min, Maximum: &max}
+}
+
+// OrganizationSettingSchema lists every organization setting in display order
+var OrganizationSettingSchema = []OrganizationSettingDefinition{
+	{Key: SettingShareLinksEnabled, Description: "Members who can view reports may share them through links",
+		Schema: SettingSchema{Type: "boolean"}, Default: true},
+	{Key: SettingShareLinksExpiryDays, Description: "Days a new share link lasts unless set otherwise",
+		Schema: integerSetting(1, 365), Default: 30},
+	{Key: SettingInvoicePaymentTermsDays, Description: "Days after creation a new invoice is due when no due date is given; 0 leaves it open",
+		Schema: integerSetting(0, 365), Default: 0},
+	{Key: SettingSyncTaskCreation, Description: "How time logs synced from the desktop app with a new task title get their task",
+		Schema:  SettingSchema{Type: "string", Enum: []string{TaskCreationAlways, TaskCreationReuseByTitle, TaskCreationRequireExisting}},
+		Default: TaskCreationAlways},
+}
+
+// FindOrganizationSetting returns the definition of a setting key
+func FindOrganizationSetting(key string) (*OrganizationSettingDefinition, bool) {
+	for i := range OrganizationSettingSchema {
+		if OrganizationSettingSchema[i].Key == key {
+			return &OrganizationSettingSchema[i], true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,171 @@
+package money
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Exchange rate providers
+const (
+	ProviderStatic = "static" // Rates from configuration
+	ProviderECB    = "ecb"    // European Central Bank daily reference rates
+)
+
+// RateProvider returns exchange rates
+type RateProvider interface {
+	// Rate returns the amount of to that one unit of from buys
+	Rate(from, to string) (float64, error)
+}
+
+// FXConfig selects and configures the exchange rate provider
+type FXConfig struct {
+	Provider     string        // static or ecb
+	BaseCurrency string        // Currency the static rates are quoted against
+	Rates        string        // Static rates, e.g. "EUR=0.92,GBP=0.79": units of each per base unit
+	CacheTTL     time.Duration // How long fetched rates are reused
+	Timeout      time.Duration
+}
+
+// NewRateProvider creates the rate provider for cfg.Provider
+func NewRateProvider(cfg FXConfig) (RateProvider, error) {
+	switch cfg.Provider {
+	case "", ProviderStatic:
+		return NewStaticRates(cfg.BaseCurrency, cfg.Rates)
+	case ProviderECB:
+		return NewECBRates(cfg.CacheTTL, cfg.Timeout), nil
+	default:
+		return nil, fmt.Errorf("unknown exchange rate provider %q", cfg.Provider)
+	}
+}
+
+// crossRate converts through the quotes of both currencies against a common
+// base; quotes hold the amount of each currency one base unit buys
+func crossRate(quotes map[string]float64, from, to string) (float64, error) {
+	from, to = Normalize(from), Normalize(to)
+	if from == to {
+		return 1, nil
+	}
+
+	fromQuote, ok := quotes[from]
+	if !ok {
+		return 0, fmt.Errorf("no exchange rate for %s", from)
+	}
+	toQuote, ok := quotes[to]
+	if !ok {
+		return 0, fmt.Errorf("no exchange rate for %s", to)
+	}
+	return toQuote / fromQuote, nil
+}
+
+// StaticRates serves fixed rates from configuration
+type StaticRates struct {
+	quotes map[string]float64
+}
+
+// NewStaticRates parses rates like "EUR=0.92,GBP=0.79" quoted against base
+func NewStaticRates(base, rates string) (*StaticRates, error) {
+	quotes := map[string]float64{Normalize(base): 1}
+	for _, pair := range strings.Split(rates, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		code, value, found := strings.Cut(pair, "=")
+		rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if !found || err != nil || rate <= 0 {
+			return nil, fmt.Errorf("invalid exchange rate %q", pair)
+		}
+		quotes[Normalize(code)] = rate
+	}
+	return &StaticRates{quotes: quotes}, nil
+}
+
+// Rate returns the configured cross rate
+func (r *StaticRates) Rate(from, to string) (float64, error) {
+	return crossRate(r.quotes, from, to)
+}
+
+// ecbDailyURL serves the ECB's euro reference rates of the last working day
+const ecbDailyURL = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"
+
+// ECBRates serves the European Central Bank's daily reference rates, fetched
+// at most once per cache TTL. Stale rates are kept when a refresh fails.
+type ECBRates struct {
+	httpClient *http.Client
+	cacheTTL   time.Duration
+
+	mu        sync.Mutex
+	quotes    map[string]float64
+	fetchedAt time.Time
+}
+
+// NewECBRates creates an ECB rate provider
+func NewECBRates(cacheTTL, timeout time.Duration) *ECBRates {
+	if cacheTTL <= 0 {
+		cacheTTL = 6 * time.Hour
+	}
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	return &ECBRates{
+		httpClient: &http.Client{Timeout: timeout},
+		cacheTTL:   cacheTTL,
+	}
+}
+
+// Rate returns the cross rate through the euro
+func (r *ECBRates) Rate(from, to string) (float64, error) {
+	if Normalize(from) == Normalize(to) {
+		return 1, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.quotes == nil || time.Since(r.fetchedAt) > r.cacheTTL {
+		quotes, err := r.fetch()
+		if err != nil && r.quotes == nil {
+			return 0, err
+		}
+		if err == nil {
+			r.quotes = quotes
+			r.fetchedAt = time.Now()
+		}
+	}
+	return crossRate(r.quotes, from, to)
+}
+
+func (r *ECBRates) fetch() (map[string]float64, error) {
+	resp, err := r.httpClient.Get(ecbDailyURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch ECB rates: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch ECB rates: status %d", resp.StatusCode)
+	}
+
+	var envelope struct {
+		Rates []struct {
+			Currency string  `xml:"currency,attr"`
+			Rate     float64 `xml:"rate,attr"`
+		} `xml:"Cube>Cube>Cube"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return nil, fmt.Errorf("failed to parse ECB rates: %w", err)
+	}
+
+	quotes := map[string]float64{"EUR": 1}
+	for _, rate := range envelope.Rates {
+		if rate.Rate > 0 {
+			quotes[rate.Currency] = rate.Rate
+		}
+	}
+	return quotes, nil
+}
@@ -0,0 +1,62 @@
+// Package money converts amounts between the major units the API uses and
+// the integer minor units they are stored in, and converts amounts between
+// currencies with a pluggable exchange rate provider.
+package money
+
+import (
+	"math"
+	"strings"
+)
+
+// DefaultCurrency is the currency of organizations that did not choose one
+const DefaultCurrency = "USD"
+
+// exponents lists the ISO 4217 currencies whose minor unit is not a
+// hundredth of the major unit
+var exponents = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+}
+
+// Normalize returns the upper-case currency code, or DefaultCurrency for an
+// empty one
+func Normalize(currency string) string {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" {
+		return DefaultCurrency
+	}
+	return currency
+}
+
+// Exponent returns the number of decimals of the currency's minor unit
+func Exponent(currency string) int {
+	if exp, ok := exponents[Normalize(currency)]; ok {
+		return exp
+	}
+	return 2
+}
+
+// ToMinor converts an amount in major units to minor units, rounding half
+// away from zero
+func ToMinor(amount float64, currency string) int64 {
+	return int64(math.Round(amount * math.Pow10(Exponent(currency))))
+}
+
+// ToMajor converts an amount in minor units to major units
+func ToMajor(minor int64, currency string) float64 {
+	return float64(minor) / math.Pow10(Exponent(currency))
+}
+
+// Round rounds an amount in major units to the currency's minor unit
+func Round(amount float64, currency string) float64 {
+	scale := math.Pow10(Exponent(currency))
+	return math.Round(amount*scale) / scale
+}
+
+// Rescale converts minor units of one currency to the minor units of
+// another, keeping the amount in major units
+func Rescale(minor int64, from, to string) int64 {
+	return ToMinor(ToMajor(minor, from), to)
+}
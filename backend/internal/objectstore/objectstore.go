@@ -0,0 +1,63 @@
+// Package objectstore stores opaque objects in a local directory or an
+// S3-compatible bucket.
+package objectstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned when an object does not exist
+var ErrNotFound = errors.New("object not found")
+
+// Object describes a stored object
+type Object struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+// Store is a flat key/value object store. Keys use "/" separators.
+type Store interface {
+	Put(ctx context.Context, key string, data []byte) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	// List returns every object whose key starts with prefix
+	List(ctx context.Context, prefix string) ([]Object, error)
+}
+
+// Restorer is implemented by stores whose objects can be archived (e.g. S3
+// Glacier) and must be restored before they are read
+type Restorer interface {
+	// Restore requests a temporary readable copy of an archived object for
+	// days. ready reports whether the object can be read now.
+	Restore(ctx context.Context, key string, days int) (ready bool, err error)
+}
+
+// S3Config configures access to an S3-compatible service
+type S3Config struct {
+	Endpoint     string // e.g. https://s3.eu-west-1.amazonaws.com or a MinIO URL
+	Region       string
+	AccessKey    string
+	SecretKey    string
+	StorageClass string // Storage class of written objects, e.g. STANDARD_IA or GLACIER; empty uses the bucket default
+}
+
+// Open returns the store for target: s3://bucket/prefix for a bucket, or a
+// local directory path (e.g. a mounted secondary volume)
+func Open(target string, cfg S3Config) (Store, error) {
+	if !strings.HasPrefix(target, "s3://") {
+		return NewFilesystem(target)
+	}
+
+	u, err := url.Parse(target)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid s3 target %q", target)
+	}
+	return NewS3(cfg, u.Host, strings.Trim(u.Path, "/"))
+}
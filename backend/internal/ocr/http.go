@@ -0,0 +1,37 @@
+package ocr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/beuphecan/remote-time-tracker/internal/httpapi"
+)
+
+// HTTP extracts text with an external API. The image is POSTed as the
+// request body and the API answers {"text": "..."}.
+type HTTP struct {
+	url        string
+	apiKey     string
+	httpClient *http.Client
+}
+
+// NewHTTP creates an HTTP API extractor
+func NewHTTP(cfg Config) *HTTP {
+	return &HTTP{
+		url:        cfg.APIURL,
+		apiKey:     cfg.APIKey,
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+// Extract sends the image to the API and returns the text it found
+func (h *HTTP) Extract(ctx context.Context, data []byte) (string, error) {
+	var result struct {
+		Text string `json:"text"`
+	}
+	if err := httpapi.PostBytes(ctx, h.httpClient, h.url, h.apiKey, data, &result); err != nil {
+		return "", fmt.Errorf("ocr: %w", err)
+	}
+	return result.Text, nil
+}
@@ -0,0 +1,53 @@
+// Package ocr extracts the text shown in screenshots with the tesseract
+// command or an external OCR API.
+package ocr
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// OCR providers
+const (
+	ProviderNone      = "none" // OCR disabled
+	ProviderTesseract = "tesseract"
+	ProviderHTTP      = "http"
+)
+
+// Extractor extracts the text of an image
+type Extractor interface {
+	Extract(ctx context.Context, data []byte) (string, error)
+}
+
+// Config selects and configures the provider
+type Config struct {
+	Provider string // none, tesseract or http
+	Timeout  time.Duration
+
+	TesseractPath string // tesseract binary
+	Languages     string // tesseract languages, e.g. "eng+vie"
+
+	APIURL string // Receives the image as the POST body
+	APIKey string // Sent as a bearer token
+}
+
+// New creates the extractor for cfg.Provider; nil when OCR is disabled
+func New(cfg Config) (Extractor, error) {
+	switch cfg.Provider {
+	case "", ProviderNone:
+		return nil, nil
+	case ProviderTesseract:
+		if cfg.TesseractPath == "" {
+			return nil, fmt.Errorf("tesseract provider needs the tesseract binary path")
+		}
+		return NewTesseract(cfg), nil
+	case ProviderHTTP:
+		if cfg.APIURL == "" {
+			return nil, fmt.Errorf("http provider needs an API URL")
+		}
+		return NewHTTP(cfg), nil
+	default:
+		return nil, fmt.Errorf("unknown OCR provider %q", cfg.Provider)
+	}
+}
@@ -0,0 +1,46 @@
+package ocr
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Tesseract extracts text by running the tesseract command on each image
+type Tesseract struct {
+	path      string
+	languages string
+	timeout   time.Duration
+}
+
+// NewTesseract creates a tesseract extractor
+func NewTesseract(cfg Config) *Tesseract {
+	return &Tesseract{path: cfg.TesseractPath, languages: cfg.Languages, timeout: cfg.Timeout}
+}
+
+// Extract pipes the image through tesseract and returns what it printed
+func (t *Tesseract) Extract(ctx context.Context, data []byte) (string, error) {
+	if t.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.timeout)
+		defer cancel()
+	}
+
+	args := []string{"stdin", "stdout"}
+	if t.languages != "" {
+		args = append(args, "-l", t.languages)
+	}
+	cmd := exec.CommandContext(ctx, t.path, args...)
+	cmd.Stdin = bytes.NewReader(data)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("tesseract: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,195 @@
+// Package pdf writes simple text-only PDF documents, enough for emailed
+// tabular reports without a layout dependency. Text is drawn with the
+// standard PDF fonts, so characters outside ASCII are transliterated.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/gosimple/unidecode"
+)
+
+// Landscape A4 in points, with the table drawn in 8pt Courier
+const (
+	pageWidth   = 842.0
+	pageHeight  = 595.0
+	margin      = 36.0
+	fontSize    = 8.0
+	charWidth   = fontSize * 0.6 // Courier glyphs are 600/1000 em wide
+	lineHeight  = 11.0
+	columnGap   = 2    // Characters between columns
+	maxColumn   = 40   // Widest column, in characters
+	minColumn   = 6    // Narrowest column when shrinking to fit
+	titleHeight = 40.0 // Title and subtitle on the first page
+)
+
+// Table is a titled table laid out on landscape A4 pages. The header row is
+// repeated on every page; cells too long for their column are cut short.
+type Table struct {
+	Title    string
+	Subtitle string
+	Columns  []string
+	Rows     [][]string
+}
+
+// Render writes the table as a PDF document
+func (t Table) Render() []byte {
+	widths := t.columnWidths()
+	header := formatRow(t.Columns, widths)
+	separator := strings.Repeat("-", len(header))
+
+	var pages [][]string
+	var lines []string
+	capacity := linesPerPage(pageHeight - 2*margin - titleHeight)
+	for _, row := range t.Rows {
+		if len(lines) == 0 {
+			lines = append(lines, header, separator)
+		}
+		lines = append(lines, formatRow(row, widths))
+		if len(lines) >= capacity {
+			pages = append(pages, lines)
+			lines = nil
+			capacity = linesPerPage(pageHeight - 2*margin)
+		}
+	}
+	if len(lines) > 0 || len(pages) == 0 {
+		if len(lines) == 0 {
+			lines = append(lines, header, separator, "No data")
+		}
+		pages = append(pages, lines)
+	}
+
+	var contents [][]byte
+	for i, lines := range pages {
+		var stream bytes.Buffer
+		y := pageHeight - margin
+		if i == 0 {
+			drawText(&stream, "F2", 14, margin, y-14, t.Title)
+			drawText(&stream, "F3", 9, margin, y-30, t.Subtitle)
+			y -= titleHeight
+		}
+		for _, line := range lines {
+			y -= lineHeight
+			drawText(&stream, "F1", fontSize, margin, y, line)
+		}
+		drawText(&stream, "F3", 8, pageWidth-margin-60, margin/2, fmt.Sprintf("Page %d of %d", i+1, len(pages)))
+		contents = append(contents, stream.Bytes())
+	}
+	return writeDocument(contents)
+}
+
+// columnWidths sizes columns to their longest value, shrinking the widest
+// ones until the row fits the page
+func (t Table) columnWidths() []int {
+	widths := make([]int, len(t.Columns))
+	for i, column := range t.Columns {
+		widths[i] = len(pdfText(column))
+	}
+	for _, row := range t.Rows {
+		for i := range widths {
+			if i < len(row) && len(pdfText(row[i])) > widths[i] {
+				widths[i] = len(pdfText(row[i]))
+			}
+		}
+	}
+	for i := range widths {
+		widths[i] = min(max(widths[i], 1), maxColumn)
+	}
+
+	width := pageWidth - 2*margin
+	available := int(width / charWidth)
+	for {
+		total := (len(widths) - 1) * columnGap
+		widest := 0
+		for i, w := range widths {
+			total += w
+			if w > widths[widest] {
+				widest = i
+			}
+		}
+		if total <= available || widths[widest] <= minColumn {
+			return widths
+		}
+		widths[widest]--
+	}
+}
+
+func linesPerPage(height float64) int {
+	return int(height/lineHeight) - 1 // Leave room for the page number
+}
+
+// formatRow pads or cuts each cell to its column width
+func formatRow(cells []string, widths []int) string {
+	var b strings.Builder
+	for i, w := range widths {
+		cell := ""
+		if i < len(cells) {
+			cell = pdfText(cells[i])
+		}
+		if len(cell) > w {
+			cell = cell[:max(w-1, 0)] + "~"
+		}
+		b.WriteString(cell)
+		if i < len(widths)-1 {
+			b.WriteString(strings.Repeat(" ", w-len(cell)+columnGap))
+		}
+	}
+	return strings.TrimRight(b.String(), " ")
+}
+
+// pdfText transliterates s to printable ASCII
+func pdfText(s string) string {
+	s = unidecode.Unidecode(s)
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r > 0x7e {
+			return ' '
+		}
+		return r
+	}, s)
+}
+
+func drawText(w *bytes.Buffer, font string, size, x, y float64, text string) {
+	escaped := strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`).Replace(pdfText(text))
+	fmt.Fprintf(w, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, escaped)
+}
+
+// writeDocument assembles the catalog, fonts, pages and content streams
+// with a cross-reference table
+func writeDocument(contents [][]byte) []byte {
+	var buf bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	// Objects 1-5 are fixed; each page is followed by its content stream
+	const firstPage = 6
+	kids := make([]string, len(contents))
+	for i := range contents {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(contents)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	for i, content := range contents {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R /F3 5 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, firstPage+2*i+1))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(content), content))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return buf.Bytes()
+}
@@ -0,0 +1,66 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// idleBucketTTL is how long an untouched bucket is kept; longer than any
+// refill period, so dropped buckets are always full
+const idleBucketTTL = 10 * time.Minute
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Memory is a Limiter for a single server instance
+type Memory struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// NewMemory creates an in-memory limiter
+func NewMemory() *Memory {
+	m := &Memory{buckets: make(map[string]*bucket)}
+
+	// Drop idle buckets periodically
+	go m.cleanup()
+
+	return m
+}
+
+func (m *Memory) cleanup() {
+	ticker := time.NewTicker(idleBucketTTL)
+	for range ticker.C {
+		m.mu.Lock()
+		cutoff := time.Now().Add(-idleBucketTTL)
+		for key, b := range m.buckets {
+			if b.last.Before(cutoff) {
+				delete(m.buckets, key)
+			}
+		}
+		m.mu.Unlock()
+	}
+}
+
+func (m *Memory) Take(_ context.Context, key string, rate Rate) (Result, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	b, ok := m.buckets[key]
+	if !ok {
+		b = &bucket{tokens: rate.capacity(), last: now}
+		m.buckets[key] = b
+	}
+	b.tokens = refill(rate, b.tokens, now.Sub(b.last))
+	b.last = now
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+	return newResult(rate, b.tokens, allowed), nil
+}
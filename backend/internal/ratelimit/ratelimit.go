@@ -0,0 +1,77 @@
+// Package ratelimit implements token bucket rate limiting backed by memory or
+// Redis. The Redis store shares buckets between server instances.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Rate configures a token bucket. Buckets hold up to Burst tokens and refill
+// at PerMinute tokens per minute; each request takes one token.
+type Rate struct {
+	PerMinute int
+	Burst     int // Defaults to PerMinute
+}
+
+// Enabled reports whether the rate limits anything
+func (r Rate) Enabled() bool {
+	return r.PerMinute > 0
+}
+
+func (r Rate) capacity() float64 {
+	if r.Burst > 0 {
+		return float64(r.Burst)
+	}
+	return float64(r.PerMinute)
+}
+
+// perSecond returns the refill rate in tokens per second
+func (r Rate) perSecond() float64 {
+	return float64(r.PerMinute) / 60
+}
+
+// Result describes a bucket after a request took (or failed to take) a token
+type Result struct {
+	Allowed    bool
+	Limit      int           // Bucket capacity
+	Remaining  int           // Whole tokens left
+	Reset      time.Duration // Time until the bucket is full again
+	RetryAfter time.Duration // Time until a token is available; zero when allowed
+}
+
+// Limiter takes tokens from named buckets
+type Limiter interface {
+	Take(ctx context.Context, key string, rate Rate) (Result, error)
+}
+
+// refill returns the tokens in a bucket after elapsed time
+func refill(rate Rate, tokens float64, elapsed time.Duration) float64 {
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	return math.Min(rate.capacity(), tokens+elapsed.Seconds()*rate.perSecond())
+}
+
+// newResult builds a Result from the tokens left after a take
+func newResult(rate Rate, tokens float64, allowed bool) Result {
+	perSecond := rate.perSecond()
+	res := Result{
+		Allowed:   allowed,
+		Limit:     int(rate.capacity()),
+		Remaining: int(math.Floor(tokens)),
+		Reset:     secondsToDuration((rate.capacity() - tokens) / perSecond),
+	}
+	if !allowed {
+		res.RetryAfter = secondsToDuration((1 - tokens) / perSecond)
+	}
+	return res
+}
+
+func secondsToDuration(s float64) time.Duration {
+	if s <= 0 {
+		return 0
+	}
+	return time.Duration(math.Ceil(s * float64(time.Second)))
+}
@@ -18,14 +18,17 @@ type AdminRepository interface {
 	GetUserDevices(userID uint) ([]models.DeviceInfo, error)
 	GetUserRecentTasks(userID uint, limit int) ([]models.Task, error)
 	GetUserRecentTimeLogs(userID uint, limit int) ([]models.TimeLog, error)
+	MergeUsers(sourceID, targetID uint) (*UserMergeCounts, error)
 
 	// Organizations
 	FindOrgsWithFilters(params *dto.AdminOrgListParams) ([]models.Organization, int64, error)
 	GetOrgStats(orgID uint) (*OrgStats, error)
+	GetOrgStatsByIDs(orgIDs []uint) (map[uint]*OrgStats, error)
 
 	// Workspaces
 	FindWorkspacesWithFilters(params *dto.AdminWorkspaceListParams) ([]models.Workspace, int64, error)
 	GetWorkspaceStats(workspaceID uint) (*WorkspaceStats, error)
+	GetWorkspaceStatsByIDs(workspaceIDs []uint) (map[uint]*WorkspaceStats, error)
 
 	// Tasks
 	FindTasksWithFilters(params *dto.AdminTaskListParams) ([]models.Task, int64, error)
@@ -33,17 +36,11 @@ type AdminRepository interface {
 
 	// Time Logs
 	FindTimeLogsWithFilters(params *dto.AdminTimeLogListParams) ([]models.TimeLog, int64, error)
+	FindTimeLogsForExport(params *dto.AdminTimeLogListParams, limit int) ([]models.TimeLog, error)
 	BulkApproveTimeLogs(ids []uint, approvedBy uint, approved bool) error
 
 	// Screenshots
 	FindScreenshotsWithFilters(params *dto.AdminScreenshotListParams) ([]models.Screenshot, int64, error)
-
-	// Statistics
-	GetOverviewStats() (*dto.AdminOverviewStats, error)
-	GetTrendStats(period string, startDate, endDate time.Time) (*dto.AdminTrendStats, error)
-	GetUserPerformanceStats(limit int) ([]dto.AdminUserPerformance, error)
-	GetOrgDistributionStats() (*dto.AdminOrgStats, error)
-	GetActivityStats() (*dto.AdminActivityStats, error)
 }
 
 // UserStats holds user statistics
@@ -55,6 +52,17 @@ type UserStats struct {
 	TotalDuration   int64
 }
 
+// UserMergeCounts holds the number of records moved by a user merge
+type UserMergeCounts struct {
+	Tasks                   int64
+	TaskAssignments         int64
+	TimeLogs                int64
+	Screenshots             int64
+	Devices                 int64
+	OrganizationMemberships int64
+	WorkspaceMemberships    int64
+}
+
 // OrgStats holds organization statistics
 type OrgStats struct {
 	MemberCount    int64
@@ -253,6 +261,132 @@ func (r *adminRepository) GetUserRecentTimeLogs(userID uint, limit int) ([]model
 	return timeLogs, err
 }
 
+// MergeUsers moves the source user's tasks, time logs, screenshots, devices
+// and memberships to the target user and deactivates the source, in a single
+// transaction. Where both belong to the same organization, workspace or task
+// assignment, the target's membership is kept; organizations the source owns
+// pass to the target.
+func (r *adminRepository) MergeUsers(sourceID, targetID uint) (*UserMergeCounts, error) {
+	counts := &UserMergeCounts{}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		reassign := func(model interface{}, column string) (int64, error) {
+			result := tx.Unscoped().Model(model).Where(column+" = ?", sourceID).Update(column, targetID)
+			return result.RowsAffected, result.Error
+		}
+
+		var err error
+		if counts.Tasks, err = reassign(&models.Task{}, "user_id"); err != nil {
+			return err
+		}
+		if counts.TimeLogs, err = reassign(&models.TimeLog{}, "user_id"); err != nil {
+			return err
+		}
+		if _, err = reassign(&models.PrivateInterval{}, "user_id"); err != nil {
+			return err
+		}
+		if counts.Screenshots, err = reassign(&models.Screenshot{}, "user_id"); err != nil {
+			return err
+		}
+		if counts.Devices, err = reassign(&models.DeviceInfo{}, "user_id"); err != nil {
+			return err
+		}
+
+		// Rollups of deleted screenshots are summed into the target's rows
+		if err := tx.Exec(`
+			INSERT INTO screenshot_daily_rollups AS r (
+				date, organization_id, workspace_id, user_id, task_id,
+				screenshot_count, total_bytes, first_captured_at, last_captured_at,
+				created_at, updated_at
+			)
+			SELECT date, organization_id, workspace_id, ?, task_id,
+				screenshot_count, total_bytes, first_captured_at, last_captured_at,
+				created_at, NOW()
+			FROM screenshot_daily_rollups
+			WHERE user_id = ?
+			ON CONFLICT (date, organization_id, workspace_id, user_id, task_id) DO UPDATE SET
+				screenshot_count = r.screenshot_count + EXCLUDED.screenshot_count,
+				total_bytes = r.total_bytes + EXCLUDED.total_bytes,
+				first_captured_at = LEAST(r.first_captured_at, EXCLUDED.first_captured_at),
+				last_captured_at = GREATEST(r.last_captured_at, EXCLUDED.last_captured_at),
+				updated_at = NOW()
+		`, targetID, sourceID).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", sourceID).Delete(&models.ScreenshotDailyRollup{}).Error; err != nil {
+			return err
+		}
+
+		// Daily stats follow the time logs; workspace stats keep counting
+		// both users as active on days they both tracked
+		if err := tx.Exec(`
+			INSERT INTO daily_user_stats AS d (
+				date, organization_id, workspace_id, user_id,
+				duration, time_logs, screenshots, created_at
+			)
+			SELECT date, organization_id, workspace_id, ?,
+				duration, time_logs, screenshots, created_at
+			FROM daily_user_stats
+			WHERE user_id = ?
+			ON CONFLICT (date, organization_id, workspace_id, user_id) DO UPDATE SET
+				duration = d.duration + EXCLUDED.duration,
+				time_logs = d.time_logs + EXCLUDED.time_logs,
+				screenshots = d.screenshots + EXCLUDED.screenshots
+		`, targetID, sourceID).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", sourceID).Delete(&models.DailyUserStat{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("user_id = ? AND task_id IN (?)", sourceID,
+			tx.Model(&models.TaskAssignee{}).Select("task_id").Where("user_id = ?", targetID)).
+			Delete(&models.TaskAssignee{}).Error; err != nil {
+			return err
+		}
+		if counts.TaskAssignments, err = reassign(&models.TaskAssignee{}, "user_id"); err != nil {
+			return err
+		}
+
+		// Ownership passes to the target, who becomes the owner member
+		if err := tx.Unscoped().Model(&models.Organization{}).Where("owner_id = ?", sourceID).
+			Update("owner_id", targetID).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.OrganizationMember{}).
+			Where("user_id = ? AND organization_id IN (?)", targetID,
+				tx.Unscoped().Model(&models.Organization{}).Select("id").Where("owner_id = ?", targetID)).
+			Update("role", models.OrgRoleOwner).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("user_id = ? AND organization_id IN (?)", sourceID,
+			tx.Model(&models.OrganizationMember{}).Select("organization_id").Where("user_id = ?", targetID)).
+			Delete(&models.OrganizationMember{}).Error; err != nil {
+			return err
+		}
+		if counts.OrganizationMemberships, err = reassign(&models.OrganizationMember{}, "user_id"); err != nil {
+			return err
+		}
+
+		if err := tx.Unscoped().Model(&models.Workspace{}).Where("admin_id = ?", sourceID).
+			Update("admin_id", targetID).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("user_id = ? AND workspace_id IN (?)", sourceID,
+			tx.Model(&models.WorkspaceMember{}).Select("workspace_id").Where("user_id = ?", targetID)).
+			Delete(&models.WorkspaceMember{}).Error; err != nil {
+			return err
+		}
+		if counts.WorkspaceMemberships, err = reassign(&models.WorkspaceMember{}, "user_id"); err != nil {
+			return err
+		}
+
+		return tx.Model(&models.User{}).Where("id = ?", sourceID).Update("is_active", false).Error
+	})
+
+	return counts, err
+}
+
 // ============================================================================
 // ORGANIZATION METHODS
 // ============================================================================
@@ -324,6 +458,36 @@ func (r *adminRepository) GetOrgStats(orgID uint) (*OrgStats, error) {
 	return stats, nil
 }
 
+// GetOrgStatsByIDs computes the stats of several organizations in a single query
+func (r *adminRepository) GetOrgStatsByIDs(orgIDs []uint) (map[uint]*OrgStats, error) {
+	result := make(map[uint]*OrgStats, len(orgIDs))
+	if len(orgIDs) == 0 {
+		return result, nil
+	}
+
+	var rows []struct {
+		OrganizationID uint
+		OrgStats
+	}
+	err := r.db.Table("organizations o").
+		Select(`o.id AS organization_id,
+			COUNT(DISTINCT om.id) AS member_count,
+			COUNT(DISTINCT w.id) AS workspace_count`).
+		Joins("LEFT JOIN organization_members om ON om.organization_id = o.id AND om.deleted_at IS NULL").
+		Joins("LEFT JOIN workspaces w ON w.organization_id = o.id AND w.deleted_at IS NULL").
+		Where("o.id IN ?", orgIDs).
+		Group("o.id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range rows {
+		result[rows[i].OrganizationID] = &rows[i].OrgStats
+	}
+	return result, nil
+}
+
 // ============================================================================
 // WORKSPACE METHODS
 // ============================================================================
@@ -399,6 +563,36 @@ func (r *adminRepository) GetWorkspaceStats(workspaceID uint) (*WorkspaceStats,
 	return stats, nil
 }
 
+// GetWorkspaceStatsByIDs computes the stats of several workspaces in a single query
+func (r *adminRepository) GetWorkspaceStatsByIDs(workspaceIDs []uint) (map[uint]*WorkspaceStats, error) {
+	result := make(map[uint]*WorkspaceStats, len(workspaceIDs))
+	if len(workspaceIDs) == 0 {
+		return result, nil
+	}
+
+	var rows []struct {
+		WorkspaceID uint
+		WorkspaceStats
+	}
+	err := r.db.Table("workspaces w").
+		Select(`w.id AS workspace_id,
+			COUNT(DISTINCT wm.id) AS member_count,
+			COUNT(DISTINCT t.id) AS task_count`).
+		Joins("LEFT JOIN workspace_members wm ON wm.workspace_id = w.id AND wm.deleted_at IS NULL").
+		Joins("LEFT JOIN tasks t ON t.workspace_id = w.id AND t.deleted_at IS NULL").
+		Where("w.id IN ?", workspaceIDs).
+		Group("w.id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range rows {
+		result[rows[i].WorkspaceID] = &rows[i].WorkspaceStats
+	}
+	return result, nil
+}
+
 // ============================================================================
 // TASK METHODS
 // ============================================================================
@@ -434,6 +628,10 @@ func (r *adminRepository) FindTasksWithFilters(params *dto.AdminTaskListParams)
 		query = query.Where("is_manual = ?", *params.IsManual)
 	}
 
+	if where, args := taskFilterSQL("tasks", &params.TaskFilter); where != "" {
+		query = query.Where(where, args...)
+	}
+
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
@@ -494,8 +692,48 @@ func (r *adminRepository) FindTimeLogsWithFilters(params *dto.AdminTimeLogListPa
 	var timeLogs []models.TimeLog
 	var total int64
 
-	query := r.db.Model(&models.TimeLog{}).Preload("User").Preload("Task").Preload("Organization").Preload("Workspace")
+	query := r.applyTimeLogFilters(r.db.Model(&models.TimeLog{}), params).
+		Preload("User").Preload("Task").Preload("Organization").Preload("Workspace")
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	sortBy := "start_time"
+	if params.SortBy != "" {
+		sortBy = params.SortBy
+	}
+	sortOrder := "DESC"
+	if params.SortOrder == "asc" {
+		sortOrder = "ASC"
+	}
+	query = query.Order("time_logs." + sortBy + " " + sortOrder)
+
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 || params.PageSize > 100 {
+		params.PageSize = 20
+	}
+	offset := (params.Page - 1) * params.PageSize
+	query = query.Offset(offset).Limit(params.PageSize)
+
+	if err := query.Find(&timeLogs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return timeLogs, total, nil
+}
+
+// timeLogCostCenterExpr and timeLogProjectCodeExpr resolve the effective
+// accounting tags of a time log: the task's value, else the workspace's
+const (
+	timeLogCostCenterExpr  = "COALESCE(NULLIF(tasks.cost_center, ''), workspaces.cost_center, '')"
+	timeLogProjectCodeExpr = "COALESCE(NULLIF(tasks.project_code, ''), workspaces.project_code, '')"
+)
 
+// applyTimeLogFilters applies the admin time log filters shared by listing and export
+func (r *adminRepository) applyTimeLogFilters(query *gorm.DB, params *dto.AdminTimeLogListParams) *gorm.DB {
 	if params.UserID != nil {
 		query = query.Where("time_logs.user_id = ?", *params.UserID)
 	}
@@ -509,60 +747,66 @@ func (r *adminRepository) FindTimeLogsWithFilters(params *dto.AdminTimeLogListPa
 	}
 
 	if params.TaskID != nil {
-		query = query.Where("task_id = ?", *params.TaskID)
+		query = query.Where("time_logs.task_id = ?", *params.TaskID)
 	}
 
 	if params.Status != "" {
-		query = query.Where("status = ?", params.Status)
+		query = query.Where("time_logs.status = ?", params.Status)
 	}
 
 	if params.IsApproved != nil {
-		query = query.Where("is_approved = ?", *params.IsApproved)
+		query = query.Where("time_logs.is_approved = ?", *params.IsApproved)
+	}
+
+	if params.Pending != nil {
+		query = query.Where("time_logs.pending_approval = ?", *params.Pending)
 	}
 
 	if params.StartDate != nil {
-		query = query.Where("start_time >= ?", *params.StartDate)
+		query = query.Where("time_logs.start_time >= ?", *params.StartDate)
 	}
 
 	if params.EndDate != nil {
-		query = query.Where("start_time <= ?", *params.EndDate)
+		query = query.Where("time_logs.start_time <= ?", *params.EndDate)
 	}
 
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, err
+	if params.CostCenter != "" || params.ProjectCode != "" {
+		query = query.
+			Joins("LEFT JOIN tasks ON tasks.id = time_logs.task_id").
+			Joins("LEFT JOIN workspaces ON workspaces.id = time_logs.workspace_id")
 	}
 
-	sortBy := "start_time"
-	if params.SortBy != "" {
-		sortBy = params.SortBy
+	if params.CostCenter != "" {
+		query = query.Where(timeLogCostCenterExpr+" = ?", params.CostCenter)
 	}
-	sortOrder := "DESC"
-	if params.SortOrder == "asc" {
-		sortOrder = "ASC"
-	}
-	query = query.Order("time_logs." + sortBy + " " + sortOrder)
 
-	if params.Page < 1 {
-		params.Page = 1
-	}
-	if params.PageSize < 1 || params.PageSize > 100 {
-		params.PageSize = 20
+	if params.ProjectCode != "" {
+		query = query.Where(timeLogProjectCodeExpr+" = ?", params.ProjectCode)
 	}
-	offset := (params.Page - 1) * params.PageSize
-	query = query.Offset(offset).Limit(params.PageSize)
 
-	if err := query.Find(&timeLogs).Error; err != nil {
-		return nil, 0, err
-	}
+	return query
+}
 
-	return timeLogs, total, nil
+// FindTimeLogsForExport returns all time logs matching the filters, oldest
+// first, capped at limit rows
+func (r *adminRepository) FindTimeLogsForExport(params *dto.AdminTimeLogListParams, limit int) ([]models.TimeLog, error) {
+	var timeLogs []models.TimeLog
+
+	err := r.applyTimeLogFilters(r.db.Model(&models.TimeLog{}), params).
+		Preload("User").Preload("Task").Preload("Organization").Preload("Workspace").
+		Order("time_logs.start_time ASC").
+		Limit(limit).
+		Find(&timeLogs).Error
+
+	return timeLogs, err
 }
 
 func (r *adminRepository) BulkApproveTimeLogs(ids []uint, approvedBy uint, approved bool) error {
 	now := time.Now()
 	updates := map[string]interface{}{
-		"is_approved": approved,
-		"updated_at":  now,
+		"is_approved":      approved,
+		"pending_approval": false,
+		"updated_at":       now,
 	}
 
 	if approved {
@@ -573,8 +817,9 @@ func (r *adminRepository) BulkApproveTimeLogs(ids []uint, approvedBy uint, appro
 		updates["approved_at"] = nil
 	}
 
+	// Invoiced time logs keep the approval they were billed with
 	return r.db.Model(&models.TimeLog{}).
-		Where("id IN ?", ids).
+		Where("id IN ? AND invoice_id IS NULL", ids).
 		Updates(updates).Error
 }
 
@@ -616,6 +861,10 @@ func (r *adminRepository) FindScreenshotsWithFilters(params *dto.AdminScreenshot
 		query = query.Where("captured_at <= ?", *params.EndDate)
 	}
 
+	if params.ScanStatus != "" {
+		query = query.Where("scan_status = ?", params.ScanStatus)
+	}
+
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
@@ -645,245 +894,3 @@ func (r *adminRepository) FindScreenshotsWithFilters(params *dto.AdminScreenshot
 
 	return screenshots, total, nil
 }
-
-// ============================================================================
-// STATISTICS METHODS
-// ============================================================================
-
-func (r *adminRepository) GetOverviewStats() (*dto.AdminOverviewStats, error) {
-	stats := &dto.AdminOverviewStats{}
-
-	// Users
-	r.db.Model(&models.User{}).Count(&stats.TotalUsers)
-	r.db.Model(&models.User{}).Where("is_active = true").Count(&stats.ActiveUsers)
-
-	weekAgo := time.Now().AddDate(0, 0, -7)
-	r.db.Model(&models.User{}).Where("created_at >= ?", weekAgo).Count(&stats.NewUsersThisWeek)
-
-	// Organizations
-	r.db.Model(&models.Organization{}).Count(&stats.TotalOrganizations)
-	r.db.Model(&models.Organization{}).Where("is_verified = true").Count(&stats.VerifiedOrganizations)
-
-	// Workspaces
-	r.db.Model(&models.Workspace{}).Count(&stats.TotalWorkspaces)
-	r.db.Model(&models.Workspace{}).Where("is_active = true AND is_archived = false").Count(&stats.ActiveWorkspaces)
-
-	// Tasks
-	r.db.Model(&models.Task{}).Count(&stats.TotalTasks)
-	r.db.Model(&models.Task{}).Where("status = 'active'").Count(&stats.ActiveTasks)
-
-	// Time Logs
-	var timeLogStats struct {
-		Count         int64
-		TotalDuration int64
-		WeekDuration  int64
-	}
-	r.db.Model(&models.TimeLog{}).
-		Select("COUNT(*) as count, COALESCE(SUM(duration), 0) as total_duration").
-		Scan(&timeLogStats)
-	stats.TotalTimeLogs = timeLogStats.Count
-	stats.TotalDuration = timeLogStats.TotalDuration
-
-	r.db.Model(&models.TimeLog{}).
-		Select("COALESCE(SUM(duration), 0)").
-		Where("start_time >= ?", weekAgo).
-		Scan(&stats.WeekDuration)
-
-	// Screenshots
-	var screenshotStats struct {
-		Count     int64
-		TotalSize int64
-	}
-	r.db.Model(&models.Screenshot{}).
-		Select("COUNT(*) as count, COALESCE(SUM(file_size), 0) as total_size").
-		Scan(&screenshotStats)
-	stats.TotalScreenshots = screenshotStats.Count
-	stats.TotalStorage = screenshotStats.TotalSize
-	stats.TotalStorageHuman = formatBytes(screenshotStats.TotalSize)
-
-	return stats, nil
-}
-
-func (r *adminRepository) GetTrendStats(period string, startDate, endDate time.Time) (*dto.AdminTrendStats, error) {
-	stats := &dto.AdminTrendStats{
-		UserGrowth:    []dto.AdminDailyStat{},
-		ActivityTrend: []dto.AdminDailyStat{},
-	}
-
-	// Get daily user growth
-	rows, err := r.db.Raw(`
-		SELECT 
-			DATE(created_at) as date,
-			COUNT(*) as new_users,
-			(SELECT COUNT(*) FROM users WHERE DATE(created_at) <= dates.date) as total_users
-		FROM users
-		WHERE created_at BETWEEN ? AND ?
-		GROUP BY DATE(created_at)
-		ORDER BY date
-	`, startDate, endDate).Rows()
-	if err == nil {
-		defer rows.Close()
-		for rows.Next() {
-			var stat dto.AdminDailyStat
-			rows.Scan(&stat.Date, &stat.NewUsers, &stat.TotalUsers)
-			stats.UserGrowth = append(stats.UserGrowth, stat)
-		}
-	}
-
-	// Get daily activity trend
-	activityRows, err := r.db.Raw(`
-		SELECT 
-			DATE(start_time) as date,
-			COALESCE(SUM(duration), 0) as duration,
-			COUNT(*) as timelogs,
-			(SELECT COUNT(*) FROM screenshots WHERE DATE(captured_at) = DATE(time_logs.start_time)) as screenshots
-		FROM time_logs
-		WHERE start_time BETWEEN ? AND ?
-		GROUP BY DATE(start_time)
-		ORDER BY date
-	`, startDate, endDate).Rows()
-	if err == nil {
-		defer activityRows.Close()
-		for activityRows.Next() {
-			var stat dto.AdminDailyStat
-			activityRows.Scan(&stat.Date, &stat.Duration, &stat.TimeLogs, &stat.Screenshots)
-			stats.ActivityTrend = append(stats.ActivityTrend, stat)
-		}
-	}
-
-	return stats, nil
-}
-
-func (r *adminRepository) GetUserPerformanceStats(limit int) ([]dto.AdminUserPerformance, error) {
-	var performers []dto.AdminUserPerformance
-
-	r.db.Raw(`
-		SELECT 
-			users.id as user_id,
-			CONCAT(users.first_name, ' ', users.last_name) as user_name,
-			users.email,
-			COALESCE(SUM(time_logs.duration), 0) as total_duration,
-			COUNT(DISTINCT tasks.id) as task_count,
-			ROW_NUMBER() OVER (ORDER BY COALESCE(SUM(time_logs.duration), 0) DESC) as rank
-		FROM users
-		LEFT JOIN time_logs ON time_logs.user_id = users.id
-		LEFT JOIN tasks ON tasks.user_id = users.id
-		WHERE users.deleted_at IS NULL
-		GROUP BY users.id, users.first_name, users.last_name, users.email
-		ORDER BY total_duration DESC
-		LIMIT ?
-	`, limit).Scan(&performers)
-
-	return performers, nil
-}
-
-func (r *adminRepository) GetOrgDistributionStats() (*dto.AdminOrgStats, error) {
-	stats := &dto.AdminOrgStats{
-		SizeDistribution: []dto.AdminOrgSizeCategory{},
-		TopWorkspaces:    []dto.AdminTopWorkspace{},
-	}
-
-	// Size distribution
-	r.db.Raw(`
-		SELECT 
-			CASE 
-				WHEN member_count <= 10 THEN 'small'
-				WHEN member_count <= 50 THEN 'medium'
-				ELSE 'large'
-			END as category,
-			COUNT(*) as count
-		FROM (
-			SELECT organizations.id, COUNT(organization_members.id) as member_count
-			FROM organizations
-			LEFT JOIN organization_members ON organization_members.organization_id = organizations.id
-			WHERE organizations.deleted_at IS NULL
-			GROUP BY organizations.id
-		) org_sizes
-		GROUP BY category
-	`).Scan(&stats.SizeDistribution)
-
-	// Top workspaces
-	r.db.Raw(`
-		SELECT 
-			workspaces.id as workspace_id,
-			workspaces.name,
-			organizations.name as organization_name,
-			COALESCE(SUM(time_logs.duration), 0) as total_duration,
-			COUNT(DISTINCT workspace_members.user_id) as member_count
-		FROM workspaces
-		JOIN organizations ON organizations.id = workspaces.organization_id
-		LEFT JOIN time_logs ON time_logs.workspace_id = workspaces.id
-		LEFT JOIN workspace_members ON workspace_members.workspace_id = workspaces.id
-		WHERE workspaces.deleted_at IS NULL
-		GROUP BY workspaces.id, workspaces.name, organizations.name
-		ORDER BY total_duration DESC
-		LIMIT 10
-	`).Scan(&stats.TopWorkspaces)
-
-	return stats, nil
-}
-
-func (r *adminRepository) GetActivityStats() (*dto.AdminActivityStats, error) {
-	stats := &dto.AdminActivityStats{
-		ActivityByHour: make([]dto.AdminHourlyStat, 24),
-	}
-
-	today := time.Now().Truncate(24 * time.Hour)
-
-	// Today's stats
-	r.db.Model(&models.TimeLog{}).
-		Select("COALESCE(SUM(duration), 0)").
-		Where("start_time >= ?", today).
-		Scan(&stats.TodayDuration)
-
-	r.db.Model(&models.TimeLog{}).
-		Select("COUNT(DISTINCT user_id)").
-		Where("start_time >= ?", today).
-		Scan(&stats.TodayActiveUsers)
-
-	r.db.Model(&models.Screenshot{}).
-		Where("captured_at >= ?", today).
-		Count(&stats.TodayScreenshots)
-
-	// Activity by hour
-	for i := 0; i < 24; i++ {
-		stats.ActivityByHour[i] = dto.AdminHourlyStat{Hour: i}
-	}
-
-	var hourlyStats []struct {
-		Hour  int
-		Count int64
-	}
-	r.db.Raw(`
-		SELECT EXTRACT(HOUR FROM start_time) as hour, COUNT(*) as count
-		FROM time_logs
-		WHERE start_time >= ?
-		GROUP BY EXTRACT(HOUR FROM start_time)
-	`, today.AddDate(0, 0, -7)).Scan(&hourlyStats)
-
-	for _, h := range hourlyStats {
-		if h.Hour >= 0 && h.Hour < 24 {
-			stats.ActivityByHour[h.Hour].Count = h.Count
-			if h.Count > stats.PeakHourCount {
-				stats.PeakHour = h.Hour
-				stats.PeakHourCount = h.Count
-			}
-		}
-	}
-
-	return stats, nil
-}
-
-// Helper function to format bytes
-func formatBytes(bytes int64) string {
-	const unit = 1024
-	if bytes < unit {
-		return string(rune(bytes)) + " B"
-	}
-	div, exp := int64(unit), 0
-	for n := bytes / unit; n >= unit; n /= unit {
-		div *= unit
-		exp++
-	}
-	return string(rune(bytes/div)) + " " + string("KMGTPE"[exp]) + "B"
-}
@@ -0,0 +1,370 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// AdminStatsRepository runs the platform-wide aggregate queries behind the
+// admin dashboard. Trends and activity read rolled-up days from the daily
+// stats. Results are uncached; see AdminAnalyticsService.
+type AdminStatsRepository interface {
+	GetOverviewStats() (*dto.AdminOverviewStats, error)
+	// GetTrendStats groups days in the IANA timezone tz
+	GetTrendStats(period string, startDate, endDate time.Time, tz string) (*dto.AdminTrendStats, error)
+	GetUserPerformanceStats(query LeaderboardQuery) ([]dto.AdminUserPerformance, error)
+	GetOrgDistributionStats() (*dto.AdminOrgStats, error)
+	GetActivityStats() (*dto.AdminActivityStats, error)
+}
+
+// LeaderboardQuery selects the time ranked by GetUserPerformanceStats: time
+// logs started within [Start, End), in one organization or workspace when set
+type LeaderboardQuery struct {
+	Start          time.Time
+	End            time.Time
+	OrganizationID uint // 0 for every organization
+	WorkspaceID    uint // 0 for every workspace
+	Limit          int
+}
+
+type adminStatsRepository struct {
+	db *gorm.DB
+}
+
+// NewAdminStatsRepository creates a new admin stats repository
+func NewAdminStatsRepository(db *gorm.DB) AdminStatsRepository {
+	return &adminStatsRepository{db: db}
+}
+
+// count runs a COUNT query into dest, keeping the first error
+func (r *adminStatsRepository) count(err *error, query *gorm.DB, dest *int64) {
+	if *err != nil {
+		return
+	}
+	*err = query.Count(dest).Error
+}
+
+// scan runs a query into dest, keeping the first error
+func (r *adminStatsRepository) scan(err *error, query *gorm.DB, dest interface{}) {
+	if *err != nil {
+		return
+	}
+	*err = query.Scan(dest).Error
+}
+
+func (r *adminStatsRepository) GetOverviewStats() (*dto.AdminOverviewStats, error) {
+	stats := &dto.AdminOverviewStats{}
+	var err error
+	weekAgo := time.Now().AddDate(0, 0, -7)
+
+	// Users
+	r.count(&err, r.db.Model(&models.User{}), &stats.TotalUsers)
+	r.count(&err, r.db.Model(&models.User{}).Where("is_active = true"), &stats.ActiveUsers)
+	r.count(&err, r.db.Model(&models.User{}).Where("created_at >= ?", weekAgo), &stats.NewUsersThisWeek)
+
+	// Organizations
+	r.count(&err, r.db.Model(&models.Organization{}), &stats.TotalOrganizations)
+	r.count(&err, r.db.Model(&models.Organization{}).Where("is_verified = true"), &stats.VerifiedOrganizations)
+
+	// Workspaces
+	r.count(&err, r.db.Model(&models.Workspace{}), &stats.TotalWorkspaces)
+	r.count(&err, r.db.Model(&models.Workspace{}).Where("is_active = true AND is_archived = false"), &stats.ActiveWorkspaces)
+
+	// Tasks
+	r.count(&err, r.db.Model(&models.Task{}), &stats.TotalTasks)
+	r.count(&err, r.db.Model(&models.Task{}).Where("status = 'active'"), &stats.ActiveTasks)
+
+	// Time Logs
+	var timeLogStats struct {
+		Count         int64
+		TotalDuration int64
+	}
+	r.scan(&err, r.db.Model(&models.TimeLog{}).
+		Select("COUNT(*) as count, COALESCE(SUM(duration), 0) as total_duration"), &timeLogStats)
+	stats.TotalTimeLogs = timeLogStats.Count
+	stats.TotalDuration = timeLogStats.TotalDuration
+
+	r.scan(&err, r.db.Model(&models.TimeLog{}).
+		Select("COALESCE(SUM(duration), 0)").
+		Where("start_time >= ?", weekAgo), &stats.WeekDuration)
+
+	// Screenshots
+	var screenshotStats struct {
+		Count     int64
+		TotalSize int64
+	}
+	r.scan(&err, r.db.Model(&models.Screenshot{}).
+		Select("COUNT(*) as count, COALESCE(SUM(file_size), 0) as total_size"), &screenshotStats)
+	stats.TotalScreenshots = screenshotStats.Count
+	stats.TotalStorage = screenshotStats.TotalSize
+
+	// Every screenshot beyond the first of a shared file saves its size
+	var dedupeStats struct {
+		Count int64
+		Saved int64
+	}
+	r.scan(&err, r.db.Raw(`
+		SELECT COALESCE(SUM(refs - 1), 0) AS count, COALESCE(SUM((refs - 1) * file_size), 0) AS saved
+		FROM (
+			SELECT COUNT(*) AS refs, MAX(file_size) AS file_size
+			FROM screenshots
+			WHERE deleted_at IS NULL
+			GROUP BY file_path
+			HAVING COUNT(*) > 1
+		) shared
+	`), &dedupeStats)
+	stats.DedupedScreenshots = dedupeStats.Count
+	stats.DedupeSavedBytes = dedupeStats.Saved
+
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (r *adminStatsRepository) GetTrendStats(period string, startDate, endDate time.Time, tz string) (*dto.AdminTrendStats, error) {
+	stats := &dto.AdminTrendStats{
+		UserGrowth:    []dto.AdminDailyStat{},
+		ActivityTrend: []dto.AdminDailyStat{},
+	}
+
+	// Daily user growth
+	err := r.db.Raw(`
+		SELECT
+			dates.date,
+			dates.new_users,
+			(SELECT COUNT(*) FROM users WHERE (created_at AT TIME ZONE @tz)::date <= dates.date) as total_users
+		FROM (
+			SELECT (created_at AT TIME ZONE @tz)::date as date, COUNT(*) as new_users
+			FROM users
+			WHERE created_at BETWEEN @start AND @end
+			GROUP BY (created_at AT TIME ZONE @tz)::date
+		) dates
+		ORDER BY dates.date
+	`, map[string]interface{}{"tz": tz, "start": startDate, "end": endDate}).Scan(&stats.UserGrowth).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// Daily activity trend. Rollups are kept per UTC day, so other timezones
+	// read the raw tables only.
+	var rollupFrom, rollupTo time.Time
+	if tz == "UTC" {
+		if rollupFrom, rollupTo, err = rollupCoverage(r.db); err != nil {
+			return nil, err
+		}
+	}
+	err = r.db.Raw(`
+		SELECT date, SUM(duration) as duration, SUM(time_logs) as time_logs, SUM(screenshots) as screenshots
+		FROM (
+			SELECT date, duration, time_logs, screenshots
+			FROM daily_workspace_stats
+			WHERE date >= @rollup_from AND date < @rollup_to AND date BETWEEN @start AND @end
+			UNION ALL
+			SELECT
+				(start_time AT TIME ZONE @tz)::date as date,
+				COALESCE(SUM(duration), 0) as duration,
+				COUNT(*) as time_logs,
+				(SELECT COUNT(*) FROM screenshots WHERE deleted_at IS NULL AND (captured_at AT TIME ZONE @tz)::date = (time_logs.start_time AT TIME ZONE @tz)::date)
+					+ (SELECT COALESCE(SUM(screenshot_count), 0) FROM screenshot_daily_rollups WHERE date = (time_logs.start_time AT TIME ZONE @tz)::date) as screenshots
+			FROM time_logs
+			WHERE deleted_at IS NULL AND start_time BETWEEN @start AND @end
+				AND (start_time < @rollup_from OR start_time >= @rollup_to)
+			GROUP BY (start_time AT TIME ZONE @tz)::date
+		) days
+		GROUP BY date
+		HAVING SUM(time_logs) > 0
+		ORDER BY date
+	`, map[string]interface{}{
+		"tz": tz, "start": startDate, "end": endDate,
+		"rollup_from": rollupFrom, "rollup_to": rollupTo,
+	}).Scan(&stats.ActivityTrend).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// GetUserPerformanceStats ranks users by tracked time, reading rolled-up days
+// from the daily stats. Task counts are the tasks assigned to the user in
+// the scope.
+func (r *adminStatsRepository) GetUserPerformanceStats(query LeaderboardQuery) ([]dto.AdminUserPerformance, error) {
+	rollupFrom, rollupTo, err := rollupCoverage(r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	performers := []dto.AdminUserPerformance{}
+	err = r.db.Raw(`
+		SELECT
+			users.id as user_id,
+			CONCAT(users.first_name, ' ', users.last_name) as user_name,
+			users.email,
+			tracked.total_duration,
+			(SELECT COUNT(*) FROM tasks
+				WHERE tasks.user_id = users.id AND tasks.deleted_at IS NULL
+					AND (@org_id = 0 OR tasks.organization_id = @org_id)
+					AND (@workspace_id = 0 OR tasks.workspace_id = @workspace_id)) as task_count,
+			ROW_NUMBER() OVER (ORDER BY tracked.total_duration DESC, users.id) as rank
+		FROM (
+			SELECT user_id, SUM(duration) as total_duration
+			FROM (
+				SELECT user_id, duration
+				FROM daily_user_stats
+				WHERE date >= @start AND date < @end AND date >= @rollup_from AND date < @rollup_to
+					AND (@org_id = 0 OR organization_id = @org_id)
+					AND (@workspace_id = 0 OR workspace_id = @workspace_id)
+				UNION ALL
+				SELECT user_id, duration
+				FROM time_logs
+				WHERE deleted_at IS NULL AND start_time >= @start AND start_time < @end
+					AND (start_time < @rollup_from OR start_time >= @rollup_to)
+					AND (@org_id = 0 OR organization_id = @org_id)
+					AND (@workspace_id = 0 OR workspace_id = @workspace_id)
+			) durations
+			GROUP BY user_id
+			HAVING SUM(duration) > 0
+		) tracked
+		JOIN users ON users.id = tracked.user_id AND users.deleted_at IS NULL
+		ORDER BY tracked.total_duration DESC, users.id
+		LIMIT @limit
+	`, map[string]interface{}{
+		"start": query.Start, "end": query.End,
+		"org_id": query.OrganizationID, "workspace_id": query.WorkspaceID,
+		"rollup_from": rollupFrom, "rollup_to": rollupTo, "limit": query.Limit,
+	}).Scan(&performers).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return performers, nil
+}
+
+func (r *adminStatsRepository) GetOrgDistributionStats() (*dto.AdminOrgStats, error) {
+	stats := &dto.AdminOrgStats{
+		SizeDistribution: []dto.AdminOrgSizeCategory{},
+		TopWorkspaces:    []dto.AdminTopWorkspace{},
+	}
+
+	// Size distribution
+	err := r.db.Raw(`
+		SELECT
+			CASE
+				WHEN member_count <= 10 THEN 'small'
+				WHEN member_count <= 50 THEN 'medium'
+				ELSE 'large'
+			END as category,
+			COUNT(*) as count
+		FROM (
+			SELECT organizations.id, COUNT(organization_members.id) as member_count
+			FROM organizations
+			LEFT JOIN organization_members ON organization_members.organization_id = organizations.id
+			WHERE organizations.deleted_at IS NULL
+			GROUP BY organizations.id
+		) org_sizes
+		GROUP BY category
+	`).Scan(&stats.SizeDistribution).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// Top workspaces
+	err = r.db.Raw(`
+		SELECT
+			workspaces.id as workspace_id,
+			workspaces.name,
+			organizations.name as organization_name,
+			COALESCE(SUM(time_logs.duration), 0) as total_duration,
+			COUNT(DISTINCT workspace_members.user_id) as member_count
+		FROM workspaces
+		JOIN organizations ON organizations.id = workspaces.organization_id
+		LEFT JOIN time_logs ON time_logs.workspace_id = workspaces.id
+		LEFT JOIN workspace_members ON workspace_members.workspace_id = workspaces.id
+		WHERE workspaces.deleted_at IS NULL
+		GROUP BY workspaces.id, workspaces.name, organizations.name
+		ORDER BY total_duration DESC
+		LIMIT 10
+	`).Scan(&stats.TopWorkspaces).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+func (r *adminStatsRepository) GetActivityStats() (*dto.AdminActivityStats, error) {
+	stats := &dto.AdminActivityStats{
+		ActivityByHour: make([]dto.AdminHourlyStat, 24),
+	}
+	var err error
+
+	today := time.Now().Truncate(24 * time.Hour)
+
+	// Today's stats
+	r.scan(&err, r.db.Model(&models.TimeLog{}).
+		Select("COALESCE(SUM(duration), 0)").
+		Where("start_time >= ?", today), &stats.TodayDuration)
+	r.scan(&err, r.db.Model(&models.TimeLog{}).
+		Select("COUNT(DISTINCT user_id)").
+		Where("start_time >= ?", today), &stats.TodayActiveUsers)
+	r.count(&err, r.db.Model(&models.Screenshot{}).
+		Where("captured_at >= ?", today), &stats.TodayScreenshots)
+
+	// Activity by hour
+	for i := 0; i < 24; i++ {
+		stats.ActivityByHour[i] = dto.AdminHourlyStat{Hour: i}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Rolled-up days come from the daily stats, the rest (today at least)
+	// from the time logs
+	rollupFrom, rollupTo, err := rollupCoverage(r.db)
+	if err != nil {
+		return nil, err
+	}
+	var hourlyStats []struct {
+		Hour  int
+		Count int64
+	}
+	r.scan(&err, r.db.Raw(`
+		SELECT hour, SUM(count) as count
+		FROM (
+			SELECT hours.hour - 1 as hour, SUM(hours.count::bigint) as count
+			FROM daily_workspace_stats,
+				jsonb_array_elements_text(daily_workspace_stats.time_logs_by_hour) WITH ORDINALITY AS hours(count, hour)
+			WHERE date >= @since AND date >= @rollup_from AND date < @rollup_to
+			GROUP BY hours.hour
+			UNION ALL
+			SELECT EXTRACT(HOUR FROM start_time AT TIME ZONE 'UTC')::int as hour, COUNT(*) as count
+			FROM time_logs
+			WHERE deleted_at IS NULL AND start_time >= @since
+				AND (start_time < @rollup_from OR start_time >= @rollup_to)
+			GROUP BY 1
+		) by_hour
+		GROUP BY hour
+	`, map[string]interface{}{
+		"since": today.AddDate(0, 0, -7), "rollup_from": rollupFrom, "rollup_to": rollupTo,
+	}), &hourlyStats)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, h := range hourlyStats {
+		if h.Hour >= 0 && h.Hour < 24 {
+			stats.ActivityByHour[h.Hour].Count = h.Count
+			if h.Count > stats.PeakHourCount {
+				stats.PeakHour = h.Hour
+				stats.PeakHourCount = h.Count
+			}
+		}
+	}
+
+	return stats, nil
+}
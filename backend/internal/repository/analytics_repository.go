@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AnalyticsRepository runs predefined read-only analytics queries
+type AnalyticsRepository interface {
+	Query(sql string, args map[string]interface{}, maxRows int, timeout time.Duration) ([]string, [][]interface{}, bool, error)
+}
+
+type analyticsRepository struct {
+	db *gorm.DB
+}
+
+// NewAnalyticsRepository creates a new analytics repository
+func NewAnalyticsRepository(db *gorm.DB) AnalyticsRepository {
+	return &analyticsRepository{db: db}
+}
+
+// Query executes sql with named args inside a read-only transaction bounded by
+// a statement timeout. At most maxRows rows are returned; the bool result
+// reports whether more rows were available.
+func (r *analyticsRepository) Query(sql string, args map[string]interface{}, maxRows int, timeout time.Duration) ([]string, [][]interface{}, bool, error) {
+	var columns []string
+	rows := [][]interface{}{}
+	truncated := false
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SET TRANSACTION READ ONLY").Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(fmt.Sprintf("SET LOCAL statement_timeout = %d", timeout.Milliseconds())).Error; err != nil {
+			return err
+		}
+
+		result, err := tx.Raw(sql, args).Rows()
+		if err != nil {
+			return err
+		}
+		defer result.Close()
+
+		columns, err = result.Columns()
+		if err != nil {
+			return err
+		}
+
+		for result.Next() {
+			if len(rows) >= maxRows {
+				truncated = true
+				break
+			}
+
+			values := make([]interface{}, len(columns))
+			pointers := make([]interface{}, len(columns))
+			for i := range values {
+				pointers[i] = &values[i]
+			}
+			if err := result.Scan(pointers...); err != nil {
+				return err
+			}
+
+			// Postgres drivers return numerics as []byte; expose them as strings
+			for i, v := range values {
+				if b, ok := v.([]byte); ok {
+					values[i] = string(b)
+				}
+			}
+			rows = append(rows, values)
+		}
+
+		return result.Err()
+	})
+
+	return columns, rows, truncated, err
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// AppReleaseRepository handles published desktop app release data operations
+type AppReleaseRepository interface {
+	// Create stores the release together with its assets
+	Create(release *models.AppRelease) error
+	FindByID(id uint) (*models.AppRelease, error)
+	FindByVersion(version string) (*models.AppRelease, error)
+	// FindByChannels lists the releases of the channels, newest first; no
+	// channels lists all releases
+	FindByChannels(channels []string) ([]models.AppRelease, error)
+	// FindMandatory lists the mandatory releases of the channels
+	FindMandatory(channels []string) ([]models.AppRelease, error)
+	// Delete removes the release and its assets
+	Delete(id uint) error
+
+	// Download statistics
+	CreateDownload(download *models.AppReleaseDownload) error
+	GetDownloadSummaries(since time.Time, platform string, limit int) ([]dto.AdminReleaseDownloadSummary, error)
+}
+
+type appReleaseRepository struct {
+	db *gorm.DB
+}
+
+// NewAppReleaseRepository creates a new app release repository
+func NewAppReleaseRepository(db *gorm.DB) AppReleaseRepository {
+	return &appReleaseRepository{db: db}
+}
+
+func (r *appReleaseRepository) Create(release *models.AppRelease) error {
+	return r.db.Create(release).Error
+}
+
+func (r *appReleaseRepository) FindByID(id uint) (*models.AppRelease, error) {
+	var release models.AppRelease
+	err := r.db.Preload("Assets").First(&release, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &release, nil
+}
+
+func (r *appReleaseRepository) FindByVersion(version string) (*models.AppRelease, error) {
+	var release models.AppRelease
+	err := r.db.Preload("Assets").Where("version = ?", version).First(&release).Error
+	if err != nil {
+		return nil, err
+	}
+	return &release, nil
+}
+
+func (r *appReleaseRepository) FindByChannels(channels []string) ([]models.AppRelease, error) {
+	var releases []models.AppRelease
+	query := r.db.Preload("Assets")
+	if len(channels) > 0 {
+		query = query.Where("channel IN ?", channels)
+	}
+	err := query.Order("published_at DESC").Find(&releases).Error
+	return releases, err
+}
+
+func (r *appReleaseRepository) FindMandatory(channels []string) ([]models.AppRelease, error) {
+	var releases []models.AppRelease
+	err := r.db.Where("channel IN ? AND is_mandatory = ?", channels, true).
+		Order("published_at DESC").
+		Find(&releases).Error
+	return releases, err
+}
+
+func (r *appReleaseRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("release_id = ?", id).Delete(&models.AppReleaseAsset{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.AppRelease{}, id).Error
+	})
+}
+
+func (r *appReleaseRepository) CreateDownload(download *models.AppReleaseDownload) error {
+	return r.db.Create(download).Error
+}
+
+// GetDownloadSummaries aggregates downloads per version, most recently
+// downloaded first
+func (r *appReleaseRepository) GetDownloadSummaries(since time.Time, platform string, limit int) ([]dto.AdminReleaseDownloadSummary, error) {
+	var summaries []dto.AdminReleaseDownloadSummary
+
+	query := r.db.Model(&models.AppReleaseDownload{}).
+		Select(`version,
+			COUNT(*) AS downloads,
+			COUNT(*) FILTER (WHERE kind = ?) AS installer_downloads,
+			COUNT(*) FILTER (WHERE kind = ?) AS blockmap_downloads,
+			COUNT(*) FILTER (WHERE kind = ?) AS delta_downloads,
+			COALESCE(SUM(bytes), 0) AS bytes,
+			COUNT(DISTINCT user_id) AS unique_users,
+			MAX(created_at) AS last_downloaded_at`,
+			models.ReleaseAssetInstaller, models.ReleaseAssetBlockmap, models.ReleaseAssetDelta).
+		Where("created_at >= ?", since)
+
+	if platform != "" {
+		query = query.Where("platform = ?", platform)
+	}
+
+	err := query.Group("version").
+		Order("last_downloaded_at DESC").
+		Limit(limit).
+		Scan(&summaries).Error
+	return summaries, err
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
 	"github.com/beuphecan/remote-time-tracker/internal/models"
 	"gorm.io/gorm"
 )
@@ -10,6 +11,7 @@ type AuditLogRepository interface {
 	Create(auditLog *models.AuditLog) error
 	FindByUserID(userID uint, page, perPage int) ([]models.AuditLog, int64, error)
 	FindByAction(action string, page, perPage int) ([]models.AuditLog, int64, error)
+	FindWithFilters(params *dto.AdminAuditLogListParams) ([]models.AuditLog, int64, error)
 }
 
 type auditLogRepository struct {
@@ -66,3 +68,62 @@ func (r *auditLogRepository) FindByAction(action string, page, perPage int) ([]m
 
 	return auditLogs, total, nil
 }
+
+func (r *auditLogRepository) FindWithFilters(params *dto.AdminAuditLogListParams) ([]models.AuditLog, int64, error) {
+	var auditLogs []models.AuditLog
+	var total int64
+
+	query := r.db.Model(&models.AuditLog{})
+
+	if params.UserID != nil {
+		query = query.Where("user_id = ?", *params.UserID)
+	}
+
+	if params.EntityType != "" {
+		query = query.Where("entity_type = ?", params.EntityType)
+	}
+
+	if params.EntityID != nil {
+		query = query.Where("entity_id = ?", *params.EntityID)
+	}
+
+	if params.Action != "" {
+		query = query.Where("action = ?", params.Action)
+	}
+
+	if params.Status != "" {
+		query = query.Where("status = ?", params.Status)
+	}
+
+	if params.StartDate != nil {
+		query = query.Where("created_at >= ?", *params.StartDate)
+	}
+
+	if params.EndDate != nil {
+		query = query.Where("created_at <= ?", *params.EndDate)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	sortOrder := "DESC"
+	if params.SortOrder == "asc" {
+		sortOrder = "ASC"
+	}
+	query = query.Order("created_at " + sortOrder)
+
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 || params.PageSize > 100 {
+		params.PageSize = 20
+	}
+	offset := (params.Page - 1) * params.PageSize
+
+	if err := query.Preload("User").Offset(offset).Limit(params.PageSize).Find(&auditLogs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return auditLogs, total, nil
+}
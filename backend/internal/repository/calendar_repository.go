@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// CalendarRepository handles calendar feeds and Google Calendar connections
+type CalendarRepository interface {
+	// ICS feeds
+	FindFeedByUser(userID uint) (*models.CalendarFeed, error)
+	FindFeedByToken(token string) (*models.CalendarFeed, error)
+	SaveFeed(feed *models.CalendarFeed) error
+	DeleteFeed(id uint) error
+	TouchFeed(id uint, at time.Time) error
+	// FindFeedTimeLogs returns the user's stopped time logs started since
+	// since, newest first
+	FindFeedTimeLogs(userID uint, since time.Time, limit int) ([]models.TimeLog, error)
+
+	// Google Calendar
+	FindGoogleByUser(userID uint) (*models.GoogleCalendarConnection, error)
+	FindActiveGoogle() ([]models.GoogleCalendarConnection, error)
+	SaveGoogle(conn *models.GoogleCalendarConnection) error
+	DeleteGoogle(id uint) error
+	SetGooglePushResult(id uint, at time.Time, pushErr string) error
+	// FindPendingGoogleTimeLogs returns stopped time logs not yet pushed to
+	// the connection, oldest first
+	FindPendingGoogleTimeLogs(conn *models.GoogleCalendarConnection, limit int) ([]models.TimeLog, error)
+	RecordGoogleEvent(event *models.GoogleCalendarEvent) error
+}
+
+type calendarRepository struct {
+	db *gorm.DB
+}
+
+// NewCalendarRepository creates a new calendar repository
+func NewCalendarRepository(db *gorm.DB) CalendarRepository {
+	return &calendarRepository{db: db}
+}
+
+func (r *calendarRepository) FindFeedByUser(userID uint) (*models.CalendarFeed, error) {
+	var feed models.CalendarFeed
+	err := r.db.Where("user_id = ?", userID).First(&feed).Error
+	if err != nil {
+		return nil, err
+	}
+	return &feed, nil
+}
+
+func (r *calendarRepository) FindFeedByToken(token string) (*models.CalendarFeed, error) {
+	var feed models.CalendarFeed
+	err := r.db.Where("token = ?", token).First(&feed).Error
+	if err != nil {
+		return nil, err
+	}
+	return &feed, nil
+}
+
+func (r *calendarRepository) SaveFeed(feed *models.CalendarFeed) error {
+	return r.db.Save(feed).Error
+}
+
+func (r *calendarRepository) DeleteFeed(id uint) error {
+	return r.db.Delete(&models.CalendarFeed{}, id).Error
+}
+
+func (r *calendarRepository) TouchFeed(id uint, at time.Time) error {
+	return r.db.Model(&models.CalendarFeed{}).Where("id = ?", id).Update("last_fetched_at", at).Error
+}
+
+func (r *calendarRepository) FindFeedTimeLogs(userID uint, since time.Time, limit int) ([]models.TimeLog, error) {
+	var logs []models.TimeLog
+	err := r.db.
+		Where("user_id = ? AND status = ? AND end_time IS NOT NULL AND start_time >= ?", userID, "stopped", since).
+		Preload("Workspace").
+		Order("start_time DESC").
+		Limit(limit).
+		Find(&logs).Error
+	return logs, err
+}
+
+func (r *calendarRepository) FindGoogleByUser(userID uint) (*models.GoogleCalendarConnection, error) {
+	var conn models.GoogleCalendarConnection
+	err := r.db.Where("user_id = ?", userID).First(&conn).Error
+	if err != nil {
+		return nil, err
+	}
+	return &conn, nil
+}
+
+func (r *calendarRepository) FindActiveGoogle() ([]models.GoogleCalendarConnection, error) {
+	var conns []models.GoogleCalendarConnection
+	err := r.db.Where("is_active = true").Order("id ASC").Find(&conns).Error
+	return conns, err
+}
+
+func (r *calendarRepository) SaveGoogle(conn *models.GoogleCalendarConnection) error {
+	return r.db.Save(conn).Error
+}
+
+// DeleteGoogle removes the connection and its pushed event records; the
+// events stay in the user's calendar
+func (r *calendarRepository) DeleteGoogle(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("connection_id = ?", id).Delete(&models.GoogleCalendarEvent{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.GoogleCalendarConnection{}, id).Error
+	})
+}
+
+// SetGooglePushResult records the outcome of the latest push; a successful
+// push clears the last error
+func (r *calendarRepository) SetGooglePushResult(id uint, at time.Time, pushErr string) error {
+	updates := map[string]interface{}{"last_error": pushErr}
+	if pushErr == "" {
+		updates["last_pushed_at"] = at
+	}
+	return r.db.Model(&models.GoogleCalendarConnection{}).Where("id = ?", id).Updates(updates).Error
+}
+
+func (r *calendarRepository) FindPendingGoogleTimeLogs(conn *models.GoogleCalendarConnection, limit int) ([]models.TimeLog, error) {
+	var logs []models.TimeLog
+	err := r.db.
+		Where("time_logs.user_id = ? AND time_logs.status = ? AND time_logs.end_time IS NOT NULL", conn.UserID, "stopped").
+		Where("time_logs.start_time >= ?", conn.PushSince).
+		Where("NOT EXISTS (SELECT 1 FROM google_calendar_events WHERE google_calendar_events.connection_id = ? AND google_calendar_events.time_log_id = time_logs.id)", conn.ID).
+		Preload("Workspace").
+		Order("time_logs.start_time ASC").
+		Limit(limit).
+		Find(&logs).Error
+	return logs, err
+}
+
+func (r *calendarRepository) RecordGoogleEvent(event *models.GoogleCalendarEvent) error {
+	return r.db.Create(event).Error
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// CaptureExclusionRepository handles capture exclusion rule data operations
+type CaptureExclusionRepository interface {
+	Create(rule *models.CaptureExclusionRule) error
+	FindByID(orgID, id uint) (*models.CaptureExclusionRule, error)
+	FindByOrg(orgID uint) ([]models.CaptureExclusionRule, error)
+	FindActiveByOrg(orgID uint) ([]models.CaptureExclusionRule, error)
+	Update(rule *models.CaptureExclusionRule) error
+	Delete(id uint) error
+}
+
+type captureExclusionRepository struct {
+	db *gorm.DB
+}
+
+// NewCaptureExclusionRepository creates a new capture exclusion rule repository
+func NewCaptureExclusionRepository(db *gorm.DB) CaptureExclusionRepository {
+	return &captureExclusionRepository{db: db}
+}
+
+func (r *captureExclusionRepository) Create(rule *models.CaptureExclusionRule) error {
+	return r.db.Create(rule).Error
+}
+
+// FindByID finds a rule scoped to its organization
+func (r *captureExclusionRepository) FindByID(orgID, id uint) (*models.CaptureExclusionRule, error) {
+	var rule models.CaptureExclusionRule
+	err := r.db.Where("id = ? AND organization_id = ?", id, orgID).First(&rule).Error
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *captureExclusionRepository) FindByOrg(orgID uint) ([]models.CaptureExclusionRule, error) {
+	var rules []models.CaptureExclusionRule
+	err := r.db.Where("organization_id = ?", orgID).Order("created_at ASC").Find(&rules).Error
+	return rules, err
+}
+
+func (r *captureExclusionRepository) FindActiveByOrg(orgID uint) ([]models.CaptureExclusionRule, error) {
+	var rules []models.CaptureExclusionRule
+	err := r.db.Where("organization_id = ? AND is_active = true", orgID).Order("created_at ASC").Find(&rules).Error
+	return rules, err
+}
+
+func (r *captureExclusionRepository) Update(rule *models.CaptureExclusionRule) error {
+	return r.db.Save(rule).Error
+}
+
+func (r *captureExclusionRepository) Delete(id uint) error {
+	return r.db.Delete(&models.CaptureExclusionRule{}, id).Error
+}
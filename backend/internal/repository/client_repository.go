@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// ClientRepository handles client data operations
+type ClientRepository interface {
+	Create(client *models.Client) error
+	FindByID(id uint) (*models.Client, error)
+	// FindByIDWithWorkspaces loads the client with its workspaces by name
+	FindByIDWithWorkspaces(id uint) (*models.Client, error)
+	// FindByOrg lists the organization's clients by name
+	FindByOrg(orgID uint) ([]models.Client, error)
+	NameExistsInOrg(orgID uint, name string, excludeID uint) (bool, error)
+	Update(client *models.Client) error
+	// Delete soft deletes the client; its workspaces no longer have a client
+	Delete(id uint) error
+
+	// GetWorkspaceCounts counts the workspaces of each client of the organization
+	GetWorkspaceCounts(orgID uint) (map[uint]int64, error)
+}
+
+type clientRepository struct {
+	db *gorm.DB
+}
+
+// NewClientRepository creates a new client repository
+func NewClientRepository(db *gorm.DB) ClientRepository {
+	return &clientRepository{db: db}
+}
+
+func (r *clientRepository) Create(client *models.Client) error {
+	return r.db.Create(client).Error
+}
+
+func (r *clientRepository) FindByID(id uint) (*models.Client, error) {
+	var client models.Client
+	if err := r.db.First(&client, id).Error; err != nil {
+		return nil, err
+	}
+	return &client, nil
+}
+
+func (r *clientRepository) FindByIDWithWorkspaces(id uint) (*models.Client, error) {
+	var client models.Client
+	err := r.db.Preload("Workspaces", func(db *gorm.DB) *gorm.DB {
+		return db.Order("name ASC")
+	}).First(&client, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &client, nil
+}
+
+func (r *clientRepository) FindByOrg(orgID uint) ([]models.Client, error) {
+	var clients []models.Client
+	err := r.db.Where("organization_id = ?", orgID).
+		Order("name ASC").
+		Find(&clients).Error
+	return clients, err
+}
+
+func (r *clientRepository) NameExistsInOrg(orgID uint, name string, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.Client{}).
+		Where("organization_id = ? AND LOWER(name) = ? AND id != ?", orgID, strings.ToLower(name), excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *clientRepository) Update(client *models.Client) error {
+	return r.db.Save(client).Error
+}
+
+func (r *clientRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Workspace{}).Where("client_id = ?", id).Update("client_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Client{}, id).Error
+	})
+}
+
+func (r *clientRepository) GetWorkspaceCounts(orgID uint) (map[uint]int64, error) {
+	var rows []struct {
+		ClientID uint
+		Count    int64
+	}
+	err := r.db.Model(&models.Workspace{}).
+		Select("client_id, COUNT(*) AS count").
+		Where("organization_id = ? AND client_id IS NOT NULL", orgID).
+		Group("client_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uint]int64, len(rows))
+	for _, row := range rows {
+		counts[row.ClientID] = row.Count
+	}
+	return counts, nil
+}
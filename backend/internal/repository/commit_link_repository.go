@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CommitLinkRepository handles workspace VCS repositories and the commits
+// linked to time logs
+type CommitLinkRepository interface {
+	// Workspace repositories
+	CreateRepository(repo *models.VCSRepository) error
+	FindRepository(id uint) (*models.VCSRepository, error)
+	FindRepositoriesByWorkspace(workspaceID uint) ([]models.VCSRepository, error)
+	ClearDefaultRepository(workspaceID uint) error
+	DeleteRepository(id uint) error
+
+	// Links; creating a link that already exists is a no-op
+	CreateLinks(links []models.TimeLogCommit) error
+	FindLink(timeLogID, id uint) (*models.TimeLogCommit, error)
+	FindByTimeLog(timeLogID uint) ([]models.TimeLogCommit, error)
+	FindByTask(taskID uint) ([]models.TimeLogCommit, error)
+	DeleteLink(id uint) error
+
+	// FindTimeLogAt finds the user's time log in the workspace that was being
+	// tracked at the given moment
+	FindTimeLogAt(userID, workspaceID uint, at time.Time) (*models.TimeLog, error)
+}
+
+type commitLinkRepository struct {
+	db *gorm.DB
+}
+
+// NewCommitLinkRepository creates a new commit link repository
+func NewCommitLinkRepository(db *gorm.DB) CommitLinkRepository {
+	return &commitLinkRepository{db: db}
+}
+
+func (r *commitLinkRepository) CreateRepository(repo *models.VCSRepository) error {
+	return r.db.Create(repo).Error
+}
+
+func (r *commitLinkRepository) FindRepository(id uint) (*models.VCSRepository, error) {
+	var repo models.VCSRepository
+	err := r.db.First(&repo, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &repo, nil
+}
+
+func (r *commitLinkRepository) FindRepositoriesByWorkspace(workspaceID uint) ([]models.VCSRepository, error) {
+	var repos []models.VCSRepository
+	err := r.db.Where("workspace_id = ?", workspaceID).Order("id ASC").Find(&repos).Error
+	return repos, err
+}
+
+func (r *commitLinkRepository) ClearDefaultRepository(workspaceID uint) error {
+	return r.db.Model(&models.VCSRepository{}).
+		Where("workspace_id = ? AND is_default = true", workspaceID).
+		Update("is_default", false).Error
+}
+
+func (r *commitLinkRepository) DeleteRepository(id uint) error {
+	return r.db.Delete(&models.VCSRepository{}, id).Error
+}
+
+func (r *commitLinkRepository) CreateLinks(links []models.TimeLogCommit) error {
+	if len(links) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&links).Error
+}
+
+// FindLink finds a link scoped to its time log
+func (r *commitLinkRepository) FindLink(timeLogID, id uint) (*models.TimeLogCommit, error) {
+	var link models.TimeLogCommit
+	err := r.db.Where("id = ? AND time_log_id = ?", id, timeLogID).First(&link).Error
+	if err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+func (r *commitLinkRepository) FindByTimeLog(timeLogID uint) ([]models.TimeLogCommit, error) {
+	var links []models.TimeLogCommit
+	err := r.db.Where("time_log_id = ?", timeLogID).Order("created_at ASC, id ASC").Find(&links).Error
+	return links, err
+}
+
+func (r *commitLinkRepository) FindByTask(taskID uint) ([]models.TimeLogCommit, error) {
+	var links []models.TimeLogCommit
+	err := r.db.Where("task_id = ?", taskID).Order("created_at ASC, id ASC").Find(&links).Error
+	return links, err
+}
+
+func (r *commitLinkRepository) DeleteLink(id uint) error {
+	return r.db.Delete(&models.TimeLogCommit{}, id).Error
+}
+
+func (r *commitLinkRepository) FindTimeLogAt(userID, workspaceID uint, at time.Time) (*models.TimeLog, error) {
+	var timeLog models.TimeLog
+	err := r.db.
+		Where("user_id = ? AND workspace_id = ?", userID, workspaceID).
+		Where("start_time <= ? AND (end_time >= ? OR (end_time IS NULL AND status <> ?))", at, at, "stopped").
+		Order("start_time DESC").
+		First(&timeLog).Error
+	if err != nil {
+		return nil, err
+	}
+	return &timeLog, nil
+}
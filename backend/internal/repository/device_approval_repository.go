@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DeviceApprovalRepository handles device approval data operations
+type DeviceApprovalRepository interface {
+	// FindOrCreate returns the device's approval in the organization,
+	// creating a pending one on its first sync there
+	FindOrCreate(orgID, deviceID, userID uint) (*models.DeviceApproval, error)
+	FindByID(id uint) (*models.DeviceApproval, error)
+	FindByOrg(orgID uint, params *dto.DeviceApprovalListParams) ([]models.DeviceApproval, int64, error)
+	Update(approval *models.DeviceApproval) error
+}
+
+type deviceApprovalRepository struct {
+	db *gorm.DB
+}
+
+// NewDeviceApprovalRepository creates a new device approval repository
+func NewDeviceApprovalRepository(db *gorm.DB) DeviceApprovalRepository {
+	return &deviceApprovalRepository{db: db}
+}
+
+func (r *deviceApprovalRepository) FindOrCreate(orgID, deviceID, userID uint) (*models.DeviceApproval, error) {
+	approval := &models.DeviceApproval{
+		OrganizationID: orgID,
+		DeviceID:       deviceID,
+		UserID:         userID,
+		Status:         models.DeviceApprovalPending,
+	}
+	// Concurrent syncs from the same device must not create two approvals
+	err := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(approval).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var existing models.DeviceApproval
+	err = r.db.Where("organization_id = ? AND device_id = ?", orgID, deviceID).First(&existing).Error
+	if err != nil {
+		return nil, err
+	}
+	return &existing, nil
+}
+
+func (r *deviceApprovalRepository) FindByID(id uint) (*models.DeviceApproval, error) {
+	var approval models.DeviceApproval
+	err := r.db.Preload("Device").Preload("User").Preload("Reviewer").First(&approval, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &approval, nil
+}
+
+// FindByOrg lists an organization's device approvals, newest first
+func (r *deviceApprovalRepository) FindByOrg(orgID uint, params *dto.DeviceApprovalListParams) ([]models.DeviceApproval, int64, error) {
+	var approvals []models.DeviceApproval
+	var total int64
+
+	query := r.db.Model(&models.DeviceApproval{}).Where("organization_id = ?", orgID)
+	if params.Status != "" {
+		query = query.Where("status = ?", params.Status)
+	}
+	if params.UserID != nil {
+		query = query.Where("user_id = ?", *params.UserID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (params.Page - 1) * params.PerPage
+	err := query.Preload("Device").
+		Preload("User").
+		Preload("Reviewer").
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(params.PerPage).
+		Find(&approvals).Error
+	return approvals, total, err
+}
+
+func (r *deviceApprovalRepository) Update(approval *models.DeviceApproval) error {
+	return r.db.Model(approval).Select(
+		"status",
+		"reviewed_by",
+		"reviewed_at",
+		"review_note",
+		"updated_at",
+	).Updates(approval).Error
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// DeviceLogRepository handles desktop app log bundle data operations
+type DeviceLogRepository interface {
+	Create(bundle *models.DeviceLogBundle) error
+	FindByID(id uint) (*models.DeviceLogBundle, error)
+	FindWithFilters(params *dto.AdminDeviceLogListParams) ([]models.DeviceLogBundle, int64, error)
+	FindByDeviceIDBeyond(deviceID uint, keep int) ([]models.DeviceLogBundle, error)
+	FindExpired(now time.Time) ([]models.DeviceLogBundle, error)
+	Delete(id uint) error
+	// FindUnder returns bundles whose file is stored under dir
+	FindUnder(dir string) ([]models.DeviceLogBundle, error)
+	UpdatePath(id uint, filePath string) error
+}
+
+type deviceLogRepository struct {
+	db *gorm.DB
+}
+
+// NewDeviceLogRepository creates a new device log repository
+func NewDeviceLogRepository(db *gorm.DB) DeviceLogRepository {
+	return &deviceLogRepository{db: db}
+}
+
+func (r *deviceLogRepository) Create(bundle *models.DeviceLogBundle) error {
+	return r.db.Create(bundle).Error
+}
+
+func (r *deviceLogRepository) FindByID(id uint) (*models.DeviceLogBundle, error) {
+	var bundle models.DeviceLogBundle
+	if err := r.db.Preload("User").Preload("Device").First(&bundle, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NotFound("log bundle not found")
+		}
+		return nil, err
+	}
+	return &bundle, nil
+}
+
+func (r *deviceLogRepository) FindWithFilters(params *dto.AdminDeviceLogListParams) ([]models.DeviceLogBundle, int64, error) {
+	var bundles []models.DeviceLogBundle
+	var total int64
+
+	query := r.db.Model(&models.DeviceLogBundle{})
+
+	if params.UserID != nil {
+		query = query.Where("user_id = ?", *params.UserID)
+	}
+
+	if params.DeviceID != nil {
+		query = query.Where("device_id = ?", *params.DeviceID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 || params.PageSize > 100 {
+		params.PageSize = 20
+	}
+
+	err := query.Preload("User").Preload("Device").
+		Order("created_at DESC").
+		Offset((params.Page - 1) * params.PageSize).
+		Limit(params.PageSize).
+		Find(&bundles).Error
+
+	return bundles, total, err
+}
+
+// FindByDeviceIDBeyond returns a device's bundles past the newest keep bundles
+func (r *deviceLogRepository) FindByDeviceIDBeyond(deviceID uint, keep int) ([]models.DeviceLogBundle, error) {
+	var bundles []models.DeviceLogBundle
+	err := r.db.Where("device_id = ?", deviceID).
+		Order("created_at DESC").
+		Offset(keep).
+		Find(&bundles).Error
+	return bundles, err
+}
+
+func (r *deviceLogRepository) FindExpired(now time.Time) ([]models.DeviceLogBundle, error) {
+	var bundles []models.DeviceLogBundle
+	err := r.db.Where("expires_at < ?", now).Find(&bundles).Error
+	return bundles, err
+}
+
+func (r *deviceLogRepository) Delete(id uint) error {
+	return r.db.Delete(&models.DeviceLogBundle{}, id).Error
+}
+
+func (r *deviceLogRepository) FindUnder(dir string) ([]models.DeviceLogBundle, error) {
+	var bundles []models.DeviceLogBundle
+	err := r.db.Where("starts_with(file_path, ?)", dir).Order("id ASC").Find(&bundles).Error
+	return bundles, err
+}
+
+func (r *deviceLogRepository) UpdatePath(id uint, filePath string) error {
+	return r.db.Model(&models.DeviceLogBundle{}).Where("id = ?", id).Update("file_path", filePath).Error
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"errors"
 
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
 	"github.com/beuphecan/remote-time-tracker/internal/models"
 	"gorm.io/gorm"
 )
@@ -35,7 +36,7 @@ func (r *deviceRepository) FindByID(id uint) (*models.DeviceInfo, error) {
 	var device models.DeviceInfo
 	if err := r.db.First(&device, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("device not found")
+			return nil, apperror.NotFound("device not found")
 		}
 		return nil, err
 	}
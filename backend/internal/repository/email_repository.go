@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// EmailSummaryRow is a named duration in a user's email summary
+type EmailSummaryRow struct {
+	Name     string
+	Duration int64 // Seconds
+}
+
+// EmailRepository handles the email outbox and notification preferences
+type EmailRepository interface {
+	// Outbox; attachments are created with the email and loaded by FindDue
+	Enqueue(email *models.EmailOutbox) error
+	FindDue(now time.Time, limit int) ([]models.EmailOutbox, error)
+	Update(email *models.EmailOutbox) error
+	DeleteFinishedBefore(before time.Time) (int64, error)
+	// HasQueued reports whether the template was queued for the user since since
+	HasQueued(userID uint, template string, since time.Time) (bool, error)
+
+	// Preferences; FindPreference returns gorm.ErrRecordNotFound for users
+	// who never changed them
+	FindPreference(userID uint) (*models.NotificationPreference, error)
+	SavePreference(pref *models.NotificationPreference) error
+
+	// Weekly summaries
+	FindWeeklySummaryRecipients(start, end time.Time) ([]models.User, error)
+	WorkspaceTotals(userID uint, start, end time.Time) ([]EmailSummaryRow, error)
+	TopTasks(userID uint, start, end time.Time, limit int) ([]EmailSummaryRow, error)
+}
+
+type emailRepository struct {
+	db *gorm.DB
+}
+
+// NewEmailRepository creates a new email repository
+func NewEmailRepository(db *gorm.DB) EmailRepository {
+	return &emailRepository{db: db}
+}
+
+func (r *emailRepository) Enqueue(email *models.EmailOutbox) error {
+	return r.db.Create(email).Error
+}
+
+func (r *emailRepository) FindDue(now time.Time, limit int) ([]models.EmailOutbox, error) {
+	var emails []models.EmailOutbox
+	err := r.db.
+		Preload("Attachments").
+		Where("status = ? AND next_attempt_at <= ?", models.EmailStatusPending, now).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&emails).Error
+	return emails, err
+}
+
+func (r *emailRepository) Update(email *models.EmailOutbox) error {
+	return r.db.Omit(clause.Associations).Save(email).Error
+}
+
+func (r *emailRepository) DeleteFinishedBefore(before time.Time) (int64, error) {
+	var deleted int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		finished := tx.Model(&models.EmailOutbox{}).Select("id").
+			Where("status IN ? AND updated_at < ?", []string{models.EmailStatusSent, models.EmailStatusFailed}, before)
+		if err := tx.Where("email_id IN (?)", finished).Delete(&models.EmailAttachment{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.
+			Where("status IN ? AND updated_at < ?", []string{models.EmailStatusSent, models.EmailStatusFailed}, before).
+			Delete(&models.EmailOutbox{})
+		deleted = result.RowsAffected
+		return result.Error
+	})
+	return deleted, err
+}
+
+func (r *emailRepository) HasQueued(userID uint, template string, since time.Time) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.EmailOutbox{}).
+		Where("user_id = ? AND template = ? AND created_at >= ?", userID, template, since).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *emailRepository) FindPreference(userID uint) (*models.NotificationPreference, error) {
+	var pref models.NotificationPreference
+	err := r.db.Where("user_id = ?", userID).First(&pref).Error
+	if err != nil {
+		return nil, err
+	}
+	return &pref, nil
+}
+
+func (r *emailRepository) SavePreference(pref *models.NotificationPreference) error {
+	return r.db.Save(pref).Error
+}
+
+// FindWeeklySummaryRecipients finds active users who tracked time in
+// [start, end) and have not opted out of weekly summaries
+func (r *emailRepository) FindWeeklySummaryRecipients(start, end time.Time) ([]models.User, error) {
+	var users []models.User
+	err := r.db.
+		Joins("LEFT JOIN notification_preferences ON notification_preferences.user_id = users.id").
+		Where("users.is_active = true AND users.anonymized_at IS NULL").
+		Where("notification_preferences.id IS NULL OR (notification_preferences.email_enabled AND notification_preferences.email_weekly_summary)").
+		Where(`EXISTS (
+			SELECT 1 FROM time_logs
+			WHERE time_logs.user_id = users.id AND time_logs.deleted_at IS NULL
+				AND time_logs.start_time >= ? AND time_logs.start_time < ?
+		)`, start, end).
+		Order("users.id ASC").
+		Find(&users).Error
+	return users, err
+}
+
+func (r *emailRepository) WorkspaceTotals(userID uint, start, end time.Time) ([]EmailSummaryRow, error) {
+	var rows []EmailSummaryRow
+	err := r.db.Raw(`
+		SELECT COALESCE(workspaces.name, 'No workspace') as name, SUM(time_logs.duration) as duration
+		FROM time_logs
+		LEFT JOIN workspaces ON workspaces.id = time_logs.workspace_id
+		WHERE time_logs.user_id = ? AND time_logs.deleted_at IS NULL
+			AND time_logs.start_time >= ? AND time_logs.start_time < ?
+		GROUP BY workspaces.name
+		ORDER BY duration DESC
+	`, userID, start, end).Scan(&rows).Error
+	return rows, err
+}
+
+func (r *emailRepository) TopTasks(userID uint, start, end time.Time, limit int) ([]EmailSummaryRow, error) {
+	var rows []EmailSummaryRow
+	err := r.db.Raw(`
+		SELECT COALESCE(NULLIF(tasks.title, ''), NULLIF(time_logs.task_title, ''), 'Untitled') as name,
+			SUM(time_logs.duration) as duration
+		FROM time_logs
+		LEFT JOIN tasks ON tasks.id = time_logs.task_id
+		WHERE time_logs.user_id = ? AND time_logs.deleted_at IS NULL
+			AND time_logs.start_time >= ? AND time_logs.start_time < ?
+		GROUP BY 1
+		ORDER BY duration DESC
+		LIMIT ?
+	`, userID, start, end, limit).Scan(&rows).Error
+	return rows, err
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// EncryptionKeyRepository handles screenshot encryption key data operations
+type EncryptionKeyRepository interface {
+	// Activate stores a new active key and retires the organization's other keys
+	Activate(key *models.ScreenshotEncryptionKey) error
+	FindByID(orgID, id uint) (*models.ScreenshotEncryptionKey, error)
+	FindByOrg(orgID uint) ([]models.ScreenshotEncryptionKey, error)
+	FindByFingerprint(orgID uint, fingerprint string) (*models.ScreenshotEncryptionKey, error)
+
+	// Screenshot envelopes
+	FindScreenshotsByFingerprint(orgID uint, fingerprint string, limit int) ([]models.Screenshot, error)
+	UpdateScreenshotKey(orgID, screenshotID uint, fingerprint, wrappedKey string) (bool, error)
+}
+
+type encryptionKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewEncryptionKeyRepository creates a new screenshot encryption key repository
+func NewEncryptionKeyRepository(db *gorm.DB) EncryptionKeyRepository {
+	return &encryptionKeyRepository{db: db}
+}
+
+func (r *encryptionKeyRepository) Activate(key *models.ScreenshotEncryptionKey) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.ScreenshotEncryptionKey{}).
+			Where("organization_id = ? AND status = ?", key.OrganizationID, models.EncryptionKeyActive).
+			Updates(map[string]interface{}{
+				"status":     models.EncryptionKeyRetired,
+				"retired_at": time.Now(),
+			}).Error
+		if err != nil {
+			return err
+		}
+		key.Status = models.EncryptionKeyActive
+		return tx.Create(key).Error
+	})
+}
+
+// FindByID finds a key scoped to its organization
+func (r *encryptionKeyRepository) FindByID(orgID, id uint) (*models.ScreenshotEncryptionKey, error) {
+	var key models.ScreenshotEncryptionKey
+	err := r.db.Where("id = ? AND organization_id = ?", id, orgID).First(&key).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// FindByOrg lists the organization's keys, newest first
+func (r *encryptionKeyRepository) FindByOrg(orgID uint) ([]models.ScreenshotEncryptionKey, error) {
+	var keys []models.ScreenshotEncryptionKey
+	err := r.db.Where("organization_id = ?", orgID).Order("created_at DESC").Find(&keys).Error
+	return keys, err
+}
+
+func (r *encryptionKeyRepository) FindByFingerprint(orgID uint, fingerprint string) (*models.ScreenshotEncryptionKey, error) {
+	var key models.ScreenshotEncryptionKey
+	err := r.db.Where("organization_id = ? AND fingerprint = ?", orgID, fingerprint).First(&key).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// FindScreenshotsByFingerprint returns encrypted screenshots whose content key
+// is wrapped with the given key, oldest first
+func (r *encryptionKeyRepository) FindScreenshotsByFingerprint(orgID uint, fingerprint string, limit int) ([]models.Screenshot, error) {
+	var screenshots []models.Screenshot
+	err := r.db.
+		Where("organization_id = ? AND is_encrypted = true AND key_fingerprint = ?", orgID, fingerprint).
+		Order("id ASC").
+		Limit(limit).
+		Find(&screenshots).Error
+	return screenshots, err
+}
+
+// UpdateScreenshotKey replaces the wrapped content key of an encrypted
+// screenshot of the organization; it reports whether one was updated
+func (r *encryptionKeyRepository) UpdateScreenshotKey(orgID, screenshotID uint, fingerprint, wrappedKey string) (bool, error) {
+	result := r.db.Model(&models.Screenshot{}).
+		Where("id = ? AND organization_id = ? AND is_encrypted = true", screenshotID, orgID).
+		Updates(map[string]interface{}{
+			"key_fingerprint": fingerprint,
+			"wrapped_key":     wrappedKey,
+		})
+	return result.RowsAffected > 0, result.Error
+}
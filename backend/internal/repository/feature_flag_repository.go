@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FeatureFlagRepository handles feature flag data operations
+type FeatureFlagRepository interface {
+	// FindAll lists the flags with their overrides, by key
+	FindAll() ([]models.FeatureFlag, error)
+	FindByID(id uint) (*models.FeatureFlag, error)
+	FindByKey(key string) (*models.FeatureFlag, error)
+	Create(flag *models.FeatureFlag) error
+	Update(flag *models.FeatureFlag) error
+	// Delete removes the flag and its overrides
+	Delete(id uint) error
+
+	// FindOverridesFor lists the overrides of any flag for the organization
+	// or the user; zero skips either
+	FindOverridesFor(orgID, userID uint) ([]models.FeatureFlagOverride, error)
+	// UpsertOverride creates the override of its flag and target or replaces
+	// the existing one
+	UpsertOverride(override *models.FeatureFlagOverride) error
+	DeleteOverride(flagID, overrideID uint) (int64, error)
+}
+
+type featureFlagRepository struct {
+	db *gorm.DB
+}
+
+// NewFeatureFlagRepository creates a new feature flag repository
+func NewFeatureFlagRepository(db *gorm.DB) FeatureFlagRepository {
+	return &featureFlagRepository{db: db}
+}
+
+func (r *featureFlagRepository) FindAll() ([]models.FeatureFlag, error) {
+	var flags []models.FeatureFlag
+	err := r.db.Preload("Overrides").Order("key ASC").Find(&flags).Error
+	return flags, err
+}
+
+func (r *featureFlagRepository) FindByID(id uint) (*models.FeatureFlag, error) {
+	var flag models.FeatureFlag
+	err := r.db.Preload("Overrides").First(&flag, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &flag, nil
+}
+
+func (r *featureFlagRepository) FindByKey(key string) (*models.FeatureFlag, error) {
+	var flag models.FeatureFlag
+	err := r.db.Where("key = ?", key).First(&flag).Error
+	if err != nil {
+		return nil, err
+	}
+	return &flag, nil
+}
+
+func (r *featureFlagRepository) Create(flag *models.FeatureFlag) error {
+	return r.db.Create(flag).Error
+}
+
+func (r *featureFlagRepository) Update(flag *models.FeatureFlag) error {
+	return r.db.Model(flag).Select(
+		"description",
+		"enabled",
+		"rollout_percent",
+		"updated_at",
+	).Updates(flag).Error
+}
+
+func (r *featureFlagRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("flag_id = ?", id).Delete(&models.FeatureFlagOverride{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.FeatureFlag{}, id).Error
+	})
+}
+
+func (r *featureFlagRepository) FindOverridesFor(orgID, userID uint) ([]models.FeatureFlagOverride, error) {
+	var overrides []models.FeatureFlagOverride
+	err := r.db.Where("(organization_id = ? AND organization_id != 0 AND user_id = 0) OR (user_id = ? AND user_id != 0 AND organization_id = 0)", orgID, userID).
+		Find(&overrides).Error
+	return overrides, err
+}
+
+func (r *featureFlagRepository) UpsertOverride(override *models.FeatureFlagOverride) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "flag_id"}, {Name: "organization_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"enabled", "created_by", "updated_at"}),
+	}).Create(override).Error
+}
+
+func (r *featureFlagRepository) DeleteOverride(flagID, overrideID uint) (int64, error) {
+	result := r.db.Where("id = ? AND flag_id = ?", overrideID, flagID).Delete(&models.FeatureFlagOverride{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// HandoffResult lists the records moved by a work handoff
+type HandoffResult struct {
+	TaskIDs          []uint
+	TimeLogIDs       []uint
+	TrackedSeconds   int64
+	ScreenshotsMoved int64
+}
+
+// HandoffRepository moves a member's pending work to another member
+type HandoffRepository interface {
+	TransferWork(orgID, fromUserID, toUserID uint) (*HandoffResult, error)
+}
+
+type handoffRepository struct {
+	db *gorm.DB
+}
+
+// NewHandoffRepository creates a new handoff repository
+func NewHandoffRepository(db *gorm.DB) HandoffRepository {
+	return &handoffRepository{db: db}
+}
+
+// TransferWork reassigns the user's active tasks and stopped, unapproved time
+// logs in the organization in a single transaction. Screenshots follow their
+// time logs so the new owner's reports stay consistent. Running and paused
+// time logs are left alone; stop them first.
+func (r *handoffRepository) TransferWork(orgID, fromUserID, toUserID uint) (*HandoffResult, error) {
+	result := &HandoffResult{TaskIDs: []uint{}, TimeLogIDs: []uint{}}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Task{}).
+			Where("organization_id = ? AND user_id = ? AND status = ?", orgID, fromUserID, "active").
+			Order("id").
+			Pluck("id", &result.TaskIDs).Error; err != nil {
+			return err
+		}
+		if len(result.TaskIDs) > 0 {
+			if err := tx.Model(&models.Task{}).
+				Where("id IN ?", result.TaskIDs).
+				Update("user_id", toUserID).Error; err != nil {
+				return err
+			}
+		}
+
+		var timeLogs []models.TimeLog
+		if err := tx.Select("id", "duration").
+			Where("organization_id = ? AND user_id = ? AND status = ? AND is_approved = ?", orgID, fromUserID, "stopped", false).
+			Order("id").
+			Find(&timeLogs).Error; err != nil {
+			return err
+		}
+		if len(timeLogs) == 0 {
+			return nil
+		}
+		for _, tl := range timeLogs {
+			result.TimeLogIDs = append(result.TimeLogIDs, tl.ID)
+			result.TrackedSeconds += tl.Duration
+		}
+
+		if err := tx.Model(&models.TimeLog{}).
+			Where("id IN ?", result.TimeLogIDs).
+			Update("user_id", toUserID).Error; err != nil {
+			return err
+		}
+
+		moved := tx.Model(&models.Screenshot{}).
+			Where("time_log_id IN ? AND user_id = ?", result.TimeLogIDs, fromUserID).
+			Update("user_id", toUserID)
+		if moved.Error != nil {
+			return moved.Error
+		}
+		result.ScreenshotsMoved = moved.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
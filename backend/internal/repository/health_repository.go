@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// HealthRepository checks database connectivity for readiness probes
+type HealthRepository interface {
+	Ping(ctx context.Context) error
+}
+
+type healthRepository struct {
+	db *gorm.DB
+}
+
+// NewHealthRepository creates a new health repository
+func NewHealthRepository(db *gorm.DB) HealthRepository {
+	return &healthRepository{db: db}
+}
+
+// Ping verifies a database connection can be used
+func (r *healthRepository) Ping(ctx context.Context) error {
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// HolidayRepository handles organization holiday data operations
+type HolidayRepository interface {
+	Create(holiday *models.OrganizationHoliday) error
+	// CreateMissing inserts the holidays whose dates the organization has no
+	// holiday for yet and returns the inserted ones
+	CreateMissing(holidays []models.OrganizationHoliday) ([]models.OrganizationHoliday, error)
+	FindByID(id uint) (*models.OrganizationHoliday, error)
+	FindByOrg(orgID uint, startDate, endDate time.Time) ([]models.OrganizationHoliday, error)
+	Delete(id uint) error
+
+	// FindDates returns every holiday date of the organization
+	FindDates(orgID uint) ([]time.Time, error)
+	// FindDatesForUsers returns the holiday dates in the inclusive range of
+	// every organization each user is an active member of
+	FindDatesForUsers(userIDs []uint, startDate, endDate time.Time) (map[uint][]time.Time, error)
+}
+
+type holidayRepository struct {
+	db *gorm.DB
+}
+
+// NewHolidayRepository creates a new organization holiday repository
+func NewHolidayRepository(db *gorm.DB) HolidayRepository {
+	return &holidayRepository{db: db}
+}
+
+func (r *holidayRepository) Create(holiday *models.OrganizationHoliday) error {
+	return r.db.Create(holiday).Error
+}
+
+func (r *holidayRepository) CreateMissing(holidays []models.OrganizationHoliday) ([]models.OrganizationHoliday, error) {
+	created := make([]models.OrganizationHoliday, 0, len(holidays))
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for i := range holidays {
+			result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&holidays[i])
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected > 0 {
+				created = append(created, holidays[i])
+			}
+		}
+		return nil
+	})
+	return created, err
+}
+
+func (r *holidayRepository) FindByID(id uint) (*models.OrganizationHoliday, error) {
+	var holiday models.OrganizationHoliday
+	if err := r.db.First(&holiday, id).Error; err != nil {
+		return nil, err
+	}
+	return &holiday, nil
+}
+
+// FindByOrg lists the organization's holidays in the inclusive range by date
+func (r *holidayRepository) FindByOrg(orgID uint, startDate, endDate time.Time) ([]models.OrganizationHoliday, error) {
+	var holidays []models.OrganizationHoliday
+	err := r.db.Where("organization_id = ? AND date >= ? AND date <= ?", orgID, startDate, endDate).
+		Order("date").
+		Find(&holidays).Error
+	return holidays, err
+}
+
+func (r *holidayRepository) Delete(id uint) error {
+	return r.db.Delete(&models.OrganizationHoliday{}, id).Error
+}
+
+func (r *holidayRepository) FindDates(orgID uint) ([]time.Time, error) {
+	var dates []time.Time
+	err := r.db.Model(&models.OrganizationHoliday{}).
+		Where("organization_id = ?", orgID).
+		Pluck("date", &dates).Error
+	return dates, err
+}
+
+func (r *holidayRepository) FindDatesForUsers(userIDs []uint, startDate, endDate time.Time) (map[uint][]time.Time, error) {
+	dates := make(map[uint][]time.Time)
+	if len(userIDs) == 0 {
+		return dates, nil
+	}
+
+	var rows []struct {
+		UserID uint
+		Date   time.Time
+	}
+	err := r.db.Model(&models.OrganizationHoliday{}).
+		Select("DISTINCT om.user_id, organization_holidays.date").
+		Joins("JOIN organization_members om ON om.organization_id = organization_holidays.organization_id AND om.is_active = true AND om.deleted_at IS NULL").
+		Where("om.user_id IN ? AND organization_holidays.date >= ? AND organization_holidays.date <= ?", userIDs, startDate, endDate).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		dates[row.UserID] = append(dates[row.UserID], row.Date)
+	}
+	return dates, nil
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IdempotencyRepository stores the requests made with an Idempotency-Key
+// header and their responses
+type IdempotencyRepository interface {
+	// Reserve records a new key; false means the user already has a record
+	// with this key
+	Reserve(record *models.IdempotencyKey) (bool, error)
+	// Find returns the user's record for the key, or nil if there is none
+	Find(userID uint, key string) (*models.IdempotencyKey, error)
+	// Complete stores the response of a reserved key
+	Complete(id uint, statusCode int, contentType string, body []byte) error
+	Delete(id uint) error
+	// DeleteExpired removes records that expired before the cutoff
+	DeleteExpired(before time.Time) (int64, error)
+}
+
+type idempotencyRepository struct {
+	db *gorm.DB
+}
+
+// NewIdempotencyRepository creates a new idempotency key repository
+func NewIdempotencyRepository(db *gorm.DB) IdempotencyRepository {
+	return &idempotencyRepository{db: db}
+}
+
+func (r *idempotencyRepository) Reserve(record *models.IdempotencyKey) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *idempotencyRepository) Find(userID uint, key string) (*models.IdempotencyKey, error) {
+	var record models.IdempotencyKey
+	err := r.db.Where("user_id = ? AND key = ?", userID, key).First(&record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+func (r *idempotencyRepository) Complete(id uint, statusCode int, contentType string, body []byte) error {
+	return r.db.Model(&models.IdempotencyKey{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status_code":   statusCode,
+		"content_type":  contentType,
+		"response_body": body,
+	}).Error
+}
+
+func (r *idempotencyRepository) Delete(id uint) error {
+	return r.db.Delete(&models.IdempotencyKey{}, id).Error
+}
+
+func (r *idempotencyRepository) DeleteExpired(before time.Time) (int64, error) {
+	result := r.db.Where("expires_at < ?", before).Delete(&models.IdempotencyKey{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// ErrTimeLogsAlreadyInvoiced is returned when time logs of a new invoice were
+// invoiced by another request in the meantime
+var ErrTimeLogsAlreadyInvoiced = apperror.Conflict("some time logs were invoiced meanwhile")
+
+// BillableTimeLog is an approved, stopped time log not yet on an invoice
+type BillableTimeLog struct {
+	ID          uint
+	WorkspaceID uint
+	CostCenter  string // The task's, else the workspace's
+	ProjectCode string // The task's, else the workspace's
+	Duration    int64
+}
+
+// BillableFilter selects the time logs an invoice bills
+type BillableFilter struct {
+	OrganizationID uint
+	Start          time.Time // First day of the period
+	End            time.Time // Last day of the period, inclusive
+	ClientID       *uint
+	WorkspaceID    *uint
+}
+
+// InvoiceRepository handles invoice data operations
+type InvoiceRepository interface {
+	// FindBillable lists the uninvoiced approved time logs of billable
+	// workspaces started in the period
+	FindBillable(filter BillableFilter) ([]BillableTimeLog, error)
+	// Create inserts the invoice with its line items and locks the time logs
+	// it bills. ErrTimeLogsAlreadyInvoiced means one of them was invoiced
+	// by another request; nothing is written then.
+	Create(invoice *models.Invoice, timeLogIDs []uint) error
+	FindByID(id uint) (*models.Invoice, error)
+	// FindByOrg lists the organization's invoices, newest first, optionally
+	// with the given status
+	FindByOrg(orgID uint, status string) ([]models.Invoice, error)
+	Update(invoice *models.Invoice) error
+	// Delete deletes the invoice and releases its time logs
+	Delete(id uint) error
+	// NextSequence returns the sequence number of the organization's next
+	// invoice of the year, following its highest number
+	NextSequence(orgID uint, year int) (int, error)
+}
+
+type invoiceRepository struct {
+	db *gorm.DB
+}
+
+// NewInvoiceRepository creates a new invoice repository
+func NewInvoiceRepository(db *gorm.DB) InvoiceRepository {
+	return &invoiceRepository{db: db}
+}
+
+func (r *invoiceRepository) FindBillable(filter BillableFilter) ([]BillableTimeLog, error) {
+	query := r.db.Model(&models.TimeLog{}).
+		Select("time_logs.id, time_logs.workspace_id, time_logs.duration, "+
+			timeLogCostCenterExpr+" AS cost_center, "+timeLogProjectCodeExpr+" AS project_code").
+		Joins("JOIN workspaces ON workspaces.id = time_logs.workspace_id AND workspaces.deleted_at IS NULL").
+		Joins("LEFT JOIN tasks ON tasks.id = time_logs.task_id").
+		Where("time_logs.organization_id = ?", filter.OrganizationID).
+		Where("time_logs.status = ? AND time_logs.is_approved = ? AND time_logs.invoice_id IS NULL", "stopped", true).
+		Where("time_logs.start_time >= ? AND time_logs.start_time < ?", filter.Start, filter.End.AddDate(0, 0, 1)).
+		Where("workspaces.is_billable = ?", true)
+
+	if filter.ClientID != nil {
+		query = query.Where("workspaces.client_id = ?", *filter.ClientID)
+	}
+	if filter.WorkspaceID != nil {
+		query = query.Where("workspaces.id = ?", *filter.WorkspaceID)
+	}
+
+	var rows []BillableTimeLog
+	err := query.Order("time_logs.start_time ASC").Scan(&rows).Error
+	return rows, err
+}
+
+func (r *invoiceRepository) Create(invoice *models.Invoice, timeLogIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(invoice).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&models.TimeLog{}).
+			Where("id IN ? AND invoice_id IS NULL", timeLogIDs).
+			UpdateColumn("invoice_id", invoice.ID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != int64(len(timeLogIDs)) {
+			return ErrTimeLogsAlreadyInvoiced
+		}
+		return nil
+	})
+}
+
+func (r *invoiceRepository) FindByID(id uint) (*models.Invoice, error) {
+	var invoice models.Invoice
+	err := r.db.Preload("LineItems", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).First(&invoice, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invoice, nil
+}
+
+func (r *invoiceRepository) FindByOrg(orgID uint, status string) ([]models.Invoice, error) {
+	query := r.db.Where("organization_id = ?", orgID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var invoices []models.Invoice
+	err := query.Order("created_at DESC, id DESC").Find(&invoices).Error
+	return invoices, err
+}
+
+func (r *invoiceRepository) Update(invoice *models.Invoice) error {
+	return r.db.Omit("LineItems").Save(invoice).Error
+}
+
+func (r *invoiceRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.TimeLog{}).Where("invoice_id = ?", id).UpdateColumn("invoice_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("invoice_id = ?", id).Delete(&models.InvoiceLineItem{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Invoice{}, id).Error
+	})
+}
+
+func (r *invoiceRepository) NextSequence(orgID uint, year int) (int, error) {
+	var last int
+	err := r.db.Model(&models.Invoice{}).
+		Select("COALESCE(MAX(CAST(SUBSTRING(number FROM '[0-9]+$') AS INTEGER)), 0)").
+		Where("organization_id = ? AND number LIKE ?", orgID, fmt.Sprintf("INV-%d-%%", year)).
+		Scan(&last).Error
+	return last + 1, err
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// JiraRepository handles workspace Jira integration data operations
+type JiraRepository interface {
+	Create(integration *models.JiraIntegration) error
+	FindByWorkspace(workspaceID uint) (*models.JiraIntegration, error)
+	FindActive() ([]models.JiraIntegration, error)
+	Update(integration *models.JiraIntegration) error
+	Delete(id uint) error
+	SetSyncResult(id uint, at time.Time, syncErr string) error
+
+	// Issue import
+	FindLinkedTasks(workspaceID uint) ([]models.Task, error)
+	SaveTasks(created, updated []*models.Task) error
+
+	// Worklog push
+	FindPendingWorklogs(integration *models.JiraIntegration, minDuration int64, limit int) ([]models.TimeLog, error)
+	RecordWorklog(worklog *models.JiraWorklog) error
+}
+
+type jiraRepository struct {
+	db *gorm.DB
+}
+
+// NewJiraRepository creates a new Jira integration repository
+func NewJiraRepository(db *gorm.DB) JiraRepository {
+	return &jiraRepository{db: db}
+}
+
+func (r *jiraRepository) Create(integration *models.JiraIntegration) error {
+	return r.db.Create(integration).Error
+}
+
+func (r *jiraRepository) FindByWorkspace(workspaceID uint) (*models.JiraIntegration, error) {
+	var integration models.JiraIntegration
+	err := r.db.Where("workspace_id = ?", workspaceID).First(&integration).Error
+	if err != nil {
+		return nil, err
+	}
+	return &integration, nil
+}
+
+func (r *jiraRepository) FindActive() ([]models.JiraIntegration, error) {
+	var integrations []models.JiraIntegration
+	err := r.db.Where("is_active = true").Order("id ASC").Find(&integrations).Error
+	return integrations, err
+}
+
+func (r *jiraRepository) Update(integration *models.JiraIntegration) error {
+	return r.db.Save(integration).Error
+}
+
+func (r *jiraRepository) Delete(id uint) error {
+	return r.db.Delete(&models.JiraIntegration{}, id).Error
+}
+
+// SetSyncResult records when the integration last synced and why it failed,
+// without touching settings changed while the sync ran
+func (r *jiraRepository) SetSyncResult(id uint, at time.Time, syncErr string) error {
+	return r.db.Model(&models.JiraIntegration{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"last_sync_at":    at,
+			"last_sync_error": syncErr,
+		}).Error
+}
+
+// FindLinkedTasks returns the workspace's tasks that reference an external
+// issue, including deleted ones so their issues are not imported again
+func (r *jiraRepository) FindLinkedTasks(workspaceID uint) ([]models.Task, error) {
+	var tasks []models.Task
+	err := r.db.Unscoped().Where("workspace_id = ? AND external_ref <> ''", workspaceID).Find(&tasks).Error
+	return tasks, err
+}
+
+// SaveTasks creates and updates imported tasks in one transaction
+func (r *jiraRepository) SaveTasks(created, updated []*models.Task) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, task := range created {
+			if err := tx.Omit(clause.Associations).Create(task).Error; err != nil {
+				return err
+			}
+		}
+		for _, task := range updated {
+			err := tx.Model(task).
+				Select("title", "status", "user_id").
+				Updates(task).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// FindPendingWorklogs returns stopped time logs of at least minDuration seconds
+// on the workspace's linked tasks that ended after the integration was
+// connected and have not been pushed yet. Task and User are preloaded.
+func (r *jiraRepository) FindPendingWorklogs(integration *models.JiraIntegration, minDuration int64, limit int) ([]models.TimeLog, error) {
+	var logs []models.TimeLog
+	err := r.db.
+		Joins("JOIN tasks ON tasks.id = time_logs.task_id AND tasks.deleted_at IS NULL").
+		Where("tasks.workspace_id = ? AND tasks.external_ref <> ''", integration.WorkspaceID).
+		Where("time_logs.status = ? AND time_logs.end_time >= ?", "stopped", integration.CreatedAt).
+		Where("time_logs.duration >= ?", minDuration).
+		Where("NOT EXISTS (SELECT 1 FROM jira_worklogs WHERE jira_worklogs.time_log_id = time_logs.id)").
+		Preload("Task").
+		Preload("User").
+		Order("time_logs.end_time ASC").
+		Limit(limit).
+		Find(&logs).Error
+	return logs, err
+}
+
+func (r *jiraRepository) RecordWorklog(worklog *models.JiraWorklog) error {
+	return r.db.Create(worklog).Error
+}
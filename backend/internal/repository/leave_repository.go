@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// LeaveRepository handles leave request data operations
+type LeaveRepository interface {
+	Create(request *models.LeaveRequest) error
+	FindByID(id uint) (*models.LeaveRequest, error)
+	FindByUser(orgID, userID uint, params *dto.LeaveRequestListParams) ([]models.LeaveRequest, int64, error)
+	FindByOrg(orgID uint, workspaceIDs []uint, params *dto.LeaveRequestListParams) ([]models.LeaveRequest, int64, error)
+	Update(request *models.LeaveRequest) error
+
+	// HasOverlap reports whether the user has pending or approved leave in the
+	// organization overlapping the inclusive date range
+	HasOverlap(orgID, userID uint, startDate, endDate time.Time) (bool, error)
+	// FindApprovedInOrg returns approved leave overlapping the inclusive date
+	// range. A non-nil workspaceIDs restricts it to those workspaces' members.
+	FindApprovedInOrg(orgID uint, workspaceIDs []uint, startDate, endDate time.Time) ([]models.LeaveRequest, error)
+	// FindApprovedForUsers returns the users' approved leave in any
+	// organization overlapping the inclusive date range
+	FindApprovedForUsers(userIDs []uint, startDate, endDate time.Time) ([]models.LeaveRequest, error)
+}
+
+type leaveRepository struct {
+	db *gorm.DB
+}
+
+// NewLeaveRepository creates a new leave request repository
+func NewLeaveRepository(db *gorm.DB) LeaveRepository {
+	return &leaveRepository{db: db}
+}
+
+func (r *leaveRepository) Create(request *models.LeaveRequest) error {
+	return r.db.Create(request).Error
+}
+
+func (r *leaveRepository) FindByID(id uint) (*models.LeaveRequest, error) {
+	var request models.LeaveRequest
+	err := r.db.Preload("User").Preload("Reviewer").First(&request, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+// FindByUser lists a user's own requests in an organization, newest first
+func (r *leaveRepository) FindByUser(orgID, userID uint, params *dto.LeaveRequestListParams) ([]models.LeaveRequest, int64, error) {
+	query := r.db.Model(&models.LeaveRequest{}).Where("organization_id = ? AND user_id = ?", orgID, userID)
+	return r.paginate(query, params)
+}
+
+// FindByOrg lists an organization's requests, newest first. A non-nil
+// workspaceIDs restricts the list to those workspaces' members.
+func (r *leaveRepository) FindByOrg(orgID uint, workspaceIDs []uint, params *dto.LeaveRequestListParams) ([]models.LeaveRequest, int64, error) {
+	query := r.inWorkspaces(r.db.Model(&models.LeaveRequest{}).Where("organization_id = ?", orgID), workspaceIDs)
+	if params.UserID != nil {
+		query = query.Where("user_id = ?", *params.UserID)
+	}
+	return r.paginate(query, params)
+}
+
+func (r *leaveRepository) paginate(query *gorm.DB, params *dto.LeaveRequestListParams) ([]models.LeaveRequest, int64, error) {
+	var requests []models.LeaveRequest
+	var total int64
+
+	if params.Status != "" {
+		query = query.Where("status = ?", params.Status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (params.Page - 1) * params.PerPage
+	err := query.Preload("User").
+		Preload("Reviewer").
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(params.PerPage).
+		Find(&requests).Error
+	return requests, total, err
+}
+
+// inWorkspaces restricts a leave request query to the members of the
+// workspaces; nil leaves it unrestricted
+func (r *leaveRepository) inWorkspaces(query *gorm.DB, workspaceIDs []uint) *gorm.DB {
+	if workspaceIDs == nil {
+		return query
+	}
+	return query.Where(
+		"user_id IN (SELECT user_id FROM workspace_members WHERE workspace_id IN ? AND is_active = true AND deleted_at IS NULL)",
+		workspaceIDs,
+	)
+}
+
+func (r *leaveRepository) Update(request *models.LeaveRequest) error {
+	return r.db.Model(request).Select(
+		"status",
+		"reviewed_by",
+		"reviewed_at",
+		"review_note",
+		"updated_at",
+	).Updates(request).Error
+}
+
+func (r *leaveRepository) HasOverlap(orgID, userID uint, startDate, endDate time.Time) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.LeaveRequest{}).
+		Where("organization_id = ? AND user_id = ?", orgID, userID).
+		Where("status IN ?", []string{models.LeaveRequestPending, models.LeaveRequestApproved}).
+		Where("start_date <= ? AND end_date >= ?", endDate, startDate).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *leaveRepository) FindApprovedInOrg(orgID uint, workspaceIDs []uint, startDate, endDate time.Time) ([]models.LeaveRequest, error) {
+	var requests []models.LeaveRequest
+	query := r.inWorkspaces(r.db.Where("organization_id = ?", orgID), workspaceIDs)
+	err := query.Preload("User").
+		Where("status = ?", models.LeaveRequestApproved).
+		Where("start_date <= ? AND end_date >= ?", endDate, startDate).
+		Order("start_date, user_id").
+		Find(&requests).Error
+	return requests, err
+}
+
+func (r *leaveRepository) FindApprovedForUsers(userIDs []uint, startDate, endDate time.Time) ([]models.LeaveRequest, error) {
+	var requests []models.LeaveRequest
+	if len(userIDs) == 0 {
+		return requests, nil
+	}
+	err := r.db.Where("user_id IN ? AND status = ?", userIDs, models.LeaveRequestApproved).
+		Where("start_date <= ? AND end_date >= ?", endDate, startDate).
+		Find(&requests).Error
+	return requests, err
+}
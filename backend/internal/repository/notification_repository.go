@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// NotificationRecipient is a user to notify about some of their time logs
+type NotificationRecipient struct {
+	UserID uint
+	Count  int
+}
+
+// NotificationRepository handles in-app notifications
+type NotificationRepository interface {
+	CreateBatch(notifications []models.Notification) error
+	FindByUser(userID uint, params *dto.NotificationListParams) ([]models.Notification, int64, error)
+	CountUnread(userID uint) (int64, error)
+	// MarkRead marks one of the user's notifications read; false when it
+	// does not exist or belongs to someone else
+	MarkRead(userID, id uint, at time.Time) (bool, error)
+	MarkAllRead(userID uint, at time.Time) (int64, error)
+	DeleteBefore(before time.Time) (int64, error)
+
+	// Recipients
+	FindTimeLogOwners(timeLogIDs []uint) ([]NotificationRecipient, error)
+	FindWorkspaceMemberIDs(workspaceID uint) ([]uint, error)
+	FindWorkspaceAdminIDs(workspaceID uint) ([]uint, error)
+}
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepository creates a new notification repository
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+func (r *notificationRepository) CreateBatch(notifications []models.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	return r.db.CreateInBatches(notifications, 500).Error
+}
+
+// FindByUser lists a user's notifications, newest first
+func (r *notificationRepository) FindByUser(userID uint, params *dto.NotificationListParams) ([]models.Notification, int64, error) {
+	var notifications []models.Notification
+	var total int64
+
+	query := r.db.Model(&models.Notification{}).Where("user_id = ?", userID)
+	if params.UnreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (params.Page - 1) * params.PerPage
+	err := query.Order("created_at DESC, id DESC").Offset(offset).Limit(params.PerPage).Find(&notifications).Error
+	return notifications, total, err
+}
+
+func (r *notificationRepository) CountUnread(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *notificationRepository) MarkRead(userID, id uint, at time.Time) (bool, error) {
+	// COALESCE keeps the first read time when marked read again
+	result := r.db.Model(&models.Notification{}).
+		Where("id = ? AND user_id = ?", id, userID).
+		Update("read_at", gorm.Expr("COALESCE(read_at, ?)", at))
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *notificationRepository) MarkAllRead(userID uint, at time.Time) (int64, error) {
+	result := r.db.Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", at)
+	return result.RowsAffected, result.Error
+}
+
+func (r *notificationRepository) DeleteBefore(before time.Time) (int64, error) {
+	result := r.db.Where("created_at < ?", before).Delete(&models.Notification{})
+	return result.RowsAffected, result.Error
+}
+
+func (r *notificationRepository) FindTimeLogOwners(timeLogIDs []uint) ([]NotificationRecipient, error) {
+	var recipients []NotificationRecipient
+	err := r.db.Model(&models.TimeLog{}).
+		Select("user_id, COUNT(*) as count").
+		Where("id IN ?", timeLogIDs).
+		Group("user_id").
+		Scan(&recipients).Error
+	return recipients, err
+}
+
+func (r *notificationRepository) FindWorkspaceMemberIDs(workspaceID uint) ([]uint, error) {
+	var userIDs []uint
+	err := r.db.Model(&models.WorkspaceMember{}).
+		Where("workspace_id = ? AND is_active = true", workspaceID).
+		Pluck("user_id", &userIDs).Error
+	return userIDs, err
+}
+
+// FindWorkspaceAdminIDs returns the workspace admin and the members with the admin flag
+func (r *notificationRepository) FindWorkspaceAdminIDs(workspaceID uint) ([]uint, error) {
+	var userIDs []uint
+	err := r.db.Raw(`SELECT admin_id FROM workspaces WHERE id = ?
+		UNION
+		SELECT user_id FROM workspace_members
+		WHERE workspace_id = ? AND is_admin = true AND is_active = true AND deleted_at IS NULL`,
+		workspaceID, workspaceID).
+		Scan(&userIDs).Error
+	return userIDs, err
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// OperationRepository handles long-running operation records
+type OperationRepository interface {
+	Create(op *models.Operation) error
+	Update(op *models.Operation) error
+	FindByID(id uint) (*models.Operation, error)
+	// DeleteFinishedBefore removes completed and failed operations that
+	// finished before the cutoff
+	DeleteFinishedBefore(before time.Time) (int64, error)
+}
+
+type operationRepository struct {
+	db *gorm.DB
+}
+
+// NewOperationRepository creates a new operation repository
+func NewOperationRepository(db *gorm.DB) OperationRepository {
+	return &operationRepository{db: db}
+}
+
+func (r *operationRepository) Create(op *models.Operation) error {
+	return r.db.Create(op).Error
+}
+
+func (r *operationRepository) Update(op *models.Operation) error {
+	return r.db.Save(op).Error
+}
+
+func (r *operationRepository) FindByID(id uint) (*models.Operation, error) {
+	var op models.Operation
+	if err := r.db.First(&op, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NotFound("operation not found")
+		}
+		return nil, err
+	}
+	return &op, nil
+}
+
+func (r *operationRepository) DeleteFinishedBefore(before time.Time) (int64, error) {
+	result := r.db.
+		Where("status IN ? AND completed_at < ?",
+			[]string{models.OperationStatusCompleted, models.OperationStatusFailed}, before).
+		Delete(&models.Operation{})
+	return result.RowsAffected, result.Error
+}
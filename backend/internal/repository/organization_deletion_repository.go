@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// FindDueForDeletion lists organizations whose deletion grace period ended
+// before the given time, including ones soft-deleted meanwhile
+func (r *OrganizationRepository) FindDueForDeletion(before time.Time) ([]models.Organization, error) {
+	var orgs []models.Organization
+	err := r.db.Unscoped().
+		Where("deletion_scheduled_at IS NOT NULL AND deletion_scheduled_at <= ?", before).
+		Find(&orgs).Error
+	return orgs, err
+}
+
+// PurgeOrganization permanently deletes an organization with its workspaces,
+// tasks, time logs, screenshots, invitations, memberships and every other
+// record scoped to it, in a single transaction. Members' user accounts are
+// kept. Returns the file paths that must be purged from disk.
+func (r *OrganizationRepository) PurgeOrganization(orgID uint) ([]string, error) {
+	var filePaths []string
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		workspaceIDs := tx.Unscoped().Model(&models.Workspace{}).Select("id").Where("organization_id = ?", orgID)
+		taskIDs := tx.Unscoped().Model(&models.Task{}).Select("id").
+			Where("organization_id = ? OR workspace_id IN (?)", orgID, workspaceIDs)
+		timeLogIDs := tx.Unscoped().Model(&models.TimeLog{}).Select("id").
+			Where("organization_id = ? OR workspace_id IN (?)", orgID, workspaceIDs)
+		invoiceIDs := tx.Model(&models.Invoice{}).Select("id").Where("organization_id = ?", orgID)
+		webhookIDs := tx.Unscoped().Model(&models.OrganizationWebhook{}).Select("id").Where("organization_id = ?", orgID)
+		savedReportIDs := tx.Unscoped().Model(&models.SavedReport{}).Select("id").
+			Where("organization_id = ? OR workspace_id IN (?)", orgID, workspaceIDs)
+		jiraIntegrationIDs := tx.Model(&models.JiraIntegration{}).Select("id").Where("workspace_id IN (?)", workspaceIDs)
+
+		var screenshots []models.Screenshot
+		if err := tx.Unscoped().Where("organization_id = ? OR workspace_id IN (?)", orgID, workspaceIDs).
+			Find(&screenshots).Error; err != nil {
+			return err
+		}
+		for _, ss := range screenshots {
+			filePaths = append(filePaths, ss.FilePath)
+			if ss.BlurredPath != "" {
+				filePaths = append(filePaths, ss.BlurredPath)
+			}
+		}
+
+		var attachments []models.TaskAttachment
+		if err := tx.Where("task_id IN (?)", taskIDs).Find(&attachments).Error; err != nil {
+			return err
+		}
+		for _, attachment := range attachments {
+			filePaths = append(filePaths, attachment.FilePath)
+		}
+
+		var exports []models.OrganizationExport
+		if err := tx.Where("organization_id = ?", orgID).Find(&exports).Error; err != nil {
+			return err
+		}
+		for _, export := range exports {
+			if export.FilePath != "" {
+				filePaths = append(filePaths, export.FilePath)
+			}
+		}
+
+		// Children first, so no step leaves rows pointing at deleted ones
+		steps := []struct {
+			model interface{}
+			query string
+			args  []interface{}
+		}{
+			// Screenshots
+			{&models.ScreenshotDeletionRequest{}, "organization_id = ? OR workspace_id IN (?)", []interface{}{orgID, workspaceIDs}},
+			{&models.ScreenshotDailyRollup{}, "organization_id = ? OR workspace_id IN (?)", []interface{}{orgID, workspaceIDs}},
+			{&models.Screenshot{}, "organization_id = ? OR workspace_id IN (?)", []interface{}{orgID, workspaceIDs}},
+			{&models.OrganizationStorageCounter{}, "organization_id = ?", []interface{}{orgID}},
+
+			// Daily stats
+			{&models.DailyUserStat{}, "organization_id = ? OR workspace_id IN (?)", []interface{}{orgID, workspaceIDs}},
+			{&models.DailyWorkspaceStat{}, "organization_id = ? OR workspace_id IN (?)", []interface{}{orgID, workspaceIDs}},
+
+			// Time logs
+			{&models.TimeLogBreak{}, "time_log_id IN (?)", []interface{}{timeLogIDs}},
+			{&models.TimeLogEdit{}, "time_log_id IN (?)", []interface{}{timeLogIDs}},
+			{&models.PrivateInterval{}, "organization_id = ? OR time_log_id IN (?)", []interface{}{orgID, timeLogIDs}},
+			{&models.TimeLogCommit{}, "time_log_id IN (?) OR task_id IN (?)", []interface{}{timeLogIDs, taskIDs}},
+			{&models.JiraWorklog{}, "time_log_id IN (?) OR integration_id IN (?)", []interface{}{timeLogIDs, jiraIntegrationIDs}},
+			{&models.GoogleCalendarEvent{}, "time_log_id IN (?)", []interface{}{timeLogIDs}},
+			{&models.SyncConflict{}, "time_log_id IN (?)", []interface{}{timeLogIDs}},
+			{&models.TimeLog{}, "organization_id = ? OR workspace_id IN (?)", []interface{}{orgID, workspaceIDs}},
+
+			// Billing
+			{&models.InvoiceLineItem{}, "invoice_id IN (?)", []interface{}{invoiceIDs}},
+			{&models.Invoice{}, "organization_id = ?", []interface{}{orgID}},
+
+			// Tasks
+			{&models.TaskAttachment{}, "task_id IN (?)", []interface{}{taskIDs}},
+			{&models.TaskComment{}, "task_id IN (?)", []interface{}{taskIDs}},
+			{&models.TaskAssignee{}, "task_id IN (?)", []interface{}{taskIDs}},
+			{&models.Task{}, "organization_id = ? OR workspace_id IN (?)", []interface{}{orgID, workspaceIDs}},
+
+			// Workspaces
+			{&models.ReportSchedule{}, "saved_report_id IN (?)", []interface{}{savedReportIDs}},
+			{&models.SavedReport{}, "organization_id = ? OR workspace_id IN (?)", []interface{}{orgID, workspaceIDs}},
+			{&models.WorkspaceTaskStatus{}, "workspace_id IN (?)", []interface{}{workspaceIDs}},
+			{&models.TaskAssignmentRule{}, "workspace_id IN (?)", []interface{}{workspaceIDs}},
+			{&models.JiraIntegration{}, "workspace_id IN (?)", []interface{}{workspaceIDs}},
+			{&models.VCSRepository{}, "workspace_id IN (?)", []interface{}{workspaceIDs}},
+			{&models.ShareLink{}, "workspace_id IN (?)", []interface{}{workspaceIDs}},
+			{&models.RoleChange{}, "organization_id = ? OR workspace_id IN (?)", []interface{}{orgID, workspaceIDs}},
+			{&models.Invitation{}, "organization_id = ? OR workspace_id IN (?)", []interface{}{orgID, workspaceIDs}},
+			{&models.WorkspaceMember{}, "workspace_id IN (?)", []interface{}{workspaceIDs}},
+			{&models.Workspace{}, "organization_id = ?", []interface{}{orgID}},
+			{&models.Client{}, "organization_id = ?", []interface{}{orgID}},
+			{&models.WorkspaceRole{}, "organization_id = ?", []interface{}{orgID}},
+
+			// Organization
+			{&models.WebhookDelivery{}, "organization_id = ? OR webhook_id IN (?)", []interface{}{orgID, webhookIDs}},
+			{&models.OrganizationWebhook{}, "organization_id = ?", []interface{}{orgID}},
+			{&models.SlackIntegration{}, "organization_id = ?", []interface{}{orgID}},
+			{&models.CaptureExclusionRule{}, "organization_id = ?", []interface{}{orgID}},
+			{&models.ScreenshotEncryptionKey{}, "organization_id = ?", []interface{}{orgID}},
+			{&models.DeviceApproval{}, "organization_id = ?", []interface{}{orgID}},
+			{&models.FeatureFlagOverride{}, "organization_id = ?", []interface{}{orgID}},
+			{&models.LeaveRequest{}, "organization_id = ?", []interface{}{orgID}},
+			{&models.OrganizationHoliday{}, "organization_id = ?", []interface{}{orgID}},
+			{&models.OrganizationSetting{}, "organization_id = ?", []interface{}{orgID}},
+			{&models.OrganizationExport{}, "organization_id = ?", []interface{}{orgID}},
+			{&models.Operation{}, "organization_id = ?", []interface{}{orgID}},
+			{&models.OrganizationMember{}, "organization_id = ?", []interface{}{orgID}},
+		}
+		for _, step := range steps {
+			if err := tx.Unscoped().Where(step.query, step.args...).Delete(step.model).Error; err != nil {
+				return err
+			}
+		}
+
+		// Usage events are kept for product analytics, without the organization
+		if err := tx.Model(&models.UsageEvent{}).Where("organization_id = ?", orgID).
+			Update("organization_id", nil).Error; err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Delete(&models.Organization{}, orgID).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r.stats.InvalidateOrg(orgID)
+	return filePaths, nil
+}
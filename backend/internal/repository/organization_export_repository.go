@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// OrganizationDataSnapshot holds an organization's records small enough to
+// load at once; time logs and screenshots are read in batches
+type OrganizationDataSnapshot struct {
+	Organization     models.Organization
+	Members          []models.OrganizationMember
+	Workspaces       []models.Workspace
+	WorkspaceMembers []models.WorkspaceMember
+	Tasks            []models.Task
+}
+
+// OrganizationExportRepository handles organization export archives and
+// reading the data they contain
+type OrganizationExportRepository interface {
+	Create(export *models.OrganizationExport) error
+	Update(export *models.OrganizationExport) error
+	FindByID(id uint) (*models.OrganizationExport, error)
+	FindByOrganization(orgID uint) ([]models.OrganizationExport, error)
+	FindActive(orgID uint) (*models.OrganizationExport, error)
+	FindExpired(now time.Time) ([]models.OrganizationExport, error)
+	Delete(id uint) error
+
+	LoadOrganizationData(orgID uint) (*OrganizationDataSnapshot, error)
+	CountTimeLogs(orgID uint) (int64, error)
+	CountScreenshots(orgID uint) (int64, error)
+	TimeLogsInBatches(orgID uint, batchSize int, fn func([]models.TimeLog) error) error
+	ScreenshotsInBatches(orgID uint, batchSize int, fn func([]models.Screenshot) error) error
+}
+
+type organizationExportRepository struct {
+	db *gorm.DB
+}
+
+// NewOrganizationExportRepository creates a new organization export repository
+func NewOrganizationExportRepository(db *gorm.DB) OrganizationExportRepository {
+	return &organizationExportRepository{db: db}
+}
+
+func (r *organizationExportRepository) Create(export *models.OrganizationExport) error {
+	return r.db.Create(export).Error
+}
+
+func (r *organizationExportRepository) Update(export *models.OrganizationExport) error {
+	return r.db.Save(export).Error
+}
+
+func (r *organizationExportRepository) FindByID(id uint) (*models.OrganizationExport, error) {
+	var export models.OrganizationExport
+	if err := r.db.First(&export, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NotFound("organization export not found")
+		}
+		return nil, err
+	}
+	return &export, nil
+}
+
+func (r *organizationExportRepository) FindByOrganization(orgID uint) ([]models.OrganizationExport, error) {
+	var exports []models.OrganizationExport
+	err := r.db.Where("organization_id = ?", orgID).Order("created_at DESC").Find(&exports).Error
+	return exports, err
+}
+
+// FindActive returns the organization's pending or processing export, if any
+func (r *organizationExportRepository) FindActive(orgID uint) (*models.OrganizationExport, error) {
+	var export models.OrganizationExport
+	err := r.db.Where("organization_id = ? AND status IN ?", orgID,
+		[]string{models.DataExportStatusPending, models.DataExportStatusProcessing}).
+		First(&export).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
+
+func (r *organizationExportRepository) FindExpired(now time.Time) ([]models.OrganizationExport, error) {
+	var exports []models.OrganizationExport
+	err := r.db.Where("expires_at IS NOT NULL AND expires_at < ?", now).Find(&exports).Error
+	return exports, err
+}
+
+func (r *organizationExportRepository) Delete(id uint) error {
+	return r.db.Delete(&models.OrganizationExport{}, id).Error
+}
+
+// LoadOrganizationData collects the organization with its members, workspaces
+// and tasks
+func (r *organizationExportRepository) LoadOrganizationData(orgID uint) (*OrganizationDataSnapshot, error) {
+	snapshot := &OrganizationDataSnapshot{}
+
+	if err := r.db.First(&snapshot.Organization, orgID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NotFound("organization not found")
+		}
+		return nil, err
+	}
+	if err := r.db.Preload("User").Where("organization_id = ?", orgID).Order("id").Find(&snapshot.Members).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("organization_id = ?", orgID).Order("id").Find(&snapshot.Workspaces).Error; err != nil {
+		return nil, err
+	}
+	err := r.db.Joins("JOIN workspaces ON workspaces.id = workspace_members.workspace_id").
+		Where("workspaces.organization_id = ?", orgID).
+		Order("workspace_members.id").
+		Find(&snapshot.WorkspaceMembers).Error
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("organization_id = ?", orgID).Order("id").Find(&snapshot.Tasks).Error; err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+func (r *organizationExportRepository) CountTimeLogs(orgID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.TimeLog{}).Where("organization_id = ?", orgID).Count(&count).Error
+	return count, err
+}
+
+func (r *organizationExportRepository) CountScreenshots(orgID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Screenshot{}).Where("organization_id = ?", orgID).Count(&count).Error
+	return count, err
+}
+
+func (r *organizationExportRepository) TimeLogsInBatches(orgID uint, batchSize int, fn func([]models.TimeLog) error) error {
+	var batch []models.TimeLog
+	return r.db.Where("organization_id = ?", orgID).
+		FindInBatches(&batch, batchSize, func(tx *gorm.DB, _ int) error {
+			return fn(batch)
+		}).Error
+}
+
+func (r *organizationExportRepository) ScreenshotsInBatches(orgID uint, batchSize int, fn func([]models.Screenshot) error) error {
+	var batch []models.Screenshot
+	return r.db.Where("organization_id = ?", orgID).
+		FindInBatches(&batch, batchSize, func(tx *gorm.DB, _ int) error {
+			return fn(batch)
+		}).Error
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// importLookupBatchSize bounds how many local IDs are looked up per query
+const importLookupBatchSize = 1000
+
+// ImportedTimeLog is a time log to import with the task it belongs to; the
+// task may be new and get its ID while importing
+type ImportedTimeLog struct {
+	TimeLog *models.TimeLog
+	Task    *models.Task
+}
+
+// OrganizationImportRepository stores time data imported from other trackers
+type OrganizationImportRepository interface {
+	FindTasksByLocalIDs(localIDs []string) ([]models.Task, error)
+	// ExistingTimeLogLocalIDs returns which of localIDs already exist
+	ExistingTimeLogLocalIDs(localIDs []string) (map[string]bool, error)
+	// Import creates the new tasks (those without an ID) and the time logs in
+	// one transaction
+	Import(tasks []*models.Task, logs []ImportedTimeLog) error
+}
+
+type organizationImportRepository struct {
+	db *gorm.DB
+}
+
+// NewOrganizationImportRepository creates a new organization import repository
+func NewOrganizationImportRepository(db *gorm.DB) OrganizationImportRepository {
+	return &organizationImportRepository{db: db}
+}
+
+func (r *organizationImportRepository) FindTasksByLocalIDs(localIDs []string) ([]models.Task, error) {
+	var tasks []models.Task
+	for start := 0; start < len(localIDs); start += importLookupBatchSize {
+		end := min(start+importLookupBatchSize, len(localIDs))
+		var batch []models.Task
+		if err := r.db.Where("local_id IN ?", localIDs[start:end]).Find(&batch).Error; err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, batch...)
+	}
+	return tasks, nil
+}
+
+func (r *organizationImportRepository) ExistingTimeLogLocalIDs(localIDs []string) (map[string]bool, error) {
+	existing := make(map[string]bool)
+	for start := 0; start < len(localIDs); start += importLookupBatchSize {
+		end := min(start+importLookupBatchSize, len(localIDs))
+		var found []string
+		err := r.db.Model(&models.TimeLog{}).Unscoped().
+			Where("local_id IN ?", localIDs[start:end]).
+			Pluck("local_id", &found).Error
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range found {
+			existing[id] = true
+		}
+	}
+	return existing, nil
+}
+
+func (r *organizationImportRepository) Import(tasks []*models.Task, logs []ImportedTimeLog) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, task := range tasks {
+			if task.ID != 0 {
+				continue
+			}
+			if err := tx.Omit(clause.Associations).Create(task).Error; err != nil {
+				return err
+			}
+		}
+
+		timeLogs := make([]*models.TimeLog, 0, len(logs))
+		for _, entry := range logs {
+			taskID := entry.Task.ID
+			entry.TimeLog.TaskID = &taskID
+			timeLogs = append(timeLogs, entry.TimeLog)
+		}
+		if len(timeLogs) == 0 {
+			return nil
+		}
+		return tx.Omit(clause.Associations).CreateInBatches(timeLogs, 500).Error
+	})
+}
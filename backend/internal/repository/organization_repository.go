@@ -12,7 +12,14 @@ import (
 
 // OrganizationRepository handles database operations for organizations
 type OrganizationRepository struct {
-	db *gorm.DB
+	db    *gorm.DB
+	stats *StatsCache
+}
+
+// OrganizationCounts holds the active member and workspace counts of an organization
+type OrganizationCounts struct {
+	MemberCount    int64
+	WorkspaceCount int64
 }
 
 // NewOrganizationRepository creates a new organization repository
@@ -20,6 +27,11 @@ func NewOrganizationRepository(db *gorm.DB) *OrganizationRepository {
 	return &OrganizationRepository{db: db}
 }
 
+// SetStatsCache enables stats cache invalidation on membership changes
+func (r *OrganizationRepository) SetStatsCache(stats *StatsCache) {
+	r.stats = stats
+}
+
 // ============================================================================
 // ORGANIZATION CRUD
 // ============================================================================
@@ -30,7 +42,11 @@ func (r *OrganizationRepository) Create(org *models.Organization) error {
 	if org.InviteCode == "" {
 		org.InviteCode = generateInviteCode()
 	}
-	return r.db.Create(org).Error
+	if err := r.db.Create(org).Error; err != nil {
+		return err
+	}
+	r.stats.InvalidateOverview()
+	return nil
 }
 
 // GetByID gets an organization by ID
@@ -99,14 +115,19 @@ func (r *OrganizationRepository) GetByOwnerID(ownerID uint) ([]models.Organizati
 	return orgs, err
 }
 
-// Update updates an organization
+// Update updates an organization; ErrVersionConflict means it changed since
+// it was loaded
 func (r *OrganizationRepository) Update(org *models.Organization) error {
-	return r.db.Save(org).Error
+	return updateVersioned(r.db, org, &org.Version)
 }
 
 // Delete soft deletes an organization
 func (r *OrganizationRepository) Delete(id uint) error {
-	return r.db.Delete(&models.Organization{}, id).Error
+	if err := r.db.Delete(&models.Organization{}, id).Error; err != nil {
+		return err
+	}
+	r.stats.InvalidateOrg(id)
+	return nil
 }
 
 // GetMemberCount gets the member count of an organization
@@ -127,6 +148,36 @@ func (r *OrganizationRepository) GetWorkspaceCount(orgID uint) (int64, error) {
 	return count, err
 }
 
+// GetCountsByIDs gets the member and workspace counts of several organizations in a single query
+func (r *OrganizationRepository) GetCountsByIDs(orgIDs []uint) (map[uint]OrganizationCounts, error) {
+	counts := make(map[uint]OrganizationCounts, len(orgIDs))
+	if len(orgIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		OrganizationID uint
+		OrganizationCounts
+	}
+	err := r.db.Table("organizations o").
+		Select(`o.id AS organization_id,
+			COUNT(DISTINCT om.id) AS member_count,
+			COUNT(DISTINCT w.id) AS workspace_count`).
+		Joins("LEFT JOIN organization_members om ON om.organization_id = o.id AND om.is_active = true AND om.deleted_at IS NULL").
+		Joins("LEFT JOIN workspaces w ON w.organization_id = o.id AND w.is_active = true AND w.deleted_at IS NULL").
+		Where("o.id IN ?", orgIDs).
+		Group("o.id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.OrganizationID] = row.OrganizationCounts
+	}
+	return counts, nil
+}
+
 // SlugExists checks if a slug already exists
 func (r *OrganizationRepository) SlugExists(slug string) (bool, error) {
 	var count int64
@@ -147,7 +198,11 @@ func (r *OrganizationRepository) RegenerateInviteCode(orgID uint) (string, error
 
 // AddMember adds a member to an organization
 func (r *OrganizationRepository) AddMember(member *models.OrganizationMember) error {
-	return r.db.Create(member).Error
+	if err := r.db.Create(member).Error; err != nil {
+		return err
+	}
+	r.stats.InvalidateOrg(member.OrganizationID)
+	return nil
 }
 
 // GetMember gets a member by organization and user ID
@@ -193,13 +248,30 @@ func (r *OrganizationRepository) GetUserOrganizations(userID uint) ([]models.Org
 
 // UpdateMember updates a member
 func (r *OrganizationRepository) UpdateMember(member *models.OrganizationMember) error {
-	return r.db.Save(member).Error
+	if err := r.db.Save(member).Error; err != nil {
+		return err
+	}
+	r.stats.InvalidateOrg(member.OrganizationID)
+	return nil
+}
+
+// RescaleCostRates converts the members' cost rates between minor units of
+// currencies whose minor units differ by factor
+func (r *OrganizationRepository) RescaleCostRates(orgID uint, factor float64) error {
+	return r.db.Model(&models.OrganizationMember{}).
+		Where("organization_id = ? AND cost_rate_minor IS NOT NULL", orgID).
+		Update("cost_rate_minor", gorm.Expr("ROUND(cost_rate_minor * ?)", factor)).Error
 }
 
 // RemoveMember removes a member from an organization (soft delete)
 func (r *OrganizationRepository) RemoveMember(orgID, userID uint) error {
-	return r.db.Where("organization_id = ? AND user_id = ?", orgID, userID).
+	err := r.db.Where("organization_id = ? AND user_id = ?", orgID, userID).
 		Delete(&models.OrganizationMember{}).Error
+	if err != nil {
+		return err
+	}
+	r.stats.InvalidateOrg(orgID)
+	return nil
 }
 
 // IsMember checks if a user is a member of an organization
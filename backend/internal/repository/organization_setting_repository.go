@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OrganizationSettingRepository handles organization setting values
+type OrganizationSettingRepository interface {
+	// FindByOrg lists the settings the organization has set
+	FindByOrg(orgID uint) ([]models.OrganizationSetting, error)
+	// Save stores the given JSON values and deletes the reset keys, which
+	// fall back to their defaults, in a single transaction
+	Save(orgID, userID uint, values map[string]string, reset []string) error
+}
+
+type organizationSettingRepository struct {
+	db *gorm.DB
+}
+
+// NewOrganizationSettingRepository creates a new organization setting repository
+func NewOrganizationSettingRepository(db *gorm.DB) OrganizationSettingRepository {
+	return &organizationSettingRepository{db: db}
+}
+
+func (r *organizationSettingRepository) FindByOrg(orgID uint) ([]models.OrganizationSetting, error) {
+	var settings []models.OrganizationSetting
+	err := r.db.Where("organization_id = ?", orgID).Order("key").Find(&settings).Error
+	return settings, err
+}
+
+func (r *organizationSettingRepository) Save(orgID, userID uint, values map[string]string, reset []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for key, value := range values {
+			setting := models.OrganizationSetting{
+				OrganizationID: orgID,
+				Key:            key,
+				Value:          value,
+				UpdatedBy:      userID,
+			}
+			err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "organization_id"}, {Name: "key"}},
+				DoUpdates: clause.AssignmentColumns([]string{"value", "updated_by", "updated_at"}),
+			}).Create(&setting).Error
+			if err != nil {
+				return err
+			}
+		}
+		if len(reset) > 0 {
+			if err := tx.Where("organization_id = ? AND key IN ?", orgID, reset).
+				Delete(&models.OrganizationSetting{}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
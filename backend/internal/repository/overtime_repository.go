@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OvertimeRepository handles overtime record data operations
+type OvertimeRepository interface {
+	// FindTimeLogs returns the user's time logs started within [start, end), oldest first
+	FindTimeLogs(userID uint, start, end time.Time) ([]models.TimeLog, error)
+	// FindRecords returns the user's records of periods starting on or after the date
+	FindRecords(userID uint, fromDate time.Time) ([]models.OvertimeRecord, error)
+	// Replace stores the user's records of periods starting on or after the
+	// date, removing the other records of those periods, and sets the
+	// overtime of the user's time logs started within [start, end)
+	Replace(userID uint, fromDate time.Time, records []models.OvertimeRecord, start, end time.Time, timeLogOvertime map[uint]int64) error
+	MarkNotified(id uint, at time.Time) error
+
+	// FindInRange lists the records of periods starting within the inclusive
+	// dates with their users; zero userID and empty rule match all
+	FindInRange(startDate, endDate time.Time, userID uint, rule string) ([]models.OvertimeRecord, error)
+}
+
+type overtimeRepository struct {
+	db *gorm.DB
+}
+
+// NewOvertimeRepository creates a new overtime record repository
+func NewOvertimeRepository(db *gorm.DB) OvertimeRepository {
+	return &overtimeRepository{db: db}
+}
+
+func (r *overtimeRepository) FindTimeLogs(userID uint, start, end time.Time) ([]models.TimeLog, error) {
+	var timeLogs []models.TimeLog
+	err := r.db.Select("id, user_id, workspace_id, start_time, duration, overtime_seconds").
+		Where("user_id = ? AND start_time >= ? AND start_time < ?", userID, start, end).
+		Order("start_time, id").
+		Find(&timeLogs).Error
+	return timeLogs, err
+}
+
+func (r *overtimeRepository) FindRecords(userID uint, fromDate time.Time) ([]models.OvertimeRecord, error) {
+	var records []models.OvertimeRecord
+	err := r.db.Where("user_id = ? AND period_start >= ?", userID, fromDate).Find(&records).Error
+	return records, err
+}
+
+func (r *overtimeRepository) Replace(userID uint, fromDate time.Time, records []models.OvertimeRecord, start, end time.Time, timeLogOvertime map[uint]int64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		keep := make([]uint, 0, len(records))
+		for i := range records {
+			if err := tx.Omit(clause.Associations).Save(&records[i]).Error; err != nil {
+				return err
+			}
+			keep = append(keep, records[i].ID)
+		}
+
+		stale := tx.Where("user_id = ? AND period_start >= ?", userID, fromDate)
+		if len(keep) > 0 {
+			stale = stale.Where("id NOT IN ?", keep)
+		}
+		if err := stale.Delete(&models.OvertimeRecord{}).Error; err != nil {
+			return err
+		}
+
+		// A derived flag: leave updated_at and the sync version alone
+		err := tx.Model(&models.TimeLog{}).
+			Where("user_id = ? AND start_time >= ? AND start_time < ? AND overtime_seconds <> 0", userID, start, end).
+			UpdateColumn("overtime_seconds", 0).Error
+		if err != nil {
+			return err
+		}
+		for id, seconds := range timeLogOvertime {
+			if err := tx.Model(&models.TimeLog{}).Where("id = ?", id).UpdateColumn("overtime_seconds", seconds).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *overtimeRepository) MarkNotified(id uint, at time.Time) error {
+	return r.db.Model(&models.OvertimeRecord{}).Where("id = ?", id).UpdateColumn("notified_at", at).Error
+}
+
+func (r *overtimeRepository) FindInRange(startDate, endDate time.Time, userID uint, rule string) ([]models.OvertimeRecord, error) {
+	query := r.db.Preload("User").
+		Where("period_start >= ? AND period_start <= ?", startDate, endDate)
+	if userID != 0 {
+		query = query.Where("user_id = ?", userID)
+	}
+	if rule != "" {
+		query = query.Where("rule = ?", rule)
+	}
+
+	var records []models.OvertimeRecord
+	err := query.Order("user_id, period_start, rule").Find(&records).Error
+	return records, err
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// PayrollDaySeconds holds a user's approved seconds for time logs started on
+// a day with the same effective cost center and project code
+type PayrollDaySeconds struct {
+	UserID      uint
+	Day         time.Time
+	CostCenter  string
+	ProjectCode string
+	Seconds     int64
+}
+
+// PayrollRepository handles member cost rates and the approved hours payroll is computed from
+type PayrollRepository interface {
+	FindMembers(orgID uint) ([]models.OrganizationMember, error)
+	// SetCostRate sets an active member's cost rate in minor units (nil clears
+	// it); false when the user is not an active member
+	SetCostRate(orgID, userID uint, costRateMinor *int64) (bool, error)
+	GetApprovedDaily(orgID uint, start, end time.Time, tz string) ([]PayrollDaySeconds, error)
+}
+
+type payrollRepository struct {
+	db *gorm.DB
+}
+
+// NewPayrollRepository creates a new payroll repository
+func NewPayrollRepository(db *gorm.DB) PayrollRepository {
+	return &payrollRepository{db: db}
+}
+
+// FindMembers lists the organization's members with their users, including
+// inactive ones who may still have approved time in a pay period
+func (r *payrollRepository) FindMembers(orgID uint) ([]models.OrganizationMember, error) {
+	var members []models.OrganizationMember
+	err := r.db.Preload("User").
+		Where("organization_id = ?", orgID).
+		Order("user_id").
+		Find(&members).Error
+	return members, err
+}
+
+func (r *payrollRepository) SetCostRate(orgID, userID uint, costRateMinor *int64) (bool, error) {
+	result := r.db.Model(&models.OrganizationMember{}).
+		Where("organization_id = ? AND user_id = ? AND is_active = true", orgID, userID).
+		Update("cost_rate_minor", costRateMinor)
+	return result.RowsAffected > 0, result.Error
+}
+
+// GetApprovedDaily sums approved seconds per user, start day in the time zone
+// tz and effective cost center and project code (the task's, else the
+// workspace's) for the organization's time logs started within [start, end)
+func (r *payrollRepository) GetApprovedDaily(orgID uint, start, end time.Time, tz string) ([]PayrollDaySeconds, error) {
+	var days []PayrollDaySeconds
+	err := r.db.Model(&models.TimeLog{}).
+		Select("time_logs.user_id, (time_logs.start_time AT TIME ZONE ?)::date AS day, "+
+			timeLogCostCenterExpr+" AS cost_center, "+timeLogProjectCodeExpr+" AS project_code, "+
+			"COALESCE(SUM(time_logs.duration), 0) AS seconds", tz).
+		Joins("LEFT JOIN tasks ON tasks.id = time_logs.task_id").
+		Joins("LEFT JOIN workspaces ON workspaces.id = time_logs.workspace_id").
+		Where("time_logs.organization_id = ? AND time_logs.is_approved = true", orgID).
+		Where("time_logs.start_time >= ? AND time_logs.start_time < ?", start, end).
+		Group("time_logs.user_id, day, cost_center, project_code").
+		Order("time_logs.user_id, day").
+		Scan(&days).Error
+	return days, err
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// PresenceRepository reads current user presence and the presence history
+// recorded as users move between statuses
+type PresenceRepository interface {
+	// FindStale returns users not marked offline whose last heartbeat is
+	// older than the cutoff
+	FindStale(before time.Time) ([]models.User, error)
+	// MarkOffline marks a user offline as of their last heartbeat, unless a
+	// newer heartbeat arrived meanwhile; reports whether the user was marked
+	MarkOffline(userID uint, lastPresenceAt time.Time) (bool, error)
+
+	// ListOrganization returns the active members of an organization with
+	// their presence
+	ListOrganization(orgID uint) ([]PresenceMemberRow, error)
+	// FindIntervals returns the presence intervals of an organization's
+	// members overlapping [from, to], oldest first
+	FindIntervals(orgID uint, userID *uint, from, to time.Time, limit int) ([]PresenceIntervalRow, error)
+	// DeleteIntervalsBefore deletes intervals that ended before the cutoff
+	DeleteIntervalsBefore(before time.Time) (int64, error)
+}
+
+// PresenceMemberRow is an organization member with their stored presence
+type PresenceMemberRow struct {
+	UserID         uint
+	Email          string
+	FirstName      string
+	LastName       string
+	Role           string
+	PresenceStatus string
+	LastPresenceAt *time.Time
+	LastWorkingAt  *time.Time
+}
+
+// PresenceIntervalRow is a presence interval with its user
+type PresenceIntervalRow struct {
+	models.UserPresenceInterval
+	Email     string
+	FirstName string
+	LastName  string
+}
+
+type presenceRepository struct {
+	db *gorm.DB
+}
+
+// NewPresenceRepository creates a new presence repository
+func NewPresenceRepository(db *gorm.DB) PresenceRepository {
+	return &presenceRepository{db: db}
+}
+
+func (r *presenceRepository) FindStale(before time.Time) ([]models.User, error) {
+	var users []models.User
+	err := r.db.Select("id", "presence_status", "last_presence_at").
+		Where("presence_status <> ? AND last_presence_at < ?", models.UserPresenceOffline, before).
+		Find(&users).Error
+	return users, err
+}
+
+func (r *presenceRepository) MarkOffline(userID uint, lastPresenceAt time.Time) (bool, error) {
+	marked := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.User{}).
+			Where("id = ? AND last_presence_at = ? AND presence_status <> ?", userID, lastPresenceAt, models.UserPresenceOffline).
+			Update("presence_status", models.UserPresenceOffline)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		marked = true
+		return recordPresenceTransition(tx, userID, models.UserPresenceOffline, lastPresenceAt)
+	})
+	return marked, err
+}
+
+func (r *presenceRepository) ListOrganization(orgID uint) ([]PresenceMemberRow, error) {
+	var rows []PresenceMemberRow
+	err := r.db.Table("organization_members om").
+		Select("u.id AS user_id, u.email, u.first_name, u.last_name, om.role, u.presence_status, u.last_presence_at, u.last_working_at").
+		Joins("JOIN users u ON u.id = om.user_id AND u.deleted_at IS NULL").
+		Where("om.organization_id = ? AND om.is_active = true AND om.deleted_at IS NULL", orgID).
+		Order("u.last_presence_at DESC NULLS LAST, u.id ASC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *presenceRepository) FindIntervals(orgID uint, userID *uint, from, to time.Time, limit int) ([]PresenceIntervalRow, error) {
+	query := r.db.Table("user_presence_intervals pi").
+		Select("pi.*, u.email, u.first_name, u.last_name").
+		Joins("JOIN users u ON u.id = pi.user_id").
+		Where("pi.user_id IN (?)", r.db.Model(&models.OrganizationMember{}).Select("user_id").Where("organization_id = ?", orgID)).
+		Where("pi.started_at <= ? AND (pi.ended_at IS NULL OR pi.ended_at >= ?)", to, from)
+	if userID != nil {
+		query = query.Where("pi.user_id = ?", *userID)
+	}
+
+	var rows []PresenceIntervalRow
+	err := query.Order("pi.started_at ASC, pi.id ASC").Limit(limit).Scan(&rows).Error
+	return rows, err
+}
+
+func (r *presenceRepository) DeleteIntervalsBefore(before time.Time) (int64, error) {
+	result := r.db.Where("ended_at < ?", before).Delete(&models.UserPresenceInterval{})
+	return result.RowsAffected, result.Error
+}
+
+// recordPresenceTransition keeps a user's presence history in step with a
+// status change at the given time: the open interval is ended and, unless
+// the user went offline, one is opened for the new status. Repeated
+// heartbeats of the same status leave the open interval as it is.
+func recordPresenceTransition(db *gorm.DB, userID uint, status string, at time.Time) error {
+	var open models.UserPresenceInterval
+	if err := db.Where("user_id = ? AND ended_at IS NULL", userID).Order("id DESC").Limit(1).Find(&open).Error; err != nil {
+		return err
+	}
+	if open.ID != 0 && open.Status == status {
+		return nil
+	}
+	if open.ID != 0 {
+		if err := db.Model(&models.UserPresenceInterval{}).
+			Where("user_id = ? AND ended_at IS NULL", userID).
+			Update("ended_at", at).Error; err != nil {
+			return err
+		}
+	}
+	if status == models.UserPresenceOffline {
+		return nil
+	}
+	return db.Create(&models.UserPresenceInterval{
+		UserID:    userID,
+		Status:    status,
+		StartedAt: at,
+	}).Error
+}
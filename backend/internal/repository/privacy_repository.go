@@ -0,0 +1,256 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// UserDataSnapshot holds every record owned by a user, used for data exports
+type UserDataSnapshot struct {
+	User        models.User
+	Tasks       []models.Task
+	TimeLogs    []models.TimeLog
+	Screenshots []models.Screenshot
+	Devices     []models.DeviceInfo
+}
+
+// PrivacyRepository handles personal data export and erasure operations
+type PrivacyRepository interface {
+	CreateExport(export *models.DataExport) error
+	FindExportByID(id uint) (*models.DataExport, error)
+	FindExportsByUserID(userID uint) ([]models.DataExport, error)
+	FindActiveExport(userID uint) (*models.DataExport, error)
+	UpdateExport(export *models.DataExport) error
+	FindExpiredExports(before time.Time) ([]models.DataExport, error)
+	DeleteExport(id uint) error
+
+	LoadUserData(userID uint) (*UserDataSnapshot, error)
+	FindUsersDueForErasure(before time.Time) ([]models.User, error)
+	AnonymizeUser(userID uint, anonymizedEmail, passwordHash string) ([]string, error)
+}
+
+type privacyRepository struct {
+	db *gorm.DB
+}
+
+// NewPrivacyRepository creates a new privacy repository
+func NewPrivacyRepository(db *gorm.DB) PrivacyRepository {
+	return &privacyRepository{db: db}
+}
+
+func (r *privacyRepository) CreateExport(export *models.DataExport) error {
+	return r.db.Create(export).Error
+}
+
+func (r *privacyRepository) FindExportByID(id uint) (*models.DataExport, error) {
+	var export models.DataExport
+	if err := r.db.First(&export, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NotFound("data export not found")
+		}
+		return nil, err
+	}
+	return &export, nil
+}
+
+func (r *privacyRepository) FindExportsByUserID(userID uint) ([]models.DataExport, error) {
+	var exports []models.DataExport
+	err := r.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&exports).Error
+	return exports, err
+}
+
+// FindActiveExport returns the user's pending or processing export, if any
+func (r *privacyRepository) FindActiveExport(userID uint) (*models.DataExport, error) {
+	var export models.DataExport
+	err := r.db.Where("user_id = ? AND status IN ?", userID,
+		[]string{models.DataExportStatusPending, models.DataExportStatusProcessing}).
+		First(&export).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &export, nil
+}
+
+func (r *privacyRepository) UpdateExport(export *models.DataExport) error {
+	return r.db.Save(export).Error
+}
+
+func (r *privacyRepository) FindExpiredExports(before time.Time) ([]models.DataExport, error) {
+	var exports []models.DataExport
+	err := r.db.Where("expires_at IS NOT NULL AND expires_at < ?", before).Find(&exports).Error
+	return exports, err
+}
+
+func (r *privacyRepository) DeleteExport(id uint) error {
+	return r.db.Delete(&models.DataExport{}, id).Error
+}
+
+// LoadUserData collects all tasks, time logs, screenshots and devices of a user
+func (r *privacyRepository) LoadUserData(userID uint) (*UserDataSnapshot, error) {
+	snapshot := &UserDataSnapshot{}
+
+	if err := r.db.First(&snapshot.User, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NotFound("user not found")
+		}
+		return nil, err
+	}
+	if err := r.db.Where("user_id = ?", userID).Order("id").Find(&snapshot.Tasks).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Preload("Breaks").Preload("PrivateIntervals").Where("user_id = ?", userID).Order("start_time").Find(&snapshot.TimeLogs).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("user_id = ?", userID).Order("captured_at").Find(&snapshot.Screenshots).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("user_id = ?", userID).Order("id").Find(&snapshot.Devices).Error; err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// FindUsersDueForErasure returns users whose erasure grace period has ended
+func (r *privacyRepository) FindUsersDueForErasure(before time.Time) ([]models.User, error) {
+	var users []models.User
+	err := r.db.Where("deletion_scheduled_at IS NOT NULL AND deletion_scheduled_at <= ? AND anonymized_at IS NULL", before).
+		Find(&users).Error
+	return users, err
+}
+
+// AnonymizeUser strips personal data from a user and their records in a single
+// transaction. Time logs and tasks are kept (without free text) so organization
+// reports stay consistent; screenshots, devices and data exports are removed.
+// Returns the file paths that must be purged from disk.
+func (r *privacyRepository) AnonymizeUser(userID uint, anonymizedEmail, passwordHash string) ([]string, error) {
+	var filePaths []string
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var screenshots []models.Screenshot
+		if err := tx.Unscoped().Where("user_id = ?", userID).Find(&screenshots).Error; err != nil {
+			return err
+		}
+		for _, ss := range screenshots {
+			filePaths = append(filePaths, ss.FilePath)
+			if ss.BlurredPath != "" {
+				filePaths = append(filePaths, ss.BlurredPath)
+			}
+		}
+
+		var exports []models.DataExport
+		if err := tx.Where("user_id = ?", userID).Find(&exports).Error; err != nil {
+			return err
+		}
+		for _, export := range exports {
+			if export.FilePath != "" {
+				filePaths = append(filePaths, export.FilePath)
+			}
+		}
+
+		var logBundles []models.DeviceLogBundle
+		if err := tx.Where("user_id = ?", userID).Find(&logBundles).Error; err != nil {
+			return err
+		}
+		for _, bundle := range logBundles {
+			filePaths = append(filePaths, bundle.FilePath)
+		}
+
+		if err := subtractStorageUsage(tx, "user_id = ?", userID); err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("user_id = ?", userID).Delete(&models.Screenshot{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.ScreenshotDeletionRequest{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.SyncConflict{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.DataExport{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&models.TimeLog{}).Where("user_id = ?", userID).
+			Updates(map[string]interface{}{"notes": "", "device_id": nil}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.DeviceLogBundle{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("user_id = ?", userID).Delete(&models.DeviceInfo{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.UserPresenceInterval{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&models.Task{}).Where("user_id = ?", userID).
+			Updates(map[string]interface{}{"description": "", "admin_notes": ""}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.CrashReport{}).Where("user_id = ?", userID).
+			Updates(map[string]interface{}{"user_id": nil, "device_uuid": ""}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.PrivateInterval{}).Where("user_id = ?", userID).Update("note", "").Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.CalendarFeed{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("connection_id IN (?)", tx.Model(&models.GoogleCalendarConnection{}).Select("id").Where("user_id = ?", userID)).
+			Delete(&models.GoogleCalendarEvent{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.GoogleCalendarConnection{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.EmailOutbox{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.NotificationPreference{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.PasswordResetToken{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.Notification{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.UsageEvent{}).Where("user_id = ?", userID).
+			Update("user_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.AuditLog{}).Where("user_id = ?", userID).
+			Updates(map[string]interface{}{"ip_address": "", "user_agent": ""}).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		return tx.Model(&models.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"email":                 anonymizedEmail,
+			"password_hash":         passwordHash,
+			"first_name":            "Deleted",
+			"last_name":             "User",
+			"is_active":             false,
+			"presence_status":       models.UserPresenceOffline,
+			"last_login_at":         nil,
+			"last_presence_at":      nil,
+			"last_working_at":       nil,
+			"deletion_scheduled_at": nil,
+			"anonymized_at":         now,
+		}).Error
+	})
+
+	return filePaths, err
+}
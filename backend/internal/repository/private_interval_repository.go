@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// PrivateIntervalRepository handles the intervals in which users paused capture
+type PrivateIntervalRepository interface {
+	Create(interval *models.PrivateInterval) error
+	FindByID(id uint) (*models.PrivateInterval, error)
+	// FindActive returns the user's interval still in progress, or nil
+	FindActive(userID uint) (*models.PrivateInterval, error)
+	// FindByUser lists the user's intervals started within [start, end), oldest first
+	FindByUser(userID uint, start, end time.Time) ([]models.PrivateInterval, error)
+	// FindOverlapping lists the user's intervals overlapping [start, end],
+	// including one still in progress
+	FindOverlapping(userID uint, start, end time.Time) ([]models.PrivateInterval, error)
+	Update(interval *models.PrivateInterval) error
+}
+
+type privateIntervalRepository struct {
+	db *gorm.DB
+}
+
+// NewPrivateIntervalRepository creates a new private interval repository
+func NewPrivateIntervalRepository(db *gorm.DB) PrivateIntervalRepository {
+	return &privateIntervalRepository{db: db}
+}
+
+func (r *privateIntervalRepository) Create(interval *models.PrivateInterval) error {
+	return r.db.Create(interval).Error
+}
+
+func (r *privateIntervalRepository) FindByID(id uint) (*models.PrivateInterval, error) {
+	var interval models.PrivateInterval
+	if err := r.db.First(&interval, id).Error; err != nil {
+		return nil, err
+	}
+	return &interval, nil
+}
+
+func (r *privateIntervalRepository) FindActive(userID uint) (*models.PrivateInterval, error) {
+	var interval models.PrivateInterval
+	if err := r.db.Where("user_id = ? AND end_time IS NULL", userID).
+		Order("start_time DESC").
+		First(&interval).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &interval, nil
+}
+
+func (r *privateIntervalRepository) FindByUser(userID uint, start, end time.Time) ([]models.PrivateInterval, error) {
+	var intervals []models.PrivateInterval
+	err := r.db.Where("user_id = ? AND start_time >= ? AND start_time < ?", userID, start, end).
+		Order("start_time ASC").
+		Find(&intervals).Error
+	return intervals, err
+}
+
+func (r *privateIntervalRepository) FindOverlapping(userID uint, start, end time.Time) ([]models.PrivateInterval, error) {
+	var intervals []models.PrivateInterval
+	err := r.db.Where("user_id = ? AND start_time <= ? AND (end_time IS NULL OR end_time >= ?)", userID, end, start).
+		Order("start_time ASC").
+		Find(&intervals).Error
+	return intervals, err
+}
+
+func (r *privateIntervalRepository) Update(interval *models.PrivateInterval) error {
+	return r.db.Save(interval).Error
+}
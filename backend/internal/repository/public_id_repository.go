@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// publicIDTables lists the tables whose rows carry a public UUID
+var publicIDTables = map[string]bool{
+	"users":         true,
+	"organizations": true,
+	"workspaces":    true,
+	"tasks":         true,
+	"time_logs":     true,
+	"screenshots":   true,
+}
+
+// PublicIDRepository resolves the public UUIDs of primary entities to their
+// internal IDs
+type PublicIDRepository interface {
+	// FindID returns the ID of the live row of table with the UUID, or 0
+	FindID(table, uuid string) (uint, error)
+}
+
+type publicIDRepository struct {
+	db *gorm.DB
+}
+
+// NewPublicIDRepository creates a new public ID repository
+func NewPublicIDRepository(db *gorm.DB) PublicIDRepository {
+	return &publicIDRepository{db: db}
+}
+
+func (r *publicIDRepository) FindID(table, uuid string) (uint, error) {
+	if !publicIDTables[table] {
+		return 0, fmt.Errorf("table %q has no public IDs", table)
+	}
+
+	var ids []uint
+	err := r.db.Table(table).
+		Where("uuid = ? AND deleted_at IS NULL", uuid).
+		Limit(1).
+		Pluck("id", &ids).Error
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+	return ids[0], nil
+}
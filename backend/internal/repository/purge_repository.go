@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// PurgeRepository permanently removes soft-deleted rows
+type PurgeRepository interface {
+	CountSoftDeleted(model interface{}, before time.Time) (int64, error)
+	SumSoftDeletedScreenshotBytes(before time.Time) (int64, error)
+	// FindSoftDeletedIDs returns ids above afterID, in id order, so rows that
+	// cannot be deleted are not returned again
+	FindSoftDeletedIDs(model interface{}, before time.Time, afterID uint, limit int) ([]uint, error)
+	FindSoftDeletedScreenshots(before time.Time, afterID uint, limit int) ([]models.Screenshot, error)
+	// FindSharedFilePaths returns the files of the screenshots that live
+	// screenshots still reference, which must be kept
+	FindSharedFilePaths(ids []uint) (map[string]bool, error)
+	HardDelete(model interface{}, ids []uint) (int64, error)
+}
+
+type purgeRepository struct {
+	db *gorm.DB
+}
+
+// NewPurgeRepository creates a new purge repository
+func NewPurgeRepository(db *gorm.DB) PurgeRepository {
+	return &purgeRepository{db: db}
+}
+
+func (r *purgeRepository) softDeleted(model interface{}, before time.Time) *gorm.DB {
+	return r.db.Unscoped().Model(model).Where("deleted_at IS NOT NULL AND deleted_at < ?", before)
+}
+
+func (r *purgeRepository) CountSoftDeleted(model interface{}, before time.Time) (int64, error) {
+	var count int64
+	err := r.softDeleted(model, before).Count(&count).Error
+	return count, err
+}
+
+func (r *purgeRepository) SumSoftDeletedScreenshotBytes(before time.Time) (int64, error) {
+	var total int64
+	err := r.softDeleted(&models.Screenshot{}, before).
+		Select("COALESCE(SUM(file_size), 0)").
+		Scan(&total).Error
+	return total, err
+}
+
+func (r *purgeRepository) FindSoftDeletedIDs(model interface{}, before time.Time, afterID uint, limit int) ([]uint, error) {
+	var ids []uint
+	err := r.softDeleted(model, before).
+		Where("id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+func (r *purgeRepository) FindSoftDeletedScreenshots(before time.Time, afterID uint, limit int) ([]models.Screenshot, error) {
+	var screenshots []models.Screenshot
+	err := r.softDeleted(&models.Screenshot{}, before).
+		Where("id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&screenshots).Error
+	return screenshots, err
+}
+
+func (r *purgeRepository) FindSharedFilePaths(ids []uint) (map[string]bool, error) {
+	return sharedFilePaths(r.db, ids)
+}
+
+// HardDelete removes rows that are still soft-deleted, so a row restored
+// since it was selected is kept
+func (r *purgeRepository) HardDelete(model interface{}, ids []uint) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	result := r.db.Unscoped().Where("id IN ? AND deleted_at IS NOT NULL", ids).Delete(model)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,262 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// ReportScope selects the time logs of a report: a workspace's, a user's,
+// or a user's in one workspace
+type ReportScope struct {
+	WorkspaceID *uint
+	UserID      *uint
+	Timezone    string // IANA zone days and hours are grouped in; empty is UTC
+}
+
+func (s ReportScope) timezone() string {
+	if s.Timezone == "" {
+		return "UTC"
+	}
+	return s.Timezone
+}
+
+// ReportActivityRow is tracked time by weekday and hour of the start time,
+// in the scope's timezone
+type ReportActivityRow struct {
+	Weekday  int
+	Hour     int
+	Duration int64
+	TimeLogs int64
+}
+
+// ReportRepository aggregates time logs for workspace and personal reports.
+// Time logs count towards the day they start in, in the scope's timezone,
+// within [start, end).
+type ReportRepository interface {
+	// DailyDurations returns tracked time per day, labelled YYYY-MM-DD and ordered by day
+	DailyDurations(scope ReportScope, start, end time.Time) ([]dto.ReportDurationStat, error)
+	CountActiveUsers(scope ReportScope, start, end time.Time) (int64, error)
+	TopTasks(scope ReportScope, start, end time.Time, limit int) ([]dto.ReportTaskStat, error)
+	TopMembers(scope ReportScope, start, end time.Time, limit int) ([]dto.ReportMemberStat, error)
+	Activity(scope ReportScope, start, end time.Time) ([]ReportActivityRow, error)
+	// PrivateDurations returns the seconds each user spent in private mode in
+	// intervals started within [start, end), counting running ones up to now
+	PrivateDurations(scope ReportScope, start, end time.Time) (map[uint]int64, error)
+
+	// Organization dashboard
+	OrganizationCounts(orgID uint, dayStart, dayEnd time.Time) (*OrganizationDashboardCounts, error)
+	OrganizationDuration(orgID uint, start, end time.Time) (int64, error)
+	TopOrganizationWorkspaces(orgID uint, start, end time.Time, limit int) ([]dto.OrganizationWorkspaceStat, error)
+}
+
+// OrganizationDashboardCounts are an organization's member, workspace and screenshot totals
+type OrganizationDashboardCounts struct {
+	Members     int64
+	ActiveToday int64 // Members with time logs overlapping [dayStart, dayEnd)
+	TrackingNow int64
+	Workspaces  int64
+	Screenshots int64
+	StorageUsed int64 // Bytes
+}
+
+type reportRepository struct {
+	db *gorm.DB
+}
+
+// NewReportRepository creates a new report repository
+func NewReportRepository(db *gorm.DB) ReportRepository {
+	return &reportRepository{db: db}
+}
+
+func (r *reportRepository) timeLogs(scope ReportScope, start, end time.Time) *gorm.DB {
+	query := r.db.Model(&models.TimeLog{}).
+		Where("time_logs.start_time >= ? AND time_logs.start_time < ?", start, end)
+	if scope.WorkspaceID != nil {
+		query = query.Where("time_logs.workspace_id = ?", *scope.WorkspaceID)
+	}
+	if scope.UserID != nil {
+		query = query.Where("time_logs.user_id = ?", *scope.UserID)
+	}
+	return query
+}
+
+func (r *reportRepository) DailyDurations(scope ReportScope, start, end time.Time) ([]dto.ReportDurationStat, error) {
+	days := []dto.ReportDurationStat{}
+	err := r.timeLogs(scope, start, end).
+		Select(`TO_CHAR((time_logs.start_time AT TIME ZONE ?)::date, 'YYYY-MM-DD') AS period,
+			COALESCE(SUM(time_logs.duration), 0) AS duration,
+			COUNT(*) AS time_logs`, scope.timezone()).
+		Group("period").
+		Order("period").
+		Scan(&days).Error
+	return days, err
+}
+
+func (r *reportRepository) CountActiveUsers(scope ReportScope, start, end time.Time) (int64, error) {
+	var count int64
+	err := r.timeLogs(scope, start, end).
+		Select("COUNT(DISTINCT time_logs.user_id)").
+		Scan(&count).Error
+	return count, err
+}
+
+func (r *reportRepository) TopTasks(scope ReportScope, start, end time.Time, limit int) ([]dto.ReportTaskStat, error) {
+	tasks := []dto.ReportTaskStat{}
+	err := r.timeLogs(scope, start, end).
+		Select(`time_logs.task_id,
+			COALESCE(MAX(tasks.title), MAX(time_logs.task_title), '') AS title,
+			COALESCE(SUM(time_logs.duration), 0) AS duration,
+			COUNT(*) AS time_logs`).
+		Joins("LEFT JOIN tasks ON tasks.id = time_logs.task_id").
+		Group("time_logs.task_id, CASE WHEN time_logs.task_id IS NULL THEN time_logs.task_title END").
+		Order("duration DESC").
+		Limit(limit).
+		Scan(&tasks).Error
+	return tasks, err
+}
+
+func (r *reportRepository) TopMembers(scope ReportScope, start, end time.Time, limit int) ([]dto.ReportMemberStat, error) {
+	members := []dto.ReportMemberStat{}
+	err := r.timeLogs(scope, start, end).
+		Select(`time_logs.user_id,
+			CONCAT(users.first_name, ' ', users.last_name) AS user_name,
+			users.email,
+			COALESCE(SUM(time_logs.duration), 0) AS duration,
+			COUNT(*) AS time_logs`).
+		Joins("JOIN users ON users.id = time_logs.user_id").
+		Group("time_logs.user_id, users.first_name, users.last_name, users.email").
+		Order("duration DESC").
+		Limit(limit).
+		Scan(&members).Error
+	return members, err
+}
+
+func (r *reportRepository) Activity(scope ReportScope, start, end time.Time) ([]ReportActivityRow, error) {
+	var rows []ReportActivityRow
+	err := r.timeLogs(scope, start, end).
+		Select(`EXTRACT(DOW FROM time_logs.start_time AT TIME ZONE ?)::int AS weekday,
+			EXTRACT(HOUR FROM time_logs.start_time AT TIME ZONE ?)::int AS hour,
+			COALESCE(SUM(time_logs.duration), 0) AS duration,
+			COUNT(*) AS time_logs`, scope.timezone(), scope.timezone()).
+		Group("weekday, hour").
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *reportRepository) PrivateDurations(scope ReportScope, start, end time.Time) (map[uint]int64, error) {
+	query := r.db.Model(&models.PrivateInterval{}).
+		Where("start_time >= ? AND start_time < ?", start, end)
+	if scope.WorkspaceID != nil {
+		query = query.Where("workspace_id = ?", *scope.WorkspaceID)
+	}
+	if scope.UserID != nil {
+		query = query.Where("user_id = ?", *scope.UserID)
+	}
+
+	var rows []struct {
+		UserID   uint
+		Duration int64
+	}
+	err := query.Select(`user_id,
+			COALESCE(SUM(CASE WHEN end_time IS NULL THEN EXTRACT(EPOCH FROM NOW() - start_time)::bigint ELSE duration END), 0) AS duration`).
+		Group("user_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	durations := make(map[uint]int64, len(rows))
+	for _, row := range rows {
+		durations[row.UserID] = row.Duration
+	}
+	return durations, nil
+}
+
+// ============================================================================
+// ORGANIZATION DASHBOARD
+// ============================================================================
+
+func (r *reportRepository) OrganizationCounts(orgID uint, dayStart, dayEnd time.Time) (*OrganizationDashboardCounts, error) {
+	var counts OrganizationDashboardCounts
+	err := r.db.Raw(`
+		SELECT
+			(SELECT COUNT(*) FROM organization_members
+				WHERE organization_id = @org_id AND deleted_at IS NULL) AS members,
+			(SELECT COUNT(DISTINCT user_id) FROM time_logs
+				WHERE organization_id = @org_id AND deleted_at IS NULL
+					AND start_time < @day_end AND (end_time IS NULL OR end_time >= @day_start)) AS active_today,
+			(SELECT COUNT(DISTINCT user_id) FROM time_logs
+				WHERE organization_id = @org_id AND deleted_at IS NULL AND status = 'running') AS tracking_now,
+			(SELECT COUNT(*) FROM workspaces
+				WHERE organization_id = @org_id AND deleted_at IS NULL AND is_active = true AND is_archived = false) AS workspaces,
+			(SELECT COUNT(*) FROM screenshots
+				WHERE organization_id = @org_id AND deleted_at IS NULL) AS screenshots,
+			(SELECT COALESCE(SUM(file_size), 0) FROM screenshots
+				WHERE organization_id = @org_id AND deleted_at IS NULL) AS storage_used
+	`, map[string]interface{}{"org_id": orgID, "day_start": dayStart, "day_end": dayEnd}).Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+	return &counts, nil
+}
+
+// OrganizationDuration sums the organization's tracked time, reading
+// rolled-up days from the daily stats
+func (r *reportRepository) OrganizationDuration(orgID uint, start, end time.Time) (int64, error) {
+	rollupFrom, rollupTo, err := rollupCoverage(r.db)
+	if err != nil {
+		return 0, err
+	}
+
+	var duration int64
+	err = r.db.Raw(`
+		SELECT
+			(SELECT COALESCE(SUM(duration), 0) FROM daily_workspace_stats
+				WHERE organization_id = @org_id AND date >= @start AND date < @end
+					AND date >= @rollup_from AND date < @rollup_to)
+			+ (SELECT COALESCE(SUM(duration), 0) FROM time_logs
+				WHERE organization_id = @org_id AND deleted_at IS NULL AND start_time >= @start AND start_time < @end
+					AND (start_time < @rollup_from OR start_time >= @rollup_to))
+	`, map[string]interface{}{
+		"org_id": orgID, "start": start, "end": end,
+		"rollup_from": rollupFrom, "rollup_to": rollupTo,
+	}).Scan(&duration).Error
+	return duration, err
+}
+
+// TopOrganizationWorkspaces ranks the organization's workspaces by tracked
+// time, reading rolled-up days from the daily stats
+func (r *reportRepository) TopOrganizationWorkspaces(orgID uint, start, end time.Time, limit int) ([]dto.OrganizationWorkspaceStat, error) {
+	rollupFrom, rollupTo, err := rollupCoverage(r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaces := []dto.OrganizationWorkspaceStat{}
+	err = r.db.Raw(`
+		SELECT logs.workspace_id,
+			MAX(workspaces.name) AS name,
+			COALESCE(SUM(logs.duration), 0) AS duration,
+			COUNT(DISTINCT logs.user_id) AS members
+		FROM (
+			SELECT workspace_id, user_id, duration FROM daily_user_stats
+			WHERE organization_id = @org_id AND workspace_id <> 0 AND time_logs > 0
+				AND date >= @start AND date < @end AND date >= @rollup_from AND date < @rollup_to
+			UNION ALL
+			SELECT workspace_id, user_id, duration FROM time_logs
+			WHERE organization_id = @org_id AND deleted_at IS NULL AND start_time >= @start AND start_time < @end
+				AND (start_time < @rollup_from OR start_time >= @rollup_to)
+		) logs
+		JOIN workspaces ON workspaces.id = logs.workspace_id
+		GROUP BY logs.workspace_id
+		ORDER BY duration DESC
+		LIMIT @limit
+	`, map[string]interface{}{
+		"org_id": orgID, "start": start, "end": end,
+		"rollup_from": rollupFrom, "rollup_to": rollupTo, "limit": limit,
+	}).Scan(&workspaces).Error
+	return workspaces, err
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// ScreenshotUsage summarizes screenshots captured before a cutoff
+type ScreenshotUsage struct {
+	Count      int64      `gorm:"column:count"`
+	TotalBytes int64      `gorm:"column:total_bytes"`
+	Oldest     *time.Time `gorm:"column:oldest"`
+}
+
+// RetentionRepository handles data retention queries
+type RetentionRepository interface {
+	FindOrganizationsWithScreenshotRetention() ([]models.Organization, error)
+	GetScreenshotUsageBefore(orgID uint, before time.Time) (*ScreenshotUsage, error)
+	FindScreenshotsBefore(orgID uint, before time.Time, limit int) ([]models.Screenshot, error)
+	// FindSharedFilePaths returns the files of the screenshots that other
+	// live screenshots still reference, which must be kept
+	FindSharedFilePaths(ids []uint) (map[string]bool, error)
+	RollupAndDeleteScreenshots(ids []uint) error
+}
+
+type retentionRepository struct {
+	db *gorm.DB
+}
+
+// NewRetentionRepository creates a new retention repository
+func NewRetentionRepository(db *gorm.DB) RetentionRepository {
+	return &retentionRepository{db: db}
+}
+
+func (r *retentionRepository) FindOrganizationsWithScreenshotRetention() ([]models.Organization, error) {
+	var orgs []models.Organization
+	err := r.db.Where("screenshot_retention_days > 0").Find(&orgs).Error
+	return orgs, err
+}
+
+// GetScreenshotUsageBefore counts screenshots (including soft-deleted ones,
+// whose files are still on disk) captured before the cutoff
+func (r *retentionRepository) GetScreenshotUsageBefore(orgID uint, before time.Time) (*ScreenshotUsage, error) {
+	var usage ScreenshotUsage
+	err := r.db.Unscoped().Model(&models.Screenshot{}).
+		Select("COUNT(*) AS count, COALESCE(SUM(file_size), 0) AS total_bytes, MIN(captured_at) AS oldest").
+		Where("organization_id = ? AND captured_at < ?", orgID, before).
+		Scan(&usage).Error
+	return &usage, err
+}
+
+// FindScreenshotsBefore returns the oldest batch of screenshots captured before the cutoff
+func (r *retentionRepository) FindScreenshotsBefore(orgID uint, before time.Time, limit int) ([]models.Screenshot, error) {
+	var screenshots []models.Screenshot
+	err := r.db.Unscoped().
+		Where("organization_id = ? AND captured_at < ?", orgID, before).
+		Order("captured_at ASC").
+		Limit(limit).
+		Find(&screenshots).Error
+	return screenshots, err
+}
+
+func (r *retentionRepository) FindSharedFilePaths(ids []uint) (map[string]bool, error) {
+	return sharedFilePaths(r.db, ids)
+}
+
+// RollupAndDeleteScreenshots folds the screenshots into the daily rollups and
+// hard-deletes them in one transaction, so every screenshot is counted exactly
+// once: either as a live row or inside a rollup. Screenshots already
+// soft-deleted by their owner are dropped without being counted, as in reports.
+func (r *retentionRepository) RollupAndDeleteScreenshots(ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`
+			INSERT INTO screenshot_daily_rollups AS r (
+				date, organization_id, workspace_id, user_id, task_id,
+				screenshot_count, total_bytes, first_captured_at, last_captured_at,
+				created_at, updated_at
+			)
+			SELECT
+				DATE(s.captured_at),
+				s.organization_id,
+				COALESCE(s.workspace_id, 0),
+				s.user_id,
+				COALESCE(s.task_id, (SELECT t.id FROM tasks t WHERE s.task_local_id != '' AND t.local_id = s.task_local_id), 0),
+				COUNT(*),
+				COALESCE(SUM(s.file_size), 0),
+				MIN(s.captured_at),
+				MAX(s.captured_at),
+				NOW(),
+				NOW()
+			FROM screenshots s
+			WHERE s.id IN ? AND s.organization_id IS NOT NULL AND s.deleted_at IS NULL
+			GROUP BY 1, 2, 3, 4, 5
+			ON CONFLICT (date, organization_id, workspace_id, user_id, task_id) DO UPDATE SET
+				screenshot_count = r.screenshot_count + EXCLUDED.screenshot_count,
+				total_bytes = r.total_bytes + EXCLUDED.total_bytes,
+				first_captured_at = LEAST(r.first_captured_at, EXCLUDED.first_captured_at),
+				last_captured_at = GREATEST(r.last_captured_at, EXCLUDED.last_captured_at),
+				updated_at = NOW()
+		`, ids).Error
+		if err != nil {
+			return err
+		}
+
+		if err := subtractStorageUsage(tx, "id IN ?", ids); err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("id IN ?", ids).Delete(&models.Screenshot{}).Error
+	})
+}
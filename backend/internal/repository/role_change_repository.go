@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// RoleChangeRepository handles role change history data operations
+type RoleChangeRepository interface {
+	Create(changes []models.RoleChange) error
+	FindByMember(orgID, userID uint, page, perPage int) ([]models.RoleChange, int64, error)
+}
+
+type roleChangeRepository struct {
+	db *gorm.DB
+}
+
+// NewRoleChangeRepository creates a new role change repository
+func NewRoleChangeRepository(db *gorm.DB) RoleChangeRepository {
+	return &roleChangeRepository{db: db}
+}
+
+func (r *roleChangeRepository) Create(changes []models.RoleChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	return r.db.Create(&changes).Error
+}
+
+// FindByMember lists a member's role changes in an organization, including
+// their system role changes, newest first
+func (r *roleChangeRepository) FindByMember(orgID, userID uint, page, perPage int) ([]models.RoleChange, int64, error) {
+	var changes []models.RoleChange
+	var total int64
+
+	query := r.db.Model(&models.RoleChange{}).
+		Where("user_id = ? AND (organization_id = ? OR scope = ?)", userID, orgID, models.RoleScopeSystem)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * perPage
+	err := query.Preload("Actor").
+		Order("created_at DESC, id DESC").
+		Offset(offset).
+		Limit(perPage).
+		Find(&changes).Error
+	return changes, total, err
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SavedReportRepository handles saved custom reports and their email schedules
+type SavedReportRepository interface {
+	// Saved reports, loaded with their schedules
+	Create(report *models.SavedReport) error
+	FindByID(orgID, id uint) (*models.SavedReport, error)
+	FindByOwner(orgID, userID uint) ([]models.SavedReport, error)
+	Update(report *models.SavedReport) error
+	// Delete deletes the report and its schedules
+	Delete(id uint) error
+
+	// Schedules
+	CreateSchedule(schedule *models.ReportSchedule) error
+	FindSchedule(reportID, id uint) (*models.ReportSchedule, error)
+	UpdateSchedule(schedule *models.ReportSchedule) error
+	DeleteSchedule(id uint) error
+	// FindDueSchedules finds active schedules due by now, with their report and its owner
+	FindDueSchedules(now time.Time, limit int) ([]models.ReportSchedule, error)
+}
+
+type savedReportRepository struct {
+	db *gorm.DB
+}
+
+// NewSavedReportRepository creates a new saved report repository
+func NewSavedReportRepository(db *gorm.DB) SavedReportRepository {
+	return &savedReportRepository{db: db}
+}
+
+// ============================================================================
+// SAVED REPORTS
+// ============================================================================
+
+func (r *savedReportRepository) Create(report *models.SavedReport) error {
+	return r.db.Omit(clause.Associations).Create(report).Error
+}
+
+// FindByID finds a saved report scoped to its organization
+func (r *savedReportRepository) FindByID(orgID, id uint) (*models.SavedReport, error) {
+	var report models.SavedReport
+	err := r.db.
+		Preload("Schedules", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Where("id = ? AND organization_id = ?", id, orgID).
+		First(&report).Error
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+func (r *savedReportRepository) FindByOwner(orgID, userID uint) ([]models.SavedReport, error) {
+	var reports []models.SavedReport
+	err := r.db.
+		Preload("Schedules", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Where("organization_id = ? AND user_id = ?", orgID, userID).
+		Order("name ASC").
+		Find(&reports).Error
+	return reports, err
+}
+
+func (r *savedReportRepository) Update(report *models.SavedReport) error {
+	return r.db.Omit(clause.Associations).Save(report).Error
+}
+
+func (r *savedReportRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("saved_report_id = ?", id).Delete(&models.ReportSchedule{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.SavedReport{}, id).Error
+	})
+}
+
+// ============================================================================
+// SCHEDULES
+// ============================================================================
+
+func (r *savedReportRepository) CreateSchedule(schedule *models.ReportSchedule) error {
+	return r.db.Omit(clause.Associations).Create(schedule).Error
+}
+
+func (r *savedReportRepository) FindSchedule(reportID, id uint) (*models.ReportSchedule, error) {
+	var schedule models.ReportSchedule
+	err := r.db.Where("id = ? AND saved_report_id = ?", id, reportID).First(&schedule).Error
+	if err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+func (r *savedReportRepository) UpdateSchedule(schedule *models.ReportSchedule) error {
+	return r.db.Omit(clause.Associations).Save(schedule).Error
+}
+
+func (r *savedReportRepository) DeleteSchedule(id uint) error {
+	return r.db.Delete(&models.ReportSchedule{}, id).Error
+}
+
+func (r *savedReportRepository) FindDueSchedules(now time.Time, limit int) ([]models.ReportSchedule, error) {
+	var schedules []models.ReportSchedule
+	err := r.db.
+		Preload("SavedReport").
+		Preload("SavedReport.User").
+		Joins("JOIN saved_reports ON saved_reports.id = report_schedules.saved_report_id AND saved_reports.deleted_at IS NULL").
+		Where("report_schedules.is_active = true AND report_schedules.next_run_at <= ?", now).
+		Order("report_schedules.next_run_at ASC").
+		Limit(limit).
+		Find(&schedules).Error
+	return schedules, err
+}
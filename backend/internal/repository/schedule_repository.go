@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ScheduleRepository handles work schedule data operations
+type ScheduleRepository interface {
+	// Upsert creates the user's schedule or replaces the existing one
+	Upsert(schedule *models.WorkSchedule) error
+	FindByUserID(userID uint) (*models.WorkSchedule, error)
+	FindByUserIDs(userIDs []uint) (map[uint]models.WorkSchedule, error)
+	FindAll() ([]models.WorkSchedule, error)
+	List(page, pageSize int) ([]models.WorkSchedule, int64, error)
+	Delete(userID uint) (bool, error)
+
+	// SumTracked sums the user's tracked seconds for time logs started within [start, end)
+	SumTracked(userID uint, start, end time.Time) (int64, error)
+}
+
+type scheduleRepository struct {
+	db *gorm.DB
+}
+
+// NewScheduleRepository creates a new work schedule repository
+func NewScheduleRepository(db *gorm.DB) ScheduleRepository {
+	return &scheduleRepository{db: db}
+}
+
+func (r *scheduleRepository) Upsert(schedule *models.WorkSchedule) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"working_days", "expected_hours_per_day", "timezone", "updated_by", "updated_at"}),
+	}).Create(schedule).Error
+}
+
+func (r *scheduleRepository) FindByUserID(userID uint) (*models.WorkSchedule, error) {
+	var schedule models.WorkSchedule
+	err := r.db.Preload("User").Where("user_id = ?", userID).First(&schedule).Error
+	if err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+func (r *scheduleRepository) FindByUserIDs(userIDs []uint) (map[uint]models.WorkSchedule, error) {
+	schedules := make(map[uint]models.WorkSchedule, len(userIDs))
+	if len(userIDs) == 0 {
+		return schedules, nil
+	}
+
+	var rows []models.WorkSchedule
+	if err := r.db.Where("user_id IN ?", userIDs).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		schedules[row.UserID] = row
+	}
+	return schedules, nil
+}
+
+// FindAll returns every schedule with its user
+func (r *scheduleRepository) FindAll() ([]models.WorkSchedule, error) {
+	var schedules []models.WorkSchedule
+	err := r.db.Preload("User").Order("user_id").Find(&schedules).Error
+	return schedules, err
+}
+
+// List lists schedules with their users, ordered by user
+func (r *scheduleRepository) List(page, pageSize int) ([]models.WorkSchedule, int64, error) {
+	var schedules []models.WorkSchedule
+	var total int64
+
+	if err := r.db.Model(&models.WorkSchedule{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := r.db.Preload("User").
+		Order("user_id").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&schedules).Error
+	return schedules, total, err
+}
+
+func (r *scheduleRepository) Delete(userID uint) (bool, error) {
+	result := r.db.Where("user_id = ?", userID).Delete(&models.WorkSchedule{})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *scheduleRepository) SumTracked(userID uint, start, end time.Time) (int64, error) {
+	var total int64
+	err := r.db.Model(&models.TimeLog{}).
+		Select("COALESCE(SUM(duration), 0)").
+		Where("user_id = ? AND start_time >= ? AND start_time < ?", userID, start, end).
+		Scan(&total).Error
+	return total, err
+}
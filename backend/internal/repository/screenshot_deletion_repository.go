@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// ScreenshotDeletionRepository handles screenshot deletion request data operations
+type ScreenshotDeletionRepository interface {
+	Create(request *models.ScreenshotDeletionRequest) error
+	FindByID(id uint) (*models.ScreenshotDeletionRequest, error)
+	FindPendingByScreenshot(screenshotID uint) (*models.ScreenshotDeletionRequest, error)
+	FindByUser(userID uint, params *dto.ScreenshotDeletionRequestListParams) ([]models.ScreenshotDeletionRequest, int64, error)
+	FindByOrg(orgID uint, workspaceIDs []uint, params *dto.ScreenshotDeletionRequestListParams) ([]models.ScreenshotDeletionRequest, int64, error)
+	Update(request *models.ScreenshotDeletionRequest) error
+}
+
+type screenshotDeletionRepository struct {
+	db *gorm.DB
+}
+
+// NewScreenshotDeletionRepository creates a new screenshot deletion request repository
+func NewScreenshotDeletionRepository(db *gorm.DB) ScreenshotDeletionRepository {
+	return &screenshotDeletionRepository{db: db}
+}
+
+func (r *screenshotDeletionRepository) Create(request *models.ScreenshotDeletionRequest) error {
+	return r.db.Create(request).Error
+}
+
+func (r *screenshotDeletionRepository) FindByID(id uint) (*models.ScreenshotDeletionRequest, error) {
+	var request models.ScreenshotDeletionRequest
+	err := r.db.Preload("User").Preload("Reviewer").First(&request, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+// FindPendingByScreenshot returns the screenshot's pending request, or nil if there is none
+func (r *screenshotDeletionRepository) FindPendingByScreenshot(screenshotID uint) (*models.ScreenshotDeletionRequest, error) {
+	var requests []models.ScreenshotDeletionRequest
+	err := r.db.Where("screenshot_id = ? AND status = ?", screenshotID, models.DeletionRequestPending).
+		Limit(1).
+		Find(&requests).Error
+	if err != nil || len(requests) == 0 {
+		return nil, err
+	}
+	return &requests[0], nil
+}
+
+// FindByUser lists a user's own requests, newest first
+func (r *screenshotDeletionRepository) FindByUser(userID uint, params *dto.ScreenshotDeletionRequestListParams) ([]models.ScreenshotDeletionRequest, int64, error) {
+	query := r.db.Model(&models.ScreenshotDeletionRequest{}).Where("user_id = ?", userID)
+	return r.paginate(query, params)
+}
+
+// FindByOrg lists an organization's requests, newest first. A non-nil
+// workspaceIDs restricts the list to those workspaces.
+func (r *screenshotDeletionRepository) FindByOrg(orgID uint, workspaceIDs []uint, params *dto.ScreenshotDeletionRequestListParams) ([]models.ScreenshotDeletionRequest, int64, error) {
+	query := r.db.Model(&models.ScreenshotDeletionRequest{}).Where("organization_id = ?", orgID)
+	if workspaceIDs != nil {
+		query = query.Where("workspace_id IN ?", workspaceIDs)
+	}
+	if params.UserID != nil {
+		query = query.Where("user_id = ?", *params.UserID)
+	}
+	return r.paginate(query, params)
+}
+
+func (r *screenshotDeletionRepository) paginate(query *gorm.DB, params *dto.ScreenshotDeletionRequestListParams) ([]models.ScreenshotDeletionRequest, int64, error) {
+	var requests []models.ScreenshotDeletionRequest
+	var total int64
+
+	if params.Status != "" {
+		query = query.Where("status = ?", params.Status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (params.Page - 1) * params.PerPage
+	err := query.Preload("User").
+		Preload("Reviewer").
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(params.PerPage).
+		Find(&requests).Error
+	return requests, total, err
+}
+
+func (r *screenshotDeletionRepository) Update(request *models.ScreenshotDeletionRequest) error {
+	return r.db.Model(request).Select(
+		"status",
+		"reviewed_by",
+		"reviewed_at",
+		"review_note",
+		"updated_at",
+	).Updates(request).Error
+}
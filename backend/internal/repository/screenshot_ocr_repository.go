@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// ScreenshotOCRRepository stores the text read from screenshots and searches
+// it through the ocr_search_vector column PostgreSQL maintains
+type ScreenshotOCRRepository interface {
+	// FindPending returns hot screenshots waiting for OCR, in id order after
+	// afterID. Screenshots still waiting for or failing their malware scan
+	// are left out.
+	FindPending(afterID uint, limit int) ([]models.Screenshot, error)
+	// SetText records the text of a pending screenshot, and of the pending
+	// identical screenshots sharing its file
+	SetText(id uint, text string, at time.Time) error
+	// Search matches screenshot text against a to_tsquery expression built by
+	// the caller, most recent first
+	Search(query string, params *dto.AdminScreenshotSearchParams) ([]dto.AdminScreenshotSearchResult, error)
+}
+
+type screenshotOCRRepository struct {
+	db *gorm.DB
+}
+
+// NewScreenshotOCRRepository creates a new screenshot OCR repository
+func NewScreenshotOCRRepository(db *gorm.DB) ScreenshotOCRRepository {
+	return &screenshotOCRRepository{db: db}
+}
+
+func (r *screenshotOCRRepository) FindPending(afterID uint, limit int) ([]models.Screenshot, error) {
+	var screenshots []models.Screenshot
+	err := r.db.
+		Where("ocr_status = ? AND storage_tier = ? AND id > ?", models.ScreenshotOCRPending, models.StorageTierHot, afterID).
+		Where("scan_status NOT IN ?", []string{models.ScreenshotScanPending, models.ScreenshotScanQuarantined}).
+		Order("id ASC").
+		Limit(limit).
+		Find(&screenshots).Error
+	return screenshots, err
+}
+
+func (r *screenshotOCRRepository) SetText(id uint, text string, at time.Time) error {
+	return r.db.Model(&models.Screenshot{}).
+		Where("file_path = (?)", r.db.Model(&models.Screenshot{}).Select("file_path").Where("id = ?", id)).
+		Where("ocr_status = ?", models.ScreenshotOCRPending).
+		Updates(map[string]interface{}{
+			"ocr_status": models.ScreenshotOCRDone,
+			"ocr_text":   text,
+			"ocr_at":     at,
+		}).Error
+}
+
+func (r *screenshotOCRRepository) Search(query string, params *dto.AdminScreenshotSearchParams) ([]dto.AdminScreenshotSearchResult, error) {
+	sql := `
+		SELECT s.id, s.user_id, u.email AS user_email,
+			TRIM(COALESCE(u.first_name, '') || ' ' || COALESCE(u.last_name, '')) AS user_name,
+			s.organization_id AS org_id, s.workspace_id, s.time_log_id, s.captured_at,
+			ts_headline('simple', s.ocr_text, q.query, 'MaxFragments=2, MaxWords=20, MinWords=5') AS snippet
+		FROM screenshots s
+		JOIN users u ON u.id = s.user_id,
+			to_tsquery('simple', @query) q(query)
+		WHERE s.deleted_at IS NULL AND s.ocr_search_vector @@ q.query`
+	args := map[string]interface{}{
+		"query": query,
+		"limit": params.Limit,
+	}
+	if params.UserID != nil {
+		sql += " AND s.user_id = @user_id"
+		args["user_id"] = *params.UserID
+	}
+	if params.OrgID != nil {
+		sql += " AND s.organization_id = @org_id"
+		args["org_id"] = *params.OrgID
+	}
+	if params.StartDate != nil {
+		sql += " AND s.captured_at >= @start_date"
+		args["start_date"] = *params.StartDate
+	}
+	if params.EndDate != nil {
+		sql += " AND s.captured_at <= @end_date"
+		args["end_date"] = *params.EndDate
+	}
+	sql += " ORDER BY s.captured_at DESC LIMIT @limit"
+
+	var results []dto.AdminScreenshotSearchResult
+	err := r.db.Raw(sql, args).Scan(&results).Error
+	return results, err
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
 	"github.com/beuphecan/remote-time-tracker/internal/models"
 	"gorm.io/gorm"
 )
@@ -14,7 +15,13 @@ type ScreenshotRepository interface {
 	Create(screenshot *models.Screenshot) error
 	FindByID(id uint) (*models.Screenshot, error)
 	FindByLocalID(localID string, userID uint) (*models.Screenshot, error)
-	FindByUserID(userID uint, page, perPage int) ([]models.Screenshot, int64, error)
+	// FindDuplicate returns the user's latest hot, unencrypted screenshot of
+	// the organization with the same checksum and size captured in [from, to],
+	// whose file a new identical screenshot can share; nil when there is none
+	FindDuplicate(userID uint, orgID *uint, checksum string, fileSize int64, from, to time.Time) (*models.Screenshot, error)
+	// FindByUserID pages through the user's screenshots, leaving out those of
+	// the excluded workspaces
+	FindByUserID(userID uint, excludeWorkspaceIDs []uint, page, perPage int) ([]models.Screenshot, int64, error)
 	FindByTimeLogID(timeLogID uint) ([]models.Screenshot, error)
 	FindByTimeLogIDs(timeLogIDs []uint) ([]models.Screenshot, error)
 	FindByTaskID(taskID uint, userID uint) ([]models.Screenshot, error)
@@ -22,6 +29,12 @@ type ScreenshotRepository interface {
 	Update(screenshot *models.Screenshot) error
 	Delete(id uint) error
 	DeleteFile(filePath string) error
+	// FindPathsUnder returns the distinct original and blurred file paths
+	// under dir, including those of soft-deleted screenshots
+	FindPathsUnder(dir string) ([]string, error)
+	// ReplacePath points every screenshot using oldPath, as its original or
+	// blurred file, at newPath
+	ReplacePath(oldPath, newPath string) error
 	BatchCreate(screenshots []models.Screenshot) error
 	FindByDateRange(userID uint, startDate, endDate time.Time) ([]models.Screenshot, error)
 	DeleteOldScreenshots(beforeDate time.Time) error
@@ -37,8 +50,14 @@ func NewScreenshotRepository(db *gorm.DB) ScreenshotRepository {
 	return &screenshotRepository{db: db}
 }
 
+// Create stores the screenshot and adds it to its organization's storage counter
 func (r *screenshotRepository) Create(screenshot *models.Screenshot) error {
-	return r.db.Create(screenshot).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(screenshot).Error; err != nil {
+			return err
+		}
+		return addStorageUsage(tx, screenshot.OrganizationID, 1, screenshot.FileSize)
+	})
 }
 
 func (r *screenshotRepository) FindByID(id uint) (*models.Screenshot, error) {
@@ -46,7 +65,7 @@ func (r *screenshotRepository) FindByID(id uint) (*models.Screenshot, error) {
 	if err := r.db.Preload("User").Preload("TimeLog").Preload("Device").
 		First(&screenshot, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("screenshot not found")
+			return nil, apperror.NotFound("screenshot not found")
 		}
 		return nil, err
 	}
@@ -65,17 +84,25 @@ func (r *screenshotRepository) FindByLocalID(localID string, userID uint) (*mode
 	return &screenshot, nil
 }
 
-func (r *screenshotRepository) FindByUserID(userID uint, page, perPage int) ([]models.Screenshot, int64, error) {
+func (r *screenshotRepository) FindByUserID(userID uint, excludeWorkspaceIDs []uint, page, perPage int) ([]models.Screenshot, int64, error) {
 	var screenshots []models.Screenshot
 	var total int64
 
 	offset := (page - 1) * perPage
 
-	if err := r.db.Model(&models.Screenshot{}).Where("user_id = ?", userID).Count(&total).Error; err != nil {
+	scope := func(db *gorm.DB) *gorm.DB {
+		db = db.Where("user_id = ?", userID)
+		if len(excludeWorkspaceIDs) > 0 {
+			db = db.Where("workspace_id IS NULL OR workspace_id NOT IN ?", excludeWorkspaceIDs)
+		}
+		return db
+	}
+
+	if err := r.db.Model(&models.Screenshot{}).Scopes(scope).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	if err := r.db.Where("user_id = ?", userID).
+	if err := r.db.Scopes(scope).
 		Offset(offset).
 		Limit(perPage).
 		Order("captured_at DESC").
@@ -146,16 +173,50 @@ func (r *screenshotRepository) Update(screenshot *models.Screenshot) error {
 	return r.db.Save(screenshot).Error
 }
 
+// Delete soft-deletes the screenshot and takes it off its organization's
+// storage counter
 func (r *screenshotRepository) Delete(id uint) error {
-	return r.db.Delete(&models.Screenshot{}, id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := subtractStorageUsage(tx, "id = ?", id); err != nil {
+			return err
+		}
+		return tx.Delete(&models.Screenshot{}, id).Error
+	})
+}
+
+func (r *screenshotRepository) FindDuplicate(userID uint, orgID *uint, checksum string, fileSize int64, from, to time.Time) (*models.Screenshot, error) {
+	var screenshot models.Screenshot
+	err := r.db.
+		Where("user_id = ? AND organization_id IS NOT DISTINCT FROM ?", userID, orgID).
+		Where("checksum = ? AND file_size = ? AND captured_at BETWEEN ? AND ?", checksum, fileSize, from, to).
+		Where("is_encrypted = ? AND storage_tier = ? AND blurred_path = ''", false, models.StorageTierHot).
+		Order("captured_at DESC").
+		First(&screenshot).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &screenshot, nil
 }
 
-// DeleteFile deletes a screenshot file from disk
+// DeleteFile deletes a screenshot file from disk, unless live screenshots
+// still reference it: identical screenshots share one file, which goes with
+// the last of them. Call it after deleting the screenshot's row.
 func (r *screenshotRepository) DeleteFile(filePath string) error {
 	if filePath == "" {
 		return nil
 	}
 
+	var refs int64
+	if err := r.db.Model(&models.Screenshot{}).Where("file_path = ?", filePath).Count(&refs).Error; err != nil {
+		return err
+	}
+	if refs > 0 {
+		return nil
+	}
+
 	// Import os package at the top if not already imported
 	// Try to delete the file, ignore errors if file doesn't exist
 	if err := deleteFileIfExists(filePath); err != nil {
@@ -165,11 +226,42 @@ func (r *screenshotRepository) DeleteFile(filePath string) error {
 	return nil
 }
 
+func (r *screenshotRepository) FindPathsUnder(dir string) ([]string, error) {
+	var paths []string
+	err := r.db.Raw(`
+		SELECT file_path FROM screenshots WHERE starts_with(file_path, ?)
+		UNION
+		SELECT blurred_path FROM screenshots WHERE starts_with(blurred_path, ?)
+		ORDER BY 1`, dir, dir).Scan(&paths).Error
+	return paths, err
+}
+
+func (r *screenshotRepository) ReplacePath(oldPath, newPath string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&models.Screenshot{}).Where("file_path = ?", oldPath).
+			Update("file_path", newPath).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Model(&models.Screenshot{}).Where("blurred_path = ?", oldPath).
+			Update("blurred_path", newPath).Error
+	})
+}
+
 func (r *screenshotRepository) BatchCreate(screenshots []models.Screenshot) error {
 	if len(screenshots) == 0 {
 		return nil
 	}
-	return r.db.CreateInBatches(screenshots, 50).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.CreateInBatches(screenshots, 50).Error; err != nil {
+			return err
+		}
+		for i := range screenshots {
+			if err := addStorageUsage(tx, screenshots[i].OrganizationID, 1, screenshots[i].FileSize); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
 func (r *screenshotRepository) FindByDateRange(userID uint, startDate, endDate time.Time) ([]models.Screenshot, error) {
@@ -184,7 +276,12 @@ func (r *screenshotRepository) FindByDateRange(userID uint, startDate, endDate t
 }
 
 func (r *screenshotRepository) DeleteOldScreenshots(beforeDate time.Time) error {
-	return r.db.Where("captured_at < ?", beforeDate).Delete(&models.Screenshot{}).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := subtractStorageUsage(tx, "captured_at < ?", beforeDate); err != nil {
+			return err
+		}
+		return tx.Where("captured_at < ?", beforeDate).Delete(&models.Screenshot{}).Error
+	})
 }
 
 // CountTodayScreenshots counts screenshots captured today for a user
@@ -203,6 +300,29 @@ func (r *screenshotRepository) CountTodayScreenshots(userID uint) (int64, error)
 	return count, err
 }
 
+// sharedFilePaths returns the files of the given screenshots that live
+// screenshots outside them still reference, which must not be deleted with them
+func sharedFilePaths(db *gorm.DB, ids []uint) (map[string]bool, error) {
+	shared := make(map[string]bool)
+	if len(ids) == 0 {
+		return shared, nil
+	}
+
+	var paths []string
+	err := db.Model(&models.Screenshot{}).
+		Where("file_path IN (?)", db.Unscoped().Model(&models.Screenshot{}).Select("file_path").Where("id IN ?", ids)).
+		Where("id NOT IN ?", ids).
+		Distinct().
+		Pluck("file_path", &paths).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range paths {
+		shared[path] = true
+	}
+	return shared, nil
+}
+
 // Helper function to delete file if exists
 func deleteFileIfExists(filePath string) error {
 	if _, err := os.Stat(filePath); err == nil {
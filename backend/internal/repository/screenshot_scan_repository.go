@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// ScreenshotScanRepository records the malware scans of screenshot files
+type ScreenshotScanRepository interface {
+	FindByID(id uint) (*models.Screenshot, error)
+	// FindPending returns hot screenshots waiting for a scan since before the
+	// cutoff, in id order after afterID
+	FindPending(before time.Time, afterID uint, limit int) ([]models.Screenshot, error)
+	// SetResult records a scan of a pending screenshot, and of the pending
+	// identical screenshots sharing its file; those scanned meanwhile are
+	// left as they are
+	SetResult(id uint, status, signature string, at time.Time) error
+	// Release marks a quarantined screenshot as reviewed and safe
+	Release(id, reviewerID uint, at time.Time) error
+}
+
+type screenshotScanRepository struct {
+	db *gorm.DB
+}
+
+// NewScreenshotScanRepository creates a new screenshot scan repository
+func NewScreenshotScanRepository(db *gorm.DB) ScreenshotScanRepository {
+	return &screenshotScanRepository{db: db}
+}
+
+func (r *screenshotScanRepository) FindByID(id uint) (*models.Screenshot, error) {
+	var screenshot models.Screenshot
+	if err := r.db.First(&screenshot, id).Error; err != nil {
+		return nil, err
+	}
+	return &screenshot, nil
+}
+
+func (r *screenshotScanRepository) FindPending(before time.Time, afterID uint, limit int) ([]models.Screenshot, error) {
+	var screenshots []models.Screenshot
+	err := r.db.
+		Where("scan_status = ? AND storage_tier = ? AND created_at < ? AND id > ?",
+			models.ScreenshotScanPending, models.StorageTierHot, before, afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&screenshots).Error
+	return screenshots, err
+}
+
+func (r *screenshotScanRepository) SetResult(id uint, status, signature string, at time.Time) error {
+	return r.db.Model(&models.Screenshot{}).
+		Where("file_path = (?)", r.db.Model(&models.Screenshot{}).Select("file_path").Where("id = ?", id)).
+		Where("scan_status = ?", models.ScreenshotScanPending).
+		Updates(map[string]interface{}{
+			"scan_status":    status,
+			"scan_signature": signature,
+			"scanned_at":     at,
+		}).Error
+}
+
+func (r *screenshotScanRepository) Release(id, reviewerID uint, at time.Time) error {
+	return r.db.Model(&models.Screenshot{}).
+		Where("id = ? AND scan_status = ?", id, models.ScreenshotScanQuarantined).
+		Updates(map[string]interface{}{
+			"scan_status":    models.ScreenshotScanReleased,
+			"reviewed_by_id": reviewerID,
+			"reviewed_at":    at,
+		}).Error
+}
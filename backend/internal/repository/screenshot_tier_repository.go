@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// ScreenshotTierRepository tracks which storage tier screenshots live in
+type ScreenshotTierRepository interface {
+	FindByID(id uint) (*models.Screenshot, error)
+	// FindTierable returns hot screenshots captured before the cutoff and not
+	// retrieved from cold storage since, in id order after afterID
+	FindTierable(before time.Time, afterID uint, limit int) ([]models.Screenshot, error)
+	// MarkCold and MarkRetrieved move the screenshot together with the
+	// identical screenshots sharing its file
+	MarkCold(id uint, coldKey string, at time.Time) error
+	MarkRetrieved(id uint, at time.Time) error
+}
+
+type screenshotTierRepository struct {
+	db *gorm.DB
+}
+
+// NewScreenshotTierRepository creates a new screenshot tier repository
+func NewScreenshotTierRepository(db *gorm.DB) ScreenshotTierRepository {
+	return &screenshotTierRepository{db: db}
+}
+
+func (r *screenshotTierRepository) FindByID(id uint) (*models.Screenshot, error) {
+	var screenshot models.Screenshot
+	if err := r.db.First(&screenshot, id).Error; err != nil {
+		return nil, err
+	}
+	return &screenshot, nil
+}
+
+func (r *screenshotTierRepository) FindTierable(before time.Time, afterID uint, limit int) ([]models.Screenshot, error) {
+	var screenshots []models.Screenshot
+	err := r.db.
+		Where("storage_tier = ? AND captured_at < ? AND id > ?", models.StorageTierHot, before, afterID).
+		Where("retrieved_at IS NULL OR retrieved_at < ?", before).
+		Order("id ASC").
+		Limit(limit).
+		Find(&screenshots).Error
+	return screenshots, err
+}
+
+// sharingFile scopes to the screenshots referencing the file of screenshot id
+func (r *screenshotTierRepository) sharingFile(id uint) *gorm.DB {
+	return r.db.Model(&models.Screenshot{}).
+		Where("file_path = (?)", r.db.Unscoped().Model(&models.Screenshot{}).Select("file_path").Where("id = ?", id))
+}
+
+func (r *screenshotTierRepository) MarkCold(id uint, coldKey string, at time.Time) error {
+	return r.sharingFile(id).Updates(map[string]interface{}{
+		"storage_tier": models.StorageTierCold,
+		"cold_key":     coldKey,
+		"tiered_at":    at,
+	}).Error
+}
+
+func (r *screenshotTierRepository) MarkRetrieved(id uint, at time.Time) error {
+	return r.sharingFile(id).Updates(map[string]interface{}{
+		"storage_tier": models.StorageTierHot,
+		"retrieved_at": at,
+	}).Error
+}
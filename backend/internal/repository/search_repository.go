@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SearchRepository runs full-text searches over the search_vector columns
+// PostgreSQL maintains on tasks, time logs and users. Queries are to_tsquery
+// expressions built by the caller; results are limited to what the user may
+// see.
+type SearchRepository interface {
+	// SearchTasks matches tasks the user owns, is assigned to or can see as a
+	// workspace member or organization admin
+	SearchTasks(userID uint, query string, orgID *uint, limit int) ([]SearchRow, error)
+	// SearchTimeLogs matches the user's own time logs and those of the
+	// organizations they administer
+	SearchTimeLogs(userID uint, query string, orgID *uint, limit int) ([]SearchRow, error)
+	// SearchMembers matches members of the user's organizations, once per
+	// shared organization
+	SearchMembers(userID uint, query string, orgID *uint, limit int) ([]SearchRow, error)
+}
+
+// SearchRow is a search match, best ranked first
+type SearchRow struct {
+	ID             uint
+	Title          string
+	Snippet        string
+	OrganizationID *uint
+	WorkspaceID    *uint
+	UserID         uint
+	OccurredAt     time.Time
+	Rank           float64
+}
+
+// Organizations the user owns or belongs to, and those they administer
+const (
+	searchMemberOrgs = `SELECT id FROM organizations WHERE owner_id = @user_id AND deleted_at IS NULL
+		UNION SELECT organization_id FROM organization_members WHERE user_id = @user_id AND is_active = true AND deleted_at IS NULL`
+	searchAdminOrgs = `SELECT id FROM organizations WHERE owner_id = @user_id AND deleted_at IS NULL
+		UNION SELECT organization_id FROM organization_members WHERE user_id = @user_id AND role IN ('owner', 'admin') AND is_active = true AND deleted_at IS NULL`
+)
+
+type searchRepository struct {
+	db *gorm.DB
+}
+
+// NewSearchRepository creates a new search repository
+func NewSearchRepository(db *gorm.DB) SearchRepository {
+	return &searchRepository{db: db}
+}
+
+func (r *searchRepository) SearchTasks(userID uint, query string, orgID *uint, limit int) ([]SearchRow, error) {
+	sql := `
+		SELECT t.id, t.title, LEFT(COALESCE(t.description, ''), 200) AS snippet,
+			t.organization_id, t.workspace_id, t.user_id, t.created_at AS occurred_at,
+			ts_rank(t.search_vector, q.query) AS rank
+		FROM tasks t, to_tsquery('simple', @query) q(query)
+		WHERE t.deleted_at IS NULL AND t.search_vector @@ q.query
+			AND (t.user_id = @user_id
+				OR EXISTS (SELECT 1 FROM task_assignees ta WHERE ta.task_id = t.id AND ta.user_id = @user_id)
+				OR t.workspace_id IN (SELECT workspace_id FROM workspace_members WHERE user_id = @user_id AND is_active = true AND deleted_at IS NULL)
+				OR t.organization_id IN (` + searchAdminOrgs + `))`
+	return r.search(sql, "t", userID, query, orgID, limit)
+}
+
+func (r *searchRepository) SearchTimeLogs(userID uint, query string, orgID *uint, limit int) ([]SearchRow, error) {
+	sql := `
+		SELECT tl.id, COALESCE(tl.task_title, '') AS title, LEFT(COALESCE(tl.notes, ''), 200) AS snippet,
+			tl.organization_id, tl.workspace_id, tl.user_id, tl.start_time AS occurred_at,
+			ts_rank(tl.search_vector, q.query) AS rank
+		FROM time_logs tl, to_tsquery('simple', @query) q(query)
+		WHERE tl.deleted_at IS NULL AND tl.search_vector @@ q.query
+			AND (tl.user_id = @user_id OR tl.organization_id IN (` + searchAdminOrgs + `))`
+	return r.search(sql, "tl", userID, query, orgID, limit)
+}
+
+func (r *searchRepository) SearchMembers(userID uint, query string, orgID *uint, limit int) ([]SearchRow, error) {
+	sql := `
+		SELECT u.id, TRIM(COALESCE(u.first_name, '') || ' ' || COALESCE(u.last_name, '')) AS title, u.email AS snippet,
+			om.organization_id, NULL AS workspace_id, u.id AS user_id, om.joined_at AS occurred_at,
+			ts_rank(u.search_vector, q.query) AS rank
+		FROM users u
+		JOIN organization_members om ON om.user_id = u.id AND om.is_active = true AND om.deleted_at IS NULL,
+			to_tsquery('simple', @query) q(query)
+		WHERE u.deleted_at IS NULL AND u.anonymized_at IS NULL AND u.search_vector @@ q.query
+			AND om.organization_id IN (` + searchMemberOrgs + `)`
+	return r.search(sql, "om", userID, query, orgID, limit)
+}
+
+// search narrows a search to one organization when asked and ranks it
+func (r *searchRepository) search(sql, alias string, userID uint, query string, orgID *uint, limit int) ([]SearchRow, error) {
+	args := map[string]interface{}{
+		"user_id": userID,
+		"query":   query,
+		"limit":   limit,
+	}
+	if orgID != nil {
+		sql += " AND " + alias + ".organization_id = @org_id"
+		args["org_id"] = *orgID
+	}
+	sql += " ORDER BY rank DESC, occurred_at DESC LIMIT @limit"
+
+	var rows []SearchRow
+	err := r.db.Raw(sql, args).Scan(&rows).Error
+	return rows, err
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// ShareLinkRepository handles workspace report share links
+type ShareLinkRepository interface {
+	Create(link *models.ShareLink) error
+	FindByID(id uint) (*models.ShareLink, error)
+	FindByToken(token string) (*models.ShareLink, error)
+	// FindByWorkspace lists the workspace's share links, newest first
+	FindByWorkspace(workspaceID uint) ([]models.ShareLink, error)
+	Revoke(id uint, at time.Time) error
+	// RecordView counts a view of the link
+	RecordView(id uint, at time.Time) error
+}
+
+type shareLinkRepository struct {
+	db *gorm.DB
+}
+
+// NewShareLinkRepository creates a new share link repository
+func NewShareLinkRepository(db *gorm.DB) ShareLinkRepository {
+	return &shareLinkRepository{db: db}
+}
+
+func (r *shareLinkRepository) Create(link *models.ShareLink) error {
+	return r.db.Create(link).Error
+}
+
+func (r *shareLinkRepository) FindByID(id uint) (*models.ShareLink, error) {
+	var link models.ShareLink
+	if err := r.db.First(&link, id).Error; err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+func (r *shareLinkRepository) FindByToken(token string) (*models.ShareLink, error) {
+	var link models.ShareLink
+	if err := r.db.Where("token = ?", token).First(&link).Error; err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+func (r *shareLinkRepository) FindByWorkspace(workspaceID uint) ([]models.ShareLink, error) {
+	var links []models.ShareLink
+	err := r.db.Where("workspace_id = ?", workspaceID).
+		Order("created_at DESC, id DESC").
+		Find(&links).Error
+	return links, err
+}
+
+func (r *shareLinkRepository) Revoke(id uint, at time.Time) error {
+	return r.db.Model(&models.ShareLink{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at).Error
+}
+
+func (r *shareLinkRepository) RecordView(id uint, at time.Time) error {
+	return r.db.Model(&models.ShareLink{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"view_count":     gorm.Expr("view_count + 1"),
+		"last_viewed_at": at,
+	}).Error
+}
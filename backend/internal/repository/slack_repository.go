@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// SlackWorkspaceHours is a workspace's tracked time in a summary period
+type SlackWorkspaceHours struct {
+	WorkspaceID   uint
+	WorkspaceName string
+	Duration      int64 // Seconds
+	MemberCount   int64 // Members who tracked time
+}
+
+// SlackRepository handles organization Slack integration data operations
+type SlackRepository interface {
+	Create(integration *models.SlackIntegration) error
+	FindByOrg(orgID uint) (*models.SlackIntegration, error)
+	FindActive() ([]models.SlackIntegration, error)
+	Update(integration *models.SlackIntegration) error
+	Delete(id uint) error
+	SetPostResult(id uint, at time.Time, postErr string) error
+
+	// Notification data
+	FindTimeLogs(ids []uint) ([]models.TimeLog, error)
+	WorkspaceHours(orgID uint, start, end time.Time) ([]SlackWorkspaceHours, error)
+}
+
+type slackRepository struct {
+	db *gorm.DB
+}
+
+// NewSlackRepository creates a new Slack integration repository
+func NewSlackRepository(db *gorm.DB) SlackRepository {
+	return &slackRepository{db: db}
+}
+
+func (r *slackRepository) Create(integration *models.SlackIntegration) error {
+	return r.db.Create(integration).Error
+}
+
+func (r *slackRepository) FindByOrg(orgID uint) (*models.SlackIntegration, error) {
+	var integration models.SlackIntegration
+	err := r.db.Where("organization_id = ?", orgID).First(&integration).Error
+	if err != nil {
+		return nil, err
+	}
+	return &integration, nil
+}
+
+func (r *slackRepository) FindActive() ([]models.SlackIntegration, error) {
+	var integrations []models.SlackIntegration
+	err := r.db.Where("is_active = true").Order("id ASC").Find(&integrations).Error
+	return integrations, err
+}
+
+func (r *slackRepository) Update(integration *models.SlackIntegration) error {
+	return r.db.Save(integration).Error
+}
+
+func (r *slackRepository) Delete(id uint) error {
+	return r.db.Delete(&models.SlackIntegration{}, id).Error
+}
+
+// SetPostResult records the outcome of the latest post; a successful post
+// clears the last error
+func (r *slackRepository) SetPostResult(id uint, at time.Time, postErr string) error {
+	updates := map[string]interface{}{"last_error": postErr}
+	if postErr == "" {
+		updates["last_posted_at"] = at
+	}
+	return r.db.Model(&models.SlackIntegration{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// FindTimeLogs loads time logs with their user
+func (r *slackRepository) FindTimeLogs(ids []uint) ([]models.TimeLog, error) {
+	var logs []models.TimeLog
+	if len(ids) == 0 {
+		return logs, nil
+	}
+	err := r.db.Preload("User").Where("id IN ?", ids).Find(&logs).Error
+	return logs, err
+}
+
+// WorkspaceHours sums the time tracked in each of the organization's
+// workspaces by time logs started in [start, end), busiest first
+func (r *slackRepository) WorkspaceHours(orgID uint, start, end time.Time) ([]SlackWorkspaceHours, error) {
+	var rows []SlackWorkspaceHours
+	err := r.db.Raw(`
+		SELECT
+			workspaces.id as workspace_id,
+			workspaces.name as workspace_name,
+			COALESCE(SUM(time_logs.duration), 0) as duration,
+			COUNT(DISTINCT time_logs.user_id) as member_count
+		FROM time_logs
+		JOIN workspaces ON workspaces.id = time_logs.workspace_id AND workspaces.deleted_at IS NULL
+		WHERE time_logs.organization_id = ?
+			AND time_logs.deleted_at IS NULL
+			AND time_logs.start_time >= ? AND time_logs.start_time < ?
+		GROUP BY workspaces.id, workspaces.name
+		ORDER BY duration DESC
+	`, orgID, start, end).Scan(&rows).Error
+	return rows, err
+}
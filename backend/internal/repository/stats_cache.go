@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/cache"
+)
+
+// StatsCache caches the COUNT-heavy organization and workspace statistics of
+// the admin pages. A nil *StatsCache is
+// valid and disables caching, so repositories can call the invalidation hooks
+// unconditionally.
+type StatsCache struct {
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+// NewStatsCache creates a stats cache whose entries expire after ttl
+func NewStatsCache(c cache.Cache, ttl time.Duration) *StatsCache {
+	return &StatsCache{cache: c, ttl: ttl}
+}
+
+// StatsOverviewKey is where AdminAnalyticsService caches the platform
+// overview; membership changes drop it
+const StatsOverviewKey = "stats:overview"
+
+func statsOrgKey(orgID uint) string {
+	return fmt.Sprintf("stats:org:%d", orgID)
+}
+
+func statsWorkspaceKey(workspaceID uint) string {
+	return fmt.Sprintf("stats:workspace:%d", workspaceID)
+}
+
+// InvalidateOrg drops the cached stats of an organization and the overview
+func (s *StatsCache) InvalidateOrg(orgID uint) {
+	s.invalidate(statsOrgKey(orgID), StatsOverviewKey)
+}
+
+// InvalidateWorkspace drops the cached stats of a workspace and the overview
+func (s *StatsCache) InvalidateWorkspace(workspaceID uint) {
+	s.invalidate(statsWorkspaceKey(workspaceID), StatsOverviewKey)
+}
+
+// InvalidateOverview drops the cached platform overview
+func (s *StatsCache) InvalidateOverview() {
+	s.invalidate(StatsOverviewKey)
+}
+
+func (s *StatsCache) invalidate(keys ...string) {
+	if s == nil {
+		return
+	}
+	if err := s.cache.Delete(context.Background(), keys...); err != nil {
+		log.Printf("⚠️  Failed to invalidate stats cache: %v", err)
+	}
+}
+
+// load returns the cached value for key, or computes and caches it.
+// Cache errors fall back to the database rather than failing the request.
+func load[T any](s *StatsCache, key string, compute func() (*T, error)) (*T, error) {
+	if s == nil {
+		return compute()
+	}
+
+	ctx := context.Background()
+	var cached T
+	err := s.cache.Get(ctx, key, &cached)
+	if err == nil {
+		return &cached, nil
+	}
+	if !errors.Is(err, cache.ErrMiss) {
+		log.Printf("⚠️  Stats cache read failed for %s: %v", key, err)
+	}
+
+	value, err := compute()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.cache.Set(ctx, key, value, s.ttl); err != nil {
+		log.Printf("⚠️  Stats cache write failed for %s: %v", key, err)
+	}
+	return value, nil
+}
+
+// cachedAdminRepository serves the hot stats queries from the cache
+type cachedAdminRepository struct {
+	AdminRepository
+	stats *StatsCache
+}
+
+// NewCachedAdminRepository wraps an admin repository so GetOrgStats and
+// GetWorkspaceStats are cached
+func NewCachedAdminRepository(repo AdminRepository, stats *StatsCache) AdminRepository {
+	if stats == nil {
+		return repo
+	}
+	return &cachedAdminRepository{AdminRepository: repo, stats: stats}
+}
+
+func (r *cachedAdminRepository) GetOrgStats(orgID uint) (*OrgStats, error) {
+	return load(r.stats, statsOrgKey(orgID), func() (*OrgStats, error) {
+		return r.AdminRepository.GetOrgStats(orgID)
+	})
+}
+
+func (r *cachedAdminRepository) GetWorkspaceStats(workspaceID uint) (*WorkspaceStats, error) {
+	return load(r.stats, statsWorkspaceKey(workspaceID), func() (*WorkspaceStats, error) {
+		return r.AdminRepository.GetWorkspaceStats(workspaceID)
+	})
+}
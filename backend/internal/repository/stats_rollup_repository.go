@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// StatsRollupRepository rolls time logs and screenshots up into the daily
+// user and workspace stats read by dashboards
+type StatsRollupRepository interface {
+	// LastRolledUpDay returns the latest rolled-up UTC day, or nil when none is
+	LastRolledUpDay() (*time.Time, error)
+	// RollupDay recomputes a UTC day's stats, replacing earlier rollups of it
+	RollupDay(day time.Time) error
+}
+
+type statsRollupRepository struct {
+	db *gorm.DB
+}
+
+// NewStatsRollupRepository creates a new stats rollup repository
+func NewStatsRollupRepository(db *gorm.DB) StatsRollupRepository {
+	return &statsRollupRepository{db: db}
+}
+
+func (r *statsRollupRepository) LastRolledUpDay() (*time.Time, error) {
+	var last *time.Time
+	err := r.db.Model(&models.DailyStatsRollup{}).Select("MAX(date)").Scan(&last).Error
+	return last, err
+}
+
+func (r *statsRollupRepository) RollupDay(day time.Time) error {
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	args := map[string]interface{}{"day": day, "start": day, "end": day.AddDate(0, 0, 1)}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("date = ?", day).Delete(&models.DailyUserStat{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("date = ?", day).Delete(&models.DailyWorkspaceStat{}).Error; err != nil {
+			return err
+		}
+
+		// Screenshots purged by retention are counted from their rollups
+		err := tx.Exec(`
+			INSERT INTO daily_user_stats (
+				date, organization_id, workspace_id, user_id,
+				duration, time_logs, screenshots, created_at
+			)
+			SELECT @day, organization_id, workspace_id, user_id,
+				SUM(duration), SUM(time_logs), SUM(screenshots), NOW()
+			FROM (
+				SELECT COALESCE(organization_id, 0) AS organization_id, COALESCE(workspace_id, 0) AS workspace_id, user_id,
+					COALESCE(SUM(duration), 0) AS duration, COUNT(*) AS time_logs, 0 AS screenshots
+				FROM time_logs
+				WHERE deleted_at IS NULL AND start_time >= @start AND start_time < @end
+				GROUP BY 1, 2, 3
+				UNION ALL
+				SELECT COALESCE(organization_id, 0), COALESCE(workspace_id, 0), user_id, 0, 0, COUNT(*)
+				FROM screenshots
+				WHERE deleted_at IS NULL AND captured_at >= @start AND captured_at < @end
+				GROUP BY 1, 2, 3
+				UNION ALL
+				SELECT organization_id, workspace_id, user_id, 0, 0, SUM(screenshot_count)
+				FROM screenshot_daily_rollups
+				WHERE date = @day
+				GROUP BY 1, 2, 3
+			) day_stats
+			GROUP BY organization_id, workspace_id, user_id
+		`, args).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Exec(`
+			WITH hourly AS (
+				SELECT COALESCE(organization_id, 0) AS organization_id, COALESCE(workspace_id, 0) AS workspace_id,
+					EXTRACT(HOUR FROM start_time AT TIME ZONE 'UTC')::int AS hour, COUNT(*) AS time_logs
+				FROM time_logs
+				WHERE deleted_at IS NULL AND start_time >= @start AND start_time < @end
+				GROUP BY 1, 2, 3
+			), by_hour AS (
+				SELECT w.organization_id, w.workspace_id,
+					jsonb_agg(COALESCE(h.time_logs, 0) ORDER BY hours.hour) AS time_logs_by_hour
+				FROM (SELECT DISTINCT organization_id, workspace_id FROM hourly) w
+				CROSS JOIN generate_series(0, 23) AS hours(hour)
+				LEFT JOIN hourly h ON h.organization_id = w.organization_id
+					AND h.workspace_id = w.workspace_id AND h.hour = hours.hour
+				GROUP BY w.organization_id, w.workspace_id
+			)
+			INSERT INTO daily_workspace_stats (
+				date, organization_id, workspace_id,
+				duration, time_logs, active_users, screenshots, time_logs_by_hour, created_at
+			)
+			SELECT u.date, u.organization_id, u.workspace_id,
+				SUM(u.duration), SUM(u.time_logs), COUNT(*) FILTER (WHERE u.time_logs > 0), SUM(u.screenshots),
+				COALESCE(b.time_logs_by_hour, '[]'), NOW()
+			FROM daily_user_stats u
+			LEFT JOIN by_hour b ON b.organization_id = u.organization_id AND b.workspace_id = u.workspace_id
+			WHERE u.date = @day
+			GROUP BY u.date, u.organization_id, u.workspace_id, b.time_logs_by_hour
+		`, args).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Exec(`
+			INSERT INTO daily_stats_rollups (date, rolled_up_at) VALUES (@day, NOW())
+			ON CONFLICT (date) DO UPDATE SET rolled_up_at = EXCLUDED.rolled_up_at
+		`, args).Error
+	})
+}
+
+// rollupCoverage returns the rolled-up UTC days [from, to), which queries read
+// from the daily stats; from equals to when nothing is rolled up. Days outside
+// it, such as today, are read from the raw tables.
+func rollupCoverage(db *gorm.DB) (from, to time.Time, err error) {
+	var days struct {
+		First *time.Time
+		Last  *time.Time
+	}
+	err = db.Model(&models.DailyStatsRollup{}).
+		Select("MIN(date) AS first, MAX(date) AS last").
+		Scan(&days).Error
+	if err != nil || days.First == nil || days.Last == nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return *days.First, days.Last.AddDate(0, 0, 1), nil
+}
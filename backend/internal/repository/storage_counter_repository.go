@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// StorageCounterRepository reads the organization screenshot storage
+// counters that quotas are checked against
+type StorageCounterRepository interface {
+	// Get returns the organization's counter, counting its screenshots when
+	// it has none yet
+	Get(orgID uint) (*models.OrganizationStorageCounter, error)
+	// Recount recomputes the counter from the screenshots
+	Recount(orgID uint) (*models.OrganizationStorageCounter, error)
+}
+
+type storageCounterRepository struct {
+	db *gorm.DB
+}
+
+// NewStorageCounterRepository creates a new storage counter repository
+func NewStorageCounterRepository(db *gorm.DB) StorageCounterRepository {
+	return &storageCounterRepository{db: db}
+}
+
+// countStorageSQL counts an organization's live screenshots into its counter
+const countStorageSQL = `
+	INSERT INTO organization_storage_counters AS c (organization_id, screenshot_count, used_bytes, updated_at)
+	SELECT @org_id, COUNT(*), COALESCE(SUM(file_size), 0), NOW()
+	FROM screenshots
+	WHERE organization_id = @org_id AND deleted_at IS NULL
+`
+
+func (r *storageCounterRepository) Get(orgID uint) (*models.OrganizationStorageCounter, error) {
+	err := r.db.Exec(countStorageSQL+`ON CONFLICT (organization_id) DO NOTHING`,
+		map[string]interface{}{"org_id": orgID}).Error
+	if err != nil {
+		return nil, err
+	}
+	return r.find(orgID)
+}
+
+func (r *storageCounterRepository) Recount(orgID uint) (*models.OrganizationStorageCounter, error) {
+	err := r.db.Exec(countStorageSQL+`ON CONFLICT (organization_id) DO UPDATE SET
+			screenshot_count = EXCLUDED.screenshot_count,
+			used_bytes = EXCLUDED.used_bytes,
+			updated_at = EXCLUDED.updated_at`,
+		map[string]interface{}{"org_id": orgID}).Error
+	if err != nil {
+		return nil, err
+	}
+	return r.find(orgID)
+}
+
+func (r *storageCounterRepository) find(orgID uint) (*models.OrganizationStorageCounter, error) {
+	var counter models.OrganizationStorageCounter
+	if err := r.db.Where("organization_id = ?", orgID).First(&counter).Error; err != nil {
+		return nil, err
+	}
+	return &counter, nil
+}
+
+// addStorageUsage adjusts an organization's counter by a screenshot upload
+// or delete. Organizations without a counter are skipped: it is counted
+// from the screenshots when first read.
+func addStorageUsage(db *gorm.DB, orgID *uint, count, bytes int64) error {
+	if orgID == nil {
+		return nil
+	}
+	return db.Exec(`
+		UPDATE organization_storage_counters
+		SET screenshot_count = GREATEST(screenshot_count + ?, 0),
+			used_bytes = GREATEST(used_bytes + ?, 0),
+			updated_at = NOW()
+		WHERE organization_id = ?
+	`, count, bytes, *orgID).Error
+}
+
+// subtractStorageUsage takes the live screenshots matching the condition off
+// their organizations' counters; call it before deleting them
+func subtractStorageUsage(db *gorm.DB, query string, args ...interface{}) error {
+	deleted := db.Model(&models.Screenshot{}).
+		Select("organization_id, COUNT(*) AS screenshot_count, COALESCE(SUM(file_size), 0) AS used_bytes").
+		Where(query, args...).
+		Where("organization_id IS NOT NULL").
+		Group("organization_id")
+	return db.Exec(`
+		UPDATE organization_storage_counters AS c
+		SET screenshot_count = GREATEST(c.screenshot_count - d.screenshot_count, 0),
+			used_bytes = GREATEST(c.used_bytes - d.used_bytes, 0),
+			updated_at = NOW()
+		FROM (?) AS d
+		WHERE c.organization_id = d.organization_id
+	`, deleted).Error
+}
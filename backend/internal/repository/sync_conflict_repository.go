@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// SyncConflictRepository handles sync conflict data operations
+type SyncConflictRepository interface {
+	Create(conflict *models.SyncConflict) error
+	FindByID(id, userID uint) (*models.SyncConflict, error)
+	FindByUserID(userID uint, status string, page, perPage int) ([]models.SyncConflict, int64, error)
+	FindPendingByTimeLog(timeLogID uint) ([]models.SyncConflict, error)
+	Update(conflict *models.SyncConflict) error
+}
+
+type syncConflictRepository struct {
+	db *gorm.DB
+}
+
+// NewSyncConflictRepository creates a new sync conflict repository
+func NewSyncConflictRepository(db *gorm.DB) SyncConflictRepository {
+	return &syncConflictRepository{db: db}
+}
+
+func (r *syncConflictRepository) Create(conflict *models.SyncConflict) error {
+	return r.db.Create(conflict).Error
+}
+
+// FindByID finds a conflict scoped to its user
+func (r *syncConflictRepository) FindByID(id, userID uint) (*models.SyncConflict, error) {
+	var conflict models.SyncConflict
+	if err := r.db.Where("id = ? AND user_id = ?", id, userID).First(&conflict).Error; err != nil {
+		return nil, err
+	}
+	return &conflict, nil
+}
+
+// FindByUserID lists a user's conflicts, newest first. An empty status returns all.
+func (r *syncConflictRepository) FindByUserID(userID uint, status string, page, perPage int) ([]models.SyncConflict, int64, error) {
+	var conflicts []models.SyncConflict
+	var total int64
+
+	query := r.db.Model(&models.SyncConflict{}).Where("user_id = ?", userID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * perPage
+	err := query.Order("created_at DESC").
+		Offset(offset).
+		Limit(perPage).
+		Find(&conflicts).Error
+	return conflicts, total, err
+}
+
+// FindPendingByTimeLog finds a time log's unresolved conflicts
+func (r *syncConflictRepository) FindPendingByTimeLog(timeLogID uint) ([]models.SyncConflict, error) {
+	var conflicts []models.SyncConflict
+	err := r.db.Where("time_log_id = ? AND status = ?", timeLogID, models.SyncConflictPending).
+		Find(&conflicts).Error
+	return conflicts, err
+}
+
+func (r *syncConflictRepository) Update(conflict *models.SyncConflict) error {
+	return r.db.Save(conflict).Error
+}
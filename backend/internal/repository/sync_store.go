@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+)
+
+// SyncRepos are the repositories a batch sync writes through
+type SyncRepos struct {
+	TimeLogs    TimeLogRepository
+	Tasks       TaskRepository
+	Screenshots ScreenshotRepository
+	Conflicts   SyncConflictRepository
+	Edits       TimeLogEditRepository
+}
+
+// SyncStore runs sync writes in a database transaction
+type SyncStore interface {
+	// Transaction calls fn with repositories bound to one transaction. It
+	// commits when fn returns nil and rolls back otherwise.
+	Transaction(fn func(repos SyncRepos) error) error
+}
+
+type syncStore struct {
+	db *gorm.DB
+}
+
+// NewSyncStore creates a new sync store
+func NewSyncStore(db *gorm.DB) SyncStore {
+	return &syncStore{db: db}
+}
+
+func (r *syncStore) Transaction(fn func(repos SyncRepos) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(SyncRepos{
+			TimeLogs:    NewTimeLogRepository(tx),
+			Tasks:       NewTaskRepository(tx),
+			Screenshots: NewScreenshotRepository(tx),
+			Conflicts:   NewSyncConflictRepository(tx),
+			Edits:       NewTimeLogEditRepository(tx),
+		})
+	})
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// TaskAssignmentRepository handles task assignment rule data operations
+type TaskAssignmentRepository interface {
+	Create(rule *models.TaskAssignmentRule) error
+	FindByID(workspaceID, id uint) (*models.TaskAssignmentRule, error)
+	FindByWorkspace(workspaceID uint) ([]models.TaskAssignmentRule, error)
+	FindActiveByWorkspace(workspaceID uint) ([]models.TaskAssignmentRule, error)
+	Update(rule *models.TaskAssignmentRule) error
+	SetLastAssigned(id, userID uint) error
+	Delete(id uint) error
+}
+
+type taskAssignmentRepository struct {
+	db *gorm.DB
+}
+
+// NewTaskAssignmentRepository creates a new task assignment rule repository
+func NewTaskAssignmentRepository(db *gorm.DB) TaskAssignmentRepository {
+	return &taskAssignmentRepository{db: db}
+}
+
+func (r *taskAssignmentRepository) Create(rule *models.TaskAssignmentRule) error {
+	return r.db.Create(rule).Error
+}
+
+// FindByID finds a rule scoped to its workspace
+func (r *taskAssignmentRepository) FindByID(workspaceID, id uint) (*models.TaskAssignmentRule, error) {
+	var rule models.TaskAssignmentRule
+	err := r.db.Where("id = ? AND workspace_id = ?", id, workspaceID).First(&rule).Error
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *taskAssignmentRepository) FindByWorkspace(workspaceID uint) ([]models.TaskAssignmentRule, error) {
+	var rules []models.TaskAssignmentRule
+	err := r.db.Where("workspace_id = ?", workspaceID).Order("priority ASC, id ASC").Find(&rules).Error
+	return rules, err
+}
+
+func (r *taskAssignmentRepository) FindActiveByWorkspace(workspaceID uint) ([]models.TaskAssignmentRule, error) {
+	var rules []models.TaskAssignmentRule
+	err := r.db.Where("workspace_id = ? AND is_active = true", workspaceID).Order("priority ASC, id ASC").Find(&rules).Error
+	return rules, err
+}
+
+func (r *taskAssignmentRepository) Update(rule *models.TaskAssignmentRule) error {
+	return r.db.Save(rule).Error
+}
+
+// SetLastAssigned moves a rule's round-robin cursor
+func (r *taskAssignmentRepository) SetLastAssigned(id, userID uint) error {
+	return r.db.Model(&models.TaskAssignmentRule{}).
+		Where("id = ?", id).
+		Update("last_assigned_user_id", userID).Error
+}
+
+func (r *taskAssignmentRepository) Delete(id uint) error {
+	return r.db.Delete(&models.TaskAssignmentRule{}, id).Error
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// TaskBoardRepository handles workspace Kanban board columns and task placement
+type TaskBoardRepository interface {
+	// FindStatuses returns the workspace's configured columns in board order;
+	// empty when it uses the defaults
+	FindStatuses(workspaceID uint) ([]models.WorkspaceTaskStatus, error)
+	// ReplaceStatuses swaps the workspace's columns. Tasks in removed columns
+	// fall back to the first column of their status, and tasks in columns
+	// whose category changed take the new status.
+	ReplaceStatuses(workspaceID uint, statuses []models.WorkspaceTaskStatus) error
+
+	// FindBoardTasks returns the workspace's tasks in board order with their owners
+	FindBoardTasks(workspaceID uint, includeArchived bool) ([]models.Task, error)
+	FindTasksByIDs(workspaceID uint, ids []uint) ([]models.Task, error)
+	// MoveTasks places tasks in a column in the given order
+	MoveTasks(taskIDs []uint, boardStatus, status string) error
+}
+
+type taskBoardRepository struct {
+	db *gorm.DB
+}
+
+// NewTaskBoardRepository creates a new task board repository
+func NewTaskBoardRepository(db *gorm.DB) TaskBoardRepository {
+	return &taskBoardRepository{db: db}
+}
+
+func (r *taskBoardRepository) FindStatuses(workspaceID uint) ([]models.WorkspaceTaskStatus, error) {
+	var statuses []models.WorkspaceTaskStatus
+	err := r.db.Where("workspace_id = ?", workspaceID).Order("position ASC, id ASC").Find(&statuses).Error
+	return statuses, err
+}
+
+func (r *taskBoardRepository) ReplaceStatuses(workspaceID uint, statuses []models.WorkspaceTaskStatus) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("workspace_id = ?", workspaceID).Delete(&models.WorkspaceTaskStatus{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&statuses).Error; err != nil {
+			return err
+		}
+
+		keys := make([]string, len(statuses))
+		for i, status := range statuses {
+			keys[i] = status.Key
+			if err := tx.Model(&models.Task{}).
+				Where("workspace_id = ? AND board_status = ? AND status <> ?", workspaceID, status.Key, status.Category).
+				Update("status", status.Category).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&models.Task{}).
+			Where("workspace_id = ? AND board_status <> '' AND board_status NOT IN ?", workspaceID, keys).
+			Updates(map[string]interface{}{"board_status": "", "board_position": 0}).Error
+	})
+}
+
+func (r *taskBoardRepository) FindBoardTasks(workspaceID uint, includeArchived bool) ([]models.Task, error) {
+	query := r.db.Preload("User").Where("workspace_id = ?", workspaceID)
+	if !includeArchived {
+		query = query.Where("status <> ?", models.TaskStatusArchived)
+	}
+
+	var tasks []models.Task
+	err := query.Order("board_position ASC, created_at DESC").Find(&tasks).Error
+	return tasks, err
+}
+
+func (r *taskBoardRepository) FindTasksByIDs(workspaceID uint, ids []uint) ([]models.Task, error) {
+	var tasks []models.Task
+	err := r.db.Where("workspace_id = ? AND id IN ?", workspaceID, ids).Find(&tasks).Error
+	return tasks, err
+}
+
+func (r *taskBoardRepository) MoveTasks(taskIDs []uint, boardStatus, status string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for position, id := range taskIDs {
+			err := tx.Model(&models.Task{}).
+				Where("id = ?", id).
+				Updates(map[string]interface{}{
+					"board_status":   boardStatus,
+					"board_position": position,
+					"status":         status,
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// TaskCommentRepository handles task discussions: comments and attachments
+type TaskCommentRepository interface {
+	// Comments
+	Create(comment *models.TaskComment) error
+	FindByID(id uint) (*models.TaskComment, error)
+	Update(comment *models.TaskComment) error
+	// Delete soft-deletes the comment and removes its attachment records
+	Delete(id uint) error
+	// FindByTaskID returns the task's comments, oldest first, with their authors
+	FindByTaskID(taskID uint) ([]TaskCommentRow, error)
+
+	// Attachments
+	CreateAttachment(attachment *models.TaskAttachment) error
+	FindAttachmentByID(id uint) (*models.TaskAttachment, error)
+	FindAttachments(taskID uint) ([]models.TaskAttachment, error)
+	FindAttachmentsByCommentID(commentID uint) ([]models.TaskAttachment, error)
+	DeleteAttachment(id uint) error
+	// FindAttachmentsUnder returns attachments whose file is stored under dir
+	FindAttachmentsUnder(dir string) ([]models.TaskAttachment, error)
+	UpdateAttachmentPath(id uint, filePath string) error
+}
+
+// TaskCommentRow is a task comment with its author's details
+type TaskCommentRow struct {
+	models.TaskComment
+	Email     string
+	FirstName string
+	LastName  string
+}
+
+type taskCommentRepository struct {
+	db *gorm.DB
+}
+
+// NewTaskCommentRepository creates a new task comment repository
+func NewTaskCommentRepository(db *gorm.DB) TaskCommentRepository {
+	return &taskCommentRepository{db: db}
+}
+
+func (r *taskCommentRepository) Create(comment *models.TaskComment) error {
+	return r.db.Create(comment).Error
+}
+
+func (r *taskCommentRepository) FindByID(id uint) (*models.TaskComment, error) {
+	var comment models.TaskComment
+	if err := r.db.First(&comment, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NotFound("comment not found")
+		}
+		return nil, err
+	}
+	return &comment, nil
+}
+
+func (r *taskCommentRepository) Update(comment *models.TaskComment) error {
+	return r.db.Save(comment).Error
+}
+
+func (r *taskCommentRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("comment_id = ?", id).Delete(&models.TaskAttachment{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.TaskComment{}, id).Error
+	})
+}
+
+func (r *taskCommentRepository) FindByTaskID(taskID uint) ([]TaskCommentRow, error) {
+	var rows []TaskCommentRow
+	err := r.db.Model(&models.TaskComment{}).
+		Select("task_comments.*, users.email, users.first_name, users.last_name").
+		Joins("LEFT JOIN users ON users.id = task_comments.author_id").
+		Where("task_comments.task_id = ?", taskID).
+		Order("task_comments.created_at ASC, task_comments.id ASC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *taskCommentRepository) CreateAttachment(attachment *models.TaskAttachment) error {
+	return r.db.Create(attachment).Error
+}
+
+func (r *taskCommentRepository) FindAttachmentByID(id uint) (*models.TaskAttachment, error) {
+	var attachment models.TaskAttachment
+	if err := r.db.First(&attachment, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NotFound("attachment not found")
+		}
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+func (r *taskCommentRepository) FindAttachments(taskID uint) ([]models.TaskAttachment, error) {
+	var attachments []models.TaskAttachment
+	err := r.db.Where("task_id = ?", taskID).Order("created_at ASC, id ASC").Find(&attachments).Error
+	return attachments, err
+}
+
+func (r *taskCommentRepository) FindAttachmentsByCommentID(commentID uint) ([]models.TaskAttachment, error) {
+	var attachments []models.TaskAttachment
+	err := r.db.Where("comment_id = ?", commentID).Find(&attachments).Error
+	return attachments, err
+}
+
+func (r *taskCommentRepository) DeleteAttachment(id uint) error {
+	return r.db.Delete(&models.TaskAttachment{}, id).Error
+}
+
+func (r *taskCommentRepository) FindAttachmentsUnder(dir string) ([]models.TaskAttachment, error) {
+	var attachments []models.TaskAttachment
+	err := r.db.Where("starts_with(file_path, ?)", dir).Order("id ASC").Find(&attachments).Error
+	return attachments, err
+}
+
+func (r *taskCommentRepository) UpdateAttachmentPath(id uint, filePath string) error {
+	return r.db.Model(&models.TaskAttachment{}).Where("id = ?", id).Update("file_path", filePath).Error
+}
@@ -2,8 +2,11 @@ package repository
 
 import (
 	"errors"
+	"strings"
 	"time"
 
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
 	"github.com/beuphecan/remote-time-tracker/internal/models"
 	"gorm.io/gorm"
 )
@@ -15,11 +18,45 @@ type TaskRepository interface {
 	FindByLocalID(localID string, userID uint) (*models.Task, error)
 	FindByUserID(userID uint, page, perPage int) ([]models.Task, int64, error)
 	FindByUserIDAndTitle(userID uint, title string) (*models.Task, error)
-	FindByUserIDWithStats(userID uint, page, perPage int) ([]map[string]interface{}, int64, error)
+	// FindByTitleTrackedBetween finds the user's earliest task in the
+	// workspace with the title that they tracked time on within [start, end)
+	FindByTitleTrackedBetween(userID uint, workspaceID *uint, title string, start, end time.Time) (*models.Task, error)
+	FindByUserIDWithStats(userID uint, filter *dto.TaskFilter, page, perPage int) ([]map[string]interface{}, int64, error)
 	FindActiveByUserIDWithStats(userID uint) ([]map[string]interface{}, error)
 	Update(task *models.Task) error
 	Delete(id uint) error
 	FindActiveByUserID(userID uint) ([]models.Task, error)
+	// TrackedDurations sums the time logged on each task, in seconds
+	TrackedDurations(tasks []models.Task) (map[uint]int64, error)
+
+	// Subtasks
+	// FindAncestorIDs returns the task's parent, grandparent and so on
+	FindAncestorIDs(id uint) ([]uint, error)
+	// FindSubtree returns the task and its subtasks at any depth, with owners
+	FindSubtree(id uint) ([]models.Task, error)
+	// DetachSubtasks makes the task's direct subtasks top-level tasks
+	DetachSubtasks(parentID uint) error
+
+	// Additional assignees
+	AddAssignee(assignee *models.TaskAssignee) error
+	// RemoveAssignee reports whether the user was assigned
+	RemoveAssignee(taskID, userID uint) (bool, error)
+	FindAssignees(taskID uint) ([]TaskAssigneeRow, error)
+	IsAssignee(taskID, userID uint) (bool, error)
+}
+
+// TaskAssigneeRow is an additional task assignee with their user details
+type TaskAssigneeRow struct {
+	models.TaskAssignee
+	Email     string
+	FirstName string
+	LastName  string
+}
+
+// visibleToUser matches the tasks a user owns or is assigned to, on the tasks
+// table alias; it takes the user ID twice
+func visibleToUser(alias string) string {
+	return "(" + alias + ".user_id = ? OR EXISTS (SELECT 1 FROM task_assignees ta WHERE ta.task_id = " + alias + ".id AND ta.user_id = ?))"
 }
 
 type taskRepository struct {
@@ -39,16 +76,17 @@ func (r *taskRepository) FindByID(id uint) (*models.Task, error) {
 	var task models.Task
 	if err := r.db.Preload("User").First(&task, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("task not found")
+			return nil, apperror.NotFound("task not found")
 		}
 		return nil, err
 	}
 	return &task, nil
 }
 
+// FindByLocalID finds a task the user owns or is assigned to by its desktop UUID
 func (r *taskRepository) FindByLocalID(localID string, userID uint) (*models.Task, error) {
 	var task models.Task
-	if err := r.db.Where("local_id = ? AND user_id = ?", localID, userID).First(&task).Error; err != nil {
+	if err := r.db.Where("tasks.local_id = ? AND "+visibleToUser("tasks"), localID, userID, userID).First(&task).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil // Not found is not an error
 		}
@@ -89,8 +127,28 @@ func (r *taskRepository) FindByUserIDAndTitle(userID uint, title string) (*model
 	return &task, nil
 }
 
+func (r *taskRepository) FindByTitleTrackedBetween(userID uint, workspaceID *uint, title string, start, end time.Time) (*models.Task, error) {
+	query := r.db.Where("tasks.user_id = ? AND tasks.title = ?", userID, title).
+		Where("EXISTS (SELECT 1 FROM time_logs WHERE time_logs.task_id = tasks.id AND time_logs.user_id = ? AND time_logs.start_time >= ? AND time_logs.start_time < ? AND time_logs.deleted_at IS NULL)",
+			userID, start, end)
+	if workspaceID != nil {
+		query = query.Where("tasks.workspace_id = ?", *workspaceID)
+	} else {
+		query = query.Where("tasks.workspace_id IS NULL")
+	}
+
+	var task models.Task
+	if err := query.Order("tasks.created_at ASC").First(&task).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil // Not found is not an error
+		}
+		return nil, err
+	}
+	return &task, nil
+}
+
 func (r *taskRepository) Update(task *models.Task) error {
-	return r.db.Save(task).Error
+	return updateVersioned(r.db, task, &task.Version)
 }
 
 func (r *taskRepository) Delete(id uint) error {
@@ -109,27 +167,43 @@ func (r *taskRepository) FindActiveByUserID(userID uint) ([]models.Task, error)
 
 // TaskWithStatsRow represents a row from the SQL query with stats
 type TaskWithStatsRow struct {
-	ID              uint      `gorm:"column:id"`
-	Title           string    `gorm:"column:title"`
-	Description     *string   `gorm:"column:description"` // Nullable
-	Status          string    `gorm:"column:status"`
-	Priority        int       `gorm:"column:priority"`
-	Color           *string   `gorm:"column:color"` // Nullable
-	IsManual        bool      `gorm:"column:is_manual"`
-	OrganizationID  *uint     `gorm:"column:organization_id"` // Nullable
-	WorkspaceID     *uint     `gorm:"column:workspace_id"`    // Nullable
-	CreatedAt       time.Time `gorm:"column:created_at"`
-	UpdatedAt       time.Time `gorm:"column:updated_at"`
-	Duration        int64     `gorm:"column:duration"`
-	ScreenshotCount int64     `gorm:"column:screenshot_count"`
+	ID              uint       `gorm:"column:id"`
+	UUID            string     `gorm:"column:uuid"`
+	Version         uint       `gorm:"column:version"`
+	Title           string     `gorm:"column:title"`
+	Description     *string    `gorm:"column:description"` // Nullable
+	Status          string     `gorm:"column:status"`
+	Priority        int        `gorm:"column:priority"`
+	Color           *string    `gorm:"column:color"` // Nullable
+	IsManual        bool       `gorm:"column:is_manual"`
+	OrganizationID  *uint      `gorm:"column:organization_id"` // Nullable
+	WorkspaceID     *uint      `gorm:"column:workspace_id"`    // Nullable
+	CostCenter      string     `gorm:"column:cost_center"`
+	ProjectCode     string     `gorm:"column:project_code"`
+	CreatedBy       *uint      `gorm:"column:created_by"`     // Nullable
+	ParentTaskID    *uint      `gorm:"column:parent_task_id"` // Nullable
+	DueDate         *time.Time `gorm:"column:due_date"`       // Nullable
+	EstimateSeconds int64      `gorm:"column:estimate_seconds"`
+	Tags            string     `gorm:"column:tags"`
+	CreatedAt       time.Time  `gorm:"column:created_at"`
+	UpdatedAt       time.Time  `gorm:"column:updated_at"`
+	Duration        int64      `gorm:"column:duration"`
+	ScreenshotCount int64      `gorm:"column:screenshot_count"`
 }
 
-func (r *taskRepository) FindByUserIDWithStats(userID uint, page, perPage int) ([]map[string]interface{}, int64, error) {
+func (r *taskRepository) FindByUserIDWithStats(userID uint, filter *dto.TaskFilter, page, perPage int) ([]map[string]interface{}, int64, error) {
 	var total int64
 	offset := (page - 1) * perPage
 
+	where, args := taskFilterSQL("t", filter)
+	if where != "" {
+		where = " AND " + where
+	}
+	args = append([]interface{}{userID, userID}, args...)
+
 	// Count total
-	if err := r.db.Model(&models.Task{}).Where("user_id = ?", userID).Count(&total).Error; err != nil {
+	if err := r.db.Raw("SELECT COUNT(*) FROM tasks t WHERE "+visibleToUser("t")+" AND t.deleted_at IS NULL"+where, args...).
+		Scan(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -139,6 +213,8 @@ func (r *taskRepository) FindByUserIDWithStats(userID uint, page, perPage int) (
 	query := `
 		SELECT 
 			t.id,
+			t.uuid,
+			t.version,
 			t.title,
 			t.description,
 			t.status,
@@ -147,6 +223,13 @@ func (r *taskRepository) FindByUserIDWithStats(userID uint, page, perPage int) (
 			t.is_manual,
 			t.organization_id,
 			t.workspace_id,
+			COALESCE(t.cost_center, '') as cost_center,
+			COALESCE(t.project_code, '') as project_code,
+			t.created_by,
+			t.parent_task_id,
+			t.due_date,
+			COALESCE(t.estimate_seconds, 0) as estimate_seconds,
+			COALESCE(t.tags, '') as tags,
 			t.created_at,
 			t.updated_at,
 			COALESCE(
@@ -168,14 +251,19 @@ func (r *taskRepository) FindByUserIDWithStats(userID uint, page, perPage int) (
 				     OR (s.task_id = t.id)
 				   )
 				), 0
+			) + COALESCE(
+				(SELECT SUM(r.screenshot_count)
+				 FROM screenshot_daily_rollups r
+				 WHERE r.task_id = t.id
+				), 0
 			) as screenshot_count
 		FROM tasks t
-		WHERE t.user_id = ? AND t.deleted_at IS NULL
+		WHERE ` + visibleToUser("t") + ` AND t.deleted_at IS NULL` + where + `
 		ORDER BY t.created_at DESC
 		LIMIT ? OFFSET ?
 	`
 
-	if err := r.db.Raw(query, userID, perPage, offset).Scan(&rows).Error; err != nil {
+	if err := r.db.Raw(query, append(args, perPage, offset)...).Scan(&rows).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -193,6 +281,8 @@ func (r *taskRepository) FindByUserIDWithStats(userID uint, page, perPage int) (
 
 		results[i] = map[string]interface{}{
 			"id":               row.ID,
+			"uuid":             row.UUID,
+			"version":          row.Version,
 			"title":            row.Title,
 			"description":      desc,
 			"status":           row.Status,
@@ -201,6 +291,13 @@ func (r *taskRepository) FindByUserIDWithStats(userID uint, page, perPage int) (
 			"is_manual":        row.IsManual,
 			"organization_id":  row.OrganizationID,
 			"workspace_id":     row.WorkspaceID,
+			"cost_center":      row.CostCenter,
+			"project_code":     row.ProjectCode,
+			"created_by":       row.CreatedBy,
+			"parent_task_id":   row.ParentTaskID,
+			"due_date":         row.DueDate,
+			"estimate_seconds": row.EstimateSeconds,
+			"tags":             row.Tags,
 			"created_at":       row.CreatedAt,
 			"updated_at":       row.UpdatedAt,
 			"duration":         row.Duration,
@@ -216,6 +313,8 @@ func (r *taskRepository) FindActiveByUserIDWithStats(userID uint) ([]map[string]
 	query := `
 		SELECT 
 			t.id,
+			t.uuid,
+			t.version,
 			t.title,
 			t.description,
 			t.status,
@@ -224,6 +323,13 @@ func (r *taskRepository) FindActiveByUserIDWithStats(userID uint) ([]map[string]
 			t.is_manual,
 			t.organization_id,
 			t.workspace_id,
+			COALESCE(t.cost_center, '') as cost_center,
+			COALESCE(t.project_code, '') as project_code,
+			t.created_by,
+			t.parent_task_id,
+			t.due_date,
+			COALESCE(t.estimate_seconds, 0) as estimate_seconds,
+			COALESCE(t.tags, '') as tags,
 			t.created_at,
 			t.updated_at,
 			COALESCE(
@@ -245,13 +351,18 @@ func (r *taskRepository) FindActiveByUserIDWithStats(userID uint) ([]map[string]
 				     OR (s.task_id = t.id)
 				   )
 				), 0
+			) + COALESCE(
+				(SELECT SUM(r.screenshot_count)
+				 FROM screenshot_daily_rollups r
+				 WHERE r.task_id = t.id
+				), 0
 			) as screenshot_count
 		FROM tasks t
-		WHERE t.user_id = ? AND t.status = 'active' AND t.deleted_at IS NULL
+		WHERE ` + visibleToUser("t") + ` AND t.status = 'active' AND t.deleted_at IS NULL
 		ORDER BY t.priority DESC, t.created_at DESC
 	`
 
-	if err := r.db.Raw(query, userID).Scan(&rows).Error; err != nil {
+	if err := r.db.Raw(query, userID, userID).Scan(&rows).Error; err != nil {
 		return nil, err
 	}
 
@@ -269,6 +380,8 @@ func (r *taskRepository) FindActiveByUserIDWithStats(userID uint) ([]map[string]
 
 		results[i] = map[string]interface{}{
 			"id":               row.ID,
+			"uuid":             row.UUID,
+			"version":          row.Version,
 			"title":            row.Title,
 			"description":      desc,
 			"status":           row.Status,
@@ -277,6 +390,13 @@ func (r *taskRepository) FindActiveByUserIDWithStats(userID uint) ([]map[string]
 			"is_manual":        row.IsManual,
 			"organization_id":  row.OrganizationID,
 			"workspace_id":     row.WorkspaceID,
+			"cost_center":      row.CostCenter,
+			"project_code":     row.ProjectCode,
+			"created_by":       row.CreatedBy,
+			"parent_task_id":   row.ParentTaskID,
+			"due_date":         row.DueDate,
+			"estimate_seconds": row.EstimateSeconds,
+			"tags":             row.Tags,
 			"created_at":       row.CreatedAt,
 			"updated_at":       row.UpdatedAt,
 			"duration":         row.Duration,
@@ -286,3 +406,140 @@ func (r *taskRepository) FindActiveByUserIDWithStats(userID uint) ([]map[string]
 
 	return results, nil
 }
+
+func (r *taskRepository) TrackedDurations(tasks []models.Task) (map[uint]int64, error) {
+	durations := make(map[uint]int64, len(tasks))
+	if len(tasks) == 0 {
+		return durations, nil
+	}
+	ids := make([]uint, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+
+	var rows []struct {
+		ID       uint
+		Duration int64
+	}
+	err := r.db.Raw(`
+		SELECT t.id, COALESCE(SUM(tl.duration), 0) AS duration
+		FROM tasks t
+		JOIN time_logs tl ON tl.deleted_at IS NULL
+			AND (tl.task_id = t.id OR (tl.task_local_id != '' AND tl.task_local_id = t.local_id))
+		WHERE t.id IN ?
+		GROUP BY t.id
+	`, ids).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		durations[row.ID] = row.Duration
+	}
+	return durations, nil
+}
+
+// maxTaskTreeDepth stops the recursive queries on corrupt (cyclic) data;
+// the service keeps real trees much shallower
+const maxTaskTreeDepth = 10
+
+func (r *taskRepository) FindAncestorIDs(id uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.Raw(`
+		WITH RECURSIVE chain AS (
+			SELECT parent_task_id AS id, 1 AS depth FROM tasks WHERE id = ? AND parent_task_id IS NOT NULL
+			UNION ALL
+			SELECT t.parent_task_id, chain.depth + 1
+			FROM tasks t JOIN chain ON t.id = chain.id
+			WHERE t.parent_task_id IS NOT NULL AND t.deleted_at IS NULL AND chain.depth < ?
+		)
+		SELECT id FROM chain ORDER BY depth
+	`, id, maxTaskTreeDepth).Scan(&ids).Error
+	return ids, err
+}
+
+func (r *taskRepository) FindSubtree(id uint) ([]models.Task, error) {
+	var ids []uint
+	err := r.db.Raw(`
+		WITH RECURSIVE tree AS (
+			SELECT id, 0 AS depth FROM tasks WHERE id = ? AND deleted_at IS NULL
+			UNION ALL
+			SELECT t.id, tree.depth + 1
+			FROM tasks t JOIN tree ON t.parent_task_id = tree.id
+			WHERE t.deleted_at IS NULL AND tree.depth < ?
+		)
+		SELECT id FROM tree
+	`, id, maxTaskTreeDepth).Scan(&ids).Error
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	var tasks []models.Task
+	err = r.db.Preload("User").Where("id IN ?", ids).Order("priority DESC, created_at ASC").Find(&tasks).Error
+	return tasks, err
+}
+
+func (r *taskRepository) DetachSubtasks(parentID uint) error {
+	return r.db.Model(&models.Task{}).Where("parent_task_id = ?", parentID).Update("parent_task_id", nil).Error
+}
+
+func (r *taskRepository) AddAssignee(assignee *models.TaskAssignee) error {
+	return r.db.Create(assignee).Error
+}
+
+func (r *taskRepository) RemoveAssignee(taskID, userID uint) (bool, error) {
+	result := r.db.Where("task_id = ? AND user_id = ?", taskID, userID).Delete(&models.TaskAssignee{})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *taskRepository) FindAssignees(taskID uint) ([]TaskAssigneeRow, error) {
+	var rows []TaskAssigneeRow
+	err := r.db.Model(&models.TaskAssignee{}).
+		Select("task_assignees.*, users.email, users.first_name, users.last_name").
+		Joins("JOIN users ON users.id = task_assignees.user_id").
+		Where("task_assignees.task_id = ?", taskID).
+		Order("task_assignees.created_at ASC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *taskRepository) IsAssignee(taskID, userID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.TaskAssignee{}).
+		Where("task_id = ? AND user_id = ?", taskID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// taskFilterSQL builds the conditions for a task filter on the given tasks
+// table alias, with their arguments; empty when nothing is filtered
+func taskFilterSQL(alias string, filter *dto.TaskFilter) (string, []interface{}) {
+	if filter == nil {
+		return "", nil
+	}
+
+	var conditions []string
+	var args []interface{}
+	if filter.Tag != "" {
+		// Tags are stored normalized, so wrapping both sides in commas
+		// matches whole labels only
+		conditions = append(conditions, "POSITION(? IN ',' || "+alias+".tags || ',') > 0")
+		args = append(args, ","+filter.Tag+",")
+	}
+	if filter.DueFrom != nil {
+		conditions = append(conditions, alias+".due_date >= ?")
+		args = append(args, filter.DueFrom.Format("2006-01-02"))
+	}
+	if filter.DueTo != nil {
+		conditions = append(conditions, alias+".due_date <= ?")
+		args = append(args, filter.DueTo.Format("2006-01-02"))
+	}
+	if filter.Overdue {
+		conditions = append(conditions, alias+".status = 'active' AND "+alias+".due_date < ?")
+		args = append(args, time.Now().UTC().Format("2006-01-02"))
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return strings.Join(conditions, " AND "), args
+}
@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// TelemetryRepository handles desktop crash report data operations
+type TelemetryRepository interface {
+	CreateReports(reports []models.CrashReport) error
+	GetReleaseSummaries(since time.Time, platform, version string, limit int) ([]dto.AdminReleaseCrashSummary, error)
+	GetActiveDevicesByVersion(since time.Time, platform string) (map[string]int64, error)
+	GetStackGroups(version string, since time.Time, platform string, limit int) ([]dto.AdminCrashStackGroup, error)
+	DeleteReportsBefore(before time.Time) (int64, error)
+
+	// Feature usage events
+	CreateUsageEvents(events []models.UsageEvent) error
+	GetFeatureUsage(since time.Time, orgID *uint, limit int) ([]dto.AdminFeatureUsage, error)
+	CountActiveUsageOrgs(since time.Time) (int64, error)
+	GetFeatureOrgUsage(feature string, since time.Time, limit int) ([]dto.AdminFeatureOrgUsage, error)
+	DeleteUsageEventsBefore(before time.Time) (int64, error)
+	DeleteOptedOutUsageEvents() (int64, error)
+}
+
+type telemetryRepository struct {
+	db *gorm.DB
+}
+
+// NewTelemetryRepository creates a new telemetry repository
+func NewTelemetryRepository(db *gorm.DB) TelemetryRepository {
+	return &telemetryRepository{db: db}
+}
+
+func (r *telemetryRepository) CreateReports(reports []models.CrashReport) error {
+	return r.db.Create(&reports).Error
+}
+
+// GetReleaseSummaries aggregates reports per app version, newest activity first.
+// An empty version returns every release.
+func (r *telemetryRepository) GetReleaseSummaries(since time.Time, platform, version string, limit int) ([]dto.AdminReleaseCrashSummary, error) {
+	var summaries []dto.AdminReleaseCrashSummary
+
+	query := r.db.Model(&models.CrashReport{}).
+		Select(`app_version,
+			COUNT(*) AS reports,
+			COUNT(*) FILTER (WHERE is_fatal) AS fatal_reports,
+			COUNT(DISTINCT stack_hash) AS unique_stacks,
+			COUNT(DISTINCT NULLIF(device_uuid, '')) AS affected_devices,
+			COUNT(DISTINCT user_id) AS affected_users,
+			MIN(occurred_at) AS first_seen_at,
+			MAX(occurred_at) AS last_seen_at`).
+		Where("occurred_at >= ?", since)
+
+	if platform != "" {
+		query = query.Where("platform = ?", platform)
+	}
+	if version != "" {
+		query = query.Where("app_version = ?", version)
+	}
+
+	err := query.Group("app_version").
+		Order("last_seen_at DESC").
+		Limit(limit).
+		Scan(&summaries).Error
+	return summaries, err
+}
+
+// GetActiveDevicesByVersion counts devices seen on each app version since the cutoff
+func (r *telemetryRepository) GetActiveDevicesByVersion(since time.Time, platform string) (map[string]int64, error) {
+	var rows []struct {
+		AppVersion string
+		Devices    int64
+	}
+
+	query := r.db.Model(&models.DeviceInfo{}).
+		Select("app_version, COUNT(*) AS devices").
+		Where("last_seen_at >= ? AND app_version != ''", since)
+
+	if platform != "" {
+		query = query.Where("os = ?", platform)
+	}
+
+	if err := query.Group("app_version").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.AppVersion] = row.Devices
+	}
+	return counts, nil
+}
+
+// GetStackGroups aggregates a release's reports by stack hash, most frequent first
+func (r *telemetryRepository) GetStackGroups(version string, since time.Time, platform string, limit int) ([]dto.AdminCrashStackGroup, error) {
+	var groups []dto.AdminCrashStackGroup
+
+	err := r.db.Raw(`
+		SELECT
+			g.stack_hash, g.reports, g.fatal_reports, g.affected_devices,
+			g.platforms, g.first_seen_at, g.last_seen_at,
+			latest.error_type, latest.message, latest.stack_trace, latest.process_type
+		FROM (
+			SELECT
+				stack_hash,
+				COUNT(*) AS reports,
+				COUNT(*) FILTER (WHERE is_fatal) AS fatal_reports,
+				COUNT(DISTINCT NULLIF(device_uuid, '')) AS affected_devices,
+				STRING_AGG(DISTINCT platform, ',') AS platforms,
+				MIN(occurred_at) AS first_seen_at,
+				MAX(occurred_at) AS last_seen_at,
+				MAX(id) AS latest_id
+			FROM crash_reports
+			WHERE app_version = @version
+				AND occurred_at >= @since
+				AND (@platform = '' OR platform = @platform)
+			GROUP BY stack_hash
+		) g
+		JOIN crash_reports latest ON latest.id = g.latest_id
+		ORDER BY g.reports DESC
+		LIMIT @limit
+	`, map[string]interface{}{
+		"version":  version,
+		"since":    since,
+		"platform": platform,
+		"limit":    limit,
+	}).Scan(&groups).Error
+
+	return groups, err
+}
+
+func (r *telemetryRepository) DeleteReportsBefore(before time.Time) (int64, error) {
+	result := r.db.Where("created_at < ?", before).Delete(&models.CrashReport{})
+	return result.RowsAffected, result.Error
+}
+
+// ============================================================================
+// FEATURE USAGE EVENTS
+// ============================================================================
+
+// usageEventsVisible restricts usage queries to organizations that have not opted out
+const usageEventsVisible = "(usage_events.organization_id IS NULL OR usage_events.organization_id NOT IN (SELECT id FROM organizations WHERE usage_analytics_opt_out = true))"
+
+func (r *telemetryRepository) CreateUsageEvents(events []models.UsageEvent) error {
+	return r.db.Create(&events).Error
+}
+
+// GetFeatureUsage aggregates usage events per feature, most used first
+func (r *telemetryRepository) GetFeatureUsage(since time.Time, orgID *uint, limit int) ([]dto.AdminFeatureUsage, error) {
+	var usage []dto.AdminFeatureUsage
+
+	query := r.db.Model(&models.UsageEvent{}).
+		Select(`feature,
+			COUNT(*) AS events,
+			COUNT(DISTINCT organization_id) AS organizations,
+			COUNT(DISTINCT user_id) AS users,
+			MIN(occurred_at) AS first_used_at,
+			MAX(occurred_at) AS last_used_at`).
+		Where("occurred_at >= ?", since).
+		Where(usageEventsVisible)
+
+	if orgID != nil {
+		query = query.Where("organization_id = ?", *orgID)
+	}
+
+	err := query.Group("feature").
+		Order("events DESC").
+		Limit(limit).
+		Scan(&usage).Error
+	return usage, err
+}
+
+// CountActiveUsageOrgs counts organizations with any usage event since the cutoff
+func (r *telemetryRepository) CountActiveUsageOrgs(since time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.UsageEvent{}).
+		Where("occurred_at >= ? AND organization_id IS NOT NULL", since).
+		Where(usageEventsVisible).
+		Distinct("organization_id").
+		Count(&count).Error
+	return count, err
+}
+
+// GetFeatureOrgUsage aggregates one feature's usage events per organization
+func (r *telemetryRepository) GetFeatureOrgUsage(feature string, since time.Time, limit int) ([]dto.AdminFeatureOrgUsage, error) {
+	var usage []dto.AdminFeatureOrgUsage
+
+	err := r.db.Model(&models.UsageEvent{}).
+		Select(`usage_events.organization_id,
+			organizations.name AS organization_name,
+			COUNT(*) AS events,
+			COUNT(DISTINCT usage_events.user_id) AS users,
+			MAX(usage_events.occurred_at) AS last_used_at`).
+		Joins("JOIN organizations ON organizations.id = usage_events.organization_id").
+		Where("usage_events.feature = ? AND usage_events.occurred_at >= ?", feature, since).
+		Where("organizations.usage_analytics_opt_out = false").
+		Group("usage_events.organization_id, organizations.name").
+		Order("events DESC").
+		Limit(limit).
+		Scan(&usage).Error
+	return usage, err
+}
+
+func (r *telemetryRepository) DeleteUsageEventsBefore(before time.Time) (int64, error) {
+	result := r.db.Where("created_at < ?", before).Delete(&models.UsageEvent{})
+	return result.RowsAffected, result.Error
+}
+
+// DeleteOptedOutUsageEvents removes events recorded before an organization opted out
+func (r *telemetryRepository) DeleteOptedOutUsageEvents() (int64, error) {
+	result := r.db.Where("organization_id IN (SELECT id FROM organizations WHERE usage_analytics_opt_out = true)").
+		Delete(&models.UsageEvent{})
+	return result.RowsAffected, result.Error
+}
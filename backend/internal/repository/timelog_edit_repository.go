@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// TimeLogEditRepository handles time log edit history data operations
+type TimeLogEditRepository interface {
+	Create(edit *models.TimeLogEdit) error
+	FindByTimeLogID(timeLogID uint, page, perPage int) ([]models.TimeLogEdit, int64, error)
+}
+
+type timeLogEditRepository struct {
+	db *gorm.DB
+}
+
+// NewTimeLogEditRepository creates a new time log edit repository
+func NewTimeLogEditRepository(db *gorm.DB) TimeLogEditRepository {
+	return &timeLogEditRepository{db: db}
+}
+
+func (r *timeLogEditRepository) Create(edit *models.TimeLogEdit) error {
+	return r.db.Create(edit).Error
+}
+
+// FindByTimeLogID lists a time log's edits, newest first
+func (r *timeLogEditRepository) FindByTimeLogID(timeLogID uint, page, perPage int) ([]models.TimeLogEdit, int64, error) {
+	var edits []models.TimeLogEdit
+	var total int64
+
+	query := r.db.Model(&models.TimeLogEdit{}).Where("time_log_id = ?", timeLogID)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * perPage
+	err := query.Preload("Editor").
+		Order("created_at DESC, id DESC").
+		Offset(offset).
+		Limit(perPage).
+		Find(&edits).Error
+	return edits, total, err
+}
@@ -4,10 +4,20 @@ import (
 	"errors"
 	"time"
 
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
 	"github.com/beuphecan/remote-time-tracker/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// timerLockNamespace is the high half of the advisory lock keys taken on a
+// user's running timers, keeping them apart from other advisory locks
+const timerLockNamespace int64 = 0x74696d72 // "timr"
+
+// ErrTimeLogInvoiced is returned when changing a time log that is billed on
+// an invoice
+var ErrTimeLogInvoiced = apperror.Conflict("time log is on an invoice and can no longer be edited")
+
 // TimeLogRepository handles time log data operations
 type TimeLogRepository interface {
 	Create(timeLog *models.TimeLog) error
@@ -15,12 +25,42 @@ type TimeLogRepository interface {
 	FindByLocalID(localID string, userID uint) (*models.TimeLog, error)
 	FindByUserID(userID uint, page, perPage int) ([]models.TimeLog, int64, error)
 	FindActiveByUserID(userID uint) (*models.TimeLog, error)
+	FindActiveByUserAndOrg(userID, orgID uint) ([]models.TimeLog, error)
+	FindAllActiveByUserID(userID uint) ([]models.TimeLog, error)
 	FindByTaskID(taskID uint) ([]models.TimeLog, error)
 	Update(timeLog *models.TimeLog) error
+	UpdateIfVersion(timeLog *models.TimeLog, version int) (bool, error)
 	Delete(id uint) error
 	FindByDateRange(userID uint, startDate, endDate time.Time) ([]models.TimeLog, error)
 	BatchCreate(timeLogs []models.TimeLog) error
 	GetTotalTimeByUser(userID uint, startDate, endDate time.Time) (int64, error)
+	FindOverlapping(userID uint, start, end time.Time) ([]models.TimeLog, error)
+	ReplaceBreaks(timeLogID uint, breaks []models.TimeLogBreak) error
+
+	// Running timers. Transaction calls fn with a repository bound to one
+	// transaction; LockUserTimers, called inside it, makes concurrent starts
+	// of the user's timers wait until that transaction ends.
+	Transaction(fn func(repo TimeLogRepository) error) error
+	LockUserTimers(userID uint) error
+
+	// Weekly hour caps
+	SumWorkspaceDuration(userID, workspaceID uint, start, end time.Time, excludeID uint) (int64, error)
+	GetWorkspaceWeekHours(workspaceID uint, start, end time.Time) (map[uint]WorkspaceUserHours, error)
+
+	// Workspace budgets
+	GetWorkspaceApprovedDaily(workspaceID uint) ([]WorkspaceDaySeconds, error)
+}
+
+// WorkspaceDaySeconds holds the tracked seconds of time logs started on a day (UTC)
+type WorkspaceDaySeconds struct {
+	Day     string // YYYY-MM-DD
+	Seconds int64
+}
+
+// WorkspaceUserHours holds a user's tracked time in a workspace over a period
+type WorkspaceUserHours struct {
+	Seconds     int64
+	FlaggedLogs int64
 }
 
 type timeLogRepository struct {
@@ -41,7 +81,7 @@ func (r *timeLogRepository) FindByID(id uint) (*models.TimeLog, error) {
 	if err := r.db.Preload("User").Preload("Task").Preload("Device").
 		First(&timeLog, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("time log not found")
+			return nil, apperror.NotFound("time log not found")
 		}
 		return nil, err
 	}
@@ -73,6 +113,7 @@ func (r *timeLogRepository) FindByUserID(userID uint, page, perPage int) ([]mode
 	if err := r.db.Where("user_id = ?", userID).
 		Preload("Task").
 		Preload("Device").
+		Preload("PrivateIntervals").
 		Offset(offset).
 		Limit(perPage).
 		Order("start_time DESC").
@@ -96,6 +137,24 @@ func (r *timeLogRepository) FindActiveByUserID(userID uint) (*models.TimeLog, er
 	return &timeLog, nil
 }
 
+// FindAllActiveByUserID finds all of a user's running or paused time logs,
+// newest first
+func (r *timeLogRepository) FindAllActiveByUserID(userID uint) ([]models.TimeLog, error) {
+	var timeLogs []models.TimeLog
+	err := r.db.Where("user_id = ? AND status IN ?", userID, []string{"running", "paused"}).
+		Order("start_time DESC").
+		Find(&timeLogs).Error
+	return timeLogs, err
+}
+
+// FindActiveByUserAndOrg finds a user's running or paused time logs in an organization
+func (r *timeLogRepository) FindActiveByUserAndOrg(userID, orgID uint) ([]models.TimeLog, error) {
+	var timeLogs []models.TimeLog
+	err := r.db.Where("user_id = ? AND organization_id = ? AND status IN ?", userID, orgID, []string{"running", "paused"}).
+		Find(&timeLogs).Error
+	return timeLogs, err
+}
+
 func (r *timeLogRepository) FindByTaskID(taskID uint) ([]models.TimeLog, error) {
 	var timeLogs []models.TimeLog
 	if err := r.db.Where("task_id = ?", taskID).
@@ -106,12 +165,47 @@ func (r *timeLogRepository) FindByTaskID(taskID uint) ([]models.TimeLog, error)
 	return timeLogs, nil
 }
 
+// Update saves the time log and bumps its version, so devices syncing an
+// edit of an older copy detect the conflict
 func (r *timeLogRepository) Update(timeLog *models.TimeLog) error {
+	if timeLog.InvoiceID != nil {
+		return ErrTimeLogInvoiced
+	}
+	timeLog.Version++
 	return r.db.Save(timeLog).Error
 }
 
+// UpdateIfVersion saves the time log only if its stored version still equals
+// version, bumping it. Returns false when another change got there first or
+// the time log was invoiced meanwhile.
+func (r *timeLogRepository) UpdateIfVersion(timeLog *models.TimeLog, version int) (bool, error) {
+	timeLog.Version = version + 1
+	result := r.db.Model(timeLog).
+		Where("version = ? AND invoice_id IS NULL", version).
+		Select("*").
+		Omit("id", "created_at", clause.Associations).
+		Updates(timeLog)
+	if result.Error != nil || result.RowsAffected == 0 {
+		timeLog.Version = version
+		return false, result.Error
+	}
+	return true, nil
+}
+
+// Delete deletes the time log unless it is invoiced
 func (r *timeLogRepository) Delete(id uint) error {
-	return r.db.Delete(&models.TimeLog{}, id).Error
+	result := r.db.Where("invoice_id IS NULL").Delete(&models.TimeLog{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		var count int64
+		r.db.Model(&models.TimeLog{}).Where("id = ? AND invoice_id IS NOT NULL", id).Count(&count)
+		if count > 0 {
+			return ErrTimeLogInvoiced
+		}
+	}
+	return nil
 }
 
 func (r *timeLogRepository) FindByDateRange(userID uint, startDate, endDate time.Time) ([]models.TimeLog, error) {
@@ -170,3 +264,97 @@ func (r *timeLogRepository) GetTotalTimeByUser(userID uint, startDate, endDate t
 
 	return total, nil
 }
+
+// FindOverlapping finds the user's time logs that overlap [start, end).
+// Running and paused sessions have no end time and overlap anything after
+// their start.
+func (r *timeLogRepository) FindOverlapping(userID uint, start, end time.Time) ([]models.TimeLog, error) {
+	var timeLogs []models.TimeLog
+	err := r.db.Where("user_id = ?", userID).
+		Where("start_time < ?", end).
+		Where("(end_time IS NULL OR end_time > ?)", start).
+		Order("start_time ASC").
+		Find(&timeLogs).Error
+	return timeLogs, err
+}
+
+// ReplaceBreaks replaces the breaks stored for a time log with the given ones
+func (r *timeLogRepository) ReplaceBreaks(timeLogID uint, breaks []models.TimeLogBreak) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("time_log_id = ?", timeLogID).Delete(&models.TimeLogBreak{}).Error; err != nil {
+			return err
+		}
+		if len(breaks) == 0 {
+			return nil
+		}
+		for i := range breaks {
+			breaks[i].TimeLogID = timeLogID
+		}
+		return tx.Create(&breaks).Error
+	})
+}
+
+func (r *timeLogRepository) Transaction(fn func(repo TimeLogRepository) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(NewTimeLogRepository(tx))
+	})
+}
+
+// LockUserTimers takes a transaction-scoped advisory lock on the user's
+// timers; outside a transaction it is released right away
+func (r *timeLogRepository) LockUserTimers(userID uint) error {
+	return r.db.Exec("SELECT pg_advisory_xact_lock(?)", timerLockNamespace<<32|int64(userID)).Error
+}
+
+// SumWorkspaceDuration sums the user's tracked seconds in a workspace for time
+// logs started within [start, end), including running and paused sessions.
+// excludeID leaves out the time log being evaluated (0 excludes nothing).
+func (r *timeLogRepository) SumWorkspaceDuration(userID, workspaceID uint, start, end time.Time, excludeID uint) (int64, error) {
+	var total int64
+	err := r.db.Model(&models.TimeLog{}).
+		Select("COALESCE(SUM(duration), 0)").
+		Where("user_id = ? AND workspace_id = ? AND id <> ?", userID, workspaceID, excludeID).
+		Where("start_time >= ? AND start_time < ?", start, end).
+		Scan(&total).Error
+	return total, err
+}
+
+// GetWorkspaceWeekHours aggregates tracked seconds and cap-flagged time logs
+// per user for time logs started within [start, end)
+func (r *timeLogRepository) GetWorkspaceWeekHours(workspaceID uint, start, end time.Time) (map[uint]WorkspaceUserHours, error) {
+	var rows []struct {
+		UserID uint
+		WorkspaceUserHours
+	}
+
+	err := r.db.Model(&models.TimeLog{}).
+		Select(`user_id,
+			COALESCE(SUM(duration), 0) AS seconds,
+			COUNT(*) FILTER (WHERE cap_status <> '') AS flagged_logs`).
+		Where("workspace_id = ?", workspaceID).
+		Where("start_time >= ? AND start_time < ?", start, end).
+		Group("user_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	hours := make(map[uint]WorkspaceUserHours, len(rows))
+	for _, row := range rows {
+		hours[row.UserID] = row.WorkspaceUserHours
+	}
+	return hours, nil
+}
+
+// GetWorkspaceApprovedDaily sums the seconds of the workspace's approved time
+// logs per start day, oldest first
+func (r *timeLogRepository) GetWorkspaceApprovedDaily(workspaceID uint) ([]WorkspaceDaySeconds, error) {
+	var days []WorkspaceDaySeconds
+	err := r.db.Model(&models.TimeLog{}).
+		Select("TO_CHAR(DATE(start_time), 'YYYY-MM-DD') AS day, COALESCE(SUM(duration), 0) AS seconds").
+		Where("workspace_id = ? AND is_approved = true", workspaceID).
+		Group("DATE(start_time)").
+		Order("DATE(start_time)").
+		Scan(&days).Error
+	return days, err
+}
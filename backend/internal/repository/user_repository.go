@@ -4,6 +4,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
 	"github.com/beuphecan/remote-time-tracker/internal/models"
 	"gorm.io/gorm"
 )
@@ -43,7 +44,7 @@ func (r *userRepository) FindByID(id uint) (*models.User, error) {
 	var user models.User
 	if err := r.db.First(&user, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("user not found")
+			return nil, apperror.NotFound("user not found")
 		}
 		return nil, err
 	}
@@ -54,7 +55,7 @@ func (r *userRepository) FindByEmail(email string) (*models.User, error) {
 	var user models.User
 	if err := r.db.Where("email = ?", email).First(&user).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("user not found")
+			return nil, apperror.NotFound("user not found")
 		}
 		return nil, err
 	}
@@ -100,7 +101,12 @@ func (r *userRepository) UpdatePresence(id uint, status string, lastPresenceAt t
 		updates["last_working_at"] = *lastWorkingAt
 	}
 
-	return r.db.Model(&models.User{}).Where("id = ?", id).Updates(updates).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.User{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+			return err
+		}
+		return recordPresenceTransition(tx, id, status, lastPresenceAt)
+	})
 }
 
 func (r *userRepository) FindAllPaginated(limit, offset int) ([]models.User, int64, error) {
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"gorm.io/gorm"
+)
+
+// ErrVersionConflict is returned when a versioned record changed after it
+// was loaded for the update
+var ErrVersionConflict = apperror.Conflict("record was modified by another request")
+
+// updateVersioned saves every field of model like Save, but only if the row
+// still has the version it was loaded with, and bumps that version. On
+// failure the model keeps its loaded version.
+func updateVersioned(db *gorm.DB, model interface{}, version *uint) error {
+	loaded := *version
+	*version = loaded + 1
+
+	result := db.Model(model).Where("version = ?", loaded).Select("*").Updates(model)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = ErrVersionConflict
+	}
+	if result.Error != nil {
+		*version = loaded
+	}
+	return result.Error
+}
@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"gorm.io/gorm"
+)
+
+// WebhookRepository handles organization webhook and delivery data operations
+type WebhookRepository interface {
+	// Webhooks
+	Create(webhook *models.OrganizationWebhook) error
+	FindByID(orgID, id uint) (*models.OrganizationWebhook, error)
+	FindByOrg(orgID uint) ([]models.OrganizationWebhook, error)
+	FindActiveByOrg(orgID uint) ([]models.OrganizationWebhook, error)
+	Update(webhook *models.OrganizationWebhook) error
+	Delete(id uint) error
+
+	// Deliveries
+	CreateDelivery(delivery *models.WebhookDelivery) error
+	FindDeliveryByID(id uint) (*models.WebhookDelivery, error)
+	FindDeliveries(webhookID uint, params *dto.WebhookDeliveryListParams) ([]models.WebhookDelivery, int64, error)
+	FindDeliveriesForReplay(webhookID uint, req *dto.ReplayWebhookRequest, limit int) ([]models.WebhookDelivery, error)
+	FindDueDeliveries(now time.Time, limit int) ([]models.WebhookDelivery, error)
+	UpdateDelivery(delivery *models.WebhookDelivery) error
+	TouchLastDelivery(webhookID uint, at time.Time) error
+	DeleteDeliveriesBefore(before time.Time) (int64, error)
+}
+
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository creates a new webhook repository
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+// ============================================================================
+// WEBHOOKS
+// ============================================================================
+
+func (r *webhookRepository) Create(webhook *models.OrganizationWebhook) error {
+	return r.db.Create(webhook).Error
+}
+
+// FindByID finds a webhook scoped to its organization
+func (r *webhookRepository) FindByID(orgID, id uint) (*models.OrganizationWebhook, error) {
+	var webhook models.OrganizationWebhook
+	err := r.db.Where("id = ? AND organization_id = ?", id, orgID).First(&webhook).Error
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (r *webhookRepository) FindByOrg(orgID uint) ([]models.OrganizationWebhook, error) {
+	var webhooks []models.OrganizationWebhook
+	err := r.db.Where("organization_id = ?", orgID).Order("created_at ASC").Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (r *webhookRepository) FindActiveByOrg(orgID uint) ([]models.OrganizationWebhook, error) {
+	var webhooks []models.OrganizationWebhook
+	err := r.db.Where("organization_id = ? AND is_active = true", orgID).Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (r *webhookRepository) Update(webhook *models.OrganizationWebhook) error {
+	return r.db.Save(webhook).Error
+}
+
+func (r *webhookRepository) Delete(id uint) error {
+	return r.db.Delete(&models.OrganizationWebhook{}, id).Error
+}
+
+// ============================================================================
+// DELIVERIES
+// ============================================================================
+
+func (r *webhookRepository) CreateDelivery(delivery *models.WebhookDelivery) error {
+	return r.db.Create(delivery).Error
+}
+
+func (r *webhookRepository) FindDeliveryByID(id uint) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	if err := r.db.First(&delivery, id).Error; err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// FindDeliveries lists a webhook's deliveries, newest first
+func (r *webhookRepository) FindDeliveries(webhookID uint, params *dto.WebhookDeliveryListParams) ([]models.WebhookDelivery, int64, error) {
+	var deliveries []models.WebhookDelivery
+	var total int64
+
+	query := r.db.Model(&models.WebhookDelivery{}).Where("webhook_id = ?", webhookID)
+	if params.Status != "" {
+		query = query.Where("status = ?", params.Status)
+	}
+	if params.Event != "" {
+		query = query.Where("event = ?", params.Event)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (params.Page - 1) * params.PerPage
+	err := query.Order("created_at DESC").
+		Offset(offset).
+		Limit(params.PerPage).
+		Find(&deliveries).Error
+	return deliveries, total, err
+}
+
+// FindDeliveriesForReplay selects original (non-replay) deliveries matching the request, oldest first
+func (r *webhookRepository) FindDeliveriesForReplay(webhookID uint, req *dto.ReplayWebhookRequest, limit int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+
+	query := r.db.Where("webhook_id = ? AND replay_of IS NULL", webhookID)
+	if len(req.DeliveryIDs) > 0 {
+		query = query.Where("id IN ?", req.DeliveryIDs)
+	}
+	if req.Since != nil {
+		query = query.Where("created_at >= ?", *req.Since)
+	}
+	if req.Until != nil {
+		query = query.Where("created_at < ?", *req.Until)
+	}
+	if req.Event != "" {
+		query = query.Where("event = ?", req.Event)
+	}
+	if !req.IncludeSucceeded {
+		query = query.Where("status = ?", models.WebhookDeliveryFailed)
+	}
+
+	err := query.Order("created_at ASC").Limit(limit).Find(&deliveries).Error
+	return deliveries, err
+}
+
+// FindDueDeliveries finds deliveries whose next attempt is due
+func (r *webhookRepository) FindDueDeliveries(now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := r.db.Where("status = ? AND next_attempt_at <= ?", models.WebhookDeliveryPending, now).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&deliveries).Error
+	return deliveries, err
+}
+
+func (r *webhookRepository) UpdateDelivery(delivery *models.WebhookDelivery) error {
+	return r.db.Save(delivery).Error
+}
+
+func (r *webhookRepository) TouchLastDelivery(webhookID uint, at time.Time) error {
+	return r.db.Model(&models.OrganizationWebhook{}).
+		Where("id = ?", webhookID).
+		UpdateColumn("last_delivery_at", at).Error
+}
+
+func (r *webhookRepository) DeleteDeliveriesBefore(before time.Time) (int64, error) {
+	result := r.db.Where("created_at < ?", before).Delete(&models.WebhookDelivery{})
+	return result.RowsAffected, result.Error
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"strings"
+	"time"
 
 	"github.com/beuphecan/remote-time-tracker/internal/models"
 	"gorm.io/gorm"
@@ -9,7 +10,14 @@ import (
 
 // WorkspaceRepository handles database operations for workspaces
 type WorkspaceRepository struct {
-	db *gorm.DB
+	db    *gorm.DB
+	stats *StatsCache
+}
+
+// WorkspaceCounts holds the active member and task counts of a workspace
+type WorkspaceCounts struct {
+	MemberCount int64
+	TaskCount   int64
 }
 
 // NewWorkspaceRepository creates a new workspace repository
@@ -17,13 +25,22 @@ func NewWorkspaceRepository(db *gorm.DB) *WorkspaceRepository {
 	return &WorkspaceRepository{db: db}
 }
 
+// SetStatsCache enables stats cache invalidation on workspace and membership changes
+func (r *WorkspaceRepository) SetStatsCache(stats *StatsCache) {
+	r.stats = stats
+}
+
 // ============================================================================
 // WORKSPACE CRUD
 // ============================================================================
 
 // Create creates a new workspace
 func (r *WorkspaceRepository) Create(workspace *models.Workspace) error {
-	return r.db.Create(workspace).Error
+	if err := r.db.Create(workspace).Error; err != nil {
+		return err
+	}
+	r.stats.InvalidateOrg(workspace.OrganizationID)
+	return nil
 }
 
 // GetByID gets a workspace by ID
@@ -80,14 +97,28 @@ func (r *WorkspaceRepository) GetByAdminID(adminID uint) ([]models.Workspace, er
 	return workspaces, err
 }
 
-// Update updates a workspace
+// Update updates a workspace; ErrVersionConflict means it changed since it
+// was loaded
 func (r *WorkspaceRepository) Update(workspace *models.Workspace) error {
-	return r.db.Save(workspace).Error
+	if err := updateVersioned(r.db, workspace, &workspace.Version); err != nil {
+		return err
+	}
+	// Active/archived flags feed the overview counts
+	r.stats.InvalidateWorkspace(workspace.ID)
+	return nil
 }
 
 // Delete soft deletes a workspace
 func (r *WorkspaceRepository) Delete(id uint) error {
-	return r.db.Delete(&models.Workspace{}, id).Error
+	var orgID uint
+	r.db.Model(&models.Workspace{}).Select("organization_id").Where("id = ?", id).Scan(&orgID)
+
+	if err := r.db.Delete(&models.Workspace{}, id).Error; err != nil {
+		return err
+	}
+	r.stats.InvalidateWorkspace(id)
+	r.stats.InvalidateOrg(orgID)
+	return nil
 }
 
 // SlugExistsInOrg checks if a slug exists in an organization
@@ -99,6 +130,26 @@ func (r *WorkspaceRepository) SlugExistsInOrg(orgID uint, slug string) (bool, er
 	return count > 0, err
 }
 
+// ClientExistsInOrg checks if the client belongs to the organization
+func (r *WorkspaceRepository) ClientExistsInOrg(orgID, clientID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.Client{}).
+		Where("id = ? AND organization_id = ?", clientID, orgID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// GetCurrencies lists the currencies of an organization's workspaces,
+// including deleted ones whose time logs still appear in reports
+func (r *WorkspaceRepository) GetCurrencies(orgID uint) ([]string, error) {
+	var currencies []string
+	err := r.db.Unscoped().Model(&models.Workspace{}).
+		Where("organization_id = ?", orgID).
+		Distinct().
+		Pluck("currency", &currencies).Error
+	return currencies, err
+}
+
 // GetMemberCount gets the member count of a workspace
 func (r *WorkspaceRepository) GetMemberCount(workspaceID uint) (int64, error) {
 	var count int64
@@ -117,13 +168,47 @@ func (r *WorkspaceRepository) GetTaskCount(workspaceID uint) (int64, error) {
 	return count, err
 }
 
+// GetCountsByIDs gets the member and task counts of several workspaces in a single query
+func (r *WorkspaceRepository) GetCountsByIDs(workspaceIDs []uint) (map[uint]WorkspaceCounts, error) {
+	counts := make(map[uint]WorkspaceCounts, len(workspaceIDs))
+	if len(workspaceIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		WorkspaceID uint
+		WorkspaceCounts
+	}
+	err := r.db.Table("workspaces w").
+		Select(`w.id AS workspace_id,
+			COUNT(DISTINCT wm.id) AS member_count,
+			COUNT(DISTINCT t.id) AS task_count`).
+		Joins("LEFT JOIN workspace_members wm ON wm.workspace_id = w.id AND wm.is_active = true AND wm.deleted_at IS NULL").
+		Joins("LEFT JOIN tasks t ON t.workspace_id = w.id AND t.deleted_at IS NULL").
+		Where("w.id IN ?", workspaceIDs).
+		Group("w.id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.WorkspaceID] = row.WorkspaceCounts
+	}
+	return counts, nil
+}
+
 // ============================================================================
 // WORKSPACE MEMBER OPERATIONS
 // ============================================================================
 
 // AddMember adds a member to a workspace
 func (r *WorkspaceRepository) AddMember(member *models.WorkspaceMember) error {
-	return r.db.Create(member).Error
+	if err := r.db.Create(member).Error; err != nil {
+		return err
+	}
+	r.stats.InvalidateWorkspace(member.WorkspaceID)
+	return nil
 }
 
 // GetMember gets a member by workspace and user ID
@@ -186,21 +271,108 @@ func (r *WorkspaceRepository) GetUserWorkspacesByOrg(userID, orgID uint) ([]mode
 // UpdateMember updates a workspace member
 func (r *WorkspaceRepository) UpdateMember(member *models.WorkspaceMember) error {
 	// Use Updates with Select to explicitly update all fields including pointer fields
-	return r.db.Model(member).Select(
+	err := r.db.Model(member).Select(
 		"workspace_role_id",
 		"role_name",
 		"is_admin",
 		"can_view_reports",
 		"can_manage_tasks",
 		"is_active",
+		"weekly_hour_cap",
 		"updated_at",
 	).Updates(member).Error
+	if err != nil {
+		return err
+	}
+	r.stats.InvalidateWorkspace(member.WorkspaceID)
+	return nil
+}
+
+// MarkCapNotified records the week and level of a member's last hour cap notification
+func (r *WorkspaceRepository) MarkCapNotified(memberID uint, weekStart time.Time, level string) error {
+	return r.db.Model(&models.WorkspaceMember{}).
+		Where("id = ?", memberID).
+		UpdateColumns(map[string]interface{}{
+			"cap_notified_week":  weekStart,
+			"cap_notified_level": level,
+		}).Error
+}
+
+// GetWithBudget gets the active, unarchived workspaces that have a budget
+func (r *WorkspaceRepository) GetWithBudget() ([]models.Workspace, error) {
+	var workspaces []models.Workspace
+	err := r.db.Where("budget_type <> '' AND is_active = true AND is_archived = false").
+		Order("id").
+		Find(&workspaces).Error
+	return workspaces, err
 }
 
-// RemoveMember removes a member from a workspace (soft delete)
+// UpdateBudgetRollup records a budget rollup: the consumption and the highest threshold alerted
+func (r *WorkspaceRepository) UpdateBudgetRollup(workspaceID uint, consumed float64, alertedPercent int, at time.Time) error {
+	return r.db.Model(&models.Workspace{}).
+		Where("id = ?", workspaceID).
+		UpdateColumns(map[string]interface{}{
+			"budget_consumed":        consumed,
+			"budget_alerted_percent": alertedPercent,
+			"budget_rolled_up_at":    at,
+		}).Error
+}
+
+// RemoveMember removes a member from a workspace (soft delete) along with
+// their assignments to its tasks
 func (r *WorkspaceRepository) RemoveMember(workspaceID, userID uint) error {
-	return r.db.Where("workspace_id = ? AND user_id = ?", workspaceID, userID).
-		Delete(&models.WorkspaceMember{}).Error
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("workspace_id = ? AND user_id = ?", workspaceID, userID).
+			Delete(&models.WorkspaceMember{}).Error; err != nil {
+			return err
+		}
+		return removeTaskAssignments(tx, userID, []uint{workspaceID})
+	})
+	if err != nil {
+		return err
+	}
+	r.stats.InvalidateWorkspace(workspaceID)
+	return nil
+}
+
+// RemoveMemberFromOrg removes a user from every workspace of an organization (soft delete)
+func (r *WorkspaceRepository) RemoveMemberFromOrg(orgID, userID uint) error {
+	var workspaceIDs []uint
+	err := r.db.Model(&models.WorkspaceMember{}).
+		Joins("JOIN workspaces ON workspaces.id = workspace_members.workspace_id").
+		Where("workspace_members.user_id = ? AND workspaces.organization_id = ?", userID, orgID).
+		Pluck("workspace_members.workspace_id", &workspaceIDs).Error
+	if err != nil || len(workspaceIDs) == 0 {
+		return err
+	}
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ? AND workspace_id IN ?", userID, workspaceIDs).
+			Delete(&models.WorkspaceMember{}).Error; err != nil {
+			return err
+		}
+		return removeTaskAssignments(tx, userID, workspaceIDs)
+	})
+	if err != nil {
+		return err
+	}
+	for _, id := range workspaceIDs {
+		r.stats.InvalidateWorkspace(id)
+	}
+	return nil
+}
+
+// removeTaskAssignments unassigns a user from the tasks of the given workspaces
+func removeTaskAssignments(tx *gorm.DB, userID uint, workspaceIDs []uint) error {
+	return tx.Where("user_id = ? AND task_id IN (SELECT id FROM tasks WHERE workspace_id IN ?)", userID, workspaceIDs).
+		Delete(&models.TaskAssignee{}).Error
+}
+
+// ReassignAdmin hands the organization's workspaces administered by one user to another
+func (r *WorkspaceRepository) ReassignAdmin(orgID, fromUserID, toUserID uint) error {
+	return r.db.Model(&models.Workspace{}).
+		Where("organization_id = ? AND admin_id = ?", orgID, fromUserID).
+		Update("admin_id", toUserID).Error
 }
 
 // IsMember checks if a user is a member of a workspace
@@ -304,6 +476,7 @@ func (r *WorkspaceRepository) CreateDefaultRoles(orgID uint) error {
 			Name:           defaultRole.Name,
 			DisplayName:    defaultRole.DisplayName,
 			Color:          defaultRole.Color,
+			Permissions:    defaultRole.Permissions,
 			SortOrder:      defaultRole.SortOrder,
 			IsDefault:      defaultRole.Name == "dev", // Developer is default
 		}
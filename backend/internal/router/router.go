@@ -1,10 +1,17 @@
 package router
 
 import (
+	"log"
+	"net/http"
+
 	"github.com/beuphecan/remote-time-tracker/internal/config"
 	"github.com/beuphecan/remote-time-tracker/internal/controller"
 	"github.com/beuphecan/remote-time-tracker/internal/middleware"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/ratelimit"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
 	"github.com/beuphecan/remote-time-tracker/internal/service"
+	"github.com/beuphecan/remote-time-tracker/internal/validation"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -28,12 +35,175 @@ type RouterConfig struct {
 	AdminController         *controller.AdminController
 	AdminPresenceController *controller.AdminPresenceController
 
-	// Update controller
-	UpdateController *controller.UpdateController
+	AdminActivityFeedController *controller.AdminActivityFeedController
+
+	// Update controller and admin release publishing
+	UpdateController       *controller.UpdateController
+	AdminReleaseController *controller.AdminReleaseController
+
+	// Audit log controller
+	AuditLogController *controller.AuditLogController
+
+	// Personal data export/erasure controller
+	PrivacyController *controller.PrivacyController
+
+	// Organization analytics controller
+	AnalyticsController *controller.AnalyticsController
+
+	// Workspace and personal report summaries
+	ReportController *controller.ReportController
+
+	// Saved custom reports and their email schedules
+	SavedReportController *controller.SavedReportController
+
+	// Organization holiday calendar
+	HolidayController *controller.HolidayController
+
+	// Organization clients workspaces are grouped by
+	ClientController *controller.ClientController
+
+	// Typed organization settings
+	OrganizationSettingsController *controller.OrganizationSettingsController
+
+	// Scheduled organization deletion
+	OrganizationDeletionController *controller.OrganizationDeletionController
+
+	// Organization invoices billing approved time
+	InvoiceController *controller.InvoiceController
+
+	// Organization leave requests and team leave calendar
+	LeaveController *controller.LeaveController
+
+	// Admin user work schedule controller
+	AdminScheduleController *controller.AdminScheduleController
+
+	// Admin overtime report controller
+	AdminOvertimeController *controller.AdminOvertimeController
+
+	// Admin data retention controller
+	AdminRetentionController *controller.AdminRetentionController
+
+	// Admin screenshot storage quota controller
+	AdminStorageQuotaController *controller.AdminStorageQuotaController
+
+	// Admin review of screenshots quarantined by the malware scanner
+	AdminScreenshotScanController *controller.AdminScreenshotScanController
+
+	// Admin search through the text read from screenshots
+	AdminScreenshotOCRController *controller.AdminScreenshotOCRController
+
+	// Admin background jobs controller
+	AdminJobsController *controller.AdminJobsController
+
+	// Organization CSV time data import controller
+	OrganizationImportController *controller.OrganizationImportController
+
+	// Long-running operation progress controller
+	OperationController *controller.OperationController
+
+	// Organization data export controller
+	OrganizationExportController *controller.OrganizationExportController
+
+	// Admin soft-deleted data purge controller
+	AdminMaintenanceController *controller.AdminMaintenanceController
+
+	// Desktop app log bundle controllers
+	DeviceLogController      *controller.DeviceLogController
+	AdminDeviceLogController *controller.AdminDeviceLogController
+
+	// Desktop app configuration document controller
+	DeviceConfigController *controller.DeviceConfigController
+
+	// Feature flag evaluation and admin management
+	FeatureFlagController      *controller.FeatureFlagController
+	AdminFeatureFlagController *controller.AdminFeatureFlagController
+
+	// Crash telemetry and feature usage controllers
+	TelemetryController      *controller.TelemetryController
+	AdminTelemetryController *controller.AdminTelemetryController
+	TelemetryRateLimit       int // Uploads per minute per client
+
+	// Organization webhook controller
+	WebhookController *controller.WebhookController
+
+	// Organization Slack notifications
+	SlackController *controller.SlackController
+
+	// Screenshot deletion requests and manager approval queue
+	ScreenshotDeletionController *controller.ScreenshotDeletionController
+	DeviceApprovalController     *controller.DeviceApprovalController
+
+	// Sensitive-window capture exclusions and agent capture policy
+	CapturePolicyController *controller.CapturePolicyController
+
+	// Organization keys for client-side screenshot encryption
+	EncryptionKeyController *controller.EncryptionKeyController
+
+	// Member cost rates and the payroll report
+	PayrollController *controller.PayrollController
+
+	// Pay periods closed to member edits
+	PeriodLockController *controller.PeriodLockController
+
+	// Member effective permissions and role change history
+	PermissionController *controller.PermissionController
+
+	// Workspace task assignment rules
+	TaskAssignmentController *controller.TaskAssignmentController
+
+	// Workspace Kanban board columns and task order
+	TaskBoardController *controller.TaskBoardController
+
+	// Task comments and attachments
+	TaskCommentController *controller.TaskCommentController
+
+	// Full-text search
+	SearchController *controller.SearchController
+
+	// Workspace Jira integration
+	JiraController *controller.JiraController
+
+	// Workspace repositories and commits linked to time logs
+	CommitLinkController *controller.CommitLinkController
+
+	// Edit history of time logs
+	TimeLogHistoryController *controller.TimeLogHistoryController
+
+	// Personal ICS calendar feed and Google Calendar push
+	CalendarController *controller.CalendarController
+
+	// Read-only workspace report links for people without an account
+	ShareLinkController *controller.ShareLinkController
+
+	// Privacy mode: user pauses of screenshot capture
+	PrivateIntervalController *controller.PrivateIntervalController
+
+	// Personal email notification preferences
+	NotificationPreferenceController *controller.NotificationPreferenceController
+
+	// In-app notifications (notification bell)
+	NotificationController *controller.NotificationController
+
+	// Public invite code preview and its lookup metrics
+	InvitePreviewController *controller.InvitePreviewController
+
+	// Rate limiting of auth, sync and invite preview endpoints; nil limiter disables it
+	RateLimiter            ratelimit.Limiter
+	AuthRateLimit          middleware.RateLimitPolicy
+	SyncRateLimit          middleware.RateLimitPolicy
+	InvitePreviewRateLimit middleware.RateLimitPolicy
+
+	// Kubernetes liveness and readiness probes
+	HealthController *controller.HealthController
 
 	// Services for middleware
 	OrganizationService service.OrganizationService
 	WorkspaceService    service.WorkspaceService
+	AuditService        service.AuditService
+	// Resolves UUIDs in path parameters; nil accepts numeric IDs only
+	PublicIDRepository repository.PublicIDRepository
+	// Stores Idempotency-Key responses of POST requests; nil ignores the header
+	IdempotencyRepository repository.IdempotencyRepository
 }
 
 // SetupRouter configures and returns the Gin router
@@ -59,321 +229,965 @@ func SetupRouter(
 func SetupRouterWithConfig(cfg *RouterConfig) *gin.Engine {
 	router := gin.Default()
 
+	// The client IP behind rate limits and audit logs comes from
+	// X-Forwarded-For only when a trusted proxy sent the request
+	if err := router.SetTrustedProxies(config.AppConfig.Server.TrustedProxies); err != nil {
+		log.Printf("⚠️  Invalid TRUSTED_PROXIES, trusting no proxy: %v", err)
+		_ = router.SetTrustedProxies(nil)
+	}
+
 	// Apply middleware
 	router.Use(middleware.Logger())
 	router.Use(middleware.CORSMiddleware())
+	router.Use(middleware.ErrorHandler())
+	router.NoRoute(middleware.NotFoundHandler)
 
-	// Serve static files (screenshots)
-	router.Static("/uploads", config.AppConfig.Upload.Path)
+	// Custom validators, and validation errors naming fields the way
+	// clients send them
+	validation.Setup()
 
 	// Health check
 	router.GET("/health", middleware.HealthCheck)
+	if cfg.HealthController != nil {
+		router.GET("/healthz", cfg.HealthController.Liveness)
+		router.GET("/readyz", cfg.HealthController.Readiness)
+	}
 
 	// Swagger documentation
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	// API v1 routes
-	v1 := router.Group("/api/v1")
+	// Invite code preview handlers, rate limited against code enumeration
+	var invitePreview []gin.HandlerFunc
+	if cfg.InvitePreviewController != nil {
+		if cfg.RateLimiter != nil {
+			invitePreview = append(invitePreview, middleware.RateLimit(cfg.RateLimiter, cfg.InvitePreviewRateLimit))
+		}
+		invitePreview = append(invitePreview, cfg.InvitePreviewController.Preview)
+	}
+
+	// Workspace permission checks from the role permission matrix; the
+	// services enforce the same permissions, so routes stay safe without it
+	requireWorkspacePermission := func(permission string) gin.HandlerFunc {
+		return func(c *gin.Context) { c.Next() }
+	}
+	if cfg.OrganizationService != nil && cfg.WorkspaceService != nil {
+		authz := middleware.NewAuthorizationMiddleware(cfg.OrganizationService, cfg.WorkspaceService)
+		requireWorkspacePermission = authz.RequireWorkspacePermission
+	}
+
+	// API versions share the routes and controllers. Handlers speak the v1
+	// field names; v2 renames the inconsistent ones through a compatibility
+	// shim, so older desktop clients keep syncing against v1 unchanged.
+	versions := apiVersions(config.AppConfig.API)
+	for _, version := range versions {
+		api := router.Group("/api/" + version.Name)
+		api.Use(middleware.APIVersionHeaders(version))
+		if version.FieldRenames != nil {
+			api.Use(middleware.RenameJSONFields(version.FieldRenames))
+		}
+		if cfg.AuditService != nil {
+			api.Use(middleware.AuditMutations(cfg.AuditService))
+		}
+		registerAPIRoutes(api, cfg, invitePreview, requireWorkspacePermission)
+	}
+	router.GET("/api/versions", listAPIVersions(versions))
+
+	return router
+}
+
+// apiVersions lists the mounted API versions, oldest first
+func apiVersions(apiConfig config.APIConfig) []middleware.APIVersion {
+	return []middleware.APIVersion{
+		{
+			Name:         "v1",
+			DeprecatedAt: apiConfig.V1DeprecatedAt,
+			SunsetAt:     apiConfig.V1SunsetAt,
+			Successor:    "v2",
+		},
+		{
+			Name:         "v2",
+			FieldRenames: v2FieldRenames,
+		},
+	}
+}
+
+// v2FieldRenames fixes v1 field names that abbreviate or run words together
+// where the rest of the API spells them out
+var v2FieldRenames = map[string]string{
+	"org_id":          "organization_id",
+	"org_name":        "organization_name",
+	"org_slug":        "organization_slug",
+	"org_role":        "organization_role",
+	"orgs_count":      "organizations_count",
+	"timelogs":        "time_logs",
+	"timelog_id":      "time_log_id",
+	"timelogs_count":  "time_logs_count",
+	"total_timelogs":  "total_time_logs",
+	"recent_timelogs": "recent_time_logs",
+	"page_size":       "per_page",
+}
+
+// listAPIVersions serves the API versions with their status and, for
+// deprecated ones, the sunset date
+func listAPIVersions(versions []middleware.APIVersion) gin.HandlerFunc {
+	list := make([]gin.H, 0, len(versions))
+	for _, version := range versions {
+		list = append(list, gin.H{
+			"version":       version.Name,
+			"path":          "/api/" + version.Name,
+			"status":        version.Status(),
+			"deprecated_at": version.DeprecatedAt,
+			"sunset_at":     version.SunsetAt,
+		})
+	}
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"versions": list})
+	}
+}
+
+// registerAPIRoutes registers the API routes on a version's group
+func registerAPIRoutes(api *gin.RouterGroup, cfg *RouterConfig, invitePreview []gin.HandlerFunc, requireWorkspacePermission func(string) gin.HandlerFunc) {
+	// Public routes
+	auth := api.Group("/auth")
+	if cfg.RateLimiter != nil {
+		auth.Use(middleware.RateLimit(cfg.RateLimiter, cfg.AuthRateLimit))
+	}
 	{
-		// Public routes
-		auth := v1.Group("/auth")
+		auth.POST("/register", cfg.AuthController.Register)
+		auth.POST("/login", cfg.AuthController.Login)
+		auth.POST("/refresh", cfg.AuthController.RefreshToken)
+		auth.POST("/password/forgot", cfg.AuthController.ForgotPassword)
+		auth.POST("/password/reset", cfg.AuthController.ResetPassword)
+	}
+
+	// Public system routes (no auth required) - for initializing admin
+	if cfg.SystemController != nil {
+		publicSystem := api.Group("/system")
+		{
+			publicSystem.POST("/init-admin", cfg.SystemController.InitializeAdmin)
+			publicSystem.GET("/admin-exists", cfg.SystemController.CheckAdminExists)
+		}
+	}
+
+	// Public invitation routes (for accepting invitations)
+	if cfg.InvitationController != nil {
+		invitations := api.Group("/invitations")
+		invitations.Use(middleware.OptionalAuthMiddleware())
+		{
+			invitations.GET("/:token", cfg.InvitationController.GetByToken)
+			invitations.POST("/accept", cfg.InvitationController.AcceptByBody)
+		}
+
+		// Accepting can create an account or check a password, so it
+		// shares the auth rate limit
+		acceptInvitation := invitations.Group("")
+		if cfg.RateLimiter != nil {
+			acceptInvitation.Use(middleware.RateLimit(cfg.RateLimiter, cfg.AuthRateLimit))
+		}
+		acceptInvitation.POST("/:token/accept", cfg.InvitationController.AcceptToken)
+	}
+
+	// Public organization routes (for viewing invite link info)
+	if invitePreview != nil {
+		publicOrgs := api.Group("/public/organizations")
+		{
+			publicOrgs.GET("/invite/:invite_code", invitePreview...)
+		}
+	}
+
+	// Organization export archives, authorized by a signed link
+	if cfg.OrganizationExportController != nil {
+		api.GET("/public/organization-exports/:export_id/download", cfg.OrganizationExportController.DownloadExport)
+	}
+
+	// Repository push webhooks, authorized by the repository's webhook secret
+	if cfg.CommitLinkController != nil {
+		api.POST("/public/vcs/repositories/:repo_id/webhook", cfg.CommitLinkController.HandleWebhook)
+	}
+
+	// Calendar feeds, authorized by the private token in the URL
+	if cfg.CalendarController != nil {
+		api.GET("/calendar/:token", cfg.CalendarController.ServeFeed)
+	}
+
+	// Shared workspace reports, authorized by the token in the link
+	if cfg.ShareLinkController != nil {
+		api.GET("/public/share-links/:token", cfg.ShareLinkController.ViewSharedReport)
+	}
+
+	// Public download routes (for website to get app download links)
+	if cfg.UpdateController != nil {
+		publicDownloads := api.Group("/public/downloads")
 		{
-			auth.POST("/register", cfg.AuthController.Register)
-			auth.POST("/login", cfg.AuthController.Login)
-			auth.POST("/refresh", cfg.AuthController.RefreshToken)
+			publicDownloads.GET("/latest", cfg.UpdateController.GetPublicDownloadLinks)
+			publicDownloads.GET("/file/:version/:filename", cfg.UpdateController.DownloadAsset) // Reuse existing handler
 		}
+	}
+
+	// Crash telemetry and feature usage from clients; auth is optional so
+	// crashes before login are still reported
+	if cfg.TelemetryController != nil {
+		telemetry := api.Group("/telemetry")
+		telemetry.Use(middleware.OptionalAuthMiddleware(), middleware.AdminRateLimit(cfg.TelemetryRateLimit))
+		{
+			telemetry.POST("/errors", cfg.TelemetryController.ReportErrors)
+			telemetry.POST("/events", cfg.TelemetryController.ReportEvents)
+		}
+	}
 
-		// Public system routes (no auth required) - for initializing admin
-		if cfg.SystemController != nil {
-			publicSystem := v1.Group("/system")
+	// Public update routes (for checking and downloading updates)
+	// These require JWT auth to prevent unauthorized access
+	if cfg.UpdateController != nil {
+		updates := api.Group("/updates")
+		updates.Use(middleware.AuthMiddleware())
+		{
+			updates.POST("/check", cfg.UpdateController.CheckForUpdates)
+			updates.GET("/latest", cfg.UpdateController.GetLatestVersion)
+			updates.GET("/download/:version/:filename", cfg.UpdateController.DownloadAsset)
+			updates.GET("/yml/:platform", cfg.UpdateController.GetYMLFile)
+			updates.GET("/notes/:version", cfg.UpdateController.GetReleaseNotes)
+			updates.GET("/notes", cfg.UpdateController.GetReleaseNotes) // Default to latest
+		}
+	}
+
+	// Protected routes
+	protected := api.Group("")
+	protected.Use(middleware.AuthMiddleware())
+	if cfg.PublicIDRepository != nil {
+		protected.Use(middleware.ResolvePublicIDs(cfg.PublicIDRepository))
+	}
+	if cfg.IdempotencyRepository != nil {
+		protected.Use(middleware.Idempotency(cfg.IdempotencyRepository, config.AppConfig.Idempotency.KeyTTL))
+	}
+	{
+		// Auth
+		protected.GET("/auth/me", cfg.AuthController.Me)
+		protected.PUT("/users/me/timezone", cfg.AuthController.UpdateTimezone)
+
+		// Presence
+		if cfg.PresenceController != nil {
+			presence := protected.Group("/presence")
 			{
-				publicSystem.POST("/init-admin", cfg.SystemController.InitializeAdmin)
-				publicSystem.GET("/admin-exists", cfg.SystemController.CheckAdminExists)
+				presence.POST("/heartbeat", cfg.PresenceController.Heartbeat)
 			}
 		}
 
-		// Public invitation routes (for accepting invitations)
-		if cfg.InvitationController != nil {
-			invitations := v1.Group("/invitations")
+		// Long-running operation progress
+		if cfg.OperationController != nil {
+			protected.GET("/operations/:id", cfg.OperationController.GetOperation)
+		}
+
+		// Personal data export and account erasure (GDPR)
+		if cfg.PrivacyController != nil {
+			me := protected.Group("/users/me")
 			{
-				invitations.GET("/:token", cfg.InvitationController.GetByToken)
-				invitations.POST("/accept", cfg.InvitationController.AcceptByBody)
+				me.POST("/export", cfg.PrivacyController.RequestExport)
+				me.GET("/exports", cfg.PrivacyController.ListExports)
+				me.GET("/exports/:id", cfg.PrivacyController.GetExport)
+				me.GET("/exports/:id/download", cfg.PrivacyController.DownloadExport)
+				me.DELETE("", cfg.PrivacyController.RequestDeletion)
+				me.POST("/deletion/cancel", cfg.PrivacyController.CancelDeletion)
 			}
 		}
 
-		// Public organization routes (for viewing invite link info)
-		if cfg.OrganizationController != nil {
-			publicOrgs := v1.Group("/public/organizations")
+		// Personal calendar feed and Google Calendar push
+		if cfg.CalendarController != nil {
+			calendar := protected.Group("/users/me/calendar")
 			{
-				publicOrgs.GET("/invite/:invite_code", cfg.OrganizationController.GetOrgByInviteCode)
+				calendar.GET("/feed", cfg.CalendarController.GetFeed)
+				calendar.POST("/feed", cfg.CalendarController.RotateFeed)
+				calendar.DELETE("/feed", cfg.CalendarController.DisableFeed)
+				calendar.GET("/google", cfg.CalendarController.GetGoogle)
+				calendar.DELETE("/google", cfg.CalendarController.DisconnectGoogle)
+				calendar.GET("/google/oauth", cfg.CalendarController.GoogleOAuthURL)
+				calendar.POST("/google/oauth", cfg.CalendarController.CompleteGoogleOAuth)
 			}
 		}
 
-		// Public download routes (for website to get app download links)
-		if cfg.UpdateController != nil {
-			publicDownloads := v1.Group("/public/downloads")
+		// Privacy mode
+		if cfg.PrivateIntervalController != nil {
+			private := protected.Group("/users/me/private-intervals")
 			{
-				publicDownloads.GET("/latest", cfg.UpdateController.GetPublicDownloadLinks)
-				publicDownloads.GET("/file/:version/:filename", cfg.UpdateController.DownloadAsset) // Reuse existing handler
+				private.GET("", cfg.PrivateIntervalController.ListPrivateIntervals)
+				private.POST("", cfg.PrivateIntervalController.StartPrivateInterval)
+				private.POST("/:id/end", cfg.PrivateIntervalController.EndPrivateInterval)
 			}
 		}
 
-		// Public update routes (for checking and downloading updates)
-		// These require JWT auth to prevent unauthorized access
-		if cfg.UpdateController != nil {
-			updates := v1.Group("/updates")
-			updates.Use(middleware.AuthMiddleware())
+		// Personal email notification preferences
+		if cfg.NotificationPreferenceController != nil {
+			protected.GET("/users/me/notification-preferences", cfg.NotificationPreferenceController.GetPreferences)
+			protected.PUT("/users/me/notification-preferences", cfg.NotificationPreferenceController.UpdatePreferences)
+		}
+
+		// Personal report summary
+		if cfg.ReportController != nil {
+			protected.GET("/users/me/reports/summary", cfg.ReportController.GetMySummary)
+		}
+
+		// In-app notifications
+		if cfg.NotificationController != nil {
+			notifications := protected.Group("/notifications")
 			{
-				updates.POST("/check", cfg.UpdateController.CheckForUpdates)
-				updates.GET("/latest", cfg.UpdateController.GetLatestVersion)
-				updates.GET("/download/:version/:filename", cfg.UpdateController.DownloadAsset)
-				updates.GET("/yml/:platform", cfg.UpdateController.GetYMLFile)
-				updates.GET("/notes/:version", cfg.UpdateController.GetReleaseNotes)
-				updates.GET("/notes", cfg.UpdateController.GetReleaseNotes) // Default to latest
+				notifications.GET("", cfg.NotificationController.List)
+				notifications.GET("/unread-count", cfg.NotificationController.UnreadCount)
+				notifications.PUT("/read-all", cfg.NotificationController.MarkAllRead)
+				notifications.PUT("/:id/read", cfg.NotificationController.MarkRead)
 			}
 		}
 
-		// Protected routes
-		protected := v1.Group("")
-		protected.Use(middleware.AuthMiddleware())
-		{
-			// Auth
-			protected.GET("/auth/me", cfg.AuthController.Me)
+		// Full-text search
+		if cfg.SearchController != nil {
+			protected.GET("/search", cfg.SearchController.Search)
+		}
 
-			// Presence
-			if cfg.PresenceController != nil {
-				presence := protected.Group("/presence")
-				{
-					presence.POST("/heartbeat", cfg.PresenceController.Heartbeat)
-				}
-			}
+		// User invitations
+		if cfg.InvitationController != nil {
+			protected.GET("/invitations/my", cfg.InvitationController.GetMyInvitations)
+		}
 
-			// User invitations
-			if cfg.InvitationController != nil {
-				protected.GET("/invitations/my", cfg.InvitationController.GetMyInvitations)
+		// Time logs
+		timeLogs := protected.Group("/timelogs")
+		{
+			timeLogs.GET("", cfg.TimeLogController.List)
+			timeLogs.GET("/:id", cfg.TimeLogController.GetByID)
+			timeLogs.POST("/start", cfg.TimeLogController.Start)
+			timeLogs.POST("/manual", cfg.TimeLogController.CreateManual)
+			timeLogs.POST("/stop", cfg.TimeLogController.Stop)
+			timeLogs.POST("/pause", cfg.TimeLogController.Pause)
+			timeLogs.POST("/resume", cfg.TimeLogController.Resume)
+			timeLogs.GET("/active", cfg.TimeLogController.GetActive)
+			timeLogs.GET("/current", cfg.TimeLogController.GetCurrent)
+			timeLogs.GET("/stats", cfg.TimeLogController.GetStats)
+			if cfg.CommitLinkController != nil {
+				timeLogs.GET("/:id/commits", cfg.CommitLinkController.ListTimeLogCommits)
+				timeLogs.POST("/:id/commits", cfg.CommitLinkController.AddTimeLogCommit)
+				timeLogs.DELETE("/:id/commits/:commit_id", cfg.CommitLinkController.RemoveTimeLogCommit)
 			}
-
-			// Time logs
-			timeLogs := protected.Group("/timelogs")
-			{
-				timeLogs.GET("", cfg.TimeLogController.List)
-				timeLogs.GET("/:id", cfg.TimeLogController.GetByID)
-				timeLogs.POST("/start", cfg.TimeLogController.Start)
-				timeLogs.POST("/stop", cfg.TimeLogController.Stop)
-				timeLogs.POST("/pause", cfg.TimeLogController.Pause)
-				timeLogs.POST("/resume", cfg.TimeLogController.Resume)
-				timeLogs.GET("/active", cfg.TimeLogController.GetActive)
-				timeLogs.GET("/stats", cfg.TimeLogController.GetStats)
+			if cfg.TimeLogHistoryController != nil {
+				timeLogs.GET("/:id/history", cfg.TimeLogHistoryController.ListHistory)
 			}
+		}
 
-			// Sync
-			sync := protected.Group("/sync")
-			{
-				sync.POST("/batch", cfg.SyncController.BatchSync)
-			}
+		// Sync
+		sync := protected.Group("/sync")
+		if cfg.RateLimiter != nil {
+			sync.Use(middleware.RateLimit(cfg.RateLimiter, cfg.SyncRateLimit))
+		}
+		{
+			sync.POST("/batch", cfg.SyncController.BatchSync)
+			sync.GET("/conflicts", cfg.SyncController.ListConflicts)
+			sync.POST("/conflicts/:id/resolve", cfg.SyncController.ResolveConflict)
+		}
 
-			// Screenshots
-			screenshots := protected.Group("/screenshots")
-			{
-				screenshots.GET("", cfg.ScreenshotController.ListScreenshots)
-				screenshots.GET("/today/count", cfg.ScreenshotController.GetTodayScreenshotCount)
-				screenshots.GET("/:id", cfg.ScreenshotController.GetScreenshot)
-				screenshots.GET("/:id/view", cfg.ScreenshotController.ViewScreenshot)
-				screenshots.GET("/:id/download", cfg.ScreenshotController.DownloadScreenshot)
-				screenshots.GET("/timelog/:timelog_id", cfg.ScreenshotController.GetScreenshotsByTimeLog)
-				screenshots.GET("/task/:task_id", cfg.ScreenshotController.GetScreenshotsByTaskID)
-				screenshots.GET("/range", cfg.ScreenshotController.GetScreenshotsByDateRange)
-				screenshots.GET("/stats", cfg.ScreenshotController.GetScreenshotStats)
-				screenshots.DELETE("/:id", cfg.ScreenshotController.DeleteScreenshot)
+		// Desktop app log bundles
+		if cfg.DeviceLogController != nil {
+			protected.POST("/devices/logs", cfg.DeviceLogController.Upload)
+		}
+
+		// Desktop app configuration polled for policy changes
+		if cfg.DeviceConfigController != nil {
+			protected.GET("/devices/:uuid/config", cfg.DeviceConfigController.GetConfig)
+		}
+
+		// Feature flags evaluated for the current user
+		if cfg.FeatureFlagController != nil {
+			protected.GET("/features", cfg.FeatureFlagController.Evaluate)
+		}
+
+		// Screenshots
+		screenshots := protected.Group("/screenshots")
+		{
+			screenshots.GET("", cfg.ScreenshotController.ListScreenshots)
+			screenshots.GET("/today/count", cfg.ScreenshotController.GetTodayScreenshotCount)
+			screenshots.GET("/:id", cfg.ScreenshotController.GetScreenshot)
+			screenshots.GET("/:id/view", cfg.ScreenshotController.ViewScreenshot)
+			screenshots.GET("/:id/download", cfg.ScreenshotController.DownloadScreenshot)
+			screenshots.GET("/:id/encrypted", cfg.ScreenshotController.DownloadEncryptedScreenshot)
+			screenshots.GET("/timelog/:timelog_id", cfg.ScreenshotController.GetScreenshotsByTimeLog)
+			screenshots.GET("/task/:task_id", cfg.ScreenshotController.GetScreenshotsByTaskID)
+			screenshots.GET("/range", cfg.ScreenshotController.GetScreenshotsByDateRange)
+			screenshots.GET("/stats", cfg.ScreenshotController.GetScreenshotStats)
+			screenshots.DELETE("/:id", cfg.ScreenshotController.DeleteScreenshot)
+
+			// Deletion requests for organization screenshots
+			if cfg.ScreenshotDeletionController != nil {
+				screenshots.POST("/:id/deletion-request", cfg.ScreenshotDeletionController.RequestDeletion)
+				screenshots.POST("/:id/delete-request", cfg.ScreenshotDeletionController.RequestDeletion) // Alias for the path the feature request specified
+				screenshots.GET("/deletion-requests", cfg.ScreenshotDeletionController.ListMyRequests)
+				screenshots.DELETE("/deletion-requests/:request_id", cfg.ScreenshotDeletionController.CancelRequest)
 			}
+		}
 
-			// Tasks
-			tasks := protected.Group("/tasks")
-			{
-				tasks.GET("", cfg.TaskController.List)
-				tasks.POST("", cfg.TaskController.Create)
-				tasks.GET("/:id", cfg.TaskController.GetByID)
-				tasks.PUT("/:id", cfg.TaskController.Update)
-				tasks.DELETE("/:id", cfg.TaskController.Delete)
-				tasks.GET("/active", cfg.TaskController.GetActiveTasks)
+		// Tasks
+		tasks := protected.Group("/tasks")
+		{
+			tasks.GET("", cfg.TaskController.List)
+			tasks.POST("", cfg.TaskController.Create)
+			tasks.GET("/:id", cfg.TaskController.GetByID)
+			tasks.PUT("/:id", cfg.TaskController.Update)
+			tasks.DELETE("/:id", cfg.TaskController.Delete)
+			tasks.GET("/active", cfg.TaskController.GetActiveTasks)
+			tasks.GET("/:id/tree", cfg.TaskController.GetTree)
+			tasks.GET("/:id/assignees", cfg.TaskController.ListAssignees)
+			tasks.POST("/:id/assignees", cfg.TaskController.AddAssignee)
+			tasks.DELETE("/:id/assignees/:user_id", cfg.TaskController.RemoveAssignee)
+
+			// Discussion
+			if cfg.TaskCommentController != nil {
+				tasks.GET("/:id/comments", cfg.TaskCommentController.ListComments)
+				tasks.POST("/:id/comments", cfg.TaskCommentController.CreateComment)
+				tasks.PUT("/:id/comments/:comment_id", cfg.TaskCommentController.UpdateComment)
+				tasks.DELETE("/:id/comments/:comment_id", cfg.TaskCommentController.DeleteComment)
+				tasks.GET("/:id/attachments", cfg.TaskCommentController.ListAttachments)
+				tasks.POST("/:id/attachments", cfg.TaskCommentController.UploadAttachment)
+				tasks.GET("/:id/attachments/:attachment_id/download", cfg.TaskCommentController.DownloadAttachment)
+				tasks.DELETE("/:id/attachments/:attachment_id", cfg.TaskCommentController.DeleteAttachment)
 			}
+		}
+
+		// System
+		system := protected.Group("/system")
+		{
+			system.GET("/uploads/check", cfg.SystemController.CheckUploadsFolder)
+			system.POST("/uploads/ensure", cfg.SystemController.EnsureUploadsFolders)
+		}
 
-			// System
-			system := protected.Group("/system")
+		// Organizations
+		if cfg.OrganizationController != nil {
+			orgs := protected.Group("/organizations")
 			{
-				system.GET("/uploads/check", cfg.SystemController.CheckUploadsFolder)
-				system.POST("/uploads/ensure", cfg.SystemController.EnsureUploadsFolders)
-			}
+				orgs.GET("", cfg.OrganizationController.List)
+				orgs.POST("", cfg.OrganizationController.Create)
+				if invitePreview != nil {
+					orgs.GET("/join/:invite_code", invitePreview...)
+				}
+				orgs.POST("/join/:invite_code", cfg.OrganizationController.JoinByInviteCode)
 
-			// Organizations
-			if cfg.OrganizationController != nil {
-				orgs := protected.Group("/organizations")
+				// Organization-specific routes (require org membership)
+				org := orgs.Group("/:org_id")
+				org.Use(middleware.SetUserIDMiddleware())
 				{
-					orgs.GET("", cfg.OrganizationController.List)
-					orgs.POST("", cfg.OrganizationController.Create)
-					orgs.GET("/join/:invite_code", cfg.OrganizationController.GetOrgByInviteCode)
-					orgs.POST("/join/:invite_code", cfg.OrganizationController.JoinByInviteCode)
-
-					// Organization-specific routes (require org membership)
-					org := orgs.Group("/:org_id")
-					org.Use(middleware.SetUserIDMiddleware())
+					org.GET("", cfg.OrganizationController.GetByID)
+					org.PUT("", cfg.OrganizationController.Update)
+					org.POST("/leave", cfg.OrganizationController.Leave)
+
+					// Organization deletion (grace period, then background purge)
+					if cfg.OrganizationDeletionController != nil {
+						org.DELETE("", cfg.OrganizationDeletionController.RequestDeletion)
+						org.POST("/deletion/cancel", cfg.OrganizationDeletionController.CancelDeletion)
+					}
+
+					// Organization calendar (working days, fiscal year)
+					org.GET("/calendar", cfg.OrganizationController.GetCalendar)
+					org.PUT("/calendar", cfg.OrganizationController.UpdateCalendar)
+					if cfg.HolidayController != nil {
+						org.GET("/calendar/holidays", cfg.HolidayController.ListHolidays)
+						org.POST("/calendar/holidays", cfg.HolidayController.CreateHoliday)
+						org.DELETE("/calendar/holidays/:holiday_id", cfg.HolidayController.DeleteHoliday)
+						org.GET("/calendar/holidays/presets", cfg.HolidayController.ListPresets)
+						org.POST("/calendar/holidays/presets", cfg.HolidayController.ImportPreset)
+					}
+
+					// Organization clients
+					if cfg.ClientController != nil {
+						org.GET("/clients", cfg.ClientController.ListClients)
+						org.POST("/clients", cfg.ClientController.CreateClient)
+						org.GET("/clients/:client_id", cfg.ClientController.GetClient)
+						org.PUT("/clients/:client_id", cfg.ClientController.UpdateClient)
+						org.DELETE("/clients/:client_id", cfg.ClientController.DeleteClient)
+					}
+
+					// Organization invoices
+					if cfg.InvoiceController != nil {
+						org.GET("/invoices", cfg.InvoiceController.ListInvoices)
+						org.POST("/invoices", cfg.InvoiceController.CreateInvoice)
+						org.GET("/invoices/:invoice_id", cfg.InvoiceController.GetInvoice)
+						org.DELETE("/invoices/:invoice_id", cfg.InvoiceController.DeleteInvoice)
+						org.POST("/invoices/:invoice_id/send", cfg.InvoiceController.SendInvoice)
+						org.POST("/invoices/:invoice_id/pay", cfg.InvoiceController.PayInvoice)
+						org.GET("/invoices/:invoice_id/pdf", cfg.InvoiceController.DownloadInvoicePDF)
+					}
+
+					// Organization settings
+					if cfg.OrganizationSettingsController != nil {
+						org.GET("/settings", cfg.OrganizationSettingsController.GetSettings)
+						org.PUT("/settings", cfg.OrganizationSettingsController.UpdateSettings)
+					}
+
+					// Organization data retention
+					org.GET("/retention", cfg.OrganizationController.GetRetention)
+					org.PUT("/retention", cfg.OrganizationController.UpdateRetention)
+
+					// Organization feature usage analytics opt-out
+					org.GET("/usage-analytics", cfg.OrganizationController.GetUsageAnalytics)
+					org.PUT("/usage-analytics", cfg.OrganizationController.UpdateUsageAnalytics)
+
+					// Organization data export (owner only)
+					if cfg.OrganizationExportController != nil {
+						org.POST("/export", cfg.OrganizationExportController.RequestExport)
+						org.GET("/exports", cfg.OrganizationExportController.ListExports)
+						org.GET("/exports/:export_id", cfg.OrganizationExportController.GetExport)
+					}
+
+					// Time data import from other trackers (admin only)
+					if cfg.OrganizationImportController != nil {
+						org.POST("/import", cfg.OrganizationImportController.Import)
+					}
+
+					// Organization members
+					members := org.Group("/members")
 					{
-						org.GET("", cfg.OrganizationController.GetByID)
-						org.PUT("", cfg.OrganizationController.Update)
-						org.DELETE("", cfg.OrganizationController.Delete)
+						members.GET("", cfg.OrganizationController.GetMembers)
+						members.POST("", cfg.OrganizationController.AddMember)
+						members.PUT("/:user_id", cfg.OrganizationController.UpdateMember)
+						members.DELETE("/:user_id", cfg.OrganizationController.RemoveMember)
+						members.POST("/:user_id/handoff", cfg.OrganizationController.HandoffWork)
+						if cfg.PermissionController != nil {
+							members.GET("/:user_id/permissions", cfg.PermissionController.GetMemberPermissions)
+							members.GET("/:user_id/role-history", cfg.PermissionController.GetRoleHistory)
+						}
+					}
 
-						// Organization members
-						members := org.Group("/members")
+					// Organization roles (workspace roles)
+					roles := org.Group("/roles")
+					{
+						roles.GET("", cfg.OrganizationController.GetRoles)
+						roles.POST("", cfg.OrganizationController.CreateRole)
+						roles.PUT("/:role_id", cfg.OrganizationController.UpdateRole)
+						roles.DELETE("/:role_id", cfg.OrganizationController.DeleteRole)
+						if cfg.PermissionController != nil {
+							roles.GET("/permission-schema", cfg.PermissionController.GetPermissionSchema)
+							roles.GET("/:role_id/permissions", cfg.PermissionController.GetRolePermissions)
+							roles.PUT("/:role_id/permissions", cfg.PermissionController.UpdateRolePermissions)
+						}
+					}
+
+					// Organization workspaces
+					workspaces := org.Group("/workspaces")
+					{
+						workspaces.GET("", cfg.OrganizationController.GetWorkspaces)
+						workspaces.POST("", cfg.OrganizationController.CreateWorkspace)
+					}
+
+					// Organization invitations
+					invitations := org.Group("/invitations")
+					{
+						invitations.GET("", cfg.OrganizationController.GetInvitations)
+						invitations.POST("", cfg.OrganizationController.CreateInvitation)
+						invitations.DELETE("/:invitation_id", cfg.OrganizationController.RevokeInvitation)
+					}
+
+					// Organization webhooks (member lifecycle events for HR systems)
+					if cfg.WebhookController != nil {
+						webhooks := org.Group("/webhooks")
 						{
-							members.GET("", cfg.OrganizationController.GetMembers)
-							members.POST("", cfg.OrganizationController.AddMember)
-							members.PUT("/:user_id", cfg.OrganizationController.UpdateMember)
-							members.DELETE("/:user_id", cfg.OrganizationController.RemoveMember)
+							webhooks.GET("", cfg.WebhookController.List)
+							webhooks.POST("", cfg.WebhookController.Create)
+							webhooks.PUT("/:webhook_id", cfg.WebhookController.Update)
+							webhooks.DELETE("/:webhook_id", cfg.WebhookController.Delete)
+							webhooks.POST("/:webhook_id/rotate-secret", cfg.WebhookController.RotateSecret)
+							webhooks.GET("/:webhook_id/deliveries", cfg.WebhookController.ListDeliveries)
+							webhooks.POST("/:webhook_id/replay", cfg.WebhookController.Replay)
 						}
+					}
 
-						// Organization roles (workspace roles)
-						roles := org.Group("/roles")
+					// Slack notifications
+					if cfg.SlackController != nil {
+						slack := org.Group("/integrations/slack")
 						{
-							roles.GET("", cfg.OrganizationController.GetRoles)
-							roles.POST("", cfg.OrganizationController.CreateRole)
-							roles.PUT("/:role_id", cfg.OrganizationController.UpdateRole)
-							roles.DELETE("/:role_id", cfg.OrganizationController.DeleteRole)
+							slack.GET("", cfg.SlackController.GetIntegration)
+							slack.PUT("", cfg.SlackController.SaveIntegration)
+							slack.DELETE("", cfg.SlackController.DeleteIntegration)
+							slack.POST("/test", cfg.SlackController.SendTest)
+							slack.GET("/oauth", cfg.SlackController.OAuthURL)
+							slack.POST("/oauth", cfg.SlackController.CompleteOAuth)
 						}
+					}
 
-						// Organization workspaces
-						workspaces := org.Group("/workspaces")
+					// Capture policy for agents and sensitive-window exclusion rules
+					if cfg.CapturePolicyController != nil {
+						org.GET("/capture-policy", cfg.CapturePolicyController.GetPolicy)
+						exclusions := org.Group("/capture-exclusions")
 						{
-							workspaces.GET("", cfg.OrganizationController.GetWorkspaces)
-							workspaces.POST("", cfg.OrganizationController.CreateWorkspace)
+							exclusions.GET("", cfg.CapturePolicyController.ListRules)
+							exclusions.POST("", cfg.CapturePolicyController.CreateRule)
+							exclusions.PUT("/:rule_id", cfg.CapturePolicyController.UpdateRule)
+							exclusions.DELETE("/:rule_id", cfg.CapturePolicyController.DeleteRule)
 						}
+					}
 
-						// Organization invitations
-						invitations := org.Group("/invitations")
+					// Screenshot encryption keys and re-keying after rotation
+					if cfg.EncryptionKeyController != nil {
+						keys := org.Group("/encryption-keys")
 						{
-							invitations.GET("", cfg.OrganizationController.GetInvitations)
-							invitations.POST("", cfg.OrganizationController.CreateInvitation)
-							invitations.DELETE("/:invitation_id", cfg.OrganizationController.RevokeInvitation)
+							keys.GET("", cfg.EncryptionKeyController.ListKeys)
+							keys.POST("", cfg.EncryptionKeyController.RegisterKey)
+							keys.POST("/rekey", cfg.EncryptionKeyController.RekeyScreenshots)
+							keys.GET("/:key_id/screenshots", cfg.EncryptionKeyController.ListKeyScreenshots)
 						}
+					}
 
-						// Admin operations
-						org.POST("/regenerate-invite-code", cfg.OrganizationController.RegenerateInviteCode)
-						org.POST("/transfer-ownership", cfg.OrganizationController.TransferOwnership)
+					// Member cost rates and payroll report (admin only)
+					if cfg.PayrollController != nil {
+						org.GET("/cost-rates", cfg.PayrollController.ListCostRates)
+						org.PUT("/cost-rates/:user_id", cfg.PayrollController.UpdateCostRate)
+						org.GET("/reports/payroll", cfg.PayrollController.GetReport)
+						org.GET("/reports/payroll/export", cfg.PayrollController.ExportReport)
 					}
-				}
-			}
 
-			// Workspaces (standalone routes)
-			if cfg.WorkspaceController != nil {
-				workspaces := protected.Group("/workspaces")
-				{
-					workspaces.GET("", cfg.WorkspaceController.List)
+					// Closed pay periods (locking and reopening admin only)
+					if cfg.PeriodLockController != nil {
+						org.GET("/period-lock", cfg.PeriodLockController.GetLock)
+						org.PUT("/period-lock", cfg.PeriodLockController.Lock)
+						org.POST("/period-lock/reopen", cfg.PeriodLockController.Reopen)
+					}
 
-					// Workspace-specific routes
-					ws := workspaces.Group("/:workspace_id")
-					ws.Use(middleware.SetUserIDMiddleware())
-					{
-						ws.GET("", cfg.WorkspaceController.GetByID)
-						ws.PUT("", cfg.WorkspaceController.Update)
-						ws.DELETE("", cfg.WorkspaceController.Delete)
+					// Dashboard stats (admin only) and custom report builder, scoped to what the caller may see
+					if cfg.ReportController != nil {
+						org.GET("/stats", cfg.ReportController.GetOrganizationStats)
+						org.GET("/reports/custom/options", cfg.ReportController.GetCustomReportOptions)
+						org.POST("/reports/custom", cfg.ReportController.RunCustomReport)
+					}
 
-						// Workspace members
-						members := ws.Group("/members")
+					// Saved custom reports and scheduled email delivery
+					if cfg.SavedReportController != nil {
+						saved := org.Group("/saved-reports")
 						{
-							members.GET("", cfg.WorkspaceController.GetMembers)
-							members.POST("", cfg.WorkspaceController.AddMember)
-							members.PUT("/:user_id", cfg.WorkspaceController.UpdateMember)
-							members.DELETE("/:user_id", cfg.WorkspaceController.RemoveMember)
+							saved.GET("", cfg.SavedReportController.List)
+							saved.POST("", cfg.SavedReportController.Create)
+							saved.GET("/:report_id", cfg.SavedReportController.Get)
+							saved.PUT("/:report_id", cfg.SavedReportController.Update)
+							saved.DELETE("/:report_id", cfg.SavedReportController.Delete)
+							saved.POST("/:report_id/run", cfg.SavedReportController.Run)
+							saved.GET("/:report_id/export", cfg.SavedReportController.Export)
+							saved.POST("/:report_id/schedules", cfg.SavedReportController.CreateSchedule)
+							saved.PUT("/:report_id/schedules/:schedule_id", cfg.SavedReportController.UpdateSchedule)
+							saved.DELETE("/:report_id/schedules/:schedule_id", cfg.SavedReportController.DeleteSchedule)
 						}
 					}
+
+					// Leave requests, approval queue and team leave calendar
+					if cfg.LeaveController != nil {
+						leave := org.Group("/leave-requests")
+						{
+							leave.POST("", cfg.LeaveController.RequestLeave)
+							leave.GET("", cfg.LeaveController.ListQueue)
+							leave.GET("/mine", cfg.LeaveController.ListMyRequests)
+							leave.DELETE("/:request_id", cfg.LeaveController.CancelRequest)
+							leave.POST("/:request_id/approve", cfg.LeaveController.Approve)
+							leave.POST("/:request_id/reject", cfg.LeaveController.Reject)
+						}
+						org.GET("/leave-calendar", cfg.LeaveController.GetTeamCalendar)
+					}
+
+					// Screenshot deletion approval queue
+					if cfg.ScreenshotDeletionController != nil {
+						deletions := org.Group("/screenshot-deletion-requests")
+						{
+							deletions.GET("", cfg.ScreenshotDeletionController.ListQueue)
+							deletions.POST("/:request_id/approve", cfg.ScreenshotDeletionController.Approve)
+							deletions.POST("/:request_id/reject", cfg.ScreenshotDeletionController.Reject)
+						}
+					}
+
+					// Device approval queue (admin only)
+					if cfg.DeviceApprovalController != nil {
+						devices := org.Group("/device-approvals")
+						{
+							devices.GET("", cfg.DeviceApprovalController.ListQueue)
+							devices.POST("/:approval_id/approve", cfg.DeviceApprovalController.Approve)
+							devices.POST("/:approval_id/reject", cfg.DeviceApprovalController.Reject)
+						}
+					}
+
+					// Organization analytics (predefined read-only queries)
+					if cfg.AnalyticsController != nil {
+						org.GET("/analytics/queries", cfg.AnalyticsController.ListQueries)
+						org.POST("/analytics/query", cfg.AnalyticsController.RunQuery)
+					}
+
+					// Admin operations
+					org.POST("/regenerate-invite-code", cfg.OrganizationController.RegenerateInviteCode)
+					org.POST("/transfer-ownership", cfg.OrganizationController.TransferOwnership)
 				}
 			}
+		}
+
+		// Workspaces (standalone routes)
+		if cfg.WorkspaceController != nil {
+			workspaces := protected.Group("/workspaces")
+			{
+				workspaces.GET("", cfg.WorkspaceController.List)
 
-			// Admin routes (system admin only)
-			if cfg.AdminController != nil {
-				admin := protected.Group("/admin")
-				admin.Use(middleware.RequireSystemAdmin())
+				// Workspace-specific routes
+				ws := workspaces.Group("/:workspace_id")
+				ws.Use(middleware.SetUserIDMiddleware())
 				{
-					// User management
-					users := admin.Group("/users")
-					{
-						users.GET("", cfg.AdminController.ListUsers)
-						users.POST("", cfg.AdminController.CreateUser)
-						users.GET("/:id", cfg.AdminController.GetUser)
-						users.PUT("/:id", cfg.AdminController.UpdateUser)
-						users.DELETE("/:id", cfg.AdminController.DeleteUser)
-						users.PUT("/:id/activate", cfg.AdminController.ActivateUser)
-						users.PUT("/:id/role", cfg.AdminController.ChangeUserRole)
-						users.PUT("/:id/system-role", cfg.AdminController.ChangeUserSystemRole)
+					ws.GET("", cfg.WorkspaceController.GetByID)
+					ws.PUT("", cfg.WorkspaceController.Update)
+					ws.DELETE("", cfg.WorkspaceController.Delete)
+					ws.GET("/compliance", requireWorkspacePermission(models.PermReportsView), cfg.WorkspaceController.GetCompliance)
+					ws.GET("/budget", requireWorkspacePermission(models.PermReportsView), cfg.WorkspaceController.GetBudget)
+					ws.PUT("/budget", requireWorkspacePermission(models.PermSettingsManage), cfg.WorkspaceController.UpdateBudget)
+					if cfg.ReportController != nil {
+						ws.GET("/reports/summary", requireWorkspacePermission(models.PermReportsView), cfg.ReportController.GetWorkspaceSummary)
 					}
 
-					// Presence stream
-					if cfg.AdminPresenceController != nil {
-						admin.GET("/presence/stream", cfg.AdminPresenceController.Stream)
+					// Report links for clients and stakeholders
+					if cfg.ShareLinkController != nil {
+						shareLinks := ws.Group("/share-links")
+						shareLinks.Use(requireWorkspacePermission(models.PermReportsView))
+						{
+							shareLinks.GET("", cfg.ShareLinkController.ListShareLinks)
+							shareLinks.POST("", cfg.ShareLinkController.CreateShareLink)
+							shareLinks.DELETE("/:link_id", cfg.ShareLinkController.RevokeShareLink)
+						}
 					}
 
-					// Organization management
-					orgs := admin.Group("/organizations")
+					// Screenshot capture policy for the desktop app
+					ws.GET("/tracking-settings", cfg.WorkspaceController.GetTrackingSettings)
+					ws.PUT("/tracking-settings", requireWorkspacePermission(models.PermSettingsManage), cfg.WorkspaceController.UpdateTrackingSettings)
+
+					// Workspace members
+					members := ws.Group("/members")
 					{
-						orgs.GET("", cfg.AdminController.ListOrganizations)
-						orgs.GET("/:id", cfg.AdminController.GetOrganization)
-						orgs.PUT("/:id", cfg.AdminController.UpdateOrganization)
-						orgs.DELETE("/:id", cfg.AdminController.DeleteOrganization)
-						orgs.PUT("/:id/verify", cfg.AdminController.VerifyOrganization)
+						members.GET("", cfg.WorkspaceController.GetMembers)
+						members.POST("", requireWorkspacePermission(models.PermMembersManage), cfg.WorkspaceController.AddMember)
+						members.PUT("/:user_id", requireWorkspacePermission(models.PermMembersManage), cfg.WorkspaceController.UpdateMember)
+						members.DELETE("/:user_id", requireWorkspacePermission(models.PermMembersManage), cfg.WorkspaceController.RemoveMember)
 					}
 
-					// Workspace management
-					workspaces := admin.Group("/workspaces")
-					{
-						workspaces.GET("", cfg.AdminController.ListWorkspaces)
-						workspaces.GET("/:id", cfg.AdminController.GetWorkspace)
-						workspaces.PUT("/:id", cfg.AdminController.UpdateWorkspace)
-						workspaces.DELETE("/:id", cfg.AdminController.DeleteWorkspace)
-						workspaces.PUT("/:id/archive", cfg.AdminController.ArchiveWorkspace)
+					// Task assignment rules
+					if cfg.TaskAssignmentController != nil {
+						rules := ws.Group("/assignment-rules")
+						rules.Use(requireWorkspacePermission(models.PermSettingsManage))
+						{
+							rules.GET("", cfg.TaskAssignmentController.ListRules)
+							rules.POST("", cfg.TaskAssignmentController.CreateRule)
+							rules.PUT("/:rule_id", cfg.TaskAssignmentController.UpdateRule)
+							rules.DELETE("/:rule_id", cfg.TaskAssignmentController.DeleteRule)
+						}
 					}
 
-					// Task management
-					tasks := admin.Group("/tasks")
-					{
-						tasks.GET("", cfg.AdminController.ListTasks)
-						tasks.GET("/:id", cfg.AdminController.GetTask)
-						tasks.PUT("/:id", cfg.AdminController.UpdateTask)
-						tasks.DELETE("/:id", cfg.AdminController.DeleteTask)
+					// Kanban board
+					if cfg.TaskBoardController != nil {
+						ws.GET("/task-statuses", cfg.TaskBoardController.ListStatuses)
+						ws.PUT("/task-statuses", requireWorkspacePermission(models.PermSettingsManage), cfg.TaskBoardController.UpdateStatuses)
+						ws.GET("/tasks/board", cfg.TaskBoardController.GetBoard)
+						ws.PUT("/tasks/reorder", cfg.TaskBoardController.ReorderTasks)
 					}
 
-					// Time log management
-					timelogs := admin.Group("/timelogs")
-					{
-						timelogs.GET("", cfg.AdminController.ListTimeLogs)
-						timelogs.GET("/:id", cfg.AdminController.GetTimeLog)
-						timelogs.PUT("/:id", cfg.AdminController.UpdateTimeLog)
-						timelogs.DELETE("/:id", cfg.AdminController.DeleteTimeLog)
-						timelogs.POST("/approve", cfg.AdminController.ApproveTimeLogs)
+					// Jira integration
+					if cfg.JiraController != nil {
+						jira := ws.Group("/integrations/jira")
+						jira.Use(requireWorkspacePermission(models.PermSettingsManage))
+						{
+							jira.GET("", cfg.JiraController.GetIntegration)
+							jira.PUT("", cfg.JiraController.SaveIntegration)
+							jira.DELETE("", cfg.JiraController.DeleteIntegration)
+							jira.POST("/sync", cfg.JiraController.SyncIntegration)
+						}
+					}
+
+					// Repositories for commit linking
+					if cfg.CommitLinkController != nil {
+						repos := ws.Group("/repositories")
+						repos.Use(requireWorkspacePermission(models.PermSettingsManage))
+						{
+							repos.GET("", cfg.CommitLinkController.ListRepositories)
+							repos.POST("", cfg.CommitLinkController.AddRepository)
+							repos.DELETE("/:repo_id", cfg.CommitLinkController.DeleteRepository)
+						}
 					}
+				}
+			}
+		}
+
+		// Admin routes (system admin only)
+		if cfg.AdminController != nil {
+			admin := protected.Group("/admin")
+			admin.Use(middleware.RequireSystemAdmin())
+			{
+				// User management
+				users := admin.Group("/users")
+				{
+					users.GET("", cfg.AdminController.ListUsers)
+					users.POST("", cfg.AdminController.CreateUser)
+					users.GET("/:id", cfg.AdminController.GetUser)
+					users.PUT("/:id", cfg.AdminController.UpdateUser)
+					users.DELETE("/:id", cfg.AdminController.DeleteUser)
+					users.PUT("/:id/activate", cfg.AdminController.ActivateUser)
+					users.PUT("/:id/role", cfg.AdminController.ChangeUserRole)
+					users.PUT("/:id/system-role", cfg.AdminController.ChangeUserSystemRole)
+					users.POST("/:id/impersonate", cfg.AdminController.ImpersonateUser)
+					users.POST("/:id/merge", cfg.AdminController.MergeUser)
+					if cfg.AdminScheduleController != nil {
+						users.GET("/:id/schedule", cfg.AdminScheduleController.GetSchedule)
+						users.PUT("/:id/schedule", cfg.AdminScheduleController.UpdateSchedule)
+						users.DELETE("/:id/schedule", cfg.AdminScheduleController.DeleteSchedule)
+					}
+				}
+
+				// Work schedules
+				if cfg.AdminScheduleController != nil {
+					admin.GET("/schedules", cfg.AdminScheduleController.ListSchedules)
+				}
+
+				// Overtime report
+				if cfg.AdminOvertimeController != nil {
+					admin.GET("/reports/overtime", cfg.AdminOvertimeController.GetReport)
+				}
+
+				// Presence dashboard and stream
+				if cfg.AdminPresenceController != nil {
+					admin.GET("/presence", cfg.AdminPresenceController.ListPresence)
+					admin.GET("/presence/history", cfg.AdminPresenceController.GetPresenceHistory)
+					admin.GET("/presence/stream", cfg.AdminPresenceController.Stream)
+				}
+
+				// Activity feed stream
+				if cfg.AdminActivityFeedController != nil {
+					admin.GET("/activfeed/stream", cfg.AdminActivityFeedController.Stream)
+				}
+
+				// Background jobs
+				if cfg.AdminJobsController != nil {
+					admin.GET("/jobs", cfg.AdminJobsController.ListJobs)
+					admin.POST("/jobs/:name/run", cfg.AdminJobsController.RunJob)
+				}
 
-					// Screenshot management
-					screenshots := admin.Group("/screenshots")
+				// Soft-deleted data purge
+				if cfg.AdminMaintenanceController != nil {
+					admin.POST("/maintenance/purge", cfg.AdminMaintenanceController.Purge)
+				}
+
+				// Feature flags
+				if cfg.AdminFeatureFlagController != nil {
+					flags := admin.Group("/feature-flags")
 					{
-						screenshots.GET("", cfg.AdminController.ListScreenshots)
-						screenshots.GET("/:id", cfg.AdminController.GetScreenshot)
-						screenshots.GET("/:id/view", cfg.AdminController.ViewScreenshot)
-						screenshots.DELETE("/:id", cfg.AdminController.DeleteScreenshot)
-						screenshots.POST("/bulk-delete", cfg.AdminController.BulkDeleteScreenshots)
+						flags.GET("", cfg.AdminFeatureFlagController.ListFlags)
+						flags.POST("", cfg.AdminFeatureFlagController.CreateFlag)
+						flags.PUT("/:id", cfg.AdminFeatureFlagController.UpdateFlag)
+						flags.DELETE("/:id", cfg.AdminFeatureFlagController.DeleteFlag)
+						flags.PUT("/:id/overrides", cfg.AdminFeatureFlagController.SetOverride)
+						flags.DELETE("/:id/overrides/:override_id", cfg.AdminFeatureFlagController.DeleteOverride)
 					}
+				}
+
+				// Published desktop app releases
+				if cfg.AdminReleaseController != nil {
+					admin.GET("/updates/releases", cfg.AdminReleaseController.ListReleases)
+					admin.POST("/updates/releases", cfg.AdminReleaseController.PublishRelease)
+					admin.DELETE("/updates/releases/:id", cfg.AdminReleaseController.WithdrawRelease)
+					admin.GET("/updates/downloads", cfg.AdminReleaseController.ListDownloadStats)
+				}
 
-					// Statistics & Reports
-					stats := admin.Group("/stats")
+				// Desktop crash telemetry by release and feature usage
+				if cfg.AdminTelemetryController != nil {
+					admin.GET("/updates/crashes", cfg.AdminTelemetryController.ListReleaseCrashes)
+					admin.GET("/updates/crashes/:version", cfg.AdminTelemetryController.GetReleaseCrashes)
+					admin.GET("/usage/features", cfg.AdminTelemetryController.ListFeatureUsage)
+					admin.GET("/usage/features/:feature", cfg.AdminTelemetryController.GetFeatureUsage)
+				}
+
+				// Desktop app log bundles
+				if cfg.AdminDeviceLogController != nil {
+					deviceLogs := admin.Group("/device-logs")
 					{
-						stats.GET("", cfg.AdminController.GetSystemStats)
-						stats.GET("/overview", cfg.AdminController.GetOverviewStats)
-						stats.GET("/trends", cfg.AdminController.GetTrendStats)
-						stats.GET("/user-performance", cfg.AdminController.GetUserPerformanceStats)
-						stats.GET("/org-distribution", cfg.AdminController.GetOrgDistributionStats)
-						stats.GET("/activity", cfg.AdminController.GetActivityStats)
+						deviceLogs.GET("", cfg.AdminDeviceLogController.ListDeviceLogs)
+						deviceLogs.GET("/:id", cfg.AdminDeviceLogController.GetDeviceLog)
+						deviceLogs.GET("/:id/download", cfg.AdminDeviceLogController.DownloadDeviceLog)
+						deviceLogs.DELETE("/:id", cfg.AdminDeviceLogController.DeleteDeviceLog)
+					}
+				}
+
+				// Organization management
+				orgs := admin.Group("/organizations")
+				{
+					orgs.GET("", cfg.AdminController.ListOrganizations)
+					orgs.GET("/:id", cfg.AdminController.GetOrganization)
+					orgs.PUT("/:id", cfg.AdminController.UpdateOrganization)
+					orgs.DELETE("/:id", cfg.AdminController.DeleteOrganization)
+					orgs.PUT("/:id/verify", cfg.AdminController.VerifyOrganization)
+					if cfg.AdminRetentionController != nil {
+						orgs.GET("/:id/retention/preview", cfg.AdminRetentionController.PreviewScreenshotRetention)
+					}
+					if cfg.AdminStorageQuotaController != nil {
+						orgs.GET("/:id/storage-quota", cfg.AdminStorageQuotaController.GetQuota)
+						orgs.PUT("/:id/storage-quota", cfg.AdminStorageQuotaController.UpdateQuota)
+						orgs.POST("/:id/storage-quota/recount", cfg.AdminStorageQuotaController.Recount)
 					}
 				}
+
+				// Workspace management
+				workspaces := admin.Group("/workspaces")
+				{
+					workspaces.GET("", cfg.AdminController.ListWorkspaces)
+					workspaces.GET("/:id", cfg.AdminController.GetWorkspace)
+					workspaces.PUT("/:id", cfg.AdminController.UpdateWorkspace)
+					workspaces.DELETE("/:id", cfg.AdminController.DeleteWorkspace)
+					workspaces.PUT("/:id/archive", cfg.AdminController.ArchiveWorkspace)
+				}
+
+				// Task management
+				tasks := admin.Group("/tasks")
+				{
+					tasks.GET("", cfg.AdminController.ListTasks)
+					tasks.GET("/:id", cfg.AdminController.GetTask)
+					tasks.PUT("/:id", cfg.AdminController.UpdateTask)
+					tasks.DELETE("/:id", cfg.AdminController.DeleteTask)
+				}
+
+				// Time log management
+				timelogs := admin.Group("/timelogs")
+				{
+					timelogs.GET("", cfg.AdminController.ListTimeLogs)
+					timelogs.GET("/export", cfg.AdminController.ExportTimeLogs)
+					timelogs.GET("/:id", cfg.AdminController.GetTimeLog)
+					timelogs.PUT("/:id", cfg.AdminController.UpdateTimeLog)
+					timelogs.DELETE("/:id", cfg.AdminController.DeleteTimeLog)
+					timelogs.POST("/approve", cfg.AdminController.ApproveTimeLogs)
+				}
+
+				// Screenshot management
+				screenshots := admin.Group("/screenshots")
+				{
+					screenshots.GET("", cfg.AdminController.ListScreenshots)
+					if cfg.AdminScreenshotOCRController != nil {
+						screenshots.GET("/search", cfg.AdminScreenshotOCRController.SearchScreenshots)
+					}
+					screenshots.GET("/:id", cfg.AdminController.GetScreenshot)
+					screenshots.GET("/:id/view", cfg.AdminController.ViewScreenshot)
+					screenshots.DELETE("/:id", cfg.AdminController.DeleteScreenshot)
+					screenshots.POST("/bulk-delete", cfg.AdminController.BulkDeleteScreenshots)
+					if cfg.AdminScreenshotScanController != nil {
+						screenshots.POST("/:id/quarantine/release", cfg.AdminScreenshotScanController.ReleaseScreenshot)
+						screenshots.DELETE("/:id/quarantine", cfg.AdminScreenshotScanController.DeleteQuarantinedScreenshot)
+					}
+				}
+
+				// Invite code lookup metrics
+				if cfg.InvitePreviewController != nil {
+					admin.GET("/security/invite-lookups", cfg.InvitePreviewController.GetLookupStats)
+				}
+
+				// Audit logs
+				if cfg.AuditLogController != nil {
+					admin.GET("/audit-logs", cfg.AuditLogController.ListAuditLogs)
+				}
+
+				// Statistics & Reports
+				stats := admin.Group("/stats")
+				{
+					stats.GET("", cfg.AdminController.GetSystemStats)
+					stats.GET("/overview", cfg.AdminController.GetOverviewStats)
+					stats.GET("/trends", cfg.AdminController.GetTrendStats)
+					stats.GET("/user-performance", cfg.AdminController.GetUserPerformanceStats)
+					stats.GET("/org-distribution", cfg.AdminController.GetOrgDistributionStats)
+					stats.GET("/activity", cfg.AdminController.GetActivityStats)
+				}
 			}
 		}
 	}
-
-	return router
 }
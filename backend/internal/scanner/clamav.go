@@ -0,0 +1,79 @@
+package scanner
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// clamAVChunkSize is the size of the INSTREAM chunks sent to clamd, below its
+// default StreamMaxLength
+const clamAVChunkSize = 64 * 1024
+
+// ClamAV scans files with a clamd daemon over its INSTREAM command
+type ClamAV struct {
+	address string
+	timeout time.Duration
+}
+
+// NewClamAV creates a ClamAV scanner
+func NewClamAV(cfg Config) *ClamAV {
+	return &ClamAV{address: cfg.ClamAVAddress, timeout: cfg.Timeout}
+}
+
+// Scan streams data to clamd and parses its verdict, e.g.
+// "stream: OK" or "stream: Win.Test.EICAR_HDB-1 FOUND"
+func (c *ClamAV) Scan(ctx context.Context, data []byte) (*Result, error) {
+	dialer := net.Dialer{Timeout: c.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", c.address)
+	if err != nil {
+		return nil, fmt.Errorf("clamav: %w", err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	} else if c.timeout > 0 {
+		conn.SetDeadline(time.Now().Add(c.timeout))
+	}
+
+	// Null-terminated command, then length-prefixed chunks ending with an
+	// empty one
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return nil, fmt.Errorf("clamav: %w", err)
+	}
+	size := make([]byte, 4)
+	for start := 0; start < len(data); start += clamAVChunkSize {
+		chunk := data[start:min(start+clamAVChunkSize, len(data))]
+		binary.BigEndian.PutUint32(size, uint32(len(chunk)))
+		if _, err := conn.Write(size); err != nil {
+			return nil, fmt.Errorf("clamav: %w", err)
+		}
+		if _, err := conn.Write(chunk); err != nil {
+			return nil, fmt.Errorf("clamav: %w", err)
+		}
+	}
+	binary.BigEndian.PutUint32(size, 0)
+	if _, err := conn.Write(size); err != nil {
+		return nil, fmt.Errorf("clamav: %w", err)
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && reply == "" {
+		return nil, fmt.Errorf("clamav: %w", err)
+	}
+	reply = strings.TrimSpace(strings.TrimRight(reply, "\x00"))
+	verdict := strings.TrimSpace(strings.TrimPrefix(reply, "stream:"))
+
+	switch {
+	case verdict == "OK":
+		return &Result{}, nil
+	case strings.HasSuffix(verdict, " FOUND"):
+		return &Result{Infected: true, Signature: strings.TrimSuffix(verdict, " FOUND")}, nil
+	default:
+		return nil, fmt.Errorf("clamav: %s", reply)
+	}
+}
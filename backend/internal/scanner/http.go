@@ -0,0 +1,38 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/beuphecan/remote-time-tracker/internal/httpapi"
+)
+
+// HTTP scans files with an external API. The file is POSTed as the request
+// body and the API answers {"infected": bool, "signature": "..."}.
+type HTTP struct {
+	url        string
+	apiKey     string
+	httpClient *http.Client
+}
+
+// NewHTTP creates an HTTP API scanner
+func NewHTTP(cfg Config) *HTTP {
+	return &HTTP{
+		url:        cfg.APIURL,
+		apiKey:     cfg.APIKey,
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+// Scan sends data to the API and returns its verdict
+func (h *HTTP) Scan(ctx context.Context, data []byte) (*Result, error) {
+	var verdict struct {
+		Infected  bool   `json:"infected"`
+		Signature string `json:"signature"`
+	}
+	if err := httpapi.PostBytes(ctx, h.httpClient, h.url, h.apiKey, data, &verdict); err != nil {
+		return nil, fmt.Errorf("scanner: %w", err)
+	}
+	return &Result{Infected: verdict.Infected, Signature: verdict.Signature}, nil
+}
@@ -0,0 +1,58 @@
+// Package scanner checks uploaded files for malware through a ClamAV daemon
+// or an external scanning API.
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Scanning providers
+const (
+	ProviderNone   = "none" // Scanning disabled
+	ProviderClamAV = "clamav"
+	ProviderHTTP   = "http"
+)
+
+// Result is the verdict on a scanned file
+type Result struct {
+	Infected  bool
+	Signature string // Name of the detected threat, when infected
+}
+
+// Scanner scans file contents
+type Scanner interface {
+	Scan(ctx context.Context, data []byte) (*Result, error)
+}
+
+// Config selects and configures the provider
+type Config struct {
+	Provider string // none, clamav or http
+	Timeout  time.Duration
+
+	ClamAVAddress string // clamd TCP address, host:port
+
+	APIURL string // Receives the file as the POST body
+	APIKey string // Sent as a bearer token
+}
+
+// New creates the scanner for cfg.Provider; nil when scanning is disabled
+func New(cfg Config) (Scanner, error) {
+	switch cfg.Provider {
+	case "", ProviderNone:
+		return nil, nil
+	case ProviderClamAV:
+		if cfg.ClamAVAddress == "" {
+			return nil, fmt.Errorf("clamav provider needs a clamd address")
+		}
+		return NewClamAV(cfg), nil
+	case ProviderHTTP:
+		if cfg.APIURL == "" {
+			return nil, fmt.Errorf("http provider needs an API URL")
+		}
+		return NewHTTP(cfg), nil
+	default:
+		return nil, fmt.Errorf("unknown scanning provider %q", cfg.Provider)
+	}
+}
@@ -0,0 +1,48 @@
+package scheduler
+
+import (
+	"context"
+	"hash/fnv"
+
+	"gorm.io/gorm"
+)
+
+// PostgresLocker coordinates jobs across instances with Postgres session
+// advisory locks, so each run happens on exactly one server
+type PostgresLocker struct {
+	db *gorm.DB
+}
+
+// NewPostgresLocker creates a locker backed by the given database
+func NewPostgresLocker(db *gorm.DB) *PostgresLocker {
+	return &PostgresLocker{db: db}
+}
+
+// WithLock runs fn while holding the advisory lock for name. Advisory locks
+// are bound to a session, so the lock, fn and unlock share one connection.
+func (l *PostgresLocker) WithLock(ctx context.Context, name string, fn func() error) (bool, error) {
+	key := lockKey(name)
+	acquired := false
+
+	err := l.db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		if err := conn.Raw("SELECT pg_try_advisory_lock(?)", key).Scan(&acquired).Error; err != nil {
+			return err
+		}
+		if !acquired {
+			return nil
+		}
+		// Unlock on a fresh context so a cancelled job still releases the lock
+		defer conn.WithContext(context.Background()).Exec("SELECT pg_advisory_unlock(?)", key)
+
+		return fn()
+	})
+
+	return acquired, err
+}
+
+// lockKey maps a job name to a stable 64-bit advisory lock key
+func lockKey(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte("rtt-job:" + name))
+	return int64(h.Sum64())
+}
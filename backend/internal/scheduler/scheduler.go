@@ -0,0 +1,232 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+// JobFunc is the work performed by a scheduled job
+type JobFunc func(ctx context.Context) error
+
+// Locker guarantees a job runs on at most one instance at a time.
+// WithLock runs fn only if the lock for name was acquired and reports whether it was.
+type Locker interface {
+	WithLock(ctx context.Context, name string, fn func() error) (bool, error)
+}
+
+// JobStatus describes a registered job and its most recent run
+type JobStatus struct {
+	Name         string     `json:"name"`
+	Schedule     string     `json:"schedule"`
+	Running      bool       `json:"running"`
+	NextRunAt    *time.Time `json:"next_run_at"`
+	LastRunAt    *time.Time `json:"last_run_at"`
+	LastDuration int64      `json:"last_duration_ms"`
+	LastError    string     `json:"last_error,omitempty"`
+	LastSkipped  bool       `json:"last_skipped"` // Lock was held by another instance
+	RunCount     int64      `json:"run_count"`
+	FailureCount int64      `json:"failure_count"`
+}
+
+type job struct {
+	name     string
+	spec     string
+	schedule Schedule
+	fn       JobFunc
+	timeout  time.Duration
+
+	mu     sync.Mutex
+	status JobStatus
+}
+
+// Scheduler runs registered jobs on cron schedules
+type Scheduler struct {
+	locker Locker
+
+	mu      sync.RWMutex
+	jobs    map[string]*job
+	started bool
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// ErrJobNotFound is returned for unknown job names
+var ErrJobNotFound = errors.New("job not found")
+
+// ErrJobRunning is returned when triggering a job that is already running
+var ErrJobRunning = errors.New("job is already running")
+
+// New creates a scheduler. locker may be nil for single-instance deployments.
+func New(locker Locker) *Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Scheduler{
+		locker: locker,
+		jobs:   make(map[string]*job),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Register adds a job. spec is a cron expression (see Parse); timeout bounds a
+// single run (0 = no timeout). Jobs must be registered before Start.
+func (s *Scheduler) Register(name, spec string, timeout time.Duration, fn JobFunc) error {
+	schedule, err := Parse(spec)
+	if err != nil {
+		return fmt.Errorf("job %s: %w", name, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return fmt.Errorf("job %s: scheduler already started", name)
+	}
+	if _, exists := s.jobs[name]; exists {
+		return fmt.Errorf("job %s: already registered", name)
+	}
+
+	s.jobs[name] = &job{
+		name:     name,
+		spec:     spec,
+		schedule: schedule,
+		fn:       fn,
+		timeout:  timeout,
+		status:   JobStatus{Name: name, Schedule: spec},
+	}
+	return nil
+}
+
+// Start launches one goroutine per registered job
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return
+	}
+	s.started = true
+
+	for _, j := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(j)
+	}
+	log.Printf("⏰ Scheduler started with %d jobs", len(s.jobs))
+}
+
+// Stop cancels running jobs and waits for their goroutines to exit
+func (s *Scheduler) Stop() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+// Status returns the state of every job, sorted by name
+func (s *Scheduler) Status() []JobStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	statuses := make([]JobStatus, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		j.mu.Lock()
+		statuses = append(statuses, j.status)
+		j.mu.Unlock()
+	}
+	sort.Slice(statuses, func(a, b int) bool { return statuses[a].Name < statuses[b].Name })
+	return statuses
+}
+
+// RunNow triggers a job immediately in the background
+func (s *Scheduler) RunNow(name string) error {
+	s.mu.RLock()
+	j, ok := s.jobs[name]
+	s.mu.RUnlock()
+	if !ok {
+		return ErrJobNotFound
+	}
+
+	j.mu.Lock()
+	running := j.status.Running
+	j.mu.Unlock()
+	if running {
+		return ErrJobRunning
+	}
+
+	go s.run(j)
+	return nil
+}
+
+func (s *Scheduler) loop(j *job) {
+	defer s.wg.Done()
+
+	for {
+		next := j.schedule.Next(time.Now())
+		if next.IsZero() {
+			log.Printf("⚠️  Job %s has no future activation; stopping", j.name)
+			return
+		}
+
+		j.mu.Lock()
+		j.status.NextRunAt = &next
+		j.mu.Unlock()
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-s.ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			s.run(j)
+		}
+	}
+}
+
+func (s *Scheduler) run(j *job) {
+	j.mu.Lock()
+	if j.status.Running {
+		j.mu.Unlock()
+		return
+	}
+	j.status.Running = true
+	j.mu.Unlock()
+
+	ctx := s.ctx
+	if j.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, j.timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	acquired := true
+	var err error
+
+	if s.locker != nil {
+		acquired, err = s.locker.WithLock(ctx, j.name, func() error { return j.fn(ctx) })
+	} else {
+		err = j.fn(ctx)
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.status.Running = false
+	j.status.LastSkipped = !acquired
+	if !acquired && err == nil {
+		return
+	}
+
+	j.status.LastRunAt = &start
+	j.status.LastDuration = time.Since(start).Milliseconds()
+	j.status.RunCount++
+	j.status.LastError = ""
+	if err != nil {
+		j.status.FailureCount++
+		j.status.LastError = err.Error()
+		log.Printf("⚠️  Job %s failed: %v", j.name, err)
+	}
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// Activity feed event actions
+const (
+	ActivityUserLogin      = "user.login"
+	ActivityUserSignup     = "user.signup"
+	ActivityTimeLogCreated = "time_log.created"
+	ActivityOrgCreated     = "organization.created"
+
+	ActivityHourCapApproaching = "member.hour_cap_approaching"
+	ActivityHourCapExceeded    = "member.hour_cap_exceeded"
+	ActivityBudgetThreshold    = "workspace.budget_threshold"
+)
+
+// ActivityEvent represents an audit-log-style activity feed payload
+type ActivityEvent struct {
+	Action     string                 `json:"action"`
+	UserID     *uint                  `json:"user_id"`
+	EntityType string                 `json:"entity_type"`
+	EntityID   *uint                  `json:"entity_id"`
+	Details    map[string]interface{} `json:"details,omitempty"`
+	OccurredAt time.Time              `json:"occurred_at"`
+}
+
+// ActivityFeedHub manages activity feed subscribers
+type ActivityFeedHub struct {
+	mu          sync.RWMutex
+	subscribers map[chan []byte]struct{}
+	closed      bool
+}
+
+// NewActivityFeedHub creates a new ActivityFeedHub
+func NewActivityFeedHub() *ActivityFeedHub {
+	return &ActivityFeedHub{
+		subscribers: make(map[chan []byte]struct{}),
+	}
+}
+
+// Subscribe registers a new subscriber channel
+func (h *ActivityFeedHub) Subscribe() chan []byte {
+	ch := make(chan []byte, 50)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		close(ch)
+		return ch
+	}
+	h.subscribers[ch] = struct{}{}
+	return ch
+}
+
+// Unsubscribe removes a subscriber channel
+func (h *ActivityFeedHub) Unsubscribe(ch chan []byte) {
+	h.mu.Lock()
+	if _, ok := h.subscribers[ch]; ok {
+		delete(h.subscribers, ch)
+		close(ch)
+	}
+	h.mu.Unlock()
+}
+
+// Close closes every subscriber channel so open streams end, e.g. on server
+// shutdown. Later subscribers receive an already closed channel.
+func (h *ActivityFeedHub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for ch := range h.subscribers {
+		delete(h.subscribers, ch)
+		close(ch)
+	}
+}
+
+// Broadcast sends an activity event to all subscribers
+func (h *ActivityFeedHub) Broadcast(event ActivityEvent) {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now().UTC()
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for ch := range h.subscribers {
+		select {
+		case ch <- payload:
+		default:
+		}
+	}
+}
+
+// ActivityFeedBroadcaster is a global hub instance
+var ActivityFeedBroadcaster = NewActivityFeedHub()
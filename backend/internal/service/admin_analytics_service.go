@@ -0,0 +1,414 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/cache"
+	"github.com/beuphecan/remote-time-tracker/internal/calendar"
+	"github.com/beuphecan/remote-time-tracker/internal/config"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/format"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+)
+
+// analyticsBustInterval throttles cache busting from sync, which runs far
+// more often than the dashboard is viewed
+const analyticsBustInterval = 15 * time.Second
+
+// Default dashboard queries, precomputed by Warm
+const (
+	defaultTrendDays        = 30
+	defaultPerformanceLimit = 10
+)
+
+// Cache keys of the admin dashboard metrics. The overview shares its key with
+// repository.StatsCache so membership changes drop it too.
+const (
+	analyticsOverviewKey        = repository.StatsOverviewKey
+	analyticsOrgDistributionKey = "stats:org_distribution"
+	analyticsActivityKey        = "stats:activity"
+)
+
+// Trends are grouped into periods after caching, so the key has no period
+func analyticsTrendKey(start, end time.Time, loc *time.Location) string {
+	return fmt.Sprintf("stats:trends:%s:%s:%s", start.Format("2006-01-02"), end.Format("2006-01-02"), loc)
+}
+
+func analyticsPerformanceKey(query repository.LeaderboardQuery) string {
+	return fmt.Sprintf("stats:user_performance:%d:%s:%s:%d:%d", query.Limit,
+		query.Start.Format("2006-01-02"), query.End.Format("2006-01-02"), query.OrganizationID, query.WorkspaceID)
+}
+
+// Leaderboard periods of GetUserPerformanceStats
+const (
+	LeaderboardPeriodAll    = "all"
+	LeaderboardPeriodWeek   = "week"
+	LeaderboardPeriodMonth  = "month"
+	LeaderboardPeriodCustom = "custom"
+)
+
+// ErrInvalidLeaderboardPeriod is returned for an unknown leaderboard period
+var ErrInvalidLeaderboardPeriod = apperror.Validation("invalid period: use all, week, month or custom", nil)
+
+// AdminAnalyticsService serves the platform-wide admin dashboard statistics.
+// Each metric is cached with its own TTL, and syncs drop the metrics that
+// depend on tracked time.
+type AdminAnalyticsService interface {
+	GetOverviewStats(locale format.Locale) (*dto.AdminOverviewStats, error)
+	GetTrendStats(req *dto.AdminTrendRequest, locale format.Locale) (*dto.AdminTrendStats, error)
+	GetUserPerformanceStats(params *dto.AdminUserPerformanceParams, locale format.Locale) ([]dto.AdminUserPerformance, error)
+	GetOrgDistributionStats(locale format.Locale) (*dto.AdminOrgStats, error)
+	GetActivityStats(locale format.Locale) (*dto.AdminActivityStats, error)
+
+	// Warm computes the dashboard's default metrics so the first view is fast
+	Warm(ctx context.Context)
+	// InvalidateActivity drops metrics derived from time logs and screenshots.
+	// Calls within analyticsBustInterval of the last bust are ignored.
+	InvalidateActivity()
+}
+
+type adminAnalyticsService struct {
+	statsRepo repository.AdminStatsRepository
+	orgRepo   *repository.OrganizationRepository
+	schedules ScheduleService
+	cache     cache.Cache
+	ttl       config.CacheConfig
+
+	lastBust atomic.Int64 // Unix nanoseconds
+
+	// Parameterized keys this instance has cached, so busting can drop them
+	mu          sync.Mutex
+	dynamicKeys map[string]struct{}
+}
+
+// NewAdminAnalyticsService creates a new admin analytics service. c is the
+// shared Redis cache, or an in-memory cache when Redis is not configured.
+func NewAdminAnalyticsService(statsRepo repository.AdminStatsRepository, orgRepo *repository.OrganizationRepository, schedules ScheduleService, c cache.Cache) AdminAnalyticsService {
+	return &adminAnalyticsService{
+		statsRepo:   statsRepo,
+		orgRepo:     orgRepo,
+		schedules:   schedules,
+		cache:       c,
+		ttl:         config.AppConfig.Cache,
+		dynamicKeys: make(map[string]struct{}),
+	}
+}
+
+// cached returns the cached value for key, or computes and caches it for ttl.
+// Cache errors fall back to the database rather than failing the request.
+func cached[T any](s *adminAnalyticsService, key string, ttl time.Duration, compute func() (*T, error)) (*T, error) {
+	ctx := context.Background()
+	var value T
+	err := s.cache.Get(ctx, key, &value)
+	if err == nil {
+		return &value, nil
+	}
+	if !errors.Is(err, cache.ErrMiss) {
+		log.Printf("⚠️  Analytics cache read failed for %s: %v", key, err)
+	}
+
+	computed, err := compute()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.cache.Set(ctx, key, computed, ttl); err != nil {
+		log.Printf("⚠️  Analytics cache write failed for %s: %v", key, err)
+	}
+	return computed, nil
+}
+
+func (s *adminAnalyticsService) trackKey(key string) {
+	s.mu.Lock()
+	s.dynamicKeys[key] = struct{}{}
+	s.mu.Unlock()
+}
+
+func (s *adminAnalyticsService) overview() (*dto.AdminOverviewStats, error) {
+	return cached(s, analyticsOverviewKey, s.ttl.OverviewStatsTTL, s.statsRepo.GetOverviewStats)
+}
+
+// trends caches whole days of loc: start is moved to midnight and end to the
+// end of its day, so requests during a day share an entry
+func (s *adminAnalyticsService) trends(period string, start, end time.Time, loc *time.Location) (*dto.AdminTrendStats, error) {
+	start = dateIn(start, loc)
+	end = dateIn(end, loc).AddDate(0, 0, 1).Add(-time.Nanosecond)
+
+	key := analyticsTrendKey(start, end, loc)
+	s.trackKey(key)
+	return cached(s, key, s.ttl.TrendStatsTTL, func() (*dto.AdminTrendStats, error) {
+		return s.statsRepo.GetTrendStats(period, start, end, loc.String())
+	})
+}
+
+func (s *adminAnalyticsService) userPerformance(query repository.LeaderboardQuery) (*[]dto.AdminUserPerformance, error) {
+	key := analyticsPerformanceKey(query)
+	s.trackKey(key)
+	return cached(s, key, s.ttl.UserPerformanceStatsTTL, func() (*[]dto.AdminUserPerformance, error) {
+		performers, err := s.statsRepo.GetUserPerformanceStats(query)
+		return &performers, err
+	})
+}
+
+func (s *adminAnalyticsService) orgDistribution() (*dto.AdminOrgStats, error) {
+	return cached(s, analyticsOrgDistributionKey, s.ttl.OrgDistributionStatsTTL, s.statsRepo.GetOrgDistributionStats)
+}
+
+func (s *adminAnalyticsService) activity() (*dto.AdminActivityStats, error) {
+	return cached(s, analyticsActivityKey, s.ttl.ActivityStatsTTL, s.statsRepo.GetActivityStats)
+}
+
+func (s *adminAnalyticsService) GetOverviewStats(locale format.Locale) (*dto.AdminOverviewStats, error) {
+	stats, err := s.overview()
+	if err != nil {
+		return nil, err
+	}
+
+	stats.TotalDurationHuman = format.Duration(stats.TotalDuration, locale)
+	stats.WeekDurationHuman = format.Duration(stats.WeekDuration, locale)
+	stats.TotalStorageHuman = format.Bytes(stats.TotalStorage, locale)
+	stats.DedupeSavedHuman = format.Bytes(stats.DedupeSavedBytes, locale)
+
+	return stats, nil
+}
+
+func (s *adminAnalyticsService) GetTrendStats(req *dto.AdminTrendRequest, locale format.Locale) (*dto.AdminTrendStats, error) {
+	if err := calendar.ValidatePeriod(req.Period); err != nil {
+		return nil, apperror.Validation(err.Error(), nil)
+	}
+
+	cal := calendar.Default()
+	loc := reportLocation(req.Location)
+	if req.OrgID != nil {
+		org, err := s.orgRepo.GetByID(*req.OrgID)
+		if err != nil {
+			return nil, apperror.NotFound("organization not found")
+		}
+		cal = org.Calendar()
+		loc = reportLocation(req.Location, org.Timezone)
+	}
+
+	stats, err := s.trends(req.Period, req.StartDate, req.EndDate, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	stats.UserGrowth = groupDailyStats(stats.UserGrowth, req.Period, cal)
+	stats.ActivityTrend = groupDailyStats(stats.ActivityTrend, req.Period, cal)
+
+	for i := range stats.ActivityTrend {
+		stats.ActivityTrend[i].DurationHuman = format.Duration(stats.ActivityTrend[i].Duration, locale)
+	}
+
+	return stats, nil
+}
+
+func (s *adminAnalyticsService) GetUserPerformanceStats(params *dto.AdminUserPerformanceParams, locale format.Locale) ([]dto.AdminUserPerformance, error) {
+	query, err := s.leaderboardQuery(params)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.userPerformance(query)
+	if err != nil {
+		return nil, err
+	}
+	performers := *result
+
+	// Schedule comparison is per request window, so it is not cached
+	userIDs := make([]uint, 0, len(performers))
+	for i := range performers {
+		userIDs = append(userIDs, performers[i].UserID)
+	}
+	comparisons, err := s.schedules.Compare(userIDs, params.StartDate, params.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range performers {
+		performers[i].TotalDurationHuman = format.Duration(performers[i].TotalDuration, locale)
+
+		comparison := comparisons[performers[i].UserID]
+		performers[i].ScheduleStatus = comparison.Status
+		performers[i].TrackedHours = secondsToHours(comparison.TrackedSeconds)
+		performers[i].LeaveDays = comparison.LeaveDays
+		performers[i].HolidayDays = comparison.HolidayDays
+		if comparison.ExpectedSeconds != nil {
+			expected := secondsToHours(*comparison.ExpectedSeconds)
+			variance := secondsToHours(comparison.TrackedSeconds - *comparison.ExpectedSeconds)
+			performers[i].ExpectedHours = &expected
+			performers[i].VarianceHours = &variance
+		}
+	}
+
+	return performers, nil
+}
+
+// leaderboardQuery resolves the ranked period to whole UTC days: this week
+// or month of the organization calendar, the custom dates, or all time
+func (s *adminAnalyticsService) leaderboardQuery(params *dto.AdminUserPerformanceParams) (repository.LeaderboardQuery, error) {
+	query := repository.LeaderboardQuery{Limit: params.Limit}
+	if query.Limit <= 0 {
+		query.Limit = defaultPerformanceLimit
+	}
+	if params.OrgID != nil {
+		query.OrganizationID = *params.OrgID
+	}
+	if params.WorkspaceID != nil {
+		query.WorkspaceID = *params.WorkspaceID
+	}
+
+	cal := calendar.Default()
+	if params.OrgID != nil {
+		org, err := s.orgRepo.GetByID(*params.OrgID)
+		if err != nil {
+			return query, apperror.NotFound("organization not found")
+		}
+		cal = org.Calendar()
+	}
+
+	now := time.Now().UTC()
+	switch params.Period {
+	case "", LeaderboardPeriodAll:
+		query.End = dateIn(now, time.UTC).AddDate(0, 0, 1)
+	case LeaderboardPeriodWeek:
+		query.Start, query.End = cal.PeriodRange(calendar.PeriodWeek, now)
+	case LeaderboardPeriodMonth:
+		query.Start, query.End = cal.PeriodRange(calendar.PeriodMonth, now)
+	case LeaderboardPeriodCustom:
+		query.Start = dateIn(params.StartDate, time.UTC)
+		query.End = dateIn(params.EndDate, time.UTC).AddDate(0, 0, 1)
+	default:
+		return query, ErrInvalidLeaderboardPeriod
+	}
+	return query, nil
+}
+
+func (s *adminAnalyticsService) GetOrgDistributionStats(locale format.Locale) (*dto.AdminOrgStats, error) {
+	stats, err := s.orgDistribution()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range stats.TopWorkspaces {
+		stats.TopWorkspaces[i].TotalDurationHuman = format.Duration(stats.TopWorkspaces[i].TotalDuration, locale)
+	}
+
+	return stats, nil
+}
+
+func (s *adminAnalyticsService) GetActivityStats(locale format.Locale) (*dto.AdminActivityStats, error) {
+	stats, err := s.activity()
+	if err != nil {
+		return nil, err
+	}
+
+	stats.TodayDurationHuman = format.Duration(stats.TodayDuration, locale)
+
+	return stats, nil
+}
+
+func (s *adminAnalyticsService) Warm(ctx context.Context) {
+	began := time.Now()
+	end := began
+	warmers := []struct {
+		name string
+		fn   func() error
+	}{
+		{"overview", func() error { _, err := s.overview(); return err }},
+		{"trends", func() error {
+			_, err := s.trends(calendar.PeriodDay, end.AddDate(0, 0, -defaultTrendDays), end, time.UTC)
+			return err
+		}},
+		{"user performance", func() error {
+			query, err := s.leaderboardQuery(&dto.AdminUserPerformanceParams{Limit: defaultPerformanceLimit})
+			if err == nil {
+				_, err = s.userPerformance(query)
+			}
+			return err
+		}},
+		{"org distribution", func() error { _, err := s.orgDistribution(); return err }},
+		{"activity", func() error { _, err := s.activity(); return err }},
+	}
+
+	for _, w := range warmers {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := w.fn(); err != nil {
+			log.Printf("⚠️  Failed to warm %s stats: %v", w.name, err)
+		}
+	}
+	log.Printf("✅ Admin dashboard stats warmed in %s", time.Since(began).Round(time.Millisecond))
+}
+
+func (s *adminAnalyticsService) InvalidateActivity() {
+	now := time.Now().UnixNano()
+	last := s.lastBust.Load()
+	if now-last < int64(analyticsBustInterval) || !s.lastBust.CompareAndSwap(last, now) {
+		return
+	}
+
+	s.mu.Lock()
+	keys := make([]string, 0, len(s.dynamicKeys)+3)
+	for key := range s.dynamicKeys {
+		keys = append(keys, key)
+	}
+	s.dynamicKeys = make(map[string]struct{})
+	s.mu.Unlock()
+	keys = append(keys, analyticsOverviewKey, analyticsOrgDistributionKey, analyticsActivityKey)
+
+	if err := s.cache.Delete(context.Background(), keys...); err != nil {
+		log.Printf("⚠️  Failed to invalidate analytics cache: %v", err)
+	}
+}
+
+// groupDailyStats buckets daily stats (ordered by date) into calendar periods.
+// Counters are summed; TotalUsers is cumulative so the last day of a period wins.
+func groupDailyStats(daily []dto.AdminDailyStat, period string, cal calendar.Calendar) []dto.AdminDailyStat {
+	if period == calendar.PeriodDay {
+		return daily
+	}
+
+	grouped := []dto.AdminDailyStat{}
+	index := make(map[string]int)
+	for _, stat := range daily {
+		date, err := parseStatDate(stat.Date)
+		if err != nil {
+			continue
+		}
+
+		label := cal.PeriodLabel(period, date)
+		i, ok := index[label]
+		if !ok {
+			index[label] = len(grouped)
+			grouped = append(grouped, dto.AdminDailyStat{Date: label})
+			i = len(grouped) - 1
+		}
+
+		bucket := &grouped[i]
+		bucket.NewUsers += stat.NewUsers
+		bucket.Duration += stat.Duration
+		bucket.TimeLogs += stat.TimeLogs
+		bucket.Screenshots += stat.Screenshots
+		if stat.TotalUsers > 0 {
+			bucket.TotalUsers = stat.TotalUsers
+		}
+	}
+
+	return grouped
+}
+
+// parseStatDate parses dates scanned from SQL DATE columns ("2006-01-02" or RFC3339)
+func parseStatDate(s string) (time.Time, error) {
+	if len(s) >= 10 {
+		s = s[:10]
+	}
+	return time.Parse("2006-01-02", s)
+}
@@ -1,12 +1,16 @@
 package service
 
 import (
+	"encoding/json"
 	"errors"
+	"strings"
 	"time"
 
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
 	"github.com/beuphecan/remote-time-tracker/internal/config"
 	"github.com/beuphecan/remote-time-tracker/internal/dto"
 	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/money"
 	"github.com/beuphecan/remote-time-tracker/internal/repository"
 	"github.com/beuphecan/remote-time-tracker/internal/utils"
 	"golang.org/x/crypto/bcrypt"
@@ -18,11 +22,13 @@ type AdminService interface {
 	ListUsers(params *dto.AdminUserListParams) (*dto.AdminUserListResponse, error)
 	GetUser(id uint) (*dto.AdminUserDetailResponse, error)
 	CreateUser(req *dto.AdminCreateUserRequest) (*dto.AdminUserResponse, error)
-	UpdateUser(id uint, req *dto.AdminUpdateUserRequest) (*dto.AdminUserResponse, error)
+	UpdateUser(id, actorID uint, req *dto.AdminUpdateUserRequest) (*dto.AdminUserResponse, error)
 	DeleteUser(id uint) error
 	ActivateUser(id uint, active bool) error
 	ChangeUserRole(id uint, role string) error
-	ChangeUserSystemRole(id uint, systemRole string) error
+	ChangeUserSystemRole(id, actorID uint, systemRole string) error
+	ImpersonateUser(id, adminID uint) (*dto.AdminImpersonationResponse, error)
+	MergeUser(sourceID, targetID, adminID uint) (*dto.AdminUserMergeResponse, error)
 
 	// Organizations
 	ListOrganizations(params *dto.AdminOrgListParams) (*dto.AdminOrgListResponse, error)
@@ -46,8 +52,9 @@ type AdminService interface {
 
 	// Time Logs
 	ListTimeLogs(params *dto.AdminTimeLogListParams) (*dto.AdminTimeLogListResponse, error)
+	ExportTimeLogs(params *dto.AdminTimeLogListParams) ([]dto.AdminTimeLogResponse, error)
 	GetTimeLog(id uint) (*dto.AdminTimeLogDetailResponse, error)
-	UpdateTimeLog(id uint, req *dto.AdminUpdateTimeLogRequest) (*dto.AdminTimeLogResponse, error)
+	UpdateTimeLog(id uint, req *dto.AdminUpdateTimeLogRequest, adminID uint) (*dto.AdminTimeLogResponse, error)
 	DeleteTimeLog(id uint) error
 	ApproveTimeLogs(req *dto.AdminApproveTimeLogsRequest, adminID uint) error
 
@@ -56,13 +63,6 @@ type AdminService interface {
 	GetScreenshot(id uint) (*dto.AdminScreenshotResponse, error)
 	DeleteScreenshot(id uint) error
 	BulkDeleteScreenshots(ids []uint) error
-
-	// Statistics
-	GetOverviewStats() (*dto.AdminOverviewStats, error)
-	GetTrendStats(req *dto.AdminTrendRequest) (*dto.AdminTrendStats, error)
-	GetUserPerformanceStats(limit int) ([]dto.AdminUserPerformance, error)
-	GetOrgDistributionStats() (*dto.AdminOrgStats, error)
-	GetActivityStats() (*dto.AdminActivityStats, error)
 }
 
 type adminService struct {
@@ -73,6 +73,14 @@ type adminService struct {
 	taskRepo       repository.TaskRepository
 	timeLogRepo    repository.TimeLogRepository
 	screenshotRepo repository.ScreenshotRepository
+
+	permissionService   PermissionService
+	slackService        SlackService
+	commitService       CommitLinkService
+	notificationService NotificationService
+	orgDeletion         OrganizationDeletionService
+	auditService        AuditService
+	timeLogHistory      TimeLogHistoryService
 }
 
 // NewAdminService creates new admin service
@@ -84,6 +92,13 @@ func NewAdminService(
 	taskRepo repository.TaskRepository,
 	timeLogRepo repository.TimeLogRepository,
 	screenshotRepo repository.ScreenshotRepository,
+	permissionService PermissionService,
+	slackService SlackService,
+	commitService CommitLinkService,
+	notificationService NotificationService,
+	orgDeletion OrganizationDeletionService,
+	auditService AuditService,
+	timeLogHistory TimeLogHistoryService,
 ) AdminService {
 	return &adminService{
 		adminRepo:      adminRepo,
@@ -93,6 +108,14 @@ func NewAdminService(
 		taskRepo:       taskRepo,
 		timeLogRepo:    timeLogRepo,
 		screenshotRepo: screenshotRepo,
+
+		permissionService:   permissionService,
+		slackService:        slackService,
+		commitService:       commitService,
+		notificationService: notificationService,
+		orgDeletion:         orgDeletion,
+		auditService:        auditService,
+		timeLogHistory:      timeLogHistory,
 	}
 }
 
@@ -191,7 +214,7 @@ func (s *adminService) CreateUser(req *dto.AdminCreateUserRequest) (*dto.AdminUs
 	// Check email exists
 	existing, _ := s.userRepo.FindByEmail(req.Email)
 	if existing != nil {
-		return nil, errors.New("email already exists")
+		return nil, apperror.Conflict("email already exists")
 	}
 
 	// Hash password
@@ -218,16 +241,17 @@ func (s *adminService) CreateUser(req *dto.AdminCreateUserRequest) (*dto.AdminUs
 	return &response, nil
 }
 
-func (s *adminService) UpdateUser(id uint, req *dto.AdminUpdateUserRequest) (*dto.AdminUserResponse, error) {
+func (s *adminService) UpdateUser(id, actorID uint, req *dto.AdminUpdateUserRequest) (*dto.AdminUserResponse, error) {
 	user, err := s.userRepo.FindByID(id)
 	if err != nil {
 		return nil, err
 	}
+	previousSystemRole := user.SystemRole
 
 	if req.Email != "" && req.Email != user.Email {
 		existing, _ := s.userRepo.FindByEmail(req.Email)
 		if existing != nil && existing.ID != id {
-			return nil, errors.New("email already exists")
+			return nil, apperror.Conflict("email already exists")
 		}
 		user.Email = req.Email
 	}
@@ -258,6 +282,7 @@ func (s *adminService) UpdateUser(id uint, req *dto.AdminUpdateUserRequest) (*dt
 	if err := s.userRepo.Update(user); err != nil {
 		return nil, err
 	}
+	s.recordSystemRoleChange(user, previousSystemRole, actorID)
 
 	response := s.userToResponse(user)
 	return &response, nil
@@ -285,13 +310,128 @@ func (s *adminService) ChangeUserRole(id uint, role string) error {
 	return s.userRepo.Update(user)
 }
 
-func (s *adminService) ChangeUserSystemRole(id uint, systemRole string) error {
+func (s *adminService) ChangeUserSystemRole(id, actorID uint, systemRole string) error {
 	user, err := s.userRepo.FindByID(id)
 	if err != nil {
 		return err
 	}
+	previousSystemRole := user.SystemRole
 	user.SystemRole = systemRole
-	return s.userRepo.Update(user)
+	if err := s.userRepo.Update(user); err != nil {
+		return err
+	}
+	s.recordSystemRoleChange(user, previousSystemRole, actorID)
+	return nil
+}
+
+// recordSystemRoleChange adds a system role change to the user's role history
+func (s *adminService) recordSystemRoleChange(user *models.User, previousSystemRole string, actorID uint) {
+	if user.SystemRole == previousSystemRole {
+		return
+	}
+	s.permissionService.RecordRoleChanges(models.RoleChange{
+		UserID:    user.ID,
+		Scope:     models.RoleScopeSystem,
+		Attribute: "system_role",
+		FromValue: previousSystemRole,
+		ToValue:   user.SystemRole,
+		ChangedBy: &actorID,
+		Source:    models.RoleChangeSourceSystemAdmin,
+	})
+}
+
+func (s *adminService) ImpersonateUser(id, adminID uint) (*dto.AdminImpersonationResponse, error) {
+	if id == adminID {
+		return nil, apperror.Validation("cannot impersonate yourself", nil)
+	}
+
+	user, err := s.userRepo.FindByID(id)
+	if err != nil {
+		return nil, apperror.NotFound("user not found")
+	}
+
+	if user.IsSystemAdmin() {
+		return nil, apperror.Forbidden("cannot impersonate a system admin")
+	}
+
+	if !user.IsActive {
+		return nil, apperror.Validation("cannot impersonate an inactive user", nil)
+	}
+
+	token, expiresAt, err := utils.GenerateImpersonationToken(user.ID, user.Email, user.Role, adminID)
+	if err != nil {
+		return nil, errors.New("failed to generate impersonation token")
+	}
+
+	return &dto.AdminImpersonationResponse{
+		AccessToken:    token,
+		ExpiresAt:      expiresAt,
+		Impersonation:  true,
+		ImpersonatorID: adminID,
+		User:           s.userToResponse(user),
+	}, nil
+}
+
+// MergeUser moves a duplicate account's records to the account the person
+// keeps, deactivates the duplicate and records the merge in the audit log
+func (s *adminService) MergeUser(sourceID, targetID, adminID uint) (*dto.AdminUserMergeResponse, error) {
+	if sourceID == targetID {
+		return nil, apperror.Validation("cannot merge a user into itself", nil)
+	}
+
+	source, err := s.userRepo.FindByID(sourceID)
+	if err != nil {
+		return nil, apperror.NotFound("user not found")
+	}
+	target, err := s.userRepo.FindByID(targetID)
+	if err != nil {
+		return nil, apperror.NotFound("target user not found")
+	}
+	if source.IsSystemAdmin() {
+		return nil, apperror.Forbidden("cannot merge a system admin")
+	}
+	if !target.IsActive {
+		return nil, apperror.Validation("cannot merge into an inactive user", nil)
+	}
+
+	counts, err := s.adminRepo.MergeUsers(sourceID, targetID)
+	if err != nil {
+		return nil, errors.New("failed to merge users")
+	}
+
+	details, _ := json.Marshal(map[string]interface{}{
+		"source_user_id":           source.ID,
+		"source_email":             source.Email,
+		"target_user_id":           target.ID,
+		"target_email":             target.Email,
+		"tasks":                    counts.Tasks,
+		"task_assignments":         counts.TaskAssignments,
+		"time_logs":                counts.TimeLogs,
+		"screenshots":              counts.Screenshots,
+		"devices":                  counts.Devices,
+		"organization_memberships": counts.OrganizationMemberships,
+		"workspace_memberships":    counts.WorkspaceMemberships,
+	})
+	s.auditService.Record(&models.AuditLog{
+		UserID:     &adminID,
+		Action:     "merge",
+		EntityType: "user",
+		EntityID:   &source.ID,
+		Details:    string(details),
+		Status:     AuditStatusSuccess,
+	})
+
+	return &dto.AdminUserMergeResponse{
+		SourceUserID:            source.ID,
+		Target:                  s.userToResponse(target),
+		Tasks:                   counts.Tasks,
+		TaskAssignments:         counts.TaskAssignments,
+		TimeLogs:                counts.TimeLogs,
+		Screenshots:             counts.Screenshots,
+		Devices:                 counts.Devices,
+		OrganizationMemberships: counts.OrganizationMemberships,
+		WorkspaceMemberships:    counts.WorkspaceMemberships,
+	}, nil
 }
 
 // ============================================================================
@@ -304,10 +444,18 @@ func (s *adminService) ListOrganizations(params *dto.AdminOrgListParams) (*dto.A
 		return nil, err
 	}
 
+	orgIDs := make([]uint, len(orgs))
+	for i, o := range orgs {
+		orgIDs[i] = o.ID
+	}
+	statsByOrg, err := s.adminRepo.GetOrgStatsByIDs(orgIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	var orgResponses []dto.AdminOrgResponse
 	for _, o := range orgs {
-		stats, _ := s.adminRepo.GetOrgStats(o.ID)
-		orgResponses = append(orgResponses, s.orgToResponse(&o, stats))
+		orgResponses = append(orgResponses, s.orgToResponse(&o, statsByOrg[o.ID]))
 	}
 
 	totalPages := int((total + int64(params.PageSize) - 1) / int64(params.PageSize))
@@ -372,6 +520,17 @@ func (s *adminService) UpdateOrganization(id uint, req *dto.AdminUpdateOrgReques
 	if err != nil {
 		return nil, err
 	}
+	state := func() (interface{}, error) {
+		current, err := s.orgRepo.GetByID(id)
+		if err != nil {
+			return nil, err
+		}
+		stats, _ := s.adminRepo.GetOrgStats(id)
+		return s.orgToResponse(current, stats), nil
+	}
+	if err := checkVersion(req.Version, org.Version, state); err != nil {
+		return nil, err
+	}
 
 	if req.Name != "" {
 		org.Name = req.Name
@@ -387,7 +546,7 @@ func (s *adminService) UpdateOrganization(id uint, req *dto.AdminUpdateOrgReques
 	}
 
 	if err := s.orgRepo.Update(org); err != nil {
-		return nil, err
+		return nil, versionedUpdateError(err, state)
 	}
 
 	stats, _ := s.adminRepo.GetOrgStats(id)
@@ -395,8 +554,11 @@ func (s *adminService) UpdateOrganization(id uint, req *dto.AdminUpdateOrgReques
 	return &response, nil
 }
 
+// DeleteOrganization schedules the organization's deletion; it is purged
+// after the grace period like deletions requested by owners
 func (s *adminService) DeleteOrganization(id uint) error {
-	return s.orgRepo.Delete(id)
+	_, err := s.orgDeletion.ScheduleDeletion(id)
+	return err
 }
 
 func (s *adminService) VerifyOrganization(id uint, verified bool, adminID uint) error {
@@ -428,10 +590,18 @@ func (s *adminService) ListWorkspaces(params *dto.AdminWorkspaceListParams) (*dt
 		return nil, err
 	}
 
+	workspaceIDs := make([]uint, len(workspaces))
+	for i, w := range workspaces {
+		workspaceIDs[i] = w.ID
+	}
+	statsByWorkspace, err := s.adminRepo.GetWorkspaceStatsByIDs(workspaceIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	var wsResponses []dto.AdminWorkspaceResponse
 	for _, w := range workspaces {
-		stats, _ := s.adminRepo.GetWorkspaceStats(w.ID)
-		wsResponses = append(wsResponses, s.workspaceToResponse(&w, stats))
+		wsResponses = append(wsResponses, s.workspaceToResponse(&w, statsByWorkspace[w.ID]))
 	}
 
 	totalPages := int((total + int64(params.PageSize) - 1) / int64(params.PageSize))
@@ -498,6 +668,17 @@ func (s *adminService) UpdateWorkspace(id uint, req *dto.AdminUpdateWorkspaceReq
 	if err != nil {
 		return nil, err
 	}
+	state := func() (interface{}, error) {
+		current, err := s.workspaceRepo.GetByID(id)
+		if err != nil {
+			return nil, err
+		}
+		stats, _ := s.adminRepo.GetWorkspaceStats(id)
+		return s.workspaceToResponse(current, stats), nil
+	}
+	if err := checkVersion(req.Version, workspace.Version, state); err != nil {
+		return nil, err
+	}
 
 	if req.Name != "" {
 		workspace.Name = req.Name
@@ -508,9 +689,26 @@ func (s *adminService) UpdateWorkspace(id uint, req *dto.AdminUpdateWorkspaceReq
 	if req.IsActive != nil {
 		workspace.IsActive = *req.IsActive
 	}
+	if req.IsBillable != nil {
+		workspace.IsBillable = *req.IsBillable
+	}
+	if req.Currency != nil {
+		currency := money.Normalize(*req.Currency)
+		workspace.HourlyRateMinor = money.Rescale(workspace.HourlyRateMinor, workspace.Currency, currency)
+		workspace.Currency = currency
+	}
+	if req.HourlyRate != nil {
+		workspace.HourlyRateMinor = money.ToMinor(*req.HourlyRate, workspace.Currency)
+	}
+	if req.CostCenter != nil {
+		workspace.CostCenter = strings.TrimSpace(*req.CostCenter)
+	}
+	if req.ProjectCode != nil {
+		workspace.ProjectCode = strings.TrimSpace(*req.ProjectCode)
+	}
 
 	if err := s.workspaceRepo.Update(workspace); err != nil {
-		return nil, err
+		return nil, versionedUpdateError(err, state)
 	}
 
 	stats, _ := s.adminRepo.GetWorkspaceStats(id)
@@ -538,7 +736,13 @@ func (s *adminService) ArchiveWorkspace(id uint, archived bool, adminID uint) er
 		workspace.ArchivedBy = nil
 	}
 
-	return s.workspaceRepo.Update(workspace)
+	if err := s.workspaceRepo.Update(workspace); err != nil {
+		return err
+	}
+	if archived {
+		s.notificationService.NotifyWorkspaceArchived(workspace)
+	}
+	return nil
 }
 
 // ============================================================================
@@ -603,6 +807,11 @@ func (s *adminService) GetTask(id uint) (*dto.AdminTaskDetailResponse, error) {
 		screenshotResponses = append(screenshotResponses, s.screenshotToResponse(&ss))
 	}
 
+	estimate, err := rolledUpEstimate(s.taskRepo, task)
+	if err != nil {
+		return nil, err
+	}
+
 	return &dto.AdminTaskDetailResponse{
 		AdminTaskResponse: s.taskToResponse(task),
 		TimeLogsCount:     stats.TimeLogsCount,
@@ -610,6 +819,8 @@ func (s *adminService) GetTask(id uint) (*dto.AdminTaskDetailResponse, error) {
 		ScreenshotCount:   stats.ScreenshotCount,
 		TimeLogs:          timeLogResponses,
 		Screenshots:       screenshotResponses,
+		Commits:           s.commitService.CommitsForTask(task.ID),
+		Estimate:          dto.TaskEstimateResponse(*estimate),
 	}, nil
 }
 
@@ -618,6 +829,16 @@ func (s *adminService) UpdateTask(id uint, req *dto.AdminUpdateTaskRequest) (*dt
 	if err != nil {
 		return nil, err
 	}
+	state := func() (interface{}, error) {
+		current, err := s.taskRepo.FindByID(id)
+		if err != nil {
+			return nil, err
+		}
+		return s.taskToResponse(current), nil
+	}
+	if err := checkVersion(req.Version, task.Version, state); err != nil {
+		return nil, err
+	}
 
 	if req.Title != "" {
 		task.Title = req.Title
@@ -631,9 +852,18 @@ func (s *adminService) UpdateTask(id uint, req *dto.AdminUpdateTaskRequest) (*dt
 	if req.AdminNotes != "" {
 		task.AdminNotes = req.AdminNotes
 	}
+	if req.CostCenter != nil {
+		task.CostCenter = strings.TrimSpace(*req.CostCenter)
+	}
+	if req.ProjectCode != nil {
+		task.ProjectCode = strings.TrimSpace(*req.ProjectCode)
+	}
+	if err := applyTaskPlanning(task, req.DueDate, req.EstimateSeconds, req.Tags); err != nil {
+		return nil, err
+	}
 
 	if err := s.taskRepo.Update(task); err != nil {
-		return nil, err
+		return nil, versionedUpdateError(err, state)
 	}
 
 	response := s.taskToResponse(task)
@@ -674,6 +904,25 @@ func (s *adminService) ListTimeLogs(params *dto.AdminTimeLogListParams) (*dto.Ad
 	}, nil
 }
 
+// maxTimeLogExportRows caps a single export to keep memory bounded
+const maxTimeLogExportRows = 50000
+
+// ExportTimeLogs returns every time log matching the filters with its
+// effective cost center and project code resolved for accounting exports
+func (s *adminService) ExportTimeLogs(params *dto.AdminTimeLogListParams) ([]dto.AdminTimeLogResponse, error) {
+	timeLogs, err := s.adminRepo.FindTimeLogsForExport(params, maxTimeLogExportRows)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]dto.AdminTimeLogResponse, 0, len(timeLogs))
+	for _, tl := range timeLogs {
+		rows = append(rows, s.timeLogToResponse(&tl))
+	}
+
+	return rows, nil
+}
+
 func (s *adminService) GetTimeLog(id uint) (*dto.AdminTimeLogDetailResponse, error) {
 	timeLog, err := s.timeLogRepo.FindByID(id)
 	if err != nil {
@@ -696,20 +945,27 @@ func (s *adminService) GetTimeLog(id uint) (*dto.AdminTimeLogDetailResponse, err
 		AdminTimeLogResponse: s.timeLogToResponse(timeLog),
 		ScreenshotCount:      screenshotCount,
 		Screenshots:          screenshotResponses,
+		Commits:              s.commitService.CommitsForTimeLog(timeLog.ID),
 	}, nil
 }
 
-func (s *adminService) UpdateTimeLog(id uint, req *dto.AdminUpdateTimeLogRequest) (*dto.AdminTimeLogResponse, error) {
+func (s *adminService) UpdateTimeLog(id uint, req *dto.AdminUpdateTimeLogRequest, adminID uint) (*dto.AdminTimeLogResponse, error) {
 	timeLog, err := s.timeLogRepo.FindByID(id)
 	if err != nil {
 		return nil, err
 	}
+	if timeLog.InvoiceID != nil {
+		return nil, ErrTimeLogInvoiced
+	}
 
+	before := timeLog.Snapshot()
+	reviewed := req.IsApproved != nil && *req.IsApproved != timeLog.IsApproved
 	if req.Status != "" {
 		timeLog.Status = req.Status
 	}
 	if req.IsApproved != nil {
 		timeLog.IsApproved = *req.IsApproved
+		timeLog.PendingApproval = false
 	}
 	if req.AdminNotes != "" {
 		timeLog.AdminNotes = req.AdminNotes
@@ -718,17 +974,37 @@ func (s *adminService) UpdateTimeLog(id uint, req *dto.AdminUpdateTimeLogRequest
 	if err := s.timeLogRepo.Update(timeLog); err != nil {
 		return nil, err
 	}
+	s.timeLogHistory.Record(timeLog.ID, before, timeLog.Snapshot(), &adminID, models.TimeLogEditSourceAdmin)
+	if reviewed {
+		s.slackService.NotifyTimeLogsReviewed([]uint{timeLog.ID}, timeLog.IsApproved, adminID)
+		if !timeLog.IsApproved {
+			s.notificationService.NotifyTimeLogsRejected([]uint{timeLog.ID})
+		}
+	}
 
 	response := s.timeLogToResponse(timeLog)
 	return &response, nil
 }
 
 func (s *adminService) DeleteTimeLog(id uint) error {
-	return s.timeLogRepo.Delete(id)
+	if err := s.timeLogRepo.Delete(id); err != nil {
+		if errors.Is(err, repository.ErrTimeLogInvoiced) {
+			return ErrTimeLogInvoiced
+		}
+		return err
+	}
+	return nil
 }
 
 func (s *adminService) ApproveTimeLogs(req *dto.AdminApproveTimeLogsRequest, adminID uint) error {
-	return s.adminRepo.BulkApproveTimeLogs(req.IDs, adminID, req.Approved)
+	if err := s.adminRepo.BulkApproveTimeLogs(req.IDs, adminID, req.Approved); err != nil {
+		return err
+	}
+	s.slackService.NotifyTimeLogsReviewed(req.IDs, req.Approved, adminID)
+	if !req.Approved {
+		s.notificationService.NotifyTimeLogsRejected(req.IDs)
+	}
+	return nil
 }
 
 // ============================================================================
@@ -784,33 +1060,6 @@ func (s *adminService) BulkDeleteScreenshots(ids []uint) error {
 	return nil
 }
 
-// ============================================================================
-// STATISTICS METHODS
-// ============================================================================
-
-func (s *adminService) GetOverviewStats() (*dto.AdminOverviewStats, error) {
-	return s.adminRepo.GetOverviewStats()
-}
-
-func (s *adminService) GetTrendStats(req *dto.AdminTrendRequest) (*dto.AdminTrendStats, error) {
-	return s.adminRepo.GetTrendStats(req.Period, req.StartDate, req.EndDate)
-}
-
-func (s *adminService) GetUserPerformanceStats(limit int) ([]dto.AdminUserPerformance, error) {
-	if limit <= 0 {
-		limit = 10
-	}
-	return s.adminRepo.GetUserPerformanceStats(limit)
-}
-
-func (s *adminService) GetOrgDistributionStats() (*dto.AdminOrgStats, error) {
-	return s.adminRepo.GetOrgDistributionStats()
-}
-
-func (s *adminService) GetActivityStats() (*dto.AdminActivityStats, error) {
-	return s.adminRepo.GetActivityStats()
-}
-
 // ============================================================================
 // HELPER METHODS - Convert models to DTOs
 // ============================================================================
@@ -820,6 +1069,7 @@ func (s *adminService) userToResponse(u *models.User) dto.AdminUserResponse {
 
 	return dto.AdminUserResponse{
 		ID:             u.ID,
+		UUID:           u.UUID,
 		Email:          u.Email,
 		FirstName:      u.FirstName,
 		LastName:       u.LastName,
@@ -838,6 +1088,8 @@ func (s *adminService) userToResponse(u *models.User) dto.AdminUserResponse {
 func (s *adminService) orgToResponse(o *models.Organization, stats *repository.OrgStats) dto.AdminOrgResponse {
 	resp := dto.AdminOrgResponse{
 		ID:          o.ID,
+		UUID:        o.UUID,
+		Version:     o.Version,
 		Name:        o.Name,
 		Slug:        o.Slug,
 		Description: o.Description,
@@ -866,6 +1118,8 @@ func (s *adminService) orgToResponse(o *models.Organization, stats *repository.O
 func (s *adminService) workspaceToResponse(w *models.Workspace, stats *repository.WorkspaceStats) dto.AdminWorkspaceResponse {
 	resp := dto.AdminWorkspaceResponse{
 		ID:          w.ID,
+		UUID:        w.UUID,
+		Version:     w.Version,
 		Name:        w.Name,
 		Slug:        w.Slug,
 		Description: w.Description,
@@ -874,6 +1128,11 @@ func (s *adminService) workspaceToResponse(w *models.Workspace, stats *repositor
 		IsActive:    w.IsActive,
 		IsArchived:  w.IsArchived,
 		ArchivedAt:  w.ArchivedAt,
+		IsBillable:  w.IsBillable,
+		HourlyRate:  money.ToMajor(w.HourlyRateMinor, w.Currency),
+		Currency:    w.Currency,
+		CostCenter:  w.CostCenter,
+		ProjectCode: w.ProjectCode,
 		CreatedAt:   w.CreatedAt,
 		UpdatedAt:   w.UpdatedAt,
 	}
@@ -897,19 +1156,29 @@ func (s *adminService) workspaceToResponse(w *models.Workspace, stats *repositor
 
 func (s *adminService) taskToResponse(t *models.Task) dto.AdminTaskResponse {
 	resp := dto.AdminTaskResponse{
-		ID:          t.ID,
-		Title:       t.Title,
-		Description: t.Description,
-		Status:      t.Status,
-		Priority:    t.Priority,
-		Color:       t.Color,
-		IsManual:    t.IsManual,
-		UserID:      t.UserID,
-		OrgID:       t.OrganizationID,
-		WorkspaceID: t.WorkspaceID,
-		AdminNotes:  t.AdminNotes,
-		CreatedAt:   t.CreatedAt,
-		UpdatedAt:   t.UpdatedAt,
+		ID:           t.ID,
+		UUID:         t.UUID,
+		Version:      t.Version,
+		Title:        t.Title,
+		Description:  t.Description,
+		Status:       t.Status,
+		Priority:     t.Priority,
+		Color:        t.Color,
+		IsManual:     t.IsManual,
+		UserID:       t.UserID,
+		OrgID:        t.OrganizationID,
+		WorkspaceID:  t.WorkspaceID,
+		AdminNotes:   t.AdminNotes,
+		CostCenter:   t.CostCenter,
+		ProjectCode:  t.ProjectCode,
+		CreatedBy:    t.CreatedBy,
+		ParentTaskID: t.ParentTaskID,
+		DueDate:      t.DueDate,
+		Tags:         taskTagList(t.Tags),
+		CreatedAt:    t.CreatedAt,
+		UpdatedAt:    t.UpdatedAt,
+
+		EstimateSeconds: t.EstimateSeconds,
 	}
 
 	if t.User.ID > 0 {
@@ -930,21 +1199,26 @@ func (s *adminService) taskToResponse(t *models.Task) dto.AdminTaskResponse {
 
 func (s *adminService) timeLogToResponse(tl *models.TimeLog) dto.AdminTimeLogResponse {
 	resp := dto.AdminTimeLogResponse{
-		ID:          tl.ID,
-		UserID:      tl.UserID,
-		TaskID:      tl.TaskID,
-		OrgID:       tl.OrganizationID,
-		WorkspaceID: tl.WorkspaceID,
-		StartTime:   tl.StartTime,
-		EndTime:     tl.EndTime,
-		Duration:    tl.Duration,
-		Status:      tl.Status,
-		IsManual:    tl.IsManual,
-		IsApproved:  tl.IsApproved,
-		ApprovedBy:  tl.ApprovedBy,
-		ApprovedAt:  tl.ApprovedAt,
-		AdminNotes:  tl.AdminNotes,
-		CreatedAt:   tl.CreatedAt,
+		ID:              tl.ID,
+		UUID:            tl.UUID,
+		UserID:          tl.UserID,
+		TaskID:          tl.TaskID,
+		OrgID:           tl.OrganizationID,
+		WorkspaceID:     tl.WorkspaceID,
+		StartTime:       tl.StartTime,
+		EndTime:         tl.EndTime,
+		Duration:        tl.Duration,
+		Status:          tl.Status,
+		CapStatus:       tl.CapStatus,
+		OvertimeSeconds: tl.OvertimeSeconds,
+		IsManual:        tl.IsManual,
+		IsApproved:      tl.IsApproved,
+		ApprovedBy:      tl.ApprovedBy,
+		ApprovedAt:      tl.ApprovedAt,
+		AdminNotes:      tl.AdminNotes,
+		CreatedAt:       tl.CreatedAt,
+		PendingApproval: tl.PendingApproval,
+		InvoiceID:       tl.InvoiceID,
 	}
 
 	if tl.User.ID > 0 {
@@ -954,6 +1228,8 @@ func (s *adminService) timeLogToResponse(tl *models.TimeLog) dto.AdminTimeLogRes
 
 	if tl.Task != nil && tl.Task.ID > 0 {
 		resp.TaskTitle = tl.Task.Title
+		resp.CostCenter = tl.Task.CostCenter
+		resp.ProjectCode = tl.Task.ProjectCode
 	}
 
 	if tl.Organization != nil && tl.Organization.ID > 0 {
@@ -962,6 +1238,13 @@ func (s *adminService) timeLogToResponse(tl *models.TimeLog) dto.AdminTimeLogRes
 
 	if tl.Workspace != nil && tl.Workspace.ID > 0 {
 		resp.WorkspaceName = tl.Workspace.Name
+		// Task-level tags take precedence over the workspace defaults
+		if resp.CostCenter == "" {
+			resp.CostCenter = tl.Workspace.CostCenter
+		}
+		if resp.ProjectCode == "" {
+			resp.ProjectCode = tl.Workspace.ProjectCode
+		}
 	}
 
 	return resp
@@ -969,21 +1252,26 @@ func (s *adminService) timeLogToResponse(tl *models.TimeLog) dto.AdminTimeLogRes
 
 func (s *adminService) screenshotToResponse(ss *models.Screenshot) dto.AdminScreenshotResponse {
 	resp := dto.AdminScreenshotResponse{
-		ID:           ss.ID,
-		UserID:       ss.UserID,
-		TaskID:       ss.TaskID,
-		TimeLogID:    ss.TimeLogID,
-		OrgID:        ss.OrganizationID,
-		WorkspaceID:  ss.WorkspaceID,
-		FileName:     ss.FileName,
-		FilePath:     ss.FilePath,
-		FileSize:     ss.FileSize,
-		MimeType:     ss.MimeType,
-		ScreenNumber: ss.ScreenNumber,
-		MonitorIndex: ss.ScreenNumber, // Use ScreenNumber as MonitorIndex
-		IsEncrypted:  ss.IsEncrypted,
-		CapturedAt:   ss.CapturedAt,
-		CreatedAt:    ss.CreatedAt,
+		ID:            ss.ID,
+		UUID:          ss.UUID,
+		UserID:        ss.UserID,
+		TaskID:        ss.TaskID,
+		TimeLogID:     ss.TimeLogID,
+		OrgID:         ss.OrganizationID,
+		WorkspaceID:   ss.WorkspaceID,
+		FileName:      ss.FileName,
+		FilePath:      ss.FilePath,
+		FileSize:      ss.FileSize,
+		MimeType:      ss.MimeType,
+		ScreenNumber:  ss.ScreenNumber,
+		MonitorIndex:  ss.ScreenNumber, // Use ScreenNumber as MonitorIndex
+		IsEncrypted:   ss.IsEncrypted,
+		StorageTier:   ss.StorageTier,
+		ScanStatus:    ss.ScanStatus,
+		ScanSignature: ss.ScanSignature,
+		ScannedAt:     ss.ScannedAt,
+		CapturedAt:    ss.CapturedAt,
+		CreatedAt:     ss.CreatedAt,
 	}
 
 	if ss.User.ID > 0 {
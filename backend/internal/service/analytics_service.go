@@ -0,0 +1,356 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+)
+
+// Analytics result scopes
+const (
+	AnalyticsScopeOrganization = "organization" // org owners/admins see every member
+	AnalyticsScopeSelf         = "self"         // members only see their own rows
+)
+
+// ErrAnalyticsAccessDenied is returned when the caller is not a member of the organization
+var ErrAnalyticsAccessDenied = apperror.Forbidden("access denied: not a member of this organization")
+
+const (
+	analyticsDefaultRange = 30 * 24 * time.Hour
+	analyticsMaxRange     = 366 * 24 * time.Hour
+	analyticsDefaultLimit = 100
+	analyticsMaxLimit     = 1000
+	analyticsTimeout      = 5 * time.Second
+)
+
+// AnalyticsService runs predefined, parameterized analytics queries scoped to
+// the caller's organization
+type AnalyticsService interface {
+	ListQueries() []dto.AnalyticsQueryInfo
+	Run(orgID, userID uint, req *dto.AnalyticsQueryRequest) (*dto.AnalyticsQueryResponse, error)
+}
+
+type analyticsService struct {
+	analyticsRepo repository.AnalyticsRepository
+	orgRepo       *repository.OrganizationRepository
+}
+
+// NewAnalyticsService creates a new analytics service
+func NewAnalyticsService(analyticsRepo repository.AnalyticsRepository, orgRepo *repository.OrganizationRepository) AnalyticsService {
+	return &analyticsService{
+		analyticsRepo: analyticsRepo,
+		orgRepo:       orgRepo,
+	}
+}
+
+// analyticsQuery is a predefined query. Every statement must filter on
+// @org_id and apply the @scope_user_id row filter (0 = all members).
+type analyticsQuery struct {
+	info dto.AnalyticsQueryInfo
+	sql  string
+}
+
+var analyticsDateParams = []dto.AnalyticsQueryParamInfo{
+	{Name: "start_date", Type: "date", Description: "Range start (YYYY-MM-DD), defaults to 30 days before end_date"},
+	{Name: "end_date", Type: "date", Description: "Range end, inclusive (YYYY-MM-DD), defaults to today"},
+	{Name: "workspace_id", Type: "int", Description: "Restrict to one workspace"},
+	{Name: "client_id", Type: "int", Description: "Restrict to the workspaces of one client"},
+	{Name: "limit", Type: "int", Description: "Maximum rows (default 100, max 1000)"},
+}
+
+const analyticsTimeLogFilter = `
+	tl.organization_id = @org_id
+	AND tl.deleted_at IS NULL
+	AND tl.start_time >= @start_date AND tl.start_time < @end_date
+	AND (@workspace_id = 0 OR tl.workspace_id = @workspace_id)
+	AND (@client_id = 0 OR tl.workspace_id IN (SELECT id FROM workspaces WHERE client_id = @client_id))
+	AND (@scope_user_id = 0 OR tl.user_id = @scope_user_id)`
+
+var analyticsQueries = []analyticsQuery{
+	{
+		info: dto.AnalyticsQueryInfo{
+			Name:        "hours_by_user",
+			Description: "Tracked hours and sessions per member, with days on approved leave",
+			Columns:     []string{"user_id", "user_name", "email", "sessions", "total_seconds", "total_hours", "leave_days"},
+		},
+		sql: `SELECT u.id AS user_id, TRIM(u.first_name || ' ' || u.last_name) AS user_name, u.email,
+				COUNT(tl.id) AS sessions, COALESCE(SUM(tl.duration), 0) AS total_seconds,
+				ROUND(COALESCE(SUM(tl.duration), 0) / 3600.0, 2) AS total_hours,
+				(SELECT COALESCE(SUM(LEAST(lr.end_date, DATE(@end_date) - 1) - GREATEST(lr.start_date, DATE(@start_date)) + 1), 0)
+					FROM leave_requests lr
+					WHERE lr.organization_id = @org_id AND lr.user_id = u.id AND lr.status = 'approved'
+						AND lr.start_date < DATE(@end_date) AND lr.end_date >= DATE(@start_date)) AS leave_days
+			FROM time_logs tl
+			JOIN users u ON u.id = tl.user_id
+			WHERE` + analyticsTimeLogFilter + `
+			GROUP BY u.id, u.first_name, u.last_name, u.email
+			ORDER BY total_seconds DESC
+			LIMIT @limit`,
+	},
+	{
+		info: dto.AnalyticsQueryInfo{
+			Name:        "hours_by_workspace",
+			Description: "Tracked hours per workspace with accounting tags",
+			Columns:     []string{"workspace_id", "workspace_name", "cost_center", "project_code", "members", "total_seconds", "total_hours"},
+		},
+		sql: `SELECT w.id AS workspace_id, w.name AS workspace_name,
+				COALESCE(w.cost_center, '') AS cost_center, COALESCE(w.project_code, '') AS project_code,
+				COUNT(DISTINCT tl.user_id) AS members, COALESCE(SUM(tl.duration), 0) AS total_seconds,
+				ROUND(COALESCE(SUM(tl.duration), 0) / 3600.0, 2) AS total_hours
+			FROM time_logs tl
+			JOIN workspaces w ON w.id = tl.workspace_id
+			WHERE` + analyticsTimeLogFilter + `
+			GROUP BY w.id, w.name, w.cost_center, w.project_code
+			ORDER BY total_seconds DESC
+			LIMIT @limit`,
+	},
+	{
+		info: dto.AnalyticsQueryInfo{
+			Name:        "hours_by_client",
+			Description: "Tracked hours per client across its workspaces; time in workspaces without a client is grouped under an empty client",
+			Columns:     []string{"client_id", "client_name", "workspaces", "members", "total_seconds", "total_hours"},
+		},
+		sql: `SELECT c.id AS client_id, COALESCE(c.name, '') AS client_name,
+				COUNT(DISTINCT tl.workspace_id) AS workspaces, COUNT(DISTINCT tl.user_id) AS members,
+				COALESCE(SUM(tl.duration), 0) AS total_seconds,
+				ROUND(COALESCE(SUM(tl.duration), 0) / 3600.0, 2) AS total_hours
+			FROM time_logs tl
+			JOIN workspaces w ON w.id = tl.workspace_id
+			LEFT JOIN clients c ON c.id = w.client_id AND c.deleted_at IS NULL
+			WHERE` + analyticsTimeLogFilter + `
+			GROUP BY c.id, c.name
+			ORDER BY total_seconds DESC
+			LIMIT @limit`,
+	},
+	{
+		info: dto.AnalyticsQueryInfo{
+			Name:        "hours_by_task",
+			Description: "Tracked hours per task",
+			Columns:     []string{"task_id", "task_title", "workspace_id", "sessions", "total_seconds", "total_hours"},
+		},
+		sql: `SELECT t.id AS task_id, t.title AS task_title, t.workspace_id,
+				COUNT(tl.id) AS sessions, COALESCE(SUM(tl.duration), 0) AS total_seconds,
+				ROUND(COALESCE(SUM(tl.duration), 0) / 3600.0, 2) AS total_hours
+			FROM time_logs tl
+			JOIN tasks t ON t.id = tl.task_id
+			WHERE` + analyticsTimeLogFilter + `
+			GROUP BY t.id, t.title, t.workspace_id
+			ORDER BY total_seconds DESC
+			LIMIT @limit`,
+	},
+	{
+		info: dto.AnalyticsQueryInfo{
+			Name:        "hours_by_day",
+			Description: "Tracked hours, breaks and pauses, and active members and members on approved leave per day",
+			Columns:     []string{"day", "active_users", "users_on_leave", "sessions", "total_seconds", "total_hours", "break_seconds", "paused_seconds"},
+		},
+		sql: `SELECT TO_CHAR(DATE(tl.start_time), 'YYYY-MM-DD') AS day,
+				COUNT(DISTINCT tl.user_id) AS active_users,
+				(SELECT COUNT(DISTINCT lr.user_id)
+					FROM leave_requests lr
+					WHERE lr.organization_id = @org_id AND lr.status = 'approved'
+						AND DATE(tl.start_time) BETWEEN lr.start_date AND lr.end_date
+						AND (@scope_user_id = 0 OR lr.user_id = @scope_user_id)) AS users_on_leave,
+				COUNT(tl.id) AS sessions,
+				COALESCE(SUM(tl.duration), 0) AS total_seconds,
+				ROUND(COALESCE(SUM(tl.duration), 0) / 3600.0, 2) AS total_hours,
+				COALESCE(SUM(tl.break_total), 0) AS break_seconds,
+				COALESCE(SUM(tl.paused_total), 0) AS paused_seconds
+			FROM time_logs tl
+			WHERE` + analyticsTimeLogFilter + `
+			GROUP BY DATE(tl.start_time)
+			ORDER BY day
+			LIMIT @limit`,
+	},
+	{
+		info: dto.AnalyticsQueryInfo{
+			Name:        "screenshots_by_user",
+			Description: "Screenshot counts and storage per member",
+			Columns:     []string{"user_id", "user_name", "screenshots", "total_bytes"},
+		},
+		// Screenshots purged by retention are counted from the daily rollups
+		sql: `SELECT u.id AS user_id, TRIM(u.first_name || ' ' || u.last_name) AS user_name,
+				SUM(c.screenshots) AS screenshots, SUM(c.total_bytes) AS total_bytes
+			FROM (
+				SELECT s.user_id, COUNT(s.id) AS screenshots, COALESCE(SUM(s.file_size), 0) AS total_bytes
+				FROM screenshots s
+				WHERE s.organization_id = @org_id
+					AND s.deleted_at IS NULL
+					AND s.captured_at >= @start_date AND s.captured_at < @end_date
+					AND (@workspace_id = 0 OR s.workspace_id = @workspace_id)
+					AND (@client_id = 0 OR s.workspace_id IN (SELECT id FROM workspaces WHERE client_id = @client_id))
+					AND (@scope_user_id = 0 OR s.user_id = @scope_user_id)
+				GROUP BY s.user_id
+				UNION ALL
+				SELECT r.user_id, SUM(r.screenshot_count), SUM(r.total_bytes)
+				FROM screenshot_daily_rollups r
+				WHERE r.organization_id = @org_id
+					AND r.date >= DATE(@start_date) AND r.date < DATE(@end_date)
+					AND (@workspace_id = 0 OR r.workspace_id = @workspace_id)
+					AND (@client_id = 0 OR r.workspace_id IN (SELECT id FROM workspaces WHERE client_id = @client_id))
+					AND (@scope_user_id = 0 OR r.user_id = @scope_user_id)
+				GROUP BY r.user_id
+			) c
+			JOIN users u ON u.id = c.user_id
+			GROUP BY u.id, u.first_name, u.last_name
+			ORDER BY screenshots DESC
+			LIMIT @limit`,
+	},
+}
+
+func findAnalyticsQuery(name string) *analyticsQuery {
+	for i := range analyticsQueries {
+		if analyticsQueries[i].info.Name == name {
+			return &analyticsQueries[i]
+		}
+	}
+	return nil
+}
+
+func (s *analyticsService) ListQueries() []dto.AnalyticsQueryInfo {
+	infos := make([]dto.AnalyticsQueryInfo, 0, len(analyticsQueries))
+	for _, q := range analyticsQueries {
+		info := q.info
+		info.Params = analyticsDateParams
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+func (s *analyticsService) Run(orgID, userID uint, req *dto.AnalyticsQueryRequest) (*dto.AnalyticsQueryResponse, error) {
+	isMember, err := s.orgRepo.IsMember(orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, ErrAnalyticsAccessDenied
+	}
+
+	query := findAnalyticsQuery(req.Query)
+	if query == nil {
+		return nil, apperror.Validation(fmt.Sprintf("unknown analytics query %q", req.Query), nil)
+	}
+
+	args, limit, err := parseAnalyticsParams(req.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	// Row-level security: the organization always comes from the path, and
+	// regular members are restricted to their own records
+	isAdmin, err := s.orgRepo.IsAdmin(orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	scope := AnalyticsScopeOrganization
+	args["org_id"] = orgID
+	args["scope_user_id"] = uint(0)
+	if !isAdmin {
+		scope = AnalyticsScopeSelf
+		args["scope_user_id"] = userID
+	}
+
+	columns, rows, truncated, err := s.analyticsRepo.Query(query.sql, args, limit, analyticsTimeout)
+	if err != nil {
+		return nil, errors.New("failed to run analytics query")
+	}
+
+	return &dto.AnalyticsQueryResponse{
+		Query:     query.info.Name,
+		Scope:     scope,
+		Columns:   columns,
+		Rows:      rows,
+		RowCount:  len(rows),
+		Truncated: truncated,
+	}, nil
+}
+
+// parseAnalyticsParams validates the caller-supplied parameters. Unknown
+// parameters are rejected so typos do not silently widen a query.
+func parseAnalyticsParams(params map[string]string) (map[string]interface{}, int, error) {
+	allowed := make(map[string]bool, len(analyticsDateParams))
+	for _, p := range analyticsDateParams {
+		allowed[p.Name] = true
+	}
+	var unknown []string
+	for name := range params {
+		if !allowed[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, 0, apperror.Validation(fmt.Sprintf("unknown parameters: %v", unknown), nil)
+	}
+
+	now := time.Now().UTC()
+	endDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if v := params["end_date"]; v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return nil, 0, apperror.Validation("invalid end_date: expected YYYY-MM-DD", nil)
+		}
+		endDate = t
+	}
+	endDate = endDate.AddDate(0, 0, 1) // Exclusive upper bound
+
+	startDate := endDate.Add(-analyticsDefaultRange)
+	if v := params["start_date"]; v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return nil, 0, apperror.Validation("invalid start_date: expected YYYY-MM-DD", nil)
+		}
+		startDate = t
+	}
+
+	if !startDate.Before(endDate) {
+		return nil, 0, apperror.Validation("start_date must not be after end_date", nil)
+	}
+	if endDate.Sub(startDate) > analyticsMaxRange {
+		return nil, 0, apperror.Validation("date range cannot exceed 366 days", nil)
+	}
+
+	workspaceID := uint64(0)
+	if v := params["workspace_id"]; v != "" {
+		id, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return nil, 0, apperror.Validation("invalid workspace_id", nil)
+		}
+		workspaceID = id
+	}
+
+	clientID := uint64(0)
+	if v := params["client_id"]; v != "" {
+		id, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return nil, 0, apperror.Validation("invalid client_id", nil)
+		}
+		clientID = id
+	}
+
+	limit := analyticsDefaultLimit
+	if v := params["limit"]; v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return nil, 0, apperror.Validation("invalid limit", nil)
+		}
+		if n > analyticsMaxLimit {
+			n = analyticsMaxLimit
+		}
+		limit = n
+	}
+
+	return map[string]interface{}{
+		"start_date":   startDate,
+		"end_date":     endDate,
+		"workspace_id": uint(workspaceID),
+		"client_id":    uint(clientID),
+		"limit":        limit + 1, // One extra row detects truncation
+	}, limit, nil
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+)
+
+// Audit log statuses
+const (
+	AuditStatusSuccess = "success"
+	AuditStatusFailed  = "failed"
+)
+
+// AuditService handles audit trail recording and querying
+type AuditService interface {
+	Record(entry *models.AuditLog)
+	// RecordView records a read of sensitive data, which the mutation audit
+	// middleware does not see
+	RecordView(userID uint, entityType string, entityID uint, ipAddress, userAgent string, details map[string]interface{})
+	List(params *dto.AdminAuditLogListParams) (*dto.AdminAuditLogListResponse, error)
+}
+
+type auditService struct {
+	auditRepo repository.AuditLogRepository
+}
+
+// NewAuditService creates a new audit service
+func NewAuditService(auditRepo repository.AuditLogRepository) AuditService {
+	return &auditService{
+		auditRepo: auditRepo,
+	}
+}
+
+// Record persists an audit entry asynchronously so it never blocks or fails the request
+func (s *auditService) Record(entry *models.AuditLog) {
+	if entry.Details == "" {
+		entry.Details = "{}"
+	}
+
+	go func() {
+		if err := s.auditRepo.Create(entry); err != nil {
+			log.Printf("⚠️ Failed to write audit log (%s %s): %v", entry.Action, entry.EntityType, err)
+		}
+	}()
+}
+
+func (s *auditService) RecordView(userID uint, entityType string, entityID uint, ipAddress, userAgent string, details map[string]interface{}) {
+	detailsJSON, _ := json.Marshal(details)
+	s.Record(&models.AuditLog{
+		UserID:     &userID,
+		Action:     "view",
+		EntityType: entityType,
+		EntityID:   &entityID,
+		IPAddress:  ipAddress,
+		UserAgent:  userAgent,
+		Details:    string(detailsJSON),
+		Status:     AuditStatusSuccess,
+	})
+}
+
+func (s *auditService) List(params *dto.AdminAuditLogListParams) (*dto.AdminAuditLogListResponse, error) {
+	auditLogs, total, err := s.auditRepo.FindWithFilters(params)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.AdminAuditLogResponse, 0, len(auditLogs))
+	for i := range auditLogs {
+		responses = append(responses, s.toResponse(&auditLogs[i]))
+	}
+
+	totalPages := int((total + int64(params.PageSize) - 1) / int64(params.PageSize))
+
+	return &dto.AdminAuditLogListResponse{
+		AuditLogs: responses,
+		Pagination: dto.AdminPaginationResponse{
+			Page:       params.Page,
+			PageSize:   params.PageSize,
+			TotalItems: total,
+			TotalPages: totalPages,
+			HasNext:    params.Page < totalPages,
+			HasPrev:    params.Page > 1,
+		},
+	}, nil
+}
+
+func (s *auditService) toResponse(a *models.AuditLog) dto.AdminAuditLogResponse {
+	resp := dto.AdminAuditLogResponse{
+		ID:         a.ID,
+		UserID:     a.UserID,
+		Action:     a.Action,
+		EntityType: a.EntityType,
+		EntityID:   a.EntityID,
+		IPAddress:  a.IPAddress,
+		UserAgent:  a.UserAgent,
+		Status:     a.Status,
+		CreatedAt:  a.CreatedAt,
+	}
+
+	if a.Details != "" {
+		_ = json.Unmarshal([]byte(a.Details), &resp.Details)
+	}
+
+	if a.User != nil && a.User.ID > 0 {
+		resp.UserEmail = a.User.Email
+		resp.UserName = a.User.FirstName + " " + a.User.LastName
+	}
+
+	return resp
+}
@@ -1,10 +1,17 @@
 package service
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"log"
+	"strings"
 	"time"
 
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/config"
 	"github.com/beuphecan/remote-time-tracker/internal/dto"
 	"github.com/beuphecan/remote-time-tracker/internal/models"
 	"github.com/beuphecan/remote-time-tracker/internal/repository"
@@ -17,13 +24,27 @@ type AuthService interface {
 	Login(req *dto.LoginRequest) (*dto.LoginResponse, error)
 	RefreshToken(refreshToken string) (*dto.LoginResponse, error)
 	GetUserByID(userID uint) (*models.User, error)
+	// UpdateTimezone sets the timezone the user's own reports group days in
+	UpdateTimezone(userID uint, req *dto.UpdateTimezoneRequest) (*models.User, error)
+
+	// Password reset: RequestPasswordReset emails a single-use link and
+	// succeeds for unknown addresses so it cannot be used to probe accounts
+	RequestPasswordReset(req *dto.ForgotPasswordRequest) error
+	ResetPassword(req *dto.ResetPasswordRequest) error
 }
 
+// ErrInvalidResetToken is returned for unknown, used or expired reset tokens
+var ErrInvalidResetToken = apperror.Validation("invalid or expired password reset token", nil)
+
 type authService struct {
 	userRepo       repository.UserRepository
 	orgRepo        *repository.OrganizationRepository
 	invitationRepo *repository.InvitationRepository
 	workspaceRepo  *repository.WorkspaceRepository
+	webhookService WebhookService
+	resetRepo      repository.PasswordResetRepository
+	emailService   EmailService
+	resetTTL       time.Duration
 }
 
 // NewAuthService creates a new auth service
@@ -32,12 +53,19 @@ func NewAuthService(
 	orgRepo *repository.OrganizationRepository,
 	invitationRepo *repository.InvitationRepository,
 	workspaceRepo *repository.WorkspaceRepository,
+	webhookService WebhookService,
+	resetRepo repository.PasswordResetRepository,
+	emailService EmailService,
 ) AuthService {
 	return &authService{
 		userRepo:       userRepo,
 		orgRepo:        orgRepo,
 		invitationRepo: invitationRepo,
 		workspaceRepo:  workspaceRepo,
+		webhookService: webhookService,
+		resetRepo:      resetRepo,
+		emailService:   emailService,
+		resetTTL:       config.AppConfig.Email.PasswordResetTTL,
 	}
 }
 
@@ -61,16 +89,16 @@ func (s *authService) Register(req *dto.RegisterRequest) (*dto.LoginResponse, er
 
 	// Must have exactly one organization option
 	if optionCount == 0 {
-		return nil, errors.New("must either create a new organization, join with invite code, or accept an invitation")
+		return nil, apperror.Validation("must either create a new organization, join with invite code, or accept an invitation", nil)
 	}
 	if optionCount > 1 {
-		return nil, errors.New("can only use one organization option: create, invite code, or invitation token")
+		return nil, apperror.Validation("can only use one organization option: create, invite code, or invitation token", nil)
 	}
 
 	// Check if user already exists
 	existingUser, _ := s.userRepo.FindByEmail(req.Email)
 	if existingUser != nil {
-		return nil, errors.New("email already registered")
+		return nil, apperror.Conflict("email already registered")
 	}
 
 	// Validate organization options before creating user
@@ -81,23 +109,23 @@ func (s *authService) Register(req *dto.RegisterRequest) (*dto.LoginResponse, er
 		// Validate invitation token
 		invitation, err := s.invitationRepo.GetByToken(req.InvitationToken)
 		if err != nil {
-			return nil, errors.New("invalid or expired invitation token")
+			return nil, apperror.Validation("invalid or expired invitation token", nil)
 		}
 		if invitation.Status != models.InvitationStatusPending {
-			return nil, errors.New("invitation has already been used or expired")
+			return nil, apperror.Conflict("invitation has already been used or expired")
 		}
 		if invitation.Email != req.Email {
-			return nil, errors.New("invitation was sent to a different email address")
+			return nil, apperror.Forbidden("invitation was sent to a different email address")
 		}
 		pendingInvitation = invitation
 	} else if hasInviteCode {
 		// Validate organization invite code
 		org, err := s.orgRepo.GetByInviteCode(req.InviteCode)
 		if err != nil {
-			return nil, errors.New("invalid organization invite code")
+			return nil, apperror.Validation("invalid organization invite code", nil)
 		}
 		if !org.IsActive {
-			return nil, errors.New("organization is not active")
+			return nil, apperror.Conflict("organization is not active")
 		}
 		joinOrg = org
 	} else if hasCreateOrg {
@@ -108,7 +136,7 @@ func (s *authService) Register(req *dto.RegisterRequest) (*dto.LoginResponse, er
 		}
 		existing, _ := s.orgRepo.GetBySlug(slug)
 		if existing != nil {
-			return nil, fmt.Errorf("organization slug '%s' is already taken", slug)
+			return nil, apperror.Conflict(fmt.Sprintf("organization slug '%s' is already taken", slug))
 		}
 	}
 
@@ -170,12 +198,23 @@ func (s *authService) Register(req *dto.RegisterRequest) (*dto.LoginResponse, er
 	// Update last login
 	s.userRepo.UpdateLastLogin(user.ID)
 
+	s.emailService.SendWelcome(user)
+
+	ActivityFeedBroadcaster.Broadcast(ActivityEvent{
+		Action:     ActivityUserSignup,
+		UserID:     &user.ID,
+		EntityType: "user",
+		EntityID:   &user.ID,
+		Details:    map[string]interface{}{"email": user.Email},
+	})
+
 	return &dto.LoginResponse{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
 		ExpiresAt:    expiresAt,
 		User: dto.UserResponse{
 			ID:         user.ID,
+			UUID:       user.UUID,
 			Email:      user.Email,
 			FirstName:  user.FirstName,
 			LastName:   user.LastName,
@@ -280,16 +319,25 @@ func (s *authService) joinOrganization(user *models.User, org *models.Organizati
 		Role:           models.OrgRoleMember,
 	}
 
-	return s.orgRepo.AddMember(member)
+	if err := s.orgRepo.AddMember(member); err != nil {
+		return err
+	}
+
+	member.User = *user
+	s.webhookService.EmitMemberEvent(models.WebhookEventMemberJoined, *member, nil, webhookSourceRegistration, nil)
+	return nil
 }
 
-// acceptInvitation accepts a pending invitation
+// acceptInvitation accepts a pending invitation with the roles stored on it
 func (s *authService) acceptInvitation(user *models.User, invitation *models.Invitation) error {
 	// Add user to organization
 	orgMember := &models.OrganizationMember{
 		OrganizationID: invitation.OrganizationID,
 		UserID:         user.ID,
-		Role:           models.OrgRoleMember,
+		Role:           invitation.OrgRole,
+		InvitedBy:      &invitation.InvitedBy,
+		JoinedAt:       time.Now(),
+		IsActive:       true,
 	}
 
 	if err := s.orgRepo.AddMember(orgMember); err != nil {
@@ -297,11 +345,19 @@ func (s *authService) acceptInvitation(user *models.User, invitation *models.Inv
 	}
 
 	// If workspace is specified, add user to workspace
-	if invitation.WorkspaceID != nil && invitation.WorkspaceRoleID != nil {
+	if invitation.WorkspaceID != nil {
 		workspaceMember := &models.WorkspaceMember{
 			WorkspaceID:     *invitation.WorkspaceID,
 			UserID:          user.ID,
 			WorkspaceRoleID: invitation.WorkspaceRoleID,
+			AddedBy:         &invitation.InvitedBy,
+			JoinedAt:        time.Now(),
+			IsActive:        true,
+		}
+		if invitation.WorkspaceRoleID != nil {
+			if role, _ := s.workspaceRepo.GetRoleByID(*invitation.WorkspaceRoleID); role != nil {
+				workspaceMember.RoleName = role.Name
+			}
 		}
 
 		if err := s.workspaceRepo.AddMember(workspaceMember); err != nil {
@@ -310,24 +366,30 @@ func (s *authService) acceptInvitation(user *models.User, invitation *models.Inv
 	}
 
 	// Mark invitation as accepted
-	return s.invitationRepo.Accept(invitation.ID, user.ID)
+	if err := s.invitationRepo.Accept(invitation.ID, user.ID); err != nil {
+		return err
+	}
+
+	orgMember.User = *user
+	s.webhookService.EmitMemberEvent(models.WebhookEventMemberJoined, *orgMember, nil, webhookSourceRegistration, nil)
+	return nil
 }
 
 func (s *authService) Login(req *dto.LoginRequest) (*dto.LoginResponse, error) {
 	// Find user by email
 	user, err := s.userRepo.FindByEmail(req.Email)
 	if err != nil {
-		return nil, errors.New("invalid email or password")
+		return nil, apperror.Unauthorized("invalid email or password")
 	}
 
 	// Check if user is active
 	if !user.IsActive {
-		return nil, errors.New("user account is inactive")
+		return nil, apperror.Forbidden("user account is inactive")
 	}
 
 	// Check password
 	if err := utils.CheckPassword(req.Password, user.PasswordHash); err != nil {
-		return nil, errors.New("invalid email or password")
+		return nil, apperror.Unauthorized("invalid email or password")
 	}
 
 	// Generate tokens
@@ -344,12 +406,21 @@ func (s *authService) Login(req *dto.LoginRequest) (*dto.LoginResponse, error) {
 	// Update last login
 	s.userRepo.UpdateLastLogin(user.ID)
 
+	ActivityFeedBroadcaster.Broadcast(ActivityEvent{
+		Action:     ActivityUserLogin,
+		UserID:     &user.ID,
+		EntityType: "user",
+		EntityID:   &user.ID,
+		Details:    map[string]interface{}{"email": user.Email},
+	})
+
 	return &dto.LoginResponse{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
 		ExpiresAt:    expiresAt,
 		User: dto.UserResponse{
 			ID:          user.ID,
+			UUID:        user.UUID,
 			Email:       user.Email,
 			FirstName:   user.FirstName,
 			LastName:    user.LastName,
@@ -366,17 +437,22 @@ func (s *authService) RefreshToken(refreshToken string) (*dto.LoginResponse, err
 	// Validate refresh token
 	claims, err := utils.ValidateToken(refreshToken)
 	if err != nil {
-		return nil, errors.New("invalid refresh token")
+		return nil, apperror.Unauthorized("invalid refresh token")
+	}
+
+	// Impersonation sessions are short-lived by design and cannot be extended
+	if claims.IsImpersonation() {
+		return nil, apperror.Forbidden("impersonation tokens cannot be refreshed")
 	}
 
 	// Get user
 	user, err := s.userRepo.FindByID(claims.UserID)
 	if err != nil {
-		return nil, errors.New("user not found")
+		return nil, apperror.NotFound("user not found")
 	}
 
 	if !user.IsActive {
-		return nil, errors.New("user account is inactive")
+		return nil, apperror.Forbidden("user account is inactive")
 	}
 
 	// Generate new tokens
@@ -396,6 +472,7 @@ func (s *authService) RefreshToken(refreshToken string) (*dto.LoginResponse, err
 		ExpiresAt:    expiresAt,
 		User: dto.UserResponse{
 			ID:          user.ID,
+			UUID:        user.UUID,
 			Email:       user.Email,
 			FirstName:   user.FirstName,
 			LastName:    user.LastName,
@@ -412,12 +489,132 @@ func (s *authService) RefreshToken(refreshToken string) (*dto.LoginResponse, err
 func (s *authService) GetUserByID(userID uint) (*models.User, error) {
 	user, err := s.userRepo.FindByID(userID)
 	if err != nil {
-		return nil, errors.New("user not found")
+		return nil, apperror.NotFound("user not found")
 	}
 
 	if !user.IsActive {
-		return nil, errors.New("user account is inactive")
+		return nil, apperror.Forbidden("user account is inactive")
+	}
+
+	return user, nil
+}
+
+func (s *authService) UpdateTimezone(userID uint, req *dto.UpdateTimezoneRequest) (*models.User, error) {
+	user, err := s.GetUserByID(userID)
+	if err != nil {
+		return nil, err
 	}
 
+	user.Timezone = req.Timezone
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, err
+	}
 	return user, nil
 }
+
+func (s *authService) RequestPasswordReset(req *dto.ForgotPasswordRequest) error {
+	user, err := s.userRepo.FindByEmail(strings.TrimSpace(req.Email))
+	if err != nil || !user.IsActive || user.AnonymizedAt != nil {
+		return nil
+	}
+
+	token, err := generateResetToken()
+	if err != nil {
+		return err
+	}
+
+	// Only the newest link works
+	if err := s.resetRepo.DeleteForUser(user.ID); err != nil {
+		return errors.New("failed to create password reset token")
+	}
+	resetToken := &models.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: hashResetToken(token),
+		ExpiresAt: time.Now().Add(s.resetTTL),
+	}
+	if err := s.resetRepo.Create(resetToken); err != nil {
+		return errors.New("failed to create password reset token")
+	}
+
+	s.emailService.SendPasswordReset(user, token, s.resetTTL)
+	return nil
+}
+
+func (s *authService) ResetPassword(req *dto.ResetPasswordRequest) error {
+	resetToken, err := s.resetRepo.FindByHash(hashResetToken(req.Token))
+	if err != nil || resetToken.UsedAt != nil || time.Now().After(resetToken.ExpiresAt) {
+		return ErrInvalidResetToken
+	}
+
+	user, err := s.userRepo.FindByID(resetToken.UserID)
+	if err != nil || !user.IsActive {
+		return ErrInvalidResetToken
+	}
+
+	consumed, err := s.resetRepo.Consume(resetToken.ID, time.Now())
+	if err != nil {
+		return errors.New("failed to reset password")
+	}
+	if !consumed {
+		return ErrInvalidResetToken
+	}
+
+	hashedPassword, err := utils.HashPassword(req.NewPassword)
+	if err != nil {
+		return errors.New("failed to hash password")
+	}
+	user.PasswordHash = hashedPassword
+	if err := s.userRepo.Update(user); err != nil {
+		return errors.New("failed to reset password")
+	}
+
+	if err := s.resetRepo.DeleteForUser(user.ID); err != nil {
+		log.Printf("⚠️  Failed to clear password reset tokens for user %d: %v", user.ID, err)
+	}
+	return nil
+}
+
+// newLoginResponse issues tokens for a user logged in outside Login, such as
+// an invitee who signed up while accepting an invitation
+func newLoginResponse(user *models.User) (*dto.LoginResponse, error) {
+	accessToken, expiresAt, err := utils.GenerateToken(user.ID, user.Email, user.Role)
+	if err != nil {
+		return nil, errors.New("failed to generate access token")
+	}
+
+	refreshToken, _, err := utils.GenerateRefreshToken(user.ID, user.Email, user.Role)
+	if err != nil {
+		return nil, errors.New("failed to generate refresh token")
+	}
+
+	return &dto.LoginResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresAt:    expiresAt,
+		User: dto.UserResponse{
+			ID:          user.ID,
+			UUID:        user.UUID,
+			Email:       user.Email,
+			FirstName:   user.FirstName,
+			LastName:    user.LastName,
+			Role:        user.Role,
+			SystemRole:  user.SystemRole,
+			IsActive:    user.IsActive,
+			LastLoginAt: user.LastLoginAt,
+			CreatedAt:   user.CreatedAt,
+		},
+	}, nil
+}
+
+func generateResetToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.New("failed to generate password reset token")
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func hashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
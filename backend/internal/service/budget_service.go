@@ -0,0 +1,312 @@
+package service
+
+import (
+	"context"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/money"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+)
+
+var defaultBudgetThresholds = []int{80, 100}
+
+var (
+	// ErrBudgetAmountRequired is returned when a budget is set without a positive amount
+	ErrBudgetAmountRequired = apperror.Validation("budget amount must be greater than zero", nil)
+	// ErrBudgetHourlyRateRequired is returned for money budgets on workspaces without an hourly rate
+	ErrBudgetHourlyRateRequired = apperror.Validation("set the workspace hourly rate before using a money budget", nil)
+)
+
+// BudgetService tracks workspace budgets, in hours or money at the workspace
+// hourly rate, against approved time logs
+type BudgetService interface {
+	GetWorkspaceBudget(workspaceID, userID uint) (*dto.WorkspaceBudgetResponse, error)
+	UpdateWorkspaceBudget(workspaceID, userID uint, req *dto.UpdateWorkspaceBudgetRequest) (*dto.WorkspaceBudgetResponse, error)
+
+	// RollupAll recomputes every budget's consumption and alerts workspace
+	// admins and webhooks once per threshold crossed (scheduled job)
+	RollupAll(ctx context.Context) error
+}
+
+type budgetService struct {
+	timeLogRepo         repository.TimeLogRepository
+	workspaceRepo       *repository.WorkspaceRepository
+	orgRepo             *repository.OrganizationRepository
+	workspaceService    WorkspaceService
+	notificationService NotificationService
+	webhookService      WebhookService
+}
+
+// NewBudgetService creates a new budget service
+func NewBudgetService(
+	timeLogRepo repository.TimeLogRepository,
+	workspaceRepo *repository.WorkspaceRepository,
+	orgRepo *repository.OrganizationRepository,
+	workspaceService WorkspaceService,
+	notificationService NotificationService,
+	webhookService WebhookService,
+) BudgetService {
+	return &budgetService{
+		timeLogRepo:         timeLogRepo,
+		workspaceRepo:       workspaceRepo,
+		orgRepo:             orgRepo,
+		workspaceService:    workspaceService,
+		notificationService: notificationService,
+		webhookService:      webhookService,
+	}
+}
+
+func (s *budgetService) GetWorkspaceBudget(workspaceID, userID uint) (*dto.WorkspaceBudgetResponse, error) {
+	canView, err := s.workspaceService.HasPermission(workspaceID, userID, models.PermReportsView)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, apperror.Forbidden("access denied: you cannot view the budget of this workspace")
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildBudget(workspace)
+}
+
+func (s *budgetService) UpdateWorkspaceBudget(workspaceID, userID uint, req *dto.UpdateWorkspaceBudgetRequest) (*dto.WorkspaceBudgetResponse, error) {
+	workspace, err := s.workspaceRepo.GetByID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	canManage, err := s.workspaceService.HasPermission(workspaceID, userID, models.PermSettingsManage)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, apperror.Forbidden("access denied: you cannot change the budget of this workspace")
+	}
+
+	budgetType, amount := workspace.BudgetType, workspace.BudgetAmount
+	if req.BudgetType != nil {
+		budgetType = *req.BudgetType
+		if budgetType == "none" {
+			budgetType = ""
+		}
+	}
+	if req.BudgetAmount != nil {
+		amount = *req.BudgetAmount
+	}
+	if budgetType == "" {
+		amount = 0
+	} else if amount <= 0 {
+		return nil, ErrBudgetAmountRequired
+	}
+	if budgetType == models.BudgetTypeMoney && workspace.HourlyRateMinor <= 0 {
+		return nil, ErrBudgetHourlyRateRequired
+	}
+
+	// A new budget starts without alerts or a rollup
+	if budgetType != workspace.BudgetType || amount != workspace.BudgetAmount {
+		workspace.BudgetAlertedPercent = 0
+		workspace.BudgetConsumed = 0
+		workspace.BudgetRolledUpAt = nil
+	}
+	workspace.BudgetType = budgetType
+	workspace.BudgetAmount = amount
+	if req.Thresholds != nil {
+		workspace.BudgetThresholds = formatBudgetThresholds(req.Thresholds)
+	}
+
+	if err := s.workspaceRepo.Update(workspace); err != nil {
+		return nil, err
+	}
+	return s.buildBudget(workspace)
+}
+
+// buildBudget computes the workspace's consumption and burn-down from its approved time logs
+func (s *budgetService) buildBudget(workspace *models.Workspace) (*dto.WorkspaceBudgetResponse, error) {
+	response := &dto.WorkspaceBudgetResponse{
+		WorkspaceID:    workspace.ID,
+		BudgetType:     workspace.BudgetType,
+		BudgetAmount:   workspace.BudgetAmount,
+		HourlyRate:     money.ToMajor(workspace.HourlyRateMinor, workspace.Currency),
+		Currency:       workspace.Currency,
+		Thresholds:     parseBudgetThresholds(workspace.BudgetThresholds),
+		AlertedPercent: workspace.BudgetAlertedPercent,
+		RolledUpAt:     workspace.BudgetRolledUpAt,
+		BurnDown:       []dto.BudgetBurnDownPoint{},
+	}
+
+	days, err := s.timeLogRepo.GetWorkspaceApprovedDaily(workspace.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var seconds int64
+	for _, day := range days {
+		seconds += day.Seconds
+		response.BurnDown = append(response.BurnDown, dto.BudgetBurnDownPoint{
+			Date:      day.Day,
+			Hours:     secondsToHours(day.Seconds),
+			Consumed:  budgetConsumption(workspace, day.Seconds),
+			Remaining: roundBudget(workspace.BudgetAmount - budgetConsumption(workspace, seconds)),
+		})
+	}
+
+	response.ConsumedHours = secondsToHours(seconds)
+	response.Consumed = budgetConsumption(workspace, seconds)
+	response.Remaining = roundBudget(workspace.BudgetAmount - response.Consumed)
+	response.PercentUsed = budgetPercent(workspace.BudgetAmount, response.Consumed)
+	return response, nil
+}
+
+// ============================================================================
+// SCHEDULED JOBS
+// ============================================================================
+
+func (s *budgetService) RollupAll(ctx context.Context) error {
+	workspaces, err := s.workspaceRepo.GetWithBudget()
+	if err != nil {
+		return err
+	}
+
+	alerts := 0
+	for i := range workspaces {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		alerted, err := s.rollup(&workspaces[i])
+		if err != nil {
+			log.Printf("⚠️  Failed to roll up budget of workspace %d: %v", workspaces[i].ID, err)
+			continue
+		}
+		if alerted {
+			alerts++
+		}
+	}
+
+	log.Printf("✅ Rolled up %d workspace budgets (%d alerts)", len(workspaces), alerts)
+	return nil
+}
+
+// rollup stores a workspace's consumption and alerts the highest threshold
+// crossed since the last alert; it reports whether an alert was sent
+func (s *budgetService) rollup(workspace *models.Workspace) (bool, error) {
+	days, err := s.timeLogRepo.GetWorkspaceApprovedDaily(workspace.ID)
+	if err != nil {
+		return false, err
+	}
+	var seconds int64
+	for _, day := range days {
+		seconds += day.Seconds
+	}
+
+	consumed := budgetConsumption(workspace, seconds)
+	percent := budgetPercent(workspace.BudgetAmount, consumed)
+
+	crossed := 0
+	for _, threshold := range parseBudgetThresholds(workspace.BudgetThresholds) {
+		if percent >= float64(threshold) {
+			crossed = threshold
+		}
+	}
+
+	alerted := workspace.BudgetAlertedPercent
+	if err := s.workspaceRepo.UpdateBudgetRollup(workspace.ID, consumed, max(alerted, crossed), time.Now()); err != nil {
+		return false, err
+	}
+	if crossed <= alerted {
+		return false, nil
+	}
+
+	s.alert(workspace, crossed, consumed, percent)
+	return true, nil
+}
+
+func (s *budgetService) alert(workspace *models.Workspace, threshold int, consumed, percent float64) {
+	s.notificationService.NotifyBudgetThreshold(workspace, threshold, percent)
+
+	ActivityFeedBroadcaster.Broadcast(ActivityEvent{
+		Action:     ActivityBudgetThreshold,
+		UserID:     &workspace.AdminID,
+		EntityType: "workspace",
+		EntityID:   &workspace.ID,
+		Details: map[string]interface{}{
+			"threshold":    threshold,
+			"percent_used": percent,
+			"budget_type":  workspace.BudgetType,
+		},
+	})
+
+	admin, err := s.orgRepo.GetMemberWithUser(workspace.OrganizationID, workspace.AdminID)
+	if err != nil {
+		return
+	}
+	s.webhookService.EmitBudgetEvent(*admin, dto.WebhookBudgetInfo{
+		WorkspaceID:   workspace.ID,
+		WorkspaceName: workspace.Name,
+		BudgetType:    workspace.BudgetType,
+		BudgetAmount:  workspace.BudgetAmount,
+		Currency:      workspace.Currency,
+		Consumed:      consumed,
+		PercentUsed:   percent,
+		Threshold:     threshold,
+	})
+}
+
+// budgetConsumption converts tracked seconds to the budget's unit
+func budgetConsumption(workspace *models.Workspace, seconds int64) float64 {
+	hours := float64(seconds) / 3600
+	if workspace.BudgetType == models.BudgetTypeMoney {
+		return money.Round(hours*money.ToMajor(workspace.HourlyRateMinor, workspace.Currency), workspace.Currency)
+	}
+	return roundBudget(hours)
+}
+
+func budgetPercent(amount, consumed float64) float64 {
+	if amount <= 0 {
+		return 0
+	}
+	return math.Round(consumed/amount*10000) / 100
+}
+
+func roundBudget(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// parseBudgetThresholds parses stored thresholds ("80,100"), ascending
+func parseBudgetThresholds(raw string) []int {
+	thresholds := []int{}
+	for _, part := range strings.Split(raw, ",") {
+		if n, err := strconv.Atoi(strings.TrimSpace(part)); err == nil && n > 0 {
+			thresholds = append(thresholds, n)
+		}
+	}
+	if len(thresholds) == 0 {
+		return append(thresholds, defaultBudgetThresholds...)
+	}
+	sort.Ints(thresholds)
+	return thresholds
+}
+
+func formatBudgetThresholds(thresholds []int) string {
+	sorted := append([]int(nil), thresholds...)
+	sort.Ints(sorted)
+
+	parts := make([]string, 0, len(sorted))
+	for i, t := range sorted {
+		if i > 0 && t == sorted[i-1] {
+			continue
+		}
+		parts = append(parts, strconv.Itoa(t))
+	}
+	return strings.Join(parts, ",")
+}
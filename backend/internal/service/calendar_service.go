@@ -0,0 +1,457 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/config"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/format"
+	"github.com/beuphecan/remote-time-tracker/internal/googlecalendar"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	// Calendar apps refetch the whole feed, so it only covers recent time logs
+	calendarFeedWindow    = 90 * 24 * time.Hour
+	calendarFeedMaxEvents = 2000
+
+	googleOAuthStateTTL   = 10 * time.Minute
+	googleCalendarBatch   = 100
+	defaultGoogleCalendar = "primary"
+)
+
+// ErrCalendarFeedNotFound is returned when a feed token is unknown or the user has no feed
+var ErrCalendarFeedNotFound = apperror.NotFound("calendar feed not found")
+
+// ErrGoogleCalendarNotConnected is returned when a user has not connected Google Calendar
+var ErrGoogleCalendarNotConnected = apperror.NotFound("Google Calendar is not connected")
+
+// CalendarService shows users their tracked time in their calendar, through a
+// private ICS feed and by pushing completed time logs to Google Calendar
+type CalendarService interface {
+	// ICS feed of the user's completed time logs
+	GetFeed(userID uint) (*dto.CalendarFeedResponse, error)
+	// RotateFeed enables the feed, or replaces its URL when already enabled
+	RotateFeed(userID uint) (*dto.CalendarFeedResponse, error)
+	DisableFeed(userID uint) error
+	// RenderFeed renders the feed with token as an iCalendar document
+	RenderFeed(token string) ([]byte, error)
+
+	// Google Calendar: GoogleOAuthURL sends the user to Google, which
+	// redirects to the frontend with a code and state that CompleteGoogleOAuth exchanges
+	GetGoogle(userID uint) (*dto.GoogleCalendarResponse, error)
+	GoogleOAuthURL(userID uint) (*dto.GoogleCalendarOAuthURLResponse, error)
+	CompleteGoogleOAuth(ctx context.Context, userID uint, req *dto.CompleteGoogleCalendarOAuthRequest) (*dto.GoogleCalendarResponse, error)
+	DisconnectGoogle(userID uint) error
+
+	// PushAll pushes completed time logs to every active Google Calendar
+	// connection (scheduled job)
+	PushAll(ctx context.Context) error
+}
+
+type calendarService struct {
+	calendarRepo repository.CalendarRepository
+	google       *googlecalendar.Client
+	googleOn     bool
+	signingKey   []byte
+}
+
+// NewCalendarService creates a new calendar service
+func NewCalendarService(calendarRepo repository.CalendarRepository) CalendarService {
+	cfg := config.AppConfig.Google
+	return &calendarService{
+		calendarRepo: calendarRepo,
+		google:       googlecalendar.NewClient(cfg.ClientID, cfg.ClientSecret, cfg.RedirectURL, cfg.Timeout),
+		googleOn:     cfg.ClientID != "",
+		signingKey:   []byte(config.AppConfig.JWT.Secret),
+	}
+}
+
+// ============================================================================
+// ICS FEED
+// ============================================================================
+
+func (s *calendarService) GetFeed(userID uint) (*dto.CalendarFeedResponse, error) {
+	feed, err := s.calendarRepo.FindFeedByUser(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &dto.CalendarFeedResponse{Enabled: false}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toCalendarFeedResponse(feed), nil
+}
+
+func (s *calendarService) RotateFeed(userID uint) (*dto.CalendarFeedResponse, error) {
+	feed, err := s.calendarRepo.FindFeedByUser(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		feed = &models.CalendarFeed{UserID: userID}
+	} else if err != nil {
+		return nil, err
+	}
+
+	token, err := generateCalendarToken()
+	if err != nil {
+		return nil, err
+	}
+	feed.Token = token
+	feed.LastFetchedAt = nil
+
+	if err := s.calendarRepo.SaveFeed(feed); err != nil {
+		return nil, errors.New("failed to save calendar feed")
+	}
+	return toCalendarFeedResponse(feed), nil
+}
+
+func (s *calendarService) DisableFeed(userID uint) error {
+	feed, err := s.calendarRepo.FindFeedByUser(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrCalendarFeedNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return s.calendarRepo.DeleteFeed(feed.ID)
+}
+
+func (s *calendarService) RenderFeed(token string) ([]byte, error) {
+	if !strings.HasPrefix(token, "cal_") {
+		return nil, ErrCalendarFeedNotFound
+	}
+	feed, err := s.calendarRepo.FindFeedByToken(token)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCalendarFeedNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	logs, err := s.calendarRepo.FindFeedTimeLogs(feed.UserID, now.Add(-calendarFeedWindow), calendarFeedMaxEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.calendarRepo.TouchFeed(feed.ID, now); err != nil {
+		log.Printf("⚠️  Failed to record fetch of calendar feed %d: %v", feed.ID, err)
+	}
+
+	var buf bytes.Buffer
+	writeICSLine(&buf, "BEGIN:VCALENDAR")
+	writeICSLine(&buf, "VERSION:2.0")
+	writeICSLine(&buf, "PRODID:-//Remote Time Tracker//Tracked Time//EN")
+	writeICSLine(&buf, "CALSCALE:GREGORIAN")
+	writeICSLine(&buf, "METHOD:PUBLISH")
+	writeICSLine(&buf, "X-WR-CALNAME:Tracked time")
+	writeICSLine(&buf, "REFRESH-INTERVAL;VALUE=DURATION:PT1H")
+	writeICSLine(&buf, "X-PUBLISHED-TTL:PT1H")
+	for i := range logs {
+		timeLog := &logs[i]
+		writeICSLine(&buf, "BEGIN:VEVENT")
+		writeICSLine(&buf, fmt.Sprintf("UID:timelog-%d@remote-time-tracker", timeLog.ID))
+		writeICSLine(&buf, "DTSTAMP:"+icsTime(timeLog.UpdatedAt))
+		writeICSLine(&buf, "DTSTART:"+icsTime(timeLog.StartTime))
+		writeICSLine(&buf, "DTEND:"+icsTime(*timeLog.EndTime))
+		writeICSLine(&buf, "SUMMARY:"+icsEscape(timeLogEventSummary(timeLog)))
+		writeICSLine(&buf, "DESCRIPTION:"+icsEscape(timeLogEventDescription(timeLog)))
+		writeICSLine(&buf, "TRANSP:TRANSPARENT")
+		writeICSLine(&buf, "END:VEVENT")
+	}
+	writeICSLine(&buf, "END:VCALENDAR")
+	return buf.Bytes(), nil
+}
+
+// writeICSLine writes a content line, folding it at 75 octets as RFC 5545
+// requires without splitting UTF-8 characters
+func writeICSLine(buf *bytes.Buffer, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space, which counts toward the limit
+		limit = 74
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
+
+func icsTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+func icsEscape(s string) string {
+	return icsEscaper.Replace(s)
+}
+
+func timeLogEventSummary(timeLog *models.TimeLog) string {
+	if title := strings.TrimSpace(timeLog.TaskTitle); title != "" {
+		return title
+	}
+	return "Tracked time"
+}
+
+func timeLogEventDescription(timeLog *models.TimeLog) string {
+	lines := []string{"Tracked: " + format.Duration(timeLog.Duration, format.DefaultLocale)}
+	if timeLog.Workspace != nil {
+		lines = append(lines, "Workspace: "+timeLog.Workspace.Name)
+	}
+	if notes := strings.TrimSpace(timeLog.Notes); notes != "" {
+		lines = append(lines, "", notes)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func generateCalendarToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.New("failed to generate calendar feed token")
+	}
+	return "cal_" + hex.EncodeToString(b), nil
+}
+
+// ============================================================================
+// GOOGLE CALENDAR
+// ============================================================================
+
+func (s *calendarService) findGoogle(userID uint) (*models.GoogleCalendarConnection, error) {
+	conn, err := s.calendarRepo.FindGoogleByUser(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrGoogleCalendarNotConnected
+	}
+	return conn, err
+}
+
+func (s *calendarService) GetGoogle(userID uint) (*dto.GoogleCalendarResponse, error) {
+	conn, err := s.findGoogle(userID)
+	if err != nil {
+		return nil, err
+	}
+	response := toGoogleCalendarResponse(conn)
+	return &response, nil
+}
+
+func (s *calendarService) GoogleOAuthURL(userID uint) (*dto.GoogleCalendarOAuthURLResponse, error) {
+	if !s.googleOn {
+		return nil, apperror.Unavailable("Google Calendar is not configured")
+	}
+	state := s.oauthState(userID, time.Now().Add(googleOAuthStateTTL).Unix())
+	return &dto.GoogleCalendarOAuthURLResponse{URL: s.google.AuthCodeURL(state)}, nil
+}
+
+// oauthState binds a Google consent to the user that started it, until
+// expires (unix seconds)
+func (s *calendarService) oauthState(userID uint, expires int64) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	fmt.Fprintf(mac, "google-calendar-oauth:%d:%d", userID, expires)
+	return fmt.Sprintf("%d.%s", expires, hex.EncodeToString(mac.Sum(nil)))
+}
+
+func (s *calendarService) validOAuthState(userID uint, state string) bool {
+	expiresPart, _, ok := strings.Cut(state, ".")
+	if !ok {
+		return false
+	}
+	expires, err := strconv.ParseInt(expiresPart, 10, 64)
+	if err != nil || time.Now().Unix() > expires {
+		return false
+	}
+	return hmac.Equal([]byte(state), []byte(s.oauthState(userID, expires)))
+}
+
+func (s *calendarService) CompleteGoogleOAuth(ctx context.Context, userID uint, req *dto.CompleteGoogleCalendarOAuthRequest) (*dto.GoogleCalendarResponse, error) {
+	if !s.googleOn {
+		return nil, apperror.Unavailable("Google Calendar is not configured")
+	}
+	if !s.validOAuthState(userID, req.State) {
+		return nil, apperror.Validation("invalid or expired Google Calendar consent state", nil)
+	}
+
+	token, err := s.google.Exchange(ctx, req.Code)
+	if err != nil {
+		log.Printf("⚠️  Google Calendar code exchange failed for user %d: %v", userID, err)
+		return nil, apperror.Validation("Google rejected the authorization code", nil)
+	}
+	if token.RefreshToken == "" {
+		return nil, apperror.Validation("Google did not grant offline access; remove the app from your Google account permissions and connect again", nil)
+	}
+
+	now := time.Now().UTC()
+	conn, err := s.findGoogle(userID)
+	if errors.Is(err, ErrGoogleCalendarNotConnected) {
+		// Time tracked before connecting is not pushed
+		conn = &models.GoogleCalendarConnection{UserID: userID, PushSince: now}
+	} else if err != nil {
+		return nil, err
+	}
+
+	conn.CalendarID = strings.TrimSpace(req.CalendarID)
+	if conn.CalendarID == "" {
+		conn.CalendarID = defaultGoogleCalendar
+	}
+	conn.RefreshToken = token.RefreshToken
+	conn.AccessToken = token.AccessToken
+	conn.TokenExpiry = token.Expiry(now)
+	conn.IsActive = true
+	conn.LastError = ""
+
+	if err := s.calendarRepo.SaveGoogle(conn); err != nil {
+		return nil, errors.New("failed to save Google Calendar connection")
+	}
+
+	response := toGoogleCalendarResponse(conn)
+	return &response, nil
+}
+
+func (s *calendarService) DisconnectGoogle(userID uint) error {
+	conn, err := s.findGoogle(userID)
+	if err != nil {
+		return err
+	}
+	return s.calendarRepo.DeleteGoogle(conn.ID)
+}
+
+func (s *calendarService) PushAll(ctx context.Context) error {
+	if !s.googleOn {
+		return nil
+	}
+
+	conns, err := s.calendarRepo.FindActiveGoogle()
+	if err != nil {
+		return err
+	}
+
+	for i := range conns {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		conn := &conns[i]
+		pushed, pushErr := s.push(ctx, conn)
+
+		errText := ""
+		if pushErr != nil {
+			errText = truncateText(pushErr.Error(), 1000)
+			log.Printf("⚠️  Google Calendar push failed for user %d: %v", conn.UserID, pushErr)
+		} else if pushed > 0 {
+			log.Printf("✅ Pushed %d time logs to Google Calendar for user %d", pushed, conn.UserID)
+		}
+		if err := s.calendarRepo.SetGooglePushResult(conn.ID, time.Now().UTC(), errText); err != nil {
+			log.Printf("⚠️  Failed to record Google Calendar push result for user %d: %v", conn.UserID, err)
+		}
+	}
+	return nil
+}
+
+// push pushes the connection's pending time logs, stopping at the first
+// failure so the rest are retried in order by the next run
+func (s *calendarService) push(ctx context.Context, conn *models.GoogleCalendarConnection) (int, error) {
+	logs, err := s.calendarRepo.FindPendingGoogleTimeLogs(conn, googleCalendarBatch)
+	if err != nil || len(logs) == 0 {
+		return 0, err
+	}
+
+	if err := s.refreshToken(ctx, conn); err != nil {
+		return 0, s.revokeOn(conn, err)
+	}
+
+	pushed := 0
+	for i := range logs {
+		timeLog := &logs[i]
+		eventID, err := s.google.InsertEvent(ctx, conn.AccessToken, conn.CalendarID, googlecalendar.Event{
+			Summary:     timeLogEventSummary(timeLog),
+			Description: timeLogEventDescription(timeLog),
+			Start:       googlecalendar.NewEventTime(timeLog.StartTime),
+			End:         googlecalendar.NewEventTime(*timeLog.EndTime),
+		})
+		if err != nil {
+			return pushed, s.revokeOn(conn, err)
+		}
+
+		if err := s.calendarRepo.RecordGoogleEvent(&models.GoogleCalendarEvent{
+			ConnectionID: conn.ID,
+			TimeLogID:    timeLog.ID,
+			EventID:      eventID,
+		}); err != nil {
+			return pushed, err
+		}
+		pushed++
+	}
+	return pushed, nil
+}
+
+// refreshToken renews the access token when it has expired
+func (s *calendarService) refreshToken(ctx context.Context, conn *models.GoogleCalendarConnection) error {
+	now := time.Now().UTC()
+	if conn.AccessToken != "" && now.Before(conn.TokenExpiry) {
+		return nil
+	}
+
+	token, err := s.google.Refresh(ctx, conn.RefreshToken)
+	if err != nil {
+		return err
+	}
+	conn.AccessToken = token.AccessToken
+	conn.TokenExpiry = token.Expiry(now)
+	return s.calendarRepo.SaveGoogle(conn)
+}
+
+// revokeOn deactivates the connection when Google no longer accepts its
+// grant; the user has to connect again
+func (s *calendarService) revokeOn(conn *models.GoogleCalendarConnection, err error) error {
+	var apiErr *googlecalendar.APIError
+	if errors.As(err, &apiErr) && apiErr.Revoked() {
+		conn.IsActive = false
+		conn.AccessToken = ""
+		if saveErr := s.calendarRepo.SaveGoogle(conn); saveErr != nil {
+			log.Printf("⚠️  Failed to deactivate Google Calendar connection of user %d: %v", conn.UserID, saveErr)
+		}
+		return apperror.Validation("Google revoked calendar access; connect Google Calendar again", nil)
+	}
+	return err
+}
+
+// ============================================================================
+// HELPERS
+// ============================================================================
+
+func toCalendarFeedResponse(feed *models.CalendarFeed) *dto.CalendarFeedResponse {
+	createdAt := feed.CreatedAt
+	return &dto.CalendarFeedResponse{
+		Enabled:       true,
+		URL:           "/api/v1/calendar/" + feed.Token + ".ics",
+		LastFetchedAt: feed.LastFetchedAt,
+		CreatedAt:     &createdAt,
+	}
+}
+
+func toGoogleCalendarResponse(conn *models.GoogleCalendarConnection) dto.GoogleCalendarResponse {
+	return dto.GoogleCalendarResponse{
+		ID:           conn.ID,
+		CalendarID:   conn.CalendarID,
+		PushSince:    conn.PushSince,
+		IsActive:     conn.IsActive,
+		LastPushedAt: conn.LastPushedAt,
+		LastError:    conn.LastError,
+		CreatedAt:    conn.CreatedAt,
+	}
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+)
+
+// ErrCaptchaFailed is returned when a CAPTCHA token is missing or rejected
+var ErrCaptchaFailed = apperror.Validation("captcha verification failed", nil)
+
+// CaptchaService verifies CAPTCHA tokens with a siteverify endpoint. hCaptcha,
+// reCAPTCHA and Cloudflare Turnstile share the same request format.
+type CaptchaService interface {
+	// Enabled reports whether a verify endpoint is configured
+	Enabled() bool
+	Verify(ctx context.Context, token, remoteIP string) error
+}
+
+type captchaService struct {
+	verifyURL  string
+	secret     string
+	httpClient *http.Client
+}
+
+// NewCaptchaService creates a CAPTCHA verifier; an empty verifyURL disables it
+func NewCaptchaService(verifyURL, secret string) CaptchaService {
+	return &captchaService{
+		verifyURL:  verifyURL,
+		secret:     secret,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (s *captchaService) Enabled() bool {
+	return s.verifyURL != ""
+}
+
+func (s *captchaService) Verify(ctx context.Context, token, remoteIP string) error {
+	if token == "" {
+		return ErrCaptchaFailed
+	}
+
+	form := url.Values{"secret": {s.secret}, "response": {token}}
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.verifyURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("captcha verification unavailable: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Success bool `json:"success"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("captcha verification unavailable: %w", err)
+	}
+	if !result.Success {
+		return ErrCaptchaFailed
+	}
+	return nil
+}
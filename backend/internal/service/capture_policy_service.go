@@ -0,0 +1,238 @@
+package service
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+)
+
+// CapturePolicyService manages the organization's sensitive-window capture
+// exclusion rules and the capture policy agents fetch
+type CapturePolicyService interface {
+	// Agent policy
+	GetPolicy(orgID, userID uint) (*dto.CapturePolicyResponse, error)
+
+	// Rule management (owner/admin)
+	ListRules(orgID, userID uint) ([]dto.CaptureExclusionRuleResponse, error)
+	CreateRule(orgID, userID uint, req *dto.CreateCaptureExclusionRuleRequest) (*dto.CaptureExclusionRuleResponse, error)
+	UpdateRule(orgID, ruleID, userID uint, req *dto.UpdateCaptureExclusionRuleRequest) (*dto.CaptureExclusionRuleResponse, error)
+	DeleteRule(orgID, ruleID, userID uint) error
+
+	// ActiveRules returns the rules uploaded screenshots are checked against
+	ActiveRules(orgID uint) ([]models.CaptureExclusionRule, error)
+}
+
+type capturePolicyService struct {
+	ruleRepo repository.CaptureExclusionRepository
+	orgRepo  *repository.OrganizationRepository
+}
+
+// NewCapturePolicyService creates a new capture policy service
+func NewCapturePolicyService(
+	ruleRepo repository.CaptureExclusionRepository,
+	orgRepo *repository.OrganizationRepository,
+) CapturePolicyService {
+	return &capturePolicyService{
+		ruleRepo: ruleRepo,
+		orgRepo:  orgRepo,
+	}
+}
+
+func (s *capturePolicyService) requireAdmin(orgID, userID uint) error {
+	isAdmin, err := s.orgRepo.IsAdmin(orgID, userID)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return apperror.Forbidden("access denied: only admins can manage capture exclusions")
+	}
+	return nil
+}
+
+// ============================================================================
+// AGENT POLICY
+// ============================================================================
+
+func (s *capturePolicyService) GetPolicy(orgID, userID uint) (*dto.CapturePolicyResponse, error) {
+	isMember, err := s.orgRepo.IsMember(orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, apperror.Forbidden("access denied: not a member of this organization")
+	}
+
+	rules, err := s.ruleRepo.FindActiveByOrg(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.CapturePolicyResponse{
+		OrganizationID: orgID,
+		Exclusions:     toCaptureExclusionResponses(rules),
+	}, nil
+}
+
+func (s *capturePolicyService) ActiveRules(orgID uint) ([]models.CaptureExclusionRule, error) {
+	return s.ruleRepo.FindActiveByOrg(orgID)
+}
+
+// ============================================================================
+// RULE MANAGEMENT
+// ============================================================================
+
+func (s *capturePolicyService) ListRules(orgID, userID uint) ([]dto.CaptureExclusionRuleResponse, error) {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return nil, err
+	}
+
+	rules, err := s.ruleRepo.FindByOrg(orgID)
+	if err != nil {
+		return nil, err
+	}
+	return toCaptureExclusionResponses(rules), nil
+}
+
+func (s *capturePolicyService) CreateRule(orgID, userID uint, req *dto.CreateCaptureExclusionRuleRequest) (*dto.CaptureExclusionRuleResponse, error) {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return nil, err
+	}
+
+	pattern := strings.TrimSpace(req.Pattern)
+	if err := validateCapturePattern(pattern, req.IsRegex); err != nil {
+		return nil, err
+	}
+
+	rule := &models.CaptureExclusionRule{
+		OrganizationID: orgID,
+		Name:           strings.TrimSpace(req.Name),
+		MatchType:      req.MatchType,
+		Pattern:        pattern,
+		IsRegex:        req.IsRegex,
+		IsActive:       true,
+		CreatedBy:      userID,
+	}
+	if err := s.ruleRepo.Create(rule); err != nil {
+		return nil, err
+	}
+
+	response := toCaptureExclusionResponse(rule)
+	return &response, nil
+}
+
+func (s *capturePolicyService) UpdateRule(orgID, ruleID, userID uint, req *dto.UpdateCaptureExclusionRuleRequest) (*dto.CaptureExclusionRuleResponse, error) {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return nil, err
+	}
+
+	rule, err := s.ruleRepo.FindByID(orgID, ruleID)
+	if err != nil {
+		return nil, apperror.NotFound("capture exclusion rule not found")
+	}
+
+	if req.Name != nil {
+		rule.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.MatchType != "" {
+		rule.MatchType = req.MatchType
+	}
+	if pattern := strings.TrimSpace(req.Pattern); pattern != "" {
+		rule.Pattern = pattern
+	}
+	if req.IsRegex != nil {
+		rule.IsRegex = *req.IsRegex
+	}
+	if req.IsActive != nil {
+		rule.IsActive = *req.IsActive
+	}
+	if err := validateCapturePattern(rule.Pattern, rule.IsRegex); err != nil {
+		return nil, err
+	}
+
+	if err := s.ruleRepo.Update(rule); err != nil {
+		return nil, err
+	}
+
+	response := toCaptureExclusionResponse(rule)
+	return &response, nil
+}
+
+func (s *capturePolicyService) DeleteRule(orgID, ruleID, userID uint) error {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return err
+	}
+
+	rule, err := s.ruleRepo.FindByID(orgID, ruleID)
+	if err != nil {
+		return apperror.NotFound("capture exclusion rule not found")
+	}
+	return s.ruleRepo.Delete(rule.ID)
+}
+
+// ============================================================================
+// HELPER FUNCTIONS
+// ============================================================================
+
+func validateCapturePattern(pattern string, isRegex bool) error {
+	if pattern == "" {
+		return apperror.Validation("pattern is required", nil)
+	}
+	if isRegex {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return apperror.Validation("invalid regular expression: "+err.Error(), nil)
+		}
+	}
+	return nil
+}
+
+// matchCaptureExclusion returns the first rule matching the foreground window,
+// or nil. Matching is case-insensitive.
+func matchCaptureExclusion(rules []models.CaptureExclusionRule, appName, windowTitle string) *models.CaptureExclusionRule {
+	for i := range rules {
+		target := windowTitle
+		if rules[i].MatchType == models.CaptureMatchApp {
+			target = appName
+		}
+		if target == "" {
+			continue
+		}
+
+		if rules[i].IsRegex {
+			re, err := regexp.Compile("(?i)" + rules[i].Pattern)
+			if err == nil && re.MatchString(target) {
+				return &rules[i]
+			}
+			continue
+		}
+		if strings.Contains(strings.ToLower(target), strings.ToLower(rules[i].Pattern)) {
+			return &rules[i]
+		}
+	}
+	return nil
+}
+
+func toCaptureExclusionResponses(rules []models.CaptureExclusionRule) []dto.CaptureExclusionRuleResponse {
+	responses := make([]dto.CaptureExclusionRuleResponse, 0, len(rules))
+	for i := range rules {
+		responses = append(responses, toCaptureExclusionResponse(&rules[i]))
+	}
+	return responses
+}
+
+func toCaptureExclusionResponse(r *models.CaptureExclusionRule) dto.CaptureExclusionRuleResponse {
+	return dto.CaptureExclusionRuleResponse{
+		ID:        r.ID,
+		Name:      r.Name,
+		MatchType: r.MatchType,
+		Pattern:   r.Pattern,
+		IsRegex:   r.IsRegex,
+		IsActive:  r.IsActive,
+		CreatedBy: r.CreatedBy,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/money"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+)
+
+var (
+	// ErrClientNotFound is returned for unknown clients and clients of other organizations
+	ErrClientNotFound = apperror.NotFound("client not found")
+	// ErrClientExists is returned when the organization already has a client with the name
+	ErrClientExists = apperror.Conflict("a client with this name already exists")
+)
+
+// ClientService manages the clients an organization's workspaces are done
+// for. Reports aggregate tracked time per client across its workspaces.
+type ClientService interface {
+	List(orgID, userID uint) ([]dto.ClientResponse, error)
+	GetByID(orgID, clientID, userID uint) (*dto.ClientResponse, error)
+	Create(orgID, userID uint, req *dto.CreateClientRequest) (*dto.ClientResponse, error)
+	Update(orgID, clientID, userID uint, req *dto.UpdateClientRequest) (*dto.ClientResponse, error)
+	Delete(orgID, clientID, userID uint) error
+}
+
+type clientService struct {
+	clientRepo repository.ClientRepository
+	orgRepo    *repository.OrganizationRepository
+}
+
+// NewClientService creates a new client service
+func NewClientService(clientRepo repository.ClientRepository, orgRepo *repository.OrganizationRepository) ClientService {
+	return &clientService{
+		clientRepo: clientRepo,
+		orgRepo:    orgRepo,
+	}
+}
+
+func (s *clientService) requireMember(orgID, userID uint) error {
+	isMember, err := s.orgRepo.IsMember(orgID, userID)
+	if err != nil {
+		return err
+	}
+	if !isMember {
+		return apperror.Forbidden("access denied: not a member of this organization")
+	}
+	return nil
+}
+
+func (s *clientService) requireAdmin(orgID, userID uint) error {
+	isAdmin, err := s.orgRepo.IsAdmin(orgID, userID)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return apperror.Forbidden("access denied: only admins can manage clients")
+	}
+	return nil
+}
+
+// findClient loads a client of the organization
+func (s *clientService) findClient(orgID, clientID uint) (*models.Client, error) {
+	client, err := s.clientRepo.FindByID(clientID)
+	if err != nil || client.OrganizationID != orgID {
+		return nil, ErrClientNotFound
+	}
+	return client, nil
+}
+
+func (s *clientService) List(orgID, userID uint) ([]dto.ClientResponse, error) {
+	if err := s.requireMember(orgID, userID); err != nil {
+		return nil, err
+	}
+
+	clients, err := s.clientRepo.FindByOrg(orgID)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.clientRepo.GetWorkspaceCounts(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.ClientResponse, 0, len(clients))
+	for i := range clients {
+		response := toClientResponse(&clients[i])
+		response.WorkspaceCount = counts[clients[i].ID]
+		responses = append(responses, response)
+	}
+	return responses, nil
+}
+
+func (s *clientService) GetByID(orgID, clientID, userID uint) (*dto.ClientResponse, error) {
+	if err := s.requireMember(orgID, userID); err != nil {
+		return nil, err
+	}
+
+	client, err := s.clientRepo.FindByIDWithWorkspaces(clientID)
+	if err != nil || client.OrganizationID != orgID {
+		return nil, ErrClientNotFound
+	}
+
+	response := toClientResponse(client)
+	response.WorkspaceCount = int64(len(client.Workspaces))
+	response.Workspaces = make([]dto.ClientWorkspaceResponse, 0, len(client.Workspaces))
+	for _, w := range client.Workspaces {
+		response.Workspaces = append(response.Workspaces, dto.ClientWorkspaceResponse{
+			ID:         w.ID,
+			Name:       w.Name,
+			Slug:       w.Slug,
+			IsActive:   w.IsActive,
+			IsBillable: w.IsBillable,
+			HourlyRate: money.ToMajor(w.HourlyRateMinor, w.Currency),
+			Currency:   w.Currency,
+		})
+	}
+	return &response, nil
+}
+
+func (s *clientService) Create(orgID, userID uint, req *dto.CreateClientRequest) (*dto.ClientResponse, error) {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if err := s.checkName(orgID, name, 0); err != nil {
+		return nil, err
+	}
+
+	client := &models.Client{
+		OrganizationID: orgID,
+		Name:           name,
+		ContactName:    strings.TrimSpace(req.ContactName),
+		ContactEmail:   strings.TrimSpace(req.ContactEmail),
+		Notes:          req.Notes,
+		CreatedBy:      userID,
+	}
+	if err := s.clientRepo.Create(client); err != nil {
+		return nil, err
+	}
+
+	response := toClientResponse(client)
+	return &response, nil
+}
+
+func (s *clientService) Update(orgID, clientID, userID uint, req *dto.UpdateClientRequest) (*dto.ClientResponse, error) {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return nil, err
+	}
+
+	client, err := s.findClient(orgID, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if err := s.checkName(orgID, name, client.ID); err != nil {
+			return nil, err
+		}
+		client.Name = name
+	}
+	if req.ContactName != nil {
+		client.ContactName = strings.TrimSpace(*req.ContactName)
+	}
+	if req.ContactEmail != nil {
+		client.ContactEmail = strings.TrimSpace(*req.ContactEmail)
+	}
+	if req.Notes != nil {
+		client.Notes = *req.Notes
+	}
+
+	if err := s.clientRepo.Update(client); err != nil {
+		return nil, err
+	}
+	return s.GetByID(orgID, clientID, userID)
+}
+
+func (s *clientService) Delete(orgID, clientID, userID uint) error {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return err
+	}
+
+	client, err := s.findClient(orgID, clientID)
+	if err != nil {
+		return err
+	}
+	return s.clientRepo.Delete(client.ID)
+}
+
+// checkName rejects empty names and names of the organization's other clients
+func (s *clientService) checkName(orgID uint, name string, clientID uint) error {
+	if name == "" {
+		return apperror.Validation("name is required", nil)
+	}
+	exists, err := s.clientRepo.NameExistsInOrg(orgID, name, clientID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrClientExists
+	}
+	return nil
+}
+
+func toClientResponse(client *models.Client) dto.ClientResponse {
+	return dto.ClientResponse{
+		ID:             client.ID,
+		OrganizationID: client.OrganizationID,
+		Name:           client.Name,
+		ContactName:    client.ContactName,
+		ContactEmail:   client.ContactEmail,
+		Notes:          client.Notes,
+		CreatedAt:      client.CreatedAt,
+		UpdatedAt:      client.UpdatedAt,
+	}
+}
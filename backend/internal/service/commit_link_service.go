@@ -0,0 +1,708 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+	"gorm.io/gorm"
+)
+
+// Notes rarely reference more than a handful of commits; anything beyond
+// this is ignored rather than flooding the time log
+const maxNoteCommitRefs = 20
+
+var (
+	// Full URLs in notes
+	vcsURLPattern = regexp.MustCompile(`https?://[^\s<>()"'\[\]]+`)
+	// "owner/repo#123", "group/project!45" or "owner/repo@1a2b3c4"
+	vcsQualifiedRefPattern = regexp.MustCompile(`(?:^|[^\w./-])([\w.-]+(?:/[\w.-]+)+)([#!@])([0-9A-Za-z]+)\b`)
+	// "#123" or "!45" on their own
+	vcsBareRefPattern = regexp.MustCompile(`(?:^|[^\w/&])([#!])(\d+)\b`)
+	vcsCommitSHA      = regexp.MustCompile(`^[0-9a-fA-F]{7,40}$`)
+	vcsNumber         = regexp.MustCompile(`^\d{1,10}$`)
+	vcsFullName       = regexp.MustCompile(`^[\w.-]+(/[\w.-]+)+$`)
+)
+
+// ErrVCSRepositoryNotFound is returned when a workspace repository does not exist
+var ErrVCSRepositoryNotFound = apperror.NotFound("repository not found")
+
+// ErrCommitLinkNotFound is returned when a time log or one of its commit links does not exist
+var ErrCommitLinkNotFound = apperror.NotFound("commit link not found")
+
+// ErrVCSWebhookUnauthorized is returned when a push webhook fails verification
+var ErrVCSWebhookUnauthorized = apperror.Unauthorized("invalid webhook signature")
+
+// CommitLinkService links GitHub and GitLab commits, pull/merge requests and
+// issues to time logs. Links come from refs in time log notes, from
+// repository push webhooks and from users adding URLs by hand.
+type CommitLinkService interface {
+	// Workspace repositories (workspace managers)
+	ListRepositories(workspaceID, userID uint) ([]dto.VCSRepositoryResponse, error)
+	AddRepository(workspaceID, userID uint, req *dto.CreateVCSRepositoryRequest) (*dto.VCSRepositoryResponse, error)
+	DeleteRepository(workspaceID, repoID, userID uint) error
+
+	// Links on the user's own time logs
+	ListTimeLogCommits(timeLogID, userID uint) ([]dto.TimeLogCommitResponse, error)
+	AddTimeLogCommit(timeLogID, userID uint, req *dto.LinkTimeLogCommitRequest) (*dto.TimeLogCommitResponse, error)
+	RemoveTimeLogCommit(timeLogID, linkID, userID uint) error
+
+	// LinkFromNotes links the refs found in a time log's notes. Failures are
+	// logged, never returned, so it is safe to call after saving a time log.
+	LinkFromNotes(timeLog *models.TimeLog)
+
+	// HandleWebhook links the commits of a GitHub or GitLab push to the time
+	// log each author was tracking when committing
+	HandleWebhook(repoID uint, header http.Header, body []byte) (*dto.VCSWebhookResponse, error)
+
+	// Links shown in task and time log details
+	CommitsForTask(taskID uint) []dto.TimeLogCommitResponse
+	CommitsForTimeLog(timeLogID uint) []dto.TimeLogCommitResponse
+}
+
+type commitLinkService struct {
+	commitRepo       repository.CommitLinkRepository
+	timeLogRepo      repository.TimeLogRepository
+	workspaceRepo    *repository.WorkspaceRepository
+	userRepo         repository.UserRepository
+	workspaceService WorkspaceService
+}
+
+// NewCommitLinkService creates a new commit link service
+func NewCommitLinkService(
+	commitRepo repository.CommitLinkRepository,
+	timeLogRepo repository.TimeLogRepository,
+	workspaceRepo *repository.WorkspaceRepository,
+	userRepo repository.UserRepository,
+	workspaceService WorkspaceService,
+) CommitLinkService {
+	return &commitLinkService{
+		commitRepo:       commitRepo,
+		timeLogRepo:      timeLogRepo,
+		workspaceRepo:    workspaceRepo,
+		userRepo:         userRepo,
+		workspaceService: workspaceService,
+	}
+}
+
+// vcsRef is a parsed reference to a commit, pull/merge request or issue
+type vcsRef struct {
+	Provider   string
+	BaseURL    string
+	Repository string
+	Kind       string
+	Ref        string
+}
+
+func (r vcsRef) URL() string {
+	base := strings.TrimRight(r.BaseURL, "/") + "/" + r.Repository
+	if r.Provider == models.VCSProviderGitLab {
+		base += "/-"
+	}
+	switch r.Kind {
+	case models.CommitKindCommit:
+		return base + "/commit/" + r.Ref
+	case models.CommitKindPullRequest:
+		return base + "/pull/" + r.Ref
+	case models.CommitKindMergeRequest:
+		return base + "/merge_requests/" + r.Ref
+	default:
+		return base + "/issues/" + r.Ref
+	}
+}
+
+func (r vcsRef) key() string {
+	return r.Provider + "|" + strings.ToLower(r.Repository) + "|" + r.Kind + "|" + strings.ToLower(r.Ref)
+}
+
+// ============================================================================
+// WORKSPACE REPOSITORIES
+// ============================================================================
+
+func (s *commitLinkService) requireManager(workspaceID, userID uint) error {
+	canManage, err := s.workspaceService.HasPermission(workspaceID, userID, models.PermSettingsManage)
+	if err != nil {
+		return err
+	}
+	if !canManage {
+		return apperror.Forbidden("access denied: you cannot manage repositories in this workspace")
+	}
+	return nil
+}
+
+func (s *commitLinkService) ListRepositories(workspaceID, userID uint) ([]dto.VCSRepositoryResponse, error) {
+	if err := s.requireManager(workspaceID, userID); err != nil {
+		return nil, err
+	}
+
+	repos, err := s.commitRepo.FindRepositoriesByWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.VCSRepositoryResponse, len(repos))
+	for i := range repos {
+		responses[i] = toVCSRepositoryResponse(&repos[i])
+	}
+	return responses, nil
+}
+
+func (s *commitLinkService) AddRepository(workspaceID, userID uint, req *dto.CreateVCSRepositoryRequest) (*dto.VCSRepositoryResponse, error) {
+	if err := s.requireManager(workspaceID, userID); err != nil {
+		return nil, err
+	}
+
+	fullName := strings.Trim(strings.TrimSpace(req.FullName), "/")
+	if !vcsFullName.MatchString(fullName) {
+		return nil, apperror.Validation("full_name must look like owner/repo", nil)
+	}
+	if req.Provider == models.VCSProviderGitHub && strings.Count(fullName, "/") != 1 {
+		return nil, apperror.Validation("GitHub repositories must look like owner/repo", nil)
+	}
+
+	baseURL := strings.TrimRight(strings.TrimSpace(req.BaseURL), "/")
+	if baseURL == "" {
+		baseURL = defaultVCSBaseURL(req.Provider)
+	} else if u, err := url.Parse(baseURL); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, apperror.Validation("base_url must be an http(s) URL", nil)
+	}
+
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	repo := &models.VCSRepository{
+		WorkspaceID:   workspaceID,
+		Provider:      req.Provider,
+		BaseURL:       baseURL,
+		FullName:      fullName,
+		IsDefault:     req.IsDefault,
+		WebhookSecret: secret,
+		CreatedBy:     userID,
+	}
+
+	existing, err := s.commitRepo.FindRepositoriesByWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range existing {
+		if other.Provider == repo.Provider && strings.EqualFold(other.FullName, repo.FullName) {
+			return nil, apperror.Conflict("repository is already linked to this workspace")
+		}
+	}
+	// The first repository resolves bare refs until another one is made default
+	if len(existing) == 0 {
+		repo.IsDefault = true
+	}
+	if repo.IsDefault {
+		if err := s.commitRepo.ClearDefaultRepository(workspaceID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.commitRepo.CreateRepository(repo); err != nil {
+		return nil, errors.New("failed to add repository")
+	}
+
+	response := toVCSRepositoryResponse(repo)
+	response.WebhookSecret = repo.WebhookSecret
+	return &response, nil
+}
+
+func (s *commitLinkService) DeleteRepository(workspaceID, repoID, userID uint) error {
+	if err := s.requireManager(workspaceID, userID); err != nil {
+		return err
+	}
+
+	repo, err := s.commitRepo.FindRepository(repoID)
+	if err != nil || repo.WorkspaceID != workspaceID {
+		return ErrVCSRepositoryNotFound
+	}
+
+	// Links already made are kept; they point at the provider, not the repository row
+	return s.commitRepo.DeleteRepository(repo.ID)
+}
+
+// ============================================================================
+// TIME LOG LINKS
+// ============================================================================
+
+func (s *commitLinkService) ownTimeLog(timeLogID, userID uint) (*models.TimeLog, error) {
+	timeLog, err := s.timeLogRepo.FindByID(timeLogID)
+	if err != nil {
+		return nil, ErrCommitLinkNotFound
+	}
+	if timeLog.UserID != userID {
+		return nil, apperror.Forbidden("access denied: not your time log")
+	}
+	return timeLog, nil
+}
+
+func (s *commitLinkService) ListTimeLogCommits(timeLogID, userID uint) ([]dto.TimeLogCommitResponse, error) {
+	if _, err := s.ownTimeLog(timeLogID, userID); err != nil {
+		return nil, err
+	}
+
+	links, err := s.commitRepo.FindByTimeLog(timeLogID)
+	if err != nil {
+		return nil, err
+	}
+	return toTimeLogCommitResponses(links), nil
+}
+
+func (s *commitLinkService) AddTimeLogCommit(timeLogID, userID uint, req *dto.LinkTimeLogCommitRequest) (*dto.TimeLogCommitResponse, error) {
+	timeLog, err := s.ownTimeLog(timeLogID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	ref, ok := parseVCSURL(req.URL, s.workspaceRepositories(timeLog))
+	if !ok {
+		return nil, apperror.Validation("url must point to a GitHub or GitLab commit, pull/merge request or issue", nil)
+	}
+
+	link := newTimeLogCommit(timeLog, ref, models.CommitSourceManual)
+	link.CreatedBy = &userID
+	if err := s.commitRepo.CreateLinks([]models.TimeLogCommit{link}); err != nil {
+		return nil, errors.New("failed to link commit")
+	}
+
+	// Return the stored link whether it was just created or already existed
+	links, err := s.commitRepo.FindByTimeLog(timeLog.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, existing := range links {
+		if (vcsRef{Provider: existing.Provider, Repository: existing.Repository, Kind: existing.Kind, Ref: existing.Ref}).key() == ref.key() {
+			response := toTimeLogCommitResponse(&existing)
+			return &response, nil
+		}
+	}
+	return nil, errors.New("failed to link commit")
+}
+
+func (s *commitLinkService) RemoveTimeLogCommit(timeLogID, linkID, userID uint) error {
+	if _, err := s.ownTimeLog(timeLogID, userID); err != nil {
+		return err
+	}
+
+	link, err := s.commitRepo.FindLink(timeLogID, linkID)
+	if err != nil {
+		return ErrCommitLinkNotFound
+	}
+	return s.commitRepo.DeleteLink(link.ID)
+}
+
+func (s *commitLinkService) LinkFromNotes(timeLog *models.TimeLog) {
+	if timeLog == nil || timeLog.ID == 0 || strings.TrimSpace(timeLog.Notes) == "" {
+		return
+	}
+
+	refs := parseNoteRefs(timeLog.Notes, s.workspaceRepositories(timeLog))
+	if len(refs) == 0 {
+		return
+	}
+
+	links := make([]models.TimeLogCommit, len(refs))
+	for i, ref := range refs {
+		links[i] = newTimeLogCommit(timeLog, ref, models.CommitSourceNotes)
+	}
+	if err := s.commitRepo.CreateLinks(links); err != nil {
+		log.Printf("⚠️  Failed to link commits from notes of time log %d: %v", timeLog.ID, err)
+	}
+}
+
+func (s *commitLinkService) workspaceRepositories(timeLog *models.TimeLog) []models.VCSRepository {
+	if timeLog.WorkspaceID == nil {
+		return nil
+	}
+	repos, err := s.commitRepo.FindRepositoriesByWorkspace(*timeLog.WorkspaceID)
+	if err != nil {
+		log.Printf("⚠️  Failed to load repositories of workspace %d: %v", *timeLog.WorkspaceID, err)
+		return nil
+	}
+	return repos
+}
+
+func (s *commitLinkService) CommitsForTask(taskID uint) []dto.TimeLogCommitResponse {
+	links, err := s.commitRepo.FindByTask(taskID)
+	if err != nil {
+		log.Printf("⚠️  Failed to load commits of task %d: %v", taskID, err)
+	}
+	return toTimeLogCommitResponses(links)
+}
+
+func (s *commitLinkService) CommitsForTimeLog(timeLogID uint) []dto.TimeLogCommitResponse {
+	links, err := s.commitRepo.FindByTimeLog(timeLogID)
+	if err != nil {
+		log.Printf("⚠️  Failed to load commits of time log %d: %v", timeLogID, err)
+	}
+	return toTimeLogCommitResponses(links)
+}
+
+// ============================================================================
+// PUSH WEBHOOKS
+// ============================================================================
+
+// vcsPushPayload holds the fields GitHub and GitLab push events have in common
+type vcsPushPayload struct {
+	Commits []struct {
+		ID        string    `json:"id"`
+		Message   string    `json:"message"`
+		Timestamp time.Time `json:"timestamp"`
+		URL       string    `json:"url"`
+		Author    struct {
+			Email string `json:"email"`
+		} `json:"author"`
+	} `json:"commits"`
+}
+
+func (s *commitLinkService) HandleWebhook(repoID uint, header http.Header, body []byte) (*dto.VCSWebhookResponse, error) {
+	repo, err := s.commitRepo.FindRepository(repoID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrVCSRepositoryNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch repo.Provider {
+	case models.VCSProviderGitHub:
+		if !verifyGitHubSignature(repo.WebhookSecret, header.Get("X-Hub-Signature-256"), body) {
+			return nil, ErrVCSWebhookUnauthorized
+		}
+		if event := header.Get("X-GitHub-Event"); event != "push" {
+			// "ping" is sent when the webhook is created; other events are not used
+			return &dto.VCSWebhookResponse{}, nil
+		}
+	case models.VCSProviderGitLab:
+		if subtle.ConstantTimeCompare([]byte(header.Get("X-Gitlab-Token")), []byte(repo.WebhookSecret)) != 1 {
+			return nil, ErrVCSWebhookUnauthorized
+		}
+		if event := header.Get("X-Gitlab-Event"); event != "Push Hook" {
+			return &dto.VCSWebhookResponse{}, nil
+		}
+	}
+
+	var payload vcsPushPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, apperror.Validation("invalid push payload", nil)
+	}
+
+	result := &dto.VCSWebhookResponse{}
+	users := make(map[string]*models.User)
+	var links []models.TimeLogCommit
+	for _, commit := range payload.Commits {
+		timeLog := s.commitTimeLog(repo, commit.Author.Email, commit.Timestamp, users)
+		if timeLog == nil || !vcsCommitSHA.MatchString(commit.ID) {
+			result.Skipped++
+			continue
+		}
+
+		ref := vcsRef{
+			Provider:   repo.Provider,
+			BaseURL:    repo.BaseURL,
+			Repository: repo.FullName,
+			Kind:       models.CommitKindCommit,
+			Ref:        strings.ToLower(commit.ID),
+		}
+		link := newTimeLogCommit(timeLog, ref, models.CommitSourceWebhook)
+		if commit.URL != "" {
+			link.URL = truncateText(commit.URL, 500)
+		}
+		link.Title = truncateText(strings.TrimSpace(strings.SplitN(commit.Message, "\n", 2)[0]), 255)
+		links = append(links, link)
+		result.Linked++
+	}
+
+	if err := s.commitRepo.CreateLinks(links); err != nil {
+		return nil, errors.New("failed to link commits")
+	}
+	return result, nil
+}
+
+// commitTimeLog finds the time log a commit author was tracking in the
+// repository's workspace when committing
+func (s *commitLinkService) commitTimeLog(repo *models.VCSRepository, email string, at time.Time, users map[string]*models.User) *models.TimeLog {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" || at.IsZero() {
+		return nil
+	}
+
+	user, seen := users[email]
+	if !seen {
+		user, _ = s.userRepo.FindByEmail(email)
+		if user != nil {
+			if isMember, err := s.workspaceRepo.IsMember(repo.WorkspaceID, user.ID); err != nil || !isMember {
+				user = nil
+			}
+		}
+		users[email] = user
+	}
+	if user == nil {
+		return nil
+	}
+
+	timeLog, err := s.commitRepo.FindTimeLogAt(user.ID, repo.WorkspaceID, at.UTC())
+	if err != nil {
+		return nil
+	}
+	return timeLog
+}
+
+func verifyGitHubSignature(secret, signature string, body []byte) bool {
+	if !strings.HasPrefix(signature, "sha256=") {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(signature), []byte(expected))
+}
+
+// ============================================================================
+// REF PARSING
+// ============================================================================
+
+func defaultVCSBaseURL(provider string) string {
+	if provider == models.VCSProviderGitLab {
+		return "https://gitlab.com"
+	}
+	return "https://github.com"
+}
+
+// parseVCSURL parses a GitHub or GitLab URL to a commit, pull/merge request or
+// issue. GitHub URLs are accepted on github.com and on the hosts of the
+// workspace's GitHub repositories; GitLab's "/-/" URLs on any host.
+func parseVCSURL(raw string, repos []models.VCSRepository) (vcsRef, bool) {
+	u, err := url.Parse(strings.TrimRight(raw, ".,;:!?"))
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return vcsRef{}, false
+	}
+	base := u.Scheme + "://" + u.Host
+	path := strings.Trim(u.Path, "/")
+
+	// GitLab: group/subgroup/project/-/merge_requests/12
+	if project, rest, found := strings.Cut(path, "/-/"); found {
+		parts := strings.Split(rest, "/")
+		if len(parts) < 2 || !vcsFullName.MatchString(project) {
+			return vcsRef{}, false
+		}
+		ref := vcsRef{Provider: models.VCSProviderGitLab, BaseURL: base, Repository: project, Ref: parts[1]}
+		switch parts[0] {
+		case "merge_requests":
+			ref.Kind = models.CommitKindMergeRequest
+		case "commit":
+			ref.Kind = models.CommitKindCommit
+		case "issues":
+			ref.Kind = models.CommitKindIssue
+		default:
+			return vcsRef{}, false
+		}
+		return ref, validVCSRef(ref)
+	}
+
+	// GitHub: owner/repo/pull/12
+	if !isGitHubHost(u.Host, repos) {
+		return vcsRef{}, false
+	}
+	parts := strings.Split(path, "/")
+	if len(parts) < 4 {
+		return vcsRef{}, false
+	}
+	ref := vcsRef{Provider: models.VCSProviderGitHub, BaseURL: base, Repository: parts[0] + "/" + parts[1], Ref: parts[3]}
+	switch parts[2] {
+	case "pull":
+		ref.Kind = models.CommitKindPullRequest
+	case "commit":
+		ref.Kind = models.CommitKindCommit
+	case "issues":
+		ref.Kind = models.CommitKindIssue
+	default:
+		return vcsRef{}, false
+	}
+	return ref, validVCSRef(ref)
+}
+
+func isGitHubHost(host string, repos []models.VCSRepository) bool {
+	if strings.EqualFold(host, "github.com") {
+		return true
+	}
+	for _, repo := range repos {
+		if repo.Provider != models.VCSProviderGitHub {
+			continue
+		}
+		if u, err := url.Parse(repo.BaseURL); err == nil && strings.EqualFold(u.Host, host) {
+			return true
+		}
+	}
+	return false
+}
+
+func validVCSRef(ref vcsRef) bool {
+	if ref.Kind == models.CommitKindCommit {
+		return vcsCommitSHA.MatchString(ref.Ref)
+	}
+	return vcsNumber.MatchString(ref.Ref)
+}
+
+// parseNoteRefs finds the refs in time log notes: full URLs, then
+// "owner/repo#123", "group/project!45" and "owner/repo@sha" refs to workspace
+// repositories, then bare "#123" (a GitHub pull request or GitLab issue) and
+// "!45" (a GitLab merge request) refs to the workspace's default repository
+func parseNoteRefs(notes string, repos []models.VCSRepository) []vcsRef {
+	var refs []vcsRef
+	seen := make(map[string]bool)
+	add := func(ref vcsRef) {
+		if len(refs) >= maxNoteCommitRefs || !validVCSRef(ref) || seen[ref.key()] {
+			return
+		}
+		seen[ref.key()] = true
+		refs = append(refs, ref)
+	}
+
+	for _, raw := range vcsURLPattern.FindAllString(notes, -1) {
+		if ref, ok := parseVCSURL(raw, repos); ok {
+			add(ref)
+		}
+	}
+	// URLs contain "#" and "/" that would otherwise read as short refs
+	notes = vcsURLPattern.ReplaceAllString(notes, " ")
+
+	for _, m := range vcsQualifiedRefPattern.FindAllStringSubmatch(notes, -1) {
+		repo := findVCSRepository(repos, m[1])
+		if repo == nil {
+			continue
+		}
+		if ref, ok := shortVCSRef(repo, m[2], m[3]); ok {
+			add(ref)
+		}
+	}
+
+	if repo := defaultVCSRepository(repos); repo != nil {
+		for _, m := range vcsBareRefPattern.FindAllStringSubmatch(notes, -1) {
+			if ref, ok := shortVCSRef(repo, m[1], m[2]); ok {
+				add(ref)
+			}
+		}
+	}
+	return refs
+}
+
+// shortVCSRef builds a ref from a short "#", "!" or "@" reference to a repository
+func shortVCSRef(repo *models.VCSRepository, sigil, value string) (vcsRef, bool) {
+	ref := vcsRef{Provider: repo.Provider, BaseURL: repo.BaseURL, Repository: repo.FullName, Ref: value}
+	switch sigil {
+	case "#":
+		ref.Kind = models.CommitKindIssue
+		if repo.Provider == models.VCSProviderGitHub {
+			// GitHub numbers issues and pull requests together and redirects
+			// /pull/N to the issue when N is one, so pull requests are assumed
+			ref.Kind = models.CommitKindPullRequest
+		}
+	case "!":
+		if repo.Provider != models.VCSProviderGitLab {
+			return vcsRef{}, false
+		}
+		ref.Kind = models.CommitKindMergeRequest
+	case "@":
+		ref.Kind = models.CommitKindCommit
+		ref.Ref = strings.ToLower(value)
+	default:
+		return vcsRef{}, false
+	}
+	return ref, true
+}
+
+func findVCSRepository(repos []models.VCSRepository, fullName string) *models.VCSRepository {
+	for i := range repos {
+		if strings.EqualFold(repos[i].FullName, fullName) {
+			return &repos[i]
+		}
+	}
+	return nil
+}
+
+// defaultVCSRepository returns the repository bare refs resolve against: the
+// default one, or the only one
+func defaultVCSRepository(repos []models.VCSRepository) *models.VCSRepository {
+	for i := range repos {
+		if repos[i].IsDefault {
+			return &repos[i]
+		}
+	}
+	if len(repos) == 1 {
+		return &repos[0]
+	}
+	return nil
+}
+
+// ============================================================================
+// HELPERS
+// ============================================================================
+
+func newTimeLogCommit(timeLog *models.TimeLog, ref vcsRef, source string) models.TimeLogCommit {
+	return models.TimeLogCommit{
+		TimeLogID:  timeLog.ID,
+		TaskID:     timeLog.TaskID,
+		Provider:   ref.Provider,
+		Repository: ref.Repository,
+		Kind:       ref.Kind,
+		Ref:        ref.Ref,
+		URL:        truncateText(ref.URL(), 500),
+		Source:     source,
+	}
+}
+
+func toVCSRepositoryResponse(repo *models.VCSRepository) dto.VCSRepositoryResponse {
+	return dto.VCSRepositoryResponse{
+		ID:          repo.ID,
+		WorkspaceID: repo.WorkspaceID,
+		Provider:    repo.Provider,
+		BaseURL:     repo.BaseURL,
+		FullName:    repo.FullName,
+		IsDefault:   repo.IsDefault,
+		WebhookURL:  fmt.Sprintf("/api/v1/public/vcs/repositories/%d/webhook", repo.ID),
+		CreatedBy:   repo.CreatedBy,
+		CreatedAt:   repo.CreatedAt,
+	}
+}
+
+func toTimeLogCommitResponse(link *models.TimeLogCommit) dto.TimeLogCommitResponse {
+	return dto.TimeLogCommitResponse{
+		ID:         link.ID,
+		TimeLogID:  link.TimeLogID,
+		TaskID:     link.TaskID,
+		Provider:   link.Provider,
+		Repository: link.Repository,
+		Kind:       link.Kind,
+		Ref:        link.Ref,
+		URL:        link.URL,
+		Title:      link.Title,
+		Source:     link.Source,
+		CreatedAt:  link.CreatedAt,
+	}
+}
+
+func toTimeLogCommitResponses(links []models.TimeLogCommit) []dto.TimeLogCommitResponse {
+	responses := make([]dto.TimeLogCommitResponse, len(links))
+	for i := range links {
+		responses[i] = toTimeLogCommitResponse(&links[i])
+	}
+	return responses
+}
@@ -0,0 +1,279 @@
+package service
+
+import (
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+)
+
+// Member compliance statuses in reports besides models.CapStatus*
+const (
+	complianceStatusOK       = "ok"
+	complianceStatusUncapped = "uncapped"
+)
+
+// CapCheck is the outcome of evaluating a time log against the member's weekly hour cap
+type CapCheck struct {
+	WorkspaceID   uint
+	Status        string // "", approaching, exceeded
+	Blocked       bool   // Workspace blocks new time logs and the cap was already reached
+	WeeklyHourCap float64
+	LoggedHours   float64 // Including the evaluated time log
+}
+
+// ComplianceService enforces per-member weekly hour caps (contractor compliance)
+type ComplianceService interface {
+	// CheckTimeLog evaluates the time log's workspace week for its user and fires
+	// notifications the first time the member approaches or exceeds the cap that
+	// week. Returns nil when the time log has no workspace or the member no cap.
+	CheckTimeLog(timeLog *models.TimeLog) (*CapCheck, error)
+	GetWorkspaceReport(workspaceID, userID uint, params *dto.WorkspaceComplianceParams) (*dto.WorkspaceComplianceReport, error)
+}
+
+type complianceService struct {
+	timeLogRepo      repository.TimeLogRepository
+	workspaceRepo    *repository.WorkspaceRepository
+	orgRepo          *repository.OrganizationRepository
+	workspaceService WorkspaceService
+	webhookService   WebhookService
+}
+
+// NewComplianceService creates a new compliance service
+func NewComplianceService(
+	timeLogRepo repository.TimeLogRepository,
+	workspaceRepo *repository.WorkspaceRepository,
+	orgRepo *repository.OrganizationRepository,
+	workspaceService WorkspaceService,
+	webhookService WebhookService,
+) ComplianceService {
+	return &complianceService{
+		timeLogRepo:      timeLogRepo,
+		workspaceRepo:    workspaceRepo,
+		orgRepo:          orgRepo,
+		workspaceService: workspaceService,
+		webhookService:   webhookService,
+	}
+}
+
+func (s *complianceService) CheckTimeLog(timeLog *models.TimeLog) (*CapCheck, error) {
+	if timeLog.WorkspaceID == nil {
+		return nil, nil
+	}
+
+	member, err := s.workspaceRepo.GetMember(*timeLog.WorkspaceID, timeLog.UserID)
+	if err != nil || member.WeeklyHourCap == nil || *member.WeeklyHourCap <= 0 {
+		return nil, nil
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(*timeLog.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	org, err := s.orgRepo.GetByID(workspace.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	weekStart := org.Calendar().StartOfWeek(timeLog.StartTime)
+	weekEnd := weekStart.AddDate(0, 0, 7)
+
+	prior, err := s.timeLogRepo.SumWorkspaceDuration(timeLog.UserID, workspace.ID, weekStart, weekEnd, timeLog.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	capSeconds := *member.WeeklyHourCap * 3600
+	total := prior + timeLog.Duration
+
+	check := &CapCheck{
+		WorkspaceID:   workspace.ID,
+		Status:        capStatus(float64(total), capSeconds, workspace.CapWarnPercent),
+		WeeklyHourCap: *member.WeeklyHourCap,
+		LoggedHours:   secondsToHours(total),
+	}
+
+	// Only new time logs are rejected; sessions already on the server keep syncing
+	// so their final duration is not lost, and are flagged instead
+	if workspace.CapEnforcement == models.CapEnforcementBlock && timeLog.ID == 0 && float64(prior) >= capSeconds {
+		check.Status = models.CapStatusExceeded
+		check.Blocked = true
+		check.LoggedHours = secondsToHours(prior)
+	}
+
+	if check.Status != "" {
+		s.notify(member, workspace, weekStart, check)
+	}
+
+	return check, nil
+}
+
+// notify sends at most one notification per member, week and level
+func (s *complianceService) notify(member *models.WorkspaceMember, workspace *models.Workspace, weekStart time.Time, check *CapCheck) {
+	if member.CapNotifiedWeek != nil && member.CapNotifiedWeek.Equal(weekStart) &&
+		(member.CapNotifiedLevel == check.Status || member.CapNotifiedLevel == models.CapStatusExceeded) {
+		return
+	}
+
+	if err := s.workspaceRepo.MarkCapNotified(member.ID, weekStart, check.Status); err != nil {
+		log.Printf("⚠️  Failed to record hour cap notification for member %d: %v", member.ID, err)
+		return
+	}
+
+	event := models.WebhookEventHourCapApproaching
+	action := ActivityHourCapApproaching
+	if check.Status == models.CapStatusExceeded {
+		event = models.WebhookEventHourCapExceeded
+		action = ActivityHourCapExceeded
+	}
+
+	userID := member.UserID
+	ActivityFeedBroadcaster.Broadcast(ActivityEvent{
+		Action:     action,
+		UserID:     &userID,
+		EntityType: "workspace",
+		EntityID:   &workspace.ID,
+		Details: map[string]interface{}{
+			"week_start":      weekStart,
+			"weekly_hour_cap": check.WeeklyHourCap,
+			"logged_hours":    check.LoggedHours,
+			"blocked":         check.Blocked,
+		},
+	})
+
+	orgMember, err := s.orgRepo.GetMemberWithUser(workspace.OrganizationID, member.UserID)
+	if err != nil {
+		return
+	}
+	s.webhookService.EmitHourCapEvent(event, *orgMember, dto.WebhookHourCapInfo{
+		WorkspaceID:   workspace.ID,
+		WorkspaceName: workspace.Name,
+		WeekStart:     weekStart,
+		WeeklyHourCap: check.WeeklyHourCap,
+		LoggedHours:   check.LoggedHours,
+		Enforcement:   workspace.CapEnforcement,
+	})
+}
+
+func (s *complianceService) GetWorkspaceReport(workspaceID, userID uint, params *dto.WorkspaceComplianceParams) (*dto.WorkspaceComplianceReport, error) {
+	canView, err := s.workspaceService.HasPermission(workspaceID, userID, models.PermReportsView)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, apperror.Forbidden("access denied: you cannot view compliance for this workspace")
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	org, err := s.orgRepo.GetByID(workspace.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	week := time.Now()
+	if params.Week != nil {
+		week = *params.Week
+	}
+	weekStart := org.Calendar().StartOfWeek(week)
+	weekEnd := weekStart.AddDate(0, 0, 7)
+
+	members, err := s.workspaceRepo.GetMembersByWorkspaceID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	hours, err := s.timeLogRepo.GetWorkspaceWeekHours(workspaceID, weekStart, weekEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &dto.WorkspaceComplianceReport{
+		WorkspaceID:    workspaceID,
+		WeekStart:      weekStart,
+		WeekEnd:        weekEnd,
+		CapWarnPercent: workspace.CapWarnPercent,
+		CapEnforcement: workspace.CapEnforcement,
+		Members:        make([]dto.MemberComplianceReport, 0, len(members)),
+	}
+
+	for _, m := range members {
+		logged := hours[m.UserID]
+		entry := dto.MemberComplianceReport{
+			UserID:        m.UserID,
+			WeeklyHourCap: m.WeeklyHourCap,
+			LoggedHours:   secondsToHours(logged.Seconds),
+			Status:        complianceStatusUncapped,
+			FlaggedLogs:   logged.FlaggedLogs,
+		}
+		if m.User.ID > 0 {
+			entry.User = &dto.UserResponse{
+				ID:        m.User.ID,
+				UUID:      m.User.UUID,
+				Email:     m.User.Email,
+				FirstName: m.User.FirstName,
+				LastName:  m.User.LastName,
+				Role:      m.User.Role,
+				IsActive:  m.User.IsActive,
+				CreatedAt: m.User.CreatedAt,
+			}
+		}
+
+		if m.WeeklyHourCap != nil && *m.WeeklyHourCap > 0 {
+			capHours := *m.WeeklyHourCap
+			remaining := math.Max(0, math.Round((capHours-entry.LoggedHours)*100)/100)
+			percent := math.Round(entry.LoggedHours/capHours*10000) / 100
+			entry.RemainingHours = &remaining
+			entry.PercentUsed = &percent
+
+			entry.Status = capStatus(float64(logged.Seconds), capHours*3600, workspace.CapWarnPercent)
+			switch entry.Status {
+			case models.CapStatusApproaching:
+				report.Approaching++
+			case models.CapStatusExceeded:
+				report.Exceeded++
+			default:
+				entry.Status = complianceStatusOK
+			}
+			report.CappedMembers++
+		}
+
+		report.Members = append(report.Members, entry)
+	}
+
+	// Closest to (or furthest over) their cap first, uncapped members last
+	sort.SliceStable(report.Members, func(i, j int) bool {
+		a, b := report.Members[i].PercentUsed, report.Members[j].PercentUsed
+		if a == nil || b == nil {
+			return a != nil
+		}
+		return *a > *b
+	})
+
+	return report, nil
+}
+
+// capStatus classifies tracked seconds against a cap in seconds
+func capStatus(seconds, capSeconds float64, warnPercent int) string {
+	if warnPercent <= 0 || warnPercent > 100 {
+		warnPercent = 80
+	}
+	switch {
+	case seconds > capSeconds:
+		return models.CapStatusExceeded
+	case seconds >= capSeconds*float64(warnPercent)/100:
+		return models.CapStatusApproaching
+	default:
+		return ""
+	}
+}
+
+func secondsToHours(seconds int64) float64 {
+	return math.Round(float64(seconds)/3600*100) / 100
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+)
+
+// DeviceApprovalService gates sync for organizations that require new
+// devices to be approved, and handles the admin approval queue
+type DeviceApprovalService interface {
+	// Authorize fails with a device_not_approved error unless the device is
+	// approved in every organization of orgIDs that requires approval.
+	// Devices new to such an organization are queued as pending.
+	Authorize(device *models.DeviceInfo, userID uint, orgIDs []uint) error
+	// Status returns the device's approval status in the organization, or
+	// "" when the organization does not require device approval
+	Status(device *models.DeviceInfo, userID, orgID uint) (string, error)
+
+	// Admin queue
+	ListForOrg(orgID, userID uint, params *dto.DeviceApprovalListParams) ([]dto.DeviceApprovalResponse, int64, error)
+	Approve(orgID, approvalID, userID uint, req *dto.ReviewDeviceApprovalRequest) (*dto.DeviceApprovalResponse, error)
+	Reject(orgID, approvalID, userID uint, req *dto.ReviewDeviceApprovalRequest) (*dto.DeviceApprovalResponse, error)
+}
+
+type deviceApprovalService struct {
+	approvalRepo repository.DeviceApprovalRepository
+	orgRepo      *repository.OrganizationRepository
+}
+
+// NewDeviceApprovalService creates a new device approval service
+func NewDeviceApprovalService(approvalRepo repository.DeviceApprovalRepository, orgRepo *repository.OrganizationRepository) DeviceApprovalService {
+	return &deviceApprovalService{
+		approvalRepo: approvalRepo,
+		orgRepo:      orgRepo,
+	}
+}
+
+func (s *deviceApprovalService) Authorize(device *models.DeviceInfo, userID uint, orgIDs []uint) error {
+	for _, orgID := range orgIDs {
+		org, err := s.orgRepo.GetByID(orgID)
+		if err != nil || !org.RequireDeviceApproval {
+			// Unknown organizations are rejected item by item further on
+			continue
+		}
+
+		if device == nil {
+			return &apperror.Error{
+				Status:  http.StatusForbidden,
+				Code:    apperror.CodeDeviceNotApproved,
+				Message: org.Name + " requires device approval: send device_info so this device can be registered",
+			}
+		}
+
+		approval, err := s.approvalRepo.FindOrCreate(orgID, device.ID, userID)
+		if err != nil {
+			return err
+		}
+		if approval.Status == models.DeviceApprovalApproved {
+			continue
+		}
+
+		message := "This device is waiting for approval by an admin of " + org.Name
+		if approval.Status == models.DeviceApprovalRejected {
+			message = "This device was rejected by an admin of " + org.Name
+		}
+		return &apperror.Error{
+			Status:  http.StatusForbidden,
+			Code:    apperror.CodeDeviceNotApproved,
+			Message: message,
+			Details: toDeviceApprovalResponse(approval),
+		}
+	}
+	return nil
+}
+
+func (s *deviceApprovalService) Status(device *models.DeviceInfo, userID, orgID uint) (string, error) {
+	org, err := s.orgRepo.GetByID(orgID)
+	if err != nil {
+		return "", err
+	}
+	if !org.RequireDeviceApproval {
+		return "", nil
+	}
+
+	approval, err := s.approvalRepo.FindOrCreate(orgID, device.ID, userID)
+	if err != nil {
+		return "", err
+	}
+	return approval.Status, nil
+}
+
+// ============================================================================
+// ADMIN QUEUE
+// ============================================================================
+
+func (s *deviceApprovalService) requireAdmin(orgID, userID uint) error {
+	isAdmin, err := s.orgRepo.IsAdmin(orgID, userID)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return apperror.Forbidden("access denied: only organization admins can review devices")
+	}
+	return nil
+}
+
+func (s *deviceApprovalService) ListForOrg(orgID, userID uint, params *dto.DeviceApprovalListParams) ([]dto.DeviceApprovalResponse, int64, error) {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return nil, 0, err
+	}
+
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PerPage < 1 || params.PerPage > 100 {
+		params.PerPage = 20
+	}
+
+	approvals, total, err := s.approvalRepo.FindByOrg(orgID, params)
+	if err != nil {
+		return nil, 0, err
+	}
+	responses := make([]dto.DeviceApprovalResponse, 0, len(approvals))
+	for i := range approvals {
+		responses = append(responses, toDeviceApprovalResponse(&approvals[i]))
+	}
+	return responses, total, nil
+}
+
+func (s *deviceApprovalService) Approve(orgID, approvalID, userID uint, req *dto.ReviewDeviceApprovalRequest) (*dto.DeviceApprovalResponse, error) {
+	return s.review(orgID, approvalID, userID, models.DeviceApprovalApproved, req.Note)
+}
+
+// Reject also revokes an approved device; it can no longer sync into the
+// organization until approved again
+func (s *deviceApprovalService) Reject(orgID, approvalID, userID uint, req *dto.ReviewDeviceApprovalRequest) (*dto.DeviceApprovalResponse, error) {
+	return s.review(orgID, approvalID, userID, models.DeviceApprovalRejected, req.Note)
+}
+
+func (s *deviceApprovalService) review(orgID, approvalID, reviewerID uint, status, note string) (*dto.DeviceApprovalResponse, error) {
+	if err := s.requireAdmin(orgID, reviewerID); err != nil {
+		return nil, err
+	}
+
+	approval, err := s.approvalRepo.FindByID(approvalID)
+	if err != nil || approval.OrganizationID != orgID {
+		return nil, apperror.NotFound("device approval not found")
+	}
+	if approval.Status == status {
+		return nil, apperror.Conflict("device has already been " + status)
+	}
+
+	now := time.Now()
+	approval.Status = status
+	approval.ReviewedBy = &reviewerID
+	approval.ReviewedAt = &now
+	approval.ReviewNote = strings.TrimSpace(note)
+	if err := s.approvalRepo.Update(approval); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.approvalRepo.FindByID(approval.ID)
+	if err != nil {
+		updated = approval
+	}
+	response := toDeviceApprovalResponse(updated)
+	return &response, nil
+}
+
+// ============================================================================
+// HELPER FUNCTIONS
+// ============================================================================
+
+func toDeviceApprovalResponse(a *models.DeviceApproval) dto.DeviceApprovalResponse {
+	response := dto.DeviceApprovalResponse{
+		ID:             a.ID,
+		OrganizationID: a.OrganizationID,
+		DeviceID:       a.DeviceID,
+		UserID:         a.UserID,
+		Status:         a.Status,
+		ReviewedBy:     a.ReviewedBy,
+		ReviewedAt:     a.ReviewedAt,
+		ReviewNote:     a.ReviewNote,
+		CreatedAt:      a.CreatedAt,
+	}
+
+	if a.Device.ID > 0 {
+		response.Device = &dto.DeviceInfoResponse{
+			ID:         a.Device.ID,
+			DeviceUUID: a.Device.DeviceUUID,
+			DeviceName: a.Device.DeviceName,
+			OS:         a.Device.OS,
+			OSVersion:  a.Device.OSVersion,
+			AppVersion: a.Device.AppVersion,
+			LastSeenAt: a.Device.LastSeenAt,
+			IsActive:   a.Device.IsActive,
+		}
+	}
+	if a.User.ID > 0 {
+		response.User = &dto.UserResponse{
+			ID:        a.User.ID,
+			UUID:      a.User.UUID,
+			Email:     a.User.Email,
+			FirstName: a.User.FirstName,
+			LastName:  a.User.LastName,
+			Role:      a.User.Role,
+			IsActive:  a.User.IsActive,
+			CreatedAt: a.User.CreatedAt,
+		}
+	}
+	if a.Reviewer != nil && a.Reviewer.ID > 0 {
+		response.Reviewer = &dto.UserResponse{
+			ID:        a.Reviewer.ID,
+			UUID:      a.Reviewer.UUID,
+			Email:     a.Reviewer.Email,
+			FirstName: a.Reviewer.FirstName,
+			LastName:  a.Reviewer.LastName,
+			Role:      a.Reviewer.Role,
+			IsActive:  a.Reviewer.IsActive,
+			CreatedAt: a.Reviewer.CreatedAt,
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/config"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+)
+
+// Device configuration outside a workspace, matching the workspace defaults
+const (
+	defaultScreenshotIntervalSeconds = 300
+	defaultIdleThresholdSeconds      = 300
+)
+
+// DeviceConfigService builds the configuration document the desktop app
+// polls, so tracking and capture policy changes reach devices without an app
+// release
+type DeviceConfigService interface {
+	// GetConfig returns the document for one of the user's devices, with the
+	// policy of the workspace when workspaceID is set
+	GetConfig(userID uint, deviceUUID string, workspaceID *uint) (*dto.DeviceConfigResponse, error)
+}
+
+type deviceConfigService struct {
+	deviceRepo           repository.DeviceRepository
+	workspaceRepo        *repository.WorkspaceRepository
+	orgRepo              *repository.OrganizationRepository
+	capturePolicyService CapturePolicyService
+	deviceApprovals      DeviceApprovalService
+	featureFlags         FeatureFlagService
+}
+
+// NewDeviceConfigService creates a new device configuration service
+func NewDeviceConfigService(
+	deviceRepo repository.DeviceRepository,
+	workspaceRepo *repository.WorkspaceRepository,
+	orgRepo *repository.OrganizationRepository,
+	capturePolicyService CapturePolicyService,
+	deviceApprovals DeviceApprovalService,
+	featureFlags FeatureFlagService,
+) DeviceConfigService {
+	return &deviceConfigService{
+		deviceRepo:           deviceRepo,
+		workspaceRepo:        workspaceRepo,
+		orgRepo:              orgRepo,
+		capturePolicyService: capturePolicyService,
+		deviceApprovals:      deviceApprovals,
+		featureFlags:         featureFlags,
+	}
+}
+
+func (s *deviceConfigService) GetConfig(userID uint, deviceUUID string, workspaceID *uint) (*dto.DeviceConfigResponse, error) {
+	device, err := s.deviceRepo.FindByUUID(deviceUUID)
+	if err != nil {
+		return nil, err
+	}
+	if device == nil || device.UserID != userID {
+		return nil, apperror.NotFound("device not found")
+	}
+
+	features := map[string]bool{models.FeatureScreenshots: true}
+	for _, feature := range models.DesktopFeatures {
+		features[feature] = true
+	}
+	response := &dto.DeviceConfigResponse{
+		DeviceUUID:                device.DeviceUUID,
+		ScreenshotIntervalSeconds: defaultScreenshotIntervalSeconds,
+		ScreenshotBlurMode:        "none",
+		IdleThresholdSeconds:      defaultIdleThresholdSeconds,
+		Features:                  features,
+		CaptureExclusions:         []dto.CaptureExclusionRuleResponse{},
+		PollIntervalSeconds:       int(config.AppConfig.Sync.ConfigPollInterval.Seconds()),
+	}
+	if workspaceID == nil {
+		if err := s.applyFeatureFlags(response, userID); err != nil {
+			return nil, err
+		}
+		return response, nil
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(*workspaceID)
+	if err != nil {
+		return nil, apperror.NotFound("workspace not found")
+	}
+	isMember, _ := s.workspaceRepo.IsMember(workspace.ID, userID)
+	isOrgMember, _ := s.orgRepo.IsMember(workspace.OrganizationID, userID)
+	if !isMember && !isOrgMember {
+		return nil, apperror.Forbidden("access denied: not a member of this workspace or organization")
+	}
+
+	response.OrganizationID = &workspace.OrganizationID
+	response.WorkspaceID = &workspace.ID
+	response.ScreenshotsDisabled = workspace.ScreenshotsDisabled
+	response.ScreenshotIntervalSeconds = workspace.ScreenshotIntervalSeconds
+	response.ScreenshotBlurMode = workspace.ScreenshotBlurMode
+	response.ScreenshotMonitors = workspace.ScreenshotMonitors
+	response.IdleThresholdSeconds = workspace.IdleThresholdSeconds
+
+	features[models.FeatureScreenshots] = !workspace.ScreenshotsDisabled
+	for _, feature := range splitFeatures(workspace.DisabledFeatures) {
+		if _, known := features[feature]; known {
+			features[feature] = false
+		}
+	}
+
+	rules, err := s.capturePolicyService.ActiveRules(workspace.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	response.CaptureExclusions = toCaptureExclusionResponses(rules)
+
+	// Polling the configuration registers a new device for approval before
+	// its first sync
+	response.DeviceApproval, err = s.deviceApprovals.Status(device, userID, workspace.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyFeatureFlags(response, userID); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// applyFeatureFlags turns off the features whose flag is off for the user in
+// the document's organization
+func (s *deviceConfigService) applyFeatureFlags(response *dto.DeviceConfigResponse, userID uint) error {
+	flags, err := s.featureFlags.Evaluate(userID, response.OrganizationID)
+	if err != nil {
+		return err
+	}
+	for feature := range response.Features {
+		if on, defined := flags.Features[feature]; defined && !on {
+			response.Features[feature] = false
+		}
+	}
+	return nil
+}
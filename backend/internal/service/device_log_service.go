@@ -0,0 +1,276 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"mime/multipart"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/config"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+)
+
+// allowedDeviceLogExtensions lists the archive and text formats the desktop app sends
+var allowedDeviceLogExtensions = []string{".zip", ".gz", ".tgz", ".log", ".txt"}
+
+// DeviceLogService handles desktop app log bundles used to debug sync issues
+type DeviceLogService interface {
+	Upload(userID uint, req *dto.DeviceLogUploadRequest, file *multipart.FileHeader) (*dto.DeviceLogUploadResponse, error)
+
+	// Admin
+	List(params *dto.AdminDeviceLogListParams) (*dto.AdminDeviceLogListResponse, error)
+	Get(id uint) (*dto.AdminDeviceLogResponse, error)
+	GetFile(id uint) (*models.DeviceLogBundle, error)
+	Delete(id uint) error
+
+	// MovePublicBundles moves bundle files stored under the public uploads
+	// directory by earlier versions to the private one
+	MovePublicBundles() error
+
+	// Scheduled jobs
+	PurgeExpired(ctx context.Context) error
+}
+
+type deviceLogService struct {
+	deviceLogRepo repository.DeviceLogRepository
+	deviceRepo    repository.DeviceRepository
+	maxSize       int64
+	retention     time.Duration
+	maxPerDevice  int
+}
+
+// NewDeviceLogService creates a new device log service
+func NewDeviceLogService(deviceLogRepo repository.DeviceLogRepository, deviceRepo repository.DeviceRepository) DeviceLogService {
+	cfg := config.AppConfig.DeviceLog
+	return &deviceLogService{
+		deviceLogRepo: deviceLogRepo,
+		deviceRepo:    deviceRepo,
+		maxSize:       cfg.MaxSize,
+		retention:     cfg.Retention,
+		maxPerDevice:  cfg.MaxPerDevice,
+	}
+}
+
+// Upload stores a log bundle for one of the user's registered devices
+func (s *deviceLogService) Upload(userID uint, req *dto.DeviceLogUploadRequest, file *multipart.FileHeader) (*dto.DeviceLogUploadResponse, error) {
+	if file.Size > s.maxSize {
+		return nil, apperror.Validation(fmt.Sprintf("log bundle exceeds the maximum size of %d bytes", s.maxSize), nil)
+	}
+	if !isAllowedDeviceLogFile(file.Filename) {
+		return nil, apperror.Validation(fmt.Sprintf("unsupported log bundle type; allowed: %s", strings.Join(allowedDeviceLogExtensions, ", ")), nil)
+	}
+
+	device, err := s.deviceRepo.FindByUUID(req.DeviceUUID)
+	if err != nil {
+		return nil, err
+	}
+	if device == nil || device.UserID != userID {
+		return nil, apperror.NotFound("device not found")
+	}
+
+	filePath, fileName, err := utils.SavePrivateUploadedFile(file, filepath.Join("device-logs", fmt.Sprintf("%d", device.ID)))
+	if err != nil {
+		return nil, err
+	}
+
+	appVersion := req.AppVersion
+	if appVersion == "" {
+		appVersion = device.AppVersion
+	}
+
+	bundle := &models.DeviceLogBundle{
+		UserID:       userID,
+		DeviceID:     device.ID,
+		FilePath:     filePath,
+		FileName:     fileName,
+		OriginalName: filepath.Base(file.Filename),
+		FileSize:     file.Size,
+		AppVersion:   appVersion,
+		Note:         req.Note,
+		ExpiresAt:    time.Now().Add(s.retention),
+	}
+	if err := s.deviceLogRepo.Create(bundle); err != nil {
+		_ = utils.DeleteFile(filePath)
+		return nil, errors.New("failed to save log bundle")
+	}
+
+	s.pruneDevice(device.ID)
+
+	return &dto.DeviceLogUploadResponse{
+		ID:        bundle.ID,
+		FileName:  bundle.OriginalName,
+		FileSize:  bundle.FileSize,
+		CreatedAt: bundle.CreatedAt,
+		ExpiresAt: bundle.ExpiresAt,
+	}, nil
+}
+
+// pruneDevice drops the oldest bundles beyond the per-device limit
+func (s *deviceLogService) pruneDevice(deviceID uint) {
+	if s.maxPerDevice <= 0 {
+		return
+	}
+
+	bundles, err := s.deviceLogRepo.FindByDeviceIDBeyond(deviceID, s.maxPerDevice)
+	if err != nil {
+		log.Printf("⚠️  Failed to load old log bundles of device %d: %v", deviceID, err)
+		return
+	}
+	for _, bundle := range bundles {
+		s.deleteBundle(&bundle)
+	}
+}
+
+func (s *deviceLogService) deleteBundle(bundle *models.DeviceLogBundle) {
+	if err := utils.DeleteFile(bundle.FilePath); err != nil {
+		log.Printf("⚠️  Failed to delete log bundle file %d: %v", bundle.ID, err)
+		return
+	}
+	_ = s.deviceLogRepo.Delete(bundle.ID)
+}
+
+func isAllowedDeviceLogFile(name string) bool {
+	name = strings.ToLower(name)
+	for _, ext := range allowedDeviceLogExtensions {
+		if strings.HasSuffix(name, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// ============================================================================
+// ADMIN
+// ============================================================================
+
+func (s *deviceLogService) List(params *dto.AdminDeviceLogListParams) (*dto.AdminDeviceLogListResponse, error) {
+	bundles, total, err := s.deviceLogRepo.FindWithFilters(params)
+	if err != nil {
+		return nil, err
+	}
+
+	logs := make([]dto.AdminDeviceLogResponse, 0, len(bundles))
+	for i := range bundles {
+		logs = append(logs, toAdminDeviceLogResponse(&bundles[i]))
+	}
+
+	totalPages := int((total + int64(params.PageSize) - 1) / int64(params.PageSize))
+
+	return &dto.AdminDeviceLogListResponse{
+		Logs: logs,
+		Pagination: dto.AdminPaginationResponse{
+			Page:       params.Page,
+			PageSize:   params.PageSize,
+			TotalItems: total,
+			TotalPages: totalPages,
+			HasNext:    params.Page < totalPages,
+			HasPrev:    params.Page > 1,
+		},
+	}, nil
+}
+
+func (s *deviceLogService) Get(id uint) (*dto.AdminDeviceLogResponse, error) {
+	bundle, err := s.deviceLogRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toAdminDeviceLogResponse(bundle)
+	return &response, nil
+}
+
+func (s *deviceLogService) GetFile(id uint) (*models.DeviceLogBundle, error) {
+	bundle, err := s.deviceLogRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if !utils.FileExists(bundle.FilePath) {
+		return nil, apperror.NotFound("log bundle file not found")
+	}
+	return bundle, nil
+}
+
+func (s *deviceLogService) Delete(id uint) error {
+	bundle, err := s.deviceLogRepo.FindByID(id)
+	if err != nil {
+		return err
+	}
+	if err := utils.DeleteFile(bundle.FilePath); err != nil {
+		return err
+	}
+	return s.deviceLogRepo.Delete(bundle.ID)
+}
+
+func (s *deviceLogService) MovePublicBundles() error {
+	publicDir := filepath.Clean(config.AppConfig.Upload.Path) + string(filepath.Separator)
+	bundles, err := s.deviceLogRepo.FindUnder(publicDir)
+	if err != nil {
+		return err
+	}
+
+	moved := 0
+	for i := range bundles {
+		bundle := &bundles[i]
+		filePath := filepath.Join(config.AppConfig.Upload.PrivatePath, "device-logs", fmt.Sprintf("%d", bundle.DeviceID), bundle.FileName)
+		if err := utils.MoveFile(bundle.FilePath, filePath); err != nil {
+			log.Printf("⚠️  Failed to move log bundle file %d: %v", bundle.ID, err)
+			continue
+		}
+		if err := s.deviceLogRepo.UpdatePath(bundle.ID, filePath); err != nil {
+			return err
+		}
+		moved++
+	}
+
+	if moved > 0 {
+		log.Printf("✅ Moved %d device log bundles out of the public uploads directory", moved)
+	}
+	return nil
+}
+
+// PurgeExpired deletes log bundles past their retention
+func (s *deviceLogService) PurgeExpired(ctx context.Context) error {
+	bundles, err := s.deviceLogRepo.FindExpired(time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to load expired log bundles: %w", err)
+	}
+
+	for i := range bundles {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		s.deleteBundle(&bundles[i])
+	}
+
+	return nil
+}
+
+func toAdminDeviceLogResponse(bundle *models.DeviceLogBundle) dto.AdminDeviceLogResponse {
+	return dto.AdminDeviceLogResponse{
+		ID:             bundle.ID,
+		UserID:         bundle.UserID,
+		UserEmail:      bundle.User.Email,
+		UserName:       strings.TrimSpace(bundle.User.FirstName + " " + bundle.User.LastName),
+		DeviceID:       bundle.DeviceID,
+		DeviceUUID:     bundle.Device.DeviceUUID,
+		DeviceName:     bundle.Device.DeviceName,
+		OS:             bundle.Device.OS,
+		OSVersion:      bundle.Device.OSVersion,
+		AppVersion:     bundle.AppVersion,
+		DeviceLastSeen: bundle.Device.LastSeenAt,
+		FileName:       bundle.FileName,
+		OriginalName:   bundle.OriginalName,
+		FileSize:       bundle.FileSize,
+		Note:           bundle.Note,
+		CreatedAt:      bundle.CreatedAt,
+		ExpiresAt:      bundle.ExpiresAt,
+	}
+}
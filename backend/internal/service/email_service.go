@@ -0,0 +1,378 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/config"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/format"
+	"github.com/beuphecan/remote-time-tracker/internal/mail"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	emailDeliveryBatch      = 100
+	weeklySummaryTopTasks   = 5
+	maxEmailErrorLength     = 1000
+	defaultEmailMaxAttempts = 6
+)
+
+// EmailService renders templated emails into the outbox and delivers them
+// through the configured provider. Queueing never fails the caller's
+// operation; problems are logged and retried by the delivery job.
+type EmailService interface {
+	// Transactional email, always sent
+	SendInvitation(invitation *models.Invitation, inviterName string)
+	SendWelcome(user *models.User)
+	SendPasswordReset(user *models.User, token string, ttl time.Duration)
+
+	// SendSavedReport queues a scheduled saved report with its file attached.
+	// Recipients chose none of it, so preferences do not apply.
+	SendSavedReport(recipient *models.User, data mail.SavedReportData, attachment mail.Attachment)
+
+	// Notification preferences
+	GetPreferences(userID uint) (*dto.NotificationPreferencesResponse, error)
+	UpdatePreferences(userID uint, req *dto.UpdateNotificationPreferencesRequest) (*dto.NotificationPreferencesResponse, error)
+
+	// Scheduled jobs
+	DeliverDue(ctx context.Context) error
+	PurgeOld(ctx context.Context) error
+	SendWeeklySummaries(ctx context.Context) error
+}
+
+type emailService struct {
+	emailRepo   repository.EmailRepository
+	sender      mail.Sender
+	appURL      string
+	maxAttempts int
+	retention   time.Duration
+}
+
+// NewEmailService creates a new email service
+func NewEmailService(emailRepo repository.EmailRepository, sender mail.Sender) EmailService {
+	cfg := config.AppConfig.Email
+	maxAttempts := cfg.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultEmailMaxAttempts
+	}
+	return &emailService{
+		emailRepo:   emailRepo,
+		sender:      sender,
+		appURL:      cfg.AppURL,
+		maxAttempts: maxAttempts,
+		retention:   cfg.Retention,
+	}
+}
+
+// ============================================================================
+// TRANSACTIONAL EMAIL
+// ============================================================================
+
+func (s *emailService) SendInvitation(invitation *models.Invitation, inviterName string) {
+	data := mail.InvitationData{
+		OrganizationName: invitation.Organization.Name,
+		InviterName:      inviterName,
+		Message:          invitation.Message,
+		AcceptURL:        invitationURL(invitation.Token),
+		ExpiresAt:        invitation.ExpiresAt,
+	}
+	if invitation.Workspace != nil {
+		data.WorkspaceName = invitation.Workspace.Name
+	}
+	s.enqueue(nil, invitation.Email, "", mail.TemplateInvitation, data)
+}
+
+func (s *emailService) SendWelcome(user *models.User) {
+	s.enqueue(&user.ID, user.Email, emailUserName(user), mail.TemplateWelcome, mail.WelcomeData{
+		Name:   emailUserName(user),
+		AppURL: s.appURL,
+	})
+}
+
+func (s *emailService) SendPasswordReset(user *models.User, token string, ttl time.Duration) {
+	s.enqueue(&user.ID, user.Email, emailUserName(user), mail.TemplatePasswordReset, mail.PasswordResetData{
+		Name:      emailUserName(user),
+		ResetURL:  s.link("/reset-password", url.Values{"token": {token}}),
+		ExpiresIn: humanizeTTL(ttl),
+	})
+}
+
+func (s *emailService) SendSavedReport(recipient *models.User, data mail.SavedReportData, attachment mail.Attachment) {
+	data.Name = emailUserName(recipient)
+	data.ReportsURL = s.link("/reports", nil)
+	s.enqueue(&recipient.ID, recipient.Email, emailUserName(recipient), mail.TemplateSavedReport, data, attachment)
+}
+
+// enqueue renders the template and queues it for the delivery job
+func (s *emailService) enqueue(userID *uint, to, toName, template string, data interface{}, attachments ...mail.Attachment) {
+	msg, err := mail.Render(template, to, toName, data)
+	if err != nil {
+		log.Printf("⚠️  Failed to render %s email: %v", template, err)
+		return
+	}
+
+	now := time.Now()
+	email := &models.EmailOutbox{
+		UserID:        userID,
+		ToEmail:       msg.To,
+		ToName:        msg.ToName,
+		Template:      template,
+		Subject:       msg.Subject,
+		TextBody:      msg.Text,
+		HTMLBody:      msg.HTML,
+		Status:        models.EmailStatusPending,
+		NextAttemptAt: &now,
+	}
+	for _, attachment := range attachments {
+		email.Attachments = append(email.Attachments, models.EmailAttachment{
+			Filename:    attachment.Filename,
+			ContentType: attachment.ContentType,
+			Data:        attachment.Data,
+		})
+	}
+	if err := s.emailRepo.Enqueue(email); err != nil {
+		log.Printf("⚠️  Failed to queue %s email to %s: %v", template, to, err)
+	}
+}
+
+// ============================================================================
+// PREFERENCES
+// ============================================================================
+
+func (s *emailService) GetPreferences(userID uint) (*dto.NotificationPreferencesResponse, error) {
+	pref, err := s.findPreference(userID)
+	if err != nil {
+		return nil, err
+	}
+	return toNotificationPreferencesResponse(pref), nil
+}
+
+func (s *emailService) UpdatePreferences(userID uint, req *dto.UpdateNotificationPreferencesRequest) (*dto.NotificationPreferencesResponse, error) {
+	pref, err := s.findPreference(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.EmailEnabled != nil {
+		pref.EmailEnabled = *req.EmailEnabled
+	}
+	if req.EmailWeeklySummary != nil {
+		pref.EmailWeeklySummary = *req.EmailWeeklySummary
+	}
+
+	if err := s.emailRepo.SavePreference(pref); err != nil {
+		return nil, errors.New("failed to update notification preferences")
+	}
+	return toNotificationPreferencesResponse(pref), nil
+}
+
+// findPreference returns the user's preferences, or unsaved defaults
+func (s *emailService) findPreference(userID uint) (*models.NotificationPreference, error) {
+	pref, err := s.emailRepo.FindPreference(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		defaults := models.DefaultNotificationPreference(userID)
+		return &defaults, nil
+	}
+	if err != nil {
+		return nil, errors.New("failed to load notification preferences")
+	}
+	return pref, nil
+}
+
+// ============================================================================
+// SCHEDULED JOBS
+// ============================================================================
+
+// DeliverDue sends queued emails whose next attempt is due
+func (s *emailService) DeliverDue(ctx context.Context) error {
+	emails, err := s.emailRepo.FindDue(time.Now(), emailDeliveryBatch)
+	if err != nil {
+		return fmt.Errorf("failed to load due emails: %w", err)
+	}
+
+	for i := range emails {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		s.deliver(ctx, &emails[i])
+	}
+	return nil
+}
+
+func (s *emailService) deliver(ctx context.Context, email *models.EmailOutbox) {
+	msg := mail.Message{
+		To:      email.ToEmail,
+		ToName:  email.ToName,
+		Subject: email.Subject,
+		Text:    email.TextBody,
+		HTML:    email.HTMLBody,
+	}
+	for _, attachment := range email.Attachments {
+		msg.Attachments = append(msg.Attachments, mail.Attachment{
+			Filename:    attachment.Filename,
+			ContentType: attachment.ContentType,
+			Data:        attachment.Data,
+		})
+	}
+	err := s.sender.Send(ctx, msg)
+
+	now := time.Now()
+	email.Attempts++
+	if err == nil {
+		email.Status = models.EmailStatusSent
+		email.SentAt = &now
+		email.NextAttemptAt = nil
+		email.LastError = ""
+	} else {
+		email.LastError = truncateText(err.Error(), maxEmailErrorLength)
+		if email.Attempts >= s.maxAttempts {
+			email.Status = models.EmailStatusFailed
+			email.NextAttemptAt = nil
+			log.Printf("⚠️  Giving up on %s email %d to %s: %v", email.Template, email.ID, email.ToEmail, err)
+		} else {
+			next := now.Add(webhookRetryBackoff(email.Attempts))
+			email.NextAttemptAt = &next
+		}
+	}
+
+	if err := s.emailRepo.Update(email); err != nil {
+		log.Printf("⚠️  Failed to update email %d: %v", email.ID, err)
+	}
+}
+
+// PurgeOld deletes sent and failed emails past the outbox retention
+func (s *emailService) PurgeOld(ctx context.Context) error {
+	deleted, err := s.emailRepo.DeleteFinishedBefore(time.Now().Add(-s.retention))
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		log.Printf("✅ Purged %d old emails from the outbox", deleted)
+	}
+	return nil
+}
+
+// SendWeeklySummaries queues last week's (Monday to Sunday, UTC) summary for
+// every user who tracked time and has not opted out. Users already sent this
+// week's summary are skipped, so a rerun does not send duplicates.
+func (s *emailService) SendWeeklySummaries(ctx context.Context) error {
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	end := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7)) // This Monday
+	start := end.AddDate(0, 0, -7)
+
+	users, err := s.emailRepo.FindWeeklySummaryRecipients(start, end)
+	if err != nil {
+		return fmt.Errorf("failed to load weekly summary recipients: %w", err)
+	}
+
+	queued := 0
+	for i := range users {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		user := &users[i]
+
+		sent, err := s.emailRepo.HasQueued(user.ID, mail.TemplateWeeklySummary, end)
+		if err != nil || sent {
+			continue
+		}
+
+		data, err := s.weeklySummary(user, start, end)
+		if err != nil {
+			log.Printf("⚠️  Failed to build weekly summary for user %d: %v", user.ID, err)
+			continue
+		}
+		s.enqueue(&user.ID, user.Email, data.Name, mail.TemplateWeeklySummary, data)
+		queued++
+	}
+
+	if queued > 0 {
+		log.Printf("✅ Queued %d weekly summaries", queued)
+	}
+	return nil
+}
+
+func (s *emailService) weeklySummary(user *models.User, start, end time.Time) (*mail.WeeklySummaryData, error) {
+	workspaces, err := s.emailRepo.WorkspaceTotals(user.ID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := s.emailRepo.TopTasks(user.ID, start, end, weeklySummaryTopTasks)
+	if err != nil {
+		return nil, err
+	}
+
+	var total int64
+	for _, row := range workspaces {
+		total += row.Duration
+	}
+
+	return &mail.WeeklySummaryData{
+		Name:           emailUserName(user),
+		PeriodStart:    start,
+		PeriodEnd:      end.AddDate(0, 0, -1),
+		Total:          format.Duration(total, format.DefaultLocale),
+		Workspaces:     toSummaryLines(workspaces),
+		Tasks:          toSummaryLines(tasks),
+		PreferencesURL: s.link("/settings/notifications", nil),
+	}, nil
+}
+
+// ============================================================================
+// HELPERS
+// ============================================================================
+
+// link builds a frontend URL
+func (s *emailService) link(path string, query url.Values) string {
+	link := s.appURL + path
+	if len(query) > 0 {
+		link += "?" + query.Encode()
+	}
+	return link
+}
+
+// humanizeTTL spells out a link lifetime, e.g. "1 hour" or "30 minutes"
+func humanizeTTL(ttl time.Duration) string {
+	n, unit := int(ttl/time.Minute), "minute"
+	if ttl >= time.Hour && ttl%time.Hour == 0 {
+		n, unit = int(ttl/time.Hour), "hour"
+	}
+	if n != 1 {
+		unit += "s"
+	}
+	return fmt.Sprintf("%d %s", n, unit)
+}
+
+func emailUserName(user *models.User) string {
+	if name := strings.TrimSpace(user.FirstName + " " + user.LastName); name != "" {
+		return name
+	}
+	return user.Email
+}
+
+func toSummaryLines(rows []repository.EmailSummaryRow) []mail.SummaryLine {
+	lines := make([]mail.SummaryLine, 0, len(rows))
+	for _, row := range rows {
+		lines = append(lines, mail.SummaryLine{
+			Name:     row.Name,
+			Duration: format.Duration(row.Duration, format.DefaultLocale),
+		})
+	}
+	return lines
+}
+
+func toNotificationPreferencesResponse(pref *models.NotificationPreference) *dto.NotificationPreferencesResponse {
+	return &dto.NotificationPreferencesResponse{
+		EmailEnabled:       pref.EmailEnabled,
+		EmailWeeklySummary: pref.EmailWeeklySummary,
+	}
+}
@@ -0,0 +1,253 @@
+package service
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"strings"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	minEncryptionKeyBits  = 2048
+	defaultRekeyBatchSize = 100
+	maxRekeyBatchSize     = 500
+)
+
+var (
+	// ErrInvalidEncryptionKey is returned for public keys that are not PEM encoded RSA keys of at least 2048 bits
+	ErrInvalidEncryptionKey = apperror.Validation("public key must be a PEM encoded RSA key of at least 2048 bits", nil)
+	// ErrEncryptionKeyExists is returned when the key is already registered for the organization
+	ErrEncryptionKeyExists = apperror.Conflict("encryption key is already registered")
+	// ErrEncryptionKeyNotFound is returned for unknown keys
+	ErrEncryptionKeyNotFound = apperror.NotFound("encryption key not found")
+	// ErrEncryptionKeyNotActive is returned when re-keying to a key other than the active one
+	ErrEncryptionKeyNotActive = apperror.Conflict("screenshots can only be re-keyed to the active key")
+)
+
+// EncryptionKeyService manages the organization public keys desktop agents
+// encrypt screenshots for, and re-keying screenshots after a key rotation
+type EncryptionKeyService interface {
+	// ListKeys lists the organization's keys; agents encrypt for the active one
+	ListKeys(orgID, userID uint) ([]dto.EncryptionKeyResponse, error)
+
+	// Key management (owner/admin)
+	RegisterKey(orgID, userID uint, req *dto.RegisterEncryptionKeyRequest) (*dto.EncryptionKeyResponse, error)
+	ListKeyScreenshots(orgID, keyID, userID uint, limit int) ([]dto.EncryptedScreenshotKey, error)
+	RekeyScreenshots(orgID, userID uint, req *dto.RekeyScreenshotsRequest) (*dto.RekeyScreenshotsResponse, error)
+
+	// ValidateUploadKey checks that an encrypted upload references a key
+	// registered for the organization; retired keys are still accepted so
+	// agents that have not picked up a rotation yet do not lose screenshots
+	ValidateUploadKey(orgID uint, fingerprint string) error
+}
+
+type encryptionKeyService struct {
+	keyRepo repository.EncryptionKeyRepository
+	orgRepo *repository.OrganizationRepository
+}
+
+// NewEncryptionKeyService creates a new encryption key service
+func NewEncryptionKeyService(
+	keyRepo repository.EncryptionKeyRepository,
+	orgRepo *repository.OrganizationRepository,
+) EncryptionKeyService {
+	return &encryptionKeyService{
+		keyRepo: keyRepo,
+		orgRepo: orgRepo,
+	}
+}
+
+func (s *encryptionKeyService) requireAdmin(orgID, userID uint) error {
+	isAdmin, err := s.orgRepo.IsAdmin(orgID, userID)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return apperror.Forbidden("access denied: only admins can manage encryption keys")
+	}
+	return nil
+}
+
+func (s *encryptionKeyService) ListKeys(orgID, userID uint) ([]dto.EncryptionKeyResponse, error) {
+	isMember, err := s.orgRepo.IsMember(orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, apperror.Forbidden("access denied: not a member of this organization")
+	}
+
+	keys, err := s.keyRepo.FindByOrg(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.EncryptionKeyResponse, 0, len(keys))
+	for i := range keys {
+		responses = append(responses, toEncryptionKeyResponse(&keys[i]))
+	}
+	return responses, nil
+}
+
+func (s *encryptionKeyService) RegisterKey(orgID, userID uint, req *dto.RegisterEncryptionKeyRequest) (*dto.EncryptionKeyResponse, error) {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return nil, err
+	}
+
+	publicKey := strings.TrimSpace(req.PublicKey)
+	fingerprint, err := encryptionKeyFingerprint(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.keyRepo.FindByFingerprint(orgID, fingerprint); err == nil {
+		return nil, ErrEncryptionKeyExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	key := &models.ScreenshotEncryptionKey{
+		OrganizationID: orgID,
+		Fingerprint:    fingerprint,
+		PublicKey:      publicKey,
+		Algorithm:      models.EncryptionKeyAlgorithm,
+		CreatedBy:      userID,
+	}
+	if err := s.keyRepo.Activate(key); err != nil {
+		return nil, err
+	}
+
+	response := toEncryptionKeyResponse(key)
+	return &response, nil
+}
+
+func (s *encryptionKeyService) ListKeyScreenshots(orgID, keyID, userID uint, limit int) ([]dto.EncryptedScreenshotKey, error) {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return nil, err
+	}
+
+	key, err := s.keyRepo.FindByID(orgID, keyID)
+	if err != nil {
+		return nil, ErrEncryptionKeyNotFound
+	}
+
+	if limit < 1 || limit > maxRekeyBatchSize {
+		limit = defaultRekeyBatchSize
+	}
+	screenshots, err := s.keyRepo.FindScreenshotsByFingerprint(orgID, key.Fingerprint, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	envelopes := make([]dto.EncryptedScreenshotKey, 0, len(screenshots))
+	for i := range screenshots {
+		envelopes = append(envelopes, toEncryptedScreenshotKey(&screenshots[i]))
+	}
+	return envelopes, nil
+}
+
+func (s *encryptionKeyService) RekeyScreenshots(orgID, userID uint, req *dto.RekeyScreenshotsRequest) (*dto.RekeyScreenshotsResponse, error) {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return nil, err
+	}
+
+	key, err := s.keyRepo.FindByFingerprint(orgID, req.KeyFingerprint)
+	if err != nil {
+		return nil, ErrEncryptionKeyNotFound
+	}
+	if key.Status != models.EncryptionKeyActive {
+		return nil, ErrEncryptionKeyNotActive
+	}
+
+	response := &dto.RekeyScreenshotsResponse{Skipped: []uint{}}
+	for _, item := range req.Screenshots {
+		updated, err := s.keyRepo.UpdateScreenshotKey(orgID, item.ScreenshotID, key.Fingerprint, item.WrappedKey)
+		if err != nil {
+			return nil, err
+		}
+		if !updated {
+			response.Skipped = append(response.Skipped, item.ScreenshotID)
+			continue
+		}
+		response.Updated++
+	}
+	return response, nil
+}
+
+func (s *encryptionKeyService) ValidateUploadKey(orgID uint, fingerprint string) error {
+	if _, err := s.keyRepo.FindByFingerprint(orgID, fingerprint); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrEncryptionKeyNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// encryptionKeyFingerprint validates a PEM encoded RSA public key and returns
+// the hex SHA256 of its DER encoding
+func encryptionKeyFingerprint(publicKey string) (string, error) {
+	block, _ := pem.Decode([]byte(publicKey))
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return "", ErrInvalidEncryptionKey
+	}
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return "", ErrInvalidEncryptionKey
+	}
+	rsaKey, ok := parsed.(*rsa.PublicKey)
+	if !ok || rsaKey.N.BitLen() < minEncryptionKeyBits {
+		return "", ErrInvalidEncryptionKey
+	}
+
+	sum := sha256.Sum256(block.Bytes)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func toEncryptionKeyResponse(key *models.ScreenshotEncryptionKey) dto.EncryptionKeyResponse {
+	return dto.EncryptionKeyResponse{
+		ID:          key.ID,
+		Fingerprint: key.Fingerprint,
+		PublicKey:   key.PublicKey,
+		Algorithm:   key.Algorithm,
+		Status:      key.Status,
+		CreatedBy:   key.CreatedBy,
+		CreatedAt:   key.CreatedAt,
+		RetiredAt:   key.RetiredAt,
+	}
+}
+
+// toEncryptedScreenshotKey returns the encryption envelope of a screenshot
+func toEncryptedScreenshotKey(screenshot *models.Screenshot) dto.EncryptedScreenshotKey {
+	return dto.EncryptedScreenshotKey{
+		ScreenshotID:   screenshot.ID,
+		KeyFingerprint: screenshot.KeyFingerprint,
+		WrappedKey:     screenshot.WrappedKey,
+		Nonce:          screenshot.EncryptionNonce,
+	}
+}
+
+// ToEncryptedScreenshotResponse wraps an encrypted screenshot file with what
+// clients need to decrypt it
+func ToEncryptedScreenshotResponse(screenshot *models.Screenshot, data []byte) *dto.EncryptedScreenshotResponse {
+	return &dto.EncryptedScreenshotResponse{
+		EncryptedScreenshotKey: toEncryptedScreenshotKey(screenshot),
+		KeyAlgorithm:           models.EncryptionKeyAlgorithm,
+		ContentAlgorithm:       models.EncryptionContentAlgorithm,
+		FileName:               screenshot.FileName,
+		MimeType:               screenshot.MimeType,
+		Checksum:               screenshot.Checksum,
+		CapturedAt:             screenshot.CapturedAt,
+		Data:                   base64.StdEncoding.EncodeToString(data),
+	}
+}
@@ -0,0 +1,279 @@
+package service
+
+import (
+	"fmt"
+	"hash/fnv"
+	"log"
+	"strings"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+)
+
+// FeatureFlagService evaluates feature flags for users and organizations and
+// lets system admins roll capabilities out gradually
+type FeatureFlagService interface {
+	// Evaluate returns every flag's state for the user, in the organization
+	// when orgID is set
+	Evaluate(userID uint, orgID *uint) (*dto.FeaturesResponse, error)
+	// IsEnabled tells whether the flag is on for the user in the
+	// organization; orgID may be zero. Undefined flags are on, so gating a
+	// capability changes nothing until its flag is created.
+	IsEnabled(key string, orgID, userID uint) bool
+
+	// Flag management (system admin)
+	ListFlags() ([]dto.FeatureFlagResponse, error)
+	CreateFlag(req *dto.CreateFeatureFlagRequest) (*dto.FeatureFlagResponse, error)
+	UpdateFlag(flagID uint, req *dto.UpdateFeatureFlagRequest) (*dto.FeatureFlagResponse, error)
+	DeleteFlag(flagID uint) error
+	SetOverride(flagID, adminID uint, req *dto.SetFeatureFlagOverrideRequest) (*dto.FeatureFlagResponse, error)
+	DeleteOverride(flagID, overrideID uint) error
+}
+
+type featureFlagService struct {
+	flagRepo repository.FeatureFlagRepository
+	orgRepo  *repository.OrganizationRepository
+}
+
+// NewFeatureFlagService creates a new feature flag service
+func NewFeatureFlagService(flagRepo repository.FeatureFlagRepository, orgRepo *repository.OrganizationRepository) FeatureFlagService {
+	return &featureFlagService{
+		flagRepo: flagRepo,
+		orgRepo:  orgRepo,
+	}
+}
+
+// ============================================================================
+// EVALUATION
+// ============================================================================
+
+func (s *featureFlagService) Evaluate(userID uint, orgID *uint) (*dto.FeaturesResponse, error) {
+	var org uint
+	if orgID != nil {
+		isMember, err := s.orgRepo.IsMember(*orgID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !isMember {
+			return nil, apperror.Forbidden("access denied: not a member of this organization")
+		}
+		org = *orgID
+	}
+
+	flags, err := s.flagRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	overrides, err := s.flagRepo.FindOverridesFor(org, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	features := make(map[string]bool, len(flags))
+	for i := range flags {
+		features[flags[i].Key] = evaluateFlag(&flags[i], overrides, org, userID)
+	}
+	return &dto.FeaturesResponse{OrganizationID: orgID, Features: features}, nil
+}
+
+func (s *featureFlagService) IsEnabled(key string, orgID, userID uint) bool {
+	flag, err := s.flagRepo.FindByKey(key)
+	if err != nil {
+		return true
+	}
+	overrides, err := s.flagRepo.FindOverridesFor(orgID, userID)
+	if err != nil {
+		log.Printf("⚠️  Failed to load feature flag overrides: %v", err)
+	}
+	return evaluateFlag(flag, overrides, orgID, userID)
+}
+
+// evaluateFlag applies, in order: the user's override, the organization's
+// override, the flag's global state and the rollout percentage. Rollout
+// buckets organizations (users outside one), so an organization's members
+// see the same state.
+func evaluateFlag(flag *models.FeatureFlag, overrides []models.FeatureFlagOverride, orgID, userID uint) bool {
+	var orgOverride *bool
+	for i := range overrides {
+		o := overrides[i]
+		if o.FlagID != flag.ID {
+			continue
+		}
+		if userID != 0 && o.UserID == userID {
+			return o.Enabled
+		}
+		if orgID != 0 && o.OrganizationID == orgID {
+			orgOverride = &o.Enabled
+		}
+	}
+	if orgOverride != nil {
+		return *orgOverride
+	}
+	if flag.Enabled {
+		return true
+	}
+	if flag.RolloutPercent <= 0 {
+		return false
+	}
+
+	target := fmt.Sprintf("org:%d", orgID)
+	if orgID == 0 {
+		target = fmt.Sprintf("user:%d", userID)
+	}
+	return rolloutBucket(flag.Key, target) < flag.RolloutPercent
+}
+
+// rolloutBucket places the target in one of 100 buckets, independently per
+// flag so the same organizations are not always first
+func rolloutBucket(key, target string) int {
+	h := fnv.New32a()
+	h.Write([]byte(key + "/" + target))
+	return int(h.Sum32() % 100)
+}
+
+// ============================================================================
+// FLAG MANAGEMENT
+// ============================================================================
+
+func (s *featureFlagService) ListFlags() ([]dto.FeatureFlagResponse, error) {
+	flags, err := s.flagRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	responses := make([]dto.FeatureFlagResponse, 0, len(flags))
+	for i := range flags {
+		responses = append(responses, toFeatureFlagResponse(&flags[i]))
+	}
+	return responses, nil
+}
+
+func (s *featureFlagService) CreateFlag(req *dto.CreateFeatureFlagRequest) (*dto.FeatureFlagResponse, error) {
+	if existing, _ := s.flagRepo.FindByKey(req.Key); existing != nil {
+		return nil, apperror.Conflict("feature flag " + req.Key + " already exists")
+	}
+
+	flag := &models.FeatureFlag{
+		Key:            req.Key,
+		Description:    strings.TrimSpace(req.Description),
+		Enabled:        req.Enabled,
+		RolloutPercent: req.RolloutPercent,
+	}
+	if err := s.flagRepo.Create(flag); err != nil {
+		return nil, err
+	}
+
+	response := toFeatureFlagResponse(flag)
+	return &response, nil
+}
+
+func (s *featureFlagService) UpdateFlag(flagID uint, req *dto.UpdateFeatureFlagRequest) (*dto.FeatureFlagResponse, error) {
+	flag, err := s.flagRepo.FindByID(flagID)
+	if err != nil {
+		return nil, apperror.NotFound("feature flag not found")
+	}
+
+	if req.Description != nil {
+		flag.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.Enabled != nil {
+		flag.Enabled = *req.Enabled
+	}
+	if req.RolloutPercent != nil {
+		flag.RolloutPercent = *req.RolloutPercent
+	}
+	if err := s.flagRepo.Update(flag); err != nil {
+		return nil, err
+	}
+
+	response := toFeatureFlagResponse(flag)
+	return &response, nil
+}
+
+func (s *featureFlagService) DeleteFlag(flagID uint) error {
+	if _, err := s.flagRepo.FindByID(flagID); err != nil {
+		return apperror.NotFound("feature flag not found")
+	}
+	return s.flagRepo.Delete(flagID)
+}
+
+func (s *featureFlagService) SetOverride(flagID, adminID uint, req *dto.SetFeatureFlagOverrideRequest) (*dto.FeatureFlagResponse, error) {
+	if (req.OrganizationID == nil) == (req.UserID == nil) {
+		return nil, apperror.Validation("set either organization_id or user_id", nil)
+	}
+	if _, err := s.flagRepo.FindByID(flagID); err != nil {
+		return nil, apperror.NotFound("feature flag not found")
+	}
+
+	override := &models.FeatureFlagOverride{
+		FlagID:    flagID,
+		Enabled:   req.Enabled,
+		CreatedBy: adminID,
+	}
+	if req.OrganizationID != nil {
+		if _, err := s.orgRepo.GetByID(*req.OrganizationID); err != nil {
+			return nil, apperror.NotFound("organization not found")
+		}
+		override.OrganizationID = *req.OrganizationID
+	} else {
+		override.UserID = *req.UserID
+	}
+	if err := s.flagRepo.UpsertOverride(override); err != nil {
+		return nil, err
+	}
+
+	flag, err := s.flagRepo.FindByID(flagID)
+	if err != nil {
+		return nil, err
+	}
+	response := toFeatureFlagResponse(flag)
+	return &response, nil
+}
+
+func (s *featureFlagService) DeleteOverride(flagID, overrideID uint) error {
+	deleted, err := s.flagRepo.DeleteOverride(flagID, overrideID)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return apperror.NotFound("feature flag override not found")
+	}
+	return nil
+}
+
+// ============================================================================
+// HELPER FUNCTIONS
+// ============================================================================
+
+func toFeatureFlagResponse(flag *models.FeatureFlag) dto.FeatureFlagResponse {
+	overrides := make([]dto.FeatureFlagOverrideResponse, 0, len(flag.Overrides))
+	for _, o := range flag.Overrides {
+		override := dto.FeatureFlagOverrideResponse{
+			ID:        o.ID,
+			Enabled:   o.Enabled,
+			CreatedBy: o.CreatedBy,
+			UpdatedAt: o.UpdatedAt,
+		}
+		if o.OrganizationID != 0 {
+			orgID := o.OrganizationID
+			override.OrganizationID = &orgID
+		}
+		if o.UserID != 0 {
+			userID := o.UserID
+			override.UserID = &userID
+		}
+		overrides = append(overrides, override)
+	}
+
+	return dto.FeatureFlagResponse{
+		ID:             flag.ID,
+		Key:            flag.Key,
+		Description:    flag.Description,
+		Enabled:        flag.Enabled,
+		RolloutPercent: flag.RolloutPercent,
+		Overrides:      overrides,
+		CreatedAt:      flag.CreatedAt,
+		UpdatedAt:      flag.UpdatedAt,
+	}
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+)
+
+// Health probe statuses
+const (
+	HealthStatusOK           = "ok"
+	HealthStatusUnavailable  = "unavailable"
+	HealthStatusShuttingDown = "shutting_down"
+	healthCheckFailed        = "failed"
+)
+
+// healthCheckTimeout bounds each dependency check of a readiness probe
+const healthCheckTimeout = 2 * time.Second
+
+// HealthService answers liveness and readiness probes
+type HealthService interface {
+	// Liveness reports that the process is serving requests. It does not
+	// check dependencies so a database outage does not restart every pod.
+	Liveness() *dto.HealthResponse
+	// Readiness checks the database and upload storage; ready is false when a
+	// check fails or the server is shutting down.
+	Readiness(ctx context.Context) (resp *dto.HealthResponse, ready bool)
+	// MarkShuttingDown makes readiness fail so load balancers stop routing
+	// new requests while in-flight ones drain
+	MarkShuttingDown()
+}
+
+type healthService struct {
+	healthRepo   repository.HealthRepository
+	uploadPath   string
+	shuttingDown atomic.Bool
+}
+
+// NewHealthService creates a new health service
+func NewHealthService(healthRepo repository.HealthRepository, uploadPath string) HealthService {
+	return &healthService{
+		healthRepo: healthRepo,
+		uploadPath: uploadPath,
+	}
+}
+
+func (s *healthService) Liveness() *dto.HealthResponse {
+	return &dto.HealthResponse{Status: HealthStatusOK, Time: time.Now().UTC()}
+}
+
+func (s *healthService) Readiness(ctx context.Context) (*dto.HealthResponse, bool) {
+	resp := &dto.HealthResponse{
+		Status: HealthStatusOK,
+		Checks: map[string]dto.HealthCheckResult{
+			"database": runHealthCheck(ctx, s.healthRepo.Ping),
+			"storage":  runHealthCheck(ctx, s.checkStorage),
+		},
+		Time: time.Now().UTC(),
+	}
+
+	for _, check := range resp.Checks {
+		if check.Status != HealthStatusOK {
+			resp.Status = HealthStatusUnavailable
+		}
+	}
+	if s.shuttingDown.Load() {
+		resp.Status = HealthStatusShuttingDown
+	}
+	return resp, resp.Status == HealthStatusOK
+}
+
+func (s *healthService) MarkShuttingDown() {
+	s.shuttingDown.Store(true)
+}
+
+// checkStorage verifies screenshots can be written to the private upload directory
+func (s *healthService) checkStorage(ctx context.Context) error {
+	f, err := os.CreateTemp(filepath.Join(s.uploadPath, "screenshots"), ".readyz-*")
+	if err != nil {
+		return err
+	}
+	name := f.Name()
+	f.Close()
+	return os.Remove(name)
+}
+
+func runHealthCheck(ctx context.Context, check func(ctx context.Context) error) dto.HealthCheckResult {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+	result := dto.HealthCheckResult{
+		Status:    HealthStatusOK,
+		LatencyMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		result.Status = healthCheckFailed
+		result.Error = err.Error()
+	}
+	return result
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/calendar"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+)
+
+var (
+	// ErrHolidayNotFound is returned for unknown holidays
+	ErrHolidayNotFound = apperror.NotFound("holiday not found")
+	// ErrHolidayExists is returned when the date already has a holiday
+	ErrHolidayExists = apperror.Conflict("this date already has a holiday")
+)
+
+// HolidayService manages organization holiday calendars. Holidays are not
+// working days, so they are not expected to have tracked time.
+type HolidayService interface {
+	ListPresets() []dto.HolidayPresetResponse
+	List(orgID, userID uint, year int) ([]dto.HolidayResponse, error)
+	Create(orgID, userID uint, req *dto.CreateHolidayRequest) (*dto.HolidayResponse, error)
+	ImportPreset(orgID, userID uint, req *dto.ImportHolidayPresetRequest) (*dto.ImportHolidayPresetResponse, error)
+	Delete(orgID, holidayID, userID uint) error
+}
+
+type holidayService struct {
+	holidayRepo repository.HolidayRepository
+	orgRepo     *repository.OrganizationRepository
+}
+
+// NewHolidayService creates a new holiday service
+func NewHolidayService(holidayRepo repository.HolidayRepository, orgRepo *repository.OrganizationRepository) HolidayService {
+	return &holidayService{
+		holidayRepo: holidayRepo,
+		orgRepo:     orgRepo,
+	}
+}
+
+func (s *holidayService) requireAdmin(orgID, userID uint) error {
+	isAdmin, err := s.orgRepo.IsAdmin(orgID, userID)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return apperror.Forbidden("access denied: only admins can manage holidays")
+	}
+	return nil
+}
+
+func (s *holidayService) ListPresets() []dto.HolidayPresetResponse {
+	countries := calendar.HolidayPresetCountries()
+	presets := make([]dto.HolidayPresetResponse, 0, len(countries))
+	for code, name := range countries {
+		presets = append(presets, dto.HolidayPresetResponse{Country: code, Name: name})
+	}
+	sort.Slice(presets, func(i, j int) bool { return presets[i].Country < presets[j].Country })
+	return presets
+}
+
+func (s *holidayService) List(orgID, userID uint, year int) ([]dto.HolidayResponse, error) {
+	isMember, err := s.orgRepo.IsMember(orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, apperror.Forbidden("access denied: not a member of this organization")
+	}
+
+	if year == 0 {
+		year = time.Now().Year()
+	}
+	holidays, err := s.holidayRepo.FindByOrg(orgID,
+		time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		return nil, err
+	}
+	return toHolidayResponses(holidays), nil
+}
+
+func (s *holidayService) Create(orgID, userID uint, req *dto.CreateHolidayRequest) (*dto.HolidayResponse, error) {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return nil, err
+	}
+
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return nil, apperror.Validation("invalid date: use YYYY-MM-DD", nil)
+	}
+
+	created, err := s.holidayRepo.CreateMissing([]models.OrganizationHoliday{{
+		OrganizationID: orgID,
+		Date:           date,
+		Name:           strings.TrimSpace(req.Name),
+		CreatedBy:      &userID,
+	}})
+	if err != nil {
+		return nil, err
+	}
+	if len(created) == 0 {
+		return nil, ErrHolidayExists
+	}
+
+	response := toHolidayResponse(&created[0])
+	return &response, nil
+}
+
+// ImportPreset adds a country's public holidays for a year; dates that
+// already have a holiday keep it
+func (s *holidayService) ImportPreset(orgID, userID uint, req *dto.ImportHolidayPresetRequest) (*dto.ImportHolidayPresetResponse, error) {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return nil, err
+	}
+
+	country := strings.ToUpper(req.Country)
+	presets, err := calendar.PresetHolidays(country, req.Year)
+	if err != nil {
+		return nil, apperror.Validation(err.Error(), nil)
+	}
+
+	holidays := make([]models.OrganizationHoliday, 0, len(presets))
+	for _, preset := range presets {
+		holidays = append(holidays, models.OrganizationHoliday{
+			OrganizationID: orgID,
+			Date:           preset.Date,
+			Name:           preset.Name,
+			Country:        country,
+			CreatedBy:      &userID,
+		})
+	}
+	created, err := s.holidayRepo.CreateMissing(holidays)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.ImportHolidayPresetResponse{
+		Country:  country,
+		Year:     req.Year,
+		Added:    len(created),
+		Skipped:  len(holidays) - len(created),
+		Holidays: toHolidayResponses(created),
+	}, nil
+}
+
+func (s *holidayService) Delete(orgID, holidayID, userID uint) error {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return err
+	}
+
+	holiday, err := s.holidayRepo.FindByID(holidayID)
+	if err != nil || holiday.OrganizationID != orgID {
+		return ErrHolidayNotFound
+	}
+	return s.holidayRepo.Delete(holiday.ID)
+}
+
+func toHolidayResponses(holidays []models.OrganizationHoliday) []dto.HolidayResponse {
+	responses := make([]dto.HolidayResponse, 0, len(holidays))
+	for i := range holidays {
+		responses = append(responses, toHolidayResponse(&holidays[i]))
+	}
+	return responses
+}
+
+func toHolidayResponse(holiday *models.OrganizationHoliday) dto.HolidayResponse {
+	return dto.HolidayResponse{
+		ID:        holiday.ID,
+		Date:      holiday.Date.Format("2006-01-02"),
+		Name:      holiday.Name,
+		Country:   holiday.Country,
+		CreatedAt: holiday.CreatedAt,
+	}
+}
@@ -2,13 +2,21 @@ package service
 
 import (
 	"errors"
+	"net/url"
+	"strings"
 	"time"
 
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/config"
 	"github.com/beuphecan/remote-time-tracker/internal/dto"
 	"github.com/beuphecan/remote-time-tracker/internal/models"
 	"github.com/beuphecan/remote-time-tracker/internal/repository"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
 )
 
+// ErrInvitationLoginFailed is returned when accepting as an existing account with the wrong password
+var ErrInvitationLoginFailed = apperror.Unauthorized("invalid email or password")
+
 // InvitationService handles invitation business logic
 type InvitationService interface {
 	// Invitation CRUD
@@ -23,16 +31,23 @@ type InvitationService interface {
 
 	// Accept invitation
 	Accept(token string, userID uint) (*dto.OrganizationMemberResponse, error)
+	// AcceptWithAccount accepts from the invitation landing page: as the
+	// logged-in user when userID is set, otherwise by creating the invitee's
+	// account or logging into it with the request's password
+	AcceptWithAccount(token string, userID uint, req *dto.AcceptInvitationTokenRequest) (*dto.AcceptInvitationResponse, error)
 
 	// Maintenance
 	ExpireOldInvitations() error
 }
 
 type invitationService struct {
-	invitationRepo *repository.InvitationRepository
-	orgRepo        *repository.OrganizationRepository
-	workspaceRepo  *repository.WorkspaceRepository
-	userRepo       repository.UserRepository
+	invitationRepo      *repository.InvitationRepository
+	orgRepo             *repository.OrganizationRepository
+	workspaceRepo       *repository.WorkspaceRepository
+	userRepo            repository.UserRepository
+	webhookService      WebhookService
+	notificationService NotificationService
+	emailService        EmailService
 }
 
 // NewInvitationService creates a new invitation service
@@ -41,12 +56,18 @@ func NewInvitationService(
 	orgRepo *repository.OrganizationRepository,
 	workspaceRepo *repository.WorkspaceRepository,
 	userRepo repository.UserRepository,
+	webhookService WebhookService,
+	notificationService NotificationService,
+	emailService EmailService,
 ) InvitationService {
 	return &invitationService{
-		invitationRepo: invitationRepo,
-		orgRepo:        orgRepo,
-		workspaceRepo:  workspaceRepo,
-		userRepo:       userRepo,
+		invitationRepo:      invitationRepo,
+		orgRepo:             orgRepo,
+		workspaceRepo:       workspaceRepo,
+		userRepo:            userRepo,
+		webhookService:      webhookService,
+		notificationService: notificationService,
+		emailService:        emailService,
 	}
 }
 
@@ -61,7 +82,7 @@ func (s *invitationService) Create(orgID, inviterID uint, req *dto.CreateInvitat
 		return nil, err
 	}
 	if !isAdmin {
-		return nil, errors.New("access denied: only organization admins can create invitations")
+		return nil, apperror.Forbidden("access denied: only organization admins can create invitations")
 	}
 
 	// Check if email already has pending invitation
@@ -70,7 +91,7 @@ func (s *invitationService) Create(orgID, inviterID uint, req *dto.CreateInvitat
 		return nil, err
 	}
 	if hasPending {
-		return nil, errors.New("this email already has a pending invitation to this organization")
+		return nil, apperror.Conflict("this email already has a pending invitation to this organization")
 	}
 
 	// Check if user with this email is already a member
@@ -78,7 +99,7 @@ func (s *invitationService) Create(orgID, inviterID uint, req *dto.CreateInvitat
 	if user != nil {
 		isMember, _ := s.orgRepo.IsMember(orgID, user.ID)
 		if isMember {
-			return nil, errors.New("user with this email is already a member of this organization")
+			return nil, apperror.Conflict("user with this email is already a member of this organization")
 		}
 	}
 
@@ -86,10 +107,10 @@ func (s *invitationService) Create(orgID, inviterID uint, req *dto.CreateInvitat
 	if req.WorkspaceID != nil {
 		workspace, err := s.workspaceRepo.GetByID(*req.WorkspaceID)
 		if err != nil {
-			return nil, errors.New("workspace not found")
+			return nil, apperror.NotFound("workspace not found")
 		}
 		if workspace.OrganizationID != orgID {
-			return nil, errors.New("workspace does not belong to this organization")
+			return nil, apperror.Validation("workspace does not belong to this organization", nil)
 		}
 	}
 
@@ -123,7 +144,14 @@ func (s *invitationService) Create(orgID, inviterID uint, req *dto.CreateInvitat
 		return nil, err
 	}
 
-	return s.toInvitationResponse(fullInvitation, true), nil
+	s.emailService.SendInvitation(fullInvitation, emailUserName(&fullInvitation.Inviter))
+
+	// Existing users also see the invitation in the app
+	if user != nil {
+		s.notificationService.NotifyInvitationReceived(fullInvitation, user.ID)
+	}
+
+	return s.toInvitationResponse(fullInvitation, true, s.invitationOrgCounts(*fullInvitation)), nil
 }
 
 func (s *invitationService) GetByID(invitationID, userID uint) (*dto.InvitationResponse, error) {
@@ -138,16 +166,16 @@ func (s *invitationService) GetByID(invitationID, userID uint) (*dto.InvitationR
 	isInvitee := user != nil && user.Email == invitation.Email
 
 	if !isAdmin && !isInvitee {
-		return nil, errors.New("access denied")
+		return nil, apperror.Forbidden("access denied")
 	}
 
-	return s.toInvitationResponse(invitation, isAdmin), nil
+	return s.toInvitationResponse(invitation, isAdmin, s.invitationOrgCounts(*invitation)), nil
 }
 
 func (s *invitationService) GetByToken(token string) (*dto.InvitationResponse, error) {
 	invitation, err := s.invitationRepo.GetByToken(token)
 	if err != nil {
-		return nil, errors.New("invitation not found")
+		return nil, apperror.NotFound("invitation not found")
 	}
 
 	// Check if still valid
@@ -155,12 +183,16 @@ func (s *invitationService) GetByToken(token string) (*dto.InvitationResponse, e
 		if invitation.Status == models.InvitationStatusPending && invitation.ExpiresAt.Before(time.Now()) {
 			// Mark as expired
 			s.invitationRepo.Revoke(invitation.ID)
-			return nil, errors.New("invitation has expired")
+			return nil, apperror.Gone("invitation has expired")
 		}
-		return nil, errors.New("invitation is no longer valid")
+		return nil, apperror.Gone("invitation is no longer valid")
 	}
 
-	return s.toInvitationResponse(invitation, false), nil
+	response := s.toInvitationResponse(invitation, false, s.invitationOrgCounts(*invitation))
+	existing, _ := s.userRepo.FindByEmail(invitation.Email)
+	accountExists := existing != nil
+	response.AccountExists = &accountExists
+	return response, nil
 }
 
 func (s *invitationService) Revoke(invitationID, userID uint) error {
@@ -175,7 +207,7 @@ func (s *invitationService) Revoke(invitationID, userID uint) error {
 		return err
 	}
 	if !isAdmin {
-		return errors.New("access denied: only organization admins can revoke invitations")
+		return apperror.Forbidden("access denied: only organization admins can revoke invitations")
 	}
 
 	return s.invitationRepo.Revoke(invitationID)
@@ -192,7 +224,7 @@ func (s *invitationService) GetPendingByOrg(orgID, userID uint) ([]dto.Invitatio
 		return nil, err
 	}
 	if !isAdmin {
-		return nil, errors.New("access denied: only organization admins can view invitations")
+		return nil, apperror.Forbidden("access denied: only organization admins can view invitations")
 	}
 
 	invitations, err := s.invitationRepo.GetPendingByOrganizationID(orgID)
@@ -200,9 +232,10 @@ func (s *invitationService) GetPendingByOrg(orgID, userID uint) ([]dto.Invitatio
 		return nil, err
 	}
 
+	orgCounts := s.invitationOrgCounts(invitations...)
 	result := make([]dto.InvitationResponse, 0, len(invitations))
 	for _, inv := range invitations {
-		result = append(result, *s.toInvitationResponse(&inv, true))
+		result = append(result, *s.toInvitationResponse(&inv, true, orgCounts))
 	}
 
 	return result, nil
@@ -214,9 +247,10 @@ func (s *invitationService) GetByEmail(email string) ([]dto.InvitationResponse,
 		return nil, err
 	}
 
+	orgCounts := s.invitationOrgCounts(invitations...)
 	result := make([]dto.InvitationResponse, 0, len(invitations))
 	for _, inv := range invitations {
-		result = append(result, *s.toInvitationResponse(&inv, false))
+		result = append(result, *s.toInvitationResponse(&inv, false, orgCounts))
 	}
 
 	return result, nil
@@ -227,36 +261,134 @@ func (s *invitationService) GetByEmail(email string) ([]dto.InvitationResponse,
 // ============================================================================
 
 func (s *invitationService) Accept(token string, userID uint) (*dto.OrganizationMemberResponse, error) {
+	invitation, err := s.findAcceptable(token)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get user
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, apperror.NotFound("user not found")
+	}
+
+	// Check if email matches (optional - can be removed for more flexibility)
+	if user.Email != invitation.Email {
+		return nil, apperror.Forbidden("invitation was sent to a different email address")
+	}
+
+	return s.join(invitation, user)
+}
+
+func (s *invitationService) AcceptWithAccount(token string, userID uint, req *dto.AcceptInvitationTokenRequest) (*dto.AcceptInvitationResponse, error) {
+	if userID != 0 {
+		member, err := s.Accept(token, userID)
+		if err != nil {
+			return nil, err
+		}
+		return &dto.AcceptInvitationResponse{Member: *member}, nil
+	}
+
+	invitation, err := s.findAcceptable(token)
+	if err != nil {
+		return nil, err
+	}
+
+	created := false
+	user, _ := s.userRepo.FindByEmail(invitation.Email)
+	if user == nil {
+		if user, err = s.createInvitee(invitation, req); err != nil {
+			return nil, err
+		}
+		created = true
+	} else {
+		if !user.IsActive {
+			return nil, apperror.Forbidden("user account is inactive")
+		}
+		if req.Password == "" || utils.CheckPassword(req.Password, user.PasswordHash) != nil {
+			return nil, ErrInvitationLoginFailed
+		}
+	}
+
+	member, err := s.join(invitation, user)
+	if err != nil {
+		if created {
+			// Rollback user creation
+			s.userRepo.Delete(user.ID)
+		}
+		return nil, err
+	}
+	if created {
+		s.emailService.SendWelcome(user)
+	}
+
+	auth, err := newLoginResponse(user)
+	if err != nil {
+		return nil, err
+	}
+	s.userRepo.UpdateLastLogin(user.ID)
+
+	return &dto.AcceptInvitationResponse{
+		Member:         *member,
+		AccountCreated: created,
+		Auth:           auth,
+	}, nil
+}
+
+// findAcceptable loads a pending, unexpired invitation
+func (s *invitationService) findAcceptable(token string) (*models.Invitation, error) {
 	invitation, err := s.invitationRepo.GetByToken(token)
 	if err != nil {
-		return nil, errors.New("invitation not found")
+		return nil, apperror.NotFound("invitation not found")
 	}
 
 	// Check if still valid
 	if !s.invitationRepo.IsValid(invitation) {
-		return nil, errors.New("invitation is no longer valid or has expired")
+		return nil, apperror.Gone("invitation is no longer valid or has expired")
 	}
+	return invitation, nil
+}
 
-	// Get user
-	user, err := s.userRepo.FindByID(userID)
+// createInvitee creates the account of an invitee who has none yet
+func (s *invitationService) createInvitee(invitation *models.Invitation, req *dto.AcceptInvitationTokenRequest) (*models.User, error) {
+	if strings.TrimSpace(req.FirstName) == "" {
+		return nil, apperror.Validation("first name is required to create an account", nil)
+	}
+	if len(req.Password) < 8 {
+		return nil, apperror.Validation("password must be at least 8 characters", nil)
+	}
+
+	hashedPassword, err := utils.HashPassword(req.Password)
 	if err != nil {
-		return nil, errors.New("user not found")
+		return nil, errors.New("failed to hash password")
 	}
 
-	// Check if email matches (optional - can be removed for more flexibility)
-	if user.Email != invitation.Email {
-		return nil, errors.New("invitation was sent to a different email address")
+	user := &models.User{
+		Email:        invitation.Email,
+		PasswordHash: hashedPassword,
+		FirstName:    strings.TrimSpace(req.FirstName),
+		LastName:     strings.TrimSpace(req.LastName),
+		Role:         "user",
+		IsActive:     true,
 	}
+	if err := s.userRepo.Create(user); err != nil {
+		return nil, errors.New("failed to create user")
+	}
+	return user, nil
+}
 
+// join adds the user to the invitation's organization and workspace with the
+// roles stored on the invitation, and marks it accepted
+func (s *invitationService) join(invitation *models.Invitation, user *models.User) (*dto.OrganizationMemberResponse, error) {
 	// Check if already a member
-	isMember, err := s.orgRepo.IsMember(invitation.OrganizationID, userID)
+	isMember, err := s.orgRepo.IsMember(invitation.OrganizationID, user.ID)
 	if err != nil {
 		return nil, err
 	}
 	if isMember {
 		// Mark invitation as accepted anyway
-		s.invitationRepo.Accept(invitation.ID, userID)
-		return nil, errors.New("you are already a member of this organization")
+		s.invitationRepo.Accept(invitation.ID, user.ID)
+		return nil, apperror.Conflict("you are already a member of this organization")
 	}
 
 	// Check member limit
@@ -267,13 +399,13 @@ func (s *invitationService) Accept(token string, userID uint) (*dto.Organization
 
 	memberCount, _ := s.orgRepo.GetMemberCount(org.ID)
 	if int(memberCount) >= org.MaxMembers {
-		return nil, errors.New("organization has reached maximum member limit")
+		return nil, apperror.Conflict("organization has reached maximum member limit")
 	}
 
 	// Add to organization
 	orgMember := &models.OrganizationMember{
 		OrganizationID: invitation.OrganizationID,
-		UserID:         userID,
+		UserID:         user.ID,
 		Role:           invitation.OrgRole,
 		InvitedBy:      &invitation.InvitedBy,
 		JoinedAt:       time.Now(),
@@ -288,7 +420,7 @@ func (s *invitationService) Accept(token string, userID uint) (*dto.Organization
 	if invitation.WorkspaceID != nil {
 		wsMember := &models.WorkspaceMember{
 			WorkspaceID:     *invitation.WorkspaceID,
-			UserID:          userID,
+			UserID:          user.ID,
 			WorkspaceRoleID: invitation.WorkspaceRoleID,
 			AddedBy:         &invitation.InvitedBy,
 			JoinedAt:        time.Now(),
@@ -307,9 +439,10 @@ func (s *invitationService) Accept(token string, userID uint) (*dto.Organization
 	}
 
 	// Mark invitation as accepted
-	s.invitationRepo.Accept(invitation.ID, userID)
+	s.invitationRepo.Accept(invitation.ID, user.ID)
 
 	orgMember.User = *user
+	s.webhookService.EmitMemberEvent(models.WebhookEventMemberJoined, *orgMember, nil, webhookSourceInvitation, nil)
 
 	return &dto.OrganizationMemberResponse{
 		ID:        orgMember.ID,
@@ -334,7 +467,19 @@ func (s *invitationService) ExpireOldInvitations() error {
 // HELPER FUNCTIONS
 // ============================================================================
 
-func (s *invitationService) toInvitationResponse(inv *models.Invitation, showToken bool) *dto.InvitationResponse {
+// invitationOrgCounts loads the member and workspace counts of the invitations' organizations in one query
+func (s *invitationService) invitationOrgCounts(invitations ...models.Invitation) map[uint]repository.OrganizationCounts {
+	orgIDs := make([]uint, 0, len(invitations))
+	for _, inv := range invitations {
+		if inv.Organization.ID > 0 {
+			orgIDs = append(orgIDs, inv.Organization.ID)
+		}
+	}
+	counts, _ := s.orgRepo.GetCountsByIDs(orgIDs)
+	return counts
+}
+
+func (s *invitationService) toInvitationResponse(inv *models.Invitation, showToken bool, orgCounts map[uint]repository.OrganizationCounts) *dto.InvitationResponse {
 	response := &dto.InvitationResponse{
 		ID:              inv.ID,
 		OrganizationID:  inv.OrganizationID,
@@ -352,22 +497,21 @@ func (s *invitationService) toInvitationResponse(inv *models.Invitation, showTok
 
 	if showToken {
 		response.Token = inv.Token
-		// Generate invite link (would use config for base URL)
-		response.InviteLink = "/invitations/accept/" + inv.Token
+		response.InviteLink = invitationURL(inv.Token)
 	}
 
 	// Organization
 	if inv.Organization.ID > 0 {
-		memberCount, _ := s.orgRepo.GetMemberCount(inv.Organization.ID)
-		wsCount, _ := s.orgRepo.GetWorkspaceCount(inv.Organization.ID)
+		counts := orgCounts[inv.Organization.ID]
 		response.Organization = &dto.OrganizationResponse{
 			ID:             inv.Organization.ID,
+			UUID:           inv.Organization.UUID,
 			Name:           inv.Organization.Name,
 			Slug:           inv.Organization.Slug,
 			Description:    inv.Organization.Description,
 			LogoURL:        inv.Organization.LogoURL,
-			MemberCount:    memberCount,
-			WorkspaceCount: wsCount,
+			MemberCount:    counts.MemberCount,
+			WorkspaceCount: counts.WorkspaceCount,
 		}
 	}
 
@@ -375,6 +519,7 @@ func (s *invitationService) toInvitationResponse(inv *models.Invitation, showTok
 	if inv.Workspace != nil && inv.Workspace.ID > 0 {
 		response.Workspace = &dto.WorkspaceResponse{
 			ID:             inv.Workspace.ID,
+			UUID:           inv.Workspace.UUID,
 			OrganizationID: inv.Workspace.OrganizationID,
 			Name:           inv.Workspace.Name,
 			Slug:           inv.Workspace.Slug,
@@ -401,9 +546,15 @@ func (s *invitationService) toInvitationResponse(inv *models.Invitation, showTok
 	return response
 }
 
+// invitationURL is the frontend landing page of an invitation
+func invitationURL(token string) string {
+	return config.AppConfig.Email.AppURL + "/invitations/" + url.PathEscape(token)
+}
+
 func (s *invitationService) toUserResponse(u *models.User) *dto.UserResponse {
 	return &dto.UserResponse{
 		ID:        u.ID,
+		UUID:      u.UUID,
 		Email:     u.Email,
 		FirstName: u.FirstName,
 		LastName:  u.LastName,
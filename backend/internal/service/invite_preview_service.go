@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+)
+
+// ErrInviteCodeInvalid is the single error for every failed invite code
+// lookup, so responses do not reveal why a code was rejected
+var ErrInviteCodeInvalid = apperror.NotFound("invalid invite code or organization not accepting new members")
+
+const (
+	// invitePreviewMinDuration pads every lookup so found and unknown codes
+	// take the same time
+	invitePreviewMinDuration = 250 * time.Millisecond
+
+	// Failed lookups per IP are counted over this window; an IP reaching the
+	// alert threshold is logged once per window
+	inviteLookupWindow         = time.Hour
+	inviteLookupAlertThreshold = 20
+	inviteLookupTopIPs         = 20
+)
+
+// InvitePreviewService serves the public invite code preview with abuse
+// protection: optional CAPTCHA, uniform response timing and failure metrics
+type InvitePreviewService interface {
+	Preview(ctx context.Context, code, clientIP, captchaToken string) (*dto.OrganizationPublicInfo, error)
+	Stats() *dto.InviteLookupStats
+}
+
+type invitePreviewService struct {
+	orgRepo        *repository.OrganizationRepository
+	captchaService CaptchaService
+	requireCaptcha bool
+	metrics        *inviteLookupMetrics
+}
+
+// NewInvitePreviewService creates a new invite preview service. requireCaptcha
+// only takes effect when the CAPTCHA service is configured.
+func NewInvitePreviewService(orgRepo *repository.OrganizationRepository, captchaService CaptchaService, requireCaptcha bool) InvitePreviewService {
+	if requireCaptcha && !captchaService.Enabled() {
+		log.Println("⚠️  Invite preview CAPTCHA requested but no CAPTCHA verify URL is configured")
+		requireCaptcha = false
+	}
+	return &invitePreviewService{
+		orgRepo:        orgRepo,
+		captchaService: captchaService,
+		requireCaptcha: requireCaptcha,
+		metrics:        newInviteLookupMetrics(),
+	}
+}
+
+func (s *invitePreviewService) Preview(ctx context.Context, code, clientIP, captchaToken string) (*dto.OrganizationPublicInfo, error) {
+	if s.requireCaptcha {
+		if err := s.captchaService.Verify(ctx, captchaToken, clientIP); err != nil {
+			s.metrics.recordCaptchaFailure()
+			if errors.Is(err, ErrCaptchaFailed) {
+				return nil, err
+			}
+			log.Printf("⚠️  %v", err)
+			return nil, ErrCaptchaFailed
+		}
+	}
+
+	start := time.Now()
+	info, err := s.lookup(code)
+	if wait := invitePreviewMinDuration - time.Since(start); wait > 0 {
+		time.Sleep(wait)
+	}
+
+	s.metrics.recordLookup(clientIP, err == nil)
+	return info, err
+}
+
+func (s *invitePreviewService) lookup(code string) (*dto.OrganizationPublicInfo, error) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return nil, ErrInviteCodeInvalid
+	}
+
+	org, err := s.orgRepo.GetByInviteCode(code)
+	if err != nil {
+		return nil, ErrInviteCodeInvalid
+	}
+	// Guard against collation-insensitive matches in the database
+	if subtle.ConstantTimeCompare([]byte(org.InviteCode), []byte(code)) != 1 {
+		return nil, ErrInviteCodeInvalid
+	}
+
+	memberCount, _ := s.orgRepo.GetMemberCount(org.ID)
+
+	return &dto.OrganizationPublicInfo{
+		ID:          org.ID,
+		Name:        org.Name,
+		Slug:        org.Slug,
+		Description: org.Description,
+		LogoURL:     org.LogoURL,
+		MemberCount: memberCount,
+	}, nil
+}
+
+func (s *invitePreviewService) Stats() *dto.InviteLookupStats {
+	return s.metrics.snapshot()
+}
+
+// ============================================================================
+// LOOKUP METRICS
+// ============================================================================
+
+type ipLookupFailures struct {
+	count   int64
+	last    time.Time
+	alerted bool
+}
+
+// inviteLookupMetrics counts lookups in memory, per server instance
+type inviteLookupMetrics struct {
+	mu              sync.Mutex
+	since           time.Time
+	total           int64
+	failed          int64
+	captchaFailures int64
+	windowStart     time.Time
+	failuresByIP    map[string]*ipLookupFailures
+}
+
+func newInviteLookupMetrics() *inviteLookupMetrics {
+	now := time.Now().UTC()
+	return &inviteLookupMetrics{
+		since:        now,
+		windowStart:  now,
+		failuresByIP: make(map[string]*ipLookupFailures),
+	}
+}
+
+// rotate starts a new per-IP window once the current one has passed
+func (m *inviteLookupMetrics) rotate(now time.Time) {
+	if now.Sub(m.windowStart) >= inviteLookupWindow {
+		m.windowStart = now
+		m.failuresByIP = make(map[string]*ipLookupFailures)
+	}
+}
+
+func (m *inviteLookupMetrics) recordLookup(ip string, found bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.total++
+	if found {
+		return
+	}
+	m.failed++
+
+	now := time.Now().UTC()
+	m.rotate(now)
+	f, ok := m.failuresByIP[ip]
+	if !ok {
+		f = &ipLookupFailures{}
+		m.failuresByIP[ip] = f
+	}
+	f.count++
+	f.last = now
+
+	if f.count >= inviteLookupAlertThreshold && !f.alerted {
+		f.alerted = true
+		log.Printf("⚠️  Possible invite code enumeration from %s: %d failed lookups since %s",
+			ip, f.count, m.windowStart.Format(time.RFC3339))
+	}
+}
+
+func (m *inviteLookupMetrics) recordCaptchaFailure() {
+	m.mu.Lock()
+	m.captchaFailures++
+	m.mu.Unlock()
+}
+
+func (m *inviteLookupMetrics) snapshot() *dto.InviteLookupStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.rotate(time.Now().UTC())
+	stats := &dto.InviteLookupStats{
+		Since:           m.since,
+		TotalLookups:    m.total,
+		FailedLookups:   m.failed,
+		CaptchaFailures: m.captchaFailures,
+		WindowStart:     m.windowStart,
+		TopFailingIPs:   make([]dto.InviteLookupIPStat, 0, len(m.failuresByIP)),
+	}
+	for ip, f := range m.failuresByIP {
+		stats.TopFailingIPs = append(stats.TopFailingIPs, dto.InviteLookupIPStat{
+			IP:            ip,
+			Failures:      f.count,
+			LastFailureAt: f.last,
+		})
+	}
+	sort.Slice(stats.TopFailingIPs, func(i, j int) bool {
+		return stats.TopFailingIPs[i].Failures > stats.TopFailingIPs[j].Failures
+	})
+	if len(stats.TopFailingIPs) > inviteLookupTopIPs {
+		stats.TopFailingIPs = stats.TopFailingIPs[:inviteLookupTopIPs]
+	}
+	return stats
+}
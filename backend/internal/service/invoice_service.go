@@ -0,0 +1,447 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/money"
+	"github.com/beuphecan/remote-time-tracker/internal/pdf"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+)
+
+// maxInvoiceDays bounds the period one invoice bills
+const maxInvoiceDays = 366
+
+var (
+	// ErrInvoiceNotFound is returned for unknown invoices and invoices of other organizations
+	ErrInvoiceNotFound = apperror.NotFound("invoice not found")
+	// ErrNothingToInvoice is returned when the period has no approved, uninvoiced billable time
+	ErrNothingToInvoice = apperror.Validation("no approved billable time left to invoice in this period", nil)
+	// ErrTimeLogInvoiced is returned when editing or deleting a time log billed on an invoice
+	ErrTimeLogInvoiced = apperror.Conflict("time log is on an invoice and can no longer be edited")
+)
+
+// InvoiceService bills organizations' clients for approved time. Creating an
+// invoice locks the time logs it bills; deleting a draft releases them.
+type InvoiceService interface {
+	List(orgID, userID uint, params *dto.InvoiceListParams) ([]dto.InvoiceResponse, error)
+	GetByID(orgID, invoiceID, userID uint) (*dto.InvoiceResponse, error)
+	Create(orgID, userID uint, req *dto.CreateInvoiceRequest) (*dto.InvoiceResponse, error)
+	Send(orgID, invoiceID, userID uint) (*dto.InvoiceResponse, error)
+	Pay(orgID, invoiceID, userID uint, req *dto.PayInvoiceRequest) (*dto.InvoiceResponse, error)
+	Delete(orgID, invoiceID, userID uint) error
+	PDF(orgID, invoiceID, userID uint) (*ReportFile, error)
+}
+
+type invoiceService struct {
+	invoiceRepo   repository.InvoiceRepository
+	clientRepo    repository.ClientRepository
+	orgRepo       *repository.OrganizationRepository
+	workspaceRepo *repository.WorkspaceRepository
+	rates         money.RateProvider
+	settings      OrganizationSettingsService
+}
+
+// NewInvoiceService creates a new invoice service
+func NewInvoiceService(
+	invoiceRepo repository.InvoiceRepository,
+	clientRepo repository.ClientRepository,
+	orgRepo *repository.OrganizationRepository,
+	workspaceRepo *repository.WorkspaceRepository,
+	rates money.RateProvider,
+	settings OrganizationSettingsService,
+) InvoiceService {
+	return &invoiceService{
+		invoiceRepo:   invoiceRepo,
+		clientRepo:    clientRepo,
+		orgRepo:       orgRepo,
+		workspaceRepo: workspaceRepo,
+		rates:         rates,
+		settings:      settings,
+	}
+}
+
+func (s *invoiceService) requireAdmin(orgID, userID uint) error {
+	isAdmin, err := s.orgRepo.IsAdmin(orgID, userID)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return apperror.Forbidden("access denied: only admins can manage invoices")
+	}
+	return nil
+}
+
+// findInvoice loads an invoice of the organization with its line items
+func (s *invoiceService) findInvoice(orgID, invoiceID uint) (*models.Invoice, error) {
+	invoice, err := s.invoiceRepo.FindByID(invoiceID)
+	if err != nil || invoice.OrganizationID != orgID {
+		return nil, ErrInvoiceNotFound
+	}
+	return invoice, nil
+}
+
+func (s *invoiceService) List(orgID, userID uint, params *dto.InvoiceListParams) ([]dto.InvoiceResponse, error) {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return nil, err
+	}
+
+	invoices, err := s.invoiceRepo.FindByOrg(orgID, params.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.InvoiceResponse, 0, len(invoices))
+	for i := range invoices {
+		responses = append(responses, toInvoiceResponse(&invoices[i]))
+	}
+	return responses, nil
+}
+
+func (s *invoiceService) GetByID(orgID, invoiceID, userID uint) (*dto.InvoiceResponse, error) {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return nil, err
+	}
+
+	invoice, err := s.findInvoice(orgID, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	response := toInvoiceDetailResponse(invoice)
+	return &response, nil
+}
+
+func (s *invoiceService) Create(orgID, userID uint, req *dto.CreateInvoiceRequest) (*dto.InvoiceResponse, error) {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return nil, err
+	}
+
+	start, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return nil, apperror.Validation("invalid start_date: use YYYY-MM-DD", nil)
+	}
+	end, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		return nil, apperror.Validation("invalid end_date: use YYYY-MM-DD", nil)
+	}
+	if end.Before(start) || end.Sub(start) >= maxInvoiceDays*24*time.Hour {
+		return nil, apperror.Validation(fmt.Sprintf("end_date must be on or after start_date and within %d days", maxInvoiceDays), nil)
+	}
+	var dueDate *time.Time
+	if req.DueDate != "" {
+		due, err := time.Parse("2006-01-02", req.DueDate)
+		if err != nil {
+			return nil, apperror.Validation("invalid due_date: use YYYY-MM-DD", nil)
+		}
+		dueDate = &due
+	} else if days := s.settings.Int(orgID, models.SettingInvoicePaymentTermsDays); days > 0 {
+		now := time.Now().UTC()
+		due := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, days)
+		dueDate = &due
+	}
+
+	org, err := s.orgRepo.GetByID(orgID)
+	if err != nil {
+		return nil, err
+	}
+	workspaces, err := s.workspaceRepo.GetByOrganizationID(orgID)
+	if err != nil {
+		return nil, err
+	}
+	workspaceByID := make(map[uint]*models.Workspace, len(workspaces))
+	for i := range workspaces {
+		workspaceByID[workspaces[i].ID] = &workspaces[i]
+	}
+
+	invoice := &models.Invoice{
+		OrganizationID: orgID,
+		ClientID:       req.ClientID,
+		WorkspaceID:    req.WorkspaceID,
+		Status:         models.InvoiceStatusDraft,
+		Currency:       money.Normalize(org.Currency),
+		PeriodStart:    start,
+		PeriodEnd:      end,
+		DueDate:        dueDate,
+		Notes:          req.Notes,
+		CreatedBy:      userID,
+	}
+
+	if req.WorkspaceID != nil {
+		workspace, ok := workspaceByID[*req.WorkspaceID]
+		if !ok {
+			return nil, apperror.NotFound("workspace not found")
+		}
+		if invoice.ClientID == nil {
+			invoice.ClientID = workspace.ClientID
+		}
+	}
+	if invoice.ClientID != nil {
+		client, err := s.clientRepo.FindByID(*invoice.ClientID)
+		if err != nil || client.OrganizationID != orgID {
+			return nil, ErrClientNotFound
+		}
+		invoice.BillTo = client.Name
+	}
+
+	timeLogs, err := s.invoiceRepo.FindBillable(repository.BillableFilter{
+		OrganizationID: orgID,
+		Start:          start,
+		End:            end,
+		ClientID:       req.ClientID,
+		WorkspaceID:    req.WorkspaceID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(timeLogs) == 0 {
+		return nil, ErrNothingToInvoice
+	}
+
+	lineItems, timeLogIDs, err := s.buildLineItems(timeLogs, workspaceByID, invoice.Currency)
+	if err != nil {
+		return nil, err
+	}
+	invoice.LineItems = lineItems
+	for _, item := range lineItems {
+		invoice.TotalMinor += item.AmountMinor
+	}
+
+	now := time.Now().UTC()
+	sequence, err := s.invoiceRepo.NextSequence(orgID, now.Year())
+	if err != nil {
+		return nil, err
+	}
+	invoice.Number = fmt.Sprintf("INV-%d-%04d", now.Year(), sequence)
+
+	if err := s.invoiceRepo.Create(invoice, timeLogIDs); err != nil {
+		if errors.Is(err, repository.ErrTimeLogsAlreadyInvoiced) {
+			return nil, apperror.Conflict("some time logs of the period were invoiced meanwhile; try again")
+		}
+		return nil, err
+	}
+
+	response := toInvoiceDetailResponse(invoice)
+	return &response, nil
+}
+
+// buildLineItems bills each workspace's time at its hourly rate converted to
+// the invoice currency, one line per workspace, cost center and project code,
+// ordered by workspace name
+func (s *invoiceService) buildLineItems(timeLogs []repository.BillableTimeLog, workspaceByID map[uint]*models.Workspace, currency string) ([]models.InvoiceLineItem, []uint, error) {
+	type itemKey struct {
+		workspaceID uint
+		costCenter  string
+		projectCode string
+	}
+	itemByKey := make(map[itemKey]*models.InvoiceLineItem)
+	unitPriceByWorkspace := make(map[uint]int64)
+	timeLogIDs := make([]uint, 0, len(timeLogs))
+	for _, tl := range timeLogs {
+		key := itemKey{tl.WorkspaceID, tl.CostCenter, tl.ProjectCode}
+		item, ok := itemByKey[key]
+		if !ok {
+			workspace := workspaceByID[tl.WorkspaceID]
+			if workspace == nil {
+				continue
+			}
+			unitPrice, ok := unitPriceByWorkspace[workspace.ID]
+			if !ok {
+				rate, err := s.rates.Rate(workspace.Currency, currency)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to convert the rate of workspace %s: %w", workspace.Name, err)
+				}
+				unitPrice = money.ToMinor(money.ToMajor(workspace.HourlyRateMinor, workspace.Currency)*rate, currency)
+				unitPriceByWorkspace[workspace.ID] = unitPrice
+			}
+			item = &models.InvoiceLineItem{
+				WorkspaceID:    workspace.ID,
+				Description:    workspace.Name,
+				CostCenter:     tl.CostCenter,
+				ProjectCode:    tl.ProjectCode,
+				UnitPriceMinor: unitPrice,
+			}
+			itemByKey[key] = item
+		}
+		item.Seconds += tl.Duration
+		item.TimeLogCount++
+		timeLogIDs = append(timeLogIDs, tl.ID)
+	}
+
+	items := make([]models.InvoiceLineItem, 0, len(itemByKey))
+	for _, item := range itemByKey {
+		item.AmountMinor = int64(math.Round(float64(item.Seconds) * float64(item.UnitPriceMinor) / 3600))
+		items = append(items, *item)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if da, db := strings.ToLower(a.Description), strings.ToLower(b.Description); da != db {
+			return da < db
+		}
+		if a.CostCenter != b.CostCenter {
+			return a.CostCenter < b.CostCenter
+		}
+		return a.ProjectCode < b.ProjectCode
+	})
+	return items, timeLogIDs, nil
+}
+
+func (s *invoiceService) Send(orgID, invoiceID, userID uint) (*dto.InvoiceResponse, error) {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return nil, err
+	}
+
+	invoice, err := s.findInvoice(orgID, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	if invoice.Status != models.InvoiceStatusDraft {
+		return nil, apperror.Conflict("only draft invoices can be sent")
+	}
+
+	now := time.Now().UTC()
+	invoice.Status = models.InvoiceStatusSent
+	invoice.SentAt = &now
+	if err := s.invoiceRepo.Update(invoice); err != nil {
+		return nil, err
+	}
+
+	response := toInvoiceDetailResponse(invoice)
+	return &response, nil
+}
+
+func (s *invoiceService) Pay(orgID, invoiceID, userID uint, req *dto.PayInvoiceRequest) (*dto.InvoiceResponse, error) {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return nil, err
+	}
+
+	invoice, err := s.findInvoice(orgID, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	if invoice.Status == models.InvoiceStatusPaid {
+		return nil, apperror.Conflict("invoice is already paid")
+	}
+
+	paidAt := time.Now().UTC()
+	if req.PaidAt != nil {
+		paidAt = req.PaidAt.UTC()
+	}
+	invoice.Status = models.InvoiceStatusPaid
+	invoice.PaidAt = &paidAt
+	if err := s.invoiceRepo.Update(invoice); err != nil {
+		return nil, err
+	}
+
+	response := toInvoiceDetailResponse(invoice)
+	return &response, nil
+}
+
+func (s *invoiceService) Delete(orgID, invoiceID, userID uint) error {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return err
+	}
+
+	invoice, err := s.findInvoice(orgID, invoiceID)
+	if err != nil {
+		return err
+	}
+	if invoice.Status != models.InvoiceStatusDraft {
+		return apperror.Conflict("only draft invoices can be deleted")
+	}
+	return s.invoiceRepo.Delete(invoice.ID)
+}
+
+func (s *invoiceService) PDF(orgID, invoiceID, userID uint) (*ReportFile, error) {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return nil, err
+	}
+
+	invoice, err := s.findInvoice(orgID, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([][]string, 0, len(invoice.LineItems)+1)
+	for _, item := range invoice.LineItems {
+		rows = append(rows, []string{
+			item.Description,
+			item.CostCenter,
+			item.ProjectCode,
+			fmt.Sprintf("%.2f", float64(item.Seconds)/3600),
+			formatMinor(item.UnitPriceMinor, invoice.Currency),
+			formatMinor(item.AmountMinor, invoice.Currency),
+		})
+	}
+	rows = append(rows, []string{"Total", "", "", "", "", formatMinor(invoice.TotalMinor, invoice.Currency)})
+
+	subtitle := fmt.Sprintf("Bill to %s, %s to %s, %s", invoice.BillTo,
+		invoice.PeriodStart.Format("2006-01-02"), invoice.PeriodEnd.Format("2006-01-02"), invoice.Status)
+	if invoice.DueDate != nil {
+		subtitle += ", due " + invoice.DueDate.Format("2006-01-02")
+	}
+	table := pdf.Table{
+		Title:    "Invoice " + invoice.Number,
+		Subtitle: subtitle,
+		Columns:  []string{"description", "cost center", "project code", "hours", "rate (" + invoice.Currency + ")", "amount (" + invoice.Currency + ")"},
+		Rows:     rows,
+	}
+	return &ReportFile{Filename: invoice.Number + ".pdf", ContentType: "application/pdf", Data: table.Render()}, nil
+}
+
+// formatMinor formats minor units with the currency's decimals
+func formatMinor(minor int64, currency string) string {
+	return fmt.Sprintf("%.*f", money.Exponent(currency), money.ToMajor(minor, currency))
+}
+
+func toInvoiceResponse(invoice *models.Invoice) dto.InvoiceResponse {
+	response := dto.InvoiceResponse{
+		ID:             invoice.ID,
+		OrganizationID: invoice.OrganizationID,
+		Number:         invoice.Number,
+		ClientID:       invoice.ClientID,
+		BillTo:         invoice.BillTo,
+		WorkspaceID:    invoice.WorkspaceID,
+		Status:         invoice.Status,
+		Currency:       invoice.Currency,
+		PeriodStart:    invoice.PeriodStart.Format("2006-01-02"),
+		PeriodEnd:      invoice.PeriodEnd.Format("2006-01-02"),
+		Total:          money.ToMajor(invoice.TotalMinor, invoice.Currency),
+		Notes:          invoice.Notes,
+		CreatedBy:      invoice.CreatedBy,
+		SentAt:         invoice.SentAt,
+		PaidAt:         invoice.PaidAt,
+		CreatedAt:      invoice.CreatedAt,
+		UpdatedAt:      invoice.UpdatedAt,
+	}
+	if invoice.DueDate != nil {
+		due := invoice.DueDate.Format("2006-01-02")
+		response.DueDate = &due
+	}
+	return response
+}
+
+// toInvoiceDetailResponse includes the line items
+func toInvoiceDetailResponse(invoice *models.Invoice) dto.InvoiceResponse {
+	response := toInvoiceResponse(invoice)
+	response.LineItems = make([]dto.InvoiceLineItemResponse, 0, len(invoice.LineItems))
+	for _, item := range invoice.LineItems {
+		response.LineItems = append(response.LineItems, dto.InvoiceLineItemResponse{
+			WorkspaceID:  item.WorkspaceID,
+			Description:  item.Description,
+			CostCenter:   item.CostCenter,
+			ProjectCode:  item.ProjectCode,
+			Hours:        math.Round(float64(item.Seconds)/36) / 100,
+			TimeLogCount: item.TimeLogCount,
+			UnitPrice:    money.ToMajor(item.UnitPriceMinor, invoice.Currency),
+			Amount:       money.ToMajor(item.AmountMinor, invoice.Currency),
+		})
+	}
+	return response
+}
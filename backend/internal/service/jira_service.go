@@ -0,0 +1,502 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/config"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/jira"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	// Jira rejects worklogs shorter than a minute
+	minJiraWorklogSeconds = 60
+	jiraWorklogBatch      = 200
+)
+
+// jiraProjectKeyPattern matches Jira project keys, e.g. "PROJ" or "WEB2"
+var jiraProjectKeyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]{0,49}$`)
+
+// ErrJiraNotConnected is returned when a workspace has no Jira integration
+var ErrJiraNotConnected = apperror.NotFound("workspace is not connected to Jira")
+
+// ErrJiraSyncRunning is returned when a sync is already running for the workspace
+var ErrJiraSyncRunning = apperror.Conflict("a Jira sync is already running for this workspace")
+
+// JiraService connects workspaces to Jira projects. Syncing imports the
+// project's issues as tasks (linked by Task.ExternalRef) and pushes stopped
+// time logs on them back to Jira as worklogs.
+type JiraService interface {
+	// Integration management (workspace managers)
+	Get(workspaceID, userID uint) (*dto.JiraIntegrationResponse, error)
+	Save(ctx context.Context, workspaceID, userID uint, req *dto.SaveJiraIntegrationRequest) (*dto.JiraIntegrationResponse, error)
+	Disconnect(workspaceID, userID uint) error
+	// SyncNow runs a sync immediately. Jira failures are reported in the
+	// response rather than as an error.
+	SyncNow(ctx context.Context, workspaceID, userID uint) (*dto.JiraSyncResponse, error)
+
+	// SyncAll syncs every active integration (scheduled job)
+	SyncAll(ctx context.Context) error
+}
+
+type jiraService struct {
+	jiraRepo          repository.JiraRepository
+	workspaceRepo     *repository.WorkspaceRepository
+	workspaceService  WorkspaceService
+	assignmentService TaskAssignmentService
+	timeout           time.Duration
+	allowHTTP         bool
+
+	// Workspaces with a sync in progress, so a manual sync and the job never
+	// push the same worklog twice
+	mu      sync.Mutex
+	syncing map[uint]bool
+}
+
+// NewJiraService creates a new Jira integration service
+func NewJiraService(
+	jiraRepo repository.JiraRepository,
+	workspaceRepo *repository.WorkspaceRepository,
+	workspaceService WorkspaceService,
+	assignmentService TaskAssignmentService,
+) JiraService {
+	cfg := config.AppConfig.Jira
+	return &jiraService{
+		jiraRepo:          jiraRepo,
+		workspaceRepo:     workspaceRepo,
+		workspaceService:  workspaceService,
+		assignmentService: assignmentService,
+		timeout:           cfg.Timeout,
+		allowHTTP:         cfg.AllowHTTP,
+		syncing:           make(map[uint]bool),
+	}
+}
+
+func (s *jiraService) requireManager(workspaceID, userID uint) error {
+	canManage, err := s.workspaceService.HasPermission(workspaceID, userID, models.PermSettingsManage)
+	if err != nil {
+		return err
+	}
+	if !canManage {
+		return apperror.Forbidden("access denied: you cannot manage the Jira integration in this workspace")
+	}
+	return nil
+}
+
+func (s *jiraService) find(workspaceID uint) (*models.JiraIntegration, error) {
+	integration, err := s.jiraRepo.FindByWorkspace(workspaceID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrJiraNotConnected
+	}
+	return integration, err
+}
+
+func (s *jiraService) client(integration *models.JiraIntegration) *jira.Client {
+	return jira.NewClient(integration.BaseURL, integration.Email, integration.APIToken, s.timeout)
+}
+
+// ============================================================================
+// INTEGRATION MANAGEMENT
+// ============================================================================
+
+func (s *jiraService) Get(workspaceID, userID uint) (*dto.JiraIntegrationResponse, error) {
+	if err := s.requireManager(workspaceID, userID); err != nil {
+		return nil, err
+	}
+
+	integration, err := s.find(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toJiraIntegrationResponse(integration)
+	return &response, nil
+}
+
+func (s *jiraService) Save(ctx context.Context, workspaceID, userID uint, req *dto.SaveJiraIntegrationRequest) (*dto.JiraIntegrationResponse, error) {
+	if err := s.requireManager(workspaceID, userID); err != nil {
+		return nil, err
+	}
+
+	baseURL := strings.TrimRight(strings.TrimSpace(req.BaseURL), "/")
+	if err := s.validateURL(baseURL); err != nil {
+		return nil, err
+	}
+
+	integration, err := s.find(workspaceID)
+	if errors.Is(err, ErrJiraNotConnected) {
+		integration = &models.JiraIntegration{
+			WorkspaceID:  workspaceID,
+			PushWorklogs: true,
+			IsActive:     true,
+			CreatedBy:    userID,
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	// The stored token is never sent to a different site or account
+	email := strings.TrimSpace(req.Email)
+	if req.APIToken == "" && (baseURL != integration.BaseURL || email != integration.Email) {
+		integration.APIToken = ""
+	}
+
+	integration.BaseURL = baseURL
+	integration.Email = email
+	integration.ProjectKey = strings.ToUpper(strings.TrimSpace(req.ProjectKey))
+	if !jiraProjectKeyPattern.MatchString(integration.ProjectKey) {
+		return nil, apperror.Validation("invalid Jira project key", nil)
+	}
+	integration.JQL = strings.TrimSpace(req.JQL)
+	if req.APIToken != "" {
+		integration.APIToken = req.APIToken
+	}
+	if integration.APIToken == "" {
+		return nil, apperror.Validation("api_token is required", nil)
+	}
+	if req.PushWorklogs != nil {
+		integration.PushWorklogs = *req.PushWorklogs
+	}
+	if req.IsActive != nil {
+		integration.IsActive = *req.IsActive
+	}
+
+	// Check the credentials and project before saving
+	if _, err := s.client(integration).GetProject(ctx, integration.ProjectKey); err != nil {
+		return nil, jiraConnectError(err, integration.ProjectKey)
+	}
+
+	if integration.ID == 0 {
+		err = s.jiraRepo.Create(integration)
+	} else {
+		err = s.jiraRepo.Update(integration)
+	}
+	if err != nil {
+		return nil, errors.New("failed to save Jira integration")
+	}
+
+	response := toJiraIntegrationResponse(integration)
+	return &response, nil
+}
+
+func (s *jiraService) validateURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return apperror.Validation("invalid Jira URL", nil)
+	}
+	switch u.Scheme {
+	case "https":
+		return nil
+	case "http":
+		if s.allowHTTP {
+			return nil
+		}
+	}
+	return apperror.Validation("Jira URL must use https", nil)
+}
+
+// jiraConnectError explains why Jira rejected the connection check
+func jiraConnectError(err error, projectKey string) error {
+	var apiErr *jira.APIError
+	if errors.As(err, &apiErr) {
+		if apiErr.Unauthorized() {
+			return apperror.Validation("Jira rejected the email or API token", nil)
+		}
+		if apiErr.StatusCode == http.StatusNotFound {
+			return apperror.NotFound(fmt.Sprintf("Jira project %s not found", projectKey))
+		}
+	}
+	return fmt.Errorf("failed to reach Jira: %w", err)
+}
+
+func (s *jiraService) Disconnect(workspaceID, userID uint) error {
+	if err := s.requireManager(workspaceID, userID); err != nil {
+		return err
+	}
+
+	integration, err := s.find(workspaceID)
+	if err != nil {
+		return err
+	}
+
+	// Imported tasks keep their external_ref so a reconnect links them again
+	return s.jiraRepo.Delete(integration.ID)
+}
+
+// ============================================================================
+// SYNC
+// ============================================================================
+
+func (s *jiraService) SyncNow(ctx context.Context, workspaceID, userID uint) (*dto.JiraSyncResponse, error) {
+	if err := s.requireManager(workspaceID, userID); err != nil {
+		return nil, err
+	}
+
+	integration, err := s.find(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.lock(workspaceID) {
+		return nil, ErrJiraSyncRunning
+	}
+	defer s.unlock(workspaceID)
+
+	result, _ := s.sync(ctx, integration)
+	return result, nil
+}
+
+func (s *jiraService) SyncAll(ctx context.Context) error {
+	integrations, err := s.jiraRepo.FindActive()
+	if err != nil {
+		return fmt.Errorf("failed to load Jira integrations: %w", err)
+	}
+
+	failed := 0
+	for i := range integrations {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		integration := &integrations[i]
+
+		if !s.lock(integration.WorkspaceID) {
+			continue
+		}
+		_, err := s.sync(ctx, integration)
+		s.unlock(integration.WorkspaceID)
+
+		if err != nil {
+			failed++
+			log.Printf("⚠️  Jira sync failed for workspace %d: %v", integration.WorkspaceID, err)
+		}
+	}
+
+	if len(integrations) > 0 {
+		log.Printf("✅ Synced %d Jira integrations (%d failed)", len(integrations), failed)
+	}
+	return nil
+}
+
+func (s *jiraService) lock(workspaceID uint) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.syncing[workspaceID] {
+		return false
+	}
+	s.syncing[workspaceID] = true
+	return true
+}
+
+func (s *jiraService) unlock(workspaceID uint) {
+	s.mu.Lock()
+	delete(s.syncing, workspaceID)
+	s.mu.Unlock()
+}
+
+// sync imports issues then pushes worklogs, recording the outcome on the integration
+func (s *jiraService) sync(ctx context.Context, integration *models.JiraIntegration) (*dto.JiraSyncResponse, error) {
+	client := s.client(integration)
+	result := &dto.JiraSyncResponse{}
+
+	err := s.importIssues(ctx, client, integration, result)
+	if err == nil && integration.PushWorklogs {
+		err = s.pushWorklogs(ctx, client, integration, result)
+	}
+
+	syncErr := ""
+	if err != nil {
+		syncErr = err.Error()
+		result.Error = syncErr
+	}
+	if recordErr := s.jiraRepo.SetSyncResult(integration.ID, time.Now(), syncErr); recordErr != nil {
+		log.Printf("⚠️  Failed to record Jira sync result for workspace %d: %v", integration.WorkspaceID, recordErr)
+	}
+
+	return result, err
+}
+
+// importIssues creates a task for each new issue and keeps the title, status
+// and owner of linked tasks in step with Jira
+func (s *jiraService) importIssues(ctx context.Context, client *jira.Client, integration *models.JiraIntegration, result *dto.JiraSyncResponse) error {
+	jql := fmt.Sprintf(`project = "%s"`, integration.ProjectKey)
+	if integration.JQL != "" {
+		jql += " AND (" + integration.JQL + ")"
+	}
+	jql += " ORDER BY key ASC"
+
+	issues, err := client.SearchIssues(ctx, jql)
+	if err != nil {
+		return fmt.Errorf("failed to search Jira issues: %w", err)
+	}
+	result.IssuesFound = len(issues)
+
+	workspace, err := s.workspaceRepo.GetByID(integration.WorkspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to load workspace: %w", err)
+	}
+
+	// Issue assignees are matched to workspace members by email
+	members, err := s.workspaceRepo.GetMembersByWorkspaceID(integration.WorkspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to load workspace members: %w", err)
+	}
+	memberByEmail := make(map[string]uint, len(members))
+	for _, member := range members {
+		memberByEmail[strings.ToLower(member.User.Email)] = member.UserID
+	}
+
+	linked, err := s.jiraRepo.FindLinkedTasks(integration.WorkspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to load linked tasks: %w", err)
+	}
+	taskByRef := make(map[string]*models.Task, len(linked))
+	for i := range linked {
+		taskByRef[linked[i].ExternalRef] = &linked[i]
+	}
+
+	var created, updated []*models.Task
+	for _, issue := range issues {
+		title := truncateText(issue.Key+" "+strings.TrimSpace(issue.Fields.Summary), 255)
+		status := "active"
+		if issue.Fields.Status.StatusCategory.Key == jira.StatusCategoryDone {
+			status = "completed"
+		}
+		var assigneeID uint
+		if assignee := issue.Fields.Assignee; assignee != nil && assignee.EmailAddress != "" {
+			assigneeID = memberByEmail[strings.ToLower(assignee.EmailAddress)]
+		}
+
+		if task, ok := taskByRef[issue.Key]; ok {
+			if task.DeletedAt.Valid {
+				continue
+			}
+			changed := false
+			if task.Title != title {
+				task.Title = title
+				changed = true
+			}
+			// Archiving is local; Jira does not reopen archived tasks
+			if task.Status != "archived" && task.Status != status {
+				task.Status = status
+				changed = true
+			}
+			if assigneeID != 0 && task.UserID != assigneeID {
+				task.UserID = assigneeID
+				changed = true
+			}
+			if changed {
+				updated = append(updated, task)
+			}
+			continue
+		}
+
+		workspaceID := integration.WorkspaceID
+		orgID := workspace.OrganizationID
+		task := &models.Task{
+			UserID:         integration.CreatedBy,
+			CreatedBy:      &integration.CreatedBy,
+			OrganizationID: &orgID,
+			WorkspaceID:    &workspaceID,
+			LocalID:        fmt.Sprintf("jira-%d-%s", integration.WorkspaceID, issue.Key),
+			Title:          title,
+			Status:         status,
+			IsManual:       true,
+			ExternalRef:    issue.Key,
+		}
+		if assigneeID != 0 {
+			task.UserID = assigneeID
+		} else if err := s.assignmentService.ApplyRules(task, integration.CreatedBy); err != nil {
+			log.Printf("⚠️  Failed to apply assignment rules to Jira issue %s: %v", issue.Key, err)
+		}
+		created = append(created, task)
+	}
+
+	if err := s.jiraRepo.SaveTasks(created, updated); err != nil {
+		return fmt.Errorf("failed to save imported tasks: %w", err)
+	}
+	result.TasksCreated = len(created)
+	result.TasksUpdated = len(updated)
+	return nil
+}
+
+// pushWorklogs logs stopped time on linked tasks to their Jira issues. Pushing
+// stops at the first failure; the rest are retried on the next sync.
+func (s *jiraService) pushWorklogs(ctx context.Context, client *jira.Client, integration *models.JiraIntegration, result *dto.JiraSyncResponse) error {
+	logs, err := s.jiraRepo.FindPendingWorklogs(integration, minJiraWorklogSeconds, jiraWorklogBatch)
+	if err != nil {
+		return fmt.Errorf("failed to load pending worklogs: %w", err)
+	}
+
+	for i := range logs {
+		timeLog := &logs[i]
+		if err := ctx.Err(); err != nil {
+			result.WorklogsPending = len(logs) - i
+			return err
+		}
+
+		issueKey := timeLog.Task.ExternalRef
+		worklog, err := client.AddWorklog(ctx, issueKey, timeLog.StartTime, timeLog.Duration, jiraWorklogComment(timeLog))
+		if err != nil {
+			result.WorklogsPending = len(logs) - i
+			return fmt.Errorf("failed to push time log %d to %s: %w", timeLog.ID, issueKey, err)
+		}
+
+		err = s.jiraRepo.RecordWorklog(&models.JiraWorklog{
+			IntegrationID: integration.ID,
+			TimeLogID:     timeLog.ID,
+			IssueKey:      issueKey,
+			WorklogID:     worklog.ID,
+		})
+		if err != nil {
+			result.WorklogsPending = len(logs) - i - 1
+			return fmt.Errorf("failed to record worklog %s on %s: %w", worklog.ID, issueKey, err)
+		}
+		result.WorklogsPushed++
+	}
+
+	return nil
+}
+
+// jiraWorklogComment credits the member, since every worklog is posted by the
+// integration's Jira account
+func jiraWorklogComment(timeLog *models.TimeLog) string {
+	comment := strings.TrimSpace(timeLog.User.FirstName + " " + timeLog.User.LastName)
+	if comment == "" {
+		comment = timeLog.User.Email
+	}
+	if notes := strings.TrimSpace(timeLog.Notes); notes != "" {
+		comment += ": " + notes
+	}
+	return comment
+}
+
+func toJiraIntegrationResponse(integration *models.JiraIntegration) dto.JiraIntegrationResponse {
+	return dto.JiraIntegrationResponse{
+		ID:            integration.ID,
+		WorkspaceID:   integration.WorkspaceID,
+		BaseURL:       integration.BaseURL,
+		Email:         integration.Email,
+		ProjectKey:    integration.ProjectKey,
+		JQL:           integration.JQL,
+		PushWorklogs:  integration.PushWorklogs,
+		IsActive:      integration.IsActive,
+		CreatedBy:     integration.CreatedBy,
+		LastSyncAt:    integration.LastSyncAt,
+		LastSyncError: integration.LastSyncError,
+		CreatedAt:     integration.CreatedAt,
+		UpdatedAt:     integration.UpdatedAt,
+	}
+}
@@ -0,0 +1,452 @@
+package service
+
+import (
+	"strings"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/calendar"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+)
+
+const (
+	maxLeaveDays             = 366
+	maxLeaveCalendarDays     = 93
+	defaultLeaveCalendarDays = 28
+)
+
+var (
+	// ErrInvalidLeaveRange is returned for reversed or too long leave ranges
+	ErrInvalidLeaveRange = apperror.Validation("invalid date range: end_date must not be before start_date and leave cannot exceed 366 days", nil)
+	// ErrLeaveOverlap is returned when a request overlaps the user's pending or approved leave
+	ErrLeaveOverlap = apperror.Conflict("you already have pending or approved leave in this period")
+	// ErrLeaveRequestNotFound is returned for unknown leave requests
+	ErrLeaveRequestNotFound = apperror.NotFound("leave request not found")
+	// ErrInvalidLeaveCalendarRange is returned for reversed or too long calendar ranges
+	ErrInvalidLeaveCalendarRange = apperror.Validation("invalid date range: end_date must not be before start_date and the range cannot exceed 93 days", nil)
+)
+
+// LeaveService handles members' time-off requests, their review by org and
+// workspace admins, and the team leave calendar
+type LeaveService interface {
+	// Member requests
+	Request(orgID, userID uint, req *dto.CreateLeaveRequest) (*dto.LeaveRequestResponse, error)
+	ListMine(orgID, userID uint, params *dto.LeaveRequestListParams) ([]dto.LeaveRequestResponse, int64, error)
+	Cancel(orgID, userID, requestID uint) error
+
+	// Admin review queue
+	ListForOrg(orgID, userID uint, params *dto.LeaveRequestListParams) ([]dto.LeaveRequestResponse, int64, error)
+	Approve(orgID, requestID, userID uint, req *dto.ReviewLeaveRequest) (*dto.LeaveRequestResponse, error)
+	Reject(orgID, requestID, userID uint, req *dto.ReviewLeaveRequest) (*dto.LeaveRequestResponse, error)
+
+	GetTeamCalendar(orgID, userID uint, params *dto.TeamLeaveCalendarParams) (*dto.TeamLeaveCalendar, error)
+}
+
+type leaveService struct {
+	leaveRepo           repository.LeaveRepository
+	holidayRepo         repository.HolidayRepository
+	orgRepo             *repository.OrganizationRepository
+	workspaceRepo       *repository.WorkspaceRepository
+	notificationService NotificationService
+}
+
+// NewLeaveService creates a new leave service
+func NewLeaveService(
+	leaveRepo repository.LeaveRepository,
+	holidayRepo repository.HolidayRepository,
+	orgRepo *repository.OrganizationRepository,
+	workspaceRepo *repository.WorkspaceRepository,
+	notificationService NotificationService,
+) LeaveService {
+	return &leaveService{
+		leaveRepo:           leaveRepo,
+		holidayRepo:         holidayRepo,
+		orgRepo:             orgRepo,
+		workspaceRepo:       workspaceRepo,
+		notificationService: notificationService,
+	}
+}
+
+// ============================================================================
+// MEMBER REQUESTS
+// ============================================================================
+
+func (s *leaveService) Request(orgID, userID uint, req *dto.CreateLeaveRequest) (*dto.LeaveRequestResponse, error) {
+	isMember, err := s.orgRepo.IsMember(orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, apperror.Forbidden("access denied: not a member of this organization")
+	}
+
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return nil, apperror.Validation("invalid start_date: use YYYY-MM-DD", nil)
+	}
+	endDate, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		return nil, apperror.Validation("invalid end_date: use YYYY-MM-DD", nil)
+	}
+	if endDate.Before(startDate) || endDate.Sub(startDate) >= maxLeaveDays*24*time.Hour {
+		return nil, ErrInvalidLeaveRange
+	}
+
+	overlap, err := s.leaveRepo.HasOverlap(orgID, userID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	if overlap {
+		return nil, ErrLeaveOverlap
+	}
+
+	request := &models.LeaveRequest{
+		OrganizationID: orgID,
+		UserID:         userID,
+		Type:           req.Type,
+		StartDate:      startDate,
+		EndDate:        endDate,
+		Reason:         strings.TrimSpace(req.Reason),
+		Status:         models.LeaveRequestPending,
+	}
+	if err := s.leaveRepo.Create(request); err != nil {
+		return nil, err
+	}
+
+	response := toLeaveRequestResponse(request, s.calendar(orgID))
+	return &response, nil
+}
+
+func (s *leaveService) ListMine(orgID, userID uint, params *dto.LeaveRequestListParams) ([]dto.LeaveRequestResponse, int64, error) {
+	normalizeLeaveListParams(params)
+
+	requests, total, err := s.leaveRepo.FindByUser(orgID, userID, params)
+	if err != nil {
+		return nil, 0, err
+	}
+	return toLeaveRequestResponses(requests, s.calendar(orgID)), total, nil
+}
+
+// Cancel withdraws a pending request, or approved leave that has not started yet
+func (s *leaveService) Cancel(orgID, userID, requestID uint) error {
+	request, err := s.leaveRepo.FindByID(requestID)
+	if err != nil || request.OrganizationID != orgID {
+		return ErrLeaveRequestNotFound
+	}
+	if request.UserID != userID {
+		return apperror.NotFound("unauthorized access to leave request")
+	}
+
+	switch request.Status {
+	case models.LeaveRequestPending:
+	case models.LeaveRequestApproved:
+		if !request.StartDate.After(time.Now()) {
+			return apperror.Conflict("approved leave that has already started cannot be cancelled")
+		}
+	default:
+		return apperror.Conflict("leave request has already been " + request.Status)
+	}
+
+	request.Status = models.LeaveRequestCancelled
+	return s.leaveRepo.Update(request)
+}
+
+// ============================================================================
+// ADMIN REVIEW QUEUE
+// ============================================================================
+
+// reviewScope returns the workspaces whose members' requests the user may
+// review: nil for org owners and admins (every request), otherwise the
+// workspaces they administer
+func (s *leaveService) reviewScope(orgID, userID uint) ([]uint, error) {
+	isAdmin, err := s.orgRepo.IsAdmin(orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if isAdmin {
+		return nil, nil
+	}
+
+	memberships, err := s.workspaceRepo.GetUserWorkspacesByOrg(userID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	workspaceIDs := make([]uint, 0)
+	for _, m := range memberships {
+		if m.IsAdmin {
+			workspaceIDs = append(workspaceIDs, m.WorkspaceID)
+		}
+	}
+	if len(workspaceIDs) == 0 {
+		return nil, apperror.Forbidden("access denied: only admins can review leave requests")
+	}
+	return workspaceIDs, nil
+}
+
+func (s *leaveService) ListForOrg(orgID, userID uint, params *dto.LeaveRequestListParams) ([]dto.LeaveRequestResponse, int64, error) {
+	workspaceIDs, err := s.reviewScope(orgID, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	normalizeLeaveListParams(params)
+
+	requests, total, err := s.leaveRepo.FindByOrg(orgID, workspaceIDs, params)
+	if err != nil {
+		return nil, 0, err
+	}
+	return toLeaveRequestResponses(requests, s.calendar(orgID)), total, nil
+}
+
+// loadForReview loads a pending request the user is allowed to review
+func (s *leaveService) loadForReview(orgID, requestID, userID uint) (*models.LeaveRequest, error) {
+	workspaceIDs, err := s.reviewScope(orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := s.leaveRepo.FindByID(requestID)
+	if err != nil || request.OrganizationID != orgID {
+		return nil, ErrLeaveRequestNotFound
+	}
+
+	if workspaceIDs != nil {
+		memberships, err := s.workspaceRepo.GetUserWorkspacesByOrg(request.UserID, orgID)
+		if err != nil {
+			return nil, err
+		}
+		inScope := false
+		for _, m := range memberships {
+			for _, id := range workspaceIDs {
+				if m.WorkspaceID == id {
+					inScope = true
+				}
+			}
+		}
+		if !inScope {
+			return nil, apperror.Forbidden("access denied: requester is outside the workspaces you manage")
+		}
+	}
+
+	// Admins cannot approve their own leave; only the owner has nobody above them
+	if request.UserID == userID {
+		isOwner, _ := s.orgRepo.IsOwner(orgID, userID)
+		if !isOwner {
+			return nil, apperror.Forbidden("you cannot review your own leave request")
+		}
+	}
+
+	if request.Status != models.LeaveRequestPending {
+		return nil, apperror.Conflict("leave request has already been " + request.Status)
+	}
+
+	return request, nil
+}
+
+func (s *leaveService) Approve(orgID, requestID, userID uint, req *dto.ReviewLeaveRequest) (*dto.LeaveRequestResponse, error) {
+	request, err := s.loadForReview(orgID, requestID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.review(request, userID, models.LeaveRequestApproved, req.Note)
+}
+
+func (s *leaveService) Reject(orgID, requestID, userID uint, req *dto.ReviewLeaveRequest) (*dto.LeaveRequestResponse, error) {
+	if strings.TrimSpace(req.Note) == "" {
+		return nil, apperror.Validation("a note explaining the rejection is required", nil)
+	}
+
+	request, err := s.loadForReview(orgID, requestID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.review(request, userID, models.LeaveRequestRejected, req.Note)
+}
+
+func (s *leaveService) review(request *models.LeaveRequest, reviewerID uint, status, note string) (*dto.LeaveRequestResponse, error) {
+	now := time.Now()
+	request.Status = status
+	request.ReviewedBy = &reviewerID
+	request.ReviewedAt = &now
+	request.ReviewNote = strings.TrimSpace(note)
+
+	if err := s.leaveRepo.Update(request); err != nil {
+		return nil, err
+	}
+
+	s.notificationService.NotifyLeaveReviewed(request)
+
+	updated, err := s.leaveRepo.FindByID(request.ID)
+	if err != nil {
+		updated = request
+	}
+	response := toLeaveRequestResponse(updated, s.calendar(request.OrganizationID))
+	return &response, nil
+}
+
+// ============================================================================
+// TEAM CALENDAR
+// ============================================================================
+
+func (s *leaveService) GetTeamCalendar(orgID, userID uint, params *dto.TeamLeaveCalendarParams) (*dto.TeamLeaveCalendar, error) {
+	isMember, err := s.orgRepo.IsMember(orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, apperror.Forbidden("access denied: not a member of this organization")
+	}
+
+	today := time.Now().UTC()
+	startDate := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	if params.StartDate != nil {
+		startDate = *params.StartDate
+	}
+	endDate := startDate.AddDate(0, 0, defaultLeaveCalendarDays-1)
+	if params.EndDate != nil {
+		endDate = *params.EndDate
+	}
+	if endDate.Before(startDate) || endDate.Sub(startDate) >= maxLeaveCalendarDays*24*time.Hour {
+		return nil, ErrInvalidLeaveCalendarRange
+	}
+
+	var workspaceIDs []uint
+	if params.WorkspaceID != nil {
+		workspaceIDs = []uint{*params.WorkspaceID}
+	}
+	requests, err := s.leaveRepo.FindApprovedInOrg(orgID, workspaceIDs, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	holidays, err := s.holidayRepo.FindByOrg(orgID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	holidayNames := make(map[string]string, len(holidays))
+	for _, h := range holidays {
+		holidayNames[h.Date.Format("2006-01-02")] = h.Name
+	}
+
+	cal := s.calendar(orgID)
+	result := &dto.TeamLeaveCalendar{
+		OrganizationID: orgID,
+		StartDate:      startDate.Format("2006-01-02"),
+		EndDate:        endDate.Format("2006-01-02"),
+		Days:           []dto.TeamLeaveCalendarDay{},
+	}
+	for day := startDate; !day.After(endDate); day = day.AddDate(0, 0, 1) {
+		entry := dto.TeamLeaveCalendarDay{
+			Date:         day.Format("2006-01-02"),
+			IsWorkingDay: cal.IsWorkingDay(day),
+			Holiday:      holidayNames[day.Format("2006-01-02")],
+			OnLeave:      []dto.TeamLeaveCalendarEntry{},
+		}
+		for i := range requests {
+			r := &requests[i]
+			if r.StartDate.After(day) || r.EndDate.Before(day) {
+				continue
+			}
+			entry.OnLeave = append(entry.OnLeave, dto.TeamLeaveCalendarEntry{
+				RequestID: r.ID,
+				UserID:    r.UserID,
+				Name:      emailUserName(&r.User),
+				Type:      r.Type,
+			})
+		}
+		result.Days = append(result.Days, entry)
+	}
+
+	return result, nil
+}
+
+// ============================================================================
+// HELPER FUNCTIONS
+// ============================================================================
+
+// calendar returns the organization's calendar with its holidays, or the
+// default one if the organization cannot be loaded
+func (s *leaveService) calendar(orgID uint) calendar.Calendar {
+	org, err := s.orgRepo.GetByID(orgID)
+	if err != nil {
+		return calendar.Default()
+	}
+	// Leave dates are calendar dates, so days are counted in UTC
+	cal := org.Calendar()
+	cal.Location = time.UTC
+	if dates, err := s.holidayRepo.FindDates(orgID); err == nil {
+		cal = cal.WithHolidays(dates)
+	}
+	return cal
+}
+
+// leaveWorkingDays counts the working days of an inclusive leave range
+func leaveWorkingDays(cal calendar.Calendar, startDate, endDate time.Time) int {
+	return cal.WorkingDaysBetween(startDate, endDate.AddDate(0, 0, 1))
+}
+
+func normalizeLeaveListParams(params *dto.LeaveRequestListParams) {
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PerPage < 1 || params.PerPage > 100 {
+		params.PerPage = 20
+	}
+}
+
+func toLeaveRequestResponses(requests []models.LeaveRequest, cal calendar.Calendar) []dto.LeaveRequestResponse {
+	responses := make([]dto.LeaveRequestResponse, 0, len(requests))
+	for i := range requests {
+		responses = append(responses, toLeaveRequestResponse(&requests[i], cal))
+	}
+	return responses
+}
+
+func toLeaveRequestResponse(r *models.LeaveRequest, cal calendar.Calendar) dto.LeaveRequestResponse {
+	response := dto.LeaveRequestResponse{
+		ID:             r.ID,
+		OrganizationID: r.OrganizationID,
+		UserID:         r.UserID,
+		Type:           r.Type,
+		StartDate:      r.StartDate.Format("2006-01-02"),
+		EndDate:        r.EndDate.Format("2006-01-02"),
+		WorkingDays:    leaveWorkingDays(cal, r.StartDate, r.EndDate),
+		Reason:         r.Reason,
+		Status:         r.Status,
+		ReviewedBy:     r.ReviewedBy,
+		ReviewedAt:     r.ReviewedAt,
+		ReviewNote:     r.ReviewNote,
+		CreatedAt:      r.CreatedAt,
+	}
+
+	if r.User.ID > 0 {
+		response.User = &dto.UserResponse{
+			ID:        r.User.ID,
+			UUID:      r.User.UUID,
+			Email:     r.User.Email,
+			FirstName: r.User.FirstName,
+			LastName:  r.User.LastName,
+			Role:      r.User.Role,
+			IsActive:  r.User.IsActive,
+			CreatedAt: r.User.CreatedAt,
+		}
+	}
+	if r.Reviewer != nil && r.Reviewer.ID > 0 {
+		response.Reviewer = &dto.UserResponse{
+			ID:        r.Reviewer.ID,
+			UUID:      r.Reviewer.UUID,
+			Email:     r.Reviewer.Email,
+			FirstName: r.Reviewer.FirstName,
+			LastName:  r.Reviewer.LastName,
+			Role:      r.Reviewer.Role,
+			IsActive:  r.Reviewer.IsActive,
+			CreatedAt: r.Reviewer.CreatedAt,
+		}
+	}
+
+	return response
+}
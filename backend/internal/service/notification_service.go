@@ -0,0 +1,335 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/config"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+)
+
+// ErrNotificationNotFound is returned when a notification does not exist or belongs to another user
+var ErrNotificationNotFound = apperror.NotFound("notification not found")
+
+// NotificationService manages the in-app notifications behind the bell in
+// the web and desktop apps. The Notify methods are called by other services
+// after their change succeeded; failures are logged and never fail the caller.
+type NotificationService interface {
+	List(userID uint, params *dto.NotificationListParams) ([]dto.NotificationResponse, int64, error)
+	UnreadCount(userID uint) (*dto.UnreadNotificationCountResponse, error)
+	MarkRead(userID, notificationID uint) error
+	MarkAllRead(userID uint) error
+
+	// Events
+	NotifyInvitationReceived(invitation *models.Invitation, inviteeID uint)
+	NotifyTimeLogsRejected(timeLogIDs []uint)
+	NotifyWorkspaceArchived(workspace *models.Workspace)
+	NotifyBudgetThreshold(workspace *models.Workspace, threshold int, percentUsed float64)
+	NotifyLeaveReviewed(request *models.LeaveRequest)
+	NotifyOvertime(record *models.OvertimeRecord, user *models.User, workspaceIDs []uint)
+	NotifyTaskAssigned(task *models.Task, assigneeID uint, assignedBy *models.User)
+	NotifyTaskMentioned(task *models.Task, comment *models.TaskComment, userIDs []uint, author *models.User)
+
+	// PurgeOld deletes notifications past the retention (scheduled job)
+	PurgeOld(ctx context.Context) error
+}
+
+type notificationService struct {
+	notificationRepo repository.NotificationRepository
+	retention        time.Duration
+}
+
+// NewNotificationService creates a new notification service
+func NewNotificationService(notificationRepo repository.NotificationRepository) NotificationService {
+	return &notificationService{
+		notificationRepo: notificationRepo,
+		retention:        config.AppConfig.Notification.Retention,
+	}
+}
+
+func (s *notificationService) List(userID uint, params *dto.NotificationListParams) ([]dto.NotificationResponse, int64, error) {
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PerPage < 1 || params.PerPage > 100 {
+		params.PerPage = 20
+	}
+
+	notifications, total, err := s.notificationRepo.FindByUser(userID, params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	responses := make([]dto.NotificationResponse, 0, len(notifications))
+	for i := range notifications {
+		responses = append(responses, toNotificationResponse(&notifications[i]))
+	}
+	return responses, total, nil
+}
+
+func (s *notificationService) UnreadCount(userID uint) (*dto.UnreadNotificationCountResponse, error) {
+	count, err := s.notificationRepo.CountUnread(userID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.UnreadNotificationCountResponse{Unread: count}, nil
+}
+
+func (s *notificationService) MarkRead(userID, notificationID uint) error {
+	found, err := s.notificationRepo.MarkRead(userID, notificationID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrNotificationNotFound
+	}
+	return nil
+}
+
+func (s *notificationService) MarkAllRead(userID uint) error {
+	_, err := s.notificationRepo.MarkAllRead(userID, time.Now())
+	return err
+}
+
+// ============================================================================
+// EVENTS
+// ============================================================================
+
+func (s *notificationService) NotifyInvitationReceived(invitation *models.Invitation, inviteeID uint) {
+	title := fmt.Sprintf("You're invited to join %s", invitation.Organization.Name)
+	if invitation.Workspace != nil {
+		title = fmt.Sprintf("You're invited to join %s / %s", invitation.Organization.Name, invitation.Workspace.Name)
+	}
+	body := fmt.Sprintf("%s invited you. The invitation expires on %s.",
+		emailUserName(&invitation.Inviter), invitation.ExpiresAt.Format("Jan 2, 2006"))
+
+	s.create(models.Notification{
+		UserID:     inviteeID,
+		Type:       models.NotificationTypeInvitationReceived,
+		Title:      title,
+		Body:       body,
+		EntityType: "invitation",
+		EntityID:   &invitation.ID,
+	})
+}
+
+func (s *notificationService) NotifyTimeLogsRejected(timeLogIDs []uint) {
+	if len(timeLogIDs) == 0 {
+		return
+	}
+	owners, err := s.notificationRepo.FindTimeLogOwners(timeLogIDs)
+	if err != nil {
+		log.Printf("⚠️  Failed to load rejected time log owners: %v", err)
+		return
+	}
+
+	// A single rejection links to its time log; bulk rejections link nowhere
+	var entityID *uint
+	if len(timeLogIDs) == 1 {
+		entityID = &timeLogIDs[0]
+	}
+
+	notifications := make([]models.Notification, 0, len(owners))
+	for _, owner := range owners {
+		title := "Your time log was rejected"
+		if owner.Count > 1 {
+			title = fmt.Sprintf("%d of your time logs were rejected", owner.Count)
+		}
+		notifications = append(notifications, models.Notification{
+			UserID:     owner.UserID,
+			Type:       models.NotificationTypeTimeLogRejected,
+			Title:      title,
+			Body:       "Review the entries and contact your manager if you think this is a mistake.",
+			EntityType: "timelog",
+			EntityID:   entityID,
+		})
+	}
+	s.create(notifications...)
+}
+
+func (s *notificationService) NotifyWorkspaceArchived(workspace *models.Workspace) {
+	memberIDs, err := s.notificationRepo.FindWorkspaceMemberIDs(workspace.ID)
+	if err != nil {
+		log.Printf("⚠️  Failed to load members of archived workspace %d: %v", workspace.ID, err)
+		return
+	}
+
+	notifications := make([]models.Notification, 0, len(memberIDs))
+	for _, userID := range memberIDs {
+		notifications = append(notifications, models.Notification{
+			UserID:     userID,
+			Type:       models.NotificationTypeWorkspaceArchived,
+			Title:      fmt.Sprintf("Workspace %s was archived", workspace.Name),
+			Body:       "An administrator archived this workspace.",
+			EntityType: "workspace",
+			EntityID:   &workspace.ID,
+		})
+	}
+	s.create(notifications...)
+}
+
+func (s *notificationService) NotifyBudgetThreshold(workspace *models.Workspace, threshold int, percentUsed float64) {
+	adminIDs, err := s.notificationRepo.FindWorkspaceAdminIDs(workspace.ID)
+	if err != nil {
+		log.Printf("⚠️  Failed to load admins of workspace %d: %v", workspace.ID, err)
+		return
+	}
+
+	title := fmt.Sprintf("Workspace %s reached %d%% of its budget", workspace.Name, threshold)
+	body := fmt.Sprintf("Approved time has used %.1f%% of the budget.", percentUsed)
+	if percentUsed > 100 {
+		body = fmt.Sprintf("Approved time has used %.1f%% of the budget; the workspace is over budget.", percentUsed)
+	}
+
+	notifications := make([]models.Notification, 0, len(adminIDs))
+	for _, userID := range adminIDs {
+		notifications = append(notifications, models.Notification{
+			UserID:     userID,
+			Type:       models.NotificationTypeBudgetThreshold,
+			Title:      title,
+			Body:       body,
+			EntityType: "workspace",
+			EntityID:   &workspace.ID,
+		})
+	}
+	s.create(notifications...)
+}
+
+func (s *notificationService) NotifyLeaveReviewed(request *models.LeaveRequest) {
+	period := request.StartDate.Format("Jan 2")
+	if !request.EndDate.Equal(request.StartDate) {
+		period += " – " + request.EndDate.Format("Jan 2")
+	}
+
+	body := "Your manager reviewed the request."
+	if request.ReviewNote != "" {
+		body = request.ReviewNote
+	}
+	s.create(models.Notification{
+		UserID:     request.UserID,
+		Type:       models.NotificationTypeLeaveReviewed,
+		Title:      fmt.Sprintf("Your %s leave for %s was %s", request.Type, period, request.Status),
+		Body:       body,
+		EntityType: "leave_request",
+		EntityID:   &request.ID,
+	})
+}
+
+// NotifyOvertime tells the admins of the workspaces the overtime was tracked
+// in, except the user themselves
+func (s *notificationService) NotifyOvertime(record *models.OvertimeRecord, user *models.User, workspaceIDs []uint) {
+	recipients := make(map[uint]bool)
+	for _, workspaceID := range workspaceIDs {
+		adminIDs, err := s.notificationRepo.FindWorkspaceAdminIDs(workspaceID)
+		if err != nil {
+			log.Printf("⚠️  Failed to load admins of workspace %d: %v", workspaceID, err)
+			continue
+		}
+		for _, adminID := range adminIDs {
+			if adminID != user.ID {
+				recipients[adminID] = true
+			}
+		}
+	}
+	if len(recipients) == 0 {
+		return
+	}
+
+	period := "on " + record.PeriodStart.Format("Mon, Jan 2")
+	if record.Rule == models.OvertimeRuleWeekly {
+		period = "in the week of " + record.PeriodStart.Format("Jan 2")
+	}
+	title := fmt.Sprintf("%s worked overtime %s", emailUserName(user), period)
+	body := fmt.Sprintf("Tracked %.1fh against %.1fh expected by their work schedule: %.1fh overtime.",
+		secondsToHours(record.TrackedSeconds), secondsToHours(record.ExpectedSeconds), secondsToHours(record.OvertimeSeconds))
+
+	notifications := make([]models.Notification, 0, len(recipients))
+	for userID := range recipients {
+		notifications = append(notifications, models.Notification{
+			UserID:     userID,
+			Type:       models.NotificationTypeOvertimeDetected,
+			Title:      title,
+			Body:       body,
+			EntityType: "overtime_record",
+			EntityID:   &record.ID,
+		})
+	}
+	s.create(notifications...)
+}
+
+func (s *notificationService) NotifyTaskAssigned(task *models.Task, assigneeID uint, assignedBy *models.User) {
+	body := "The task now shows in your task list."
+	if task.DueDate != nil {
+		body = fmt.Sprintf("The task is due on %s and now shows in your task list.", task.DueDate.Format("Jan 2, 2006"))
+	}
+	s.create(models.Notification{
+		UserID:     assigneeID,
+		Type:       models.NotificationTypeTaskAssigned,
+		Title:      fmt.Sprintf("%s assigned you to %s", emailUserName(assignedBy), task.Title),
+		Body:       body,
+		EntityType: "task",
+		EntityID:   &task.ID,
+	})
+}
+
+func (s *notificationService) NotifyTaskMentioned(task *models.Task, comment *models.TaskComment, userIDs []uint, author *models.User) {
+	if len(userIDs) == 0 {
+		return
+	}
+
+	body := []rune(comment.Body)
+	if len(body) > 200 {
+		body = append(body[:199], '…')
+	}
+	notifications := make([]models.Notification, 0, len(userIDs))
+	for _, userID := range userIDs {
+		notifications = append(notifications, models.Notification{
+			UserID:     userID,
+			Type:       models.NotificationTypeTaskMentioned,
+			Title:      fmt.Sprintf("%s mentioned you on %s", emailUserName(author), task.Title),
+			Body:       string(body),
+			EntityType: "task",
+			EntityID:   &task.ID,
+		})
+	}
+	s.create(notifications...)
+}
+
+func (s *notificationService) create(notifications ...models.Notification) {
+	if err := s.notificationRepo.CreateBatch(notifications); err != nil {
+		log.Printf("⚠️  Failed to create %d notifications: %v", len(notifications), err)
+	}
+}
+
+// ============================================================================
+// SCHEDULED JOBS
+// ============================================================================
+
+func (s *notificationService) PurgeOld(ctx context.Context) error {
+	deleted, err := s.notificationRepo.DeleteBefore(time.Now().Add(-s.retention))
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		log.Printf("✅ Purged %d old notifications", deleted)
+	}
+	return nil
+}
+
+func toNotificationResponse(n *models.Notification) dto.NotificationResponse {
+	return dto.NotificationResponse{
+		ID:         n.ID,
+		Type:       n.Type,
+		Title:      n.Title,
+		Body:       n.Body,
+		EntityType: n.EntityType,
+		EntityID:   n.EntityID,
+		ReadAt:     n.ReadAt,
+		CreatedAt:  n.CreatedAt,
+	}
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+)
+
+// operationRetention is how long finished operations stay pollable
+const operationRetention = 30 * 24 * time.Hour
+
+// OperationService tracks long-running background work. Features start an
+// operation when they queue work, report progress while it runs and finish
+// it with a result link, so clients poll one resource for all of them.
+type OperationService interface {
+	Start(opType string, userID uint, orgID *uint) (*models.Operation, error)
+	// SetProgress records percent done (0-99) and marks the operation running
+	SetProgress(op *models.Operation, progress int)
+	// Complete finishes the operation; result, when not nil, is stored as JSON
+	Complete(op *models.Operation, resultURL string, result interface{})
+	Fail(op *models.Operation, err error)
+
+	GetOperation(id, userID uint) (*dto.OperationResponse, error)
+
+	// PurgeFinished deletes old finished operations; it matches scheduler.JobFunc
+	PurgeFinished(ctx context.Context) error
+}
+
+type operationService struct {
+	operationRepo repository.OperationRepository
+	// mu serializes saves of one operation from concurrent progress callbacks
+	mu sync.Mutex
+}
+
+// NewOperationService creates a new operation service
+func NewOperationService(operationRepo repository.OperationRepository) OperationService {
+	return &operationService{
+		operationRepo: operationRepo,
+	}
+}
+
+func (s *operationService) Start(opType string, userID uint, orgID *uint) (*models.Operation, error) {
+	op := &models.Operation{
+		Type:           opType,
+		UserID:         userID,
+		OrganizationID: orgID,
+		Status:         models.OperationStatusPending,
+	}
+	if err := s.operationRepo.Create(op); err != nil {
+		return nil, errors.New("failed to create operation")
+	}
+	return op, nil
+}
+
+func (s *operationService) SetProgress(op *models.Operation, progress int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if progress > 99 {
+		progress = 99
+	}
+	if op.Status == models.OperationStatusRunning && progress <= op.Progress {
+		return
+	}
+	if op.StartedAt == nil {
+		now := time.Now()
+		op.StartedAt = &now
+	}
+	op.Status = models.OperationStatusRunning
+	if progress > op.Progress {
+		op.Progress = progress
+	}
+	s.save(op)
+}
+
+func (s *operationService) Complete(op *models.Operation, resultURL string, result interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if op.StartedAt == nil {
+		op.StartedAt = &now
+	}
+	op.Status = models.OperationStatusCompleted
+	op.Progress = 100
+	op.ResultURL = resultURL
+	op.CompletedAt = &now
+	if result != nil {
+		if data, err := json.Marshal(result); err == nil {
+			op.Result = string(data)
+		}
+	}
+	s.save(op)
+}
+
+func (s *operationService) Fail(op *models.Operation, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	op.Status = models.OperationStatusFailed
+	op.Error = err.Error()
+	op.CompletedAt = &now
+	s.save(op)
+}
+
+func (s *operationService) save(op *models.Operation) {
+	if err := s.operationRepo.Update(op); err != nil {
+		log.Printf("⚠️  Failed to update operation %d: %v", op.ID, err)
+	}
+}
+
+func (s *operationService) GetOperation(id, userID uint) (*dto.OperationResponse, error) {
+	op, err := s.operationRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if op.UserID != userID {
+		return nil, apperror.NotFound("operation not found")
+	}
+
+	resp := &dto.OperationResponse{
+		ID:             op.ID,
+		Type:           op.Type,
+		OrganizationID: op.OrganizationID,
+		Status:         op.Status,
+		Progress:       op.Progress,
+		ResultURL:      op.ResultURL,
+		Error:          op.Error,
+		CreatedAt:      op.CreatedAt,
+		StartedAt:      op.StartedAt,
+		CompletedAt:    op.CompletedAt,
+	}
+	if op.Result != "" {
+		resp.Result = json.RawMessage(op.Result)
+	}
+	return resp, nil
+}
+
+func (s *operationService) PurgeFinished(ctx context.Context) error {
+	deleted, err := s.operationRepo.DeleteFinishedBefore(time.Now().Add(-operationRetention))
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		log.Printf("✅ Deleted %d finished operations", deleted)
+	}
+	return nil
+}
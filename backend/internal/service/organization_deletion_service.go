@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/config"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+)
+
+// ErrOrganizationDeletionNotScheduled is returned when cancelling a deletion
+// that was never requested
+var ErrOrganizationDeletionNotScheduled = apperror.Conflict("no organization deletion is scheduled")
+
+// OrganizationDeletionService deletes organizations: a deletion is scheduled
+// after a grace period, during which the owner can cancel it, and then the
+// organization and all its data are purged in the background
+type OrganizationDeletionService interface {
+	RequestDeletion(orgID, userID uint) (*dto.OrganizationDeletionResponse, error)
+	CancelDeletion(orgID, userID uint) error
+	// ScheduleDeletion schedules a deletion requested by a system admin
+	ScheduleDeletion(orgID uint) (*dto.OrganizationDeletionResponse, error)
+
+	// Scheduled jobs
+	ProcessDueDeletions(ctx context.Context) error
+}
+
+type organizationDeletionService struct {
+	orgRepo     *repository.OrganizationRepository
+	tierService ScreenshotTierService
+	gracePeriod time.Duration
+}
+
+// NewOrganizationDeletionService creates a new organization deletion service
+func NewOrganizationDeletionService(orgRepo *repository.OrganizationRepository, tierService ScreenshotTierService) OrganizationDeletionService {
+	return &organizationDeletionService{
+		orgRepo:     orgRepo,
+		tierService: tierService,
+		gracePeriod: config.AppConfig.Privacy.OrgDeletionGracePeriod,
+	}
+}
+
+// RequestDeletion schedules the organization for deletion. Requesting it
+// again keeps the original schedule.
+func (s *organizationDeletionService) RequestDeletion(orgID, userID uint) (*dto.OrganizationDeletionResponse, error) {
+	isOwner, err := s.orgRepo.IsOwner(orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isOwner {
+		return nil, apperror.Forbidden("access denied: only owner can delete organization")
+	}
+
+	return s.schedule(orgID, &userID)
+}
+
+func (s *organizationDeletionService) ScheduleDeletion(orgID uint) (*dto.OrganizationDeletionResponse, error) {
+	return s.schedule(orgID, nil)
+}
+
+func (s *organizationDeletionService) schedule(orgID uint, requestedBy *uint) (*dto.OrganizationDeletionResponse, error) {
+	org, err := s.orgRepo.GetByID(orgID)
+	if err != nil {
+		return nil, apperror.NotFound("organization not found")
+	}
+
+	if org.DeletionScheduledAt == nil {
+		now := time.Now()
+		scheduledAt := now.Add(s.gracePeriod)
+		org.DeletionRequestedAt = &now
+		org.DeletionScheduledAt = &scheduledAt
+		org.DeletionRequestedBy = requestedBy
+
+		if err := s.orgRepo.Update(org); err != nil {
+			return nil, err
+		}
+	}
+
+	return toOrganizationDeletionResponse(org), nil
+}
+
+// CancelDeletion withdraws a pending deletion during the grace period
+func (s *organizationDeletionService) CancelDeletion(orgID, userID uint) error {
+	isOwner, err := s.orgRepo.IsOwner(orgID, userID)
+	if err != nil {
+		return err
+	}
+	if !isOwner {
+		return apperror.Forbidden("access denied: only owner can cancel the deletion")
+	}
+
+	org, err := s.orgRepo.GetByID(orgID)
+	if err != nil {
+		return apperror.NotFound("organization not found")
+	}
+	if org.DeletionScheduledAt == nil {
+		return ErrOrganizationDeletionNotScheduled
+	}
+
+	org.DeletionRequestedAt = nil
+	org.DeletionScheduledAt = nil
+	org.DeletionRequestedBy = nil
+	return s.orgRepo.Update(org)
+}
+
+// ProcessDueDeletions purges organizations whose deletion grace period has
+// ended, with their screenshot, attachment and export files
+func (s *organizationDeletionService) ProcessDueDeletions(ctx context.Context) error {
+	orgs, err := s.orgRepo.FindDueForDeletion(time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to load organizations due for deletion: %w", err)
+	}
+
+	for _, org := range orgs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		filePaths, err := s.orgRepo.PurgeOrganization(org.ID)
+		if err != nil {
+			log.Printf("⚠️  Failed to purge organization %d: %v", org.ID, err)
+			continue
+		}
+
+		for _, path := range filePaths {
+			if err := utils.DeleteFile(path); err != nil {
+				log.Printf("⚠️  Failed to purge file of deleted organization %d: %v", org.ID, err)
+			}
+		}
+		s.tierService.DeleteColdCopies(ctx, filePaths)
+
+		log.Printf("✅ Purged organization %d (%d files purged)", org.ID, len(filePaths))
+	}
+
+	return nil
+}
+
+func toOrganizationDeletionResponse(org *models.Organization) *dto.OrganizationDeletionResponse {
+	return &dto.OrganizationDeletionResponse{
+		OrganizationID:      org.ID,
+		DeletionRequestedAt: org.DeletionRequestedAt,
+		DeletionScheduledAt: org.DeletionScheduledAt,
+	}
+}
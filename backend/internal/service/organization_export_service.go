@@ -0,0 +1,470 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/config"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+)
+
+// orgExportBatchSize bounds how many time logs or screenshots are loaded at once
+const orgExportBatchSize = 1000
+
+// ErrExportLinkInvalid is returned for a tampered or expired download link
+var ErrExportLinkInvalid = apperror.Forbidden("download link is invalid or has expired")
+
+// OrganizationExportService builds full data export archives of organizations
+type OrganizationExportService interface {
+	RequestExport(orgID, actorID uint) (*dto.OrganizationExportResponse, error)
+	ListExports(orgID, actorID uint) ([]dto.OrganizationExportResponse, error)
+	GetExport(orgID, exportID, actorID uint) (*dto.OrganizationExportResponse, error)
+	// GetSignedExportFile returns a completed export for a signed download link
+	GetSignedExportFile(exportID uint, expires, signature string) (*models.OrganizationExport, error)
+	PurgeExpiredExports(ctx context.Context) error
+}
+
+type organizationExportService struct {
+	exportRepo   repository.OrganizationExportRepository
+	orgRepo      *repository.OrganizationRepository
+	tierService  ScreenshotTierService
+	operations   OperationService
+	exportDir    string
+	exportMaxAge time.Duration
+	linkTTL      time.Duration
+	signingKey   []byte
+}
+
+// NewOrganizationExportService creates a new organization export service
+func NewOrganizationExportService(
+	exportRepo repository.OrganizationExportRepository,
+	orgRepo *repository.OrganizationRepository,
+	tierService ScreenshotTierService,
+	operations OperationService,
+) OrganizationExportService {
+	return &organizationExportService{
+		exportRepo:   exportRepo,
+		orgRepo:      orgRepo,
+		tierService:  tierService,
+		operations:   operations,
+		exportDir:    filepath.Join(config.AppConfig.Upload.PrivatePath, "organization-exports"),
+		exportMaxAge: config.AppConfig.Privacy.ExportRetention,
+		linkTTL:      config.AppConfig.Privacy.ExportLinkTTL,
+		signingKey:   []byte(config.AppConfig.JWT.Secret),
+	}
+}
+
+func (s *organizationExportService) requireOwner(orgID, userID uint) error {
+	isOwner, err := s.orgRepo.IsOwner(orgID, userID)
+	if err != nil {
+		return err
+	}
+	if !isOwner {
+		return apperror.Forbidden("access denied: only the organization owner can export its data")
+	}
+	return nil
+}
+
+// RequestExport queues a new export archive; it is built in the background
+func (s *organizationExportService) RequestExport(orgID, actorID uint) (*dto.OrganizationExportResponse, error) {
+	if err := s.requireOwner(orgID, actorID); err != nil {
+		return nil, err
+	}
+
+	active, err := s.exportRepo.FindActive(orgID)
+	if err != nil {
+		return nil, err
+	}
+	if active != nil {
+		return nil, apperror.Conflict("an organization export is already in progress")
+	}
+
+	op, err := s.operations.Start(models.OperationTypeOrganizationExport, actorID, &orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &models.OrganizationExport{
+		OrganizationID: orgID,
+		RequestedBy:    actorID,
+		OperationID:    &op.ID,
+		Status:         models.DataExportStatusPending,
+	}
+	if err := s.exportRepo.Create(export); err != nil {
+		s.operations.Fail(op, err)
+		return nil, errors.New("failed to create organization export")
+	}
+
+	go s.buildExport(export, op)
+
+	return s.toResponse(export), nil
+}
+
+func (s *organizationExportService) ListExports(orgID, actorID uint) ([]dto.OrganizationExportResponse, error) {
+	if err := s.requireOwner(orgID, actorID); err != nil {
+		return nil, err
+	}
+
+	exports, err := s.exportRepo.FindByOrganization(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.OrganizationExportResponse, 0, len(exports))
+	for i := range exports {
+		responses = append(responses, *s.toResponse(&exports[i]))
+	}
+	return responses, nil
+}
+
+// GetExport returns an export's status and progress, with a fresh signed
+// download link once it is completed
+func (s *organizationExportService) GetExport(orgID, exportID, actorID uint) (*dto.OrganizationExportResponse, error) {
+	if err := s.requireOwner(orgID, actorID); err != nil {
+		return nil, err
+	}
+
+	export, err := s.exportRepo.FindByID(exportID)
+	if err != nil {
+		return nil, err
+	}
+	if export.OrganizationID != orgID {
+		return nil, apperror.NotFound("organization export not found")
+	}
+	return s.toResponse(export), nil
+}
+
+func (s *organizationExportService) GetSignedExportFile(exportID uint, expires, signature string) (*models.OrganizationExport, error) {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt {
+		return nil, ErrExportLinkInvalid
+	}
+	expected := s.sign(exportID, expiresAt)
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return nil, ErrExportLinkInvalid
+	}
+
+	export, err := s.exportRepo.FindByID(exportID)
+	if err != nil {
+		return nil, err
+	}
+	if export.Status != models.DataExportStatusCompleted {
+		return nil, apperror.Conflict("organization export is not ready yet")
+	}
+	if export.ExpiresAt != nil && time.Now().After(*export.ExpiresAt) {
+		return nil, apperror.Gone("organization export has expired")
+	}
+	return export, nil
+}
+
+// sign authenticates a download link for an export until expires (unix seconds)
+func (s *organizationExportService) sign(exportID uint, expires int64) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	fmt.Fprintf(mac, "organization-export:%d:%d", exportID, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// PurgeExpiredExports deletes export archives past their retention
+func (s *organizationExportService) PurgeExpiredExports(ctx context.Context) error {
+	exports, err := s.exportRepo.FindExpired(time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to load expired organization exports: %w", err)
+	}
+
+	for _, export := range exports {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if export.FilePath != "" {
+			if err := utils.DeleteFile(export.FilePath); err != nil {
+				log.Printf("⚠️  Failed to delete organization export file %d: %v", export.ID, err)
+				continue
+			}
+		}
+		_ = s.exportRepo.Delete(export.ID)
+	}
+
+	return nil
+}
+
+func (s *organizationExportService) buildExport(export *models.OrganizationExport, op *models.Operation) {
+	export.Status = models.DataExportStatusProcessing
+	_ = s.exportRepo.Update(export)
+	s.operations.SetProgress(op, 0)
+
+	filePath, err := s.writeExportArchive(export, op)
+	if err != nil {
+		log.Printf("⚠️  Organization export %d for organization %d failed: %v", export.ID, export.OrganizationID, err)
+		export.Status = models.DataExportStatusFailed
+		export.Error = err.Error()
+		_ = s.exportRepo.Update(export)
+		s.operations.Fail(op, err)
+		return
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.exportMaxAge)
+	export.Status = models.DataExportStatusCompleted
+	export.Progress = 100
+	export.FilePath = filePath
+	export.FileName = filepath.Base(filePath)
+	export.CompletedAt = &now
+	export.ExpiresAt = &expiresAt
+	if info, err := os.Stat(filePath); err == nil {
+		export.FileSize = info.Size()
+	}
+
+	if err := s.exportRepo.Update(export); err != nil {
+		log.Printf("⚠️  Failed to update organization export %d: %v", export.ID, err)
+	}
+	// The export resource hands out fresh signed links, so point there
+	s.operations.Complete(op, fmt.Sprintf("/api/v1/organizations/%d/exports/%d", export.OrganizationID, export.ID), nil)
+}
+
+// exportProgress saves the share of records written, at most once per percent
+type exportProgress struct {
+	s      *organizationExportService
+	export *models.OrganizationExport
+	op     *models.Operation
+	total  int64
+	done   int64
+}
+
+func (p *exportProgress) add(n int) {
+	p.done += int64(n)
+	if p.total == 0 {
+		return
+	}
+	// Stop at 99; 100 means the archive is complete
+	percent := int(p.done * 99 / p.total)
+	if percent > p.export.Progress {
+		p.export.Progress = percent
+		_ = p.s.exportRepo.Update(p.export)
+		p.s.operations.SetProgress(p.op, percent)
+	}
+}
+
+// writeExportArchive writes a zip with one JSON file per record type plus
+// the organization's screenshot images
+func (s *organizationExportService) writeExportArchive(export *models.OrganizationExport, op *models.Operation) (string, error) {
+	orgID := export.OrganizationID
+	data, err := s.exportRepo.LoadOrganizationData(orgID)
+	if err != nil {
+		return "", err
+	}
+	timeLogCount, err := s.exportRepo.CountTimeLogs(orgID)
+	if err != nil {
+		return "", err
+	}
+	screenshotCount, err := s.exportRepo.CountScreenshots(orgID)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(s.exportDir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	suffix, err := utils.GenerateSecureToken(16)
+	if err != nil {
+		return "", err
+	}
+	fileName := fmt.Sprintf("organization_%d_export_%s_%s.zip", orgID, time.Now().Format("20060102150405"), suffix)
+	filePath := filepath.Join(s.exportDir, fileName)
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create export file: %w", err)
+	}
+	defer file.Close()
+
+	if err := s.writeArchive(file, export, op, data, timeLogCount, screenshotCount); err != nil {
+		file.Close()
+		os.Remove(filePath)
+		return "", err
+	}
+	return filePath, nil
+}
+
+func (s *organizationExportService) writeArchive(file io.Writer, export *models.OrganizationExport, op *models.Operation, data *repository.OrganizationDataSnapshot, timeLogCount, screenshotCount int64) error {
+	orgID := export.OrganizationID
+	archive := zip.NewWriter(file)
+	// Screenshots are read twice: once for screenshots.json, once for files
+	progress := &exportProgress{s: s, export: export, op: op, total: int64(len(data.Tasks)) + timeLogCount + 2*screenshotCount}
+
+	entries := []struct {
+		name  string
+		value interface{}
+	}{
+		{"organization.json", data.Organization},
+		{"members.json", data.Members},
+		{"workspaces.json", data.Workspaces},
+		{"workspace_members.json", data.WorkspaceMembers},
+		{"tasks.json", data.Tasks},
+	}
+	for _, entry := range entries {
+		w, err := archive.Create(entry.name)
+		if err != nil {
+			return err
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(entry.value); err != nil {
+			return fmt.Errorf("failed to write %s: %w", entry.name, err)
+		}
+	}
+	progress.add(len(data.Tasks))
+
+	// Time logs and screenshot metadata are streamed as JSON arrays
+	w, err := archive.Create("time_logs.json")
+	if err != nil {
+		return err
+	}
+	timeLogs := newJSONArrayWriter(w)
+	err = s.exportRepo.TimeLogsInBatches(orgID, orgExportBatchSize, func(batch []models.TimeLog) error {
+		for i := range batch {
+			if err := timeLogs.write(batch[i]); err != nil {
+				return err
+			}
+		}
+		progress.add(len(batch))
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write time_logs.json: %w", err)
+	}
+	if err := timeLogs.close(); err != nil {
+		return err
+	}
+
+	w, err = archive.Create("screenshots.json")
+	if err != nil {
+		return err
+	}
+	screenshots := newJSONArrayWriter(w)
+	err = s.exportRepo.ScreenshotsInBatches(orgID, orgExportBatchSize, func(batch []models.Screenshot) error {
+		for i := range batch {
+			if err := screenshots.write(batch[i]); err != nil {
+				return err
+			}
+		}
+		progress.add(len(batch))
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write screenshots.json: %w", err)
+	}
+	if err := screenshots.close(); err != nil {
+		return err
+	}
+
+	err = s.exportRepo.ScreenshotsInBatches(orgID, orgExportBatchSize, func(batch []models.Screenshot) error {
+		for i := range batch {
+			ss := &batch[i]
+			// Files flagged by the malware scanner are never handed out
+			if ss.ScanStatus == models.ScreenshotScanQuarantined {
+				continue
+			}
+			// Archived screenshots still being restored are skipped like missing files
+			if err := s.tierService.EnsureLocal(context.Background(), ss); err != nil {
+				log.Printf("⚠️  Skipping screenshot %d in organization export: %v", ss.ID, err)
+				continue
+			}
+			name := fmt.Sprintf("screenshots/%d/%d_%s", ss.UserID, ss.ID, ss.FileName)
+			if err := addFileToArchive(archive, ss.FilePath, name); err != nil {
+				// Missing files are skipped; the metadata is still exported
+				log.Printf("⚠️  Skipping screenshot %d in organization export: %v", ss.ID, err)
+			}
+		}
+		progress.add(len(batch))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("failed to finalize export archive: %w", err)
+	}
+	return nil
+}
+
+// jsonArrayWriter writes values as a JSON array one element at a time
+type jsonArrayWriter struct {
+	w     io.Writer
+	count int
+}
+
+func newJSONArrayWriter(w io.Writer) *jsonArrayWriter {
+	return &jsonArrayWriter{w: w}
+}
+
+func (a *jsonArrayWriter) write(value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	sep := ",\n  "
+	if a.count == 0 {
+		sep = "[\n  "
+	}
+	a.count++
+	if _, err := io.WriteString(a.w, sep); err != nil {
+		return err
+	}
+	_, err = a.w.Write(data)
+	return err
+}
+
+func (a *jsonArrayWriter) close() error {
+	end := "\n]\n"
+	if a.count == 0 {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(a.w, end)
+	return err
+}
+
+func (s *organizationExportService) toResponse(export *models.OrganizationExport) *dto.OrganizationExportResponse {
+	resp := &dto.OrganizationExportResponse{
+		ID:             export.ID,
+		OrganizationID: export.OrganizationID,
+		RequestedBy:    export.RequestedBy,
+		OperationID:    export.OperationID,
+		Status:         export.Status,
+		Progress:       export.Progress,
+		FileName:       export.FileName,
+		FileSize:       export.FileSize,
+		Error:          export.Error,
+		CreatedAt:      export.CreatedAt,
+		CompletedAt:    export.CompletedAt,
+		ExpiresAt:      export.ExpiresAt,
+	}
+	if export.Status == models.DataExportStatusCompleted {
+		linkExpiresAt := time.Now().Add(s.linkTTL).Truncate(time.Second)
+		if export.ExpiresAt != nil && export.ExpiresAt.Before(linkExpiresAt) {
+			linkExpiresAt = export.ExpiresAt.Truncate(time.Second)
+		}
+		expires := linkExpiresAt.Unix()
+		resp.DownloadURL = fmt.Sprintf("/api/v1/public/organization-exports/%d/download?expires=%d&signature=%s",
+			export.ID, expires, s.sign(export.ID, expires))
+		resp.DownloadURLExpiresAt = &linkExpiresAt
+	}
+	return resp
+}
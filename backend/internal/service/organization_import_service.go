@@ -0,0 +1,602 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+)
+
+const (
+	// importMaxRows bounds the rows of one CSV import
+	importMaxRows = 50000
+	// importMaxReportedErrors bounds the row errors listed in the response
+	importMaxReportedErrors = 1000
+	// importMaxEntryDuration rejects entries that cannot be a single session
+	importMaxEntryDuration = 24 * time.Hour
+)
+
+// Fields a CSV column can map to
+const (
+	importFieldEmail       = "email"
+	importFieldUser        = "user"
+	importFieldProject     = "project"
+	importFieldTask        = "task"
+	importFieldDescription = "description"
+	importFieldStartDate   = "start_date"
+	importFieldStartTime   = "start_time"
+	importFieldEndDate     = "end_date"
+	importFieldEndTime     = "end_time"
+	importFieldDuration    = "duration"
+)
+
+// importSource describes the CSV export of one time tracker: the headers of
+// each field (lowercase, first match wins) and its date formats
+type importSource struct {
+	name        string
+	title       string
+	columns     map[string][]string
+	dateLayouts []string
+}
+
+var importSources = map[string]importSource{
+	"toggl": {
+		name:  "toggl",
+		title: "Toggl",
+		columns: map[string][]string{
+			importFieldEmail:       {"email"},
+			importFieldUser:        {"user"},
+			importFieldProject:     {"project"},
+			importFieldTask:        {"task"},
+			importFieldDescription: {"description"},
+			importFieldStartDate:   {"start date"},
+			importFieldStartTime:   {"start time"},
+			importFieldEndDate:     {"end date"},
+			importFieldEndTime:     {"end time"},
+			importFieldDuration:    {"duration"},
+		},
+		dateLayouts: []string{"2006-01-02", "01/02/2006"},
+	},
+	"clockify": {
+		name:  "clockify",
+		title: "Clockify",
+		columns: map[string][]string{
+			importFieldEmail:       {"email"},
+			importFieldUser:        {"user"},
+			importFieldProject:     {"project"},
+			importFieldTask:        {"task"},
+			importFieldDescription: {"description"},
+			importFieldStartDate:   {"start date"},
+			importFieldStartTime:   {"start time"},
+			importFieldEndDate:     {"end date"},
+			importFieldEndTime:     {"end time"},
+			importFieldDuration:    {"duration (h)", "duration (decimal)", "duration"},
+		},
+		dateLayouts: []string{"01/02/2006", "2006-01-02", "02.01.2006"},
+	},
+	"hubstaff": {
+		name:  "hubstaff",
+		title: "Hubstaff",
+		columns: map[string][]string{
+			importFieldEmail:       {"email", "member email"},
+			importFieldUser:        {"member"},
+			importFieldProject:     {"project"},
+			importFieldTask:        {"task", "to-do", "task summary"},
+			importFieldDescription: {"notes", "note"},
+			importFieldStartDate:   {"date", "start date"},
+			importFieldStartTime:   {"start", "start time"},
+			importFieldEndDate:     {"end date"},
+			importFieldEndTime:     {"stop", "end", "end time"},
+			importFieldDuration:    {"time", "duration"},
+		},
+		dateLayouts: []string{"2006-01-02", "01/02/2006", "Jan 2, 2006", "Mon, Jan 2, 2006"},
+	},
+}
+
+// importClockLayouts are the time-of-day formats accepted from every source
+var importClockLayouts = []string{"15:04:05", "15:04", "3:04:05 PM", "3:04 PM", "3:04:05PM", "3:04PM"}
+
+// importDateTimeLayouts are accepted in a time column when there is no date column
+var importDateTimeLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02T15:04:05"}
+
+// OrganizationImportService imports historical time data exported from other
+// time trackers
+type OrganizationImportService interface {
+	Import(orgID, actorID uint, req *dto.OrganizationImportRequest, file io.Reader) (*dto.OrganizationImportResponse, error)
+}
+
+type organizationImportService struct {
+	importRepo    repository.OrganizationImportRepository
+	orgRepo       *repository.OrganizationRepository
+	workspaceRepo *repository.WorkspaceRepository
+}
+
+// NewOrganizationImportService creates a new organization import service
+func NewOrganizationImportService(
+	importRepo repository.OrganizationImportRepository,
+	orgRepo *repository.OrganizationRepository,
+	workspaceRepo *repository.WorkspaceRepository,
+) OrganizationImportService {
+	return &organizationImportService{
+		importRepo:    importRepo,
+		orgRepo:       orgRepo,
+		workspaceRepo: workspaceRepo,
+	}
+}
+
+// importRow is a validated CSV row
+type importRow struct {
+	userID      uint
+	title       string
+	project     string
+	description string
+	start       time.Time
+	end         time.Time
+}
+
+// importMembers resolves CSV users to organization members, by email first
+// and by full name when the export has no email column
+type importMembers struct {
+	byEmail map[string]uint
+	byName  map[string][]uint
+}
+
+func (m *importMembers) resolve(email, name string) (uint, error) {
+	if email != "" {
+		if id, ok := m.byEmail[strings.ToLower(email)]; ok {
+			return id, nil
+		}
+		return 0, apperror.Validation(fmt.Sprintf("no organization member with email %q", email), nil)
+	}
+	if name == "" {
+		return 0, apperror.Validation("row has no user email or name", nil)
+	}
+	ids := m.byName[strings.ToLower(name)]
+	switch len(ids) {
+	case 0:
+		return 0, apperror.Validation(fmt.Sprintf("no organization member named %q", name), nil)
+	case 1:
+		return ids[0], nil
+	default:
+		return 0, apperror.Validation(fmt.Sprintf("several organization members are named %q; export with emails", name), nil)
+	}
+}
+
+// Import validates every CSV row, then creates tasks and time logs for the
+// valid ones in one transaction. Rows imported before are skipped, so a file
+// can be imported again after fixing its failed rows.
+func (s *organizationImportService) Import(orgID, actorID uint, req *dto.OrganizationImportRequest, file io.Reader) (*dto.OrganizationImportResponse, error) {
+	isAdmin, err := s.orgRepo.IsAdmin(orgID, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if !isAdmin {
+		return nil, apperror.Forbidden("access denied: only organization admins can import data")
+	}
+
+	var workspaceID *uint
+	if req.WorkspaceID != nil {
+		workspace, err := s.workspaceRepo.GetByID(*req.WorkspaceID)
+		if err != nil || workspace.OrganizationID != orgID {
+			return nil, apperror.NotFound("workspace not found in this organization")
+		}
+		workspaceID = &workspace.ID
+	}
+
+	loc := time.UTC
+	if req.Timezone != "" {
+		if loc, err = time.LoadLocation(req.Timezone); err != nil {
+			return nil, apperror.Validation(fmt.Sprintf("unknown timezone %q", req.Timezone), nil)
+		}
+	}
+
+	members, err := s.loadMembers(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, apperror.Validation("CSV file is empty or invalid", nil)
+	}
+	headers := make([]string, len(header))
+	for i, h := range header {
+		// Excel adds a byte order mark to UTF-8 exports
+		headers[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+	}
+
+	source, err := resolveImportSource(req.Source, headers)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := source.mapColumns(headers)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.OrganizationImportResponse{
+		Source: source.name,
+		DryRun: req.DryRun,
+		Errors: []dto.OrganizationImportRowError{},
+	}
+	fail := func(line int, err error) {
+		result.Failed++
+		if len(result.Errors) < importMaxReportedErrors {
+			result.Errors = append(result.Errors, dto.OrganizationImportRowError{Line: line, Error: err.Error()})
+		} else {
+			result.ErrorsTruncated = true
+		}
+	}
+
+	var rows []importRow
+	now := time.Now()
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, err
+			}
+			result.TotalRows++
+			fail(parseErr.StartLine, apperror.Validation("malformed CSV row", nil))
+			continue
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+
+		result.TotalRows++
+		if result.TotalRows > importMaxRows {
+			return nil, apperror.Validation(fmt.Sprintf("CSV file has more than %d rows; split it into smaller files", importMaxRows), nil)
+		}
+
+		row, err := source.parseRow(columns, record, members, loc, now)
+		if err != nil {
+			fail(line, err)
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	tasks, logs, err := s.buildRecords(source, orgID, workspaceID, rows, result)
+	if err != nil {
+		return nil, err
+	}
+	result.Imported = len(logs)
+
+	if req.DryRun || len(logs) == 0 {
+		return result, nil
+	}
+	if err := s.importRepo.Import(tasks, logs); err != nil {
+		return nil, fmt.Errorf("failed to import time data: %w", err)
+	}
+	return result, nil
+}
+
+func (s *organizationImportService) loadMembers(orgID uint) (*importMembers, error) {
+	orgMembers, err := s.orgRepo.GetMembersByOrgID(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	members := &importMembers{byEmail: map[string]uint{}, byName: map[string][]uint{}}
+	for _, member := range orgMembers {
+		user := member.User
+		members.byEmail[strings.ToLower(user.Email)] = user.ID
+		name := strings.ToLower(strings.TrimSpace(user.FirstName + " " + user.LastName))
+		if name != "" {
+			members.byName[name] = append(members.byName[name], user.ID)
+		}
+	}
+	return members, nil
+}
+
+// buildRecords turns valid rows into time logs and the tasks they belong to,
+// reusing tasks and skipping time logs created by earlier imports
+func (s *organizationImportService) buildRecords(source importSource, orgID uint, workspaceID *uint, rows []importRow, result *dto.OrganizationImportResponse) ([]*models.Task, []repository.ImportedTimeLog, error) {
+	wsKey := uint(0)
+	if workspaceID != nil {
+		wsKey = *workspaceID
+	}
+
+	taskLocalIDs := make([]string, 0)
+	logLocalIDs := make([]string, 0, len(rows))
+	taskIDOf := make([]string, len(rows))
+	logIDOf := make([]string, len(rows))
+	seenTasks := map[string]bool{}
+	for i, row := range rows {
+		// Task IDs do not include the source, so imports from different
+		// trackers share tasks
+		taskIDOf[i] = importLocalID("import-task", orgID, wsKey, row.userID, row.title)
+		logIDOf[i] = importLocalID("import-"+source.name, orgID, wsKey, row.userID, row.title, row.start.Unix(), row.end.Unix())
+		if !seenTasks[taskIDOf[i]] {
+			seenTasks[taskIDOf[i]] = true
+			taskLocalIDs = append(taskLocalIDs, taskIDOf[i])
+		}
+		logLocalIDs = append(logLocalIDs, logIDOf[i])
+	}
+
+	existingTasks, err := s.importRepo.FindTasksByLocalIDs(taskLocalIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	existingLogs, err := s.importRepo.ExistingTimeLogLocalIDs(logLocalIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tasks := make(map[string]*models.Task, len(existingTasks))
+	for i := range existingTasks {
+		tasks[existingTasks[i].LocalID] = &existingTasks[i]
+	}
+
+	var newTasks []*models.Task
+	var logs []repository.ImportedTimeLog
+	seenLogs := map[string]bool{}
+	for i, row := range rows {
+		if existingLogs[logIDOf[i]] || seenLogs[logIDOf[i]] {
+			result.Duplicates++
+			continue
+		}
+		seenLogs[logIDOf[i]] = true
+
+		task, ok := tasks[taskIDOf[i]]
+		if !ok {
+			description := "Imported from " + source.title
+			if row.project != "" {
+				description += " (project: " + row.project + ")"
+			}
+			task = &models.Task{
+				UserID:         row.userID,
+				OrganizationID: &orgID,
+				WorkspaceID:    workspaceID,
+				LocalID:        taskIDOf[i],
+				Title:          row.title,
+				Description:    description,
+				Status:         "completed",
+				IsManual:       true,
+			}
+			tasks[taskIDOf[i]] = task
+			newTasks = append(newTasks, task)
+		}
+
+		start, end := row.start, row.end
+		logs = append(logs, repository.ImportedTimeLog{
+			Task: task,
+			TimeLog: &models.TimeLog{
+				UserID:         row.userID,
+				OrganizationID: &orgID,
+				WorkspaceID:    workspaceID,
+				TaskLocalID:    task.LocalID,
+				StartTime:      start,
+				EndTime:        &end,
+				Duration:       int64(end.Sub(start).Seconds()),
+				Status:         "stopped",
+				TaskTitle:      row.title,
+				IsManual:       true,
+				Notes:          row.description,
+				IsSynced:       true,
+				LocalID:        logIDOf[i],
+			},
+		})
+	}
+
+	result.TasksCreated = len(newTasks)
+	return newTasks, logs, nil
+}
+
+// importLocalID derives a stable local ID from parts, so importing the same
+// data twice yields the same IDs
+func importLocalID(prefix string, parts ...interface{}) string {
+	h := sha256.New()
+	for _, part := range parts {
+		fmt.Fprintf(h, "%v\x00", part)
+	}
+	return prefix + "-" + hex.EncodeToString(h.Sum(nil))[:32]
+}
+
+// resolveImportSource returns the named source, detecting it from the CSV
+// header for "auto"
+func resolveImportSource(name string, headers []string) (importSource, error) {
+	if name != "" && name != "auto" {
+		source, ok := importSources[name]
+		if !ok {
+			return importSource{}, apperror.Validation(fmt.Sprintf("unsupported import source %q", name), nil)
+		}
+		return source, nil
+	}
+
+	has := make(map[string]bool, len(headers))
+	for _, h := range headers {
+		has[h] = true
+	}
+	switch {
+	case has["duration (h)"] || has["duration (decimal)"]:
+		return importSources["clockify"], nil
+	case has["member"]:
+		return importSources["hubstaff"], nil
+	case has["start date"] && has["start time"]:
+		return importSources["toggl"], nil
+	}
+	return importSource{}, apperror.Validation("could not detect the CSV format; set source to hubstaff, toggl or clockify", nil)
+}
+
+// mapColumns finds the column index of each field; missing fields map to -1
+func (src importSource) mapColumns(headers []string) (map[string]int, error) {
+	columns := make(map[string]int, len(src.columns))
+	for field, aliases := range src.columns {
+		columns[field] = -1
+	alias:
+		for _, alias := range aliases {
+			for i, h := range headers {
+				if h == alias {
+					columns[field] = i
+					break alias
+				}
+			}
+		}
+	}
+
+	if columns[importFieldEmail] < 0 && columns[importFieldUser] < 0 {
+		return nil, apperror.Validation(fmt.Sprintf("%s CSV must have a user or email column", src.title), nil)
+	}
+	if columns[importFieldStartDate] < 0 && columns[importFieldStartTime] < 0 {
+		return nil, apperror.Validation(fmt.Sprintf("%s CSV must have a start date column", src.title), nil)
+	}
+	if columns[importFieldEndTime] < 0 && columns[importFieldDuration] < 0 {
+		return nil, apperror.Validation(fmt.Sprintf("%s CSV must have an end time or duration column", src.title), nil)
+	}
+	return columns, nil
+}
+
+func (src importSource) parseRow(columns map[string]int, record []string, members *importMembers, loc *time.Location, now time.Time) (importRow, error) {
+	field := func(name string) string {
+		i := columns[name]
+		if i < 0 || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var row importRow
+	userID, err := members.resolve(field(importFieldEmail), field(importFieldUser))
+	if err != nil {
+		return row, err
+	}
+	row.userID = userID
+
+	row.project = field(importFieldProject)
+	row.description = field(importFieldDescription)
+	row.title = strings.ToValidUTF8(truncateText(firstNonEmpty(field(importFieldTask), row.description, row.project, "Imported time"), 255), "")
+
+	startDate := field(importFieldStartDate)
+	row.start, err = src.parseDateTime(startDate, field(importFieldStartTime), loc)
+	if err != nil {
+		return row, fmt.Errorf("invalid start: %w", err)
+	}
+
+	if endClock := field(importFieldEndTime); endClock != "" {
+		endDate := field(importFieldEndDate)
+		row.end, err = src.parseDateTime(firstNonEmpty(endDate, startDate), endClock, loc)
+		if err != nil {
+			return row, fmt.Errorf("invalid end: %w", err)
+		}
+		// An entry without an end date that ends "before" it starts ran past midnight
+		if endDate == "" && startDate != "" && row.end.Before(row.start) {
+			row.end = row.end.AddDate(0, 0, 1)
+		}
+	} else {
+		duration, err := parseImportDuration(field(importFieldDuration))
+		if err != nil {
+			return row, err
+		}
+		row.end = row.start.Add(duration)
+	}
+
+	switch {
+	case !row.end.After(row.start):
+		return row, apperror.Validation("entry must end after it starts", nil)
+	case row.end.Sub(row.start) > importMaxEntryDuration:
+		return row, apperror.Validation("entry is longer than 24 hours", nil)
+	case row.end.After(now):
+		return row, apperror.Validation("entry ends in the future", nil)
+	}
+	return row, nil
+}
+
+// parseDateTime combines a date and a time-of-day; without a date, clock must
+// hold both, and without a time of day the entry starts at midnight
+func (src importSource) parseDateTime(date, clock string, loc *time.Location) (time.Time, error) {
+	if date == "" {
+		for _, layout := range importDateTimeLayouts {
+			if t, err := time.ParseInLocation(layout, clock, loc); err == nil {
+				return t, nil
+			}
+		}
+		return time.Time{}, apperror.Validation(fmt.Sprintf("unrecognized date and time %q", clock), nil)
+	}
+
+	if clock == "" {
+		for _, layout := range src.dateLayouts {
+			if t, err := time.ParseInLocation(layout, date, loc); err == nil {
+				return t, nil
+			}
+		}
+		return time.Time{}, apperror.Validation(fmt.Sprintf("unrecognized date %q", date), nil)
+	}
+
+	clock = strings.ToUpper(clock)
+	for _, dateLayout := range src.dateLayouts {
+		for _, clockLayout := range importClockLayouts {
+			if t, err := time.ParseInLocation(dateLayout+" "+clockLayout, date+" "+clock, loc); err == nil {
+				return t, nil
+			}
+		}
+	}
+	return time.Time{}, apperror.Validation(fmt.Sprintf("unrecognized date and time %q %q", date, clock), nil)
+}
+
+// parseImportDuration parses h:mm:ss, h:mm or decimal hours
+func parseImportDuration(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, apperror.Validation("row has no end time or duration", nil)
+	}
+
+	if strings.Contains(value, ":") {
+		parts := strings.Split(value, ":")
+		if len(parts) > 3 {
+			return 0, apperror.Validation(fmt.Sprintf("invalid duration %q", value), nil)
+		}
+		var total time.Duration
+		units := []time.Duration{time.Hour, time.Minute, time.Second}
+		for i, part := range parts {
+			n, err := strconv.Atoi(part)
+			if err != nil || n < 0 {
+				return 0, apperror.Validation(fmt.Sprintf("invalid duration %q", value), nil)
+			}
+			total += time.Duration(n) * units[i]
+		}
+		return total, nil
+	}
+
+	hours, err := strconv.ParseFloat(strings.ReplaceAll(value, ",", "."), 64)
+	if err != nil || hours < 0 {
+		return 0, apperror.Validation(fmt.Sprintf("invalid duration %q", value), nil)
+	}
+	return time.Duration(hours * float64(time.Hour)), nil
+}
+
+func isBlankRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -1,12 +1,15 @@
 package service
 
 import (
-	"errors"
+	"math"
 	"strings"
 	"time"
 
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/calendar"
 	"github.com/beuphecan/remote-time-tracker/internal/dto"
 	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/money"
 	"github.com/beuphecan/remote-time-tracker/internal/repository"
 	"github.com/gosimple/slug"
 )
@@ -18,7 +21,14 @@ type OrganizationService interface {
 	GetByID(orgID, userID uint) (*dto.OrganizationResponse, error)
 	GetByIDWithDetails(orgID, userID uint) (*dto.OrganizationResponse, error)
 	Update(orgID, userID uint, req *dto.UpdateOrganizationRequest) (*dto.OrganizationResponse, error)
-	Delete(orgID, userID uint) error
+
+	// Calendar settings
+	GetCalendar(orgID, userID uint) (*dto.OrganizationCalendarResponse, error)
+	UpdateCalendar(orgID, userID uint, req *dto.UpdateOrganizationCalendarRequest) (*dto.OrganizationCalendarResponse, error)
+	GetRetention(orgID, userID uint) (*dto.OrganizationRetentionResponse, error)
+	UpdateRetention(orgID, userID uint, req *dto.UpdateOrganizationRetentionRequest) (*dto.OrganizationRetentionResponse, error)
+	GetUsageAnalytics(orgID, userID uint) (*dto.OrganizationUsageAnalyticsResponse, error)
+	UpdateUsageAnalytics(orgID, userID uint, req *dto.UpdateOrganizationUsageAnalyticsRequest) (*dto.OrganizationUsageAnalyticsResponse, error)
 
 	// User's organizations
 	GetUserOrganizations(userID uint) ([]dto.OrganizationListResponse, error)
@@ -27,10 +37,11 @@ type OrganizationService interface {
 	AddMember(orgID, actorID uint, req *dto.AddOrganizationMemberRequest) (*dto.OrganizationMemberResponse, error)
 	UpdateMember(orgID, memberUserID, actorID uint, req *dto.UpdateOrganizationMemberRequest) (*dto.OrganizationMemberResponse, error)
 	RemoveMember(orgID, memberUserID, actorID uint) error
+	Leave(orgID, userID uint) error
+	HandoffWork(orgID, fromUserID, actorID uint, req *dto.MemberHandoffRequest) (*dto.MemberHandoffResponse, error)
 	GetMembers(orgID, userID uint) ([]dto.OrganizationMemberResponse, error)
 
 	// Join by invite code
-	GetOrgByInviteCode(code string) (*dto.OrganizationPublicInfo, error)
 	JoinByInviteCode(userID uint, code string) (*dto.OrganizationMemberResponse, error)
 
 	// Misc
@@ -45,9 +56,13 @@ type OrganizationService interface {
 }
 
 type organizationService struct {
-	orgRepo       *repository.OrganizationRepository
-	workspaceRepo *repository.WorkspaceRepository
-	userRepo      repository.UserRepository
+	orgRepo           *repository.OrganizationRepository
+	workspaceRepo     *repository.WorkspaceRepository
+	userRepo          repository.UserRepository
+	timeLogRepo       repository.TimeLogRepository
+	handoffRepo       repository.HandoffRepository
+	webhookService    WebhookService
+	permissionService PermissionService
 }
 
 // NewOrganizationService creates a new organization service
@@ -55,11 +70,19 @@ func NewOrganizationService(
 	orgRepo *repository.OrganizationRepository,
 	workspaceRepo *repository.WorkspaceRepository,
 	userRepo repository.UserRepository,
+	timeLogRepo repository.TimeLogRepository,
+	handoffRepo repository.HandoffRepository,
+	webhookService WebhookService,
+	permissionService PermissionService,
 ) OrganizationService {
 	return &organizationService{
-		orgRepo:       orgRepo,
-		workspaceRepo: workspaceRepo,
-		userRepo:      userRepo,
+		orgRepo:           orgRepo,
+		workspaceRepo:     workspaceRepo,
+		userRepo:          userRepo,
+		timeLogRepo:       timeLogRepo,
+		handoffRepo:       handoffRepo,
+		webhookService:    webhookService,
+		permissionService: permissionService,
 	}
 }
 
@@ -89,7 +112,7 @@ func (s *organizationService) Create(userID uint, req *dto.CreateOrganizationReq
 			return nil, err
 		}
 		if exists {
-			return nil, errors.New("slug already exists")
+			return nil, apperror.Conflict("slug already exists")
 		}
 		orgSlug = strings.ToLower(req.Slug)
 	}
@@ -104,6 +127,7 @@ func (s *organizationService) Create(userID uint, req *dto.CreateOrganizationReq
 		AllowInviteLink: true,
 		MaxMembers:      100,
 		IsActive:        true,
+		Currency:        money.Normalize(req.Currency),
 	}
 
 	if err := s.orgRepo.Create(org); err != nil {
@@ -158,6 +182,14 @@ func (s *organizationService) Create(userID uint, req *dto.CreateOrganizationReq
 		s.workspaceRepo.AddMember(wsMember)
 	}
 
+	ActivityFeedBroadcaster.Broadcast(ActivityEvent{
+		Action:     ActivityOrgCreated,
+		UserID:     &userID,
+		EntityType: "organization",
+		EntityID:   &org.ID,
+		Details:    map[string]interface{}{"name": org.Name, "slug": org.Slug},
+	})
+
 	// Get user for response
 	user, _ := s.userRepo.FindByID(userID)
 
@@ -171,7 +203,7 @@ func (s *organizationService) GetByID(orgID, userID uint) (*dto.OrganizationResp
 		return nil, err
 	}
 	if !isMember {
-		return nil, errors.New("access denied: not a member of this organization")
+		return nil, apperror.Forbidden("access denied: not a member of this organization")
 	}
 
 	org, err := s.orgRepo.GetByID(orgID)
@@ -198,7 +230,7 @@ func (s *organizationService) GetByIDWithDetails(orgID, userID uint) (*dto.Organ
 		return nil, err
 	}
 	if !isMember {
-		return nil, errors.New("access denied: not a member of this organization")
+		return nil, apperror.Forbidden("access denied: not a member of this organization")
 	}
 
 	org, err := s.orgRepo.GetByIDWithDetails(orgID)
@@ -220,11 +252,18 @@ func (s *organizationService) GetByIDWithDetails(orgID, userID uint) (*dto.Organ
 	response.Members = members
 
 	// Add workspaces
+	workspaceIDs := make([]uint, len(org.Workspaces))
+	for i, w := range org.Workspaces {
+		workspaceIDs[i] = w.ID
+	}
+	wsCounts, err := s.workspaceRepo.GetCountsByIDs(workspaceIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	workspaces := make([]dto.WorkspaceResponse, 0, len(org.Workspaces))
 	for _, w := range org.Workspaces {
-		wsMemberCount, _ := s.workspaceRepo.GetMemberCount(w.ID)
-		wsTaskCount, _ := s.workspaceRepo.GetTaskCount(w.ID)
-		workspaces = append(workspaces, *s.toWorkspaceResponse(&w, wsMemberCount, wsTaskCount))
+		workspaces = append(workspaces, *s.toWorkspaceResponse(&w, wsCounts[w.ID].MemberCount, wsCounts[w.ID].TaskCount))
 	}
 	response.Workspaces = workspaces
 
@@ -238,13 +277,17 @@ func (s *organizationService) Update(orgID, userID uint, req *dto.UpdateOrganiza
 		return nil, err
 	}
 	if !isAdmin {
-		return nil, errors.New("access denied: only admins can update organization")
+		return nil, apperror.Forbidden("access denied: only admins can update organization")
 	}
 
 	org, err := s.orgRepo.GetByID(orgID)
 	if err != nil {
 		return nil, err
 	}
+	state := func() (interface{}, error) { return s.GetByID(orgID, userID) }
+	if err := checkVersion(req.Version, org.Version, state); err != nil {
+		return nil, err
+	}
 
 	// Update fields
 	if req.Name != nil {
@@ -268,25 +311,195 @@ func (s *organizationService) Update(orgID, userID uint, req *dto.UpdateOrganiza
 	if req.IsActive != nil {
 		org.IsActive = *req.IsActive
 	}
+	if req.RequireDeviceApproval != nil {
+		org.RequireDeviceApproval = *req.RequireDeviceApproval
+	}
+	if req.UpdateChannel != nil {
+		org.UpdateChannel = *req.UpdateChannel
+	}
+	if req.Timezone != nil {
+		org.Timezone = *req.Timezone
+	}
+	previousCurrency := org.Currency
+	if req.Currency != nil {
+		org.Currency = money.Normalize(*req.Currency)
+	}
 
 	if err := s.orgRepo.Update(org); err != nil {
-		return nil, err
+		return nil, versionedUpdateError(err, state)
+	}
+
+	// Cost rates are stored in minor units of the organization currency
+	if shift := money.Exponent(org.Currency) - money.Exponent(previousCurrency); shift != 0 {
+		if err := s.orgRepo.RescaleCostRates(orgID, math.Pow10(shift)); err != nil {
+			return nil, err
+		}
 	}
 
 	return s.GetByID(orgID, userID)
 }
 
-func (s *organizationService) Delete(orgID, userID uint) error {
-	// Only owner can delete organization
-	isOwner, err := s.orgRepo.IsOwner(orgID, userID)
+// ============================================================================
+// CALENDAR SETTINGS
+// ============================================================================
+
+func (s *organizationService) GetCalendar(orgID, userID uint) (*dto.OrganizationCalendarResponse, error) {
+	isMember, err := s.orgRepo.IsMember(orgID, userID)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if !isOwner {
-		return errors.New("access denied: only owner can delete organization")
+	if !isMember {
+		return nil, apperror.Forbidden("access denied: not a member of this organization")
+	}
+
+	org, err := s.orgRepo.GetByID(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toCalendarResponse(org), nil
+}
+
+func (s *organizationService) UpdateCalendar(orgID, userID uint, req *dto.UpdateOrganizationCalendarRequest) (*dto.OrganizationCalendarResponse, error) {
+	isAdmin, err := s.orgRepo.IsAdmin(orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isAdmin {
+		return nil, apperror.Forbidden("access denied: only admins can update calendar settings")
+	}
+
+	org, err := s.orgRepo.GetByID(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.WorkingDays != nil {
+		parsed, err := calendar.WeekdaysFromInts(req.WorkingDays)
+		if err != nil {
+			return nil, apperror.Validation(err.Error(), nil)
+		}
+		if len(parsed) == 0 {
+			return nil, apperror.Validation("at least one working day is required", nil)
+		}
+		org.WorkingDays = calendar.FormatWorkingDays(parsed)
+	}
+	if req.WeekStartDay != nil {
+		org.WeekStartDay = *req.WeekStartDay
+	}
+	if req.FiscalYearStartMonth != nil {
+		org.FiscalYearStartMonth = *req.FiscalYearStartMonth
+	}
+
+	if err := s.orgRepo.Update(org); err != nil {
+		return nil, err
+	}
+
+	return s.toCalendarResponse(org), nil
+}
+
+// ============================================================================
+// RETENTION SETTINGS
+// ============================================================================
+
+func (s *organizationService) GetRetention(orgID, userID uint) (*dto.OrganizationRetentionResponse, error) {
+	isMember, err := s.orgRepo.IsMember(orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, apperror.Forbidden("access denied: not a member of this organization")
+	}
+
+	org, err := s.orgRepo.GetByID(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toRetentionResponse(org), nil
+}
+
+func (s *organizationService) UpdateRetention(orgID, userID uint, req *dto.UpdateOrganizationRetentionRequest) (*dto.OrganizationRetentionResponse, error) {
+	isAdmin, err := s.orgRepo.IsAdmin(orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isAdmin {
+		return nil, apperror.Forbidden("access denied: only admins can update retention settings")
+	}
+
+	org, err := s.orgRepo.GetByID(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	org.ScreenshotRetentionDays = *req.ScreenshotRetentionDays
+	if err := s.orgRepo.Update(org); err != nil {
+		return nil, err
+	}
+
+	return toRetentionResponse(org), nil
+}
+
+func toRetentionResponse(org *models.Organization) *dto.OrganizationRetentionResponse {
+	resp := &dto.OrganizationRetentionResponse{
+		OrganizationID:          org.ID,
+		ScreenshotRetentionDays: org.ScreenshotRetentionDays,
+	}
+	if org.ScreenshotRetentionDays > 0 {
+		cutoff := RetentionCutoff(org.ScreenshotRetentionDays, time.Now()).Format("2006-01-02")
+		resp.ScreenshotsPurgedBefore = &cutoff
+	}
+	return resp
+}
+
+// ============================================================================
+// USAGE ANALYTICS SETTINGS
+// ============================================================================
+
+func (s *organizationService) GetUsageAnalytics(orgID, userID uint) (*dto.OrganizationUsageAnalyticsResponse, error) {
+	isMember, err := s.orgRepo.IsMember(orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, apperror.Forbidden("access denied: not a member of this organization")
+	}
+
+	org, err := s.orgRepo.GetByID(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.OrganizationUsageAnalyticsResponse{
+		OrganizationID: org.ID,
+		OptOut:         org.UsageAnalyticsOptOut,
+	}, nil
+}
+
+func (s *organizationService) UpdateUsageAnalytics(orgID, userID uint, req *dto.UpdateOrganizationUsageAnalyticsRequest) (*dto.OrganizationUsageAnalyticsResponse, error) {
+	isAdmin, err := s.orgRepo.IsAdmin(orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isAdmin {
+		return nil, apperror.Forbidden("access denied: only admins can update usage analytics settings")
 	}
 
-	return s.orgRepo.Delete(orgID)
+	org, err := s.orgRepo.GetByID(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	org.UsageAnalyticsOptOut = *req.OptOut
+	if err := s.orgRepo.Update(org); err != nil {
+		return nil, err
+	}
+
+	return &dto.OrganizationUsageAnalyticsResponse{
+		OrganizationID: org.ID,
+		OptOut:         org.UsageAnalyticsOptOut,
+	}, nil
 }
 
 // ============================================================================
@@ -299,19 +512,25 @@ func (s *organizationService) GetUserOrganizations(userID uint) ([]dto.Organizat
 		return nil, err
 	}
 
+	orgIDs := make([]uint, len(memberships))
+	for i, m := range memberships {
+		orgIDs[i] = m.OrganizationID
+	}
+	counts, err := s.orgRepo.GetCountsByIDs(orgIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	result := make([]dto.OrganizationListResponse, 0, len(memberships))
 	for _, m := range memberships {
-		memberCount, _ := s.orgRepo.GetMemberCount(m.OrganizationID)
-		workspaceCount, _ := s.orgRepo.GetWorkspaceCount(m.OrganizationID)
-
 		result = append(result, dto.OrganizationListResponse{
 			ID:             m.Organization.ID,
 			Name:           m.Organization.Name,
 			Slug:           m.Organization.Slug,
 			LogoURL:        m.Organization.LogoURL,
 			Role:           m.Role,
-			MemberCount:    memberCount,
-			WorkspaceCount: workspaceCount,
+			MemberCount:    counts[m.OrganizationID].MemberCount,
+			WorkspaceCount: counts[m.OrganizationID].WorkspaceCount,
 			IsActive:       m.Organization.IsActive,
 			JoinedAt:       m.JoinedAt,
 		})
@@ -331,13 +550,13 @@ func (s *organizationService) AddMember(orgID, actorID uint, req *dto.AddOrganiz
 		return nil, err
 	}
 	if !isAdmin {
-		return nil, errors.New("access denied: only admins can add members")
+		return nil, apperror.Forbidden("access denied: only admins can add members")
 	}
 
 	// Check if target user exists
 	user, err := s.userRepo.FindByID(req.UserID)
 	if err != nil {
-		return nil, errors.New("user not found")
+		return nil, apperror.NotFound("user not found")
 	}
 
 	// Check if already a member
@@ -346,7 +565,7 @@ func (s *organizationService) AddMember(orgID, actorID uint, req *dto.AddOrganiz
 		return nil, err
 	}
 	if isMember {
-		return nil, errors.New("user is already a member of this organization")
+		return nil, apperror.Conflict("user is already a member of this organization")
 	}
 
 	// Check member limit
@@ -357,7 +576,7 @@ func (s *organizationService) AddMember(orgID, actorID uint, req *dto.AddOrganiz
 
 	memberCount, _ := s.orgRepo.GetMemberCount(orgID)
 	if int(memberCount) >= org.MaxMembers {
-		return nil, errors.New("organization has reached maximum member limit")
+		return nil, apperror.Conflict("organization has reached maximum member limit")
 	}
 
 	// Create member
@@ -375,6 +594,7 @@ func (s *organizationService) AddMember(orgID, actorID uint, req *dto.AddOrganiz
 	}
 
 	member.User = *user
+	s.webhookService.EmitMemberEvent(models.WebhookEventMemberJoined, *member, &actorID, webhookSourceAdmin, nil)
 	return s.toMemberResponse(member), nil
 }
 
@@ -385,24 +605,25 @@ func (s *organizationService) UpdateMember(orgID, memberUserID, actorID uint, re
 		return nil, err
 	}
 	if !isAdmin {
-		return nil, errors.New("access denied: only admins can update members")
+		return nil, apperror.Forbidden("access denied: only admins can update members")
 	}
 
 	// Get member
 	member, err := s.orgRepo.GetMemberWithUser(orgID, memberUserID)
 	if err != nil {
-		return nil, errors.New("member not found")
+		return nil, apperror.NotFound("member not found")
 	}
 
 	// Prevent changing owner role unless by owner
 	if member.Role == models.OrgRoleOwner && req.Role != models.OrgRoleOwner {
 		isOwner, _ := s.orgRepo.IsOwner(orgID, actorID)
 		if !isOwner {
-			return nil, errors.New("only owner can change their own role")
+			return nil, apperror.Forbidden("only owner can change their own role")
 		}
 	}
 
 	// Update fields
+	previousRole := member.Role
 	if req.Role != "" {
 		member.Role = req.Role
 	}
@@ -414,6 +635,12 @@ func (s *organizationService) UpdateMember(orgID, memberUserID, actorID uint, re
 		return nil, err
 	}
 
+	if member.Role != previousRole {
+		s.permissionService.RecordRoleChanges(orgRoleChange(*member, previousRole, actorID, models.RoleChangeSourceAdmin))
+		s.webhookService.EmitMemberEvent(models.WebhookEventMemberRoleChanged, *member, &actorID, webhookSourceAdmin,
+			map[string]dto.WebhookChange{"role": {From: previousRole, To: member.Role}})
+	}
+
 	return s.toMemberResponse(member), nil
 }
 
@@ -424,7 +651,7 @@ func (s *organizationService) RemoveMember(orgID, memberUserID, actorID uint) er
 		return err
 	}
 	if !isAdmin {
-		return errors.New("access denied: only admins can remove members")
+		return apperror.Forbidden("access denied: only admins can remove members")
 	}
 
 	// Prevent removing owner
@@ -433,10 +660,133 @@ func (s *organizationService) RemoveMember(orgID, memberUserID, actorID uint) er
 		return err
 	}
 	if isOwner {
-		return errors.New("cannot remove organization owner")
+		return apperror.Validation("cannot remove organization owner", nil)
+	}
+
+	// Snapshot the member for the webhook payload before it is deleted
+	member, err := s.orgRepo.GetMemberWithUser(orgID, memberUserID)
+	if err != nil {
+		return apperror.NotFound("member not found")
+	}
+
+	if err := s.orgRepo.RemoveMember(orgID, memberUserID); err != nil {
+		return err
+	}
+
+	s.webhookService.EmitMemberEvent(models.WebhookEventMemberRemoved, *member, &actorID, webhookSourceAdmin, nil)
+	return nil
+}
+
+// Leave removes the user from the organization at their own request. Their
+// running timers in the organization are stopped, their workspace memberships
+// removed and the workspaces they administer handed to the owner.
+func (s *organizationService) Leave(orgID, userID uint) error {
+	member, err := s.orgRepo.GetMemberWithUser(orgID, userID)
+	if err != nil {
+		return apperror.Forbidden("you are not a member of this organization")
+	}
+
+	org, err := s.orgRepo.GetByID(orgID)
+	if err != nil {
+		return err
+	}
+	if org.OwnerID == userID || member.Role == models.OrgRoleOwner {
+		return apperror.Validation("the organization owner cannot leave, transfer ownership first", nil)
+	}
+
+	if err := s.stopOrgTimers(orgID, userID); err != nil {
+		return err
+	}
+	if err := s.workspaceRepo.RemoveMemberFromOrg(orgID, userID); err != nil {
+		return err
+	}
+	if err := s.workspaceRepo.ReassignAdmin(orgID, userID, org.OwnerID); err != nil {
+		return err
+	}
+	if err := s.orgRepo.RemoveMember(orgID, userID); err != nil {
+		return err
+	}
+
+	s.webhookService.EmitMemberEvent(models.WebhookEventMemberRemoved, *member, nil, webhookSourceLeave, nil)
+	return nil
+}
+
+// HandoffWork reassigns a member's active tasks and unapproved time logs in the
+// organization to another active member. When the source user has already been
+// removed, their leftover running timers are stopped first so that work is
+// handed off as well.
+func (s *organizationService) HandoffWork(orgID, fromUserID, actorID uint, req *dto.MemberHandoffRequest) (*dto.MemberHandoffResponse, error) {
+	isAdmin, err := s.orgRepo.IsAdmin(orgID, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if !isAdmin {
+		return nil, apperror.Forbidden("access denied: only admins can hand off member work")
 	}
 
-	return s.orgRepo.RemoveMember(orgID, memberUserID)
+	if req.ToUserID == fromUserID {
+		return nil, apperror.Validation("cannot hand off work to the same member", nil)
+	}
+	target, err := s.orgRepo.GetMember(orgID, req.ToUserID)
+	if err != nil || !target.IsActive {
+		return nil, apperror.Validation("target user is not an active member of this organization", nil)
+	}
+
+	if _, err := s.orgRepo.GetMember(orgID, fromUserID); err != nil {
+		if err := s.stopOrgTimers(orgID, fromUserID); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := s.handoffRepo.TransferWork(orgID, fromUserID, req.ToUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.MemberHandoffResponse{
+		OrganizationID:         orgID,
+		FromUserID:             fromUserID,
+		ToUserID:               req.ToUserID,
+		TasksTransferred:       len(result.TaskIDs),
+		TaskIDs:                result.TaskIDs,
+		TimeLogsTransferred:    len(result.TimeLogIDs),
+		TimeLogIDs:             result.TimeLogIDs,
+		TrackedSeconds:         result.TrackedSeconds,
+		ScreenshotsTransferred: result.ScreenshotsMoved,
+	}, nil
+}
+
+// stopOrgTimers stops the user's running and paused time logs in the organization
+func (s *organizationService) stopOrgTimers(orgID, userID uint) error {
+	active, err := s.timeLogRepo.FindActiveByUserAndOrg(userID, orgID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	for i := range active {
+		timeLog := &active[i]
+		// Close the current pause so it does not count as worked time
+		if timeLog.Status == "paused" && timeLog.PausedAt != nil {
+			timeLog.PausedTotal += int64(now.Sub(*timeLog.PausedAt).Seconds())
+		}
+		timeLog.EndTime = &now
+		timeLog.Status = "stopped"
+		timeLog.Duration = int64(now.Sub(timeLog.StartTime).Seconds()) - timeLog.PausedTotal
+		if err := s.timeLogRepo.Update(timeLog); err != nil {
+			return err
+		}
+	}
+
+	if len(active) > 0 {
+		_ = s.userRepo.UpdatePresence(userID, models.UserPresenceIdle, now, nil)
+		PresenceBroadcaster.Broadcast(PresenceEvent{
+			UserID:         userID,
+			Status:         models.UserPresenceIdle,
+			LastPresenceAt: now,
+		})
+	}
+	return nil
 }
 
 func (s *organizationService) GetMembers(orgID, userID uint) ([]dto.OrganizationMemberResponse, error) {
@@ -446,7 +796,7 @@ func (s *organizationService) GetMembers(orgID, userID uint) ([]dto.Organization
 		return nil, err
 	}
 	if !isMember {
-		return nil, errors.New("access denied: not a member of this organization")
+		return nil, apperror.Forbidden("access denied: not a member of this organization")
 	}
 
 	members, err := s.orgRepo.GetMembersByOrgID(orgID)
@@ -466,28 +816,10 @@ func (s *organizationService) GetMembers(orgID, userID uint) ([]dto.Organization
 // JOIN BY INVITE CODE
 // ============================================================================
 
-func (s *organizationService) GetOrgByInviteCode(code string) (*dto.OrganizationPublicInfo, error) {
-	org, err := s.orgRepo.GetByInviteCode(code)
-	if err != nil {
-		return nil, errors.New("invalid invite code or organization not accepting new members")
-	}
-
-	memberCount, _ := s.orgRepo.GetMemberCount(org.ID)
-
-	return &dto.OrganizationPublicInfo{
-		ID:          org.ID,
-		Name:        org.Name,
-		Slug:        org.Slug,
-		Description: org.Description,
-		LogoURL:     org.LogoURL,
-		MemberCount: memberCount,
-	}, nil
-}
-
 func (s *organizationService) JoinByInviteCode(userID uint, code string) (*dto.OrganizationMemberResponse, error) {
 	org, err := s.orgRepo.GetByInviteCode(code)
 	if err != nil {
-		return nil, errors.New("invalid invite code or organization not accepting new members")
+		return nil, apperror.Validation("invalid invite code or organization not accepting new members", nil)
 	}
 
 	// Check if already a member
@@ -496,13 +828,13 @@ func (s *organizationService) JoinByInviteCode(userID uint, code string) (*dto.O
 		return nil, err
 	}
 	if isMember {
-		return nil, errors.New("you are already a member of this organization")
+		return nil, apperror.Conflict("you are already a member of this organization")
 	}
 
 	// Check member limit
 	memberCount, _ := s.orgRepo.GetMemberCount(org.ID)
 	if int(memberCount) >= org.MaxMembers {
-		return nil, errors.New("organization has reached maximum member limit")
+		return nil, apperror.Conflict("organization has reached maximum member limit")
 	}
 
 	// Add as member
@@ -520,6 +852,7 @@ func (s *organizationService) JoinByInviteCode(userID uint, code string) (*dto.O
 
 	user, _ := s.userRepo.FindByID(userID)
 	member.User = *user
+	s.webhookService.EmitMemberEvent(models.WebhookEventMemberJoined, *member, nil, webhookSourceInviteCode, nil)
 
 	return s.toMemberResponse(member), nil
 }
@@ -535,7 +868,7 @@ func (s *organizationService) RegenerateInviteCode(orgID, userID uint) (string,
 		return "", err
 	}
 	if !isAdmin {
-		return "", errors.New("access denied: only admins can regenerate invite code")
+		return "", apperror.Forbidden("access denied: only admins can regenerate invite code")
 	}
 
 	return s.orgRepo.RegenerateInviteCode(orgID)
@@ -548,7 +881,7 @@ func (s *organizationService) TransferOwnership(orgID, actorID uint, req *dto.Tr
 		return err
 	}
 	if !isOwner {
-		return errors.New("access denied: only owner can transfer ownership")
+		return apperror.Forbidden("access denied: only owner can transfer ownership")
 	}
 
 	// Check if new owner is a member
@@ -557,7 +890,7 @@ func (s *organizationService) TransferOwnership(orgID, actorID uint, req *dto.Tr
 		return err
 	}
 	if !isMember {
-		return errors.New("new owner must be a member of the organization")
+		return apperror.Validation("new owner must be a member of the organization", nil)
 	}
 
 	// Update old owner's role to admin
@@ -567,7 +900,28 @@ func (s *organizationService) TransferOwnership(orgID, actorID uint, req *dto.Tr
 		s.orgRepo.UpdateMember(oldMember)
 	}
 
-	return s.orgRepo.TransferOwnership(orgID, req.NewOwnerID)
+	newOwner, _ := s.orgRepo.GetMember(orgID, req.NewOwnerID)
+	previousRole := ""
+	if newOwner != nil {
+		previousRole = newOwner.Role
+	}
+
+	if err := s.orgRepo.TransferOwnership(orgID, req.NewOwnerID); err != nil {
+		return err
+	}
+
+	if oldMember != nil {
+		s.permissionService.RecordRoleChanges(orgRoleChange(*oldMember, models.OrgRoleOwner, actorID, models.RoleChangeSourceOwnershipTransfer))
+		s.webhookService.EmitMemberEvent(models.WebhookEventMemberRoleChanged, *oldMember, &actorID, webhookSourceOwnershipTransfer,
+			map[string]dto.WebhookChange{"role": {From: models.OrgRoleOwner, To: models.OrgRoleAdmin}})
+	}
+	if newOwner != nil && previousRole != models.OrgRoleOwner {
+		newOwner.Role = models.OrgRoleOwner
+		s.permissionService.RecordRoleChanges(orgRoleChange(*newOwner, previousRole, actorID, models.RoleChangeSourceOwnershipTransfer))
+		s.webhookService.EmitMemberEvent(models.WebhookEventMemberRoleChanged, *newOwner, &actorID, webhookSourceOwnershipTransfer,
+			map[string]dto.WebhookChange{"role": {From: previousRole, To: models.OrgRoleOwner}})
+	}
+	return nil
 }
 
 // ============================================================================
@@ -594,11 +948,27 @@ func (s *organizationService) GetMemberRole(orgID, userID uint) (string, error)
 // HELPER FUNCTIONS
 // ============================================================================
 
+// orgRoleChange builds the history entry for a member's new organization role
+func orgRoleChange(member models.OrganizationMember, previousRole string, actorID uint, source string) models.RoleChange {
+	orgID := member.OrganizationID
+	return models.RoleChange{
+		UserID:         member.UserID,
+		OrganizationID: &orgID,
+		Scope:          models.RoleScopeOrganization,
+		Attribute:      "role",
+		FromValue:      previousRole,
+		ToValue:        member.Role,
+		ChangedBy:      &actorID,
+		Source:         source,
+	}
+}
+
 func (s *organizationService) toOrganizationResponse(org *models.Organization, owner *models.User, memberCount, workspaceCount int64, userRole string) *dto.OrganizationResponse {
 	var ownerResp *dto.UserResponse
 	if owner != nil {
 		ownerResp = &dto.UserResponse{
 			ID:        owner.ID,
+			UUID:      owner.UUID,
 			Email:     owner.Email,
 			FirstName: owner.FirstName,
 			LastName:  owner.LastName,
@@ -609,21 +979,28 @@ func (s *organizationService) toOrganizationResponse(org *models.Organization, o
 	}
 
 	response := &dto.OrganizationResponse{
-		ID:              org.ID,
-		Name:            org.Name,
-		Slug:            org.Slug,
-		Description:     org.Description,
-		LogoURL:         org.LogoURL,
-		OwnerID:         org.OwnerID,
-		Owner:           ownerResp,
-		AllowInviteLink: org.AllowInviteLink,
-		ShareInviteCode: org.ShareInviteCode,
-		MaxMembers:      org.MaxMembers,
-		IsActive:        org.IsActive,
-		MemberCount:     memberCount,
-		WorkspaceCount:  workspaceCount,
-		CreatedAt:       org.CreatedAt,
-		UpdatedAt:       org.UpdatedAt,
+		ID:                    org.ID,
+		UUID:                  org.UUID,
+		Version:               org.Version,
+		Name:                  org.Name,
+		Slug:                  org.Slug,
+		Description:           org.Description,
+		LogoURL:               org.LogoURL,
+		OwnerID:               org.OwnerID,
+		Owner:                 ownerResp,
+		AllowInviteLink:       org.AllowInviteLink,
+		ShareInviteCode:       org.ShareInviteCode,
+		MaxMembers:            org.MaxMembers,
+		IsActive:              org.IsActive,
+		RequireDeviceApproval: org.RequireDeviceApproval,
+		UpdateChannel:         org.UpdateChannel,
+		Currency:              org.Currency,
+		Timezone:              org.Timezone,
+		MemberCount:           memberCount,
+		WorkspaceCount:        workspaceCount,
+		DeletionScheduledAt:   org.DeletionScheduledAt,
+		CreatedAt:             org.CreatedAt,
+		UpdatedAt:             org.UpdatedAt,
 	}
 
 	// Show invite code based on role and share settings:
@@ -639,11 +1016,32 @@ func (s *organizationService) toOrganizationResponse(org *models.Organization, o
 	return response
 }
 
+func (s *organizationService) toCalendarResponse(org *models.Organization) *dto.OrganizationCalendarResponse {
+	cal := org.Calendar()
+	now := time.Now()
+
+	workingDays := make([]int, 0, len(cal.WorkingDays))
+	for _, d := range cal.WorkingDays {
+		workingDays = append(workingDays, int(d))
+	}
+
+	return &dto.OrganizationCalendarResponse{
+		OrganizationID:       org.ID,
+		WorkingDays:          workingDays,
+		WeekStartDay:         int(cal.WeekStart),
+		FiscalYearStartMonth: int(cal.FiscalYearStartMonth),
+		CurrentFiscalYear:    cal.FiscalYear(now),
+		CurrentFiscalQuarter: cal.FiscalQuarter(now),
+		CurrentWeekStart:     cal.StartOfWeek(now).Format("2006-01-02"),
+	}
+}
+
 func (s *organizationService) toMemberResponse(m *models.OrganizationMember) *dto.OrganizationMemberResponse {
 	var userResp *dto.UserResponse
 	if m.User.ID > 0 {
 		userResp = &dto.UserResponse{
 			ID:        m.User.ID,
+			UUID:      m.User.UUID,
 			Email:     m.User.Email,
 			FirstName: m.User.FirstName,
 			LastName:  m.User.LastName,
@@ -669,6 +1067,7 @@ func (s *organizationService) toWorkspaceResponse(w *models.Workspace, memberCou
 	if w.Admin.ID > 0 {
 		adminResp = &dto.UserResponse{
 			ID:        w.Admin.ID,
+			UUID:      w.Admin.UUID,
 			Email:     w.Admin.Email,
 			FirstName: w.Admin.FirstName,
 			LastName:  w.Admin.LastName,
@@ -680,6 +1079,8 @@ func (s *organizationService) toWorkspaceResponse(w *models.Workspace, memberCou
 
 	return &dto.WorkspaceResponse{
 		ID:             w.ID,
+		UUID:           w.UUID,
+		Version:        w.Version,
 		OrganizationID: w.OrganizationID,
 		Name:           w.Name,
 		Slug:           w.Slug,
@@ -690,9 +1091,13 @@ func (s *organizationService) toWorkspaceResponse(w *models.Workspace, memberCou
 		Admin:          adminResp,
 		IsActive:       w.IsActive,
 		IsBillable:     w.IsBillable,
-		HourlyRate:     w.HourlyRate,
+		HourlyRate:     money.ToMajor(w.HourlyRateMinor, w.Currency),
+		Currency:       w.Currency,
+		ClientID:       w.ClientID,
 		StartDate:      w.StartDate,
 		EndDate:        w.EndDate,
+		CostCenter:     w.CostCenter,
+		ProjectCode:    w.ProjectCode,
 		MemberCount:    memberCount,
 		TaskCount:      taskCount,
 		CreatedAt:      w.CreatedAt,
@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"unicode/utf8"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/cache"
+	"github.com/beuphecan/remote-time-tracker/internal/config"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+)
+
+// OrganizationSettingsService manages the typed settings of
+// models.OrganizationSettingSchema. Organizations only store the settings
+// they change; the rest keep their defaults.
+type OrganizationSettingsService interface {
+	Get(orgID, userID uint) (*dto.OrganizationSettingsResponse, error)
+	Update(orgID, userID uint, req *dto.UpdateOrganizationSettingsRequest) (*dto.OrganizationSettingsResponse, error)
+
+	// Bool, Int and String return the organization's value of a setting, or
+	// its default when the organization has not set it or it cannot be read
+	Bool(orgID uint, key string) bool
+	Int(orgID uint, key string) int
+	String(orgID uint, key string) string
+}
+
+type organizationSettingsService struct {
+	settingRepo repository.OrganizationSettingRepository
+	orgRepo     *repository.OrganizationRepository
+	cache       cache.Cache
+}
+
+// NewOrganizationSettingsService creates a new organization settings
+// service. c caches each organization's values, in Redis when configured.
+func NewOrganizationSettingsService(settingRepo repository.OrganizationSettingRepository, orgRepo *repository.OrganizationRepository, c cache.Cache) OrganizationSettingsService {
+	return &organizationSettingsService{
+		settingRepo: settingRepo,
+		orgRepo:     orgRepo,
+		cache:       c,
+	}
+}
+
+func orgSettingsKey(orgID uint) string {
+	return fmt.Sprintf("org_settings:%d", orgID)
+}
+
+func (s *organizationSettingsService) Get(orgID, userID uint) (*dto.OrganizationSettingsResponse, error) {
+	isMember, err := s.orgRepo.IsMember(orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, apperror.Forbidden("access denied: not a member of this organization")
+	}
+
+	return s.settings(orgID)
+}
+
+func (s *organizationSettingsService) Update(orgID, userID uint, req *dto.UpdateOrganizationSettingsRequest) (*dto.OrganizationSettingsResponse, error) {
+	isAdmin, err := s.orgRepo.IsAdmin(orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isAdmin {
+		return nil, apperror.Forbidden("access denied: only admins can change organization settings")
+	}
+
+	values := make(map[string]string)
+	var reset []string
+	for key, raw := range req.Settings {
+		def, ok := models.FindOrganizationSetting(key)
+		if !ok {
+			return nil, apperror.Validation(fmt.Sprintf("unknown setting %q", key), nil)
+		}
+		if string(raw) == "null" {
+			reset = append(reset, key)
+			continue
+		}
+		if err := validateSetting(def, raw); err != nil {
+			return nil, err
+		}
+		values[key] = string(raw)
+	}
+
+	if err := s.settingRepo.Save(orgID, userID, values, reset); err != nil {
+		return nil, err
+	}
+	if err := s.cache.Delete(context.Background(), orgSettingsKey(orgID)); err != nil {
+		log.Printf("⚠️  Failed to drop cached settings of organization %d: %v", orgID, err)
+	}
+
+	return s.settings(orgID)
+}
+
+// settings lists every setting of the schema with the organization's value
+func (s *organizationSettingsService) settings(orgID uint) (*dto.OrganizationSettingsResponse, error) {
+	stored, err := s.settingRepo.FindByOrg(orgID)
+	if err != nil {
+		return nil, err
+	}
+	byKey := make(map[string]*models.OrganizationSetting, len(stored))
+	for i := range stored {
+		byKey[stored[i].Key] = &stored[i]
+	}
+
+	response := &dto.OrganizationSettingsResponse{
+		OrganizationID: orgID,
+		Settings:       make([]dto.OrganizationSettingResponse, 0, len(models.OrganizationSettingSchema)),
+	}
+	for _, def := range models.OrganizationSettingSchema {
+		setting := dto.OrganizationSettingResponse{
+			Key:         def.Key,
+			Description: def.Description,
+			Value:       def.Default,
+			Default:     def.Default,
+			IsDefault:   true,
+			Schema: dto.SettingSchemaResponse{
+				Type:      def.Schema.Type,
+				Minimum:   def.Schema.Minimum,
+				Maximum:   def.Schema.Maximum,
+				MaxLength: def.Schema.MaxLength,
+				Enum:      def.Schema.Enum,
+			},
+		}
+		if row, ok := byKey[def.Key]; ok {
+			var value interface{}
+			if err := json.Unmarshal([]byte(row.Value), &value); err == nil {
+				setting.Value = value
+				setting.IsDefault = false
+				setting.UpdatedAt = &row.UpdatedAt
+				setting.UpdatedBy = &row.UpdatedBy
+			}
+		}
+		response.Settings = append(response.Settings, setting)
+	}
+	return response, nil
+}
+
+// validateSetting checks a value against the setting's schema
+func validateSetting(def *models.OrganizationSettingDefinition, raw json.RawMessage) error {
+	schema := def.Schema
+	switch schema.Type {
+	case "boolean":
+		var value bool
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return apperror.Validation(fmt.Sprintf("%s must be a boolean", def.Key), nil)
+		}
+	case "integer":
+		var value int64
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return apperror.Validation(fmt.Sprintf("%s must be an integer", def.Key), nil)
+		}
+		if schema.Minimum != nil && value < *schema.Minimum {
+			return apperror.Validation(fmt.Sprintf("%s must be at least %d", def.Key, *schema.Minimum), nil)
+		}
+		if schema.Maximum != nil && value > *schema.Maximum {
+			return apperror.Validation(fmt.Sprintf("%s must be at most %d", def.Key, *schema.Maximum), nil)
+		}
+	case "string":
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return apperror.Validation(fmt.Sprintf("%s must be a string", def.Key), nil)
+		}
+		if schema.MaxLength > 0 && utf8.RuneCountInString(value) > schema.MaxLength {
+			return apperror.Validation(fmt.Sprintf("%s must be at most %d characters", def.Key, schema.MaxLength), nil)
+		}
+		if len(schema.Enum) > 0 && !slices.Contains(schema.Enum, value) {
+			return apperror.Validation(fmt.Sprintf("%s must be one of %v", def.Key, schema.Enum), nil)
+		}
+	default:
+		return fmt.Errorf("setting %s has unsupported type %q", def.Key, schema.Type)
+	}
+	return nil
+}
+
+// values returns the organization's stored values by key, as JSON. Cache
+// errors fall back to the database.
+func (s *organizationSettingsService) values(orgID uint) (map[string]string, error) {
+	ctx := context.Background()
+	key := orgSettingsKey(orgID)
+
+	values := make(map[string]string)
+	err := s.cache.Get(ctx, key, &values)
+	if err == nil {
+		return values, nil
+	}
+	if !errors.Is(err, cache.ErrMiss) {
+		log.Printf("⚠️  Settings cache read failed for %s: %v", key, err)
+	}
+
+	stored, err := s.settingRepo.FindByOrg(orgID)
+	if err != nil {
+		return nil, err
+	}
+	for _, setting := range stored {
+		values[setting.Key] = setting.Value
+	}
+	if err := s.cache.Set(ctx, key, values, config.AppConfig.Cache.OrgSettingsTTL); err != nil {
+		log.Printf("⚠️  Settings cache write failed for %s: %v", key, err)
+	}
+	return values, nil
+}
+
+// value decodes the organization's value of a setting into dest, reporting
+// whether it was set
+func (s *organizationSettingsService) value(orgID uint, key string, dest interface{}) bool {
+	values, err := s.values(orgID)
+	if err != nil {
+		log.Printf("⚠️  Failed to load settings of organization %d: %v", orgID, err)
+		return false
+	}
+	raw, ok := values[key]
+	return ok && json.Unmarshal([]byte(raw), dest) == nil
+}
+
+func (s *organizationSettingsService) Bool(orgID uint, key string) bool {
+	var value bool
+	if s.value(orgID, key, &value) {
+		return value
+	}
+	def, _ := models.FindOrganizationSetting(key)
+	if def == nil {
+		return false
+	}
+	value, _ = def.Default.(bool)
+	return value
+}
+
+func (s *organizationSettingsService) Int(orgID uint, key string) int {
+	var value int
+	if s.value(orgID, key, &value) {
+		return value
+	}
+	def, _ := models.FindOrganizationSetting(key)
+	if def == nil {
+		return 0
+	}
+	value, _ = def.Default.(int)
+	return value
+}
+
+func (s *organizationSettingsService) String(orgID uint, key string) string {
+	var value string
+	if s.value(orgID, key, &value) {
+		return value
+	}
+	def, _ := models.FindOrganizationSetting(key)
+	if def == nil {
+		return ""
+	}
+	value, _ = def.Default.(string)
+	return value
+}
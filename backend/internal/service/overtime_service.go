@@ -0,0 +1,331 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/calendar"
+	"github.com/beuphecan/remote-time-tracker/internal/config"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+)
+
+// ErrInvalidOvertimeRule is returned for unknown overtime rules
+var ErrInvalidOvertimeRule = apperror.Validation("invalid rule: use daily or weekly", nil)
+
+// overtimeRule is a threshold on tracked time: each period of the rule may
+// have the schedule's expected hours for that period tracked, the rest is overtime
+type overtimeRule struct {
+	name   string
+	period string // Calendar period time logs count towards
+}
+
+// overtimeRules are evaluated for every user with a work schedule
+var overtimeRules = []overtimeRule{
+	{name: models.OvertimeRuleDaily, period: calendar.PeriodDay},
+	{name: models.OvertimeRuleWeekly, period: calendar.PeriodWeek},
+}
+
+// overtimeSchedule is the time a user is expected to track. Days off,
+// holidays and leave days expect none, so time tracked on them is overtime.
+type overtimeSchedule struct {
+	cal         calendar.Calendar // Working days and timezone of the schedule, with holidays
+	hoursPerDay float64
+	leaves      []models.LeaveRequest
+}
+
+// expectedSeconds returns the time expected in [start, end)
+func (s overtimeSchedule) expectedSeconds(start, end time.Time) int64 {
+	days := s.cal.WorkingDaysBetween(start, end) - leaveWorkingDaysBetween(s.cal, s.leaves, start, end)
+	return int64(float64(days) * s.hoursPerDay * 3600)
+}
+
+// overtimePeriod is a period a rule found overtime in
+type overtimePeriod struct {
+	record       models.OvertimeRecord
+	workspaceIDs []uint // Workspaces of the time logs with overtime
+}
+
+// evaluate applies the rule to time logs sorted by start time and returns
+// the periods with overtime and the overtime of each time log. Time logs
+// count towards the period they start in; once the period's tracked time
+// passes the expected time, the rest of it is overtime. Periods with less
+// than minSeconds of overtime are left out.
+func (r overtimeRule) evaluate(schedule overtimeSchedule, timeLogs []models.TimeLog, minSeconds int64) ([]overtimePeriod, map[uint]int64) {
+	var periods []overtimePeriod
+	timeLogOvertime := make(map[uint]int64)
+
+	for i := 0; i < len(timeLogs); {
+		start, end := schedule.cal.PeriodRange(r.period, timeLogs[i].StartTime)
+		expected := schedule.expectedSeconds(start, end)
+
+		var tracked int64
+		overtime := make(map[uint]int64)
+		var workspaceIDs []uint
+		seen := make(map[uint]bool)
+		for ; i < len(timeLogs) && timeLogs[i].StartTime.Before(end); i++ {
+			timeLog := timeLogs[i]
+			before := tracked
+			tracked += timeLog.Duration
+			if tracked <= expected {
+				continue
+			}
+			overtime[timeLog.ID] = tracked - max(before, expected)
+			if timeLog.WorkspaceID != nil && !seen[*timeLog.WorkspaceID] {
+				seen[*timeLog.WorkspaceID] = true
+				workspaceIDs = append(workspaceIDs, *timeLog.WorkspaceID)
+			}
+		}
+
+		if tracked-expected < max(minSeconds, 1) {
+			continue
+		}
+		for id, seconds := range overtime {
+			timeLogOvertime[id] = seconds
+		}
+		periods = append(periods, overtimePeriod{
+			record: models.OvertimeRecord{
+				Rule:            r.name,
+				PeriodStart:     time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC),
+				ExpectedSeconds: expected,
+				TrackedSeconds:  tracked,
+				OvertimeSeconds: tracked - expected,
+				TimeLogCount:    len(overtime),
+			},
+			workspaceIDs: workspaceIDs,
+		})
+	}
+	return periods, timeLogOvertime
+}
+
+// OvertimeService finds time tracked past users' work schedules. The daily
+// rule flags time past the expected hours of a day, the weekly rule time
+// past the expected hours of a week (starting Monday in the schedule's
+// timezone). Overtime is stored per user, rule and period, and on the
+// time logs it falls in.
+type OvertimeService interface {
+	GetReport(params *dto.AdminOvertimeReportParams) (*dto.AdminOvertimeReport, error)
+
+	// DetectAll re-evaluates the current and previous week of every user with
+	// a schedule and, when enabled, notifies workspace admins the first time a
+	// day or week has overtime (scheduled job)
+	DetectAll(ctx context.Context) error
+}
+
+type overtimeService struct {
+	overtimeRepo        repository.OvertimeRepository
+	scheduleRepo        repository.ScheduleRepository
+	leaveRepo           repository.LeaveRepository
+	holidayRepo         repository.HolidayRepository
+	notificationService NotificationService
+	minSeconds          int64
+	notifyManagers      bool
+}
+
+// NewOvertimeService creates a new overtime service
+func NewOvertimeService(
+	overtimeRepo repository.OvertimeRepository,
+	scheduleRepo repository.ScheduleRepository,
+	leaveRepo repository.LeaveRepository,
+	holidayRepo repository.HolidayRepository,
+	notificationService NotificationService,
+) OvertimeService {
+	return &overtimeService{
+		overtimeRepo:        overtimeRepo,
+		scheduleRepo:        scheduleRepo,
+		leaveRepo:           leaveRepo,
+		holidayRepo:         holidayRepo,
+		notificationService: notificationService,
+		minSeconds:          int64(config.AppConfig.Overtime.MinMinutes) * 60,
+		notifyManagers:      config.AppConfig.Overtime.NotifyManagers,
+	}
+}
+
+func (s *overtimeService) GetReport(params *dto.AdminOvertimeReportParams) (*dto.AdminOvertimeReport, error) {
+	if params.Rule != "" && params.Rule != models.OvertimeRuleDaily && params.Rule != models.OvertimeRuleWeekly {
+		return nil, ErrInvalidOvertimeRule
+	}
+
+	records, err := s.overtimeRepo.FindInRange(params.StartDate, params.EndDate, params.UserID, params.Rule)
+	if err != nil {
+		return nil, err
+	}
+
+	type userTotals struct {
+		daily, weekly int64
+	}
+	var daily, weekly int64
+	totals := make(map[uint]*userTotals)
+	users := make(map[uint]*dto.AdminOvertimeUser)
+	for i := range records {
+		record := &records[i]
+		user, ok := users[record.UserID]
+		if !ok {
+			user = &dto.AdminOvertimeUser{
+				UserID:   record.UserID,
+				UserName: emailUserName(&record.User),
+				Email:    record.User.Email,
+				Periods:  []dto.AdminOvertimePeriod{},
+			}
+			users[record.UserID] = user
+			totals[record.UserID] = &userTotals{}
+		}
+
+		if record.Rule == models.OvertimeRuleWeekly {
+			totals[record.UserID].weekly += record.OvertimeSeconds
+			weekly += record.OvertimeSeconds
+		} else {
+			totals[record.UserID].daily += record.OvertimeSeconds
+			daily += record.OvertimeSeconds
+		}
+		user.Periods = append(user.Periods, dto.AdminOvertimePeriod{
+			ID:            record.ID,
+			Rule:          record.Rule,
+			PeriodStart:   record.PeriodStart.Format("2006-01-02"),
+			ExpectedHours: secondsToHours(record.ExpectedSeconds),
+			TrackedHours:  secondsToHours(record.TrackedSeconds),
+			OvertimeHours: secondsToHours(record.OvertimeSeconds),
+			TimeLogCount:  record.TimeLogCount,
+			NotifiedAt:    record.NotifiedAt,
+		})
+	}
+
+	report := &dto.AdminOvertimeReport{
+		StartDate:           params.StartDate.Format("2006-01-02"),
+		EndDate:             params.EndDate.Format("2006-01-02"),
+		DailyOvertimeHours:  secondsToHours(daily),
+		WeeklyOvertimeHours: secondsToHours(weekly),
+		Users:               make([]dto.AdminOvertimeUser, 0, len(users)),
+	}
+	for userID, user := range users {
+		user.DailyOvertimeHours = secondsToHours(totals[userID].daily)
+		user.WeeklyOvertimeHours = secondsToHours(totals[userID].weekly)
+		report.Users = append(report.Users, *user)
+	}
+
+	// Most weekly overtime first
+	sort.Slice(report.Users, func(i, j int) bool {
+		a, b := report.Users[i], report.Users[j]
+		if a.WeeklyOvertimeHours != b.WeeklyOvertimeHours {
+			return a.WeeklyOvertimeHours > b.WeeklyOvertimeHours
+		}
+		if a.DailyOvertimeHours != b.DailyOvertimeHours {
+			return a.DailyOvertimeHours > b.DailyOvertimeHours
+		}
+		return a.UserID < b.UserID
+	})
+
+	return report, nil
+}
+
+// ============================================================================
+// SCHEDULED JOBS
+// ============================================================================
+
+func (s *overtimeService) DetectAll(ctx context.Context) error {
+	schedules, err := s.scheduleRepo.FindAll()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	notified := 0
+	for i := range schedules {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		count, err := s.detect(&schedules[i], now)
+		if err != nil {
+			log.Printf("⚠️  Failed to detect overtime of user %d: %v", schedules[i].UserID, err)
+			continue
+		}
+		notified += count
+	}
+
+	log.Printf("✅ Checked overtime of %d scheduled users (%d new alerts)", len(schedules), notified)
+	return nil
+}
+
+// detect stores the overtime of the user's current and previous week, so
+// time logs synced late are included, and returns the alerts sent
+func (s *overtimeService) detect(schedule *models.WorkSchedule, now time.Time) (int, error) {
+	cal := scheduleCalendar(schedule)
+	end := cal.StartOfWeek(now).AddDate(0, 0, 7)
+	start := end.AddDate(0, 0, -14)
+	fromDate := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	toDate := fromDate.AddDate(0, 0, 13)
+
+	holidays, err := s.holidayRepo.FindDatesForUsers([]uint{schedule.UserID}, fromDate, toDate)
+	if err != nil {
+		return 0, err
+	}
+	leaves, err := s.leaveRepo.FindApprovedForUsers([]uint{schedule.UserID}, fromDate, toDate)
+	if err != nil {
+		return 0, err
+	}
+	timeLogs, err := s.overtimeRepo.FindTimeLogs(schedule.UserID, start, end)
+	if err != nil {
+		return 0, err
+	}
+	existing, err := s.overtimeRepo.FindRecords(schedule.UserID, fromDate)
+	if err != nil {
+		return 0, err
+	}
+
+	expected := overtimeSchedule{
+		cal:         cal.WithHolidays(holidays[schedule.UserID]),
+		hoursPerDay: schedule.ExpectedHoursPerDay,
+		leaves:      leaves,
+	}
+	var periods []overtimePeriod
+	timeLogOvertime := make(map[uint]int64)
+	for _, rule := range overtimeRules {
+		found, overtime := rule.evaluate(expected, timeLogs, s.minSeconds)
+		periods = append(periods, found...)
+		for id, seconds := range overtime {
+			timeLogOvertime[id] = max(timeLogOvertime[id], seconds)
+		}
+	}
+
+	// Keep the identity and notification time of periods found before
+	previous := make(map[string]models.OvertimeRecord, len(existing))
+	for _, record := range existing {
+		previous[record.Rule+record.PeriodStart.Format("2006-01-02")] = record
+	}
+	records := make([]models.OvertimeRecord, 0, len(periods))
+	for _, period := range periods {
+		record := period.record
+		record.UserID = schedule.UserID
+		if old, ok := previous[record.Rule+record.PeriodStart.Format("2006-01-02")]; ok {
+			record.ID = old.ID
+			record.CreatedAt = old.CreatedAt
+			record.NotifiedAt = old.NotifiedAt
+		}
+		records = append(records, record)
+	}
+
+	if err := s.overtimeRepo.Replace(schedule.UserID, fromDate, records, start, end, timeLogOvertime); err != nil {
+		return 0, err
+	}
+
+	if !s.notifyManagers {
+		return 0, nil
+	}
+	alerts := 0
+	for i := range records {
+		if records[i].NotifiedAt != nil {
+			continue
+		}
+		s.notificationService.NotifyOvertime(&records[i], &schedule.User, periods[i].workspaceIDs)
+		if err := s.overtimeRepo.MarkNotified(records[i].ID, now); err != nil {
+			log.Printf("⚠️  Failed to record overtime notification %d: %v", records[i].ID, err)
+			continue
+		}
+		alerts++
+	}
+	return alerts, nil
+}
@@ -0,0 +1,273 @@
+package service
+
+import (
+	"sort"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/calendar"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/money"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+)
+
+const maxPayrollRangeDays = 400
+
+var (
+	// ErrInvalidPayrollPeriod is returned for pay periods other than week and month
+	ErrInvalidPayrollPeriod = apperror.Validation("invalid period: must be week or month", nil)
+	// ErrInvalidPayrollRange is returned for reversed or too long report ranges
+	ErrInvalidPayrollRange = apperror.Validation("invalid date range: end_date must not be before start_date and the range cannot exceed 400 days", nil)
+	// ErrCostRateMemberNotFound is returned when setting the cost rate of a non-member
+	ErrCostRateMemberNotFound = apperror.NotFound("member not found")
+)
+
+// PayrollService manages member cost rates and reports approved hours times
+// cost rate per member, pay period, cost center and project code for payroll
+type PayrollService interface {
+	ListCostRates(orgID, userID uint) ([]dto.MemberCostRateResponse, error)
+	UpdateCostRate(orgID, memberUserID, userID uint, req *dto.UpdateCostRateRequest) (*dto.MemberCostRateResponse, error)
+	GetReport(orgID, userID uint, params *dto.PayrollReportParams) (*dto.PayrollReport, error)
+}
+
+type payrollService struct {
+	payrollRepo repository.PayrollRepository
+	orgRepo     *repository.OrganizationRepository
+}
+
+// NewPayrollService creates a new payroll service
+func NewPayrollService(
+	payrollRepo repository.PayrollRepository,
+	orgRepo *repository.OrganizationRepository,
+) PayrollService {
+	return &payrollService{
+		payrollRepo: payrollRepo,
+		orgRepo:     orgRepo,
+	}
+}
+
+func (s *payrollService) requireAdmin(orgID, userID uint) error {
+	isAdmin, err := s.orgRepo.IsAdmin(orgID, userID)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return apperror.Forbidden("access denied: only admins can manage payroll")
+	}
+	return nil
+}
+
+func (s *payrollService) ListCostRates(orgID, userID uint) ([]dto.MemberCostRateResponse, error) {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return nil, err
+	}
+
+	org, err := s.orgRepo.GetByID(orgID)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.payrollRepo.FindMembers(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.MemberCostRateResponse, 0, len(members))
+	for i := range members {
+		if members[i].IsActive {
+			responses = append(responses, toMemberCostRateResponse(&members[i], org.Currency))
+		}
+	}
+	return responses, nil
+}
+
+func (s *payrollService) UpdateCostRate(orgID, memberUserID, userID uint, req *dto.UpdateCostRateRequest) (*dto.MemberCostRateResponse, error) {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return nil, err
+	}
+
+	org, err := s.orgRepo.GetByID(orgID)
+	if err != nil {
+		return nil, err
+	}
+	var costRateMinor *int64
+	if req.CostRate != nil {
+		minor := money.ToMinor(*req.CostRate, org.Currency)
+		costRateMinor = &minor
+	}
+
+	updated, err := s.payrollRepo.SetCostRate(orgID, memberUserID, costRateMinor)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, ErrCostRateMemberNotFound
+	}
+
+	member, err := s.orgRepo.GetMemberWithUser(orgID, memberUserID)
+	if err != nil {
+		return nil, err
+	}
+	response := toMemberCostRateResponse(member, org.Currency)
+	return &response, nil
+}
+
+func (s *payrollService) GetReport(orgID, userID uint, params *dto.PayrollReportParams) (*dto.PayrollReport, error) {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return nil, err
+	}
+
+	period := params.Period
+	if period == "" {
+		period = calendar.PeriodMonth
+	}
+	if period != calendar.PeriodWeek && period != calendar.PeriodMonth {
+		return nil, ErrInvalidPayrollPeriod
+	}
+
+	org, err := s.orgRepo.GetByID(orgID)
+	if err != nil {
+		return nil, err
+	}
+	cal := org.Calendar()
+	loc := reportLocation(params.Location, org.Timezone)
+
+	// The range is widened to whole pay periods of dates in loc
+	from := time.Now().In(loc)
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	if params.StartDate != nil {
+		from = *params.StartDate
+	}
+	to := from
+	if params.EndDate != nil {
+		to = *params.EndDate
+	}
+	if to.Before(from) || to.Sub(from) > maxPayrollRangeDays*24*time.Hour {
+		return nil, ErrInvalidPayrollRange
+	}
+	start := cal.PeriodStart(period, from)
+	_, end := cal.PeriodRange(period, to)
+
+	members, err := s.payrollRepo.FindMembers(orgID)
+	if err != nil {
+		return nil, err
+	}
+	byUser := make(map[uint]*models.OrganizationMember, len(members))
+	for i := range members {
+		byUser[members[i].UserID] = &members[i]
+	}
+
+	days, err := s.payrollRepo.GetApprovedDaily(orgID, dateIn(start, loc), dateIn(end, loc), loc.String())
+	if err != nil {
+		return nil, err
+	}
+
+	type rowKey struct {
+		userID      uint
+		period      string
+		costCenter  string
+		projectCode string
+	}
+	rows := make(map[rowKey]*dto.PayrollReportRow)
+	seconds := make(map[rowKey]int64)
+	for _, day := range days {
+		key := rowKey{day.UserID, cal.PeriodLabel(period, day.Day), day.CostCenter, day.ProjectCode}
+		seconds[key] += day.Seconds
+		if rows[key] != nil {
+			continue
+		}
+
+		periodStart, periodEnd := cal.PeriodRange(period, day.Day)
+		row := &dto.PayrollReportRow{
+			UserID:      day.UserID,
+			Period:      key.period,
+			PeriodStart: periodStart,
+			PeriodEnd:   periodEnd,
+			CostCenter:  day.CostCenter,
+			ProjectCode: day.ProjectCode,
+		}
+		if member := byUser[day.UserID]; member != nil {
+			row.Email = member.User.Email
+			row.Name = emailUserName(&member.User)
+			row.CostRate = costRateMajor(member.CostRateMinor, org.Currency)
+		}
+		rows[key] = row
+	}
+
+	report := &dto.PayrollReport{
+		OrganizationID: orgID,
+		Period:         period,
+		StartDate:      start,
+		EndDate:        end,
+		Currency:       org.Currency,
+		Rows:           make([]dto.PayrollReportRow, 0, len(rows)),
+	}
+
+	var totalSeconds int64
+	missing := make(map[uint]bool)
+	for key, row := range rows {
+		totalSeconds += seconds[key]
+		row.ApprovedHours = secondsToHours(seconds[key])
+		if row.CostRate != nil {
+			row.Cost = money.Round(float64(seconds[key])/3600**row.CostRate, org.Currency)
+		} else {
+			missing[row.UserID] = true
+		}
+		report.TotalCost += row.Cost
+		report.Rows = append(report.Rows, *row)
+	}
+	report.TotalHours = secondsToHours(totalSeconds)
+	report.TotalCost = money.Round(report.TotalCost, org.Currency)
+	report.MissingCostRates = len(missing)
+
+	sort.Slice(report.Rows, func(i, j int) bool {
+		a, b := report.Rows[i], report.Rows[j]
+		if !a.PeriodStart.Equal(b.PeriodStart) {
+			return a.PeriodStart.Before(b.PeriodStart)
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.UserID != b.UserID {
+			return a.UserID < b.UserID
+		}
+		if a.CostCenter != b.CostCenter {
+			return a.CostCenter < b.CostCenter
+		}
+		return a.ProjectCode < b.ProjectCode
+	})
+
+	return report, nil
+}
+
+// costRateMajor converts a stored cost rate to major units; nil stays nil
+func costRateMajor(costRateMinor *int64, currency string) *float64 {
+	if costRateMinor == nil {
+		return nil
+	}
+	costRate := money.ToMajor(*costRateMinor, currency)
+	return &costRate
+}
+
+func toMemberCostRateResponse(member *models.OrganizationMember, currency string) dto.MemberCostRateResponse {
+	response := dto.MemberCostRateResponse{
+		UserID:   member.UserID,
+		Role:     member.Role,
+		IsActive: member.IsActive,
+		CostRate: costRateMajor(member.CostRateMinor, currency),
+		Currency: currency,
+	}
+	if member.User.ID > 0 {
+		response.User = &dto.UserResponse{
+			ID:        member.User.ID,
+			UUID:      member.User.UUID,
+			Email:     member.User.Email,
+			FirstName: member.User.FirstName,
+			LastName:  member.User.LastName,
+			Role:      member.User.Role,
+			IsActive:  member.User.IsActive,
+			CreatedAt: member.User.CreatedAt,
+		}
+	}
+	return response
+}
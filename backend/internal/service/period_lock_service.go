@@ -0,0 +1,183 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+)
+
+// ErrLockMovedBack is returned when locking with a date before the current
+// lock; moving it back reopens closed periods and goes through Reopen
+var ErrLockMovedBack = apperror.Validation("locked_before cannot be before the current lock date: reopen the period instead", nil)
+
+// PeriodLockService closes pay periods: once an admin locks entries before
+// a date, members can no longer add or edit time logs starting before it
+type PeriodLockService interface {
+	Get(orgID, userID uint) (*dto.PeriodLockResponse, error)
+	Lock(orgID, userID uint, req *dto.LockPeriodRequest) (*dto.PeriodLockResponse, error)
+	// Reopen moves the lock date back or removes it, with an audit entry
+	Reopen(orgID, userID uint, req *dto.ReopenPeriodRequest) (*dto.PeriodLockResponse, error)
+
+	// LockedBefore returns the organization's lock date that applies to the
+	// user, or nil when no period is closed or the user is an admin
+	LockedBefore(orgID *uint, userID uint) (*time.Time, error)
+}
+
+type periodLockService struct {
+	orgRepo      *repository.OrganizationRepository
+	auditService AuditService
+}
+
+// NewPeriodLockService creates a new period lock service
+func NewPeriodLockService(orgRepo *repository.OrganizationRepository, auditService AuditService) PeriodLockService {
+	return &periodLockService{
+		orgRepo:      orgRepo,
+		auditService: auditService,
+	}
+}
+
+// periodLockedError reports an add or edit of a time log in a closed period
+func periodLockedError(lockedBefore time.Time) *apperror.Error {
+	return &apperror.Error{
+		Status:  http.StatusConflict,
+		Code:    apperror.CodePeriodLocked,
+		Message: fmt.Sprintf("time entries before %s are locked: the pay period is closed", lockedBefore.Format("2006-01-02")),
+	}
+}
+
+func (s *periodLockService) requireAdmin(orgID, userID uint) error {
+	isAdmin, err := s.orgRepo.IsAdmin(orgID, userID)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return apperror.Forbidden("access denied: only admins can lock pay periods")
+	}
+	return nil
+}
+
+func (s *periodLockService) Get(orgID, userID uint) (*dto.PeriodLockResponse, error) {
+	isMember, err := s.orgRepo.IsMember(orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, apperror.Forbidden("access denied: not a member of this organization")
+	}
+
+	org, err := s.orgRepo.GetByID(orgID)
+	if err != nil {
+		return nil, apperror.NotFound("organization not found")
+	}
+	return toPeriodLockResponse(org), nil
+}
+
+func (s *periodLockService) Lock(orgID, userID uint, req *dto.LockPeriodRequest) (*dto.PeriodLockResponse, error) {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return nil, err
+	}
+	lockedBefore, err := time.Parse("2006-01-02", req.LockedBefore)
+	if err != nil {
+		return nil, apperror.Validation("invalid locked_before: use YYYY-MM-DD", nil)
+	}
+
+	org, err := s.orgRepo.GetByID(orgID)
+	if err != nil {
+		return nil, apperror.NotFound("organization not found")
+	}
+	if org.EntriesLockedBefore != nil && lockedBefore.Before(*org.EntriesLockedBefore) {
+		return nil, ErrLockMovedBack
+	}
+
+	org.EntriesLockedBefore = &lockedBefore
+	if err := s.orgRepo.Update(org); err != nil {
+		return nil, err
+	}
+	return toPeriodLockResponse(org), nil
+}
+
+func (s *periodLockService) Reopen(orgID, userID uint, req *dto.ReopenPeriodRequest) (*dto.PeriodLockResponse, error) {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return nil, err
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, apperror.Validation("reason is required", nil)
+	}
+
+	org, err := s.orgRepo.GetByID(orgID)
+	if err != nil {
+		return nil, apperror.NotFound("organization not found")
+	}
+	previous := org.EntriesLockedBefore
+	if previous == nil {
+		return nil, apperror.Conflict("no pay period is locked")
+	}
+
+	var lockedBefore *time.Time
+	if req.LockedBefore != "" {
+		date, err := time.Parse("2006-01-02", req.LockedBefore)
+		if err != nil {
+			return nil, apperror.Validation("invalid locked_before: use YYYY-MM-DD", nil)
+		}
+		if !date.Before(*previous) {
+			return nil, apperror.Validation("locked_before must be before the current lock date", nil)
+		}
+		lockedBefore = &date
+	}
+
+	org.EntriesLockedBefore = lockedBefore
+	if err := s.orgRepo.Update(org); err != nil {
+		return nil, err
+	}
+
+	details, _ := json.Marshal(map[string]interface{}{
+		"previous_locked_before": previous.Format("2006-01-02"),
+		"locked_before":          req.LockedBefore,
+		"reason":                 reason,
+	})
+	s.auditService.Record(&models.AuditLog{
+		UserID:     &userID,
+		Action:     "reopen_period",
+		EntityType: "organization",
+		EntityID:   &orgID,
+		Details:    string(details),
+		Status:     AuditStatusSuccess,
+	})
+
+	return toPeriodLockResponse(org), nil
+}
+
+func (s *periodLockService) LockedBefore(orgID *uint, userID uint) (*time.Time, error) {
+	if orgID == nil {
+		return nil, nil
+	}
+	org, err := s.orgRepo.GetByID(*orgID)
+	if err != nil || org.EntriesLockedBefore == nil {
+		// Unknown organizations are rejected by the caller's own checks
+		return nil, nil
+	}
+
+	isAdmin, err := s.orgRepo.IsAdmin(*orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if isAdmin {
+		return nil, nil
+	}
+	return org.EntriesLockedBefore, nil
+}
+
+func toPeriodLockResponse(org *models.Organization) *dto.PeriodLockResponse {
+	return &dto.PeriodLockResponse{
+		OrganizationID: org.ID,
+		LockedBefore:   org.EntriesLockedBefore,
+	}
+}
@@ -0,0 +1,498 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+)
+
+// Reasons a workspace permission is granted
+const (
+	grantMembership       = "membership"
+	grantWorkspaceAdmin   = "workspace_admin"
+	grantOrganizationRole = "organization_role"
+)
+
+// ErrUnknownPermission is returned when a role matrix names a permission
+// that is not in the workspace permission schema
+var ErrUnknownPermission = apperror.Validation("unknown workspace permission", nil)
+
+// PermissionService resolves members' effective permissions, evaluates
+// workspace role permission matrices and keeps the history of role changes
+type PermissionService interface {
+	GetMemberPermissions(orgID, memberUserID, actorID uint) (*dto.MemberPermissionsResponse, error)
+	GetRoleHistory(orgID, memberUserID, actorID uint, page, perPage int) ([]dto.RoleChangeResponse, int64, error)
+
+	// HasWorkspacePermission reports whether the user holds a workspace
+	// permission (see models.WorkspacePermissionSchema)
+	HasWorkspacePermission(workspaceID, userID uint, permission string) (bool, error)
+
+	// Role permission matrix
+	GetPermissionSchema() []dto.WorkspacePermissionDefinitionResponse
+	GetRolePermissions(orgID, roleID, actorID uint) (*dto.RolePermissionsResponse, error)
+	UpdateRolePermissions(orgID, roleID, actorID uint, req *dto.UpdateRolePermissionsRequest) (*dto.RolePermissionsResponse, error)
+
+	// RecordRoleChanges stores role changes; failures are logged and never
+	// fail the change itself
+	RecordRoleChanges(changes ...models.RoleChange)
+}
+
+type permissionService struct {
+	roleChangeRepo repository.RoleChangeRepository
+	orgRepo        *repository.OrganizationRepository
+	workspaceRepo  *repository.WorkspaceRepository
+	userRepo       repository.UserRepository
+}
+
+// NewPermissionService creates a new permission service
+func NewPermissionService(
+	roleChangeRepo repository.RoleChangeRepository,
+	orgRepo *repository.OrganizationRepository,
+	workspaceRepo *repository.WorkspaceRepository,
+	userRepo repository.UserRepository,
+) PermissionService {
+	return &permissionService{
+		roleChangeRepo: roleChangeRepo,
+		orgRepo:        orgRepo,
+		workspaceRepo:  workspaceRepo,
+		userRepo:       userRepo,
+	}
+}
+
+// requireViewer allows org admins, system admins and the member themselves
+func (s *permissionService) requireViewer(orgID, memberUserID, actorID uint) error {
+	if actorID == memberUserID {
+		return nil
+	}
+
+	isAdmin, err := s.orgRepo.IsAdmin(orgID, actorID)
+	if err != nil {
+		return err
+	}
+	if isAdmin {
+		return nil
+	}
+
+	actor, err := s.userRepo.FindByID(actorID)
+	if err == nil && actor.IsSystemAdmin() {
+		return nil
+	}
+	return apperror.Forbidden("access denied: only admins can view other members' permissions")
+}
+
+func (s *permissionService) GetMemberPermissions(orgID, memberUserID, actorID uint) (*dto.MemberPermissionsResponse, error) {
+	if err := s.requireViewer(orgID, memberUserID, actorID); err != nil {
+		return nil, err
+	}
+
+	member, err := s.orgRepo.GetMember(orgID, memberUserID)
+	if err != nil {
+		return nil, apperror.NotFound("member not found")
+	}
+	user, err := s.userRepo.FindByID(memberUserID)
+	if err != nil {
+		return nil, apperror.NotFound("member not found")
+	}
+
+	orgRole, err := s.orgRepo.GetMemberRole(orgID, memberUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.MemberPermissionsResponse{
+		OrganizationID: orgID,
+		UserID:         memberUserID,
+		SystemRole:     user.SystemRole,
+		IsSystemAdmin:  user.IsSystemAdmin(),
+		Workspaces:     []dto.WorkspacePermission{},
+		Permissions:    []string{},
+	}
+	if resp.IsSystemAdmin {
+		resp.Permissions = append(resp.Permissions, "system:admin")
+	}
+
+	// An inactive membership grants nothing in the organization
+	if !member.IsActive {
+		resp.Organization = dto.OrgPermission{OrganizationID: orgID, Role: orgRole}
+		return resp, nil
+	}
+
+	isOrgAdmin := orgRole == models.OrgRoleOwner || orgRole == models.OrgRoleAdmin
+	resp.Organization = dto.OrgPermission{
+		OrganizationID: orgID,
+		Role:           orgRole,
+		CanManageOrg:   isOrgAdmin,
+		CanInvite:      isOrgAdmin,
+		CanRemove:      isOrgAdmin,
+	}
+	resp.Permissions = append(resp.Permissions, "org:"+orgRole)
+
+	workspaces, err := s.workspaceRepo.GetByOrganizationID(orgID)
+	if err != nil {
+		return nil, err
+	}
+	memberships, err := s.workspaceRepo.GetUserWorkspacesByOrg(memberUserID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	byWorkspace := make(map[uint]*models.WorkspaceMember, len(memberships))
+	for i := range memberships {
+		byWorkspace[memberships[i].WorkspaceID] = &memberships[i]
+	}
+
+	for i := range workspaces {
+		ws := &workspaces[i]
+		m := byWorkspace[ws.ID]
+		if m == nil && !isOrgAdmin && ws.AdminID != memberUserID {
+			continue
+		}
+
+		perm := resolveWorkspacePermission(ws, m, memberUserID, isOrgAdmin)
+		resp.Workspaces = append(resp.Workspaces, perm)
+		resp.Permissions = append(resp.Permissions, workspacePermissionNames(&perm)...)
+	}
+
+	return resp, nil
+}
+
+// resolveWorkspacePermission combines a workspace membership (nil when the
+// user is not a member) with the rights the organization role grants.
+// Organization owners and admins manage every workspace.
+func resolveWorkspacePermission(ws *models.Workspace, m *models.WorkspaceMember, userID uint, isOrgAdmin bool) dto.WorkspacePermission {
+	perm := dto.WorkspacePermission{
+		WorkspaceID:    ws.ID,
+		OrganizationID: ws.OrganizationID,
+		WorkspaceName:  ws.Name,
+	}
+
+	if m != nil {
+		perm.GrantedBy = append(perm.GrantedBy, grantMembership)
+		perm.RoleName = m.RoleName
+		perm.IsAdmin = m.IsAdmin
+		perm.CanViewReports = m.CanViewReports
+		perm.CanManageTasks = m.CanManageTasks
+		if m.WorkspaceRole != nil {
+			if perm.RoleName == "" {
+				perm.RoleName = m.WorkspaceRole.Name
+			}
+			perm.RolePermissions = parseRolePermissions(m.WorkspaceRole.Permissions)
+		}
+	}
+	if ws.AdminID == userID {
+		perm.IsAdmin = true
+		perm.GrantedBy = append(perm.GrantedBy, grantWorkspaceAdmin)
+	}
+	if isOrgAdmin {
+		perm.IsAdmin = true
+		perm.GrantedBy = append(perm.GrantedBy, grantOrganizationRole)
+	}
+
+	// Workspace admins can do everything members can
+	if perm.IsAdmin {
+		perm.CanViewReports = true
+		perm.CanManageTasks = true
+	}
+	perm.Permissions = effectiveWorkspacePermissions(perm.IsAdmin, m)
+	return perm
+}
+
+// effectiveWorkspacePermissions resolves the schema permissions a member
+// holds. Admins hold all of them. Members start from the schema defaults,
+// their role's matrix grants (true) or revokes (false) on top, and the legacy
+// can_view_reports and can_manage_tasks flags grant what they always did.
+func effectiveWorkspacePermissions(isAdmin bool, m *models.WorkspaceMember) []string {
+	granted := make(map[string]bool, len(models.WorkspacePermissionSchema))
+	for _, def := range models.WorkspacePermissionSchema {
+		granted[def.Key] = isAdmin || def.Default
+	}
+
+	if !isAdmin && m != nil {
+		if m.WorkspaceRole != nil {
+			for name, enabled := range parseRoleMatrix(m.WorkspaceRole.Permissions) {
+				granted[name] = enabled
+			}
+		}
+		if m.CanViewReports {
+			granted[models.PermReportsView] = true
+		}
+		if m.CanManageTasks {
+			granted[models.PermTasksCreate] = true
+			granted[models.PermTasksManage] = true
+		}
+	}
+
+	permissions := []string{}
+	for _, def := range models.WorkspacePermissionSchema {
+		if granted[def.Key] {
+			permissions = append(permissions, def.Key)
+		}
+	}
+	return permissions
+}
+
+// parseRoleMatrix reads a custom role's JSON, e.g. {"tasks.create": true}.
+// Non-boolean values are ignored and malformed JSON yields nothing.
+func parseRoleMatrix(raw string) map[string]bool {
+	var perms map[string]interface{}
+	if raw == "" || json.Unmarshal([]byte(raw), &perms) != nil {
+		return nil
+	}
+
+	matrix := make(map[string]bool, len(perms))
+	for name, value := range perms {
+		if enabled, ok := value.(bool); ok {
+			matrix[name] = enabled
+		}
+	}
+	return matrix
+}
+
+// parseRolePermissions lists the permissions a custom role's JSON grants
+func parseRolePermissions(raw string) []string {
+	var granted []string
+	for name, enabled := range parseRoleMatrix(raw) {
+		if enabled {
+			granted = append(granted, name)
+		}
+	}
+	sort.Strings(granted)
+	return granted
+}
+
+// validateRolePermissions checks that a role's permission JSON is an object
+// of schema permissions to booleans. Empty means no overrides.
+func validateRolePermissions(raw string) error {
+	if strings.TrimSpace(raw) == "" {
+		return nil
+	}
+
+	var perms map[string]bool
+	if err := json.Unmarshal([]byte(raw), &perms); err != nil {
+		return apperror.Validation("permissions must be a JSON object of permission names to true or false", nil)
+	}
+	for name := range perms {
+		if !models.IsWorkspacePermission(name) {
+			return fmt.Errorf("%w: %s", ErrUnknownPermission, name)
+		}
+	}
+	return nil
+}
+
+// workspacePermissionNames flattens a workspace permission into names
+func workspacePermissionNames(perm *dto.WorkspacePermission) []string {
+	prefix := fmt.Sprintf("workspace:%d:", perm.WorkspaceID)
+	names := []string{prefix + "member"}
+	if perm.IsAdmin {
+		names = append(names, prefix+"admin")
+	}
+	if perm.CanViewReports {
+		names = append(names, prefix+"view_reports")
+	}
+	if perm.CanManageTasks {
+		names = append(names, prefix+"manage_tasks")
+	}
+	for _, p := range perm.Permissions {
+		names = append(names, prefix+p)
+	}
+	return names
+}
+
+// ============================================================================
+// WORKSPACE PERMISSION CHECKS
+// ============================================================================
+
+func (s *permissionService) HasWorkspacePermission(workspaceID, userID uint, permission string) (bool, error) {
+	workspace, err := s.workspaceRepo.GetByID(workspaceID)
+	if err != nil {
+		return false, err
+	}
+
+	// System admins reach every workspace through the admin API already
+	if user, err := s.userRepo.FindByID(userID); err == nil && user.IsSystemAdmin() {
+		return true, nil
+	}
+
+	isOrgAdmin, err := s.orgRepo.IsAdmin(workspace.OrganizationID, userID)
+	if err != nil {
+		return false, err
+	}
+
+	member, err := s.workspaceRepo.GetMember(workspaceID, userID)
+	if err != nil || !member.IsActive {
+		member = nil
+	}
+	if member == nil && !isOrgAdmin && workspace.AdminID != userID {
+		return false, nil
+	}
+
+	perm := resolveWorkspacePermission(workspace, member, userID, isOrgAdmin)
+	for _, p := range perm.Permissions {
+		if p == permission {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ============================================================================
+// ROLE PERMISSION MATRIX
+// ============================================================================
+
+func (s *permissionService) GetPermissionSchema() []dto.WorkspacePermissionDefinitionResponse {
+	schema := make([]dto.WorkspacePermissionDefinitionResponse, 0, len(models.WorkspacePermissionSchema))
+	for _, def := range models.WorkspacePermissionSchema {
+		schema = append(schema, dto.WorkspacePermissionDefinitionResponse{
+			Key:         def.Key,
+			Group:       def.Group,
+			Description: def.Description,
+			Default:     def.Default,
+		})
+	}
+	return schema
+}
+
+// findOrgRole loads a workspace role and checks it belongs to the organization
+func (s *permissionService) findOrgRole(orgID, roleID uint) (*models.WorkspaceRole, error) {
+	role, err := s.workspaceRepo.GetRoleByID(roleID)
+	if err != nil || role.OrganizationID != orgID {
+		return nil, apperror.NotFound("role not found")
+	}
+	return role, nil
+}
+
+func (s *permissionService) GetRolePermissions(orgID, roleID, actorID uint) (*dto.RolePermissionsResponse, error) {
+	isMember, err := s.orgRepo.IsMember(orgID, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, apperror.Forbidden("access denied: not a member of this organization")
+	}
+
+	role, err := s.findOrgRole(orgID, roleID)
+	if err != nil {
+		return nil, err
+	}
+	return toRolePermissionsResponse(role), nil
+}
+
+func (s *permissionService) UpdateRolePermissions(orgID, roleID, actorID uint, req *dto.UpdateRolePermissionsRequest) (*dto.RolePermissionsResponse, error) {
+	isAdmin, err := s.orgRepo.IsAdmin(orgID, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if !isAdmin {
+		return nil, apperror.Forbidden("access denied: only organization admins can edit role permissions")
+	}
+
+	role, err := s.findOrgRole(orgID, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	for name := range req.Permissions {
+		if !models.IsWorkspacePermission(name) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownPermission, name)
+		}
+	}
+	raw, err := json.Marshal(req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	role.Permissions = string(raw)
+	if err := s.workspaceRepo.UpdateRole(role); err != nil {
+		return nil, errors.New("failed to update role permissions")
+	}
+	return toRolePermissionsResponse(role), nil
+}
+
+// toRolePermissionsResponse expands a role's matrix over the whole schema,
+// as it applies to a member without admin rights or legacy flags
+func toRolePermissionsResponse(role *models.WorkspaceRole) *dto.RolePermissionsResponse {
+	member := &models.WorkspaceMember{WorkspaceRole: role}
+	granted := effectiveWorkspacePermissions(false, member)
+
+	permissions := make(map[string]bool, len(models.WorkspacePermissionSchema))
+	for _, def := range models.WorkspacePermissionSchema {
+		permissions[def.Key] = false
+	}
+	for _, p := range granted {
+		permissions[p] = true
+	}
+
+	return &dto.RolePermissionsResponse{
+		RoleID:         role.ID,
+		OrganizationID: role.OrganizationID,
+		Name:           role.Name,
+		DisplayName:    role.DisplayName,
+		Permissions:    permissions,
+	}
+}
+
+func (s *permissionService) GetRoleHistory(orgID, memberUserID, actorID uint, page, perPage int) ([]dto.RoleChangeResponse, int64, error) {
+	if err := s.requireViewer(orgID, memberUserID, actorID); err != nil {
+		return nil, 0, err
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+
+	changes, total, err := s.roleChangeRepo.FindByMember(orgID, memberUserID, page, perPage)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]dto.RoleChangeResponse, 0, len(changes))
+	for i := range changes {
+		result = append(result, toRoleChangeResponse(&changes[i]))
+	}
+	return result, total, nil
+}
+
+func (s *permissionService) RecordRoleChanges(changes ...models.RoleChange) {
+	if err := s.roleChangeRepo.Create(changes); err != nil {
+		log.Printf("⚠️  Failed to record %d role changes: %v", len(changes), err)
+	}
+}
+
+func toRoleChangeResponse(c *models.RoleChange) dto.RoleChangeResponse {
+	resp := dto.RoleChangeResponse{
+		ID:             c.ID,
+		UserID:         c.UserID,
+		OrganizationID: c.OrganizationID,
+		WorkspaceID:    c.WorkspaceID,
+		Scope:          c.Scope,
+		Attribute:      c.Attribute,
+		From:           c.FromValue,
+		To:             c.ToValue,
+		Source:         c.Source,
+		ChangedBy:      c.ChangedBy,
+		ChangedAt:      c.CreatedAt,
+	}
+	if c.Actor != nil {
+		resp.Actor = &dto.UserResponse{
+			ID:        c.Actor.ID,
+			UUID:      c.Actor.UUID,
+			Email:     c.Actor.Email,
+			FirstName: c.Actor.FirstName,
+			LastName:  c.Actor.LastName,
+			Role:      c.Actor.Role,
+			IsActive:  c.Actor.IsActive,
+			CreatedAt: c.Actor.CreatedAt,
+		}
+	}
+	return resp
+}
@@ -18,6 +18,7 @@ type PresenceEvent struct {
 type PresenceHub struct {
 	mu          sync.RWMutex
 	subscribers map[chan []byte]struct{}
+	closed      bool
 }
 
 // NewPresenceHub creates a new PresenceHub
@@ -31,8 +32,12 @@ func NewPresenceHub() *PresenceHub {
 func (h *PresenceHub) Subscribe() chan []byte {
 	ch := make(chan []byte, 20)
 	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		close(ch)
+		return ch
+	}
 	h.subscribers[ch] = struct{}{}
-	h.mu.Unlock()
 	return ch
 }
 
@@ -46,6 +51,18 @@ func (h *PresenceHub) Unsubscribe(ch chan []byte) {
 	h.mu.Unlock()
 }
 
+// Close closes every subscriber channel so open streams end, e.g. on server
+// shutdown. Later subscribers receive an already closed channel.
+func (h *PresenceHub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for ch := range h.subscribers {
+		delete(h.subscribers, ch)
+		close(ch)
+	}
+}
+
 // Broadcast sends presence event to all subscribers
 func (h *PresenceHub) Broadcast(event PresenceEvent) {
 	payload, err := json.Marshal(event)
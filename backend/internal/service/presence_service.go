@@ -1,37 +1,70 @@
 package service
 
 import (
-	"errors"
+	"context"
+	"log"
 	"strings"
 	"time"
 
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/config"
 	"github.com/beuphecan/remote-time-tracker/internal/dto"
 	"github.com/beuphecan/remote-time-tracker/internal/models"
 	"github.com/beuphecan/remote-time-tracker/internal/repository"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
 )
 
-// PresenceService handles user presence updates
+const (
+	// presenceHistoryMaxRange bounds the period of a presence history query
+	presenceHistoryMaxRange = 31 * 24 * time.Hour
+	// presenceHistoryMaxIntervals bounds the intervals returned per query
+	presenceHistoryMaxIntervals = 5000
+)
+
+// PresenceService handles user presence updates, the offline sweep and the
+// presence history behind the admin presence dashboard
 type PresenceService interface {
 	UpdatePresence(userID uint, req *dto.PresenceHeartbeatRequest) (*dto.PresenceStatusResponse, error)
+
+	// MarkStaleOffline marks users whose heartbeats stopped offline
+	// (scheduled job)
+	MarkStaleOffline(ctx context.Context) error
+	// PurgeHistory deletes presence history past the retention (scheduled job)
+	PurgeHistory(ctx context.Context) error
+
+	// ListOrganization returns the current presence of an organization's members
+	ListOrganization(orgID uint) (*dto.AdminPresenceListResponse, error)
+	// History returns who was in which status during a period
+	History(params *dto.AdminPresenceHistoryParams) (*dto.AdminPresenceHistoryResponse, error)
 }
 
 type presenceService struct {
-	userRepo   repository.UserRepository
-	deviceRepo repository.DeviceRepository
+	userRepo     repository.UserRepository
+	deviceRepo   repository.DeviceRepository
+	presenceRepo repository.PresenceRepository
+	orgRepo      *repository.OrganizationRepository
+	staleAfter   time.Duration
+	retention    time.Duration
 }
 
 // NewPresenceService creates a new presence service
-func NewPresenceService(userRepo repository.UserRepository, deviceRepo repository.DeviceRepository) PresenceService {
+func NewPresenceService(userRepo repository.UserRepository, deviceRepo repository.DeviceRepository, presenceRepo repository.PresenceRepository, orgRepo *repository.OrganizationRepository) PresenceService {
 	return &presenceService{
-		userRepo:   userRepo,
-		deviceRepo: deviceRepo,
+		userRepo:     userRepo,
+		deviceRepo:   deviceRepo,
+		presenceRepo: presenceRepo,
+		orgRepo:      orgRepo,
+		staleAfter:   config.AppConfig.Presence.StaleAfter,
+		retention:    config.AppConfig.Presence.HistoryRetention,
 	}
 }
 
 func (s *presenceService) UpdatePresence(userID uint, req *dto.PresenceHeartbeatRequest) (*dto.PresenceStatusResponse, error) {
 	status := strings.ToLower(strings.TrimSpace(req.Status))
-	if status != models.UserPresenceWorking && status != models.UserPresenceIdle {
-		return nil, errors.New("invalid status: must be working or idle")
+	switch status {
+	case models.UserPresenceWorking, models.UserPresenceOnline, models.UserPresenceIdle, models.UserPresenceOffline:
+	default:
+		return nil, apperror.Validation("invalid status: must be working, online, idle or offline", nil)
 	}
 
 	now := time.Now().UTC()
@@ -61,3 +94,134 @@ func (s *presenceService) UpdatePresence(userID uint, req *dto.PresenceHeartbeat
 		LastWorkingAt:  lastWorkingAt,
 	}, nil
 }
+
+func (s *presenceService) MarkStaleOffline(ctx context.Context) error {
+	if s.staleAfter <= 0 {
+		return nil
+	}
+
+	users, err := s.presenceRepo.FindStale(time.Now().Add(-s.staleAfter))
+	if err != nil {
+		return err
+	}
+
+	marked := 0
+	for _, user := range users {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		ok, err := s.presenceRepo.MarkOffline(user.ID, *user.LastPresenceAt)
+		if err != nil {
+			log.Printf("⚠️  Failed to mark user %d offline: %v", user.ID, err)
+			continue
+		}
+		if !ok {
+			// A heartbeat arrived meanwhile
+			continue
+		}
+		PresenceBroadcaster.Broadcast(PresenceEvent{
+			UserID:         user.ID,
+			Status:         models.UserPresenceOffline,
+			LastPresenceAt: *user.LastPresenceAt,
+		})
+		marked++
+	}
+
+	if marked > 0 {
+		log.Printf("✅ Marked %d users offline", marked)
+	}
+	return nil
+}
+
+func (s *presenceService) PurgeHistory(ctx context.Context) error {
+	if s.retention <= 0 {
+		return nil
+	}
+
+	deleted, err := s.presenceRepo.DeleteIntervalsBefore(time.Now().Add(-s.retention))
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		log.Printf("✅ Purged %d expired presence intervals", deleted)
+	}
+	return nil
+}
+
+func (s *presenceService) ListOrganization(orgID uint) (*dto.AdminPresenceListResponse, error) {
+	if _, err := s.orgRepo.GetByID(orgID); err != nil {
+		return nil, apperror.NotFound("organization not found")
+	}
+
+	rows, err := s.presenceRepo.ListOrganization(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.AdminPresenceListResponse{
+		OrganizationID: orgID,
+		Counts:         map[string]int{},
+		Members:        make([]dto.AdminPresenceMemberResponse, 0, len(rows)),
+	}
+	for _, row := range rows {
+		status := utils.ComputePresenceStatus(row.PresenceStatus, row.LastPresenceAt, s.staleAfter)
+		resp.Counts[status]++
+		resp.Members = append(resp.Members, dto.AdminPresenceMemberResponse{
+			UserID:         row.UserID,
+			UserEmail:      row.Email,
+			UserName:       strings.TrimSpace(row.FirstName + " " + row.LastName),
+			Role:           row.Role,
+			Status:         status,
+			LastPresenceAt: row.LastPresenceAt,
+			LastWorkingAt:  row.LastWorkingAt,
+		})
+	}
+	return resp, nil
+}
+
+func (s *presenceService) History(params *dto.AdminPresenceHistoryParams) (*dto.AdminPresenceHistoryResponse, error) {
+	if params.To.Before(params.From) {
+		return nil, apperror.Validation("from must not be after to", nil)
+	}
+	if params.To.Sub(params.From) > presenceHistoryMaxRange {
+		return nil, apperror.Validation("presence history covers at most 31 days per query", nil)
+	}
+	if _, err := s.orgRepo.GetByID(params.OrgID); err != nil {
+		return nil, apperror.NotFound("organization not found")
+	}
+
+	// One more than the limit tells whether the result was cut
+	rows, err := s.presenceRepo.FindIntervals(params.OrgID, params.UserID, params.From, params.To, presenceHistoryMaxIntervals+1)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.AdminPresenceHistoryResponse{
+		OrganizationID: params.OrgID,
+		From:           params.From,
+		To:             params.To,
+		Intervals:      make([]dto.AdminPresenceIntervalResponse, 0, len(rows)),
+	}
+	if len(rows) > presenceHistoryMaxIntervals {
+		rows = rows[:presenceHistoryMaxIntervals]
+		resp.Truncated = true
+	}
+
+	now := time.Now()
+	for _, row := range rows {
+		end := now
+		if row.EndedAt != nil {
+			end = *row.EndedAt
+		}
+		resp.Intervals = append(resp.Intervals, dto.AdminPresenceIntervalResponse{
+			UserID:          row.UserID,
+			UserEmail:       row.Email,
+			UserName:        strings.TrimSpace(row.FirstName + " " + row.LastName),
+			Status:          row.Status,
+			StartedAt:       row.StartedAt,
+			EndedAt:         row.EndedAt,
+			DurationSeconds: int64(end.Sub(row.StartedAt).Seconds()),
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,385 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/config"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+)
+
+// PrivacyService handles personal data export and account erasure (GDPR)
+type PrivacyService interface {
+	RequestExport(userID uint) (*dto.DataExportResponse, error)
+	ListExports(userID uint) ([]dto.DataExportResponse, error)
+	GetExport(id, userID uint) (*dto.DataExportResponse, error)
+	GetExportFile(id, userID uint) (*models.DataExport, error)
+
+	RequestErasure(userID uint, req *dto.AccountDeletionRequest) (*dto.AccountDeletionResponse, error)
+	CancelErasure(userID uint) error
+
+	// Scheduled jobs
+	ProcessDueErasures(ctx context.Context) error
+	PurgeExpiredExports(ctx context.Context) error
+}
+
+type privacyService struct {
+	privacyRepo  repository.PrivacyRepository
+	userRepo     repository.UserRepository
+	tierService  ScreenshotTierService
+	operations   OperationService
+	exportDir    string
+	gracePeriod  time.Duration
+	exportMaxAge time.Duration
+}
+
+// NewPrivacyService creates a new privacy service
+func NewPrivacyService(privacyRepo repository.PrivacyRepository, userRepo repository.UserRepository, tierService ScreenshotTierService, operations OperationService) PrivacyService {
+	return &privacyService{
+		privacyRepo:  privacyRepo,
+		userRepo:     userRepo,
+		tierService:  tierService,
+		operations:   operations,
+		exportDir:    filepath.Join(config.AppConfig.Upload.PrivatePath, "exports"),
+		gracePeriod:  config.AppConfig.Privacy.ErasureGracePeriod,
+		exportMaxAge: config.AppConfig.Privacy.ExportRetention,
+	}
+}
+
+// ============================================================================
+// DATA EXPORT
+// ============================================================================
+
+// RequestExport queues a new export archive; it is built in the background
+func (s *privacyService) RequestExport(userID uint) (*dto.DataExportResponse, error) {
+	active, err := s.privacyRepo.FindActiveExport(userID)
+	if err != nil {
+		return nil, err
+	}
+	if active != nil {
+		return nil, apperror.Conflict("a data export is already in progress")
+	}
+
+	op, err := s.operations.Start(models.OperationTypeDataExport, userID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &models.DataExport{
+		UserID:      userID,
+		OperationID: &op.ID,
+		Status:      models.DataExportStatusPending,
+	}
+	if err := s.privacyRepo.CreateExport(export); err != nil {
+		s.operations.Fail(op, err)
+		return nil, errors.New("failed to create data export")
+	}
+
+	go s.buildExport(export, op)
+
+	return toDataExportResponse(export), nil
+}
+
+func (s *privacyService) ListExports(userID uint) ([]dto.DataExportResponse, error) {
+	exports, err := s.privacyRepo.FindExportsByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.DataExportResponse, 0, len(exports))
+	for i := range exports {
+		responses = append(responses, *toDataExportResponse(&exports[i]))
+	}
+	return responses, nil
+}
+
+func (s *privacyService) GetExport(id, userID uint) (*dto.DataExportResponse, error) {
+	export, err := s.findOwnedExport(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	return toDataExportResponse(export), nil
+}
+
+// GetExportFile returns a completed, unexpired export for download
+func (s *privacyService) GetExportFile(id, userID uint) (*models.DataExport, error) {
+	export, err := s.findOwnedExport(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if export.Status != models.DataExportStatusCompleted {
+		return nil, apperror.Conflict("data export is not ready yet")
+	}
+	if export.ExpiresAt != nil && time.Now().After(*export.ExpiresAt) {
+		return nil, apperror.Gone("data export has expired")
+	}
+	return export, nil
+}
+
+func (s *privacyService) findOwnedExport(id, userID uint) (*models.DataExport, error) {
+	export, err := s.privacyRepo.FindExportByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if export.UserID != userID {
+		return nil, apperror.NotFound("data export not found")
+	}
+	return export, nil
+}
+
+func (s *privacyService) buildExport(export *models.DataExport, op *models.Operation) {
+	export.Status = models.DataExportStatusProcessing
+	_ = s.privacyRepo.UpdateExport(export)
+	s.operations.SetProgress(op, 0)
+
+	filePath, err := s.writeExportArchive(export)
+	if err != nil {
+		log.Printf("⚠️  Data export %d for user %d failed: %v", export.ID, export.UserID, err)
+		export.Status = models.DataExportStatusFailed
+		export.Error = err.Error()
+		_ = s.privacyRepo.UpdateExport(export)
+		s.operations.Fail(op, err)
+		return
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.exportMaxAge)
+	export.Status = models.DataExportStatusCompleted
+	export.FilePath = filePath
+	export.FileName = filepath.Base(filePath)
+	export.CompletedAt = &now
+	export.ExpiresAt = &expiresAt
+	if info, err := os.Stat(filePath); err == nil {
+		export.FileSize = info.Size()
+	}
+
+	if err := s.privacyRepo.UpdateExport(export); err != nil {
+		log.Printf("⚠️  Failed to update data export %d: %v", export.ID, err)
+	}
+	s.operations.Complete(op, fmt.Sprintf("/api/v1/users/me/exports/%d/download", export.ID), nil)
+}
+
+// writeExportArchive writes a zip with one JSON file per record type plus
+// the user's screenshot images
+func (s *privacyService) writeExportArchive(export *models.DataExport) (string, error) {
+	data, err := s.privacyRepo.LoadUserData(export.UserID)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(s.exportDir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	suffix, err := utils.GenerateSecureToken(16)
+	if err != nil {
+		return "", err
+	}
+	fileName := fmt.Sprintf("user_%d_export_%s_%s.zip", export.UserID, time.Now().Format("20060102150405"), suffix)
+	filePath := filepath.Join(s.exportDir, fileName)
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create export file: %w", err)
+	}
+	defer file.Close()
+
+	archive := zip.NewWriter(file)
+
+	entries := []struct {
+		name  string
+		value interface{}
+	}{
+		{"profile.json", data.User},
+		{"tasks.json", data.Tasks},
+		{"time_logs.json", data.TimeLogs},
+		{"screenshots.json", data.Screenshots},
+		{"devices.json", data.Devices},
+	}
+	for _, entry := range entries {
+		w, err := archive.Create(entry.name)
+		if err != nil {
+			return "", err
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(entry.value); err != nil {
+			return "", fmt.Errorf("failed to write %s: %w", entry.name, err)
+		}
+	}
+
+	for _, ss := range data.Screenshots {
+		// Files flagged by the malware scanner are never handed out
+		if ss.ScanStatus == models.ScreenshotScanQuarantined {
+			continue
+		}
+		// Archived screenshots still being restored are skipped like missing files
+		if err := s.tierService.EnsureLocal(context.Background(), &ss); err != nil {
+			log.Printf("⚠️  Skipping screenshot %d in data export: %v", ss.ID, err)
+			continue
+		}
+		if err := addFileToArchive(archive, ss.FilePath, fmt.Sprintf("screenshots/%d_%s", ss.ID, ss.FileName)); err != nil {
+			// Missing files are skipped; the metadata is still exported
+			log.Printf("⚠️  Skipping screenshot %d in data export: %v", ss.ID, err)
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		os.Remove(filePath)
+		return "", fmt.Errorf("failed to finalize export archive: %w", err)
+	}
+
+	return filePath, nil
+}
+
+func addFileToArchive(archive *zip.Writer, srcPath, name string) error {
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	w, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, src)
+	return err
+}
+
+func toDataExportResponse(export *models.DataExport) *dto.DataExportResponse {
+	resp := &dto.DataExportResponse{
+		ID:          export.ID,
+		OperationID: export.OperationID,
+		Status:      export.Status,
+		FileName:    export.FileName,
+		FileSize:    export.FileSize,
+		Error:       export.Error,
+		CreatedAt:   export.CreatedAt,
+		CompletedAt: export.CompletedAt,
+		ExpiresAt:   export.ExpiresAt,
+	}
+	if export.Status == models.DataExportStatusCompleted {
+		resp.DownloadURL = fmt.Sprintf("/api/v1/users/me/exports/%d/download", export.ID)
+	}
+	return resp
+}
+
+// ============================================================================
+// ACCOUNT ERASURE
+// ============================================================================
+
+// RequestErasure schedules the account for anonymization after the grace period
+func (s *privacyService) RequestErasure(userID uint, req *dto.AccountDeletionRequest) (*dto.AccountDeletionResponse, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := utils.CheckPassword(req.Password, user.PasswordHash); err != nil {
+		return nil, apperror.Validation("invalid password", nil)
+	}
+
+	if user.DeletionScheduledAt == nil {
+		now := time.Now()
+		scheduledAt := now.Add(s.gracePeriod)
+		user.DeletionRequestedAt = &now
+		user.DeletionScheduledAt = &scheduledAt
+
+		if err := s.userRepo.Update(user); err != nil {
+			return nil, errors.New("failed to schedule account deletion")
+		}
+	}
+
+	return &dto.AccountDeletionResponse{
+		DeletionRequestedAt: user.DeletionRequestedAt,
+		DeletionScheduledAt: user.DeletionScheduledAt,
+	}, nil
+}
+
+// CancelErasure withdraws a pending erasure during the grace period
+func (s *privacyService) CancelErasure(userID uint) error {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if user.DeletionScheduledAt == nil {
+		return apperror.NotFound("no account deletion is scheduled")
+	}
+
+	user.DeletionRequestedAt = nil
+	user.DeletionScheduledAt = nil
+	return s.userRepo.Update(user)
+}
+
+// ProcessDueErasures anonymizes accounts whose erasure grace period has ended
+func (s *privacyService) ProcessDueErasures(ctx context.Context) error {
+	users, err := s.privacyRepo.FindUsersDueForErasure(time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to load accounts due for erasure: %w", err)
+	}
+
+	for _, user := range users {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		// Unusable random password; the account can no longer log in
+		passwordHash, err := utils.HashPassword(utils.GenerateRandomString(32))
+		if err != nil {
+			log.Printf("⚠️  Failed to erase user %d: %v", user.ID, err)
+			continue
+		}
+
+		anonymizedEmail := fmt.Sprintf("deleted-user-%d@erased.invalid", user.ID)
+		filePaths, err := s.privacyRepo.AnonymizeUser(user.ID, anonymizedEmail, passwordHash)
+		if err != nil {
+			log.Printf("⚠️  Failed to erase user %d: %v", user.ID, err)
+			continue
+		}
+
+		for _, path := range filePaths {
+			if err := utils.DeleteFile(path); err != nil {
+				log.Printf("⚠️  Failed to purge file for erased user %d: %v", user.ID, err)
+			}
+		}
+		s.tierService.DeleteColdCopies(ctx, filePaths)
+
+		log.Printf("✅ Erased personal data of user %d (%d files purged)", user.ID, len(filePaths))
+	}
+
+	return nil
+}
+
+// PurgeExpiredExports deletes export archives past their retention
+func (s *privacyService) PurgeExpiredExports(ctx context.Context) error {
+	exports, err := s.privacyRepo.FindExpiredExports(time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to load expired data exports: %w", err)
+	}
+
+	for _, export := range exports {
+		if export.FilePath != "" {
+			if err := utils.DeleteFile(export.FilePath); err != nil {
+				log.Printf("⚠️  Failed to delete data export file %d: %v", export.ID, err)
+				continue
+			}
+		}
+		_ = s.privacyRepo.DeleteExport(export.ID)
+	}
+
+	return nil
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"strings"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+)
+
+var (
+	// ErrPrivateIntervalNotFound is returned for unknown intervals and those of other users
+	ErrPrivateIntervalNotFound = apperror.NotFound("private interval not found")
+	// ErrPrivateModeActive is returned when turning on private mode while it is on
+	ErrPrivateModeActive = apperror.Conflict("private mode is already on")
+	// ErrPrivateIntervalOverlap is returned when a recorded interval overlaps another
+	ErrPrivateIntervalOverlap = apperror.Conflict("interval overlaps another private interval")
+)
+
+// PrivateIntervalService manages privacy mode: intervals in which users
+// pause screenshot capture for a stated reason
+type PrivateIntervalService interface {
+	List(userID uint, params *dto.ReportSummaryParams) (*dto.PrivateIntervalListResponse, error)
+	Start(userID uint, req *dto.StartPrivateIntervalRequest) (*dto.PrivateIntervalResponse, error)
+	End(userID, intervalID uint) (*dto.PrivateIntervalResponse, error)
+	// Overlapping lists the user's intervals overlapping [start, end], for
+	// discarding screenshots captured within them
+	Overlapping(userID uint, start, end time.Time) ([]models.PrivateInterval, error)
+}
+
+type privateIntervalService struct {
+	intervalRepo repository.PrivateIntervalRepository
+	timeLogRepo  repository.TimeLogRepository
+}
+
+// NewPrivateIntervalService creates a new private interval service
+func NewPrivateIntervalService(intervalRepo repository.PrivateIntervalRepository, timeLogRepo repository.TimeLogRepository) PrivateIntervalService {
+	return &privateIntervalService{
+		intervalRepo: intervalRepo,
+		timeLogRepo:  timeLogRepo,
+	}
+}
+
+func (s *privateIntervalService) List(userID uint, params *dto.ReportSummaryParams) (*dto.PrivateIntervalListResponse, error) {
+	start, end := reportRange(params)
+	intervals, err := s.intervalRepo.FindByUser(userID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	response := &dto.PrivateIntervalListResponse{
+		StartDate: params.StartDate.Format("2006-01-02"),
+		EndDate:   params.EndDate.Format("2006-01-02"),
+		Intervals: make([]dto.PrivateIntervalResponse, 0, len(intervals)),
+	}
+	for i := range intervals {
+		interval := toPrivateIntervalResponse(&intervals[i], now)
+		response.TotalDuration += interval.Duration
+		response.Intervals = append(response.Intervals, interval)
+	}
+	return response, nil
+}
+
+func (s *privateIntervalService) Start(userID uint, req *dto.StartPrivateIntervalRequest) (*dto.PrivateIntervalResponse, error) {
+	now := time.Now().UTC()
+	interval := &models.PrivateInterval{
+		UserID:    userID,
+		Reason:    req.Reason,
+		Note:      strings.TrimSpace(req.Note),
+		StartTime: now,
+	}
+	if req.StartTime != nil {
+		interval.StartTime = req.StartTime.UTC()
+	}
+	if interval.StartTime.After(now) {
+		return nil, apperror.Validation("start_time cannot be in the future", nil)
+	}
+
+	if req.EndTime != nil {
+		// A finished interval, e.g. one the desktop app recorded offline
+		endTime := req.EndTime.UTC()
+		if !endTime.After(interval.StartTime) || endTime.After(now) {
+			return nil, apperror.Validation("end_time must be after start_time and not in the future", nil)
+		}
+		overlapping, err := s.intervalRepo.FindOverlapping(userID, interval.StartTime, endTime)
+		if err != nil {
+			return nil, err
+		}
+		if len(overlapping) > 0 {
+			return nil, ErrPrivateIntervalOverlap
+		}
+		interval.EndTime = &endTime
+		interval.Duration = int64(endTime.Sub(interval.StartTime).Seconds())
+	} else {
+		active, err := s.intervalRepo.FindActive(userID)
+		if err != nil {
+			return nil, err
+		}
+		if active != nil {
+			return nil, ErrPrivateModeActive
+		}
+	}
+
+	if err := s.labelTimeLog(interval, req.TimeLogID); err != nil {
+		return nil, err
+	}
+
+	if err := s.intervalRepo.Create(interval); err != nil {
+		return nil, err
+	}
+
+	response := toPrivateIntervalResponse(interval, now)
+	return &response, nil
+}
+
+// labelTimeLog links the interval to the given time log, or to the user's
+// running one, and files it under the time log's workspace
+func (s *privateIntervalService) labelTimeLog(interval *models.PrivateInterval, timeLogID *uint) error {
+	var timeLog *models.TimeLog
+	if timeLogID != nil {
+		found, err := s.timeLogRepo.FindByID(*timeLogID)
+		if err != nil || found.UserID != interval.UserID {
+			return apperror.NotFound("time log not found")
+		}
+		timeLog = found
+	} else if interval.EndTime == nil {
+		active, err := s.timeLogRepo.FindActiveByUserID(interval.UserID)
+		if err != nil {
+			return err
+		}
+		timeLog = active
+	}
+	if timeLog == nil {
+		return nil
+	}
+
+	interval.TimeLogID = &timeLog.ID
+	interval.OrganizationID = timeLog.OrganizationID
+	interval.WorkspaceID = timeLog.WorkspaceID
+	return nil
+}
+
+func (s *privateIntervalService) End(userID, intervalID uint) (*dto.PrivateIntervalResponse, error) {
+	interval, err := s.intervalRepo.FindByID(intervalID)
+	if err != nil || interval.UserID != userID {
+		return nil, ErrPrivateIntervalNotFound
+	}
+
+	now := time.Now().UTC()
+	if interval.EndTime == nil {
+		interval.EndTime = &now
+		interval.Duration = int64(now.Sub(interval.StartTime).Seconds())
+		if err := s.intervalRepo.Update(interval); err != nil {
+			return nil, err
+		}
+	}
+
+	response := toPrivateIntervalResponse(interval, now)
+	return &response, nil
+}
+
+func (s *privateIntervalService) Overlapping(userID uint, start, end time.Time) ([]models.PrivateInterval, error) {
+	return s.intervalRepo.FindOverlapping(userID, start, end)
+}
+
+// privateIntervalAt returns the interval covering t, if any
+func privateIntervalAt(intervals []models.PrivateInterval, t time.Time) *models.PrivateInterval {
+	for i := range intervals {
+		interval := &intervals[i]
+		if t.Before(interval.StartTime) {
+			continue
+		}
+		if interval.EndTime == nil || !t.After(*interval.EndTime) {
+			return interval
+		}
+	}
+	return nil
+}
+
+func toPrivateIntervalResponse(interval *models.PrivateInterval, now time.Time) dto.PrivateIntervalResponse {
+	response := dto.PrivateIntervalResponse{
+		ID:          interval.ID,
+		TimeLogID:   interval.TimeLogID,
+		WorkspaceID: interval.WorkspaceID,
+		Reason:      interval.Reason,
+		Note:        interval.Note,
+		StartTime:   interval.StartTime,
+		EndTime:     interval.EndTime,
+		Duration:    interval.Duration,
+		Active:      interval.EndTime == nil,
+	}
+	if response.Active {
+		response.Duration = int64(now.Sub(interval.StartTime).Seconds())
+	}
+	return response
+}
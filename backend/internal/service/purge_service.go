@@ -0,0 +1,307 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+)
+
+// purgeBatchSize bounds how many rows are purged per query
+const purgeBatchSize = 500
+
+// purgeTable is a soft-deletable table
+type purgeTable struct {
+	name  string
+	model interface{}
+}
+
+// purgeTables lists soft-deletable tables, children before their parents so
+// foreign keys to purged rows are gone first. Screenshots come first and are
+// handled separately because their files are deleted too.
+var purgeTables = []purgeTable{
+	{"time_logs", &models.TimeLog{}},
+	{"task_comments", &models.TaskComment{}},
+	{"tasks", &models.Task{}},
+	{"sync_logs", &models.SyncLog{}},
+	{"device_infos", &models.DeviceInfo{}},
+	{"invitations", &models.Invitation{}},
+	{"organization_webhooks", &models.OrganizationWebhook{}},
+	{"workspace_members", &models.WorkspaceMember{}},
+	{"workspace_roles", &models.WorkspaceRole{}},
+	{"workspaces", &models.Workspace{}},
+	{"organization_members", &models.OrganizationMember{}},
+	{"organizations", &models.Organization{}},
+	{"users", &models.User{}},
+}
+
+// PurgeService permanently removes data that was soft-deleted long ago
+type PurgeService interface {
+	Purge(ctx context.Context, olderThanDays int, dryRun bool) (*dto.AdminPurgeResponse, error)
+	// StartPurge runs Purge in the background, tracked as an operation
+	StartPurge(olderThanDays int, dryRun bool, actorID uint) (*dto.OperationResponse, error)
+	// PurgeExpired purges with the configured age; it matches scheduler.JobFunc
+	PurgeExpired(ctx context.Context) error
+}
+
+type purgeService struct {
+	purgeRepo     repository.PurgeRepository
+	tierService   ScreenshotTierService
+	operations    OperationService
+	olderThanDays int
+}
+
+// NewPurgeService creates a new purge service. olderThanDays is the age used
+// by the scheduled job and when a request does not give one.
+func NewPurgeService(purgeRepo repository.PurgeRepository, tierService ScreenshotTierService, operations OperationService, olderThanDays int) PurgeService {
+	return &purgeService{
+		purgeRepo:     purgeRepo,
+		tierService:   tierService,
+		operations:    operations,
+		olderThanDays: olderThanDays,
+	}
+}
+
+func (s *purgeService) PurgeExpired(ctx context.Context) error {
+	if s.olderThanDays <= 0 {
+		return nil
+	}
+
+	result, err := s.Purge(ctx, s.olderThanDays, false)
+	if result != nil && result.TotalRows > 0 {
+		log.Printf("✅ Purged %d soft-deleted rows and %d files (%d bytes)", result.TotalRows, result.FilesDeleted, result.BytesFreed)
+	}
+	return err
+}
+
+// Purge hard-deletes rows soft-deleted more than olderThanDays ago, with the
+// files of purged screenshots. A dry run only counts them. Rows that live data
+// still references are skipped and retried on the next run.
+func (s *purgeService) Purge(ctx context.Context, olderThanDays int, dryRun bool) (*dto.AdminPurgeResponse, error) {
+	return s.purge(ctx, olderThanDays, dryRun, nil)
+}
+
+func (s *purgeService) StartPurge(olderThanDays int, dryRun bool, actorID uint) (*dto.OperationResponse, error) {
+	olderThanDays, err := s.resolveAge(olderThanDays)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := s.operations.Start(models.OperationTypePurge, actorID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		s.operations.SetProgress(op, 0)
+		result, err := s.purge(context.Background(), olderThanDays, dryRun, func(done, total int) {
+			s.operations.SetProgress(op, done*100/total)
+		})
+		if err != nil {
+			log.Printf("⚠️  Purge operation %d failed: %v", op.ID, err)
+			s.operations.Fail(op, err)
+			return
+		}
+		s.operations.Complete(op, "", result)
+	}()
+
+	return s.operations.GetOperation(op.ID, actorID)
+}
+
+// resolveAge applies the configured default to a purge age
+func (s *purgeService) resolveAge(olderThanDays int) (int, error) {
+	if olderThanDays <= 0 {
+		olderThanDays = s.olderThanDays
+	}
+	if olderThanDays <= 0 {
+		return 0, apperror.Validation("purge age must be at least one day", nil)
+	}
+	return olderThanDays, nil
+}
+
+// purge runs a purge, calling onTable (when set) after each table is done
+func (s *purgeService) purge(ctx context.Context, olderThanDays int, dryRun bool, onTable func(done, total int)) (*dto.AdminPurgeResponse, error) {
+	olderThanDays, err := s.resolveAge(olderThanDays)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.AdminPurgeResponse{
+		DryRun:        dryRun,
+		OlderThanDays: olderThanDays,
+		Cutoff:        time.Now().UTC().AddDate(0, 0, -olderThanDays),
+		Tables:        []dto.AdminPurgeTableResult{},
+	}
+
+	if dryRun {
+		return result, s.countPurgeable(result)
+	}
+
+	// Screenshots plus every table in purgeTables
+	totalTables := len(purgeTables) + 1
+	tableDone := func(done int) {
+		if onTable != nil {
+			onTable(done, totalTables)
+		}
+	}
+
+	screenshots, err := s.purgeScreenshots(ctx, result)
+	result.Tables = append(result.Tables, screenshots)
+	result.TotalRows += screenshots.Rows
+	if err != nil {
+		return result, err
+	}
+	tableDone(1)
+
+	for i, table := range purgeTables {
+		tableResult, err := s.purgeTable(ctx, table, result.Cutoff)
+		result.Tables = append(result.Tables, tableResult)
+		result.TotalRows += tableResult.Rows
+		if err != nil {
+			return result, err
+		}
+		tableDone(i + 2)
+	}
+	return result, nil
+}
+
+func (s *purgeService) countPurgeable(result *dto.AdminPurgeResponse) error {
+	count, err := s.purgeRepo.CountSoftDeleted(&models.Screenshot{}, result.Cutoff)
+	if err != nil {
+		return err
+	}
+	bytes, err := s.purgeRepo.SumSoftDeletedScreenshotBytes(result.Cutoff)
+	if err != nil {
+		return err
+	}
+	result.Tables = append(result.Tables, dto.AdminPurgeTableResult{Table: "screenshots", Rows: count})
+	result.TotalRows += count
+	result.FilesDeleted = count
+	result.BytesFreed = bytes
+
+	for _, table := range purgeTables {
+		count, err := s.purgeRepo.CountSoftDeleted(table.model, result.Cutoff)
+		if err != nil {
+			return err
+		}
+		result.Tables = append(result.Tables, dto.AdminPurgeTableResult{Table: table.name, Rows: count})
+		result.TotalRows += count
+	}
+	return nil
+}
+
+// purgeScreenshots deletes files before rows, so a failed file delete keeps
+// its row for the next run
+func (s *purgeService) purgeScreenshots(ctx context.Context, result *dto.AdminPurgeResponse) (dto.AdminPurgeTableResult, error) {
+	tableResult := dto.AdminPurgeTableResult{Table: "screenshots"}
+	var afterID uint
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return tableResult, err
+		}
+
+		batch, err := s.purgeRepo.FindSoftDeletedScreenshots(result.Cutoff, afterID, purgeBatchSize)
+		if err != nil {
+			return tableResult, err
+		}
+		if len(batch) == 0 {
+			return tableResult, nil
+		}
+		afterID = batch[len(batch)-1].ID
+
+		shared, err := s.purgeRepo.FindSharedFilePaths(screenshotIDs(batch))
+		if err != nil {
+			return tableResult, err
+		}
+
+		ids := make([]uint, 0, len(batch))
+		var coldPaths []string
+		var files, bytes int64
+		for _, ss := range batch {
+			// The file stays with the identical live screenshots sharing it
+			if shared[ss.FilePath] {
+				ids = append(ids, ss.ID)
+				continue
+			}
+			if ss.FilePath != "" {
+				if err := utils.DeleteFile(ss.FilePath); err != nil {
+					log.Printf("⚠️  Purge: %v", err)
+					tableResult.Skipped++
+					continue
+				}
+			}
+			if ss.BlurredPath != "" {
+				if err := utils.DeleteFile(ss.BlurredPath); err != nil {
+					log.Printf("⚠️  Purge: %v", err)
+				}
+			}
+			ids = append(ids, ss.ID)
+			files++
+			bytes += ss.FileSize
+			if ss.ColdKey != "" {
+				coldPaths = append(coldPaths, ss.FilePath)
+			}
+		}
+		s.tierService.DeleteColdCopies(ctx, coldPaths)
+
+		deleted, err := s.purgeRepo.HardDelete(&models.Screenshot{}, ids)
+		if err != nil {
+			return tableResult, err
+		}
+		tableResult.Rows += deleted
+		result.FilesDeleted += files
+		result.BytesFreed += bytes
+
+		if len(batch) < purgeBatchSize {
+			return tableResult, nil
+		}
+	}
+}
+
+// purgeTable deletes a table's rows in batches. When a batch violates a
+// foreign key it falls back to row by row, skipping the referenced rows.
+func (s *purgeService) purgeTable(ctx context.Context, table purgeTable, cutoff time.Time) (dto.AdminPurgeTableResult, error) {
+	tableResult := dto.AdminPurgeTableResult{Table: table.name}
+	var afterID uint
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return tableResult, err
+		}
+
+		ids, err := s.purgeRepo.FindSoftDeletedIDs(table.model, cutoff, afterID, purgeBatchSize)
+		if err != nil {
+			return tableResult, err
+		}
+		if len(ids) == 0 {
+			return tableResult, nil
+		}
+		afterID = ids[len(ids)-1]
+
+		deleted, err := s.purgeRepo.HardDelete(table.model, ids)
+		if err != nil {
+			for _, id := range ids {
+				n, err := s.purgeRepo.HardDelete(table.model, []uint{id})
+				if err != nil {
+					tableResult.Skipped++
+					continue
+				}
+				deleted += n
+			}
+		}
+		tableResult.Rows += deleted
+
+		if len(ids) < purgeBatchSize {
+			if tableResult.Skipped > 0 {
+				log.Printf("⚠️  Purge: skipped %d %s rows still referenced by live data", tableResult.Skipped, table.name)
+			}
+			return tableResult, nil
+		}
+	}
+}
@@ -0,0 +1,729 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/calendar"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/format"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/money"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+)
+
+// reportTopLimit is how many tasks and members report summaries list
+const reportTopLimit = 10
+
+// ReportScopeWorkspace is the custom report scope of members who can view a
+// workspace's reports, next to the analytics organization and self scopes
+const ReportScopeWorkspace = "workspace"
+
+// ReportService builds tracked time dashboards for workspace members who can
+// view reports and for each user's own time, without system admin rights
+type ReportService interface {
+	GetWorkspaceSummary(workspaceID, userID uint, params *dto.ReportSummaryParams, locale format.Locale) (*dto.ReportSummary, error)
+	GetMySummary(userID uint, params *dto.ReportSummaryParams, locale format.Locale) (*dto.ReportSummary, error)
+	// GetSharedWorkspaceSummary builds the workspace summary shown through a
+	// share link, which the caller has checked, without the top members
+	GetSharedWorkspaceSummary(workspaceID uint, params *dto.ReportSummaryParams, locale format.Locale) (*dto.ReportSummary, error)
+	// GetOrganizationStats builds the organization dashboard for its owners and admins
+	GetOrganizationStats(orgID, userID uint, locale format.Locale) (*dto.OrganizationStats, error)
+
+	// Custom reports group an organization's time logs by the requested
+	// dimensions and aggregate the requested measures
+	CustomReportOptions() dto.CustomReportOptions
+	RunCustomReport(orgID, userID uint, req *dto.CustomReportRequest) (*dto.CustomReportResponse, error)
+}
+
+type reportService struct {
+	reportRepo       repository.ReportRepository
+	analyticsRepo    repository.AnalyticsRepository
+	workspaceRepo    *repository.WorkspaceRepository
+	orgRepo          *repository.OrganizationRepository
+	userRepo         repository.UserRepository
+	workspaceService WorkspaceService
+	rates            money.RateProvider
+}
+
+// NewReportService creates a new report service
+func NewReportService(
+	reportRepo repository.ReportRepository,
+	analyticsRepo repository.AnalyticsRepository,
+	workspaceRepo *repository.WorkspaceRepository,
+	orgRepo *repository.OrganizationRepository,
+	userRepo repository.UserRepository,
+	workspaceService WorkspaceService,
+	rates money.RateProvider,
+) ReportService {
+	return &reportService{
+		reportRepo:       reportRepo,
+		analyticsRepo:    analyticsRepo,
+		workspaceRepo:    workspaceRepo,
+		orgRepo:          orgRepo,
+		userRepo:         userRepo,
+		workspaceService: workspaceService,
+		rates:            rates,
+	}
+}
+
+func (s *reportService) GetWorkspaceSummary(workspaceID, userID uint, params *dto.ReportSummaryParams, locale format.Locale) (*dto.ReportSummary, error) {
+	canView, err := s.workspaceService.HasPermission(workspaceID, userID, models.PermReportsView)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, apperror.Forbidden("access denied: you cannot view reports of this workspace")
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	org, err := s.orgRepo.GetByID(workspace.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	params.Location = reportLocation(params.Location, org.Timezone)
+	scope := repository.ReportScope{WorkspaceID: &workspace.ID, Timezone: params.Location.String()}
+	summary, err := s.summarize(scope, org.Calendar(), params, locale)
+	if err != nil {
+		return nil, err
+	}
+	summary.WorkspaceID = &workspace.ID
+
+	start, end := reportRange(params)
+	summary.TopMembers, err = s.reportRepo.TopMembers(scope, start, end, reportTopLimit)
+	if err != nil {
+		return nil, err
+	}
+	private, err := s.addPrivateTime(summary, scope, start, end, locale)
+	if err != nil {
+		return nil, err
+	}
+	for i := range summary.TopMembers {
+		summary.TopMembers[i].DurationHuman = format.Duration(summary.TopMembers[i].Duration, locale)
+		summary.TopMembers[i].PrivateDuration = private[summary.TopMembers[i].UserID]
+	}
+
+	return summary, nil
+}
+
+func (s *reportService) GetSharedWorkspaceSummary(workspaceID uint, params *dto.ReportSummaryParams, locale format.Locale) (*dto.ReportSummary, error) {
+	workspace, err := s.workspaceRepo.GetByID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	org, err := s.orgRepo.GetByID(workspace.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	params.Location = reportLocation(params.Location, org.Timezone)
+	scope := repository.ReportScope{WorkspaceID: &workspace.ID, Timezone: params.Location.String()}
+	summary, err := s.summarize(scope, org.Calendar(), params, locale)
+	if err != nil {
+		return nil, err
+	}
+	summary.WorkspaceID = &workspace.ID
+	return summary, nil
+}
+
+func (s *reportService) GetMySummary(userID uint, params *dto.ReportSummaryParams, locale format.Locale) (*dto.ReportSummary, error) {
+	cal := calendar.Default()
+	if params.WorkspaceID != nil {
+		workspace, err := s.workspaceRepo.GetByID(*params.WorkspaceID)
+		if err != nil {
+			return nil, apperror.NotFound("workspace not found")
+		}
+		if org, err := s.orgRepo.GetByID(workspace.OrganizationID); err == nil {
+			cal = org.Calendar()
+		}
+	}
+
+	if params.Location == nil {
+		user, err := s.userRepo.FindByID(userID)
+		if err != nil {
+			return nil, err
+		}
+		params.Location = reportLocation(nil, user.Timezone)
+	}
+	scope := repository.ReportScope{WorkspaceID: params.WorkspaceID, UserID: &userID, Timezone: params.Location.String()}
+	summary, err := s.summarize(scope, cal, params, locale)
+	if err != nil {
+		return nil, err
+	}
+	start, end := reportRange(params)
+	if _, err := s.addPrivateTime(summary, scope, start, end, locale); err != nil {
+		return nil, err
+	}
+	summary.WorkspaceID = params.WorkspaceID
+	summary.UserID = &userID
+	return summary, nil
+}
+
+func (s *reportService) GetOrganizationStats(orgID, userID uint, locale format.Locale) (*dto.OrganizationStats, error) {
+	isAdmin, err := s.orgRepo.IsAdmin(orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isAdmin {
+		return nil, apperror.Forbidden("access denied: only organization owners and admins can view organization stats")
+	}
+	org, err := s.orgRepo.GetByID(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	cal := org.Calendar()
+	dayStart, dayEnd := cal.PeriodRange(calendar.PeriodDay, now)
+	weekStart, weekEnd := cal.PeriodRange(calendar.PeriodWeek, now)
+
+	counts, err := s.reportRepo.OrganizationCounts(orgID, dayStart, dayEnd)
+	if err != nil {
+		return nil, err
+	}
+	weekDuration, err := s.reportRepo.OrganizationDuration(orgID, weekStart, weekEnd)
+	if err != nil {
+		return nil, err
+	}
+	topWorkspaces, err := s.reportRepo.TopOrganizationWorkspaces(orgID, weekStart, weekEnd, reportTopLimit)
+	if err != nil {
+		return nil, err
+	}
+	for i := range topWorkspaces {
+		topWorkspaces[i].DurationHuman = format.Duration(topWorkspaces[i].Duration, locale)
+	}
+
+	return &dto.OrganizationStats{
+		OrganizationID:    orgID,
+		Members:           counts.Members,
+		ActiveToday:       counts.ActiveToday,
+		TrackingNow:       counts.TrackingNow,
+		Workspaces:        counts.Workspaces,
+		WeekStart:         weekStart.Format("2006-01-02"),
+		WeekEnd:           weekEnd.AddDate(0, 0, -1).Format("2006-01-02"),
+		WeekDuration:      weekDuration,
+		WeekDurationHuman: format.Duration(weekDuration, locale),
+		TopWorkspaces:     topWorkspaces,
+		Screenshots:       counts.Screenshots,
+		StorageUsed:       counts.StorageUsed,
+		StorageUsedHuman:  format.Bytes(counts.StorageUsed, locale),
+	}, nil
+}
+
+// summarize builds the parts shared by workspace and personal summaries.
+// Days are in the scope's timezone; weeks and months are grouped by the
+// calendar.
+func (s *reportService) summarize(scope repository.ReportScope, cal calendar.Calendar, params *dto.ReportSummaryParams, locale format.Locale) (*dto.ReportSummary, error) {
+	start, end := reportRange(params)
+
+	daily, err := s.reportRepo.DailyDurations(scope, start, end)
+	if err != nil {
+		return nil, err
+	}
+	activeUsers, err := s.reportRepo.CountActiveUsers(scope, start, end)
+	if err != nil {
+		return nil, err
+	}
+	topTasks, err := s.reportRepo.TopTasks(scope, start, end, reportTopLimit)
+	if err != nil {
+		return nil, err
+	}
+	activity, err := s.reportRepo.Activity(scope, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	// Week and month labels follow the calendar's week start, but the days,
+	// which are already in the report's timezone
+	cal.Location = time.UTC
+
+	summary := &dto.ReportSummary{
+		StartDate:   params.StartDate.Format("2006-01-02"),
+		EndDate:     params.EndDate.Format("2006-01-02"),
+		ActiveDays:  len(daily),
+		ActiveUsers: activeUsers,
+		Daily:       daily,
+		Weekly:      groupReportDurations(daily, calendar.PeriodWeek, cal),
+		Monthly:     groupReportDurations(daily, calendar.PeriodMonth, cal),
+		TopTasks:    topTasks,
+		Activity:    reportActivity(activity),
+	}
+	for _, day := range daily {
+		summary.TotalDuration += day.Duration
+		summary.TimeLogs += day.TimeLogs
+	}
+	summary.TotalDurationHuman = format.Duration(summary.TotalDuration, locale)
+
+	for _, stats := range [][]dto.ReportDurationStat{summary.Daily, summary.Weekly, summary.Monthly} {
+		for i := range stats {
+			stats[i].DurationHuman = format.Duration(stats[i].Duration, locale)
+		}
+	}
+	for i := range summary.TopTasks {
+		summary.TopTasks[i].DurationHuman = format.Duration(summary.TopTasks[i].Duration, locale)
+	}
+
+	return summary, nil
+}
+
+// addPrivateTime totals the private mode time of the summary's scope and
+// returns it per user
+func (s *reportService) addPrivateTime(summary *dto.ReportSummary, scope repository.ReportScope, start, end time.Time, locale format.Locale) (map[uint]int64, error) {
+	private, err := s.reportRepo.PrivateDurations(scope, start, end)
+	if err != nil {
+		return nil, err
+	}
+	for _, seconds := range private {
+		summary.PrivateDuration += seconds
+	}
+	if summary.PrivateDuration > 0 {
+		summary.PrivateDurationHuman = format.Duration(summary.PrivateDuration, locale)
+	}
+	return private, nil
+}
+
+// reportRange returns the half-open range of the summary's inclusive dates,
+// from midnight in its timezone
+func reportRange(params *dto.ReportSummaryParams) (time.Time, time.Time) {
+	loc := reportLocation(params.Location)
+	return dateIn(params.StartDate, loc), dateIn(params.EndDate, loc).AddDate(0, 0, 1)
+}
+
+// reportLocation returns the zone a report groups days in: the requested
+// one, else the first valid stored timezone, else UTC
+func reportLocation(requested *time.Location, timezones ...string) *time.Location {
+	if requested != nil {
+		return requested
+	}
+	for _, name := range timezones {
+		if name == "" {
+			continue
+		}
+		if loc, err := time.LoadLocation(name); err == nil {
+			return loc
+		}
+	}
+	return time.UTC
+}
+
+// dateIn returns midnight of the date's day in loc
+func dateIn(date time.Time, loc *time.Location) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+}
+
+// groupReportDurations buckets daily durations (ordered by day) into calendar periods
+func groupReportDurations(daily []dto.ReportDurationStat, period string, cal calendar.Calendar) []dto.ReportDurationStat {
+	grouped := []dto.ReportDurationStat{}
+	index := make(map[string]int)
+	for _, stat := range daily {
+		date, err := parseStatDate(stat.Period)
+		if err != nil {
+			continue
+		}
+
+		label := cal.PeriodLabel(period, date)
+		i, ok := index[label]
+		if !ok {
+			index[label] = len(grouped)
+			grouped = append(grouped, dto.ReportDurationStat{Period: label})
+			i = len(grouped) - 1
+		}
+		grouped[i].Duration += stat.Duration
+		grouped[i].TimeLogs += stat.TimeLogs
+	}
+	return grouped
+}
+
+// reportActivity folds weekday and hour rows into hour of day and weekday totals
+func reportActivity(rows []repository.ReportActivityRow) dto.ReportActivity {
+	activity := dto.ReportActivity{
+		ByHour:    make([]dto.ReportHourStat, 24),
+		ByWeekday: make([]dto.ReportWeekdayStat, 7),
+	}
+	for hour := range activity.ByHour {
+		activity.ByHour[hour].Hour = hour
+	}
+	for weekday := range activity.ByWeekday {
+		activity.ByWeekday[weekday].Weekday = weekday
+	}
+
+	for _, row := range rows {
+		if row.Hour < 0 || row.Hour > 23 || row.Weekday < 0 || row.Weekday > 6 {
+			continue
+		}
+		activity.ByHour[row.Hour].Duration += row.Duration
+		activity.ByHour[row.Hour].TimeLogs += row.TimeLogs
+		activity.ByWeekday[row.Weekday].Duration += row.Duration
+		activity.ByWeekday[row.Weekday].TimeLogs += row.TimeLogs
+	}
+
+	for _, stat := range activity.ByHour {
+		if stat.Duration > activity.ByHour[activity.PeakHour].Duration {
+			activity.PeakHour = stat.Hour
+		}
+	}
+	return activity
+}
+
+// ============================================================================
+// CUSTOM REPORTS
+// ============================================================================
+
+// reportField is a dimension or measure of the report builder. Its SQL is
+// fixed here; requests only pick fields by name.
+type reportField struct {
+	info    dto.CustomReportField
+	selects []string // Select expressions, aliased to info.Columns
+	groupBy string   // Dimensions only; time dimensions group by their alias, as @week_start cannot repeat
+	joins   []string // Joins needed by the expressions, in order
+	isTime  bool     // Day, week or month dimension
+}
+
+const (
+	reportJoinUsers      = "JOIN users u ON u.id = tl.user_id"
+	reportJoinTasks      = "LEFT JOIN tasks t ON t.id = tl.task_id"
+	reportJoinWorkspaces = "LEFT JOIN workspaces w ON w.id = tl.workspace_id"
+	reportJoinClients    = "LEFT JOIN clients c ON c.id = w.client_id AND c.deleted_at IS NULL"
+	// One join per nesting level up to maxTaskDepth
+	reportJoinRootTasks = "LEFT JOIN tasks rt1 ON rt1.id = tl.task_id LEFT JOIN tasks rt2 ON rt2.id = rt1.parent_task_id LEFT JOIN tasks rt3 ON rt3.id = rt2.parent_task_id"
+	// Screenshots purged by retention no longer belong to a time log and are not counted
+	reportJoinScreenshots = `LEFT JOIN (
+		SELECT time_log_id, COUNT(*) AS screenshots FROM screenshots
+		WHERE organization_id = @org_id AND deleted_at IS NULL AND time_log_id IS NOT NULL
+		GROUP BY time_log_id
+	) sc ON sc.time_log_id = tl.id`
+)
+
+const reportMaxDimensions = 3
+
+var reportDimensions = []reportField{
+	{
+		info:    dto.CustomReportField{Name: "user", Description: "Member who tracked the time", Columns: []string{"user_id", "user_name"}},
+		selects: []string{"tl.user_id AS user_id", "TRIM(u.first_name || ' ' || u.last_name) AS user_name"},
+		groupBy: "tl.user_id, u.first_name, u.last_name",
+		joins:   []string{reportJoinUsers},
+	},
+	{
+		info:    dto.CustomReportField{Name: "task", Description: "Task, or the time log title without a task", Columns: []string{"task_id", "task_title"}},
+		selects: []string{"tl.task_id AS task_id", "COALESCE(MAX(t.title), MAX(tl.task_title), '') AS task_title"},
+		groupBy: "tl.task_id, CASE WHEN tl.task_id IS NULL THEN tl.task_title END",
+		joins:   []string{reportJoinTasks},
+	},
+	{
+		info:    dto.CustomReportField{Name: "root_task", Description: "Top-level task, with subtask time rolled up into it", Columns: []string{"root_task_id", "root_task_title"}},
+		selects: []string{"COALESCE(rt3.id, rt2.id, rt1.id) AS root_task_id", "COALESCE(MAX(COALESCE(rt3.title, rt2.title, rt1.title)), MAX(tl.task_title), '') AS root_task_title"},
+		groupBy: "COALESCE(rt3.id, rt2.id, rt1.id), CASE WHEN tl.task_id IS NULL THEN tl.task_title END",
+		joins:   []string{reportJoinRootTasks},
+	},
+	{
+		info:    dto.CustomReportField{Name: "workspace", Description: "Workspace the time was tracked in", Columns: []string{"workspace_id", "workspace_name"}},
+		selects: []string{"tl.workspace_id AS workspace_id", "COALESCE(MAX(w.name), '') AS workspace_name"},
+		groupBy: "tl.workspace_id",
+		joins:   []string{reportJoinWorkspaces},
+	},
+	{
+		info:    dto.CustomReportField{Name: "client", Description: "Client of the workspace, aggregated across the client's workspaces; empty for workspaces without a client", Columns: []string{"client_id", "client_name"}},
+		selects: []string{"c.id AS client_id", "COALESCE(MAX(c.name), '') AS client_name"},
+		groupBy: "c.id",
+		joins:   []string{reportJoinWorkspaces, reportJoinClients},
+	},
+	{
+		info:    dto.CustomReportField{Name: "day", Description: "Day the time log started, in the report's timezone", Columns: []string{"day"}},
+		selects: []string{"TO_CHAR((tl.start_time AT TIME ZONE @tz)::date, 'YYYY-MM-DD') AS day"},
+		groupBy: "day",
+		isTime:  true,
+	},
+	{
+		info:    dto.CustomReportField{Name: "week", Description: "First day of the week the time log started, following the organization calendar", Columns: []string{"week"}},
+		selects: []string{"TO_CHAR((tl.start_time AT TIME ZONE @tz)::date - (EXTRACT(DOW FROM tl.start_time AT TIME ZONE @tz)::int - @week_start + 7) % 7, 'YYYY-MM-DD') AS week"},
+		groupBy: "week",
+		isTime:  true,
+	},
+	{
+		info:    dto.CustomReportField{Name: "month", Description: "Calendar month the time log started, in the report's timezone", Columns: []string{"month"}},
+		selects: []string{"TO_CHAR(DATE_TRUNC('month', tl.start_time AT TIME ZONE @tz), 'YYYY-MM') AS month"},
+		groupBy: "month",
+		isTime:  true,
+	},
+}
+
+var reportMeasures = []reportField{
+	{
+		info: dto.CustomReportField{Name: "duration", Description: "Tracked time", Columns: []string{"total_seconds", "total_hours"}},
+		selects: []string{
+			"COALESCE(SUM(tl.duration), 0) AS total_seconds",
+			"ROUND(COALESCE(SUM(tl.duration), 0) / 3600.0, 2) AS total_hours",
+		},
+	},
+	{
+		info:    dto.CustomReportField{Name: "billable_amount", Description: "Tracked time in billable workspaces at the workspace hourly rate, converted to the organization currency", Columns: []string{"billable_amount"}},
+		selects: []string{"ROUND(COALESCE(SUM(CASE WHEN w.is_billable THEN tl.duration * w.hourly_rate_minor * (@fx_factors::jsonb ->> w.currency)::numeric / 3600.0 END), 0), @currency_exponent) AS billable_amount"},
+		joins:   []string{reportJoinWorkspaces},
+	},
+	{
+		info:    dto.CustomReportField{Name: "screenshots", Description: "Screenshots captured during the time logs", Columns: []string{"screenshots"}},
+		selects: []string{"COALESCE(SUM(sc.screenshots), 0) AS screenshots"},
+		joins:   []string{reportJoinScreenshots},
+	},
+}
+
+func findReportField(fields []reportField, name string) *reportField {
+	for i := range fields {
+		if fields[i].info.Name == name {
+			return &fields[i]
+		}
+	}
+	return nil
+}
+
+func (s *reportService) CustomReportOptions() dto.CustomReportOptions {
+	options := dto.CustomReportOptions{
+		Dimensions: make([]dto.CustomReportField, 0, len(reportDimensions)),
+		Measures:   make([]dto.CustomReportField, 0, len(reportMeasures)),
+	}
+	for _, d := range reportDimensions {
+		options.Dimensions = append(options.Dimensions, d.info)
+	}
+	for _, m := range reportMeasures {
+		options.Measures = append(options.Measures, m.info)
+	}
+	return options
+}
+
+func (s *reportService) RunCustomReport(orgID, userID uint, req *dto.CustomReportRequest) (*dto.CustomReportResponse, error) {
+	isMember, err := s.orgRepo.IsMember(orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, ErrAnalyticsAccessDenied
+	}
+
+	dimensions, measures, err := resolveReportFields(req.Dimensions, req.Measures)
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]string{
+		"start_date": req.StartDate,
+		"end_date":   req.EndDate,
+	}
+	if req.WorkspaceID != nil {
+		params["workspace_id"] = strconv.FormatUint(uint64(*req.WorkspaceID), 10)
+	}
+	if req.Limit != 0 {
+		params["limit"] = strconv.Itoa(req.Limit)
+	}
+	args, limit, err := parseAnalyticsParams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	scope, err := s.customReportScope(orgID, userID, req)
+	if err != nil {
+		return nil, err
+	}
+	args["org_id"] = orgID
+	args["scope_user_id"] = uint(0)
+	if scope == AnalyticsScopeSelf {
+		args["scope_user_id"] = userID
+	} else if req.UserID != nil {
+		args["scope_user_id"] = *req.UserID
+	}
+
+	org, err := s.orgRepo.GetByID(orgID)
+	if err != nil {
+		return nil, err
+	}
+	args["week_start"] = int(org.Calendar().WeekStart)
+
+	// Dates start at midnight in the report's timezone
+	var requested *time.Location
+	if req.Timezone != "" {
+		if requested, err = time.LoadLocation(req.Timezone); err != nil {
+			return nil, apperror.Validation("invalid timezone", nil)
+		}
+	}
+	loc := reportLocation(requested, org.Timezone)
+	args["tz"] = loc.String()
+	args["start_date"] = dateIn(args["start_date"].(time.Time), loc)
+	args["end_date"] = dateIn(args["end_date"].(time.Time), loc)
+	if slices.Contains(req.Measures, "billable_amount") {
+		factors, err := s.currencyFactors(orgID, org.Currency)
+		if err != nil {
+			return nil, err
+		}
+		args["fx_factors"] = factors
+		args["currency_exponent"] = money.Exponent(org.Currency)
+	}
+
+	columns, rows, truncated, err := s.analyticsRepo.Query(buildCustomReportSQL(dimensions, measures), args, limit, analyticsTimeout)
+	if err != nil {
+		return nil, errors.New("failed to run custom report")
+	}
+
+	return &dto.CustomReportResponse{
+		Dimensions: req.Dimensions,
+		Measures:   req.Measures,
+		Scope:      scope,
+		Currency:   org.Currency,
+		Timezone:   loc.String(),
+		StartDate:  args["start_date"].(time.Time).Format("2006-01-02"),
+		EndDate:    args["end_date"].(time.Time).AddDate(0, 0, -1).Format("2006-01-02"),
+		Columns:    columns,
+		Rows:       rows,
+		RowCount:   len(rows),
+		Truncated:  truncated,
+	}, nil
+}
+
+// currencyFactors maps each workspace currency of the organization to the
+// factor converting its minor units to major units of the organization
+// currency, as JSON for the billable_amount measure
+func (s *reportService) currencyFactors(orgID uint, currency string) (string, error) {
+	currencies, err := s.workspaceRepo.GetCurrencies(orgID)
+	if err != nil {
+		return "", err
+	}
+
+	factors := make(map[string]float64, len(currencies))
+	for _, from := range currencies {
+		rate, err := s.rates.Rate(from, currency)
+		if err != nil {
+			return "", fmt.Errorf("failed to convert billable amounts: %w", err)
+		}
+		factors[from] = rate / math.Pow10(money.Exponent(from))
+	}
+
+	encoded, err := json.Marshal(factors)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// customReportScope decides whose time logs the caller may report on:
+// organization admins see every member, members who can view a workspace's
+// reports see that workspace, everyone else only their own time
+func (s *reportService) customReportScope(orgID, userID uint, req *dto.CustomReportRequest) (string, error) {
+	isAdmin, err := s.orgRepo.IsAdmin(orgID, userID)
+	if err != nil {
+		return "", err
+	}
+	if isAdmin {
+		return AnalyticsScopeOrganization, nil
+	}
+
+	if req.WorkspaceID != nil {
+		workspace, err := s.workspaceRepo.GetByID(*req.WorkspaceID)
+		if err != nil || workspace.OrganizationID != orgID {
+			return "", apperror.NotFound("workspace not found")
+		}
+		canView, err := s.workspaceService.HasPermission(workspace.ID, userID, models.PermReportsView)
+		if err != nil {
+			return "", err
+		}
+		if canView {
+			return ReportScopeWorkspace, nil
+		}
+	}
+
+	if req.UserID != nil && *req.UserID != userID {
+		return "", apperror.Forbidden("access denied: you can only report on your own time")
+	}
+	return AnalyticsScopeSelf, nil
+}
+
+// resolveReportFields looks up the requested fields and rejects unknown or
+// repeated ones, too many dimensions and more than one time dimension
+func resolveReportFields(dimensionNames, measureNames []string) ([]*reportField, []*reportField, error) {
+	if len(dimensionNames) == 0 || len(dimensionNames) > reportMaxDimensions {
+		return nil, nil, apperror.Validation(fmt.Sprintf("choose 1 to %d dimensions", reportMaxDimensions), nil)
+	}
+	if len(measureNames) == 0 {
+		return nil, nil, apperror.Validation("choose at least one measure", nil)
+	}
+
+	seen := make(map[string]bool)
+	var dimensions, measures []*reportField
+	var timeDimension string
+	for _, name := range dimensionNames {
+		field := findReportField(reportDimensions, name)
+		if field == nil {
+			return nil, nil, apperror.Validation(fmt.Sprintf("unknown dimension %q", name), nil)
+		}
+		if seen[name] {
+			return nil, nil, apperror.Validation(fmt.Sprintf("dimension %q is repeated", name), nil)
+		}
+		if field.isTime {
+			if timeDimension != "" {
+				return nil, nil, apperror.Validation(fmt.Sprintf("cannot group by both %s and %s", timeDimension, name), nil)
+			}
+			timeDimension = name
+		}
+		seen[name] = true
+		dimensions = append(dimensions, field)
+	}
+	for _, name := range measureNames {
+		field := findReportField(reportMeasures, name)
+		if field == nil {
+			return nil, nil, apperror.Validation(fmt.Sprintf("unknown measure %q", name), nil)
+		}
+		if seen[name] {
+			return nil, nil, apperror.Validation(fmt.Sprintf("measure %q is repeated", name), nil)
+		}
+		seen[name] = true
+		measures = append(measures, field)
+	}
+	return dimensions, measures, nil
+}
+
+// buildCustomReportSQL assembles the query from the fields' fixed SQL. Rows
+// are ordered by the time dimension, then by the first measure, largest first.
+func buildCustomReportSQL(dimensions, measures []*reportField) string {
+	var selects, groups, joins, order []string
+	joined := make(map[string]bool)
+	addJoin := func(join string) {
+		if join != "" && !joined[join] {
+			joined[join] = true
+			joins = append(joins, join)
+		}
+	}
+
+	for _, d := range dimensions {
+		selects = append(selects, d.selects...)
+		groups = append(groups, d.groupBy)
+		for _, join := range d.joins {
+			addJoin(join)
+		}
+		if d.isTime {
+			order = append(order, d.info.Columns[0])
+		}
+	}
+	for _, m := range measures {
+		selects = append(selects, m.selects...)
+		for _, join := range m.joins {
+			addJoin(join)
+		}
+	}
+	order = append(order, measures[0].info.Columns[0]+" DESC")
+
+	return `SELECT ` + strings.Join(selects, ", ") + `
+		FROM time_logs tl
+		` + strings.Join(joins, "\n\t\t") + `
+		WHERE` + analyticsTimeLogFilter + `
+		GROUP BY ` + strings.Join(groups, ", ") + `
+		ORDER BY ` + strings.Join(order, ", ") + `
+		LIMIT @limit`
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/format"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+)
+
+// retentionBatchSize bounds how many screenshots are purged per query
+const retentionBatchSize = 500
+
+// RetentionCutoff returns the instant before which data older than days is expired
+func RetentionCutoff(days int, now time.Time) time.Time {
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return today.AddDate(0, 0, -days)
+}
+
+// RetentionService enforces per-organization data retention policies
+type RetentionService interface {
+	PreviewScreenshotRetention(orgID uint, days *int, locale format.Locale) (*dto.AdminRetentionPreviewResponse, error)
+	PurgeExpiredScreenshots(ctx context.Context) (int, int64, error)
+}
+
+type retentionService struct {
+	retentionRepo repository.RetentionRepository
+	orgRepo       *repository.OrganizationRepository
+	tierService   ScreenshotTierService
+}
+
+// NewRetentionService creates a new retention service
+func NewRetentionService(retentionRepo repository.RetentionRepository, orgRepo *repository.OrganizationRepository, tierService ScreenshotTierService) RetentionService {
+	return &retentionService{
+		retentionRepo: retentionRepo,
+		orgRepo:       orgRepo,
+		tierService:   tierService,
+	}
+}
+
+// PreviewScreenshotRetention reports how many screenshots and bytes a policy
+// would purge. days overrides the organization's configured retention.
+func (s *retentionService) PreviewScreenshotRetention(orgID uint, days *int, locale format.Locale) (*dto.AdminRetentionPreviewResponse, error) {
+	org, err := s.orgRepo.GetByID(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	retentionDays := org.ScreenshotRetentionDays
+	if days != nil {
+		retentionDays = *days
+	}
+	if retentionDays <= 0 {
+		return nil, apperror.Validation("retention is disabled for this organization; pass days to preview a policy", nil)
+	}
+
+	cutoff := RetentionCutoff(retentionDays, time.Now())
+	usage, err := s.retentionRepo.GetScreenshotUsageBefore(orgID, cutoff)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.AdminRetentionPreviewResponse{
+		OrganizationID:     orgID,
+		RetentionDays:      retentionDays,
+		Cutoff:             cutoff,
+		ScreenshotCount:    usage.Count,
+		ReclaimableBytes:   usage.TotalBytes,
+		ReclaimableHuman:   format.Bytes(usage.TotalBytes, locale),
+		OldestScreenshotAt: usage.Oldest,
+	}, nil
+}
+
+// PurgeExpiredScreenshots deletes files and rows of screenshots older than
+// each organization's retention, in batches. Returns screenshots purged and bytes freed.
+func (s *retentionService) PurgeExpiredScreenshots(ctx context.Context) (int, int64, error) {
+	orgs, err := s.retentionRepo.FindOrganizationsWithScreenshotRetention()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	totalPurged := 0
+	var totalBytes int64
+	now := time.Now()
+
+	for _, org := range orgs {
+		cutoff := RetentionCutoff(org.ScreenshotRetentionDays, now)
+
+		for {
+			if err := ctx.Err(); err != nil {
+				return totalPurged, totalBytes, err
+			}
+
+			batch, err := s.retentionRepo.FindScreenshotsBefore(org.ID, cutoff, retentionBatchSize)
+			if err != nil {
+				return totalPurged, totalBytes, err
+			}
+			if len(batch) == 0 {
+				break
+			}
+
+			shared, err := s.retentionRepo.FindSharedFilePaths(screenshotIDs(batch))
+			if err != nil {
+				return totalPurged, totalBytes, err
+			}
+
+			ids := make([]uint, 0, len(batch))
+			var coldPaths []string
+			for _, ss := range batch {
+				// The file stays with the identical screenshots sharing it
+				// that are not expired yet
+				if shared[ss.FilePath] {
+					ids = append(ids, ss.ID)
+					continue
+				}
+				if err := utils.DeleteFile(ss.FilePath); err != nil {
+					// Keep the row so the file is retried on the next run
+					log.Printf("⚠️  Retention: %v", err)
+					continue
+				}
+				if ss.BlurredPath != "" {
+					if err := utils.DeleteFile(ss.BlurredPath); err != nil {
+						log.Printf("⚠️  Retention: %v", err)
+					}
+				}
+				ids = append(ids, ss.ID)
+				totalBytes += ss.FileSize
+				if ss.ColdKey != "" {
+					coldPaths = append(coldPaths, ss.FilePath)
+				}
+			}
+			s.tierService.DeleteColdCopies(ctx, coldPaths)
+
+			// Aggregates survive in the daily rollups after the rows are gone
+			if err := s.retentionRepo.RollupAndDeleteScreenshots(ids); err != nil {
+				return totalPurged, totalBytes, err
+			}
+			totalPurged += len(ids)
+
+			// Every file in the batch failed; stop rather than loop on the same rows
+			if len(ids) == 0 || len(batch) < retentionBatchSize {
+				break
+			}
+		}
+	}
+
+	return totalPurged, totalBytes, nil
+}
@@ -1,9 +1,9 @@
 package service
 
 import (
-	"errors"
 	"strings"
 
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
 	"github.com/beuphecan/remote-time-tracker/internal/dto"
 	"github.com/beuphecan/remote-time-tracker/internal/models"
 	"github.com/beuphecan/remote-time-tracker/internal/repository"
@@ -51,7 +51,7 @@ func (s *roleService) Create(orgID, userID uint, req *dto.CreateWorkspaceRoleReq
 		return nil, err
 	}
 	if !isAdmin {
-		return nil, errors.New("access denied: only organization admins can create roles")
+		return nil, apperror.Forbidden("access denied: only organization admins can create roles")
 	}
 
 	// Check if role name already exists
@@ -61,7 +61,11 @@ func (s *roleService) Create(orgID, userID uint, req *dto.CreateWorkspaceRoleReq
 		return nil, err
 	}
 	if exists {
-		return nil, errors.New("role name already exists in this organization")
+		return nil, apperror.Conflict("role name already exists in this organization")
+	}
+
+	if err := validateRolePermissions(req.Permissions); err != nil {
+		return nil, err
 	}
 
 	// Get max sort order
@@ -123,7 +127,7 @@ func (s *roleService) Update(roleID, userID uint, req *dto.UpdateWorkspaceRoleRe
 		return nil, err
 	}
 	if !isAdmin {
-		return nil, errors.New("access denied: only organization admins can update roles")
+		return nil, apperror.Forbidden("access denied: only organization admins can update roles")
 	}
 
 	// Update fields
@@ -137,6 +141,9 @@ func (s *roleService) Update(roleID, userID uint, req *dto.UpdateWorkspaceRoleRe
 		role.Color = *req.Color
 	}
 	if req.Permissions != nil {
+		if err := validateRolePermissions(*req.Permissions); err != nil {
+			return nil, err
+		}
 		role.Permissions = *req.Permissions
 	}
 	if req.IsDefault != nil {
@@ -168,7 +175,7 @@ func (s *roleService) Delete(roleID, userID uint) error {
 		return err
 	}
 	if !isOwner {
-		return errors.New("access denied: only organization owner can delete roles")
+		return apperror.Forbidden("access denied: only organization owner can delete roles")
 	}
 
 	return s.workspaceRepo.DeleteRole(roleID)
@@ -185,7 +192,7 @@ func (s *roleService) GetByOrganization(orgID, userID uint) ([]dto.WorkspaceRole
 		return nil, err
 	}
 	if !isMember {
-		return nil, errors.New("access denied: not a member of this organization")
+		return nil, apperror.Forbidden("access denied: not a member of this organization")
 	}
 
 	roles, err := s.workspaceRepo.GetRolesByOrgID(orgID)
@@ -0,0 +1,601 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/mail"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/pdf"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+	"github.com/gosimple/slug"
+)
+
+const (
+	defaultSavedReportRange   = 7 // Days
+	defaultReportScheduleHour = 8
+	savedReportDeliveryBatch  = 50
+)
+
+// Saved report errors
+var (
+	ErrSavedReportNotFound    = apperror.NotFound("saved report not found")
+	ErrReportScheduleNotFound = apperror.NotFound("report schedule not found")
+)
+
+// ReportFile is a rendered saved report, downloaded or emailed
+type ReportFile struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// SavedReportService manages members' saved custom reports and emails them
+// as CSV or PDF on daily or weekly schedules. Reports only ever run with
+// their owner's access, whoever receives them.
+type SavedReportService interface {
+	List(orgID, userID uint) ([]dto.SavedReportResponse, error)
+	Create(orgID, userID uint, req *dto.CreateSavedReportRequest) (*dto.SavedReportResponse, error)
+	Get(orgID, reportID, userID uint) (*dto.SavedReportResponse, error)
+	Update(orgID, reportID, userID uint, req *dto.UpdateSavedReportRequest) (*dto.SavedReportResponse, error)
+	Delete(orgID, reportID, userID uint) error
+
+	// Run and Export cover the report's range up to yesterday (UTC); Export
+	// uses days in loc instead when set
+	Run(orgID, reportID, userID uint) (*dto.CustomReportResponse, error)
+	Export(orgID, reportID, userID uint, loc *time.Location) (*ReportFile, error)
+
+	// Schedules
+	CreateSchedule(orgID, reportID, userID uint, req *dto.CreateReportScheduleRequest) (*dto.ReportScheduleResponse, error)
+	UpdateSchedule(orgID, reportID, scheduleID, userID uint, req *dto.UpdateReportScheduleRequest) (*dto.ReportScheduleResponse, error)
+	DeleteSchedule(orgID, reportID, scheduleID, userID uint) error
+
+	// Scheduled jobs
+	DeliverDue(ctx context.Context) error
+}
+
+type savedReportService struct {
+	savedReportRepo repository.SavedReportRepository
+	orgRepo         *repository.OrganizationRepository
+	userRepo        repository.UserRepository
+	reportService   ReportService
+	emailService    EmailService
+}
+
+// NewSavedReportService creates a new saved report service
+func NewSavedReportService(
+	savedReportRepo repository.SavedReportRepository,
+	orgRepo *repository.OrganizationRepository,
+	userRepo repository.UserRepository,
+	reportService ReportService,
+	emailService EmailService,
+) SavedReportService {
+	return &savedReportService{
+		savedReportRepo: savedReportRepo,
+		orgRepo:         orgRepo,
+		userRepo:        userRepo,
+		reportService:   reportService,
+		emailService:    emailService,
+	}
+}
+
+// ============================================================================
+// SAVED REPORTS
+// ============================================================================
+
+// findOwned finds a report of the user; other members' reports are not found
+func (s *savedReportService) findOwned(orgID, reportID, userID uint) (*models.SavedReport, error) {
+	report, err := s.savedReportRepo.FindByID(orgID, reportID)
+	if err != nil || report.UserID != userID {
+		return nil, ErrSavedReportNotFound
+	}
+	return report, nil
+}
+
+func (s *savedReportService) List(orgID, userID uint) ([]dto.SavedReportResponse, error) {
+	reports, err := s.savedReportRepo.FindByOwner(orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.SavedReportResponse, 0, len(reports))
+	for i := range reports {
+		result = append(result, toSavedReportResponse(&reports[i]))
+	}
+	return result, nil
+}
+
+func (s *savedReportService) Create(orgID, userID uint, req *dto.CreateSavedReportRequest) (*dto.SavedReportResponse, error) {
+	isMember, err := s.orgRepo.IsMember(orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, ErrAnalyticsAccessDenied
+	}
+	if _, _, err := resolveReportFields(req.Dimensions, req.Measures); err != nil {
+		return nil, err
+	}
+
+	report := &models.SavedReport{
+		OrganizationID: orgID,
+		UserID:         userID,
+		Name:           req.Name,
+		Dimensions:     strings.Join(req.Dimensions, ","),
+		Measures:       strings.Join(req.Measures, ","),
+		WorkspaceID:    req.WorkspaceID,
+		FilterUserID:   req.UserID,
+		RangeDays:      req.RangeDays,
+		Format:         req.Format,
+	}
+	if report.RangeDays == 0 {
+		report.RangeDays = defaultSavedReportRange
+	}
+	if report.Format == "" {
+		report.Format = models.SavedReportFormatCSV
+	}
+	if err := s.savedReportRepo.Create(report); err != nil {
+		return nil, err
+	}
+
+	response := toSavedReportResponse(report)
+	return &response, nil
+}
+
+func (s *savedReportService) Get(orgID, reportID, userID uint) (*dto.SavedReportResponse, error) {
+	report, err := s.findOwned(orgID, reportID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toSavedReportResponse(report)
+	return &response, nil
+}
+
+func (s *savedReportService) Update(orgID, reportID, userID uint, req *dto.UpdateSavedReportRequest) (*dto.SavedReportResponse, error) {
+	report, err := s.findOwned(orgID, reportID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		report.Name = *req.Name
+	}
+	if len(req.Dimensions) > 0 {
+		report.Dimensions = strings.Join(req.Dimensions, ",")
+	}
+	if len(req.Measures) > 0 {
+		report.Measures = strings.Join(req.Measures, ",")
+	}
+	if _, _, err := resolveReportFields(strings.Split(report.Dimensions, ","), strings.Split(report.Measures, ",")); err != nil {
+		return nil, err
+	}
+	if req.WorkspaceID != nil {
+		report.WorkspaceID = optionalID(*req.WorkspaceID)
+	}
+	if req.UserID != nil {
+		report.FilterUserID = optionalID(*req.UserID)
+	}
+	if req.RangeDays != nil {
+		report.RangeDays = *req.RangeDays
+	}
+	if req.Format != nil {
+		report.Format = *req.Format
+	}
+
+	if err := s.savedReportRepo.Update(report); err != nil {
+		return nil, err
+	}
+
+	response := toSavedReportResponse(report)
+	return &response, nil
+}
+
+func (s *savedReportService) Delete(orgID, reportID, userID uint) error {
+	report, err := s.findOwned(orgID, reportID, userID)
+	if err != nil {
+		return err
+	}
+	return s.savedReportRepo.Delete(report.ID)
+}
+
+func (s *savedReportService) Run(orgID, reportID, userID uint) (*dto.CustomReportResponse, error) {
+	report, err := s.findOwned(orgID, reportID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.reportService.RunCustomReport(orgID, userID, savedReportRequest(report, time.Now().UTC()))
+}
+
+func (s *savedReportService) Export(orgID, reportID, userID uint, loc *time.Location) (*ReportFile, error) {
+	report, err := s.findOwned(orgID, reportID, userID)
+	if err != nil {
+		return nil, err
+	}
+	req := savedReportRequest(report, time.Now().UTC())
+	if loc != nil {
+		req = savedReportRequest(report, time.Now().In(loc))
+		req.Timezone = loc.String()
+	}
+	result, err := s.reportService.RunCustomReport(orgID, userID, req)
+	if err != nil {
+		return nil, err
+	}
+	return renderSavedReport(report, result)
+}
+
+// ============================================================================
+// SCHEDULES
+// ============================================================================
+
+func (s *savedReportService) CreateSchedule(orgID, reportID, userID uint, req *dto.CreateReportScheduleRequest) (*dto.ReportScheduleResponse, error) {
+	report, err := s.findOwned(orgID, reportID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := &models.ReportSchedule{
+		SavedReportID: report.ID,
+		Frequency:     req.Frequency,
+		Weekday:       int(time.Monday),
+		Hour:          defaultReportScheduleHour,
+		Timezone:      req.Timezone,
+		IsActive:      true,
+	}
+	if req.Weekday != nil {
+		schedule.Weekday = *req.Weekday
+	}
+	if req.Hour != nil {
+		schedule.Hour = *req.Hour
+	}
+	if schedule.Timezone == "" {
+		schedule.Timezone = "UTC"
+	}
+	if _, err := time.LoadLocation(schedule.Timezone); err != nil {
+		return nil, ErrInvalidTimezone
+	}
+	if req.IsActive != nil {
+		schedule.IsActive = *req.IsActive
+	}
+
+	recipients := req.Recipients
+	if len(recipients) == 0 {
+		owner, err := s.userRepo.FindByID(userID)
+		if err != nil {
+			return nil, err
+		}
+		recipients = []string{owner.Email}
+	}
+	if schedule.Recipients, err = s.validateRecipients(orgID, recipients); err != nil {
+		return nil, err
+	}
+
+	schedule.NextRunAt = nextReportRun(schedule, time.Now())
+	if err := s.savedReportRepo.CreateSchedule(schedule); err != nil {
+		return nil, err
+	}
+
+	response := toReportScheduleResponse(schedule)
+	return &response, nil
+}
+
+func (s *savedReportService) UpdateSchedule(orgID, reportID, scheduleID, userID uint, req *dto.UpdateReportScheduleRequest) (*dto.ReportScheduleResponse, error) {
+	report, err := s.findOwned(orgID, reportID, userID)
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := s.savedReportRepo.FindSchedule(report.ID, scheduleID)
+	if err != nil {
+		return nil, ErrReportScheduleNotFound
+	}
+
+	if req.Frequency != nil {
+		schedule.Frequency = *req.Frequency
+	}
+	if req.Weekday != nil {
+		schedule.Weekday = *req.Weekday
+	}
+	if req.Hour != nil {
+		schedule.Hour = *req.Hour
+	}
+	if req.Timezone != nil {
+		if _, err := time.LoadLocation(*req.Timezone); err != nil || *req.Timezone == "" {
+			return nil, ErrInvalidTimezone
+		}
+		schedule.Timezone = *req.Timezone
+	}
+	if len(req.Recipients) > 0 {
+		if schedule.Recipients, err = s.validateRecipients(orgID, req.Recipients); err != nil {
+			return nil, err
+		}
+	}
+	if req.IsActive != nil {
+		schedule.IsActive = *req.IsActive
+	}
+
+	schedule.NextRunAt = nextReportRun(schedule, time.Now())
+	if err := s.savedReportRepo.UpdateSchedule(schedule); err != nil {
+		return nil, err
+	}
+
+	response := toReportScheduleResponse(schedule)
+	return &response, nil
+}
+
+func (s *savedReportService) DeleteSchedule(orgID, reportID, scheduleID, userID uint) error {
+	report, err := s.findOwned(orgID, reportID, userID)
+	if err != nil {
+		return err
+	}
+	schedule, err := s.savedReportRepo.FindSchedule(report.ID, scheduleID)
+	if err != nil {
+		return ErrReportScheduleNotFound
+	}
+	return s.savedReportRepo.DeleteSchedule(schedule.ID)
+}
+
+// validateRecipients only accepts organization members, so reports never
+// leave the organization, and returns the comma-separated addresses
+func (s *savedReportService) validateRecipients(orgID uint, emails []string) (string, error) {
+	normalized := make([]string, 0, len(emails))
+	for _, email := range emails {
+		normalized = append(normalized, strings.ToLower(strings.TrimSpace(email)))
+	}
+	normalized = uniqueStrings(normalized)
+
+	users, err := s.memberRecipients(orgID, normalized)
+	if err != nil {
+		return "", err
+	}
+	if len(users) < len(normalized) {
+		return "", apperror.Validation("recipients must be members of the organization", nil)
+	}
+
+	addresses := make([]string, 0, len(users))
+	for _, user := range users {
+		addresses = append(addresses, user.Email)
+	}
+	return strings.Join(addresses, ","), nil
+}
+
+// memberRecipients finds the active organization members among normalized emails
+func (s *savedReportService) memberRecipients(orgID uint, emails []string) ([]*models.User, error) {
+	var users []*models.User
+	for _, email := range emails {
+		user, err := s.userRepo.FindByEmail(email)
+		if err != nil || !user.IsActive {
+			continue
+		}
+		isMember, err := s.orgRepo.IsMember(orgID, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		if isMember {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+// ============================================================================
+// DELIVERY
+// ============================================================================
+
+// DeliverDue emails every due scheduled report and moves its schedule to the
+// next run. Runs missed while the server was down are sent once.
+func (s *savedReportService) DeliverDue(ctx context.Context) error {
+	now := time.Now()
+	schedules, err := s.savedReportRepo.FindDueSchedules(now, savedReportDeliveryBatch)
+	if err != nil {
+		return fmt.Errorf("failed to load due report schedules: %w", err)
+	}
+
+	for i := range schedules {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		s.deliver(&schedules[i], now)
+	}
+	return nil
+}
+
+// deliver queues the report for each recipient. Failures are recorded on the
+// schedule and not retried before its next run.
+func (s *savedReportService) deliver(schedule *models.ReportSchedule, now time.Time) {
+	runDate := schedule.NextRunAt
+	if loc, err := time.LoadLocation(schedule.Timezone); err == nil {
+		runDate = runDate.In(loc)
+	}
+
+	schedule.LastError = ""
+	if err := s.send(schedule, runDate); err != nil {
+		schedule.LastError = truncateText(err.Error(), maxEmailErrorLength)
+		log.Printf("⚠️  Failed to deliver saved report %d (schedule %d): %v", schedule.SavedReportID, schedule.ID, err)
+	}
+
+	schedule.LastRunAt = &now
+	schedule.NextRunAt = nextReportRun(schedule, now)
+	if err := s.savedReportRepo.UpdateSchedule(schedule); err != nil {
+		log.Printf("⚠️  Failed to update report schedule %d: %v", schedule.ID, err)
+	}
+}
+
+func (s *savedReportService) send(schedule *models.ReportSchedule, runDate time.Time) error {
+	report := &schedule.SavedReport
+	req := savedReportRequest(report, runDate)
+	req.Timezone = schedule.Timezone
+	result, err := s.reportService.RunCustomReport(report.OrganizationID, report.UserID, req)
+	if err != nil {
+		return err
+	}
+	file, err := renderSavedReport(report, result)
+	if err != nil {
+		return err
+	}
+
+	recipients, err := s.memberRecipients(report.OrganizationID, strings.Split(schedule.Recipients, ","))
+	if err != nil {
+		return err
+	}
+	if len(recipients) == 0 {
+		return apperror.Validation("no recipient is still a member of the organization", nil)
+	}
+
+	start, _ := time.Parse("2006-01-02", result.StartDate)
+	end, _ := time.Parse("2006-01-02", result.EndDate)
+	data := mail.SavedReportData{
+		ReportName: report.Name,
+		OwnerName:  emailUserName(&report.User),
+		StartDate:  start,
+		EndDate:    end,
+		Format:     strings.ToUpper(report.Format),
+		RowCount:   result.RowCount,
+		Truncated:  result.Truncated,
+	}
+	attachment := mail.Attachment{Filename: file.Filename, ContentType: file.ContentType, Data: file.Data}
+	for _, recipient := range recipients {
+		s.emailService.SendSavedReport(recipient, data, attachment)
+	}
+	return nil
+}
+
+// ============================================================================
+// HELPERS
+// ============================================================================
+
+// savedReportRequest builds the custom report query of a saved report run on
+// runDate, covering its range of days up to the day before
+func savedReportRequest(report *models.SavedReport, runDate time.Time) *dto.CustomReportRequest {
+	end := time.Date(runDate.Year(), runDate.Month(), runDate.Day()-1, 0, 0, 0, 0, time.UTC)
+	start := end.AddDate(0, 0, -(report.RangeDays - 1))
+	return &dto.CustomReportRequest{
+		Dimensions:  strings.Split(report.Dimensions, ","),
+		Measures:    strings.Split(report.Measures, ","),
+		StartDate:   start.Format("2006-01-02"),
+		EndDate:     end.Format("2006-01-02"),
+		WorkspaceID: report.WorkspaceID,
+		UserID:      report.FilterUserID,
+		Limit:       analyticsMaxLimit,
+	}
+}
+
+// nextReportRun returns the schedule's first run after after: its hour in its
+// timezone, every day or on its weekday
+func nextReportRun(schedule *models.ReportSchedule, after time.Time) time.Time {
+	loc, err := time.LoadLocation(schedule.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	local := after.In(loc)
+	next := time.Date(local.Year(), local.Month(), local.Day(), schedule.Hour, 0, 0, 0, loc)
+	for !next.After(after) ||
+		(schedule.Frequency == models.ReportFrequencyWeekly && int(next.Weekday()) != schedule.Weekday) {
+		next = time.Date(next.Year(), next.Month(), next.Day()+1, schedule.Hour, 0, 0, 0, loc)
+	}
+	return next
+}
+
+// renderSavedReport writes a custom report result in the saved report's format
+func renderSavedReport(report *models.SavedReport, result *dto.CustomReportResponse) (*ReportFile, error) {
+	rows := make([][]string, 0, len(result.Rows))
+	for _, row := range result.Rows {
+		cells := make([]string, len(row))
+		for i, value := range row {
+			cells[i] = reportCell(value)
+		}
+		rows = append(rows, cells)
+	}
+
+	name := slug.Make(report.Name)
+	if name == "" {
+		name = "report"
+	}
+	filename := fmt.Sprintf("%s-%s-%s", name, strings.ReplaceAll(result.StartDate, "-", ""), strings.ReplaceAll(result.EndDate, "-", ""))
+
+	if report.Format == models.SavedReportFormatPDF {
+		table := pdf.Table{
+			Title:    report.Name,
+			Subtitle: fmt.Sprintf("%s to %s, %d rows", result.StartDate, result.EndDate, result.RowCount),
+			Columns:  result.Columns,
+			Rows:     rows,
+		}
+		if result.Truncated {
+			table.Subtitle += " (row limit reached)"
+		}
+		return &ReportFile{Filename: filename + ".pdf", ContentType: "application/pdf", Data: table.Render()}, nil
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(result.Columns); err != nil {
+		return nil, err
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return &ReportFile{Filename: filename + ".csv", ContentType: "text/csv; charset=utf-8", Data: buf.Bytes()}, nil
+}
+
+func reportCell(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case time.Time:
+		return v.Format(time.RFC3339)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// optionalID maps 0 to no ID, for filters that can be removed
+func optionalID(id uint) *uint {
+	if id == 0 {
+		return nil
+	}
+	return &id
+}
+
+func toSavedReportResponse(report *models.SavedReport) dto.SavedReportResponse {
+	response := dto.SavedReportResponse{
+		ID:             report.ID,
+		OrganizationID: report.OrganizationID,
+		Name:           report.Name,
+		Dimensions:     strings.Split(report.Dimensions, ","),
+		Measures:       strings.Split(report.Measures, ","),
+		WorkspaceID:    report.WorkspaceID,
+		UserID:         report.FilterUserID,
+		RangeDays:      report.RangeDays,
+		Format:         report.Format,
+		Schedules:      make([]dto.ReportScheduleResponse, 0, len(report.Schedules)),
+		CreatedAt:      report.CreatedAt,
+		UpdatedAt:      report.UpdatedAt,
+	}
+	for i := range report.Schedules {
+		response.Schedules = append(response.Schedules, toReportScheduleResponse(&report.Schedules[i]))
+	}
+	return response
+}
+
+func toReportScheduleResponse(schedule *models.ReportSchedule) dto.ReportScheduleResponse {
+	return dto.ReportScheduleResponse{
+		ID:            schedule.ID,
+		SavedReportID: schedule.SavedReportID,
+		Frequency:     schedule.Frequency,
+		Weekday:       schedule.Weekday,
+		Hour:          schedule.Hour,
+		Timezone:      schedule.Timezone,
+		Recipients:    strings.Split(schedule.Recipients, ","),
+		IsActive:      schedule.IsActive,
+		NextRunAt:     schedule.NextRunAt,
+		LastRunAt:     schedule.LastRunAt,
+		LastError:     schedule.LastError,
+	}
+}
@@ -0,0 +1,292 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/calendar"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+	"gorm.io/gorm"
+)
+
+// Schedule statuses of tracked against expected hours
+const (
+	ScheduleStatusUnder       = "under"
+	ScheduleStatusOnTrack     = "on_track"
+	ScheduleStatusOver        = "over"
+	ScheduleStatusUnscheduled = "unscheduled"
+)
+
+// scheduleTolerancePercent is how far tracked hours may be from the expected
+// hours and still count as on track
+const scheduleTolerancePercent = 10
+
+var (
+	// ErrScheduleNotFound is returned for users without a work schedule
+	ErrScheduleNotFound = apperror.NotFound("work schedule not found")
+	// ErrScheduleUserNotFound is returned when scheduling an unknown user
+	ErrScheduleUserNotFound = apperror.NotFound("user not found")
+	// ErrInvalidTimezone is returned for unknown IANA timezone names
+	ErrInvalidTimezone = apperror.Validation("invalid timezone: use an IANA name such as Europe/Berlin", nil)
+)
+
+// ScheduleComparison is a user's tracked time against their work schedule over a window
+type ScheduleComparison struct {
+	Status          string
+	TrackedSeconds  int64
+	ExpectedSeconds *int64 // Nil without a schedule
+	LeaveDays       int    // Working days on approved leave, not expected to be tracked
+	HolidayDays     int    // Scheduled weekdays that are organization holidays
+}
+
+// ScheduleService manages users' work schedules (admin) and compares tracked
+// hours against the expected hours
+type ScheduleService interface {
+	ListSchedules(page, pageSize int) (*dto.WorkScheduleListResponse, error)
+	GetSchedule(userID uint) (*dto.WorkScheduleResponse, error)
+	UpdateSchedule(userID, actorID uint, req *dto.UpdateWorkScheduleRequest) (*dto.WorkScheduleResponse, error)
+	DeleteSchedule(userID uint) error
+
+	// Compare returns each user's tracked and expected time for the dates
+	// startDate to endDate inclusive, with days taken in the user's timezone.
+	// Holidays of the user's organizations and working days on approved leave
+	// are not expected.
+	Compare(userIDs []uint, startDate, endDate time.Time) (map[uint]ScheduleComparison, error)
+}
+
+type scheduleService struct {
+	scheduleRepo repository.ScheduleRepository
+	leaveRepo    repository.LeaveRepository
+	holidayRepo  repository.HolidayRepository
+	userRepo     repository.UserRepository
+}
+
+// NewScheduleService creates a new work schedule service
+func NewScheduleService(
+	scheduleRepo repository.ScheduleRepository,
+	leaveRepo repository.LeaveRepository,
+	holidayRepo repository.HolidayRepository,
+	userRepo repository.UserRepository,
+) ScheduleService {
+	return &scheduleService{
+		scheduleRepo: scheduleRepo,
+		leaveRepo:    leaveRepo,
+		holidayRepo:  holidayRepo,
+		userRepo:     userRepo,
+	}
+}
+
+func (s *scheduleService) ListSchedules(page, pageSize int) (*dto.WorkScheduleListResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	schedules, total, err := s.scheduleRepo.List(page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.WorkScheduleResponse, 0, len(schedules))
+	for i := range schedules {
+		responses = append(responses, toWorkScheduleResponse(&schedules[i]))
+	}
+
+	totalPages := int((total + int64(pageSize) - 1) / int64(pageSize))
+	return &dto.WorkScheduleListResponse{
+		Schedules: responses,
+		Pagination: dto.AdminPaginationResponse{
+			Page:       page,
+			PageSize:   pageSize,
+			TotalItems: total,
+			TotalPages: totalPages,
+			HasNext:    page < totalPages,
+			HasPrev:    page > 1,
+		},
+	}, nil
+}
+
+func (s *scheduleService) GetSchedule(userID uint) (*dto.WorkScheduleResponse, error) {
+	schedule, err := s.scheduleRepo.FindByUserID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrScheduleNotFound
+		}
+		return nil, err
+	}
+	response := toWorkScheduleResponse(schedule)
+	return &response, nil
+}
+
+func (s *scheduleService) UpdateSchedule(userID, actorID uint, req *dto.UpdateWorkScheduleRequest) (*dto.WorkScheduleResponse, error) {
+	if _, err := s.userRepo.FindByID(userID); err != nil {
+		return nil, ErrScheduleUserNotFound
+	}
+
+	timezone := req.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return nil, ErrInvalidTimezone
+	}
+
+	days, err := calendar.WeekdaysFromInts(req.WorkingDays)
+	if err != nil {
+		return nil, apperror.Validation(err.Error(), nil)
+	}
+
+	schedule := &models.WorkSchedule{
+		UserID:              userID,
+		WorkingDays:         calendar.FormatWorkingDays(days),
+		ExpectedHoursPerDay: req.ExpectedHoursPerDay,
+		Timezone:            timezone,
+		UpdatedBy:           &actorID,
+	}
+	if err := s.scheduleRepo.Upsert(schedule); err != nil {
+		return nil, err
+	}
+	return s.GetSchedule(userID)
+}
+
+func (s *scheduleService) DeleteSchedule(userID uint) error {
+	deleted, err := s.scheduleRepo.Delete(userID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrScheduleNotFound
+	}
+	return nil
+}
+
+func (s *scheduleService) Compare(userIDs []uint, startDate, endDate time.Time) (map[uint]ScheduleComparison, error) {
+	schedules, err := s.scheduleRepo.FindByUserIDs(userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Leave dates are calendar dates; widen by a day for timezones ahead of UTC
+	leaves, err := s.leaveRepo.FindApprovedForUsers(userIDs, startDate.AddDate(0, 0, -1), endDate.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	leavesByUser := make(map[uint][]models.LeaveRequest)
+	for _, leave := range leaves {
+		leavesByUser[leave.UserID] = append(leavesByUser[leave.UserID], leave)
+	}
+	holidays, err := s.holidayRepo.FindDatesForUsers(userIDs, startDate.AddDate(0, 0, -1), endDate.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	comparisons := make(map[uint]ScheduleComparison, len(userIDs))
+	for _, userID := range userIDs {
+		schedule, ok := schedules[userID]
+
+		cal := calendar.Default()
+		if ok {
+			cal = scheduleCalendar(&schedule)
+		}
+		start := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, cal.Location)
+		end := time.Date(endDate.Year(), endDate.Month(), endDate.Day(), 0, 0, 0, 0, cal.Location).AddDate(0, 0, 1)
+
+		tracked, err := s.scheduleRepo.SumTracked(userID, start, end)
+		if err != nil {
+			return nil, err
+		}
+
+		comparison := ScheduleComparison{
+			Status:         ScheduleStatusUnscheduled,
+			TrackedSeconds: tracked,
+		}
+		if ok {
+			scheduledDays := cal.WorkingDaysBetween(start, end)
+			cal = cal.WithHolidays(holidays[userID])
+			comparison.LeaveDays = leaveWorkingDaysBetween(cal, leavesByUser[userID], start, end)
+			workingDays := cal.WorkingDaysBetween(start, end)
+			comparison.HolidayDays = scheduledDays - workingDays
+			workingDays -= comparison.LeaveDays
+			expected := int64(float64(workingDays) * schedule.ExpectedHoursPerDay * 3600)
+			comparison.ExpectedSeconds = &expected
+			comparison.Status = scheduleStatus(tracked, expected)
+		}
+		comparisons[userID] = comparison
+	}
+	return comparisons, nil
+}
+
+// scheduleCalendar returns the calendar of a schedule's working days and timezone
+func scheduleCalendar(schedule *models.WorkSchedule) calendar.Calendar {
+	cal := calendar.Default()
+	if loc, err := time.LoadLocation(schedule.Timezone); err == nil {
+		cal.Location = loc
+	}
+	if days, err := calendar.ParseWorkingDays(schedule.WorkingDays); err == nil {
+		cal.WorkingDays = days
+	}
+	return cal
+}
+
+// leaveWorkingDaysBetween counts the calendar's working days in [start, end)
+// that fall within any of the leave ranges
+func leaveWorkingDaysBetween(cal calendar.Calendar, leaves []models.LeaveRequest, start, end time.Time) int {
+	if len(leaves) == 0 {
+		return 0
+	}
+
+	count := 0
+	for d := start; d.Before(end); d = d.AddDate(0, 0, 1) {
+		if !cal.IsWorkingDay(d) {
+			continue
+		}
+		date := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.UTC)
+		for _, leave := range leaves {
+			if !date.Before(leave.StartDate) && !date.After(leave.EndDate) {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
+
+// scheduleStatus classifies tracked against expected seconds
+func scheduleStatus(tracked, expected int64) string {
+	tolerance := float64(expected) * scheduleTolerancePercent / 100
+	switch {
+	case float64(tracked) < float64(expected)-tolerance:
+		return ScheduleStatusUnder
+	case float64(tracked) > float64(expected)+tolerance:
+		return ScheduleStatusOver
+	default:
+		return ScheduleStatusOnTrack
+	}
+}
+
+func toWorkScheduleResponse(schedule *models.WorkSchedule) dto.WorkScheduleResponse {
+	days, _ := calendar.ParseWorkingDays(schedule.WorkingDays)
+	workingDays := make([]int, 0, len(days))
+	for _, d := range days {
+		workingDays = append(workingDays, int(d))
+	}
+
+	return dto.WorkScheduleResponse{
+		ID:                  schedule.ID,
+		UserID:              schedule.UserID,
+		UserName:            schedule.User.FirstName + " " + schedule.User.LastName,
+		Email:               schedule.User.Email,
+		WorkingDays:         workingDays,
+		ExpectedHoursPerDay: schedule.ExpectedHoursPerDay,
+		ExpectedHoursWeek:   math.Round(float64(len(days))*schedule.ExpectedHoursPerDay*100) / 100,
+		Timezone:            schedule.Timezone,
+		UpdatedBy:           schedule.UpdatedBy,
+		UpdatedAt:           schedule.UpdatedAt,
+	}
+}
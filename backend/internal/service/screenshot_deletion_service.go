@@ -0,0 +1,349 @@
+package service
+
+import (
+	"encoding/json"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+)
+
+// ScreenshotDeletionService handles users' requests to delete organization
+// screenshots and the manager approval queue for them
+type ScreenshotDeletionService interface {
+	// User requests
+	Request(userID, screenshotID uint, req *dto.CreateScreenshotDeletionRequest) (*dto.ScreenshotDeletionRequestResponse, error)
+	ListMine(userID uint, params *dto.ScreenshotDeletionRequestListParams) ([]dto.ScreenshotDeletionRequestResponse, int64, error)
+	Cancel(userID, requestID uint) error
+
+	// Manager review queue
+	ListForOrg(orgID, userID uint, params *dto.ScreenshotDeletionRequestListParams) ([]dto.ScreenshotDeletionRequestResponse, int64, error)
+	Approve(orgID, requestID, userID uint, req *dto.ReviewScreenshotDeletionRequest) (*dto.ScreenshotDeletionRequestResponse, error)
+	Reject(orgID, requestID, userID uint, req *dto.ReviewScreenshotDeletionRequest) (*dto.ScreenshotDeletionRequestResponse, error)
+}
+
+type screenshotDeletionService struct {
+	deletionRepo   repository.ScreenshotDeletionRepository
+	screenshotRepo repository.ScreenshotRepository
+	orgRepo        *repository.OrganizationRepository
+	workspaceRepo  *repository.WorkspaceRepository
+	auditService   AuditService
+}
+
+// NewScreenshotDeletionService creates a new screenshot deletion service
+func NewScreenshotDeletionService(
+	deletionRepo repository.ScreenshotDeletionRepository,
+	screenshotRepo repository.ScreenshotRepository,
+	orgRepo *repository.OrganizationRepository,
+	workspaceRepo *repository.WorkspaceRepository,
+	auditService AuditService,
+) ScreenshotDeletionService {
+	return &screenshotDeletionService{
+		deletionRepo:   deletionRepo,
+		screenshotRepo: screenshotRepo,
+		orgRepo:        orgRepo,
+		workspaceRepo:  workspaceRepo,
+		auditService:   auditService,
+	}
+}
+
+// ============================================================================
+// USER REQUESTS
+// ============================================================================
+
+func (s *screenshotDeletionService) Request(userID, screenshotID uint, req *dto.CreateScreenshotDeletionRequest) (*dto.ScreenshotDeletionRequestResponse, error) {
+	screenshot, err := s.screenshotRepo.FindByID(screenshotID)
+	if err != nil {
+		return nil, err
+	}
+	if screenshot.UserID != userID {
+		return nil, apperror.NotFound("unauthorized access to screenshot")
+	}
+	if screenshot.OrganizationID == nil {
+		return nil, apperror.Validation("personal screenshots can be deleted directly", nil)
+	}
+
+	pending, err := s.deletionRepo.FindPendingByScreenshot(screenshotID)
+	if err != nil {
+		return nil, err
+	}
+	if pending != nil {
+		return nil, apperror.Conflict("a deletion request for this screenshot is already pending")
+	}
+
+	request := &models.ScreenshotDeletionRequest{
+		ScreenshotID:   screenshot.ID,
+		UserID:         userID,
+		OrganizationID: *screenshot.OrganizationID,
+		WorkspaceID:    screenshot.WorkspaceID,
+		Reason:         strings.TrimSpace(req.Reason),
+		Status:         models.DeletionRequestPending,
+		FileName:       screenshot.FileName,
+		CapturedAt:     screenshot.CapturedAt,
+	}
+	if err := s.deletionRepo.Create(request); err != nil {
+		return nil, err
+	}
+
+	response := toDeletionRequestResponse(request)
+	return &response, nil
+}
+
+func (s *screenshotDeletionService) ListMine(userID uint, params *dto.ScreenshotDeletionRequestListParams) ([]dto.ScreenshotDeletionRequestResponse, int64, error) {
+	normalizeDeletionListParams(params)
+
+	requests, total, err := s.deletionRepo.FindByUser(userID, params)
+	if err != nil {
+		return nil, 0, err
+	}
+	return toDeletionRequestResponses(requests), total, nil
+}
+
+func (s *screenshotDeletionService) Cancel(userID, requestID uint) error {
+	request, err := s.deletionRepo.FindByID(requestID)
+	if err != nil {
+		return apperror.NotFound("deletion request not found")
+	}
+	if request.UserID != userID {
+		return apperror.NotFound("unauthorized access to deletion request")
+	}
+	if request.Status != models.DeletionRequestPending {
+		return apperror.Conflict("only pending requests can be cancelled")
+	}
+
+	request.Status = models.DeletionRequestCancelled
+	return s.deletionRepo.Update(request)
+}
+
+// ============================================================================
+// MANAGER REVIEW QUEUE
+// ============================================================================
+
+// reviewScope returns the workspaces whose requests the user may review: nil
+// for org owners and admins (every request), otherwise the workspaces they
+// administer
+func (s *screenshotDeletionService) reviewScope(orgID, userID uint) ([]uint, error) {
+	isAdmin, err := s.orgRepo.IsAdmin(orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if isAdmin {
+		return nil, nil
+	}
+
+	memberships, err := s.workspaceRepo.GetUserWorkspacesByOrg(userID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	workspaceIDs := make([]uint, 0)
+	for _, m := range memberships {
+		if m.IsAdmin {
+			workspaceIDs = append(workspaceIDs, m.WorkspaceID)
+		}
+	}
+	if len(workspaceIDs) == 0 {
+		return nil, apperror.Forbidden("access denied: only managers can review screenshot deletion requests")
+	}
+	return workspaceIDs, nil
+}
+
+func (s *screenshotDeletionService) ListForOrg(orgID, userID uint, params *dto.ScreenshotDeletionRequestListParams) ([]dto.ScreenshotDeletionRequestResponse, int64, error) {
+	workspaceIDs, err := s.reviewScope(orgID, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	normalizeDeletionListParams(params)
+
+	requests, total, err := s.deletionRepo.FindByOrg(orgID, workspaceIDs, params)
+	if err != nil {
+		return nil, 0, err
+	}
+	return toDeletionRequestResponses(requests), total, nil
+}
+
+// loadForReview loads a pending request the user is allowed to review
+func (s *screenshotDeletionService) loadForReview(orgID, requestID, userID uint) (*models.ScreenshotDeletionRequest, error) {
+	workspaceIDs, err := s.reviewScope(orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := s.deletionRepo.FindByID(requestID)
+	if err != nil || request.OrganizationID != orgID {
+		return nil, apperror.NotFound("deletion request not found")
+	}
+
+	if workspaceIDs != nil {
+		inScope := false
+		for _, id := range workspaceIDs {
+			if request.WorkspaceID != nil && *request.WorkspaceID == id {
+				inScope = true
+				break
+			}
+		}
+		if !inScope {
+			return nil, apperror.Forbidden("access denied: request is outside the workspaces you manage")
+		}
+	}
+
+	// Managers cannot sign off their own captures; only the owner has nobody above them
+	if request.UserID == userID {
+		isOwner, _ := s.orgRepo.IsOwner(orgID, userID)
+		if !isOwner {
+			return nil, apperror.Forbidden("you cannot review your own deletion request")
+		}
+	}
+
+	if request.Status != models.DeletionRequestPending {
+		return nil, apperror.Conflict("deletion request has already been " + request.Status)
+	}
+
+	return request, nil
+}
+
+func (s *screenshotDeletionService) Approve(orgID, requestID, userID uint, req *dto.ReviewScreenshotDeletionRequest) (*dto.ScreenshotDeletionRequestResponse, error) {
+	request, err := s.loadForReview(orgID, requestID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The screenshot may already be gone (e.g. purged by retention); the
+	// request is still approved so the decision stays on record
+	if screenshot, err := s.screenshotRepo.FindByID(request.ScreenshotID); err == nil {
+		if err := s.screenshotRepo.Delete(screenshot.ID); err != nil {
+			return nil, err
+		}
+		for _, path := range []string{screenshot.FilePath, screenshot.BlurredPath} {
+			if err := s.screenshotRepo.DeleteFile(path); err != nil {
+				log.Printf("⚠️  Failed to delete screenshot file %s: %v", path, err)
+			}
+		}
+		s.auditDeletion(request, screenshot, userID)
+	}
+
+	return s.review(request, userID, models.DeletionRequestApproved, req.Note)
+}
+
+func (s *screenshotDeletionService) Reject(orgID, requestID, userID uint, req *dto.ReviewScreenshotDeletionRequest) (*dto.ScreenshotDeletionRequestResponse, error) {
+	if strings.TrimSpace(req.Note) == "" {
+		return nil, apperror.Validation("a note explaining the rejection is required", nil)
+	}
+
+	request, err := s.loadForReview(orgID, requestID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.review(request, userID, models.DeletionRequestRejected, req.Note)
+}
+
+// auditDeletion records the screenshot removal itself; the request audit only
+// names the deletion request
+func (s *screenshotDeletionService) auditDeletion(request *models.ScreenshotDeletionRequest, screenshot *models.Screenshot, reviewerID uint) {
+	details, _ := json.Marshal(map[string]interface{}{
+		"deletion_request_id": request.ID,
+		"owner_id":            screenshot.UserID,
+		"workspace_id":        screenshot.WorkspaceID,
+		"file_name":           screenshot.FileName,
+		"captured_at":         screenshot.CapturedAt,
+		"reason":              request.Reason,
+	})
+	s.auditService.Record(&models.AuditLog{
+		UserID:     &reviewerID,
+		Action:     "delete",
+		EntityType: "screenshot",
+		EntityID:   &screenshot.ID,
+		Details:    string(details),
+		Status:     AuditStatusSuccess,
+	})
+}
+
+func (s *screenshotDeletionService) review(request *models.ScreenshotDeletionRequest, reviewerID uint, status, note string) (*dto.ScreenshotDeletionRequestResponse, error) {
+	now := time.Now()
+	request.Status = status
+	request.ReviewedBy = &reviewerID
+	request.ReviewedAt = &now
+	request.ReviewNote = strings.TrimSpace(note)
+
+	if err := s.deletionRepo.Update(request); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.deletionRepo.FindByID(request.ID)
+	if err != nil {
+		updated = request
+	}
+	response := toDeletionRequestResponse(updated)
+	return &response, nil
+}
+
+// ============================================================================
+// HELPER FUNCTIONS
+// ============================================================================
+
+func normalizeDeletionListParams(params *dto.ScreenshotDeletionRequestListParams) {
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PerPage < 1 || params.PerPage > 100 {
+		params.PerPage = 20
+	}
+}
+
+func toDeletionRequestResponses(requests []models.ScreenshotDeletionRequest) []dto.ScreenshotDeletionRequestResponse {
+	responses := make([]dto.ScreenshotDeletionRequestResponse, 0, len(requests))
+	for i := range requests {
+		responses = append(responses, toDeletionRequestResponse(&requests[i]))
+	}
+	return responses
+}
+
+func toDeletionRequestResponse(r *models.ScreenshotDeletionRequest) dto.ScreenshotDeletionRequestResponse {
+	response := dto.ScreenshotDeletionRequestResponse{
+		ID:             r.ID,
+		ScreenshotID:   r.ScreenshotID,
+		UserID:         r.UserID,
+		OrganizationID: r.OrganizationID,
+		WorkspaceID:    r.WorkspaceID,
+		Reason:         r.Reason,
+		Status:         r.Status,
+		FileName:       r.FileName,
+		CapturedAt:     r.CapturedAt,
+		ReviewedBy:     r.ReviewedBy,
+		ReviewedAt:     r.ReviewedAt,
+		ReviewNote:     r.ReviewNote,
+		CreatedAt:      r.CreatedAt,
+	}
+
+	if r.User.ID > 0 {
+		response.User = &dto.UserResponse{
+			ID:        r.User.ID,
+			UUID:      r.User.UUID,
+			Email:     r.User.Email,
+			FirstName: r.User.FirstName,
+			LastName:  r.User.LastName,
+			Role:      r.User.Role,
+			IsActive:  r.User.IsActive,
+			CreatedAt: r.User.CreatedAt,
+		}
+	}
+	if r.Reviewer != nil && r.Reviewer.ID > 0 {
+		response.Reviewer = &dto.UserResponse{
+			ID:        r.Reviewer.ID,
+			UUID:      r.Reviewer.UUID,
+			Email:     r.Reviewer.Email,
+			FirstName: r.Reviewer.FirstName,
+			LastName:  r.Reviewer.LastName,
+			Role:      r.Reviewer.Role,
+			IsActive:  r.Reviewer.IsActive,
+			CreatedAt: r.Reviewer.CreatedAt,
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/ocr"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+)
+
+const (
+	// ocrBatchSize bounds how many pending screenshots are read per query
+	ocrBatchSize = 50
+	// ocrMaxTextBytes bounds the text kept per screenshot
+	ocrMaxTextBytes = 64 << 10
+	// Screenshot text search limits
+	screenshotSearchDefaultLimit = 50
+	screenshotSearchMaxLimit     = 200
+)
+
+// ScreenshotOCRService reads the text shown in screenshots and lets system
+// admins search it, e.g. to find when a document or URL was on screen
+type ScreenshotOCRService interface {
+	// Enabled reports whether an OCR provider is configured; new screenshots
+	// are then stored as pending
+	Enabled() bool
+	// ExtractPending reads the text of pending screenshots; it matches
+	// scheduler.JobFunc
+	ExtractPending(ctx context.Context) error
+	// Search finds screenshots whose text matches every word of the query
+	Search(params *dto.AdminScreenshotSearchParams) (*dto.AdminScreenshotSearchResponse, error)
+}
+
+type screenshotOCRService struct {
+	ocrRepo   repository.ScreenshotOCRRepository
+	extractor ocr.Extractor // nil when OCR is disabled
+	timeout   time.Duration
+}
+
+// NewScreenshotOCRService creates a new screenshot OCR service. extractor may
+// be nil, which disables OCR; screenshots read earlier stay searchable.
+func NewScreenshotOCRService(ocrRepo repository.ScreenshotOCRRepository, extractor ocr.Extractor, timeout time.Duration) ScreenshotOCRService {
+	return &screenshotOCRService{
+		ocrRepo:   ocrRepo,
+		extractor: extractor,
+		timeout:   timeout,
+	}
+}
+
+func (s *screenshotOCRService) Enabled() bool {
+	return s.extractor != nil
+}
+
+func (s *screenshotOCRService) ExtractPending(ctx context.Context) error {
+	if s.extractor == nil {
+		return nil
+	}
+
+	extracted := 0
+	var afterID uint
+
+	for {
+		batch, err := s.ocrRepo.FindPending(afterID, ocrBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			break
+		}
+		afterID = batch[len(batch)-1].ID
+
+		for i := range batch {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := s.extract(ctx, &batch[i]); err != nil {
+				// Left pending; retried by the next run
+				log.Printf("⚠️  OCR: screenshot %d: %v", batch[i].ID, err)
+				continue
+			}
+			extracted++
+		}
+
+		if len(batch) < ocrBatchSize {
+			break
+		}
+	}
+
+	if extracted > 0 {
+		log.Printf("✅ Read the text of %d screenshots", extracted)
+	}
+	return nil
+}
+
+// extract reads a screenshot's text and stores it
+func (s *screenshotOCRService) extract(ctx context.Context, screenshot *models.Screenshot) error {
+	data, err := os.ReadFile(screenshot.FilePath)
+	if err != nil {
+		return err
+	}
+
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+	text, err := s.extractor.Extract(ctx, data)
+	if err != nil {
+		return err
+	}
+
+	return s.ocrRepo.SetText(screenshot.ID, truncateOCRText(text), time.Now())
+}
+
+// truncateOCRText collapses whitespace and cuts the text to ocrMaxTextBytes
+// on a rune boundary; PostgreSQL also refuses invalid UTF-8 and NUL bytes
+func truncateOCRText(text string) string {
+	text = strings.Join(strings.Fields(strings.ToValidUTF8(strings.ReplaceAll(text, "\x00", ""), "")), " ")
+	if len(text) <= ocrMaxTextBytes {
+		return text
+	}
+	cut := ocrMaxTextBytes
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	return text[:cut]
+}
+
+func (s *screenshotOCRService) Search(params *dto.AdminScreenshotSearchParams) (*dto.AdminScreenshotSearchResponse, error) {
+	query := searchTSQuery(params.Query)
+	if query == "" {
+		return nil, fmt.Errorf("%w: enter at least one word to search for", ErrInvalidSearch)
+	}
+	if params.Limit < 1 || params.Limit > screenshotSearchMaxLimit {
+		params.Limit = screenshotSearchDefaultLimit
+	}
+
+	results, err := s.ocrRepo.Search(query, params)
+	if err != nil {
+		return nil, err
+	}
+	if results == nil {
+		results = []dto.AdminScreenshotSearchResult{}
+	}
+	return &dto.AdminScreenshotSearchResponse{
+		Query:   params.Query,
+		Results: results,
+	}, nil
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/config"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+	"github.com/beuphecan/remote-time-tracker/internal/scanner"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+)
+
+const (
+	// scanBatchSize bounds how many pending screenshots are scanned per query
+	scanBatchSize = 100
+	// scanConcurrency bounds the background scans running at once; uploads
+	// beyond it wait for the scheduled job
+	scanConcurrency = 4
+	// scanGracePeriod leaves new screenshots to their background scan before
+	// the scheduled job picks them up
+	scanGracePeriod = 5 * time.Minute
+)
+
+// ErrScreenshotNotQuarantined is returned when reviewing a screenshot the
+// scanner did not flag
+var ErrScreenshotNotQuarantined = apperror.Conflict("screenshot is not quarantined")
+
+// ScreenshotScanService scans uploaded screenshot files for malware and lets
+// system admins review the quarantined ones
+type ScreenshotScanService interface {
+	// Enabled reports whether a scanner is configured; new screenshots are
+	// then stored as pending
+	Enabled() bool
+	// ScanAsync scans a new screenshot in the background
+	ScanAsync(id uint)
+	// ScanPending scans screenshots still pending, e.g. after a restart or a
+	// scanner outage; it matches scheduler.JobFunc
+	ScanPending(ctx context.Context) error
+
+	// Release clears a quarantined screenshot flagged by mistake, moving its
+	// file back out of quarantine
+	Release(id, adminID uint) error
+	// DeleteQuarantined deletes a quarantined screenshot and its file
+	DeleteQuarantined(id uint) error
+}
+
+type screenshotScanService struct {
+	scanRepo       repository.ScreenshotScanRepository
+	screenshotRepo repository.ScreenshotRepository
+	scanner        scanner.Scanner // nil when scanning is disabled
+	timeout        time.Duration
+	slots          chan struct{}
+}
+
+// NewScreenshotScanService creates a new screenshot scan service. scanner may
+// be nil, which disables scanning.
+func NewScreenshotScanService(scanRepo repository.ScreenshotScanRepository, screenshotRepo repository.ScreenshotRepository, fileScanner scanner.Scanner, timeout time.Duration) ScreenshotScanService {
+	return &screenshotScanService{
+		scanRepo:       scanRepo,
+		screenshotRepo: screenshotRepo,
+		scanner:        fileScanner,
+		timeout:        timeout,
+		slots:          make(chan struct{}, scanConcurrency),
+	}
+}
+
+func (s *screenshotScanService) Enabled() bool {
+	return s.scanner != nil
+}
+
+func (s *screenshotScanService) ScanAsync(id uint) {
+	if s.scanner == nil {
+		return
+	}
+	select {
+	case s.slots <- struct{}{}:
+	default:
+		return
+	}
+
+	go func() {
+		defer func() { <-s.slots }()
+		screenshot, err := s.scanRepo.FindByID(id)
+		if err != nil {
+			log.Printf("⚠️  Malware scan: screenshot %d: %v", id, err)
+			return
+		}
+		if err := s.scan(context.Background(), screenshot); err != nil {
+			// Left pending; retried by the scheduled job
+			log.Printf("⚠️  Malware scan: screenshot %d: %v", id, err)
+		}
+	}()
+}
+
+func (s *screenshotScanService) ScanPending(ctx context.Context) error {
+	if s.scanner == nil {
+		return nil
+	}
+
+	cutoff := time.Now().Add(-scanGracePeriod)
+	scanned := 0
+	var afterID uint
+
+	for {
+		batch, err := s.scanRepo.FindPending(cutoff, afterID, scanBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			break
+		}
+		afterID = batch[len(batch)-1].ID
+
+		for i := range batch {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := s.scan(ctx, &batch[i]); err != nil {
+				log.Printf("⚠️  Malware scan: screenshot %d: %v", batch[i].ID, err)
+				continue
+			}
+			scanned++
+		}
+
+		if len(batch) < scanBatchSize {
+			break
+		}
+	}
+
+	if scanned > 0 {
+		log.Printf("✅ Scanned %d pending screenshots for malware", scanned)
+	}
+	return nil
+}
+
+// scan scans a screenshot's file and records the verdict, moving infected
+// files into quarantine
+func (s *screenshotScanService) scan(ctx context.Context, screenshot *models.Screenshot) error {
+	if screenshot.ScanStatus != models.ScreenshotScanPending {
+		return nil
+	}
+	data, err := os.ReadFile(screenshot.FilePath)
+	if err != nil {
+		return err
+	}
+
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+	result, err := s.scanner.Scan(ctx, data)
+	if err != nil {
+		return err
+	}
+
+	status := models.ScreenshotScanClean
+	if result.Infected {
+		status = models.ScreenshotScanQuarantined
+		log.Printf("🚨 Screenshot %d of user %d quarantined: %s", screenshot.ID, screenshot.UserID, result.Signature)
+	}
+	if err := s.scanRepo.SetResult(screenshot.ID, status, result.Signature, time.Now()); err != nil {
+		return err
+	}
+	if result.Infected {
+		return s.moveFile(screenshot, quarantineDir)
+	}
+	return nil
+}
+
+// moveFile moves a screenshot's original into dir, and the screenshots
+// sharing it along. Blurred variants are re-encoded and are not scanned.
+func (s *screenshotScanService) moveFile(screenshot *models.Screenshot, dir string) error {
+	filePath := filepath.Join(config.AppConfig.Upload.PrivatePath, dir, filepath.Base(screenshot.FilePath))
+	if filePath == screenshot.FilePath {
+		return nil
+	}
+	if err := utils.MoveFile(screenshot.FilePath, filePath); err != nil {
+		return err
+	}
+	return s.screenshotRepo.ReplacePath(screenshot.FilePath, filePath)
+}
+
+// findQuarantined loads a screenshot under review
+func (s *screenshotScanService) findQuarantined(id uint) (*models.Screenshot, error) {
+	screenshot, err := s.scanRepo.FindByID(id)
+	if err != nil {
+		return nil, apperror.NotFound("screenshot not found")
+	}
+	if screenshot.ScanStatus != models.ScreenshotScanQuarantined {
+		return nil, ErrScreenshotNotQuarantined
+	}
+	return screenshot, nil
+}
+
+func (s *screenshotScanService) Release(id, adminID uint) error {
+	screenshot, err := s.findQuarantined(id)
+	if err != nil {
+		return err
+	}
+	if err := s.moveFile(screenshot, screenshotDir); err != nil {
+		return err
+	}
+	return s.scanRepo.Release(id, adminID, time.Now())
+}
+
+func (s *screenshotScanService) DeleteQuarantined(id uint) error {
+	screenshot, err := s.findQuarantined(id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.screenshotRepo.Delete(id); err != nil {
+		return err
+	}
+	// The file goes right away rather than with the purge of soft-deleted
+	// rows, unless identical screenshots still share it
+	for _, path := range []string{screenshot.FilePath, screenshot.BlurredPath} {
+		if err := s.screenshotRepo.DeleteFile(path); err != nil {
+			log.Printf("⚠️  Failed to delete quarantined screenshot file %s: %v", path, err)
+		}
+	}
+	return nil
+}
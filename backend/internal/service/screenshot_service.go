@@ -2,15 +2,61 @@ package service
 
 import (
 	"errors"
+	"io/fs"
+	"log"
+	"path/filepath"
 	"time"
 
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/config"
 	"github.com/beuphecan/remote-time-tracker/internal/models"
 	"github.com/beuphecan/remote-time-tracker/internal/repository"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
 )
 
+// Screenshot originals, blurred variants and the files the malware scanner
+// flagged are kept apart under the private upload path
+const (
+	screenshotDir        = "screenshots"
+	blurredScreenshotDir = "screenshots/blurred"
+	quarantineDir        = "screenshots-quarantine"
+)
+
+// screenshotDedupeWindow is how far apart identical screenshots of a user may
+// be captured and still share one file
+const screenshotDedupeWindow = time.Hour
+
+// ErrOriginalRestricted is returned when a manager asks for a screenshot
+// whose original only system admins may view and no blurred variant exists
+var ErrOriginalRestricted = apperror.Forbidden("the original screenshot is restricted to system admins")
+
+// ErrScreenshotDownloadDenied is returned when downloading another member's
+// screenshot without the screenshots.download permission
+var ErrScreenshotDownloadDenied = apperror.Forbidden("you cannot download other members' screenshots in this workspace")
+
+// ErrScreenshotQuarantined is returned when viewing a screenshot whose file
+// the malware scanner flagged; only system admins reviewing it may see it
+var ErrScreenshotQuarantined = apperror.Forbidden("the screenshot file is quarantined pending an admin review")
+
+// ErrScreenshotScanPending is returned when viewing another member's
+// screenshot before the malware scanner has checked its file
+var ErrScreenshotScanPending = apperror.Forbidden("the screenshot file is waiting for a malware scan")
+
+// ScreenshotView is the screenshot file a viewer may see
+type ScreenshotView struct {
+	Screenshot *models.Screenshot
+	Path       string
+	Blurred    bool // Path is the blurred variant, which never moves to cold storage
+}
+
 // ScreenshotService handles business logic for screenshots
 type ScreenshotService interface {
 	GetScreenshot(id uint, userID uint) (*models.Screenshot, error)
+	// GetScreenshotView resolves the file to serve. Owners get the original
+	// unless their role revokes screenshots.view_own. Members with
+	// screenshots.view get the blurred variant when their workspace blurs
+	// screenshots; downloading their files also needs screenshots.download.
+	GetScreenshotView(id uint, userID uint, download bool) (*ScreenshotView, error)
 	GetScreenshotsByUser(userID uint, page, perPage int) ([]models.Screenshot, int64, error)
 	GetScreenshotsByTimeLog(timeLogID uint, userID uint) ([]models.Screenshot, error)
 	GetScreenshotsByTaskID(taskID uint, userID uint) ([]models.Screenshot, error)
@@ -18,12 +64,17 @@ type ScreenshotService interface {
 	DeleteScreenshot(id uint, userID uint) error
 	GetScreenshotStats(userID uint, startDate, endDate time.Time) (map[string]interface{}, error)
 	GetTodayScreenshotCount(userID uint) (int64, error)
+	// MovePublicScreenshots moves screenshot files left in the legacy public
+	// upload directory into the private one
+	MovePublicScreenshots() error
 }
 
 type screenshotService struct {
-	screenshotRepo repository.ScreenshotRepository
-	timeLogRepo    repository.TimeLogRepository
-	taskRepo       repository.TaskRepository
+	screenshotRepo   repository.ScreenshotRepository
+	timeLogRepo      repository.TimeLogRepository
+	taskRepo         repository.TaskRepository
+	workspaceRepo    *repository.WorkspaceRepository
+	workspaceService WorkspaceService
 }
 
 // NewScreenshotService creates a new screenshot service
@@ -31,11 +82,15 @@ func NewScreenshotService(
 	screenshotRepo repository.ScreenshotRepository,
 	timeLogRepo repository.TimeLogRepository,
 	taskRepo repository.TaskRepository,
+	workspaceRepo *repository.WorkspaceRepository,
+	workspaceService WorkspaceService,
 ) ScreenshotService {
 	return &screenshotService{
-		screenshotRepo: screenshotRepo,
-		timeLogRepo:    timeLogRepo,
-		taskRepo:       taskRepo,
+		screenshotRepo:   screenshotRepo,
+		timeLogRepo:      timeLogRepo,
+		taskRepo:         taskRepo,
+		workspaceRepo:    workspaceRepo,
+		workspaceService: workspaceService,
 	}
 }
 
@@ -47,13 +102,63 @@ func (s *screenshotService) GetScreenshot(id uint, userID uint) (*models.Screens
 	}
 
 	// Check ownership
-	if screenshot.UserID != userID {
-		return nil, errors.New("unauthorized access to screenshot")
+	if screenshot.UserID != userID || !s.canViewOwn(screenshot.WorkspaceID, userID) {
+		return nil, apperror.NotFound("unauthorized access to screenshot")
 	}
 
 	return screenshot, nil
 }
 
+func (s *screenshotService) GetScreenshotView(id uint, userID uint, download bool) (*ScreenshotView, error) {
+	screenshot, err := s.screenshotRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if screenshot.ScanStatus == models.ScreenshotScanQuarantined {
+		return nil, ErrScreenshotQuarantined
+	}
+
+	if screenshot.UserID == userID {
+		if !s.canViewOwn(screenshot.WorkspaceID, userID) {
+			return nil, apperror.NotFound("unauthorized access to screenshot")
+		}
+		return &ScreenshotView{Screenshot: screenshot, Path: screenshot.FilePath}, nil
+	}
+	if screenshot.ScanStatus == models.ScreenshotScanPending {
+		return nil, ErrScreenshotScanPending
+	}
+
+	if screenshot.WorkspaceID == nil {
+		return nil, apperror.NotFound("unauthorized access to screenshot")
+	}
+	canView, err := s.workspaceService.HasPermission(*screenshot.WorkspaceID, userID, models.PermScreenshotsView)
+	if err != nil || !canView {
+		return nil, apperror.NotFound("unauthorized access to screenshot")
+	}
+
+	if download {
+		canDownload, err := s.workspaceService.HasPermission(*screenshot.WorkspaceID, userID, models.PermScreenshotsDownload)
+		if err != nil {
+			return nil, err
+		}
+		if !canDownload {
+			return nil, ErrScreenshotDownloadDenied
+		}
+	}
+
+	if screenshot.BlurredPath != "" {
+		return &ScreenshotView{Screenshot: screenshot, Path: screenshot.BlurredPath, Blurred: true}, nil
+	}
+	workspace, err := s.workspaceRepo.GetByID(*screenshot.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if workspace.ScreenshotBlurMode == models.ScreenshotBlurBlur {
+		return nil, ErrOriginalRestricted
+	}
+	return &ScreenshotView{Screenshot: screenshot, Path: screenshot.FilePath}, nil
+}
+
 // GetScreenshotsByUser retrieves screenshots for a user with pagination
 func (s *screenshotService) GetScreenshotsByUser(userID uint, page, perPage int) ([]models.Screenshot, int64, error) {
 	if page < 1 {
@@ -63,7 +168,11 @@ func (s *screenshotService) GetScreenshotsByUser(userID uint, page, perPage int)
 		perPage = 20
 	}
 
-	return s.screenshotRepo.FindByUserID(userID, page, perPage)
+	hidden, err := s.hiddenOwnWorkspaces(userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.screenshotRepo.FindByUserID(userID, hidden, page, perPage)
 }
 
 // GetScreenshotsByTimeLog retrieves all screenshots for a specific timelog
@@ -75,10 +184,14 @@ func (s *screenshotService) GetScreenshotsByTimeLog(timeLogID uint, userID uint)
 	}
 
 	if timeLog.UserID != userID {
-		return nil, errors.New("unauthorized access to timelog")
+		return nil, apperror.NotFound("unauthorized access to timelog")
 	}
 
-	return s.screenshotRepo.FindByTimeLogID(timeLogID)
+	screenshots, err := s.screenshotRepo.FindByTimeLogID(timeLogID)
+	if err != nil {
+		return nil, err
+	}
+	return s.filterOwn(screenshots, userID), nil
 }
 
 // GetScreenshotsByTaskID retrieves all screenshots for a specific task
@@ -91,7 +204,7 @@ func (s *screenshotService) GetScreenshotsByTaskID(taskID uint, userID uint) ([]
 
 	// Verify ownership
 	if task.UserID != userID {
-		return nil, errors.New("unauthorized access to task")
+		return nil, apperror.NotFound("unauthorized access to task")
 	}
 
 	// Query screenshots by task_local_id (primary) OR task_id (fallback for old data)
@@ -101,22 +214,84 @@ func (s *screenshotService) GetScreenshotsByTaskID(taskID uint, userID uint) ([]
 		return nil, err
 	}
 
-	return screenshots, nil
+	return s.filterOwn(screenshots, userID), nil
 }
 
 // GetScreenshotsByDateRange retrieves screenshots within a date range
 func (s *screenshotService) GetScreenshotsByDateRange(userID uint, startDate, endDate time.Time) ([]models.Screenshot, error) {
 	// Validate date range
 	if endDate.Before(startDate) {
-		return nil, errors.New("end date must be after start date")
+		return nil, apperror.Validation("end date must be after start date", nil)
 	}
 
 	// Limit to 90 days
 	if endDate.Sub(startDate) > 90*24*time.Hour {
-		return nil, errors.New("date range cannot exceed 90 days")
+		return nil, apperror.Validation("date range cannot exceed 90 days", nil)
 	}
 
-	return s.screenshotRepo.FindByDateRange(userID, startDate, endDate)
+	screenshots, err := s.screenshotRepo.FindByDateRange(userID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	return s.filterOwn(screenshots, userID), nil
+}
+
+// canViewOwn reports whether the user may see their own screenshots of a
+// workspace. Only an active membership whose role revokes
+// screenshots.view_own hides them; personal screenshots and those of
+// workspaces the user left stay visible.
+func (s *screenshotService) canViewOwn(workspaceID *uint, userID uint) bool {
+	if workspaceID == nil {
+		return true
+	}
+	member, err := s.workspaceRepo.GetMember(*workspaceID, userID)
+	if err != nil || !member.IsActive {
+		return true
+	}
+	canView, err := s.workspaceService.HasPermission(*workspaceID, userID, models.PermScreenshotsViewOwn)
+	return err == nil && canView
+}
+
+// hiddenOwnWorkspaces lists the workspaces whose screenshots the user may
+// not see, though they are their own
+func (s *screenshotService) hiddenOwnWorkspaces(userID uint) ([]uint, error) {
+	memberships, err := s.workspaceRepo.GetUserWorkspaces(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var hidden []uint
+	for _, m := range memberships {
+		canView, err := s.workspaceService.HasPermission(m.WorkspaceID, userID, models.PermScreenshotsViewOwn)
+		if err != nil {
+			return nil, err
+		}
+		if !canView {
+			hidden = append(hidden, m.WorkspaceID)
+		}
+	}
+	return hidden, nil
+}
+
+// filterOwn drops the user's screenshots of workspaces where they may not
+// see them
+func (s *screenshotService) filterOwn(screenshots []models.Screenshot, userID uint) []models.Screenshot {
+	allowed := make(map[uint]bool)
+	visible := screenshots[:0]
+	for _, screenshot := range screenshots {
+		if screenshot.WorkspaceID != nil {
+			canView, ok := allowed[*screenshot.WorkspaceID]
+			if !ok {
+				canView = s.canViewOwn(screenshot.WorkspaceID, userID)
+				allowed[*screenshot.WorkspaceID] = canView
+			}
+			if !canView {
+				continue
+			}
+		}
+		visible = append(visible, screenshot)
+	}
+	return visible
 }
 
 // DeleteScreenshot deletes a screenshot (both DB record and file)
@@ -128,7 +303,12 @@ func (s *screenshotService) DeleteScreenshot(id uint, userID uint) error {
 	}
 
 	if screenshot.UserID != userID {
-		return errors.New("unauthorized access to screenshot")
+		return apperror.NotFound("unauthorized access to screenshot")
+	}
+
+	// Organization screenshots go through the manager approval queue
+	if screenshot.OrganizationID != nil {
+		return apperror.Validation("organization screenshots require an approved deletion request", nil)
 	}
 
 	// Delete from database first
@@ -144,6 +324,7 @@ func (s *screenshotService) DeleteScreenshot(id uint, userID uint) error {
 		// In production, you might want to use a proper logger here
 		_ = err
 	}
+	_ = s.screenshotRepo.DeleteFile(screenshot.BlurredPath)
 
 	return nil
 }
@@ -223,3 +404,39 @@ func (s *screenshotService) GetScreenshotStats(userID uint, startDate, endDate t
 func (s *screenshotService) GetTodayScreenshotCount(userID uint) (int64, error) {
 	return s.screenshotRepo.CountTodayScreenshots(userID)
 }
+
+// screenshotIDs returns the ids of the screenshots
+func screenshotIDs(screenshots []models.Screenshot) []uint {
+	ids := make([]uint, len(screenshots))
+	for i := range screenshots {
+		ids[i] = screenshots[i].ID
+	}
+	return ids
+}
+
+func (s *screenshotService) MovePublicScreenshots() error {
+	publicDir := filepath.Clean(config.AppConfig.Upload.Path) + string(filepath.Separator)
+	paths, err := s.screenshotRepo.FindPathsUnder(publicDir)
+	if err != nil {
+		return err
+	}
+
+	moved := 0
+	for _, oldPath := range paths {
+		filePath := filepath.Join(config.AppConfig.Upload.PrivatePath, oldPath[len(publicDir):])
+		// Files in cold storage have no local copy; only their path moves
+		if err := utils.MoveFile(oldPath, filePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			log.Printf("⚠️  Failed to move screenshot file %s: %v", oldPath, err)
+			continue
+		}
+		if err := s.screenshotRepo.ReplacePath(oldPath, filePath); err != nil {
+			return err
+		}
+		moved++
+	}
+
+	if moved > 0 {
+		log.Printf("✅ Moved %d screenshot files out of the public uploads directory", moved)
+	}
+	return nil
+}
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/objectstore"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+)
+
+// tierBatchSize bounds how many screenshots are moved per query
+const tierBatchSize = 200
+
+// ErrRetrievalPending is returned while a cold screenshot is being restored
+// from archive storage; the client should retry later
+var ErrRetrievalPending = apperror.Conflict("screenshot is being retrieved from cold storage")
+
+// ScreenshotTierService moves old screenshot files to cold storage and
+// brings them back on demand
+type ScreenshotTierService interface {
+	// TierOldScreenshots moves files of screenshots older than the configured
+	// age to cold storage; it matches scheduler.JobFunc
+	TierOldScreenshots(ctx context.Context) error
+	// EnsureLocal makes a screenshot's file available at its FilePath,
+	// retrieving it from cold storage if needed. Returns ErrRetrievalPending
+	// while an archive restore is in progress.
+	EnsureLocal(ctx context.Context, screenshot *models.Screenshot) error
+	EnsureLocalByID(ctx context.Context, id uint) error
+	// DeleteColdCopies removes the cold copies of deleted screenshot files;
+	// failures are logged
+	DeleteColdCopies(ctx context.Context, filePaths []string)
+}
+
+type screenshotTierService struct {
+	tierRepo    repository.ScreenshotTierRepository
+	store       objectstore.Store // nil when cold storage is disabled
+	uploadRoot  string
+	afterDays   int
+	restoreDays int
+}
+
+// NewScreenshotTierService creates a new screenshot tier service. store may be
+// nil, which disables tiering; screenshots already cold then cannot be read.
+func NewScreenshotTierService(tierRepo repository.ScreenshotTierRepository, store objectstore.Store, uploadRoot string, afterDays, restoreDays int) ScreenshotTierService {
+	return &screenshotTierService{
+		tierRepo:    tierRepo,
+		store:       store,
+		uploadRoot:  uploadRoot,
+		afterDays:   afterDays,
+		restoreDays: restoreDays,
+	}
+}
+
+// coldKey maps a screenshot file to its object key, mirroring its path under
+// the upload directory
+func (s *screenshotTierService) coldKey(filePath string) string {
+	rel, err := filepath.Rel(s.uploadRoot, filePath)
+	if err != nil || !filepath.IsLocal(rel) {
+		rel = filepath.Join("screenshots", filepath.Base(filePath))
+	}
+	return filepath.ToSlash(rel)
+}
+
+func (s *screenshotTierService) TierOldScreenshots(ctx context.Context) error {
+	if s.store == nil || s.afterDays <= 0 {
+		return nil
+	}
+
+	cutoff := time.Now().UTC().AddDate(0, 0, -s.afterDays)
+	moved := 0
+	var freed int64
+	var afterID uint
+	// Identical screenshots share a file, which moves once for all of them
+	movedFiles := make(map[string]bool)
+
+	for {
+		batch, err := s.tierRepo.FindTierable(cutoff, afterID, tierBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			break
+		}
+		afterID = batch[len(batch)-1].ID
+
+		for i := range batch {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if movedFiles[batch[i].FilePath] {
+				continue
+			}
+			if err := s.moveToCold(ctx, &batch[i]); err != nil {
+				// Left hot; retried on the next run
+				log.Printf("⚠️  Cold storage: screenshot %d: %v", batch[i].ID, err)
+				continue
+			}
+			movedFiles[batch[i].FilePath] = true
+			moved++
+			freed += batch[i].FileSize
+		}
+
+		if len(batch) < tierBatchSize {
+			break
+		}
+	}
+
+	if moved > 0 {
+		log.Printf("✅ Moved %d screenshots to cold storage (%d bytes freed)", moved, freed)
+	}
+	return nil
+}
+
+// moveToCold uploads the file (unless a copy from an earlier tiering exists),
+// marks the row cold and only then deletes the local file
+func (s *screenshotTierService) moveToCold(ctx context.Context, ss *models.Screenshot) error {
+	key := ss.ColdKey
+	if key == "" {
+		data, err := os.ReadFile(ss.FilePath)
+		if err != nil {
+			return err
+		}
+		key = s.coldKey(ss.FilePath)
+		if err := s.store.Put(ctx, key, data); err != nil {
+			return err
+		}
+	}
+
+	if err := s.tierRepo.MarkCold(ss.ID, key, time.Now()); err != nil {
+		return err
+	}
+	return utils.DeleteFile(ss.FilePath)
+}
+
+func (s *screenshotTierService) EnsureLocal(ctx context.Context, ss *models.Screenshot) error {
+	if ss.StorageTier != models.StorageTierCold {
+		return nil
+	}
+	// Another request may have retrieved it already
+	if utils.FileExists(ss.FilePath) {
+		return nil
+	}
+	if s.store == nil {
+		return apperror.Unavailable("screenshot is in cold storage, which is not configured")
+	}
+
+	if restorer, ok := s.store.(objectstore.Restorer); ok {
+		ready, err := restorer.Restore(ctx, ss.ColdKey, s.restoreDays)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve screenshot: %w", err)
+		}
+		if !ready {
+			return ErrRetrievalPending
+		}
+	}
+
+	if err := s.download(ctx, ss); err != nil {
+		return fmt.Errorf("failed to retrieve screenshot: %w", err)
+	}
+
+	now := time.Now()
+	if err := s.tierRepo.MarkRetrieved(ss.ID, now); err != nil {
+		return err
+	}
+	ss.StorageTier = models.StorageTierHot
+	ss.RetrievedAt = &now
+	return nil
+}
+
+func (s *screenshotTierService) EnsureLocalByID(ctx context.Context, id uint) error {
+	screenshot, err := s.tierRepo.FindByID(id)
+	if err != nil {
+		return err
+	}
+	return s.EnsureLocal(ctx, screenshot)
+}
+
+// download writes the cold copy to a temporary file and renames it into
+// place, so concurrent readers never see a partial file
+func (s *screenshotTierService) download(ctx context.Context, ss *models.Screenshot) error {
+	r, err := s.store.Get(ctx, ss.ColdKey)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	if err := os.MkdirAll(filepath.Dir(ss.FilePath), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(ss.FilePath), ".retrieve-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), ss.FilePath)
+}
+
+func (s *screenshotTierService) DeleteColdCopies(ctx context.Context, filePaths []string) {
+	if s.store == nil {
+		return
+	}
+	for _, path := range filePaths {
+		if path == "" {
+			continue
+		}
+		if err := s.store.Delete(ctx, s.coldKey(path)); err != nil {
+			log.Printf("⚠️  Failed to delete cold copy of %s: %v", path, err)
+		}
+	}
+}
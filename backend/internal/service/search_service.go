@@ -0,0 +1,158 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+)
+
+// Search result types
+const (
+	SearchTypeTask    = "task"
+	SearchTypeTimeLog = "time_log"
+	SearchTypeMember  = "member"
+)
+
+const (
+	searchDefaultLimit = 20
+	searchMaxLimit     = 50
+	searchMaxTerms     = 8
+)
+
+// ErrInvalidSearch is wrapped by search validation errors
+var ErrInvalidSearch = apperror.Validation("invalid search", nil)
+
+// SearchService searches tasks, time log notes and members across the
+// caller's organizations using PostgreSQL full-text search
+type SearchService interface {
+	Search(userID uint, params *dto.SearchParams) (*dto.SearchResponse, error)
+}
+
+type searchService struct {
+	searchRepo repository.SearchRepository
+	orgRepo    *repository.OrganizationRepository
+}
+
+// NewSearchService creates a new search service
+func NewSearchService(searchRepo repository.SearchRepository, orgRepo *repository.OrganizationRepository) SearchService {
+	return &searchService{
+		searchRepo: searchRepo,
+		orgRepo:    orgRepo,
+	}
+}
+
+func (s *searchService) Search(userID uint, params *dto.SearchParams) (*dto.SearchResponse, error) {
+	query := searchTSQuery(params.Query)
+	if query == "" {
+		return nil, fmt.Errorf("%w: enter at least one word to search for", ErrInvalidSearch)
+	}
+	if params.Limit < 1 || params.Limit > searchMaxLimit {
+		params.Limit = searchDefaultLimit
+	}
+
+	types := map[string]bool{}
+	for _, t := range params.Types {
+		switch t {
+		case SearchTypeTask, SearchTypeTimeLog, SearchTypeMember:
+			types[t] = true
+		default:
+			return nil, fmt.Errorf("%w: unknown result type %q", ErrInvalidSearch, t)
+		}
+	}
+	if len(types) == 0 {
+		types = map[string]bool{SearchTypeTask: true, SearchTypeTimeLog: true, SearchTypeMember: true}
+	}
+
+	if params.OrganizationID != nil {
+		isMember, err := s.orgRepo.IsMember(*params.OrganizationID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !isMember {
+			return nil, apperror.Forbidden("access denied: you are not a member of this organization")
+		}
+	}
+
+	searches := []struct {
+		resultType string
+		search     func(userID uint, query string, orgID *uint, limit int) ([]repository.SearchRow, error)
+	}{
+		{SearchTypeTask, s.searchRepo.SearchTasks},
+		{SearchTypeTimeLog, s.searchRepo.SearchTimeLogs},
+		{SearchTypeMember, s.searchRepo.SearchMembers},
+	}
+
+	results := []dto.SearchResult{}
+	for _, search := range searches {
+		if !types[search.resultType] {
+			continue
+		}
+		rows, err := search.search(userID, query, params.OrganizationID, params.Limit)
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			results = append(results, toSearchResult(search.resultType, &row))
+		}
+	}
+
+	// Each type is ranked by the database; merge them by rank
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Rank > results[j].Rank
+	})
+	if len(results) > params.Limit {
+		results = results[:params.Limit]
+	}
+
+	return &dto.SearchResponse{Query: params.Query, Results: results}, nil
+}
+
+// searchTSQuery turns free text into a to_tsquery expression matching
+// every word as a prefix. Operators and quotes are dropped, so user input
+// never reaches the query parser as syntax.
+func searchTSQuery(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsMark(r) && !unicode.IsDigit(r) && !strings.ContainsRune("@._-", r)
+	})
+
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		word = strings.Trim(word, "@._-")
+		if word == "" {
+			continue
+		}
+		terms = append(terms, word+":*")
+		if len(terms) == searchMaxTerms {
+			break
+		}
+	}
+	return strings.Join(terms, " & ")
+}
+
+func toSearchResult(resultType string, row *repository.SearchRow) dto.SearchResult {
+	title := row.Title
+	if title == "" {
+		switch resultType {
+		case SearchTypeTimeLog:
+			title = "Untitled time log"
+		case SearchTypeMember:
+			title = row.Snippet
+		}
+	}
+	return dto.SearchResult{
+		Type:           resultType,
+		ID:             row.ID,
+		Title:          title,
+		Snippet:        row.Snippet,
+		OrganizationID: row.OrganizationID,
+		WorkspaceID:    row.WorkspaceID,
+		UserID:         row.UserID,
+		Date:           row.OccurredAt,
+		Rank:           row.Rank,
+	}
+}
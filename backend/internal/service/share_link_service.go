@@ -0,0 +1,247 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/format"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+)
+
+var (
+	// ErrShareLinkNotFound is returned for unknown tokens and links of other workspaces
+	ErrShareLinkNotFound = apperror.NotFound("share link not found")
+	// ErrShareLinkExpired is returned when viewing an expired or revoked link
+	ErrShareLinkExpired = apperror.New(http.StatusGone, "share link has expired or was revoked")
+)
+
+// ShareLinkService manages expiring links that show a workspace's report of
+// tracked hours and tasks to people outside the organization, without
+// screenshots or member details
+type ShareLinkService interface {
+	List(workspaceID, userID uint) ([]dto.ShareLinkResponse, error)
+	Create(workspaceID, userID uint, req *dto.CreateShareLinkRequest) (*dto.ShareLinkResponse, error)
+	Revoke(workspaceID, linkID, userID uint) error
+	// View shows the report of a link and counts the view. A link with a
+	// fixed range ignores the range of params.
+	View(token string, params *dto.ReportSummaryParams, locale format.Locale) (*dto.SharedReportResponse, error)
+}
+
+type shareLinkService struct {
+	shareLinkRepo    repository.ShareLinkRepository
+	workspaceRepo    *repository.WorkspaceRepository
+	workspaceService WorkspaceService
+	reportService    ReportService
+	settings         OrganizationSettingsService
+}
+
+// NewShareLinkService creates a new share link service
+func NewShareLinkService(
+	shareLinkRepo repository.ShareLinkRepository,
+	workspaceRepo *repository.WorkspaceRepository,
+	workspaceService WorkspaceService,
+	reportService ReportService,
+	settings OrganizationSettingsService,
+) ShareLinkService {
+	return &shareLinkService{
+		shareLinkRepo:    shareLinkRepo,
+		workspaceRepo:    workspaceRepo,
+		workspaceService: workspaceService,
+		reportService:    reportService,
+		settings:         settings,
+	}
+}
+
+func (s *shareLinkService) requireReportsView(workspaceID, userID uint) error {
+	canView, err := s.workspaceService.HasPermission(workspaceID, userID, models.PermReportsView)
+	if err != nil {
+		return err
+	}
+	if !canView {
+		return apperror.Forbidden("access denied: you cannot share reports of this workspace")
+	}
+	return nil
+}
+
+func (s *shareLinkService) List(workspaceID, userID uint) ([]dto.ShareLinkResponse, error) {
+	if err := s.requireReportsView(workspaceID, userID); err != nil {
+		return nil, err
+	}
+
+	links, err := s.shareLinkRepo.FindByWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	responses := make([]dto.ShareLinkResponse, 0, len(links))
+	for i := range links {
+		responses = append(responses, toShareLinkResponse(&links[i], now))
+	}
+	return responses, nil
+}
+
+func (s *shareLinkService) Create(workspaceID, userID uint, req *dto.CreateShareLinkRequest) (*dto.ShareLinkResponse, error) {
+	if err := s.requireReportsView(workspaceID, userID); err != nil {
+		return nil, err
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(workspaceID)
+	if err != nil {
+		return nil, apperror.NotFound("workspace not found")
+	}
+	if !s.settings.Bool(workspace.OrganizationID, models.SettingShareLinksEnabled) {
+		return nil, apperror.Forbidden("share links are turned off for this organization")
+	}
+
+	startDate, endDate, err := parseShareLinkRange(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := generateShareLinkToken()
+	if err != nil {
+		return nil, err
+	}
+
+	days := req.ExpiresInDays
+	if days == 0 {
+		days = s.settings.Int(workspace.OrganizationID, models.SettingShareLinksExpiryDays)
+	}
+	now := time.Now().UTC()
+	link := &models.ShareLink{
+		WorkspaceID: workspaceID,
+		Name:        strings.TrimSpace(req.Name),
+		Token:       token,
+		StartDate:   startDate,
+		EndDate:     endDate,
+		ExpiresAt:   now.AddDate(0, 0, days),
+		CreatedBy:   userID,
+	}
+	if err := s.shareLinkRepo.Create(link); err != nil {
+		return nil, err
+	}
+
+	response := toShareLinkResponse(link, now)
+	return &response, nil
+}
+
+// parseShareLinkRange parses the optional fixed report range of a link;
+// both dates or neither must be given
+func parseShareLinkRange(start, end string) (*time.Time, *time.Time, error) {
+	if start == "" && end == "" {
+		return nil, nil, nil
+	}
+	if start == "" || end == "" {
+		return nil, nil, apperror.Validation("start_date and end_date must be given together", nil)
+	}
+
+	startDate, err := time.Parse("2006-01-02", start)
+	if err != nil {
+		return nil, nil, apperror.Validation("invalid start_date: use YYYY-MM-DD", nil)
+	}
+	endDate, err := time.Parse("2006-01-02", end)
+	if err != nil {
+		return nil, nil, apperror.Validation("invalid end_date: use YYYY-MM-DD", nil)
+	}
+	if endDate.Before(startDate) || endDate.Sub(startDate) > 366*24*time.Hour {
+		return nil, nil, apperror.Validation("invalid date range: end_date must not be before start_date and the range cannot exceed 366 days", nil)
+	}
+	return &startDate, &endDate, nil
+}
+
+func (s *shareLinkService) Revoke(workspaceID, linkID, userID uint) error {
+	if err := s.requireReportsView(workspaceID, userID); err != nil {
+		return err
+	}
+
+	link, err := s.shareLinkRepo.FindByID(linkID)
+	if err != nil || link.WorkspaceID != workspaceID {
+		return ErrShareLinkNotFound
+	}
+	if link.RevokedAt != nil {
+		return nil
+	}
+	return s.shareLinkRepo.Revoke(link.ID, time.Now().UTC())
+}
+
+func (s *shareLinkService) View(token string, params *dto.ReportSummaryParams, locale format.Locale) (*dto.SharedReportResponse, error) {
+	if !strings.HasPrefix(token, "shr_") {
+		return nil, ErrShareLinkNotFound
+	}
+	link, err := s.shareLinkRepo.FindByToken(token)
+	if err != nil {
+		return nil, ErrShareLinkNotFound
+	}
+
+	now := time.Now().UTC()
+	if !shareLinkActive(link, now) {
+		return nil, ErrShareLinkExpired
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(link.WorkspaceID)
+	if err != nil {
+		return nil, ErrShareLinkNotFound
+	}
+
+	if link.StartDate != nil && link.EndDate != nil {
+		params.StartDate, params.EndDate = *link.StartDate, *link.EndDate
+	}
+	params.WorkspaceID = nil
+	summary, err := s.reportService.GetSharedWorkspaceSummary(workspace.ID, params, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.shareLinkRepo.RecordView(link.ID, now); err != nil {
+		log.Printf("⚠️  Failed to record view of share link %d: %v", link.ID, err)
+	}
+
+	return &dto.SharedReportResponse{
+		WorkspaceName: workspace.Name,
+		Name:          link.Name,
+		ExpiresAt:     link.ExpiresAt,
+		Report:        *summary,
+	}, nil
+}
+
+func shareLinkActive(link *models.ShareLink, now time.Time) bool {
+	return link.RevokedAt == nil && now.Before(link.ExpiresAt)
+}
+
+func generateShareLinkToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.New("failed to generate share link token")
+	}
+	return "shr_" + hex.EncodeToString(b), nil
+}
+
+func toShareLinkResponse(link *models.ShareLink, now time.Time) dto.ShareLinkResponse {
+	response := dto.ShareLinkResponse{
+		ID:           link.ID,
+		WorkspaceID:  link.WorkspaceID,
+		Name:         link.Name,
+		URL:          "/api/v1/public/share-links/" + link.Token,
+		ExpiresAt:    link.ExpiresAt,
+		RevokedAt:    link.RevokedAt,
+		Active:       shareLinkActive(link, now),
+		ViewCount:    link.ViewCount,
+		LastViewedAt: link.LastViewedAt,
+		CreatedBy:    link.CreatedBy,
+		CreatedAt:    link.CreatedAt,
+	}
+	if link.StartDate != nil && link.EndDate != nil {
+		start, end := link.StartDate.Format("2006-01-02"), link.EndDate.Format("2006-01-02")
+		response.StartDate, response.EndDate = &start, &end
+	}
+	return response
+}
@@ -0,0 +1,596 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/config"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/format"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+	"gorm.io/gorm"
+)
+
+// Slack OAuth endpoints
+var (
+	slackAuthorizeURL = "https://slack.com/oauth/v2/authorize"
+	slackOAuthURL     = "https://slack.com/api/oauth.v2.access"
+)
+
+// Ways a Slack integration was connected
+const (
+	slackInstalledViaWebhook = "webhook"
+	slackInstalledViaOAuth   = "oauth"
+)
+
+const (
+	slackOAuthStateTTL   = 10 * time.Minute
+	maxSlackResponseBody = 512
+)
+
+// slackWebhookHosts are the hosts Slack issues incoming webhook URLs on
+var slackWebhookHosts = map[string]bool{"hooks.slack.com": true, "hooks.slack-gov.com": true}
+
+// ErrSlackNotConnected is returned when an organization has no Slack integration
+var ErrSlackNotConnected = apperror.NotFound("organization is not connected to Slack")
+
+// SlackService manages organization Slack integrations and posts
+// notifications to them through incoming webhooks
+type SlackService interface {
+	// Integration management (organization admins)
+	Get(orgID, userID uint) (*dto.SlackIntegrationResponse, error)
+	Save(orgID, userID uint, req *dto.SaveSlackIntegrationRequest) (*dto.SlackIntegrationResponse, error)
+	Delete(orgID, userID uint) error
+	SendTest(orgID, userID uint) error
+
+	// OAuth install: OAuthURL sends the admin to Slack, which redirects to the
+	// frontend with a code and state that CompleteOAuth exchanges
+	OAuthURL(orgID, userID uint) (*dto.SlackOAuthURLResponse, error)
+	CompleteOAuth(ctx context.Context, orgID, userID uint, req *dto.CompleteSlackOAuthRequest) (*dto.SlackIntegrationResponse, error)
+
+	// Notifications are posted in the background and never fail the caller
+	NotifyMemberEvent(event string, member models.OrganizationMember)
+	NotifyTimeLogsReviewed(timeLogIDs []uint, approved bool, reviewerID uint)
+
+	// SendDailySummaries posts the previous UTC day's hours per workspace (scheduled job)
+	SendDailySummaries(ctx context.Context) error
+}
+
+type slackService struct {
+	slackRepo  repository.SlackRepository
+	orgRepo    *repository.OrganizationRepository
+	userRepo   repository.UserRepository
+	httpClient *http.Client
+	cfg        config.SlackConfig
+	signingKey []byte
+}
+
+// NewSlackService creates a new Slack notification service
+func NewSlackService(
+	slackRepo repository.SlackRepository,
+	orgRepo *repository.OrganizationRepository,
+	userRepo repository.UserRepository,
+) SlackService {
+	cfg := config.AppConfig.Slack
+	return &slackService{
+		slackRepo: slackRepo,
+		orgRepo:   orgRepo,
+		userRepo:  userRepo,
+		httpClient: &http.Client{
+			Timeout: cfg.Timeout,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		cfg:        cfg,
+		signingKey: []byte(config.AppConfig.JWT.Secret),
+	}
+}
+
+// ============================================================================
+// INTEGRATION MANAGEMENT
+// ============================================================================
+
+func (s *slackService) requireAdmin(orgID, userID uint) error {
+	isAdmin, err := s.orgRepo.IsAdmin(orgID, userID)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return apperror.Forbidden("access denied: only admins can manage the Slack integration")
+	}
+	return nil
+}
+
+func (s *slackService) find(orgID uint) (*models.SlackIntegration, error) {
+	integration, err := s.slackRepo.FindByOrg(orgID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrSlackNotConnected
+	}
+	return integration, err
+}
+
+func (s *slackService) Get(orgID, userID uint) (*dto.SlackIntegrationResponse, error) {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return nil, err
+	}
+
+	integration, err := s.find(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toSlackIntegrationResponse(integration)
+	return &response, nil
+}
+
+func (s *slackService) Save(orgID, userID uint, req *dto.SaveSlackIntegrationRequest) (*dto.SlackIntegrationResponse, error) {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return nil, err
+	}
+
+	integration, err := s.find(orgID)
+	if errors.Is(err, ErrSlackNotConnected) {
+		if req.WebhookURL == "" {
+			return nil, apperror.Validation("webhook_url is required", nil)
+		}
+		integration = &models.SlackIntegration{OrganizationID: orgID, IsActive: true, CreatedBy: userID}
+	} else if err != nil {
+		return nil, err
+	}
+
+	if req.WebhookURL != "" {
+		if err := validateSlackWebhookURL(req.WebhookURL); err != nil {
+			return nil, err
+		}
+		integration.WebhookURL = req.WebhookURL
+		integration.InstalledVia = slackInstalledViaWebhook
+		integration.Channel = ""
+		integration.TeamName = ""
+	}
+	integration.Events = strings.Join(req.Events, ",")
+	if req.IsActive != nil {
+		integration.IsActive = *req.IsActive
+	}
+
+	if err := s.saveIntegration(integration); err != nil {
+		return nil, err
+	}
+
+	response := toSlackIntegrationResponse(integration)
+	return &response, nil
+}
+
+func (s *slackService) saveIntegration(integration *models.SlackIntegration) error {
+	var err error
+	if integration.ID == 0 {
+		err = s.slackRepo.Create(integration)
+	} else {
+		err = s.slackRepo.Update(integration)
+	}
+	if err != nil {
+		return errors.New("failed to save Slack integration")
+	}
+	return nil
+}
+
+// validateSlackWebhookURL only accepts Slack's own incoming webhook URLs, so
+// the integration cannot be pointed at arbitrary hosts
+func validateSlackWebhookURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != "https" || !slackWebhookHosts[u.Host] || !strings.HasPrefix(u.Path, "/services/") {
+		return apperror.Validation("webhook_url must be a Slack incoming webhook URL (https://hooks.slack.com/services/...)", nil)
+	}
+	return nil
+}
+
+func (s *slackService) Delete(orgID, userID uint) error {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return err
+	}
+
+	integration, err := s.find(orgID)
+	if err != nil {
+		return err
+	}
+
+	return s.slackRepo.Delete(integration.ID)
+}
+
+func (s *slackService) SendTest(orgID, userID uint) error {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return err
+	}
+
+	integration, err := s.find(orgID)
+	if err != nil {
+		return err
+	}
+	org, err := s.orgRepo.GetByID(orgID)
+	if err != nil {
+		return err
+	}
+
+	return s.post(integration, fmt.Sprintf(":white_check_mark: Remote Time Tracker notifications for *%s* will be posted here.", slackEscape(org.Name)))
+}
+
+// ============================================================================
+// OAUTH INSTALL
+// ============================================================================
+
+func (s *slackService) OAuthURL(orgID, userID uint) (*dto.SlackOAuthURLResponse, error) {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return nil, err
+	}
+	if s.cfg.ClientID == "" {
+		return nil, apperror.Unavailable("Slack OAuth is not configured; use an incoming webhook URL instead")
+	}
+
+	query := url.Values{
+		"client_id":    {s.cfg.ClientID},
+		"scope":        {"incoming-webhook"},
+		"redirect_uri": {s.cfg.RedirectURL},
+		"state":        {s.oauthState(orgID, userID, time.Now().Add(slackOAuthStateTTL).Unix())},
+	}
+	return &dto.SlackOAuthURLResponse{URL: slackAuthorizeURL + "?" + query.Encode()}, nil
+}
+
+// oauthState binds an install to the organization and admin that started it,
+// until expires (unix seconds)
+func (s *slackService) oauthState(orgID, userID uint, expires int64) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	fmt.Fprintf(mac, "slack-oauth:%d:%d:%d", orgID, userID, expires)
+	return fmt.Sprintf("%d.%s", expires, hex.EncodeToString(mac.Sum(nil)))
+}
+
+func (s *slackService) validOAuthState(orgID, userID uint, state string) bool {
+	expiresPart, _, ok := strings.Cut(state, ".")
+	if !ok {
+		return false
+	}
+	expires, err := strconv.ParseInt(expiresPart, 10, 64)
+	if err != nil || time.Now().Unix() > expires {
+		return false
+	}
+	return hmac.Equal([]byte(state), []byte(s.oauthState(orgID, userID, expires)))
+}
+
+func (s *slackService) CompleteOAuth(ctx context.Context, orgID, userID uint, req *dto.CompleteSlackOAuthRequest) (*dto.SlackIntegrationResponse, error) {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return nil, err
+	}
+	if s.cfg.ClientID == "" {
+		return nil, apperror.Unavailable("Slack OAuth is not configured")
+	}
+	if !s.validOAuthState(orgID, userID, req.State) {
+		return nil, apperror.Validation("invalid or expired Slack install state", nil)
+	}
+
+	access, err := s.exchangeCode(ctx, req.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	integration, err := s.find(orgID)
+	if errors.Is(err, ErrSlackNotConnected) {
+		integration = &models.SlackIntegration{OrganizationID: orgID, IsActive: true, CreatedBy: userID}
+	} else if err != nil {
+		return nil, err
+	}
+
+	integration.WebhookURL = access.IncomingWebhook.URL
+	integration.Channel = access.IncomingWebhook.Channel
+	integration.TeamName = access.Team.Name
+	integration.InstalledVia = slackInstalledViaOAuth
+	integration.LastError = ""
+	events := req.Events
+	if len(events) == 0 {
+		events = models.SlackEvents
+	}
+	integration.Events = strings.Join(events, ",")
+
+	if err := s.saveIntegration(integration); err != nil {
+		return nil, err
+	}
+
+	response := toSlackIntegrationResponse(integration)
+	return &response, nil
+}
+
+// slackOAuthAccess is the oauth.v2.access response for an incoming-webhook install
+type slackOAuthAccess struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error"`
+	Team  struct {
+		Name string `json:"name"`
+	} `json:"team"`
+	IncomingWebhook struct {
+		Channel string `json:"channel"`
+		URL     string `json:"url"`
+	} `json:"incoming_webhook"`
+}
+
+func (s *slackService) exchangeCode(ctx context.Context, code string) (*slackOAuthAccess, error) {
+	form := url.Values{
+		"client_id":     {s.cfg.ClientID},
+		"client_secret": {s.cfg.ClientSecret},
+		"code":          {code},
+		"redirect_uri":  {s.cfg.RedirectURL},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, slackOAuthURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach Slack: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var access slackOAuthAccess
+	if err := json.NewDecoder(resp.Body).Decode(&access); err != nil {
+		return nil, fmt.Errorf("invalid Slack OAuth response: %w", err)
+	}
+	if !access.OK {
+		return nil, apperror.Validation(fmt.Sprintf("Slack rejected the install: %s", access.Error), nil)
+	}
+	if access.IncomingWebhook.URL == "" {
+		return nil, apperror.Validation("Slack did not return an incoming webhook; the app must request the incoming-webhook scope", nil)
+	}
+	return &access, nil
+}
+
+// ============================================================================
+// NOTIFICATIONS
+// ============================================================================
+
+// subscribed returns the organization's active integration when it posts event
+func (s *slackService) subscribed(orgID uint, event string) *models.SlackIntegration {
+	integration, err := s.slackRepo.FindByOrg(orgID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Printf("⚠️  Failed to load Slack integration of organization %d: %v", orgID, err)
+		}
+		return nil
+	}
+	if !integration.IsActive || !integration.Subscribes(event) {
+		return nil
+	}
+	return integration
+}
+
+func (s *slackService) NotifyMemberEvent(event string, member models.OrganizationMember) {
+	if event != models.SlackEventMemberJoined {
+		return
+	}
+
+	go func() {
+		integration := s.subscribed(member.OrganizationID, event)
+		if integration == nil {
+			return
+		}
+
+		org, err := s.orgRepo.GetByID(member.OrganizationID)
+		if err != nil {
+			log.Printf("⚠️  Failed to load organization %d for Slack: %v", member.OrganizationID, err)
+			return
+		}
+		user := &member.User
+		if user.ID == 0 {
+			if user, err = s.userRepo.FindByID(member.UserID); err != nil {
+				log.Printf("⚠️  Failed to load user %d for Slack: %v", member.UserID, err)
+				return
+			}
+		}
+
+		text := fmt.Sprintf(":wave: *%s* (%s) joined *%s* as %s",
+			slackEscape(slackUserName(user)), slackEscape(user.Email), slackEscape(org.Name), member.Role)
+		_ = s.post(integration, text)
+	}()
+}
+
+func (s *slackService) NotifyTimeLogsReviewed(timeLogIDs []uint, approved bool, reviewerID uint) {
+	event, verb, emoji := models.SlackEventTimeLogApproved, "approved", ":white_check_mark:"
+	if !approved {
+		event, verb, emoji = models.SlackEventTimeLogRejected, "rejected", ":x:"
+	}
+
+	go func() {
+		logs, err := s.slackRepo.FindTimeLogs(timeLogIDs)
+		if err != nil {
+			log.Printf("⚠️  Failed to load reviewed time logs for Slack: %v", err)
+			return
+		}
+		reviewer := "An admin"
+		if user, err := s.userRepo.FindByID(reviewerID); err == nil {
+			reviewer = slackUserName(user)
+		}
+
+		// One message per organization, one line per member
+		type memberTotal struct {
+			name     string
+			count    int
+			duration int64
+		}
+		byOrg := make(map[uint][]*memberTotal)
+		index := make(map[[2]uint]*memberTotal)
+		for i := range logs {
+			timeLog := &logs[i]
+			if timeLog.OrganizationID == nil {
+				continue
+			}
+			orgID := *timeLog.OrganizationID
+			key := [2]uint{orgID, timeLog.UserID}
+			total, ok := index[key]
+			if !ok {
+				total = &memberTotal{name: slackUserName(&timeLog.User)}
+				index[key] = total
+				byOrg[orgID] = append(byOrg[orgID], total)
+			}
+			total.count++
+			total.duration += timeLog.Duration
+		}
+
+		for orgID, totals := range byOrg {
+			integration := s.subscribed(orgID, event)
+			if integration == nil {
+				continue
+			}
+
+			count := 0
+			var lines []string
+			for _, total := range totals {
+				count += total.count
+				lines = append(lines, fmt.Sprintf("• %s: %d time %s, %s",
+					slackEscape(total.name), total.count, pluralize("log", total.count), format.Duration(total.duration, format.DefaultLocale)))
+			}
+			text := fmt.Sprintf("%s *%s* %s %d time %s\n%s",
+				emoji, slackEscape(reviewer), verb, count, pluralize("log", count), strings.Join(lines, "\n"))
+			_ = s.post(integration, text)
+		}
+	}()
+}
+
+func (s *slackService) SendDailySummaries(ctx context.Context) error {
+	integrations, err := s.slackRepo.FindActive()
+	if err != nil {
+		return fmt.Errorf("failed to load Slack integrations: %w", err)
+	}
+
+	end := time.Now().UTC().Truncate(24 * time.Hour)
+	start := end.AddDate(0, 0, -1)
+
+	sent := 0
+	for i := range integrations {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		integration := &integrations[i]
+		if !integration.Subscribes(models.SlackEventDailySummary) {
+			continue
+		}
+
+		rows, err := s.slackRepo.WorkspaceHours(integration.OrganizationID, start, end)
+		if err != nil {
+			log.Printf("⚠️  Failed to summarize hours of organization %d: %v", integration.OrganizationID, err)
+			continue
+		}
+		// Quiet days are not posted
+		if len(rows) == 0 {
+			continue
+		}
+
+		var total int64
+		lines := make([]string, 0, len(rows))
+		for _, row := range rows {
+			total += row.Duration
+			lines = append(lines, fmt.Sprintf("• %s: %s (%d %s)",
+				slackEscape(row.WorkspaceName), format.Duration(row.Duration, format.DefaultLocale), row.MemberCount, pluralize("member", int(row.MemberCount))))
+		}
+		text := fmt.Sprintf(":bar_chart: *Daily summary for %s* (UTC): %s tracked\n%s",
+			start.Format("Mon, Jan 2"), format.Duration(total, format.DefaultLocale), strings.Join(lines, "\n"))
+		if err := s.post(integration, text); err == nil {
+			sent++
+		}
+	}
+
+	if sent > 0 {
+		log.Printf("✅ Posted %d Slack daily summaries", sent)
+	}
+	return nil
+}
+
+// post sends a message to the integration's webhook and records the outcome
+func (s *slackService) post(integration *models.SlackIntegration, text string) error {
+	err := s.send(integration.WebhookURL, text)
+
+	postErr := ""
+	if err != nil {
+		postErr = err.Error()
+		log.Printf("⚠️  Slack post failed for organization %d: %v", integration.OrganizationID, err)
+	}
+	if recordErr := s.slackRepo.SetPostResult(integration.ID, time.Now(), postErr); recordErr != nil {
+		log.Printf("⚠️  Failed to record Slack post result for organization %d: %v", integration.OrganizationID, recordErr)
+	}
+	return err
+}
+
+func (s *slackService) send(webhookURL, text string) error {
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.httpClient.Post(webhookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to reach Slack: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		// Slack answers with a short reason such as no_service or channel_is_archived
+		reason, _ := io.ReadAll(io.LimitReader(resp.Body, maxSlackResponseBody))
+		return fmt.Errorf("slack responded %d: %s", resp.StatusCode, strings.TrimSpace(string(reason)))
+	}
+	return nil
+}
+
+// slackEscape escapes the characters Slack treats as markup in message text
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+func slackUserName(user *models.User) string {
+	if name := strings.TrimSpace(user.FirstName + " " + user.LastName); name != "" {
+		return name
+	}
+	return user.Email
+}
+
+func pluralize(word string, n int) string {
+	if n == 1 {
+		return word
+	}
+	return word + "s"
+}
+
+func toSlackIntegrationResponse(integration *models.SlackIntegration) dto.SlackIntegrationResponse {
+	events := []string{}
+	for _, e := range strings.Split(integration.Events, ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			events = append(events, e)
+		}
+	}
+
+	return dto.SlackIntegrationResponse{
+		ID:             integration.ID,
+		OrganizationID: integration.OrganizationID,
+		Channel:        integration.Channel,
+		TeamName:       integration.TeamName,
+		InstalledVia:   integration.InstalledVia,
+		Events:         events,
+		IsActive:       integration.IsActive,
+		CreatedBy:      integration.CreatedBy,
+		LastPostedAt:   integration.LastPostedAt,
+		LastError:      integration.LastError,
+		CreatedAt:      integration.CreatedAt,
+		UpdatedAt:      integration.UpdatedAt,
+	}
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+)
+
+const (
+	// statsRollupBackfillDays is how far back the first rollup starts; older
+	// days are read from the raw tables
+	statsRollupBackfillDays = 366
+	// statsRollupRecheckDays is how many past days every run rolls up again,
+	// for time logs synced late by offline devices and for edits
+	statsRollupRecheckDays = 7
+)
+
+// StatsRollupService keeps the daily user and workspace stats that trend,
+// activity and organization dashboards read instead of the raw tables
+type StatsRollupService interface {
+	// RollupDays rolls up the days through yesterday (UTC) not rolled up yet
+	// and the last few days again (scheduled job)
+	RollupDays(ctx context.Context) error
+}
+
+type statsRollupService struct {
+	rollupRepo repository.StatsRollupRepository
+}
+
+// NewStatsRollupService creates a new stats rollup service
+func NewStatsRollupService(rollupRepo repository.StatsRollupRepository) StatsRollupService {
+	return &statsRollupService{rollupRepo: rollupRepo}
+}
+
+func (s *statsRollupService) RollupDays(ctx context.Context) error {
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	// Days are rolled up in order, so the rolled-up days stay contiguous even
+	// when a run times out
+	day := today.AddDate(0, 0, -statsRollupBackfillDays)
+	last, err := s.rollupRepo.LastRolledUpDay()
+	if err != nil {
+		return err
+	}
+	if last != nil {
+		day = last.AddDate(0, 0, 1)
+		if recheck := today.AddDate(0, 0, -statsRollupRecheckDays); recheck.Before(day) {
+			day = recheck
+		}
+	}
+
+	rolledUp := 0
+	for ; day.Before(today); day = day.AddDate(0, 0, 1) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := s.rollupRepo.RollupDay(day); err != nil {
+			return err
+		}
+		rolledUp++
+	}
+
+	log.Printf("✅ Rolled up daily stats of %d days", rolledUp)
+	return nil
+}
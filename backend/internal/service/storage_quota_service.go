@@ -0,0 +1,110 @@
+package service
+
+import (
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/format"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+)
+
+// StorageQuotaService manages per-organization screenshot storage quotas.
+// Usage comes from the storage counters, updated as screenshots are uploaded
+// and deleted.
+type StorageQuotaService interface {
+	Get(orgID uint, locale format.Locale) (*dto.AdminStorageQuotaResponse, error)
+	Update(orgID uint, req *dto.AdminUpdateStorageQuotaRequest, locale format.Locale) (*dto.AdminStorageQuotaResponse, error)
+	// Recount recomputes the usage from the screenshots, e.g. after files
+	// were removed outside the application
+	Recount(orgID uint, locale format.Locale) (*dto.AdminStorageQuotaResponse, error)
+
+	// Remaining returns the bytes of screenshots the organization can still
+	// store, or nil when it has no quota
+	Remaining(orgID uint) (*int64, error)
+}
+
+type storageQuotaService struct {
+	counterRepo repository.StorageCounterRepository
+	orgRepo     *repository.OrganizationRepository
+}
+
+// NewStorageQuotaService creates a new storage quota service
+func NewStorageQuotaService(counterRepo repository.StorageCounterRepository, orgRepo *repository.OrganizationRepository) StorageQuotaService {
+	return &storageQuotaService{
+		counterRepo: counterRepo,
+		orgRepo:     orgRepo,
+	}
+}
+
+func (s *storageQuotaService) Get(orgID uint, locale format.Locale) (*dto.AdminStorageQuotaResponse, error) {
+	org, err := s.orgRepo.GetByID(orgID)
+	if err != nil {
+		return nil, apperror.NotFound("organization not found")
+	}
+	counter, err := s.counterRepo.Get(orgID)
+	if err != nil {
+		return nil, err
+	}
+	return toStorageQuotaResponse(org, counter, locale), nil
+}
+
+func (s *storageQuotaService) Update(orgID uint, req *dto.AdminUpdateStorageQuotaRequest, locale format.Locale) (*dto.AdminStorageQuotaResponse, error) {
+	org, err := s.orgRepo.GetByID(orgID)
+	if err != nil {
+		return nil, apperror.NotFound("organization not found")
+	}
+
+	org.StorageQuotaBytes = req.QuotaBytes
+	if err := s.orgRepo.Update(org); err != nil {
+		return nil, err
+	}
+
+	counter, err := s.counterRepo.Get(orgID)
+	if err != nil {
+		return nil, err
+	}
+	return toStorageQuotaResponse(org, counter, locale), nil
+}
+
+func (s *storageQuotaService) Recount(orgID uint, locale format.Locale) (*dto.AdminStorageQuotaResponse, error) {
+	org, err := s.orgRepo.GetByID(orgID)
+	if err != nil {
+		return nil, apperror.NotFound("organization not found")
+	}
+	counter, err := s.counterRepo.Recount(orgID)
+	if err != nil {
+		return nil, err
+	}
+	return toStorageQuotaResponse(org, counter, locale), nil
+}
+
+func (s *storageQuotaService) Remaining(orgID uint) (*int64, error) {
+	org, err := s.orgRepo.GetByID(orgID)
+	if err != nil || org.StorageQuotaBytes == nil {
+		// Unknown organizations are rejected by the caller's own checks
+		return nil, nil
+	}
+	counter, err := s.counterRepo.Get(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := *org.StorageQuotaBytes - counter.UsedBytes
+	return &remaining, nil
+}
+
+func toStorageQuotaResponse(org *models.Organization, counter *models.OrganizationStorageCounter, locale format.Locale) *dto.AdminStorageQuotaResponse {
+	resp := &dto.AdminStorageQuotaResponse{
+		OrganizationID:  org.ID,
+		QuotaBytes:      org.StorageQuotaBytes,
+		UsedBytes:       counter.UsedBytes,
+		UsedHuman:       format.Bytes(counter.UsedBytes, locale),
+		ScreenshotCount: counter.ScreenshotCount,
+		UpdatedAt:       counter.UpdatedAt,
+	}
+	if org.StorageQuotaBytes != nil {
+		resp.QuotaHuman = format.Bytes(*org.StorageQuotaBytes, locale)
+		resp.Exceeded = counter.UsedBytes >= *org.StorageQuotaBytes
+	}
+	return resp
+}
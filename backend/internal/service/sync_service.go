@@ -2,11 +2,18 @@ package service
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/config"
 	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/imaging"
 	"github.com/beuphecan/remote-time-tracker/internal/models"
 	"github.com/beuphecan/remote-time-tracker/internal/repository"
 	"github.com/beuphecan/remote-time-tracker/internal/utils"
@@ -15,30 +22,94 @@ import (
 // SyncService handles synchronization logic
 type SyncService interface {
 	BatchSync(userID uint, req *dto.BatchSyncRequest) (*dto.BatchSyncResponse, error)
+
+	// Time logs edited on two devices
+	ListConflicts(userID uint, status string, page, perPage int) ([]dto.SyncConflictResponse, int64, error)
+	ResolveConflict(userID, conflictID uint, req *dto.ResolveSyncConflictRequest) (*dto.SyncConflictResponse, error)
 }
 
 type syncService struct {
-	timeLogRepo    repository.TimeLogRepository
-	screenshotRepo repository.ScreenshotRepository
-	deviceRepo     repository.DeviceRepository
-	syncLogRepo    repository.SyncLogRepository
-	taskRepo       repository.TaskRepository
+	syncStore     repository.SyncStore
+	deviceRepo    repository.DeviceRepository
+	syncLogRepo   repository.SyncLogRepository
+	conflictRepo  repository.SyncConflictRepository
+	workspaceRepo *repository.WorkspaceRepository
+
+	complianceService    ComplianceService
+	capturePolicyService CapturePolicyService
+	encryptionKeyService EncryptionKeyService
+	privateIntervals     PrivateIntervalService
+	settings             OrganizationSettingsService
+	adminAnalytics       AdminAnalyticsService
+	commitService        CommitLinkService
+	deviceApprovals      DeviceApprovalService
+	updateService        *UpdateService
+	periodLocks          PeriodLockService
+	storageQuotas        StorageQuotaService
+	scans                ScreenshotScanService
+	ocr                  ScreenshotOCRService
+	conflictPolicy       string
+	transactionMode      string
+	timerPolicy          string
 }
 
 // NewSyncService creates a new sync service
 func NewSyncService(
-	timeLogRepo repository.TimeLogRepository,
-	screenshotRepo repository.ScreenshotRepository,
+	syncStore repository.SyncStore,
 	deviceRepo repository.DeviceRepository,
 	syncLogRepo repository.SyncLogRepository,
-	taskRepo repository.TaskRepository,
+	conflictRepo repository.SyncConflictRepository,
+	workspaceRepo *repository.WorkspaceRepository,
+	complianceService ComplianceService,
+	capturePolicyService CapturePolicyService,
+	encryptionKeyService EncryptionKeyService,
+	privateIntervals PrivateIntervalService,
+	settings OrganizationSettingsService,
+	adminAnalytics AdminAnalyticsService,
+	commitService CommitLinkService,
+	deviceApprovals DeviceApprovalService,
+	updateService *UpdateService,
+	periodLocks PeriodLockService,
+	storageQuotas StorageQuotaService,
+	scans ScreenshotScanService,
+	ocr ScreenshotOCRService,
 ) SyncService {
+	policy := config.AppConfig.Sync.ConflictPolicy
+	switch policy {
+	case models.SyncConflictLastWriteWins, models.SyncConflictServerWins, models.SyncConflictManual:
+	default:
+		log.Printf("⚠️  Unknown sync conflict policy %q, using %s", policy, models.SyncConflictLastWriteWins)
+		policy = models.SyncConflictLastWriteWins
+	}
+
+	mode := config.AppConfig.Sync.TransactionMode
+	if mode != models.SyncTransactionItem && mode != models.SyncTransactionBatch {
+		log.Printf("⚠️  Unknown sync transaction mode %q, using %s", mode, models.SyncTransactionItem)
+		mode = models.SyncTransactionItem
+	}
+
 	return &syncService{
-		timeLogRepo:    timeLogRepo,
-		screenshotRepo: screenshotRepo,
-		deviceRepo:     deviceRepo,
-		syncLogRepo:    syncLogRepo,
-		taskRepo:       taskRepo,
+		syncStore:            syncStore,
+		deviceRepo:           deviceRepo,
+		syncLogRepo:          syncLogRepo,
+		conflictRepo:         conflictRepo,
+		workspaceRepo:        workspaceRepo,
+		complianceService:    complianceService,
+		capturePolicyService: capturePolicyService,
+		encryptionKeyService: encryptionKeyService,
+		privateIntervals:     privateIntervals,
+		settings:             settings,
+		adminAnalytics:       adminAnalytics,
+		commitService:        commitService,
+		deviceApprovals:      deviceApprovals,
+		updateService:        updateService,
+		periodLocks:          periodLocks,
+		storageQuotas:        storageQuotas,
+		scans:                scans,
+		ocr:                  ocr,
+		conflictPolicy:       policy,
+		transactionMode:      mode,
+		timerPolicy:          timerConcurrencyPolicy(),
 	}
 }
 
@@ -69,24 +140,49 @@ func (s *syncService) BatchSync(userID uint, req *dto.BatchSyncRequest) (*dto.Ba
 			LastSeenAt: device.LastSeenAt,
 			IsActive:   device.IsActive,
 		}
+	} else if known, _ := s.deviceRepo.FindByUUID(req.DeviceUUID); known != nil && known.UserID == userID {
+		// Needed to tell which device last changed a time log
+		device = known
 	}
 
-	// Sync time logs
-	if len(req.TimeLogs) > 0 {
-		response.TimeLogsSync = s.syncTimeLogs(userID, device, req.TimeLogs, req.OrganizationID, req.WorkspaceID)
+	// Clients below the minimum version of their channels must upgrade before
+	// their time logs and screenshots are accepted
+	orgIDs := batchOrgIDs(req)
+	if device != nil {
+		if err := s.updateService.RequireSupportedVersion(device.AppVersion, orgIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.deviceApprovals.Authorize(device, userID, orgIDs); err != nil {
+		return nil, err
 	}
 
-	// Sync screenshots
-	if len(req.Screenshots) > 0 {
-		response.ScreenshotsSync = s.syncScreenshots(userID, device, req.Screenshots, req.OrganizationID, req.WorkspaceID)
+	if s.transactionMode == models.SyncTransactionBatch {
+		s.syncAllOrNothing(userID, device, req, response)
+	} else {
+		// Sync time logs
+		if len(req.TimeLogs) > 0 {
+			response.TimeLogsSync, response.TimeLogVersions, response.Conflicts, _ =
+				s.syncTimeLogs(nil, userID, device, req.TimeLogs, req.OrganizationID, req.WorkspaceID)
+		}
+
+		// Sync screenshots
+		if len(req.Screenshots) > 0 {
+			response.ScreenshotsSync, _ = s.syncScreenshots(nil, userID, device, req.Screenshots, req.OrganizationID, req.WorkspaceID)
+		}
 	}
 
 	// Create sync log
+	status := "success"
+	if !response.Success {
+		status = "failed"
+	}
 	duration := time.Since(startTime).Milliseconds()
 	syncLog := &models.SyncLog{
 		UserID:       userID,
 		SyncType:     "batch",
-		Status:       "success",
+		Status:       status,
 		ItemsCount:   len(req.TimeLogs) + len(req.Screenshots),
 		SuccessCount: response.TimeLogsSync.Success + response.ScreenshotsSync.Success,
 		FailedCount:  response.TimeLogsSync.Failed + response.ScreenshotsSync.Failed,
@@ -101,9 +197,77 @@ func (s *syncService) BatchSync(userID uint, req *dto.BatchSyncRequest) (*dto.Ba
 
 	s.syncLogRepo.Create(syncLog)
 
+	// New time logs and screenshots change the admin dashboard
+	if syncLog.SuccessCount > 0 {
+		s.adminAnalytics.InvalidateActivity()
+	}
+
 	return response, nil
 }
 
+// syncAllOrNothing syncs the whole batch in one transaction. Items rejected on
+// their own (e.g. by the weekly hour cap) do not affect the others, but a
+// database or storage error rolls back every item of the batch.
+func (s *syncService) syncAllOrNothing(userID uint, device *models.DeviceInfo, req *dto.BatchSyncRequest, response *dto.BatchSyncResponse) {
+	err := s.inTransaction(func(tx *syncTx) error {
+		var err error
+		if len(req.TimeLogs) > 0 {
+			response.TimeLogsSync, response.TimeLogVersions, response.Conflicts, err =
+				s.syncTimeLogs(tx, userID, device, req.TimeLogs, req.OrganizationID, req.WorkspaceID)
+			if err != nil {
+				return err
+			}
+		}
+		if len(req.Screenshots) > 0 {
+			response.ScreenshotsSync, err = s.syncScreenshots(tx, userID, device, req.Screenshots, req.OrganizationID, req.WorkspaceID)
+		}
+		return err
+	})
+	if err == nil {
+		return
+	}
+
+	fmt.Printf("❌ Batch sync rolled back: %v\n", err)
+
+	timeLogIDs := make([]string, 0, len(req.TimeLogs))
+	for _, item := range req.TimeLogs {
+		timeLogIDs = append(timeLogIDs, item.LocalID)
+	}
+	screenshotIDs := make([]string, 0, len(req.Screenshots))
+	for _, item := range req.Screenshots {
+		screenshotIDs = append(screenshotIDs, item.LocalID)
+	}
+
+	response.Success = false
+	response.Message = "Batch sync rolled back"
+	response.TimeLogVersions = nil
+	response.Conflicts = nil
+	response.TimeLogsSync = abortedSyncResult(timeLogIDs, response.TimeLogsSync)
+	response.ScreenshotsSync = abortedSyncResult(screenshotIDs, response.ScreenshotsSync)
+}
+
+// batchOrgIDs returns the organizations a batch syncs into
+func batchOrgIDs(req *dto.BatchSyncRequest) []uint {
+	seen := make(map[uint]bool)
+	orgIDs := make([]uint, 0)
+	add := func(orgID *uint) {
+		if orgID == nil {
+			orgID = req.OrganizationID
+		}
+		if orgID != nil && !seen[*orgID] {
+			seen[*orgID] = true
+			orgIDs = append(orgIDs, *orgID)
+		}
+	}
+	for _, item := range req.TimeLogs {
+		add(item.OrganizationID)
+	}
+	for _, item := range req.Screenshots {
+		add(item.OrganizationID)
+	}
+	return orgIDs
+}
+
 func (s *syncService) syncDeviceInfo(userID uint, deviceInfo *dto.SyncDeviceInfoItem) (*models.DeviceInfo, error) {
 	// Check if device exists
 	device, err := s.deviceRepo.FindByUUID(deviceInfo.DeviceUUID)
@@ -144,7 +308,18 @@ func (s *syncService) syncDeviceInfo(userID uint, deviceInfo *dto.SyncDeviceInfo
 	return device, nil
 }
 
-func (s *syncService) syncTimeLogs(userID uint, device *models.DeviceInfo, items []dto.SyncTimeLogItem, defaultOrgID *uint, defaultWsID *uint) dto.SyncResult {
+// timeLogOutcome is what syncing one time log reports back to the client
+type timeLogOutcome struct {
+	version  int
+	conflict *dto.SyncConflictResponse
+	capFlag  *dto.SyncCapFlag
+	stopped  []string // LocalIDs of active time logs stopped for a newer timer
+}
+
+// syncTimeLogs syncs each time log in its own transaction, or all of them in
+// batch when given one. With a batch transaction it stops at the first error
+// that must roll the batch back and returns it.
+func (s *syncService) syncTimeLogs(batch *syncTx, userID uint, device *models.DeviceInfo, items []dto.SyncTimeLogItem, defaultOrgID *uint, defaultWsID *uint) (dto.SyncResult, map[string]int, []dto.SyncConflictResponse, error) {
 	// Debug logging
 	fmt.Printf("🔄 syncTimeLogs called with defaultOrgID=%v, defaultWsID=%v\n", defaultOrgID, defaultWsID)
 
@@ -154,6 +329,15 @@ func (s *syncService) syncTimeLogs(userID uint, device *models.DeviceInfo, items
 		Failed:  0,
 		Errors:  []string{},
 	}
+	versions := make(map[string]int, len(items))
+	var conflicts []dto.SyncConflictResponse
+
+	// Time logs in this batch are updated by it, so they never count as
+	// another running timer
+	batchIDs := make(map[string]bool, len(items))
+	for _, item := range items {
+		batchIDs[item.LocalID] = true
+	}
 
 	for _, item := range items {
 		// Resolve organization and workspace IDs
@@ -171,203 +355,537 @@ func (s *syncService) syncTimeLogs(userID uint, device *models.DeviceInfo, items
 		fmt.Printf("📋 TimeLog item: LocalID=%s, item.OrgID=%v, item.WsID=%v, resolved orgID=%v, wsID=%v\n",
 			item.LocalID, item.OrganizationID, item.WorkspaceID, orgID, wsID)
 
-		// Handle task creation/lookup
-		var taskID *uint
-
-		// PRIORITY 1: Check if task_id is provided (manual task)
-		// This means the time log is for an existing manual task
-		if item.TaskID != nil && *item.TaskID > 0 {
-			// Verify the task exists and belongs to this user
-			existingTask, err := s.taskRepo.FindByID(*item.TaskID)
-			if err == nil && existingTask != nil && existingTask.UserID == userID {
-				taskID = item.TaskID
-				fmt.Printf("🎯 Using existing manual task ID: %d (Title: %s)\n", *taskID, existingTask.Title)
-			} else {
-				fmt.Printf("⚠️  Manual task ID %d not found or not owned by user, will create new\n", *item.TaskID)
-			}
+		var outcome timeLogOutcome
+		sync := func(tx *syncTx) error {
+			var err error
+			outcome, err = s.syncTimeLog(tx, userID, device, &item, orgID, wsID, batchIDs)
+			return err
 		}
 
-		// PRIORITY 2: Check task_local_id (UUID) for auto-track tasks
-		if taskID == nil && item.TaskLocalID != "" {
-			// Check if task already exists by LocalID
-			existingTask, _ := s.taskRepo.FindByLocalID(item.TaskLocalID, userID)
-			if existingTask != nil {
-				taskID = &existingTask.ID
-				fmt.Printf("🔍 Found existing task by LocalID: %s (ID: %d)\n", item.TaskLocalID, existingTask.ID)
-			} else if item.TaskTitle != "" {
-				// Create new task with LocalID and Title
-				taskStatus := "completed"
-				if item.Status == "running" || item.Status == "paused" {
-					taskStatus = "active"
-				}
+		var err error
+		if batch != nil {
+			err = sync(batch)
+		} else {
+			err = s.inTransaction(sync)
+		}
 
-				task := &models.Task{
-					UserID:         userID,
-					OrganizationID: orgID,            // Set organization context
-					WorkspaceID:    wsID,             // Set workspace context
-					LocalID:        item.TaskLocalID, // Set UUID from Electron
-					Title:          item.TaskTitle,
-					Description:    item.Notes,
-					Status:         taskStatus,
-					Priority:       1,
-					IsManual:       false, // Auto-created from time tracker
-				}
-				if err := s.taskRepo.Create(task); err == nil {
-					taskID = &task.ID
-					fmt.Printf("✅ Created task with LocalID: %s (Title: %s, ID: %d, WsID: %v)\n", item.TaskLocalID, item.TaskTitle, task.ID, wsID)
-				} else {
-					fmt.Printf("⚠️  Failed to create task: %s - %v\n", item.TaskTitle, err)
-				}
+		if outcome.capFlag != nil {
+			result.CapFlags = append(result.CapFlags, *outcome.capFlag)
+		}
+		if err != nil {
+			addSyncItemError(&result, item.LocalID, err)
+			if batch != nil && abortsBatch(err) {
+				return result, versions, conflicts, err
 			}
+			continue
 		}
 
-		// PRIORITY 3: Fallback - create task from title only (backward compatibility)
-		if taskID == nil && item.TaskTitle != "" {
-			// Create task without LocalID (will generate UUID in DB)
-			taskStatus := "completed"
-			if item.Status == "running" || item.Status == "paused" {
-				taskStatus = "active"
-			}
+		result.Success++
+		versions[item.LocalID] = outcome.version
+		result.StoppedTimers = append(result.StoppedTimers, outcome.stopped...)
+		if outcome.conflict != nil {
+			conflicts = append(conflicts, *outcome.conflict)
+		}
+	}
+
+	return result, versions, conflicts, nil
+}
+
+// syncTimeLog creates or updates one time log, together with its auto-created task
+func (s *syncService) syncTimeLog(tx *syncTx, userID uint, device *models.DeviceInfo, item *dto.SyncTimeLogItem, orgID, wsID *uint, batchIDs map[string]bool) (timeLogOutcome, error) {
+	var outcome timeLogOutcome
+
+	// Check if time log already exists
+	existing, err := tx.TimeLogs.FindByLocalID(item.LocalID, userID)
+	if err != nil {
+		return outcome, newSyncItemError(models.SyncErrorDatabase, "Failed to look up time log %s: %v", item.LocalID, err)
+	}
+
+	// Invoiced time logs are locked; the server copy is what was billed
+	if existing != nil && existing.InvoiceID != nil {
+		return outcome, newSyncItemError(models.SyncErrorInvoiced, "Time log %s is on an invoice and can no longer be edited", item.LocalID)
+	}
+
+	// So are time logs in pay periods closed by an admin; a timer still
+	// running across the lock date can be stopped
+	if existing == nil || (existing.Status != "running" && existing.Status != "paused") {
+		lockOrgID := orgID
+		if existing != nil {
+			lockOrgID = existing.OrganizationID
+		}
+		lockedBefore, err := s.periodLocks.LockedBefore(lockOrgID, userID)
+		if err != nil {
+			return outcome, newSyncItemError(models.SyncErrorDatabase, "Failed to check pay period lock of time log %s: %v", item.LocalID, err)
+		}
+		if lockedBefore != nil && (item.StartTime.Before(*lockedBefore) || (existing != nil && existing.StartTime.Before(*lockedBefore))) {
+			return outcome, newSyncItemError(models.SyncErrorPeriodLocked, "Time log %s is in a pay period locked before %s and can no longer be edited", item.LocalID, lockedBefore.Format("2006-01-02"))
+		}
+	}
 
-			task := &models.Task{
-				UserID:         userID,
-				OrganizationID: orgID, // Set organization context
-				WorkspaceID:    wsID,  // Set workspace context
-				Title:          item.TaskTitle,
-				Description:    item.Notes,
-				Status:         taskStatus,
-				Priority:       1,
-				IsManual:       false, // Auto-created from time tracker
+	// New time logs are checked against the weekly hour cap before any task is
+	// auto-created for them, so a blocked time log leaves nothing behind
+	var capCheck *CapCheck
+	if existing == nil {
+		capCheck = s.checkHourCap(&models.TimeLog{
+			UserID:      userID,
+			WorkspaceID: wsID,
+			LocalID:     item.LocalID,
+			StartTime:   item.StartTime,
+			Duration:    item.Duration,
+		}, &outcome)
+		if capCheck != nil && capCheck.Blocked {
+			return outcome, newSyncItemError(models.SyncErrorHourCapBlocked, "Time log %s blocked: weekly hour cap of %.2fh reached", item.LocalID, capCheck.WeeklyHourCap)
+		}
+	}
+
+	taskID, err := s.resolveSyncTask(tx, userID, item, orgID, wsID)
+	if err != nil {
+		return outcome, err
+	}
+
+	if existing != nil {
+		// Debug logging for UPDATE
+		fmt.Printf("🔄 Backend updating existing TimeLog (LocalID: %s):\n", item.LocalID)
+		fmt.Printf("   Old Duration: %d seconds\n", existing.Duration)
+		fmt.Printf("   New Duration: %d seconds\n", item.Duration)
+		fmt.Printf("   Old PausedTotal: %d seconds\n", existing.PausedTotal)
+		fmt.Printf("   New PausedTotal: %d seconds\n", item.PausedTotal)
+
+		// The time log changed on the server since this device's copy was taken
+		if isSyncConflict(existing, item, device) {
+			conflict, applyClient, err := s.recordConflict(tx, userID, existing, item, device)
+			if err != nil {
+				return outcome, newSyncItemError(models.SyncErrorDatabase, "Failed to record sync conflict for time log %s: %v", item.LocalID, err)
 			}
-			if err := s.taskRepo.Create(task); err == nil {
-				taskID = &task.ID
-				fmt.Printf("✅ Auto-created task: %s (ID: %d, WsID: %v)\n", item.TaskTitle, task.ID, wsID)
-			} else {
-				fmt.Printf("⚠️  Failed to create task: %s - %v\n", item.TaskTitle, err)
+			response := toSyncConflictResponse(conflict)
+			outcome.conflict = &response
+			if !applyClient {
+				fmt.Printf("⚠️  Sync conflict on TimeLog %s, keeping server version %d (%s)\n", item.LocalID, existing.Version, s.conflictPolicy)
+				outcome.version = existing.Version
+				return outcome, nil
 			}
 		}
 
-		// Check if time log already exists
-		existing, _ := s.timeLogRepo.FindByLocalID(item.LocalID, userID)
-		if existing != nil {
-			// Debug logging for UPDATE
-			fmt.Printf("🔄 Backend updating existing TimeLog (LocalID: %s):\n", item.LocalID)
-			fmt.Printf("   Old Duration: %d seconds\n", existing.Duration)
-			fmt.Printf("   New Duration: %d seconds\n", item.Duration)
-			fmt.Printf("   Old PausedTotal: %d seconds\n", existing.PausedTotal)
-			fmt.Printf("   New PausedTotal: %d seconds\n", item.PausedTotal)
-
-			// Update existing
-			existing.EndTime = item.EndTime
-			existing.PausedAt = item.PausedAt
-			existing.ResumedAt = item.ResumedAt
-			existing.Duration = item.Duration
-			existing.PausedTotal = item.PausedTotal
-			existing.Status = item.Status
-			existing.Notes = item.Notes
-			existing.TaskTitle = item.TaskTitle
-			existing.TaskID = taskID
-			existing.IsSynced = true
-
-			if err := s.timeLogRepo.Update(existing); err != nil {
-				result.Failed++
-				result.Errors = append(result.Errors, fmt.Sprintf("Failed to update time log %s", item.LocalID))
-			} else {
-				result.Success++
-				// Update task status and duration if this is for a manual task
-				if taskID != nil {
-					s.updateTaskAfterTimeLog(*taskID, item.Duration, item.Status)
-				}
-			}
-		} else {
-			// Auto-create task from task_title if provided and no task_id
-			// Each time tracking session with a title creates a new task
-			// Users can have multiple tasks with the same title (different task_id)
-			if taskID == nil && item.TaskTitle != "" {
-				newTask := &models.Task{
-					UserID:         userID,
-					OrganizationID: orgID, // Set organization context
-					WorkspaceID:    wsID,  // Set workspace context
-					Title:          item.TaskTitle,
-					Status:         "active",
-					Priority:       0,
-				}
-				if err := s.taskRepo.Create(newTask); err == nil {
-					taskID = &newTask.ID
-					fmt.Printf("✅ Auto-created task: %s (ID: %d, WsID: %v)\n", newTask.Title, newTask.ID, wsID)
-				} else {
-					fmt.Printf("⚠️  Failed to create task: %v\n", err)
+		// Update existing. Edits of finished time logs go to their history;
+		// running ones change on every sync while tracking.
+		baseVersion := existing.Version
+		finished := existing.Status != "running" && existing.Status != "paused"
+		before := existing.Snapshot()
+		applySyncTimeLogItem(existing, item)
+		existing.TaskID = taskID
+		if device != nil {
+			existing.SyncDeviceID = &device.ID
+		}
+
+		if check := s.checkHourCap(existing, &outcome); check != nil {
+			existing.CapStatus = check.Status
+		}
+
+		if err := s.enforceSingleTimer(tx, existing, batchIDs, &outcome); err != nil {
+			return outcome, err
+		}
+
+		updated, err := tx.TimeLogs.UpdateIfVersion(existing, baseVersion)
+		if err != nil {
+			return outcome, newSyncItemError(models.SyncErrorDatabase, "Failed to update time log %s", item.LocalID)
+		}
+		if !updated {
+			return outcome, newSyncItemError(models.SyncErrorVersionConflict, "Time log %s was changed by another device during sync, retry", item.LocalID)
+		}
+		outcome.version = existing.Version
+		if finished {
+			if edit := newTimeLogEdit(existing.ID, before, existing.Snapshot(), &userID, models.TimeLogEditSourceSync); edit != nil {
+				if err := tx.Edits.Create(edit); err != nil {
+					return outcome, newSyncItemError(models.SyncErrorDatabase, "Failed to record edit of time log %s", item.LocalID)
 				}
 			}
+		}
+		if err := replaceSyncBreaks(tx, existing, item); err != nil {
+			return outcome, err
+		}
+		tx.afterCommit = append(tx.afterCommit, func() {
+			s.commitService.LinkFromNotes(existing)
+		})
 
-			// Debug logging
-			fmt.Printf("🔍 Backend received TimeLog data:\n")
-			fmt.Printf("   Duration: %d seconds\n", item.Duration)
-			fmt.Printf("   PausedTotal: %d seconds\n", item.PausedTotal)
-			fmt.Printf("   TaskTitle: %s\n", item.TaskTitle)
-			fmt.Printf("   StartTime: %v\n", item.StartTime)
-			fmt.Printf("   EndTime: %v\n", item.EndTime)
-			fmt.Printf("   WorkspaceID: %v\n", wsID)
-
-			// Create new
-			timeLog := &models.TimeLog{
-				UserID:         userID,
-				OrganizationID: orgID, // Set organization context
-				WorkspaceID:    wsID,  // Set workspace context
-				TaskID:         taskID,
-				TaskLocalID:    item.TaskLocalID, // Store UUID for consistent reference
-				LocalID:        item.LocalID,
-				StartTime:      item.StartTime,
-				EndTime:        item.EndTime,
-				PausedAt:       item.PausedAt,
-				ResumedAt:      item.ResumedAt,
-				Duration:       item.Duration,
-				PausedTotal:    item.PausedTotal,
-				Status:         item.Status,
-				Notes:          item.Notes,
-				TaskTitle:      item.TaskTitle,
-				IsSynced:       true,
+		// Update task status and duration if this is for a manual task
+		if taskID != nil {
+			if err := s.updateTaskAfterTimeLog(tx, *taskID, existing.Duration, existing.Status); err != nil {
+				return outcome, newSyncItemError(models.SyncErrorDatabase, "Failed to update task of time log %s", item.LocalID)
 			}
+		}
+		return outcome, nil
+	}
 
-			if device != nil {
-				timeLog.DeviceID = &device.ID
-			}
+	// Debug logging
+	fmt.Printf("🔍 Backend received TimeLog data:\n")
+	fmt.Printf("   Duration: %d seconds\n", item.Duration)
+	fmt.Printf("   PausedTotal: %d seconds\n", item.PausedTotal)
+	fmt.Printf("   TaskTitle: %s\n", item.TaskTitle)
+	fmt.Printf("   StartTime: %v\n", item.StartTime)
+	fmt.Printf("   EndTime: %v\n", item.EndTime)
+	fmt.Printf("   WorkspaceID: %v\n", wsID)
 
-			if err := s.timeLogRepo.Create(timeLog); err != nil {
-				result.Failed++
-				result.Errors = append(result.Errors, fmt.Sprintf("Failed to create time log %s", item.LocalID))
-			} else {
-				result.Success++
+	// Create new
+	timeLog := &models.TimeLog{
+		UserID:         userID,
+		OrganizationID: orgID, // Set organization context
+		WorkspaceID:    wsID,  // Set workspace context
+		TaskID:         taskID,
+		TaskLocalID:    item.TaskLocalID, // Store UUID for consistent reference
+		LocalID:        item.LocalID,
+		StartTime:      item.StartTime,
+		EndTime:        item.EndTime,
+		PausedAt:       item.PausedAt,
+		ResumedAt:      item.ResumedAt,
+		Duration:       item.Duration,
+		PausedTotal:    item.PausedTotal,
+		BreakTotal:     syncBreakTotal(item),
+		Status:         item.Status,
+		Notes:          item.Notes,
+		TaskTitle:      item.TaskTitle,
+		IsSynced:       true,
+		Version:        1,
+	}
 
-				// Update task status and duration if this is for a manual task
-				if taskID != nil {
-					s.updateTaskAfterTimeLog(*taskID, item.Duration, item.Status)
-				}
+	if capCheck != nil {
+		timeLog.CapStatus = capCheck.Status
+	}
+
+	if device != nil {
+		timeLog.DeviceID = &device.ID
+		timeLog.SyncDeviceID = &device.ID
+	}
+
+	if err := s.enforceSingleTimer(tx, timeLog, batchIDs, &outcome); err != nil {
+		return outcome, err
+	}
+
+	if err := tx.TimeLogs.Create(timeLog); err != nil {
+		return outcome, newSyncItemError(models.SyncErrorDatabase, "Failed to create time log %s", item.LocalID)
+	}
+	outcome.version = timeLog.Version
+	if err := replaceSyncBreaks(tx, timeLog, item); err != nil {
+		return outcome, err
+	}
 
-				// Update screenshots with task_id if task was created/found
-				if taskID != nil {
-					screenshots, _ := s.screenshotRepo.FindByTimeLogID(timeLog.ID)
-					for _, screenshot := range screenshots {
-						if screenshot.TaskID == nil {
-							screenshot.TaskID = taskID
-							s.screenshotRepo.Update(&screenshot)
-						}
-					}
+	tx.afterCommit = append(tx.afterCommit, func() {
+		ActivityFeedBroadcaster.Broadcast(ActivityEvent{
+			Action:     ActivityTimeLogCreated,
+			UserID:     &userID,
+			EntityType: "time_log",
+			EntityID:   &timeLog.ID,
+			Details:    map[string]interface{}{"task_id": timeLog.TaskID, "source": "sync"},
+		})
+		s.commitService.LinkFromNotes(timeLog)
+	})
+
+	if taskID != nil {
+		// Update task status and duration if this is for a manual task
+		if err := s.updateTaskAfterTimeLog(tx, *taskID, timeLog.Duration, timeLog.Status); err != nil {
+			return outcome, newSyncItemError(models.SyncErrorDatabase, "Failed to update task of time log %s", item.LocalID)
+		}
+
+		// Update screenshots with task_id if task was created/found
+		screenshots, _ := tx.Screenshots.FindByTimeLogID(timeLog.ID)
+		for _, screenshot := range screenshots {
+			if screenshot.TaskID == nil {
+				screenshot.TaskID = taskID
+				if err := tx.Screenshots.Update(&screenshot); err != nil {
+					return outcome, newSyncItemError(models.SyncErrorDatabase, "Failed to link screenshots of time log %s", item.LocalID)
 				}
 			}
 		}
 	}
 
-	return result
+	return outcome, nil
+}
+
+// resolveSyncTask finds the time log's task, auto-creating it from the title
+// as the organization's task creation policy allows. A failed creation fails
+// the time log so no task is left behind.
+func (s *syncService) resolveSyncTask(tx *syncTx, userID uint, item *dto.SyncTimeLogItem, orgID, wsID *uint) (*uint, error) {
+	// PRIORITY 1: Check if task_id is provided (manual task)
+	// This means the time log is for an existing manual task
+	if item.TaskID != nil && *item.TaskID > 0 {
+		// Verify the task exists and belongs or is assigned to this user
+		existingTask, err := tx.Tasks.FindByID(*item.TaskID)
+		if err == nil && existingTask != nil {
+			visible := existingTask.UserID == userID
+			if !visible {
+				visible, _ = tx.Tasks.IsAssignee(existingTask.ID, userID)
+			}
+			if visible {
+				fmt.Printf("🎯 Using existing manual task ID: %d (Title: %s)\n", existingTask.ID, existingTask.Title)
+				return item.TaskID, nil
+			}
+		}
+		fmt.Printf("⚠️  Manual task ID %d not found or not owned by user, will create new\n", *item.TaskID)
+	}
+
+	// PRIORITY 2: Check task_local_id (UUID) for auto-track tasks
+	if item.TaskLocalID != "" {
+		// Check if task already exists by LocalID
+		existingTask, err := tx.Tasks.FindByLocalID(item.TaskLocalID, userID)
+		if err != nil {
+			return nil, newSyncItemError(models.SyncErrorDatabase, "Failed to look up task %s: %v", item.TaskLocalID, err)
+		}
+		if existingTask != nil {
+			fmt.Printf("🔍 Found existing task by LocalID: %s (ID: %d)\n", item.TaskLocalID, existingTask.ID)
+			return &existingTask.ID, nil
+		}
+	}
+
+	policy := models.TaskCreationAlways
+	if orgID != nil {
+		policy = s.settings.String(*orgID, models.SettingSyncTaskCreation)
+	}
+	if policy == models.TaskCreationRequireExisting {
+		return nil, newSyncItemError(models.SyncErrorTaskRequired, "Time log %s must be on an existing task: the organization does not create tasks from synced time", item.LocalID)
+	}
+	if item.TaskTitle == "" {
+		return nil, nil
+	}
+
+	if policy == models.TaskCreationReuseByTitle {
+		dayStart := item.StartTime.UTC().Truncate(24 * time.Hour)
+		existingTask, err := tx.Tasks.FindByTitleTrackedBetween(userID, wsID, item.TaskTitle, dayStart, dayStart.AddDate(0, 0, 1))
+		if err != nil {
+			return nil, newSyncItemError(models.SyncErrorDatabase, "Failed to look up task %s: %v", item.TaskTitle, err)
+		}
+		if existingTask != nil {
+			fmt.Printf("🔍 Reusing task tracked today by title: %s (ID: %d)\n", item.TaskTitle, existingTask.ID)
+			return &existingTask.ID, nil
+		}
+	}
+
+	taskStatus := "completed"
+	if item.Status == "running" || item.Status == "paused" {
+		taskStatus = "active"
+	}
+
+	// PRIORITY 3: Create the task with the desktop UUID when sent; without
+	// one (older desktop apps) the database generates it
+	task := &models.Task{
+		UserID:         userID,
+		CreatedBy:      &userID,
+		OrganizationID: orgID,            // Set organization context
+		WorkspaceID:    wsID,             // Set workspace context
+		LocalID:        item.TaskLocalID, // Set UUID from Electron
+		Title:          item.TaskTitle,
+		Description:    item.Notes,
+		Status:         taskStatus,
+		Priority:       1,
+		IsManual:       false, // Auto-created from time tracker
+	}
+	if err := tx.Tasks.Create(task); err != nil {
+		return nil, newSyncItemError(models.SyncErrorDatabase, "Failed to create task %s: %v", item.TaskTitle, err)
+	}
+	fmt.Printf("✅ Auto-created task: %s (LocalID: %s, ID: %d, WsID: %v)\n", item.TaskTitle, item.TaskLocalID, task.ID, wsID)
+	return &task.ID, nil
+}
+
+// isSyncConflict reports whether the time log changed on the server after the
+// device's copy was taken. Clients that predate versioning send no base
+// version; for them a newer change synced from another device is a conflict.
+func isSyncConflict(existing *models.TimeLog, item *dto.SyncTimeLogItem, device *models.DeviceInfo) bool {
+	if sameTimeLogState(syncStateFromTimeLog(existing), syncStateFromItem(item)) {
+		return false
+	}
+	if item.BaseVersion != nil {
+		return *item.BaseVersion < existing.Version
+	}
+	if item.UpdatedAt == nil || device == nil || existing.SyncDeviceID == nil {
+		return false
+	}
+	return *existing.SyncDeviceID != device.ID && existing.UpdatedAt.After(*item.UpdatedAt)
+}
+
+// recordConflict stores the conflict and applies the configured policy.
+// Returns whether the device's change should overwrite the server.
+func (s *syncService) recordConflict(tx *syncTx, userID uint, existing *models.TimeLog, item *dto.SyncTimeLogItem, device *models.DeviceInfo) (*models.SyncConflict, bool, error) {
+	applyClient := false
+	if s.conflictPolicy == models.SyncConflictLastWriteWins {
+		// Without an edit time the device's change is the latest we know of
+		applyClient = item.UpdatedAt == nil || !item.UpdatedAt.Before(existing.UpdatedAt)
+	}
+
+	clientData, _ := json.Marshal(item)
+	serverData, _ := json.Marshal(syncStateFromTimeLog(existing))
+
+	conflict := &models.SyncConflict{
+		UserID:          userID,
+		TimeLogID:       existing.ID,
+		LocalID:         existing.LocalID,
+		Policy:          s.conflictPolicy,
+		ServerVersion:   existing.Version,
+		ClientVersion:   item.BaseVersion,
+		ClientUpdatedAt: item.UpdatedAt,
+		ClientData:      string(clientData),
+		ServerData:      string(serverData),
+		Status:          models.SyncConflictPending,
+	}
+	if device != nil {
+		conflict.DeviceID = &device.ID
+	}
+	if s.conflictPolicy != models.SyncConflictManual {
+		now := time.Now()
+		conflict.Status = models.SyncConflictResolved
+		conflict.ResolvedAt = &now
+		conflict.Resolution = models.SyncResolutionServerKept
+		if applyClient {
+			conflict.Resolution = models.SyncResolutionClientApplied
+		}
+	}
+
+	if err := tx.Conflicts.Create(conflict); err != nil {
+		return nil, false, err
+	}
+
+	return conflict, applyClient, nil
+}
+
+// enforceSingleTimer keeps one active session per user across devices when a
+// synced time log is running or paused. The most recently started session
+// wins: older ones, possibly the synced one itself, are stopped when the newer
+// one started. Under the reject policy the synced time log is refused instead.
+func (s *syncService) enforceSingleTimer(tx *syncTx, timeLog *models.TimeLog, batchIDs map[string]bool, outcome *timeLogOutcome) error {
+	if !isActiveTimeLogStatus(timeLog.Status) {
+		return nil
+	}
+
+	// Held until the item's transaction ends, so a concurrent start or sync
+	// of the user's timers sees this one
+	if err := tx.TimeLogs.LockUserTimers(timeLog.UserID); err != nil {
+		return newSyncItemError(models.SyncErrorDatabase, "Failed to lock running time logs for %s: %v", timeLog.LocalID, err)
+	}
+	active, err := tx.TimeLogs.FindAllActiveByUserID(timeLog.UserID)
+	if err != nil {
+		return newSyncItemError(models.SyncErrorDatabase, "Failed to look up running time logs for %s: %v", timeLog.LocalID, err)
+	}
+	others := make([]models.TimeLog, 0, len(active))
+	for _, other := range active {
+		if other.ID != timeLog.ID && !batchIDs[other.LocalID] {
+			others = append(others, other)
+		}
+	}
+	if len(others) == 0 {
+		return nil
+	}
+
+	if s.timerPolicy == models.TimerConcurrencyReject {
+		return newSyncItemError(models.SyncErrorTimerRunning, "Time log %s rejected: time log %s is already running", timeLog.LocalID, others[0].LocalID)
+	}
+
+	// others are newest first
+	if newest := &others[0]; newest.StartTime.After(timeLog.StartTime) {
+		stopTimeLogAt(timeLog, newest.StartTime)
+		outcome.stopped = append(outcome.stopped, timeLog.LocalID)
+		others = others[1:]
+		timeLog = newest
+	}
+	for i := range others {
+		stopTimeLogAt(&others[i], timeLog.StartTime)
+		if err := tx.TimeLogs.Update(&others[i]); err != nil {
+			return newSyncItemError(models.SyncErrorDatabase, "Failed to stop running time log %s: %v", others[i].LocalID, err)
+		}
+		outcome.stopped = append(outcome.stopped, others[i].LocalID)
+	}
+	return nil
+}
+
+// applySyncTimeLogItem copies the device-owned fields of a sync item onto the time log
+func applySyncTimeLogItem(timeLog *models.TimeLog, item *dto.SyncTimeLogItem) {
+	timeLog.EndTime = item.EndTime
+	timeLog.PausedAt = item.PausedAt
+	timeLog.ResumedAt = item.ResumedAt
+	timeLog.Duration = item.Duration
+	timeLog.PausedTotal = item.PausedTotal
+	if item.Breaks != nil || item.BreakTotal > 0 {
+		timeLog.BreakTotal = syncBreakTotal(item)
+	}
+	timeLog.Status = item.Status
+	timeLog.Notes = item.Notes
+	timeLog.TaskTitle = item.TaskTitle
+	timeLog.IsSynced = true
+}
+
+// syncBreakTotal returns the break time reported for a time log, summing the
+// break segments when the device sent no total
+func syncBreakTotal(item *dto.SyncTimeLogItem) int64 {
+	if item.BreakTotal > 0 {
+		return item.BreakTotal
+	}
+	var total int64
+	for _, b := range item.Breaks {
+		total += b.Duration
+	}
+	return total
+}
+
+// replaceSyncBreaks stores the break segments sent with a time log. Items
+// without breaks keep the ones already on the server.
+func replaceSyncBreaks(tx *syncTx, timeLog *models.TimeLog, item *dto.SyncTimeLogItem) error {
+	if item.Breaks == nil {
+		return nil
+	}
+	breaks := make([]models.TimeLogBreak, 0, len(item.Breaks))
+	for _, b := range item.Breaks {
+		kind := b.Kind
+		if kind == "" {
+			kind = models.BreakKindShort
+		}
+		breaks = append(breaks, models.TimeLogBreak{
+			UserID:    timeLog.UserID,
+			LocalID:   b.LocalID,
+			Kind:      kind,
+			StartTime: b.StartTime,
+			EndTime:   b.EndTime,
+			Duration:  b.Duration,
+		})
+	}
+	if err := tx.TimeLogs.ReplaceBreaks(timeLog.ID, breaks); err != nil {
+		return newSyncItemError(models.SyncErrorDatabase, "Failed to store breaks of time log %s", item.LocalID)
+	}
+	return nil
 }
 
-func (s *syncService) syncScreenshots(userID uint, device *models.DeviceInfo, items []dto.SyncScreenshotItem, defaultOrgID *uint, defaultWsID *uint) dto.SyncResult {
+// checkHourCap evaluates the time log against the member's weekly hour cap and
+// reports any flag in the time log's outcome
+func (s *syncService) checkHourCap(timeLog *models.TimeLog, outcome *timeLogOutcome) *CapCheck {
+	check, err := s.complianceService.CheckTimeLog(timeLog)
+	if err != nil {
+		fmt.Printf("⚠️  Failed to check weekly hour cap for time log %s: %v\n", timeLog.LocalID, err)
+		return nil
+	}
+	if check == nil || check.Status == "" {
+		return check
+	}
+
+	outcome.capFlag = &dto.SyncCapFlag{
+		LocalID:       timeLog.LocalID,
+		WorkspaceID:   check.WorkspaceID,
+		Status:        check.Status,
+		Blocked:       check.Blocked,
+		WeeklyHourCap: check.WeeklyHourCap,
+		LoggedHours:   check.LoggedHours,
+	}
+	return check
+}
+
+// syncScreenshots syncs each screenshot in its own transaction, or all of them
+// in batch when given one. With a batch transaction it stops at the first error
+// that must roll the batch back and returns it.
+func (s *syncService) syncScreenshots(batch *syncTx, userID uint, device *models.DeviceInfo, items []dto.SyncScreenshotItem, defaultOrgID *uint, defaultWsID *uint) (dto.SyncResult, error) {
 	result := dto.SyncResult{
 		Total:   len(items),
 		Success: 0,
 		Failed:  0,
 		Errors:  []string{},
 	}
+	exclusions := make(map[uint][]models.CaptureExclusionRule)
+	workspaces := make(map[uint]*models.Workspace)
+	quotas := make(map[uint]*int64) // Bytes each organization can still store, nil without a quota
+	private := s.privateIntervalsOf(userID, items)
 
 	for _, item := range items {
 		// Resolve organization and workspace IDs
@@ -381,121 +899,604 @@ func (s *syncService) syncScreenshots(userID uint, device *models.DeviceInfo, it
 			wsID = defaultWsID
 		}
 
-		// Check if screenshot already exists
-		existing, _ := s.screenshotRepo.FindByLocalID(item.LocalID, userID)
-		if existing != nil {
-			// Verify file still exists
-			if utils.FileExists(existing.FilePath) {
+		// Workspaces with screenshots disabled reject uploads outright
+		var workspace *models.Workspace
+		if wsID != nil {
+			cached := false
+			if workspace, cached = workspaces[*wsID]; !cached {
+				workspace, _ = s.workspaceRepo.GetByID(*wsID)
+				workspaces[*wsID] = workspace
+			}
+		}
+		if workspace != nil && workspace.ScreenshotsDisabled {
+			addSyncItemError(&result, item.LocalID, newSyncItemError(models.SyncErrorScreenshotsDisabled,
+				"Screenshot %s rejected: screenshots are disabled in workspace %d", item.LocalID, workspace.ID))
+			continue
+		}
+		blur := workspace != nil && workspace.ScreenshotBlurMode == models.ScreenshotBlurBlur
+
+		if item.IsEncrypted {
+			if err := s.validateEncryptedScreenshot(&item, orgID); err != nil {
+				addSyncItemError(&result, item.LocalID, err)
+				continue
+			}
+		}
+
+		// The user paused capture; the agent should not have captured at all
+		if interval := privateIntervalAt(private, item.CapturedAt); interval != nil {
+			fmt.Printf("🙈 Screenshot %s discarded by private interval %d\n", item.LocalID, interval.ID)
+			result.Success++
+			result.Discarded = append(result.Discarded, item.LocalID)
+			continue
+		}
+
+		// Defense in depth: the agent should not have captured a sensitive window
+		// at all, so a matching upload is dropped before it touches the disk
+		if orgID != nil && (item.AppName != "" || item.WindowTitle != "") {
+			rules, cached := exclusions[*orgID]
+			if !cached {
+				rules, _ = s.capturePolicyService.ActiveRules(*orgID)
+				exclusions[*orgID] = rules
+			}
+			if rule := matchCaptureExclusion(rules, item.AppName, item.WindowTitle); rule != nil {
+				fmt.Printf("🙈 Screenshot %s discarded by capture exclusion rule %d\n", item.LocalID, rule.ID)
 				result.Success++
+				result.Discarded = append(result.Discarded, item.LocalID)
 				continue
 			}
-			// File missing, delete old record and re-upload
-			fmt.Printf("⚠️  Screenshot file missing, re-uploading: %s\n", existing.FilePath)
-			s.screenshotRepo.Delete(existing.ID)
 		}
 
-		// Decode base64 data
-		imageData, err := base64.StdEncoding.DecodeString(item.Base64Data)
+		var remaining *int64
+		if orgID != nil {
+			cached := false
+			if remaining, cached = quotas[*orgID]; !cached {
+				var err error
+				if remaining, err = s.storageQuotas.Remaining(*orgID); err != nil {
+					fmt.Printf("⚠️  Failed to check storage quota of organization %d: %v\n", *orgID, err)
+				}
+				quotas[*orgID] = remaining
+			}
+		}
+
+		sync := func(tx *syncTx) error {
+			return s.syncScreenshot(tx, userID, device, &item, orgID, wsID, blur, remaining)
+		}
+
+		var err error
+		if batch != nil {
+			err = sync(batch)
+		} else {
+			err = s.inTransaction(sync)
+		}
+
 		if err != nil {
-			result.Failed++
-			result.Errors = append(result.Errors, fmt.Sprintf("Failed to decode screenshot %s: %v", item.LocalID, err))
+			addSyncItemError(&result, item.LocalID, err)
+			if batch != nil && abortsBatch(err) {
+				return result, err
+			}
 			continue
 		}
+		result.Success++
+	}
 
+	return result, nil
+}
+
+// privateIntervalsOf loads the user's private intervals overlapping the
+// capture times of the screenshots
+func (s *syncService) privateIntervalsOf(userID uint, items []dto.SyncScreenshotItem) []models.PrivateInterval {
+	if len(items) == 0 {
+		return nil
+	}
+	first, last := items[0].CapturedAt, items[0].CapturedAt
+	for _, item := range items[1:] {
+		if item.CapturedAt.Before(first) {
+			first = item.CapturedAt
+		}
+		if item.CapturedAt.After(last) {
+			last = item.CapturedAt
+		}
+	}
+
+	intervals, err := s.privateIntervals.Overlapping(userID, first, last)
+	if err != nil {
+		fmt.Printf("⚠️  Failed to load private intervals of user %d: %v\n", userID, err)
+		return nil
+	}
+	return intervals
+}
+
+// validateEncryptedScreenshot checks that an encrypted upload carries its
+// envelope and references a key registered for the organization, so it can be
+// decrypted later
+func (s *syncService) validateEncryptedScreenshot(item *dto.SyncScreenshotItem, orgID *uint) error {
+	if orgID == nil {
+		return newSyncItemError(models.SyncErrorInvalidData, "Encrypted screenshot %s has no organization", item.LocalID)
+	}
+	if item.KeyFingerprint == "" || item.WrappedKey == "" || item.Nonce == "" {
+		return newSyncItemError(models.SyncErrorInvalidData, "Encrypted screenshot %s is missing key_fingerprint, wrapped_key or nonce", item.LocalID)
+	}
+	if err := s.encryptionKeyService.ValidateUploadKey(*orgID, item.KeyFingerprint); err != nil {
+		if errors.Is(err, ErrEncryptionKeyNotFound) {
+			return newSyncItemError(models.SyncErrorInvalidData, "Encrypted screenshot %s uses a key not registered for organization %d", item.LocalID, *orgID)
+		}
+		return newSyncItemError(models.SyncErrorDatabase, "Failed to look up encryption key for screenshot %s: %v", item.LocalID, err)
+	}
+	return nil
+}
+
+// syncScreenshot stores one screenshot file and its record, plus a blurred
+// variant when blur is set. New screenshots larger than the organization's
+// remaining storage quota are rejected; stored ones are taken off remaining.
+func (s *syncService) syncScreenshot(tx *syncTx, userID uint, device *models.DeviceInfo, item *dto.SyncScreenshotItem, orgID, wsID *uint, blur bool, remaining *int64) error {
+	// Check if screenshot already exists
+	existing, err := tx.Screenshots.FindByLocalID(item.LocalID, userID)
+	if err != nil {
+		return newSyncItemError(models.SyncErrorDatabase, "Failed to look up screenshot %s: %v", item.LocalID, err)
+	}
+	if existing != nil {
+		// Verify file still exists
+		if utils.FileExists(existing.FilePath) {
+			return nil
+		}
+		// File missing, delete old record and re-upload
+		fmt.Printf("⚠️  Screenshot file missing, re-uploading: %s\n", existing.FilePath)
+		if err := tx.Screenshots.Delete(existing.ID); err != nil {
+			return newSyncItemError(models.SyncErrorDatabase, "Failed to replace screenshot %s: %v", item.LocalID, err)
+		}
+	}
+
+	if remaining != nil && item.FileSize > *remaining {
+		return newSyncItemError(models.SyncErrorQuotaExceeded, "Screenshot %s rejected: organization %d reached its storage quota", item.LocalID, *orgID)
+	}
+
+	// Decode base64 data
+	imageData, err := base64.StdEncoding.DecodeString(item.Base64Data)
+	if err != nil {
+		return newSyncItemError(models.SyncErrorInvalidData, "Failed to decode screenshot %s: %v", item.LocalID, err)
+	}
+
+	// The client's MimeType is not trusted: the bytes themselves must be an
+	// image within the upload limits
+	raw := imageData
+	imageData, mimeType, err := sanitizeScreenshot(item, raw)
+	if err != nil {
+		return newSyncItemError(models.SyncErrorInvalidData, "Screenshot %s rejected: %v", item.LocalID, err)
+	}
+	fileSize := int64(len(imageData))
+
+	// A static screen uploads the same image over and over; it shares the
+	// file of the earlier identical screenshot instead of storing a copy
+	var filePath string
+	if !blur {
+		original, err := s.findDuplicateScreenshot(tx, userID, orgID, item, raw, fileSize)
+		if err != nil {
+			return newSyncItemError(models.SyncErrorDatabase, "Failed to look up duplicates of screenshot %s: %v", item.LocalID, err)
+		}
+		if original != nil {
+			filePath = original.FilePath
+			fmt.Printf("♻️  Screenshot %s shares the file of screenshot %d: %s\n", item.LocalID, original.ID, filePath)
+		}
+	}
+
+	if filePath == "" {
 		// Save file
-		filePath, err := utils.SaveBase64File(imageData, "screenshots", item.FileName)
+		filePath, err = utils.SavePrivateFile(imageData, screenshotDir, item.FileName)
 		if err != nil {
-			result.Failed++
-			result.Errors = append(result.Errors, fmt.Sprintf("Failed to save screenshot %s: %v", item.LocalID, err))
-			continue
+			return newSyncItemError(models.SyncErrorStorage, "Failed to save screenshot %s: %v", item.LocalID, err)
 		}
+		tx.files = append(tx.files, filePath)
 
 		// Verify file was saved successfully
 		if !utils.FileExists(filePath) {
-			result.Failed++
-			result.Errors = append(result.Errors, fmt.Sprintf("Screenshot file not found after save: %s", filePath))
-			continue
+			return newSyncItemError(models.SyncErrorStorage, "Screenshot file not found after save: %s", filePath)
 		}
 
-		fmt.Printf("✅ Screenshot saved: %s (size: %d bytes)\n", filePath, item.FileSize)
+		fmt.Printf("✅ Screenshot saved: %s (size: %d bytes)\n", filePath, fileSize)
+	}
 
-		// IMPORTANT: TimeLogID from Electron is LOCAL ID, not server ID
-		// We need to find the actual TimeLog by LocalID if provided
-		var serverTimeLogID *uint
-		if item.TimeLogLocalID != "" {
-			timeLog, err := s.timeLogRepo.FindByLocalID(item.TimeLogLocalID, userID)
-			if err == nil && timeLog != nil {
-				serverTimeLogID = &timeLog.ID
-			} else {
-				fmt.Printf("⚠️  TimeLog not found for LocalID: %s, screenshot will have null timelog_id\n", item.TimeLogLocalID)
-			}
+	// Encrypted uploads cannot be blurred; without a variant, managers are
+	// refused and only system admins see the original
+	var blurredPath string
+	if blur && !item.IsEncrypted {
+		blurred, err := imaging.Blur(imageData)
+		if err != nil {
+			fmt.Printf("⚠️  Failed to blur screenshot %s: %v\n", item.LocalID, err)
+		} else if blurredPath, err = utils.SavePrivateFile(blurred, blurredScreenshotDir, item.FileName); err != nil {
+			return newSyncItemError(models.SyncErrorStorage, "Failed to save blurred screenshot %s: %v", item.LocalID, err)
+		} else {
+			tx.files = append(tx.files, blurredPath)
+		}
+	}
+
+	// IMPORTANT: TimeLogID from Electron is LOCAL ID, not server ID
+	// We need to find the actual TimeLog by LocalID if provided
+	var serverTimeLogID *uint
+	if item.TimeLogLocalID != "" {
+		timeLog, err := tx.TimeLogs.FindByLocalID(item.TimeLogLocalID, userID)
+		if err == nil && timeLog != nil {
+			serverTimeLogID = &timeLog.ID
+		} else {
+			fmt.Printf("⚠️  TimeLog not found for LocalID: %s, screenshot will have null timelog_id\n", item.TimeLogLocalID)
 		}
+	}
 
-		// IMPORTANT: Find actual TaskID from TaskLocalID
-		// This is essential for manual tasks where TaskID might be set
-		var serverTaskID *uint
-		if item.TaskID != nil && *item.TaskID > 0 {
-			// If TaskID is provided directly (manual task case), verify it exists
-			task, err := s.taskRepo.FindByID(*item.TaskID)
-			if err == nil && task != nil {
-				serverTaskID = &task.ID
-				fmt.Printf("✅ Screenshot task found by TaskID: %d\n", *serverTaskID)
-			}
+	// IMPORTANT: Find actual TaskID from TaskLocalID
+	// This is essential for manual tasks where TaskID might be set
+	var serverTaskID *uint
+	if item.TaskID != nil && *item.TaskID > 0 {
+		// If TaskID is provided directly (manual task case), verify it exists
+		task, err := tx.Tasks.FindByID(*item.TaskID)
+		if err == nil && task != nil {
+			serverTaskID = &task.ID
+			fmt.Printf("✅ Screenshot task found by TaskID: %d\n", *serverTaskID)
 		}
-		if serverTaskID == nil && item.TaskLocalID != "" {
-			// Find task by TaskLocalID
-			task, err := s.taskRepo.FindByLocalID(item.TaskLocalID, userID)
-			if err == nil && task != nil {
-				serverTaskID = &task.ID
-				fmt.Printf("✅ Screenshot task found by TaskLocalID: %s -> TaskID: %d\n", item.TaskLocalID, *serverTaskID)
-			} else {
-				fmt.Printf("⚠️  Task not found for TaskLocalID: %s\n", item.TaskLocalID)
-			}
+	}
+	if serverTaskID == nil && item.TaskLocalID != "" {
+		// Find task by TaskLocalID
+		task, err := tx.Tasks.FindByLocalID(item.TaskLocalID, userID)
+		if err == nil && task != nil {
+			serverTaskID = &task.ID
+			fmt.Printf("✅ Screenshot task found by TaskLocalID: %s -> TaskID: %d\n", item.TaskLocalID, *serverTaskID)
+		} else {
+			fmt.Printf("⚠️  Task not found for TaskLocalID: %s\n", item.TaskLocalID)
+		}
+	}
+
+	// Create screenshot record
+	screenshot := &models.Screenshot{
+		UserID:         userID,
+		OrganizationID: orgID,            // Set organization context
+		WorkspaceID:    wsID,             // Set workspace context
+		TimeLogID:      serverTimeLogID,  // Use mapped server ID or nil
+		TaskID:         serverTaskID,     // Use resolved server TaskID
+		TaskLocalID:    item.TaskLocalID, // Primary task identifier (UUID)
+		LocalID:        item.LocalID,
+		FilePath:       filePath,
+		BlurredPath:    blurredPath,
+		FileName:       item.FileName,
+		FileSize:       fileSize,
+		MimeType:       mimeType,
+		CapturedAt:     item.CapturedAt,
+		ScreenNumber:   item.ScreenNumber,
+		IsEncrypted:    item.IsEncrypted,
+		Checksum:       item.Checksum,
+		IsSynced:       true,
+	}
+	if item.IsEncrypted {
+		screenshot.KeyFingerprint = item.KeyFingerprint
+		screenshot.WrappedKey = item.WrappedKey
+		screenshot.EncryptionNonce = item.Nonce
+	}
+
+	if device != nil {
+		screenshot.DeviceID = &device.ID
+	}
+	// Encrypted files cannot be scanned; plain ones are scanned after commit
+	if s.scans.Enabled() && !item.IsEncrypted {
+		screenshot.ScanStatus = models.ScreenshotScanPending
+	}
+	// Read by the OCR job once the file passed its scan
+	if s.ocr.Enabled() && !item.IsEncrypted {
+		screenshot.OCRStatus = models.ScreenshotOCRPending
+	}
+
+	if err := tx.Screenshots.Create(screenshot); err != nil {
+		return newSyncItemError(models.SyncErrorDatabase, "Failed to create screenshot DB record %s: %v", item.LocalID, err)
+	}
+	if screenshot.ScanStatus == models.ScreenshotScanPending {
+		tx.afterCommit = append(tx.afterCommit, func() {
+			s.scans.ScanAsync(screenshot.ID)
+		})
+	}
+	if remaining != nil {
+		*remaining -= fileSize
+	}
+	return nil
+}
+
+// sanitizeScreenshot validates an uploaded screenshot file and returns the
+// file to store with its MIME type. Plain images are sniffed by their magic
+// bytes and stripped of metadata; encrypted ones can only be size-checked.
+func sanitizeScreenshot(item *dto.SyncScreenshotItem, data []byte) ([]byte, string, error) {
+	upload := config.AppConfig.Upload
+	if item.IsEncrypted {
+		if upload.MaxSize > 0 && int64(len(data)) > upload.MaxSize {
+			return nil, "", apperror.Validation(fmt.Sprintf("file is %d bytes, over the %d byte limit", len(data), upload.MaxSize), nil)
 		}
+		return data, item.MimeType, nil
+	}
+
+	if item.MimeType != "" && len(upload.AllowedFileTypes) > 0 && !slices.Contains(upload.AllowedFileTypes, item.MimeType) {
+		return nil, "", apperror.Validation(fmt.Sprintf("file type %s is not allowed", item.MimeType), nil)
+	}
+	clean, mimeType, err := imaging.Sanitize(data, imaging.Limits{
+		MaxBytes:  upload.MaxSize,
+		MaxWidth:  upload.MaxScreenshotWidth,
+		MaxHeight: upload.MaxScreenshotHeight,
+	})
+	if err != nil {
+		return nil, "", apperror.Validation(err.Error(), nil)
+	}
+	if item.MimeType != "" && item.MimeType != mimeType && !(item.MimeType == "image/jpg" && mimeType == "image/jpeg") {
+		return nil, "", apperror.Validation(fmt.Sprintf("file is %s, not the declared %s", mimeType, item.MimeType), nil)
+	}
+	return clean, mimeType, nil
+}
+
+// findDuplicateScreenshot returns an earlier screenshot of the user with the
+// same content, captured within screenshotDedupeWindow, whose file the new
+// one can share. The checksum is verified against the uploaded data, so a
+// wrong client checksum never links unrelated images.
+func (s *syncService) findDuplicateScreenshot(tx *syncTx, userID uint, orgID *uint, item *dto.SyncScreenshotItem, raw []byte, fileSize int64) (*models.Screenshot, error) {
+	// Encrypted files are unique per upload, under their own content key
+	if item.IsEncrypted || item.Checksum == "" || !strings.EqualFold(utils.CalculateChecksum(raw), item.Checksum) {
+		return nil, nil
+	}
+
+	original, err := tx.Screenshots.FindDuplicate(userID, orgID, item.Checksum, fileSize,
+		item.CapturedAt.Add(-screenshotDedupeWindow), item.CapturedAt.Add(screenshotDedupeWindow))
+	if err != nil || original == nil {
+		return nil, err
+	}
+	// The file may be gone, e.g. deleted outside the application
+	if !utils.FileExists(original.FilePath) {
+		return nil, nil
+	}
+	return original, nil
+}
+
+// ============================================================================
+// TRANSACTIONS
+// ============================================================================
+
+// syncTx is one sync transaction: the repositories bound to it, the files
+// written under it and the side effects that must wait for its commit
+type syncTx struct {
+	repository.SyncRepos
+	files       []string
+	afterCommit []func()
+}
 
-		// Create screenshot record
-		screenshot := &models.Screenshot{
-			UserID:         userID,
-			OrganizationID: orgID,            // Set organization context
-			WorkspaceID:    wsID,             // Set workspace context
-			TimeLogID:      serverTimeLogID,  // Use mapped server ID or nil
-			TaskID:         serverTaskID,     // Use resolved server TaskID
-			TaskLocalID:    item.TaskLocalID, // Primary task identifier (UUID)
-			LocalID:        item.LocalID,
-			FilePath:       filePath,
-			FileName:       item.FileName,
-			FileSize:       item.FileSize,
-			MimeType:       item.MimeType,
-			CapturedAt:     item.CapturedAt,
-			ScreenNumber:   item.ScreenNumber,
-			IsEncrypted:    item.IsEncrypted,
-			Checksum:       item.Checksum,
-			IsSynced:       true,
+// inTransaction runs fn in a new transaction. On rollback the files written
+// under it are deleted so no screenshot file is left without its record.
+func (s *syncService) inTransaction(fn func(tx *syncTx) error) error {
+	tx := &syncTx{}
+	err := s.syncStore.Transaction(func(repos repository.SyncRepos) error {
+		tx.SyncRepos = repos
+		return fn(tx)
+	})
+	if err != nil {
+		for _, path := range tx.files {
+			utils.DeleteFile(path)
 		}
+		return err
+	}
 
-		if device != nil {
-			screenshot.DeviceID = &device.ID
+	for _, f := range tx.afterCommit {
+		f()
+	}
+	return nil
+}
+
+// syncItemError is why one item failed to sync, with a code the client can act on
+type syncItemError struct {
+	code    string
+	message string
+}
+
+func (e *syncItemError) Error() string {
+	return e.message
+}
+
+func newSyncItemError(code, format string, args ...interface{}) *syncItemError {
+	return &syncItemError{code: code, message: fmt.Sprintf(format, args...)}
+}
+
+// abortsBatch reports whether the error must roll back a batch transaction.
+// Items rejected as sent leave nothing written and do not.
+func abortsBatch(err error) bool {
+	var itemErr *syncItemError
+	if !errors.As(err, &itemErr) {
+		return true
+	}
+	return itemErr.code == models.SyncErrorDatabase || itemErr.code == models.SyncErrorStorage
+}
+
+func addSyncItemError(result *dto.SyncResult, localID string, err error) {
+	code, message := models.SyncErrorDatabase, fmt.Sprintf("Failed to sync %s: %v", localID, err)
+	var itemErr *syncItemError
+	if errors.As(err, &itemErr) {
+		code, message = itemErr.code, itemErr.message
+	}
+
+	result.Failed++
+	result.Errors = append(result.Errors, message)
+	result.ItemErrors = append(result.ItemErrors, dto.SyncItemError{
+		LocalID: localID,
+		Code:    code,
+		Message: message,
+	})
+}
+
+// abortedSyncResult reports every item of a rolled back batch as failed. Items
+// that failed on their own keep their error; the others get batch_aborted.
+func abortedSyncResult(localIDs []string, result dto.SyncResult) dto.SyncResult {
+	own := make(map[string]dto.SyncItemError, len(result.ItemErrors))
+	for _, itemErr := range result.ItemErrors {
+		own[itemErr.LocalID] = itemErr
+	}
+
+	aborted := dto.SyncResult{
+		Total:  len(localIDs),
+		Failed: len(localIDs),
+		Errors: []string{},
+	}
+	for _, localID := range localIDs {
+		itemErr, ok := own[localID]
+		if !ok {
+			itemErr = dto.SyncItemError{
+				LocalID: localID,
+				Code:    models.SyncErrorBatchAborted,
+				Message: fmt.Sprintf("Sync of %s rolled back with the rest of the batch", localID),
+			}
 		}
+		aborted.Errors = append(aborted.Errors, itemErr.Message)
+		aborted.ItemErrors = append(aborted.ItemErrors, itemErr)
+	}
+	return aborted
+}
 
-		if err := s.screenshotRepo.Create(screenshot); err != nil {
-			result.Failed++
-			result.Errors = append(result.Errors, fmt.Sprintf("Failed to create screenshot DB record %s: %v", item.LocalID, err))
-			// Cleanup file if DB insert failed
-			utils.DeleteFile(filePath)
-		} else {
-			result.Success++
+// ============================================================================
+// SYNC CONFLICTS
+// ============================================================================
+
+func (s *syncService) ListConflicts(userID uint, status string, page, perPage int) ([]dto.SyncConflictResponse, int64, error) {
+	conflicts, total, err := s.conflictRepo.FindByUserID(userID, status, page, perPage)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	responses := make([]dto.SyncConflictResponse, 0, len(conflicts))
+	for i := range conflicts {
+		responses = append(responses, toSyncConflictResponse(&conflicts[i]))
+	}
+	return responses, total, nil
+}
+
+func (s *syncService) ResolveConflict(userID, conflictID uint, req *dto.ResolveSyncConflictRequest) (*dto.SyncConflictResponse, error) {
+	conflict, err := s.conflictRepo.FindByID(conflictID, userID)
+	if err != nil {
+		return nil, apperror.NotFound("sync conflict not found")
+	}
+	if conflict.Status != models.SyncConflictPending {
+		return nil, apperror.Conflict("sync conflict has already been resolved")
+	}
+
+	err = s.inTransaction(func(tx *syncTx) error {
+		resolution := models.SyncResolutionServerKept
+		if req.Keep == "client" {
+			var item dto.SyncTimeLogItem
+			if err := json.Unmarshal([]byte(conflict.ClientData), &item); err != nil {
+				return fmt.Errorf("failed to read device change: %w", err)
+			}
+
+			timeLog, err := tx.TimeLogs.FindByID(conflict.TimeLogID)
+			if err != nil {
+				return apperror.NotFound("time log not found")
+			}
+
+			lockedBefore, err := s.periodLocks.LockedBefore(timeLog.OrganizationID, userID)
+			if err != nil {
+				return err
+			}
+			if lockedBefore != nil && (timeLog.StartTime.Before(*lockedBefore) || item.StartTime.Before(*lockedBefore)) {
+				return periodLockedError(*lockedBefore)
+			}
+
+			before := timeLog.Snapshot()
+			applySyncTimeLogItem(timeLog, &item)
+			timeLog.SyncDeviceID = conflict.DeviceID
+			updated, err := tx.TimeLogs.UpdateIfVersion(timeLog, timeLog.Version)
+			if err != nil {
+				return err
+			}
+			if !updated {
+				return apperror.Conflict("time log changed while resolving, please retry")
+			}
+			if edit := newTimeLogEdit(timeLog.ID, before, timeLog.Snapshot(), &userID, models.TimeLogEditSourceConflict); edit != nil {
+				if err := tx.Edits.Create(edit); err != nil {
+					return err
+				}
+			}
+			if timeLog.TaskID != nil {
+				if err := s.updateTaskAfterTimeLog(tx, *timeLog.TaskID, timeLog.Duration, timeLog.Status); err != nil {
+					return err
+				}
+			}
+			resolution = models.SyncResolutionClientApplied
 		}
+
+		now := time.Now()
+		conflict.Status = models.SyncConflictResolved
+		conflict.Resolution = resolution
+		conflict.ResolvedAt = &now
+		return tx.Conflicts.Update(conflict)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return result
+	response := toSyncConflictResponse(conflict)
+	return &response, nil
+}
+
+func toSyncConflictResponse(c *models.SyncConflict) dto.SyncConflictResponse {
+	response := dto.SyncConflictResponse{
+		ID:            c.ID,
+		TimeLogID:     c.TimeLogID,
+		LocalID:       c.LocalID,
+		Policy:        c.Policy,
+		Status:        c.Status,
+		Resolution:    c.Resolution,
+		ServerVersion: c.ServerVersion,
+		ClientVersion: c.ClientVersion,
+		CreatedAt:     c.CreatedAt,
+		ResolvedAt:    c.ResolvedAt,
+	}
+
+	json.Unmarshal([]byte(c.ServerData), &response.Server)
+	var item dto.SyncTimeLogItem
+	if err := json.Unmarshal([]byte(c.ClientData), &item); err == nil {
+		response.Client = syncStateFromItem(&item)
+	}
+
+	return response
+}
+
+func syncStateFromTimeLog(t *models.TimeLog) dto.SyncTimeLogState {
+	updatedAt := t.UpdatedAt
+	return dto.SyncTimeLogState{
+		EndTime:     t.EndTime,
+		Duration:    t.Duration,
+		PausedTotal: t.PausedTotal,
+		Status:      t.Status,
+		Notes:       t.Notes,
+		TaskTitle:   t.TaskTitle,
+		UpdatedAt:   &updatedAt,
+	}
+}
+
+func syncStateFromItem(item *dto.SyncTimeLogItem) dto.SyncTimeLogState {
+	return dto.SyncTimeLogState{
+		EndTime:     item.EndTime,
+		Duration:    item.Duration,
+		PausedTotal: item.PausedTotal,
+		Status:      item.Status,
+		Notes:       item.Notes,
+		TaskTitle:   item.TaskTitle,
+		UpdatedAt:   item.UpdatedAt,
+	}
+}
+
+// sameTimeLogState compares the conflicting fields, ignoring edit times
+func sameTimeLogState(a, b dto.SyncTimeLogState) bool {
+	sameEnd := (a.EndTime == nil && b.EndTime == nil) ||
+		(a.EndTime != nil && b.EndTime != nil && a.EndTime.Equal(*b.EndTime))
+	return sameEnd &&
+		a.Duration == b.Duration &&
+		a.PausedTotal == b.PausedTotal &&
+		a.Status == b.Status &&
+		a.Notes == b.Notes &&
+		a.TaskTitle == b.TaskTitle
 }
 
 // updateTaskAfterTimeLog updates task status after time log sync
-func (s *syncService) updateTaskAfterTimeLog(taskID uint, duration int64, status string) {
+func (s *syncService) updateTaskAfterTimeLog(tx *syncTx, taskID uint, duration int64, status string) error {
 	// Get task
-	task, err := s.taskRepo.FindByID(taskID)
+	task, err := tx.Tasks.FindByID(taskID)
 	if err != nil || task == nil {
-		return
+		return nil
 	}
 
 	// Update task status based on time log status
@@ -504,7 +1505,7 @@ func (s *syncService) updateTaskAfterTimeLog(taskID uint, duration int64, status
 	} else if status == "stopped" || status == "completed" {
 		// Check if there are any running time logs for this task
 		hasRunning := false
-		timeLogs, _ := s.timeLogRepo.FindByTaskID(taskID)
+		timeLogs, _ := tx.TimeLogs.FindByTaskID(taskID)
 		for _, tl := range timeLogs {
 			if tl.Status == "running" {
 				hasRunning = true
@@ -517,5 +1518,5 @@ func (s *syncService) updateTaskAfterTimeLog(taskID uint, duration int64, status
 	}
 
 	// Save task
-	s.taskRepo.Update(task)
+	return tx.Tasks.Update(task)
 }
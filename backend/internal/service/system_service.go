@@ -3,6 +3,7 @@ package service
 import (
 	"errors"
 
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
 	"github.com/beuphecan/remote-time-tracker/internal/dto"
 	"github.com/beuphecan/remote-time-tracker/internal/models"
 	"github.com/beuphecan/remote-time-tracker/internal/repository"
@@ -47,13 +48,13 @@ func (s *systemService) InitializeAdmin(req *dto.InitAdminRequest) (*models.User
 		return nil, err
 	}
 	if hasAdmin {
-		return nil, errors.New("system admin already exists")
+		return nil, apperror.Conflict("system admin already exists")
 	}
 
 	// Check if email already exists
 	existingUser, _ := s.userRepo.FindByEmail(req.Email)
 	if existingUser != nil {
-		return nil, errors.New("email already registered")
+		return nil, apperror.Conflict("email already registered")
 	}
 
 	// Hash password
@@ -0,0 +1,367 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+)
+
+// TaskAssignmentService manages workspace task assignment rules and applies
+// them to new tasks
+type TaskAssignmentService interface {
+	// Rule management (workspace managers)
+	ListRules(workspaceID, userID uint) ([]dto.TaskAssignmentRuleResponse, error)
+	CreateRule(workspaceID, userID uint, req *dto.CreateTaskAssignmentRuleRequest) (*dto.TaskAssignmentRuleResponse, error)
+	UpdateRule(workspaceID, ruleID, userID uint, req *dto.UpdateTaskAssignmentRuleRequest) (*dto.TaskAssignmentRuleResponse, error)
+	DeleteRule(workspaceID, ruleID, userID uint) error
+
+	// ApplyRules sets the assignee of an unsaved workspace task created by
+	// actorID. Every task creation path (API, imports, incoming webhooks) calls
+	// it before saving. The task keeps its owner when no rule matches.
+	ApplyRules(task *models.Task, actorID uint) error
+
+	// Assign hands a workspace task to a workspace member chosen by actorID
+	Assign(task *models.Task, assigneeID, actorID uint) error
+	// CheckAssignee verifies that actorID may assign the workspace task and
+	// that assigneeID is a member of its workspace
+	CheckAssignee(task *models.Task, assigneeID, actorID uint) error
+	// RequireAssigner verifies that actorID may assign the workspace task
+	RequireAssigner(task *models.Task, actorID uint) error
+}
+
+type taskAssignmentService struct {
+	ruleRepo         repository.TaskAssignmentRepository
+	workspaceRepo    *repository.WorkspaceRepository
+	workspaceService WorkspaceService
+}
+
+// NewTaskAssignmentService creates a new task assignment service
+func NewTaskAssignmentService(
+	ruleRepo repository.TaskAssignmentRepository,
+	workspaceRepo *repository.WorkspaceRepository,
+	workspaceService WorkspaceService,
+) TaskAssignmentService {
+	return &taskAssignmentService{
+		ruleRepo:         ruleRepo,
+		workspaceRepo:    workspaceRepo,
+		workspaceService: workspaceService,
+	}
+}
+
+func (s *taskAssignmentService) requireManager(workspaceID, userID uint) error {
+	canManage, err := s.workspaceService.HasPermission(workspaceID, userID, models.PermSettingsManage)
+	if err != nil {
+		return err
+	}
+	if !canManage {
+		return apperror.Forbidden("access denied: you cannot manage assignment rules in this workspace")
+	}
+	return nil
+}
+
+// ============================================================================
+// RULE MANAGEMENT
+// ============================================================================
+
+func (s *taskAssignmentService) ListRules(workspaceID, userID uint) ([]dto.TaskAssignmentRuleResponse, error) {
+	if err := s.requireManager(workspaceID, userID); err != nil {
+		return nil, err
+	}
+
+	rules, err := s.ruleRepo.FindByWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.TaskAssignmentRuleResponse, 0, len(rules))
+	for i := range rules {
+		result = append(result, toTaskAssignmentRuleResponse(&rules[i]))
+	}
+	return result, nil
+}
+
+func (s *taskAssignmentService) CreateRule(workspaceID, userID uint, req *dto.CreateTaskAssignmentRuleRequest) (*dto.TaskAssignmentRuleResponse, error) {
+	if err := s.requireManager(workspaceID, userID); err != nil {
+		return nil, err
+	}
+
+	rule := &models.TaskAssignmentRule{
+		WorkspaceID:     workspaceID,
+		Name:            strings.TrimSpace(req.Name),
+		Strategy:        req.Strategy,
+		Priority:        req.Priority,
+		WorkspaceRoleID: req.WorkspaceRoleID,
+		TagField:        req.TagField,
+		TagValue:        strings.TrimSpace(req.TagValue),
+		AssigneeID:      req.AssigneeID,
+		IsActive:        true,
+		CreatedBy:       userID,
+	}
+	if err := s.validateRule(rule); err != nil {
+		return nil, err
+	}
+
+	if err := s.ruleRepo.Create(rule); err != nil {
+		return nil, err
+	}
+
+	response := toTaskAssignmentRuleResponse(rule)
+	return &response, nil
+}
+
+func (s *taskAssignmentService) UpdateRule(workspaceID, ruleID, userID uint, req *dto.UpdateTaskAssignmentRuleRequest) (*dto.TaskAssignmentRuleResponse, error) {
+	if err := s.requireManager(workspaceID, userID); err != nil {
+		return nil, err
+	}
+
+	rule, err := s.ruleRepo.FindByID(workspaceID, ruleID)
+	if err != nil {
+		return nil, apperror.NotFound("assignment rule not found")
+	}
+
+	if req.Name != nil {
+		rule.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Strategy != "" && req.Strategy != rule.Strategy {
+		rule.Strategy = req.Strategy
+		rule.LastAssignedUserID = nil
+	}
+	if req.Priority != nil {
+		rule.Priority = *req.Priority
+	}
+	if req.WorkspaceRoleID != nil {
+		rule.WorkspaceRoleID = req.WorkspaceRoleID
+	}
+	if req.TagField != "" {
+		rule.TagField = req.TagField
+	}
+	if req.TagValue != nil {
+		rule.TagValue = strings.TrimSpace(*req.TagValue)
+	}
+	if req.AssigneeID != nil {
+		rule.AssigneeID = req.AssigneeID
+		if *req.AssigneeID == 0 {
+			rule.AssigneeID = nil
+		}
+	}
+	if req.IsActive != nil {
+		rule.IsActive = *req.IsActive
+	}
+	if err := s.validateRule(rule); err != nil {
+		return nil, err
+	}
+
+	if err := s.ruleRepo.Update(rule); err != nil {
+		return nil, err
+	}
+
+	response := toTaskAssignmentRuleResponse(rule)
+	return &response, nil
+}
+
+func (s *taskAssignmentService) DeleteRule(workspaceID, ruleID, userID uint) error {
+	if err := s.requireManager(workspaceID, userID); err != nil {
+		return err
+	}
+
+	rule, err := s.ruleRepo.FindByID(workspaceID, ruleID)
+	if err != nil {
+		return apperror.NotFound("assignment rule not found")
+	}
+	return s.ruleRepo.Delete(rule.ID)
+}
+
+// validateRule checks the fields each strategy needs and clears the rest
+func (s *taskAssignmentService) validateRule(rule *models.TaskAssignmentRule) error {
+	switch rule.Strategy {
+	case models.AssignmentRoundRobin:
+		rule.WorkspaceRoleID = nil
+		rule.TagField, rule.TagValue, rule.AssigneeID = "", "", nil
+
+	case models.AssignmentByRole:
+		if rule.WorkspaceRoleID == nil {
+			return apperror.Validation("workspace_role_id is required for by_role rules", nil)
+		}
+		workspace, err := s.workspaceRepo.GetByID(rule.WorkspaceID)
+		if err != nil {
+			return err
+		}
+		role, err := s.workspaceRepo.GetRoleByID(*rule.WorkspaceRoleID)
+		if err != nil || role.OrganizationID != workspace.OrganizationID {
+			return apperror.NotFound("workspace role not found")
+		}
+		rule.TagField, rule.TagValue, rule.AssigneeID = "", "", nil
+
+	case models.AssignmentByTag:
+		if rule.TagField == "" || rule.TagValue == "" {
+			return apperror.Validation("tag_field and tag_value are required for by_tag rules", nil)
+		}
+		if rule.AssigneeID != nil {
+			isMember, err := s.workspaceRepo.IsMember(rule.WorkspaceID, *rule.AssigneeID)
+			if err != nil {
+				return err
+			}
+			if !isMember {
+				return apperror.Validation("assignee is not an active member of this workspace", nil)
+			}
+		}
+		rule.WorkspaceRoleID = nil
+
+	default:
+		return apperror.Validation("invalid assignment strategy", nil)
+	}
+	return nil
+}
+
+// ============================================================================
+// RULE APPLICATION
+// ============================================================================
+
+func (s *taskAssignmentService) ApplyRules(task *models.Task, actorID uint) error {
+	if task.WorkspaceID == nil {
+		return apperror.Validation("workspace_id is required to auto-assign a task", nil)
+	}
+	workspaceID := *task.WorkspaceID
+
+	if err := s.requireAssigner(workspaceID, actorID); err != nil {
+		return err
+	}
+
+	rules, err := s.ruleRepo.FindActiveByWorkspace(workspaceID)
+	if err != nil {
+		return err
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	members, err := s.workspaceRepo.GetMembersByWorkspaceID(workspaceID)
+	if err != nil {
+		return err
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].UserID < members[j].UserID })
+
+	for i := range rules {
+		rule := &rules[i]
+		if rule.Strategy == models.AssignmentByTag && !taskHasTag(task, rule.TagField, rule.TagValue) {
+			continue
+		}
+
+		assigneeID, ok := pickAssignee(rule, members)
+		if !ok {
+			continue
+		}
+
+		task.UserID = assigneeID
+		if rule.AssigneeID == nil {
+			// Losing the cursor only makes the rotation repeat a member
+			if err := s.ruleRepo.SetLastAssigned(rule.ID, assigneeID); err != nil {
+				log.Printf("⚠️  Failed to advance assignment rule %d: %v", rule.ID, err)
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
+func (s *taskAssignmentService) Assign(task *models.Task, assigneeID, actorID uint) error {
+	if err := s.CheckAssignee(task, assigneeID, actorID); err != nil {
+		return err
+	}
+	task.UserID = assigneeID
+	return nil
+}
+
+func (s *taskAssignmentService) CheckAssignee(task *models.Task, assigneeID, actorID uint) error {
+	if err := s.RequireAssigner(task, actorID); err != nil {
+		return err
+	}
+
+	isMember, err := s.workspaceRepo.IsMember(*task.WorkspaceID, assigneeID)
+	if err != nil {
+		return err
+	}
+	if !isMember {
+		return fmt.Errorf("%w: assignee is not a member of this workspace", ErrInvalidTask)
+	}
+	return nil
+}
+
+func (s *taskAssignmentService) RequireAssigner(task *models.Task, actorID uint) error {
+	if task.WorkspaceID == nil {
+		return fmt.Errorf("%w: workspace_id is required to assign a task", ErrInvalidTask)
+	}
+	return s.requireAssigner(*task.WorkspaceID, actorID)
+}
+
+// requireAssigner allows workspace managers and members who can manage tasks
+func (s *taskAssignmentService) requireAssigner(workspaceID, userID uint) error {
+	canManage, err := s.workspaceService.HasPermission(workspaceID, userID, models.PermTasksManage)
+	if err != nil {
+		return err
+	}
+	if !canManage {
+		return apperror.Forbidden("access denied: you cannot assign tasks in this workspace")
+	}
+	return nil
+}
+
+// pickAssignee returns the rule's fixed assignee when they are still a member,
+// otherwise the next candidate after the rule's round-robin cursor
+func pickAssignee(rule *models.TaskAssignmentRule, members []models.WorkspaceMember) (uint, bool) {
+	var candidates []uint
+	for _, m := range members {
+		if rule.AssigneeID != nil && m.UserID == *rule.AssigneeID {
+			return m.UserID, true
+		}
+		if rule.Strategy == models.AssignmentByRole &&
+			(m.WorkspaceRoleID == nil || *m.WorkspaceRoleID != *rule.WorkspaceRoleID) {
+			continue
+		}
+		candidates = append(candidates, m.UserID)
+	}
+	if len(candidates) == 0 {
+		return 0, false
+	}
+
+	if rule.LastAssignedUserID != nil {
+		for _, id := range candidates {
+			if id > *rule.LastAssignedUserID {
+				return id, true
+			}
+		}
+	}
+	return candidates[0], true
+}
+
+func taskHasTag(task *models.Task, field, value string) bool {
+	tag := task.ProjectCode
+	if field == models.AssignmentTagCostCenter {
+		tag = task.CostCenter
+	}
+	return tag != "" && strings.EqualFold(tag, value)
+}
+
+func toTaskAssignmentRuleResponse(r *models.TaskAssignmentRule) dto.TaskAssignmentRuleResponse {
+	return dto.TaskAssignmentRuleResponse{
+		ID:              r.ID,
+		WorkspaceID:     r.WorkspaceID,
+		Name:            r.Name,
+		Strategy:        r.Strategy,
+		Priority:        r.Priority,
+		WorkspaceRoleID: r.WorkspaceRoleID,
+		TagField:        r.TagField,
+		TagValue:        r.TagValue,
+		AssigneeID:      r.AssigneeID,
+		IsActive:        r.IsActive,
+		CreatedBy:       r.CreatedBy,
+		CreatedAt:       r.CreatedAt,
+		UpdatedAt:       r.UpdatedAt,
+	}
+}
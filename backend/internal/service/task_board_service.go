@@ -0,0 +1,279 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+)
+
+// taskStatusKeyPattern limits column keys to URL- and filter-friendly slugs
+var taskStatusKeyPattern = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
+// TaskBoardService manages workspace Kanban boards: configurable columns
+// mapped onto the base task statuses, and the order of tasks within them
+type TaskBoardService interface {
+	// Columns; any member can view them, changing them takes settings.manage
+	ListStatuses(workspaceID, userID uint) (*dto.TaskStatusListResponse, error)
+	UpdateStatuses(workspaceID, userID uint, req *dto.UpdateTaskStatusesRequest) (*dto.TaskStatusListResponse, error)
+
+	// GetBoard returns the columns with their tasks, for any member
+	GetBoard(workspaceID, userID uint, includeArchived bool) (*dto.TaskBoardResponse, error)
+	// Reorder moves tasks into a column in the given order. Members can move
+	// the tasks they own or are assigned to; tasks.manage moves any task.
+	Reorder(workspaceID, userID uint, req *dto.ReorderTasksRequest) (*dto.TaskBoardResponse, error)
+}
+
+type taskBoardService struct {
+	boardRepo        repository.TaskBoardRepository
+	taskRepo         repository.TaskRepository
+	workspaceService WorkspaceService
+}
+
+// NewTaskBoardService creates a new task board service
+func NewTaskBoardService(boardRepo repository.TaskBoardRepository, taskRepo repository.TaskRepository, workspaceService WorkspaceService) TaskBoardService {
+	return &taskBoardService{
+		boardRepo:        boardRepo,
+		taskRepo:         taskRepo,
+		workspaceService: workspaceService,
+	}
+}
+
+func (s *taskBoardService) requireMember(workspaceID, userID uint) error {
+	isMember, err := s.workspaceService.IsMember(workspaceID, userID)
+	if err != nil {
+		return err
+	}
+	if !isMember {
+		return apperror.Forbidden("access denied: you are not a member of this workspace")
+	}
+	return nil
+}
+
+// ============================================================================
+// COLUMNS
+// ============================================================================
+
+func (s *taskBoardService) ListStatuses(workspaceID, userID uint) (*dto.TaskStatusListResponse, error) {
+	if err := s.requireMember(workspaceID, userID); err != nil {
+		return nil, err
+	}
+
+	statuses, isDefault, err := s.columns(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	return toTaskStatusListResponse(statuses, isDefault), nil
+}
+
+func (s *taskBoardService) UpdateStatuses(workspaceID, userID uint, req *dto.UpdateTaskStatusesRequest) (*dto.TaskStatusListResponse, error) {
+	canManage, err := s.workspaceService.HasPermission(workspaceID, userID, models.PermSettingsManage)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, apperror.Forbidden("access denied: you cannot manage the task board of this workspace")
+	}
+
+	statuses := make([]models.WorkspaceTaskStatus, 0, len(req.Statuses))
+	keys := make(map[string]bool, len(req.Statuses))
+	categories := make(map[string]bool, len(models.TaskStatuses))
+	for i, column := range req.Statuses {
+		key := strings.ToLower(strings.TrimSpace(column.Key))
+		if !taskStatusKeyPattern.MatchString(key) {
+			return nil, fmt.Errorf("%w: status key %q may only contain lowercase letters, digits, \"-\" and \"_\"", ErrInvalidTask, column.Key)
+		}
+		if keys[key] {
+			return nil, fmt.Errorf("%w: duplicate status key %q", ErrInvalidTask, key)
+		}
+		keys[key] = true
+		categories[column.Category] = true
+
+		statuses = append(statuses, models.WorkspaceTaskStatus{
+			WorkspaceID: workspaceID,
+			Key:         key,
+			Name:        strings.TrimSpace(column.Name),
+			Color:       column.Color,
+			Category:    column.Category,
+			Position:    i,
+		})
+	}
+
+	// Every task must land in some column whatever its status
+	for _, status := range models.TaskStatuses {
+		if !categories[status] {
+			return nil, fmt.Errorf("%w: the board needs at least one %s column", ErrInvalidTask, status)
+		}
+	}
+
+	if err := s.boardRepo.ReplaceStatuses(workspaceID, statuses); err != nil {
+		return nil, err
+	}
+	return toTaskStatusListResponse(statuses, false), nil
+}
+
+// columns returns the workspace's configured columns, or the defaults
+func (s *taskBoardService) columns(workspaceID uint) ([]models.WorkspaceTaskStatus, bool, error) {
+	statuses, err := s.boardRepo.FindStatuses(workspaceID)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(statuses) == 0 {
+		return models.DefaultTaskStatuses(workspaceID), true, nil
+	}
+	return statuses, false, nil
+}
+
+// ============================================================================
+// BOARD
+// ============================================================================
+
+func (s *taskBoardService) GetBoard(workspaceID, userID uint, includeArchived bool) (*dto.TaskBoardResponse, error) {
+	if err := s.requireMember(workspaceID, userID); err != nil {
+		return nil, err
+	}
+	return s.board(workspaceID, includeArchived)
+}
+
+func (s *taskBoardService) Reorder(workspaceID, userID uint, req *dto.ReorderTasksRequest) (*dto.TaskBoardResponse, error) {
+	if err := s.requireMember(workspaceID, userID); err != nil {
+		return nil, err
+	}
+
+	statuses, _, err := s.columns(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	var column *models.WorkspaceTaskStatus
+	for i := range statuses {
+		if statuses[i].Key == req.Status {
+			column = &statuses[i]
+			break
+		}
+	}
+	if column == nil {
+		return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidTask, req.Status)
+	}
+
+	seen := make(map[uint]bool, len(req.TaskIDs))
+	for _, id := range req.TaskIDs {
+		if seen[id] {
+			return nil, fmt.Errorf("%w: task %d is listed twice", ErrInvalidTask, id)
+		}
+		seen[id] = true
+	}
+	tasks, err := s.boardRepo.FindTasksByIDs(workspaceID, req.TaskIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(tasks) != len(req.TaskIDs) {
+		return nil, fmt.Errorf("%w: some tasks do not belong to this workspace", ErrInvalidTask)
+	}
+
+	canManage, err := s.workspaceService.HasPermission(workspaceID, userID, models.PermTasksManage)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		for i := range tasks {
+			if err := s.requireOwnTask(&tasks[i], userID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := s.boardRepo.MoveTasks(req.TaskIDs, column.Key, column.Category); err != nil {
+		return nil, err
+	}
+	return s.board(workspaceID, column.Category == models.TaskStatusArchived)
+}
+
+// requireOwnTask allows moving tasks the user owns or is assigned to
+func (s *taskBoardService) requireOwnTask(task *models.Task, userID uint) error {
+	if task.UserID == userID {
+		return nil
+	}
+	assigned, err := s.taskRepo.IsAssignee(task.ID, userID)
+	if err != nil {
+		return err
+	}
+	if !assigned {
+		return apperror.Forbidden(fmt.Sprintf("access denied: you cannot move task %d", task.ID))
+	}
+	return nil
+}
+
+// board places each task in its column; tasks without a valid column go to
+// the first column of their status
+func (s *taskBoardService) board(workspaceID uint, includeArchived bool) (*dto.TaskBoardResponse, error) {
+	statuses, _, err := s.columns(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := s.boardRepo.FindBoardTasks(workspaceID, includeArchived)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]dto.TaskBoardColumn, len(statuses))
+	byKey := make(map[string]int, len(statuses))
+	byCategory := make(map[string]int, len(models.TaskStatuses))
+	for i := range statuses {
+		columns[i] = dto.TaskBoardColumn{
+			TaskStatusResponse: toTaskStatusResponse(&statuses[i]),
+			Tasks:              []dto.TaskBoardCard{},
+		}
+		byKey[statuses[i].Key] = i
+		if _, ok := byCategory[statuses[i].Category]; !ok {
+			byCategory[statuses[i].Category] = i
+		}
+	}
+
+	for i := range tasks {
+		task := &tasks[i]
+		index, ok := byKey[task.BoardStatus]
+		if !ok || statuses[index].Category != task.Status {
+			index = byCategory[task.Status] // Unknown statuses land in the first column
+		}
+		columns[index].Tasks = append(columns[index].Tasks, dto.TaskBoardCard{
+			ID:        task.ID,
+			Title:     task.Title,
+			Status:    task.Status,
+			Position:  task.BoardPosition,
+			Priority:  task.Priority,
+			Color:     task.Color,
+			UserID:    task.UserID,
+			UserName:  emailUserName(&task.User),
+			DueDate:   task.DueDate,
+			Tags:      taskTagList(task.Tags),
+			CreatedAt: task.CreatedAt,
+		})
+	}
+
+	return &dto.TaskBoardResponse{WorkspaceID: workspaceID, Columns: columns}, nil
+}
+
+func toTaskStatusResponse(status *models.WorkspaceTaskStatus) dto.TaskStatusResponse {
+	return dto.TaskStatusResponse{
+		Key:      status.Key,
+		Name:     status.Name,
+		Color:    status.Color,
+		Category: status.Category,
+		Position: status.Position,
+	}
+}
+
+func toTaskStatusListResponse(statuses []models.WorkspaceTaskStatus, isDefault bool) *dto.TaskStatusListResponse {
+	response := &dto.TaskStatusListResponse{
+		Statuses:  make([]dto.TaskStatusResponse, 0, len(statuses)),
+		IsDefault: isDefault,
+	}
+	for i := range statuses {
+		response.Statuses = append(response.Statuses, toTaskStatusResponse(&statuses[i]))
+	}
+	return response
+}
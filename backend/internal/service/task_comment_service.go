@@ -0,0 +1,548 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"mime/multipart"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/config"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+)
+
+// maxTaskCommentMentions bounds the users one comment can notify
+const maxTaskCommentMentions = 20
+
+// TaskCommentService handles task discussions. The task's owner, its
+// assignees and the members of its workspace can comment and attach files.
+type TaskCommentService interface {
+	ListComments(taskID, userID uint) ([]dto.TaskCommentResponse, error)
+	CreateComment(taskID, userID uint, req *dto.CreateTaskCommentRequest) (*dto.TaskCommentResponse, error)
+	// UpdateComment edits one of the user's own comments
+	UpdateComment(taskID, commentID, userID uint, req *dto.UpdateTaskCommentRequest) (*dto.TaskCommentResponse, error)
+	// DeleteComment deletes a comment with its attachments. Authors delete
+	// their own comments; the task owner and tasks.manage delete any.
+	DeleteComment(taskID, commentID, userID uint) error
+
+	ListAttachments(taskID, userID uint) ([]dto.TaskAttachmentResponse, error)
+	UploadAttachment(taskID, userID uint, req *dto.TaskAttachmentUploadRequest, file *multipart.FileHeader) (*dto.TaskAttachmentResponse, error)
+	GetAttachmentFile(taskID, attachmentID, userID uint) (*models.TaskAttachment, error)
+	// DeleteAttachment deletes an attachment and its file. Uploaders delete
+	// their own files; the task owner and tasks.manage delete any.
+	DeleteAttachment(taskID, attachmentID, userID uint) error
+	// MovePublicAttachments moves attachment files stored under the public
+	// uploads directory by earlier versions to the private one
+	MovePublicAttachments() error
+}
+
+type taskCommentService struct {
+	commentRepo         repository.TaskCommentRepository
+	taskRepo            repository.TaskRepository
+	userRepo            repository.UserRepository
+	workspaceService    WorkspaceService
+	notificationService NotificationService
+	maxSize             int64
+	allowedTypes        map[string]bool // Lowercase extensions, with the dot
+}
+
+// NewTaskCommentService creates a new task comment service
+func NewTaskCommentService(commentRepo repository.TaskCommentRepository, taskRepo repository.TaskRepository, userRepo repository.UserRepository, workspaceService WorkspaceService, notificationService NotificationService) TaskCommentService {
+	return &taskCommentService{
+		commentRepo:         commentRepo,
+		taskRepo:            taskRepo,
+		userRepo:            userRepo,
+		workspaceService:    workspaceService,
+		notificationService: notificationService,
+		maxSize:             config.AppConfig.Upload.MaxSize,
+		allowedTypes:        attachmentTypeSet(config.AppConfig.Upload.AttachmentTypes),
+	}
+}
+
+// attachmentTypeSet normalizes the configured attachment extensions
+func attachmentTypeSet(types []string) map[string]bool {
+	set := make(map[string]bool, len(types))
+	for _, t := range types {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" {
+			continue
+		}
+		if !strings.HasPrefix(t, ".") {
+			t = "." + t
+		}
+		set[t] = true
+	}
+	return set
+}
+
+// ============================================================================
+// COMMENTS
+// ============================================================================
+
+func (s *taskCommentService) ListComments(taskID, userID uint) ([]dto.TaskCommentResponse, error) {
+	task, err := s.findTask(taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.commentRepo.FindByTaskID(task.ID)
+	if err != nil {
+		return nil, err
+	}
+	attachments, err := s.commentRepo.FindAttachments(task.ID)
+	if err != nil {
+		return nil, err
+	}
+	byComment := make(map[uint][]dto.TaskAttachmentResponse)
+	for i := range attachments {
+		if commentID := attachments[i].CommentID; commentID != nil {
+			byComment[*commentID] = append(byComment[*commentID], toTaskAttachmentResponse(&attachments[i]))
+		}
+	}
+
+	responses := make([]dto.TaskCommentResponse, 0, len(rows))
+	for i := range rows {
+		response := toTaskCommentResponse(&rows[i].TaskComment, &models.User{
+			Email:     rows[i].Email,
+			FirstName: rows[i].FirstName,
+			LastName:  rows[i].LastName,
+		})
+		if files, ok := byComment[rows[i].ID]; ok {
+			response.Attachments = files
+		}
+		responses = append(responses, response)
+	}
+	return responses, nil
+}
+
+func (s *taskCommentService) CreateComment(taskID, userID uint, req *dto.CreateTaskCommentRequest) (*dto.TaskCommentResponse, error) {
+	task, err := s.findTask(taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return nil, fmt.Errorf("%w: comment cannot be empty", ErrInvalidTask)
+	}
+	mentions, err := s.checkMentions(task, req.MentionIDs, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	comment := &models.TaskComment{
+		TaskID:   task.ID,
+		AuthorID: userID,
+		Body:     body,
+		Mentions: joinIDList(mentions),
+	}
+	if err := s.commentRepo.Create(comment); err != nil {
+		return nil, err
+	}
+
+	author, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	s.notificationService.NotifyTaskMentioned(task, comment, mentions, author)
+
+	response := toTaskCommentResponse(comment, author)
+	return &response, nil
+}
+
+func (s *taskCommentService) UpdateComment(taskID, commentID, userID uint, req *dto.UpdateTaskCommentRequest) (*dto.TaskCommentResponse, error) {
+	task, err := s.findTask(taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+	comment, err := s.findComment(task, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.AuthorID != userID {
+		return nil, apperror.Forbidden("access denied: you can only edit your own comments")
+	}
+
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return nil, fmt.Errorf("%w: comment cannot be empty", ErrInvalidTask)
+	}
+	mentions, err := s.checkMentions(task, req.MentionIDs, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only users mentioned for the first time are notified
+	previous := make(map[uint]bool)
+	for _, id := range parseIDList(comment.Mentions) {
+		previous[id] = true
+	}
+	var added []uint
+	for _, id := range mentions {
+		if !previous[id] {
+			added = append(added, id)
+		}
+	}
+
+	comment.Body = body
+	comment.Mentions = joinIDList(mentions)
+	if err := s.commentRepo.Update(comment); err != nil {
+		return nil, err
+	}
+
+	author, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	s.notificationService.NotifyTaskMentioned(task, comment, added, author)
+
+	response := toTaskCommentResponse(comment, author)
+	attachments, err := s.commentRepo.FindAttachmentsByCommentID(comment.ID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range attachments {
+		response.Attachments = append(response.Attachments, toTaskAttachmentResponse(&attachments[i]))
+	}
+	return &response, nil
+}
+
+func (s *taskCommentService) DeleteComment(taskID, commentID, userID uint) error {
+	task, err := s.findTask(taskID, userID)
+	if err != nil {
+		return err
+	}
+	comment, err := s.findComment(task, commentID)
+	if err != nil {
+		return err
+	}
+	if comment.AuthorID != userID {
+		if err := s.requireModerator(task, userID, "access denied: you can only delete your own comments"); err != nil {
+			return err
+		}
+	}
+
+	attachments, err := s.commentRepo.FindAttachmentsByCommentID(comment.ID)
+	if err != nil {
+		return err
+	}
+	if err := s.commentRepo.Delete(comment.ID); err != nil {
+		return err
+	}
+	for i := range attachments {
+		deleteTaskAttachmentFile(&attachments[i])
+	}
+	return nil
+}
+
+// checkMentions deduplicates the mentioned users and makes sure each can see
+// the task; the author is never notified of their own comment
+func (s *taskCommentService) checkMentions(task *models.Task, ids []uint, authorID uint) ([]uint, error) {
+	mentions := make([]uint, 0, len(ids))
+	seen := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		if id == authorID || seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		allowed, err := s.canDiscuss(task, id)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			return nil, fmt.Errorf("%w: user %d cannot see this task", ErrInvalidTask, id)
+		}
+		mentions = append(mentions, id)
+	}
+	if len(mentions) > maxTaskCommentMentions {
+		return nil, fmt.Errorf("%w: a comment can mention at most %d users", ErrInvalidTask, maxTaskCommentMentions)
+	}
+	return mentions, nil
+}
+
+func (s *taskCommentService) findComment(task *models.Task, commentID uint) (*models.TaskComment, error) {
+	comment, err := s.commentRepo.FindByID(commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.TaskID != task.ID {
+		return nil, apperror.NotFound("comment not found")
+	}
+	return comment, nil
+}
+
+// ============================================================================
+// ATTACHMENTS
+// ============================================================================
+
+func (s *taskCommentService) ListAttachments(taskID, userID uint) ([]dto.TaskAttachmentResponse, error) {
+	task, err := s.findTask(taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.commentRepo.FindAttachments(task.ID)
+	if err != nil {
+		return nil, err
+	}
+	responses := make([]dto.TaskAttachmentResponse, 0, len(attachments))
+	for i := range attachments {
+		responses = append(responses, toTaskAttachmentResponse(&attachments[i]))
+	}
+	return responses, nil
+}
+
+func (s *taskCommentService) UploadAttachment(taskID, userID uint, req *dto.TaskAttachmentUploadRequest, file *multipart.FileHeader) (*dto.TaskAttachmentResponse, error) {
+	task, err := s.findTask(taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if file.Size > s.maxSize {
+		return nil, fmt.Errorf("%w: attachment exceeds the maximum size of %d bytes", ErrInvalidTask, s.maxSize)
+	}
+	if req.CommentID != nil {
+		comment, err := s.findComment(task, *req.CommentID)
+		if err != nil {
+			return nil, err
+		}
+		if comment.AuthorID != userID {
+			return nil, apperror.Forbidden("access denied: you can only attach files to your own comments")
+		}
+	}
+
+	ext := strings.ToLower(filepath.Ext(file.Filename))
+	if !s.allowedTypes[ext] {
+		return nil, fmt.Errorf("%w: %q files cannot be attached", ErrInvalidTask, ext)
+	}
+
+	filePath, fileName, err := utils.SavePrivateUploadedFile(file, filepath.Join("task-attachments", fmt.Sprintf("%d", task.ID)))
+	if err != nil {
+		return nil, err
+	}
+
+	attachment := &models.TaskAttachment{
+		TaskID:       task.ID,
+		CommentID:    req.CommentID,
+		UploadedBy:   userID,
+		FilePath:     filePath,
+		FileName:     fileName,
+		OriginalName: filepath.Base(file.Filename),
+		ContentType:  s.contentType(fileName),
+		FileSize:     file.Size,
+	}
+	if err := s.commentRepo.CreateAttachment(attachment); err != nil {
+		_ = utils.DeleteFile(filePath)
+		return nil, errors.New("failed to save attachment")
+	}
+
+	response := toTaskAttachmentResponse(attachment)
+	return &response, nil
+}
+
+func (s *taskCommentService) GetAttachmentFile(taskID, attachmentID, userID uint) (*models.TaskAttachment, error) {
+	task, err := s.findTask(taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+	attachment, err := s.findAttachment(task, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+	// Files from before the type allow-list may have any type; those are
+	// served as plain downloads
+	attachment.ContentType = s.contentType(attachment.FileName)
+	return attachment, nil
+}
+
+// contentType derives an attachment's type from its extension, never from
+// the uploader
+func (s *taskCommentService) contentType(fileName string) string {
+	ext := strings.ToLower(filepath.Ext(fileName))
+	if s.allowedTypes[ext] {
+		if contentType := mime.TypeByExtension(ext); contentType != "" {
+			return contentType
+		}
+	}
+	return "application/octet-stream"
+}
+
+func (s *taskCommentService) MovePublicAttachments() error {
+	publicDir := filepath.Clean(config.AppConfig.Upload.Path) + string(filepath.Separator)
+	attachments, err := s.commentRepo.FindAttachmentsUnder(publicDir)
+	if err != nil {
+		return err
+	}
+
+	moved := 0
+	for i := range attachments {
+		attachment := &attachments[i]
+		filePath := filepath.Join(config.AppConfig.Upload.PrivatePath, "task-attachments", fmt.Sprintf("%d", attachment.TaskID), attachment.FileName)
+		if err := utils.MoveFile(attachment.FilePath, filePath); err != nil {
+			log.Printf("⚠️  Failed to move task attachment file %d: %v", attachment.ID, err)
+			continue
+		}
+		if err := s.commentRepo.UpdateAttachmentPath(attachment.ID, filePath); err != nil {
+			return err
+		}
+		moved++
+	}
+
+	if moved > 0 {
+		log.Printf("✅ Moved %d task attachments out of the public uploads directory", moved)
+	}
+	return nil
+}
+
+func (s *taskCommentService) DeleteAttachment(taskID, attachmentID, userID uint) error {
+	task, err := s.findTask(taskID, userID)
+	if err != nil {
+		return err
+	}
+	attachment, err := s.findAttachment(task, attachmentID)
+	if err != nil {
+		return err
+	}
+	if attachment.UploadedBy != userID {
+		if err := s.requireModerator(task, userID, "access denied: you can only delete your own attachments"); err != nil {
+			return err
+		}
+	}
+
+	if err := s.commentRepo.DeleteAttachment(attachment.ID); err != nil {
+		return err
+	}
+	deleteTaskAttachmentFile(attachment)
+	return nil
+}
+
+func (s *taskCommentService) findAttachment(task *models.Task, attachmentID uint) (*models.TaskAttachment, error) {
+	attachment, err := s.commentRepo.FindAttachmentByID(attachmentID)
+	if err != nil {
+		return nil, err
+	}
+	if attachment.TaskID != task.ID {
+		return nil, apperror.NotFound("attachment not found")
+	}
+	return attachment, nil
+}
+
+func deleteTaskAttachmentFile(attachment *models.TaskAttachment) {
+	if err := utils.DeleteFile(attachment.FilePath); err != nil {
+		log.Printf("⚠️  Failed to delete task attachment file %d: %v", attachment.ID, err)
+	}
+}
+
+// ============================================================================
+// ACCESS
+// ============================================================================
+
+// findTask loads a task the user can discuss
+func (s *taskCommentService) findTask(taskID, userID uint) (*models.Task, error) {
+	task, err := s.taskRepo.FindByID(taskID)
+	if err != nil {
+		return nil, err
+	}
+	allowed, err := s.canDiscuss(task, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, apperror.NotFound("unauthorized access to task")
+	}
+	return task, nil
+}
+
+// canDiscuss reports whether the user owns the task, is assigned to it or is
+// a member of its workspace
+func (s *taskCommentService) canDiscuss(task *models.Task, userID uint) (bool, error) {
+	if task.UserID == userID {
+		return true, nil
+	}
+	assigned, err := s.taskRepo.IsAssignee(task.ID, userID)
+	if err != nil || assigned {
+		return assigned, err
+	}
+	if task.WorkspaceID == nil {
+		return false, nil
+	}
+	return s.workspaceService.IsMember(*task.WorkspaceID, userID)
+}
+
+// requireModerator allows the task owner and members who can manage the
+// workspace's tasks to remove other people's comments and files
+func (s *taskCommentService) requireModerator(task *models.Task, userID uint, message string) error {
+	if task.UserID == userID {
+		return nil
+	}
+	if task.WorkspaceID != nil {
+		canManage, err := s.workspaceService.HasPermission(*task.WorkspaceID, userID, models.PermTasksManage)
+		if err != nil {
+			return err
+		}
+		if canManage {
+			return nil
+		}
+	}
+	return apperror.Forbidden(message)
+}
+
+func toTaskCommentResponse(comment *models.TaskComment, author *models.User) dto.TaskCommentResponse {
+	return dto.TaskCommentResponse{
+		ID:          comment.ID,
+		TaskID:      comment.TaskID,
+		AuthorID:    comment.AuthorID,
+		AuthorName:  emailUserName(author),
+		AuthorEmail: author.Email,
+		Body:        comment.Body,
+		MentionIDs:  parseIDList(comment.Mentions),
+		Attachments: []dto.TaskAttachmentResponse{},
+		Edited:      comment.UpdatedAt.After(comment.CreatedAt),
+		CreatedAt:   comment.CreatedAt,
+		UpdatedAt:   comment.UpdatedAt,
+	}
+}
+
+func toTaskAttachmentResponse(attachment *models.TaskAttachment) dto.TaskAttachmentResponse {
+	fileName := attachment.OriginalName
+	if fileName == "" {
+		fileName = attachment.FileName
+	}
+	return dto.TaskAttachmentResponse{
+		ID:          attachment.ID,
+		TaskID:      attachment.TaskID,
+		CommentID:   attachment.CommentID,
+		UploadedBy:  attachment.UploadedBy,
+		FileName:    fileName,
+		ContentType: attachment.ContentType,
+		FileSize:    attachment.FileSize,
+		CreatedAt:   attachment.CreatedAt,
+	}
+}
+
+// joinIDList stores IDs as a comma-separated list
+func joinIDList(ids []uint) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.FormatUint(uint64(id), 10)
+	}
+	return strings.Join(parts, ",")
+}
+
+// parseIDList reads a comma-separated list of IDs, skipping invalid entries
+func parseIDList(list string) []uint {
+	ids := []uint{}
+	for _, part := range strings.Split(list, ",") {
+		if id, err := strconv.ParseUint(strings.TrimSpace(part), 10, 32); err == nil {
+			ids = append(ids, uint(id))
+		}
+	}
+	return ids
+}
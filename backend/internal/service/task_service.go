@@ -2,41 +2,91 @@ package service
 
 import (
 	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
 	"time"
 
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
 	"github.com/beuphecan/remote-time-tracker/internal/dto"
 	"github.com/beuphecan/remote-time-tracker/internal/models"
 	"github.com/beuphecan/remote-time-tracker/internal/repository"
 	"github.com/google/uuid"
 )
 
+// Task errors
+var (
+	// ErrInvalidTask is wrapped by task validation errors
+	ErrInvalidTask          = apperror.Validation("invalid task", nil)
+	ErrTaskAssigneeNotFound = apperror.NotFound("user is not assigned to this task")
+)
+
 // TaskService handles task business logic
 type TaskService interface {
 	Create(userID uint, req *dto.CreateTaskRequest) (*models.Task, error)
 	GetByID(id, userID uint) (*models.Task, error)
-	GetByUserID(userID uint, page, perPage int) ([]dto.TaskWithStats, int64, error)
+	GetByUserID(userID uint, filter *dto.TaskFilter, page, perPage int) ([]dto.TaskWithStats, int64, error)
 	Update(id, userID uint, req *dto.UpdateTaskRequest) (*models.Task, error)
 	Delete(id, userID uint) error
 	GetActiveTasks(userID uint) ([]dto.TaskWithStats, error)
+	// GetTree returns the task with its subtasks and rolled-up tracked time
+	GetTree(id, userID uint) (*dto.TaskTreeNode, error)
+
+	// Additional assignees of workspace tasks. Owners and assignees can list
+	// them; adding and removing takes permission to assign tasks, except
+	// that assignees can remove themselves.
+	ListAssignees(taskID, userID uint) ([]dto.TaskAssigneeResponse, error)
+	AddAssignee(taskID, userID uint, req *dto.AddTaskAssigneeRequest) ([]dto.TaskAssigneeResponse, error)
+	RemoveAssignee(taskID, assigneeID, userID uint) error
 }
 
 type taskService struct {
-	taskRepo repository.TaskRepository
+	taskRepo            repository.TaskRepository
+	commitRepo          repository.CommitLinkRepository
+	userRepo            repository.UserRepository
+	workspaceService    WorkspaceService
+	assignmentService   TaskAssignmentService
+	notificationService NotificationService
 }
 
 // NewTaskService creates a new task service
-func NewTaskService(taskRepo repository.TaskRepository) TaskService {
+func NewTaskService(
+	taskRepo repository.TaskRepository,
+	commitRepo repository.CommitLinkRepository,
+	userRepo repository.UserRepository,
+	workspaceService WorkspaceService,
+	assignmentService TaskAssignmentService,
+	notificationService NotificationService,
+) TaskService {
 	return &taskService{
-		taskRepo: taskRepo,
+		taskRepo:            taskRepo,
+		commitRepo:          commitRepo,
+		userRepo:            userRepo,
+		workspaceService:    workspaceService,
+		assignmentService:   assignmentService,
+		notificationService: notificationService,
 	}
 }
 
 func (s *taskService) Create(userID uint, req *dto.CreateTaskRequest) (*models.Task, error) {
+	// Personal tasks need no permission; workspace tasks take tasks.create
+	if req.WorkspaceID != nil {
+		canCreate, err := s.workspaceService.HasPermission(*req.WorkspaceID, userID, models.PermTasksCreate)
+		if err != nil {
+			return nil, err
+		}
+		if !canCreate {
+			return nil, apperror.Forbidden("access denied: you cannot create tasks in this workspace")
+		}
+	}
+
 	// Generate LocalID (UUID) if not provided
 	localID := uuid.New().String()
 
 	task := &models.Task{
 		UserID:         userID,
+		CreatedBy:      &userID,
 		OrganizationID: req.OrganizationID, // Set organization context
 		WorkspaceID:    req.WorkspaceID,    // Set workspace context
 		LocalID:        localID,            // Auto-generate UUID for LocalID
@@ -46,31 +96,63 @@ func (s *taskService) Create(userID uint, req *dto.CreateTaskRequest) (*models.T
 		Color:          req.Color,
 		Status:         "active",
 		IsManual:       req.IsManual, // Set from request
+		CostCenter:     strings.TrimSpace(req.CostCenter),
+		ProjectCode:    strings.TrimSpace(req.ProjectCode),
+	}
+
+	if err := applyTaskPlanning(task, &req.DueDate, &req.EstimateSeconds, &req.Tags); err != nil {
+		return nil, err
+	}
+	if req.ParentTaskID != nil {
+		if err := s.setParent(task, *req.ParentTaskID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case req.AutoAssign && req.AssigneeID != nil:
+		return nil, fmt.Errorf("%w: assignee_id cannot be combined with auto_assign", ErrInvalidTask)
+	case req.AutoAssign:
+		if err := s.assignmentService.ApplyRules(task, userID); err != nil {
+			return nil, err
+		}
+	case req.AssigneeID != nil && *req.AssigneeID != userID:
+		if err := s.assignmentService.Assign(task, *req.AssigneeID, userID); err != nil {
+			return nil, err
+		}
 	}
 
 	if err := s.taskRepo.Create(task); err != nil {
 		return nil, errors.New("failed to create task")
 	}
+	s.notifyAssigned(task, task.UserID, userID)
 
 	return task, nil
 }
 
 func (s *taskService) GetByID(id, userID uint) (*models.Task, error) {
-	task, err := s.taskRepo.FindByID(id)
+	task, err := s.findVisible(id, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Check if task belongs to user
-	if task.UserID != userID {
-		return nil, errors.New("unauthorized access to task")
+	commits, err := s.commitRepo.FindByTask(task.ID)
+	if err != nil {
+		return nil, err
+	}
+	task.Commits = commits
+
+	estimate, err := rolledUpEstimate(s.taskRepo, task)
+	if err != nil {
+		return nil, err
 	}
+	task.Estimate = estimate
 
 	return task, nil
 }
 
-func (s *taskService) GetByUserID(userID uint, page, perPage int) ([]dto.TaskWithStats, int64, error) {
-	results, total, err := s.taskRepo.FindByUserIDWithStats(userID, page, perPage)
+func (s *taskService) GetByUserID(userID uint, filter *dto.TaskFilter, page, perPage int) ([]dto.TaskWithStats, int64, error) {
+	results, total, err := s.taskRepo.FindByUserIDWithStats(userID, filter, page, perPage)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -96,7 +178,11 @@ func (s *taskService) Update(id, userID uint, req *dto.UpdateTaskRequest) (*mode
 
 	// Check if task belongs to user
 	if task.UserID != userID {
-		return nil, errors.New("unauthorized access to task")
+		return nil, apperror.NotFound("unauthorized access to task")
+	}
+	state := func() (interface{}, error) { return s.taskRepo.FindByID(id) }
+	if err := checkVersion(req.Version, task.Version, state); err != nil {
+		return nil, err
 	}
 
 	// Update fields if provided
@@ -118,10 +204,38 @@ func (s *taskService) Update(id, userID uint, req *dto.UpdateTaskRequest) (*mode
 	if req.IsManual != nil {
 		task.IsManual = *req.IsManual
 	}
+	if req.CostCenter != nil {
+		task.CostCenter = strings.TrimSpace(*req.CostCenter)
+	}
+	if req.ProjectCode != nil {
+		task.ProjectCode = strings.TrimSpace(*req.ProjectCode)
+	}
+	if err := applyTaskPlanning(task, req.DueDate, req.EstimateSeconds, req.Tags); err != nil {
+		return nil, err
+	}
+	if req.ParentTaskID != nil {
+		if *req.ParentTaskID == 0 {
+			task.ParentTaskID = nil
+		} else if err := s.setParent(task, *req.ParentTaskID, userID); err != nil {
+			return nil, err
+		}
+	}
+	reassigned := req.AssigneeID != nil && *req.AssigneeID != task.UserID
+	if reassigned {
+		if err := s.assignmentService.Assign(task, *req.AssigneeID, userID); err != nil {
+			return nil, err
+		}
+	}
 
 	if err := s.taskRepo.Update(task); err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			return nil, versionedUpdateError(err, state)
+		}
 		return nil, errors.New("failed to update task")
 	}
+	if reassigned {
+		s.notifyAssigned(task, task.UserID, userID)
+	}
 
 	return task, nil
 }
@@ -134,10 +248,13 @@ func (s *taskService) Delete(id, userID uint) error {
 
 	// Check if task belongs to user
 	if task.UserID != userID {
-		return errors.New("unauthorized access to task")
+		return apperror.NotFound("unauthorized access to task")
 	}
 
-	return s.taskRepo.Delete(id)
+	if err := s.taskRepo.Delete(id); err != nil {
+		return err
+	}
+	return s.taskRepo.DetachSubtasks(id)
 }
 
 func (s *taskService) GetActiveTasks(userID uint) ([]dto.TaskWithStats, error) {
@@ -159,6 +276,262 @@ func (s *taskService) GetActiveTasks(userID uint) ([]dto.TaskWithStats, error) {
 	return tasksWithStats, nil
 }
 
+func (s *taskService) ListAssignees(taskID, userID uint) ([]dto.TaskAssigneeResponse, error) {
+	task, err := s.findVisible(taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.assigneeResponses(task)
+}
+
+func (s *taskService) AddAssignee(taskID, userID uint, req *dto.AddTaskAssigneeRequest) ([]dto.TaskAssigneeResponse, error) {
+	task, err := s.findVisible(taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.assignmentService.CheckAssignee(task, req.UserID, userID); err != nil {
+		return nil, err
+	}
+
+	if req.UserID == task.UserID {
+		return nil, fmt.Errorf("%w: the user already owns this task", ErrInvalidTask)
+	}
+	assigned, err := s.taskRepo.IsAssignee(task.ID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if assigned {
+		return nil, fmt.Errorf("%w: the user is already assigned to this task", ErrInvalidTask)
+	}
+
+	if err := s.taskRepo.AddAssignee(&models.TaskAssignee{
+		TaskID:     task.ID,
+		UserID:     req.UserID,
+		AssignedBy: userID,
+	}); err != nil {
+		return nil, err
+	}
+	s.notifyAssigned(task, req.UserID, userID)
+
+	return s.assigneeResponses(task)
+}
+
+func (s *taskService) RemoveAssignee(taskID, assigneeID, userID uint) error {
+	task, err := s.findVisible(taskID, userID)
+	if err != nil {
+		return err
+	}
+	if assigneeID != userID {
+		if err := s.assignmentService.RequireAssigner(task, userID); err != nil {
+			return err
+		}
+	}
+
+	removed, err := s.taskRepo.RemoveAssignee(task.ID, assigneeID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrTaskAssigneeNotFound
+	}
+	return nil
+}
+
+func (s *taskService) GetTree(id, userID uint) (*dto.TaskTreeNode, error) {
+	root, err := s.findVisible(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.taskRepo.FindSubtree(root.ID)
+	if err != nil {
+		return nil, err
+	}
+	durations, err := s.taskRepo.TrackedDurations(tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	children := make(map[uint][]*models.Task, len(tasks))
+	for i := range tasks {
+		if parentID := tasks[i].ParentTaskID; parentID != nil {
+			children[*parentID] = append(children[*parentID], &tasks[i])
+		}
+	}
+
+	var build func(task *models.Task) dto.TaskTreeNode
+	build = func(task *models.Task) dto.TaskTreeNode {
+		node := dto.TaskTreeNode{
+			ID:              task.ID,
+			Title:           task.Title,
+			Status:          task.Status,
+			UserID:          task.UserID,
+			ParentTaskID:    task.ParentTaskID,
+			EstimateSeconds: task.EstimateSeconds,
+			TrackedSeconds:  durations[task.ID],
+			TotalSeconds:    durations[task.ID],
+			Subtasks:        []dto.TaskTreeNode{},
+		}
+		for _, child := range children[task.ID] {
+			subtask := build(child)
+			node.TotalSeconds += subtask.TotalSeconds
+			node.Subtasks = append(node.Subtasks, subtask)
+		}
+		return node
+	}
+
+	tree := build(root)
+	return &tree, nil
+}
+
+// maxTaskDepth bounds task nesting: top-level tasks, their subtasks and
+// the subtasks of those
+const maxTaskDepth = 3
+
+// setParent makes the task a subtask of parentID. The parent must be visible
+// to the user and in the task's workspace, and the move may neither loop nor
+// nest deeper than maxTaskDepth.
+func (s *taskService) setParent(task *models.Task, parentID, userID uint) error {
+	if parentID == task.ID {
+		return fmt.Errorf("%w: a task cannot be its own subtask", ErrInvalidTask)
+	}
+	parent, err := s.findVisible(parentID, userID)
+	if err != nil {
+		return fmt.Errorf("%w: parent task not found", ErrInvalidTask)
+	}
+	if (parent.WorkspaceID == nil) != (task.WorkspaceID == nil) ||
+		(parent.WorkspaceID != nil && *parent.WorkspaceID != *task.WorkspaceID) {
+		return fmt.Errorf("%w: a subtask must be in the workspace of its parent", ErrInvalidTask)
+	}
+
+	ancestors, err := s.taskRepo.FindAncestorIDs(parent.ID)
+	if err != nil {
+		return err
+	}
+	for _, id := range ancestors {
+		if id == task.ID {
+			return fmt.Errorf("%w: a task cannot be moved under its own subtask", ErrInvalidTask)
+		}
+	}
+
+	// Depth of the parent plus the levels moving with the task
+	height := 1
+	if task.ID != 0 {
+		subtree, err := s.taskRepo.FindSubtree(task.ID)
+		if err != nil {
+			return err
+		}
+		height = subtreeHeight(task.ID, subtree)
+	}
+	if len(ancestors)+1+height > maxTaskDepth {
+		return fmt.Errorf("%w: tasks can be nested at most %d levels deep", ErrInvalidTask, maxTaskDepth)
+	}
+
+	task.ParentTaskID = &parent.ID
+	return nil
+}
+
+// subtreeHeight counts the levels of a subtree, 1 for a task without subtasks
+func subtreeHeight(rootID uint, tasks []models.Task) int {
+	children := make(map[uint][]uint, len(tasks))
+	for _, task := range tasks {
+		if task.ParentTaskID != nil {
+			children[*task.ParentTaskID] = append(children[*task.ParentTaskID], task.ID)
+		}
+	}
+
+	height := 0
+	for level := []uint{rootID}; len(level) > 0 && height < maxTaskDepth+1; height++ {
+		var next []uint
+		for _, id := range level {
+			next = append(next, children[id]...)
+		}
+		level = next
+	}
+	return height
+}
+
+// rolledUpEstimate compares the time tracked on a task and its subtasks with
+// the task's estimate
+func rolledUpEstimate(taskRepo repository.TaskRepository, task *models.Task) (*models.TaskEstimate, error) {
+	subtree, err := taskRepo.FindSubtree(task.ID)
+	if err != nil {
+		return nil, err
+	}
+	durations, err := taskRepo.TrackedDurations(subtree)
+	if err != nil {
+		return nil, err
+	}
+
+	var subtasks int64
+	for id, duration := range durations {
+		if id != task.ID {
+			subtasks += duration
+		}
+	}
+	estimate := taskEstimate(task.EstimateSeconds, durations[task.ID]+subtasks)
+	estimate.SubtaskSeconds = subtasks
+	return &estimate, nil
+}
+
+// findVisible loads a task its owner or one of its assignees asks for
+func (s *taskService) findVisible(id, userID uint) (*models.Task, error) {
+	task, err := s.taskRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if task.UserID == userID {
+		return task, nil
+	}
+
+	assigned, err := s.taskRepo.IsAssignee(task.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !assigned {
+		return nil, apperror.NotFound("unauthorized access to task")
+	}
+	return task, nil
+}
+
+func (s *taskService) assigneeResponses(task *models.Task) ([]dto.TaskAssigneeResponse, error) {
+	rows, err := s.taskRepo.FindAssignees(task.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.TaskAssigneeResponse, 0, len(rows)+1)
+	responses = append(responses, dto.TaskAssigneeResponse{
+		UserID: task.UserID,
+		Email:  task.User.Email,
+		Name:   emailUserName(&task.User),
+		Owner:  true,
+	})
+	for _, row := range rows {
+		responses = append(responses, dto.TaskAssigneeResponse{
+			UserID:     row.UserID,
+			Email:      row.Email,
+			Name:       emailUserName(&models.User{Email: row.Email, FirstName: row.FirstName, LastName: row.LastName}),
+			AssignedBy: &row.AssignedBy,
+			AssignedAt: &row.CreatedAt,
+		})
+	}
+	return responses, nil
+}
+
+// notifyAssigned tells a user someone else assigned them to the task
+func (s *taskService) notifyAssigned(task *models.Task, assigneeID, actorID uint) {
+	if assigneeID == actorID {
+		return
+	}
+	actor, err := s.userRepo.FindByID(actorID)
+	if err != nil {
+		log.Printf("⚠️  Failed to load user %d to notify a task assignment: %v", actorID, err)
+		return
+	}
+	s.notificationService.NotifyTaskAssigned(task, assigneeID, actor)
+}
+
 // Helper function to safely convert map to TaskWithStats
 func mapToTaskWithStats(m map[string]interface{}) (dto.TaskWithStats, error) {
 	task := dto.TaskWithStats{}
@@ -170,6 +543,14 @@ func mapToTaskWithStats(m map[string]interface{}) (dto.TaskWithStats, error) {
 		task.ID = id
 	}
 
+	if uuid, ok := m["uuid"].(string); ok {
+		task.UUID = uuid
+	}
+
+	if version, ok := m["version"].(uint); ok {
+		task.Version = version
+	}
+
 	if title, ok := m["title"].(string); ok {
 		task.Title = title
 	}
@@ -212,6 +593,34 @@ func mapToTaskWithStats(m map[string]interface{}) (dto.TaskWithStats, error) {
 		task.WorkspaceID = &wsID
 	}
 
+	if costCenter, ok := m["cost_center"].(string); ok {
+		task.CostCenter = costCenter
+	}
+
+	if projectCode, ok := m["project_code"].(string); ok {
+		task.ProjectCode = projectCode
+	}
+
+	if createdByID, ok := m["created_by"].(*uint); ok {
+		task.CreatedBy = createdByID
+	}
+
+	if parentTaskID, ok := m["parent_task_id"].(*uint); ok {
+		task.ParentTaskID = parentTaskID
+	}
+
+	if dueDate, ok := m["due_date"].(*time.Time); ok {
+		task.DueDate = dueDate
+	}
+
+	if estimate, ok := m["estimate_seconds"].(int64); ok {
+		task.EstimateSeconds = estimate
+	}
+
+	if tags, ok := m["tags"].(string); ok {
+		task.Tags = taskTagList(tags)
+	}
+
 	// Duration - can be int64 or float64 from SQL
 	if duration, ok := m["duration"].(int64); ok {
 		task.Duration = duration
@@ -236,3 +645,89 @@ func mapToTaskWithStats(m map[string]interface{}) (dto.TaskWithStats, error) {
 
 	return task, nil
 }
+
+const (
+	maxTaskTags      = 20
+	maxTaskTagLength = 50
+)
+
+// applyTaskPlanning sets the due date, estimate and labels that are provided.
+// An empty due date and a zero estimate clear them.
+func applyTaskPlanning(task *models.Task, dueDate *string, estimateSeconds *int64, tags *[]string) error {
+	if dueDate != nil {
+		if *dueDate == "" {
+			task.DueDate = nil
+		} else {
+			date, err := time.Parse("2006-01-02", *dueDate)
+			if err != nil {
+				return fmt.Errorf("%w: due_date must be YYYY-MM-DD", ErrInvalidTask)
+			}
+			task.DueDate = &date
+		}
+	}
+
+	if estimateSeconds != nil {
+		if *estimateSeconds < 0 {
+			return fmt.Errorf("%w: estimate_seconds cannot be negative", ErrInvalidTask)
+		}
+		task.EstimateSeconds = *estimateSeconds
+	}
+
+	if tags != nil {
+		normalized, err := normalizeTaskTags(*tags)
+		if err != nil {
+			return err
+		}
+		task.Tags = normalized
+	}
+	return nil
+}
+
+// normalizeTaskTags lowercases and deduplicates labels into their stored
+// comma-separated form
+func normalizeTaskTags(tags []string) (string, error) {
+	var normalized []string
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			continue
+		}
+		if strings.Contains(tag, ",") {
+			return "", fmt.Errorf("%w: tag %q cannot contain commas", ErrInvalidTask, tag)
+		}
+		if len(tag) > maxTaskTagLength {
+			return "", fmt.Errorf("%w: tag %q is longer than %d characters", ErrInvalidTask, tag, maxTaskTagLength)
+		}
+		normalized = append(normalized, tag)
+	}
+
+	normalized = uniqueStrings(normalized)
+	if len(normalized) > maxTaskTags {
+		return "", fmt.Errorf("%w: a task can have at most %d tags", ErrInvalidTask, maxTaskTags)
+	}
+	return strings.Join(normalized, ","), nil
+}
+
+// taskTagList splits stored labels, never returning nil so responses carry
+// an empty list
+func taskTagList(tags string) []string {
+	if tags == "" {
+		return []string{}
+	}
+	return strings.Split(tags, ",")
+}
+
+// taskEstimate compares tracked time with the estimate; without an estimate
+// only the tracked time is reported
+func taskEstimate(estimateSeconds, trackedSeconds int64) models.TaskEstimate {
+	estimate := models.TaskEstimate{
+		EstimateSeconds: estimateSeconds,
+		TrackedSeconds:  trackedSeconds,
+	}
+	if estimateSeconds > 0 {
+		estimate.RemainingSeconds = estimateSeconds - trackedSeconds
+		estimate.PercentUsed = math.Round(float64(trackedSeconds)/float64(estimateSeconds)*1000) / 10
+		estimate.OverEstimate = trackedSeconds > estimateSeconds
+	}
+	return estimate
+}
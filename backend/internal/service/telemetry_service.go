@@ -0,0 +1,338 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/config"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+	"github.com/beuphecan/remote-time-tracker/internal/utils"
+)
+
+// maxCrashTextLength bounds message and stack trace sizes stored per report
+const maxCrashTextLength = 16 * 1024
+
+// usageFeaturePattern restricts feature and action names to snake_case identifiers
+var usageFeaturePattern = regexp.MustCompile(`^[a-z0-9_.]+$`)
+
+// TelemetryService ingests desktop crash reports and feature usage events and aggregates them for admins
+type TelemetryService interface {
+	IngestCrashReports(userID *uint, req *dto.CrashReportBatchRequest) (*dto.CrashReportBatchResponse, error)
+	IngestUsageEvents(userID *uint, req *dto.UsageEventBatchRequest) (*dto.UsageEventBatchResponse, error)
+
+	// Admin
+	ListReleaseCrashes(params *dto.AdminCrashListParams) ([]dto.AdminReleaseCrashSummary, error)
+	GetReleaseCrashes(version string, params *dto.AdminCrashListParams) (*dto.AdminReleaseCrashResponse, error)
+	ListFeatureUsage(params *dto.AdminFeatureUsageParams) (*dto.AdminFeatureUsageResponse, error)
+	GetFeatureUsage(feature string, params *dto.AdminFeatureUsageParams) (*dto.AdminFeatureDetailResponse, error)
+
+	// Scheduled jobs
+	PurgeExpired(ctx context.Context) error
+}
+
+type telemetryService struct {
+	telemetryRepo repository.TelemetryRepository
+	orgRepo       *repository.OrganizationRepository
+	retention     time.Duration
+}
+
+// NewTelemetryService creates a new telemetry service
+func NewTelemetryService(telemetryRepo repository.TelemetryRepository, orgRepo *repository.OrganizationRepository) TelemetryService {
+	return &telemetryService{
+		telemetryRepo: telemetryRepo,
+		orgRepo:       orgRepo,
+		retention:     config.AppConfig.Telemetry.Retention,
+	}
+}
+
+// IngestCrashReports stores a batch of reports. userID is nil for anonymous clients.
+func (s *telemetryService) IngestCrashReports(userID *uint, req *dto.CrashReportBatchRequest) (*dto.CrashReportBatchResponse, error) {
+	now := time.Now()
+	reports := make([]models.CrashReport, 0, len(req.Reports))
+
+	for _, item := range req.Reports {
+		stackTrace := truncateText(item.StackTrace, maxCrashTextLength)
+
+		stackHash := strings.ToLower(strings.TrimSpace(item.StackHash))
+		if stackHash == "" {
+			// Hash the error type and stack so identical crashes group together
+			stackHash = utils.CalculateChecksum([]byte(item.ErrorType + "\n" + stackTrace))
+		}
+
+		occurredAt := now
+		if item.OccurredAt != nil && item.OccurredAt.Before(now) {
+			occurredAt = *item.OccurredAt
+		}
+
+		reports = append(reports, models.CrashReport{
+			UserID:      userID,
+			DeviceUUID:  item.DeviceUUID,
+			AppVersion:  strings.TrimPrefix(item.AppVersion, "v"),
+			Platform:    item.Platform,
+			OSVersion:   item.OSVersion,
+			Arch:        item.Arch,
+			ProcessType: item.ProcessType,
+			ErrorType:   item.ErrorType,
+			Message:     truncateText(item.Message, maxCrashTextLength),
+			StackHash:   stackHash,
+			StackTrace:  stackTrace,
+			IsFatal:     item.IsFatal,
+			OccurredAt:  occurredAt,
+		})
+	}
+
+	if err := s.telemetryRepo.CreateReports(reports); err != nil {
+		return nil, errors.New("failed to store crash reports")
+	}
+
+	return &dto.CrashReportBatchResponse{Accepted: len(reports)}, nil
+}
+
+// IngestUsageEvents stores a batch of feature usage events. Events are only
+// attributed to organizations the user belongs to, and are dropped entirely
+// for organizations that opted out of usage analytics.
+func (s *telemetryService) IngestUsageEvents(userID *uint, req *dto.UsageEventBatchRequest) (*dto.UsageEventBatchResponse, error) {
+	now := time.Now()
+	allowedOrgs := make(map[uint]bool)
+	events := make([]models.UsageEvent, 0, len(req.Events))
+
+	for _, item := range req.Events {
+		feature := normalizeUsageName(item.Feature)
+		action := normalizeUsageName(item.Action)
+		if !usageFeaturePattern.MatchString(feature) || (action != "" && !usageFeaturePattern.MatchString(action)) {
+			continue
+		}
+
+		var orgID *uint
+		if item.OrganizationID != nil {
+			allowed, checked := allowedOrgs[*item.OrganizationID]
+			if !checked {
+				allowed = s.acceptsUsageEvents(*item.OrganizationID, userID)
+				allowedOrgs[*item.OrganizationID] = allowed
+			}
+			if !allowed {
+				continue
+			}
+			orgID = item.OrganizationID
+		}
+
+		occurredAt := now
+		if item.OccurredAt != nil && item.OccurredAt.Before(now) {
+			occurredAt = *item.OccurredAt
+		}
+
+		events = append(events, models.UsageEvent{
+			OrganizationID: orgID,
+			UserID:         userID,
+			Feature:        feature,
+			Action:         action,
+			Platform:       item.Platform,
+			AppVersion:     strings.TrimPrefix(item.AppVersion, "v"),
+			OccurredAt:     occurredAt,
+		})
+	}
+
+	if len(events) > 0 {
+		if err := s.telemetryRepo.CreateUsageEvents(events); err != nil {
+			return nil, errors.New("failed to store usage events")
+		}
+	}
+
+	return &dto.UsageEventBatchResponse{
+		Accepted: len(events),
+		Dropped:  len(req.Events) - len(events),
+	}, nil
+}
+
+// acceptsUsageEvents reports whether events may be recorded for the organization
+func (s *telemetryService) acceptsUsageEvents(orgID uint, userID *uint) bool {
+	if userID == nil {
+		return false
+	}
+	if isMember, err := s.orgRepo.IsMember(orgID, *userID); err != nil || !isMember {
+		return false
+	}
+	org, err := s.orgRepo.GetByID(orgID)
+	if err != nil {
+		return false
+	}
+	return !org.UsageAnalyticsOptOut
+}
+
+func normalizeUsageName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+}
+
+// ListReleaseCrashes aggregates crash reports per release with crash-free rates
+func (s *telemetryService) ListReleaseCrashes(params *dto.AdminCrashListParams) ([]dto.AdminReleaseCrashSummary, error) {
+	normalizeCrashListParams(params)
+	since := time.Now().AddDate(0, 0, -params.Days)
+
+	summaries, err := s.telemetryRepo.GetReleaseSummaries(since, params.Platform, "", params.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyCrashFreeRates(summaries, since, params.Platform); err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
+
+// GetReleaseCrashes returns one release's summary and its most frequent crashes
+func (s *telemetryService) GetReleaseCrashes(version string, params *dto.AdminCrashListParams) (*dto.AdminReleaseCrashResponse, error) {
+	normalizeCrashListParams(params)
+	version = strings.TrimPrefix(version, "v")
+	since := time.Now().AddDate(0, 0, -params.Days)
+
+	summaries, err := s.telemetryRepo.GetReleaseSummaries(since, params.Platform, version, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(summaries) == 0 {
+		return nil, apperror.NotFound("no crash reports for this release")
+	}
+	if err := s.applyCrashFreeRates(summaries, since, params.Platform); err != nil {
+		return nil, err
+	}
+
+	stacks, err := s.telemetryRepo.GetStackGroups(version, since, params.Platform, params.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.AdminReleaseCrashResponse{
+		Summary: summaries[0],
+		Stacks:  stacks,
+	}, nil
+}
+
+func (s *telemetryService) applyCrashFreeRates(summaries []dto.AdminReleaseCrashSummary, since time.Time, platform string) error {
+	activeDevices, err := s.telemetryRepo.GetActiveDevicesByVersion(since, platform)
+	if err != nil {
+		return err
+	}
+
+	for i := range summaries {
+		active := activeDevices[summaries[i].AppVersion]
+		summaries[i].ActiveDevices = active
+		if active > 0 && summaries[i].AffectedDevices <= active {
+			rate := math.Round((1-float64(summaries[i].AffectedDevices)/float64(active))*10000) / 100
+			summaries[i].CrashFreeRate = &rate
+		}
+	}
+	return nil
+}
+
+// ListFeatureUsage aggregates usage events per feature with organization adoption rates
+func (s *telemetryService) ListFeatureUsage(params *dto.AdminFeatureUsageParams) (*dto.AdminFeatureUsageResponse, error) {
+	normalizeFeatureUsageParams(params)
+	since := time.Now().AddDate(0, 0, -params.Days)
+
+	features, err := s.telemetryRepo.GetFeatureUsage(since, params.OrgID, params.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	activeOrgs, err := s.telemetryRepo.CountActiveUsageOrgs(since)
+	if err != nil {
+		return nil, err
+	}
+
+	// Adoption across organizations is meaningless when filtering a single one
+	if params.OrgID == nil && activeOrgs > 0 {
+		for i := range features {
+			rate := math.Round(float64(features[i].Organizations)/float64(activeOrgs)*10000) / 100
+			features[i].AdoptionRate = &rate
+		}
+	}
+
+	return &dto.AdminFeatureUsageResponse{
+		Days:       params.Days,
+		ActiveOrgs: activeOrgs,
+		Features:   features,
+	}, nil
+}
+
+// GetFeatureUsage lists the organizations using a feature, most active first
+func (s *telemetryService) GetFeatureUsage(feature string, params *dto.AdminFeatureUsageParams) (*dto.AdminFeatureDetailResponse, error) {
+	normalizeFeatureUsageParams(params)
+	feature = normalizeUsageName(feature)
+	since := time.Now().AddDate(0, 0, -params.Days)
+
+	orgs, err := s.telemetryRepo.GetFeatureOrgUsage(feature, since, params.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.AdminFeatureDetailResponse{
+		Feature:       feature,
+		Days:          params.Days,
+		Organizations: orgs,
+	}, nil
+}
+
+// PurgeExpired deletes crash reports and usage events past the telemetry
+// retention, and usage events of organizations that opted out
+func (s *telemetryService) PurgeExpired(ctx context.Context) error {
+	cutoff := time.Now().Add(-s.retention)
+
+	deleted, err := s.telemetryRepo.DeleteReportsBefore(cutoff)
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		log.Printf("✅ Purged %d expired crash reports", deleted)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	deleted, err = s.telemetryRepo.DeleteUsageEventsBefore(cutoff)
+	if err != nil {
+		return err
+	}
+	optedOut, err := s.telemetryRepo.DeleteOptedOutUsageEvents()
+	if err != nil {
+		return err
+	}
+	if deleted+optedOut > 0 {
+		log.Printf("✅ Purged %d usage events (%d from opted-out organizations)", deleted+optedOut, optedOut)
+	}
+	return nil
+}
+
+func normalizeCrashListParams(params *dto.AdminCrashListParams) {
+	if params.Days < 1 || params.Days > 365 {
+		params.Days = 30
+	}
+	if params.Limit < 1 || params.Limit > 100 {
+		params.Limit = 20
+	}
+}
+
+func normalizeFeatureUsageParams(params *dto.AdminFeatureUsageParams) {
+	if params.Days < 1 || params.Days > 365 {
+		params.Days = 30
+	}
+	if params.Limit < 1 || params.Limit > 100 {
+		params.Limit = 50
+	}
+}
+
+func truncateText(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max]
+}
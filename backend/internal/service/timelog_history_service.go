@@ -0,0 +1,139 @@
+package service
+
+import (
+	"encoding/json"
+	"log"
+	"reflect"
+	"sort"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+)
+
+// TimeLogHistoryService keeps the edit history of time logs: the values
+// before and after each edit by an admin or a user
+type TimeLogHistoryService interface {
+	// Record stores an edit when the values changed; failures are logged and
+	// never fail the edit itself
+	Record(timeLogID uint, before, after models.TimeLogSnapshot, editedBy *uint, source string)
+	List(timeLogID, userID uint, page, perPage int) ([]dto.TimeLogEditResponse, int64, error)
+}
+
+type timeLogHistoryService struct {
+	editRepo          repository.TimeLogEditRepository
+	timeLogRepo       repository.TimeLogRepository
+	permissionService PermissionService
+}
+
+// NewTimeLogHistoryService creates a new time log history service
+func NewTimeLogHistoryService(editRepo repository.TimeLogEditRepository, timeLogRepo repository.TimeLogRepository, permissionService PermissionService) TimeLogHistoryService {
+	return &timeLogHistoryService{
+		editRepo:          editRepo,
+		timeLogRepo:       timeLogRepo,
+		permissionService: permissionService,
+	}
+}
+
+func (s *timeLogHistoryService) Record(timeLogID uint, before, after models.TimeLogSnapshot, editedBy *uint, source string) {
+	edit := newTimeLogEdit(timeLogID, before, after, editedBy, source)
+	if edit == nil {
+		return
+	}
+	if err := s.editRepo.Create(edit); err != nil {
+		log.Printf("⚠️  Failed to record edit of time log %d: %v", timeLogID, err)
+	}
+}
+
+// List returns the time log's edits, newest first, to its owner and to
+// members who can view others' time logs in its workspace
+func (s *timeLogHistoryService) List(timeLogID, userID uint, page, perPage int) ([]dto.TimeLogEditResponse, int64, error) {
+	timeLog, err := s.timeLogRepo.FindByID(timeLogID)
+	if err != nil {
+		return nil, 0, apperror.NotFound("time log not found")
+	}
+	if timeLog.UserID != userID {
+		allowed := false
+		if timeLog.WorkspaceID != nil {
+			allowed, err = s.permissionService.HasWorkspacePermission(*timeLog.WorkspaceID, userID, models.PermTimeLogsView)
+			if err != nil {
+				return nil, 0, err
+			}
+		}
+		if !allowed {
+			return nil, 0, apperror.Forbidden("access denied: cannot view this time log's history")
+		}
+	}
+
+	edits, total, err := s.editRepo.FindByTimeLogID(timeLogID, page, perPage)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]dto.TimeLogEditResponse, 0, len(edits))
+	for i := range edits {
+		result = append(result, toTimeLogEditResponse(&edits[i]))
+	}
+	return result, total, nil
+}
+
+// newTimeLogEdit builds the history entry of an edit, or nil when it left
+// the values unchanged
+func newTimeLogEdit(timeLogID uint, before, after models.TimeLogSnapshot, editedBy *uint, source string) *models.TimeLogEdit {
+	beforeJSON, _ := json.Marshal(before)
+	afterJSON, _ := json.Marshal(after)
+	if string(beforeJSON) == string(afterJSON) {
+		return nil
+	}
+	return &models.TimeLogEdit{
+		TimeLogID: timeLogID,
+		EditedBy:  editedBy,
+		Source:    source,
+		Before:    string(beforeJSON),
+		After:     string(afterJSON),
+	}
+}
+
+func toTimeLogEditResponse(edit *models.TimeLogEdit) dto.TimeLogEditResponse {
+	resp := dto.TimeLogEditResponse{
+		ID:        edit.ID,
+		TimeLogID: edit.TimeLogID,
+		Source:    edit.Source,
+		EditedBy:  edit.EditedBy,
+		Changed:   changedSnapshotValues(edit.Before, edit.After),
+		Before:    json.RawMessage(edit.Before),
+		After:     json.RawMessage(edit.After),
+		EditedAt:  edit.CreatedAt,
+	}
+	if edit.Editor != nil {
+		resp.Editor = &dto.UserResponse{
+			ID:        edit.Editor.ID,
+			UUID:      edit.Editor.UUID,
+			Email:     edit.Editor.Email,
+			FirstName: edit.Editor.FirstName,
+			LastName:  edit.Editor.LastName,
+			Role:      edit.Editor.Role,
+			IsActive:  edit.Editor.IsActive,
+			CreatedAt: edit.Editor.CreatedAt,
+		}
+	}
+	return resp
+}
+
+// changedSnapshotValues lists the names of the values that differ between
+// two snapshots, in alphabetical order
+func changedSnapshotValues(before, after string) []string {
+	var beforeValues, afterValues map[string]interface{}
+	_ = json.Unmarshal([]byte(before), &beforeValues)
+	_ = json.Unmarshal([]byte(after), &afterValues)
+
+	changed := []string{}
+	for key, value := range afterValues {
+		if !reflect.DeepEqual(beforeValues[key], value) {
+			changed = append(changed, key)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
@@ -2,30 +2,54 @@ package service
 
 import (
 	"errors"
+	"fmt"
+	"log"
+	"strings"
 	"time"
 
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/config"
 	"github.com/beuphecan/remote-time-tracker/internal/dto"
 	"github.com/beuphecan/remote-time-tracker/internal/models"
 	"github.com/beuphecan/remote-time-tracker/internal/repository"
 )
 
+// maxManualEntryDuration caps a single manual time entry
+const maxManualEntryDuration = 24 * time.Hour
+
+var (
+	// ErrTimeLogOverlap is returned when a manual entry overlaps the user's existing time logs
+	ErrTimeLogOverlap = apperror.Conflict("time entry overlaps an existing time log")
+	// ErrTimerAlreadyRunning is returned when starting a timer while another one
+	// is active under the reject concurrency policy
+	ErrTimerAlreadyRunning = apperror.Conflict("there is already an active time tracking session")
+)
+
 // TimeLogService handles time log business logic
 type TimeLogService interface {
 	Start(userID uint, req *dto.StartTimeLogRequest) (*models.TimeLog, error)
+	CreateManual(userID uint, req *dto.CreateManualTimeLogRequest) (*models.TimeLog, error)
 	Stop(userID uint, req *dto.StopTimeLogRequest) (*models.TimeLog, error)
 	Pause(userID uint, req *dto.PauseTimeLogRequest) (*models.TimeLog, error)
 	Resume(userID uint, req *dto.ResumeTimeLogRequest) (*models.TimeLog, error)
 	GetByID(id, userID uint) (*models.TimeLog, error)
 	GetByUserID(userID uint, page, perPage int) ([]models.TimeLog, int64, error)
 	GetActiveSession(userID uint) (*models.TimeLog, error)
+	GetCurrent(userID uint) (*dto.CurrentTimerResponse, error)
 	GetByDateRange(userID uint, startDate, endDate time.Time) ([]models.TimeLog, error)
 	GetTotalTime(userID uint, startDate, endDate time.Time) (int64, error)
 }
 
 type timeLogService struct {
-	timeLogRepo repository.TimeLogRepository
-	deviceRepo  repository.DeviceRepository
-	userRepo    repository.UserRepository
+	timeLogRepo   repository.TimeLogRepository
+	deviceRepo    repository.DeviceRepository
+	userRepo      repository.UserRepository
+	taskRepo      repository.TaskRepository
+	workspaceRepo *repository.WorkspaceRepository
+
+	commitService CommitLinkService
+	periodLocks   PeriodLockService
+	timerPolicy   string
 }
 
 // NewTimeLogService creates a new time log service
@@ -33,24 +57,25 @@ func NewTimeLogService(
 	timeLogRepo repository.TimeLogRepository,
 	deviceRepo repository.DeviceRepository,
 	userRepo repository.UserRepository,
+	taskRepo repository.TaskRepository,
+	workspaceRepo *repository.WorkspaceRepository,
+	commitService CommitLinkService,
+	periodLocks PeriodLockService,
 ) TimeLogService {
 	return &timeLogService{
-		timeLogRepo: timeLogRepo,
-		deviceRepo:  deviceRepo,
-		userRepo:    userRepo,
+		timeLogRepo:   timeLogRepo,
+		deviceRepo:    deviceRepo,
+		userRepo:      userRepo,
+		taskRepo:      taskRepo,
+		workspaceRepo: workspaceRepo,
+		commitService: commitService,
+		periodLocks:   periodLocks,
+		timerPolicy:   timerConcurrencyPolicy(),
 	}
 }
 
 func (s *timeLogService) Start(userID uint, req *dto.StartTimeLogRequest) (*models.TimeLog, error) {
-	// Check if there's already an active session
-	activeSession, err := s.timeLogRepo.FindActiveByUserID(userID)
-	if err != nil {
-		return nil, err
-	}
-
-	if activeSession != nil {
-		return nil, errors.New("there is already an active time tracking session")
-	}
+	now := time.Now().UTC()
 
 	// Create new time log
 	timeLog := &models.TimeLog{
@@ -58,16 +83,26 @@ func (s *timeLogService) Start(userID uint, req *dto.StartTimeLogRequest) (*mode
 		TaskID:    req.TaskID,
 		DeviceID:  req.DeviceID,
 		LocalID:   req.LocalID,
-		StartTime: time.Now().UTC(),
+		StartTime: now,
 		Status:    "running",
 		Notes:     req.Notes,
 	}
 
-	if err := s.timeLogRepo.Create(timeLog); err != nil {
-		return nil, errors.New("failed to start time tracking")
+	// Stop or refuse any session running on another device, holding the
+	// user's timer lock so concurrent starts cannot both succeed
+	err := s.timeLogRepo.Transaction(func(repo repository.TimeLogRepository) error {
+		if err := s.enforceSingleTimer(repo, userID, 0, now); err != nil {
+			return err
+		}
+		if err := repo.Create(timeLog); err != nil {
+			return errors.New("failed to start time tracking")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	now := time.Now().UTC()
 	_ = s.userRepo.UpdatePresence(userID, models.UserPresenceWorking, now, &now)
 	PresenceBroadcaster.Broadcast(PresenceEvent{
 		UserID:         userID,
@@ -76,6 +111,14 @@ func (s *timeLogService) Start(userID uint, req *dto.StartTimeLogRequest) (*mode
 		LastWorkingAt:  &now,
 	})
 
+	ActivityFeedBroadcaster.Broadcast(ActivityEvent{
+		Action:     ActivityTimeLogCreated,
+		UserID:     &userID,
+		EntityType: "time_log",
+		EntityID:   &timeLog.ID,
+		Details:    map[string]interface{}{"task_id": timeLog.TaskID, "source": "tracker"},
+	})
+
 	// Update device last seen
 	if req.DeviceID != nil {
 		s.deviceRepo.UpdateLastSeen(*req.DeviceID)
@@ -84,6 +127,102 @@ func (s *timeLogService) Start(userID uint, req *dto.StartTimeLogRequest) (*mode
 	return timeLog, nil
 }
 
+// CreateManual adds a past time entry. Entries may not overlap the user's
+// other time logs, including a running session; in workspaces that require
+// approval they stay pending until a manager reviews them.
+func (s *timeLogService) CreateManual(userID uint, req *dto.CreateManualTimeLogRequest) (*models.TimeLog, error) {
+	start, end := req.StartTime.UTC(), req.EndTime.UTC()
+	if !end.After(start) {
+		return nil, apperror.Validation("end time must be after start time", nil)
+	}
+	if end.After(time.Now().UTC()) {
+		return nil, apperror.Validation("manual entries cannot end in the future", nil)
+	}
+	if end.Sub(start) > maxManualEntryDuration {
+		return nil, apperror.Validation(fmt.Sprintf("manual entries cannot be longer than %d hours", int(maxManualEntryDuration.Hours())), nil)
+	}
+
+	timeLog := &models.TimeLog{
+		UserID:      userID,
+		WorkspaceID: req.WorkspaceID,
+		StartTime:   start,
+		EndTime:     &end,
+		Duration:    int64(end.Sub(start).Seconds()),
+		Status:      "stopped",
+		IsManual:    true,
+		Notes:       strings.TrimSpace(req.Notes),
+	}
+
+	if req.TaskID != nil {
+		task, err := s.taskRepo.FindByID(*req.TaskID)
+		if err != nil {
+			return nil, apperror.NotFound("task not found")
+		}
+		if req.WorkspaceID != nil && (task.WorkspaceID == nil || *task.WorkspaceID != *req.WorkspaceID) {
+			return nil, apperror.Validation("task does not belong to this workspace", nil)
+		}
+		if task.UserID != userID && task.WorkspaceID == nil {
+			return nil, apperror.NotFound("unauthorized access to task")
+		}
+		timeLog.TaskID = &task.ID
+		timeLog.TaskLocalID = task.LocalID
+		timeLog.TaskTitle = task.Title
+		timeLog.WorkspaceID = task.WorkspaceID
+		timeLog.OrganizationID = task.OrganizationID
+	}
+
+	if timeLog.WorkspaceID != nil {
+		workspace, err := s.workspaceRepo.GetByID(*timeLog.WorkspaceID)
+		if err != nil {
+			return nil, apperror.NotFound("workspace not found")
+		}
+		isMember, err := s.workspaceRepo.IsMember(workspace.ID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !isMember {
+			return nil, apperror.Forbidden("access denied: not a member of this workspace")
+		}
+		if workspace.IsArchived {
+			return nil, apperror.Conflict("workspace is archived")
+		}
+		timeLog.OrganizationID = &workspace.OrganizationID
+		timeLog.PendingApproval = workspace.ManualEntriesRequireApproval
+	}
+
+	lockedBefore, err := s.periodLocks.LockedBefore(timeLog.OrganizationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if lockedBefore != nil && start.Before(*lockedBefore) {
+		return nil, periodLockedError(*lockedBefore)
+	}
+
+	overlapping, err := s.timeLogRepo.FindOverlapping(userID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if len(overlapping) > 0 {
+		return nil, fmt.Errorf("%w (time log %d started %s)", ErrTimeLogOverlap,
+			overlapping[0].ID, overlapping[0].StartTime.UTC().Format(time.RFC3339))
+	}
+
+	if err := s.timeLogRepo.Create(timeLog); err != nil {
+		return nil, errors.New("failed to create time entry")
+	}
+	s.commitService.LinkFromNotes(timeLog)
+
+	ActivityFeedBroadcaster.Broadcast(ActivityEvent{
+		Action:     ActivityTimeLogCreated,
+		UserID:     &userID,
+		EntityType: "time_log",
+		EntityID:   &timeLog.ID,
+		Details:    map[string]interface{}{"task_id": timeLog.TaskID, "source": "manual"},
+	})
+
+	return timeLog, nil
+}
+
 func (s *timeLogService) Stop(userID uint, req *dto.StopTimeLogRequest) (*models.TimeLog, error) {
 	var timeLog *models.TimeLog
 	var err error
@@ -103,12 +242,12 @@ func (s *timeLogService) Stop(userID uint, req *dto.StopTimeLogRequest) (*models
 	}
 
 	if timeLog == nil {
-		return nil, errors.New("no active time tracking session found")
+		return nil, apperror.NotFound("no active time tracking session found")
 	}
 
 	// Check ownership
 	if timeLog.UserID != userID {
-		return nil, errors.New("unauthorized access to time log")
+		return nil, apperror.NotFound("unauthorized access to time log")
 	}
 
 	now := time.Now().UTC()
@@ -132,6 +271,7 @@ func (s *timeLogService) Stop(userID uint, req *dto.StopTimeLogRequest) (*models
 	if err := s.timeLogRepo.Update(timeLog); err != nil {
 		return nil, errors.New("failed to stop time tracking")
 	}
+	s.commitService.LinkFromNotes(timeLog)
 
 	_ = s.userRepo.UpdatePresence(userID, models.UserPresenceIdle, now, nil)
 	PresenceBroadcaster.Broadcast(PresenceEvent{
@@ -159,15 +299,15 @@ func (s *timeLogService) Pause(userID uint, req *dto.PauseTimeLogRequest) (*mode
 	}
 
 	if timeLog == nil {
-		return nil, errors.New("no active time tracking session found")
+		return nil, apperror.NotFound("no active time tracking session found")
 	}
 
 	if timeLog.UserID != userID {
-		return nil, errors.New("unauthorized access to time log")
+		return nil, apperror.NotFound("unauthorized access to time log")
 	}
 
 	if timeLog.Status != "running" {
-		return nil, errors.New("time tracking session is not running")
+		return nil, apperror.Conflict("time tracking session is not running")
 	}
 
 	now := time.Now().UTC()
@@ -204,15 +344,15 @@ func (s *timeLogService) Resume(userID uint, req *dto.ResumeTimeLogRequest) (*mo
 	}
 
 	if timeLog == nil {
-		return nil, errors.New("no paused time tracking session found")
+		return nil, apperror.NotFound("no paused time tracking session found")
 	}
 
 	if timeLog.UserID != userID {
-		return nil, errors.New("unauthorized access to time log")
+		return nil, apperror.NotFound("unauthorized access to time log")
 	}
 
 	if timeLog.Status != "paused" {
-		return nil, errors.New("time tracking session is not paused")
+		return nil, apperror.Conflict("time tracking session is not paused")
 	}
 
 	now := time.Now().UTC()
@@ -227,8 +367,17 @@ func (s *timeLogService) Resume(userID uint, req *dto.ResumeTimeLogRequest) (*mo
 	timeLog.Status = "running"
 	timeLog.PausedAt = nil
 
-	if err := s.timeLogRepo.Update(timeLog); err != nil {
-		return nil, errors.New("failed to resume time tracking")
+	err = s.timeLogRepo.Transaction(func(repo repository.TimeLogRepository) error {
+		if err := s.enforceSingleTimer(repo, userID, timeLog.ID, now); err != nil {
+			return err
+		}
+		if err := repo.Update(timeLog); err != nil {
+			return errors.New("failed to resume time tracking")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	_ = s.userRepo.UpdatePresence(userID, models.UserPresenceWorking, now, &now)
@@ -249,7 +398,7 @@ func (s *timeLogService) GetByID(id, userID uint) (*models.TimeLog, error) {
 	}
 
 	if timeLog.UserID != userID {
-		return nil, errors.New("unauthorized access to time log")
+		return nil, apperror.NotFound("unauthorized access to time log")
 	}
 
 	return timeLog, nil
@@ -263,6 +412,32 @@ func (s *timeLogService) GetActiveSession(userID uint) (*models.TimeLog, error)
 	return s.timeLogRepo.FindActiveByUserID(userID)
 }
 
+// GetCurrent returns the user's authoritative active session: the most
+// recently started running or paused time log
+func (s *timeLogService) GetCurrent(userID uint) (*dto.CurrentTimerResponse, error) {
+	timeLog, err := s.timeLogRepo.FindActiveByUserID(userID)
+	if err != nil || timeLog == nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	return &dto.CurrentTimerResponse{
+		TimeLogID:   timeLog.ID,
+		LocalID:     timeLog.LocalID,
+		TaskID:      timeLog.TaskID,
+		TaskTitle:   timeLog.TaskTitle,
+		WorkspaceID: timeLog.WorkspaceID,
+		DeviceID:    activeTimeLogDevice(timeLog),
+		Status:      timeLog.Status,
+		StartTime:   timeLog.StartTime,
+		PausedAt:    timeLog.PausedAt,
+		PausedTotal: timeLog.PausedTotal,
+		Elapsed:     trackedSeconds(timeLog, now),
+		Version:     timeLog.Version,
+		ServerTime:  now,
+	}, nil
+}
+
 func (s *timeLogService) GetByDateRange(userID uint, startDate, endDate time.Time) ([]models.TimeLog, error) {
 	return s.timeLogRepo.FindByDateRange(userID, startDate, endDate)
 }
@@ -270,3 +445,85 @@ func (s *timeLogService) GetByDateRange(userID uint, startDate, endDate time.Tim
 func (s *timeLogService) GetTotalTime(userID uint, startDate, endDate time.Time) (int64, error) {
 	return s.timeLogRepo.GetTotalTimeByUser(userID, startDate, endDate)
 }
+
+// ============================================================================
+// RUNNING TIMER INVARIANT
+// ============================================================================
+
+// enforceSingleTimer makes the session being started or resumed the user's
+// only active one. Other running or paused time logs (keepID aside) are
+// stopped at the given time, or the start is refused under the reject policy.
+// repo must be bound to the transaction that saves the session; the user's
+// timer lock is held until it ends.
+func (s *timeLogService) enforceSingleTimer(repo repository.TimeLogRepository, userID, keepID uint, at time.Time) error {
+	if err := repo.LockUserTimers(userID); err != nil {
+		return err
+	}
+	active, err := repo.FindAllActiveByUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	for i := range active {
+		other := &active[i]
+		if other.ID == keepID {
+			continue
+		}
+		if s.timerPolicy == models.TimerConcurrencyReject {
+			return ErrTimerAlreadyRunning
+		}
+		stopTimeLogAt(other, at)
+		if err := repo.Update(other); err != nil {
+			return errors.New("failed to stop the running time tracking session")
+		}
+		log.Printf("⏹️  Stopped time log %d of user %d: another timer was started", other.ID, userID)
+	}
+	return nil
+}
+
+// timerConcurrencyPolicy returns the configured running timer policy
+func timerConcurrencyPolicy() string {
+	policy := config.AppConfig.Timer.ConcurrencyPolicy
+	if policy != models.TimerConcurrencyStop && policy != models.TimerConcurrencyReject {
+		log.Printf("⚠️  Unknown timer concurrency policy %q, using %s", policy, models.TimerConcurrencyStop)
+		return models.TimerConcurrencyStop
+	}
+	return policy
+}
+
+func isActiveTimeLogStatus(status string) bool {
+	return status == "running" || status == "paused"
+}
+
+// stopTimeLogAt stops a running or paused time log at the given time; a
+// paused session stopped counting when it was paused
+func stopTimeLogAt(timeLog *models.TimeLog, at time.Time) {
+	if at.Before(timeLog.StartTime) {
+		at = timeLog.StartTime
+	}
+	timeLog.Duration = trackedSeconds(timeLog, at)
+	timeLog.EndTime = &at
+	timeLog.Status = "stopped"
+	timeLog.PausedAt = nil
+}
+
+// trackedSeconds is the time an active session has tracked as of at,
+// excluding pauses
+func trackedSeconds(timeLog *models.TimeLog, at time.Time) int64 {
+	if timeLog.Status == "paused" && timeLog.PausedAt != nil && timeLog.PausedAt.Before(at) {
+		at = *timeLog.PausedAt
+	}
+	seconds := int64(at.Sub(timeLog.StartTime).Seconds()) - timeLog.PausedTotal
+	if seconds < 0 {
+		return 0
+	}
+	return seconds
+}
+
+// activeTimeLogDevice is the device that last touched the session
+func activeTimeLogDevice(timeLog *models.TimeLog) *uint {
+	if timeLog.SyncDeviceID != nil {
+		return timeLog.SyncDeviceID
+	}
+	return timeLog.DeviceID
+}
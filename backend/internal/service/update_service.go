@@ -2,7 +2,6 @@ package service
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -11,28 +10,37 @@ import (
 	"strings"
 	"time"
 
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
 	"github.com/beuphecan/remote-time-tracker/internal/config"
 	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
 	"gopkg.in/yaml.v3"
 )
 
-// UpdateService handles auto-update operations via GitHub API
+// UpdateService handles auto-update operations. Releases published by admins
+// are served per release channel; without any, updates come from the GitHub
+// API.
 type UpdateService struct {
-	httpClient *http.Client
-	ghOwner    string
-	ghRepo     string
-	ghToken    string
+	httpClient  *http.Client
+	ghOwner     string
+	ghRepo      string
+	ghToken     string
+	releaseRepo repository.AppReleaseRepository
+	orgRepo     *repository.OrganizationRepository
 }
 
 // NewUpdateService creates a new update service instance
-func NewUpdateService() *UpdateService {
+func NewUpdateService(releaseRepo repository.AppReleaseRepository, orgRepo *repository.OrganizationRepository) *UpdateService {
 	return &UpdateService{
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		ghOwner: config.AppConfig.GitHub.Owner,
-		ghRepo:  config.AppConfig.GitHub.Repo,
-		ghToken: config.AppConfig.GitHub.Token,
+		ghOwner:     config.AppConfig.GitHub.Owner,
+		ghRepo:      config.AppConfig.GitHub.Repo,
+		ghToken:     config.AppConfig.GitHub.Token,
+		releaseRepo: releaseRepo,
+		orgRepo:     orgRepo,
 	}
 }
 
@@ -52,11 +60,47 @@ func (s *UpdateService) getAuthHeaders() map[string]string {
 	return headers
 }
 
-// CheckForUpdates checks if a newer version is available
-func (s *UpdateService) CheckForUpdates(req dto.UpdateCheckRequest) (*dto.UpdateCheckResponse, error) {
+// CheckForUpdates checks if a newer version is available on the user's
+// release channel
+func (s *UpdateService) CheckForUpdates(userID uint, req dto.UpdateCheckRequest) (*dto.UpdateCheckResponse, error) {
 	log.Printf("🔍 Checking for updates: current=%s, platform=%s, arch=%s",
 		req.CurrentVersion, req.Platform, req.Arch)
 
+	channel := s.resolveChannel(userID, req)
+	currentVersion := strings.TrimPrefix(req.CurrentVersion, "v")
+
+	releases, err := s.releaseRepo.FindByChannels(releaseChannels(channel))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load published releases: %w", err)
+	}
+
+	var response *dto.UpdateCheckResponse
+	if latest := newestRelease(releases); latest != nil {
+		response = publishedUpdate(latest, currentVersion, req)
+	} else {
+		response, err = s.checkGitHubForUpdates(currentVersion, req)
+		if err != nil {
+			return nil, err
+		}
+	}
+	response.Channel = channel
+
+	minimum, err := s.minimumVersion([]string{channel})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load minimum version: %w", err)
+	}
+	if minimum != "" {
+		response.MinimumVersion = minimum
+		response.IsMandatory = compareVersions(minimum, currentVersion) > 0
+	}
+
+	log.Printf("✅ Update check complete: channel=%s, available=%v, latest=%s",
+		channel, response.UpdateAvailable, response.LatestVersion)
+	return response, nil
+}
+
+// checkGitHubForUpdates checks the latest GitHub release
+func (s *UpdateService) checkGitHubForUpdates(currentVersion string, req dto.UpdateCheckRequest) (*dto.UpdateCheckResponse, error) {
 	// Get latest release from GitHub
 	release, err := s.getLatestRelease()
 	if err != nil {
@@ -65,7 +109,6 @@ func (s *UpdateService) CheckForUpdates(req dto.UpdateCheckRequest) (*dto.Update
 
 	// Parse version from tag name (remove 'v' prefix if present)
 	latestVersion := strings.TrimPrefix(release.TagName, "v")
-	currentVersion := strings.TrimPrefix(req.CurrentVersion, "v")
 
 	// Compare versions
 	updateAvailable := compareVersions(latestVersion, currentVersion) > 0
@@ -75,7 +118,6 @@ func (s *UpdateService) CheckForUpdates(req dto.UpdateCheckRequest) (*dto.Update
 		LatestVersion:   latestVersion,
 		ReleaseDate:     &release.PublishedAt,
 		ReleaseNotes:    release.Body,
-		IsMandatory:     false, // Can be determined from release notes or tags
 	}
 
 	if updateAvailable {
@@ -83,10 +125,79 @@ func (s *UpdateService) CheckForUpdates(req dto.UpdateCheckRequest) (*dto.Update
 		response.Files = s.filterAssetsForPlatform(release.Assets, req.Platform, req.Arch, latestVersion)
 	}
 
-	log.Printf("✅ Update check complete: available=%v, latest=%s", updateAvailable, latestVersion)
 	return response, nil
 }
 
+// resolveChannel returns the channel the organization of the request is
+// pinned to, else the requested channel, else stable
+func (s *UpdateService) resolveChannel(userID uint, req dto.UpdateCheckRequest) string {
+	if req.OrganizationID != nil {
+		if isMember, _ := s.orgRepo.IsMember(*req.OrganizationID, userID); isMember {
+			org, err := s.orgRepo.GetByID(*req.OrganizationID)
+			if err == nil && org.UpdateChannel != "" {
+				return org.UpdateChannel
+			}
+		}
+	}
+	if req.Channel != "" {
+		return req.Channel
+	}
+	return models.ReleaseChannelStable
+}
+
+// minimumVersion returns the newest mandatory version of the channels, or ""
+// when none of their releases is mandatory
+func (s *UpdateService) minimumVersion(channels []string) (string, error) {
+	var all []string
+	for _, channel := range channels {
+		all = append(all, releaseChannels(channel)...)
+	}
+
+	releases, err := s.releaseRepo.FindMandatory(uniqueStrings(all))
+	if err != nil {
+		return "", err
+	}
+	if newest := newestRelease(releases); newest != nil {
+		return newest.Version, nil
+	}
+	return "", nil
+}
+
+// RequireSupportedVersion fails with an upgrade_required error when the app
+// version is below the minimum of the stable channel or of a channel one of
+// the organizations is pinned to. An unknown version passes.
+func (s *UpdateService) RequireSupportedVersion(appVersion string, orgIDs []uint) error {
+	appVersion = strings.TrimPrefix(strings.TrimSpace(appVersion), "v")
+	if appVersion == "" {
+		return nil
+	}
+
+	channels := []string{models.ReleaseChannelStable}
+	for _, orgID := range orgIDs {
+		if org, err := s.orgRepo.GetByID(orgID); err == nil && org.UpdateChannel != "" {
+			channels = append(channels, org.UpdateChannel)
+		}
+	}
+
+	minimum, err := s.minimumVersion(channels)
+	if err != nil {
+		return err
+	}
+	if minimum == "" || compareVersions(minimum, appVersion) <= 0 {
+		return nil
+	}
+
+	return &apperror.Error{
+		Status:  http.StatusUpgradeRequired,
+		Code:    apperror.CodeUpgradeRequired,
+		Message: fmt.Sprintf("App version %s is no longer supported: update to %s or later", appVersion, minimum),
+		Details: dto.UpgradeRequiredDetails{
+			CurrentVersion: appVersion,
+			MinimumVersion: minimum,
+		},
+	}
+}
+
 // getLatestRelease fetches the latest release from GitHub
 func (s *UpdateService) getLatestRelease() (*dto.GHRelease, error) {
 	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/latest", s.ghOwner, s.ghRepo)
@@ -107,7 +218,7 @@ func (s *UpdateService) getLatestRelease() (*dto.GHRelease, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusNotFound {
-		return nil, errors.New("no releases found")
+		return nil, apperror.NotFound("no releases found")
 	}
 
 	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
@@ -171,12 +282,14 @@ func (s *UpdateService) filterAssetsForPlatform(assets []dto.GHAsset, platform,
 			patterns = []string{
 				`.*-arm64\.dmg$`,
 				`.*-arm64-mac\.zip$`,
+				`.*-arm64-mac\.zip\.blockmap$`,
 				`latest-mac\.yml$`,
 			}
 		} else {
 			patterns = []string{
-				`.*\.dmg$`,     // Generic dmg (not arm64)
-				`.*-mac\.zip$`, // Generic mac zip (not arm64)
+				`.*\.dmg$`,               // Generic dmg (not arm64)
+				`.*-mac\.zip$`,           // Generic mac zip (not arm64)
+				`.*-mac\.zip\.blockmap$`, // Block map for differential download
 				`latest-mac\.yml$`,
 			}
 		}
@@ -209,6 +322,7 @@ func (s *UpdateService) filterAssetsForPlatform(assets []dto.GHAsset, platform,
 					URL:         fmt.Sprintf("/api/v1/updates/download/%s/%s", version, asset.Name),
 					Size:        asset.Size,
 					ContentType: asset.ContentType,
+					Kind:        assetKind(asset.Name),
 				})
 				break
 			}
@@ -255,7 +369,7 @@ func (s *UpdateService) GetAssetInfo(version, assetName string) (*AssetInfo, err
 		}
 	}
 
-	return nil, fmt.Errorf("asset %s not found in release %s", assetName, version)
+	return nil, apperror.NotFound(fmt.Sprintf("asset %s not found in release %s", assetName, version))
 }
 
 // GetAssetDownloadURL returns the actual GitHub download URL for an asset
@@ -343,7 +457,7 @@ func (s *UpdateService) GetYMLFile(platform string) (*dto.YMLUpdateInfo, error)
 	}
 
 	if ymlAsset == nil {
-		return nil, fmt.Errorf("yml file %s not found in release", ymlFileName)
+		return nil, apperror.NotFound(fmt.Sprintf("yml file %s not found in release", ymlFileName))
 	}
 
 	// Download yml content
@@ -455,6 +569,272 @@ func (s *UpdateService) GetAllPlatformDownloads() (*dto.PublicDownloadResponse,
 	return response, nil
 }
 
+// ============================================================================
+// PUBLISHED RELEASES
+// ============================================================================
+
+// ListReleases lists published releases, newest first; an empty channel
+// lists all channels
+func (s *UpdateService) ListReleases(channel string) ([]dto.AppReleaseResponse, error) {
+	var channels []string
+	if channel != "" {
+		channels = []string{channel}
+	}
+
+	releases, err := s.releaseRepo.FindByChannels(channels)
+	if err != nil {
+		return nil, err
+	}
+	responses := make([]dto.AppReleaseResponse, 0, len(releases))
+	for i := range releases {
+		responses = append(responses, toAppReleaseResponse(&releases[i]))
+	}
+	return responses, nil
+}
+
+// GetPublishedRelease returns a published release by version
+func (s *UpdateService) GetPublishedRelease(version string) (*dto.AppReleaseResponse, error) {
+	release, err := s.releaseRepo.FindByVersion(strings.TrimPrefix(version, "v"))
+	if err != nil {
+		return nil, apperror.NotFound("release not found")
+	}
+	response := toAppReleaseResponse(release)
+	return &response, nil
+}
+
+// PublishRelease publishes a release to its channel. Its assets are served
+// from their URLs; the backend does not host them.
+func (s *UpdateService) PublishRelease(userID uint, req *dto.PublishReleaseRequest) (*dto.AppReleaseResponse, error) {
+	version := strings.TrimPrefix(strings.TrimSpace(req.Version), "v")
+	if version == "" {
+		return nil, apperror.Validation("version is required", nil)
+	}
+	if existing, _ := s.releaseRepo.FindByVersion(version); existing != nil {
+		return nil, apperror.Conflict("version " + version + " has already been published")
+	}
+
+	channel := req.Channel
+	if channel == "" {
+		channel = models.ReleaseChannelStable
+	}
+
+	release := &models.AppRelease{
+		Version:      version,
+		Channel:      channel,
+		ReleaseNotes: req.ReleaseNotes,
+		IsMandatory:  req.IsMandatory,
+		PublishedBy:  userID,
+		PublishedAt:  time.Now(),
+	}
+	for _, asset := range req.Assets {
+		kind := asset.Kind
+		if kind == "" {
+			kind = models.ReleaseAssetInstaller
+		}
+		fromVersion := ""
+		if kind == models.ReleaseAssetDelta {
+			fromVersion = strings.TrimPrefix(strings.TrimSpace(asset.FromVersion), "v")
+			if compareVersions(fromVersion, version) >= 0 {
+				return nil, apperror.Validation("delta "+asset.Name+" must apply to a version older than "+version, nil)
+			}
+		}
+
+		release.Assets = append(release.Assets, models.AppReleaseAsset{
+			Name:        asset.Name,
+			Kind:        kind,
+			FromVersion: fromVersion,
+			Platform:    asset.Platform,
+			Arch:        asset.Arch,
+			URL:         asset.URL,
+			Size:        asset.Size,
+			ContentType: asset.ContentType,
+			SHA512:      asset.SHA512,
+		})
+	}
+	if err := s.releaseRepo.Create(release); err != nil {
+		return nil, err
+	}
+
+	log.Printf("📦 Published release %s on the %s channel (mandatory=%v)", version, channel, req.IsMandatory)
+	response := toAppReleaseResponse(release)
+	return &response, nil
+}
+
+// WithdrawRelease unpublishes a release. Clients that installed it are offered
+// the newest remaining release only once it is newer.
+func (s *UpdateService) WithdrawRelease(id uint) error {
+	release, err := s.releaseRepo.FindByID(id)
+	if err != nil {
+		return apperror.NotFound("release not found")
+	}
+	if err := s.releaseRepo.Delete(release.ID); err != nil {
+		return err
+	}
+
+	log.Printf("🗑️ Withdrew release %s from the %s channel", release.Version, release.Channel)
+	return nil
+}
+
+// GetPublishedAsset returns an asset of a published release, with the URL it
+// is hosted at
+func (s *UpdateService) GetPublishedAsset(version, assetName string) (*dto.ReleaseAsset, error) {
+	release, err := s.releaseRepo.FindByVersion(strings.TrimPrefix(version, "v"))
+	if err != nil {
+		return nil, apperror.NotFound("release not found")
+	}
+	for _, asset := range release.Assets {
+		if asset.Name == assetName {
+			file := toReleaseAsset(asset)
+			return &file, nil
+		}
+	}
+	return nil, apperror.NotFound("asset not found")
+}
+
+// RecordDownload counts a download of a release asset for the download
+// statistics. Failures are logged, never returned: they must not break the
+// download.
+func (s *UpdateService) RecordDownload(userID uint, version string, asset dto.ReleaseAsset) {
+	download := &models.AppReleaseDownload{
+		Version:   strings.TrimPrefix(version, "v"),
+		AssetName: asset.Name,
+		Kind:      asset.Kind,
+		Platform:  asset.Platform,
+		Arch:      asset.Arch,
+		Bytes:     asset.Size,
+	}
+	if userID > 0 {
+		download.UserID = &userID
+	}
+	if download.Kind == "" {
+		download.Kind = assetKind(asset.Name)
+	}
+	if download.Platform == "" {
+		download.Platform = assetPlatform(asset.Name)
+	}
+
+	if err := s.releaseRepo.CreateDownload(download); err != nil {
+		log.Printf("⚠️  Failed to record download of %s %s: %v", version, asset.Name, err)
+	}
+}
+
+// ListDownloadStats aggregates release downloads per version for the admin
+// dashboard
+func (s *UpdateService) ListDownloadStats(params *dto.AdminReleaseDownloadParams) ([]dto.AdminReleaseDownloadSummary, error) {
+	if params.Days < 1 || params.Days > 365 {
+		params.Days = 30
+	}
+	if params.Limit < 1 || params.Limit > 100 {
+		params.Limit = 20
+	}
+
+	since := time.Now().AddDate(0, 0, -params.Days)
+	return s.releaseRepo.GetDownloadSummaries(since, params.Platform, params.Limit)
+}
+
+// assetKind tells installers from block maps by file name; deltas are only
+// known from published metadata
+func assetKind(name string) string {
+	if strings.HasSuffix(name, ".blockmap") {
+		return models.ReleaseAssetBlockmap
+	}
+	return models.ReleaseAssetInstaller
+}
+
+// assetPlatform guesses the platform of a GitHub release asset from its name
+func assetPlatform(name string) string {
+	name = strings.TrimSuffix(name, ".blockmap")
+	switch {
+	case strings.HasSuffix(name, ".exe"):
+		return "win32"
+	case strings.HasSuffix(name, ".dmg"), strings.HasSuffix(name, "-mac.zip"):
+		return "darwin"
+	case strings.HasSuffix(name, ".AppImage"):
+		return "linux"
+	}
+	return ""
+}
+
+// releaseChannels returns the channels whose releases clients of the channel
+// receive
+func releaseChannels(channel string) []string {
+	if channel == models.ReleaseChannelBeta {
+		return []string{models.ReleaseChannelStable, models.ReleaseChannelBeta}
+	}
+	return []string{models.ReleaseChannelStable}
+}
+
+// newestRelease returns the release with the highest version, or nil
+func newestRelease(releases []models.AppRelease) *models.AppRelease {
+	var newest *models.AppRelease
+	for i := range releases {
+		if newest == nil || compareVersions(releases[i].Version, newest.Version) > 0 {
+			newest = &releases[i]
+		}
+	}
+	return newest
+}
+
+// publishedUpdate builds the update check response for a published release
+func publishedUpdate(release *models.AppRelease, currentVersion string, req dto.UpdateCheckRequest) *dto.UpdateCheckResponse {
+	response := &dto.UpdateCheckResponse{
+		UpdateAvailable: compareVersions(release.Version, currentVersion) > 0,
+		LatestVersion:   release.Version,
+		ReleaseDate:     &release.PublishedAt,
+		ReleaseNotes:    release.ReleaseNotes,
+	}
+	if !response.UpdateAvailable {
+		return response
+	}
+
+	for _, asset := range release.Assets {
+		if asset.Platform != req.Platform || (asset.Arch != "" && asset.Arch != req.Arch) {
+			continue
+		}
+		// Deltas only help clients on the version they were built from
+		if asset.Kind == models.ReleaseAssetDelta && asset.FromVersion != currentVersion {
+			continue
+		}
+
+		file := toReleaseAsset(asset)
+		// Served through the backend so downloads are counted
+		file.URL = fmt.Sprintf("/api/v1/updates/download/%s/%s", release.Version, asset.Name)
+		response.Files = append(response.Files, file)
+	}
+	return response
+}
+
+func toReleaseAsset(asset models.AppReleaseAsset) dto.ReleaseAsset {
+	return dto.ReleaseAsset{
+		Name:        asset.Name,
+		URL:         asset.URL,
+		Size:        asset.Size,
+		ContentType: asset.ContentType,
+		SHA512:      asset.SHA512,
+		Kind:        asset.Kind,
+		FromVersion: asset.FromVersion,
+		Platform:    asset.Platform,
+		Arch:        asset.Arch,
+	}
+}
+
+func toAppReleaseResponse(release *models.AppRelease) dto.AppReleaseResponse {
+	assets := make([]dto.ReleaseAsset, 0, len(release.Assets))
+	for _, asset := range release.Assets {
+		assets = append(assets, toReleaseAsset(asset))
+	}
+	return dto.AppReleaseResponse{
+		ID:           release.ID,
+		Version:      release.Version,
+		Channel:      release.Channel,
+		ReleaseNotes: release.ReleaseNotes,
+		IsMandatory:  release.IsMandatory,
+		PublishedBy:  release.PublishedBy,
+		PublishedAt:  release.PublishedAt,
+		Assets:       assets,
+	}
+}
+
 // compareVersions compares two semantic versions
 // Returns: 1 if v1 > v2, -1 if v1 < v2, 0 if equal
 func compareVersions(v1, v2 string) int {
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+)
+
+// checkVersion rejects an update made against an outdated version of a
+// record. Clients that send no version keep last-write-wins updates.
+func checkVersion(expected *uint, current uint, state func() (interface{}, error)) error {
+	if expected == nil || *expected == current {
+		return nil
+	}
+	return versionConflict(state)
+}
+
+// versionedUpdateError turns a repository version conflict, where another
+// update landed between loading and saving the record, into the conflict
+// clients see
+func versionedUpdateError(err error, state func() (interface{}, error)) error {
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return versionConflict(state)
+	}
+	return err
+}
+
+// versionConflict reports a version conflict with the record's current
+// state, so the client can merge its edit and retry
+func versionConflict(state func() (interface{}, error)) error {
+	current, err := state()
+	if err != nil {
+		return err
+	}
+	return apperror.VersionConflict(current)
+}
@@ -0,0 +1,750 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
+	"github.com/beuphecan/remote-time-tracker/internal/config"
+	"github.com/beuphecan/remote-time-tracker/internal/dto"
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/repository"
+	"github.com/google/uuid"
+)
+
+// Sources reported in member lifecycle payloads
+const (
+	webhookSourceAdmin             = "admin"
+	webhookSourceInvitation        = "invitation"
+	webhookSourceInviteCode        = "invite_code"
+	webhookSourceRegistration      = "registration"
+	webhookSourceOwnershipTransfer = "ownership_transfer"
+	webhookSourceHourCap           = "hour_cap"
+	webhookSourceBudget            = "budget"
+	webhookSourceLeave             = "leave"
+)
+
+const (
+	maxWebhookResponseDrain = 2 * 1024
+	maxWebhookReplay        = 500
+	webhookRetryBatch       = 100
+	maxWebhookRetryBackoff  = 6 * time.Hour
+	webhookLookupTimeout    = 5 * time.Second
+)
+
+// errWebhookAddressBlocked is returned when a receiver resolves to an address
+// inside the server's network
+var errWebhookAddressBlocked = errors.New("webhook receiver address is not allowed")
+
+// WebhookService manages organization webhooks and delivers member lifecycle events to them
+type WebhookService interface {
+	List(orgID, userID uint) ([]dto.WebhookResponse, error)
+	Create(orgID, userID uint, req *dto.CreateWebhookRequest) (*dto.WebhookResponse, error)
+	Update(orgID, webhookID, userID uint, req *dto.UpdateWebhookRequest) (*dto.WebhookResponse, error)
+	Delete(orgID, webhookID, userID uint) error
+	RotateSecret(orgID, webhookID, userID uint) (*dto.WebhookResponse, error)
+	ListDeliveries(orgID, webhookID, userID uint, params *dto.WebhookDeliveryListParams) ([]dto.WebhookDeliveryResponse, int64, error)
+	Replay(orgID, webhookID, userID uint, req *dto.ReplayWebhookRequest) (*dto.ReplayWebhookResponse, error)
+
+	// EmitMemberEvent snapshots the member and delivers the event to subscribed
+	// webhooks and Slack in the background. changes is only set for member.role_changed.
+	EmitMemberEvent(event string, member models.OrganizationMember, actorID *uint, source string, changes map[string]dto.WebhookChange)
+	// EmitHourCapEvent delivers a member.hour_cap_* event for the member's
+	// weekly hours in a workspace in the background.
+	EmitHourCapEvent(event string, member models.OrganizationMember, hourCap dto.WebhookHourCapInfo)
+	// EmitBudgetEvent delivers a workspace.budget_threshold event in the
+	// background; member is the workspace admin.
+	EmitBudgetEvent(member models.OrganizationMember, budget dto.WebhookBudgetInfo)
+
+	// Scheduled jobs
+	RetryDue(ctx context.Context) error
+	PurgeDeliveries(ctx context.Context) error
+}
+
+type webhookService struct {
+	webhookRepo   repository.WebhookRepository
+	orgRepo       *repository.OrganizationRepository
+	workspaceRepo *repository.WorkspaceRepository
+	userRepo      repository.UserRepository
+	slackService  SlackService
+	httpClient    *http.Client
+	maxAttempts   int
+	retention     time.Duration
+	allowHTTP     bool
+	allowPrivate  bool
+}
+
+// NewWebhookService creates a new webhook service
+func NewWebhookService(
+	webhookRepo repository.WebhookRepository,
+	orgRepo *repository.OrganizationRepository,
+	workspaceRepo *repository.WorkspaceRepository,
+	userRepo repository.UserRepository,
+	slackService SlackService,
+) WebhookService {
+	cfg := config.AppConfig.Webhook
+	return &webhookService{
+		webhookRepo:   webhookRepo,
+		orgRepo:       orgRepo,
+		workspaceRepo: workspaceRepo,
+		userRepo:      userRepo,
+		slackService:  slackService,
+		httpClient:    newWebhookHTTPClient(cfg.Timeout, cfg.AllowPrivateHosts),
+		maxAttempts:   cfg.MaxAttempts,
+		retention:     cfg.DeliveryRetention,
+		allowHTTP:     cfg.AllowHTTP,
+		allowPrivate:  cfg.AllowPrivateHosts,
+	}
+}
+
+// newWebhookHTTPClient returns the client deliveries are sent with. Unless
+// allowPrivate is set, it refuses to connect to internal addresses, which
+// also covers receivers whose DNS changed after they were validated.
+func newWebhookHTTPClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	if !allowPrivate {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || blockedWebhookIP(ip) {
+				return errWebhookAddressBlocked
+			}
+			return nil
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil // A proxy would dial the receiver on our behalf, unchecked
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		// Receivers must answer directly; following redirects could reach internal hosts
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// blockedWebhookIP reports whether ip is a loopback, link-local, private or
+// unspecified address, which receivers may not use
+func blockedWebhookIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsPrivate() || ip.IsUnspecified()
+}
+
+// ============================================================================
+// WEBHOOK MANAGEMENT
+// ============================================================================
+
+func (s *webhookService) requireAdmin(orgID, userID uint) error {
+	isAdmin, err := s.orgRepo.IsAdmin(orgID, userID)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return apperror.Forbidden("access denied: only admins can manage webhooks")
+	}
+	return nil
+}
+
+func (s *webhookService) List(orgID, userID uint) ([]dto.WebhookResponse, error) {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return nil, err
+	}
+
+	webhooks, err := s.webhookRepo.FindByOrg(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.WebhookResponse, 0, len(webhooks))
+	for i := range webhooks {
+		result = append(result, toWebhookResponse(&webhooks[i], false))
+	}
+	return result, nil
+}
+
+func (s *webhookService) Create(orgID, userID uint, req *dto.CreateWebhookRequest) (*dto.WebhookResponse, error) {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return nil, err
+	}
+	if err := s.validateURL(req.URL); err != nil {
+		return nil, err
+	}
+
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	webhook := &models.OrganizationWebhook{
+		OrganizationID: orgID,
+		URL:            req.URL,
+		Secret:         secret,
+		Events:         strings.Join(uniqueStrings(req.Events), ","),
+		Description:    req.Description,
+		IsActive:       true,
+		CreatedBy:      userID,
+	}
+	if err := s.webhookRepo.Create(webhook); err != nil {
+		return nil, err
+	}
+
+	response := toWebhookResponse(webhook, true)
+	return &response, nil
+}
+
+func (s *webhookService) Update(orgID, webhookID, userID uint, req *dto.UpdateWebhookRequest) (*dto.WebhookResponse, error) {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return nil, err
+	}
+
+	webhook, err := s.webhookRepo.FindByID(orgID, webhookID)
+	if err != nil {
+		return nil, apperror.NotFound("webhook not found")
+	}
+
+	if req.URL != "" {
+		if err := s.validateURL(req.URL); err != nil {
+			return nil, err
+		}
+		webhook.URL = req.URL
+	}
+	if len(req.Events) > 0 {
+		webhook.Events = strings.Join(uniqueStrings(req.Events), ",")
+	}
+	if req.Description != nil {
+		webhook.Description = *req.Description
+	}
+	if req.IsActive != nil {
+		webhook.IsActive = *req.IsActive
+	}
+
+	if err := s.webhookRepo.Update(webhook); err != nil {
+		return nil, err
+	}
+
+	response := toWebhookResponse(webhook, false)
+	return &response, nil
+}
+
+func (s *webhookService) Delete(orgID, webhookID, userID uint) error {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return err
+	}
+
+	webhook, err := s.webhookRepo.FindByID(orgID, webhookID)
+	if err != nil {
+		return apperror.NotFound("webhook not found")
+	}
+	return s.webhookRepo.Delete(webhook.ID)
+}
+
+// RotateSecret issues a new signing secret; the old one stops working immediately
+func (s *webhookService) RotateSecret(orgID, webhookID, userID uint) (*dto.WebhookResponse, error) {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return nil, err
+	}
+
+	webhook, err := s.webhookRepo.FindByID(orgID, webhookID)
+	if err != nil {
+		return nil, apperror.NotFound("webhook not found")
+	}
+
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+	webhook.Secret = secret
+	if err := s.webhookRepo.Update(webhook); err != nil {
+		return nil, err
+	}
+
+	response := toWebhookResponse(webhook, true)
+	return &response, nil
+}
+
+func (s *webhookService) validateURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return apperror.Validation("invalid webhook URL", nil)
+	}
+	if u.Scheme != "https" && (u.Scheme != "http" || !s.allowHTTP) {
+		return apperror.Validation("webhook URL must use https", nil)
+	}
+	if s.allowPrivate {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), webhookLookupTimeout)
+	defer cancel()
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, u.Hostname())
+	if err != nil || len(addrs) == 0 {
+		return apperror.Validation("webhook URL host cannot be resolved", nil)
+	}
+	for _, addr := range addrs {
+		if blockedWebhookIP(addr.IP) {
+			return apperror.Validation("webhook URL must not point to a private or local address", nil)
+		}
+	}
+	return nil
+}
+
+// ============================================================================
+// DELIVERY HISTORY & REPLAY
+// ============================================================================
+
+func (s *webhookService) ListDeliveries(orgID, webhookID, userID uint, params *dto.WebhookDeliveryListParams) ([]dto.WebhookDeliveryResponse, int64, error) {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return nil, 0, err
+	}
+	if _, err := s.webhookRepo.FindByID(orgID, webhookID); err != nil {
+		return nil, 0, apperror.NotFound("webhook not found")
+	}
+
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PerPage < 1 || params.PerPage > 100 {
+		params.PerPage = 20
+	}
+
+	deliveries, total, err := s.webhookRepo.FindDeliveries(webhookID, params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]dto.WebhookDeliveryResponse, 0, len(deliveries))
+	for i := range deliveries {
+		result = append(result, toWebhookDeliveryResponse(&deliveries[i]))
+	}
+	return result, total, nil
+}
+
+// Replay sends past events again with their original event IDs so receivers
+// that missed them (downtime, rejected signature after a secret rotation)
+// can catch up. Each replay is recorded as a new delivery.
+func (s *webhookService) Replay(orgID, webhookID, userID uint, req *dto.ReplayWebhookRequest) (*dto.ReplayWebhookResponse, error) {
+	if err := s.requireAdmin(orgID, userID); err != nil {
+		return nil, err
+	}
+	if len(req.DeliveryIDs) == 0 && req.Since == nil {
+		return nil, apperror.Validation("either delivery_ids or since is required", nil)
+	}
+
+	webhook, err := s.webhookRepo.FindByID(orgID, webhookID)
+	if err != nil {
+		return nil, apperror.NotFound("webhook not found")
+	}
+	if !webhook.IsActive {
+		return nil, apperror.Conflict("webhook is disabled")
+	}
+
+	originals, err := s.webhookRepo.FindDeliveriesForReplay(webhook.ID, req, maxWebhookReplay)
+	if err != nil {
+		return nil, err
+	}
+
+	replays := make([]*models.WebhookDelivery, 0, len(originals))
+	for i := range originals {
+		original := &originals[i]
+		replay := s.newDelivery(webhook, original.UserID, original.EventID, original.Event, original.Payload)
+		replay.ReplayOf = &original.ID
+		if err := s.webhookRepo.CreateDelivery(replay); err != nil {
+			return nil, err
+		}
+		replays = append(replays, replay)
+	}
+
+	go func() {
+		for _, replay := range replays {
+			s.attempt(webhook, replay)
+		}
+	}()
+
+	return &dto.ReplayWebhookResponse{Replayed: len(replays)}, nil
+}
+
+// ============================================================================
+// EVENT EMISSION
+// ============================================================================
+
+func (s *webhookService) EmitMemberEvent(event string, member models.OrganizationMember, actorID *uint, source string, changes map[string]dto.WebhookChange) {
+	occurredAt := time.Now()
+
+	go s.dispatch(event, &member, func() (*dto.WebhookEventPayload, error) {
+		return s.buildMemberPayload(event, &member, actorID, source, changes, occurredAt)
+	})
+	s.slackService.NotifyMemberEvent(event, member)
+}
+
+func (s *webhookService) EmitHourCapEvent(event string, member models.OrganizationMember, hourCap dto.WebhookHourCapInfo) {
+	occurredAt := time.Now()
+
+	go s.dispatch(event, &member, func() (*dto.WebhookEventPayload, error) {
+		payload, err := s.buildMemberPayload(event, &member, nil, webhookSourceHourCap, nil, occurredAt)
+		if err != nil {
+			return nil, err
+		}
+		payload.HourCap = &hourCap
+		return payload, nil
+	})
+}
+
+func (s *webhookService) EmitBudgetEvent(member models.OrganizationMember, budget dto.WebhookBudgetInfo) {
+	occurredAt := time.Now()
+	event := models.WebhookEventBudgetThreshold
+
+	go s.dispatch(event, &member, func() (*dto.WebhookEventPayload, error) {
+		payload, err := s.buildMemberPayload(event, &member, nil, webhookSourceBudget, nil, occurredAt)
+		if err != nil {
+			return nil, err
+		}
+		payload.Budget = &budget
+		return payload, nil
+	})
+}
+
+// dispatch builds the payload only if a webhook subscribes to the event, then
+// records and attempts a delivery per subscribed webhook
+func (s *webhookService) dispatch(event string, member *models.OrganizationMember, build func() (*dto.WebhookEventPayload, error)) {
+	webhooks, err := s.webhookRepo.FindActiveByOrg(member.OrganizationID)
+	if err != nil {
+		log.Printf("⚠️  Failed to load webhooks of organization %d: %v", member.OrganizationID, err)
+		return
+	}
+
+	var subscribed []models.OrganizationWebhook
+	for _, w := range webhooks {
+		if w.Subscribes(event) {
+			subscribed = append(subscribed, w)
+		}
+	}
+	if len(subscribed) == 0 {
+		return
+	}
+
+	payload, err := build()
+	if err != nil {
+		log.Printf("⚠️  Failed to build %s payload for organization %d: %v", event, member.OrganizationID, err)
+		return
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("⚠️  Failed to encode %s payload: %v", event, err)
+		return
+	}
+
+	for i := range subscribed {
+		delivery := s.newDelivery(&subscribed[i], member.UserID, payload.ID, event, string(body))
+		if err := s.webhookRepo.CreateDelivery(delivery); err != nil {
+			log.Printf("⚠️  Failed to record webhook delivery for webhook %d: %v", subscribed[i].ID, err)
+			continue
+		}
+		s.attempt(&subscribed[i], delivery)
+	}
+}
+
+func (s *webhookService) buildMemberPayload(event string, member *models.OrganizationMember, actorID *uint, source string, changes map[string]dto.WebhookChange, occurredAt time.Time) (*dto.WebhookEventPayload, error) {
+	org, err := s.orgRepo.GetByID(member.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &member.User
+	if user.ID == 0 {
+		if user, err = s.userRepo.FindByID(member.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	workspaces := make([]dto.WebhookWorkspaceMember, 0)
+	if event != models.WebhookEventMemberRemoved {
+		memberships, _ := s.workspaceRepo.GetUserWorkspacesByOrg(member.UserID, member.OrganizationID)
+		for _, m := range memberships {
+			roleName := m.RoleName
+			if roleName == "" && m.WorkspaceRole != nil {
+				roleName = m.WorkspaceRole.DisplayName
+				if roleName == "" {
+					roleName = m.WorkspaceRole.Name
+				}
+			}
+			workspaces = append(workspaces, dto.WebhookWorkspaceMember{
+				WorkspaceID: m.WorkspaceID,
+				Name:        m.Workspace.Name,
+				Role:        roleName,
+				IsAdmin:     m.IsAdmin,
+			})
+		}
+	}
+
+	payload := &dto.WebhookEventPayload{
+		ID:         uuid.New().String(),
+		Event:      event,
+		OccurredAt: occurredAt,
+		Organization: dto.WebhookOrganizationInfo{
+			ID:   org.ID,
+			Name: org.Name,
+			Slug: org.Slug,
+		},
+		Member: dto.WebhookMemberInfo{
+			UserID:     user.ID,
+			Email:      user.Email,
+			FirstName:  user.FirstName,
+			LastName:   user.LastName,
+			Role:       member.Role,
+			IsActive:   member.IsActive && event != models.WebhookEventMemberRemoved,
+			JoinedAt:   member.JoinedAt,
+			InvitedBy:  member.InvitedBy,
+			Workspaces: workspaces,
+		},
+		Changes: changes,
+		Source:  source,
+	}
+
+	if actorID != nil && *actorID != member.UserID {
+		if actor, err := s.userRepo.FindByID(*actorID); err == nil {
+			payload.Actor = &dto.WebhookActorInfo{
+				UserID: actor.ID,
+				Email:  actor.Email,
+				Name:   strings.TrimSpace(actor.FirstName + " " + actor.LastName),
+			}
+		}
+	}
+
+	return payload, nil
+}
+
+// newDelivery prepares a pending delivery. The first attempt happens right
+// away; NextAttemptAt only lets the retry job recover it if that attempt never
+// completes (e.g. the server restarts mid-flight).
+func (s *webhookService) newDelivery(webhook *models.OrganizationWebhook, userID uint, eventID, event, payload string) *models.WebhookDelivery {
+	recoverAt := time.Now().Add(2*s.httpClient.Timeout + time.Minute)
+	return &models.WebhookDelivery{
+		WebhookID:      webhook.ID,
+		OrganizationID: webhook.OrganizationID,
+		UserID:         userID,
+		EventID:        eventID,
+		Event:          event,
+		Payload:        payload,
+		Status:         models.WebhookDeliveryPending,
+		NextAttemptAt:  &recoverAt,
+	}
+}
+
+// ============================================================================
+// DELIVERY
+// ============================================================================
+
+// attempt POSTs the delivery once and schedules a retry with exponential
+// backoff on failure, until the attempt limit is reached
+func (s *webhookService) attempt(webhook *models.OrganizationWebhook, delivery *models.WebhookDelivery) {
+	now := time.Now()
+	delivery.Attempts++
+
+	status, err := s.send(webhook, delivery, now)
+	delivery.ResponseStatus = status
+
+	switch {
+	case err == nil && status >= 200 && status < 300:
+		delivery.Status = models.WebhookDeliverySucceeded
+		delivery.Error = ""
+		delivery.DeliveredAt = &now
+		delivery.NextAttemptAt = nil
+	default:
+		if err != nil {
+			delivery.Error = err.Error()
+		} else {
+			delivery.Error = fmt.Sprintf("unexpected response status %d", status)
+		}
+		if delivery.Attempts >= s.maxAttempts {
+			delivery.Status = models.WebhookDeliveryFailed
+			delivery.NextAttemptAt = nil
+		} else {
+			next := now.Add(webhookRetryBackoff(delivery.Attempts))
+			delivery.Status = models.WebhookDeliveryPending
+			delivery.NextAttemptAt = &next
+		}
+	}
+
+	if err := s.webhookRepo.UpdateDelivery(delivery); err != nil {
+		log.Printf("⚠️  Failed to update webhook delivery %d: %v", delivery.ID, err)
+	}
+	_ = s.webhookRepo.TouchLastDelivery(webhook.ID, now)
+}
+
+// send POSTs the delivery and returns the response status. The response body
+// is not kept: echoing it back to admins would let them read any page the
+// server can reach.
+func (s *webhookService) send(webhook *models.OrganizationWebhook, delivery *models.WebhookDelivery, now time.Time) (int, error) {
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewBufferString(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "RemoteTimeTracker-Webhooks/1.0")
+	req.Header.Set("X-Webhook-Event", delivery.Event)
+	req.Header.Set("X-Webhook-Event-ID", delivery.EventID)
+	req.Header.Set("X-Webhook-Delivery", strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set("X-Webhook-Timestamp", timestamp)
+	req.Header.Set("X-Webhook-Signature", "sha256="+signWebhookPayload(webhook.Secret, timestamp, delivery.Payload))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// Drained so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxWebhookResponseDrain))
+	return resp.StatusCode, nil
+}
+
+// signWebhookPayload signs "<timestamp>.<body>" so receivers can reject replayed or tampered requests
+func signWebhookPayload(secret, timestamp, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookRetryBackoff waits 1m, 5m, 25m, ... capped at six hours
+func webhookRetryBackoff(attempts int) time.Duration {
+	backoff := time.Minute
+	for i := 1; i < attempts; i++ {
+		backoff *= 5
+		if backoff >= maxWebhookRetryBackoff {
+			return maxWebhookRetryBackoff
+		}
+	}
+	return backoff
+}
+
+// ============================================================================
+// SCHEDULED JOBS
+// ============================================================================
+
+// RetryDue re-attempts deliveries whose retry time has come
+func (s *webhookService) RetryDue(ctx context.Context) error {
+	deliveries, err := s.webhookRepo.FindDueDeliveries(time.Now(), webhookRetryBatch)
+	if err != nil {
+		return fmt.Errorf("failed to load due webhook deliveries: %w", err)
+	}
+
+	webhooks := make(map[uint]*models.OrganizationWebhook)
+	for i := range deliveries {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		delivery := &deliveries[i]
+
+		webhook, ok := webhooks[delivery.WebhookID]
+		if !ok {
+			webhook, _ = s.webhookRepo.FindByID(delivery.OrganizationID, delivery.WebhookID)
+			webhooks[delivery.WebhookID] = webhook
+		}
+		if webhook == nil || !webhook.IsActive {
+			delivery.Status = models.WebhookDeliveryFailed
+			delivery.Error = "webhook was deleted or disabled"
+			delivery.NextAttemptAt = nil
+			_ = s.webhookRepo.UpdateDelivery(delivery)
+			continue
+		}
+
+		s.attempt(webhook, delivery)
+	}
+
+	return nil
+}
+
+// PurgeDeliveries deletes delivery history past the retention
+func (s *webhookService) PurgeDeliveries(ctx context.Context) error {
+	deleted, err := s.webhookRepo.DeleteDeliveriesBefore(time.Now().Add(-s.retention))
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		log.Printf("✅ Purged %d old webhook deliveries", deleted)
+	}
+	return nil
+}
+
+// ============================================================================
+// HELPERS
+// ============================================================================
+
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.New("failed to generate webhook secret")
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func toWebhookResponse(webhook *models.OrganizationWebhook, withSecret bool) dto.WebhookResponse {
+	response := dto.WebhookResponse{
+		ID:             webhook.ID,
+		OrganizationID: webhook.OrganizationID,
+		URL:            webhook.URL,
+		Events:         strings.Split(webhook.Events, ","),
+		Description:    webhook.Description,
+		IsActive:       webhook.IsActive,
+		LastDeliveryAt: webhook.LastDeliveryAt,
+		CreatedBy:      webhook.CreatedBy,
+		CreatedAt:      webhook.CreatedAt,
+	}
+	if withSecret {
+		response.Secret = webhook.Secret
+	}
+	return response
+}
+
+func toWebhookDeliveryResponse(delivery *models.WebhookDelivery) dto.WebhookDeliveryResponse {
+	return dto.WebhookDeliveryResponse{
+		ID:             delivery.ID,
+		EventID:        delivery.EventID,
+		Event:          delivery.Event,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		Error:          delivery.Error,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		DeliveredAt:    delivery.DeliveredAt,
+		ReplayOf:       delivery.ReplayOf,
+		Payload:        json.RawMessage(delivery.Payload),
+		CreatedAt:      delivery.CreatedAt,
+	}
+}
@@ -1,12 +1,14 @@
 package service
 
 import (
-	"errors"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
 	"github.com/beuphecan/remote-time-tracker/internal/dto"
 	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/beuphecan/remote-time-tracker/internal/money"
 	"github.com/beuphecan/remote-time-tracker/internal/repository"
 	"github.com/gosimple/slug"
 )
@@ -25,22 +27,29 @@ type WorkspaceService interface {
 	GetUserWorkspaces(userID uint) ([]dto.WorkspaceListResponse, error)
 	GetUserWorkspacesByOrg(userID, orgID uint) ([]dto.WorkspaceListResponse, error)
 
+	// Screenshot capture policy
+	GetTrackingSettings(workspaceID, userID uint) (*dto.TrackingSettingsResponse, error)
+	UpdateTrackingSettings(workspaceID, userID uint, req *dto.UpdateTrackingSettingsRequest) (*dto.TrackingSettingsResponse, error)
+
 	// Member management
 	AddMember(workspaceID, actorID uint, req *dto.AddWorkspaceMemberRequest) (*dto.WorkspaceMemberResponse, error)
 	UpdateMember(workspaceID, memberUserID, actorID uint, req *dto.UpdateWorkspaceMemberRequest) (*dto.WorkspaceMemberResponse, error)
 	RemoveMember(workspaceID, memberUserID, actorID uint) error
+	// GetMembers takes the members.view permission
 	GetMembers(workspaceID, userID uint) ([]dto.WorkspaceMemberResponse, error)
 
 	// Permission checks (exposed for middleware)
 	IsAdmin(workspaceID, userID uint) (bool, error)
 	IsMember(workspaceID, userID uint) (bool, error)
 	CanManageWorkspace(workspaceID, userID uint) (bool, error)
+	HasPermission(workspaceID, userID uint, permission string) (bool, error)
 }
 
 type workspaceService struct {
-	workspaceRepo *repository.WorkspaceRepository
-	orgRepo       *repository.OrganizationRepository
-	userRepo      repository.UserRepository
+	workspaceRepo     *repository.WorkspaceRepository
+	orgRepo           *repository.OrganizationRepository
+	userRepo          repository.UserRepository
+	permissionService PermissionService
 }
 
 // NewWorkspaceService creates a new workspace service
@@ -48,11 +57,13 @@ func NewWorkspaceService(
 	workspaceRepo *repository.WorkspaceRepository,
 	orgRepo *repository.OrganizationRepository,
 	userRepo repository.UserRepository,
+	permissionService PermissionService,
 ) WorkspaceService {
 	return &workspaceService{
-		workspaceRepo: workspaceRepo,
-		orgRepo:       orgRepo,
-		userRepo:      userRepo,
+		workspaceRepo:     workspaceRepo,
+		orgRepo:           orgRepo,
+		userRepo:          userRepo,
+		permissionService: permissionService,
 	}
 }
 
@@ -67,7 +78,7 @@ func (s *workspaceService) Create(orgID, userID uint, req *dto.CreateWorkspaceRe
 		return nil, err
 	}
 	if !isAdmin {
-		return nil, errors.New("access denied: only organization admins can create workspaces")
+		return nil, apperror.Forbidden("access denied: only organization admins can create workspaces")
 	}
 
 	// Generate slug from name
@@ -91,7 +102,7 @@ func (s *workspaceService) Create(orgID, userID uint, req *dto.CreateWorkspaceRe
 			return nil, err
 		}
 		if exists {
-			return nil, errors.New("slug already exists in this organization")
+			return nil, apperror.Conflict("slug already exists in this organization")
 		}
 		wsSlug = strings.ToLower(req.Slug)
 	}
@@ -105,25 +116,46 @@ func (s *workspaceService) Create(orgID, userID uint, req *dto.CreateWorkspaceRe
 			return nil, err
 		}
 		if !isMember {
-			return nil, errors.New("workspace admin must be a member of the organization")
+			return nil, apperror.Validation("workspace admin must be a member of the organization", nil)
 		}
 		adminID = req.AdminID
 	}
 
+	if req.ClientID != nil {
+		if err := s.checkClient(orgID, *req.ClientID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Rates are in the organization currency unless the workspace bills in its own
+	currency := req.Currency
+	if currency == "" {
+		org, err := s.orgRepo.GetByID(orgID)
+		if err != nil {
+			return nil, err
+		}
+		currency = org.Currency
+	}
+	currency = money.Normalize(currency)
+
 	// Create workspace
 	workspace := &models.Workspace{
-		OrganizationID: orgID,
-		Name:           req.Name,
-		Slug:           wsSlug,
-		Description:    req.Description,
-		Color:          req.Color,
-		Icon:           req.Icon,
-		AdminID:        adminID,
-		IsActive:       true,
-		IsBillable:     req.IsBillable,
-		HourlyRate:     req.HourlyRate,
-		StartDate:      req.StartDate,
-		EndDate:        req.EndDate,
+		OrganizationID:  orgID,
+		Name:            req.Name,
+		Slug:            wsSlug,
+		Description:     req.Description,
+		Color:           req.Color,
+		Icon:            req.Icon,
+		AdminID:         adminID,
+		IsActive:        true,
+		IsBillable:      req.IsBillable,
+		Currency:        currency,
+		HourlyRateMinor: money.ToMinor(req.HourlyRate, currency),
+		ClientID:        req.ClientID,
+		StartDate:       req.StartDate,
+		EndDate:         req.EndDate,
+		CostCenter:      strings.TrimSpace(req.CostCenter),
+		ProjectCode:     strings.TrimSpace(req.ProjectCode),
 	}
 
 	if err := s.workspaceRepo.Create(workspace); err != nil {
@@ -162,7 +194,7 @@ func (s *workspaceService) GetByID(workspaceID, userID uint) (*dto.WorkspaceResp
 	isOrgMember, _ := s.orgRepo.IsMember(workspace.OrganizationID, userID)
 
 	if !isMember && !isOrgMember {
-		return nil, errors.New("access denied: not a member of this workspace or organization")
+		return nil, apperror.Forbidden("access denied: not a member of this workspace or organization")
 	}
 
 	memberCount, _ := s.workspaceRepo.GetMemberCount(workspaceID)
@@ -182,7 +214,7 @@ func (s *workspaceService) GetByIDWithMembers(workspaceID, userID uint) (*dto.Wo
 	isOrgMember, _ := s.orgRepo.IsMember(workspace.OrganizationID, userID)
 
 	if !isMember && !isOrgMember {
-		return nil, errors.New("access denied: not a member of this workspace or organization")
+		return nil, apperror.Forbidden("access denied: not a member of this workspace or organization")
 	}
 
 	memberCount := int64(len(workspace.Members))
@@ -212,7 +244,11 @@ func (s *workspaceService) Update(workspaceID, userID uint, req *dto.UpdateWorks
 		return nil, err
 	}
 	if !canManage {
-		return nil, errors.New("access denied: you cannot manage this workspace")
+		return nil, apperror.Forbidden("access denied: you cannot manage this workspace")
+	}
+	state := func() (interface{}, error) { return s.GetByID(workspaceID, userID) }
+	if err := checkVersion(req.Version, workspace.Version, state); err != nil {
+		return nil, err
 	}
 
 	// Update fields
@@ -235,7 +271,7 @@ func (s *workspaceService) Update(workspaceID, userID uint, req *dto.UpdateWorks
 			return nil, err
 		}
 		if !isMember {
-			return nil, errors.New("workspace admin must be a member of the organization")
+			return nil, apperror.Validation("workspace admin must be a member of the organization", nil)
 		}
 		workspace.AdminID = *req.AdminID
 	}
@@ -245,8 +281,22 @@ func (s *workspaceService) Update(workspaceID, userID uint, req *dto.UpdateWorks
 	if req.IsBillable != nil {
 		workspace.IsBillable = *req.IsBillable
 	}
+	if req.Currency != nil {
+		currency := money.Normalize(*req.Currency)
+		workspace.HourlyRateMinor = money.Rescale(workspace.HourlyRateMinor, workspace.Currency, currency)
+		workspace.Currency = currency
+	}
 	if req.HourlyRate != nil {
-		workspace.HourlyRate = *req.HourlyRate
+		workspace.HourlyRateMinor = money.ToMinor(*req.HourlyRate, workspace.Currency)
+	}
+	if req.ClientID != nil {
+		workspace.ClientID = nil
+		if *req.ClientID != 0 {
+			if err := s.checkClient(workspace.OrganizationID, *req.ClientID); err != nil {
+				return nil, err
+			}
+			workspace.ClientID = req.ClientID
+		}
 	}
 	if req.StartDate != nil {
 		workspace.StartDate = req.StartDate
@@ -254,9 +304,21 @@ func (s *workspaceService) Update(workspaceID, userID uint, req *dto.UpdateWorks
 	if req.EndDate != nil {
 		workspace.EndDate = req.EndDate
 	}
+	if req.CostCenter != nil {
+		workspace.CostCenter = strings.TrimSpace(*req.CostCenter)
+	}
+	if req.ProjectCode != nil {
+		workspace.ProjectCode = strings.TrimSpace(*req.ProjectCode)
+	}
+	if req.CapWarnPercent != nil {
+		workspace.CapWarnPercent = *req.CapWarnPercent
+	}
+	if req.CapEnforcement != nil {
+		workspace.CapEnforcement = *req.CapEnforcement
+	}
 
 	if err := s.workspaceRepo.Update(workspace); err != nil {
-		return nil, err
+		return nil, versionedUpdateError(err, state)
 	}
 
 	return s.GetByID(workspaceID, userID)
@@ -274,7 +336,7 @@ func (s *workspaceService) Delete(workspaceID, userID uint) error {
 		return err
 	}
 	if !isOrgOwner {
-		return errors.New("access denied: only organization owner can delete workspaces")
+		return apperror.Forbidden("access denied: only organization owner can delete workspaces")
 	}
 
 	return s.workspaceRepo.Delete(workspaceID)
@@ -291,7 +353,7 @@ func (s *workspaceService) GetWorkspacesByOrg(orgID, userID uint) ([]dto.Workspa
 		return nil, err
 	}
 	if !isMember {
-		return nil, errors.New("access denied: not a member of this organization")
+		return nil, apperror.Forbidden("access denied: not a member of this organization")
 	}
 
 	// Get organization for name
@@ -306,18 +368,36 @@ func (s *workspaceService) GetWorkspacesByOrg(orgID, userID uint) ([]dto.Workspa
 		return nil, err
 	}
 
+	workspaceIDs := make([]uint, len(workspaces))
+	for i, w := range workspaces {
+		workspaceIDs[i] = w.ID
+	}
+	counts, err := s.workspaceRepo.GetCountsByIDs(workspaceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load the user's memberships in this organization once instead of per workspace
+	memberships, err := s.workspaceRepo.GetUserWorkspacesByOrg(userID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	membershipByWorkspace := make(map[uint]*models.WorkspaceMember, len(memberships))
+	for i := range memberships {
+		membershipByWorkspace[memberships[i].WorkspaceID] = &memberships[i]
+	}
+
 	result := make([]dto.WorkspaceListResponse, 0, len(workspaces))
 	for _, w := range workspaces {
-		memberCount, _ := s.workspaceRepo.GetMemberCount(w.ID)
-		taskCount, _ := s.workspaceRepo.GetTaskCount(w.ID)
-		isAdmin, _ := s.workspaceRepo.IsAdmin(w.ID, userID)
+		isAdmin := w.AdminID == userID
 
 		// Get user's membership info
-		member, _ := s.workspaceRepo.GetMember(w.ID, userID)
+		member := membershipByWorkspace[w.ID]
 		var roleName string
 		var workspaceRoleID *uint
 		var joinedAt time.Time
 		if member != nil {
+			isAdmin = isAdmin || member.IsAdmin
 			roleName = member.RoleName
 			workspaceRoleID = member.WorkspaceRoleID
 			joinedAt = member.JoinedAt
@@ -339,11 +419,12 @@ func (s *workspaceService) GetWorkspacesByOrg(orgID, userID uint) ([]dto.Workspa
 			Description:      w.Description,
 			Color:            w.Color,
 			Icon:             w.Icon,
+			ClientID:         w.ClientID,
 			IsAdmin:          isAdmin,
 			WorkspaceRoleID:  workspaceRoleID,
 			RoleName:         roleName,
-			MemberCount:      memberCount,
-			TaskCount:        taskCount,
+			MemberCount:      counts[w.ID].MemberCount,
+			TaskCount:        counts[w.ID].TaskCount,
 			IsActive:         w.IsActive,
 			JoinedAt:         joinedAt,
 		})
@@ -358,10 +439,13 @@ func (s *workspaceService) GetUserWorkspaces(userID uint) ([]dto.WorkspaceListRe
 		return nil, err
 	}
 
+	counts, err := s.workspaceRepo.GetCountsByIDs(membershipWorkspaceIDs(memberships))
+	if err != nil {
+		return nil, err
+	}
+
 	result := make([]dto.WorkspaceListResponse, 0, len(memberships))
 	for _, m := range memberships {
-		memberCount, _ := s.workspaceRepo.GetMemberCount(m.WorkspaceID)
-		taskCount, _ := s.workspaceRepo.GetTaskCount(m.WorkspaceID)
 
 		// Get organization name from preloaded data
 		var orgName string
@@ -387,11 +471,12 @@ func (s *workspaceService) GetUserWorkspaces(userID uint) ([]dto.WorkspaceListRe
 			Description:      m.Workspace.Description,
 			Color:            m.Workspace.Color,
 			Icon:             m.Workspace.Icon,
+			ClientID:         m.Workspace.ClientID,
 			IsAdmin:          m.IsAdmin,
 			WorkspaceRoleID:  m.WorkspaceRoleID,
 			RoleName:         roleName,
-			MemberCount:      memberCount,
-			TaskCount:        taskCount,
+			MemberCount:      counts[m.WorkspaceID].MemberCount,
+			TaskCount:        counts[m.WorkspaceID].TaskCount,
 			IsActive:         m.Workspace.IsActive,
 			JoinedAt:         m.JoinedAt,
 		})
@@ -400,6 +485,14 @@ func (s *workspaceService) GetUserWorkspaces(userID uint) ([]dto.WorkspaceListRe
 	return result, nil
 }
 
+func membershipWorkspaceIDs(memberships []models.WorkspaceMember) []uint {
+	ids := make([]uint, len(memberships))
+	for i, m := range memberships {
+		ids[i] = m.WorkspaceID
+	}
+	return ids
+}
+
 func (s *workspaceService) GetUserWorkspacesByOrg(userID, orgID uint) ([]dto.WorkspaceListResponse, error) {
 	memberships, err := s.workspaceRepo.GetUserWorkspacesByOrg(userID, orgID)
 	if err != nil {
@@ -413,10 +506,13 @@ func (s *workspaceService) GetUserWorkspacesByOrg(userID, orgID uint) ([]dto.Wor
 		orgName = org.Name
 	}
 
+	counts, err := s.workspaceRepo.GetCountsByIDs(membershipWorkspaceIDs(memberships))
+	if err != nil {
+		return nil, err
+	}
+
 	result := make([]dto.WorkspaceListResponse, 0, len(memberships))
 	for _, m := range memberships {
-		memberCount, _ := s.workspaceRepo.GetMemberCount(m.WorkspaceID)
-		taskCount, _ := s.workspaceRepo.GetTaskCount(m.WorkspaceID)
 
 		// Get role name - prefer WorkspaceRole if available
 		roleName := m.RoleName
@@ -436,11 +532,12 @@ func (s *workspaceService) GetUserWorkspacesByOrg(userID, orgID uint) ([]dto.Wor
 			Description:      m.Workspace.Description,
 			Color:            m.Workspace.Color,
 			Icon:             m.Workspace.Icon,
+			ClientID:         m.Workspace.ClientID,
 			IsAdmin:          m.IsAdmin,
 			WorkspaceRoleID:  m.WorkspaceRoleID,
 			RoleName:         roleName,
-			MemberCount:      memberCount,
-			TaskCount:        taskCount,
+			MemberCount:      counts[m.WorkspaceID].MemberCount,
+			TaskCount:        counts[m.WorkspaceID].TaskCount,
 			IsActive:         m.Workspace.IsActive,
 			JoinedAt:         m.JoinedAt,
 		})
@@ -449,6 +546,68 @@ func (s *workspaceService) GetUserWorkspacesByOrg(userID, orgID uint) ([]dto.Wor
 	return result, nil
 }
 
+// ============================================================================
+// TRACKING SETTINGS
+// ============================================================================
+
+func (s *workspaceService) GetTrackingSettings(workspaceID, userID uint) (*dto.TrackingSettingsResponse, error) {
+	workspace, err := s.workspaceRepo.GetByID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check access: must be org member or workspace member
+	isMember, _ := s.workspaceRepo.IsMember(workspaceID, userID)
+	isOrgMember, _ := s.orgRepo.IsMember(workspace.OrganizationID, userID)
+	if !isMember && !isOrgMember {
+		return nil, apperror.Forbidden("access denied: not a member of this workspace or organization")
+	}
+
+	return toTrackingSettingsResponse(workspace), nil
+}
+
+func (s *workspaceService) UpdateTrackingSettings(workspaceID, userID uint, req *dto.UpdateTrackingSettingsRequest) (*dto.TrackingSettingsResponse, error) {
+	workspace, err := s.workspaceRepo.GetByID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	canManage, err := s.HasPermission(workspaceID, userID, models.PermSettingsManage)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, apperror.Forbidden("access denied: you cannot change tracking settings for this workspace")
+	}
+
+	if req.ScreenshotsDisabled != nil {
+		workspace.ScreenshotsDisabled = *req.ScreenshotsDisabled
+	}
+	if req.ScreenshotIntervalSeconds != nil {
+		workspace.ScreenshotIntervalSeconds = *req.ScreenshotIntervalSeconds
+	}
+	if req.ScreenshotBlurMode != nil {
+		workspace.ScreenshotBlurMode = *req.ScreenshotBlurMode
+	}
+	if req.ScreenshotMonitors != nil {
+		workspace.ScreenshotMonitors = *req.ScreenshotMonitors
+	}
+	if req.IdleThresholdSeconds != nil {
+		workspace.IdleThresholdSeconds = *req.IdleThresholdSeconds
+	}
+	if req.DisabledFeatures != nil {
+		workspace.DisabledFeatures = strings.Join(uniqueStrings(*req.DisabledFeatures), ",")
+	}
+	if req.ManualEntriesRequireApproval != nil {
+		workspace.ManualEntriesRequireApproval = *req.ManualEntriesRequireApproval
+	}
+
+	if err := s.workspaceRepo.Update(workspace); err != nil {
+		return nil, err
+	}
+	return toTrackingSettingsResponse(workspace), nil
+}
+
 // ============================================================================
 // MEMBER MANAGEMENT
 // ============================================================================
@@ -459,13 +618,18 @@ func (s *workspaceService) AddMember(workspaceID, actorID uint, req *dto.AddWork
 		return nil, err
 	}
 
-	// Check if actor can manage workspace
-	canManage, err := s.CanManageWorkspace(workspaceID, actorID)
+	// Check if actor can manage members
+	canManage, err := s.HasPermission(workspaceID, actorID, models.PermMembersManage)
 	if err != nil {
 		return nil, err
 	}
 	if !canManage {
-		return nil, errors.New("access denied: you cannot add members to this workspace")
+		return nil, apperror.Forbidden("access denied: you cannot add members to this workspace")
+	}
+	if req.IsAdmin {
+		if err := s.requireManager(workspaceID, actorID); err != nil {
+			return nil, err
+		}
 	}
 
 	// Target user must be org member
@@ -474,7 +638,7 @@ func (s *workspaceService) AddMember(workspaceID, actorID uint, req *dto.AddWork
 		return nil, err
 	}
 	if !isOrgMember {
-		return nil, errors.New("user must be a member of the organization first")
+		return nil, apperror.Validation("user must be a member of the organization first", nil)
 	}
 
 	// Check if already a member
@@ -483,13 +647,13 @@ func (s *workspaceService) AddMember(workspaceID, actorID uint, req *dto.AddWork
 		return nil, err
 	}
 	if isMember {
-		return nil, errors.New("user is already a member of this workspace")
+		return nil, apperror.Conflict("user is already a member of this workspace")
 	}
 
 	// Get user
 	user, err := s.userRepo.FindByID(req.UserID)
 	if err != nil {
-		return nil, errors.New("user not found")
+		return nil, apperror.NotFound("user not found")
 	}
 
 	// Create member
@@ -504,6 +668,7 @@ func (s *workspaceService) AddMember(workspaceID, actorID uint, req *dto.AddWork
 		AddedBy:         &actorID,
 		JoinedAt:        time.Now(),
 		IsActive:        true,
+		WeeklyHourCap:   req.WeeklyHourCap,
 	}
 
 	if err := s.workspaceRepo.AddMember(member); err != nil {
@@ -525,19 +690,27 @@ func (s *workspaceService) AddMember(workspaceID, actorID uint, req *dto.AddWork
 }
 
 func (s *workspaceService) UpdateMember(workspaceID, memberUserID, actorID uint, req *dto.UpdateWorkspaceMemberRequest) (*dto.WorkspaceMemberResponse, error) {
-	// Check if actor can manage workspace
-	canManage, err := s.CanManageWorkspace(workspaceID, actorID)
+	// Check if actor can manage members
+	canManage, err := s.HasPermission(workspaceID, actorID, models.PermMembersManage)
 	if err != nil {
 		return nil, err
 	}
 	if !canManage {
-		return nil, errors.New("access denied: you cannot update members in this workspace")
+		return nil, apperror.Forbidden("access denied: you cannot update members in this workspace")
 	}
 
 	// Get member
 	member, err := s.workspaceRepo.GetMemberWithDetails(workspaceID, memberUserID)
 	if err != nil {
-		return nil, errors.New("member not found")
+		return nil, apperror.NotFound("member not found")
+	}
+	before := *member
+
+	// Only managers can change admin rights, or the rights of an admin
+	if member.IsAdmin || (req.IsAdmin != nil && *req.IsAdmin) {
+		if err := s.requireManager(workspaceID, actorID); err != nil {
+			return nil, err
+		}
 	}
 
 	// Update fields
@@ -564,11 +737,22 @@ func (s *workspaceService) UpdateMember(workspaceID, memberUserID, actorID uint,
 	if req.IsActive != nil {
 		member.IsActive = *req.IsActive
 	}
+	if req.WeeklyHourCap != nil {
+		if *req.WeeklyHourCap > 0 {
+			member.WeeklyHourCap = req.WeeklyHourCap
+		} else {
+			member.WeeklyHourCap = nil
+		}
+	}
 
 	if err := s.workspaceRepo.UpdateMember(member); err != nil {
 		return nil, err
 	}
 
+	if workspace, err := s.workspaceRepo.GetByID(workspaceID); err == nil {
+		s.permissionService.RecordRoleChanges(workspaceRoleChanges(workspace.OrganizationID, &before, member, actorID)...)
+	}
+
 	// Reload member with full details to ensure WorkspaceRole is loaded
 	updatedMember, err := s.workspaceRepo.GetMemberWithDetails(workspaceID, memberUserID)
 	if err != nil {
@@ -585,35 +769,30 @@ func (s *workspaceService) RemoveMember(workspaceID, memberUserID, actorID uint)
 		return err
 	}
 
-	// Check if actor can manage workspace
-	canManage, err := s.CanManageWorkspace(workspaceID, actorID)
+	// Check if actor can manage members
+	canManage, err := s.HasPermission(workspaceID, actorID, models.PermMembersManage)
 	if err != nil {
 		return err
 	}
 	if !canManage {
-		return errors.New("access denied: you cannot remove members from this workspace")
+		return apperror.Forbidden("access denied: you cannot remove members from this workspace")
 	}
 
 	// Prevent removing workspace admin
 	if workspace.AdminID == memberUserID {
-		return errors.New("cannot remove workspace admin, transfer admin rights first")
+		return apperror.Validation("cannot remove workspace admin, transfer admin rights first", nil)
 	}
 
 	return s.workspaceRepo.RemoveMember(workspaceID, memberUserID)
 }
 
 func (s *workspaceService) GetMembers(workspaceID, userID uint) ([]dto.WorkspaceMemberResponse, error) {
-	workspace, err := s.workspaceRepo.GetByID(workspaceID)
+	canView, err := s.HasPermission(workspaceID, userID, models.PermMembersView)
 	if err != nil {
 		return nil, err
 	}
-
-	// Check access
-	isMember, _ := s.workspaceRepo.IsMember(workspaceID, userID)
-	isOrgMember, _ := s.orgRepo.IsMember(workspace.OrganizationID, userID)
-
-	if !isMember && !isOrgMember {
-		return nil, errors.New("access denied: not a member of this workspace or organization")
+	if !canView {
+		return nil, apperror.Forbidden("access denied: you cannot view the members of this workspace")
 	}
 
 	members, err := s.workspaceRepo.GetMembersByWorkspaceID(workspaceID)
@@ -664,15 +843,75 @@ func (s *workspaceService) CanManageWorkspace(workspaceID, userID uint) (bool, e
 	return isWsAdmin, nil
 }
 
+// HasPermission checks a fine-grained workspace permission such as
+// models.PermMembersManage; managers hold every permission
+func (s *workspaceService) HasPermission(workspaceID, userID uint, permission string) (bool, error) {
+	return s.permissionService.HasWorkspacePermission(workspaceID, userID, permission)
+}
+
+// requireManager denies members whose permissions come from their role only
+func (s *workspaceService) requireManager(workspaceID, userID uint) error {
+	canManage, err := s.CanManageWorkspace(workspaceID, userID)
+	if err != nil {
+		return err
+	}
+	if !canManage {
+		return apperror.Forbidden("access denied: only workspace managers can change admin rights")
+	}
+	return nil
+}
+
 // ============================================================================
 // HELPER FUNCTIONS
 // ============================================================================
 
+// workspaceRoleChanges builds history entries for the role and permission
+// flags that differ between two states of a workspace membership
+func workspaceRoleChanges(orgID uint, before, after *models.WorkspaceMember, actorID uint) []models.RoleChange {
+	var changes []models.RoleChange
+	add := func(attribute, from, to string) {
+		if from == to {
+			return
+		}
+		workspaceID := after.WorkspaceID
+		changes = append(changes, models.RoleChange{
+			UserID:         after.UserID,
+			OrganizationID: &orgID,
+			WorkspaceID:    &workspaceID,
+			Scope:          models.RoleScopeWorkspace,
+			Attribute:      attribute,
+			FromValue:      from,
+			ToValue:        to,
+			ChangedBy:      &actorID,
+			Source:         models.RoleChangeSourceAdmin,
+		})
+	}
+
+	add("workspace_role", before.RoleName, after.RoleName)
+	add("is_admin", strconv.FormatBool(before.IsAdmin), strconv.FormatBool(after.IsAdmin))
+	add("can_view_reports", strconv.FormatBool(before.CanViewReports), strconv.FormatBool(after.CanViewReports))
+	add("can_manage_tasks", strconv.FormatBool(before.CanManageTasks), strconv.FormatBool(after.CanManageTasks))
+	return changes
+}
+
+// checkClient verifies the client belongs to the workspace's organization
+func (s *workspaceService) checkClient(orgID, clientID uint) error {
+	exists, err := s.workspaceRepo.ClientExistsInOrg(orgID, clientID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return apperror.NotFound("client not found in this organization")
+	}
+	return nil
+}
+
 func (s *workspaceService) toWorkspaceResponse(w *models.Workspace, memberCount, taskCount int64) *dto.WorkspaceResponse {
 	var adminResp *dto.UserResponse
 	if w.Admin.ID > 0 {
 		adminResp = &dto.UserResponse{
 			ID:        w.Admin.ID,
+			UUID:      w.Admin.UUID,
 			Email:     w.Admin.Email,
 			FirstName: w.Admin.FirstName,
 			LastName:  w.Admin.LastName,
@@ -684,6 +923,8 @@ func (s *workspaceService) toWorkspaceResponse(w *models.Workspace, memberCount,
 
 	return &dto.WorkspaceResponse{
 		ID:             w.ID,
+		UUID:           w.UUID,
+		Version:        w.Version,
 		OrganizationID: w.OrganizationID,
 		Name:           w.Name,
 		Slug:           w.Slug,
@@ -694,9 +935,15 @@ func (s *workspaceService) toWorkspaceResponse(w *models.Workspace, memberCount,
 		Admin:          adminResp,
 		IsActive:       w.IsActive,
 		IsBillable:     w.IsBillable,
-		HourlyRate:     w.HourlyRate,
+		HourlyRate:     money.ToMajor(w.HourlyRateMinor, w.Currency),
+		Currency:       w.Currency,
+		ClientID:       w.ClientID,
 		StartDate:      w.StartDate,
 		EndDate:        w.EndDate,
+		CostCenter:     w.CostCenter,
+		ProjectCode:    w.ProjectCode,
+		CapWarnPercent: w.CapWarnPercent,
+		CapEnforcement: w.CapEnforcement,
 		MemberCount:    memberCount,
 		TaskCount:      taskCount,
 		CreatedAt:      w.CreatedAt,
@@ -709,6 +956,7 @@ func (s *workspaceService) toMemberResponse(m *models.WorkspaceMember) *dto.Work
 	if m.User.ID > 0 {
 		userResp = &dto.UserResponse{
 			ID:        m.User.ID,
+			UUID:      m.User.UUID,
 			Email:     m.User.Email,
 			FirstName: m.User.FirstName,
 			LastName:  m.User.LastName,
@@ -747,5 +995,32 @@ func (s *workspaceService) toMemberResponse(m *models.WorkspaceMember) *dto.Work
 		JoinedAt:        m.JoinedAt,
 		IsActive:        m.IsActive,
 		AddedBy:         m.AddedBy,
+		WeeklyHourCap:   m.WeeklyHourCap,
+	}
+}
+
+func toTrackingSettingsResponse(w *models.Workspace) *dto.TrackingSettingsResponse {
+	return &dto.TrackingSettingsResponse{
+		WorkspaceID:               w.ID,
+		ScreenshotsDisabled:       w.ScreenshotsDisabled,
+		ScreenshotIntervalSeconds: w.ScreenshotIntervalSeconds,
+		ScreenshotBlurMode:        w.ScreenshotBlurMode,
+		ScreenshotMonitors:        w.ScreenshotMonitors,
+
+		IdleThresholdSeconds: w.IdleThresholdSeconds,
+		DisabledFeatures:     splitFeatures(w.DisabledFeatures),
+
+		ManualEntriesRequireApproval: w.ManualEntriesRequireApproval,
+	}
+}
+
+// splitFeatures parses a workspace's comma-separated disabled features
+func splitFeatures(list string) []string {
+	features := make([]string, 0)
+	for _, feature := range strings.Split(list, ",") {
+		if feature = strings.TrimSpace(feature); feature != "" {
+			features = append(features, feature)
+		}
 	}
+	return features
 }
@@ -8,41 +8,40 @@ import (
 	"mime/multipart"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/beuphecan/remote-time-tracker/internal/config"
 )
 
-// SaveUploadedFile saves an uploaded file to disk
-func SaveUploadedFile(file *multipart.FileHeader, subDir string) (string, string, error) {
-	cfg := config.AppConfig.Upload
-
-	// Create upload directory if not exists
-	uploadDir := filepath.Join(cfg.Path, subDir)
-	if err := os.MkdirAll(uploadDir, 0755); err != nil {
+// SavePrivateUploadedFile saves an uploaded file under the private upload
+// directory, which is never served statically, with an unguessable name.
+// The extension is kept, lowercased.
+func SavePrivateUploadedFile(file *multipart.FileHeader, subDir string) (string, string, error) {
+	uploadDir := filepath.Join(config.AppConfig.Upload.PrivatePath, subDir)
+	if err := os.MkdirAll(uploadDir, 0700); err != nil {
 		return "", "", fmt.Errorf("failed to create upload directory: %w", err)
 	}
 
-	// Generate unique filename
-	ext := filepath.Ext(file.Filename)
-	filename := fmt.Sprintf("%d_%s%s", time.Now().UnixNano(), GenerateRandomString(8), ext)
+	token, err := GenerateSecureToken(12)
+	if err != nil {
+		return "", "", err
+	}
+	filename := fmt.Sprintf("%d_%s%s", time.Now().UnixNano(), token, strings.ToLower(filepath.Ext(file.Filename)))
 	filePath := filepath.Join(uploadDir, filename)
 
-	// Open source file
 	src, err := file.Open()
 	if err != nil {
 		return "", "", fmt.Errorf("failed to open source file: %w", err)
 	}
 	defer src.Close()
 
-	// Create destination file
-	dst, err := os.Create(filePath)
+	dst, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
 	if err != nil {
 		return "", "", fmt.Errorf("failed to create destination file: %w", err)
 	}
 	defer dst.Close()
 
-	// Copy file
 	if _, err := io.Copy(dst, src); err != nil {
 		return "", "", fmt.Errorf("failed to copy file: %w", err)
 	}
@@ -50,20 +49,49 @@ func SaveUploadedFile(file *multipart.FileHeader, subDir string) (string, string
 	return filePath, filename, nil
 }
 
-// SaveBase64File saves a base64 encoded file to disk
-func SaveBase64File(data []byte, subDir, filename string) (string, error) {
-	cfg := config.AppConfig.Upload
+// MoveFile moves a file, copying it when src and dst are on different
+// filesystems
+func MoveFile(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0700); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open source file: %w", err)
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create destination file: %w", err)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return fmt.Errorf("failed to copy file: %w", err)
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(dst)
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return os.Remove(src)
+}
 
-	// Create upload directory if not exists
-	uploadDir := filepath.Join(cfg.Path, subDir)
-	if err := os.MkdirAll(uploadDir, 0755); err != nil {
+// SavePrivateFile saves data under the private upload directory, keeping
+// only the base of filename
+func SavePrivateFile(data []byte, subDir, filename string) (string, error) {
+	uploadDir := filepath.Join(config.AppConfig.Upload.PrivatePath, subDir)
+	if err := os.MkdirAll(uploadDir, 0700); err != nil {
 		return "", fmt.Errorf("failed to create upload directory: %w", err)
 	}
 
-	filePath := filepath.Join(uploadDir, filename)
+	filePath := filepath.Join(uploadDir, filepath.Base(filename))
 
 	// Write file
-	if err := os.WriteFile(filePath, data, 0644); err != nil {
+	if err := os.WriteFile(filePath, data, 0600); err != nil {
 		return "", fmt.Errorf("failed to write file: %w", err)
 	}
 
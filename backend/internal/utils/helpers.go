@@ -1,6 +1,8 @@
 package utils
 
 import (
+	cryptorand "crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"math/rand"
 	"regexp"
@@ -23,6 +25,16 @@ func GenerateRandomString(length int) string {
 	return string(b)
 }
 
+// GenerateSecureToken returns n bytes from crypto/rand, hex encoded, for
+// names and tokens that must not be guessable
+func GenerateSecureToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := cryptorand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
 // GenerateInviteCode generates a random invite code for organizations
 // Format: XXXX-XXXX-XXXX (12 chars without dashes)
 func GenerateInviteCode() string {
@@ -13,9 +13,17 @@ type JWTClaims struct {
 	UserID uint   `json:"user_id"`
 	Email  string `json:"email"`
 	Role   string `json:"role"`
+
+	// ImpersonatorID is set only on impersonation tokens and identifies the admin acting as UserID
+	ImpersonatorID *uint `json:"impersonator_id,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// IsImpersonation reports whether the token was issued for admin impersonation
+func (c *JWTClaims) IsImpersonation() bool {
+	return c.ImpersonatorID != nil
+}
+
 // GenerateToken generates a new JWT token
 func GenerateToken(userID uint, email, role string) (string, time.Time, error) {
 	cfg := config.AppConfig.JWT
@@ -68,6 +76,35 @@ func GenerateRefreshToken(userID uint, email, role string) (string, time.Time, e
 	return tokenString, expirationTime, nil
 }
 
+// GenerateImpersonationToken generates a short-lived access token for the target
+// user that is marked with the impersonating admin's ID
+func GenerateImpersonationToken(userID uint, email, role string, impersonatorID uint) (string, time.Time, error) {
+	cfg := config.AppConfig.JWT
+
+	expirationTime := time.Now().Add(cfg.ImpersonationExpiry)
+
+	claims := &JWTClaims{
+		UserID:         userID,
+		Email:          email,
+		Role:           role,
+		ImpersonatorID: &impersonatorID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "impersonation",
+			ExpiresAt: jwt.NewNumericDate(expirationTime),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			NotBefore: jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	tokenString, err := token.SignedString([]byte(cfg.Secret))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return tokenString, expirationTime, nil
+}
+
 // ValidateToken validates a JWT token and returns the claims
 func ValidateToken(tokenString string) (*JWTClaims, error) {
 	cfg := config.AppConfig.JWT
@@ -6,10 +6,11 @@ import (
 	"github.com/beuphecan/remote-time-tracker/internal/models"
 )
 
-// ComputePresenceStatus returns working/idle/stale based on last heartbeat and stored status.
+// ComputePresenceStatus returns working/online/idle/offline based on the stored
+// status, or stale when heartbeats stopped and the user is not marked offline yet.
 func ComputePresenceStatus(status string, lastPresenceAt *time.Time, staleAfter time.Duration) string {
-	if lastPresenceAt == nil {
-		return models.UserPresenceIdle
+	if lastPresenceAt == nil || status == models.UserPresenceOffline {
+		return models.UserPresenceOffline
 	}
 
 	if staleAfter > 0 && time.Since(*lastPresenceAt) > staleAfter {
@@ -17,10 +18,8 @@ func ComputePresenceStatus(status string, lastPresenceAt *time.Time, staleAfter
 	}
 
 	switch status {
-	case models.UserPresenceWorking:
-		return models.UserPresenceWorking
-	case models.UserPresenceIdle:
-		return models.UserPresenceIdle
+	case models.UserPresenceWorking, models.UserPresenceOnline:
+		return status
 	default:
 		return models.UserPresenceIdle
 	}
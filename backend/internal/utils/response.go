@@ -1,6 +1,14 @@
 package utils
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/beuphecan/remote-time-tracker/internal/apperror"
 	"github.com/beuphecan/remote-time-tracker/internal/dto"
 	"github.com/gin-gonic/gin"
 )
@@ -14,15 +22,56 @@ func SuccessResponse(c *gin.Context, statusCode int, message string, data interf
 	})
 }
 
-// ErrorResponse sends an error JSON response
+// ErrorResponse sends the error envelope with the code for the status
 func ErrorResponse(c *gin.Context, statusCode int, message string) {
-	c.JSON(statusCode, dto.ErrorResponse{
-		Error:   "error",
-		Message: message,
-		Code:    statusCode,
+	RespondError(c, apperror.New(statusCode, message))
+}
+
+// ValidationErrorResponse sends a validation_failed error envelope for a
+// request binding error, listing the invalid fields
+func ValidationErrorResponse(c *gin.Context, err error) {
+	RespondError(c, apperror.Binding(err))
+}
+
+// RespondError sends the error envelope for err. Internal errors are logged
+// and reported with a generic message so their details never reach clients.
+func RespondError(c *gin.Context, err error) {
+	appErr := apperror.From(err)
+	if appErr.Code == apperror.CodeInternal {
+		log.Printf("Internal error on %s %s: %v", c.Request.Method, c.Request.URL.Path, err)
+		appErr.Message = apperror.InternalMessage
+	}
+	c.JSON(appErr.Status, dto.ErrorResponse{
+		Code:    appErr.Code,
+		Message: appErr.Message,
+		Details: appErr.Details,
 	})
 }
 
+// ETagResponse sends data as JSON with an ETag of its content, or 304 Not
+// Modified when the client's If-None-Match already names that ETag. Polling
+// clients then only download data that changed.
+func ETagResponse(c *gin.Context, data interface{}) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		RespondError(c, err)
+		return
+	}
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "private, no-cache")
+	for _, candidate := range strings.Split(c.GetHeader("If-None-Match"), ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			c.Status(http.StatusNotModified)
+			return
+		}
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
+
 // PaginatedResponse sends a paginated JSON response
 func PaginatedResponse(c *gin.Context, statusCode int, data interface{}, page, perPage int, total int64) {
 	c.JSON(statusCode, dto.PaginatedResponse{
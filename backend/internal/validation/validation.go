@@ -0,0 +1,92 @@
+// Package validation registers the custom request validators used in DTO
+// binding tags:
+//
+//	hex_color      #RGB or #RRGGBB, or empty for no color
+//	slug           lowercase letters and digits, separated by single hyphens
+//	flag_key       lowercase letters and digits, separated by single underscores
+//	iana_timezone  an IANA time zone name such as Europe/Berlin
+//	role=<scope>   a role of the scope: user, system or organization
+package validation
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/beuphecan/remote-time-tracker/internal/models"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+var (
+	hexColorPattern = regexp.MustCompile(`^(?:#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{6}))?$`)
+	slugPattern     = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+	flagKeyPattern  = regexp.MustCompile(`^[a-z0-9]+(?:_[a-z0-9]+)*$`)
+)
+
+// roles lists the valid roles of each role=<scope> validator scope
+var roles = map[string][]string{
+	"user":         {"admin", "manager", "user"}, // Legacy user role
+	"system":       {models.SystemRoleAdmin, models.SystemRoleMember},
+	"organization": {models.OrgRoleOwner, models.OrgRoleAdmin, models.OrgRoleMember},
+}
+
+// Setup registers the custom validators with gin's validator. Call it once
+// before serving requests.
+func Setup() {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		Register(v)
+	}
+}
+
+// Register makes v name fields by their JSON (or form) key in errors and
+// adds the custom validators
+func Register(v *validator.Validate) {
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		for _, tag := range []string{"json", "form"} {
+			name := strings.SplitN(field.Tag.Get(tag), ",", 2)[0]
+			if name != "" && name != "-" {
+				return name
+			}
+		}
+		return field.Name
+	})
+
+	v.RegisterValidation("hex_color", matches(hexColorPattern))
+	v.RegisterValidation("slug", matches(slugPattern))
+	v.RegisterValidation("flag_key", matches(flagKeyPattern))
+	v.RegisterValidation("iana_timezone", isTimezone)
+	v.RegisterValidation("role", isRole)
+}
+
+// Roles returns the valid roles of a role=<scope> validator scope
+func Roles(scope string) []string {
+	return roles[scope]
+}
+
+func matches(pattern *regexp.Regexp) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		return pattern.MatchString(fl.Field().String())
+	}
+}
+
+func isTimezone(fl validator.FieldLevel) bool {
+	name := fl.Field().String()
+	// LoadLocation treats "" as UTC and "Local" as the server's zone
+	if name == "" || strings.EqualFold(name, "local") {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
+
+func isRole(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	for _, role := range roles[fl.Param()] {
+		if value == role {
+			return true
+		}
+	}
+	return false
+}